@@ -0,0 +1,527 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// newFlags creates the flag set for a subcommand.
+func newFlags(name string) *flag.FlagSet {
+	return flag.NewFlagSet("adsbscope-ctl "+name, flag.ContinueOnError)
+}
+
+// newTable returns a writer that aligns tab-separated columns on stdout.
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+}
+
+func runLogin(c *client, args []string) error {
+	flags := newFlags("login")
+	username := flags.String("u", "", "Username")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		var err error
+		if *username, err = prompt("Username: "); err != nil {
+			return err
+		}
+	}
+	password, err := promptPassword("Password: ")
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+		User  struct {
+			Username string `json:"username"`
+			Role     string `json:"role"`
+		} `json:"user"`
+	}
+	body := map[string]string{"username": *username, "password": password}
+	if err := c.do("POST", "/auth/login", body, &resp); err != nil {
+		return err
+	}
+	if err := saveToken(resp.Token); err != nil {
+		return err
+	}
+
+	fmt.Printf("Logged in as %s (%s)\n", resp.User.Username, resp.User.Role)
+	return nil
+}
+
+func runLogout(c *client, args []string) error {
+	if c.token != "" {
+		// The token is forgotten locally even if the server is unreachable
+		if err := c.do("POST", "/auth/logout", nil, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	if err := removeToken(); err != nil {
+		return err
+	}
+	fmt.Println("Logged out")
+	return nil
+}
+
+func runStatus(c *client, args []string) error {
+	var status struct {
+		Telescope                 bool     `json:"telescope"`
+		Tracking                  bool     `json:"tracking"`
+		ADSB                      bool     `json:"adsb"`
+		ADSBLagSeconds            *float64 `json:"adsbLagSeconds"`
+		CollectorHeartbeatSeconds *float64 `json:"collectorHeartbeatSeconds"`
+		ADSBSource                string   `json:"adsbSource"`
+		ADSBFailedOver            bool     `json:"adsbFailedOver"`
+		Control                   *struct {
+			Username string `json:"username"`
+			Target   string `json:"target"`
+		} `json:"control"`
+		Database struct {
+			Connected bool   `json:"connected"`
+			Error     string `json:"error"`
+			PingMs    int64  `json:"pingMs"`
+		} `json:"database"`
+	}
+	if err := c.do("GET", "/system/status", nil, &status); err != nil {
+		return err
+	}
+
+	table := newTable()
+	fmt.Fprintf(table, "Telescope\t%s\n", upDown(status.Telescope, "connected", "disconnected"))
+	fmt.Fprintf(table, "Tracking\t%s\n", upDown(status.Tracking, "on", "off"))
+	if status.Control != nil {
+		fmt.Fprintf(table, "Control\t%s (%s)\n", status.Control.Username, status.Control.Target)
+	}
+	adsb := upDown(status.ADSB, "receiving", "stale")
+	if status.ADSBLagSeconds != nil {
+		adsb += fmt.Sprintf(" (last update %.0fs ago)", *status.ADSBLagSeconds)
+	}
+	fmt.Fprintf(table, "ADS-B\t%s\n", adsb)
+	if status.ADSBSource != "" {
+		source := status.ADSBSource
+		if status.ADSBFailedOver {
+			source += " (failed over from primary)"
+		}
+		fmt.Fprintf(table, "Source\t%s\n", source)
+	}
+	if status.CollectorHeartbeatSeconds != nil {
+		fmt.Fprintf(table, "Collector\theartbeat %.0fs ago\n", *status.CollectorHeartbeatSeconds)
+	}
+	if status.Database.Connected {
+		fmt.Fprintf(table, "Database\tconnected (%d ms)\n", status.Database.PingMs)
+	} else {
+		fmt.Fprintf(table, "Database\terror: %s\n", status.Database.Error)
+	}
+	return table.Flush()
+}
+
+// upDown returns up if ok, otherwise down.
+func upDown(ok bool, up, down string) string {
+	if ok {
+		return up
+	}
+	return down
+}
+
+func runAircraft(c *client, args []string) error {
+	flags := newFlags("aircraft")
+	trackable := flags.Bool("trackable", false, "Only aircraft within the telescope's limits")
+	emergency := flags.Bool("emergency", false, "Only aircraft squawking an emergency")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if *trackable {
+		query.Set("trackable", "true")
+	}
+	if *emergency {
+		query.Set("emergency", "true")
+	}
+	path := "/aircraft"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp struct {
+		Aircraft []struct {
+			ICAO      string  `json:"icao"`
+			Callsign  string  `json:"callsign"`
+			Altitude  float64 `json:"altitude"`
+			Speed     float64 `json:"speed"`
+			Heading   float64 `json:"heading"`
+			Distance  float64 `json:"distance"`
+			Azimuth   float64 `json:"azimuth"`
+			Elevation float64 `json:"elevation"`
+			Trackable bool    `json:"trackable"`
+			Phase     string  `json:"phase"`
+			Emergency string  `json:"emergency"`
+		} `json:"aircraft"`
+	}
+	if err := c.do("GET", path, nil, &resp); err != nil {
+		return err
+	}
+
+	table := newTable()
+	fmt.Fprintln(table, "ICAO\tCALLSIGN\tALT FT\tKTS\tHDG\tAZ\tEL\tKM\tPHASE\t")
+	for _, ac := range resp.Aircraft {
+		notes := ""
+		if ac.Trackable {
+			notes = "trackable"
+		}
+		if ac.Emergency != "" {
+			notes = strings.TrimSpace(notes + " " + ac.Emergency)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%.0f\t%.0f\t%03.0f\t%.1f\t%.1f\t%.1f\t%s\t%s\n",
+			ac.ICAO, strings.TrimSpace(ac.Callsign), ac.Altitude, ac.Speed, ac.Heading,
+			ac.Azimuth, ac.Elevation, ac.Distance, ac.Phase, notes)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d aircraft\n", len(resp.Aircraft))
+	return nil
+}
+
+func runSources(c *client, args []string) error {
+	flags := newFlags("sources")
+	hours := flags.Int("hours", 24, "Report window in hours (max 168)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var resp struct {
+		Sources []struct {
+			Source           string  `json:"source"`
+			Cycles           int     `json:"cycles"`
+			Errors           int     `json:"errors"`
+			AvgAircraft      float64 `json:"avgAircraft"`
+			AvgUnique        float64 `json:"avgUnique"`
+			MaxAircraft      int     `json:"maxAircraft"`
+			AvgLatencyMs     float64 `json:"avgLatencyMs"`
+			AvgPositionAgeMs float64 `json:"avgPositionAgeMs"`
+			PositionAgeP50Ms float64 `json:"positionAgeP50Ms"`
+			PositionAgeP90Ms float64 `json:"positionAgeP90Ms"`
+			PositionAgeP99Ms float64 `json:"positionAgeP99Ms"`
+			Availability     float64 `json:"availability"`
+		} `json:"sources"`
+	}
+	if err := c.do("GET", "/system/sources?hours="+strconv.Itoa(*hours), nil, &resp); err != nil {
+		return err
+	}
+	if len(resp.Sources) == 0 {
+		fmt.Printf("No source statistics in the last %d hours\n", *hours)
+		return nil
+	}
+
+	table := newTable()
+	fmt.Fprintln(table, "SOURCE\tAIRCRAFT\tMAX\tUNIQUE\tPOS AGE\tP50/P90/P99\tLATENCY\tAVAILABLE\tCYCLES\t")
+	for _, src := range resp.Sources {
+		fmt.Fprintf(table, "%s\t%.1f\t%d\t%.1f\t%.1fs\t%.1f/%.1f/%.1fs\t%.0fms\t%.0f%%\t%d\t\n",
+			src.Source, src.AvgAircraft, src.MaxAircraft, src.AvgUnique,
+			src.AvgPositionAgeMs/1000, src.PositionAgeP50Ms/1000, src.PositionAgeP90Ms/1000, src.PositionAgeP99Ms/1000,
+			src.AvgLatencyMs, src.Availability*100, src.Cycles)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	fmt.Println("Averages per collection cycle; UNIQUE counts aircraft no other source reported; P50/P90/P99 are position age percentiles")
+	return nil
+}
+
+func runTrack(c *client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: track ICAO")
+	}
+
+	var resp struct {
+		ICAO     string  `json:"icao"`
+		Callsign string  `json:"callsign"`
+		Altitude float64 `json:"altitude"`
+		Azimuth  float64 `json:"azimuth"`
+	}
+	if err := c.do("POST", "/telescope/track/"+url.PathEscape(strings.ToLower(args[0])), nil, &resp); err != nil {
+		return err
+	}
+
+	fmt.Printf("Tracking %s (%s) at Alt %.1f° Az %.1f°\n", strings.TrimSpace(resp.Callsign), resp.ICAO, resp.Altitude, resp.Azimuth)
+	return nil
+}
+
+func runStop(c *client, args []string) error {
+	if err := c.do("POST", "/telescope/stop", nil, nil); err != nil {
+		return err
+	}
+	fmt.Println("Tracking stopped")
+	return nil
+}
+
+func runAbort(c *client, args []string) error {
+	if err := c.do("POST", "/telescope/abort", nil, nil); err != nil {
+		return err
+	}
+	fmt.Println("Slew aborted")
+	return nil
+}
+
+// observationPoint is an observation point as returned by the API.
+type observationPoint struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	ElevationMeters float64 `json:"elevationMeters"`
+	IsActive        bool    `json:"isActive"`
+	TelescopeURL    string  `json:"telescopeUrl"`
+}
+
+func runPoints(c *client, args []string) error {
+	sub, args := subcommand(args, "list")
+	switch sub {
+	case "list":
+		var resp struct {
+			Points []observationPoint `json:"points"`
+		}
+		if err := c.do("GET", "/observer/points", nil, &resp); err != nil {
+			return err
+		}
+
+		table := newTable()
+		fmt.Fprintln(table, "ID\tNAME\tLAT\tLON\tELEV M\tTELESCOPE\t")
+		for _, p := range resp.Points {
+			active := ""
+			if p.IsActive {
+				active = "active"
+			}
+			fmt.Fprintf(table, "%d\t%s\t%.5f\t%.5f\t%.0f\t%s\t%s\n",
+				p.ID, p.Name, p.Latitude, p.Longitude, p.ElevationMeters, p.TelescopeURL, active)
+		}
+		return table.Flush()
+
+	case "add":
+		flags := newFlags("points add")
+		point := observationPoint{}
+		flags.StringVar(&point.Name, "name", "", "Name (required)")
+		flags.Float64Var(&point.Latitude, "lat", 0, "Latitude in degrees")
+		flags.Float64Var(&point.Longitude, "lon", 0, "Longitude in degrees")
+		flags.Float64Var(&point.ElevationMeters, "elev", 0, "Elevation in meters")
+		flags.StringVar(&point.TelescopeURL, "telescope", "", "Alpaca telescope URL at this point (default: the server's)")
+		flags.BoolVar(&point.IsActive, "activate", false, "Make it the active observation point")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if point.Name == "" {
+			return errors.New("-name is required")
+		}
+
+		var created observationPoint
+		if err := c.do("POST", "/observer/points", point, &created); err != nil {
+			return err
+		}
+		fmt.Printf("Created observation point %d (%s)\n", created.ID, created.Name)
+		return nil
+
+	case "activate", "rm":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: points %s ID", sub)
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid observation point ID %q", args[0])
+		}
+
+		if sub == "activate" {
+			if err := c.do("POST", fmt.Sprintf("/observer/points/%d/activate", id), nil, nil); err != nil {
+				return err
+			}
+			fmt.Printf("Observation point %d is now active\n", id)
+			return nil
+		}
+		if err := c.do("DELETE", fmt.Sprintf("/observer/points/%d", id), nil, nil); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted observation point %d\n", id)
+		return nil
+	}
+
+	return fmt.Errorf("unknown points command %q (list, add, activate, rm)", sub)
+}
+
+// user is a user account as returned by the API.
+type user struct {
+	ID        int        `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	IsActive  bool       `json:"is_active"`
+	LastLogin *time.Time `json:"last_login"`
+}
+
+func runUsers(c *client, args []string) error {
+	sub, args := subcommand(args, "list")
+	switch sub {
+	case "list":
+		var users []user
+		if err := c.do("GET", "/users", nil, &users); err != nil {
+			return err
+		}
+
+		table := newTable()
+		fmt.Fprintln(table, "ID\tUSERNAME\tEMAIL\tROLE\tLAST LOGIN\t")
+		for _, u := range users {
+			lastLogin := "never"
+			if u.LastLogin != nil {
+				lastLogin = u.LastLogin.Local().Format("2006-01-02 15:04")
+			}
+			disabled := ""
+			if !u.IsActive {
+				disabled = "disabled"
+			}
+			fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\t%s\n", u.ID, u.Username, u.Email, u.Role, lastLogin, disabled)
+		}
+		return table.Flush()
+
+	case "add":
+		flags := newFlags("users add")
+		username := flags.String("u", "", "Username (required)")
+		email := flags.String("e", "", "Email (required)")
+		role := flags.String("r", "viewer", "Role: admin, observer, viewer or guest")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if *username == "" || *email == "" {
+			return errors.New("-u and -e are required")
+		}
+		password, err := promptPassword("Password for " + *username + ": ")
+		if err != nil {
+			return err
+		}
+
+		var created user
+		body := map[string]string{"username": *username, "email": *email, "password": password, "role": *role}
+		if err := c.do("POST", "/users", body, &created); err != nil {
+			return err
+		}
+		fmt.Printf("Created user %d (%s, %s)\n", created.ID, created.Username, created.Role)
+		return nil
+
+	case "set":
+		flags := newFlags("users set")
+		email := flags.String("e", "", "New email")
+		role := flags.String("r", "", "New role: admin, observer, viewer or guest")
+		enable := flags.Bool("enable", false, "Enable the account")
+		disable := flags.Bool("disable", false, "Disable the account (it can no longer log in)")
+		password := flags.Bool("password", false, "Set a new password (prompted)")
+		if len(args) == 0 {
+			return errors.New("usage: users set ID [-e EMAIL] [-r ROLE] [-enable|-disable] [-password]")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", args[0])
+		}
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *enable && *disable {
+			return errors.New("-enable and -disable are exclusive")
+		}
+
+		body := map[string]interface{}{}
+		if *email != "" {
+			body["email"] = *email
+		}
+		if *role != "" {
+			body["role"] = *role
+		}
+		if *enable || *disable {
+			body["isActive"] = *enable
+		}
+		if *password {
+			pw, err := promptPassword("New password: ")
+			if err != nil {
+				return err
+			}
+			body["password"] = pw
+		}
+		if len(body) == 0 {
+			return errors.New("nothing to change")
+		}
+
+		var updated user
+		if err := c.do("PUT", fmt.Sprintf("/users/%d", id), body, &updated); err != nil {
+			return err
+		}
+		fmt.Printf("Updated user %d (%s, %s)\n", updated.ID, updated.Username, updated.Role)
+		return nil
+
+	case "rm":
+		if len(args) != 1 {
+			return errors.New("usage: users rm ID")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", args[0])
+		}
+		if err := c.do("DELETE", fmt.Sprintf("/users/%d", id), nil, nil); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted user %d\n", id)
+		return nil
+	}
+
+	return fmt.Errorf("unknown users command %q (list, add, set, rm)", sub)
+}
+
+// subcommand splits off a subcommand (def if none is given).
+func subcommand(args []string, def string) (string, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return def, args
+	}
+	return args[0], args[1:]
+}
+
+// logFollowInterval is how often the log is polled with -f
+const logFollowInterval = 2 * time.Second
+
+func runLogs(c *client, args []string) error {
+	flags := newFlags("logs")
+	lines := flags.Int("n", 100, "Number of lines")
+	follow := flags.Bool("f", false, "Keep printing new lines as they're logged")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var after int64
+	for {
+		var resp struct {
+			Lines []struct {
+				Seq  int64  `json:"seq"`
+				Line string `json:"line"`
+			} `json:"lines"`
+			Last int64 `json:"last"`
+		}
+		path := fmt.Sprintf("/system/logs?lines=%d&after=%d", *lines, after)
+		if err := c.do("GET", path, nil, &resp); err != nil {
+			return err
+		}
+		for _, l := range resp.Lines {
+			fmt.Println(l.Line)
+		}
+		after = resp.Last
+
+		if !*follow {
+			return nil
+		}
+		time.Sleep(logFollowInterval)
+	}
+}
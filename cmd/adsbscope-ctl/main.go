@@ -0,0 +1,109 @@
+// adsbscope-ctl manages an ADS-B Scope server through its web API, so a
+// headless server can be run over SSH without psql: list aircraft, start and
+// stop tracking, manage users and observation points, and tail the log.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// command is an adsbscope-ctl subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(c *client, args []string) error
+}
+
+var commands = []command{
+	{"login", "login [-u USER]             Log in and save the session token", runLogin},
+	{"logout", "logout                      Log out and forget the session token", runLogout},
+	{"status", "status                      Show telescope, collector and database status", runStatus},
+	{"aircraft", "aircraft [-trackable] [-emergency]  List aircraft in view", runAircraft},
+	{"sources", "sources [-hours N]          Compare ADS-B data sources", runSources},
+	{"track", "track ICAO                  Slew to an aircraft and track it", runTrack},
+	{"stop", "stop                        Stop tracking", runStop},
+	{"abort", "abort                       Abort a slew and stop tracking", runAbort},
+	{"points", "points [list|add|activate|rm]  Manage your observation points", runPoints},
+	{"users", "users [list|add|set|rm]     Manage user accounts (admin)", runUsers},
+	{"logs", "logs [-n LINES] [-f]        Show (or follow) the server log (admin)", runLogs},
+}
+
+func main() {
+	server := flag.String("server", envOrDefault("ADS_BSCOPE_URL", "http://localhost:8080"), "Web server URL (or ADS_BSCOPE_URL)")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		if err := cmd.run(newClient(*server, loadToken()), flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+// usage prints the command list.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: adsbscope-ctl [-server URL] COMMAND [ARGS]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "The session token is saved by login, or taken from ADS_BSCOPE_TOKEN.")
+}
+
+// envOrDefault returns an environment variable, or def if it's unset.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// prompt asks for a line of input.
+func prompt(label string) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read %s: %w", strings.TrimSuffix(strings.ToLower(label), ": "), err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptPassword asks for a password without echoing it. When stdin isn't a
+// terminal (e.g. piped from a secrets manager) it is read as a line.
+func promptPassword(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return prompt(label)
+	}
+
+	fmt.Fprint(os.Stderr, label)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
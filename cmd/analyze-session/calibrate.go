@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// trackMargin is how long before a pass started its position history is
+// read, so the first commands have a report before them
+const trackMargin = time.Minute
+
+// calibrate assesses every command of the passes against the aircraft's
+// position history and fits the error to expect at each prediction
+// confidence. The calibration is saved unless dryRun is set.
+func calibrate(ctx context.Context, w io.Writer, database *db.DB, observer coordinates.Observer, reports []db.PassReport, from, to time.Time, dryRun bool) error {
+	aircraftRepo := db.NewAircraftRepository(database, observer)
+
+	var samples []tracking.CalibrationSample
+	for _, p := range reports {
+		entries, err := aircraftRepo.GetTrackingLog(ctx, p.ICAO, p.StartedAt, p.EndedAt)
+		if err != nil {
+			return err
+		}
+		var pointing []tracking.PointingSample
+		for _, e := range entries {
+			if e.CommandSent && !e.CommandSuccess {
+				continue // The telescope never went there
+			}
+			pointing = append(pointing, e.PointingSample())
+		}
+
+		tracks, err := aircraftRepo.GetRecentTracks(ctx, []string{p.ICAO}, p.StartedAt.Add(-trackMargin))
+		if err != nil {
+			return err
+		}
+		samples = append(samples, tracking.PointingErrors(pointing, tracks[p.ICAO], observer)...)
+	}
+
+	fit, ok := tracking.CalibrateErrors(samples)
+	if !ok {
+		return fmt.Errorf("too few assessed commands to calibrate (%d); record more passes", len(samples))
+	}
+
+	fmt.Fprintf(w, "Expected error by prediction confidence (%d commands of %d passes):\n", fit.Samples, len(reports))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONFIDENCE\tRMS ERROR\tUNCALIBRATED\tCOMMANDS")
+	for _, bin := range fit.Bins {
+		fmt.Fprintf(tw, "%.0f%%\t%s\t%s\t%d\n", bin.Confidence*100,
+			tracking.FormatExpectedError(bin.ErrorDeg),
+			tracking.FormatExpectedError(tracking.DefaultCalibration.ExpectedError(bin.Confidence)), bin.Samples)
+	}
+	tw.Flush()
+
+	if dryRun {
+		return nil
+	}
+	calibration := db.ErrorCalibration{From: from, To: to, Passes: len(reports), ErrorCalibration: fit}
+	if err := db.NewCalibrationRepository(database).Save(ctx, &calibration); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Saved calibration %d\n", calibration.ID)
+	return nil
+}
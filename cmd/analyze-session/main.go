@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// main prints the pointing accuracy of the passes tracked in an observing
+// session: one line per pass, then totals for the session. With -pass it
+// prints the full report of a single pass instead. With -calibrate it also
+// fits the pointing error to expect at each prediction confidence to the
+// session's passes and saves it, for displays such as "±0.3°".
+//
+// Example:
+//
+//	analyze-session -from 2025-06-01T20:00:00Z -to 2025-06-02T04:00:00Z
+//	analyze-session -pass 42
+//	analyze-session -from 2025-05-01 -calibrate
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	from := flag.String("from", "", "Start of the session (RFC 3339 or YYYY-MM-DD, default: 24 hours ago)")
+	to := flag.String("to", "", "End of the session, exclusive (RFC 3339 or YYYY-MM-DD, default: now)")
+	icao := flag.String("icao", "", "Only passes of this aircraft")
+	passID := flag.Int64("pass", 0, "Show the full report of one pass")
+	calibrateErrors := flag.Bool("calibrate", false, "Calibrate prediction confidence against the passes' observed errors and save it")
+	dryRun := flag.Bool("dry-run", false, "With -calibrate, print the calibration without saving it")
+	flag.Parse()
+
+	now := time.Now().UTC()
+	start, end := now.Add(-24*time.Hour), now
+	var err error
+	if *from != "" {
+		if start, err = parseTime(*from); err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+	}
+	if *to != "" {
+		if end, err = parseTime(*to); err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+	}
+	if !end.After(start) {
+		log.Fatal("-to must be after -from")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	repo := db.NewPassReportRepository(database)
+
+	if *passID != 0 {
+		report, err := repo.Get(ctx, *passID)
+		if err != nil {
+			log.Fatalf("Failed to get pass: %v", err)
+		}
+		if report == nil {
+			log.Fatalf("No pass %d", *passID)
+		}
+		printPass(os.Stdout, *report)
+		return
+	}
+
+	reports, err := repo.List(ctx, start, end, strings.TrimSpace(*icao), 0)
+	if err != nil {
+		log.Fatalf("Failed to list passes: %v", err)
+	}
+	if len(reports) == 0 {
+		fmt.Printf("No passes tracked between %s and %s\n", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		return
+	}
+
+	// Oldest first reads like the session went
+	slices.Reverse(reports)
+	printSession(os.Stdout, reports)
+
+	if *calibrateErrors {
+		observer := coordinates.Observer{
+			Location: coordinates.Geographic{
+				Latitude:  cfg.Observer.Latitude,
+				Longitude: cfg.Observer.Longitude,
+				Altitude:  cfg.Observer.Elevation,
+			},
+			Timezone: cfg.Observer.TimeZone,
+		}
+		fmt.Println()
+		if err := calibrate(ctx, os.Stdout, database, observer, reports, start, end, *dryRun); err != nil {
+			log.Fatalf("Failed to calibrate: %v", err)
+		}
+	}
+}
+
+// printSession prints a line per pass and the session totals.
+func printSession(w io.Writer, reports []db.PassReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tENDED\tAIRCRAFT\tDURATION\tCMDS\tRMS\tMAX\tDATA AGE\tMODES")
+	for _, p := range reports {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d/%d\t%s\t%s\t%.1f s\t%s\n",
+			p.ID, p.EndedAt.Local().Format("01-02 15:04"), aircraftName(p),
+			p.EndedAt.Sub(p.StartedAt).Round(time.Second),
+			p.Assessed, p.Commands, degrees(p.RMSErrorDeg, p.Assessed), degrees(p.MaxErrorDeg, p.Assessed),
+			p.MeanDataAge, modeSummary(p.ModeUsage))
+	}
+	tw.Flush()
+
+	s := summarize(reports)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Passes:       %d (%s tracked)\n", len(reports), s.duration.Round(time.Second))
+	fmt.Fprintf(w, "Commands:     %d, %d assessed\n", s.commands, s.assessed)
+	fmt.Fprintf(w, "RMS error:    %s\n", degrees(s.rmsErrorDeg, s.assessed))
+	fmt.Fprintf(w, "Max error:    %s (pass %d)\n", degrees(s.maxErrorDeg, s.assessed), s.maxPass)
+	fmt.Fprintf(w, "Data age:     %.1f s (%.2f° RMS motion)\n", s.meanDataAge, s.dataAgeDeg)
+	fmt.Fprintf(w, "Update lag:   %.2f° RMS\n", s.updateLagDeg)
+	fmt.Fprintf(w, "Prediction:   %s\n", modeSummary(s.modeUsage))
+}
+
+// printPass prints one pass's full report.
+func printPass(w io.Writer, p db.PassReport) {
+	fmt.Fprintf(w, "Pass %d: %s", p.ID, aircraftName(p))
+	if p.Rule != "" {
+		fmt.Fprintf(w, " (rule %q)", p.Rule)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Tracked:      %s - %s (%s)\n", p.StartedAt.Local().Format("2006-01-02 15:04:05"),
+		p.EndedAt.Local().Format("15:04:05"), p.EndedAt.Sub(p.StartedAt).Round(time.Second))
+	if p.EndReason != "" {
+		fmt.Fprintf(w, "Ended:        %s\n", p.EndReason)
+	}
+	fmt.Fprintf(w, "Commands:     %d, %d assessed against the position history\n", p.Commands, p.Assessed)
+	fmt.Fprintf(w, "RMS error:    %s\n", degrees(p.RMSErrorDeg, p.Assessed))
+	fmt.Fprintf(w, "Max error:    %s", degrees(p.MaxErrorDeg, p.Assessed))
+	if p.MaxErrorAt != nil {
+		fmt.Fprintf(w, " at %s", p.MaxErrorAt.Local().Format("15:04:05"))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Latency breakdown (RMS motion of the aircraft across the sky):")
+	fmt.Fprintf(w, "  Data age:   %.1f s mean, %.2f° (error without prediction)\n", p.MeanDataAge, p.DataAgeDeg)
+	fmt.Fprintf(w, "  Update lag: %.1f s between commands, %.2f° (waiting for the next command)\n", p.MeanInterval, p.UpdateLagDeg)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Prediction modes:")
+	for _, mode := range sortedModes(p.ModeUsage) {
+		fmt.Fprintf(w, "  %-16s %5.1f%%\n", modeName(mode), p.ModeUsage[mode])
+	}
+}
+
+// sessionSummary is the accuracy of all the passes of a session together.
+type sessionSummary struct {
+	duration     time.Duration
+	commands     int
+	assessed     int
+	rmsErrorDeg  float64
+	maxErrorDeg  float64
+	maxPass      int64
+	meanDataAge  float64
+	dataAgeDeg   float64
+	updateLagDeg float64
+	modeUsage    map[tracking.PredictionMode]float64
+}
+
+// summarize combines pass reports, weighting each by the commands it
+// assessed (or sent, for mode usage).
+func summarize(reports []db.PassReport) sessionSummary {
+	s := sessionSummary{modeUsage: make(map[tracking.PredictionMode]float64)}
+	var sumSq, sumAge, sumAgeSq, sumLagSq float64
+	for _, p := range reports {
+		s.duration += p.EndedAt.Sub(p.StartedAt)
+		s.commands += p.Commands
+		s.assessed += p.Assessed
+
+		n := float64(p.Assessed)
+		sumSq += p.RMSErrorDeg * p.RMSErrorDeg * n
+		sumAge += p.MeanDataAge * n
+		sumAgeSq += p.DataAgeDeg * p.DataAgeDeg * n
+		sumLagSq += p.UpdateLagDeg * p.UpdateLagDeg * n
+		if p.Assessed > 0 && p.MaxErrorDeg > s.maxErrorDeg {
+			s.maxErrorDeg = p.MaxErrorDeg
+			s.maxPass = p.ID
+		}
+		for mode, pct := range p.ModeUsage {
+			s.modeUsage[mode] += pct * float64(p.Commands)
+		}
+	}
+
+	if s.assessed > 0 {
+		n := float64(s.assessed)
+		s.rmsErrorDeg = math.Sqrt(sumSq / n)
+		s.meanDataAge = sumAge / n
+		s.dataAgeDeg = math.Sqrt(sumAgeSq / n)
+		s.updateLagDeg = math.Sqrt(sumLagSq / n)
+	}
+	for mode := range s.modeUsage {
+		s.modeUsage[mode] /= float64(max(s.commands, 1))
+	}
+	return s
+}
+
+// aircraftName returns the callsign and ICAO address of a pass's aircraft.
+func aircraftName(p db.PassReport) string {
+	if p.Callsign == "" {
+		return p.ICAO
+	}
+	return fmt.Sprintf("%s (%s)", p.Callsign, p.ICAO)
+}
+
+// degrees formats an error angle, or "--" if nothing was assessed.
+func degrees(deg float64, assessed int) string {
+	if assessed == 0 {
+		return "--"
+	}
+	return fmt.Sprintf("%.2f°", deg)
+}
+
+// modeSummary formats mode usage compactly, e.g. "live 80%, dead_reckoning 20%".
+func modeSummary(usage map[tracking.PredictionMode]float64) string {
+	var parts []string
+	for _, mode := range sortedModes(usage) {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", modeName(mode), usage[mode]))
+	}
+	if len(parts) == 0 {
+		return "--"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedModes returns the modes used in the order of tracking.PredictionModes,
+// followed by any others (such as "" for commands logged before modes were
+// recorded).
+func sortedModes(usage map[tracking.PredictionMode]float64) []tracking.PredictionMode {
+	var modes []tracking.PredictionMode
+	for _, mode := range tracking.PredictionModes {
+		if usage[mode] > 0 {
+			modes = append(modes, mode)
+		}
+	}
+	var others []tracking.PredictionMode
+	for mode, pct := range usage {
+		if pct > 0 && !slices.Contains(tracking.PredictionModes, mode) {
+			others = append(others, mode)
+		}
+	}
+	slices.Sort(others)
+	return append(modes, others...)
+}
+
+// modeName returns a mode's name for display.
+func modeName(mode tracking.PredictionMode) string {
+	if mode == "" {
+		return "unknown"
+	}
+	return string(mode)
+}
+
+// parseTime parses an RFC 3339 time or a YYYY-MM-DD date (midnight UTC).
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 time or YYYY-MM-DD date", s)
+	}
+	return t, nil
+}
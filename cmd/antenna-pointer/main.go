@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/rotctl"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// pointing is one line of pointing output.
+type pointing struct {
+	Time      time.Time `json:"time"`
+	ICAO      string    `json:"icao"`
+	Callsign  string    `json:"callsign,omitempty"`
+	Azimuth   float64   `json:"azimuth"`
+	Elevation float64   `json:"elevation"`
+
+	// Waiting is true while the aircraft is below the mask and the antenna
+	// waits at the azimuth where it will rise
+	Waiting bool `json:"waiting,omitempty"`
+}
+
+// main points a directional antenna at an aircraft. Every interval it
+// writes the aircraft's predicted azimuth and elevation to stdout, as text
+// or JSON lines, and, with -rotctld, turns a hamlib rotator to it. While the
+// aircraft is below the elevation mask the antenna waits at the azimuth
+// where it is predicted to rise.
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	icao := flag.String("icao", "", "ICAO hex code of aircraft to follow")
+	rotctld := flag.String("rotctld", "", "rotctld address to drive a rotator (e.g. "+rotctl.DefaultAddress+"); output only if empty")
+	azimuthOnly := flag.Bool("azimuth-only", false, "Output and command azimuth only (elevation 0), for azimuth-only rotators")
+	mask := flag.Float64("mask", 0, "Elevation mask in degrees: below it, wait at the rise azimuth")
+	interval := flag.Duration("interval", time.Second, "Update interval")
+	format := flag.String("format", "text", "Output format: text or json")
+	flag.Parse()
+
+	if *icao == "" {
+		log.Fatal("-icao is required")
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("Unknown format %q (text or json)", *format)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  cfg.Observer.Latitude,
+			Longitude: cfg.Observer.Longitude,
+			Altitude:  cfg.Observer.Elevation,
+		},
+		Timezone: cfg.Observer.TimeZone,
+	}
+	repo := db.NewAircraftRepository(database, observer)
+
+	var rotator *rotctl.Client
+	if *rotctld != "" {
+		rotator = rotctl.NewClient(*rotctld)
+		defer rotator.Close()
+		log.Printf("Driving rotator via rotctld at %s", *rotctld)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	encoder := json.NewEncoder(os.Stdout)
+	var last *pointing
+	for {
+		aircraft, err := repo.GetAircraftByICAO(ctx, *icao)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Warning: Database query failed: %v", err)
+		case aircraft == nil && err == nil:
+			log.Printf("Warning: Aircraft %s not in database", *icao)
+		case aircraft != nil:
+			now := time.Now().UTC()
+			if p, ok := point(*aircraft, observer, now, *mask); ok {
+				if *azimuthOnly {
+					p.Elevation = 0
+				}
+				if *format == "json" {
+					encoder.Encode(p)
+				} else {
+					fmt.Printf("%s %s %.2f %.2f\n", p.Time.Format(time.RFC3339), p.ICAO, p.Azimuth, p.Elevation)
+				}
+
+				// A waiting antenna is only moved when the rise azimuth changes
+				if rotator != nil && (last == nil || !p.Waiting || !last.Waiting || p.Azimuth != last.Azimuth) {
+					if err := rotator.SetPosition(ctx, p.Azimuth, p.Elevation); err != nil {
+						log.Printf("Warning: %v", err)
+					}
+				}
+				last = &p
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if rotator != nil {
+				stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := rotator.Stop(stopCtx); err != nil {
+					log.Printf("Warning: %v", err)
+				}
+				cancel()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// point returns where to point the antenna for an aircraft: at its position
+// predicted for now if it is above the mask, otherwise at the azimuth where
+// it is predicted to rise. Returns false if it isn't predicted to rise.
+func point(ac adsb.Aircraft, observer coordinates.Observer, now time.Time, mask float64) (pointing, bool) {
+	p := pointing{Time: now, ICAO: ac.ICAO, Callsign: ac.Callsign}
+
+	pos := tracking.PredictPosition(ac, now).Position
+	horiz := coordinates.GeographicToHorizontal(pos, observer, now)
+	if horiz.Altitude >= mask {
+		p.Azimuth, p.Elevation = horiz.Azimuth, horiz.Altitude
+		return p, true
+	}
+
+	crossings, ok := tracking.PredictHorizonCrossings(ac, observer, now, planner.DefaultHorizon, mask)
+	if !ok || !crossings.Rises {
+		return pointing{}, false
+	}
+	p.Azimuth, p.Elevation, p.Waiting = crossings.RiseAzimuth, mask, true
+	return p, true
+}
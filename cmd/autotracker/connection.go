@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alerts"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// superviseTelescope watches the telescope connection until ctx is
+// cancelled, reconnecting and restoring tracking when the Alpaca server
+// restarts, so the unattended daemon recovers without a manual restart.
+// Connection changes are logged and delivered as alerts.
+func superviseTelescope(ctx context.Context, telescope *alpaca.Client, alertsCfg config.AlertsConfig) {
+	var notifiers []alerts.Notifier
+	if alertsCfg.Enabled {
+		notifiers = alerts.NewNotifiers(alertsCfg)
+	}
+	dispatcher := alerts.NewDispatcher(0, notifiers...)
+
+	supervisor := alpaca.NewSupervisor(telescope, alpaca.DefaultSupervisorInterval)
+	supervisor.OnEvent = func(event alpaca.ConnectionEvent) {
+		var alert alerts.Alert
+		if event.Type == alpaca.ConnectionLost {
+			alert = alerts.NewTelescopeDisconnectedAlert(event.Error, event.Time)
+			log.Printf("⚠️  %s", alert.Description)
+		} else {
+			downtime := time.Duration(event.DowntimeSeconds * float64(time.Second))
+			alert = alerts.NewTelescopeReconnectedAlert(downtime, event.Error, event.Time)
+			log.Printf("🔭 %s (%d attempts)", alert.Description, event.Attempts)
+		}
+
+		if err := dispatcher.Dispatch(ctx, alert); err != nil {
+			log.Printf("✗ Failed to deliver telescope connection alert: %v", err)
+		}
+	}
+	supervisor.Run(ctx)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// holdCheckInterval is how often a target's position history is re-examined
+// for a hold.
+const holdCheckInterval = 30 * time.Second
+
+// targetPosition returns a target's position extrapolated to now and how it
+// was predicted. A holding target is flown around its hold so the telescope
+// isn't driven off along a straight-line extrapolation; with a flight-plan or
+// airway strategy the target is predicted along its route.
+func (t *autotracker) targetPosition(ctx context.Context, tg *target, ac adsb.Aircraft, now time.Time) (coordinates.Geographic, float64, tracking.PredictionMode) {
+	dataAge := now.Sub(ac.LastSeen).Seconds()
+	if tg.prediction.UsesHolds() {
+		t.updateHold(ctx, tg, now)
+	}
+	if tg.hold != nil {
+		predicted := tracking.PredictPositionInHold(ac, *tg.hold, now)
+		return predicted.Position, predicted.Confidence, tracking.ClassifyPrediction(dataAge, true)
+	}
+
+	mode := tracking.ClassifyPrediction(dataAge, false)
+	if predicted, routeMode, ok := t.routePosition(ctx, tg, ac, now); ok {
+		if mode != tracking.PredictionLive {
+			mode = routeMode
+		}
+		return predicted.Position, predicted.Confidence, mode
+	}
+	pos, confidence := t.position(ac, now)
+	return pos, confidence, mode
+}
+
+// updateHold re-detects whether a target is flying a hold, at most every
+// holdCheckInterval. On a database error the previous result is kept.
+func (t *autotracker) updateHold(ctx context.Context, tg *target, now time.Time) {
+	if now.Sub(tg.holdCheckedAt) < holdCheckInterval {
+		return
+	}
+
+	tracks, err := t.repo.GetRecentTracks(ctx, []string{tg.icao}, now.Add(-tracking.HoldHistory))
+	if err != nil {
+		log.Printf("Warning: Failed to query position history of %s: %v", tg.icao, err)
+		return
+	}
+	tg.holdCheckedAt = now
+
+	hold, ok := tracking.DetectHold(tracks[tg.icao])
+	switch {
+	case ok && tg.hold == nil:
+		log.Printf("  %s is holding (%03.0f°, %.1f nm legs)", tg.icao, hold.CourseDeg, hold.LegNM)
+	case !ok && tg.hold != nil:
+		log.Printf("  %s has left the hold", tg.icao)
+	}
+	if ok {
+		tg.hold = &hold
+	} else {
+		tg.hold = nil
+	}
+}
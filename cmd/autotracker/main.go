@@ -0,0 +1,647 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alignment"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/autotrack"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/onvif"
+	"github.com/unklstewy/ads-bscope/pkg/performance"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/rotctl"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// updateInterval is how often the database is polled and the telescope updated
+const updateInterval = 2 * time.Second
+
+// maxCachedPlans bounds the flight plan cache; it is cleared when full so
+// plans filed later in the day are picked up
+const maxCachedPlans = 5000
+
+// target is the aircraft currently being tracked.
+type target struct {
+	icao     string
+	callsign string
+	rule     string
+	task     *db.ScheduledTask // Scheduled task being executed (nil for rule matches)
+	started  time.Time
+	captured bool
+	monitor  *tracking.PassMonitor
+	horiz    coordinates.HorizontalCoordinates // Last known position in the sky
+
+	// Models its position is predicted with (the -prediction flag, or the
+	// scheduled task's strategy)
+	prediction tracking.PredictionStrategy
+
+	// Holding pattern being flown (nil if none), re-detected every
+	// holdCheckInterval
+	hold          *tracking.Hold
+	holdCheckedAt time.Time
+}
+
+// autotracker acquires, tracks and captures aircraft according to the rules
+// without an operator.
+type autotracker struct {
+	cfg       *config.Config
+	rules     *autotrack.RuleSet
+	observer  coordinates.Observer
+	repo      *db.AircraftRepository
+	fpRepo    *db.FlightPlanRepository
+	schedule  *db.ScheduleRepository
+	passes    *db.PassReportRepository
+	telescope *alpaca.Client        // nil in dry run mode or with another driver
+	mount     *alpaca.SafeTelescope // telescope (or rotator, PTZ camera), kept within the mount limits
+	limits    tracking.TrackingLimits
+	solar     *safety.SolarGuard
+	predict   tracking.PredictionStrategy       // Default for targets of rules and tasks that don't set one
+	sky       planner.SkyBrightness             // Configured sky, when no meter reads
+	sqm       *alpaca.ObservingConditionsClient // Sky quality meter; nil if unavailable
+
+	target   *target
+	task     *activeTask               // Scheduled task whose window is open
+	plans    map[string]*db.FlightPlan // Flight plans by ICAO (nil if none filed)
+	cooldown map[string]time.Time      // When each tracked aircraft may be picked again
+
+	// Time-sliced mode (experimental): several targets tracked at once
+	group    []*target
+	slicer   *autotrack.TimeSlicer // nil unless enabled in the rules
+	pointing coordinates.HorizontalCoordinates
+}
+
+// main runs the headless tracking daemon. It picks targets from the
+// declarative rules file (or the observation schedule while a scheduled
+// window is open), tracks each through its pass, runs the capture command at
+// culmination and logs every telescope command to the database.
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	rulesPath := flag.String("rules", "configs/autotracker-rules.json", "Path to target rules file")
+	dryRun := flag.Bool("dry-run", false, "Simulate tracking without moving telescope")
+	prediction := flag.String("prediction", "auto", "Prediction strategy: auto, dead-reckoning, flight-plan or airway")
+	flag.Parse()
+
+	log.Println("===========================================")
+	log.Println("  ADS-B Autotracker")
+	log.Println("===========================================")
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	log.Printf("Configuration loaded from: %s", *configPath)
+
+	if cfg.ADSB.PerformanceFile != "" {
+		n, err := performance.UseFile(cfg.ADSB.PerformanceFile)
+		if err != nil {
+			log.Fatalf("Failed to load aircraft performance: %v", err)
+		}
+		log.Printf("Loaded performance for %d aircraft types from: %s", n, cfg.ADSB.PerformanceFile)
+	}
+
+	rules, err := autotrack.LoadRules(*rulesPath)
+	if err != nil {
+		log.Fatalf("Failed to load rules: %v", err)
+	}
+	log.Printf("Loaded %d rules from: %s", len(rules.Rules), *rulesPath)
+	for _, r := range rules.Rules {
+		log.Printf("  - %s (priority %d, elevation ≥ %.0f°)", r.Name, r.Priority, r.MinElevation)
+	}
+	if n := rules.GetTimeSliceTargets(); n > 0 {
+		log.Printf("Time-sliced mode (experimental): %d targets, %s each", n, rules.GetDwell())
+	}
+
+	minAlt, maxAlt := cfg.Telescope.GetAltitudeLimits()
+	log.Printf("Tracking limits: %.0f° - %.0f° altitude", minAlt, maxAlt)
+
+	strategy, err := tracking.ParsePredictionStrategy(*prediction)
+	if err != nil {
+		log.Fatalf("Invalid -prediction: %v", err)
+	}
+	if strategy != tracking.StrategyAuto {
+		log.Printf("Prediction strategy: %s", strategy)
+	}
+
+	// Connect to database
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+	log.Println("✓ Database connected")
+
+	// Apply pending schema migrations
+	if err := database.Migrate(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  cfg.Observer.Latitude,
+			Longitude: cfg.Observer.Longitude,
+			Altitude:  cfg.Observer.Elevation,
+		},
+		Timezone: cfg.Observer.TimeZone,
+	}
+
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+
+	scorer, err := rules.Scoring.NewScorer(observer, limits)
+	if err != nil {
+		log.Fatalf("Failed to configure scoring: %v", err)
+	}
+	rules.UseScorer(scorer)
+	if rules.Scoring.Strategy != "" {
+		log.Printf("Scoring strategy: %s", rules.Scoring.Strategy)
+	}
+
+	// Unattended operation always avoids the sun unless a solar filter is
+	// installed
+	solar := safety.NewSolarGuard(cfg.Telescope, observer).Enforce()
+	if !cfg.Telescope.SolarFilterInstalled {
+		log.Printf("Solar avoidance: %.0f° minimum separation", solar.MinSeparation())
+	}
+
+	t := &autotracker{
+		cfg:      cfg,
+		rules:    rules,
+		observer: observer,
+		repo:     db.NewAircraftRepository(database, observer),
+		fpRepo:   db.NewFlightPlanRepository(database),
+		schedule: db.NewScheduleRepository(database),
+		passes:   db.NewPassReportRepository(database),
+		limits:   limits,
+		solar:    solar,
+		predict:  strategy,
+		sky:      planner.ObserverSky(cfg.Observer),
+		plans:    make(map[string]*db.FlightPlan),
+		cooldown: make(map[string]time.Time),
+	}
+	if rules.GetTimeSliceTargets() > 0 {
+		t.slicer = autotrack.NewTimeSlicer(rules.GetDwell())
+	}
+
+	// Alt-az slews are corrected by the mount's alignment, if measured
+	aligned := alignment.ModelFromConfig(cfg.Telescope.Alignment)
+
+	switch {
+	case *dryRun:
+		log.Println("DRY RUN MODE: Telescope commands will be simulated")
+	case cfg.Telescope.GetDriver() == "rotctld":
+		// An antenna rotator: alt-az, no Alpaca devices
+		rotator := rotctl.NewDriver(rotctl.NewClient(cfg.Telescope.GetRotctldAddress()), cfg.Telescope.SlewRate)
+		t.mount = alpaca.NewSafeTelescope(alignment.NewTelescope(rotator, aligned), alpaca.NewSafetyEnvelope(cfg.Telescope))
+		t.mount.SetSolarGuard(t.solar)
+		log.Printf("Connecting to rotator via rotctld at %s...", cfg.Telescope.GetRotctldAddress())
+		if err := rotator.Connect(context.Background()); err != nil {
+			log.Fatalf("Failed to connect to rotator: %v", err)
+		}
+		defer rotator.Disconnect()
+		log.Println("✓ Rotator connected")
+	case cfg.Telescope.GetDriver() == "onvif":
+		// A PTZ camera: alt-az through its pan/tilt calibration
+		camera := onvif.NewDriver(onvif.NewClientFromConfig(cfg.Telescope.ONVIF), onvif.CalibrationFromConfig(cfg.Telescope.ONVIF), cfg.Telescope.SlewRate)
+		t.mount = alpaca.NewSafeTelescope(alignment.NewTelescope(camera, aligned), alpaca.NewSafetyEnvelope(cfg.Telescope))
+		t.mount.SetSolarGuard(t.solar)
+		log.Printf("Connecting to PTZ camera at %s...", cfg.Telescope.ONVIF.PTZURL)
+		if err := camera.Connect(context.Background()); err != nil {
+			log.Fatalf("Failed to connect to PTZ camera: %v", err)
+		}
+		log.Println("✓ PTZ camera connected")
+	case cfg.Telescope.GetDriver() == "alpaca":
+		t.telescope = alpaca.NewClient(cfg.Telescope)
+		t.mount = alpaca.NewSafeTelescope(alignment.NewTelescope(t.telescope, aligned), alpaca.NewSafetyEnvelope(cfg.Telescope))
+		t.mount.SetSolarGuard(t.solar)
+		log.Printf("Connecting to telescope at %s...", cfg.Telescope.BaseURL)
+		if err := t.telescope.Connect(); err != nil {
+			log.Fatalf("Failed to connect to telescope: %v", err)
+		}
+		defer func() {
+			log.Println("Disconnecting from telescope...")
+			t.telescope.Disconnect()
+		}()
+		log.Println("✓ Telescope connected")
+
+		// A sky quality meter on the weather station overrides the
+		// configured sky brightness
+		conditions := alpaca.NewObservingConditionsClient(alpaca.NewClient(cfg.Telescope))
+		if err := conditions.Connect(); err != nil {
+			log.Printf("Sky brightness: Bortle %d (%s, no sky quality meter)", t.sky.Bortle(), t.sky.Source)
+		} else if _, err := conditions.GetSkyQuality(); err != nil {
+			log.Printf("Sky brightness: Bortle %d (%s, weather station has no sky quality sensor)", t.sky.Bortle(), t.sky.Source)
+		} else {
+			t.sqm = conditions
+			log.Println("✓ Sky quality meter connected")
+		}
+	default:
+		log.Fatalf("Unknown telescope driver %q (alpaca, rotctld or onvif)", cfg.Telescope.Driver)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Reconnect by itself when the Alpaca server restarts
+	if t.telescope != nil {
+		go superviseTelescope(ctx, t.telescope, cfg.Alerts)
+	}
+
+	log.Println("Autotracker running. Press Ctrl+C to stop")
+
+	ticker := time.NewTicker(updateInterval)
+	defer ticker.Stop()
+
+	for {
+		t.step(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			t.endAll(time.Now().UTC(), "shutting down")
+			log.Println("Autotracker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step runs one update: acquire a target if idle, otherwise keep tracking it.
+func (t *autotracker) step(ctx context.Context, now time.Time) {
+	t.updateSchedule(ctx, now)
+
+	if len(t.plans) > maxCachedPlans {
+		t.plans = make(map[string]*db.FlightPlan)
+	}
+
+	// Scheduled tasks are always tracked one target at a time
+	if t.slicer != nil && t.task == nil {
+		t.stepTimeSliced(ctx, now)
+		return
+	}
+
+	if t.target == nil {
+		t.acquire(ctx, now)
+		return
+	}
+	t.track(ctx, now)
+}
+
+// candidates returns the trackable aircraft that are within the limits and
+// clear of the sun.
+func (t *autotracker) candidates(ctx context.Context, now time.Time) ([]autotrack.Candidate, error) {
+	aircraft, err := t.repo.GetTrackableAircraft(ctx, t.limits.MinAltitude, t.limits.MaxAltitude)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]autotrack.Candidate, 0, len(aircraft))
+	for _, ac := range aircraft {
+		pos, _ := t.position(ac, now)
+		horiz := coordinates.GeographicToHorizontal(pos, t.observer, now)
+		if tracking.ShouldAbortTracking(horiz, t.limits) || !t.solarSafe(horiz) {
+			continue
+		}
+
+		candidates = append(candidates, autotrack.Candidate{
+			Aircraft:     ac,
+			AircraftType: t.aircraftType(ctx, ac.ICAO),
+			Horizontal:   horiz,
+			RangeNM:      coordinates.DistanceNauticalMiles(t.observer.Location, pos),
+		})
+	}
+	return candidates, nil
+}
+
+// acquire evaluates the rules (or the open scheduled task) against all
+// trackable aircraft and starts tracking the best match.
+func (t *autotracker) acquire(ctx context.Context, now time.Time) {
+	candidates, err := t.candidates(ctx, now)
+	if err != nil {
+		log.Printf("Warning: Failed to query trackable aircraft: %v", err)
+		return
+	}
+
+	rules := t.rules
+	skip := func(icao string) bool {
+		return now.Before(t.cooldown[icao])
+	}
+	var task *db.ScheduledTask
+	if t.task != nil {
+		task = &t.task.ScheduledTask
+		rules, candidates = t.taskRules(ctx, candidates)
+		if task.Kind == db.TaskTrack {
+			skip = nil // Explicitly requested, so cooldown doesn't apply
+		}
+	}
+
+	best, rule, ok := rules.Select(candidates, skip)
+	if !ok {
+		return
+	}
+	t.target = t.newTarget(best, rule, task, now)
+}
+
+// newTarget starts tracking a selected candidate.
+func (t *autotracker) newTarget(best autotrack.Candidate, rule autotrack.Rule, task *db.ScheduledTask, now time.Time) *target {
+	tg := &target{
+		icao:     best.Aircraft.ICAO,
+		callsign: strings.TrimSpace(best.Aircraft.Callsign),
+		rule:     rule.Name,
+		task:     task,
+		started:  now,
+		monitor:  tracking.NewPassMonitor(t.limits, t.cfg.Display.GetLimitWarning()),
+		horiz:    best.Horizontal,
+
+		prediction: t.predict,
+	}
+	if task != nil && task.Prediction != "" && task.Prediction != tracking.StrategyAuto {
+		tg.prediction = task.Prediction
+	}
+
+	typeInfo := ""
+	if best.AircraftType != "" {
+		typeInfo = " " + best.AircraftType
+	}
+	log.Printf("🎯 Acquired %s (%s)%s by rule %q: Alt %.1f° Az %.1f°, %.1f nm",
+		tg.callsign, tg.icao, typeInfo, rule.Name,
+		best.Horizontal.Altitude, best.Horizontal.Azimuth, best.RangeNM)
+	return tg
+}
+
+// observation is a target's position at one update.
+type observation struct {
+	aircraft   adsb.Aircraft
+	pos        coordinates.Geographic
+	horiz      coordinates.HorizontalCoordinates
+	confidence float64
+	mode       tracking.PredictionMode
+
+	// culminating is set when the target culminates and hasn't been captured
+	culminating bool
+}
+
+// track updates the telescope for the current target and handles its pass
+// events.
+func (t *autotracker) track(ctx context.Context, now time.Time) {
+	tg := t.target
+	obs, ok := t.update(ctx, tg, now)
+	if !ok {
+		return
+	}
+
+	if obs.culminating {
+		t.capture(tg, obs.horiz)
+	}
+	t.slew(ctx, obs.aircraft, obs.pos, obs.horiz, obs.confidence, obs.mode, now)
+}
+
+// update locates a target, handles its pass events and ends it once it's
+// lost or can no longer be tracked. Returns false if it was ended or isn't
+// currently reported.
+func (t *autotracker) update(ctx context.Context, tg *target, now time.Time) (observation, bool) {
+	ac, err := t.repo.GetAircraftByICAO(ctx, tg.icao)
+	if err != nil {
+		log.Printf("Warning: Database query failed: %v", err)
+		return observation{}, false
+	}
+
+	var obs observation
+	if ac != nil {
+		obs.aircraft = *ac
+		obs.pos, obs.confidence, obs.mode = t.targetPosition(ctx, tg, *ac, now)
+		obs.horiz = coordinates.GeographicToHorizontal(obs.pos, t.observer, now)
+		tg.horiz = obs.horiz
+	}
+
+	for _, event := range tg.monitor.Update(obs.horiz.Altitude, ac != nil) {
+		switch event {
+		case tracking.Culmination:
+			obs.culminating = !tg.captured
+		case tracking.ApproachingLimit:
+			log.Printf("  %s approaching altitude limit (%.1f°)", tg.icao, obs.horiz.Altitude)
+		case tracking.TargetLost:
+			t.endTarget(tg, now, "target lost")
+			return observation{}, false
+		}
+	}
+	if ac == nil {
+		// Never seen since acquisition, so the pass monitor can't report it lost
+		if now.Sub(tg.started) > time.Minute {
+			t.endTarget(tg, now, "target not reported")
+		}
+		return observation{}, false
+	}
+
+	if limit := t.rules.GetMaxTrackDuration(); limit > 0 && now.Sub(tg.started) > limit {
+		t.endTarget(tg, now, "maximum track time reached")
+		return observation{}, false
+	}
+	if !t.solarSafe(obs.horiz) {
+		t.endTarget(tg, now, "too close to the sun")
+		return observation{}, false
+	}
+
+	return obs, true
+}
+
+// slew points the telescope at the target and records the command.
+func (t *autotracker) slew(
+	ctx context.Context,
+	ac adsb.Aircraft,
+	pos coordinates.Geographic,
+	horiz coordinates.HorizontalCoordinates,
+	confidence float64,
+	mode tracking.PredictionMode,
+	now time.Time,
+) {
+	dataAge := now.Sub(ac.LastSeen).Seconds()
+	entry := db.TrackingLogEntry{
+		ICAO:                 ac.ICAO,
+		Timestamp:            now,
+		Latitude:             pos.Latitude,
+		Longitude:            pos.Longitude,
+		AltitudeFt:           pos.Altitude.Feet(),
+		RangeNM:              coordinates.DistanceNauticalMiles(t.observer.Location, pos),
+		TelescopeAltitude:    horiz.Altitude,
+		TelescopeAzimuth:     horiz.Azimuth,
+		MountType:            t.cfg.Telescope.MountType,
+		Predicted:            dataAge > 0,
+		PredictionLatency:    dataAge,
+		PredictionConfidence: confidence,
+		PredictionMode:       mode,
+	}
+
+	if t.mount != nil {
+		var slewErr error
+		if t.telescope == nil || t.cfg.Telescope.MountType == "altaz" {
+			slewErr = t.mount.SlewToAltAz(horiz.Altitude, horiz.Azimuth)
+		} else {
+			// Equatorial slews are checked against the same envelope and
+			// the sun before converting the (possibly held) target
+			var safe coordinates.HorizontalCoordinates
+			safe.Altitude, safe.Azimuth, slewErr = t.mount.Check(horiz.Altitude, horiz.Azimuth)
+			if slewErr == nil {
+				eq := coordinates.HorizontalToEquatorial(safe, t.observer, now)
+				slewErr = t.telescope.SlewToCoordinates(eq.RightAscension, eq.Declination)
+			}
+		}
+
+		entry.CommandSent = true
+		entry.CommandSuccess = slewErr == nil
+		if slewErr != nil {
+			entry.ErrorMessage = slewErr.Error()
+			log.Printf("  Error: Failed to slew telescope: %v", slewErr)
+		}
+	}
+
+	if err := t.repo.LogTrackingCommand(ctx, entry); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	t.pointing = horiz
+}
+
+// endTarget stops tracking a target and puts it on cooldown.
+func (t *autotracker) endTarget(tg *target, now time.Time, reason string) {
+	log.Printf("■ Finished %s (%s) after %s: %s",
+		tg.callsign, tg.icao, now.Sub(tg.started).Round(time.Second), reason)
+	t.reportPass(tg, now, reason)
+
+	t.cooldown[tg.icao] = now.Add(t.rules.GetCooldown())
+	if t.task != nil && tg.task != nil {
+		t.task.tracked = true
+		if t.task.Kind == db.TaskTrack {
+			// A single aircraft pass completes the task
+			t.finishTask(db.TaskCompleted, "")
+		}
+	}
+	if tg == t.target {
+		t.target = nil
+	}
+	t.leaveGroup(tg, now)
+
+	// Forget expired cooldowns so the map doesn't grow all day
+	for icao, until := range t.cooldown {
+		if now.After(until) {
+			delete(t.cooldown, icao)
+		}
+	}
+}
+
+// endAll stops tracking every target.
+func (t *autotracker) endAll(now time.Time, reason string) {
+	if t.target != nil {
+		t.endTarget(t.target, now, reason)
+	}
+	for len(t.group) > 0 {
+		t.endTarget(t.group[0], now, reason)
+	}
+}
+
+// capture runs the rules' capture command for a target. The command runs in
+// the background so a slow camera doesn't stall tracking.
+func (t *autotracker) capture(tg *target, horiz coordinates.HorizontalCoordinates) {
+	tg.captured = true
+	log.Printf("📷 %s (%s) culminated at Alt %.1f° Az %.1f°",
+		tg.callsign, tg.icao, horiz.Altitude, horiz.Azimuth)
+
+	fields := strings.Fields(t.rules.CaptureCommand)
+	if len(fields) == 0 {
+		return
+	}
+
+	// Suggest an exposure for how dark the sky is
+	phase := coordinates.GetTwilightPhase(t.solar.Sun().Altitude)
+	sky := t.currentSky()
+	exposure := t.rules.GetCaptureExposure(phase, sky)
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Env = append(os.Environ(),
+		"ADS_BSCOPE_ICAO="+tg.icao,
+		"ADS_BSCOPE_CALLSIGN="+tg.callsign,
+		"ADS_BSCOPE_RULE="+tg.rule,
+		fmt.Sprintf("ADS_BSCOPE_ALTITUDE=%.2f", horiz.Altitude),
+		fmt.Sprintf("ADS_BSCOPE_AZIMUTH=%.2f", horiz.Azimuth),
+		"ADS_BSCOPE_TWILIGHT="+phase.String(),
+		fmt.Sprintf("ADS_BSCOPE_EXPOSURE_MS=%g", float64(exposure)/float64(time.Millisecond)),
+		fmt.Sprintf("ADS_BSCOPE_SKY_SQM=%.2f", sky.SQM),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("Warning: Failed to run capture command: %v", err)
+		return
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Printf("Warning: Capture command failed: %v", err)
+		}
+	}()
+}
+
+// currentSky returns the sky quality meter's reading, or the configured sky
+// brightness if there is no meter or it can't be read.
+func (t *autotracker) currentSky() planner.SkyBrightness {
+	if t.sqm == nil {
+		return t.sky
+	}
+	sqm, err := t.sqm.GetSkyQuality()
+	if err != nil {
+		log.Printf("Warning: Failed to read sky quality meter: %v", err)
+		return t.sky
+	}
+	return planner.SkyFromMeter(sqm)
+}
+
+// position returns the aircraft's position extrapolated to now and the
+// confidence of the extrapolation.
+func (t *autotracker) position(ac adsb.Aircraft, now time.Time) (coordinates.Geographic, float64) {
+	predicted := tracking.PredictPosition(ac, now)
+	return predicted.Position, predicted.Confidence
+}
+
+// aircraftType returns the aircraft type from the flight plan, looked up
+// only when a rule needs it.
+func (t *autotracker) aircraftType(ctx context.Context, icao string) string {
+	if !t.rules.NeedsAircraftType() {
+		return ""
+	}
+	if fp := t.flightPlan(ctx, icao); fp != nil {
+		return fp.AircraftType
+	}
+	return ""
+}
+
+// flightPlan returns the aircraft's flight plan, looked up once per aircraft.
+func (t *autotracker) flightPlan(ctx context.Context, icao string) *db.FlightPlan {
+	if fp, ok := t.plans[icao]; ok {
+		return fp
+	}
+
+	fp, err := t.fpRepo.GetFlightPlanByICAO(ctx, icao)
+	if err != nil {
+		fp = nil
+	}
+	t.plans[icao] = fp
+	return fp
+}
+
+// solarSafe reports whether pointing at the position keeps the required
+// separation from the sun.
+func (t *autotracker) solarSafe(horiz coordinates.HorizontalCoordinates) bool {
+	_, err := t.solar.Check(horiz)
+	return err == nil
+}
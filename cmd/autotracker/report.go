@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// reportTimeout bounds the queries made to report a pass, which also runs
+// while shutting down after the main context is cancelled.
+const reportTimeout = 10 * time.Second
+
+// reportPass compares the positions commanded while tracking a target with
+// its position history and stores the pass's accuracy report. Passes with
+// no commands (never reported, or ended before the first slew) are skipped.
+func (t *autotracker) reportPass(tg *target, now time.Time, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+
+	entries, err := t.repo.GetTrackingLog(ctx, tg.icao, tg.started, now)
+	if err != nil {
+		log.Printf("Warning: Failed to report pass of %s: %v", tg.icao, err)
+		return
+	}
+
+	var samples []tracking.PointingSample
+	for _, e := range entries {
+		if e.CommandSent && !e.CommandSuccess {
+			continue // The telescope never went there
+		}
+		samples = append(samples, e.PointingSample())
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	tracks, err := t.repo.GetRecentTracks(ctx, []string{tg.icao}, tg.started.Add(-updateInterval))
+	if err != nil {
+		log.Printf("Warning: Failed to report pass of %s: %v", tg.icao, err)
+		return
+	}
+
+	report := db.PassReport{
+		ICAO:      tg.icao,
+		Callsign:  tg.callsign,
+		Rule:      tg.rule,
+		StartedAt: tg.started,
+		EndedAt:   now,
+		EndReason: reason,
+	}
+	report.SetAccuracy(tracking.AnalyzePass(samples, tracks[tg.icao], t.observer))
+	if err := t.passes.Save(ctx, &report); err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+
+	if report.Assessed > 0 {
+		log.Printf("  Pointing: RMS %.2f°, max %.2f° (%d of %d commands assessed, data age %.1f s)",
+			report.RMSErrorDeg, report.MaxErrorDeg, report.Assessed, report.Commands, report.MeanDataAge)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// airwaySearchRadius is how far (NM) from a target airways are matched
+const airwaySearchRadius = 25.0
+
+// routePosition predicts a target's position along its flight plan route or
+// a matching airway, when its prediction strategy asks for one. Under the
+// auto strategy targets are dead-reckoned, which is as good over the few
+// seconds between updates. Returns false if no route applies.
+func (t *autotracker) routePosition(ctx context.Context, tg *target, ac adsb.Aircraft, now time.Time) (tracking.PredictedPosition, tracking.PredictionMode, bool) {
+	switch tg.prediction {
+	case tracking.StrategyFlightPlan:
+		waypoints := t.routeWaypoints(ctx, ac)
+		if len(waypoints) == 0 {
+			return tracking.PredictedPosition{}, "", false
+		}
+		return tracking.PredictPositionWithWaypoints(ac, waypoints, now), tracking.PredictionFlightPlan, true
+	case tracking.StrategyAirway:
+		airway := t.matchAirway(ctx, ac)
+		if airway == nil {
+			return tracking.PredictedPosition{}, "", false
+		}
+		return tracking.PredictPositionWithAirway(ac, *airway, now), tracking.PredictionAirway, true
+	}
+	return tracking.PredictedPosition{}, "", false
+}
+
+// routeWaypoints returns the aircraft's flight plan route with the waypoints
+// it has passed marked, or nil if it has none.
+func (t *autotracker) routeWaypoints(ctx context.Context, ac adsb.Aircraft) []tracking.Waypoint {
+	fp := t.flightPlan(ctx, ac.ICAO)
+	if fp == nil {
+		return nil
+	}
+	routes, err := t.fpRepo.GetFlightPlanRoute(ctx, fp.ID)
+	if err != nil {
+		log.Printf("Warning: Failed to get route of %s: %v", ac.ICAO, err)
+		return nil
+	}
+
+	waypoints := make([]tracking.Waypoint, len(routes))
+	for i, r := range routes {
+		waypoints[i] = r.Waypoint()
+	}
+	return tracking.DeterminePassedWaypoints(ac, waypoints)
+}
+
+// matchAirway returns the nearby airway the aircraft is flying along, or nil
+// if none matches.
+func (t *autotracker) matchAirway(ctx context.Context, ac adsb.Aircraft) *tracking.AirwaySegment {
+	segments, err := t.fpRepo.FindNearbyAirways(ctx, ac.Latitude, ac.Longitude, airwaySearchRadius,
+		int(ac.Altitude*0.9), int(ac.Altitude*1.1))
+	if err != nil {
+		log.Printf("Warning: Failed to find airways near %s: %v", ac.ICAO, err)
+		return nil
+	}
+
+	airways := make([]tracking.AirwaySegment, len(segments))
+	for i, seg := range segments {
+		airways[i] = seg.TrackingSegment()
+	}
+	return tracking.MatchAirway(ac, tracking.FilterAirwaysByAltitude(airways, ac.Altitude))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/autotrack"
+)
+
+// activeTask is a scheduled task whose window is open.
+type activeTask struct {
+	db.ScheduledTask
+
+	// tracked is set once any target has been tracked for the task
+	tracked bool
+}
+
+// updateSchedule closes tasks whose window has ended and starts the task
+// that is due. A due task pre-empts a target picked by the rules.
+func (t *autotracker) updateSchedule(ctx context.Context, now time.Time) {
+	expired, err := t.schedule.Expired(ctx, now)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	for _, task := range expired {
+		if t.task != nil && t.task.ID == task.ID {
+			if t.target != nil && t.target.task != nil {
+				t.endTarget(t.target, now, "scheduled window ended")
+			}
+			if t.task == nil {
+				continue // Completed by endTarget
+			}
+			if t.task.tracked {
+				t.finishTask(db.TaskCompleted, "")
+			} else {
+				t.finishTask(db.TaskFailed, "no matching aircraft was trackable")
+			}
+			continue
+		}
+
+		// Window passed while the daemon wasn't running or was busy
+		log.Printf("⏰ Missed scheduled task %q", task.Title)
+		if err := t.schedule.SetStatus(ctx, task.ID, db.TaskFailed, "window missed"); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	due, err := t.schedule.Due(ctx, now)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	if due == nil || (t.task != nil && t.task.ID == due.ID) {
+		return
+	}
+
+	t.endAll(now, "scheduled task starting")
+	t.task = &activeTask{ScheduledTask: *due}
+	if err := t.schedule.SetStatus(ctx, due.ID, db.TaskRunning, ""); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	log.Printf("📅 Starting scheduled task %q (%s %s until %s)",
+		due.Title, due.Kind, due.Target, due.EndTime.Local().Format("15:04"))
+}
+
+// finishTask records the outcome of the open task.
+func (t *autotracker) finishTask(status, message string) {
+	log.Printf("📅 Scheduled task %q %s", t.task.Title, status)
+	if err := t.schedule.SetStatus(context.Background(), t.task.ID, status, message); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	t.task = nil
+}
+
+// taskRules narrows the candidates to those the open task asks for and
+// returns rules that pick the highest of them.
+func (t *autotracker) taskRules(ctx context.Context, candidates []autotrack.Candidate) (*autotrack.RuleSet, []autotrack.Candidate) {
+	task := t.task.ScheduledTask
+	target := strings.ToUpper(task.Target)
+
+	matching := candidates[:0]
+	for _, c := range candidates {
+		switch task.Kind {
+		case db.TaskTrack:
+			if strings.EqualFold(c.Aircraft.ICAO, target) ||
+				strings.EqualFold(strings.TrimSpace(c.Aircraft.Callsign), target) {
+				matching = append(matching, c)
+			}
+		case db.TaskArrivals, db.TaskDepartures:
+			fp := t.flightPlan(ctx, c.Aircraft.ICAO)
+			if fp == nil {
+				continue
+			}
+			airport := fp.ArrivalICAO
+			if task.Kind == db.TaskDepartures {
+				airport = fp.DepartureICAO
+			}
+			if strings.EqualFold(airport, target) {
+				matching = append(matching, c)
+			}
+		}
+	}
+
+	rules := &autotrack.RuleSet{Rules: []autotrack.Rule{{Name: task.Title}}}
+	return rules, matching
+}
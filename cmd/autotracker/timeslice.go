@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/autotrack"
+)
+
+// stepTimeSliced runs one update in time-sliced mode: top up the group of
+// targets, update each of them and point the telescope at the one whose turn
+// it is. A target that culminates takes its turn at once so it is captured.
+func (t *autotracker) stepTimeSliced(ctx context.Context, now time.Time) {
+	if len(t.group) < t.rules.GetTimeSliceTargets() {
+		t.acquireGroup(ctx, now)
+	}
+
+	observations := make(map[*target]observation, len(t.group))
+	for _, tg := range slices.Clone(t.group) { // Ended targets leave the group
+		obs, ok := t.update(ctx, tg, now)
+		if !ok {
+			continue
+		}
+		observations[tg] = obs
+		if obs.culminating {
+			t.slicer.Focus(tg.icao, now)
+		}
+	}
+
+	icao, ok := t.slicer.Current(now)
+	if !ok {
+		return
+	}
+	tg := t.groupTarget(icao)
+	obs, ok := observations[tg]
+	if !ok {
+		return // Not reported at this update
+	}
+
+	if obs.culminating {
+		t.capture(tg, obs.horiz)
+	}
+	t.slew(ctx, obs.aircraft, obs.pos, obs.horiz, obs.confidence, obs.mode, now)
+}
+
+// acquireGroup adds the best matches to the group until it is full, then
+// reorders the group for the least slewing.
+func (t *autotracker) acquireGroup(ctx context.Context, now time.Time) {
+	candidates, err := t.candidates(ctx, now)
+	if err != nil {
+		log.Printf("Warning: Failed to query trackable aircraft: %v", err)
+		return
+	}
+
+	skip := func(icao string) bool {
+		return now.Before(t.cooldown[icao]) || t.groupTarget(icao) != nil
+	}
+
+	added := false
+	for len(t.group) < t.rules.GetTimeSliceTargets() {
+		best, rule, ok := t.rules.Select(candidates, skip)
+		if !ok {
+			break
+		}
+		t.group = append(t.group, t.newTarget(best, rule, nil, now))
+		added = true
+	}
+
+	if added {
+		t.reorderGroup(now)
+	}
+}
+
+// reorderGroup hands the group's current positions to the time slicer, so
+// it visits them in the order that needs the least slewing.
+func (t *autotracker) reorderGroup(now time.Time) {
+	targets := make([]autotrack.SliceTarget, len(t.group))
+	for i, tg := range t.group {
+		targets[i] = autotrack.SliceTarget{ICAO: tg.icao, Horizontal: tg.horiz}
+	}
+	t.slicer.SetTargets(targets, t.pointing, now)
+
+	if len(targets) > 1 {
+		log.Printf("⏱ Time slicing %v, %s each", t.slicer.Targets(), t.rules.GetDwell())
+	}
+}
+
+// leaveGroup removes an ended target from the group.
+func (t *autotracker) leaveGroup(tg *target, now time.Time) {
+	i := slices.Index(t.group, tg)
+	if i < 0 {
+		return
+	}
+	t.group = slices.Delete(t.group, i, i+1)
+	t.slicer.Remove(tg.icao, now)
+}
+
+// groupTarget returns the group's target for an aircraft, or nil.
+func (t *autotracker) groupTarget(icao string) *target {
+	for _, tg := range t.group {
+		if tg.icao == icao {
+			return tg
+		}
+	}
+	return nil
+}
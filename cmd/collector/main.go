@@ -2,17 +2,27 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/internal/flightplans"
 	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alerts"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/scenario"
+	"github.com/unklstewy/ads-bscope/pkg/terrain"
 )
 
 // Collector continuously fetches aircraft data and stores it in the database.
@@ -32,28 +42,9 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Get effective collection regions
-	collectionRegions := cfg.ADSB.GetCollectionRegions(cfg.Observer)
-	enabledRegions := 0
-	for _, region := range collectionRegions {
-		if region.Enabled {
-			enabledRegions++
-		}
-	}
-
 	log.Printf("Configuration loaded from: %s", *configPath)
 	log.Printf("Observer: %s at %.4f°N, %.4f°W, %.0fm MSL",
 		cfg.Observer.Name, cfg.Observer.Latitude, cfg.Observer.Longitude, cfg.Observer.Elevation)
-	log.Printf("Collection regions: %d total, %d enabled", len(collectionRegions), enabledRegions)
-	for _, region := range collectionRegions {
-		if region.Enabled {
-			log.Printf("  ✓ %s: %.4f°N, %.4f°W (%.0f nm)",
-				region.Name, region.Latitude, region.Longitude, region.RadiusNM)
-			if region.RadiusNM > 250 {
-				log.Printf("    ⚠️  WARNING: Large radius (>250 nm) may cause API rate limit issues")
-			}
-		}
-	}
 	log.Printf("Update interval: %d seconds", cfg.ADSB.UpdateIntervalSeconds)
 
 	// Get telescope limits
@@ -70,12 +61,37 @@ func main() {
 	defer database.Close()
 	log.Println("✓ Database connected")
 
-	// Initialize schema
+	// Apply pending schema migrations
 	ctx := context.Background()
-	if err := database.InitSchema(ctx); err != nil {
-		log.Fatalf("Failed to initialize schema: %v", err)
+	if err := database.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	log.Println("✓ Database schema up to date")
+
+	// Resolve regions defined by airport/waypoint identifier
+	resolveRegionCenters(ctx, cfg, database)
+
+	// Get effective collection regions
+	collectionRegions := cfg.ADSB.GetCollectionRegions(cfg.Observer)
+	enabledRegions := 0
+	for _, region := range collectionRegions {
+		if region.Enabled {
+			enabledRegions++
+		}
+	}
+
+	log.Printf("Collection regions: %d total, %d enabled", len(collectionRegions), enabledRegions)
+	for _, region := range collectionRegions {
+		if region.Enabled {
+			log.Printf("  ✓ %s: %.4f°N, %.4f°W (%.0f nm)",
+				region.Name, region.Latitude, region.Longitude, region.RadiusNM)
+			if region.RadiusNM > adsb.MaxQueryRadiusNM {
+				tiles := adsb.TileRegion(region.Latitude, region.Longitude, region.RadiusNM, adsb.MaxQueryRadiusNM)
+				log.Printf("    ℹ Large radius (>%.0f nm): split into %d tiles, outer tiles polled less often",
+					adsb.MaxQueryRadiusNM, len(tiles))
+			}
+		}
 	}
-	log.Println("✓ Database schema initialized")
 
 	// Create observer
 	observer := coordinates.Observer{
@@ -87,39 +103,174 @@ func main() {
 		Timezone: cfg.Observer.TimeZone,
 	}
 
-	// Create repository
+	// Create repository; positions are buffered and stored once per cycle
 	repo := db.NewAircraftRepository(database, observer)
+	repo.ConfigurePositionHistory(cfg.Database.PositionHistory, true)
+	if h := cfg.Database.PositionHistory; h.DownsampleBeyondNM > 0 && h.DownsampleEvery > 1 {
+		log.Printf("Storing every %d positions of aircraft beyond %.0f nm", h.DownsampleEvery, h.DownsampleBeyondNM)
+	}
+
+	// Stored az/el/range are relative to the observer; recompute them in
+	// case the observer moved since they were stored
+	if n, err := repo.RefreshGeometry(ctx); err != nil {
+		log.Printf("Warning: Failed to refresh aircraft geometry: %v", err)
+	} else if n > 0 {
+		log.Printf("Refreshed geometry of %d visible aircraft", n)
+	}
 
-	// Create ADS-B client
+	// Create ADS-B clients
+	// One online source at a time is polled per region, starting with the
+	// first; the others are backups it fails over to. Local receivers
+	// (dump978) are read once per update and merged in.
 	if len(cfg.ADSB.Sources) == 0 {
 		log.Fatal("Error: No ADS-B sources configured")
 	}
-	source := cfg.ADSB.Sources[0]
-	adsbClient := adsb.NewAirplanesLiveClient(source.BaseURL)
-	defer adsbClient.Close()
+	var sources []*onlineSource
+	var extraSources []*supplementalSource
+	for _, src := range cfg.ADSB.Sources {
+		if !src.Enabled {
+			continue
+		}
+		switch src.Type {
+		case "dump978":
+			client := adsb.NewDump978Client(dump978URL(src))
+			defer client.Close()
+			extraSources = append(extraSources, &supplementalSource{
+				name:    src.Name,
+				client:  client,
+				rangeNM: localSourceRangeNM,
+			})
+			log.Printf("\n✓ Using UAT 978 receiver: %s (%s)", src.Name, dump978URL(src))
+			continue
+		case "airframes":
+			client := adsb.NewADSCClient(src.BaseURL, src.APIKey)
+			defer client.Close()
+			interval := time.Duration(src.RateLimitSeconds * float64(time.Second))
+			if interval < time.Minute {
+				interval = time.Minute
+			}
+			extraSources = append(extraSources, &supplementalSource{
+				name:     src.Name,
+				client:   client,
+				rangeNM:  oceanicSourceRangeNM,
+				interval: interval,
+			})
+			log.Printf("\n✓ Using oceanic ADS-C feed: %s (every %v)", src.Name, interval)
+			continue
+		case "scenario":
+			sc, err := scenario.Load(src.ScenarioFile)
+			if err != nil {
+				log.Fatalf("Failed to load scenario for source %s: %v", src.Name, err)
+			}
+			extraSources = append(extraSources, &supplementalSource{
+				name:    src.Name,
+				client:  scenario.NewPlaybackSource(sc, observer.Location, time.Now().UTC()),
+				rangeNM: localSourceRangeNM,
+			})
+			log.Printf("\n✓ Playing scenario: %s (%d flights, %s)", sc.Name, len(sc.Flights), src.ScenarioFile)
+			continue
+		case "local":
+			// dump1090 receivers are monitored by the web server
+			// (/api/v1/system/receiver), not polled for aircraft here
+			continue
+		}
+		client := adsb.NewAirplanesLiveClient(src.BaseURL)
+		defer client.Close()
+		sources = append(sources, &onlineSource{
+			name:      src.Name,
+			client:    client,
+			rateLimit: time.Duration(src.RateLimitSeconds * float64(time.Second)),
+			breaker:   adsb.NewCircuitBreaker(adsb.DefaultBreakerConfig()),
+		})
+		if len(sources) == 1 {
+			log.Printf("\n✓ Using ADS-B source: %s", src.Name)
+			log.Printf("  Rate limit: %.1f seconds between calls", src.RateLimitSeconds)
+		} else {
+			log.Printf("  Backup ADS-B source: %s", src.Name)
+		}
+	}
+	if len(sources) == 0 && len(extraSources) == 0 {
+		log.Fatal("Error: No enabled ADS-B sources configured")
+	}
+
+	// Fail over between online sources
+	failoverCfg := adsb.DefaultFailoverConfig()
+	failoverCfg.FailureThreshold = cfg.ADSB.GetFailoverAfterCycles()
+	names := make([]string, len(sources))
+	for i, src := range sources {
+		names[i] = src.name
+	}
+	failover := adsb.NewFailover(names, failoverCfg)
+	if len(sources) > 1 {
+		log.Printf("  Failover after %d failed cycles", failoverCfg.FailureThreshold)
+	}
+
+	// Create emergency alert dispatcher
+	alertDispatcher := newAlertDispatcher(cfg.Alerts)
+
+	// Create upcoming pass notifier
+	var passNotifier *planner.PassNotifier
+	if alertDispatcher != nil && len(cfg.Alerts.PassNotifications) > 0 {
+		passNotifier = planner.NewPassNotifier(cfg.Alerts.PassNotifications, observer)
+		for _, rule := range cfg.Alerts.PassNotifications {
+			log.Printf("  Pass notification %q: %.0f min before reaching %.0f°", rule.Name, rule.GetLead().Minutes(), rule.MinElevation)
+		}
+	}
+
+	// Create adaptive cadence scheduler
+	updateInterval := time.Duration(cfg.ADSB.UpdateIntervalSeconds) * time.Second
+	var cadence *adsb.CadenceScheduler
+	if cfg.ADSB.Cadence.Enabled && len(sources) > 0 {
+		cadence = newCadenceScheduler(cfg.ADSB.Cadence, updateInterval, sources[0].rateLimit.Seconds())
+	}
+
+	// Low aircraft behind high ground aren't trackable
+	terrainChecker, err := terrain.CheckerFromConfig(cfg.Observer.Terrain)
+	if err != nil {
+		log.Fatalf("Failed to load terrain: %v", err)
+	}
+	if terrainChecker != nil {
+		log.Printf("  Terrain line of sight checked below %.0f° elevation", terrainChecker.MaxElevation)
+	}
 
-	log.Printf("\n✓ Using ADS-B source: %s", source.Name)
-	log.Printf("  Rate limit: %.1f seconds between calls", source.RateLimitSeconds)
+	// Write aircraft on a worker pool so slow writes don't delay polling
+	writer := db.NewAircraftWriter(repo, cfg.Database.GetWriteWorkers(), cfg.Database.GetWriteQueueSize())
+	writer.Start(ctx)
+	log.Printf("  %d write workers, queue of %d", cfg.Database.GetWriteWorkers(), writer.Stats().QueueCapacity)
 
 	// Start collector
 	collector := &Collector{
 		repo:              repo,
+		writer:            writer,
 		db:                database,
-		adsbClient:        adsbClient,
+		sources:           sources,
+		failover:          failover,
+		extraSources:      extraSources,
 		observer:          observer,
 		collectionRegions: collectionRegions,
+		fetchUnits:        buildFetchUnits(collectionRegions, updateInterval),
 		minAlt:            minAlt,
 		maxAlt:            maxAlt,
-		updateInterval:    time.Duration(cfg.ADSB.UpdateIntervalSeconds) * time.Second,
-		rateLimit:         time.Duration(source.RateLimitSeconds * float64(time.Second)),
+		updateInterval:    updateInterval,
 		regionStats:       make(map[string]*RegionStats),
+		alerts:            alertDispatcher,
+		passNotifier:      passNotifier,
+		watchRepo:         db.NewWatchRepository(database),
+		watchTracker:      alerts.NewWatchTracker(cfg.Alerts.GetWatchGone()),
+		cadence:           cadence,
+		statusRepo:        db.NewCollectorRepository(database),
+		terrain:           terrainChecker,
 	}
 
 	// Setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start collection loop in goroutine
+	// Start collection loop in goroutine. It runs on its own context so
+	// shutdown can stop it before the writer closes, leaving the writer's
+	// context to finish the queued writes.
+	runCtx, stopRun := context.WithCancel(ctx)
+	defer stopRun()
 	doneChan := make(chan struct{})
 	go func() {
 		defer func() {
@@ -136,16 +287,32 @@ func main() {
 							close(doneChan)
 						}
 					}()
-					collector.Run(ctx)
+					collector.Run(runCtx)
 					close(doneChan)
 				}()
 				return
 			}
 			close(doneChan)
 		}()
-		collector.Run(ctx)
+		collector.Run(runCtx)
 	}()
 
+	// Fetch flight plans in-process, sharing the database connection
+	var planWorker *flightplans.Worker
+	if flightplans.Enabled(cfg.FlightAware) {
+		provider, err := flightplans.NewFlightAwareProvider(database, cfg.FlightAware)
+		if err != nil {
+			log.Printf("⚠️  Flight plan fetching disabled: %v", err)
+		} else {
+			planWorker = flightplans.NewWorker(database, provider, cfg.FlightAware, observer)
+			if err := planWorker.Start(ctx); err != nil {
+				log.Fatalf("Failed to start flight plan worker: %v", err)
+			}
+			log.Println("\n✓ Fetching flight plans from FlightAware")
+			flightplans.LogSettings(cfg.FlightAware)
+		}
+	}
+
 	log.Println("\n===========================================")
 	log.Println("  Collector service started")
 	log.Println("  Initializing dataset...")
@@ -161,13 +328,47 @@ func main() {
 	}
 
 	log.Println("Shutting down gracefully...")
+	// Stop the collection loop so no cycle queues writes once the writer closes
+	stopRun()
+	<-doneChan
+	if planWorker != nil {
+		planWorker.Stop()
+		log.Println("✓ Flight plan worker stopped")
+	}
+	writer.Close()
+	if _, err := repo.FlushPositionHistory(context.Background()); err != nil {
+		log.Printf("Error storing position history: %v", err)
+	}
+	log.Println("✓ Queued aircraft stored")
+	if dropped := writer.Stats().Dropped; dropped > 0 {
+		log.Printf("⚠️  %d aircraft writes dropped on a full queue", dropped)
+	}
 	log.Println("✓ Collector service stopped")
 }
 
+// resolveRegionCenters looks up each region's center identifier in the
+// waypoints table. Unresolved regions keep their configured coordinates.
+func resolveRegionCenters(ctx context.Context, cfg *config.Config, database *db.DB) {
+	fpRepo := db.NewFlightPlanRepository(database)
+	err := cfg.ADSB.ResolveRegionCenters(func(identifier string) (float64, float64, bool, error) {
+		wp, err := fpRepo.GetWaypointByIdentifier(ctx, identifier)
+		if err != nil || wp == nil {
+			return 0, 0, false, err
+		}
+		log.Printf("✓ Region center %s resolved to %.4f°N, %.4f°W", identifier, wp.Latitude, wp.Longitude)
+		return wp.Latitude, wp.Longitude, true, nil
+	})
+	if err != nil {
+		log.Printf("⚠️  Could not resolve all region centers (using configured coordinates): %v", err)
+	}
+}
+
 // RegionStats tracks per-region collection statistics.
 type RegionStats struct {
 	Fetched      int
 	Stored       int
+	Errors       int
+	Latency      time.Duration
 	LastUpdate   time.Time
 	TotalUpdates int
 }
@@ -175,14 +376,24 @@ type RegionStats struct {
 // Collector manages the aircraft data collection process.
 type Collector struct {
 	repo              *db.AircraftRepository
+	writer            *db.AircraftWriter // Stores each cycle's aircraft in the background
 	db                *db.DB
-	adsbClient        *adsb.AirplanesLiveClient
+	sources           []*onlineSource       // Online sources in order of preference (empty if only local receivers are configured)
+	failover          *adsb.Failover        // Chooses which of sources is polled
+	extraSources      []*supplementalSource // Feeds merged into each update (dump978, ADS-C)
 	observer          coordinates.Observer
 	collectionRegions []config.CollectionRegion
+	fetchUnits        []*fetchUnit // API queries covering the enabled regions (large regions are tiled)
 	minAlt            float64
 	maxAlt            float64
 	updateInterval    time.Duration
-	rateLimit         time.Duration
+	alerts            *alerts.Dispatcher     // nil if alerts are disabled
+	passNotifier      *planner.PassNotifier  // nil without pass notification rules
+	watchRepo         *db.WatchRepository
+	watchTracker      *alerts.WatchTracker // Tells when watched aircraft reappear
+	cadence           *adsb.CadenceScheduler // nil for a fixed update interval
+	statusRepo        *db.CollectorRepository
+	terrain           *terrain.Checker // nil without terrain tiles
 
 	// Statistics
 	regionStats    map[string]*RegionStats
@@ -193,7 +404,13 @@ type Collector struct {
 
 // Run starts the collection loop.
 func (c *Collector) Run(ctx context.Context) {
-	ticker := time.NewTicker(c.updateInterval)
+	// With adaptive cadence, tick at the fastest region interval and let the
+	// scheduler decide which regions are due
+	tickInterval := c.updateInterval
+	if c.cadence != nil {
+		tickInterval = c.cadence.MinInterval()
+	}
+	ticker := time.NewTicker(tickInterval)
 	defer ticker.Stop()
 
 	// Do first update immediately
@@ -226,7 +443,7 @@ func (c *Collector) Run(ctx context.Context) {
 // update fetches aircraft data from all enabled regions and stores in database.
 func (c *Collector) update(ctx context.Context) {
 	// Nil check for critical components
-	if c == nil || c.repo == nil || c.db == nil || c.adsbClient == nil {
+	if c == nil || c.repo == nil || c.db == nil || (len(c.sources) == 0 && len(c.extraSources) == 0) {
 		log.Println("Error: Collector or critical components are nil, skipping update")
 		return
 	}
@@ -243,93 +460,615 @@ func (c *Collector) update(ctx context.Context) {
 	c.totalUpdates++
 
 	// Collect aircraft from all enabled regions
-	type aircraftWithRegion struct {
-		aircraft   adsb.Aircraft
-		regionName string
-	}
-	allAircraft := make(map[string]aircraftWithRegion) // ICAO -> Aircraft+Region (deduplication)
+	allAircraft := make(map[string]*regionReport) // ICAO -> merged report (deduplication)
 	regionCount := 0
 
-	for _, region := range c.collectionRegions {
-		if !region.Enabled {
-			continue
+	// Per-region results of this cycle, persisted for the dashboard
+	cycleStats := make(map[string]*db.CollectorStat)
+	statFor := func(name string) *db.CollectorStat {
+		if cycleStats[name] == nil {
+			cycleStats[name] = &db.CollectorStat{CycleAt: now, Region: name}
 		}
+		return cycleStats[name]
+	}
 
-		// Fetch aircraft for this region
-		aircraft, err := c.fetchRegion(ctx, region)
+	// What each data source reported, for comparing sources
+	tally := newSourceTally(now)
+
+	// While failed over, check whether the primary source has recovered
+	c.probePrimary(ctx, now)
+
+	// Outcome of this cycle on the active source: nil once any query succeeds
+	source := c.activeSource()
+	var cycleErr error
+	dueUnits := c.dueUnits(now)
+	for i, unit := range dueUnits {
+		region := unit.region
+		unit.lastFetch = now
+
+		// Fetch aircraft for this region (or tile)
+		fetchStart := time.Now()
+		aircraft, err := c.fetchRegion(ctx, source, unit.query())
+		latency := time.Since(fetchStart)
+		if !errors.Is(err, adsb.ErrCircuitOpen) {
+			tally.record(source.name, aircraft, latency, err)
+		}
+		if errors.Is(err, adsb.ErrCircuitOpen) {
+			log.Printf("⚠️  %s circuit breaker open until %s, skipping remaining regions",
+				source.name, source.breaker.Status().OpenUntil.Format("15:04:05"))
+			if regionCount == 0 {
+				cycleErr = err
+			}
+			break
+		}
 		if err != nil {
-			log.Printf("✗ Failed to fetch region %s after retries: %v (will retry in next update cycle)", region.Name, err)
+			if regionCount == 0 {
+				cycleErr = err
+			}
+			log.Printf("✗ Failed to fetch region %s after retries: %v (will retry in next update cycle)", unit.key, err)
+			st := statFor(region.Name)
+			st.Errors++
+			st.LatencyMs += int(latency.Milliseconds())
+			if c.cadence != nil {
+				c.cadence.Record(unit.key, adsb.ActivityNormal, now)
+			}
 			continue
 		}
 		
 		if len(aircraft) == 0 {
-			log.Printf("  ℹ Region %s: no aircraft found", region.Name)
+			log.Printf("  ℹ Region %s: no aircraft found", unit.key)
 		} else {
-			log.Printf("  ✓ Region %s: fetched %d aircraft", region.Name, len(aircraft))
+			log.Printf("  ✓ Region %s: fetched %d aircraft", unit.key, len(aircraft))
 		}
 
-		// Update region stats
+		// Adapt the region's polling interval to what was seen
+		if c.cadence != nil {
+			activity := c.classifyRegion(aircraft, now)
+			interval := c.cadence.Record(unit.key, activity, now)
+			log.Printf("    Region %s is %s, next poll in %v", unit.key, activity, interval)
+		}
+
+		// Update region stats (tiles accumulate into their parent region)
 		if c.regionStats[region.Name] == nil {
 			c.regionStats[region.Name] = &RegionStats{}
 		}
 		stats := c.regionStats[region.Name]
-		stats.Fetched = len(aircraft)
+		if !stats.LastUpdate.Equal(now) {
+			stats.Fetched = 0
+			stats.Latency = 0
+			stats.TotalUpdates++
+		}
+		stats.Fetched += len(aircraft)
+		stats.Latency += latency
 		stats.LastUpdate = now
-		stats.TotalUpdates++
 
-		// Merge into global collection (deduplicate by ICAO)
-		// If aircraft seen in multiple regions, use first region for now
-		// (could be enhanced to track multiple regions per aircraft)
+		st := statFor(region.Name)
+		st.Fetched += len(aircraft)
+		st.LatencyMs += int(latency.Milliseconds())
+
+		// Merge into global collection (deduplicate by ICAO). Aircraft in
+		// overlapping regions or tiles keep the best report.
 		for _, ac := range aircraft {
 			if ac.Latitude == 0 && ac.Longitude == 0 {
 				continue // Skip invalid positions
 			}
-			// Only store if not already seen (first region wins for deduplication)
-			if _, exists := allAircraft[ac.ICAO]; !exists {
-				allAircraft[ac.ICAO] = aircraftWithRegion{
-					aircraft:   ac,
-					regionName: region.Name,
-				}
-			}
+			mergeReport(allAircraft, ac, region.Name)
 		}
 
 		regionCount++
+		cycleErr = nil
 
 		// Rate limit between regions
-		if regionCount < len(c.collectionRegions) {
-			time.Sleep(c.rateLimit)
+		if i < len(dueUnits)-1 {
+			time.Sleep(source.rateLimit)
+		}
+	}
+	if len(dueUnits) > 0 {
+		if sw := c.failover.RecordCycle(cycleErr); sw != nil {
+			c.announceFailover(ctx, sw, now)
 		}
 	}
 
-	// Store deduplicated aircraft with region tracking
+	// Merge supplemental feeds (UAT 978 receivers, oceanic ADS-C). These are
+	// queried once around the observer rather than per region; each aircraft is
+	// assigned to the first enabled region containing it. A supplemental report
+	// replaces an existing report for the same aircraft only if it is better.
+	for _, src := range c.extraSources {
+		if !src.due(now) {
+			continue
+		}
+		src.lastFetch = now
+
+		fetchStart := time.Now()
+		aircraft, err := src.client.GetAircraft(
+			c.observer.Location.Latitude,
+			c.observer.Location.Longitude,
+			src.rangeNM,
+		)
+		latency := time.Since(fetchStart)
+		tally.record(src.name, aircraft, latency, err)
+		st := statFor(src.name)
+		st.LatencyMs += int(latency.Milliseconds())
+		if err != nil {
+			log.Printf("✗ Failed to read %s: %v", src.name, err)
+			st.Errors++
+			continue
+		}
+		st.Fetched += len(aircraft)
+
+		merged := 0
+		for _, ac := range aircraft {
+			if ac.Latitude == 0 && ac.Longitude == 0 {
+				continue
+			}
+			if mergeReport(allAircraft, ac, c.regionFor(ac, src.name)) {
+				merged++
+			}
+		}
+		log.Printf("  ✓ %s: %d aircraft (%d merged)", src.name, len(aircraft), merged)
+	}
+
+	// Raise alerts for emergency squawks
+	for _, acWithRegion := range allAircraft {
+		if acWithRegion.aircraft.IsEmergency() {
+			c.raiseEmergencyAlert(ctx, acWithRegion.aircraft, acWithRegion.regionName, now)
+		}
+	}
+
+	// Announce aircraft about to pass overhead
+	if c.passNotifier != nil {
+		aircraft := make([]adsb.Aircraft, 0, len(allAircraft))
+		for _, acWithRegion := range allAircraft {
+			aircraft = append(aircraft, acWithRegion.aircraft)
+		}
+		c.notifyUpcomingPasses(ctx, aircraft, now)
+	}
+
+	// Announce watched aircraft back in coverage
+	c.notifyWatchedAircraft(ctx, allAircraft, now)
+
+	// Store the positions written since the last cycle in one batch
+	if _, err := c.repo.FlushPositionHistory(ctx); err != nil {
+		log.Printf("Error storing position history: %v", err)
+	}
+
+	// Queue deduplicated aircraft for storage with region tracking. A full
+	// queue means the database is falling behind; those aircraft are stored
+	// next cycle.
 	stored := 0
 	for _, acWithRegion := range allAircraft {
-		if err := c.repo.UpsertAircraft(ctx, acWithRegion.aircraft, now, acWithRegion.regionName); err != nil {
-			log.Printf("Error storing aircraft %s: %v", acWithRegion.aircraft.ICAO, err)
+		if !c.writer.Enqueue(acWithRegion.aircraft, now, acWithRegion.seenIn()) {
 			continue
 		}
 		stored++
+		statFor(acWithRegion.regionName).Stored++
+	}
+	if writes := c.writer.Stats(); writes.QueueDepth > writes.QueueCapacity/2 {
+		log.Printf("⚠️  Database falling behind: %d of %d queued writes pending, %d dropped",
+			writes.QueueDepth, writes.QueueCapacity, writes.Dropped)
 	}
 
 	// Update region stats with stored count
-	for _, stats := range c.regionStats {
-		stats.Stored = stored // Simplified: all regions contribute to total
+	for name, stats := range c.regionStats {
+		if st := cycleStats[name]; st != nil {
+			stats.Stored = st.Stored
+			stats.Errors += st.Errors
+		}
 	}
 
-	// Update trackable status for all aircraft
+	// Update trackable status for all aircraft (those still queued are
+	// updated next cycle)
 	if err := c.repo.UpdateTrackableStatus(ctx, c.minAlt, c.maxAlt); err != nil {
 		log.Printf("Error updating trackable status: %v", err)
+	} else if c.terrain != nil {
+		c.updateTerrainBlocked(ctx)
 	}
 
 	c.lastUpdateTime = now
 	c.totalAircraft = len(allAircraft)
 
-	log.Printf("[%s] Update #%d: %d regions, %d unique aircraft, %d stored",
+	c.saveStatus(ctx, now)
+	c.saveCycleStats(ctx, cycleStats)
+	c.saveSourceStats(ctx, tally.results())
+
+	log.Printf("[%s] Update #%d: %d regions, %d unique aircraft, %d queued for storage",
 		now.Format("15:04:05"), c.totalUpdates, regionCount, len(allAircraft), stored)
 }
 
+// regionReport is the report kept for an aircraft in a collection cycle and
+// the regions that reported it.
+type regionReport struct {
+	aircraft   adsb.Aircraft
+	regionName string   // Region of the kept report
+	regions    []string // Every region that reported the aircraft
+}
+
+// seenIn returns the regions that reported the aircraft, the kept report's
+// region first.
+func (r *regionReport) seenIn() []string {
+	regions := []string{r.regionName}
+	for _, name := range r.regions {
+		if name != r.regionName {
+			regions = append(regions, name)
+		}
+	}
+	return regions
+}
+
+// mergeReport adds a region's report of an aircraft to the cycle's
+// reports. Reports of the same aircraft are merged with adsb.Merge, so the
+// freshest, most accurate position is kept regardless of which region
+// reported it first. Returns true if the report replaced the kept one.
+func mergeReport(reports map[string]*regionReport, ac adsb.Aircraft, region string) bool {
+	existing, exists := reports[ac.ICAO]
+	if !exists {
+		reports[ac.ICAO] = &regionReport{aircraft: ac, regionName: region, regions: []string{region}}
+		return true
+	}
+
+	better := adsb.Better(ac, existing.aircraft)
+	existing.aircraft = adsb.Merge(existing.aircraft, ac)
+	if !slices.Contains(existing.regions, region) {
+		existing.regions = append(existing.regions, region)
+	}
+	if better {
+		existing.regionName = region
+	}
+	return better
+}
+
+// fetchUnit is a single API query: a whole collection region, or one tile of
+// a region too large to query at once.
+type fetchUnit struct {
+	key       string                  // Scheduling key ("name" or "name [tile n/m]")
+	region    config.CollectionRegion // Parent region
+	tile      adsb.Tile               // Area covered by this query
+	interval  time.Duration           // Minimum time between polls (0 = every update)
+	lastFetch time.Time
+}
+
+// query returns the region to pass to the API for this unit.
+func (u *fetchUnit) query() config.CollectionRegion {
+	return config.CollectionRegion{
+		Name:      u.key,
+		Latitude:  u.tile.Latitude,
+		Longitude: u.tile.Longitude,
+		RadiusNM:  u.tile.RadiusNM,
+		Enabled:   u.region.Enabled,
+	}
+}
+
+// tileIntervalStepNM controls per-tile scheduling: each additional step of
+// distance from the region center adds one update interval between polls.
+const tileIntervalStepNM = 500.0
+
+// buildFetchUnits expands the enabled collection regions into API queries.
+// Regions larger than the API radius limit are tiled; outer tiles are polled
+// less often than the core, since distant aircraft take longer to matter.
+func buildFetchUnits(regions []config.CollectionRegion, updateInterval time.Duration) []*fetchUnit {
+	var units []*fetchUnit
+	for _, region := range regions {
+		if !region.Enabled {
+			continue
+		}
+
+		tiles := adsb.TileRegion(region.Latitude, region.Longitude, region.RadiusNM, adsb.MaxQueryRadiusNM)
+		if len(tiles) == 1 {
+			units = append(units, &fetchUnit{key: region.Name, region: region, tile: tiles[0]})
+			continue
+		}
+
+		for i, tile := range tiles {
+			steps := 1 + int(tile.OffsetNM/tileIntervalStepNM)
+			units = append(units, &fetchUnit{
+				key:      fmt.Sprintf("%s [tile %d/%d]", region.Name, i+1, len(tiles)),
+				region:   region,
+				tile:     tile,
+				interval: time.Duration(steps) * updateInterval,
+			})
+		}
+	}
+	return units
+}
+
+// saveStatus publishes the collector's health (including the active source
+// and its circuit breaker) for the web server's /api/v1/system/collector
+// endpoint.
+func (c *Collector) saveStatus(ctx context.Context, now time.Time) {
+	if c.statusRepo == nil {
+		return
+	}
+
+	writes := c.writer.Stats()
+	status := db.CollectorStatus{
+		BreakerState:       adsb.BreakerClosed.String(),
+		WriteQueueDepth:    writes.QueueDepth,
+		WriteQueueCapacity: writes.QueueCapacity,
+		WritesFailed:       writes.Failed,
+		WritesDropped:      writes.Dropped,
+		UpdatedAt:          now,
+	}
+	if source := c.activeSource(); source != nil {
+		breaker := source.breaker.Status()
+		status.BreakerState = breaker.State.String()
+		status.ConsecutiveFailures = breaker.ConsecutiveFailures
+		status.BreakerTrips = breaker.Trips
+		status.RetriesDenied = breaker.RetriesDenied
+		status.LastError = breaker.LastError
+		if !breaker.OpenUntil.IsZero() {
+			openUntil := breaker.OpenUntil.UTC()
+			status.OpenUntil = &openUntil
+		}
+
+		failover := c.failover.Status()
+		status.ActiveSource = failover.Active
+		status.PrimarySource = failover.Primary
+		status.Failovers = failover.Failovers
+		if !failover.FailedOverAt.IsZero() {
+			failedOverAt := failover.FailedOverAt.UTC()
+			status.FailedOverAt = &failedOverAt
+		}
+	}
+
+	if err := c.statusRepo.SaveStatus(ctx, status); err != nil {
+		log.Printf("Error saving collector status: %v", err)
+	}
+}
+
+// saveCycleStats persists this cycle's per-region results for charting.
+func (c *Collector) saveCycleStats(ctx context.Context, cycleStats map[string]*db.CollectorStat) {
+	if c.statusRepo == nil || len(cycleStats) == 0 {
+		return
+	}
+
+	stats := make([]db.CollectorStat, 0, len(cycleStats))
+	for _, st := range cycleStats {
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Region < stats[j].Region
+	})
+
+	if err := c.statusRepo.SaveCycleStats(ctx, stats); err != nil {
+		log.Printf("Error saving collector stats: %v", err)
+	}
+}
+
+// saveSourceStats persists this cycle's per-source results for comparing sources.
+func (c *Collector) saveSourceStats(ctx context.Context, stats []db.SourceStat) {
+	if c.statusRepo == nil || len(stats) == 0 {
+		return
+	}
+
+	if err := c.statusRepo.SaveSourceStats(ctx, stats); err != nil {
+		log.Printf("Error saving source stats: %v", err)
+	}
+}
+
+// sourceTally accumulates what each data source reported in one cycle.
+type sourceTally struct {
+	cycleAt time.Time
+	stats   map[string]*db.SourceStat
+	seen    map[string]map[string]bool // Source -> ICAO addresses it reported a position for
+	ageMs   map[string]float64         // Source -> summed position age
+}
+
+// newSourceTally starts the tally of the cycle starting at now.
+func newSourceTally(now time.Time) *sourceTally {
+	return &sourceTally{
+		cycleAt: now,
+		stats:   make(map[string]*db.SourceStat),
+		seen:    make(map[string]map[string]bool),
+		ageMs:   make(map[string]float64),
+	}
+}
+
+// record adds the result of one query of a source. Aircraft reported by
+// several queries of the same source (overlapping tiles) count once.
+func (t *sourceTally) record(source string, aircraft []adsb.Aircraft, latency time.Duration, err error) {
+	st := t.stats[source]
+	if st == nil {
+		st = &db.SourceStat{CycleAt: t.cycleAt, Source: source, PositionAges: *adsb.NewLatencyHistogram()}
+		t.stats[source] = st
+		t.seen[source] = make(map[string]bool)
+	}
+	st.LatencyMs += int(latency.Milliseconds())
+	if err != nil {
+		st.Errors++
+		return
+	}
+
+	fetched := time.Now().UTC()
+	for _, ac := range aircraft {
+		if (ac.Latitude == 0 && ac.Longitude == 0) || t.seen[source][ac.ICAO] {
+			continue
+		}
+		t.seen[source][ac.ICAO] = true
+		age := fetched.Sub(ac.LastSeen)
+		if age > 0 {
+			t.ageMs[source] += float64(age.Milliseconds())
+		}
+		st.PositionAges.Record(max(age, 0))
+	}
+}
+
+// results returns each source's statistics, with the aircraft no other
+// source reported this cycle counted as unique.
+func (t *sourceTally) results() []db.SourceStat {
+	stats := make([]db.SourceStat, 0, len(t.stats))
+	for source, st := range t.stats {
+		seen := t.seen[source]
+		st.Aircraft = len(seen)
+		if st.Aircraft > 0 {
+			st.PositionAgeMs = int(t.ageMs[source] / float64(st.Aircraft))
+		}
+		for icao := range seen {
+			unique := true
+			for other, otherSeen := range t.seen {
+				if other != source && otherSeen[icao] {
+					unique = false
+					break
+				}
+			}
+			if unique {
+				st.Unique++
+			}
+		}
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Source < stats[j].Source
+	})
+	return stats
+}
+
+// dueUnits returns the queries to run in this update.
+// Untiled regions are polled every update. Tiles are polled once their own
+// interval has elapsed, least recently fetched first, limited to what fits
+// in one update interval at the source rate limit. With adaptive cadence,
+// the scheduler's shared budget decides instead.
+func (c *Collector) dueUnits(now time.Time) []*fetchUnit {
+	source := c.activeSource()
+	if source == nil {
+		return nil
+	}
+
+	var untiled, tiles []*fetchUnit
+	for _, unit := range c.fetchUnits {
+		switch {
+		case unit.interval == 0:
+			untiled = append(untiled, unit)
+		case now.Sub(unit.lastFetch) >= unit.interval:
+			tiles = append(tiles, unit)
+		}
+	}
+	sort.SliceStable(tiles, func(i, j int) bool {
+		return tiles[i].lastFetch.Before(tiles[j].lastFetch)
+	})
+
+	if c.cadence != nil {
+		candidates := append(untiled, tiles...)
+		byKey := make(map[string]*fetchUnit, len(candidates))
+		keys := make([]string, 0, len(candidates))
+		for _, unit := range candidates {
+			byKey[unit.key] = unit
+			keys = append(keys, unit.key)
+		}
+
+		due := make([]*fetchUnit, 0, len(keys))
+		for _, key := range c.cadence.Due(keys, now) {
+			due = append(due, byKey[key])
+		}
+		return due
+	}
+
+	// Keep each update within its interval so tiles never cause a burst
+	budget := 1
+	if source.rateLimit > 0 {
+		budget = int(c.updateInterval / source.rateLimit)
+	}
+	budget -= len(untiled)
+	if budget < 1 {
+		budget = 1
+	}
+	if len(tiles) > budget {
+		tiles = tiles[:budget]
+	}
+
+	return append(untiled, tiles...)
+}
+
+const (
+	// approachRangeNM is the closest-approach distance at which an aircraft
+	// counts as approaching the observer for cadence purposes.
+	approachRangeNM = 25.0
+
+	// approachWindow is how soon the closest approach must happen.
+	approachWindow = 10 * time.Minute
+)
+
+// classifyRegion determines a region's activity from its fetched aircraft.
+// Aircraft currently within the telescope's altitude limits make a region
+// normal; aircraft on course to pass near the observer make it approaching.
+func (c *Collector) classifyRegion(aircraft []adsb.Aircraft, now time.Time) adsb.RegionActivity {
+	activity := adsb.ActivityIdle
+	for _, ac := range aircraft {
+		if ac.Altitude <= 0 || (ac.Latitude == 0 && ac.Longitude == 0) {
+			continue
+		}
+
+		pos := coordinates.Geographic{
+			Latitude:  ac.Latitude,
+			Longitude: ac.Longitude,
+			Altitude:  ac.Altitude.Meters(),
+		}
+
+		closest, timeToClosest, approaching := coordinates.EstimateTimeToClosestApproach(
+			c.observer.Location, pos, ac.GroundSpeed, ac.Track)
+		if approaching && closest <= approachRangeNM && timeToClosest <= approachWindow {
+			return adsb.ActivityApproaching
+		}
+
+		horiz := coordinates.GeographicToHorizontal(pos, c.observer, now)
+		if horiz.Altitude >= c.minAlt && horiz.Altitude <= c.maxAlt {
+			activity = adsb.ActivityNormal
+		}
+	}
+	return activity
+}
+
+// updateTerrainBlocked flags the trackable aircraft hidden from the observer
+// by terrain, so they drop out of the trackable lists.
+func (c *Collector) updateTerrainBlocked(ctx context.Context) {
+	aircraft, err := c.repo.GetVisibleAircraft(ctx)
+	if err != nil {
+		log.Printf("Error checking terrain: %v", err)
+		return
+	}
+
+	var blocked []string
+	for _, ac := range aircraft {
+		if !ac.IsTrackable(c.minAlt, c.maxAlt) {
+			continue
+		}
+		pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude, Altitude: ac.Altitude.Meters()}
+		if c.terrain.Blocked(c.observer.Location, pos, ac.Horizontal.Altitude) {
+			blocked = append(blocked, ac.ICAO)
+		}
+	}
+	if err := c.repo.SetTerrainBlocked(ctx, blocked); err != nil {
+		log.Printf("Error updating trackable status: %v", err)
+	}
+}
+
+// newCadenceScheduler builds the adaptive cadence scheduler.
+// The request budget defaults to one request per source rate limit period.
+func newCadenceScheduler(cfg config.CadenceConfig, updateInterval time.Duration, rateLimitSeconds float64) *adsb.CadenceScheduler {
+	minInterval := time.Duration(cfg.MinIntervalSeconds) * time.Second
+	if minInterval <= 0 || minInterval > updateInterval {
+		minInterval = updateInterval
+	}
+	idleInterval := time.Duration(cfg.IdleIntervalSeconds) * time.Second
+
+	budget := cfg.RequestsPerMinute
+	if budget <= 0 && rateLimitSeconds > 0 {
+		budget = 60.0 / rateLimitSeconds
+	}
+
+	log.Printf("✓ Adaptive cadence: %v (approaching) / %v (normal) / up to %v (idle), budget %.1f requests/min",
+		minInterval, updateInterval, idleInterval, budget)
+
+	return adsb.NewCadenceScheduler(adsb.CadenceConfig{
+		MinInterval:       minInterval,
+		BaseInterval:      updateInterval,
+		IdleInterval:      idleInterval,
+		RequestsPerMinute: budget,
+		Burst:             cfg.Burst,
+	})
+}
+
 // fetchRegion fetches aircraft from a single collection region with exponential backoff retry.
-func (c *Collector) fetchRegion(ctx context.Context, region config.CollectionRegion) ([]adsb.Aircraft, error) {
+func (c *Collector) fetchRegion(ctx context.Context, source *onlineSource, region config.CollectionRegion) ([]adsb.Aircraft, error) {
 	// Configure retry with exponential backoff
 	// Max 5 attempts with delays: 2s, 4s, 8s, 16s, 32s
 	retryConfig := adsb.RetryConfig{
@@ -338,11 +1077,12 @@ func (c *Collector) fetchRegion(ctx context.Context, region config.CollectionReg
 		MaxDelay:          32 * time.Second,
 		Multiplier:        2.0, // Exponential: 2s, 4s, 8s, 16s, 32s
 		RespectRetryAfter: true, // Respect API's Retry-After header
+		Breaker:           source.breaker,
 	}
 
 	// Fetch with retry
 	aircraft, err := adsb.RetryWithBackoffResult(ctx, retryConfig, func() ([]adsb.Aircraft, error) {
-		return c.adsbClient.GetAircraft(
+		return source.client.GetAircraft(
 			region.Latitude,
 			region.Longitude,
 			region.RadiusNM,
@@ -355,6 +1095,207 @@ func (c *Collector) fetchRegion(ctx context.Context, region config.CollectionReg
 	return aircraft, nil
 }
 
+// onlineSource is an API polled per collection region.
+type onlineSource struct {
+	name      string
+	client    *adsb.AirplanesLiveClient
+	rateLimit time.Duration        // Minimum time between queries
+	breaker   *adsb.CircuitBreaker // Shared across regions to stop retry storms during API outages
+}
+
+// activeSource returns the online source to poll, or nil if only local
+// receivers are configured.
+func (c *Collector) activeSource() *onlineSource {
+	if len(c.sources) == 0 {
+		return nil
+	}
+	return c.sources[c.failover.Active()]
+}
+
+// probePrimary queries the primary source once while failed over, and fails
+// back to it if it answers. Probes are limited to the failover probe interval.
+func (c *Collector) probePrimary(ctx context.Context, now time.Time) {
+	if len(c.fetchUnits) == 0 || !c.failover.ProbeDue() {
+		return
+	}
+
+	primary := c.sources[0]
+	_, err := adsb.RetryWithBackoffResult(ctx, adsb.RetryConfig{Breaker: primary.breaker}, func() ([]adsb.Aircraft, error) {
+		region := c.fetchUnits[0].query()
+		return primary.client.GetAircraft(region.Latitude, region.Longitude, region.RadiusNM)
+	})
+	if err != nil {
+		log.Printf("  ℹ %s still failing: %v", primary.name, err)
+	}
+	if sw := c.failover.RecordProbe(err); sw != nil {
+		c.announceFailover(ctx, sw, now)
+	}
+}
+
+// announceFailover logs a change of active source and alerts the operator.
+func (c *Collector) announceFailover(ctx context.Context, sw *adsb.FailoverSwitch, now time.Time) {
+	var alert alerts.Alert
+	if sw.Recovered {
+		alert = alerts.NewSourceRecoveredAlert(sw.To, sw.Downtime, now)
+		log.Printf("✓ FAILBACK: %s", alert.Description)
+	} else {
+		alert = alerts.NewSourceFailoverAlert(sw.From, sw.To, sw.Reason, now)
+		log.Printf("⚠️  FAILOVER: %s", alert.Description)
+	}
+
+	if c.alerts == nil {
+		return
+	}
+	if err := c.alerts.Dispatch(ctx, alert); err != nil {
+		log.Printf("⚠️  Failed to deliver failover alert: %v", err)
+	}
+}
+
+// supplementalSource is a feed merged into the primary per-region collection.
+type supplementalSource struct {
+	name      string
+	client    adsb.DataSource
+	rangeNM   float64       // Radius around the observer to query
+	interval  time.Duration // Minimum time between queries (0 = every update)
+	lastFetch time.Time
+}
+
+// due reports whether the source should be queried in this update.
+func (s *supplementalSource) due(now time.Time) bool {
+	return s.interval == 0 || now.Sub(s.lastFetch) >= s.interval
+}
+
+const (
+	// localSourceRangeNM is the radius around the observer used when reading local receivers.
+	// Generous enough to cover any UAT reception range.
+	localSourceRangeNM = 300.0
+
+	// oceanicSourceRangeNM matches the maximum radar mode radius.
+	oceanicSourceRangeNM = 2500.0
+)
+
+// regionFor returns the name of the first enabled collection region containing
+// the aircraft, or fallback if none does.
+func (c *Collector) regionFor(ac adsb.Aircraft, fallback string) string {
+	pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+	for _, region := range c.collectionRegions {
+		if !region.Enabled {
+			continue
+		}
+		center := coordinates.Geographic{Latitude: region.Latitude, Longitude: region.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) <= region.RadiusNM {
+			return region.Name
+		}
+	}
+	return fallback
+}
+
+// dump978URL returns the skyaware978 web root for a dump978 source.
+// Uses BaseURL if set, otherwise builds it from LocalHost/LocalPort.
+func dump978URL(src config.ADSBSource) string {
+	if src.BaseURL != "" {
+		return src.BaseURL
+	}
+	host := src.LocalHost
+	if host == "" {
+		host = "localhost"
+	}
+	port := src.LocalPort
+	if port == 0 {
+		port = 80
+	}
+	return fmt.Sprintf("http://%s:%d/skyaware978", host, port)
+}
+
+// raiseEmergencyAlert logs an emergency squawk and dispatches it to the
+// configured notifiers (duplicates are suppressed by the dispatcher).
+func (c *Collector) raiseEmergencyAlert(ctx context.Context, ac adsb.Aircraft, regionName string, now time.Time) {
+	if c.alerts == nil {
+		return
+	}
+
+	sent, err := c.alerts.ProcessAircraft(ctx, ac, regionName, now)
+	if sent {
+		log.Printf("🚨 EMERGENCY: %s (%s) squawking %s - %s in %s at %.0f ft",
+			ac.Callsign, ac.ICAO, ac.Squawk, adsb.EmergencyDescription(ac.Squawk), regionName, ac.Altitude)
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to deliver emergency alert: %v", err)
+	}
+}
+
+// notifyUpcomingPasses announces aircraft that are about to reach a pass
+// notification rule's elevation.
+func (c *Collector) notifyUpcomingPasses(ctx context.Context, aircraft []adsb.Aircraft, now time.Time) {
+	for _, up := range c.passNotifier.Check(aircraft, now) {
+		alert := alerts.NewUpcomingPassAlert(up.Aircraft, up.Rule, up.MinElevation, up.Pass, now)
+		log.Printf("🔭 PASS (%s): %s", up.Rule, alert.Description)
+		if err := c.alerts.Dispatch(ctx, alert); err != nil {
+			log.Printf("⚠️  Failed to deliver pass notification: %v", err)
+		}
+	}
+}
+
+// notifyWatchedAircraft announces aircraft on users' watch lists that have
+// reappeared in coverage. They are logged even with alerts disabled.
+func (c *Collector) notifyWatchedAircraft(ctx context.Context, allAircraft map[string]*regionReport, now time.Time) {
+	watchList, err := c.watchRepo.All(ctx)
+	if err != nil {
+		log.Printf("Error loading watch lists: %v", err)
+		return
+	}
+	if len(watchList) == 0 {
+		return
+	}
+
+	for _, report := range allAircraft {
+		matches := watchList.Match(report.aircraft)
+		if len(matches) == 0 || !c.watchTracker.Seen(report.aircraft.ICAO, now) {
+			continue
+		}
+
+		var watchers []string
+		for _, w := range matches {
+			if !slices.Contains(watchers, w.Username) {
+				watchers = append(watchers, w.Username)
+			}
+		}
+
+		alert := alerts.NewWatchedAircraftAlert(report.aircraft, report.regionName, watchers, now)
+		log.Printf("⭐ WATCHED (%s): %s in %s", strings.Join(watchers, ", "), alert.Description, report.regionName)
+		if c.alerts == nil {
+			continue
+		}
+		if err := c.alerts.Dispatch(ctx, alert); err != nil {
+			log.Printf("⚠️  Failed to deliver watched aircraft notification: %v", err)
+		}
+	}
+}
+
+// newAlertDispatcher creates the emergency alert dispatcher from configuration.
+// Returns nil if alerts are disabled. With no webhook or MQTT broker configured,
+// emergencies are still logged by the collector.
+func newAlertDispatcher(cfg config.AlertsConfig) *alerts.Dispatcher {
+	if !cfg.Enabled {
+		log.Println("  Emergency alerts: disabled")
+		return nil
+	}
+
+	notifiers := alerts.NewNotifiers(cfg)
+	if cfg.WebhookURL != "" {
+		log.Printf("  Emergency alerts: webhook %s", cfg.WebhookURL)
+	}
+	if cfg.MQTTBroker != "" {
+		log.Printf("  Emergency alerts: MQTT %s (topic %s)", cfg.MQTTBroker, cfg.MQTTTopic)
+	}
+	if len(notifiers) == 0 {
+		log.Println("  Emergency alerts: log only")
+	}
+
+	repeat := time.Duration(cfg.RepeatIntervalMinutes) * time.Minute
+	return alerts.NewDispatcher(repeat, notifiers...)
+}
+
 // cleanup removes stale aircraft and old position history.
 func (c *Collector) cleanup(ctx context.Context) {
 	// Nil check
@@ -376,6 +1317,15 @@ func (c *Collector) cleanup(ctx context.Context) {
 		return
 	}
 
+	// Forget emergencies that ended long ago so a recurrence alerts again
+	if c.alerts != nil {
+		c.alerts.Prune(time.Now().UTC(), time.Hour)
+	}
+	if c.passNotifier != nil {
+		c.passNotifier.Prune(time.Now().UTC())
+	}
+	c.watchTracker.Prune(time.Now().UTC())
+
 	log.Println("✓ Cleanup completed")
 }
 
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/parquet"
+)
+
+// csvTimeFormat is used for timestamps in CSV output (UTC). Unlike RFC 3339
+// it is recognised as a date by Excel as well as pandas.
+const csvTimeFormat = "2006-01-02 15:04:05.000"
+
+// rowWriter writes exported rows in one output format.
+type rowWriter interface {
+	Write(values []interface{}) error
+	Close() error
+}
+
+// main dumps aircraft positions, sessions, flight plans or the telescope
+// tracking log for a time range to CSV or Parquet, for analysis in pandas,
+// Excel and similar tools.
+//
+// Example:
+//
+//	export-data -dataset positions -from 2025-06-01 -to 2025-06-02 -icao A1B2C3 -format parquet -out positions.parquet
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	dataset := flag.String("dataset", "positions", "Dataset to export: "+strings.Join(db.ExportDatasets(), ", "))
+	format := flag.String("format", "csv", "Output format: csv or parquet")
+	out := flag.String("out", "-", "Output file (- for stdout)")
+	from := flag.String("from", "", "Start of time range (RFC 3339 or YYYY-MM-DD, default: 24 hours ago)")
+	to := flag.String("to", "", "End of time range, exclusive (RFC 3339 or YYYY-MM-DD, default: now)")
+	icaos := flag.String("icao", "", "Comma-separated ICAO addresses to include")
+	callsign := flag.String("callsign", "", "Callsign prefix to include (e.g. UAL)")
+	minAlt := flag.Float64("min-alt", 0, "Minimum aircraft altitude in feet")
+	maxAlt := flag.Float64("max-alt", 0, "Maximum aircraft altitude in feet")
+	maxRange := flag.Float64("max-range", 0, "Maximum range from the observer in NM")
+	flag.Parse()
+
+	columns, err := db.ExportColumns(*dataset)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *format != "csv" && *format != "parquet" {
+		log.Fatalf("Unknown format %q (expected csv or parquet)", *format)
+	}
+
+	now := time.Now().UTC()
+	filter := db.ExportFilter{
+		From:           now.Add(-24 * time.Hour),
+		To:             now,
+		CallsignPrefix: strings.TrimSpace(*callsign),
+		MinAltitudeFt:  *minAlt,
+		MaxAltitudeFt:  *maxAlt,
+		MaxRangeNM:     *maxRange,
+	}
+	if *from != "" {
+		if filter.From, err = parseTime(*from); err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+	}
+	if *to != "" {
+		if filter.To, err = parseTime(*to); err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+	}
+	if !filter.To.After(filter.From) {
+		log.Fatal("-to must be after -from")
+	}
+	for _, icao := range strings.Split(*icaos, ",") {
+		if icao = strings.TrimSpace(icao); icao != "" {
+			filter.ICAOs = append(filter.ICAOs, icao)
+		}
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	var output io.Writer = os.Stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	var writer rowWriter
+	if *format == "parquet" {
+		writer, err = newParquetWriter(output, columns)
+	} else {
+		writer, err = newCSVWriter(output, columns)
+	}
+	if err != nil {
+		log.Fatalf("Failed to start %s output: %v", *format, err)
+	}
+
+	log.Printf("Exporting %s from %s to %s...", *dataset,
+		filter.From.Format(time.RFC3339), filter.To.Format(time.RFC3339))
+
+	count := 0
+	err = database.Export(context.Background(), *dataset, filter, func(values []interface{}) error {
+		count++
+		return writer.Write(values)
+	})
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("Failed to finish %s output: %v", *format, err)
+	}
+
+	log.Printf("✓ Exported %d rows", count)
+}
+
+// parseTime parses an RFC 3339 time or a YYYY-MM-DD date (midnight UTC).
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 time or YYYY-MM-DD date", s)
+	}
+	return t, nil
+}
+
+// csvWriter writes rows as CSV with a header line.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// newCSVWriter writes the header and returns the writer.
+func newCSVWriter(w io.Writer, columns []db.ExportColumn) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, c := range columns {
+		cw.record[i] = c.Name
+	}
+	return cw, cw.w.Write(cw.record)
+}
+
+// Write writes one row. NULL values are written as empty fields.
+func (cw *csvWriter) Write(values []interface{}) error {
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+			cw.record[i] = ""
+		case float64:
+			cw.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int64:
+			cw.record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			cw.record[i] = strconv.FormatBool(v)
+		case time.Time:
+			cw.record[i] = v.UTC().Format(csvTimeFormat)
+		case string:
+			cw.record[i] = v
+		default:
+			cw.record[i] = fmt.Sprint(v)
+		}
+	}
+	return cw.w.Write(cw.record)
+}
+
+// Close flushes buffered output.
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// newParquetWriter starts a Parquet file with the dataset's columns.
+func newParquetWriter(w io.Writer, columns []db.ExportColumn) (*parquet.Writer, error) {
+	schema := make([]parquet.Column, len(columns))
+	for i, c := range columns {
+		schema[i] = parquet.Column{Name: c.Name}
+		switch c.Kind {
+		case db.ExportFloat:
+			schema[i].Type = parquet.Double
+		case db.ExportInt:
+			schema[i].Type = parquet.Int64
+		case db.ExportBool:
+			schema[i].Type = parquet.Bool
+		case db.ExportTime:
+			schema[i].Type = parquet.Timestamp
+		default:
+			schema[i].Type = parquet.String
+		}
+	}
+	return parquet.NewWriter(w, schema)
+}
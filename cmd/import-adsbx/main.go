@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// ADS-B Exchange History Importer
+// Backfills aircraft position history from ADS-B Exchange globe_history
+// trace files, so prediction algorithms can be tested against large real
+// datasets.
+//
+// Daily archives are laid out as:
+//
+//	globe_history/YYYY/MM/DD/traces/<last two hex digits>/trace_full_<icao>.json
+//
+// Files are usually gzip compressed despite the .json extension; both forms
+// are accepted. Point -path at a single file, a day directory or a whole
+// archive.
+
+// regionName is recorded as the collection region of imported aircraft
+const regionName = "adsbx-history"
+
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	path := flag.String("path", "", "Trace file or directory of trace files to import")
+	maxRange := flag.Float64("max-range", -1, "Only import positions within this many NM of the observer (0 for all, default: search radius)")
+	icaos := flag.String("icao", "", "Comma-separated ICAO addresses to import (default: all)")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("-path is required")
+	}
+
+	wanted := make(map[string]bool)
+	for _, icao := range strings.Split(*icaos, ",") {
+		if icao = strings.TrimSpace(icao); icao != "" {
+			wanted[strings.ToLower(icao)] = true
+		}
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if *maxRange < 0 {
+		*maxRange = cfg.ADSB.SearchRadiusNM
+	}
+
+	log.Println("Connecting to database...")
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if err := database.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  cfg.Observer.Latitude,
+			Longitude: cfg.Observer.Longitude,
+			Altitude:  cfg.Observer.Elevation,
+		},
+		Timezone: cfg.Observer.TimeZone,
+	}
+	repo := db.NewAircraftRepository(database, observer)
+
+	if *maxRange > 0 {
+		log.Printf("Importing positions within %.0f NM of %.4f, %.4f", *maxRange,
+			observer.Location.Latitude, observer.Location.Longitude)
+	}
+
+	var files, aircraft, positions, failed int
+	err = filepath.WalkDir(*path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isTraceFile(d.Name()) {
+			return nil
+		}
+		files++
+
+		n, err := importFile(ctx, repo, file, observer, *maxRange, wanted)
+		if err != nil {
+			log.Printf("Warning: %s: %v", file, err)
+			failed++
+			return nil
+		}
+		if n > 0 {
+			aircraft++
+			positions += n
+		}
+		if files%1000 == 0 {
+			log.Printf("  %d files, %d aircraft, %d positions...", files, aircraft, positions)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *path, err)
+	}
+
+	log.Printf("✓ Imported %d positions for %d aircraft from %d files (%d failed)",
+		positions, aircraft, files, failed)
+}
+
+// isTraceFile reports whether a file name looks like a trace file.
+func isTraceFile(name string) bool {
+	return strings.HasPrefix(name, "trace_full_") &&
+		(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz"))
+}
+
+// importFile imports the positions from one trace file, returning how many
+// were stored.
+func importFile(
+	ctx context.Context,
+	repo *db.AircraftRepository,
+	path string,
+	observer coordinates.Observer,
+	maxRange float64,
+	wanted map[string]bool,
+) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	trace, err := adsb.ParseTrace(f)
+	if err != nil {
+		return 0, err
+	}
+	if len(wanted) > 0 && !wanted[trace.ICAO] {
+		return 0, nil
+	}
+
+	positions := trace.Positions
+	if maxRange > 0 {
+		positions = positions[:0]
+		for _, p := range trace.Positions {
+			pos := coordinates.Geographic{Latitude: p.Latitude, Longitude: p.Longitude}
+			if coordinates.DistanceNauticalMiles(observer.Location, pos) <= maxRange {
+				positions = append(positions, p)
+			}
+		}
+	}
+
+	n, err := repo.ImportPositions(ctx, positions, regionName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import %s: %w", trace.ICAO, err)
+	}
+	return n, nil
+}
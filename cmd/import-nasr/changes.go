@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+)
+
+// changeSet holds the record hashes already in a table, keyed by the
+// table's unique key.
+type changeSet struct {
+	hashes map[string]string
+}
+
+// changed reports whether a record differs from the stored one and must be
+// written. A nil change set (a full import) treats every record as changed.
+func (c *changeSet) changed(key, hash string) bool {
+	return c == nil || c.hashes[key] != hash
+}
+
+// loadWaypointHashes reads the record hashes of the waypoints table.
+func (i *NASRImporter) loadWaypointHashes(ctx context.Context) (*changeSet, error) {
+	hashes, err := db.NewNavdataRepository(i.db).WaypointHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &changeSet{hashes: hashes}, nil
+}
+
+// loadAirwayHashes reads the record hashes of the airways table.
+func (i *NASRImporter) loadAirwayHashes(ctx context.Context) (*changeSet, error) {
+	hashes, err := db.NewNavdataRepository(i.db).AirwayHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &changeSet{hashes: hashes}, nil
+}
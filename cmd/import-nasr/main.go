@@ -12,6 +12,7 @@ import (
 
 	"github.com/unklstewy/ads-bscope/internal/db"
 	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
 )
 
 // NASR Data Importer
@@ -24,11 +25,17 @@ import (
 // - FIX.txt (Navigation fixes)
 // - NAV.txt (VORs and NDBs)
 // - AWY.txt (Airways)
+// - ARB.txt (ARTCC boundaries)
 // - APT.txt (Airports - optional, for reference)
+//
+// Each record is stored with a hash of its fields, and a re-import only
+// writes records whose hash has changed since the last cycle. Use --full to
+// rewrite every record.
 
 func main() {
 	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
 	nasrDir := flag.String("nasr-dir", "data/nasr", "Directory containing NASR data files")
+	full := flag.Bool("full", false, "Rewrite every record, not only those changed since the last import")
 	flag.Parse()
 
 	log.Println("===========================================")
@@ -50,18 +57,30 @@ func main() {
 	defer database.Close()
 	log.Println("✓ Database connected")
 
-	// Initialize schema
+	// Apply pending schema migrations
 	ctx := context.Background()
-	if err := database.InitSchema(ctx); err != nil {
-		log.Fatalf("Failed to initialize schema: %v", err)
+	if err := database.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
 	}
-	log.Println("✓ Schema initialized")
+	log.Println("✓ Schema up to date")
 
 	importer := &NASRImporter{
 		db:      database,
 		nasrDir: *nasrDir,
 	}
 
+	// Hashes of the records already imported, to skip unchanged ones
+	if !*full {
+		if importer.waypoints, err = importer.loadWaypointHashes(ctx); err != nil {
+			log.Fatalf("Failed to load existing waypoints: %v", err)
+		}
+		if importer.airways, err = importer.loadAirwayHashes(ctx); err != nil {
+			log.Fatalf("Failed to load existing airways: %v", err)
+		}
+		log.Printf("✓ %d waypoints and %d airway segments already imported",
+			len(importer.waypoints.hashes), len(importer.airways.hashes))
+	}
+
 	// Import airports first (they may be referenced by waypoints)
 	log.Println("\n===========================================")
 	log.Println("Importing Airports")
@@ -105,6 +124,18 @@ func main() {
 		log.Printf("✓ Imported %d airway segments", awyCount)
 	}
 
+	// Import ARTCC boundaries
+	log.Println("\n===========================================")
+	log.Println("Importing ARTCC Boundaries")
+	log.Println("===========================================")
+
+	arbCount, err := importer.ImportBoundaries(ctx, *full)
+	if err != nil {
+		log.Printf("Warning: Failed to import ARTCC boundaries: %v", err)
+	} else {
+		log.Printf("✓ Imported %d ARTCC boundaries", arbCount)
+	}
+
 	// Summary
 	log.Println("\n===========================================")
 	log.Println("Import Complete")
@@ -112,12 +143,22 @@ func main() {
 	log.Printf("Total airports: %d", aptCount)
 	log.Printf("Total waypoints: %d", fixCount+navCount)
 	log.Printf("Total airway segments: %d", awyCount)
+	log.Printf("Total ARTCC boundaries: %d", arbCount)
+	log.Printf("Unchanged records skipped: %d", importer.skipped)
 }
 
 // NASRImporter handles importing NASR data files.
 type NASRImporter struct {
 	db      *db.DB
 	nasrDir string
+
+	// waypoints and airways are the hashes of the records already in the
+	// database; nil for a full import
+	waypoints *changeSet
+	airways   *changeSet
+
+	// skipped counts records left unwritten because they were unchanged
+	skipped int
 }
 
 // ImportAirports imports airports from APT_BASE.csv (OurAirports format).
@@ -155,14 +196,18 @@ func (i *NASRImporter) ImportAirports(ctx context.Context) (int, error) {
 		name := strings.TrimSpace(fields[3])
 		latStr := strings.TrimSpace(fields[4])
 		lonStr := strings.TrimSpace(fields[5])
+		country := strings.TrimSpace(fields[8])
 		region := strings.TrimSpace(fields[9])
 
-		// Filter: only import airports (not heliports, seaplane bases, etc.)
-		// and prioritize US airports
+		// Filter: only import US airports (not heliports, seaplane bases,
+		// etc.); import-navdata imports the rest of the world
 		if apt_type != "small_airport" && apt_type != "medium_airport" &&
 			apt_type != "large_airport" {
 			continue
 		}
+		if country != "US" {
+			continue
+		}
 
 		// Parse coordinates
 		lat, err := strconv.ParseFloat(latStr, 64)
@@ -182,15 +227,22 @@ func (i *NASRImporter) ImportAirports(ctx context.Context) (int, error) {
 			regionCode = region[:2]
 		}
 
+		hash := navdata.RecordHash(ident, name, lat, lon, "airport", regionCode)
+		if !i.waypoints.changed(db.WaypointKey(ident, regionCode), hash) {
+			i.skipped++
+			continue
+		}
+
 		// Insert airport as waypoint with type "airport"
 		_, err = i.db.ExecContext(ctx,
-			`INSERT INTO waypoints (identifier, name, latitude, longitude, type, region)
-			 VALUES ($1, $2, $3, $4, $5, $6)
+			`INSERT INTO waypoints (identifier, name, latitude, longitude, type, region, record_hash)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
 			 ON CONFLICT (identifier, region) DO UPDATE SET
 			 name = EXCLUDED.name,
 			 latitude = EXCLUDED.latitude,
-			 longitude = EXCLUDED.longitude`,
-			ident, name, lat, lon, "airport", regionCode,
+			 longitude = EXCLUDED.longitude,
+			 record_hash = EXCLUDED.record_hash`,
+			ident, name, lat, lon, "airport", regionCode, hash,
 		)
 		if err != nil {
 			log.Printf("Warning: Failed to insert airport %s: %v", ident, err)
@@ -266,23 +318,30 @@ func (i *NASRImporter) ImportFixes(ctx context.Context) (int, error) {
 		lonStr := strings.TrimSpace(line[80:94])
 
 		// Parse lat/lon (format: DD-MM-SS.SSSH where H is N/S/E/W)
-		lat, err := parseLatLon(latStr)
+		lat, err := navdata.ParseDMS(latStr)
 		if err != nil {
 			continue
 		}
-		lon, err := parseLatLon(lonStr)
+		lon, err := navdata.ParseDMS(lonStr)
 		if err != nil {
 			continue
 		}
 
+		hash := navdata.RecordHash(identifier, lat, lon, "fix", region)
+		if !i.waypoints.changed(db.WaypointKey(identifier, region), hash) {
+			i.skipped++
+			continue
+		}
+
 		// Insert waypoint
 		_, err = i.db.ExecContext(ctx,
-			`INSERT INTO waypoints (identifier, latitude, longitude, type, region)
-			 VALUES ($1, $2, $3, $4, $5)
+			`INSERT INTO waypoints (identifier, latitude, longitude, type, region, record_hash)
+			 VALUES ($1, $2, $3, $4, $5, $6)
 			 ON CONFLICT (identifier, region) DO UPDATE SET
 			 latitude = EXCLUDED.latitude,
-			 longitude = EXCLUDED.longitude`,
-			identifier, lat, lon, "fix", region,
+			 longitude = EXCLUDED.longitude,
+			 record_hash = EXCLUDED.record_hash`,
+			identifier, lat, lon, "fix", region, hash,
 		)
 		if err != nil {
 			log.Printf("Warning: Failed to insert fix %s: %v", identifier, err)
@@ -329,11 +388,11 @@ func (i *NASRImporter) ImportNavaids(ctx context.Context) (int, error) {
 		lonStr := strings.TrimSpace(line[396:410])
 
 		// Parse lat/lon
-		lat, err := parseLatLon(latStr)
+		lat, err := navdata.ParseDMS(latStr)
 		if err != nil {
 			continue
 		}
-		lon, err := parseLatLon(lonStr)
+		lon, err := navdata.ParseDMS(lonStr)
 		if err != nil {
 			continue
 		}
@@ -346,15 +405,22 @@ func (i *NASRImporter) ImportNavaids(ctx context.Context) (int, error) {
 			wpType = "tacan"
 		}
 
+		hash := navdata.RecordHash(identifier, name, lat, lon, wpType, "US")
+		if !i.waypoints.changed(db.WaypointKey(identifier, "US"), hash) {
+			i.skipped++
+			continue
+		}
+
 		// Insert waypoint
 		_, err = i.db.ExecContext(ctx,
-			`INSERT INTO waypoints (identifier, name, latitude, longitude, type, region)
-			 VALUES ($1, $2, $3, $4, $5, $6)
+			`INSERT INTO waypoints (identifier, name, latitude, longitude, type, region, record_hash)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
 			 ON CONFLICT (identifier, region) DO UPDATE SET
 			 name = EXCLUDED.name,
 			 latitude = EXCLUDED.latitude,
-			 longitude = EXCLUDED.longitude`,
-			identifier, name, lat, lon, wpType, "US",
+			 longitude = EXCLUDED.longitude,
+			 record_hash = EXCLUDED.record_hash`,
+			identifier, name, lat, lon, wpType, "US", hash,
 		)
 		if err != nil {
 			log.Printf("Warning: Failed to insert navaid %s: %v", identifier, err)
@@ -379,6 +445,12 @@ func (i *NASRImporter) ImportAirways(ctx context.Context) (int, error) {
 	}
 	defer file.Close()
 
+	// Resolve waypoints from memory rather than a query per segment
+	waypointIDs, err := db.NewNavdataRepository(i.db).WaypointIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	count := 0
 	scanner := bufio.NewScanner(file)
 
@@ -413,25 +485,26 @@ func (i *NASRImporter) ImportAirways(ctx context.Context) (int, error) {
 			awyType = "rnav"
 		}
 
-		// Get waypoint ID from database
-		var waypointDBID int
-		err = i.db.QueryRowContext(ctx,
-			`SELECT id FROM waypoints WHERE identifier = $1 LIMIT 1`,
-			waypointID,
-		).Scan(&waypointDBID)
-
-		if err != nil {
+		waypointDBID, ok := waypointIDs[waypointID]
+		if !ok {
 			// Waypoint not found - skip this airway segment
 			continue
 		}
 
+		hash := navdata.RecordHash(airwayID, awyType, sequence, waypointDBID, "bidirectional")
+		if !i.airways.changed(db.AirwayKey(airwayID, sequence), hash) {
+			i.skipped++
+			continue
+		}
+
 		// Insert airway segment
 		_, err = i.db.ExecContext(ctx,
-			`INSERT INTO airways (identifier, type, sequence, waypoint_id, direction)
-			 VALUES ($1, $2, $3, $4, $5)
+			`INSERT INTO airways (identifier, type, sequence, waypoint_id, direction, record_hash)
+			 VALUES ($1, $2, $3, $4, $5, $6)
 			 ON CONFLICT (identifier, sequence) DO UPDATE SET
-			 waypoint_id = EXCLUDED.waypoint_id`,
-			airwayID, awyType, sequence, waypointDBID, "bidirectional",
+			 waypoint_id = EXCLUDED.waypoint_id,
+			 record_hash = EXCLUDED.record_hash`,
+			airwayID, awyType, sequence, waypointDBID, "bidirectional", hash,
 		)
 		if err != nil {
 			log.Printf("Warning: Failed to insert airway %s seq %d: %v", airwayID, sequence, err)
@@ -447,45 +520,46 @@ func (i *NASRImporter) ImportAirways(ctx context.Context) (int, error) {
 	return count, scanner.Err()
 }
 
-// parseLatLon parses NASR lat/lon format: DD-MM-SS.SSSH (where H is N/S/E/W).
-func parseLatLon(s string) (float64, error) {
-	s = strings.TrimSpace(s)
-	if len(s) < 11 {
-		return 0, fmt.Errorf("invalid lat/lon format: %s", s)
-	}
-
-	// Extract hemisphere
-	hemisphere := s[len(s)-1:]
-	s = s[:len(s)-1]
-
-	// Parse DD-MM-SS.SSS
-	parts := strings.Split(s, "-")
-	if len(parts) != 3 {
-		return 0, fmt.Errorf("invalid lat/lon parts: %s", s)
-	}
+// nasrSource is the airspaces table's source for NASR airspaces
+const nasrSource = "nasr"
 
-	degrees, err := strconv.ParseFloat(parts[0], 64)
+// ImportBoundaries imports the low and high ARTCC boundaries from ARB.txt
+// into the airspaces table. Unless full is set, boundaries unchanged since
+// the last import are skipped.
+func (i *NASRImporter) ImportBoundaries(ctx context.Context, full bool) (int, error) {
+	filePath := fmt.Sprintf("%s/ARB.txt", i.nasrDir)
+	file, err := os.Open(filePath)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("failed to open ARB.txt: %w", err)
 	}
+	defer file.Close()
 
-	minutes, err := strconv.ParseFloat(parts[1], 64)
+	boundaries, err := navdata.ReadARTCCBoundaries(file)
 	if err != nil {
 		return 0, err
 	}
 
-	seconds, err := strconv.ParseFloat(parts[2], 64)
-	if err != nil {
-		return 0, err
+	repo := db.NewNavdataRepository(i.db)
+	var hashes map[string]string
+	if !full {
+		if hashes, err = repo.AirspaceHashes(ctx, nasrSource); err != nil {
+			return 0, err
+		}
 	}
 
-	// Convert to decimal degrees
-	decimal := degrees + (minutes / 60.0) + (seconds / 3600.0)
-
-	// Apply hemisphere
-	if hemisphere == "S" || hemisphere == "W" {
-		decimal = -decimal
+	count := 0
+	for _, a := range boundaries {
+		hash := navdata.RecordHash(a.Name, a.Type, a.Class, a.Country, a.Lower, a.Upper, a.Boundary)
+		if !full && hashes[a.SourceID] == hash {
+			i.skipped++
+			continue
+		}
+		if err := repo.UpsertAirspace(ctx, nasrSource, a, hash); err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		count++
 	}
 
-	return decimal, nil
+	return count, nil
 }
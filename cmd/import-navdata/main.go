@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
+)
+
+// Worldwide Navigation Data Importer
+// Imports airports, navaids, reporting points and airspaces outside the US,
+// which NASR doesn't cover, keyed by ICAO region.
+//
+// OurAirports (https://ourairports.com/data/), in --ourairports-dir:
+// - airports.csv (Airports worldwide)
+// - navaids.csv (VORs, NDBs, TACANs and DMEs worldwide)
+//
+// OpenAIP exports (https://www.openaip.net), one set of files per country
+// in --openaip-dir, JSON or GeoJSON:
+// - xx_nav.json (Navaids)
+// - xx_rpp.json (VFR reporting points)
+// - xx_asp.json (Airspaces)
+//
+// Like the NASR importer, only records changed since the last import are
+// written. Use --full to rewrite every record.
+
+// openAIPSource is the airspaces table's source for OpenAIP airspaces
+const openAIPSource = "openaip"
+
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	ourAirportsDir := flag.String("ourairports-dir", "data/ourairports", "Directory containing OurAirports CSV files")
+	openAIPDir := flag.String("openaip-dir", "data/openaip", "Directory containing OpenAIP export files")
+	includeUS := flag.Bool("include-us", false, "Also import US records (normally imported from NASR)")
+	full := flag.Bool("full", false, "Rewrite every record, not only those changed since the last import")
+	flag.Parse()
+
+	log.Println("===========================================")
+	log.Println("  Worldwide Navigation Data Importer")
+	log.Println("===========================================")
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	log.Println("Connecting to database...")
+	database, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+	log.Println("✓ Database connected")
+
+	ctx := context.Background()
+	if err := database.Migrate(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	log.Println("✓ Schema up to date")
+
+	// Read everything first: navaids and reporting points take their ICAO
+	// region from the airports of their country
+	log.Println("\n===========================================")
+	log.Println("Reading Data")
+	log.Println("===========================================")
+
+	airports := readWaypoints(filepath.Join(*ourAirportsDir, "airports.csv"), navdata.ReadOurAirports)
+	navaids := readWaypoints(filepath.Join(*ourAirportsDir, "navaids.csv"), navdata.ReadOurAirportsNavaids)
+	var points, openAIPNavaids []navdata.Waypoint
+	for _, path := range openAIPFiles(*openAIPDir, "rpp") {
+		points = append(points, readWaypoints(path, navdata.ReadOpenAIPReportingPoints)...)
+	}
+	for _, path := range openAIPFiles(*openAIPDir, "nav") {
+		openAIPNavaids = append(openAIPNavaids, readWaypoints(path, navdata.ReadOpenAIPNavaids)...)
+	}
+	var airspaces []navdata.Airspace
+	for _, path := range openAIPFiles(*openAIPDir, "asp") {
+		airspaces = append(airspaces, readAirspaces(path)...)
+	}
+
+	// Airports without an ICAO code take their country's region too
+	regions := navdata.CountryRegions(airports)
+	navdata.AssignRegions(airports, regions)
+	navdata.AssignRegions(navaids, regions)
+	navdata.AssignRegions(points, regions)
+	navdata.AssignRegions(openAIPNavaids, regions)
+
+	// Where records share an identifier and region, later sources win:
+	// OpenAIP navaids over OurAirports', and airports over everything
+	waypoints := make(map[string]navdata.Waypoint)
+	for _, list := range [][]navdata.Waypoint{points, navaids, openAIPNavaids, airports} {
+		for _, wp := range list {
+			if wp.Country == "US" && !*includeUS {
+				continue
+			}
+			waypoints[db.WaypointKey(wp.Identifier, wp.Region)] = wp
+		}
+	}
+	log.Printf("✓ %d waypoints and %d airspaces read", len(waypoints), len(airspaces))
+
+	repo := db.NewNavdataRepository(database)
+
+	// Waypoints
+	log.Println("\n===========================================")
+	log.Println("Importing Waypoints")
+	log.Println("===========================================")
+
+	var waypointHashes map[string]string
+	if !*full {
+		if waypointHashes, err = repo.WaypointHashes(ctx); err != nil {
+			log.Fatalf("Failed to load existing waypoints: %v", err)
+		}
+	}
+	written, skipped := 0, 0
+	for key, wp := range waypoints {
+		hash := navdata.RecordHash(wp.Identifier, wp.Name, wp.Latitude, wp.Longitude, wp.Type, wp.Region)
+		if !*full && waypointHashes[key] == hash {
+			skipped++
+			continue
+		}
+		err := repo.UpsertWaypoint(ctx, db.Waypoint{
+			Identifier: wp.Identifier,
+			Name:       wp.Name,
+			Latitude:   wp.Latitude,
+			Longitude:  wp.Longitude,
+			Type:       wp.Type,
+			Region:     wp.Region,
+		}, hash)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		written++
+		if written%1000 == 0 {
+			log.Printf("  Imported %d waypoints...", written)
+		}
+	}
+	log.Printf("✓ Imported %d waypoints", written)
+
+	// Airspaces
+	log.Println("\n===========================================")
+	log.Println("Importing Airspaces")
+	log.Println("===========================================")
+
+	var airspaceHashes map[string]string
+	if !*full {
+		if airspaceHashes, err = repo.AirspaceHashes(ctx, openAIPSource); err != nil {
+			log.Fatalf("Failed to load existing airspaces: %v", err)
+		}
+	}
+	airspaceCount := 0
+	for _, a := range airspaces {
+		if a.Country == "US" && !*includeUS {
+			continue
+		}
+		hash := navdata.RecordHash(a.Name, a.Type, a.Class, a.Country, a.Lower, a.Upper, a.Boundary)
+		if !*full && airspaceHashes[a.SourceID] == hash {
+			skipped++
+			continue
+		}
+		if err := repo.UpsertAirspace(ctx, openAIPSource, a, hash); err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		airspaceCount++
+		if airspaceCount%500 == 0 {
+			log.Printf("  Imported %d airspaces...", airspaceCount)
+		}
+	}
+	log.Printf("✓ Imported %d airspaces", airspaceCount)
+
+	log.Println("\n===========================================")
+	log.Println("Import Complete")
+	log.Println("===========================================")
+	log.Printf("Total waypoints: %d", written)
+	log.Printf("Total airspaces: %d", airspaceCount)
+	log.Printf("Unchanged records skipped: %d", skipped)
+}
+
+// openAIPFiles returns the OpenAIP export files of one kind ("nav", "rpp"
+// or "asp") in a directory, e.g. de_nav.json and fr_nav.geojson.
+func openAIPFiles(dir, kind string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*_"+kind+".*json"))
+	return files
+}
+
+// readWaypoints reads a waypoint file with one of the navdata readers. A
+// missing or unreadable file is logged and skipped, so the other sources
+// are still imported.
+func readWaypoints(path string, read func(io.Reader) ([]navdata.Waypoint, error)) []navdata.Waypoint {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("  Skipping %s (not found)", path)
+		} else {
+			log.Printf("Warning: Failed to open %s: %v", path, err)
+		}
+		return nil
+	}
+	defer file.Close()
+
+	waypoints, err := read(file)
+	if err != nil {
+		log.Printf("Warning: Failed to read %s: %v", path, err)
+		return nil
+	}
+	log.Printf("  %s: %d records", path, len(waypoints))
+	return waypoints
+}
+
+// readAirspaces reads an OpenAIP airspace export, logging and skipping it
+// if it can't be read.
+func readAirspaces(path string) []navdata.Airspace {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Printf("Warning: Failed to open %s: %v", path, err)
+		return nil
+	}
+	defer file.Close()
+
+	airspaces, err := navdata.ReadOpenAIPAirspaces(file)
+	if err != nil {
+		log.Printf("Warning: Failed to read %s: %v", path, err)
+		return nil
+	}
+	log.Printf("  %s: %d airspaces", path, len(airspaces))
+	return airspaces
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/onvif"
+)
+
+// main calibrates a PTZ camera's pan/tilt frame for the onvif telescope
+// driver. The user points the camera (with its own controls) at two
+// references whose azimuth and elevation are known, e.g. landmarks or an
+// aircraft whose position is shown by the web or terminal clients, and
+// enters them; the camera's pan/tilt is read at each. The fitted
+// calibration is printed for the telescope's "onvif" configuration.
+func main() {
+	configPath := flag.String("config", "configs/config.json", "Path to configuration file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.Telescope.ONVIF.PTZURL == "" {
+		log.Fatal("telescope.onvif.ptz_url is not set")
+	}
+	client := onvif.NewClientFromConfig(cfg.Telescope.ONVIF)
+	ctx := context.Background()
+
+	fmt.Println("PTZ camera calibration")
+	fmt.Println("Point the camera at each reference with its own controls, then enter")
+	fmt.Println("the reference's azimuth and elevation in degrees (e.g. \"245.5 12\").")
+	fmt.Println("Choose references far apart in both azimuth and elevation.")
+
+	input := bufio.NewScanner(os.Stdin)
+	var points [2]onvif.ReferencePoint
+	for i := range points {
+		fmt.Printf("\nReference %d (azimuth elevation): ", i+1)
+		if !input.Scan() {
+			log.Fatal("No input")
+		}
+		azimuth, elevation, err := parseDirection(input.Text())
+		if err != nil {
+			log.Fatalf("Invalid reference: %v", err)
+		}
+
+		status, err := client.GetStatus(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read the camera's position: %v", err)
+		}
+		fmt.Printf("  Camera at pan %.4f, tilt %.4f\n", status.Pan, status.Tilt)
+		points[i] = onvif.ReferencePoint{Pan: status.Pan, Tilt: status.Tilt, Azimuth: azimuth, Elevation: elevation}
+	}
+
+	cal, err := onvif.FitCalibration(points[0], points[1])
+	if err != nil {
+		log.Fatalf("Failed to calibrate: %v", err)
+	}
+
+	fmt.Println("\nAdd to the telescope's \"onvif\" configuration:")
+	out, _ := json.MarshalIndent(map[string]float64{
+		"pan_zero_azimuth":      round(cal.PanZeroAzimuth),
+		"pan_degrees_per_unit":  round(cal.PanDegreesPerUnit),
+		"tilt_zero_elevation":   round(cal.TiltZeroElevation),
+		"tilt_degrees_per_unit": round(cal.TiltDegreesPerUnit),
+	}, "", "  ")
+	fmt.Println(string(out))
+}
+
+// parseDirection parses "azimuth elevation".
+func parseDirection(s string) (azimuth, elevation float64, err error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("expected azimuth and elevation, got %q", s)
+	}
+	if azimuth, err = strconv.ParseFloat(fields[0], 64); err != nil || azimuth < 0 || azimuth >= 360 {
+		return 0, 0, fmt.Errorf("azimuth %q must be 0-360", fields[0])
+	}
+	if elevation, err = strconv.ParseFloat(fields[1], 64); err != nil || elevation < -90 || elevation > 90 {
+		return 0, 0, fmt.Errorf("elevation %q must be -90 to 90", fields[1])
+	}
+	return azimuth, elevation, nil
+}
+
+// round rounds to 0.01°.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
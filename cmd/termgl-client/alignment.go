@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alignment"
+)
+
+// toggleAlignment starts or ends an alignment run. Each run starts without
+// samples; the model in use is kept until a new one is applied.
+func (a *App) toggleAlignment() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.aligning = !a.aligning
+	if !a.aligning {
+		a.addLog("INFO", "Alignment OFF")
+		return
+	}
+	a.alignStar = ""
+	a.alignSamples = nil
+	a.addLog("INFO", "Alignment ON: g slews to a star (or track an aircraft), center it with nudges, x measures, w applies")
+}
+
+// isAligning reports whether an alignment run is in progress
+func (a *App) isAligning() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.aligning
+}
+
+// slewToAlignmentStar slews to the visible star farthest in azimuth from
+// the references measured so far and tracks it, ready to be centered.
+func (a *App) slewToAlignmentStar() {
+	a.mu.Lock()
+	if !a.telescopeConnected {
+		a.mu.Unlock()
+		a.addLog("ERROR", "Telescope not connected")
+		return
+	}
+	if a.tracking {
+		a.mu.Unlock()
+		a.addLog("WARN", "Stop tracking (SPACE) before slewing to a star")
+		return
+	}
+	now := time.Now()
+	star, ok := alignment.NextStar(a.observer, now, a.minAlt, a.maxAlt, a.alignSamples)
+	if !ok {
+		a.mu.Unlock()
+		a.addLog("WARN", "No alignment stars within the altitude limits")
+		return
+	}
+	a.alignStar = star.Name
+	a.mu.Unlock()
+
+	pos := star.Position(a.observer, now)
+	a.addLog("INFO", fmt.Sprintf("Slewing to %s (mag %.1f) at Az %.1f° Alt %.1f°", star.Name, star.Magnitude, pos.Azimuth, pos.Altitude))
+
+	go func() {
+		if err := a.mount.SlewToAltAz(pos.Altitude, pos.Azimuth); err != nil {
+			a.addLog("ERROR", fmt.Sprintf("Failed to slew telescope: %v", err))
+			return
+		}
+		if err := a.telescope.SetTracking(true); err != nil {
+			a.addLog("WARN", fmt.Sprintf("Failed to enable tracking: %v", err))
+		}
+	}()
+}
+
+// measureAlignment records the centered reference as a sample: the tracked
+// aircraft while tracking, otherwise the star slewed to.
+func (a *App) measureAlignment() {
+	go func() {
+		mount, err := a.aligned.MountPosition()
+		if err != nil {
+			a.addLog("ERROR", fmt.Sprintf("Failed to read telescope position: %v", err))
+			return
+		}
+		now := time.Now()
+
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		sample := alignment.Sample{Mount: mount, Time: now.UTC()}
+		switch {
+		case a.tracking:
+			ac, ok := a.trackedAircraft()
+			if !ok {
+				a.addLog("WARN", fmt.Sprintf("No position for %s", a.trackICAO))
+				return
+			}
+			sample.Reference = "aircraft " + ac.ICAO
+			sample.Sky = alignment.AircraftPosition(ac.Aircraft, a.observer, now)
+		case a.alignStar != "":
+			star, _ := alignment.FindStar(a.alignStar)
+			sample.Reference = star.Name
+			sample.Sky = star.Position(a.observer, now)
+		default:
+			a.addLog("WARN", "Slew to a star (g) or track an aircraft to measure")
+			return
+		}
+
+		a.alignSamples = append(a.alignSamples, sample)
+		dAlt, dAz := sample.Offset()
+		a.addLog("INFO", fmt.Sprintf("Measured %s: off Alt %+.2f° Az %+.2f° (%d samples)", sample.Reference, dAlt, dAz, len(a.alignSamples)))
+	}()
+}
+
+// trackedAircraft returns the view of the aircraft being tracked. The
+// caller must hold a.mu.
+func (a *App) trackedAircraft() (AircraftView, bool) {
+	for _, ac := range a.aircraft {
+		if ac.ICAO == a.trackICAO {
+			return ac, true
+		}
+	}
+	return AircraftView{}, false
+}
+
+// undoAlignmentSample drops the last sample, e.g. one measured off center
+func (a *App) undoAlignmentSample() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.alignSamples) == 0 {
+		return
+	}
+	dropped := a.alignSamples[len(a.alignSamples)-1]
+	a.alignSamples = a.alignSamples[:len(a.alignSamples)-1]
+	a.addLog("INFO", fmt.Sprintf("Dropped %s (%d samples)", dropped.Reference, len(a.alignSamples)))
+}
+
+// applyAlignment starts using the model fitted to the samples and saves it
+// to the configuration file.
+func (a *App) applyAlignment() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	fit, err := alignment.FitModel(a.alignSamples)
+	if err != nil {
+		a.addLog("ERROR", fmt.Sprintf("Alignment not applied: %v", err))
+		return
+	}
+	a.aligned.SetModel(fit.Model)
+
+	tilt, low := fit.Model.Level()
+	a.addLog("INFO", fmt.Sprintf("Alignment applied: Az zero %+.2f° Alt zero %+.2f° tilt %.2f° (low side Az %.0f°), RMS %.2f°",
+		math.Remainder(fit.Model.AzimuthZero, 360), fit.Model.AltitudeZero, tilt, low, fit.RMS))
+
+	a.config.Telescope.Alignment = fit.Model.Config()
+	if err := a.config.Save(a.configPath); err != nil {
+		a.addLog("ERROR", fmt.Sprintf("Failed to save alignment: %v", err))
+		return
+	}
+	a.addLog("INFO", "Alignment saved to "+a.configPath)
+}
+
+// alignmentText shows the alignment run in the telemetry panel. The caller
+// must hold a.mu.
+func (a *App) alignmentText() string {
+	text := fmt.Sprintf("[yellow]ALIGNMENT:[-] [white]%d samples[-]\n", len(a.alignSamples))
+	if a.alignStar != "" && !a.tracking {
+		text += fmt.Sprintf("[gray]Ref:[-]  [white]%s[-]\n", a.alignStar)
+	}
+
+	fit, err := alignment.FitModel(a.alignSamples)
+	switch {
+	case errors.Is(err, alignment.ErrNoSamples):
+		text += "[gray]g star, center, x measure[-]\n"
+	case err != nil:
+		text += "[red]Spread references in azimuth[-]\n"
+	default:
+		text += fmt.Sprintf("[gray]Zero:[-] [white]Az %+.2f° Alt %+.2f°[-]\n", math.Remainder(fit.Model.AzimuthZero, 360), fit.Model.AltitudeZero)
+		if !fit.ZerosOnly {
+			tilt, low := fit.Model.Level()
+			text += fmt.Sprintf("[gray]Tilt:[-] [white]%.2f° low Az %.0f°[-]\n", tilt, low)
+		}
+		text += fmt.Sprintf("[gray]RMS:[-]  [white]%.2f°[-]\n", fit.RMS)
+	}
+	return text
+}
@@ -6,15 +6,24 @@ import (
 	"math"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
 	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alignment"
 	"github.com/unklstewy/ads-bscope/pkg/alpaca"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+	"github.com/unklstewy/ads-bscope/pkg/theme"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+	"github.com/unklstewy/ads-bscope/pkg/weather"
 )
 
 // ViewMode represents the current active view
@@ -39,6 +48,12 @@ const (
 // Position threshold for considering slew complete (degrees)
 const positionThreshold = 0.1
 
+// Zoom limits
+const (
+	minZoom = 0.5
+	maxZoom = 5.0
+)
+
 // AppConfig holds the application configuration
 type AppConfig struct {
 	Config             *config.Config
@@ -60,10 +75,13 @@ type App struct {
 	database       *db.DB
 	aircraftRepo   *db.AircraftRepository
 	flightPlanRepo *db.FlightPlanRepository
+	watchRepo      *db.WatchRepository
+	watchList      db.WatchList // Every user's watched aircraft, highlighted
 
 	// UI components
 	tviewApp     *tview.Application
 	mainView     tview.Primitive
+	mainPages    *tview.Pages // sky and radar views
 	telemetry    *tview.TextView
 	controls     *tview.TextView
 	logManager   *LogManager
@@ -72,6 +90,8 @@ type App struct {
 
 	// Telescope
 	telescope          *alpaca.Client
+	aligned            *alignment.Telescope  // Pointing corrected by the mount's alignment
+	mount              *alpaca.SafeTelescope // Slews and axis moves, kept within the mount limits
 	telescopeConnected bool
 	telescopeAlt       float64
 	telescopeAz        float64
@@ -93,23 +113,60 @@ type App struct {
 	filterName           string
 
 	// Solar Safety
+	solar                *safety.SolarGuard
 	sunPosition          coordinates.SunPosition
 	solarSeparation      float64
 	solarSafetyZone      coordinates.SolarSafetyZone
 	solarDarkFilterActive bool
 
+	// Night mode
+	twilight  coordinates.TwilightPhase
+	nightMode atomic.Bool // Red display, read on every draw
+
+	// Color theme (T cycles at runtime)
+	theme theme.Theme
+
 	// Switch (Dew Heater)
 	switchClient       *alpaca.SwitchClient
 	switchConnected    bool
 	dewHeaterEnabled   bool
 
+	// Thermal compensation (dew heater power and focus vs temperature)
+	conditions     *alpaca.ObservingConditionsClient
+	metarClient    *weather.METARClient
+	ambient        alpaca.Ambient
+	ambientValid   bool
+	dewHeaterPower float64
+
+	// Nudge mode (arrow keys trim pointing with guide pulses)
+	nudgeMode    bool
+	nudgeAlt     float64 // accumulated altitude trim in degrees
+	nudgeAz      float64 // accumulated azimuth trim in degrees
+	guideRateAlt float64 // degrees per second
+	guideRateAz  float64 // degrees per second
+
+	// Rotator (field derotation while tracking)
+	rotator          *alpaca.RotatorClient
+	rotatorConnected bool
+	derotator        *alpaca.DerotationController
+	derotationPass   int     // trackingPass the derotator reference belongs to
+	fieldRotation    float64 // parallactic angle rate in deg/s
+
+	// Alignment wizard (references centred with nudges fit a pointing model)
+	aligning     bool
+	alignStar    string // star slewed to, measured when not tracking
+	alignSamples []alignment.Sample
+
 	// State
 	aircraft      []AircraftView
 	selectedIndex int
 	tracking      bool
 	trackICAO     string
+	trackingPass  int // incremented each time tracking starts
 	showTrails    bool
 	showConstell  bool
+	trails        map[string][]trailPoint // ICAO -> recent positions
+	airports      []db.Waypoint           // radar overlay
 	zoom          float64
 	minAlt        float64
 	maxAlt        float64
@@ -124,7 +181,7 @@ type App struct {
 type AircraftView struct {
 	ICAO       string
 	Callsign   string
-	Altitude   float64
+	Altitude   units.Feet
 	Speed      float64
 	Heading    float64
 	Latitude   float64
@@ -133,8 +190,13 @@ type AircraftView struct {
 	Age        time.Duration
 	Selected   bool
 	Tracking   bool
+	Watched    bool          // On a user's watch list
+	Aircraft   adsb.Aircraft // As received, for pass prediction
 }
 
+// passSparklineWidth is the width of the pass profile in the telemetry panel
+const passSparklineWidth = 20
+
 // NewApp creates a new application instance
 func NewApp(cfg *AppConfig) *App {
 	// Get altitude limits from config
@@ -147,10 +209,12 @@ func NewApp(cfg *AppConfig) *App {
 		database:       cfg.Database,
 		aircraftRepo:   cfg.AircraftRepository,
 		flightPlanRepo: cfg.FlightPlanRepo,
+		watchRepo:      db.NewWatchRepository(cfg.Database),
 		aircraft:       make([]AircraftView, 0),
 		selectedIndex:  0,
 		tracking:       false,
 		showTrails:     false,
+		trails:         make(map[string][]trailPoint),
 		showConstell:   false,
 		zoom:           1.0,
 		minAlt:         minAlt,
@@ -159,8 +223,21 @@ func NewApp(cfg *AppConfig) *App {
 		stopChan:       make(chan struct{}),
 		telescope:      alpaca.NewClient(cfg.Config.Telescope),
 	}
+	app.aligned = alignment.NewTelescope(app.telescope, alignment.ModelFromConfig(cfg.Config.Telescope.Alignment))
+	app.mount = alpaca.NewSafeTelescope(app.aligned, alpaca.NewSafetyEnvelope(cfg.Config.Telescope))
+	app.solar = safety.NewSolarGuard(cfg.Config.Telescope, cfg.Observer)
+	app.mount.SetSolarGuard(app.solar)
+
+	th, themeErr := theme.Lookup(cfg.Config.Display.Theme)
+	if themeErr != nil {
+		th, _ = theme.Lookup(theme.DefaultName)
+	}
+	app.theme = th
 
 	app.setupUI()
+	if themeErr != nil {
+		app.addLog("WARN", fmt.Sprintf("%v; using the default theme", themeErr))
+	}
 	return app
 }
 
@@ -179,13 +256,24 @@ func (a *App) setupUI() {
 
 	// Setup keyboard handlers
 	a.tviewApp.SetInputCapture(a.handleKeyboard)
+
+	// Recolor every draw red while night mode is on
+	a.tviewApp.SetAfterDrawFunc(a.applyNightMode)
 }
 
+// Page names for the main view
+const (
+	pageSky   = "sky"
+	pageRadar = "radar"
+)
+
 // createMainView creates the main view (sky or radar)
 func (a *App) createMainView() {
-	// Create the sky view with geometric rendering
-	skyView := NewSkyView(a)
-	a.mainView = skyView
+	// Sky and radar views share the main area; switchView flips between them
+	a.mainPages = tview.NewPages().
+		AddPage(pageSky, NewSkyView(a), true, true).
+		AddPage(pageRadar, NewRadarView(a), true, false)
+	a.mainView = a.mainPages
 }
 
 // createTelemetryPanel creates the telemetry info panel
@@ -199,15 +287,8 @@ func (a *App) createTelemetryPanel() {
 	a.updateTelemetry()
 }
 
-// createControlsPanel creates the controls/shortcuts panel
-func (a *App) createControlsPanel() {
-	a.controls = tview.NewTextView().
-		SetDynamicColors(true).
-		SetScrollable(false)
-	a.controls.SetBorder(true).SetTitle(" Controls ")
-
-	// Set static controls text
-	controlsText := `[yellow]NAVIGATION[-]
+// controlsText lists the keyboard shortcuts in the controls panel
+const controlsText = `[yellow]NAVIGATION[-]
   [white]↑/↓, j/k[-]  Select
   [white]PgUp/PgDn[-] Scroll
 
@@ -216,6 +297,9 @@ func (a *App) createControlsPanel() {
   [white]SPACE[-]     Stop
   [white]t[-]         Trails
   [white]c[-]         Constellations
+  [white]T[-]         Theme
+  [white]n[-]         Nudge (arrows)
+  [white]a[-]         Align (g/x/u/w)
 
 [yellow]VIEWS[-]
   [white]s[-]         Sky view
@@ -230,12 +314,20 @@ func (a *App) createControlsPanel() {
 [yellow]CONTROL[-]
   [white]q[-]         Quit`
 
-	a.controls.SetText(controlsText)
+// createControlsPanel creates the controls/shortcuts panel
+func (a *App) createControlsPanel() {
+	a.controls = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(false)
+	a.controls.SetBorder(true).SetTitle(" Controls ")
+
+	a.controls.SetText(themeTags(a.theme.Palette, controlsText))
 }
 
 // createLogsPanel creates the log viewer panel
 func (a *App) createLogsPanel() {
 	a.logManager = NewLogManager(100)
+	a.logManager.SetPalette(a.theme.Palette)
 	a.logManager.Info("Application started")
 
 	// Attempt telescope connection
@@ -275,10 +367,14 @@ func (a *App) updateTelemetry() {
 	} else if a.selectedIndex >= 0 && a.selectedIndex < len(a.aircraft) {
 		ac := a.aircraft[a.selectedIndex]
 		text += fmt.Sprintf("[white]%d. %s (-) %s[-]\n", a.selectedIndex+1, ac.Callsign, ac.ICAO)
+		if country, ok := adsb.CountryOf(ac.ICAO); ok {
+			text += fmt.Sprintf("[gray]Reg:[-]  [white]%s %s[-]\n", country.Flag(), country.Name)
+		}
 		text += fmt.Sprintf("[gray]Alt:[-]  [white]%.0f ft[-]  [gray]Spd:[-] [white]%.0f kts[-]\n", ac.Altitude, ac.Speed)
 		text += fmt.Sprintf("[gray]Hdg:[-]  [white]%.0f°[-]     [gray]Age:[-] [white]%.1fs[-]\n", ac.Heading, ac.Age.Seconds())
 		text += fmt.Sprintf("[gray]Az:[-]   [white]%.1f°[-]  [gray]Alt:[-] [white]%.1f°[-]\n", ac.HorizCoord.Azimuth, ac.HorizCoord.Altitude)
 		text += fmt.Sprintf("[gray]Pos:[-]  [white]%.4f°, %.4f°[-]\n", ac.Latitude, ac.Longitude)
+		text += a.passProfileText(ac)
 	} else {
 		text += "[gray]No aircraft selected[-]\n"
 	}
@@ -293,26 +389,91 @@ func (a *App) updateTelemetry() {
 			text += "[gray]Mode:[-] [yellow]SLEWING[-]\n"
 		} else if a.tracking {
 			text += fmt.Sprintf("[gray]Mode:[-] [green]TRACKING %s[-]\n", a.trackICAO)
+			text += a.handoffText()
+			if a.rotatorConnected {
+				text += fmt.Sprintf("[gray]Derot:[-] [white]%+.3f°/s[-]\n", a.fieldRotation)
+			}
+			if a.nudgeAlt != 0 || a.nudgeAz != 0 {
+				text += fmt.Sprintf("[gray]Trim:[-] [white]Alt %+.3f° Az %+.3f°[-]\n", a.nudgeAlt, a.nudgeAz)
+			}
 		} else {
 			text += "[gray]Mode:[-] [white]IDLE[-]\n"
 		}
+		if a.nudgeMode {
+			text += "[gray]Keys:[-] [yellow]NUDGE (arrows, n to exit)[-]\n"
+		}
 	} else {
 		text += "[yellow]TELESCOPE:[-] [red]Not Connected[-]\n"
 		text += "[gray]Pos:[-]  [white]---[-]\n"
 		text += "[gray]Mode:[-] [white]IDLE[-]\n"
 	}
 
+	if a.aligning {
+		text += "\n" + a.alignmentText()
+	}
+
 	text += "\n"
 
 	// Observer section
 	text += fmt.Sprintf("[yellow]OBSERVER:[-] [white]%.4f°, %.4f°[-]\n", 
 		a.observer.Location.Latitude, a.observer.Location.Longitude)
-	text += fmt.Sprintf("[gray]Time:[-] [white]%s[-]\n", time.Now().Format("15:04:05"))
+	text += fmt.Sprintf("[gray]Time:[-] [white]%s[-] [gray]Sky:[-] [white]%s[-]\n", time.Now().Format("15:04:05"), a.twilight)
 	text += fmt.Sprintf("[gray]Aircraft:[-] [white]%d visible[-]\n", len(a.aircraft))
 	text += fmt.Sprintf("[gray]View:[-] [white]%s[-] [gray]Zoom:[-] [white]%.1fx[-]\n", 
 		a.getViewName(), a.zoom)
+	if a.currentView == ViewModeRadar {
+		text += fmt.Sprintf("[gray]Range:[-] [white]%.0f NM[-] [gray]Airports:[-] [white]%d[-]\n", a.radarRangeNM(), len(a.airports))
+	}
+
+	a.telemetry.SetText(themeTags(a.theme.Palette, text))
+}
+
+// passProfileText shows the aircraft's next pass as an elevation sparkline
+func (a *App) passProfileText(ac AircraftView) string {
+	limits := tracking.TrackingLimitsFromConfig(a.minAlt, a.maxAlt)
+	profile, ok := planner.PassProfile(ac.Aircraft, a.observer, time.Now().UTC(), planner.DefaultHorizon, planner.DefaultInterval, limits)
+	if !ok {
+		return "[gray]Pass:[-] [white]none in 30 min[-]\n"
+	}
+	return fmt.Sprintf("[gray]Pass:[-] [green]%s[-] [white]%.0f° %s[-]\n",
+		planner.Sparkline(profile.Samples, passSparklineWidth),
+		profile.Pass.MaxElevation, profile.Pass.Peak.Local().Format("15:04"))
+}
+
+// handoffText shows when the tracked aircraft leaves the limits and the
+// recommended next target
+func (a *App) handoffText() string {
+	others := make([]adsb.Aircraft, len(a.aircraft))
+	var current *adsb.Aircraft
+	for i, ac := range a.aircraft {
+		others[i] = ac.Aircraft
+		if ac.ICAO == a.trackICAO {
+			current = &others[i]
+		}
+	}
+	if current == nil {
+		return ""
+	}
+
+	limits := tracking.TrackingLimitsFromConfig(a.minAlt, a.maxAlt)
+	handoff, ok := planner.PlanHandoff(*current, others, a.observer, time.Now().UTC(), planner.DefaultHorizon, limits)
+	if !ok {
+		return ""
+	}
 
-	a.telemetry.SetText(text)
+	text := "[gray]Out:[-]  [white]30+ min[-]\n"
+	if handoff.LeavesLimits {
+		text = fmt.Sprintf("[gray]Out:[-]  [yellow]%s[-]\n", handoff.Leaves.Round(time.Second))
+	}
+	switch next := handoff.Next; {
+	case next == nil:
+		text += "[gray]Next:[-] [white]none predicted[-]\n"
+	case next.Wait > 0:
+		text += fmt.Sprintf("[gray]Next:[-] [white]%s in limits %s after[-]\n", next.Aircraft.Callsign, next.Wait.Round(time.Second))
+	default:
+		text += fmt.Sprintf("[gray]Next:[-] [white]%s max %.0f°[-]\n", next.Aircraft.Callsign, next.Pass.MaxElevation)
+	}
+	return text
 }
 
 // getViewName returns the current view mode name
@@ -347,6 +508,40 @@ func (a *App) handleKeyboard(event *tcell.EventKey) *tcell.EventKey {
 		a.Stop()
 		return nil
 
+	// Nudge mode: arrow keys trim pointing instead of navigating
+	case rune == 'n':
+		a.toggleNudgeMode()
+		return nil
+	case a.isNudging() && key == tcell.KeyUp:
+		a.nudge(alpaca.GuideNorth)
+		return nil
+	case a.isNudging() && key == tcell.KeyDown:
+		a.nudge(alpaca.GuideSouth)
+		return nil
+	case a.isNudging() && key == tcell.KeyLeft:
+		a.nudge(alpaca.GuideEast)
+		return nil
+	case a.isNudging() && key == tcell.KeyRight:
+		a.nudge(alpaca.GuideWest)
+		return nil
+
+	// Alignment wizard
+	case rune == 'a':
+		a.toggleAlignment()
+		return nil
+	case a.isAligning() && rune == 'g':
+		a.slewToAlignmentStar()
+		return nil
+	case a.isAligning() && rune == 'x':
+		a.measureAlignment()
+		return nil
+	case a.isAligning() && rune == 'u':
+		a.undoAlignmentSample()
+		return nil
+	case a.isAligning() && rune == 'w':
+		a.applyAlignment()
+		return nil
+
 	// Navigation
 	case key == tcell.KeyUp || rune == 'k':
 		if len(a.aircraft) > 0 {
@@ -372,6 +567,9 @@ func (a *App) handleKeyboard(event *tcell.EventKey) *tcell.EventKey {
 	case rune == 'c':
 		a.toggleConstellations()
 		return nil
+	case rune == 'T':
+		a.cycleTheme()
+		return nil
 
 	// Views
 	case rune == 's':
@@ -465,7 +663,7 @@ func (a *App) startTracking() {
 	ac := a.aircraft[a.selectedIndex]
 
 	// CRITICAL: Solar safety check
-	if a.config.Telescope.SolarSafetyEnabled && !a.checkSolarSafety(ac) {
+	if a.solar.Enabled() && !a.checkSolarSafety(ac) {
 		return
 	}
 
@@ -478,7 +676,10 @@ func (a *App) startTracking() {
 
 	a.tracking = true
 	a.trackICAO = ac.ICAO
+	a.trackingPass++
 	a.trackingMode = TrackingModeIntercept
+	a.nudgeAlt = 0
+	a.nudgeAz = 0
 	a.targetAlt = ac.HorizCoord.Altitude
 	a.targetAz = ac.HorizCoord.Azimuth
 
@@ -536,6 +737,59 @@ func (a *App) toggleConstellations() {
 	a.addLog("INFO", fmt.Sprintf("Constellations: %v", a.showConstell))
 }
 
+// toggleNudgeMode switches the arrow keys between selecting aircraft and
+// nudging the telescope
+func (a *App) toggleNudgeMode() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.nudgeMode = !a.nudgeMode
+	if a.nudgeMode {
+		a.addLog("INFO", fmt.Sprintf("Nudge mode ON: arrows pulse guide %v", a.config.Telescope.GetNudgeDuration()))
+	} else {
+		a.addLog("INFO", "Nudge mode OFF")
+	}
+}
+
+// isNudging reports whether arrow keys nudge the telescope
+func (a *App) isNudging() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.nudgeMode
+}
+
+// nudge trims pointing with a guide pulse. While tracking, the offset is
+// also added to the tracking target so continuous tracking keeps it.
+func (a *App) nudge(direction alpaca.GuideDirection) {
+	a.mu.Lock()
+	if !a.telescopeConnected {
+		a.mu.Unlock()
+		a.addLog("WARN", "Telescope not connected")
+		return
+	}
+
+	duration := a.config.Telescope.GetNudgeDuration()
+	rate := a.guideRateAz
+	if direction == alpaca.GuideNorth || direction == alpaca.GuideSouth {
+		rate = a.guideRateAlt
+	}
+	dAlt, dAz := direction.AltAzOffset(rate * duration.Seconds())
+	if a.tracking {
+		a.nudgeAlt += dAlt
+		a.nudgeAz += dAz
+	}
+	a.mu.Unlock()
+
+	go func() {
+		sent, err := a.mount.PulseGuide(direction, duration)
+		if err != nil {
+			a.addLog("ERROR", fmt.Sprintf("Failed to nudge %s: %v", direction, err))
+			return
+		}
+		a.addLog("DEBUG", fmt.Sprintf("Nudge %s %dms (Alt %+.4f° Az %+.4f°)", direction, sent.Milliseconds(), dAlt, dAz))
+	}()
+}
+
 // switchView switches to a different view mode
 func (a *App) switchView(mode ViewMode) {
 	a.mu.Lock()
@@ -545,6 +799,12 @@ func (a *App) switchView(mode ViewMode) {
 	a.addLog("INFO", fmt.Sprintf("Switched to %s view", a.getViewName()))
 
 	a.tviewApp.QueueUpdateDraw(func() {
+		switch mode {
+		case ViewModeSky:
+			a.mainPages.SwitchToPage(pageSky)
+		case ViewModeRadar:
+			a.mainPages.SwitchToPage(pageRadar)
+		}
 		a.updateTelemetry()
 	})
 }
@@ -555,8 +815,8 @@ func (a *App) zoomIn() {
 	defer a.mu.Unlock()
 
 	a.zoom = a.zoom * 1.2
-	if a.zoom > 5.0 {
-		a.zoom = 5.0
+	if a.zoom > maxZoom {
+		a.zoom = maxZoom
 	}
 
 	a.addLog("DEBUG", fmt.Sprintf("Zoom: %.1fx", a.zoom))
@@ -571,8 +831,8 @@ func (a *App) zoomOut() {
 	defer a.mu.Unlock()
 
 	a.zoom = a.zoom / 1.2
-	if a.zoom < 0.5 {
-		a.zoom = 0.5
+	if a.zoom < minZoom {
+		a.zoom = minZoom
 	}
 
 	a.addLog("DEBUG", fmt.Sprintf("Zoom: %.1fx", a.zoom))
@@ -600,16 +860,22 @@ func (a *App) Run() error {
 	a.updateTimer = time.NewTicker(2 * time.Second)
 	go a.updateLoop()
 
+	// Load airports for the radar overlay
+	go a.loadAirports()
+
 	// Start telescope position polling if connected
 	if a.telescopeConnected {
 		go a.telescopeUpdateLoop()
 	}
 
 	// Start solar position monitoring if safety enabled
-	if a.config.Telescope.SolarSafetyEnabled {
+	if a.solar.Enabled() {
 		go a.solarSafetyLoop()
 	}
 
+	// Switch to red night mode after civil twilight
+	go a.nightModeLoop()
+
 	// Run the tview application
 	fmt.Fprintln(os.Stderr, "[DEBUG] About to call tview.Run()...")
 	err := a.tviewApp.Run()
@@ -649,22 +915,33 @@ func (a *App) fetchAircraftData() {
 		return
 	}
 
+	// Load recent history for aircraft without a trail so trails survive restarts
+	now := time.Now().UTC()
+	a.mu.RLock()
+	missing := a.missingTrails(aircraft)
+	a.mu.RUnlock()
+	history, err := a.aircraftRepo.GetRecentTrails(ctx, missing, now.Add(-a.config.Display.GetTrailDuration()))
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to load trail history: %v", err))
+	}
+
+	// Watched aircraft are highlighted; keep the last list if it can't be read
+	watchList, err := a.watchRepo.All(ctx)
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to load watch lists: %v", err))
+	}
+
 	// Convert to display format
 	a.mu.Lock()
+	if err == nil {
+		a.watchList = watchList
+	}
 	oldCount := len(a.aircraft)
 	a.aircraft = make([]AircraftView, 0, len(aircraft))
 
 	for _, ac := range aircraft {
-		// Calculate horizontal coordinates
-		horiz := coordinates.GeographicToHorizontal(
-			coordinates.Geographic{
-				Latitude:  ac.Latitude,
-				Longitude: ac.Longitude,
-				Altitude:  ac.Altitude,
-			},
-			a.observer,
-			ac.LastSeen,
-		)
+		// Horizontal coordinates (precomputed by the collector for our observer)
+		horiz, _ := ac.RelativeTo(a.observer.Location)
 
 		// Calculate age
 		age := time.Since(ac.LastSeen)
@@ -682,6 +959,8 @@ func (a *App) fetchAircraftData() {
 			Age:        age,
 			Selected:   false,
 			Tracking:   a.tracking && ac.ICAO == a.trackICAO,
+			Watched:    a.watchList.Watches(ac.Aircraft),
+			Aircraft:   ac.Aircraft,
 		}
 
 		a.aircraft = append(a.aircraft, view)
@@ -701,6 +980,8 @@ func (a *App) fetchAircraftData() {
 		a.aircraft[a.selectedIndex].Selected = true
 	}
 
+	a.updateTrails(history, now)
+
 	newCount := len(a.aircraft)
 	a.mu.Unlock()
 
@@ -774,6 +1055,15 @@ func (a *App) connectTelescope() {
 
 	a.addLog("INFO", "Telescope connected successfully")
 
+	// Commands the telescope cannot perform are refused by the client
+	if caps := a.telescope.Capabilities(); caps != nil {
+		a.addLog("DEBUG", fmt.Sprintf("Telescope capabilities: slew alt/az %t, move axis %t, park %t, set tracking %t",
+			caps.CanSlewAltAz, caps.CanMoveAxis, caps.CanPark, caps.CanSetTracking))
+		if !caps.CanMoveAxis {
+			a.addLog("WARN", "Telescope cannot move axes; tracking will re-slew instead of driving axis rates")
+		}
+	}
+
 	// Check if parked
 	atPark, err := a.telescope.GetAtPark()
 	if err != nil {
@@ -805,8 +1095,126 @@ func (a *App) connectTelescope() {
 	// Get initial position
 	a.updateTelescopePosition()
 
+	// Guide rates determine how far a nudge moves
+	a.loadGuideRates()
+
 	// Initialize focuser for infinity focus (aircraft tracking)
 	go a.initializeFocuser()
+
+	// Initialize rotator for field derotation
+	if a.config.Telescope.Derotation.Enabled {
+		go a.initializeRotator()
+	}
+
+	// Manual control with a gamepad
+	if a.config.Telescope.Gamepad.Enabled {
+		go a.gamepadLoop()
+	}
+
+	// Until the app stops, reconnect if the Alpaca server restarts and stop
+	// moving axes at the mount's soft limits
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-a.stopChan
+		cancel()
+	}()
+	go a.superviseTelescope(ctx)
+	go a.mount.Run(ctx, alpaca.DefaultGuardInterval)
+}
+
+// superviseTelescope watches the telescope connection until ctx is
+// cancelled, reconnecting and resuming tracking after the Alpaca server
+// restarts
+func (a *App) superviseTelescope(ctx context.Context) {
+	supervisor := alpaca.NewSupervisor(a.telescope, alpaca.DefaultSupervisorInterval)
+	supervisor.OnEvent = a.handleConnectionEvent
+	supervisor.Run(ctx)
+}
+
+// handleConnectionEvent updates the app when the telescope disconnects or
+// reconnects. An aircraft being tracked is re-intercepted on reconnect.
+func (a *App) handleConnectionEvent(event alpaca.ConnectionEvent) {
+	if event.Type == alpaca.ConnectionLost {
+		a.mu.Lock()
+		a.telescopeConnected = false
+		a.mu.Unlock()
+		a.addLog("ERROR", fmt.Sprintf("Telescope disconnected: %s. Reconnecting...", event.Error))
+		return
+	}
+
+	a.addLog("INFO", fmt.Sprintf("Telescope reconnected after %.0fs (%d attempts)", event.DowntimeSeconds, event.Attempts))
+	if event.Error != "" {
+		a.addLog("WARN", fmt.Sprintf("Failed to restore telescope state: %s", event.Error))
+	}
+
+	a.mu.Lock()
+	a.telescopeConnected = true
+	var tracked *AircraftView
+	if a.tracking {
+		for i := range a.aircraft {
+			if a.aircraft[i].ICAO == a.trackICAO {
+				ac := a.aircraft[i]
+				tracked = &ac
+				break
+			}
+		}
+	}
+	if tracked != nil {
+		a.trackingMode = TrackingModeIntercept
+		a.targetAlt = tracked.HorizCoord.Altitude
+		a.targetAz = tracked.HorizCoord.Azimuth
+	}
+	a.mu.Unlock()
+
+	if tracked != nil {
+		a.addLog("INFO", fmt.Sprintf("Resuming tracking of %s", tracked.Callsign))
+		go a.interceptAircraft(*tracked)
+	}
+}
+
+// defaultGuideRate is half the sidereal rate in degrees per second, the
+// usual guide rate when the mount does not report one
+const defaultGuideRate = 0.5 * 15.041 / 3600.0
+
+// loadGuideRates reads the mount's guide rates for nudge trimming
+func (a *App) loadGuideRates() {
+	azRate, altRate, err := a.telescope.GetGuideRates()
+	if err != nil || azRate <= 0 || altRate <= 0 {
+		azRate, altRate = defaultGuideRate, defaultGuideRate
+		if err != nil {
+			a.addLog("DEBUG", fmt.Sprintf("Guide rates unavailable, using %.4f°/s: %v", defaultGuideRate, err))
+		}
+	}
+
+	a.mu.Lock()
+	a.guideRateAz = azRate
+	a.guideRateAlt = altRate
+	a.mu.Unlock()
+}
+
+// initializeRotator connects the rotator used for field derotation
+func (a *App) initializeRotator() {
+	rotator := alpaca.NewRotatorClient(a.telescope)
+
+	a.addLog("INFO", "Connecting to rotator...")
+	if err := rotator.Connect(); err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to connect to rotator: %v", err))
+		a.addLog("INFO", "Rotator unavailable - field derotation disabled")
+		return
+	}
+
+	position, err := rotator.GetPosition()
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to get rotator position: %v", err))
+	} else {
+		a.addLog("INFO", fmt.Sprintf("Rotator connected at %.1f°", position))
+	}
+
+	a.mu.Lock()
+	a.rotator = rotator
+	a.derotator = alpaca.NewDerotationController(rotator, a.config.Telescope.Derotation, a.observer.Location.Latitude)
+	a.rotatorConnected = true
+	a.mu.Unlock()
 }
 
 // initializeFocuser connects and sets focuser to infinity
@@ -863,6 +1271,11 @@ func (a *App) initializeFocuser() {
 
 	// Initialize dew heater
 	go a.initializeDewHeater()
+
+	// Start temperature-based dew heater and focus compensation
+	if a.config.Telescope.Thermal.Enabled {
+		go a.thermalLoop()
+	}
 }
 
 // initializeFilterWheel connects and sets filter to UV/IR Cut for tracking
@@ -922,7 +1335,7 @@ func (a *App) solarSafetyLoop() {
 		select {
 		case <-ticker.C:
 			// Calculate current sun position
-			sunPos := coordinates.CalculateSunPosition(a.observer, time.Now())
+			sunPos := a.solar.Sun()
 
 			a.mu.Lock()
 			a.sunPosition = sunPos
@@ -930,9 +1343,10 @@ func (a *App) solarSafetyLoop() {
 			// If tracking, check solar proximity
 			if a.tracking && a.selectedIndex >= 0 && a.selectedIndex < len(a.aircraft) {
 				ac := a.aircraft[a.selectedIndex]
-				separation := sunPos.AngularSeparation(ac.HorizCoord.Altitude, ac.HorizCoord.Azimuth)
+				check, err := a.solar.Check(ac.HorizCoord)
+				separation := check.Separation
 				a.solarSeparation = separation
-				a.solarSafetyZone = coordinates.GetSafetyZone(separation)
+				a.solarSafetyZone = check.Zone
 
 				// Check if we need to engage dark filter
 				if a.config.Telescope.AutoDarkFilterOnSolarProximity && 
@@ -949,7 +1363,7 @@ func (a *App) solarSafetyLoop() {
 				}
 
 				// CRITICAL: Stop tracking if too close to sun
-				if separation < a.config.Telescope.MinSolarSeparation {
+				if err != nil {
 					a.mu.Unlock()
 					a.addLog("ERROR", fmt.Sprintf("CRITICAL: Aircraft %.1f° from sun - EMERGENCY STOP", separation))
 					a.stopTracking()
@@ -967,27 +1381,26 @@ func (a *App) solarSafetyLoop() {
 // checkSolarSafety validates that tracking the given aircraft is safe from solar damage.
 // Returns false and logs errors if tracking would be dangerous.
 func (a *App) checkSolarSafety(ac AircraftView) bool {
-	// Calculate sun position
-	sunPos := coordinates.CalculateSunPosition(a.observer, time.Now())
+	check, err := a.solar.Check(ac.HorizCoord)
+	sunPos := check.Sun
 
 	// Only check if sun is above horizon
 	if !sunPos.IsSunAboveHorizon() {
 		return true // Sun below horizon - safe
 	}
 
-	// Calculate angular separation
-	separation := sunPos.AngularSeparation(ac.HorizCoord.Altitude, ac.HorizCoord.Azimuth)
-	safetyZone := coordinates.GetSafetyZone(separation)
+	separation := check.Separation
+	safetyZone := check.Zone
 
 	a.addLog("INFO", fmt.Sprintf("Solar check: %.1f° separation (Sun: Az %.1f° Alt %.1f°)", 
 		separation, sunPos.Azimuth, sunPos.Altitude))
 
 	// CRITICAL: Check against configured minimum
-	if separation < a.config.Telescope.MinSolarSeparation {
-		if !a.config.Telescope.SolarFilterInstalled {
+	if err != nil || check.FilterRequired {
+		if err != nil {
 			a.addLog("ERROR", "═══════════════════════════════════════════════════")
 			a.addLog("ERROR", "  ⚠️  SOLAR DANGER - TRACKING BLOCKED ⚠️")
-			a.addLog("ERROR", fmt.Sprintf("  Aircraft is %.1f° from sun (min: %.0f°)", separation, a.config.Telescope.MinSolarSeparation))
+			a.addLog("ERROR", fmt.Sprintf("  Aircraft is %.1f° from sun (min: %.0f°)", separation, a.solar.MinSeparation()))
 			a.addLog("ERROR", "  RISK: PERMANENT OPTICS DAMAGE")
 			a.addLog("ERROR", "")
 			a.addLog("ERROR", "  To track near sun, you MUST:")
@@ -1098,6 +1511,110 @@ func (a *App) initializeDewHeater() {
 		}
 	}
 }
+
+// thermalLoop periodically reads ambient conditions and applies the dew
+// heater power curve and focus temperature compensation.
+func (a *App) thermalLoop() {
+	thermal := a.config.Telescope.Thermal
+
+	interval := time.Duration(thermal.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	if thermal.TemperatureSource == "metar" {
+		a.metarClient = weather.NewMETARClient(weather.DefaultMETARBaseURL)
+		a.addLog("INFO", fmt.Sprintf("Thermal compensation using METAR from %s", thermal.METARStation))
+	} else {
+		a.conditions = alpaca.NewObservingConditionsClient(a.telescope)
+		if err := a.conditions.Connect(); err != nil {
+			a.addLog("WARN", fmt.Sprintf("Failed to connect to observing conditions: %v", err))
+			a.addLog("INFO", "Thermal compensation unavailable")
+			return
+		}
+		a.addLog("INFO", "Thermal compensation using ObservingConditions")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		a.applyThermalProfile()
+
+		select {
+		case <-ticker.C:
+		case <-a.stopChan:
+			return
+		}
+	}
+}
+
+// applyThermalProfile reads ambient conditions once and adjusts devices.
+func (a *App) applyThermalProfile() {
+	amb, err := a.readAmbient()
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to read ambient temperature: %v", err))
+		return
+	}
+
+	// Only drive devices that are connected
+	a.mu.RLock()
+	var heater *alpaca.SwitchClient
+	if a.switchConnected {
+		heater = a.switchClient
+	}
+	var focuser *alpaca.FocuserClient
+	if a.focuserConnected {
+		focuser = a.focuser
+	}
+	a.mu.RUnlock()
+
+	controller := alpaca.NewThermalController(a.config.Telescope, heater, focuser)
+	result, err := controller.Apply(amb)
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Thermal compensation: %v", err))
+	}
+
+	a.mu.Lock()
+	a.ambient = amb
+	a.ambientValid = true
+	if result.HeaterPower >= 0 {
+		a.dewHeaterPower = result.HeaterPower
+		a.dewHeaterEnabled = result.HeaterPower > 0
+	}
+	if result.FocusMoved {
+		a.focuserPosition = result.FocusPosition
+	}
+	a.mu.Unlock()
+
+	if result.FocusMoved {
+		a.addLog("INFO", fmt.Sprintf("Focus compensated to %d steps at %.1f°C", result.FocusPosition, amb.TemperatureC))
+	}
+	if result.HeaterPower >= 0 {
+		a.addLog("DEBUG", fmt.Sprintf("Dew heater %.0f%% at %.1f°C", result.HeaterPower*100, amb.TemperatureC))
+	}
+}
+
+// readAmbient reads temperature (and dew point if available) from the configured source.
+func (a *App) readAmbient() (alpaca.Ambient, error) {
+	if a.metarClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		m, err := a.metarClient.Latest(ctx, a.config.Telescope.Thermal.METARStation)
+		if err != nil {
+			return alpaca.Ambient{}, err
+		}
+		return alpaca.Ambient{
+			TemperatureC: m.TemperatureC,
+			DewPointC:    m.DewPointC,
+			HasDewPoint:  m.HasDewPoint,
+		}, nil
+	}
+
+	return a.conditions.GetAmbient()
+}
+
 // telescopeUpdateLoop periodically updates telescope position
 func (a *App) telescopeUpdateLoop() {
 	ticker := time.NewTicker(500 * time.Millisecond) // 2Hz update rate
@@ -1119,16 +1636,10 @@ func (a *App) updateTelescopePosition() {
 		return
 	}
 
-	// Get altitude and azimuth
-	alt, err := a.telescope.GetAltitude()
+	// Get altitude and azimuth, corrected by the mount's alignment
+	pos, err := a.aligned.Position()
 	if err != nil {
-		a.addLog("ERROR", fmt.Sprintf("Failed to get telescope altitude: %v", err))
-		return
-	}
-
-	az, err := a.telescope.GetAzimuth()
-	if err != nil {
-		a.addLog("ERROR", fmt.Sprintf("Failed to get telescope azimuth: %v", err))
+		a.addLog("ERROR", fmt.Sprintf("Failed to get telescope position: %v", err))
 		return
 	}
 
@@ -1140,8 +1651,8 @@ func (a *App) updateTelescopePosition() {
 	}
 
 	a.mu.Lock()
-	a.telescopeAlt = alt
-	a.telescopeAz = az
+	a.telescopeAlt = pos.Altitude
+	a.telescopeAz = pos.Azimuth
 	a.telescopeSlewing = slewing
 	a.mu.Unlock()
 
@@ -1159,7 +1670,7 @@ func (a *App) interceptAircraft(ac AircraftView) {
 
 	a.addLog("DEBUG", fmt.Sprintf("Slewing to Az %.1f° Alt %.1f°", ac.HorizCoord.Azimuth, ac.HorizCoord.Altitude))
 
-	err := a.telescope.SlewToAltAz(ac.HorizCoord.Altitude, ac.HorizCoord.Azimuth)
+	err := a.mount.SlewToAltAz(ac.HorizCoord.Altitude, ac.HorizCoord.Azimuth)
 	if err != nil {
 		a.addLog("ERROR", fmt.Sprintf("Failed to slew telescope: %v", err))
 		a.mu.Lock()
@@ -1269,15 +1780,36 @@ func (a *App) updateTrackingSlew() {
 	telescopeAlt := a.telescopeAlt
 	telescopeAz := a.telescopeAz
 	ac := *tracked
+	pass := a.trackingPass
+	trimAlt := a.nudgeAlt
+	trimAz := a.nudgeAz
 	a.mu.RUnlock()
 
+	// Apply the operator's nudge trim to the target
+	targetAlt := ac.HorizCoord.Altitude + trimAlt
+	targetAz := coordinates.NormalizeAzimuth(ac.HorizCoord.Azimuth + trimAz)
+
+	// Without MoveAxis, follow the aircraft by re-slewing to it each update
+	if caps := a.telescope.Capabilities(); caps != nil && !caps.CanMoveAxis {
+		if err := a.mount.SlewToAltAz(targetAlt, targetAz); err != nil {
+			a.addLog("ERROR", fmt.Sprintf("Failed to slew telescope: %v", err))
+			return
+		}
+		a.updateDerotation(pass, coordinates.HorizontalCoordinates{Altitude: telescopeAlt, Azimuth: telescopeAz})
+		a.mu.Lock()
+		a.targetAlt = targetAlt
+		a.targetAz = targetAz
+		a.mu.Unlock()
+		return
+	}
+
 	// Calculate angular velocities needed
 	// Delta position / delta time = angular rate
 	// We update every 2 seconds, so rates are in deg/sec
 	deltaTime := 2.0 // seconds
 
-	altDiff := ac.HorizCoord.Altitude - telescopeAlt
-	azDiff := ac.HorizCoord.Azimuth - telescopeAz
+	altDiff := targetAlt - telescopeAlt
+	azDiff := targetAz - telescopeAz
 
 	// Handle azimuth wrap-around (choose shortest path)
 	if azDiff > 180 {
@@ -1290,35 +1822,58 @@ func (a *App) updateTrackingSlew() {
 	altRate := altDiff / deltaTime
 	azRate := azDiff / deltaTime
 
-	// Clamp to slew rate limits (6 deg/sec for Seestar S30)
-	maxRate := a.config.Telescope.SlewRate
-	if altRate > maxRate {
-		altRate = maxRate
-	} else if altRate < -maxRate {
-		altRate = -maxRate
-	}
-	if azRate > maxRate {
-		azRate = maxRate
-	} else if azRate < -maxRate {
-		azRate = -maxRate
-	}
-
-	// Apply MoveAxis commands
-	if err := a.telescope.MoveAxis(1, altRate); err != nil {
+	// Apply MoveAxis commands (clamped to the slew rate and stopped at the
+	// soft limits by the safety envelope)
+	if err := a.mount.MoveAxis(1, altRate); err != nil {
 		a.addLog("ERROR", fmt.Sprintf("Failed to move altitude axis: %v", err))
 		return
 	}
 
-	if err := a.telescope.MoveAxis(0, azRate); err != nil {
+	if err := a.mount.MoveAxis(0, azRate); err != nil {
 		a.addLog("ERROR", fmt.Sprintf("Failed to move azimuth axis: %v", err))
 		return
 	}
 
 	a.addLog("DEBUG", fmt.Sprintf("Tracking: Az rate %.2f°/s, Alt rate %.2f°/s", azRate, altRate))
 
+	// Counter the field rotation at the telescope's pointing position
+	a.updateDerotation(pass, coordinates.HorizontalCoordinates{Altitude: telescopeAlt, Azimuth: telescopeAz})
+
 	// Update target for threshold checking
 	a.mu.Lock()
-	a.targetAlt = ac.HorizCoord.Altitude
-	a.targetAz = ac.HorizCoord.Azimuth
+	a.targetAlt = targetAlt
+	a.targetAz = targetAz
 	a.mu.Unlock()
 }
+
+// updateDerotation moves the rotator to cancel field rotation during a pass.
+// The rotator position at the start of each pass is kept as the reference.
+func (a *App) updateDerotation(pass int, pointing coordinates.HorizontalCoordinates) {
+	a.mu.RLock()
+	derotator := a.derotator
+	a.mu.RUnlock()
+
+	if derotator == nil {
+		return
+	}
+
+	if pass != a.derotationPass {
+		derotator.Reset()
+		a.derotationPass = pass
+	}
+
+	result, err := derotator.Update(pointing, time.Now())
+	if err != nil {
+		a.addLog("ERROR", fmt.Sprintf("Derotation failed: %v", err))
+		return
+	}
+
+	a.mu.Lock()
+	a.fieldRotation = result.RateDegPerSec
+	a.mu.Unlock()
+
+	if result.Moved {
+		a.addLog("DEBUG", fmt.Sprintf("Derotation: PA %.1f° (%+.3f°/s), rotator to %.1f°",
+			result.ParallacticAngle, result.RateDegPerSec, result.RotatorTarget))
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/gamepad"
+)
+
+// gamepadPollInterval is how often stick positions are turned into axis rates
+const gamepadPollInterval = 100 * time.Millisecond
+
+// gamepadRateEpsilon is the smallest rate change worth sending to the mount
+const gamepadRateEpsilon = 0.01
+
+// gamepadLoop lets the operator fly the telescope with a gamepad. The sticks
+// drive MoveAxis only while the dead-man button is held; releasing it stops
+// both axes. The handoff button starts automatic tracking of the aircraft
+// nearest to where the telescope is pointing. If the gamepad is unplugged
+// both axes are stopped and the loop ends.
+func (a *App) gamepadLoop() {
+	cfg := a.config.Telescope.Gamepad
+
+	path := cfg.Device
+	if path == "" {
+		path = gamepad.DefaultDevice
+	}
+
+	device, err := gamepad.Open(path)
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Gamepad unavailable: %v", err))
+		return
+	}
+	defer device.Close()
+
+	a.addLog("INFO", fmt.Sprintf("Gamepad connected: %s (hold button %d to drive, button %d to track)",
+		path, cfg.DeadManButton, cfg.HandoffButton))
+
+	state := gamepad.NewState()
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		if err := device.Run(state); err != nil {
+			select {
+			case <-a.stopChan:
+			default:
+				a.addLog("WARN", fmt.Sprintf("Gamepad disconnected: %v", err))
+			}
+		}
+	}()
+
+	maxRate := cfg.MaxRate
+	if maxRate <= 0 || maxRate > a.config.Telescope.SlewRate {
+		maxRate = a.config.Telescope.SlewRate
+	}
+
+	ticker := time.NewTicker(gamepadPollInterval)
+	defer ticker.Stop()
+
+	driving := false
+	handoffHeld := false
+	warnedNoMoveAxis := false
+	lastAzRate, lastAltRate := math.NaN(), math.NaN()
+
+	stop := func() {
+		driving = false
+		lastAzRate, lastAltRate = math.NaN(), math.NaN()
+		if err := a.telescope.StopAxes(); err != nil {
+			a.addLog("ERROR", fmt.Sprintf("Failed to stop axes: %v", err))
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.stopChan:
+			if driving {
+				stop()
+			}
+			return
+		case <-disconnected:
+			if driving {
+				stop()
+				a.addLog("INFO", "Gamepad lost, axes stopped")
+			}
+			return
+		}
+
+		a.mu.RLock()
+		connected := a.telescopeConnected
+		a.mu.RUnlock()
+		if !connected {
+			continue
+		}
+
+		// Handoff fires once per press
+		handoff := state.Button(cfg.HandoffButton)
+		if handoff && !handoffHeld {
+			if driving {
+				stop()
+			}
+			a.handoffToTracking()
+		}
+		handoffHeld = handoff
+
+		// Dead-man switch: no button, no motion
+		if !state.Button(cfg.DeadManButton) {
+			if driving {
+				stop()
+				a.addLog("INFO", "Gamepad released, axes stopped")
+			}
+			continue
+		}
+
+		if !driving {
+			if caps := a.telescope.Capabilities(); caps != nil && !caps.CanMoveAxis {
+				if !warnedNoMoveAxis {
+					a.addLog("WARN", "Gamepad driving disabled: telescope cannot move axes (CanMoveAxis is false)")
+					warnedNoMoveAxis = true
+				}
+				continue
+			}
+			driving = true
+			a.takeManualControl()
+		}
+
+		// Stick forward reports negative values
+		azRate := gamepad.Rate(state.Axis(cfg.AzimuthAxis), cfg.Deadzone, maxRate)
+		altRate := -gamepad.Rate(state.Axis(cfg.AltitudeAxis), cfg.Deadzone, maxRate)
+		if cfg.InvertAltitude {
+			altRate = -altRate
+		}
+
+		if math.Abs(azRate-lastAzRate) >= gamepadRateEpsilon || math.IsNaN(lastAzRate) {
+			if err := a.mount.MoveAxis(0, azRate); err != nil {
+				a.addLog("ERROR", fmt.Sprintf("Failed to move azimuth axis: %v", err))
+				continue
+			}
+			lastAzRate = azRate
+		}
+		if math.Abs(altRate-lastAltRate) >= gamepadRateEpsilon || math.IsNaN(lastAltRate) {
+			if err := a.mount.MoveAxis(1, altRate); err != nil {
+				a.addLog("ERROR", fmt.Sprintf("Failed to move altitude axis: %v", err))
+				continue
+			}
+			lastAltRate = altRate
+		}
+	}
+}
+
+// takeManualControl ends automatic tracking so the gamepad can drive the axes
+func (a *App) takeManualControl() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.tracking {
+		a.addLog("INFO", fmt.Sprintf("Manual override: stopped tracking %s", a.trackICAO))
+		a.tracking = false
+		a.trackICAO = ""
+		a.trackingMode = TrackingModeIdle
+	} else {
+		a.addLog("INFO", "Manual control")
+	}
+}
+
+// handoffToTracking selects the aircraft nearest to the telescope's pointing
+// position and starts automatic tracking
+func (a *App) handoffToTracking() {
+	a.mu.Lock()
+	pointing := coordinates.HorizontalCoordinates{Altitude: a.telescopeAlt, Azimuth: a.telescopeAz}
+
+	nearest := -1
+	best := math.Inf(1)
+	for i, ac := range a.aircraft {
+		if sep := coordinates.AngularSeparation(pointing, ac.HorizCoord); sep < best {
+			nearest, best = i, sep
+		}
+	}
+
+	if nearest < 0 {
+		a.mu.Unlock()
+		a.addLog("WARN", "Handoff: no aircraft to track")
+		return
+	}
+
+	a.selectedIndex = nearest
+	ac := a.aircraft[nearest]
+	a.mu.Unlock()
+
+	a.addLog("INFO", fmt.Sprintf("Handoff to %s (%s), %.1f° from pointing", ac.Callsign, ac.ICAO, best))
+	a.startTracking()
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/rivo/tview"
+
+	"github.com/unklstewy/ads-bscope/pkg/theme"
 )
 
 // LogLevel represents the severity of a log message
@@ -34,6 +36,9 @@ type LogManager struct {
 
 	// autoScroll controls whether new messages auto-scroll
 	autoScroll bool
+
+	// palette colors the log lines
+	palette theme.Palette
 }
 
 // LogMessage represents a single log entry
@@ -126,7 +131,7 @@ func (lm *LogManager) refresh() {
 
 		// Format: [HH:MM:SS] LEVEL Message
 		line := fmt.Sprintf("[gray]%s[-] %s %s\n", timeStr, levelStr, msg.Message)
-		fmt.Fprint(lm.textView, line)
+		fmt.Fprint(lm.textView, themeTags(lm.palette, line))
 	}
 
 	// Auto-scroll to bottom if enabled
@@ -151,6 +156,15 @@ func (lm *LogManager) getColorForLevel(level LogLevel) string {
 	}
 }
 
+// SetPalette recolors the log lines with a theme's palette
+func (lm *LogManager) SetPalette(p theme.Palette) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	lm.palette = p
+	lm.refresh()
+}
+
 // Clear removes all log messages
 func (lm *LogManager) Clear() {
 	lm.mu.Lock()
@@ -137,7 +137,7 @@ func printHelp() {
 	fmt.Println("    ?              Show help screen")
 	fmt.Println()
 	fmt.Println("  Zoom:")
-	fmt.Println("    +/-            Zoom in/out")
+	fmt.Println("    +/-            Zoom in/out (radar: range)")
 	fmt.Println("    0              Reset zoom")
 	fmt.Println()
 	fmt.Println("  Control:")
@@ -149,6 +149,7 @@ func printHelp() {
 	fmt.Println("  - Telescope control integration")
 	fmt.Println("  - Advanced geometric rendering")
 	fmt.Println("  - Track trails and trajectory predictions")
+	fmt.Println("  - Top-down radar with range rings and nearby airports")
 	fmt.Println()
 	fmt.Println("For more information, visit:")
 	fmt.Println("  https://github.com/unklstewy/ads-bscope")
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// nightModeInterval is how often the twilight phase is checked
+const nightModeInterval = time.Minute
+
+// nightModeRed is used for text in the terminal's default color
+var nightModeRed = tcell.NewRGBColor(170, 0, 0)
+
+// nightModeLoop switches red night mode on after civil twilight ends and off
+// at dawn.
+func (a *App) nightModeLoop() {
+	ticker := time.NewTicker(nightModeInterval)
+	defer ticker.Stop()
+
+	for {
+		phase := coordinates.GetTwilightPhase(a.solar.Sun().Altitude)
+
+		a.mu.Lock()
+		a.twilight = phase
+		a.mu.Unlock()
+
+		if dark := phase.IsDark(); a.nightMode.Swap(dark) != dark {
+			if dark {
+				a.addLog("INFO", fmt.Sprintf("Night mode on (%s)", phase))
+			} else {
+				a.addLog("INFO", "Night mode off")
+			}
+			a.tviewApp.QueueUpdateDraw(func() {})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.stopChan:
+			return
+		}
+	}
+}
+
+// applyNightMode redraws the screen in shades of red while night mode is on.
+// It runs after every draw, so it covers every panel and inline color.
+func (a *App) applyNightMode(screen tcell.Screen) {
+	if !a.nightMode.Load() {
+		return
+	}
+
+	width, height := screen.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mainc, combc, style, _ := screen.GetContent(x, y)
+			fg, bg, attr := style.Decompose()
+			if fg == tcell.ColorDefault {
+				fg = nightModeRed
+			}
+			style = tcell.StyleDefault.Foreground(redShade(fg)).Background(redShade(bg)).Attributes(attr)
+			screen.SetContent(x, y, mainc, combc, style)
+		}
+	}
+}
+
+// redShade returns a red of the same brightness as c. The terminal's
+// default color is kept, since its value isn't known.
+func redShade(c tcell.Color) tcell.Color {
+	r, g, b := c.RGB()
+	if r < 0 {
+		return c
+	}
+	return tcell.NewRGBColor((299*r+587*g+114*b)/1000, 0, 0)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// radarAspectRatio corrects for terminal characters being ~2:1 (height:width).
+// X distances are divided by this so range rings look round.
+const radarAspectRatio = 0.5
+
+// defaultRadarRangeNM is the radar radius at 1x zoom when the config has no search radius
+const defaultRadarRangeNM = 50.0
+
+// trailPoint is a past aircraft position drawn as a breadcrumb
+type trailPoint struct {
+	coordinates.Geographic
+	Time time.Time
+}
+
+// maxRadarAirports limits the airport overlay so labels don't swamp the display
+const maxRadarAirports = 40
+
+// RadarView is a custom tview primitive that renders a top-down radar
+// display centred on the observer
+type RadarView struct {
+	*tview.Box
+	app *App
+}
+
+// NewRadarView creates a new radar view with tcell rendering
+func NewRadarView(app *App) *RadarView {
+	rv := &RadarView{
+		Box: tview.NewBox(),
+		app: app,
+	}
+	rv.SetBorder(true).SetTitle(" Radar View - Top Down ")
+	return rv
+}
+
+// Draw renders the radar view using tcell
+func (rv *RadarView) Draw(screen tcell.Screen) {
+	rv.Box.DrawForSubclass(screen, rv)
+
+	x, y, width, height := rv.GetInnerRect()
+
+	centerX := x + width/2
+	centerY := y + height/2
+
+	// Fit the radar radius (in rows) within the smaller dimension
+	maxRadiusY := float64(height/2 - 1)
+	maxRadiusX := float64(width/2-2) * radarAspectRatio
+	maxRadius := math.Min(maxRadiusX, maxRadiusY)
+	if maxRadius < 2 {
+		return
+	}
+
+	rv.app.mu.RLock()
+	rangeNM := rv.app.radarRangeNM()
+	interval := rv.app.config.Display.GetRadarRingInterval(rangeNM)
+	aircraft := rv.app.aircraft
+	selectedIndex := rv.app.selectedIndex
+	tracking := rv.app.tracking
+	trackICAO := rv.app.trackICAO
+	showTrails := rv.app.showTrails
+	trails := make(map[string][]trailPoint, len(rv.app.trails))
+	if showTrails {
+		for icao, trail := range rv.app.trails {
+			trails[icao] = trail
+		}
+	}
+	airports := rv.app.airports
+	pal := rv.app.theme.Palette
+	rv.app.mu.RUnlock()
+
+	rv.SetTitle(fmt.Sprintf(" Radar View - %.0f NM ", rangeNM))
+
+	scale := maxRadius / rangeNM
+	center := rv.app.observer.Location
+
+	inBounds := func(px, py int) bool {
+		return px >= x && px < x+width && py >= y && py < y+height
+	}
+
+	// project converts a geographic position to screen coordinates
+	project := func(pos coordinates.Geographic) (int, int, bool) {
+		distance := coordinates.DistanceNauticalMiles(center, pos)
+		if distance > rangeNM {
+			return 0, 0, false
+		}
+		bearingRad := coordinates.Bearing(center, pos) * math.Pi / 180.0
+		px := centerX + int(math.Round(distance*scale*math.Sin(bearingRad)/radarAspectRatio))
+		py := centerY - int(math.Round(distance*scale*math.Cos(bearingRad)))
+		return px, py, inBounds(px, py)
+	}
+
+	gridStyle := themeStyle(pal.Grid)
+	labelStyle := themeStyle(pal.Label)
+	cardinalStyle := themeStyle(pal.Text).Bold(true)
+	airportStyle := themeStyle(pal.Airport)
+	trailStyle := themeStyle(pal.Trail)
+	vectorStyle := themeStyle(pal.Vector)
+	observerStyle := themeStyle(pal.Observer).Bold(true)
+
+	// Draw range rings with distance labels at the top of each ring
+	for dist := interval; dist <= rangeNM+0.001; dist += interval {
+		ringRadius := dist * scale
+		drawEllipse(screen, centerX, centerY, ringRadius, radarAspectRatio, '·', gridStyle)
+
+		label := fmt.Sprintf("%.0f", dist)
+		labelY := centerY - int(math.Round(ringRadius))
+		for i, ch := range label {
+			if px := centerX + i - len(label)/2; inBounds(px, labelY) {
+				screen.SetContent(px, labelY, ch, nil, labelStyle)
+			}
+		}
+	}
+
+	// Draw cardinal directions just outside the outer ring
+	edge := int(math.Round(maxRadius))
+	cardinals := []struct {
+		dx, dy int
+		ch     rune
+	}{
+		{0, -edge - 1, 'N'},
+		{int(float64(edge)/radarAspectRatio) + 2, 0, 'E'},
+		{0, edge + 1, 'S'},
+		{-int(float64(edge)/radarAspectRatio) - 2, 0, 'W'},
+	}
+	for _, c := range cardinals {
+		if inBounds(centerX+c.dx, centerY+c.dy) {
+			screen.SetContent(centerX+c.dx, centerY+c.dy, c.ch, nil, cardinalStyle)
+		}
+	}
+
+	// Draw airports from the waypoints table
+	for _, ap := range airports {
+		px, py, ok := project(coordinates.Geographic{Latitude: ap.Latitude, Longitude: ap.Longitude})
+		if !ok {
+			continue
+		}
+		screen.SetContent(px, py, '△', nil, airportStyle)
+		for i, ch := range ap.Identifier {
+			if inBounds(px+i+2, py) {
+				screen.SetContent(px+i+2, py, ch, nil, airportStyle)
+			}
+		}
+	}
+
+	// Draw trails (oldest first, so newer points overwrite)
+	for _, trail := range trails {
+		for _, pos := range trail {
+			if px, py, ok := project(pos.Geographic); ok {
+				screen.SetContent(px, py, '·', nil, trailStyle)
+			}
+		}
+	}
+
+	// Draw aircraft with heading vectors
+	type radarLabel struct {
+		x, y  int
+		text  string
+		style tcell.Style
+	}
+	var labels []radarLabel
+
+	for i, ac := range aircraft {
+		px, py, ok := project(coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude})
+		if !ok {
+			continue
+		}
+
+		var symbol rune
+		var style tcell.Style
+
+		if tracking && ac.ICAO == trackICAO {
+			symbol = '◉'
+			style = themeStyle(pal.Tracked)
+		} else if i == selectedIndex {
+			symbol = '●'
+			style = themeStyle(pal.Selected)
+		} else if ac.Watched {
+			symbol = '★'
+			style = themeStyle(pal.Watched)
+		} else {
+			symbol = '○'
+			style = themeStyle(pal.Aircraft)
+		}
+
+		// Velocity vector: longer for faster aircraft, capped at 4 rows
+		if ac.Speed > 50 {
+			length := int(ac.Speed/150.0) + 1
+			if length > 4 {
+				length = 4
+			}
+			headingRad := ac.Heading * math.Pi / 180.0
+			for step := 1; step <= length; step++ {
+				vx := px + int(math.Round(float64(step)*math.Sin(headingRad)/radarAspectRatio))
+				vy := py - int(math.Round(float64(step)*math.Cos(headingRad)))
+				if !inBounds(vx, vy) {
+					break
+				}
+				ch := '-'
+				if step == length {
+					ch = '→'
+				}
+				screen.SetContent(vx, vy, ch, nil, vectorStyle)
+			}
+		}
+
+		screen.SetContent(px, py, symbol, nil, style)
+
+		if i == selectedIndex || (tracking && ac.ICAO == trackICAO) || ac.Watched {
+			text := ac.Callsign
+			if text == "" {
+				text = ac.ICAO
+			}
+			text = fmt.Sprintf("%s FL%03.0f", text, ac.Altitude/100)
+			labels = append(labels, radarLabel{x: px + 2, y: py, text: text, style: style})
+		}
+	}
+
+	// Labels last so vectors and trails don't cut through them
+	for _, l := range labels {
+		for i, ch := range l.text {
+			if inBounds(l.x+i, l.y) {
+				screen.SetContent(l.x+i, l.y, ch, nil, l.style)
+			}
+		}
+	}
+
+	// Observer at the centre
+	screen.SetContent(centerX, centerY, '✈', nil, observerStyle)
+}
+
+// radarRangeNM returns the radar radius for the current zoom level.
+// Caller must hold a.mu.
+func (a *App) radarRangeNM() float64 {
+	base := a.config.ADSB.SearchRadiusNM
+	if base <= 0 {
+		base = defaultRadarRangeNM
+	}
+	return base / a.zoom
+}
+
+// missingTrails returns the aircraft that have no trail yet.
+// Caller must hold a.mu.
+func (a *App) missingTrails(aircraft []db.ObservedAircraft) []string {
+	var missing []string
+	for _, ac := range aircraft {
+		if _, ok := a.trails[ac.ICAO]; !ok {
+			missing = append(missing, ac.ICAO)
+		}
+	}
+	return missing
+}
+
+// updateTrails appends the latest aircraft positions to their trails, drops
+// positions older than the configured trail length and drops trails for
+// aircraft that are no longer reported. New trails are seeded from history
+// (recent positions from the position history table, keyed by ICAO).
+// Caller must hold a.mu.
+func (a *App) updateTrails(history map[string][]db.Position, now time.Time) {
+	cutoff := now.Add(-a.config.Display.GetTrailDuration())
+
+	seen := make(map[string]bool, len(a.aircraft))
+	for _, ac := range a.aircraft {
+		seen[ac.ICAO] = true
+
+		trail, ok := a.trails[ac.ICAO]
+		if !ok {
+			for _, p := range history[ac.ICAO] {
+				trail = append(trail, trailPoint{
+					Geographic: coordinates.Geographic{Latitude: p.Latitude, Longitude: p.Longitude, Altitude: p.AltitudeFt.Meters()},
+					Time:       p.Timestamp,
+				})
+			}
+		}
+
+		pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude, Altitude: ac.Altitude.Meters()}
+		if n := len(trail); n == 0 || trail[n-1].Geographic != pos {
+			trail = append(trail, trailPoint{Geographic: pos, Time: now})
+		}
+
+		i := 0
+		for i < len(trail) && trail[i].Time.Before(cutoff) {
+			i++
+		}
+		a.trails[ac.ICAO] = trail[i:]
+	}
+
+	for icao := range a.trails {
+		if !seen[icao] {
+			delete(a.trails, icao)
+		}
+	}
+}
+
+// loadAirports loads airports around the observer from the waypoints table
+// for the radar overlay. The search covers the widest zoomed-out range.
+func (a *App) loadAirports() {
+	if a.flightPlanRepo == nil {
+		return
+	}
+
+	a.mu.RLock()
+	radius := a.radarRangeNM() * a.zoom / minZoom
+	a.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	airports, err := a.flightPlanRepo.FindAirportsNear(ctx,
+		a.observer.Location.Latitude, a.observer.Location.Longitude, radius, maxRadarAirports)
+	if err != nil {
+		a.addLog("WARN", fmt.Sprintf("Failed to load airports for radar: %v", err))
+		return
+	}
+
+	a.mu.Lock()
+	a.airports = airports
+	a.mu.Unlock()
+
+	a.addLog("INFO", fmt.Sprintf("Loaded %d airports for radar overlay", len(airports)))
+}
+
+// drawEllipse draws a ring of radius rows, stretched horizontally by 1/aspect
+// so it appears round on a terminal
+func drawEllipse(screen tcell.Screen, cx, cy int, radius, aspect float64, char rune, style tcell.Style) {
+	if radius <= 0 {
+		return
+	}
+
+	// Enough steps that neighbouring points touch on the wider axis
+	steps := int(2*math.Pi*radius/aspect) + 8
+	for i := 0; i < steps; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		px := cx + int(math.Round(radius*math.Sin(angle)/aspect))
+		py := cy - int(math.Round(radius*math.Cos(angle)))
+		screen.SetContent(px, py, char, nil, style)
+	}
+}
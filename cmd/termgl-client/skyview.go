@@ -42,14 +42,15 @@ func (sv *SkyView) Draw(screen tcell.Screen) {
 	// Apply zoom
 	sv.app.mu.RLock()
 	zoom := sv.app.zoom
+	pal := sv.app.theme.Palette
 	sv.app.mu.RUnlock()
 	
 	radius = int(float64(radius) * zoom)
 
 	// Define colors for tcell
-	gridStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
-	horizonStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
-	zenithStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+	gridStyle := themeStyle(pal.Grid)
+	horizonStyle := themeStyle(pal.Text)
+	zenithStyle := themeStyle(pal.Selected)
 
 	// Draw altitude rings (concentric circles)
 	altitudes := []float64{30, 60, 90} // 0° (horizon) will be drawn separately
@@ -133,22 +134,26 @@ func (sv *SkyView) Draw(screen tcell.Screen) {
 		if tracking && ac.ICAO == trackICAO {
 			// Tracking this aircraft
 			symbol = '◉' // ◉
-			style = tcell.StyleDefault.Foreground(tcell.ColorGreen)
+			style = themeStyle(pal.Tracked)
 		} else if i == selectedIndex {
 			// Selected aircraft
 			symbol = '●' // ●
-			style = tcell.StyleDefault.Foreground(tcell.ColorYellow)
+			style = themeStyle(pal.Selected)
+		} else if ac.Watched {
+			// On a watch list
+			symbol = '★'
+			style = themeStyle(pal.Watched)
 		} else {
 			// Normal aircraft
 			symbol = '○' // ○
-			style = tcell.StyleDefault.Foreground(tcell.ColorLightBlue)
+			style = themeStyle(pal.Aircraft)
 		}
 
 		// Draw aircraft symbol
 		screen.SetContent(px, py, symbol, nil, style)
 
-		// Draw callsign label for selected or tracked aircraft
-		if (i == selectedIndex) || (tracking && ac.ICAO == trackICAO) {
+		// Draw callsign label for selected, tracked or watched aircraft
+		if (i == selectedIndex) || (tracking && ac.ICAO == trackICAO) || ac.Watched {
 			label := ac.Callsign
 			if label == "" {
 				label = ac.ICAO
@@ -161,7 +166,7 @@ func (sv *SkyView) Draw(screen tcell.Screen) {
 
 		// Draw velocity vector if aircraft is moving (simple arrow)
 		if ac.Speed > 50 {
-			vectorStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkCyan)
+			vectorStyle := themeStyle(pal.Vector)
 			headingRad := ac.Heading * math.Pi / 180.0
 			// Draw a short line in the direction of travel
 			vx := px + int(3*math.Sin(headingRad))
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/unklstewy/ads-bscope/pkg/theme"
+)
+
+// themeStyle returns a style with the given theme color as foreground
+func themeStyle(color string) tcell.Style {
+	return tcell.StyleDefault.Foreground(tcell.GetColor(color))
+}
+
+// themeTags rewrites the named color tags used in the sidebar panels to the
+// palette's colors, so the panel text can keep using readable tag names.
+func themeTags(p theme.Palette, text string) string {
+	return strings.NewReplacer(
+		"[yellow]", "["+p.Warning+"]",
+		"[gray]", "["+p.Muted+"]",
+		"[white]", "["+p.Text+"]",
+		"[green]", "["+p.OK+"]",
+		"[red]", "["+p.Error+"]",
+	).Replace(text)
+}
+
+// cycleTheme switches to the next color theme and recolors the sidebar
+// panels; the sky and radar views read the theme on every draw.
+func (a *App) cycleTheme() {
+	a.mu.Lock()
+	a.theme = theme.Next(a.theme.Name)
+	pal := a.theme.Palette
+	name := a.theme.Name
+	a.mu.Unlock()
+
+	a.controls.SetText(themeTags(pal, controlsText))
+	a.logManager.SetPalette(pal)
+	a.updateTelemetry()
+	a.addLog("INFO", "Theme: "+name)
+}
@@ -51,11 +51,18 @@ func main() {
 		log.Fatalf("Failed to fetch aircraft: %v", err)
 	}
 
+	// Measure the source's latency from the positions' ages
+	now := time.Now().UTC()
+	latency := adsb.NewLatencyHistogram()
+	latency.RecordAircraft(aircraft, now)
+	predictionLatency := latency.PredictionLatency()
+
 	log.Printf("Found %d aircraft\n", len(aircraft))
+	log.Printf("Position age: median %.1fs, p90 %.1fs (predicting %.1fs ahead)\n",
+		latency.Percentile(0.5).Seconds(), latency.Percentile(0.9).Seconds(), predictionLatency.Seconds())
 	log.Println("=====================================")
 
 	// Display each aircraft with calculated horizontal coordinates
-	now := time.Now().UTC()
 	for i, ac := range aircraft {
 		// Skip aircraft with missing position data
 		if ac.Latitude == 0 && ac.Longitude == 0 {
@@ -66,7 +73,7 @@ func main() {
 		targetPos := coordinates.Geographic{
 			Latitude:  ac.Latitude,
 			Longitude: ac.Longitude,
-			Altitude:  ac.Altitude * 0.3048, // Convert feet to meters
+			Altitude:  ac.Altitude.Meters(),
 		}
 
 		horiz := coordinates.GeographicToHorizontal(targetPos, observer, now)
@@ -74,8 +81,8 @@ func main() {
 		// Also calculate equatorial coordinates for equatorial mounts
 		eq := coordinates.HorizontalToEquatorial(horiz, observer, now)
 
-		// Predict position with typical online ADS-B latency (2.5 seconds)
-		predicted := tracking.PredictPositionWithLatency(ac, 2.5)
+		// Predict position by the measured source latency
+		predicted := tracking.PredictPositionWithLatency(ac, predictionLatency.Seconds())
 		predictedHoriz := coordinates.GeographicToHorizontal(predicted.Position, observer, now)
 
 		// Print aircraft info
@@ -97,7 +104,7 @@ func main() {
 		log.Printf("     [Equatorial Mount]")
 		log.Printf("       RA:       %6.2fh (right ascension)", eq.RightAscension)
 		log.Printf("       Dec:      %+6.2f° (declination)", eq.Declination)
-		log.Printf("     [Predicted Position (2.5s ahead)]")
+		log.Printf("     [Predicted Position (%.1fs ahead)]", predictionLatency.Seconds())
 		log.Printf("       Altitude: %6.2f° (confidence: %.0f%%)", predictedHoriz.Altitude, predicted.Confidence*100)
 		log.Printf("       Azimuth:  %6.2f°", predictedHoriz.Azimuth)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/unklstewy/ads-bscope/pkg/alpaca"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
 	"github.com/unklstewy/ads-bscope/pkg/tracking"
 )
 
@@ -22,8 +23,14 @@ func main() {
 	duration := flag.Int("duration", 60, "Tracking duration in seconds")
 	dryRun := flag.Bool("dry-run", false, "Simulate tracking without moving telescope")
 	random := flag.Bool("random", false, "Select a random trackable aircraft")
+	prediction := flag.String("prediction", "auto", "Prediction strategy: auto, dead-reckoning, flight-plan or airway")
 	flag.Parse()
 
+	strategy, err := tracking.ParsePredictionStrategy(*prediction)
+	if err != nil {
+		log.Fatalf("Invalid -prediction: %v", err)
+	}
+
 	log.Println("===========================================")
 	log.Println("  ADS-B Aircraft Tracking (DB Mode)")
 	log.Println("===========================================")
@@ -43,6 +50,9 @@ func main() {
 	// Get altitude limits
 	minAlt, maxAlt := cfg.Telescope.GetAltitudeLimits()
 	log.Printf("Tracking limits: %.0f° - %.0f° altitude", minAlt, maxAlt)
+	if strategy != tracking.StrategyAuto {
+		log.Printf("Prediction strategy: %s", strategy)
+	}
 
 	// Connect to database
 	log.Println("\nConnecting to database...")
@@ -53,6 +63,11 @@ func main() {
 	defer database.Close()
 	log.Println("✓ Database connected")
 
+	// Apply pending schema migrations
+	if err := database.Migrate(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
 	// Create observer
 	observer := coordinates.Observer{
 		Location: coordinates.Geographic{
@@ -73,7 +88,7 @@ func main() {
 	if *icao == "" {
 		// Get trackable aircraft from database
 		log.Println("\nQuerying trackable aircraft from database...")
-		trackable, err := repo.GetTrackableAircraft(ctx)
+		trackable, err := repo.GetTrackableAircraft(ctx, minAlt, maxAlt)
 		if err != nil {
 			log.Fatalf("Failed to query trackable aircraft: %v", err)
 		}
@@ -112,8 +127,11 @@ func main() {
 
 	// Create telescope client if not dry run
 	var telescopeClient *alpaca.Client
+	var mount *alpaca.SafeTelescope // telescopeClient, kept within the mount limits
 	if !*dryRun {
 		telescopeClient = alpaca.NewClient(cfg.Telescope)
+		mount = alpaca.NewSafeTelescope(telescopeClient, alpaca.NewSafetyEnvelope(cfg.Telescope))
+		mount.SetSolarGuard(safety.NewSolarGuard(cfg.Telescope, observer))
 		log.Printf("\nConnecting to telescope at %s...", cfg.Telescope.BaseURL)
 
 		if err := telescopeClient.Connect(); err != nil {
@@ -204,8 +222,9 @@ func main() {
 			// Data is stale - use prediction
 			predicted = true
 
-			// Try waypoint-based prediction first (if flight plan available)
-			if len(waypointList) > 0 {
+			// Try waypoint-based prediction first (if flight plan available),
+			// then airways, as the prediction strategy allows
+			if len(waypointList) > 0 && strategy.UsesFlightPlan() {
 				predictedPos := tracking.PredictPositionWithWaypoints(
 					*aircraft,
 					waypointList,
@@ -214,7 +233,7 @@ func main() {
 				acPos = predictedPos.Position
 				confidence = predictedPos.Confidence
 				predictionType = "waypoint"
-			} else {
+			} else if strategy.UsesAirways() {
 				// No flight plan - try airway matching
 				// Query nearby airways within 25 NM radius
 				airwaySegs, err := fpRepo.FindNearbyAirways(
@@ -273,6 +292,11 @@ func main() {
 					confidence = predictedPos.Confidence
 					predictionType = "deadreckoning"
 				}
+			} else {
+				predictedPos := tracking.PredictPositionWithLatency(*aircraft, dataAge)
+				acPos = predictedPos.Position
+				confidence = predictedPos.Confidence
+				predictionType = "deadreckoning"
 			}
 
 			// Warn if confidence is low
@@ -286,7 +310,7 @@ func main() {
 			acPos = coordinates.Geographic{
 				Latitude:  aircraft.Latitude,
 				Longitude: aircraft.Longitude,
-				Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+				Altitude:  aircraft.Altitude.Meters(),
 			}
 			confidence = 1.0
 			predictionType = ""
@@ -347,7 +371,7 @@ func main() {
 			fmt.Printf("  Last Known: %.4f°N, %.4f°W, %.0f ft MSL\n",
 				aircraft.Latitude, aircraft.Longitude, aircraft.Altitude)
 			fmt.Printf("  Predicted:  %.4f°N, %.4f°W, %.0f ft MSL (%.0f%% confidence)\n",
-				acPos.Latitude, acPos.Longitude, acPos.Altitude/coordinates.FeetToMeters, confidence*100)
+				acPos.Latitude, acPos.Longitude, acPos.Altitude.Feet(), confidence*100)
 		} else {
 			fmt.Printf("  Position: %.4f°N, %.4f°W, %.0f ft MSL\n",
 				aircraft.Latitude, aircraft.Longitude, aircraft.Altitude)
@@ -402,11 +426,16 @@ func main() {
 			if !*dryRun {
 				var slewErr error
 				if cfg.Telescope.MountType == "altaz" {
-					slewErr = telescopeClient.SlewToAltAz(horiz.Altitude, horiz.Azimuth)
+					slewErr = mount.SlewToAltAz(horiz.Altitude, horiz.Azimuth)
 				} else {
-					// Convert to equatorial for equatorial mounts
-					eq := coordinates.HorizontalToEquatorial(horiz, observer, now)
-					slewErr = telescopeClient.SlewToCoordinates(eq.RightAscension, eq.Declination)
+					// Convert to equatorial for equatorial mounts, after
+					// checking the target against the mount limits and the sun
+					var safe coordinates.HorizontalCoordinates
+					safe.Altitude, safe.Azimuth, slewErr = mount.Check(horiz.Altitude, horiz.Azimuth)
+					if slewErr == nil {
+						eq := coordinates.HorizontalToEquatorial(safe, observer, now)
+						slewErr = telescopeClient.SlewToCoordinates(eq.RightAscension, eq.Declination)
+					}
 				}
 
 				if slewErr != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/unklstewy/ads-bscope/pkg/alpaca"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
 	"github.com/unklstewy/ads-bscope/pkg/tracking"
 )
 
@@ -67,6 +68,10 @@ func main() {
 	adsbClient := adsb.NewAirplanesLiveClient(cfg.ADSB.Sources[0].BaseURL)
 	defer adsbClient.Close()
 
+	// The source's latency is measured from the age of the positions it
+	// returns; its median is the prediction latency
+	latency := adsb.NewLatencyHistogram()
+
 	// If no ICAO specified, fetch nearby aircraft and select one
 	var targetICAO string
 	if *icao == "" {
@@ -86,11 +91,14 @@ func main() {
 		}
 
 		log.Printf("Found %d aircraft within %.0fnm", len(aircraft), *radius)
+		latency.RecordAircraft(aircraft, time.Now().UTC())
+		log.Printf("Position age: median %.1fs, p90 %.1fs",
+			latency.Percentile(0.5).Seconds(), latency.Percentile(0.9).Seconds())
 
 		// Filter for trackable aircraft (within altitude limits)
 		// Use prediction to match tracking loop behavior
 		log.Println("\nFiltering for trackable aircraft...")
-		trackable, filtered := filterTrackableAircraftWithReason(aircraft, observer, minAlt, maxAlt, time.Now().UTC(), 2.5)
+		trackable, filtered := filterTrackableAircraftWithReason(aircraft, observer, minAlt, maxAlt, time.Now().UTC(), latency.PredictionLatency().Seconds())
 
 		// Show why aircraft were filtered out
 		if len(filtered) > 0 {
@@ -116,7 +124,7 @@ func main() {
 		if *random {
 			// Pick random aircraft
 			target = &trackable[rand.Intn(len(trackable))]
-			acPos := coordinates.Geographic{Latitude: target.Latitude, Longitude: target.Longitude, Altitude: target.Altitude}
+			acPos := coordinates.Geographic{Latitude: target.Latitude, Longitude: target.Longitude, Altitude: target.Altitude.Meters()}
 			horiz := coordinates.GeographicToHorizontal(acPos, observer, time.Now().UTC())
 			log.Printf("\n🎯 Randomly selected: %s (%s)", target.Callsign, target.ICAO)
 			log.Printf("   Position: Alt %.1f° Az %.1f° @ %.0fft MSL", horiz.Altitude, horiz.Azimuth, target.Altitude)
@@ -128,7 +136,7 @@ func main() {
 					log.Printf("  ... and %d more", len(trackable)-10)
 					break
 				}
-				acPos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude, Altitude: ac.Altitude}
+				acPos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude, Altitude: ac.Altitude.Meters()}
 				horiz := coordinates.GeographicToHorizontal(acPos, observer, time.Now().UTC())
 				log.Printf("  [%d] %s (%s) - Alt: %.1f° Az: %.1f° @ %.0fft",
 					i+1, ac.Callsign, ac.ICAO, horiz.Altitude, horiz.Azimuth, ac.Altitude)
@@ -145,8 +153,11 @@ func main() {
 
 	// Create telescope client
 	var telescopeClient *alpaca.Client
+	var mount *alpaca.SafeTelescope // telescopeClient, kept within the mount limits
 	if !*dryRun {
 		telescopeClient = alpaca.NewClient(cfg.Telescope)
+		mount = alpaca.NewSafeTelescope(telescopeClient, alpaca.NewSafetyEnvelope(cfg.Telescope))
+		mount.SetSolarGuard(safety.NewSolarGuard(cfg.Telescope, observer))
 		log.Printf("Connecting to telescope at %s...", cfg.Telescope.BaseURL)
 
 		if err := telescopeClient.Connect(); err != nil {
@@ -230,9 +241,10 @@ func main() {
 		}
 
 		now := time.Now().UTC()
+		latency.RecordAircraft([]adsb.Aircraft{*aircraft}, now)
 
-		// Predict position accounting for latency (2.5s for online sources)
-		predicted := tracking.PredictPositionWithLatency(*aircraft, 2.5)
+		// Predict position accounting for the source's measured latency
+		predicted := tracking.PredictPositionWithLatency(*aircraft, latency.PredictionLatency().Seconds())
 
 		// Convert to telescope coordinates
 		horiz := coordinates.GeographicToHorizontal(predicted.Position, observer, now)
@@ -250,7 +262,7 @@ func main() {
 		acPos := coordinates.Geographic{
 			Latitude:  aircraft.Latitude,
 			Longitude: aircraft.Longitude,
-			Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+			Altitude:  aircraft.Altitude.Meters(),
 		}
 		currentRange := coordinates.DistanceNauticalMiles(observer.Location, acPos)
 		closestRange, timeToClosest, approaching := coordinates.EstimateTimeToClosestApproach(
@@ -304,11 +316,16 @@ func main() {
 			if !*dryRun {
 				var slewErr error
 				if cfg.Telescope.MountType == "altaz" {
-					slewErr = telescopeClient.SlewToAltAz(horiz.Altitude, horiz.Azimuth)
+					slewErr = mount.SlewToAltAz(horiz.Altitude, horiz.Azimuth)
 				} else {
-					// Convert to equatorial for equatorial mounts
-					eq := coordinates.HorizontalToEquatorial(horiz, observer, now)
-					slewErr = telescopeClient.SlewToCoordinates(eq.RightAscension, eq.Declination)
+					// Convert to equatorial for equatorial mounts, after
+					// checking the target against the mount limits and the sun
+					var safe coordinates.HorizontalCoordinates
+					safe.Altitude, safe.Azimuth, slewErr = mount.Check(horiz.Altitude, horiz.Azimuth)
+					if slewErr == nil {
+						eq := coordinates.HorizontalToEquatorial(safe, observer, now)
+						slewErr = telescopeClient.SlewToCoordinates(eq.RightAscension, eq.Declination)
+					}
 				}
 
 				if slewErr != nil {
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+)
+
+// loadRadarAirways loads the airway segments around the radar centre and
+// the named fixes along them when the airway overlay is on. Airways are
+// only loaded up to radarDetailRadiusNM; wider views would be solid lines.
+func (m *model) loadRadarAirways() {
+	m.radarSegments = nil
+	m.radarFixes = nil
+	if !m.radarAirways || m.radarRadius > radarDetailRadiusNM {
+		return
+	}
+
+	ctx := context.Background()
+	segments, err := m.fpRepo.FindNearbyAirways(ctx, m.radarCenter.Latitude, m.radarCenter.Longitude, m.radarRadius, 0, 0)
+	if err != nil {
+		m.err = fmt.Errorf("failed to load airways: %w", err)
+		return
+	}
+	m.radarSegments = segments
+
+	// The fixes are the segments' named endpoints; navaids and airports
+	// are already plotted by the waypoint overlay
+	seen := make(map[int]bool)
+	for _, seg := range segments {
+		for _, wp := range []db.Waypoint{seg.FromWaypoint, seg.ToWaypoint} {
+			if wp.Type != "fix" || seen[wp.ID] || m.radarDistance(wp) > m.radarRadius {
+				continue
+			}
+			seen[wp.ID] = true
+			m.radarFixes = append(m.radarFixes, wp)
+		}
+	}
+	sort.SliceStable(m.radarFixes, func(i, j int) bool {
+		return m.radarDistance(m.radarFixes[i]) < m.radarDistance(m.radarFixes[j])
+	})
+}
+
+// toggleAirways shows or hides the airway overlay in radar mode.
+func (m *model) toggleAirways() {
+	m.radarAirways = !m.radarAirways
+	m.loadRadarAirways()
+}
+
+// drawAirway draws an airway segment between two fractional screen
+// positions, with a line character matching its direction. Only empty and
+// ring cells for which inside returns true are drawn on, so the line stops
+// at the edge of the radar and breaks around symbols and labels.
+func drawAirway(grid [][]rune, x1, y1, x2, y2 float64, inside func(x, y int) bool) {
+	char := airwayChar(x2-x1, y2-y1)
+
+	steps := int(2*math.Max(math.Abs(x2-x1), math.Abs(y2-y1))) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Round(x1 + t*(x2-x1)))
+		y := int(math.Round(y1 + t*(y2-y1)))
+		if y < 0 || y >= len(grid) || x < 0 || x >= len(grid[y]) || !inside(x, y) {
+			continue
+		}
+		if c := grid[y][x]; c == ' ' || c == radarRingChar {
+			grid[y][x] = char
+		}
+	}
+}
+
+// airwayChar picks the box-drawing character closest to a line's direction
+// on screen, given its extent in columns and rows.
+func airwayChar(dx, dy float64) rune {
+	// Angle of the line as it looks, with columns scaled to rows, folded
+	// into 0-180°
+	angle := math.Atan2(-dy, dx*radarAspectRatio) * 180 / math.Pi
+	if angle < 0 {
+		angle += 180
+	}
+
+	switch {
+	case angle < 22.5 || angle >= 157.5:
+		return '─'
+	case angle < 67.5:
+		return '╱'
+	case angle < 112.5:
+		return '│'
+	default:
+		return '╲'
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// bellGap separates repeated bells so they can be counted by ear
+const bellGap = 250 * time.Millisecond
+
+// alertBells is how many times the bell rings for each event
+var alertBells = map[tracking.PassEvent]int{
+	tracking.Culmination:      1,
+	tracking.ApproachingLimit: 2,
+	tracking.TargetLost:       3,
+}
+
+// newPassMonitor creates a pass monitor for the current altitude limits.
+func (m model) newPassMonitor() *tracking.PassMonitor {
+	limits := tracking.TrackingLimits{MinAltitude: m.minAlt, MaxAltitude: m.maxAlt}
+	return tracking.NewPassMonitor(limits, m.cfg.Display.GetLimitWarning())
+}
+
+// checkPassEvents feeds the tracked aircraft's elevation to the pass monitor,
+// records the latest event for the status line and returns a command that
+// sounds the alerts (nil when there is nothing to announce).
+func (m *model) checkPassEvents(now time.Time) tea.Cmd {
+	if !m.tracking || m.trackICAO == "" || m.passMonitor == nil {
+		return nil
+	}
+
+	elevation, found := 0.0, false
+	for _, ac := range m.allAircraft {
+		if ac.aircraft.ICAO == m.trackICAO {
+			elevation, found = ac.horiz.Altitude, true
+			break
+		}
+	}
+
+	events := m.passMonitor.Update(elevation, found)
+	if len(events) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.String()
+	}
+	m.lastAlert = fmt.Sprintf("%s %s: %s", now.Local().Format("15:04:05"), m.trackICAO, strings.Join(names, ", "))
+
+	if !m.cfg.Display.AudibleAlerts || m.alertsMuted {
+		return nil
+	}
+	return soundAlerts(events, m.cfg.Display.AlertSoundCommand)
+}
+
+// soundAlerts rings the terminal bell once for culmination, twice when
+// approaching a limit and three times when the target is lost, then runs
+// the optional sound command for each event.
+func soundAlerts(events []tracking.PassEvent, command string) tea.Cmd {
+	return func() tea.Msg {
+		for _, event := range events {
+			for i := 0; i < alertBells[event]; i++ {
+				os.Stdout.WriteString("\a")
+				time.Sleep(bellGap)
+			}
+
+			if fields := strings.Fields(command); len(fields) > 0 {
+				cmd := exec.Command(fields[0], fields[1:]...)
+				cmd.Env = append(os.Environ(), "ADS_BSCOPE_EVENT="+event.String())
+				_ = cmd.Run() // A missing sound player must not disturb tracking
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/autotrack"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// autoRulesPath is the autotracker's rules file, shared by auto-select mode
+const autoRulesPath = "configs/autotracker-rules.json"
+
+// loadAutoRules loads the autotracker's rules for auto-select mode, scoring
+// candidates as configured for the observer and altitude limits. Without
+// them any aircraft within the limits is a candidate, highest first.
+func loadAutoRules(observer coordinates.Observer, limits tracking.TrackingLimits) *autotrack.RuleSet {
+	rules, err := autotrack.LoadRules(autoRulesPath)
+	if err != nil {
+		log.Printf("Warning: %v; auto-select picks the highest aircraft", err)
+		return &autotrack.RuleSet{Rules: []autotrack.Rule{{Name: "highest"}}}
+	}
+
+	scorer, err := rules.Scoring.NewScorer(observer, limits)
+	if err != nil {
+		log.Printf("Warning: %v; auto-select scores by elevation", err)
+		return rules
+	}
+	rules.UseScorer(scorer)
+	return rules
+}
+
+// toggleAutoSelect turns auto-select mode on, tracking the best candidate
+// straight away, or off, leaving the current target tracked.
+func (m *model) toggleAutoSelect(now time.Time) {
+	m.autoSelect = !m.autoSelect
+	if m.autoSelect {
+		m.tracking = false
+		m.updateAutoSelect(now)
+	}
+}
+
+// updateAutoSelect keeps auto-select mode on a target, as the autotracker
+// does: the current one is kept until it leaves the altitude limits, drops
+// out of coverage or reaches the rules' maximum track time, then put on
+// cooldown and the best remaining candidate is tracked.
+func (m *model) updateAutoSelect(now time.Time) {
+	if !m.autoSelect {
+		return
+	}
+
+	if m.tracking && m.trackICAO != "" {
+		ac, found := m.findAircraft(m.trackICAO)
+		expired := m.autoRules.GetMaxTrackDuration() > 0 && now.Sub(m.autoStarted) > m.autoRules.GetMaxTrackDuration()
+		if found && m.withinLimits(ac) && !expired {
+			return
+		}
+		m.autoCooldown[m.trackICAO] = now.Add(m.autoRules.GetCooldown())
+		m.tracking = false
+	}
+
+	// Forget expired cooldowns so the map doesn't grow all session
+	for icao, until := range m.autoCooldown {
+		if now.After(until) {
+			delete(m.autoCooldown, icao)
+		}
+	}
+
+	candidates := make([]autotrack.Candidate, 0, len(m.allAircraft))
+	for _, ac := range m.allAircraft {
+		if !m.withinLimits(ac) {
+			continue
+		}
+		aircraftType := ac.aircraft.AircraftType
+		if ac.flightPlan != nil && ac.flightPlan.AircraftType != "" {
+			aircraftType = ac.flightPlan.AircraftType
+		}
+		candidates = append(candidates, autotrack.Candidate{
+			Aircraft:     ac.aircraft,
+			AircraftType: aircraftType,
+			Horizontal:   ac.horiz,
+			RangeNM:      ac.range_nm,
+		})
+	}
+
+	best, rule, ok := m.autoRules.Select(candidates, func(icao string) bool {
+		return now.Before(m.autoCooldown[icao])
+	})
+	if !ok {
+		return
+	}
+
+	ac, _ := m.findAircraft(best.Aircraft.ICAO)
+	m.trackAircraft(ac)
+	m.autoRule = rule.Name
+	m.autoStarted = now
+
+	// Show the new target selected if the filters let it through
+	for i := range m.aircraft {
+		if m.aircraft[i].aircraft.ICAO == ac.aircraft.ICAO {
+			m.selected = i
+			break
+		}
+	}
+}
+
+// findAircraft returns an aircraft from all aircraft, filtered or not.
+func (m *model) findAircraft(icao string) (aircraftView, bool) {
+	for _, ac := range m.allAircraft {
+		if ac.aircraft.ICAO == icao {
+			return ac, true
+		}
+	}
+	return aircraftView{}, false
+}
+
+// withinLimits reports whether an aircraft is within the altitude limits.
+func (m *model) withinLimits(ac aircraftView) bool {
+	return ac.horiz.Altitude >= m.minAlt && ac.horiz.Altitude <= m.maxAlt
+}
@@ -8,13 +8,17 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/theme"
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // configMenuModel represents the configuration menu state.
 type configMenuModel struct {
-	cfg         *config.Config // Working copy of configuration
-	originalCfg *config.Config // Original config for revert
-	configPath  string         // Path to config file
+	cfg         *config.Config        // Working copy of configuration
+	originalCfg *config.Config        // Original config for revert
+	configPath  string                // Path to config file
+	lookup      config.WaypointLookup // Resolves region center identifiers (may be nil)
+	theme       theme.Theme           // Color theme
 
 	// Navigation state
 	inMainMenu      bool   // True if in main menu, false if in submenu
@@ -23,7 +27,7 @@ type configMenuModel struct {
 	editing         bool   // Whether we're currently editing a field
 	editBuffer      string // Buffer for text editing
 	editingRegion   bool   // Whether we're editing region details
-	regionEditField int    // Which region field is being edited (0=name, 1=lat, 2=lon, 3=radius)
+	regionEditField int    // Which region field is being edited (0=name, 1=lat, 2=lon, 3=radius, 4=center)
 
 	// Status
 	dirty          bool   // Whether config has unsaved changes
@@ -68,7 +72,8 @@ func (s ConfigSection) String() string {
 }
 
 // newConfigMenuModel creates a new configuration menu.
-func newConfigMenuModel(cfg *config.Config, configPath string) configMenuModel {
+// lookup is used to resolve region center identifiers as they are entered.
+func newConfigMenuModel(cfg *config.Config, configPath string, lookup config.WaypointLookup, th theme.Theme) configMenuModel {
 	// Deep copy config for working copy
 	workingCfg := *cfg
 	originalCfg := *cfg
@@ -77,6 +82,8 @@ func newConfigMenuModel(cfg *config.Config, configPath string) configMenuModel {
 		cfg:            &workingCfg,
 		originalCfg:    &originalCfg,
 		configPath:     configPath,
+		lookup:         lookup,
+		theme:          th,
 		inMainMenu:     true, // Start in main menu
 		currentSection: 0,
 		currentField:   0,
@@ -254,7 +261,7 @@ func (m *configMenuModel) navigateUp() {
 		if m.regionEditField > 0 {
 			m.regionEditField--
 		} else {
-			m.regionEditField = 3 // Wrap to radius
+			m.regionEditField = 4 // Wrap to center
 		}
 	} else if m.inMainMenu {
 		// In main menu, navigate sections
@@ -278,7 +285,7 @@ func (m *configMenuModel) navigateUp() {
 func (m *configMenuModel) navigateDown() {
 	if m.editingRegion {
 		// Navigating region fields
-		if m.regionEditField < 3 {
+		if m.regionEditField < 4 {
 			m.regionEditField++
 		} else {
 			m.regionEditField = 0 // Wrap to name
@@ -335,6 +342,8 @@ func (m *configMenuModel) startEditing() {
 			m.editBuffer = fmt.Sprintf("%.4f", region.Longitude)
 		case 3:
 			m.editBuffer = fmt.Sprintf("%.1f", region.RadiusNM)
+		case 4:
+			m.editBuffer = region.Center
 		}
 		m.editing = true
 		m.message = "Editing... (ENTER to save, ESC to cancel)"
@@ -455,6 +464,8 @@ func (m *configMenuModel) saveFieldValue() error {
 				return fmt.Errorf("radius must be between 1 and 500 NM")
 			}
 			m.cfg.ADSB.CollectionRegions[m.currentField].RadiusNM = radius
+		case 4: // Center identifier
+			return m.setRegionCenter(strings.ToUpper(strings.TrimSpace(value)))
 		}
 		return nil
 	}
@@ -519,7 +530,7 @@ func (m *configMenuModel) saveFieldValue() error {
 			if elev < 0 || elev > 10000 {
 				return fmt.Errorf("elevation must be between 0 and 10000m")
 			}
-			m.cfg.Observer.Elevation = elev
+			m.cfg.Observer.Elevation = units.Meters(elev)
 		case 4:
 			m.cfg.Observer.TimeZone = value
 		}
@@ -605,6 +616,32 @@ func (m *configMenuModel) saveFieldValue() error {
 	return nil
 }
 
+// setRegionCenter sets the selected region's center identifier and resolves
+// it to coordinates. An empty identifier clears the center.
+func (m *configMenuModel) setRegionCenter(identifier string) error {
+	region := &m.cfg.ADSB.CollectionRegions[m.currentField]
+	if identifier == "" {
+		region.Center = ""
+		return nil
+	}
+	if m.lookup == nil {
+		return fmt.Errorf("waypoint database not available")
+	}
+
+	lat, lon, found, err := m.lookup(identifier)
+	if err != nil {
+		return fmt.Errorf("failed to look up %s: %v", identifier, err)
+	}
+	if !found {
+		return fmt.Errorf("airport or waypoint %s not found", identifier)
+	}
+
+	region.Center = identifier
+	region.Latitude = lat
+	region.Longitude = lon
+	return nil
+}
+
 // saveConfig saves the configuration to file.
 func (m *configMenuModel) saveConfig() tea.Cmd {
 	return func() tea.Msg {
@@ -721,7 +758,7 @@ func (m configMenuModel) View() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(lipgloss.Color(m.theme.Header)).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderBottom(true).
 		Padding(0, 1)
@@ -730,7 +767,7 @@ func (m configMenuModel) View() string {
 	s.WriteString("\n\n")
 
 	// Controls - different for main menu vs submenu
-	controlsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	controlsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border))
 	if m.inMainMenu {
 		s.WriteString(controlsStyle.Render("[↑/↓] Navigate  [ENTER] Select  [S] Save  [R] Reload  [D] Defaults  [ESC] Exit"))
 	} else {
@@ -740,16 +777,16 @@ func (m configMenuModel) View() string {
 
 	// Dirty indicator
 	if m.dirty {
-		dirtyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+		dirtyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Warning))
 		s.WriteString(dirtyStyle.Render("Modified: * (unsaved changes)"))
 	}
 	s.WriteString("\n\n")
 
 	// Status message
 	if m.message != "" {
-		msgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+		msgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.OK))
 		if m.messageIsError {
-			msgStyle = msgStyle.Foreground(lipgloss.Color("196"))
+			msgStyle = msgStyle.Foreground(lipgloss.Color(m.theme.Error))
 		}
 		s.WriteString(msgStyle.Render(m.message))
 		s.WriteString("\n\n")
@@ -769,7 +806,7 @@ func (m configMenuModel) View() string {
 func (m *configMenuModel) renderMainMenu() string {
 	var s strings.Builder
 
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Accent))
 	s.WriteString(headerStyle.Render("━━━ CONFIGURATION SECTIONS ━━━"))
 	s.WriteString("\n\n")
 
@@ -795,13 +832,13 @@ func (m *configMenuModel) renderMainMenu() string {
 		}
 
 		// Section name style
-		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Text))
 		if selected {
-			nameStyle = nameStyle.Bold(true).Foreground(lipgloss.Color("51"))
+			nameStyle = nameStyle.Bold(true).Foreground(lipgloss.Color(m.theme.Accent))
 		}
 
 		// Description style
-		descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+		descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Italic(true)
 
 		// Render menu item
 		s.WriteString(prefix)
@@ -813,7 +850,7 @@ func (m *configMenuModel) renderMainMenu() string {
 	}
 
 	// Hint
-	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border)).Italic(true)
 	s.WriteString("\n")
 	s.WriteString(hintStyle.Render("Press ENTER to configure the selected section"))
 	s.WriteString("\n")
@@ -826,7 +863,7 @@ func (m *configMenuModel) renderSubmenu(section ConfigSection) string {
 	var s strings.Builder
 
 	// Section header with breadcrumb
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("51"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Accent))
 	s.WriteString(headerStyle.Render(fmt.Sprintf("━━━ %s ━━━", section.String())))
 	s.WriteString("\n\n")
 
@@ -856,7 +893,7 @@ func (m *configMenuModel) renderSection(section ConfigSection) string {
 	// Section header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("51"))
+		Foreground(lipgloss.Color(m.theme.Accent))
 	s.WriteString(headerStyle.Render(fmt.Sprintf("━━━ %s ━━━", section.String())))
 	s.WriteString("\n")
 
@@ -947,7 +984,7 @@ func (m *configMenuModel) renderRegionsSubmenu(s *strings.Builder) {
 		return
 	}
 
-	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Italic(true)
 	s.WriteString(hintStyle.Render("Collection regions allow fetching aircraft from multiple areas."))
 	s.WriteString("\n")
 	s.WriteString(hintStyle.Render("[SPACE] Toggle  [ENTER] Edit  [O] Set Observer  [X] Delete"))
@@ -960,6 +997,9 @@ func (m *configMenuModel) renderRegionsSubmenu(s *strings.Builder) {
 		}
 		label := fmt.Sprintf("%s %s", checkbox, region.Name)
 		details := fmt.Sprintf("    %.4f°N, %.4f°W, Radius: %.0f NM", region.Latitude, region.Longitude, region.RadiusNM)
+		if region.Center != "" {
+			details = fmt.Sprintf("    %s (%.4f°N, %.4f°W), Radius: %.0f NM", region.Center, region.Latitude, region.Longitude, region.RadiusNM)
+		}
 
 		selected := i == m.currentField
 		prefix := "  "
@@ -967,9 +1007,9 @@ func (m *configMenuModel) renderRegionsSubmenu(s *strings.Builder) {
 			prefix = "▸ "
 		}
 
-		fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+		fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Text))
 		if selected {
-			fieldStyle = fieldStyle.Background(lipgloss.Color("237"))
+			fieldStyle = fieldStyle.Background(lipgloss.Color(m.theme.Highlight))
 		}
 
 		s.WriteString(fieldStyle.Render(prefix + label))
@@ -984,9 +1024,9 @@ func (m *configMenuModel) renderRegionsSubmenu(s *strings.Builder) {
 	if selected {
 		prefix = "▸ "
 	}
-	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.OK))
 	if selected {
-		fieldStyle = fieldStyle.Background(lipgloss.Color("237"))
+		fieldStyle = fieldStyle.Background(lipgloss.Color(m.theme.Highlight))
 	}
 	s.WriteString(fieldStyle.Render(prefix + "[+] Add New Region"))
 	s.WriteString("\n")
@@ -1000,7 +1040,7 @@ func (m *configMenuModel) renderDatabaseSubmenu(s *strings.Builder) {
 	m.renderFieldWithTooltip(s, 3, "Database", m.cfg.Database.Database, "Database name", "Default: adsbscope", true)
 	m.renderFieldWithTooltip(s, 4, "Username", m.cfg.Database.Username, "Database username", "Default: adsbscope", true)
 	s.WriteString("\n")
-	readOnlyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	readOnlyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border)).Italic(true)
 	s.WriteString(readOnlyStyle.Render("  ⚠️  Database settings are read-only. Edit via environment variables:"))
 	s.WriteString("\n")
 	s.WriteString(readOnlyStyle.Render("  ADS_BSCOPE_DB_HOST, ADS_BSCOPE_DB_PASSWORD"))
@@ -1052,7 +1092,7 @@ func (m *configMenuModel) renderDatabaseSection(s *strings.Builder) {
 	m.renderField(s, 3, "Database", m.cfg.Database.Database, true)
 	m.renderField(s, 4, "Username", m.cfg.Database.Username, true)
 	s.WriteString("\n")
-	readOnlyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	readOnlyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border)).Italic(true)
 	s.WriteString(readOnlyStyle.Render("  (Edit via environment variables or config file)"))
 	s.WriteString("\n")
 }
@@ -1068,12 +1108,12 @@ func (m *configMenuModel) renderField(s *strings.Builder, fieldIndex int, label,
 	}
 
 	// Formatting
-	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Text))
 	if readOnly {
-		fieldStyle = fieldStyle.Foreground(lipgloss.Color("240"))
+		fieldStyle = fieldStyle.Foreground(lipgloss.Color(m.theme.Border))
 	}
 	if selected && !readOnly {
-		fieldStyle = fieldStyle.Background(lipgloss.Color("237"))
+		fieldStyle = fieldStyle.Background(lipgloss.Color(m.theme.Highlight))
 	}
 
 	// If editing this field, show edit buffer
@@ -1098,7 +1138,7 @@ func (m *configMenuModel) renderField(s *strings.Builder, fieldIndex int, label,
 func (m *configMenuModel) renderRegionEditor(s *strings.Builder) {
 	region := m.cfg.ADSB.CollectionRegions[m.currentField]
 
-	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Italic(true)
 	s.WriteString(hintStyle.Render(fmt.Sprintf("Editing Region: %s", region.Name)))
 	s.WriteString("\n\n")
 
@@ -1113,6 +1153,7 @@ func (m *configMenuModel) renderRegionEditor(s *strings.Builder) {
 		{"Latitude", fmt.Sprintf("%.4f", region.Latitude), "Center latitude in decimal degrees", "Range: -90 to +90"},
 		{"Longitude", fmt.Sprintf("%.4f", region.Longitude), "Center longitude in decimal degrees", "Range: -180 to +180"},
 		{"Radius", fmt.Sprintf("%.1f NM", region.RadiusNM), "Collection radius in nautical miles", "Range: 1 to 500"},
+		{"Center", region.Center, "Airport or waypoint identifier (overrides lat/lon, blank to clear)", "Example: KCLT"},
 	}
 
 	for i, field := range fields {
@@ -1125,9 +1166,9 @@ func (m *configMenuModel) renderRegionEditor(s *strings.Builder) {
 		}
 
 		// Field style
-		fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+		fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Text))
 		if selected {
-			fieldStyle = fieldStyle.Bold(true).Foreground(lipgloss.Color("51"))
+			fieldStyle = fieldStyle.Bold(true).Foreground(lipgloss.Color(m.theme.Accent))
 		}
 
 		// If editing this field, show edit buffer
@@ -1143,7 +1184,7 @@ func (m *configMenuModel) renderRegionEditor(s *strings.Builder) {
 
 		// Show tooltip for selected field
 		if selected {
-			tooltipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+			tooltipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Italic(true)
 			s.WriteString("    ")
 			s.WriteString(tooltipStyle.Render("• " + field.description))
 			s.WriteString("\n")
@@ -1167,12 +1208,12 @@ func (m *configMenuModel) renderFieldWithTooltip(s *strings.Builder, fieldIndex
 	}
 
 	// Field style
-	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	fieldStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Text))
 	if readOnly {
-		fieldStyle = fieldStyle.Foreground(lipgloss.Color("240"))
+		fieldStyle = fieldStyle.Foreground(lipgloss.Color(m.theme.Border))
 	}
 	if selected && !readOnly {
-		fieldStyle = fieldStyle.Bold(true).Foreground(lipgloss.Color("51"))
+		fieldStyle = fieldStyle.Bold(true).Foreground(lipgloss.Color(m.theme.Accent))
 	}
 
 	// If editing this field, show edit buffer
@@ -1188,7 +1229,7 @@ func (m *configMenuModel) renderFieldWithTooltip(s *strings.Builder, fieldIndex
 
 	// Show tooltip for selected field
 	if selected {
-		tooltipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+		tooltipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Italic(true)
 		s.WriteString("    ")
 		s.WriteString(tooltipStyle.Render("• " + description))
 		s.WriteString("\n")
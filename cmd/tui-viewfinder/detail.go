@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// detailSparklineWidth is the width of the pass profile in the detail popup
+const detailSparklineWidth = 24
+
+// Callsign patterns: airline flights ("DAL123") and US registrations ("N123AB")
+var (
+	airlineCallsignPattern = regexp.MustCompile(`^([A-Z]{3})[0-9]`)
+	registrationPattern    = regexp.MustCompile(`^N[1-9][0-9A-Z]{0,4}$`)
+)
+
+// callsignAirline returns the airline for an airline callsign, or "".
+func callsignAirline(callsign string) string {
+	match := airlineCallsignPattern.FindStringSubmatch(callsign)
+	if match == nil {
+		return ""
+	}
+	if name, ok := adsb.AirlineName(match[1]); ok {
+		return fmt.Sprintf("%s (%s)", name, match[1])
+	}
+	return match[1]
+}
+
+// callsignRegistration returns the callsign if it is a registration
+// (general aviation flies under its tail number), or "".
+func callsignRegistration(callsign string) string {
+	if registrationPattern.MatchString(callsign) {
+		return callsign
+	}
+	return ""
+}
+
+// detailAircraft returns the aircraft shown in the detail popup.
+func (m model) detailAircraft() (aircraftView, bool) {
+	for _, ac := range m.allAircraft {
+		if ac.aircraft.ICAO == m.detailICAO {
+			return ac, true
+		}
+	}
+	return aircraftView{}, false
+}
+
+// renderDetail renders the detail popup for the selected aircraft.
+func (m model) renderDetail() string {
+	var d strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+
+	field := func(label, value string) {
+		if value == "" {
+			value = "—"
+		}
+		d.WriteString(labelStyle.Render(fmt.Sprintf("%-14s", label)))
+		d.WriteString(value)
+		d.WriteString("\n")
+	}
+
+	ac, ok := m.detailAircraft()
+	if !ok {
+		d.WriteString(headerStyle.Render(m.detailICAO))
+		d.WriteString("\n\n")
+		d.WriteString(m.locale.T("detail.gone") + "\n\n")
+		d.WriteString(helpStyle.Render(m.locale.T("detail.help")))
+		return m.placePopup(d.String())
+	}
+
+	callsign := ac.aircraft.Callsign
+	if callsign == "" {
+		callsign = ac.aircraft.ICAO
+	}
+	d.WriteString(headerStyle.Render(fmt.Sprintf("%s  (%s)", callsign, ac.aircraft.ICAO)))
+	d.WriteString("\n\n")
+
+	// Identity
+	field(m.locale.T("detail.registration"), callsignRegistration(ac.aircraft.Callsign))
+	field(m.locale.T("detail.airline"), callsignAirline(ac.aircraft.Callsign))
+	country, _ := adsb.CountryOf(ac.aircraft.ICAO)
+	field(m.locale.T("detail.country"), strings.TrimSpace(country.Flag()+" "+country.Name))
+	aircraftType := ac.aircraft.AircraftType
+	if ac.flightPlan != nil && ac.flightPlan.AircraftType != "" {
+		aircraftType = ac.flightPlan.AircraftType
+	}
+	field(m.locale.T("detail.type"), aircraftType)
+	field(m.locale.T("detail.squawk"), ac.aircraft.Squawk)
+	field(m.locale.T("detail.source"), ac.aircraft.Source)
+	d.WriteString("\n")
+
+	// Flight plan
+	d.WriteString(headerStyle.Render(m.locale.T("detail.flightPlan")))
+	d.WriteString("\n")
+	if ac.flightPlan == nil {
+		d.WriteString(labelStyle.Render(m.locale.T("detail.noFlightPlan")))
+		d.WriteString("\n")
+	} else {
+		fp := ac.flightPlan
+		field(m.locale.T("detail.origin"), fp.DepartureICAO)
+		field(m.locale.T("detail.destination"), fp.ArrivalICAO)
+		if fp.FiledAltitude > 0 {
+			field(m.locale.T("detail.filedAltitude"), m.locale.Altitude(units.Feet(fp.FiledAltitude)))
+		}
+		if !fp.ETA.IsZero() {
+			field(m.locale.T("detail.eta"), fp.ETA.Local().Format("15:04 MST"))
+		}
+		d.WriteString(m.renderDetailWaypoints(ac))
+	}
+	d.WriteString("\n")
+
+	// Prediction diagnostics
+	d.WriteString(headerStyle.Render(m.locale.T("detail.prediction")))
+	d.WriteString("\n")
+	mode := m.locale.T("detail.mode.live")
+	switch ac.predictionMode {
+	case "waypoint":
+		mode = m.locale.T("detail.mode.waypoint")
+	case "airway":
+		mode = m.locale.T("detail.mode.airway", ac.matchedAirway)
+	case "deadreckoning":
+		mode = m.locale.T("detail.mode.deadreckoning")
+	case "hold":
+		mode = m.locale.T("detail.mode.hold")
+	}
+	field(m.locale.T("detail.mode"), mode)
+	field(m.locale.T("detail.phase"), m.phaseName(ac.phase))
+	if ac.hold != nil {
+		key := "detail.hold.left"
+		if ac.hold.RightTurns {
+			key = "detail.hold.right"
+		}
+		field(m.locale.T("detail.hold"), m.locale.T(key, ac.hold.CourseDeg, m.locale.Distance(ac.hold.LegNM, 1)))
+	}
+	field(m.locale.T("detail.dataAge"), fmt.Sprintf("%.0fs", ac.age))
+	field(m.locale.T("detail.confidence"), m.locale.Number(ac.confidence*100, 0)+"% ("+
+		tracking.FormatExpectedError(m.calibration.ExpectedError(ac.confidence))+")")
+	reported := coordinates.Geographic{Latitude: ac.aircraft.Latitude, Longitude: ac.aircraft.Longitude}
+	field(m.locale.T("detail.reported"), fmt.Sprintf("%.4f°, %.4f°", ac.aircraft.Latitude, ac.aircraft.Longitude))
+	if ac.predictionMode != "" {
+		field(m.locale.T("detail.predicted"), m.locale.T("detail.predictedFrom",
+			ac.position.Latitude, ac.position.Longitude,
+			m.locale.Distance(coordinates.DistanceNauticalMiles(reported, ac.position), 1)))
+	}
+	field(m.locale.T("detail.motion"), fmt.Sprintf("%s  %s  %03.0f°  %s",
+		m.locale.Altitude(ac.aircraft.Altitude), m.locale.Speed(ac.aircraft.GroundSpeed),
+		ac.aircraft.Track, m.locale.VerticalRate(ac.aircraft.VerticalRate)))
+	field(m.locale.T("detail.sky"), m.locale.T("detail.skyPosition",
+		m.locale.Number(ac.horiz.Azimuth, 1), m.locale.Number(ac.horiz.Altitude, 1), m.locale.Distance(ac.range_nm, 1)))
+	field(m.locale.T("detail.pass"), m.detailPassProfile(ac))
+
+	// Autopilot settings from the local receiver
+	if m.beast != nil {
+		d.WriteString("\n")
+		d.WriteString(headerStyle.Render(m.locale.T("detail.intent")))
+		d.WriteString("\n")
+		intent, ok := m.beast.Intent(ac.aircraft.ICAO)
+		switch {
+		case ok:
+			heading := ""
+			if intent.SelectedHeading != nil {
+				heading = fmt.Sprintf("%03.0f°", *intent.SelectedHeading)
+			}
+			field(m.locale.T("detail.selectedAltitude"), m.detailSelectedAltitude(intent))
+			field(m.locale.T("detail.selectedHeading"), heading)
+			field(m.locale.T("detail.airspeed"), m.detailAirspeed(intent))
+		case m.beast.Err() != nil:
+			d.WriteString(labelStyle.Render(m.beast.Err().Error()))
+			d.WriteString("\n")
+		default:
+			d.WriteString(labelStyle.Render(m.locale.T("detail.noIntent")))
+			d.WriteString("\n")
+		}
+	}
+
+	d.WriteString("\n")
+	d.WriteString(helpStyle.Render(m.locale.T("detail.help")))
+
+	return m.placePopup(d.String())
+}
+
+// detailSelectedAltitude formats the autopilot's selected altitude and where
+// it was set, or "" if not heard.
+func (m model) detailSelectedAltitude(intent adsb.Intent) string {
+	if intent.SelectedAltitude == nil {
+		return ""
+	}
+	source := "MCP"
+	if intent.SelectedByFMS {
+		source = "FMS"
+	}
+	return fmt.Sprintf("%s (%s)", m.locale.Altitude(*intent.SelectedAltitude), source)
+}
+
+// detailAirspeed formats the indicated and true airspeeds heard, or "".
+func (m model) detailAirspeed(intent adsb.Intent) string {
+	var speeds []string
+	if intent.IAS != nil {
+		speeds = append(speeds, "IAS "+m.locale.Speed(*intent.IAS))
+	}
+	if intent.TAS != nil {
+		speeds = append(speeds, "TAS "+m.locale.Speed(*intent.TAS))
+	}
+	return strings.Join(speeds, "  ")
+}
+
+// phaseName returns a flight phase for display, or "" if unknown.
+func (m model) phaseName(phase tracking.FlightPhase) string {
+	switch phase {
+	case tracking.PhaseClimb:
+		return m.locale.T("phase.climb")
+	case tracking.PhaseCruise:
+		return m.locale.T("phase.cruise")
+	case tracking.PhaseDescent:
+		return m.locale.T("phase.descent")
+	case tracking.PhaseApproach:
+		return m.locale.T("phase.approach")
+	}
+	return ""
+}
+
+// detailPassProfile summarises the aircraft's next pass as an elevation
+// sparkline with the peak.
+func (m model) detailPassProfile(ac aircraftView) string {
+	limits := tracking.TrackingLimitsFromConfig(m.minAlt, m.maxAlt)
+	profile, ok := planner.PassProfile(ac.aircraft, m.observer, time.Now().UTC(), planner.DefaultHorizon, planner.DefaultInterval, limits)
+	if !ok {
+		return m.locale.T("detail.pass.none", m.minAlt, planner.DefaultHorizon.Minutes())
+	}
+	return m.locale.T("detail.pass.peak",
+		planner.Sparkline(profile.Samples, detailSparklineWidth),
+		profile.Pass.MaxElevation, profile.Pass.Peak.Local().Format("15:04:05"))
+}
+
+// renderDetailWaypoints renders the flight plan's waypoints, marking passed
+// ones and the next one. Long routes show a window around the next waypoint.
+func (m model) renderDetailWaypoints(ac aircraftView) string {
+	var w strings.Builder
+
+	if len(ac.waypoints) == 0 {
+		w.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Render(m.locale.T("detail.routeUnresolved")))
+		w.WriteString("\n")
+		return w.String()
+	}
+
+	passedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+	nextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected)).Bold(true)
+
+	// Leave room for the rest of the popup
+	maxRows := max(m.height-32, 3)
+	next := len(ac.waypoints)
+	for i, wp := range ac.waypoints {
+		if !wp.Passed {
+			next = i
+			break
+		}
+	}
+	start := max(next-maxRows/2, 0)
+	end := min(start+maxRows, len(ac.waypoints))
+	start = max(end-maxRows, 0)
+
+	if start > 0 {
+		w.WriteString(passedStyle.Render(m.locale.T("detail.earlier", start)))
+		w.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		wp := ac.waypoints[i]
+		switch {
+		case wp.Passed:
+			w.WriteString(passedStyle.Render(fmt.Sprintf("  ✓ %s", wp.Name)))
+		case i == next:
+			w.WriteString(nextStyle.Render(fmt.Sprintf("  → %s", wp.Name)))
+		default:
+			w.WriteString(fmt.Sprintf("    %s", wp.Name))
+		}
+		w.WriteString("\n")
+	}
+	if end < len(ac.waypoints) {
+		w.WriteString(passedStyle.Render(m.locale.T("detail.more", len(ac.waypoints)-end)))
+		w.WriteString("\n")
+	}
+
+	return w.String()
+}
+
+// placePopup draws content in a bordered box centred on the screen.
+func (m model) placePopup(content string) string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.Header)).
+		Padding(0, 2).
+		Render(strings.TrimRight(content, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
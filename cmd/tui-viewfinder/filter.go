@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// minAltitudeSteps are the values the "above N degrees" filter cycles through
+// (0 = off).
+var minAltitudeSteps = []float64{0, 10, 20, 30, 45}
+
+// aircraftFilter narrows the aircraft shown in the list and views.
+type aircraftFilter struct {
+	query       string  // Matches callsign, ICAO, aircraft type or operator; see parseQuery
+	airborne    bool    // Hide aircraft on the ground
+	minAltitude float64 // Hide aircraft below this elevation (degrees, 0 = off)
+	approaching bool    // Hide aircraft moving away from the observer
+}
+
+// active reports whether any filter is set.
+func (f aircraftFilter) active() bool {
+	return f.query != "" || f.airborne || f.minAltitude > 0 || f.approaching
+}
+
+// matches reports whether an aircraft passes the filter.
+func (f aircraftFilter) matches(ac aircraftView, observer coordinates.Observer) bool {
+	if f.query != "" {
+		text, fleet := parseQuery(f.query)
+
+		// Prefer the type reported by the feed to the filed one
+		aircraft := ac.aircraft
+		if aircraft.AircraftType == "" && ac.flightPlan != nil {
+			aircraft.AircraftType = ac.flightPlan.AircraftType
+		}
+		if !fleet.Matches(aircraft) {
+			return false
+		}
+
+		if text != "" {
+			query := strings.ToUpper(text)
+			operator, _ := adsb.AirlineName(adsb.Operator(aircraft.Callsign))
+			if !strings.Contains(strings.ToUpper(aircraft.Callsign), query) &&
+				!strings.Contains(strings.ToUpper(aircraft.ICAO), query) &&
+				!strings.Contains(strings.ToUpper(aircraft.AircraftType), query) &&
+				!strings.Contains(strings.ToUpper(operator), query) {
+				return false
+			}
+		}
+	}
+
+	// Ground reports have zero altitude
+	if f.airborne && ac.aircraft.Altitude <= 0 {
+		return false
+	}
+
+	if f.minAltitude > 0 && ac.horiz.Altitude < f.minAltitude {
+		return false
+	}
+
+	if f.approaching && !isApproaching(ac, observer) {
+		return false
+	}
+
+	return true
+}
+
+// parseQuery splits a search query into fleet filter terms and free text.
+// "op:fedex", "type:747" and "cat:widebody" select an operator, type family
+// or category (repeat a term to allow several); the remaining words are
+// matched against callsign, ICAO, aircraft type and operator name.
+func parseQuery(query string) (string, adsb.FleetFilter) {
+	var fleet adsb.FleetFilter
+	var text []string
+	for _, word := range strings.Fields(query) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			text = append(text, word)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "op":
+			fleet.Operators = append(fleet.Operators, value)
+		case "type":
+			fleet.TypeFamilies = append(fleet.TypeFamilies, value)
+		case "cat":
+			fleet.Categories = append(fleet.Categories, value)
+		default:
+			text = append(text, word)
+		}
+	}
+	return strings.Join(text, " "), fleet
+}
+
+// isApproaching reports whether an aircraft's track points towards the
+// observer (within 90°), i.e. its range is decreasing.
+func isApproaching(ac aircraftView, observer coordinates.Observer) bool {
+	if ac.aircraft.GroundSpeed <= 0 {
+		return false
+	}
+	toObserver := coordinates.Bearing(ac.position, observer.Location)
+	return math.Cos((ac.aircraft.Track-toObserver)*math.Pi/180.0) > 0
+}
+
+// applyFilters rebuilds the shown aircraft from all aircraft, keeping the
+// selected aircraft selected if it still matches.
+func (m *model) applyFilters() {
+	selectedICAO := ""
+	if m.selected < len(m.aircraft) {
+		selectedICAO = m.aircraft[m.selected].aircraft.ICAO
+	}
+
+	m.aircraft = make([]aircraftView, 0, len(m.allAircraft))
+	m.selected = 0
+	for _, ac := range m.allAircraft {
+		if !m.filter.matches(ac, m.observer) {
+			continue
+		}
+		if ac.aircraft.ICAO == selectedICAO {
+			m.selected = len(m.aircraft)
+		}
+		m.aircraft = append(m.aircraft, ac)
+	}
+}
+
+// handleSearchKey handles keys while typing a search query. The list is
+// filtered as the query is typed.
+func (m model) handleSearchKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter":
+		// Keep the query and return to normal controls
+		m.searching = false
+	case "esc":
+		// Clear the query
+		m.searching = false
+		m.filter.query = ""
+	case "backspace":
+		if len(m.filter.query) > 0 {
+			m.filter.query = m.filter.query[:len(m.filter.query)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			m.filter.query += msg.String()
+		}
+	}
+
+	m.applyFilters()
+	return m, nil
+}
+
+// cycleMinAltitude advances the "above N degrees" filter to the next step.
+func (m *model) cycleMinAltitude() {
+	next := minAltitudeSteps[0]
+	for i, step := range minAltitudeSteps {
+		if step == m.filter.minAltitude && i+1 < len(minAltitudeSteps) {
+			next = minAltitudeSteps[i+1]
+			break
+		}
+	}
+	m.filter.minAltitude = next
+}
+
+// renderFilterBar renders the search query and active quick filters.
+// Returns "" when no filter is set and no search is being typed.
+func (m model) renderFilterBar() string {
+	if !m.searching && !m.filter.active() {
+		return ""
+	}
+
+	searchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected))
+	tagStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Title))
+
+	var bar strings.Builder
+	if m.searching || m.filter.query != "" {
+		cursor := ""
+		if m.searching {
+			cursor = "_"
+		}
+		bar.WriteString(searchStyle.Render("/" + m.filter.query + cursor))
+		bar.WriteString("  ")
+	}
+	if m.filter.airborne {
+		bar.WriteString(tagStyle.Render("[Airborne]"))
+		bar.WriteString(" ")
+	}
+	if m.filter.minAltitude > 0 {
+		bar.WriteString(tagStyle.Render(fmt.Sprintf("[Alt ≥ %.0f°]", m.filter.minAltitude)))
+		bar.WriteString(" ")
+	}
+	if m.filter.approaching {
+		bar.WriteString(tagStyle.Render("[Approaching]"))
+		bar.WriteString(" ")
+	}
+	bar.WriteString(fmt.Sprintf(" %d of %d", len(m.aircraft), len(m.allAircraft)))
+
+	return bar.String()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// updateHandoff predicts when the tracked aircraft leaves the altitude
+// limits and which aircraft to track next.
+func (m *model) updateHandoff(now time.Time) {
+	m.handoffOK = false
+	if !m.tracking || m.trackICAO == "" {
+		return
+	}
+	current, found := m.findAircraft(m.trackICAO)
+	if !found {
+		return
+	}
+
+	others := make([]adsb.Aircraft, len(m.allAircraft))
+	for i, ac := range m.allAircraft {
+		others[i] = ac.aircraft
+	}
+	limits := tracking.TrackingLimits{MinAltitude: m.minAlt, MaxAltitude: m.maxAlt}
+	m.handoff, m.handoffOK = planner.PlanHandoff(current.aircraft, others, m.observer, now, planner.DefaultHorizon, limits)
+}
+
+// renderHandoff renders the countdown to the tracked aircraft leaving the
+// limits and the recommended next target, "" when there is no prediction.
+func (m model) renderHandoff() string {
+	if !m.handoffOK {
+		return ""
+	}
+
+	var lines []string
+	if m.handoff.LeavesLimits {
+		lines = append(lines, m.locale.T("handoff.leaves", shortDuration(m.handoff.Leaves)))
+	} else {
+		lines = append(lines, m.locale.T("handoff.staysUp", planner.DefaultHorizon.Minutes()))
+	}
+
+	next := m.handoff.Next
+	switch {
+	case next == nil:
+		lines = append(lines, m.locale.T("handoff.none"))
+	case next.Wait > 0:
+		lines = append(lines, m.locale.T("handoff.nextIn", callsignOrICAO(next.Aircraft), shortDuration(next.Wait)))
+	default:
+		lines = append(lines, m.locale.T("handoff.next", callsignOrICAO(next.Aircraft), m.locale.Number(next.Pass.MaxElevation, 0)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// callsignOrICAO returns an aircraft's callsign, or its ICAO address if it
+// has none.
+func callsignOrICAO(ac adsb.Aircraft) string {
+	if callsign := strings.TrimSpace(ac.Callsign); callsign != "" {
+		return callsign
+	}
+	return ac.ICAO
+}
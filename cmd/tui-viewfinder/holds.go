@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// holdCheckInterval is how often position histories are re-examined for
+// holds. A hold takes minutes to fly, so there's no need to look on every
+// refresh.
+const holdCheckInterval = 30 * time.Second
+
+// updateHolds re-detects which aircraft are flying a hold, at most every
+// holdCheckInterval. Errors are ignored: the previous holds are kept.
+func (m *model) updateHolds(ctx context.Context, aircraft []adsb.Aircraft, now time.Time) {
+	if now.Sub(m.holdsCheckedAt) < holdCheckInterval {
+		return
+	}
+
+	icaos := make([]string, len(aircraft))
+	for i, ac := range aircraft {
+		icaos[i] = ac.ICAO
+	}
+
+	tracks, err := m.repo.GetRecentTracks(ctx, icaos, now.Add(-tracking.HoldHistory))
+	if err != nil {
+		return
+	}
+
+	m.holds = make(map[string]tracking.Hold)
+	for icao, track := range tracks {
+		if hold, ok := tracking.DetectHold(track); ok {
+			m.holds[icao] = hold
+		}
+	}
+	m.holdsCheckedAt = now
+}
+
+// holdFor returns the hold an aircraft is flying, or nil.
+func (m *model) holdFor(icao string) *tracking.Hold {
+	hold, ok := m.holds[icao]
+	if !ok {
+		return nil
+	}
+	return &hold
+}
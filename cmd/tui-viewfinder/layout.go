@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Minimum terminal size for the sky and radar views
+const (
+	minTermWidth  = 80
+	minTermHeight = 24
+)
+
+// Fixed parts of the layout
+const (
+	headerLines       = 2  // Title and blank line
+	helpLines         = 1  // Controls line under the sky view
+	legendWidth       = 24 // Legend column next to the sky view, with spacing
+	radarInfoWidth    = 36 // Info column next to the radar view, with spacing
+	listOverheadLines = 6  // List header, filter bar, flight plan, telescope and alert lines
+	minListRows       = 3
+	maxListRows       = 10
+	minViewWidth      = 40
+	minViewHeight     = 10
+)
+
+// resize recomputes the layout for a new terminal size.
+func (m *model) resize(width, height int) {
+	m.width = width
+	m.height = height
+
+	m.skyWidth = max(width-legendWidth, minViewWidth)
+	m.skyHeight = max(height-headerLines-2-m.listLines()-helpLines, minViewHeight)
+}
+
+// listRows returns how many aircraft fit in the aircraft list.
+func (m model) listRows() int {
+	rows := m.height / 6
+	if rows < minListRows {
+		return minListRows
+	}
+	if rows > maxListRows {
+		return maxListRows
+	}
+	return rows
+}
+
+// listLines returns the height of the aircraft list panel.
+func (m model) listLines() int {
+	return m.listRows() + listOverheadLines
+}
+
+// radarSize returns the radar view's width (including borders) and the
+// number of grid rows.
+func (m model) radarSize() (int, int) {
+	width := max(m.width-radarInfoWidth, minViewWidth)
+	height := max(m.height-headerLines-2-m.listLines(), minViewHeight)
+	return width, height
+}
+
+// tooSmall reports whether the terminal is too small to draw the views.
+func (m model) tooSmall() bool {
+	return m.width < minTermWidth || m.height < minTermHeight
+}
+
+// renderTooSmall renders the warning shown when the terminal is too small.
+func (m model) renderTooSmall() string {
+	var s strings.Builder
+
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Warning)).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+
+	s.WriteString(warnStyle.Render("Terminal too small"))
+	s.WriteString("\n\n")
+	s.WriteString(fmt.Sprintf("Current: %dx%d\n", m.width, m.height))
+	s.WriteString(fmt.Sprintf("Needed:  %dx%d\n", minTermWidth, minTermHeight))
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render("Resize the window or press Q to quit"))
+
+	return s.String()
+}
@@ -14,24 +14,15 @@ import (
 
 	"github.com/unklstewy/ads-bscope/internal/db"
 	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/autotrack"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/i18n"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/theme"
 	"github.com/unklstewy/ads-bscope/pkg/tracking"
 )
 
-// Sky viewport dimensions (will be dynamically sized based on terminal)
-var (
-	skyWidth  = 80
-	skyHeight = 30
-)
-
-// Track trail stores recent positions for breadcrumb display
-type trackTrail struct {
-	positions []coordinates.HorizontalCoordinates
-	times     []time.Time
-	maxLength int
-}
-
 // ViewMode represents the current view mode
 type ViewMode int
 
@@ -42,22 +33,43 @@ const (
 )
 
 type model struct {
-	cfg       *config.Config
-	database  *db.DB
-	repo      *db.AircraftRepository
-	fpRepo    *db.FlightPlanRepository
-	observer  coordinates.Observer
-	aircraft  []aircraftView
-	selected  int
-	tracking  bool
-	trackICAO string
-	telesAlt  float64
-	telesAz   float64
-	err       error
-	minAlt    float64
-	maxAlt    float64
-	zoom      float64                // Zoom level: 1.0 = normal, 2.0 = 2x closer
-	trails    map[string]*trackTrail // ICAO -> trail
+	cfg        *config.Config
+	database   *db.DB
+	repo       *db.AircraftRepository
+	fpRepo     *db.FlightPlanRepository
+	watchRepo  *db.WatchRepository
+	watchList  db.WatchList // Every user's watched aircraft, highlighted
+	observer   coordinates.Observer
+	aircraft   []aircraftView
+	selected   int
+	tracking   bool
+	trackICAO  string
+	telesAlt   float64
+	telesAz    float64
+	err        error
+	minAlt     float64
+	maxAlt     float64
+	zoom       float64                // Zoom level: 1.0 = normal, 2.0 = 2x closer
+	trails     map[string]*trackTrail // ICAO -> trail
+	projection SkyProjection          // Sky view projection
+	theme      theme.Theme            // Color theme (T cycles at runtime)
+	locale     i18n.Localizer         // UI language and display units
+
+	// Telescope positions commanded while tracking, shown alongside the
+	// tracked aircraft's trail to judge lag and overshoot
+	commanded trackTrail
+
+	// Local receiver's Beast feed, decoding the autopilot settings shown in
+	// the detail popup; nil unless a local source has a beast_port
+	beast *adsb.BeastClient
+
+	// Aircraft flying holds, re-detected every holdCheckInterval
+	holds          map[string]tracking.Hold
+	holdsCheckedAt time.Time
+
+	// Expected pointing error by prediction confidence, from the latest
+	// calibration of recorded passes
+	calibration tracking.ErrorCalibration
 
 	// Radar mode
 	radarMode    bool
@@ -69,6 +81,19 @@ type model struct {
 	width        int // Terminal width
 	height       int // Terminal height
 
+	// Airports and navaids around the radar centre, nearest first
+	radarWaypoints []db.Waypoint
+
+	// Airway overlay (A toggles in radar mode): the segments around the
+	// radar centre and the named fixes along them, nearest first
+	radarAirways  bool
+	radarSegments []db.AirwaySegment
+	radarFixes    []db.Waypoint
+
+	// Sky viewport dimensions (sized to the terminal, see resize)
+	skyWidth  int
+	skyHeight int
+
 	// View mode and config menu
 	viewMode   ViewMode
 	configMenu *configMenuModel
@@ -77,6 +102,32 @@ type model struct {
 	// Airport selection
 	airportList     []db.Waypoint
 	airportSelected int
+
+	// Detail popup (ICAO of the aircraft shown, "" when closed)
+	detailICAO string
+
+	// Search and quick filters (aircraft holds the filtered allAircraft)
+	allAircraft []aircraftView
+	filter      aircraftFilter
+	searching   bool // Typing a search query
+
+	// Tracking alerts
+	passMonitor *tracking.PassMonitor
+	alertsMuted bool
+	lastAlert   string // Most recent pass event, shown under the list
+
+	// Auto-select mode: track the best candidate by the autotracker's
+	// rules, moving on as each target departs
+	autoSelect   bool
+	autoRules    *autotrack.RuleSet
+	autoRule     string               // Rule the current target matched
+	autoStarted  time.Time            // When the current target was selected
+	autoCooldown map[string]time.Time // When each past target may be picked again
+
+	// When the tracked aircraft leaves the limits and the next target,
+	// predicted every tick while tracking
+	handoff   planner.Handoff
+	handoffOK bool
 }
 
 type aircraftView struct {
@@ -85,12 +136,24 @@ type aircraftView struct {
 	equatorial     coordinates.EquatorialCoordinates
 	range_nm       float64
 	age            float64
-	predictionMode string // "", "waypoint", "airway", "deadreckoning"
+	predictionMode string // "", "hold", "waypoint", "airway", "deadreckoning"
 	matchedAirway  string // For airway predictions
 	flightPlan     *db.FlightPlan
 	nextWaypoint   string
+	waypoints      []tracking.Waypoint    // Flight plan route with passed flags
+	position       coordinates.Geographic // Displayed (possibly predicted) position
+	confidence     float64                // Prediction confidence (1.0 for fresh data)
+	limitWindow    tracking.LimitWindow   // When it enters/leaves the altitude limits
+	entersLimits   bool                   // Whether limitWindow was predicted
+	phase          tracking.FlightPhase   // Climb, cruise, descent or approach
+	hold           *tracking.Hold         // Holding pattern being flown, nil if none
+	watched        bool                   // On a user's watch list
 }
 
+// limitsHorizon is how far ahead aircraft are extrapolated to predict when
+// they enter and leave the altitude limits
+const limitsHorizon = 15 * time.Minute
+
 type tickMsg time.Time
 
 func tick() tea.Cmd {
@@ -106,9 +169,8 @@ func (m model) Init() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		// Update terminal dimensions
-		m.width = msg.Width
-		m.height = msg.Height
+		// Update terminal dimensions and rescale the panels
+		m.resize(msg.Width, msg.Height)
 		// Also update config menu if it exists
 		if m.configMenu != nil {
 			m.configMenu.width = msg.Width
@@ -210,6 +272,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						Altitude:  0,
 					}
 					m.radarMode = true
+					m.loadRadarOverlay()
 					m.viewMode = ViewSky
 					m.airportList = nil
 				}
@@ -249,6 +312,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if radius >= 50 && radius <= 2500 {
 							m.radarRadius = radius
 							m.radarMode = true
+							m.loadRadarOverlay()
 						} else {
 							m.err = fmt.Errorf("radius must be between 50 and 2500 NM")
 						}
@@ -275,6 +339,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Search mode: keys edit the query
+		if m.searching {
+			return m.handleSearchKey(msg)
+		}
+
+		// Detail popup: only close keys apply
+		if m.detailICAO != "" {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc", "i", "q", "enter":
+				m.detailICAO = ""
+			}
+			return m, nil
+		}
+
 		// Clear error on any keypress (but don't quit)
 		if m.err != nil {
 			m.err = nil
@@ -288,7 +368,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "c":
 			// Open config menu
 			m.viewMode = ViewConfigMenu
-			menu := newConfigMenuModel(m.cfg, m.configPath)
+			menu := newConfigMenuModel(m.cfg, m.configPath, newWaypointLookup(m.fpRepo), m.theme)
 			m.configMenu = &menu
 			return m, nil
 		case "r":
@@ -340,61 +420,146 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selected++
 			}
 		case "enter", " ":
-			if len(m.aircraft) > 0 && m.selected < len(m.aircraft) {
-				m.tracking = true
-				m.trackICAO = m.aircraft[m.selected].aircraft.ICAO
-				m.telesAlt = m.aircraft[m.selected].horiz.Altitude
-				m.telesAz = m.aircraft[m.selected].horiz.Azimuth
-			}
+			// Manual selection overrides auto-select
+			m.autoSelect = false
+			m.startTracking()
 		case "s":
 			m.tracking = false
+			m.autoSelect = false
+		case "a":
+			// Show or hide airways and fixes in radar mode, otherwise
+			// toggle auto-select
+			if m.radarMode {
+				m.toggleAirways()
+			} else {
+				m.toggleAutoSelect(time.Now())
+			}
+		case "b":
+			// Mute or unmute audible alerts
+			m.alertsMuted = !m.alertsMuted
+		case "/":
+			// Search by callsign, ICAO, type or operator (see parseQuery)
+			m.searching = true
+		case "1":
+			// Toggle airborne only
+			m.filter.airborne = !m.filter.airborne
+			m.applyFilters()
+		case "2":
+			// Cycle the minimum elevation filter
+			m.cycleMinAltitude()
+			m.applyFilters()
+		case "3":
+			// Toggle approaching only
+			m.filter.approaching = !m.filter.approaching
+			m.applyFilters()
+		case "esc":
+			// Clear search and filters
+			m.filter = aircraftFilter{}
+			m.applyFilters()
+		case "i":
+			// Open the detail popup for the selected aircraft
+			if len(m.aircraft) > 0 && m.selected < len(m.aircraft) {
+				m.detailICAO = m.aircraft[m.selected].aircraft.ICAO
+			}
 		case "+", "=":
 			// Zoom in (max 4x in sky mode, increase radius in radar mode)
 			if m.radarMode {
-				if m.radarRadius < 2500 {
-					m.radarRadius *= 1.5
-					if m.radarRadius > 2500 {
-						m.radarRadius = 2500
-					}
-				}
-			} else if m.zoom < 4.0 {
-				m.zoom *= 1.5
+				m.setRadarRadius(m.radarRadius * 1.5)
+			} else {
+				m.zoomIn()
 			}
 		case "-", "_":
 			// Zoom out (min 0.5x in sky mode, decrease radius in radar mode)
 			if m.radarMode {
-				if m.radarRadius > 50 {
-					m.radarRadius /= 1.5
-					if m.radarRadius < 50 {
-						m.radarRadius = 50
-					}
-				}
-			} else if m.zoom > 0.5 {
-				m.zoom /= 1.5
+				m.setRadarRadius(m.radarRadius / 1.5)
+			} else {
+				m.zoomOut()
 			}
 		case "0":
 			// Reset zoom
 			m.zoom = 1.0
+		case "p":
+			// Toggle between linear and polar sky projections
+			if m.projection == ProjectionLinear {
+				m.projection = ProjectionPolar
+			} else {
+				m.projection = ProjectionLinear
+			}
+		case "t":
+			// Cycle through the color themes
+			m.theme = theme.Next(m.theme.Name)
 		}
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tickMsg:
 		m.updateAircraft()
+		now := time.Now()
+		m.updateAutoSelect(now)
 		if m.tracking && m.trackICAO != "" {
 			// Update telescope position to track selected aircraft
-			for _, ac := range m.aircraft {
+			for _, ac := range m.allAircraft {
 				if ac.aircraft.ICAO == m.trackICAO {
 					m.telesAlt = ac.horiz.Altitude
 					m.telesAz = ac.horiz.Azimuth
+					m.commanded.add(ac.horiz, now)
 					break
 				}
 			}
 		}
-		return m, tick()
+		m.commanded.prune(now.Add(-m.cfg.Display.GetTrailDuration()))
+		m.updateHandoff(now)
+		return m, tea.Batch(tick(), m.checkPassEvents(now))
 	}
 
 	return m, nil
 }
 
+// startTracking starts tracking the selected aircraft.
+func (m *model) startTracking() {
+	if len(m.aircraft) > 0 && m.selected < len(m.aircraft) {
+		m.trackAircraft(m.aircraft[m.selected])
+	}
+}
+
+// trackAircraft starts tracking an aircraft.
+func (m *model) trackAircraft(ac aircraftView) {
+	m.tracking = true
+	m.trackICAO = ac.aircraft.ICAO
+	m.telesAlt = ac.horiz.Altitude
+	m.telesAz = ac.horiz.Azimuth
+	m.commanded = trackTrail{}
+	m.passMonitor = m.newPassMonitor()
+	m.lastAlert = ""
+}
+
+// zoomIn shows less sky: a smaller altitude range in sky mode and a
+// smaller radius in radar mode (max 4x sky zoom, min 50 NM radius).
+func (m *model) zoomIn() {
+	if m.radarMode {
+		m.setRadarRadius(m.radarRadius / 1.5)
+	} else if m.zoom < 4.0 {
+		m.zoom *= 1.5
+	}
+}
+
+// zoomOut shows more sky (min 0.5x sky zoom, max 2500 NM radius).
+func (m *model) zoomOut() {
+	if m.radarMode {
+		m.setRadarRadius(m.radarRadius * 1.5)
+	} else if m.zoom > 0.5 {
+		m.zoom /= 1.5
+	}
+}
+
+// setRadarRadius sets the radar radius, clamped to 50-2500 NM, and reloads
+// the airport overlay for the new area.
+func (m *model) setRadarRadius(radius float64) {
+	m.radarRadius = math.Max(50, math.Min(2500, radius))
+	m.loadRadarOverlay()
+}
+
 func (m *model) updateAircraft() {
 	ctx := context.Background()
 
@@ -414,7 +579,7 @@ func (m *model) updateAircraft() {
 		)
 	} else {
 		// Sky view mode: use observer-relative trackable aircraft
-		aircraftList, err = m.repo.GetTrackableAircraft(ctx)
+		aircraftList, err = m.repo.GetTrackableAircraft(ctx, m.minAlt, m.maxAlt)
 	}
 
 	if err != nil {
@@ -422,12 +587,34 @@ func (m *model) updateAircraft() {
 		return
 	}
 
-	m.aircraft = make([]aircraftView, 0)
+	// Watched aircraft are highlighted; keep the last list if it can't be read
+	if watchList, err := m.watchRepo.All(ctx); err == nil {
+		m.watchList = watchList
+	}
+
+	m.allAircraft = make([]aircraftView, 0)
 	now := time.Now().UTC()
 
+	// Seed trails for newly seen aircraft from the position history
+	m.loadTrails(ctx, aircraftList, now)
+
+	// Holding aircraft are predicted around the hold
+	m.updateHolds(ctx, aircraftList, now)
+
+	// Destinations for flight phase detection (altitude alone without them)
+	destinations, _ := m.fpRepo.GetDestinations(ctx)
+
 	for _, ac := range aircraftList {
 		dataAge := now.Sub(ac.LastSeen).Seconds()
 
+		// Autopilot settings from the local receiver are fresher than the
+		// collector's
+		if m.beast != nil {
+			if intent, ok := m.beast.Intent(ac.ICAO); ok {
+				intent.Apply(&ac)
+			}
+		}
+
 		// Get flight plan if available
 		flightPlan, _ := m.fpRepo.GetFlightPlanByICAO(ctx, ac.ICAO)
 		var waypointList []tracking.Waypoint
@@ -457,14 +644,26 @@ func (m *model) updateAircraft() {
 			}
 		}
 
+		flight := destinations[ac.ICAO].FlightContext()
+		phase := tracking.DetectPhase(ac, flight)
+		hold := m.holdFor(ac.ICAO)
+
 		// Calculate position (with prediction if needed)
 		var acPos coordinates.Geographic
 		var predictionMode string
 		var matchedAirway string
+		confidence := 1.0
 
 		if dataAge > 30 {
 			// Data is stale - use prediction
-			if len(waypointList) > 0 {
+			if hold != nil {
+				// Holding: fly on around the hold rather than off in a
+				// straight line or along a route the aircraft has left
+				predictedPos := tracking.PredictPositionInHold(ac, *hold, now.Add(time.Duration(dataAge*float64(time.Second))))
+				acPos = predictedPos.Position
+				confidence = predictedPos.Confidence
+				predictionMode = "hold"
+			} else if len(waypointList) > 0 {
 				// Waypoint-based prediction
 				predictedPos := tracking.PredictPositionWithWaypoints(
 					ac,
@@ -472,6 +671,7 @@ func (m *model) updateAircraft() {
 					now.Add(time.Duration(dataAge*float64(time.Second))),
 				)
 				acPos = predictedPos.Position
+				confidence = predictedPos.Confidence
 				predictionMode = "waypoint"
 			} else {
 				// Try airway matching
@@ -509,18 +709,21 @@ func (m *model) updateAircraft() {
 							now.Add(time.Duration(dataAge*float64(time.Second))),
 						)
 						acPos = predictedPos.Position
+						confidence = predictedPos.Confidence
 						predictionMode = "airway"
 						matchedAirway = matchedAirwaySeg.AirwayID
 					} else {
 						// Fall back to dead reckoning
-						predictedPos := tracking.PredictPositionWithLatency(ac, dataAge)
+						predictedPos := tracking.PredictPositionInPhase(ac, flight, now.Add(time.Duration(dataAge*float64(time.Second))))
 						acPos = predictedPos.Position
+						confidence = predictedPos.Confidence
 						predictionMode = "deadreckoning"
 					}
 				} else {
 					// Fall back to dead reckoning
-					predictedPos := tracking.PredictPositionWithLatency(ac, dataAge)
+					predictedPos := tracking.PredictPositionInPhase(ac, flight, now.Add(time.Duration(dataAge*float64(time.Second))))
 					acPos = predictedPos.Position
+					confidence = predictedPos.Confidence
 					predictionMode = "deadreckoning"
 				}
 			}
@@ -529,7 +732,7 @@ func (m *model) updateAircraft() {
 			acPos = coordinates.Geographic{
 				Latitude:  ac.Latitude,
 				Longitude: ac.Longitude,
-				Altitude:  ac.Altitude * coordinates.FeetToMeters,
+				Altitude:  ac.Altitude.Meters(),
 			}
 		}
 
@@ -543,22 +746,12 @@ func (m *model) updateAircraft() {
 		}
 
 		// Update track trail
-		if m.trails[ac.ICAO] == nil {
-			m.trails[ac.ICAO] = &trackTrail{
-				positions: make([]coordinates.HorizontalCoordinates, 0),
-				times:     make([]time.Time, 0),
-				maxLength: 10,
-			}
-		}
-		trail := m.trails[ac.ICAO]
-		trail.positions = append(trail.positions, horiz)
-		trail.times = append(trail.times, now)
-		if len(trail.positions) > trail.maxLength {
-			trail.positions = trail.positions[1:]
-			trail.times = trail.times[1:]
-		}
+		m.trailFor(ac.ICAO).add(horiz, now)
+
+		limitWindow, entersLimits := tracking.PredictLimitWindow(ac, m.observer, now, limitsHorizon,
+			tracking.TrackingLimitsFromConfig(m.minAlt, m.maxAlt))
 
-		m.aircraft = append(m.aircraft, aircraftView{
+		m.allAircraft = append(m.allAircraft, aircraftView{
 			aircraft:       ac,
 			horiz:          horiz,
 			equatorial:     equatorial,
@@ -568,8 +761,19 @@ func (m *model) updateAircraft() {
 			matchedAirway:  matchedAirway,
 			flightPlan:     flightPlan,
 			nextWaypoint:   nextWaypoint,
+			waypoints:      waypointList,
+			position:       acPos,
+			confidence:     confidence,
+			limitWindow:    limitWindow,
+			entersLimits:   entersLimits,
+			phase:          phase,
+			hold:           hold,
+			watched:        m.watchList.Watches(ac),
 		})
 	}
+
+	m.pruneTrails(now.Add(-m.cfg.Display.GetTrailDuration()))
+	m.applyFilters()
 }
 
 func (m model) View() string {
@@ -583,50 +787,59 @@ func (m model) View() string {
 		return m.renderAirportSelection()
 	}
 
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+
+	// If the detail popup is open, render it over the view
+	if m.detailICAO != "" {
+		return m.renderDetail()
+	}
+
 	var s strings.Builder
 
 	// Header
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("86")).
-		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color(m.theme.Title)).
+		Background(lipgloss.Color(m.theme.TitleBackground)).
 		Padding(0, 1)
 
-	title := "ADS-B SCOPE TUI VIEWFINDER"
+	title := m.locale.T("title.viewfinder")
 	if m.radarMode {
-		title = "ADS-B SCOPE RADAR MODE"
+		title = m.locale.T("title.radar")
 	}
 	s.WriteString(titleStyle.Render(title))
 	s.WriteString("\n\n")
 
 	// Handle input mode prompts
 	if m.inputMode != "" {
-		promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
-		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
-		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+		promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Header)).Bold(true)
+		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected))
+		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
 
 		if m.inputMode == "airport" {
-			s.WriteString(promptStyle.Render("Enter airport code (e.g., KATL, JFK):"))
+			s.WriteString(promptStyle.Render(m.locale.T("prompt.airport")))
 			s.WriteString("\n")
 			s.WriteString(inputStyle.Render("> " + m.inputBuffer + "_"))
 			s.WriteString("\n\n")
-			s.WriteString(helpStyle.Render("ENTER: Submit  ESC: Cancel"))
+			s.WriteString(helpStyle.Render(m.locale.T("prompt.help")))
 		} else if m.inputMode == "radius" {
-			s.WriteString(promptStyle.Render("Enter radar radius (50-2500 NM):"))
+			s.WriteString(promptStyle.Render(m.locale.T("prompt.radius")))
 			s.WriteString("\n")
 			s.WriteString(inputStyle.Render("> " + m.inputBuffer + "_"))
 			s.WriteString("\n\n")
-			s.WriteString(helpStyle.Render("ENTER: Submit  ESC: Cancel"))
+			s.WriteString(helpStyle.Render(m.locale.T("prompt.help")))
 		}
 		return s.String()
 	}
 
 	if m.err != nil {
-		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		s.WriteString(errStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Error))
+		s.WriteString(errStyle.Render(m.locale.T("error", m.err)))
 		s.WriteString("\n\n")
-		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		s.WriteString(helpStyle.Render("Press SPACE to continue (use 3-letter codes: RDU, ATL, JFK, LAX, ORD)..."))
+		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+		s.WriteString(helpStyle.Render(m.locale.T("error.continue")))
 		return s.String()
 	}
 
@@ -645,11 +858,12 @@ func (m model) View() string {
 			maxLines = len(infoLines)
 		}
 
+		radarWidth, _ := m.radarSize()
 		for i := 0; i < maxLines; i++ {
 			if i < len(radarLines) {
 				s.WriteString(radarLines[i])
 			} else {
-				s.WriteString(strings.Repeat(" ", skyWidth))
+				s.WriteString(strings.Repeat(" ", radarWidth))
 			}
 			s.WriteString("  ") // Spacing
 			if i < len(infoLines) {
@@ -680,7 +894,7 @@ func (m model) View() string {
 			if i < len(skyLines) {
 				s.WriteString(skyLines[i])
 			} else {
-				s.WriteString(strings.Repeat(" ", skyWidth))
+				s.WriteString(strings.Repeat(" ", m.skyWidth))
 			}
 			s.WriteString("  ") // Spacing
 			if i < len(legendLines) {
@@ -694,8 +908,8 @@ func (m model) View() string {
 		s.WriteString("\n")
 
 		// Controls
-		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		s.WriteString(helpStyle.Render("↑/↓: Select  ENTER/SPACE: Track  S: Stop  C: Config  R: Radar  +/-: Zoom  0: Reset  Q: Quit"))
+		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+		s.WriteString(helpStyle.Render(m.locale.T("help.sky")))
 		s.WriteString("\n")
 	}
 
@@ -706,30 +920,35 @@ func (m model) renderSky() string {
 	var sky strings.Builder
 
 	// Draw border
-	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	sky.WriteString(borderStyle.Render("┌" + strings.Repeat("─", skyWidth-2) + "┐"))
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border))
+	sky.WriteString(borderStyle.Render("┌" + strings.Repeat("─", m.skyWidth-2) + "┐"))
 	sky.WriteString("\n")
 
 	// Create sky grid
-	grid := make([][]rune, skyHeight)
+	grid := make([][]rune, m.skyHeight)
 	for i := range grid {
-		grid[i] = make([]rune, skyWidth)
+		grid[i] = make([]rune, m.skyWidth)
 		for j := range grid[i] {
 			grid[i][j] = ' '
 		}
 	}
 
-	// Draw horizon line
-	horizonY := int(float64(skyHeight) * 0.8) // 80% down is horizon
-	for x := 0; x < skyWidth; x++ {
-		grid[horizonY][x] = '·'
-	}
+	if m.projection == ProjectionPolar {
+		// Draw horizon circle, altitude rings and compass points
+		m.drawPolarGrid(grid)
+	} else {
+		// Draw horizon line
+		horizonY := int(float64(m.skyHeight) * 0.8) // 80% down is horizon
+		for x := 0; x < m.skyWidth; x++ {
+			grid[horizonY][x] = '·'
+		}
 
-	// Draw cardinal directions
-	grid[skyHeight-1][skyWidth/4] = 'E'
-	grid[skyHeight-1][skyWidth/2] = 'S'
-	grid[skyHeight-1][skyWidth*3/4] = 'W'
-	grid[skyHeight-1][0] = 'N'
+		// Draw cardinal directions
+		grid[m.skyHeight-1][m.skyWidth/4] = 'E'
+		grid[m.skyHeight-1][m.skyWidth/2] = 'S'
+		grid[m.skyHeight-1][m.skyWidth*3/4] = 'W'
+		grid[m.skyHeight-1][0] = 'N'
+	}
 
 	// Draw range rings (concentric circles at 5, 10, 25, 50 NM)
 	for _, ac := range m.aircraft {
@@ -751,7 +970,7 @@ func (m model) renderSky() string {
 				pos := trail.positions[i]
 				if pos.Altitude >= m.minAlt && pos.Altitude <= m.maxAlt {
 					tx, ty := m.altAzToScreen(pos.Altitude, pos.Azimuth)
-					if tx >= 0 && tx < skyWidth && ty >= 0 && ty < skyHeight {
+					if tx >= 0 && tx < m.skyWidth && ty >= 0 && ty < m.skyHeight {
 						if grid[ty][tx] == ' ' || grid[ty][tx] == '·' {
 							grid[ty][tx] = '·' // Breadcrumb
 						}
@@ -761,10 +980,23 @@ func (m model) renderSky() string {
 		}
 	}
 
+	// Draw commanded telescope positions behind the crosshair
+	for _, pos := range m.commanded.positions {
+		if pos.Altitude < m.minAlt || pos.Altitude > m.maxAlt {
+			continue
+		}
+		tx, ty := m.altAzToScreen(pos.Altitude, pos.Azimuth)
+		if tx >= 0 && tx < m.skyWidth && ty >= 0 && ty < m.skyHeight {
+			if grid[ty][tx] == ' ' || grid[ty][tx] == '·' {
+				grid[ty][tx] = '×'
+			}
+		}
+	}
+
 	// Draw telescope crosshair
 	if m.telesAlt >= m.minAlt && m.telesAlt <= m.maxAlt {
 		tx, ty := m.altAzToScreen(m.telesAlt, m.telesAz)
-		if tx >= 0 && tx < skyWidth && ty >= 0 && ty < skyHeight {
+		if tx >= 0 && tx < m.skyWidth && ty >= 0 && ty < m.skyHeight {
 			grid[ty][tx] = '+'
 		}
 	}
@@ -776,8 +1008,11 @@ func (m model) renderSky() string {
 		}
 
 		x, y := m.altAzToScreen(ac.horiz.Altitude, ac.horiz.Azimuth)
-		if x >= 0 && x < skyWidth && y >= 0 && y < skyHeight {
+		if x >= 0 && x < m.skyWidth && y >= 0 && y < m.skyHeight {
 			symbol := '○'
+			if ac.watched {
+				symbol = '★' // Watched aircraft
+			}
 			if i == m.selected {
 				symbol = '●' // Selected aircraft
 			}
@@ -794,23 +1029,27 @@ func (m model) renderSky() string {
 	}
 
 	// Render grid
-	for y := 0; y < skyHeight; y++ {
+	for y := 0; y < m.skyHeight; y++ {
 		sky.WriteString(borderStyle.Render("│"))
-		for x := 0; x < skyWidth-2; x++ {
+		for x := 0; x < m.skyWidth-2; x++ {
 			char := grid[y][x]
 			switch char {
 			case '+':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Telescope)).Render(string(char)))
+			case '×':
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Commanded)).Render(string(char)))
 			case '◉':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Tracked)).Bold(true).Render(string(char)))
 			case '●':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected)).Render(string(char)))
+			case '★':
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Watched)).Bold(true).Render(string(char)))
 			case '○':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Aircraft)).Render(string(char)))
 			case 'N', 'E', 'S', 'W':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Render(string(char)))
 			case '·':
-				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("237")).Render(string(char)))
+				sky.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Trail)).Render(string(char)))
 			default:
 				sky.WriteRune(char)
 			}
@@ -819,12 +1058,16 @@ func (m model) renderSky() string {
 		sky.WriteString("\n")
 	}
 
-	sky.WriteString(borderStyle.Render("└" + strings.Repeat("─", skyWidth-2) + "┘"))
+	sky.WriteString(borderStyle.Render("└" + strings.Repeat("─", m.skyWidth-2) + "┘"))
 
 	return sky.String()
 }
 
 func (m model) altAzToScreen(altitude, azimuth float64) (int, int) {
+	if m.projection == ProjectionPolar {
+		return m.polarToScreen(altitude, azimuth)
+	}
+
 	// Map altitude (0-90°) to screen Y (bottom to top)
 	// Map azimuth (0-360°) to screen X (left to right, N=0, E=90, S=180, W=270)
 
@@ -838,7 +1081,7 @@ func (m model) altAzToScreen(altitude, azimuth float64) (int, int) {
 
 	// X coordinate: map azimuth to screen width
 	// Azimuth 0° (North) = left, 90° (East) = 1/4, 180° (South) = middle, 270° (West) = 3/4
-	x := int((azimuth / 360.0) * float64(skyWidth-2))
+	x := int((azimuth / 360.0) * float64(m.skyWidth-2))
 
 	// Y coordinate: map altitude to screen height (inverted, 0° at bottom, 90° at top)
 	// Apply zoom: higher zoom = smaller altitude range visible
@@ -847,7 +1090,7 @@ func (m model) altAzToScreen(altitude, azimuth float64) (int, int) {
 		altRange = 80
 	}
 	normalizedAlt := (altitude - m.minAlt) / altRange
-	y := skyHeight - 1 - int(normalizedAlt*float64(skyHeight-1))
+	y := m.skyHeight - 1 - int(normalizedAlt*float64(m.skyHeight-1))
 
 	return x, y
 }
@@ -859,7 +1102,7 @@ func (m model) drawRingSegment(grid [][]rune, azimuth, rangeNM float64) {
 		// Calculate altitude for ring display (fixed at horizon level)
 		alt := m.minAlt + 5.0
 		x, y := m.altAzToScreen(alt, az)
-		if x >= 0 && x < skyWidth && y >= 0 && y < skyHeight {
+		if x >= 0 && x < m.skyWidth && y >= 0 && y < m.skyHeight {
 			if grid[y][x] == ' ' {
 				grid[y][x] = '◦' // Ring marker
 			}
@@ -884,7 +1127,7 @@ func (m model) drawVelocityVector(grid [][]rune, x, y int, trackDeg, speedKts fl
 		dy := -int(float64(i) * math.Cos(trackRad) * 0.5) // Negative because screen Y is inverted
 
 		nx, ny := x+dx, y+dy
-		if nx >= 0 && nx < skyWidth && ny >= 0 && ny < skyHeight {
+		if nx >= 0 && nx < m.skyWidth && ny >= 0 && ny < m.skyHeight {
 			if grid[ny][nx] == ' ' || grid[ny][nx] == '·' {
 				if i == length {
 					grid[ny][nx] = '→' // Arrow head
@@ -899,24 +1142,32 @@ func (m model) drawVelocityVector(grid [][]rune, x, y int, trackDeg, speedKts fl
 func (m model) renderAircraftList() string {
 	var list strings.Builder
 
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	list.WriteString(headerStyle.Render("Trackable Aircraft:"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	list.WriteString(headerStyle.Render(m.locale.T("list.header")))
 	list.WriteString(fmt.Sprintf(" (%d)", len(m.aircraft)))
-	list.WriteString("\n\n")
+	list.WriteString("\n")
+	list.WriteString(m.renderFilterBar())
+	list.WriteString("\n")
 
 	if len(m.aircraft) == 0 {
-		list.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("  No trackable aircraft in range"))
+		msg := "  " + m.locale.T("list.empty")
+		if m.filter.active() {
+			msg = "  " + m.locale.T("list.emptyFiltered")
+		}
+		list.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint)).Render(msg))
 		return list.String()
 	}
 
-	// Show up to 5 aircraft
+	// Show as many aircraft as fit, keeping the selection centred
+	rows := m.listRows()
 	start := 0
-	if m.selected > 2 && len(m.aircraft) > 5 {
-		start = m.selected - 2
+	if m.selected > rows/2 && len(m.aircraft) > rows {
+		start = m.selected - rows/2
 	}
-	end := start + 5
+	end := start + rows
 	if end > len(m.aircraft) {
 		end = len(m.aircraft)
+		start = max(end-rows, 0)
 	}
 
 	for i := start; i < end; i++ {
@@ -931,7 +1182,10 @@ func (m model) renderAircraftList() string {
 		// Tracking indicator
 		trackIndicator := ""
 		if m.tracking && ac.aircraft.ICAO == m.trackICAO {
-			trackIndicator = " [TRACKING]"
+			trackIndicator = " " + m.locale.T("list.tracking")
+		}
+		if ac.watched {
+			trackIndicator += " ★"
 		}
 
 		// Prediction mode indicator
@@ -944,15 +1198,22 @@ func (m model) renderAircraftList() string {
 		case "deadreckoning":
 			predMode = " [DR]"
 		}
+		if ac.aircraft.Source == adsb.SourceADSC {
+			predMode += " [SAT]" // Sparse oceanic ADS-C report
+		}
+		if ac.hold != nil {
+			predMode += " [HLD]"
+		} else {
+			predMode += phaseMarker(ac.phase)
+		}
+		predMode += limitCountdown(ac)
 
 		// Age indicator
-		ageStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
-		if ac.age > 30 {
-			ageStyle = ageStyle.Foreground(lipgloss.Color("226"))
-		}
-		if ac.age > 60 {
-			ageStyle = ageStyle.Foreground(lipgloss.Color("196"))
+		ageStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.AgeColor(time.Duration(ac.age * float64(time.Second)))))
+		if i == m.selected {
+			ageStyle = ageStyle.Background(lipgloss.Color(m.theme.Highlight))
 		}
+		age := ageStyle.Render(fmt.Sprintf("%4.0fs", ac.age))
 
 		// Format line
 		callsign := ac.aircraft.Callsign
@@ -969,27 +1230,27 @@ func (m model) renderAircraftList() string {
 			raMinutes := int((ac.equatorial.RightAscension - float64(raHours)) * 60)
 			raSeconds := int(((ac.equatorial.RightAscension-float64(raHours))*60 - float64(raMinutes)) * 60)
 
-			line = fmt.Sprintf("%s%-8s  %6.0f ft  %5.1f nm  RA:%02d:%02d:%02d Dec:%+6.2f°  %4.0fs%s%s",
+			line = fmt.Sprintf("%s%-8s  %9s  %8s  RA:%02d:%02d:%02d Dec:%+6.2f°  %s%s%s",
 				prefix,
 				callsign,
-				ac.aircraft.Altitude,
-				ac.range_nm,
+				m.locale.Altitude(ac.aircraft.Altitude),
+				m.locale.Distance(ac.range_nm, 1),
 				raHours, raMinutes, raSeconds,
 				ac.equatorial.Declination,
-				ac.age,
+				age,
 				predMode,
 				trackIndicator,
 			)
 		} else {
 			// Show Alt/Az for altazimuth mounts
-			line = fmt.Sprintf("%s%-8s  %6.0f ft  %5.1f nm  Az:%3.0f° Alt:%2.0f°  %4.0fs%s%s",
+			line = fmt.Sprintf("%s%-8s  %9s  %8s  Az:%3.0f° Alt:%2.0f°  %s%s%s",
 				prefix,
 				callsign,
-				ac.aircraft.Altitude,
-				ac.range_nm,
+				m.locale.Altitude(ac.aircraft.Altitude),
+				m.locale.Distance(ac.range_nm, 1),
 				ac.horiz.Azimuth,
 				ac.horiz.Altitude,
-				ac.age,
+				age,
 				predMode,
 				trackIndicator,
 			)
@@ -997,7 +1258,7 @@ func (m model) renderAircraftList() string {
 
 		if i == m.selected {
 			line = lipgloss.NewStyle().
-				Background(lipgloss.Color("237")).
+				Background(lipgloss.Color(m.theme.Highlight)).
 				Render(line)
 		}
 
@@ -1006,13 +1267,17 @@ func (m model) renderAircraftList() string {
 
 		// Show flight plan info if this is the selected aircraft
 		if i == m.selected && ac.flightPlan != nil {
-			fpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
-			if ac.nextWaypoint != "" {
-				list.WriteString(fpStyle.Render(fmt.Sprintf("    Plan: %s → %s (next: %s)\n",
-					ac.flightPlan.DepartureICAO, ac.flightPlan.ArrivalICAO, ac.nextWaypoint)))
+			fpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Header))
+			progress, _ := tracking.EstimateRouteProgress(ac.aircraft, ac.waypoints)
+			if next := progress.Next(); next != nil && next.ETA != nil {
+				list.WriteString(fpStyle.Render("    " + m.locale.T("list.planNextETA",
+					ac.flightPlan.DepartureICAO, ac.flightPlan.ArrivalICAO, next.Name, next.ETA.Local().Format("15:04")) + "\n"))
+			} else if ac.nextWaypoint != "" {
+				list.WriteString(fpStyle.Render("    " + m.locale.T("list.planNext",
+					ac.flightPlan.DepartureICAO, ac.flightPlan.ArrivalICAO, ac.nextWaypoint) + "\n"))
 			} else {
-				list.WriteString(fpStyle.Render(fmt.Sprintf("    Plan: %s → %s\n",
-					ac.flightPlan.DepartureICAO, ac.flightPlan.ArrivalICAO)))
+				list.WriteString(fpStyle.Render("    " + m.locale.T("list.plan",
+					ac.flightPlan.DepartureICAO, ac.flightPlan.ArrivalICAO) + "\n"))
 			}
 		}
 	}
@@ -1020,7 +1285,7 @@ func (m model) renderAircraftList() string {
 	// Telescope position
 	if m.tracking {
 		list.WriteString("\n")
-		telescopeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+		telescopeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Telescope)).Bold(true)
 
 		if m.cfg.Telescope.MountType == "equatorial" {
 			// Convert telescope Alt/Az to RA/Dec for display
@@ -1035,58 +1300,130 @@ func (m model) renderAircraftList() string {
 			raMinutes := int((telescopeEq.RightAscension - float64(raHours)) * 60)
 			raSeconds := int(((telescopeEq.RightAscension-float64(raHours))*60 - float64(raMinutes)) * 60)
 
-			list.WriteString(telescopeStyle.Render(fmt.Sprintf("Telescope: RA %02d:%02d:%02d  Dec %+6.2f°  Zoom: %.1fx",
-				raHours, raMinutes, raSeconds, telescopeEq.Declination, m.zoom)))
+			list.WriteString(telescopeStyle.Render(m.locale.T("telescope.radec",
+				raHours, raMinutes, raSeconds, telescopeEq.Declination, m.locale.Number(m.zoom, 1))))
 		} else {
-			list.WriteString(telescopeStyle.Render(fmt.Sprintf("Telescope: Az %.1f°  Alt %.1f°  Zoom: %.1fx", m.telesAz, m.telesAlt, m.zoom)))
+			list.WriteString(telescopeStyle.Render(m.locale.T("telescope.altaz",
+				m.locale.Number(m.telesAz, 1), m.locale.Number(m.telesAlt, 1), m.locale.Number(m.zoom, 1))))
+		}
+
+		// Handoff countdown and next target
+		if handoff := m.renderHandoff(); handoff != "" {
+			list.WriteString("\n")
+			list.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Header)).Render(handoff))
+		}
+
+		// Latest tracking alert
+		if m.lastAlert != "" {
+			alert := m.locale.T("alert", m.lastAlert)
+			if m.alertsMuted {
+				alert += " " + m.locale.T("alert.muted")
+			}
+			list.WriteString("\n")
+			list.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Warning)).Render(alert))
+		}
+	}
+
+	// Auto-select status
+	if m.autoSelect {
+		autoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Title)).Bold(true)
+		list.WriteString("\n")
+		if m.tracking {
+			list.WriteString(autoStyle.Render(m.locale.T("autoselect.tracking", m.trackICAO, m.autoRule)))
+		} else {
+			list.WriteString(autoStyle.Render(m.locale.T("autoselect.waiting")))
 		}
 	}
 
 	return list.String()
 }
 
+// limitCountdown shows how long until an aircraft enters the altitude
+// limits, or leaves them if it's already within them
+func limitCountdown(ac aircraftView) string {
+	switch {
+	case !ac.entersLimits:
+		return ""
+	case ac.limitWindow.Enter > 0:
+		return " [IN " + shortDuration(ac.limitWindow.Enter) + "]"
+	case ac.limitWindow.Leaves:
+		return " [OUT " + shortDuration(ac.limitWindow.Leave) + "]"
+	}
+	return ""
+}
+
+// phaseMarker marks aircraft climbing, descending or on approach. Cruising
+// is the usual case and is left unmarked.
+func phaseMarker(phase tracking.FlightPhase) string {
+	switch phase {
+	case tracking.PhaseClimb:
+		return " [CLB]"
+	case tracking.PhaseDescent:
+		return " [DES]"
+	case tracking.PhaseApproach:
+		return " [APP]"
+	}
+	return ""
+}
+
+// shortDuration formats a countdown as "45s" or "2m10s"
+func shortDuration(d time.Duration) string {
+	secs := int(d.Seconds())
+	if secs < 60 {
+		return fmt.Sprintf("%ds", secs)
+	}
+	return fmt.Sprintf("%dm%02ds", secs/60, secs%60)
+}
+
 // renderLegend renders the legend panel showing symbols and ranges
 func (m model) renderLegend() string {
 	var leg strings.Builder
 
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	leg.WriteString(headerStyle.Render("Legend"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	leg.WriteString(headerStyle.Render(m.locale.T("legend")))
 	leg.WriteString("\n\n")
 
 	// Symbols
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Render("○"))
-	leg.WriteString(" Untracked\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render("●"))
-	leg.WriteString(" Selected\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true).Render("◉"))
-	leg.WriteString(" Tracking\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render("+"))
-	leg.WriteString(" Telescope\n")
-	leg.WriteString("· Trail/Ring\n")
-	leg.WriteString("→ Velocity\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Aircraft)).Render("○"))
+	leg.WriteString(" " + m.locale.T("legend.untracked") + "\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected)).Render("●"))
+	leg.WriteString(" " + m.locale.T("legend.selected") + "\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Tracked)).Bold(true).Render("◉"))
+	leg.WriteString(" " + m.locale.T("legend.tracking") + "\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Watched)).Bold(true).Render("★"))
+	leg.WriteString(" " + m.locale.T("legend.watched") + "\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Telescope)).Render("+"))
+	leg.WriteString(" " + m.locale.T("legend.telescope") + "\n")
+	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Commanded)).Render("×"))
+	leg.WriteString(" " + m.locale.T("legend.commanded") + "\n")
+	leg.WriteString("· " + m.locale.T("legend.trail") + "\n")
+	leg.WriteString("→ " + m.locale.T("legend.velocity") + "\n")
+	leg.WriteString(m.locale.T("legend.projection", m.projection) + "\n")
+	leg.WriteString(m.locale.T("legend.theme", m.theme.Name) + "\n")
 	leg.WriteString("\n")
 
 	// Prediction modes
-	headerStyle2 := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	leg.WriteString(headerStyle2.Render("Prediction"))
+	headerStyle2 := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	leg.WriteString(headerStyle2.Render(m.locale.T("legend.prediction")))
 	leg.WriteString("\n")
-	leg.WriteString("[WPT] Waypoint\n")
-	leg.WriteString("[AWY] Airway\n")
-	leg.WriteString("[DR]  Dead Reckoning\n")
+	leg.WriteString("[WPT] " + m.locale.T("legend.waypoint") + "\n")
+	leg.WriteString("[AWY] " + m.locale.T("legend.airway") + "\n")
+	leg.WriteString("[DR]  " + m.locale.T("legend.deadreckoning") + "\n")
+	leg.WriteString("[SAT] " + m.locale.T("legend.adsc") + "\n")
+	leg.WriteString("[CLB] " + m.locale.T("legend.climb") + "\n")
+	leg.WriteString("[DES] " + m.locale.T("legend.descent") + "\n")
+	leg.WriteString("[APP] " + m.locale.T("legend.approach") + "\n")
+	leg.WriteString("[HLD] " + m.locale.T("legend.hold") + "\n")
 	leg.WriteString("\n")
 
 	// Range rings
-	headerStyle3 := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	leg.WriteString(headerStyle3.Render("Range Rings"))
+	headerStyle3 := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	leg.WriteString(headerStyle3.Render(m.locale.T("legend.rings")))
 	leg.WriteString("\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("◦"))
-	leg.WriteString("  5 nm\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render("◦"))
-	leg.WriteString(" 10 nm\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("◦"))
-	leg.WriteString(" 25 nm\n")
-	leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("◦"))
-	leg.WriteString(" 50 nm\n")
+	for i, nm := range []float64{5, 10, 25, 50} {
+		leg.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Rings[i])).Render("◦"))
+		leg.WriteString(fmt.Sprintf(" %6s\n", m.locale.Distance(nm, 0)))
+	}
 
 	return leg.String()
 }
@@ -1098,21 +1435,21 @@ func (m model) renderAirportSelection() string {
 	// Header
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("86")).
-		Background(lipgloss.Color("235")).
+		Foreground(lipgloss.Color(m.theme.Title)).
+		Background(lipgloss.Color(m.theme.TitleBackground)).
 		Padding(0, 1)
 
 	s.WriteString(titleStyle.Render("SELECT AIRPORT FOR RADAR VIEW"))
 	s.WriteString("\n\n")
 
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
 	s.WriteString(headerStyle.Render("Airports from Active Collection Regions:"))
 	s.WriteString(fmt.Sprintf(" (%d)\n\n", len(m.airportList)))
 
 	if len(m.airportList) == 0 {
-		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("  No airports found"))
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Error)).Render("  No airports found"))
 		s.WriteString("\n\n")
-		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
 		s.WriteString(helpStyle.Render("ESC: Back"))
 		return s.String()
 	}
@@ -1146,8 +1483,8 @@ func (m model) renderAirportSelection() string {
 
 		if i == m.airportSelected {
 			line = lipgloss.NewStyle().
-				Background(lipgloss.Color("237")).
-				Foreground(lipgloss.Color("226")).
+				Background(lipgloss.Color(m.theme.Highlight)).
+				Foreground(lipgloss.Color(m.theme.Selected)).
 				Render(line)
 		}
 
@@ -1161,13 +1498,27 @@ func (m model) renderAirportSelection() string {
 
 	// Controls
 	s.WriteString("\n")
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
 	s.WriteString(helpStyle.Render("↑/↓: Navigate  ENTER/SPACE: Select  ESC/Q: Back"))
 	s.WriteString("\n")
 
 	return s.String()
 }
 
+// newWaypointLookup returns a lookup that resolves identifiers against the waypoints table.
+func newWaypointLookup(fpRepo *db.FlightPlanRepository) config.WaypointLookup {
+	return func(identifier string) (float64, float64, bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		wp, err := fpRepo.GetWaypointByIdentifier(ctx, identifier)
+		if err != nil || wp == nil {
+			return 0, 0, false, err
+		}
+		return wp.Latitude, wp.Longitude, true, nil
+	}
+}
+
 func main() {
 	// Config path
 	configPath := "configs/config.json"
@@ -1199,34 +1550,71 @@ func main() {
 	repo := db.NewAircraftRepository(database, observer)
 	fpRepo := db.NewFlightPlanRepository(database)
 
+	// Resolve regions defined by airport/waypoint identifier
+	if err := cfg.ADSB.ResolveRegionCenters(newWaypointLookup(fpRepo)); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
 	// Get altitude limits
 	minAlt, maxAlt := cfg.Telescope.GetAltitudeLimits()
 
+	// Scale prediction confidence to expected error
+	calibration := tracking.DefaultCalibration
+	if latest, err := db.NewCalibrationRepository(database).Latest(context.Background()); err != nil {
+		log.Printf("Warning: %v; using the default error calibration", err)
+	} else if latest != nil {
+		calibration = latest.ErrorCalibration
+	}
+
+	th, err := theme.Lookup(cfg.Display.Theme)
+	if err != nil {
+		log.Printf("Warning: %v; using the default theme", err)
+		th, _ = theme.Lookup(theme.DefaultName)
+	}
+
+	locale, err := i18n.New(cfg.Display.Language, i18n.UnitSystem(cfg.Display.Units))
+	if err != nil {
+		log.Printf("Warning: %v; using English and aviation units", err)
+		locale = i18n.Default()
+	}
+
 	// Create model
 	m := model{
-		cfg:         cfg,
-		database:    database,
-		repo:        repo,
-		fpRepo:      fpRepo,
-		observer:    observer,
-		minAlt:      minAlt,
-		maxAlt:      maxAlt,
-		telesAlt:    45,  // Start at 45° altitude
-		telesAz:     180, // Start pointing south
-		zoom:        1.0, // Normal zoom
-		trails:      make(map[string]*trackTrail),
-		radarRadius: 100.0,   // Default radar radius 100 NM
-		width:       80,      // Default width (will be updated on first render)
-		height:      30,      // Default height (will be updated on first render)
-		viewMode:    ViewSky, // Start in sky view mode
-		configPath:  configPath,
+		cfg:          cfg,
+		database:     database,
+		repo:         repo,
+		fpRepo:       fpRepo,
+		watchRepo:    db.NewWatchRepository(database),
+		observer:     observer,
+		minAlt:       minAlt,
+		maxAlt:       maxAlt,
+		telesAlt:     45,  // Start at 45° altitude
+		telesAz:      180, // Start pointing south
+		zoom:         1.0, // Normal zoom
+		theme:        th,
+		locale:       locale,
+		trails:       make(map[string]*trackTrail),
+		holds:        make(map[string]tracking.Hold),
+		calibration:  calibration,
+		radarRadius:  100.0,   // Default radar radius 100 NM
+		viewMode:     ViewSky, // Start in sky view mode
+		configPath:   configPath,
+		autoRules:    loadAutoRules(observer, tracking.TrackingLimits{MinAltitude: minAlt, MaxAltitude: maxAlt}),
+		autoCooldown: make(map[string]time.Time),
+	}
+	m.resize(80, 30) // Default size (will be updated on first render)
+
+	// Decode extended squitters from a local receiver, if configured
+	if addr := cfg.ADSB.GetBeastAddress(); addr != "" {
+		m.beast = adsb.NewBeastClient(addr)
+		go m.beast.Run(context.Background())
 	}
 
 	// Initial data load
 	m.updateAircraft()
 
 	// Start TUI
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Screen position of the first sky/radar grid cell: the title and a blank
+// line, then the top border, with the left border in column 0.
+const (
+	viewTopRow  = 3
+	viewLeftCol = 1
+)
+
+// maxClickDistance is how far (in cells) a click may land from an aircraft
+// symbol and still select it.
+const maxClickDistance = 2
+
+// handleMouse handles mouse events in the sky and radar views.
+// Clicking an aircraft selects it (clicking the selected one starts tracking)
+// and the scroll wheel zooms.
+func (m model) handleMouse(msg tea.MouseMsg) (model, tea.Cmd) {
+	if m.viewMode != ViewSky || m.inputMode != "" || m.err != nil || m.detailICAO != "" || m.tooSmall() {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.zoomIn()
+	case tea.MouseButtonWheelDown:
+		m.zoomOut()
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		i := m.aircraftAt(msg.X-viewLeftCol, msg.Y-viewTopRow)
+		if i < 0 {
+			return m, nil
+		}
+		if i == m.selected {
+			m.startTracking()
+		} else {
+			m.selected = i
+		}
+	}
+
+	return m, nil
+}
+
+// aircraftAt returns the index of the aircraft drawn nearest to grid cell
+// (x, y), or -1 if none is within maxClickDistance.
+func (m model) aircraftAt(x, y int) int {
+	best := -1
+	bestDist := maxClickDistance*maxClickDistance + 1
+
+	for i, ac := range m.aircraft {
+		var ax, ay int
+		if m.radarMode {
+			ax, ay = m.radarToScreen(ac.aircraft.Latitude, ac.aircraft.Longitude)
+			if ax < 0 || ay < 0 {
+				continue
+			}
+		} else {
+			if ac.horiz.Altitude < m.minAlt || ac.horiz.Altitude > m.maxAlt {
+				continue
+			}
+			ax, ay = m.altAzToScreen(ac.horiz.Altitude, ac.horiz.Azimuth)
+		}
+
+		// Rows are about twice as tall as columns are wide
+		dx, dy := ax-x, 2*(ay-y)
+		if dist := dx*dx + dy*dy; dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+
+	return best
+}
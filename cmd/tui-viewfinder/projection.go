@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+)
+
+// SkyProjection selects how the sky view maps altitude/azimuth to the screen
+type SkyProjection int
+
+const (
+	// ProjectionLinear maps azimuth to x and altitude to y (a panorama)
+	ProjectionLinear SkyProjection = iota
+	// ProjectionPolar puts the zenith in the centre and the horizon on a
+	// circle (stereographic), so overhead passes draw as smooth arcs
+	ProjectionPolar
+)
+
+// String returns the projection name shown in the legend.
+func (p SkyProjection) String() string {
+	if p == ProjectionPolar {
+		return "Polar"
+	}
+	return "Linear"
+}
+
+// skyAspectRatio corrects for terminal cells being ~2:1 (height:width)
+const skyAspectRatio = 0.5
+
+// polarGeometry returns the centre and horizon radius (in rows) of the
+// polar sky view.
+func (m model) polarGeometry() (cx, cy int, radius float64) {
+	cx = (m.skyWidth - 2) / 2
+	cy = m.skyHeight / 2
+
+	radius = float64(m.skyHeight/2 - 1)
+	if r := float64((m.skyWidth-2)/2-2) * skyAspectRatio; r < radius {
+		radius = r
+	}
+	return cx, cy, radius
+}
+
+// polarToScreen projects altitude/azimuth stereographically around the
+// zenith: north up, east right, horizon on the circle. Zoom magnifies
+// around the zenith.
+func (m model) polarToScreen(altitude, azimuth float64) (int, int) {
+	cx, cy, radius := m.polarGeometry()
+
+	zenithAngle := (90 - altitude) * math.Pi / 180.0
+	r := radius * math.Tan(zenithAngle/2) * m.zoom
+	az := azimuth * math.Pi / 180.0
+
+	x := cx + int(math.Round(r*math.Sin(az)/skyAspectRatio))
+	y := cy - int(math.Round(r*math.Cos(az)))
+	return x, y
+}
+
+// drawPolarGrid draws the horizon, altitude rings and compass points of the
+// polar sky view.
+func (m model) drawPolarGrid(grid [][]rune) {
+	cx, cy, radius := m.polarGeometry()
+
+	// Altitude rings at 30° and 60°, then the horizon
+	for _, alt := range []float64{60, 30, 0} {
+		r := radius * math.Tan((90-alt)*math.Pi/360.0) * m.zoom
+		drawCircle(grid, cx, cy, int(math.Round(r)), skyAspectRatio, '·')
+	}
+
+	// Zenith and compass points just outside the horizon
+	setPixel(grid, cx, cy, '·')
+	edge := radius*m.zoom + 1
+	for _, p := range []struct {
+		az    float64
+		label rune
+	}{{0, 'N'}, {90, 'E'}, {180, 'S'}, {270, 'W'}} {
+		az := p.az * math.Pi / 180.0
+		x := cx + int(math.Round(edge*math.Sin(az)/skyAspectRatio))
+		y := cy - int(math.Round(edge*math.Cos(az)))
+		if y >= 0 && y < len(grid) && x >= 0 && x < m.skyWidth-2 {
+			grid[y][x] = p.label
+		}
+	}
+}
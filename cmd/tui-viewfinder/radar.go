@@ -1,84 +1,136 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/unklstewy/ads-bscope/internal/db"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
 )
 
+// radarAspectRatio corrects for terminal characters being about twice as
+// tall as they are wide: X distances are divided by it so circles look round
+const radarAspectRatio = 0.5
+
+// radarRingChar marks the range rings; labels, overlays and velocity vectors
+// may be drawn over it
+const radarRingChar = '·'
+
+// radarBackground reports whether a radar cell holds nothing but a range
+// ring or airway line, so labels and vectors may be drawn over it.
+func radarBackground(c rune) bool {
+	switch c {
+	case ' ', radarRingChar, '─', '│', '╱', '╲':
+		return true
+	}
+	return false
+}
+
+// maxRadarWaypoints limits the airport and navaid overlay so labels don't
+// swamp the display
+const maxRadarWaypoints = 40
+
+// radarDetailRadiusNM is the widest radar radius navaids and airways are
+// plotted at; wider views show airports only
+const radarDetailRadiusNM = 250
+
+// radarGeometry returns the radar centre cell and the scale in rows per
+// nautical mile, fitting the radar radius within the smaller dimension.
+func (m model) radarGeometry() (centerX, centerY int, scale float64) {
+	radarWidth, radarHeight := m.radarSize()
+
+	centerX = (radarWidth - 2) / 2
+	centerY = radarHeight / 2
+
+	maxScreenRadiusY := float64(radarHeight/2 - 3)
+	maxScreenRadiusX := float64(radarWidth/2-3) * radarAspectRatio
+	return centerX, centerY, math.Min(maxScreenRadiusX, maxScreenRadiusY) / m.radarRadius
+}
+
 // radarToScreen converts geographic coordinates to radar screen X/Y position.
-// Returns coordinates relative to radar center, scaled by radius.
-// Returns -1,-1 if aircraft is outside radar radius.
-// Applies aspect ratio correction to account for character height:width ratio (~2:1).
+// Returns -1,-1 if the position is outside the radar radius or the screen.
 func (m model) radarToScreen(lat, lon float64) (int, int) {
-	// Calculate distance and bearing from radar center
-	acPos := coordinates.Geographic{
+	pos := coordinates.Geographic{
 		Latitude:  lat,
 		Longitude: lon,
 		Altitude:  0,
 	}
 
-	distanceNM := coordinates.DistanceNauticalMiles(m.radarCenter, acPos)
-
-	// Check if outside radar radius
-	if distanceNM > m.radarRadius {
+	if coordinates.DistanceNauticalMiles(m.radarCenter, pos) > m.radarRadius {
 		return -1, -1
 	}
 
-	// Calculate bearing from center to aircraft
-	bearing := coordinates.Bearing(m.radarCenter, acPos)
+	radarWidth, radarHeight := m.radarSize()
+	fx, fy := m.radarProject(lat, lon)
+	x := int(math.Round(fx))
+	y := int(math.Round(fy))
 
-	// Get radar display dimensions
-	radarWidth := m.width - 60 // Reserve space for info panel
-	if radarWidth < 80 {
-		radarWidth = 80
-	}
-	radarHeight := m.height - 10 // Reserve space for header and aircraft list
-	if radarHeight < 30 {
-		radarHeight = 30
+	if x < 0 || x >= radarWidth-2 || y < 0 || y >= radarHeight {
+		return -1, -1
 	}
 
-	// Convert to screen coordinates
-	// Center of screen
-	centerX := (radarWidth - 2) / 2
-	centerY := radarHeight / 2
-
-	// Character aspect ratio correction: terminal characters are ~2:1 (height:width)
-	// This means we need to scale X distances by 0.5 to make circles look round
-	const aspectRatio = 0.5
+	return x, y
+}
 
-	// Scale factor: fit radius within smaller dimension
-	// Use vertical dimension since it's typically smaller
-	maxScreenRadiusY := float64(radarHeight/2 - 3)
-	maxScreenRadiusX := float64(radarWidth/2-3) * aspectRatio
-	maxScreenRadius := maxScreenRadiusY
-	if maxScreenRadiusX < maxScreenRadiusY {
-		maxScreenRadius = maxScreenRadiusX
+// radarProject converts geographic coordinates to fractional radar screen
+// coordinates without clipping, so lines can run off the edge of the radar.
+func (m model) radarProject(lat, lon float64) (float64, float64) {
+	pos := coordinates.Geographic{
+		Latitude:  lat,
+		Longitude: lon,
 	}
-	scale := maxScreenRadius / m.radarRadius
+	centerX, centerY, scale := m.radarGeometry()
 
 	// Convert polar (distance, bearing) to cartesian (x, y)
 	// Bearing 0° = North = up = negative Y
 	// Bearing 90° = East = right = positive X
-	bearingRad := bearing * math.Pi / 180.0
-	screenDist := distanceNM * scale
+	bearingRad := coordinates.Bearing(m.radarCenter, pos) * math.Pi / 180.0
+	screenDist := coordinates.DistanceNauticalMiles(m.radarCenter, pos) * scale
 
-	// Apply aspect ratio correction to X coordinate
-	dx := int(screenDist * math.Sin(bearingRad) / aspectRatio)
-	dy := -int(screenDist * math.Cos(bearingRad)) // Negative because Y increases downward
+	x := float64(centerX) + screenDist*math.Sin(bearingRad)/radarAspectRatio
+	y := float64(centerY) - screenDist*math.Cos(bearingRad)
+	return x, y
+}
 
-	x := centerX + dx
-	y := centerY + dy
+// loadRadarOverlay loads the airports and navaids within the radar radius
+// from the waypoints table, and the airways if they are shown. It is called
+// whenever the radar centre or radius changes; on error the overlay is left
+// empty.
+func (m *model) loadRadarOverlay() {
+	m.loadRadarAirways()
 
-	// Check bounds
-	if x < 0 || x >= radarWidth-2 || y < 0 || y >= radarHeight {
-		return -1, -1
+	types := []string{"airport", "vor", "ndb", "tacan"}
+	if m.radarRadius > radarDetailRadiusNM {
+		types = types[:1]
 	}
 
-	return x, y
+	ctx := context.Background()
+	waypoints, err := m.fpRepo.FindWaypointsNear(ctx, m.radarCenter.Latitude, m.radarCenter.Longitude, m.radarRadius, types...)
+	if err != nil {
+		m.err = fmt.Errorf("failed to load radar overlay: %w", err)
+		m.radarWaypoints = nil
+		return
+	}
+
+	// Nearest first, so the area around the centre fills in before the
+	// overlay limit is reached
+	sort.SliceStable(waypoints, func(i, j int) bool {
+		return m.radarDistance(waypoints[i]) < m.radarDistance(waypoints[j])
+	})
+	m.radarWaypoints = waypoints
+}
+
+// radarDistance returns a waypoint's distance from the radar centre in
+// nautical miles.
+func (m model) radarDistance(wp db.Waypoint) float64 {
+	return coordinates.DistanceNauticalMiles(m.radarCenter, coordinates.Geographic{
+		Latitude:  wp.Latitude,
+		Longitude: wp.Longitude,
+	})
 }
 
 // renderRadar renders the radar screen view centered on an airport.
@@ -86,103 +138,150 @@ func (m model) renderRadar() string {
 	var radar strings.Builder
 
 	// Get radar display dimensions (dynamic based on terminal size)
-	radarWidth := m.width - 60 // Reserve space for info panel
-	if radarWidth < 80 {
-		radarWidth = 80
-	}
-	radarHeight := m.height - 10 // Reserve space for header and aircraft list
-	if radarHeight < 30 {
-		radarHeight = 30
-	}
+	radarWidth, radarHeight := m.radarSize()
 
 	// Draw border
-	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Border))
 	radar.WriteString(borderStyle.Render("┌" + strings.Repeat("─", radarWidth-2) + "┐"))
 	radar.WriteString("\n")
 
-	// Create radar grid
+	// Create radar grid. Text drawn over it (ring, airport, airway and
+	// aircraft labels) is colored by position rather than by character.
 	grid := make([][]rune, radarHeight)
 	for i := range grid {
-		grid[i] = make([]rune, radarWidth)
+		grid[i] = make([]rune, radarWidth-2)
 		for j := range grid[i] {
 			grid[i][j] = ' '
 		}
 	}
+	styles := make(map[[2]int]lipgloss.Style)
+
+	// writeLabel writes text into empty, ring or airway cells only, so
+	// labels never cover symbols. All cells must be free for anything to be
+	// written.
+	writeLabel := func(x, y int, text string, style lipgloss.Style) bool {
+		runes := []rune(text)
+		if y < 0 || y >= radarHeight || x < 0 || x+len(runes) > radarWidth-2 {
+			return false
+		}
+		for i := range runes {
+			if !radarBackground(grid[y][x+i]) {
+				return false
+			}
+		}
+		for i, ch := range runes {
+			grid[y][x+i] = ch
+			styles[[2]int{x + i, y}] = style
+		}
+		return true
+	}
 
-	centerX := (radarWidth - 2) / 2
-	centerY := radarHeight / 2
+	centerX, centerY, scale := m.radarGeometry()
+	maxScreenRadius := m.radarRadius * scale
 
-	// Character aspect ratio correction (terminal chars are ~2:1 height:width)
-	const aspectRatio = 0.5
+	// Range rings at the configured spacing, labeled at the top
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Label))
+	interval := m.cfg.Display.GetRadarRingInterval(m.radarRadius)
+	for dist := interval; dist <= m.radarRadius+0.001; dist += interval {
+		screenRadius := dist * scale
+		drawEllipse(grid, centerX, centerY, screenRadius, radarAspectRatio, radarRingChar)
 
-	// Draw range rings (concentric circles)
-	maxScreenRadiusY := float64(radarHeight/2 - 3)
-	maxScreenRadiusX := float64(radarWidth/2-3) * aspectRatio
-	maxScreenRadius := maxScreenRadiusY
-	if maxScreenRadiusX < maxScreenRadiusY {
-		maxScreenRadius = maxScreenRadiusX
+		label := m.locale.Distance(dist, 0)
+		writeLabel(centerX-len([]rune(label))/2, centerY-int(math.Round(screenRadius)), label, labelStyle)
 	}
-	scale := maxScreenRadius / m.radarRadius
-
-	// Calculate nice ring intervals
-	ringIntervals := []float64{50, 100, 250, 500, 1000}
-	var ringDistances []float64
-	var ringLabels []string
-
-	for _, interval := range ringIntervals {
-		for dist := interval; dist < m.radarRadius; dist += interval {
-			ringDistances = append(ringDistances, dist)
-			if dist >= 1000 {
-				ringLabels = append(ringLabels, fmt.Sprintf("%.0fk", dist/1000))
-			} else {
-				ringLabels = append(ringLabels, fmt.Sprintf("%.0f", dist))
-			}
+
+	// Cardinal directions just outside the outer ring
+	edge := int(math.Round(maxScreenRadius))
+	for _, c := range []struct {
+		x, y int
+		ch   rune
+	}{
+		{centerX, centerY - edge - 1, 'N'},
+		{centerX + int(float64(edge)/radarAspectRatio) + 2, centerY, 'E'},
+		{centerX, centerY + edge + 1, 'S'},
+		{centerX - int(float64(edge)/radarAspectRatio) - 2, centerY, 'W'},
+	} {
+		if c.y >= 0 && c.y < radarHeight && c.x >= 0 && c.x < radarWidth-2 {
+			grid[c.y][c.x] = c.ch
 		}
-		if len(ringDistances) >= 5 {
-			break // Don't overcrowd the display
+	}
+
+	// Center airport, labeled with its identifier
+	observerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Observer)).Bold(true)
+	grid[centerY][centerX] = '✈'
+	writeLabel(centerX+2, centerY, m.radarAirport, observerStyle)
+
+	// Airports (△) and navaids (◇) from the waypoints table, nearest first.
+	// Ones whose symbol would land on something already drawn are skipped.
+	airportStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Airport))
+	plotted := 0
+	for _, wp := range m.radarWaypoints {
+		if plotted >= maxRadarWaypoints {
+			break
+		}
+		if strings.EqualFold(wp.Identifier, m.radarAirport) {
+			continue
+		}
+		x, y := m.radarToScreen(wp.Latitude, wp.Longitude)
+		if x < 0 || y < 0 {
+			continue
 		}
+		symbol := "◇"
+		if wp.Type == "airport" {
+			symbol = "△"
+		}
+		if !writeLabel(x, y, symbol, airportStyle) {
+			continue
+		}
+		writeLabel(x+1, y, wp.Identifier, labelStyle)
+		plotted++
 	}
 
-	// Draw range rings with better contrast
-	for i, ringDist := range ringDistances {
-		screenRadius := int(ringDist * scale)
-		drawCircle(grid, centerX, centerY, screenRadius, aspectRatio, '─')
-
-		// Add range label at top of ring
-		label := ringLabels[i]
-		labelY := centerY - screenRadius
-		labelX := centerX - len(label)/2
-		if labelY >= 0 && labelY < radarHeight && labelX >= 0 && labelX+len(label) < radarWidth-2 {
-			for j, ch := range label {
-				if labelX+j < radarWidth-2 {
-					grid[labelY][labelX+j] = ch
-				}
+	// Airways and the fixes along them (A toggles). The lines are drawn
+	// after the symbols and labels so they break around them.
+	if m.radarAirways {
+		faintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+		plotted = 0
+		for _, fix := range m.radarFixes {
+			if plotted >= maxRadarWaypoints {
+				break
 			}
+			x, y := m.radarToScreen(fix.Latitude, fix.Longitude)
+			if x < 0 || y < 0 || !writeLabel(x, y, "▴", labelStyle) {
+				continue
+			}
+			writeLabel(x+1, y, fix.Identifier, faintStyle)
+			plotted++
 		}
-	}
 
-	// Draw cardinal directions
-	// North (top)
-	if centerY-int(maxScreenRadius) >= 0 {
-		grid[centerY-int(maxScreenRadius)][centerX] = 'N'
-	}
-	// East (right)
-	eastX := centerX + int(maxScreenRadius/aspectRatio)
-	if eastX < radarWidth-2 {
-		grid[centerY][eastX] = 'E'
-	}
-	// South (bottom)
-	if centerY+int(maxScreenRadius) < radarHeight {
-		grid[centerY+int(maxScreenRadius)][centerX] = 'S'
-	}
-	// West (left)
-	westX := centerX - int(maxScreenRadius/aspectRatio)
-	if westX >= 0 {
-		grid[centerY][westX] = 'W'
-	}
+		inRadar := func(x, y int) bool {
+			dx := float64(x-centerX) * radarAspectRatio
+			dy := float64(y - centerY)
+			return math.Hypot(dx, dy) <= maxScreenRadius
+		}
+		for _, seg := range m.radarSegments {
+			x1, y1 := m.radarProject(seg.FromWaypoint.Latitude, seg.FromWaypoint.Longitude)
+			x2, y2 := m.radarProject(seg.ToWaypoint.Latitude, seg.ToWaypoint.Longitude)
+			drawAirway(grid, x1, y1, x2, y2, inRadar)
+		}
 
-	// Draw center point (airport)
-	grid[centerY][centerX] = '✈'
+		// Label each airway once, at the middle of its first segment
+		// with room for the label
+		mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted))
+		labeled := make(map[string]bool)
+		for _, seg := range m.radarSegments {
+			if labeled[seg.AirwayID] {
+				continue
+			}
+			x1, y1 := m.radarProject(seg.FromWaypoint.Latitude, seg.FromWaypoint.Longitude)
+			x2, y2 := m.radarProject(seg.ToWaypoint.Latitude, seg.ToWaypoint.Longitude)
+			x := int(math.Round((x1+x2)/2)) - len(seg.AirwayID)/2
+			y := int(math.Round((y1 + y2) / 2))
+			if inRadar(x, y) && writeLabel(x, y, seg.AirwayID, mutedStyle) {
+				labeled[seg.AirwayID] = true
+			}
+		}
+	}
 
 	// Draw aircraft and collect labels
 	type aircraftLabel struct {
@@ -199,6 +298,10 @@ func (m model) renderRadar() string {
 
 		symbol := '○'
 		isSpecial := false
+		if ac.watched {
+			symbol = '★' // Watched aircraft
+			isSpecial = true
+		}
 		if i == m.selected {
 			symbol = '●' // Selected aircraft
 			isSpecial = true
@@ -208,9 +311,11 @@ func (m model) renderRadar() string {
 			isSpecial = true
 		}
 
+		// Aircraft are drawn over the overlay
 		grid[y][x] = symbol
+		delete(styles, [2]int{x, y})
 
-		// Add label for selected or tracked aircraft
+		// Add label for selected, tracked or watched aircraft
 		if isSpecial {
 			labelText := ac.aircraft.Callsign
 			if labelText == "" {
@@ -221,21 +326,14 @@ func (m model) renderRadar() string {
 
 		// Draw velocity vector
 		if ac.aircraft.GroundSpeed > 50 {
-			drawVelocityVectorRadar(grid, x, y, ac.aircraft.Track, ac.aircraft.GroundSpeed, aspectRatio)
+			drawVelocityVectorRadar(grid, x, y, ac.aircraft.Track, ac.aircraft.GroundSpeed, radarAspectRatio)
 		}
 	}
 
 	// Add aircraft labels to grid (after velocity vectors)
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected))
 	for _, label := range labels {
-		lx, ly := label.x, label.y
-		for i, ch := range label.label {
-			if ly >= 0 && ly < radarHeight && lx+i >= 0 && lx+i < radarWidth-2 {
-				// Only overwrite empty space or range rings
-				if grid[ly][lx+i] == ' ' || grid[ly][lx+i] == '─' {
-					grid[ly][lx+i] = ch
-				}
-			}
-		}
+		writeLabel(label.x, label.y, label.label, selectedStyle)
 	}
 
 	// Render grid with colors
@@ -243,30 +341,31 @@ func (m model) renderRadar() string {
 		radar.WriteString(borderStyle.Render("│"))
 		for x := 0; x < radarWidth-2; x++ {
 			char := grid[y][x]
+			if style, ok := styles[[2]int{x, y}]; ok {
+				radar.WriteString(style.Render(string(char)))
+				continue
+			}
 			switch char {
 			case '✈':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true).Render(string(char)))
+				radar.WriteString(observerStyle.Render(string(char)))
 			case '◉':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true).Render(string(char)))
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Tracked)).Bold(true).Render(string(char)))
 			case '●':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(string(char)))
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Selected)).Render(string(char)))
+			case '★':
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Watched)).Bold(true).Render(string(char)))
 			case '○':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Render(string(char)))
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Aircraft)).Render(string(char)))
 			case 'N', 'E', 'S', 'W':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Bold(true).Render(string(char)))
-			case '─': // Range rings - brighter for better contrast
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(string(char)))
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Muted)).Bold(true).Render(string(char)))
+			case radarRingChar:
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Grid)).Render(string(char)))
 			case '→', '-':
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(string(char)))
-			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'k': // Range labels
-				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("248")).Render(string(char)))
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Vector)).Render(string(char)))
+			case '─', '│', '╱', '╲': // Airways
+				radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint)).Render(string(char)))
 			default:
-				// Check if it's part of an aircraft label (letters)
-				if (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') {
-					radar.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render(string(char)))
-				} else {
-					radar.WriteRune(char)
-				}
+				radar.WriteRune(char)
 			}
 		}
 		radar.WriteString(borderStyle.Render("│"))
@@ -278,6 +377,21 @@ func (m model) renderRadar() string {
 	return radar.String()
 }
 
+// drawEllipse draws a continuous range ring by sampling enough angles that
+// neighbouring points touch. radius is in rows; X distances are divided by
+// aspectRatio. Only empty cells are drawn on.
+func drawEllipse(grid [][]rune, cx, cy int, radius, aspectRatio float64, char rune) {
+	steps := int(2*math.Pi*radius/aspectRatio) + 8
+	for i := 0; i < steps; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		x := cx + int(math.Round(radius*math.Sin(angle)/aspectRatio))
+		y := cy - int(math.Round(radius*math.Cos(angle)))
+		if y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y]) && grid[y][x] == ' ' {
+			grid[y][x] = char
+		}
+	}
+}
+
 // drawCircle draws a circle on the grid using Bresenham's circle algorithm.
 // Applies aspect ratio correction to draw proper circles on terminal (chars are ~2:1 height:width).
 func drawCircle(grid [][]rune, cx, cy, radius int, aspectRatio float64, char rune) {
@@ -337,7 +451,7 @@ func drawVelocityVectorRadar(grid [][]rune, x, y int, trackDeg, speedKts, aspect
 
 		nx, ny := x+dx, y+dy
 		if ny >= 0 && ny < len(grid) && nx >= 0 && nx < len(grid[0]) {
-			if grid[ny][nx] == ' ' || grid[ny][nx] == '─' {
+			if radarBackground(grid[ny][nx]) {
 				if i == length {
 					grid[ny][nx] = '→'
 				} else {
@@ -352,21 +466,41 @@ func drawVelocityVectorRadar(grid [][]rune, x, y int, trackDeg, speedKts, aspect
 func (m model) renderRadarInfo() string {
 	var info strings.Builder
 
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	info.WriteString(headerStyle.Render("RADAR MODE"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.Header))
+	info.WriteString(headerStyle.Render(m.locale.T("radar")))
 	info.WriteString("\n\n")
 
 	// Airport and radius info
-	info.WriteString(fmt.Sprintf("Center: %s\n", m.radarAirport))
-	info.WriteString(fmt.Sprintf("Radius: %.0f NM\n", m.radarRadius))
-	info.WriteString(fmt.Sprintf("Position: %.4f°, %.4f°\n", m.radarCenter.Latitude, m.radarCenter.Longitude))
-	info.WriteString(fmt.Sprintf("Aircraft: %d in range\n", len(m.aircraft)))
+	info.WriteString(m.locale.T("radar.center", m.radarAirport) + "\n")
+	info.WriteString(m.locale.T("radar.radius", m.locale.Distance(m.radarRadius, 0)) + "\n")
+	info.WriteString(m.locale.T("radar.rings", m.locale.Distance(m.cfg.Display.GetRadarRingInterval(m.radarRadius), 0)) + "\n")
+	info.WriteString(m.locale.T("radar.position", m.radarCenter.Latitude, m.radarCenter.Longitude) + "\n")
+	info.WriteString(m.locale.T("radar.aircraft", len(m.allAircraft)) + "\n")
+	airports := 0
+	for _, wp := range m.radarWaypoints {
+		if wp.Type == "airport" {
+			airports++
+		}
+	}
+	info.WriteString(m.locale.T("radar.overlay", airports, len(m.radarWaypoints)-airports) + "\n")
+	if m.radarAirways {
+		if m.radarRadius > radarDetailRadiusNM {
+			info.WriteString(m.locale.T("radar.zoomin", m.locale.Distance(radarDetailRadiusNM, 0)) + "\n")
+		} else {
+			info.WriteString(m.locale.T("radar.airways", len(m.radarSegments), len(m.radarFixes)) + "\n")
+		}
+	}
+	if m.filter.active() {
+		info.WriteString(m.locale.T("radar.filtered", len(m.aircraft)) + "\n")
+	}
 	info.WriteString("\n")
 
 	// Controls
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	info.WriteString(helpStyle.Render("R: Exit radar  +/-: Adjust radius\n"))
-	info.WriteString(helpStyle.Render("↑/↓: Select  ENTER: Track  Q: Quit"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.Faint))
+	for _, key := range []string{"radar.help1", "radar.help2", "radar.help3"} {
+		info.WriteString(helpStyle.Render(m.locale.T(key) + "\n"))
+	}
+	info.WriteString(helpStyle.Render(m.locale.T("radar.help4")))
 
 	return info.String()
 }
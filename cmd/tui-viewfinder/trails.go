@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// trackTrail stores recent positions for breadcrumb display
+type trackTrail struct {
+	positions []coordinates.HorizontalCoordinates
+	times     []time.Time
+}
+
+// add appends a position to the trail.
+func (t *trackTrail) add(pos coordinates.HorizontalCoordinates, at time.Time) {
+	t.positions = append(t.positions, pos)
+	t.times = append(t.times, at)
+}
+
+// prune drops positions older than cutoff.
+func (t *trackTrail) prune(cutoff time.Time) {
+	i := 0
+	for i < len(t.times) && t.times[i].Before(cutoff) {
+		i++
+	}
+	t.positions = t.positions[i:]
+	t.times = t.times[i:]
+}
+
+// trailFor returns the trail for an aircraft, creating it if needed.
+func (m *model) trailFor(icao string) *trackTrail {
+	trail, ok := m.trails[icao]
+	if !ok {
+		trail = &trackTrail{}
+		m.trails[icao] = trail
+	}
+	return trail
+}
+
+// loadTrails seeds the trails of aircraft seen for the first time from the
+// position history table, so breadcrumbs survive restarts. Errors are
+// ignored: the trail then simply starts empty.
+func (m *model) loadTrails(ctx context.Context, aircraft []adsb.Aircraft, now time.Time) {
+	var missing []string
+	for _, ac := range aircraft {
+		if _, ok := m.trails[ac.ICAO]; !ok {
+			missing = append(missing, ac.ICAO)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	history, err := m.repo.GetRecentTrails(ctx, missing, now.Add(-m.cfg.Display.GetTrailDuration()))
+	if err != nil {
+		return
+	}
+
+	for _, icao := range missing {
+		trail := m.trailFor(icao)
+		for _, p := range history[icao] {
+			pos := coordinates.Geographic{
+				Latitude:  p.Latitude,
+				Longitude: p.Longitude,
+				Altitude:  p.AltitudeFt.Meters(),
+			}
+			trail.add(coordinates.GeographicToHorizontal(pos, m.observer, p.Timestamp), p.Timestamp)
+		}
+	}
+}
+
+// pruneTrails drops trail positions older than cutoff and forgets aircraft
+// with nothing left.
+func (m *model) pruneTrails(cutoff time.Time) {
+	for icao, trail := range m.trails {
+		trail.prune(cutoff)
+		if len(trail.positions) == 0 {
+			delete(m.trails, icao)
+		}
+	}
+}
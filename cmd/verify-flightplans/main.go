@@ -40,9 +40,13 @@ func main() {
 			return
 		}
 
+		// Count the request against the monthly budget
+		quota := flightaware.NewQuota(flightaware.BudgetFromConfig(cfg.FlightAware),
+			db.NewAPIUsageRepository(database, flightaware.Source))
 		faClient := flightaware.NewClient(flightaware.Config{
 			APIKey:          cfg.FlightAware.APIKey,
 			RequestsPerHour: cfg.FlightAware.RequestsPerHour,
+			Quota:           quota,
 		})
 
 		fp, err := faClient.GetFlightPlanByCallsign(ctx, callsign)
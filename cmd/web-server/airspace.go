@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/czml"
+)
+
+// maxAirspaceMinutes caps how far ahead the 3D scene predicts trajectories
+const maxAirspaceMinutes = 30
+
+// handleGetAirspaceCZML returns a CZML document (for Cesium) with aircraft
+// animated along their predicted trajectories around the observer, plus the
+// telescope's line of sight when it is reachable.
+//
+// Query parameters:
+//   - minutes: prediction horizon (default 5, max 30)
+func (s *Server) handleGetAirspaceCZML(w http.ResponseWriter, r *http.Request) {
+	opts := czml.DefaultOptions()
+	if m := r.URL.Query().Get("minutes"); m != "" {
+		minutes, err := strconv.Atoi(m)
+		if err != nil || minutes < 1 || minutes > maxAirspaceMinutes {
+			http.Error(w, "minutes must be between 1 and 30", http.StatusBadRequest)
+			return
+		}
+		opts.Horizon = time.Duration(minutes) * time.Minute
+	}
+
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  s.cfg.Observer.Latitude,
+			Longitude: s.cfg.Observer.Longitude,
+			Altitude:  s.cfg.Observer.Elevation,
+		},
+	}
+
+	aircraft, err := s.aircraftRepo.GetVisibleAircraft(r.Context())
+	if err != nil {
+		log.Printf("Error getting aircraft: %v", err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+
+	// Line of sight up to the tracked aircraft's altitude when known
+	var pointing *czml.Pointing
+	if status, err := s.telescopeStatus(); err == nil {
+		altitudeFt := pointingAltitudeFt(aircraft, s.arbiter.Current())
+		pointing = &czml.Pointing{
+			Horizontal:     coordinates.HorizontalCoordinates{Altitude: status.Altitude, Azimuth: status.Azimuth},
+			AltitudeMeters: altitudeFt.Meters(),
+		}
+	}
+
+	respondJSON(w, http.StatusOK, czml.BuildAirspace(observer, db.AircraftOf(aircraft), pointing, time.Now(), opts))
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alignment"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// errUnknownReference is returned for an alignment reference that isn't a
+// catalogued star or a known aircraft
+var errUnknownReference = errors.New("unknown alignment reference")
+
+// alignmentRun holds the samples measured by the alignment wizard: the
+// operator slews to references, centers each with nudges and measures it.
+type alignmentRun struct {
+	mu      sync.Mutex
+	samples []alignment.Sample
+}
+
+// alignmentRequest names an alignment reference: a star or an aircraft
+type alignmentRequest struct {
+	Star string `json:"star"`
+	ICAO string `json:"icao"`
+}
+
+// alignmentSample is a sample with the mount's offset from the reference
+type alignmentSample struct {
+	alignment.Sample
+	AltitudeOffset float64 `json:"altitudeOffset"`
+	AzimuthOffset  float64 `json:"azimuthOffset"`
+}
+
+// telescopeStatus returns the telescope's status with its position
+// corrected by the mount's alignment, so it matches where it points in the
+// sky.
+func (s *Server) telescopeStatus() (*alpaca.TelescopeStatus, error) {
+	status, err := s.telescope.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	sky := s.aligned.Model().FromMount(coordinates.HorizontalCoordinates{Altitude: status.Altitude, Azimuth: status.Azimuth})
+	status.Altitude, status.Azimuth = sky.Altitude, sky.Azimuth
+	return status, nil
+}
+
+// referencePosition returns a reference's name and position from the
+// observer at t. Stars are looked up in the catalogue and aircraft
+// extrapolated from their last report.
+func (s *Server) referencePosition(ctx context.Context, req alignmentRequest, observer coordinates.Observer, t time.Time) (string, coordinates.HorizontalCoordinates, error) {
+	switch {
+	case req.Star != "":
+		star, ok := alignment.FindStar(req.Star)
+		if !ok {
+			return "", coordinates.HorizontalCoordinates{}, fmt.Errorf("%w: star %q", errUnknownReference, req.Star)
+		}
+		return star.Name, star.Position(observer, t), nil
+	case req.ICAO != "":
+		aircraft, err := s.aircraftRepo.GetAircraftByICAO(ctx, req.ICAO)
+		if err != nil {
+			return "", coordinates.HorizontalCoordinates{}, err
+		}
+		if aircraft == nil {
+			return "", coordinates.HorizontalCoordinates{}, fmt.Errorf("%w: aircraft %s", errUnknownReference, req.ICAO)
+		}
+		return "aircraft " + aircraft.ICAO, alignment.AircraftPosition(*aircraft, observer, t), nil
+	default:
+		return "", coordinates.HorizontalCoordinates{}, fmt.Errorf("%w: set star or icao", errUnknownReference)
+	}
+}
+
+// decodeAlignmentReference decodes a reference request and resolves it from
+// the user's observation point, responding with an error if it fails.
+func (s *Server) decodeAlignmentReference(w http.ResponseWriter, r *http.Request) (string, coordinates.HorizontalCoordinates, bool) {
+	var req alignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return "", coordinates.HorizontalCoordinates{}, false
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return "", coordinates.HorizontalCoordinates{}, false
+	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+
+	name, pos, err := s.referencePosition(r.Context(), req, observer, time.Now())
+	if errors.Is(err, errUnknownReference) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return "", coordinates.HorizontalCoordinates{}, false
+	}
+	if err != nil {
+		log.Printf("Error getting alignment reference: %v", err)
+		http.Error(w, "Failed to get alignment reference", http.StatusInternalServerError)
+		return "", coordinates.HorizontalCoordinates{}, false
+	}
+	return name, pos, true
+}
+
+// handleGetAlignment returns the alignment in use, the wizard's samples and
+// the model fitted to them.
+func (s *Server) handleGetAlignment(w http.ResponseWriter, r *http.Request) {
+	s.alignment.mu.Lock()
+	samples := append([]alignment.Sample(nil), s.alignment.samples...)
+	s.alignment.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, alignmentResponse(s.aligned.Model(), samples))
+}
+
+// alignmentResponse describes a model in use and the samples of a run.
+func alignmentResponse(model alignment.Model, samples []alignment.Sample) map[string]interface{} {
+	tilt, low := model.Level()
+	resp := map[string]interface{}{
+		"model": model,
+		"level": map[string]float64{"tilt": tilt, "lowAzimuth": low},
+	}
+
+	measured := make([]alignmentSample, len(samples))
+	for i, sample := range samples {
+		measured[i].Sample = sample
+		measured[i].AltitudeOffset, measured[i].AzimuthOffset = sample.Offset()
+	}
+	resp["samples"] = measured
+
+	if len(samples) > 0 {
+		fit, err := alignment.FitModel(samples)
+		if err != nil {
+			resp["fitError"] = err.Error()
+		} else {
+			tilt, low := fit.Model.Level()
+			resp["fit"] = fit
+			resp["fitLevel"] = map[string]float64{"tilt": tilt, "lowAzimuth": low}
+		}
+	}
+	return resp
+}
+
+// handleGetAlignmentReferences lists the stars within the mount limits from
+// the user's observation point, brightest first, and the one suggested next:
+// the farthest in azimuth from the references already measured.
+func (s *Server) handleGetAlignmentReferences(w http.ResponseWriter, r *http.Request) {
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	now := time.Now()
+
+	type starResponse struct {
+		alignment.Star
+		Altitude float64 `json:"altitude"`
+		Azimuth  float64 `json:"azimuth"`
+	}
+	visible := alignment.VisibleStars(observer, now, minAlt, maxAlt)
+	stars := make([]starResponse, len(visible))
+	for i, star := range visible {
+		pos := star.Position(observer, now)
+		stars[i] = starResponse{Star: star, Altitude: pos.Altitude, Azimuth: pos.Azimuth}
+	}
+
+	s.alignment.mu.Lock()
+	next, ok := alignment.NextStar(observer, now, minAlt, maxAlt, s.alignment.samples)
+	s.alignment.mu.Unlock()
+
+	resp := map[string]interface{}{
+		"stars": stars,
+		"next":  nil,
+	}
+	if ok {
+		resp["next"] = next.Name
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleAlignmentSlew points the telescope at a reference {star} or {icao}
+// through the alignment in use. For stars, tracking is turned on so the
+// star stays put while it is centered.
+func (s *Server) handleAlignmentSlew(w http.ResponseWriter, r *http.Request) {
+	name, pos, ok := s.decodeAlignmentReference(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.arbiter.Do(commandOwner(r), func() error {
+		if err := s.mount.SlewToAltAzContext(r.Context(), pos.Altitude, pos.Azimuth); err != nil {
+			return err
+		}
+		if _, isStar := alignment.FindStar(name); isStar {
+			if err := s.telescope.SetTracking(true); err != nil {
+				log.Printf("Error enabling tracking: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		respondCommandError(w, err, "Failed to slew telescope")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"reference": name,
+		"altitude":  pos.Altitude,
+		"azimuth":   pos.Azimuth,
+	})
+}
+
+// handleAlignmentMeasure records the centered reference {star} or {icao} as
+// a sample: where the mount reports it is pointing and where the reference
+// is now. Returns the samples and the model fitted to them.
+func (s *Server) handleAlignmentMeasure(w http.ResponseWriter, r *http.Request) {
+	mount, err := s.aligned.MountPosition()
+	if err != nil {
+		log.Printf("Error reading telescope position: %v", err)
+		http.Error(w, "Failed to read telescope position", http.StatusInternalServerError)
+		return
+	}
+	name, sky, ok := s.decodeAlignmentReference(w, r)
+	if !ok {
+		return
+	}
+
+	s.alignment.mu.Lock()
+	s.alignment.samples = append(s.alignment.samples, alignment.Sample{
+		Reference: name,
+		Sky:       sky,
+		Mount:     mount,
+		Time:      time.Now().UTC(),
+	})
+	samples := append([]alignment.Sample(nil), s.alignment.samples...)
+	s.alignment.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, alignmentResponse(s.aligned.Model(), samples))
+}
+
+// handleAlignmentApply starts using the model fitted to the samples and
+// returns it as the telescope's "alignment" configuration, to be saved so
+// it survives a restart.
+func (s *Server) handleAlignmentApply(w http.ResponseWriter, r *http.Request) {
+	s.alignment.mu.Lock()
+	fit, err := alignment.FitModel(s.alignment.samples)
+	s.alignment.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	// Changing the pointing moves the telescope under the lease holder
+	err = s.arbiter.Do(commandOwner(r), func() error {
+		s.aligned.SetModel(fit.Model)
+		return nil
+	})
+	if err != nil {
+		respondCommandError(w, err, "Failed to apply alignment")
+		return
+	}
+	tilt, low := fit.Model.Level()
+	log.Printf("🧭 Mount alignment applied: azimuth zero %.2f°, altitude zero %.2f°, tilt %.2f° (low side %.0f°), RMS %.2f°",
+		fit.Model.AzimuthZero, fit.Model.AltitudeZero, tilt, low, fit.RMS)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"fit":     fit,
+		"config":  map[string]interface{}{"alignment": fit.Model.Config()},
+	})
+}
+
+// handleAlignmentReset discards the wizard's samples to start over.
+func (s *Server) handleAlignmentReset(w http.ResponseWriter, r *http.Request) {
+	s.alignment.mu.Lock()
+	s.alignment.samples = nil
+	s.alignment.mu.Unlock()
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
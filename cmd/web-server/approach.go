@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// handleGetAircraftApproach returns an aircraft's predicted closest approach
+// to the user's active observation point: the closest range and when it is
+// reached, the elevation and azimuth at culmination, and whether the pass
+// enters (or goes above) the telescope's altitude limits.
+func (s *Server) handleGetAircraftApproach(w http.ResponseWriter, r *http.Request) {
+	icao := chi.URLParam(r, "icao")
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	approach := tracking.PredictApproach(*aircraft, observer, time.Now(), tracking.TrackingLimitsFromConfig(minAlt, maxAlt))
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"icao":               aircraft.ICAO,
+		"callsign":           aircraft.Callsign,
+		"approaching":        approach.IsApproaching,
+		"closestRangeNm":     approach.ClosestRangeNM,
+		"secondsToClosest":   approach.TimeToClosest.Seconds(),
+		"culmination":        approach.Culmination,
+		"maxElevation":       approach.MaxElevation,
+		"culminationAzimuth": approach.Azimuth,
+		"altitude":           aircraft.Altitude,
+		"minAltitude":        minAlt,
+		"maxAltitude":        maxAlt,
+		"entersLimits":       approach.EntersLimits,
+		"exceedsLimits":      approach.ExceedsLimits,
+	})
+}
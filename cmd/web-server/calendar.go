@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/auth"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/ical"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// Calendar feed defaults
+const (
+	// calendarPassMinutes is how far ahead passes are predicted. Aircraft
+	// rarely hold their track longer, so later passes would be guesses.
+	calendarPassMinutes = 30
+
+	// calendarMinElevation is the default peak elevation for a pass to be listed
+	calendarMinElevation = 45.0
+
+	// calendarRefresh is how often subscribed calendars are asked to refresh
+	calendarRefresh = 15 * time.Minute
+)
+
+// handleGetCalendarSubscription returns the URL of the user's calendar feed,
+// with a long-lived feed token that only grants access to the feed.
+func (s *Server) handleGetCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	// Feed tokens would outlive the device's pairing, so only the account
+	// holder can create them
+	if _, ok := r.Context().Value("device_id").(int); ok {
+		http.Error(w, "Paired devices cannot create calendar feeds", http.StatusForbidden)
+		return
+	}
+
+	owner := commandOwner(r)
+	role, _ := r.Context().Value("role").(string)
+
+	token, err := s.authSvc.GenerateFeedToken(owner.UserID, owner.Username, role)
+	if err != nil {
+		log.Printf("Error generating feed token: %v", err)
+		http.Error(w, "Failed to generate feed token", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"url":       "/api/v1/calendar.ics?token=" + token,
+		"expiresAt": time.Now().Add(auth.FeedTokenDuration),
+	})
+}
+
+// handleGetCalendarFeed serves scheduled observations and predicted
+// high-elevation passes as an iCalendar feed. Calendar apps can't send an
+// Authorization header, so a feed token (see handleGetCalendarSubscription)
+// is passed in the query string unless public view is enabled.
+//
+// Query parameters:
+//   - token: feed token
+//   - min_elevation: lowest peak elevation of listed passes (default 45)
+func (s *Server) handleGetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	if token := r.URL.Query().Get("token"); token != "" {
+		if _, err := s.authSvc.ValidateFeedToken(token); err != nil {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+	} else if !s.cfg.Server.PublicView {
+		http.Error(w, "Missing token", http.StatusUnauthorized)
+		return
+	}
+
+	minElevation := calendarMinElevation
+	if v := r.URL.Query().Get("min_elevation"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 || parsed > 90 {
+			http.Error(w, "min_elevation must be between 0 and 90", http.StatusBadRequest)
+			return
+		}
+		minElevation = parsed
+	}
+
+	now := time.Now().UTC()
+	cal := ical.Calendar{
+		Name:            "ads-bscope observations",
+		RefreshInterval: calendarRefresh,
+	}
+
+	tasks, err := s.scheduleRepo.Upcoming(r.Context(), now)
+	if err != nil {
+		log.Printf("Error getting schedule: %v", err)
+		http.Error(w, "Failed to get schedule", http.StatusInternalServerError)
+		return
+	}
+	for _, task := range tasks {
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("schedule-%d@ads-bscope", task.ID),
+			Start:       task.StartTime,
+			End:         task.EndTime,
+			Summary:     "🔭 " + task.Title,
+			Description: fmt.Sprintf("Scheduled %s of %s (%s)", task.Kind, task.Target, task.Status),
+			Categories:  []string{"Scheduled"},
+		})
+	}
+
+	aircraft, err := s.aircraftRepo.GetVisibleAircraft(r.Context())
+	if err != nil {
+		log.Printf("Error getting aircraft: %v", err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  s.cfg.Observer.Latitude,
+			Longitude: s.cfg.Observer.Longitude,
+			Altitude:  s.cfg.Observer.Elevation,
+		},
+	}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+
+	// Peak times in the description are shown in the observer's time zone
+	loc, err := time.LoadLocation(s.cfg.Observer.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var passes []ical.Event
+	for _, ac := range aircraft {
+		pass, ok := tracking.PredictPass(ac.Aircraft, observer, now, calendarPassMinutes*time.Minute, limits)
+		if !ok || pass.MaxElevation < minElevation {
+			continue
+		}
+
+		name := strings.TrimSpace(ac.Callsign)
+		if name == "" {
+			name = ac.ICAO
+		}
+		passes = append(passes, ical.Event{
+			// One event per aircraft, so each refresh moves it rather than adding another
+			UID:     fmt.Sprintf("pass-%s@ads-bscope", ac.ICAO),
+			Start:   pass.Start,
+			End:     pass.End.Add(time.Minute), // Calendars hide zero-length events
+			Summary: fmt.Sprintf("✈ %s pass, peak %.0f°", name, pass.MaxElevation),
+			Description: fmt.Sprintf("%s (%s) at %.0f ft, %.0f kts\nPeak %.0f° elevation at azimuth %.0f° around %s\nPredicted from its current track; check the live view before observing.",
+				name, ac.ICAO, ac.Altitude, ac.GroundSpeed,
+				pass.MaxElevation, pass.PeakAzimuth, pass.Peak.In(loc).Format("15:04:05 MST")),
+			Categories: []string{"Pass"},
+		})
+	}
+	sort.Slice(passes, func(i, j int) bool { return passes[i].Start.Before(passes[j].Start) })
+	cal.Events = append(cal.Events, passes...)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="ads-bscope.ics"`)
+	if err := cal.Write(w, now); err != nil {
+		log.Printf("Error writing calendar: %v", err)
+	}
+}
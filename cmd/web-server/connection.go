@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alerts"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// connectionMonitor supervises the telescope connection, reconnecting and
+// restoring tracking when the Alpaca server restarts, and raises alerts
+// when the connection drops and comes back.
+type connectionMonitor struct {
+	supervisor *alpaca.Supervisor
+	dispatcher *alerts.Dispatcher
+}
+
+// newConnectionMonitor creates a monitor for telescope. Connection alerts
+// are delivered through the configured alert notifiers and are always logged.
+func newConnectionMonitor(telescope *alpaca.TelescopeClient, alertsCfg config.AlertsConfig) *connectionMonitor {
+	var notifiers []alerts.Notifier
+	if alertsCfg.Enabled {
+		notifiers = alerts.NewNotifiers(alertsCfg)
+	}
+
+	return &connectionMonitor{
+		supervisor: alpaca.NewSupervisor(telescope, alpaca.DefaultSupervisorInterval),
+		dispatcher: alerts.NewDispatcher(0, notifiers...),
+	}
+}
+
+// Run supervises the connection until ctx is cancelled.
+func (m *connectionMonitor) Run(ctx context.Context) {
+	m.supervisor.OnEvent = func(event alpaca.ConnectionEvent) {
+		m.notify(ctx, event)
+	}
+	m.supervisor.Run(ctx)
+}
+
+// notify logs a connection change and delivers it as an alert.
+func (m *connectionMonitor) notify(ctx context.Context, event alpaca.ConnectionEvent) {
+	var alert alerts.Alert
+	if event.Type == alpaca.ConnectionLost {
+		alert = alerts.NewTelescopeDisconnectedAlert(event.Error, event.Time)
+		log.Printf("⚠️  %s", alert.Description)
+	} else {
+		downtime := time.Duration(event.DowntimeSeconds * float64(time.Second))
+		alert = alerts.NewTelescopeReconnectedAlert(downtime, event.Error, event.Time)
+		log.Printf("🔭 %s (%d attempts)", alert.Description, event.Attempts)
+	}
+
+	if err := m.dispatcher.Dispatch(ctx, alert); err != nil {
+		log.Printf("✗ Failed to deliver telescope connection alert: %v", err)
+	}
+}
+
+// Status returns whether the telescope is connected and the most recent
+// connection change (nil if none), for the system status endpoint.
+func (m *connectionMonitor) Status() (bool, *alpaca.ConnectionEvent) {
+	return m.supervisor.Connected(), m.supervisor.LastEvent()
+}
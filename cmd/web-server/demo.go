@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+const (
+	// demoAircraftCount is how many synthetic aircraft fly in demo mode
+	demoAircraftCount = 25
+
+	// demoUpdateInterval is how often demo aircraft are stored
+	demoUpdateInterval = 2 * time.Second
+
+	// demoCleanupInterval is how often aircraft that left the area are
+	// marked not visible
+	demoCleanupInterval = time.Minute
+
+	// demoRegion is the collection region demo aircraft are stored under
+	demoRegion = "demo"
+)
+
+// startDemoTelescope serves a simulated Alpaca telescope on a free local
+// port and returns its base URL.
+func startDemoTelescope(observer coordinates.Observer) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to listen for simulated telescope: %w", err)
+	}
+
+	go func() {
+		if err := http.Serve(listener, alpaca.NewSimulator(observer)); err != nil {
+			log.Printf("Simulated telescope stopped: %v", err)
+		}
+	}()
+
+	return "http://" + listener.Addr().String(), nil
+}
+
+// runDemoTraffic stores synthetic aircraft around the observer until ctx is
+// cancelled, standing in for the collector so every page has traffic to show.
+func (s *Server) runDemoTraffic(ctx context.Context, source *adsb.DemoSource) {
+	ticker := time.NewTicker(demoUpdateInterval)
+	defer ticker.Stop()
+
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	var lastCleanup time.Time
+
+	for {
+		now := time.Now().UTC()
+		aircraft, _ := source.GetAircraft(s.cfg.Observer.Latitude, s.cfg.Observer.Longitude, adsb.DemoRangeNM)
+		for _, ac := range aircraft {
+			if err := s.aircraftRepo.UpsertAircraft(ctx, ac, now, demoRegion); err != nil {
+				log.Printf("Error storing demo aircraft %s: %v", ac.ICAO, err)
+			}
+		}
+		if err := s.aircraftRepo.UpdateTrackableStatus(ctx, minAlt, maxAlt); err != nil {
+			log.Printf("Error updating trackable status: %v", err)
+		}
+
+		// Report as a healthy collector for the status page
+		status := db.CollectorStatus{BreakerState: adsb.BreakerClosed.String(), UpdatedAt: now}
+		if err := s.collectorRepo.SaveStatus(ctx, status); err != nil {
+			log.Printf("Error saving demo collector status: %v", err)
+		}
+
+		if now.Sub(lastCleanup) >= demoCleanupInterval {
+			if err := (&db.DB{DB: s.db}).CleanupOldData(ctx, 2*time.Minute); err != nil {
+				log.Printf("Error cleaning up demo aircraft: %v", err)
+			}
+			lastCleanup = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
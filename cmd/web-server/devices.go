@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/internal/auth"
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/qrcode"
+)
+
+// pairingQRScale is the size in pixels of each QR code module
+const pairingQRScale = 6
+
+// maxDeviceNameLength matches the paired_devices.name column
+const maxDeviceNameLength = 100
+
+// validateToken validates a session or paired device token. Device tokens
+// are checked against the database so unpairing a device locks it out
+// immediately, and carry the role the device was paired with.
+func (s *Server) validateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	claims, err := s.authSvc.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.DeviceID == 0 {
+		return claims, nil
+	}
+
+	role, err := s.deviceRepo.TouchDevice(ctx, claims.DeviceID, claims.UserID)
+	if err != nil {
+		if !errors.Is(err, db.ErrDeviceNotFound) {
+			log.Printf("Error checking paired device %d: %v", claims.DeviceID, err)
+		}
+		return nil, auth.ErrInvalidToken
+	}
+	claims.Role = role
+
+	return claims, nil
+}
+
+// defaultDeviceRole returns the role given to a device when none is
+// requested: observer if the user may control the telescope, otherwise the
+// user's own role. Devices are never paired as admin by default.
+func defaultDeviceRole(userRole string) string {
+	if auth.HasRole(userRole, auth.RoleObserver) {
+		return auth.RoleObserver
+	}
+	return userRole
+}
+
+// validDeviceRole reports whether a user may pair a device with role.
+// Admin access is not delegated to devices.
+func validDeviceRole(userRole, role string) bool {
+	switch role {
+	case auth.RoleObserver, auth.RoleViewer, auth.RoleGuest:
+		return auth.HasRole(userRole, role)
+	}
+	return false
+}
+
+// requestBaseURL returns the scheme and host the client used to reach the
+// server, so links work from other devices on the same network.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// handleCreatePairingCode issues a one-time code for linking a phone or
+// tablet to the current user's account. The response includes a link that
+// pairs the device when opened, and a QR code of that link to scan.
+func (s *Server) handleCreatePairingCode(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	userRole := r.Context().Value("role").(string)
+
+	// Pairing needs the account holder, not another paired device
+	if _, ok := r.Context().Value("device_id").(int); ok {
+		http.Error(w, "Paired devices cannot pair other devices", http.StatusForbidden)
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+		Role string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		req.Name = "Paired device"
+	}
+	if len(req.Name) > maxDeviceNameLength {
+		http.Error(w, "Device name is too long", http.StatusBadRequest)
+		return
+	}
+	if req.Role == "" {
+		req.Role = defaultDeviceRole(userRole)
+	}
+	if !validDeviceRole(userRole, req.Role) {
+		http.Error(w, "Invalid role for device", http.StatusBadRequest)
+		return
+	}
+
+	code, expiresAt, err := s.deviceRepo.CreatePairingCode(r.Context(), userID, req.Name, req.Role)
+	if err != nil {
+		log.Printf("Error creating pairing code: %v", err)
+		http.Error(w, "Failed to create pairing code", http.StatusInternalServerError)
+		return
+	}
+
+	pairURL := requestBaseURL(r) + "/?pair=" + code
+	qr, err := qrcode.Encode(pairURL)
+	if err != nil {
+		log.Printf("Error encoding pairing QR code: %v", err)
+		http.Error(w, "Failed to create pairing code", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"code":      db.FormatPairingCode(code),
+		"role":      req.Role,
+		"expiresAt": expiresAt,
+		"pairUrl":   pairURL,
+		"qrSvg":     qr.SVG(pairingQRScale),
+	})
+}
+
+// handleRedeemPairingCode pairs the calling device using a one-time code and
+// returns a long-lived device token. The response matches a login response.
+func (s *Server) handleRedeemPairingCode(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) > maxDeviceNameLength {
+		http.Error(w, "Device name is too long", http.StatusBadRequest)
+		return
+	}
+
+	device, err := s.deviceRepo.RedeemPairingCode(r.Context(), req.Code, req.Name, r.UserAgent())
+	if errors.Is(err, db.ErrPairingCodeInvalid) {
+		http.Error(w, "Invalid or expired pairing code", http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		log.Printf("Error redeeming pairing code: %v", err)
+		http.Error(w, "Failed to pair device", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := s.userRepo.GetByID(r.Context(), device.UserID)
+	if err != nil {
+		log.Printf("Error loading user for paired device: %v", err)
+		http.Error(w, "Failed to pair device", http.StatusInternalServerError)
+		return
+	}
+	if !user.IsActive {
+		http.Error(w, "Account is disabled", http.StatusForbidden)
+		return
+	}
+
+	token, err := s.authSvc.GenerateDeviceToken(user.ID, user.Username, device.Role, device.ID)
+	if err != nil {
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("📱 Paired device %q with %s (%s)", device.Name, user.Username, device.Role)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"token":     token,
+		"expiresAt": time.Now().Add(auth.DeviceTokenDuration),
+		"user": map[string]interface{}{
+			"id":       user.ID,
+			"username": user.Username,
+			"email":    user.Email,
+			"role":     device.Role,
+		},
+		"device": device,
+	})
+}
+
+// handleGetDevices lists the devices paired with the current user's account
+func (s *Server) handleGetDevices(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	devices, err := s.deviceRepo.ListDevices(r.Context(), userID)
+	if err != nil {
+		log.Printf("Error getting paired devices: %v", err)
+		http.Error(w, "Failed to get devices", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, devices)
+}
+
+// handleRevokeDevice unpairs a device. Its token stops working immediately.
+func (s *Server) handleRevokeDevice(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	deviceID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid device ID", http.StatusBadRequest)
+		return
+	}
+
+	err = s.deviceRepo.RevokeDevice(r.Context(), deviceID, userID)
+	if errors.Is(err, db.ErrDeviceNotFound) {
+		http.Error(w, "Device not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error revoking device: %v", err)
+		http.Error(w, "Failed to revoke device", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
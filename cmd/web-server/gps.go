@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/gps"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// gpsFollowInterval is how often the latest fix is checked for movement
+const gpsFollowInterval = 5 * time.Second
+
+// metersPerNM converts great-circle distances to meters
+const metersPerNM = 1852.0
+
+// newGPSReceiver creates the configured GPS receiver.
+func newGPSReceiver(cfg config.GPSConfig) *gps.Receiver {
+	if cfg.Source == "nmea" {
+		return gps.NewNMEA(cfg.Device)
+	}
+	return gps.NewGPSD(cfg.GetAddress())
+}
+
+// followGPS moves the active observation points that follow GPS whenever
+// the receiver has moved at least minMove, until ctx is cancelled.
+func followGPS(ctx context.Context, receiver *gps.Receiver, repo *db.ObservationPointRepository, minMove units.Meters) {
+	ticker := time.NewTicker(gpsFollowInterval)
+	defer ticker.Stop()
+
+	var last coordinates.Geographic
+	moved := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fix, ok := receiver.Latest()
+		if !ok {
+			continue
+		}
+		pos := coordinates.Geographic{Latitude: fix.Latitude, Longitude: fix.Longitude}
+		if moved && coordinates.DistanceNauticalMiles(last, pos)*metersPerNM < float64(minMove) {
+			continue
+		}
+
+		var elevation *units.Meters
+		if fix.HasAltitude {
+			elevation = &fix.Altitude
+		}
+		n, err := repo.MoveGPSPoints(ctx, fix.Latitude, fix.Longitude, elevation)
+		if err != nil {
+			log.Printf("Error moving GPS observation points: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("🛰️  Moved %d observation point(s) to %.5f, %.5f", n, fix.Latitude, fix.Longitude)
+		}
+		last, moved = pos, true
+	}
+}
+
+// handleGetGPS returns the GPS receiver's latest fix and its age, or null
+// without one. Without a receiver configured, enabled is false.
+func (s *Server) handleGetGPS(w http.ResponseWriter, r *http.Request) {
+	if s.gps == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"enabled": false,
+		})
+		return
+	}
+
+	resp := map[string]interface{}{
+		"enabled": true,
+		"fix":     nil,
+	}
+	if fix, ok := s.gps.Latest(); ok {
+		position := map[string]interface{}{
+			"latitude":   fix.Latitude,
+			"longitude":  fix.Longitude,
+			"time":       fix.Time,
+			"ageSeconds": time.Since(fix.Time).Seconds(),
+		}
+		if fix.HasAltitude {
+			position["altitudeMeters"] = fix.Altitude
+		}
+		resp["fix"] = position
+	}
+	if err := s.gps.Err(); err != nil {
+		resp["error"] = err.Error()
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// stations are a user's observation points as handover stations.
+type stations struct {
+	current tracking.Station
+	others  []tracking.Station
+	points  map[int]*db.ObservationPoint // By station ID; no entry for the configured default
+}
+
+// userStations returns the user's observation points as stations. The
+// active point is the current station, or the configured observer if none
+// is active (ID 0).
+func (s *Server) userStations(ctx context.Context, userID int) (*stations, error) {
+	points, err := s.observerRepo.GetUserPoints(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &stations{
+		current: tracking.Station{
+			Name:     "Default",
+			Observer: coordinates.Observer{Location: s.defaultObserverLocation()},
+		},
+		points: make(map[int]*db.ObservationPoint, len(points)),
+	}
+	for i := range points {
+		p := &points[i]
+		station := tracking.Station{
+			ID:       p.ID,
+			Name:     p.Name,
+			Observer: coordinates.Observer{Location: observationPointLocation(p)},
+		}
+		st.points[p.ID] = p
+		if p.IsActive {
+			st.current = station
+		} else {
+			st.others = append(st.others, station)
+		}
+	}
+	return st, nil
+}
+
+// defaultObserverLocation returns the configured observer's location.
+func (s *Server) defaultObserverLocation() coordinates.Geographic {
+	return coordinates.Geographic{
+		Latitude:  s.cfg.Observer.Latitude,
+		Longitude: s.cfg.Observer.Longitude,
+		Altitude:  s.cfg.Observer.Elevation,
+	}
+}
+
+// stationTelescope returns the telescope at a station: its own if it has
+// one, otherwise the server's.
+func (s *Server) stationTelescope(point *db.ObservationPoint) *alpaca.TelescopeClient {
+	if point == nil || point.TelescopeURL == "" {
+		return s.telescope
+	}
+	telescope := alpaca.NewTelescopeClient(point.TelescopeURL, s.cfg.Telescope.DeviceNumber)
+	telescope.SetRequestPolicy(s.cfg.Telescope)
+	return telescope
+}
+
+// sameTelescope reports whether two stations share a telescope.
+func sameTelescope(a, b *db.ObservationPoint) bool {
+	urlOf := func(p *db.ObservationPoint) string {
+		if p == nil {
+			return ""
+		}
+		return p.TelescopeURL
+	}
+	return urlOf(a) == urlOf(b)
+}
+
+// recommendHandover looks up the aircraft and recommends a handover from
+// the user's current station. If to is non-zero only that station is
+// considered. Writes the error response and returns false on failure.
+func (s *Server) recommendHandover(w http.ResponseWriter, r *http.Request, to int) (*adsb.Aircraft, *stations, *tracking.Handover, bool) {
+	userID := r.Context().Value("user_id").(int)
+	icao := chi.URLParam(r, "icao")
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return nil, nil, nil, false
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return nil, nil, nil, false
+	}
+
+	st, err := s.userStations(r.Context(), userID)
+	if err != nil {
+		log.Printf("Error getting observation points: %v", err)
+		http.Error(w, "Failed to get observation points", http.StatusInternalServerError)
+		return nil, nil, nil, false
+	}
+
+	others := st.others
+	if to != 0 {
+		others = nil
+		for _, station := range st.others {
+			if station.ID == to {
+				others = append(others, station)
+			}
+		}
+		if len(others) == 0 {
+			http.Error(w, "Station not found", http.StatusNotFound)
+			return nil, nil, nil, false
+		}
+	}
+
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+	h, ok := tracking.RecommendHandover(*aircraft, st.current, others, time.Now(), limitsHorizon, limits)
+	if !ok {
+		return aircraft, st, nil, true
+	}
+	return aircraft, st, &h, true
+}
+
+// handoverJSON describes a handover for API responses.
+func handoverJSON(h *tracking.Handover, st *stations) map[string]interface{} {
+	var exitsAt *time.Time
+	if !h.ExitsAt.IsZero() {
+		exitsAt = &h.ExitsAt
+	}
+	return map[string]interface{}{
+		"from":          map[string]interface{}{"id": h.From.ID, "name": h.From.Name},
+		"to":            map[string]interface{}{"id": h.To.ID, "name": h.To.Name},
+		"leavesAt":      h.LeavesAt,
+		"entersAt":      h.EntersAt,
+		"exitsAt":       exitsAt,
+		"gapSeconds":    h.Gap.Seconds(),
+		"sameTelescope": sameTelescope(st.points[h.From.ID], st.points[h.To.ID]),
+	}
+}
+
+// handleGetHandover recommends which of the user's other stations should
+// take over an aircraft as it leaves the active station's limits, and when.
+// "handover" is false if the active station keeps it for the next 15
+// minutes or no other station will see it.
+//
+// Query parameters:
+//   - to: only consider this station (observation point ID)
+func (s *Server) handleGetHandover(w http.ResponseWriter, r *http.Request) {
+	to, err := handoverStation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	aircraft, st, h, ok := s.recommendHandover(w, r, to)
+	if !ok {
+		return
+	}
+	if h == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"icao":     aircraft.ICAO,
+			"handover": false,
+		})
+		return
+	}
+
+	resp := handoverJSON(h, st)
+	resp["icao"] = aircraft.ICAO
+	resp["handover"] = true
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handleTelescopeHandover hands an aircraft over to the recommended station
+// (or the one given by ?to=): that station becomes the user's active
+// observation point and its telescope slews to the aircraft, or to where it
+// will enter the station's limits if it hasn't yet. The previous station's
+// telescope stops tracking if it is a different one.
+func (s *Server) handleTelescopeHandover(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	to, err := handoverStation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	aircraft, st, h, ok := s.recommendHandover(w, r, to)
+	if !ok {
+		return
+	}
+	if h == nil {
+		http.Error(w, "No station can take over the aircraft", http.StatusConflict)
+		return
+	}
+
+	// Point where the aircraft is now, or where it will come into view
+	at := time.Now()
+	if h.EntersAt.After(at) {
+		at = h.EntersAt
+	}
+	pos := tracking.PredictPosition(*aircraft, at).Position
+	horiz := coordinates.GeographicToHorizontal(pos, h.To.Observer, at)
+
+	from, dest := st.points[h.From.ID], st.points[h.To.ID]
+	telescope := s.stationTelescope(dest)
+	lease, err := s.arbiter.Acquire(commandOwner(r), aircraft.ICAO, func() error {
+		mount := alpaca.NewSafeTelescope(telescope, s.mount.Envelope())
+		mount.SetSolarGuard(safety.NewSolarGuard(s.cfg.Telescope, h.To.Observer))
+		if err := mount.SlewToAltAz(horiz.Altitude, horiz.Azimuth); err != nil {
+			return err
+		}
+		if err := telescope.SetTracking(true); err != nil {
+			log.Printf("Error enabling tracking: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		respondCommandError(w, err, "Failed to slew telescope")
+		return
+	}
+
+	if !sameTelescope(from, dest) {
+		if err := s.stationTelescope(from).SetTracking(false); err != nil {
+			log.Printf("Error stopping tracking at %s: %v", h.From.Name, err)
+		}
+	}
+	if err := s.observerRepo.SetActive(r.Context(), h.To.ID, userID); err != nil {
+		log.Printf("Error activating observation point: %v", err)
+		http.Error(w, "Failed to activate observation point", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("🔀 %s handed over from %s to %s", aircraft.ICAO, h.From.Name, h.To.Name)
+
+	resp := handoverJSON(h, st)
+	resp["success"] = true
+	resp["icao"] = aircraft.ICAO
+	resp["altitude"] = horiz.Altitude
+	resp["azimuth"] = horiz.Azimuth
+	resp["control"] = lease
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// handoverStation parses the optional "to" station ID (0 if not given).
+func handoverStation(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("to")
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		return 0, errors.New("invalid station ID")
+	}
+	return id, nil
+}
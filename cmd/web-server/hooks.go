@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/unklstewy/ads-bscope/internal/control"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// hookOwner is who webhook commands are issued as. Its user ID can't clash
+// with a real user's, so the control lease tells them apart.
+var hookOwner = control.Owner{UserID: -1, Username: "webhook"}
+
+// hookMiddleware authenticates inbound webhooks by the API key in the
+// X-API-Key header. Webhooks are disabled unless a key is configured.
+func (s *Server) hookMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := s.cfg.Server.HookAPIKey
+		if key == "" {
+			http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+			return
+		}
+
+		given := r.Header.Get("X-API-Key")
+		if given == "" {
+			http.Error(w, "Missing API key", http.StatusUnauthorized)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(given), []byte(key)) != 1 {
+			http.Error(w, "Invalid API key", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "user_id", hookOwner.UserID)
+		ctx = context.WithValue(ctx, "username", hookOwner.Username)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// hookTrackRequest is the body of POST /api/v1/hooks/track.
+type hookTrackRequest struct {
+	// Action is "track" (default) or "stop"
+	Action string `json:"action"`
+
+	// ICAO or Callsign identifies the aircraft to track
+	ICAO     string `json:"icao"`
+	Callsign string `json:"callsign"`
+}
+
+// handleHookTrack lets external systems (Node-RED, a Stream Deck button)
+// start tracking an aircraft by ICAO address or callsign, or stop tracking,
+// from the configured observer location.
+func (s *Server) handleHookTrack(w http.ResponseWriter, r *http.Request) {
+	var req hookTrackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	switch strings.ToLower(req.Action) {
+	case "", "track":
+	case "stop":
+		err := s.arbiter.Release(hookOwner, func() error {
+			return s.telescope.SetTracking(false)
+		})
+		if err != nil {
+			respondCommandError(w, err, "Failed to stop tracking")
+			return
+		}
+		log.Println("🔗 Webhook stopped tracking")
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"action":  "stop",
+		})
+		return
+	default:
+		http.Error(w, "Action must be track or stop", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.ICAO) == "" && strings.TrimSpace(req.Callsign) == "" {
+		http.Error(w, "icao or callsign is required", http.StatusBadRequest)
+		return
+	}
+
+	aircraft, err := s.hookTarget(r.Context(), req)
+	if err != nil {
+		log.Printf("Error finding webhook target: %v", err)
+		http.Error(w, "Failed to find aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return
+	}
+
+	observer := coordinates.Observer{Location: s.defaultObserverLocation()}
+	elevation, azimuth, lease, err := s.slewToAircraft(hookOwner, aircraft, observer)
+	if err != nil {
+		respondCommandError(w, err, "Failed to slew telescope")
+		return
+	}
+
+	log.Printf("🔗 Webhook tracking %s (%s)", strings.TrimSpace(aircraft.Callsign), aircraft.ICAO)
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"action":   "track",
+		"icao":     aircraft.ICAO,
+		"callsign": aircraft.Callsign,
+		"altitude": elevation,
+		"azimuth":  azimuth,
+		"control":  lease,
+	})
+}
+
+// hookTarget looks up the aircraft a webhook asks for, by ICAO address if
+// given, otherwise by callsign. Returns nil if it isn't visible.
+func (s *Server) hookTarget(ctx context.Context, req hookTrackRequest) (*adsb.Aircraft, error) {
+	if icao := strings.ToLower(strings.TrimSpace(req.ICAO)); icao != "" {
+		return s.aircraftRepo.GetAircraftByICAO(ctx, icao)
+	}
+	if callsign := strings.TrimSpace(req.Callsign); callsign != "" {
+		return s.aircraftRepo.GetAircraftByCallsign(ctx, callsign)
+	}
+	return nil, nil
+}
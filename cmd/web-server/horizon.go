@@ -0,0 +1,85 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// handleGetHorizonCrossings returns azimuth-only pointing data for an
+// aircraft, for steering a directional antenna from the user's active
+// observation point: its current azimuth and elevation, and when and at
+// which azimuth it is predicted to rise above and set below the elevation
+// mask within the next 30 minutes. "rise" is null if it is already above the
+// mask (or never rises), "set" null if it is still above it at the end.
+//
+// Query parameters:
+//   - mask: elevation mask in degrees (default 0, the horizon)
+func (s *Server) handleGetHorizonCrossings(w http.ResponseWriter, r *http.Request) {
+	icao := chi.URLParam(r, "icao")
+
+	mask := 0.0
+	if v := r.URL.Query().Get("mask"); v != "" {
+		m, err := strconv.ParseFloat(v, 64)
+		if err != nil || m < -5 || m > 90 {
+			http.Error(w, "mask must be between -5 and 90 degrees", http.StatusBadRequest)
+			return
+		}
+		mask = m
+	}
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+
+	now := time.Now()
+	pos := tracking.PredictPosition(*aircraft, now).Position
+	horiz := coordinates.GeographicToHorizontal(pos, observer, now)
+	crossings, visible := tracking.PredictHorizonCrossings(*aircraft, observer, now, planner.DefaultHorizon, mask)
+
+	response := map[string]interface{}{
+		"icao":      aircraft.ICAO,
+		"callsign":  aircraft.Callsign,
+		"azimuth":   horiz.Azimuth,
+		"elevation": horiz.Altitude,
+		"mask":      mask,
+		"visible":   visible,
+		"rise":      nil,
+		"set":       nil,
+	}
+	if crossings.Rises {
+		response["rise"] = map[string]interface{}{
+			"time":    crossings.Rise,
+			"azimuth": crossings.RiseAzimuth,
+		}
+	}
+	if crossings.Sets {
+		response["set"] = map[string]interface{}{
+			"time":    crossings.Set,
+			"azimuth": crossings.SetAzimuth,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/unklstewy/ads-bscope/internal/control"
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// liveInterval is how often the live feed pushes a snapshot
+const liveInterval = 2 * time.Second
+
+// Live feed connection timings
+const (
+	liveWriteWait  = 10 * time.Second
+	livePongWait   = 60 * time.Second
+	livePingPeriod = livePongWait * 9 / 10
+)
+
+// footprintMaxRangeNM caps the pointing footprint for lines of sight near the horizon
+const footprintMaxRangeNM = 100.0
+
+// commandHistoryWindow is how far back the live feed reports commanded
+// telescope positions (the sky chart keeps aircraft trails as long)
+const commandHistoryWindow = time.Minute
+
+// footprintDefaultAltitudeFt is the altitude the footprint is drawn at when
+// the telescope is not tracking a known aircraft
+const footprintDefaultAltitudeFt units.Feet = 10000
+
+var liveUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	// The API already allows any origin (see the CORS setup)
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// liveSnapshot is one message on the live feed
+type liveSnapshot struct {
+	Type      string             `json:"type"` // always "snapshot"
+	Time      time.Time          `json:"time"`
+	Observer  liveObserver       `json:"observer"`
+	Aircraft  []aircraftResponse `json:"aircraft"`
+	Telescope *livePointing      `json:"telescope"` // null if the telescope is unreachable
+	Commanded *liveCommands      `json:"commanded"` // null if nothing is being tracked
+	Sky       liveSky            `json:"sky"`
+}
+
+// liveCommands is the recent telescope commands for the aircraft being
+// tracked, so clients can compare them with its trail for lag and overshoot
+type liveCommands struct {
+	ICAO      string        `json:"icao"`
+	Positions []liveCommand `json:"positions"` // oldest first
+}
+
+// liveCommand is one commanded telescope position
+type liveCommand struct {
+	Time      time.Time `json:"time"`
+	Altitude  float64   `json:"altitude"`
+	Azimuth   float64   `json:"azimuth"`
+	Predicted bool      `json:"predicted"`
+}
+
+// liveSky is the sun, moon and telescope altitude limits for sky charts
+type liveSky struct {
+	Sun         liveBody `json:"sun"`
+	Moon        liveBody `json:"moon"`
+	MinAltitude float64  `json:"minAltitude"`
+	MaxAltitude float64  `json:"maxAltitude"`
+}
+
+// liveBody is a celestial body's position
+type liveBody struct {
+	Altitude     float64  `json:"altitude"`
+	Azimuth      float64  `json:"azimuth"`
+	Illumination *float64 `json:"illumination,omitempty"` // moon only
+}
+
+// liveObserver is the observer location the snapshot is relative to
+type liveObserver struct {
+	Latitude        float64      `json:"latitude"`
+	Longitude       float64      `json:"longitude"`
+	ElevationMeters units.Meters `json:"elevationMeters"`
+}
+
+// livePointing is the telescope state with its footprint on the map
+type livePointing struct {
+	Connected bool           `json:"connected"`
+	Slewing   bool           `json:"slewing"`
+	Tracking  bool           `json:"tracking"`
+	Altitude  float64        `json:"altitude"`
+	Azimuth   float64        `json:"azimuth"`
+	Control   *control.Lease `json:"control"`
+
+	// Footprint outlines the field of view at FootprintAltitudeFt as
+	// [lat, lon] pairs; Center is the centre of the field at that altitude
+	Footprint           [][2]float64 `json:"footprint"`
+	Center              [2]float64   `json:"center"`
+	FootprintAltitudeFt units.Feet   `json:"footprintAltitudeFt"`
+	FieldOfViewDeg      float64      `json:"fieldOfViewDeg"`
+}
+
+// liveHub fans snapshots out to WebSocket clients. Slow clients miss
+// snapshots rather than holding up the others.
+type liveHub struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+	last    []byte
+	lastAt  time.Time
+}
+
+// newLiveHub creates an empty hub.
+func newLiveHub() *liveHub {
+	return &liveHub{clients: make(map[chan []byte]struct{})}
+}
+
+// subscribe registers a client. A recent snapshot, if any, is queued so
+// the client has data without waiting for the next tick.
+func (h *liveHub) subscribe() chan []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan []byte, 4)
+	if h.last != nil && time.Since(h.lastAt) < 2*liveInterval {
+		ch <- h.last
+	}
+	h.clients[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes a client.
+func (h *liveHub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, ch)
+}
+
+// count returns the number of connected clients.
+func (h *liveHub) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// broadcast queues msg for every client.
+func (h *liveHub) broadcast(msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = msg
+	h.lastAt = time.Now()
+	for ch := range h.clients {
+		select {
+		case ch <- msg:
+		default:
+			// Client is behind; it gets the next snapshot instead
+		}
+	}
+}
+
+// runLiveFeed builds and broadcasts snapshots until ctx is cancelled.
+// Nothing is queried while no clients are connected.
+func (s *Server) runLiveFeed(ctx context.Context) {
+	ticker := time.NewTicker(liveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.live.count() == 0 {
+			continue
+		}
+
+		snapshot, err := s.buildLiveSnapshot(ctx)
+		if err != nil {
+			log.Printf("Error building live snapshot: %v", err)
+			continue
+		}
+
+		msg, err := json.Marshal(snapshot)
+		if err != nil {
+			log.Printf("Error encoding live snapshot: %v", err)
+			continue
+		}
+		s.live.broadcast(msg)
+	}
+}
+
+// buildLiveSnapshot gathers aircraft and telescope state relative to the
+// configured observer.
+func (s *Server) buildLiveSnapshot(ctx context.Context) (*liveSnapshot, error) {
+	observer := coordinates.Observer{
+		Location: coordinates.Geographic{
+			Latitude:  s.cfg.Observer.Latitude,
+			Longitude: s.cfg.Observer.Longitude,
+			Altitude:  s.cfg.Observer.Elevation,
+		},
+	}
+
+	aircraft, err := s.aircraftRepo.GetVisibleAircraft(ctx)
+	if err != nil {
+		return nil, err
+	}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+
+	snapshot := &liveSnapshot{
+		Type: "snapshot",
+		Time: time.Now().UTC(),
+		Observer: liveObserver{
+			Latitude:        observer.Location.Latitude,
+			Longitude:       observer.Location.Longitude,
+			ElevationMeters: observer.Location.Altitude,
+		},
+		Aircraft: buildAircraftResponses(aircraft, s.destinations(ctx), s.centers, s.terrain, observer, minAlt, maxAlt),
+		Sky:      buildLiveSky(observer, s.cfg.Telescope, time.Now()),
+	}
+
+	commands, err := s.aircraftRepo.GetRecentTrackingCommands(ctx, time.Now().Add(-commandHistoryWindow))
+	if err != nil {
+		log.Printf("Error getting tracking commands: %v", err)
+	} else {
+		snapshot.Commanded = buildLiveCommands(commands)
+	}
+
+	status, err := s.telescopeStatus()
+	if err != nil {
+		// Aircraft are still worth sending without the telescope
+		return snapshot, nil
+	}
+
+	// Draw the footprint at the tracked aircraft's altitude when known
+	lease := s.arbiter.Current()
+	altitudeFt := pointingAltitudeFt(aircraft, lease)
+
+	pointing := coordinates.HorizontalCoordinates{Altitude: status.Altitude, Azimuth: status.Azimuth}
+	altitudeM := altitudeFt.Meters()
+	fov := s.cfg.Telescope.GetFieldOfView()
+
+	center, _, _ := coordinates.PointingGroundPoint(observer.Location, pointing, altitudeM, footprintMaxRangeNM)
+	outline := coordinates.PointingFootprint(observer.Location, pointing, fov, altitudeM, footprintMaxRangeNM)
+	footprint := make([][2]float64, len(outline))
+	for i, p := range outline {
+		footprint[i] = [2]float64{p.Latitude, p.Longitude}
+	}
+
+	snapshot.Telescope = &livePointing{
+		Connected:           status.Connected,
+		Slewing:             status.Slewing,
+		Tracking:            status.Tracking,
+		Altitude:            status.Altitude,
+		Azimuth:             status.Azimuth,
+		Control:             lease,
+		Footprint:           footprint,
+		Center:              [2]float64{center.Latitude, center.Longitude},
+		FootprintAltitudeFt: altitudeFt,
+		FieldOfViewDeg:      fov,
+	}
+
+	return snapshot, nil
+}
+
+// pointingAltitudeFt returns the altitude of the aircraft the control lease
+// is tracking, or footprintDefaultAltitudeFt if there is none.
+func pointingAltitudeFt(aircraft []db.ObservedAircraft, lease *control.Lease) units.Feet {
+	if lease == nil || lease.Target == "" {
+		return footprintDefaultAltitudeFt
+	}
+	for _, ac := range aircraft {
+		if ac.ICAO == lease.Target && ac.Altitude > 0 {
+			return ac.Altitude
+		}
+	}
+	return footprintDefaultAltitudeFt
+}
+
+// buildLiveCommands returns the commands sent for the most recently
+// commanded aircraft, or nil if there are none.
+func buildLiveCommands(entries []db.TrackingLogEntry) *liveCommands {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	commands := &liveCommands{ICAO: entries[len(entries)-1].ICAO}
+	for _, e := range entries {
+		if e.ICAO != commands.ICAO {
+			continue
+		}
+		commands.Positions = append(commands.Positions, liveCommand{
+			Time:      e.Timestamp,
+			Altitude:  e.TelescopeAltitude,
+			Azimuth:   e.TelescopeAzimuth,
+			Predicted: e.Predicted,
+		})
+	}
+	return commands
+}
+
+// buildLiveSky positions the sun and moon and reports the telescope's
+// altitude limits.
+func buildLiveSky(observer coordinates.Observer, telescopeCfg config.TelescopeConfig, now time.Time) liveSky {
+	sun := coordinates.CalculateSunPosition(observer, now)
+	moon := coordinates.CalculateMoonPosition(observer, now)
+	minAlt, maxAlt := telescopeCfg.GetAltitudeLimits()
+
+	return liveSky{
+		Sun:         liveBody{Altitude: sun.Altitude, Azimuth: sun.Azimuth},
+		Moon:        liveBody{Altitude: moon.Altitude, Azimuth: moon.Azimuth, Illumination: &moon.Illumination},
+		MinAltitude: minAlt,
+		MaxAltitude: maxAlt,
+	}
+}
+
+// handleLiveFeed upgrades to a WebSocket and streams snapshots.
+//
+// Browsers cannot set headers on WebSocket requests, so the token is passed
+// as ?token=. Without one the feed is only available in public view mode.
+func (s *Server) handleLiveFeed(w http.ResponseWriter, r *http.Request) {
+	if token := r.URL.Query().Get("token"); token != "" {
+		if _, err := s.validateToken(r.Context(), token); err != nil {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+	} else if !s.cfg.Server.PublicView {
+		http.Error(w, "Missing token", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an error
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch := s.live.subscribe()
+	defer s.live.unsubscribe(ch)
+
+	// Read (and discard) client messages so pongs and close frames are handled
+	done := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(livePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(livePingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case msg := <-ch:
+			conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
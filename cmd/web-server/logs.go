@@ -0,0 +1,108 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// logBufferLines is how many recent log lines are kept for
+	// GET /api/v1/system/logs
+	logBufferLines = 2000
+
+	// defaultLogLines is how many lines are returned without ?lines=
+	defaultLogLines = 100
+)
+
+// logLine is one line of the server log, numbered so clients can follow the
+// log by asking for lines after the last they saw.
+type logLine struct {
+	Seq  int64  `json:"seq"`
+	Line string `json:"line"`
+}
+
+// logBuffer keeps the most recent server log lines in memory, so the log can
+// be tailed over the API (adsbscope-ctl logs) without shell access. It is an
+// io.Writer for log.SetOutput.
+type logBuffer struct {
+	mu      sync.Mutex
+	lines   []logLine
+	nextSeq int64
+	partial string // Text after the last newline, completed by the next write
+}
+
+// newLogBuffer creates an empty log buffer.
+func newLogBuffer() *logBuffer {
+	return &logBuffer{nextSeq: 1}
+}
+
+// Write appends complete lines to the buffer, dropping the oldest beyond
+// logBufferLines.
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	text := b.partial + string(p)
+	parts := strings.Split(text, "\n")
+	b.partial = parts[len(parts)-1]
+
+	for _, line := range parts[:len(parts)-1] {
+		b.lines = append(b.lines, logLine{Seq: b.nextSeq, Line: line})
+		b.nextSeq++
+	}
+	if excess := len(b.lines) - logBufferLines; excess > 0 {
+		b.lines = append(b.lines[:0:0], b.lines[excess:]...)
+	}
+
+	return len(p), nil
+}
+
+// Tail returns up to n lines after seq (the last n lines if seq is 0), and
+// the seq to ask for next.
+func (b *logBuffer) Tail(after int64, n int) ([]logLine, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	start := 0
+	for start < len(b.lines) && b.lines[start].Seq <= after {
+		start++
+	}
+	lines := b.lines[start:]
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return append([]logLine{}, lines...), b.nextSeq - 1
+}
+
+// handleGetLogs returns recent server log lines: the last ?lines= (default
+// 100), or with ?after=SEQ only lines logged since, for following the log.
+func (s *Server) handleGetLogs(w http.ResponseWriter, r *http.Request) {
+	n := defaultLogLines
+	if v := r.URL.Query().Get("lines"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid lines", http.StatusBadRequest)
+			return
+		}
+		n = min(parsed, logBufferLines)
+	}
+
+	var after int64
+	if v := r.URL.Query().Get("after"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid after", http.StatusBadRequest)
+			return
+		}
+		after = parsed
+	}
+
+	lines, last := s.logs.Tail(after, n)
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"lines": lines,
+		"last":  last,
+	})
+}
@@ -6,14 +6,18 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -23,32 +27,70 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/unklstewy/ads-bscope/internal/auth"
+	"github.com/unklstewy/ads-bscope/internal/control"
 	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/alignment"
 	"github.com/unklstewy/ads-bscope/pkg/alpaca"
 	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/flightaware"
+	"github.com/unklstewy/ads-bscope/pkg/gps"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
+	"github.com/unklstewy/ads-bscope/pkg/offline"
+	"github.com/unklstewy/ads-bscope/pkg/performance"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+	"github.com/unklstewy/ads-bscope/pkg/terrain"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 var (
 	configPath = flag.String("config", "configs/config.json", "Path to configuration file")
 	port       = flag.Int("port", 8080, "HTTP server port")
+	demo       = flag.Bool("demo", false, "Demo mode: synthetic aircraft and a simulated telescope, no receiver, API keys or hardware needed")
 )
 
 // Server holds the HTTP server and its dependencies
 type Server struct {
-	router       *chi.Mux
-	db           *sql.DB
-	authSvc      *auth.Service
-	userRepo     *db.UserRepository
-	aircraftRepo *db.AircraftRepository
-	observerRepo *db.ObservationPointRepository
-	telescope    *alpaca.TelescopeClient
-	cfg          *config.Config
+	router         *chi.Mux
+	db             *sql.DB
+	database       *db.DB // db with the repository helpers; caches the size for health checks
+	authSvc        *auth.Service
+	userRepo       *db.UserRepository
+	aircraftRepo   *db.AircraftRepository
+	observerRepo   *db.ObservationPointRepository
+	collectorRepo  *db.CollectorRepository
+	scheduleRepo   *db.ScheduleRepository
+	deviceRepo     *db.DeviceRepository
+	watchRepo      *db.WatchRepository
+	flightPlanRepo *db.FlightPlanRepository
+	passRepo       *db.PassReportRepository
+	calibrationRepo *db.CalibrationRepository
+	centers        navdata.Centers // ARTCC/FIR areas, for locating aircraft
+	terrain        *terrain.Checker // Hides low aircraft behind high ground; nil without terrain tiles
+	telescope      *alpaca.TelescopeClient
+	mount          *alpaca.SafeTelescope // telescope, kept within the safety envelope
+	aligned        *alignment.Telescope  // telescope, corrected by the mount's alignment
+	alignment      *alignmentRun
+	arbiter        *control.Arbiter
+	weather        *weatherMonitor
+	connection     *connectionMonitor
+	receiver       *receiverMonitor
+	gps            *gps.Receiver // nil unless a GPS receiver is enabled
+	live           *liveHub
+	bundles        *offline.Store
+	logs           *logBuffer
+	cfg            *config.Config
 }
 
 func main() {
 	flag.Parse()
 
+	// Keep recent log lines for GET /api/v1/system/logs
+	logs := newLogBuffer()
+	log.SetOutput(io.MultiWriter(os.Stderr, logs))
+
 	log.Println("🚀 Starting ADS-B Scope Web Server...")
 
 	// Load configuration
@@ -57,6 +99,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Extend the aircraft performance table used by predictions
+	if cfg.ADSB.PerformanceFile != "" {
+		n, err := performance.UseFile(cfg.ADSB.PerformanceFile)
+		if err != nil {
+			log.Fatalf("Failed to load aircraft performance: %v", err)
+		}
+		log.Printf("✅ Loaded performance for %d aircraft types", n)
+	}
+
 	// Connect to database
 	database, err := connectDatabase(cfg)
 	if err != nil {
@@ -64,9 +115,12 @@ func main() {
 	}
 	defer database.Close()
 
-	// Run migrations
-	if err := runMigrations(database); err != nil {
-		log.Printf("Warning: Migrations failed: %v", err)
+	// Wrap sql.DB in db.DB for repositories
+	dbWrapper := &db.DB{DB: database}
+	
+	// Apply pending schema migrations
+	if err := dbWrapper.Migrate(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	// Initialize auth service
@@ -87,32 +141,141 @@ func main() {
 		},
 	}
 	
-	// Wrap sql.DB in db.DB for aircraft repository
-	dbWrapper := &db.DB{DB: database}
 	aircraftRepo := db.NewAircraftRepository(dbWrapper, observer)
 	observerRepo := db.NewObservationPointRepository(dbWrapper)
+	collectorRepo := db.NewCollectorRepository(dbWrapper)
+	scheduleRepo := db.NewScheduleRepository(dbWrapper)
+	deviceRepo := db.NewDeviceRepository(dbWrapper)
+	watchRepo := db.NewWatchRepository(dbWrapper)
+	flightPlanRepo := db.NewFlightPlanRepository(dbWrapper)
+	passRepo := db.NewPassReportRepository(dbWrapper)
+	calibrationRepo := db.NewCalibrationRepository(dbWrapper)
+	
+	// Center boundaries change only with each data cycle, so load them once
+	centers, err := db.NewNavdataRepository(dbWrapper).Centers(context.Background())
+	if err != nil {
+		log.Printf("⚠️  ARTCC/FIR boundaries unavailable, aircraft centers will not be reported: %v", err)
+	} else if len(centers) > 0 {
+		log.Printf("🗺️  Loaded %d ARTCC/FIR areas", len(centers))
+	}
+	
+	// Low aircraft behind high ground aren't trackable
+	terrainChecker, err := terrain.CheckerFromConfig(cfg.Observer.Terrain)
+	if err != nil {
+		log.Fatalf("Failed to load terrain: %v", err)
+	}
 	
 	// Initialize telescope client
 	// Use environment variable if set, otherwise use config
 	telescopeURL := getEnvOrDefault("TELESCOPE_URL", cfg.Telescope.BaseURL)
+	if *demo {
+		telescopeURL, err = startDemoTelescope(observer)
+		if err != nil {
+			log.Fatalf("Failed to start simulated telescope: %v", err)
+		}
+		// Nothing else real is needed: no weather station or paid lookups
+		cfg.Telescope.WeatherStationEnabled = false
+		cfg.FlightAware.Enabled = false
+	}
 	telescopeClient := alpaca.NewTelescopeClient(telescopeURL, cfg.Telescope.DeviceNumber)
+	telescopeClient.SetRequestPolicy(cfg.Telescope)
 	log.Printf("🔭 Telescope client initialized: %s (device %d)", telescopeURL, cfg.Telescope.DeviceNumber)
+	if caps, err := telescopeClient.ProbeCapabilities(); err != nil {
+		log.Printf("⚠️  Telescope capabilities unknown, commands will not be gated: %v", err)
+	} else if !caps.CanSlewAltAzAsync {
+		log.Printf("⚠️  Telescope cannot slew to alt/az asynchronously; slews will be refused")
+	}
+	envelope := alpaca.NewSafetyEnvelope(cfg.Telescope)
+	log.Printf("🛡️  Mount limits: altitude %.0f°-%.0f° (soft margin %.1f°)", envelope.MinAltitude, envelope.MaxAltitude, envelope.SoftMargin)
+	aligned := alignment.NewTelescope(telescopeClient, alignment.ModelFromConfig(cfg.Telescope.Alignment))
+	mount := alpaca.NewSafeTelescope(aligned, envelope)
+	mount.SetSolarGuard(safety.NewSolarGuard(cfg.Telescope, coordinates.Observer{Location: coordinates.Geographic{
+		Latitude:  cfg.Observer.Latitude,
+		Longitude: cfg.Observer.Longitude,
+		Altitude:  cfg.Observer.Elevation,
+	}}))
+
+	// Poll the weather station for the weather endpoint and high wind alerts
+	monitorCtx, stopMonitors := context.WithCancel(context.Background())
+	defer stopMonitors()
+
+	var weather *weatherMonitor
+	if cfg.Telescope.WeatherStationEnabled {
+		stationCfg := cfg.Telescope
+		stationCfg.BaseURL = telescopeURL
+		weather = newWeatherMonitor(stationCfg, cfg.Alerts)
+		go weather.Run(monitorCtx)
+		log.Printf("🌦️  Weather station polling enabled (device %d, wind limit %.1f m/s)",
+			cfg.Telescope.ObservingConditionsDeviceNumber, cfg.Telescope.MaxWindSpeedMS)
+	}
+
+	// Reconnect to the telescope and restore tracking if its Alpaca server restarts
+	connection := newConnectionMonitor(telescopeClient, cfg.Alerts)
+	go connection.Run(monitorCtx)
+
+	// Report the health of a local SDR receiver if one is configured
+	var receiver *receiverMonitor
+	if src, ok := localReceiverSource(cfg.ADSB.Sources); ok && !*demo {
+		receiver = newReceiverMonitor(src)
+		go receiver.Run(monitorCtx)
+		log.Printf("📡 Receiver stats polling enabled: %s (%s)", src.Name, receiver.url)
+	}
+
+	// Move observation points that follow GPS with the receiver
+	var gpsReceiver *gps.Receiver
+	if cfg.Observer.GPS.Enabled {
+		gpsReceiver = newGPSReceiver(cfg.Observer.GPS)
+		go gpsReceiver.Run(monitorCtx)
+		go followGPS(monitorCtx, gpsReceiver, observerRepo, cfg.Observer.GPS.GetMinMove())
+		log.Printf("🛰️  GPS receiver enabled: observation points following GPS move at least %.0f m", float64(cfg.Observer.GPS.GetMinMove()))
+	}
 
 	// Create server
 	srv := &Server{
-		router:       chi.NewRouter(),
-		db:           database,
-		authSvc:      authSvc,
-		userRepo:     userRepo,
-		aircraftRepo: aircraftRepo,
-		observerRepo: observerRepo,
-		telescope:    telescopeClient,
-		cfg:          cfg,
+		router:         chi.NewRouter(),
+		db:             database,
+		database:       dbWrapper,
+		authSvc:        authSvc,
+		userRepo:       userRepo,
+		aircraftRepo:   aircraftRepo,
+		observerRepo:   observerRepo,
+		collectorRepo:  collectorRepo,
+		scheduleRepo:   scheduleRepo,
+		deviceRepo:     deviceRepo,
+		watchRepo:      watchRepo,
+		flightPlanRepo: flightPlanRepo,
+		passRepo:       passRepo,
+		calibrationRepo: calibrationRepo,
+		centers:        centers,
+		terrain:        terrainChecker,
+		arbiter:        control.NewArbiter(control.DefaultLeaseDuration),
+		telescope:      telescopeClient,
+		mount:          mount,
+		aligned:        aligned,
+		alignment:      &alignmentRun{},
+		weather:        weather,
+		connection:     connection,
+		receiver:       receiver,
+		gps:            gpsReceiver,
+		live:           newLiveHub(),
+		bundles:        offline.NewStore(offline.DefaultStoreSize),
+		logs:           logs,
+		cfg:            cfg,
 	}
 
 	// Setup routes
 	srv.setupRoutes()
 
+	// Push aircraft and telescope snapshots to WebSocket clients
+	go srv.runLiveFeed(monitorCtx)
+
+	// Demo mode: fly synthetic aircraft around the observer
+	if *demo {
+		go srv.runDemoTraffic(monitorCtx, adsb.NewDemoSource(observer.Location, demoAircraftCount, time.Now().UnixNano()))
+		log.Printf("🎭 Demo mode: %d synthetic aircraft within %.0f nm, simulated telescope at %s",
+			demoAircraftCount, adsb.DemoRangeNM, telescopeURL)
+	}
+
 	// Start HTTP server
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", *port),
@@ -127,6 +290,9 @@ func main() {
 		log.Printf("📡 Server listening on http://localhost:%d", *port)
 		log.Printf("💡 Open http://localhost:%d in your browser", *port)
 		log.Printf("   Demo login: admin / admin\n")
+		if cfg.Server.PublicView {
+			log.Printf("👀 Public view enabled: aircraft and status are visible without login")
+		}
 		
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed: %v", err)
@@ -139,6 +305,7 @@ func main() {
 	<-quit
 
 	log.Println("\n👋 Shutting down server...")
+	stopMonitors()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -176,6 +343,44 @@ func (s *Server) setupRoutes() {
 	r.Route("/api/v1", func(r chi.Router) {
 		// Public routes
 		r.Post("/auth/login", s.handleLogin)
+		r.Get("/auth/config", s.handleGetAuthConfig)
+		r.Post("/auth/pair", s.handleRedeemPairingCode)
+		
+		// Read-only routes (anonymous access allowed in public view mode)
+		r.Group(func(r chi.Router) {
+			r.Use(s.viewerMiddleware)
+			
+			// Aircraft endpoints
+			r.Get("/aircraft", s.handleGetAircraft)
+			r.Get("/aircraft/{icao}", s.handleGetAircraftByICAO)
+			r.Get("/aircraft/{icao}/route", s.handleGetAircraftRoute)
+			r.Get("/aircraft/{icao}/approach", s.handleGetAircraftApproach)
+			r.Get("/aircraft/{icao}/profile", s.handleGetAircraftProfile)
+			r.Get("/aircraft/{icao}/next", s.handleGetNextTarget)
+			r.Get("/aircraft/{icao}/horizon", s.handleGetHorizonCrossings)
+			
+			// Telescope read-only endpoints
+			r.Get("/telescope/config", s.handleGetTelescopeConfig)
+			r.Get("/telescope/status", s.handleGetTelescopeStatus)
+			
+			// Pointing accuracy of passes tracked by cmd/autotracker
+			r.Get("/passes", s.handleGetPasses)
+			r.Get("/passes/calibration", s.handleGetErrorCalibration)
+			r.Get("/passes/{id}", s.handleGetPass)
+			
+			// System endpoints
+			r.Get("/system/status", s.handleGetSystemStatus)
+			r.Get("/system/collector", s.handleGetCollectorStatus)
+			r.Get("/system/sources", s.handleGetSources)
+			r.Get("/system/receiver", s.handleGetReceiver)
+			r.Get("/weather", s.handleGetWeather)
+			r.Get("/twilight", s.handleGetTwilight)
+			r.Get("/airspace/czml", s.handleGetAirspaceCZML)
+			
+			// Reference data for offline use
+			r.Get("/offline/bundle", s.handleGetOfflineBundle)
+			r.Get("/offline/bundle/diff", s.handleGetOfflineBundleDiff)
+		})
 		
 		// Protected routes (require authentication)
 		r.Group(func(r chi.Router) {
@@ -184,10 +389,6 @@ func (s *Server) setupRoutes() {
 			r.Post("/auth/logout", s.handleLogout)
 			r.Get("/auth/me", s.handleGetCurrentUser)
 			
-			// Aircraft endpoints
-			r.Get("/aircraft", s.handleGetAircraft)
-			r.Get("/aircraft/{icao}", s.handleGetAircraftByICAO)
-			
 			// Observation point endpoints
 			r.Get("/observer/points", s.handleGetObservationPoints)
 			r.Get("/observer/active", s.handleGetActiveObservationPoint)
@@ -195,21 +396,63 @@ func (s *Server) setupRoutes() {
 			r.Put("/observer/points/{id}", s.handleUpdateObservationPoint)
 			r.Delete("/observer/points/{id}", s.handleDeleteObservationPoint)
 			r.Post("/observer/points/{id}/activate", s.handleActivateObservationPoint)
+			r.Get("/observer/gps", s.handleGetGPS)
 			
-			// Telescope endpoints
-			r.Get("/telescope/config", s.handleGetTelescopeConfig)
-			r.Get("/telescope/status", s.handleGetTelescopeStatus)
+			// Telescope control endpoints
 			r.Post("/telescope/slew", s.handleTelescopeSlew)
 			r.Post("/telescope/track/{icao}", s.handleTelescopeTrack)
 			r.Post("/telescope/stop", s.handleTelescopeStop)
+			r.Post("/telescope/nudge", s.handleTelescopeNudge)
 			r.Post("/telescope/abort", s.handleTelescopeAbort)
 			
-			// System endpoints
-			r.Get("/system/status", s.handleGetSystemStatus)
+			// Alignment wizard: measure references to fit the mount's pointing model
+			r.Get("/telescope/alignment", s.handleGetAlignment)
+			r.Delete("/telescope/alignment", s.handleAlignmentReset)
+			r.Get("/telescope/alignment/references", s.handleGetAlignmentReferences)
+			r.Post("/telescope/alignment/slew", s.handleAlignmentSlew)
+			r.Post("/telescope/alignment/measure", s.handleAlignmentMeasure)
+			r.Post("/telescope/alignment/apply", s.handleAlignmentApply)
+			
+			// Handover between the user's stations (observation points)
+			r.Get("/aircraft/{icao}/handover", s.handleGetHandover)
+			r.Post("/telescope/handover/{icao}", s.handleTelescopeHandover)
+			
+			// Observation schedule endpoints (executed by cmd/autotracker)
+			r.Get("/schedule", s.handleGetSchedule)
+			r.Post("/schedule", s.handleCreateScheduledTask)
+			r.Delete("/schedule/{id}", s.handleCancelScheduledTask)
+			r.Get("/calendar/subscription", s.handleGetCalendarSubscription)
+			
+			// Paired devices
+			r.Get("/devices", s.handleGetDevices)
+			r.Post("/devices/pair", s.handleCreatePairingCode)
+			r.Delete("/devices/{id}", s.handleRevokeDevice)
+			
+			// Watch list
+			r.Get("/watchlist", s.handleGetWatchList)
+			r.Post("/watchlist", s.handleAddWatchedAircraft)
+			r.Delete("/watchlist/{id}", s.handleRemoveWatchedAircraft)
+			
+			// Administration
+			r.Group(func(r chi.Router) {
+				r.Use(s.adminMiddleware)
+				
+				r.Get("/users", s.handleGetUsers)
+				r.Post("/users", s.handleCreateUser)
+				r.Put("/users/{id}", s.handleUpdateUser)
+				r.Delete("/users/{id}", s.handleDeleteUser)
+				r.Get("/system/logs", s.handleGetLogs)
+			})
 		})
 		
-		// WebSocket endpoint (will implement later)
-		// r.Get("/ws", s.handleWebSocket)
+		// WebSocket live feed (token in the query string, see handleLiveFeed)
+		r.Get("/ws", s.handleLiveFeed)
+		
+		// iCalendar feed (feed token in the query string, see handleGetCalendarFeed)
+		r.Get("/calendar.ics", s.handleGetCalendarFeed)
+		
+		// Inbound webhooks from external systems (API key, see hookMiddleware)
+		r.With(s.hookMiddleware).Post("/hooks/track", s.handleHookTrack)
 	})
 
 	// Serve static files (PWA)
@@ -234,6 +477,11 @@ func (s *Server) setupRoutes() {
 	r.Handle("/manifest.json", fileServer)
 	r.Handle("/sw.js", fileServer)
 	
+	// Live map page
+	r.Get("/map", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join(staticDir, "map.html"))
+	})
+	
 	// Serve index.html for all other routes (SPA routing)
 	r.Get("/*", func(w http.ResponseWriter, r *http.Request) {
 		indexPath := filepath.Join(staticDir, "index.html")
@@ -261,7 +509,7 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Validate token
-		claims, err := s.authSvc.ValidateToken(token)
+		claims, err := s.validateToken(r.Context(), token)
 		if err != nil {
 			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 			return
@@ -271,11 +519,42 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 		ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
 		ctx = context.WithValue(ctx, "username", claims.Username)
 		ctx = context.WithValue(ctx, "role", claims.Role)
+		if claims.DeviceID != 0 {
+			ctx = context.WithValue(ctx, "device_id", claims.DeviceID)
+		}
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// viewerMiddleware guards read-only endpoints. In public view mode, requests
+// without an Authorization header are served anonymously (no user in the
+// context); otherwise it behaves like authMiddleware.
+func (s *Server) viewerMiddleware(next http.Handler) http.Handler {
+	authed := s.authMiddleware(next)
+	if !s.cfg.Server.PublicView {
+		return authed
+	}
+	
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authed.ServeHTTP(w, r)
+	})
+}
+
+// handleGetAuthConfig tells the PWA whether it may show the live view without
+// login, and its default language and units
+func (s *Server) handleGetAuthConfig(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"publicView": s.cfg.Server.PublicView,
+		"language":   s.cfg.Display.Language,
+		"units":      s.cfg.Display.Units,
+	})
+}
+
 // handleLogin handles user login
 func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -352,107 +631,267 @@ func (s *Server) handleGetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// handleGetAircraft returns all visible aircraft from the database
-func (s *Server) handleGetAircraft(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("user_id").(int)
+// requestObservationPoint returns the requesting user's active observation
+// point, or the configured observer for anonymous viewers and users without
+// an active point.
+func (s *Server) requestObservationPoint(r *http.Request) (*db.ObservationPoint, error) {
+	// Anonymous public viewers have no user ID
+	if userID, ok := r.Context().Value("user_id").(int); ok {
+		obsPoint, err := s.observerRepo.GetActivePoint(r.Context(), userID)
+		if err != nil {
+			return nil, err
+		}
+		if obsPoint != nil {
+			return obsPoint, nil
+		}
+	}
 	
-	// Get user's active observation point
-	obsPoint, err := s.observerRepo.GetActivePoint(r.Context(), userID)
+	// No active point - use default from config
+	return &db.ObservationPoint{
+		Latitude:        s.cfg.Observer.Latitude,
+		Longitude:       s.cfg.Observer.Longitude,
+		ElevationMeters: s.cfg.Observer.Elevation,
+	}, nil
+}
+
+// observationPointLocation returns the location of an observation point.
+func observationPointLocation(obsPoint *db.ObservationPoint) coordinates.Geographic {
+	return coordinates.Geographic{
+		Latitude:  obsPoint.Latitude,
+		Longitude: obsPoint.Longitude,
+		Altitude:  obsPoint.ElevationMeters,
+	}
+}
+
+// handleGetAircraft returns all visible aircraft from the database, with
+// distance, azimuth, elevation and trackability relative to the user's
+// active observation point
+// Query parameters:
+//   - emergency=true: only return aircraft squawking 7500/7600/7700
+//   - trackable=true: only return aircraft within the telescope's altitude limits
+//   - operator, family, category: only return aircraft of these operators
+//     ("FDX" or "fedex"), type families ("747", "A320") or categories
+//     ("widebody"); each a comma-separated list
+func (s *Server) handleGetAircraft(w http.ResponseWriter, r *http.Request) {
+	obsPoint, err := s.requestObservationPoint(r)
 	if err != nil {
 		log.Printf("Error getting active observation point: %v", err)
 		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
 		return
 	}
 	
-	if obsPoint == nil {
-		// No active point - use default from config
-		obsPoint = &db.ObservationPoint{
-			Latitude:        s.cfg.Observer.Latitude,
-			Longitude:       s.cfg.Observer.Longitude,
-			ElevationMeters: s.cfg.Observer.Elevation,
-		}
-	}
-	
 	// Create observer for calculations
-	observer := coordinates.Observer{
-		Location: coordinates.Geographic{
-			Latitude:  obsPoint.Latitude,
-			Longitude: obsPoint.Longitude,
-			Altitude:  obsPoint.ElevationMeters,
-		},
-	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
 	
-	aircraft, err := s.aircraftRepo.GetVisibleAircraft(r.Context())
+	var aircraft []db.ObservedAircraft
+	if r.URL.Query().Get("trackable") == "true" {
+		aircraft, err = s.aircraftRepo.GetTrackableAircraftFrom(r.Context(), observer.Location, minAlt, maxAlt)
+		
+		// Low aircraft behind high ground can't be tracked
+		visible := aircraft[:0]
+		for _, ac := range aircraft {
+			if !terrainBlocked(s.terrain, observer.Location, ac) {
+				visible = append(visible, ac)
+			}
+		}
+		aircraft = visible
+	} else {
+		aircraft, err = s.aircraftRepo.GetVisibleAircraftFrom(r.Context(), observer.Location)
+	}
 	if err != nil {
 		log.Printf("Error getting aircraft: %v", err)
 		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
 		return
 	}
 	
-	// Transform aircraft to include observer-relative data
-	type AircraftResponse struct {
-		ICAO          string    `json:"icao"`
-		Callsign      string    `json:"callsign"`
-		Latitude      float64   `json:"lat"`
-		Longitude     float64   `json:"lon"`
-		Altitude      float64   `json:"altitude"`
-		GroundSpeed   float64   `json:"speed"`
-		Track         float64   `json:"heading"`
-		VerticalRate  float64   `json:"verticalRate"`
-		LastSeen      time.Time `json:"lastSeen"`
-		Distance      float64   `json:"distance"`      // Distance from observer in km
-		Azimuth       float64   `json:"azimuth"`       // Azimuth from observer in degrees
-		Elevation     float64   `json:"elevation"`     // Elevation angle from observer in degrees
+	// Optional emergency filter
+	if r.URL.Query().Get("emergency") == "true" {
+		filtered := make([]db.ObservedAircraft, 0)
+		for _, ac := range aircraft {
+			if ac.IsEmergency() {
+				filtered = append(filtered, ac)
+			}
+		}
+		aircraft = filtered
 	}
 	
-	response := make([]AircraftResponse, len(aircraft))
-	for i, ac := range aircraft {
-		// Calculate observer-relative coordinates
-		acLocation := coordinates.Geographic{
-			Latitude:  ac.Latitude,
-			Longitude: ac.Longitude,
-			Altitude:  ac.Altitude * coordinates.FeetToMeters, // Convert feet to meters
+	// Optional fleet filters
+	fleet := fleetFilterFromQuery(r)
+	if fleet.Active() {
+		filtered := make([]db.ObservedAircraft, 0)
+		for _, ac := range aircraft {
+			if fleet.Matches(ac.Aircraft) {
+				filtered = append(filtered, ac)
+			}
 		}
+		aircraft = filtered
+	}
+	
+	response := buildAircraftResponses(aircraft, s.destinations(r.Context()), s.centers, s.terrain, observer, minAlt, maxAlt)
+	
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"aircraft": response,
+		"count":    len(response),
+		"observer": map[string]interface{}{
+			"latitude":        obsPoint.Latitude,
+			"longitude":       obsPoint.Longitude,
+			"elevationMeters": obsPoint.ElevationMeters,
+			"center":          s.centers.Locate(obsPoint.Latitude, obsPoint.Longitude, 0),
+		},
+	})
+}
+
+// fleetFilterFromQuery reads the operator, family and category query
+// parameters, each a comma-separated list.
+func fleetFilterFromQuery(r *http.Request) adsb.FleetFilter {
+	list := func(name string) []string {
+		var values []string
+		for _, v := range strings.Split(r.URL.Query().Get(name), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	return adsb.FleetFilter{
+		Operators:    list("operator"),
+		TypeFamilies: list("family"),
+		Categories:   list("category"),
+	}
+}
+
+// aircraftResponse is an aircraft with observer-relative data
+type aircraftResponse struct {
+	ICAO          string    `json:"icao"`
+	Callsign      string    `json:"callsign"`
+	Latitude      float64   `json:"lat"`
+	Longitude     float64   `json:"lon"`
+	Altitude      float64   `json:"altitude"`
+	GroundSpeed   float64   `json:"speed"`
+	Track         float64   `json:"heading"`
+	VerticalRate  float64   `json:"verticalRate"`
+	Squawk        string    `json:"squawk"`
+	Emergency     string    `json:"emergency,omitempty"` // Emergency description, empty if none
+	Source        string    `json:"source"`              // Data feed (e.g., "airplanes.live", "uat978")
+	LastSeen      time.Time `json:"lastSeen"`
+	Distance      float64   `json:"distance"`      // Distance from observer in km
+	Azimuth       float64   `json:"azimuth"`       // Azimuth from observer in degrees
+	Elevation     float64   `json:"elevation"`     // Elevation angle from observer in degrees
+	Trackable     bool      `json:"trackable"`     // Within the telescope's altitude limits from the observer
+	TerrainBlocked bool     `json:"terrainBlocked,omitempty"` // Hidden from the observer by terrain, so not trackable
+
+	// Fleet details, empty if unknown
+	AircraftType string `json:"aircraftType,omitempty"` // ICAO type designator (e.g., "B744")
+	Registration string `json:"registration,omitempty"` // e.g., "G-EUPT"
+	TypeFamily   string `json:"typeFamily,omitempty"`   // e.g., "747"
+	Category     string `json:"category,omitempty"`     // e.g., "widebody"
+	Operator     string `json:"operator,omitempty"`     // ICAO airline designator (e.g., "FDX")
+	OperatorName string `json:"operatorName,omitempty"` // e.g., "FedEx"
+
+	// Country of registration from the ICAO address block, empty if unknown
+	Country     string `json:"country,omitempty"`     // e.g., "United States"
+	CountryCode string `json:"countryCode,omitempty"` // ISO 3166-1 alpha-2, e.g., "US"
+	Flag        string `json:"flag,omitempty"`        // Flag emoji
+
+	// Phase is the flight phase (climb, cruise, descent or approach), empty if unknown
+	Phase tracking.FlightPhase `json:"phase,omitempty"`
+
+	// Center is the ARTCC or FIR the aircraft is in, empty if unknown
+	Center string `json:"center,omitempty"`
+
+	// Predicted from the current track, nil if not within limitsHorizon
+	SecondsUntilEnteringLimits *float64 `json:"secondsUntilEnteringLimits"` // 0 if already within limits
+	SecondsUntilLeavingLimits  *float64 `json:"secondsUntilLeavingLimits"`
+}
+
+// terrainBlocked reports whether terrain hides an aircraft from the observer.
+// The aircraft's geometry must be relative to the observer.
+func terrainBlocked(terrain *terrain.Checker, observer coordinates.Geographic, ac db.ObservedAircraft) bool {
+	pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude, Altitude: ac.Altitude.Meters()}
+	return terrain.Blocked(observer, pos, ac.Horizontal.Altitude)
+}
+
+// limitsHorizon is how far ahead aircraft are extrapolated to predict when
+// they enter and leave the telescope's altitude limits
+const limitsHorizon = 15 * time.Minute
+
+// buildAircraftResponses adds observer-relative distance, azimuth,
+// elevation and trackability (for the given altitude limits) to each
+// aircraft, using the values precomputed by the collector when they were
+// computed from the observer, when each is predicted to enter and leave
+// the limits, its flight phase (using its destination, if known), the
+// center whose airspace it is in and whether terrain hides it
+func buildAircraftResponses(aircraft []db.ObservedAircraft, destinations map[string]*db.Destination, centers navdata.Centers, terrain *terrain.Checker, observer coordinates.Observer, minAlt, maxAlt float64) []aircraftResponse {
+	response := make([]aircraftResponse, len(aircraft))
+	now := time.Now()
+	for i, ac := range aircraft {
+		ac = ac.From(observer.Location)
 		
-		// Calculate azimuth (bearing from observer to aircraft)
-		azimuth := coordinates.Bearing(observer.Location, acLocation)
-		
-		// Calculate distance in nautical miles and convert to km
-		distanceNM := coordinates.DistanceNauticalMiles(observer.Location, acLocation)
-		distanceKm := distanceNM * 1.852
-		
-		// Calculate elevation angle
-		// elevation = arctan((aircraft_altitude - observer_altitude) / ground_distance)
-		altitudeDiff := acLocation.Altitude - observer.Location.Altitude
-		groundDistanceMeters := distanceKm * 1000.0
-		elevationRad := math.Atan2(altitudeDiff, groundDistanceMeters)
-		elevationDeg := elevationRad * coordinates.RadiansToDegrees
-		
-		response[i] = AircraftResponse{
+		response[i] = aircraftResponse{
 			ICAO:         ac.ICAO,
 			Callsign:     ac.Callsign,
 			Latitude:     ac.Latitude,
 			Longitude:    ac.Longitude,
-			Altitude:     ac.Altitude,
+			Altitude:     float64(ac.Altitude),
 			GroundSpeed:  ac.GroundSpeed,
 			Track:        ac.Track,
 			VerticalRate: ac.VerticalRate,
+			Squawk:       ac.Squawk,
+			Emergency:    adsb.EmergencyDescription(ac.Squawk),
+			Source:       ac.Source,
 			LastSeen:     ac.LastSeen,
-			Distance:     distanceKm,
-			Azimuth:      azimuth,
-			Elevation:    elevationDeg,
+			Distance:     ac.RangeNM * 1.852, // Convert NM to km
+			Azimuth:      ac.Horizontal.Azimuth,
+			Elevation:    ac.Horizontal.Altitude,
+			Trackable:    ac.IsTrackable(minAlt, maxAlt) && !terrainBlocked(terrain, observer.Location, ac),
+			Center:       centers.Locate(ac.Latitude, ac.Longitude, float64(ac.Altitude)),
+			Phase:        tracking.DetectPhase(ac.Aircraft, destinations[ac.ICAO].FlightContext()),
+			AircraftType: ac.AircraftType,
+			Registration: ac.Registration,
+			TypeFamily:   adsb.TypeFamily(ac.AircraftType),
+			Category:     adsb.TypeCategory(ac.AircraftType),
+			Operator:     adsb.Operator(ac.Callsign),
 		}
+		response[i].TerrainBlocked = ac.IsTrackable(minAlt, maxAlt) && !response[i].Trackable
+		response[i].OperatorName, _ = adsb.AirlineName(response[i].Operator)
+		if country, ok := adsb.CountryOf(ac.ICAO); ok {
+			response[i].Country, response[i].CountryCode, response[i].Flag = country.Name, country.Code, country.Flag()
+		}
+		response[i].SecondsUntilEnteringLimits, response[i].SecondsUntilLeavingLimits =
+			secondsUntilLimits(ac.Aircraft, observer, minAlt, maxAlt, now)
 	}
 	
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"aircraft": response,
-		"count":    len(response),
-		"observer": map[string]interface{}{
-			"latitude":        obsPoint.Latitude,
-			"longitude":       obsPoint.Longitude,
-			"elevationMeters": obsPoint.ElevationMeters,
-		},
-	})
+	return response
+}
+
+// destinations returns the flight plan destinations of visible aircraft.
+// Without them flight phases are detected from altitude alone, so errors are
+// only logged.
+func (s *Server) destinations(ctx context.Context) map[string]*db.Destination {
+	destinations, err := s.flightPlanRepo.GetDestinations(ctx)
+	if err != nil {
+		log.Printf("Error getting flight plan destinations: %v", err)
+	}
+	return destinations
+}
+
+// secondsUntilLimits predicts when an aircraft enters and leaves the
+// altitude limits, in seconds from now. Either is nil if it isn't predicted
+// to happen within limitsHorizon.
+func secondsUntilLimits(aircraft adsb.Aircraft, observer coordinates.Observer, minAlt, maxAlt float64, now time.Time) (*float64, *float64) {
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+	window, ok := tracking.PredictLimitWindow(aircraft, observer, now, limitsHorizon, limits)
+	if !ok {
+		return nil, nil
+	}
+
+	enter := window.Enter.Seconds()
+	if !window.Leaves {
+		return &enter, nil
+	}
+	leave := window.Leave.Seconds()
+	return &enter, &leave
 }
 
 func (s *Server) handleGetAircraftByICAO(w http.ResponseWriter, r *http.Request) {
@@ -470,61 +909,119 @@ func (s *Server) handleGetAircraftByICAO(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
+	// Geometry relative to the user's active observation point
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+	observed := db.ObservedAircraft{Aircraft: *aircraft}.From(observationPointLocation(obsPoint))
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	enter, leave := secondsUntilLimits(*aircraft, coordinates.Observer{Location: observationPointLocation(obsPoint)}, minAlt, maxAlt, time.Now())
+	country, _ := adsb.CountryOf(aircraft.ICAO)
+	blocked := terrainBlocked(s.terrain, observationPointLocation(obsPoint), observed)
+	
+	// How far off a position predicted from the last report is likely to be
+	confidence := tracking.PredictPosition(*aircraft, time.Now()).Confidence
+	calibration := s.errorCalibration(r.Context())
+	expectedError := calibration.ExpectedError(confidence)
+	
+	// Progress along the flight plan route, if one has been resolved
+	route, err := s.routeProgress(r.Context(), *aircraft)
+	if err != nil {
+		log.Printf("Error getting route progress for %s: %v", aircraft.ICAO, err)
+	}
+	
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"icao":         aircraft.ICAO,
-		"callsign":     aircraft.Callsign,
-		"lat":          aircraft.Latitude,
-		"lon":          aircraft.Longitude,
-		"altitude":     aircraft.Altitude,
-		"speed":        aircraft.GroundSpeed,
-		"heading":      aircraft.Track,
-		"verticalRate": aircraft.VerticalRate,
-		"lastSeen":     aircraft.LastSeen,
+		"icao":                       aircraft.ICAO,
+		"callsign":                   aircraft.Callsign,
+		"registration":               aircraft.Registration,
+		"lat":                        aircraft.Latitude,
+		"lon":                        aircraft.Longitude,
+		"altitude":                   aircraft.Altitude,
+		"speed":                      aircraft.GroundSpeed,
+		"heading":                    aircraft.Track,
+		"verticalRate":               aircraft.VerticalRate,
+		"squawk":                     aircraft.Squawk,
+		"emergency":                  adsb.EmergencyDescription(aircraft.Squawk),
+		"source":                     aircraft.Source,
+		"lastSeen":                   aircraft.LastSeen,
+		"distance":                   observed.RangeNM * 1.852,
+		"azimuth":                    observed.Horizontal.Azimuth,
+		"elevation":                  observed.Horizontal.Altitude,
+		"trackable":                  observed.IsTrackable(minAlt, maxAlt) && !blocked,
+		"terrainBlocked":             blocked,
+		"center":                     s.centers.Locate(aircraft.Latitude, aircraft.Longitude, float64(aircraft.Altitude)),
+		"phase":                      tracking.DetectPhase(*aircraft, s.destinations(r.Context())[aircraft.ICAO].FlightContext()),
+		"secondsUntilEnteringLimits": enter,
+		"secondsUntilLeavingLimits":  leave,
+		"country":                    country.Name,
+		"countryCode":                country.Code,
+		"flag":                       country.Flag(),
+		"confidence":                 confidence,
+		"expectedErrorDeg":           expectedError,
+		"expectedError":              tracking.FormatExpectedError(expectedError),
+		"errorCalibrated":            calibration.Calibrated(),
+		"route":                      route,
 	})
 }
 
 func (s *Server) handleGetTelescopeConfig(w http.ResponseWriter, r *http.Request) {
+	// The limits the safety envelope enforces
+	envelope := s.mount.Envelope()
+	
 	// Get capabilities from telescope
 	capabilities, err := s.telescope.GetCapabilities()
 	if err != nil {
 		log.Printf("Error getting telescope capabilities: %v", err)
 		// Return config-only if Alpaca query fails
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"minAltitude": s.cfg.Telescope.MinAltitude,
-			"maxAltitude": s.cfg.Telescope.MaxAltitude,
-			"mountType":   s.cfg.Telescope.MountType,
-			"model":       s.cfg.Telescope.Model,
-			"imagingMode": s.cfg.Telescope.ImagingMode,
+			"minAltitude":     envelope.MinAltitude,
+			"maxAltitude":     envelope.MaxAltitude,
+			"softLimitMargin": envelope.SoftMargin,
+			"mountType":       s.cfg.Telescope.MountType,
+			"model":           s.cfg.Telescope.Model,
+			"imagingMode":     s.cfg.Telescope.ImagingMode,
 		})
 		return
 	}
 	
 	// Combine config and capabilities
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"minAltitude":      s.cfg.Telescope.MinAltitude,
-		"maxAltitude":      s.cfg.Telescope.MaxAltitude,
-		"mountType":        s.cfg.Telescope.MountType,
-		"model":            s.cfg.Telescope.Model,
-		"imagingMode":      s.cfg.Telescope.ImagingMode,
-		"description":      capabilities.Description,
-		"driverInfo":       capabilities.DriverInfo,
-		"interfaceVersion": capabilities.InterfaceVersion,
-		"canSetTracking":   capabilities.CanSetTracking,
-		"canSlew":          capabilities.CanSlew,
-		"canSlewAltAz":     capabilities.CanSlewAltAz,
-		"supportedActions": capabilities.SupportedActions,
+		"minAltitude":       envelope.MinAltitude,
+		"maxAltitude":       envelope.MaxAltitude,
+		"softLimitMargin":   envelope.SoftMargin,
+		"mountType":         s.cfg.Telescope.MountType,
+		"model":             s.cfg.Telescope.Model,
+		"imagingMode":       s.cfg.Telescope.ImagingMode,
+		"description":       capabilities.Description,
+		"driverInfo":        capabilities.DriverInfo,
+		"interfaceVersion":  capabilities.InterfaceVersion,
+		"canSetTracking":    capabilities.CanSetTracking,
+		"canSlew":           capabilities.CanSlew,
+		"canSlewAltAz":      capabilities.CanSlewAltAz,
+		"canSlewAltAzAsync": capabilities.CanSlewAltAzAsync,
+		"canMoveAxis":       capabilities.CanMoveAxis,
+		"canPark":           capabilities.CanPark,
+		"canUnpark":         capabilities.CanUnpark,
+		"supportedActions":  capabilities.SupportedActions,
 	})
 }
 
 func (s *Server) handleGetTelescopeStatus(w http.ResponseWriter, r *http.Request) {
-	status, err := s.telescope.GetStatus()
+	status, err := s.telescopeStatus()
 	if err != nil {
 		log.Printf("Error getting telescope status: %v", err)
 		http.Error(w, "Failed to get telescope status", http.StatusInternalServerError)
 		return
 	}
 	
-	respondJSON(w, http.StatusOK, status)
+	// Include who holds control (null if nobody)
+	respondJSON(w, http.StatusOK, struct {
+		*alpaca.TelescopeStatus
+		Control *control.Lease `json:"control"`
+	}{status, s.arbiter.Current()})
 }
 
 func (s *Server) handleTelescopeSlew(w http.ResponseWriter, r *http.Request) {
@@ -538,15 +1035,12 @@ func (s *Server) handleTelescopeSlew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	// Validate altitude limits
-	if req.Altitude < s.cfg.Telescope.MinAltitude || req.Altitude > s.cfg.Telescope.MaxAltitude {
-		http.Error(w, fmt.Sprintf("Altitude out of range (%.1f-%.1f°)", s.cfg.Telescope.MinAltitude, s.cfg.Telescope.MaxAltitude), http.StatusBadRequest)
-		return
-	}
-	
-	if err := s.telescope.SlewToAltAz(req.Altitude, req.Azimuth); err != nil {
-		log.Printf("Error slewing telescope: %v", err)
-		http.Error(w, "Failed to slew telescope", http.StatusInternalServerError)
+	// Targets outside the mount limits are refused by the safety envelope
+	err := s.arbiter.Do(commandOwner(r), func() error {
+		return s.mount.SlewToAltAzContext(r.Context(), req.Altitude, req.Azimuth)
+	})
+	if err != nil {
+		respondCommandError(w, err, "Failed to slew telescope")
 		return
 	}
 	
@@ -593,10 +1087,30 @@ func (s *Server) handleTelescopeTrack(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 	
+	elevation, azimuth, lease, err := s.slewToAircraft(commandOwner(r), aircraft, observer)
+	if err != nil {
+		respondCommandError(w, err, "Failed to slew telescope")
+		return
+	}
+	
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":   true,
+		"icao":      icao,
+		"altitude":  elevation,
+		"azimuth":   azimuth,
+		"callsign":  aircraft.Callsign,
+		"control":   lease,
+	})
+}
+
+// slewToAircraft points the telescope at an aircraft's current position and
+// takes exclusive control while tracking it. Returns an *alpaca.LimitError if
+// the aircraft is outside the mount limits.
+func (s *Server) slewToAircraft(owner control.Owner, aircraft *adsb.Aircraft, observer coordinates.Observer) (float64, float64, control.Lease, error) {
 	acLocation := coordinates.Geographic{
 		Latitude:  aircraft.Latitude,
 		Longitude: aircraft.Longitude,
-		Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+		Altitude:  aircraft.Altitude.Meters(),
 	}
 	
 	// Calculate azimuth and elevation
@@ -604,51 +1118,91 @@ func (s *Server) handleTelescopeTrack(w http.ResponseWriter, r *http.Request) {
 	altitudeDiff := acLocation.Altitude - observer.Location.Altitude
 	distanceNM := coordinates.DistanceNauticalMiles(observer.Location, acLocation)
 	groundDistanceMeters := distanceNM * 1.852 * 1000.0
-	elevationRad := math.Atan2(altitudeDiff, groundDistanceMeters)
+	elevationRad := math.Atan2(float64(altitudeDiff), groundDistanceMeters)
 	elevation := elevationRad * coordinates.RadiansToDegrees
 	
-	// Check if target is within limits
-	if elevation < s.cfg.Telescope.MinAltitude || elevation > s.cfg.Telescope.MaxAltitude {
-		http.Error(w, fmt.Sprintf("Target elevation %.1f° is out of telescope limits (%.1f-%.1f°)", elevation, s.cfg.Telescope.MinAltitude, s.cfg.Telescope.MaxAltitude), http.StatusBadRequest)
+	// Slew to target and take exclusive control while tracking
+	lease, err := s.arbiter.Acquire(owner, aircraft.ICAO, func() error {
+		if err := s.mount.SlewToAltAz(elevation, azimuth); err != nil {
+			return err
+		}
+		
+		// Enable tracking
+		if err := s.telescope.SetTracking(true); err != nil {
+			log.Printf("Error enabling tracking: %v", err)
+			// Don't fail the request, just log the error
+		}
+		return nil
+	})
+	return elevation, azimuth, lease, err
+}
+
+func (s *Server) handleTelescopeStop(w http.ResponseWriter, r *http.Request) {
+	err := s.arbiter.Release(commandOwner(r), func() error {
+		return s.telescope.SetTracking(false)
+	})
+	if err != nil {
+		respondCommandError(w, err, "Failed to stop tracking")
 		return
 	}
 	
-	// Slew to target
-	if err := s.telescope.SlewToAltAz(elevation, azimuth); err != nil {
-		log.Printf("Error slewing to aircraft: %v", err)
-		http.Error(w, "Failed to slew telescope", http.StatusInternalServerError)
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
+// handleTelescopeNudge trims pointing with a guide-rate pulse. Nudges do not
+// take control, so the lease holder can trim while tracking.
+func (s *Server) handleTelescopeNudge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Direction  string `json:"direction"`
+		DurationMs int    `json:"durationMs"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 	
-	// Enable tracking
-	if err := s.telescope.SetTracking(true); err != nil {
-		log.Printf("Error enabling tracking: %v", err)
-		// Don't fail the request, just log the error
+	direction, err := alpaca.ParseGuideDirection(req.Direction)
+	if err != nil {
+		http.Error(w, "Direction must be north, south, east or west", http.StatusBadRequest)
+		return
 	}
 	
-	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"success":   true,
-		"icao":      icao,
-		"altitude":  elevation,
-		"azimuth":   azimuth,
-		"callsign":  aircraft.Callsign,
+	duration := s.cfg.Telescope.GetNudgeDuration()
+	if req.DurationMs != 0 {
+		duration = time.Duration(req.DurationMs) * time.Millisecond
+	}
+	if duration <= 0 || duration > alpaca.MaxPulseDuration {
+		http.Error(w, fmt.Sprintf("Duration out of range (1-%d ms)", alpaca.MaxPulseDuration.Milliseconds()), http.StatusBadRequest)
+		return
+	}
+	
+	// Pulses go through the safety envelope, and are shortened to stop at a soft limit
+	err = s.arbiter.Do(commandOwner(r), func() error {
+		duration, err = s.mount.PulseGuide(direction, duration)
+		return err
 	})
-}
-
-func (s *Server) handleTelescopeStop(w http.ResponseWriter, r *http.Request) {
-	if err := s.telescope.SetTracking(false); err != nil {
-		log.Printf("Error stopping tracking: %v", err)
-		http.Error(w, "Failed to stop tracking", http.StatusInternalServerError)
+	if err != nil {
+		respondCommandError(w, err, "Failed to nudge telescope")
 		return
 	}
 	
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
+		"success":    true,
+		"direction":  direction.String(),
+		"durationMs": duration.Milliseconds(),
 	})
 }
 
 func (s *Server) handleTelescopeAbort(w http.ResponseWriter, r *http.Request) {
-	if err := s.telescope.AbortSlew(); err != nil {
+	// Abort is a safety command: any user may issue it, and it releases control
+	owner := commandOwner(r)
+	if lease := s.arbiter.Current(); lease != nil && lease.UserID != owner.UserID {
+		log.Printf("⚠️  %s aborted telescope controlled by %s", owner.Username, lease.Username)
+	}
+	if err := s.arbiter.Abort(s.telescope.AbortSlew); err != nil {
 		log.Printf("Error aborting slew: %v", err)
 		http.Error(w, "Failed to abort slew", http.StatusInternalServerError)
 		return
@@ -665,6 +1219,40 @@ func (s *Server) handleTelescopeAbort(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// commandOwner identifies the authenticated user issuing a telescope command
+func commandOwner(r *http.Request) control.Owner {
+	userID, _ := r.Context().Value("user_id").(int)
+	username, _ := r.Context().Value("username").(string)
+	role, _ := r.Context().Value("role").(string)
+	
+	return control.Owner{
+		UserID:   userID,
+		Username: username,
+		Admin:    role == auth.RoleAdmin,
+	}
+}
+
+// respondCommandError reports a failed telescope command. Commands rejected
+// because another user holds control return 409 Conflict.
+func respondCommandError(w http.ResponseWriter, err error, msg string) {
+	var held *control.HeldError
+	if errors.As(err, &held) {
+		http.Error(w, held.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, alpaca.ErrNotSupported) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if errors.Is(err, alpaca.ErrOutsideLimits) || errors.Is(err, safety.ErrSolarProximity) || errors.Is(err, coordinates.ErrNoSunSafePath) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	
+	log.Printf("%s: %v", msg, err)
+	http.Error(w, msg, http.StatusInternalServerError)
+}
+
 func (s *Server) handleGetSystemStatus(w http.ResponseWriter, r *http.Request) {
 	// Check telescope connection
 	telescopeConnected := false
@@ -675,10 +1263,139 @@ func (s *Server) handleGetSystemStatus(w http.ResponseWriter, r *http.Request) {
 		telescopeTracking = status.Tracking
 	}
 	
+	// Most recent disconnect or reconnect, so the UI can report it
+	_, telescopeConnection := s.connection.Status()
+	
+	now := time.Now().UTC()
+	
+	// Check database connectivity and aircraft data freshness
+	var dbStatus map[string]interface{}
+	var latestSeen time.Time
+	health, err := s.database.CheckHealth(r.Context())
+	if err != nil {
+		log.Printf("Error checking database health: %v", err)
+		dbStatus = map[string]interface{}{
+			"connected": false,
+			"error":     err.Error(),
+		}
+	} else {
+		latestSeen = health.LatestAircraftSeen
+		dbStatus = map[string]interface{}{
+			"connected": true,
+			"pingMs":    health.PingLatency.Milliseconds(),
+			"sizeBytes": health.SizeBytes,
+		}
+	}
+	
+	// Prefer the collector heartbeat; fall back to aircraft updates for
+	// collectors that do not report status
+	var heartbeatAge interface{}
+	var adsbSource interface{}
+	adsbOK := false
+	adsbFailedOver := false
+	if status, err := s.collectorRepo.GetStatus(r.Context()); err != nil {
+		log.Printf("Error getting collector status: %v", err)
+	} else if status != nil {
+		age := now.Sub(status.UpdatedAt)
+		heartbeatAge = math.Round(age.Seconds())
+		adsbOK = age <= collectorStaleAfter && status.BreakerState != adsb.BreakerOpen.String()
+		if status.ActiveSource != "" {
+			adsbSource = status.ActiveSource
+			adsbFailedOver = status.FailedOver()
+		}
+	} else if !latestSeen.IsZero() {
+		adsbOK = now.Sub(latestSeen) <= collectorStaleAfter
+	}
+	
+	var lag interface{}
+	if !latestSeen.IsZero() {
+		lag = math.Round(now.Sub(latestSeen).Seconds())
+	}
+	
+	// Check disk usage of the filesystem the server runs from
+	var diskStatus map[string]interface{}
+	if total, free, err := diskUsage("."); err == nil && total > 0 {
+		diskStatus = map[string]interface{}{
+			"totalBytes":  total,
+			"freeBytes":   free,
+			"usedPercent": math.Round(float64(total-free)/float64(total)*1000) / 10,
+		}
+	}
+	
+	// FlightAware usage against the monthly budget
+	var flightAwareStatus interface{}
+	if s.cfg.FlightAware.Enabled {
+		usage := db.NewAPIUsageRepository(&db.DB{DB: s.db}, flightaware.Source)
+		quota, err := flightaware.QuotaStatusOf(r.Context(), flightaware.BudgetFromConfig(s.cfg.FlightAware), usage, now)
+		if err != nil {
+			log.Printf("Error getting FlightAware usage: %v", err)
+		} else {
+			flightAwareStatus = quota
+		}
+	}
+	
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"telescope": telescopeConnected,
-		"adsb":      true, // Assume ADS-B is working if we have aircraft data
-		"tracking":  telescopeTracking,
+		"telescope":                 telescopeConnected,
+		"adsb":                      adsbOK,
+		"adsbLagSeconds":            lag,
+		"collectorHeartbeatSeconds": heartbeatAge,
+		"adsbSource":                adsbSource,
+		"adsbFailedOver":            adsbFailedOver,
+		"tracking":                  telescopeTracking,
+		"telescopeConnection":       telescopeConnection,
+		"control":                   s.arbiter.Current(),
+		"database":                  dbStatus,
+		"disk":                      diskStatus,
+		"flightaware":               flightAwareStatus,
+	})
+}
+
+// collectorStaleAfter is how long without a status report before the collector is considered down
+const collectorStaleAfter = 2 * time.Minute
+
+// handleGetCollectorStatus returns the collector's health and its recent
+// per-cycle statistics. The history window is set with ?minutes= (default 60,
+// max 7 days).
+func (s *Server) handleGetCollectorStatus(w http.ResponseWriter, r *http.Request) {
+	minutes := 60
+	if v := r.URL.Query().Get("minutes"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 7*24*60 {
+			http.Error(w, "Invalid minutes parameter", http.StatusBadRequest)
+			return
+		}
+		minutes = n
+	}
+	
+	status, err := s.collectorRepo.GetStatus(r.Context())
+	if err != nil {
+		log.Printf("Error getting collector status: %v", err)
+		http.Error(w, "Failed to get collector status", http.StatusInternalServerError)
+		return
+	}
+	
+	since := time.Now().UTC().Add(-time.Duration(minutes) * time.Minute)
+	stats, err := s.collectorRepo.GetCycleStats(r.Context(), since)
+	if err != nil {
+		log.Printf("Error getting collector stats: %v", err)
+		http.Error(w, "Failed to get collector stats", http.StatusInternalServerError)
+		return
+	}
+	if stats == nil {
+		stats = []db.CollectorStat{}
+	}
+	
+	history := db.TotalsByCycle(stats)
+	if history == nil {
+		history = []db.CollectorStat{}
+	}
+	
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  status,
+		"stale":   status == nil || time.Since(status.UpdatedAt) > collectorStaleAfter,
+		"history": history,
+		"regions": stats,
+		"minutes": minutes,
 	})
 }
 
@@ -727,20 +1444,30 @@ func (s *Server) handleCreateObservationPoint(w http.ResponseWriter, r *http.Req
 		Longitude       float64 `json:"longitude"`
 		ElevationMeters float64 `json:"elevationMeters"`
 		IsActive        bool    `json:"isActive"`
+		TelescopeURL    string  `json:"telescopeUrl"`
+		Bortle          int     `json:"bortle"`
+		FollowGPS       bool    `json:"followGps"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if req.Bortle < 0 || req.Bortle > 9 {
+		http.Error(w, "bortle must be between 1 and 9 (0 = server default)", http.StatusBadRequest)
+		return
+	}
 	
 	point := &db.ObservationPoint{
 		UserID:          userID,
 		Name:            req.Name,
 		Latitude:        req.Latitude,
 		Longitude:       req.Longitude,
-		ElevationMeters: req.ElevationMeters,
+		ElevationMeters: units.Meters(req.ElevationMeters),
 		IsActive:        req.IsActive,
+		TelescopeURL:    req.TelescopeURL,
+		Bortle:          req.Bortle,
+		FollowGPS:       req.FollowGPS,
 	}
 	
 	if err := s.observerRepo.Create(r.Context(), point); err != nil {
@@ -768,12 +1495,19 @@ func (s *Server) handleUpdateObservationPoint(w http.ResponseWriter, r *http.Req
 		Longitude       float64 `json:"longitude"`
 		ElevationMeters float64 `json:"elevationMeters"`
 		IsActive        bool    `json:"isActive"`
+		TelescopeURL    string  `json:"telescopeUrl"`
+		Bortle          int     `json:"bortle"`
+		FollowGPS       bool    `json:"followGps"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if req.Bortle < 0 || req.Bortle > 9 {
+		http.Error(w, "bortle must be between 1 and 9 (0 = server default)", http.StatusBadRequest)
+		return
+	}
 	
 	point := &db.ObservationPoint{
 		ID:              pointID,
@@ -781,8 +1515,11 @@ func (s *Server) handleUpdateObservationPoint(w http.ResponseWriter, r *http.Req
 		Name:            req.Name,
 		Latitude:        req.Latitude,
 		Longitude:       req.Longitude,
-		ElevationMeters: req.ElevationMeters,
+		ElevationMeters: units.Meters(req.ElevationMeters),
 		IsActive:        req.IsActive,
+		TelescopeURL:    req.TelescopeURL,
+		Bortle:          req.Bortle,
+		FollowGPS:       req.FollowGPS,
 	}
 	
 	if err := s.observerRepo.Update(r.Context(), point); err != nil {
@@ -862,19 +1599,6 @@ func connectDatabase(cfg *config.Config) (*sql.DB, error) {
 	return db, nil
 }
 
-func runMigrations(db *sql.DB) error {
-	// For now, just create a default admin user if it doesn't exist
-	_, err := db.Exec(`
-		INSERT INTO users (username, email, password_hash, role, is_active, email_verified)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (username) DO NOTHING
-	`, "admin", "admin@ads-bscope.local",
-		"$2a$10$YourHashedPasswordHere", // This will need to be properly hashed
-		"admin", true, true)
-	
-	return err
-}
-
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// handleGetNextTarget returns, for an aircraft being tracked from the
+// user's active observation point, the seconds until it leaves the altitude
+// limits and the recommended next target: the visible aircraft within the
+// limits soonest after it leaves. "inLimits" is false if the aircraft isn't
+// predicted within the limits in the next 30 minutes; "secondsUntilLeaving"
+// is null if it is still within them then, and "next" null if no other
+// aircraft is predicted.
+func (s *Server) handleGetNextTarget(w http.ResponseWriter, r *http.Request) {
+	icao := chi.URLParam(r, "icao")
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+
+	visible, err := s.aircraftRepo.GetVisibleAircraftFrom(r.Context(), observer.Location)
+	if err != nil {
+		log.Printf("Error getting aircraft: %v", err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	others := make([]adsb.Aircraft, len(visible))
+	for i, ac := range visible {
+		others[i] = ac.Aircraft
+	}
+
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+	now := time.Now()
+
+	handoff, ok := planner.PlanHandoff(*aircraft, others, observer, now, planner.DefaultHorizon, limits)
+	response := map[string]interface{}{
+		"icao":                aircraft.ICAO,
+		"callsign":            aircraft.Callsign,
+		"inLimits":            ok,
+		"secondsUntilLeaving": nil,
+		"next":                nil,
+	}
+	if handoff.LeavesLimits {
+		response["secondsUntilLeaving"] = handoff.Leaves.Seconds()
+	}
+	if next := handoff.Next; next != nil {
+		horiz := coordinates.GeographicToHorizontal(tracking.PredictPosition(next.Aircraft, now).Position, observer, now)
+		response["next"] = map[string]interface{}{
+			"icao":         next.Aircraft.ICAO,
+			"callsign":     next.Aircraft.Callsign,
+			"azimuth":      horiz.Azimuth,
+			"elevation":    horiz.Altitude,
+			"waitSeconds":  next.Wait.Seconds(),
+			"passStart":    next.Pass.Start,
+			"passEnd":      next.Pass.End,
+			"maxElevation": next.Pass.MaxElevation,
+			"peakAzimuth":  next.Pass.PeakAzimuth,
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
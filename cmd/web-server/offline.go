@@ -0,0 +1,126 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/offline"
+)
+
+// Offline bundle limits
+const (
+	// offlineBundleRadiusNM is the default area covered by a bundle, wide
+	// enough to include the routes of aircraft passing over the observer
+	offlineBundleRadiusNM = 250.0
+
+	// maxOfflineBundleRadiusNM keeps bundles small enough for phone storage
+	maxOfflineBundleRadiusNM = 1000.0
+)
+
+// buildOfflineBundle builds the reference data bundle around the requesting
+// user's observation point, and remembers it so later requests can be
+// answered with a diff.
+//
+// Query parameters:
+//   - radius: area covered in NM (default 250, max 1000)
+func (s *Server) buildOfflineBundle(r *http.Request) (*offline.Bundle, int, string) {
+	radius := offlineBundleRadiusNM
+	if v := r.URL.Query().Get("radius"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 || parsed > maxOfflineBundleRadiusNM {
+			return nil, http.StatusBadRequest, "radius must be between 0 and 1000"
+		}
+		radius = parsed
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting observation point: %v", err)
+		return nil, http.StatusInternalServerError, "Failed to get observation point"
+	}
+
+	waypoints, err := s.flightPlanRepo.FindWaypointsNear(r.Context(), obsPoint.Latitude, obsPoint.Longitude, radius)
+	if err != nil {
+		log.Printf("Error getting waypoints for offline bundle: %v", err)
+		return nil, http.StatusInternalServerError, "Failed to get waypoints"
+	}
+
+	items := make([]offline.Waypoint, len(waypoints))
+	for i, wp := range waypoints {
+		items[i] = offline.Waypoint{
+			Identifier: wp.Identifier,
+			Name:       wp.Name,
+			Latitude:   wp.Latitude,
+			Longitude:  wp.Longitude,
+			Type:       wp.Type,
+			Region:     wp.Region,
+		}
+	}
+
+	var airlines []offline.Airline
+	for designator, name := range adsb.Airlines() {
+		airlines = append(airlines, offline.Airline{Designator: designator, Name: name})
+	}
+
+	area := offline.Area{Latitude: obsPoint.Latitude, Longitude: obsPoint.Longitude, RadiusNM: radius}
+	bundle := offline.NewBundle(area, items, airlines)
+	s.bundles.Add(bundle)
+
+	return bundle, http.StatusOK, ""
+}
+
+// handleGetOfflineBundle returns the full reference data bundle. The version
+// is sent as the ETag, so a client that already has it gets 304 Not Modified.
+func (s *Server) handleGetOfflineBundle(w http.ResponseWriter, r *http.Request) {
+	bundle, status, msg := s.buildOfflineBundle(r)
+	if bundle == nil {
+		http.Error(w, msg, status)
+		return
+	}
+
+	etag := `"` + bundle.Version + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, bundle)
+}
+
+// handleGetOfflineBundleDiff returns what changed since the bundle version a
+// client holds. If that version is no longer known to the server the full
+// bundle is returned instead, flagged with "full": true.
+//
+// Query parameters:
+//   - since: version held by the client (required)
+//   - radius: as for the full bundle; must match the held bundle
+func (s *Server) handleGetOfflineBundleDiff(w http.ResponseWriter, r *http.Request) {
+	since := r.URL.Query().Get("since")
+	if since == "" {
+		http.Error(w, "since is required", http.StatusBadRequest)
+		return
+	}
+
+	bundle, status, msg := s.buildOfflineBundle(r)
+	if bundle == nil {
+		http.Error(w, msg, status)
+		return
+	}
+
+	previous, ok := s.bundles.Get(since)
+	if !ok {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"full":   true,
+			"bundle": bundle,
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"full": false,
+		"diff": offline.Compare(previous, bundle),
+	})
+}
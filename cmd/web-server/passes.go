@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+const (
+	// defaultPassLimit is how many pass reports are listed by default
+	defaultPassLimit = 50
+
+	// maxPassHours is the longest period pass reports can be listed for
+	maxPassHours = 30 * 24
+)
+
+// handleGetPasses lists the accuracy reports of recently tracked passes,
+// most recent first. Optional query parameters: hours (default 24), icao
+// and limit (default 50).
+func (s *Server) handleGetPasses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	hours := 24
+	if v := query.Get("hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPassHours {
+			http.Error(w, "Invalid hours parameter", http.StatusBadRequest)
+			return
+		}
+		hours = n
+	}
+
+	limit := defaultPassLimit
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	now := time.Now().UTC()
+	icao := strings.TrimSpace(query.Get("icao"))
+	reports, err := s.passRepo.List(r.Context(), now.Add(-time.Duration(hours)*time.Hour), now, icao, limit)
+	if err != nil {
+		log.Printf("Error getting pass reports: %v", err)
+		http.Error(w, "Failed to get pass reports", http.StatusInternalServerError)
+		return
+	}
+	if reports == nil {
+		reports = []db.PassReport{}
+	}
+
+	respondJSON(w, http.StatusOK, reports)
+}
+
+// handleGetPass returns one pass report.
+func (s *Server) handleGetPass(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid pass ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := s.passRepo.Get(r.Context(), id)
+	if err != nil {
+		log.Printf("Error getting pass report: %v", err)
+		http.Error(w, "Failed to get pass report", http.StatusInternalServerError)
+		return
+	}
+	if report == nil {
+		http.Error(w, "Pass report not found", http.StatusNotFound)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// errorCalibration returns the latest calibration of prediction confidence
+// against observed error, or tracking.DefaultCalibration until one has been
+// made with cmd/analyze-session -calibrate.
+func (s *Server) errorCalibration(ctx context.Context) tracking.ErrorCalibration {
+	calibration, err := s.calibrationRepo.Latest(ctx)
+	if err != nil {
+		log.Printf("Error getting error calibration: %v", err)
+	}
+	if calibration == nil {
+		return tracking.DefaultCalibration
+	}
+	return calibration.ErrorCalibration
+}
+
+// handleGetErrorCalibration returns the latest calibration of prediction
+// confidence against observed pointing error, or null with the default
+// scale if none has been made.
+func (s *Server) handleGetErrorCalibration(w http.ResponseWriter, r *http.Request) {
+	calibration, err := s.calibrationRepo.Latest(r.Context())
+	if err != nil {
+		log.Printf("Error getting error calibration: %v", err)
+		http.Error(w, "Failed to get error calibration", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"calibration": calibration,
+		"default":     tracking.DefaultCalibration,
+	})
+}
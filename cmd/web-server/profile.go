@@ -0,0 +1,85 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// maxProfileInterval keeps profiles detailed enough to chart
+const maxProfileInterval = 120
+
+// handleGetAircraftProfile returns the elevation/azimuth time series of an
+// aircraft's next predicted pass over the user's active observation point,
+// for pass profile charts. "pass" is false if the aircraft isn't predicted
+// to rise above the minimum altitude within the next 30 minutes.
+//
+// Query parameters:
+//   - interval: seconds between samples (default 10, max 120)
+func (s *Server) handleGetAircraftProfile(w http.ResponseWriter, r *http.Request) {
+	icao := chi.URLParam(r, "icao")
+
+	interval := planner.DefaultInterval
+	if v := r.URL.Query().Get("interval"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 1 || seconds > maxProfileInterval {
+			http.Error(w, "interval must be between 1 and 120 seconds", http.StatusBadRequest)
+			return
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft == nil {
+		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		return
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+	minAlt, maxAlt := s.cfg.Telescope.GetAltitudeLimits()
+	limits := tracking.TrackingLimitsFromConfig(minAlt, maxAlt)
+
+	profile, ok := planner.PassProfile(*aircraft, observer, time.Now(), planner.DefaultHorizon, interval, limits)
+	if !ok {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"icao":     aircraft.ICAO,
+			"callsign": aircraft.Callsign,
+			"pass":     false,
+			"samples":  []planner.Sample{},
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"icao":            aircraft.ICAO,
+		"callsign":        aircraft.Callsign,
+		"pass":            true,
+		"start":           profile.Pass.Start,
+		"end":             profile.Pass.End,
+		"peak":            profile.Pass.Peak,
+		"maxElevation":    profile.Pass.MaxElevation,
+		"peakAzimuth":     profile.Pass.PeakAzimuth,
+		"minAltitude":     minAlt,
+		"maxAltitude":     maxAlt,
+		"intervalSeconds": profile.Interval.Seconds(),
+		"samples":         profile.Samples,
+	})
+}
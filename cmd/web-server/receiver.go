@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+const (
+	// receiverPollInterval is how often the receiver's stats are read.
+	// dump1090 rewrites stats.json once a minute.
+	receiverPollInterval = 30 * time.Second
+
+	// receiverStaleAfter is how old stats can be before the receiver is
+	// considered offline (dump1090 stopped but its web server still up)
+	receiverStaleAfter = 5 * time.Minute
+
+	// receiverGuide is the setup guide for self-hosting a receiver
+	receiverGuide = "docs/RECEIVER.md"
+)
+
+// receiverMonitor polls a local dump1090 receiver and keeps its latest
+// statistics for the receiver endpoint.
+type receiverMonitor struct {
+	name   string
+	url    string
+	client *adsb.ReceiverClient
+
+	mu     sync.RWMutex
+	latest *adsb.ReceiverStats
+	err    error
+}
+
+// localReceiverSource returns the first enabled local 1090 MHz receiver
+// source, if any.
+func localReceiverSource(sources []config.ADSBSource) (config.ADSBSource, bool) {
+	for _, src := range sources {
+		if src.Enabled && src.Type == "local" {
+			return src, true
+		}
+	}
+	return config.ADSBSource{}, false
+}
+
+// dump1090URL returns the dump1090 web root for a local source.
+// Uses BaseURL if set, otherwise builds it from LocalHost/LocalPort.
+func dump1090URL(src config.ADSBSource) string {
+	if src.BaseURL != "" {
+		return src.BaseURL
+	}
+	host := src.LocalHost
+	if host == "" {
+		host = "localhost"
+	}
+	port := src.LocalPort
+	if port == 0 {
+		port = 80
+	}
+	return fmt.Sprintf("http://%s:%d/skyaware", host, port)
+}
+
+// newReceiverMonitor creates a monitor for a local receiver source.
+func newReceiverMonitor(src config.ADSBSource) *receiverMonitor {
+	url := dump1090URL(src)
+	return &receiverMonitor{
+		name:   src.Name,
+		url:    url,
+		client: adsb.NewReceiverClient(url),
+	}
+}
+
+// Run polls the receiver until ctx is cancelled.
+func (m *receiverMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(receiverPollInterval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := m.client.Stats()
+		if err != nil {
+			log.Printf("Error reading receiver stats: %v", err)
+		}
+		m.setStats(stats, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setStats records the latest statistics (nil on error).
+func (m *receiverMonitor) setStats(stats *adsb.ReceiverStats, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stats != nil {
+		m.latest = stats
+	}
+	m.err = err
+}
+
+// Latest returns the most recent statistics (nil if none) and the error
+// from the last poll, if it failed.
+func (m *receiverMonitor) Latest() (*adsb.ReceiverStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest, m.err
+}
+
+// handleGetReceiver returns the health of the local SDR receiver: message
+// rate, maximum range, gain and signal levels. Without a local receiver
+// configured it points to the self-hosting guide.
+func (s *Server) handleGetReceiver(w http.ResponseWriter, r *http.Request) {
+	if s.receiver == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"configured": false,
+			"guide":      receiverGuide,
+		})
+		return
+	}
+
+	stats, err := s.receiver.Latest()
+	resp := map[string]interface{}{
+		"configured": true,
+		"name":       s.receiver.name,
+		"url":        s.receiver.url,
+		"online":     err == nil && stats != nil && time.Since(stats.ReadAt) < receiverStaleAfter,
+		"stats":      stats,
+	}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
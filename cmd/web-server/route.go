@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/geojson"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// routeStepNM is the spacing of points added along great-circle legs, so
+// long legs are drawn curved on flat maps.
+const routeStepNM = 25.0
+
+// skyPoint is a route vertex as seen from the observer, for drawing the
+// route on the sky chart.
+type skyPoint struct {
+	Azimuth   float64 `json:"azimuth"`
+	Elevation float64 `json:"elevation"`
+}
+
+// handleGetAircraftRoute returns an aircraft's resolved flight plan route
+// as a GeoJSON FeatureCollection:
+//   - "route": the whole route as a great-circle LineString, flagged
+//     "rerouted" if the aircraft has left it and the plan is being re-fetched
+//   - "remaining": from the aircraft's last position through the waypoints
+//     not yet passed, with the same path as azimuth/elevation from the
+//     observation point in its "skyPath" property
+//   - one Point per waypoint, with its name, sequence, ETA and whether it
+//     has been passed
+func (s *Server) handleGetAircraftRoute(w http.ResponseWriter, r *http.Request) {
+	icao := chi.URLParam(r, "icao")
+
+	aircraft, err := s.aircraftRepo.GetAircraftByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting aircraft %s: %v", icao, err)
+		http.Error(w, "Failed to get aircraft", http.StatusInternalServerError)
+		return
+	}
+	if aircraft != nil {
+		// Flight plans are stored under the aircraft table's ICAO
+		icao = aircraft.ICAO
+	}
+
+	fp, err := s.flightPlanRepo.GetFlightPlanByICAO(r.Context(), icao)
+	if err != nil {
+		log.Printf("Error getting flight plan for %s: %v", icao, err)
+		http.Error(w, "Failed to get flight plan", http.StatusInternalServerError)
+		return
+	}
+	if fp == nil {
+		http.Error(w, "No flight plan for aircraft", http.StatusNotFound)
+		return
+	}
+
+	waypoints, err := s.flightPlanRepo.GetFlightPlanRoute(r.Context(), fp.ID)
+	if err != nil {
+		log.Printf("Error getting route for flight plan %d: %v", fp.ID, err)
+		http.Error(w, "Failed to get flight plan route", http.StatusInternalServerError)
+		return
+	}
+	if len(waypoints) == 0 {
+		http.Error(w, "Flight plan route has not been resolved", http.StatusNotFound)
+		return
+	}
+
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting active observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+
+	// Sky positions assume the aircraft holds its current altitude, or the
+	// filed altitude if it isn't being received
+	altitudeFt := units.Feet(fp.FiledAltitude)
+	if aircraft != nil && aircraft.Altitude > 0 {
+		altitudeFt = aircraft.Altitude
+	}
+
+	respondJSON(w, http.StatusOK, buildRouteGeoJSON(fp, waypoints, aircraft, observationPointLocation(obsPoint), altitudeFt, time.Now()))
+}
+
+// buildRouteGeoJSON builds the route features for handleGetAircraftRoute.
+// aircraft is nil if the aircraft isn't currently being received.
+func buildRouteGeoJSON(
+	fp *db.FlightPlan,
+	waypoints []db.FlightPlanRoute,
+	aircraft *adsb.Aircraft,
+	observer coordinates.Geographic,
+	altitudeFt units.Feet,
+	now time.Time,
+) geojson.FeatureCollection {
+	var full, remaining []coordinates.Geographic
+	if aircraft != nil {
+		remaining = append(remaining, coordinates.Geographic{Latitude: aircraft.Latitude, Longitude: aircraft.Longitude})
+	}
+
+	var features []geojson.Feature
+	for _, wp := range waypoints {
+		pos := coordinates.Geographic{Latitude: wp.Latitude, Longitude: wp.Longitude}
+		full = append(full, pos)
+		if !wp.Passed {
+			remaining = append(remaining, pos)
+		}
+
+		props := map[string]interface{}{
+			"kind":     "waypoint",
+			"name":     wp.WaypointName,
+			"sequence": wp.Sequence,
+			"passed":   wp.Passed,
+		}
+		if wp.ETA != nil {
+			props["eta"] = wp.ETA
+		}
+		features = append(features, geojson.NewFeature("", geojson.Point(pos), props))
+	}
+
+	fullPath := greatCirclePath(full)
+	routeFeature := geojson.NewFeature("route", geojson.LineString(fullPath), map[string]interface{}{
+		"kind":          "route",
+		"callsign":      fp.Callsign,
+		"departure":     fp.DepartureICAO,
+		"arrival":       fp.ArrivalICAO,
+		"route":         fp.Route,
+		"filedAltitude": fp.FiledAltitude,
+		"distanceNm":    pathDistanceNM(full),
+		"rerouted":      fp.Rerouted,
+	})
+	if fp.DeviationNM != nil {
+		routeFeature.Properties["deviationNm"] = *fp.DeviationNM
+	}
+	if fp.OffRouteSince != nil {
+		routeFeature.Properties["offRouteSince"] = fp.OffRouteSince
+	}
+
+	remainingPath := greatCirclePath(remaining)
+	sky := make([]skyPoint, len(remainingPath))
+	for i, p := range remainingPath {
+		p.Altitude = altitudeFt.Meters()
+		horiz := coordinates.GeographicToHorizontal(p, coordinates.Observer{Location: observer}, now)
+		sky[i] = skyPoint{Azimuth: horiz.Azimuth, Elevation: horiz.Altitude}
+	}
+	remainingFeature := geojson.NewFeature("remaining", geojson.LineString(remainingPath), map[string]interface{}{
+		"kind":       "remaining",
+		"distanceNm": pathDistanceNM(remaining),
+		"altitudeFt": altitudeFt,
+		"skyPath":    sky,
+	})
+
+	return geojson.NewFeatureCollection(append([]geojson.Feature{routeFeature, remainingFeature}, features...)...)
+}
+
+// greatCirclePath joins points with great-circle legs.
+func greatCirclePath(points []coordinates.Geographic) []coordinates.Geographic {
+	if len(points) < 2 {
+		return points
+	}
+
+	path := []coordinates.Geographic{points[0]}
+	for i := 1; i < len(points); i++ {
+		leg := coordinates.GreatCirclePoints(points[i-1], points[i], routeStepNM)
+		path = append(path, leg[1:]...)
+	}
+	return path
+}
+
+// pathDistanceNM returns the length of a path through the points.
+func pathDistanceNM(points []coordinates.Geographic) float64 {
+	var total float64
+	for i := 1; i < len(points); i++ {
+		total += coordinates.DistanceNauticalMiles(points[i-1], points[i])
+	}
+	return total
+}
+
+// routeProgress returns an aircraft's progress along its flight plan route
+// to the arrival airport, for the aircraft detail: percent complete and the
+// ETA at each remaining waypoint and at the destination. Returns nil if the
+// aircraft has no resolved route.
+func (s *Server) routeProgress(ctx context.Context, aircraft adsb.Aircraft) (map[string]interface{}, error) {
+	fp, err := s.flightPlanRepo.GetFlightPlanByICAO(ctx, aircraft.ICAO)
+	if err != nil || fp == nil {
+		return nil, err
+	}
+	routes, err := s.flightPlanRepo.GetFlightPlanRoute(ctx, fp.ID)
+	if err != nil || len(routes) == 0 {
+		return nil, err
+	}
+
+	waypoints := make([]tracking.Waypoint, len(routes))
+	for i, r := range routes {
+		waypoints[i] = r.Waypoint()
+	}
+
+	// Routes are often filed from fix to fix rather than between the airports
+	if last := routes[len(routes)-1]; fp.ArrivalICAO != "" && last.WaypointName != fp.ArrivalICAO {
+		airport, err := s.flightPlanRepo.GetWaypointByIdentifier(ctx, fp.ArrivalICAO)
+		if err != nil {
+			return nil, err
+		}
+		if airport != nil {
+			waypoints = append(waypoints, tracking.Waypoint{
+				Name:      airport.Identifier,
+				Latitude:  airport.Latitude,
+				Longitude: airport.Longitude,
+				Sequence:  last.Sequence + 1,
+			})
+		}
+	}
+
+	progress, _ := tracking.EstimateRouteProgress(aircraft, tracking.DeterminePassedWaypoints(aircraft, waypoints))
+	remaining := make([]map[string]interface{}, len(progress.Remaining))
+	for i, wp := range progress.Remaining {
+		remaining[i] = map[string]interface{}{
+			"name":       wp.Name,
+			"sequence":   wp.Sequence,
+			"distanceNm": wp.DistanceNM,
+			"eta":        wp.ETA,
+		}
+	}
+
+	result := map[string]interface{}{
+		"departure":       fp.DepartureICAO,
+		"arrival":         fp.ArrivalICAO,
+		"percentComplete": progress.PercentComplete,
+		"totalNm":         progress.TotalNM,
+		"remainingNm":     progress.RemainingNM,
+		"remaining":       remaining,
+		"nextWaypoint":    nil,
+		"nextWaypointEta": nil,
+		"destinationEta":  nil,
+	}
+	if next := progress.Next(); next != nil {
+		result["nextWaypoint"] = next.Name
+		result["nextWaypointEta"] = next.ETA
+	}
+	if dest := progress.Destination(); dest != nil {
+		result["destinationEta"] = dest.ETA
+	}
+	return result, nil
+}
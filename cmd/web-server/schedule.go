@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// handleGetSchedule returns pending and running observation windows,
+// earliest first. The telescope is shared, so every user sees all tasks.
+func (s *Server) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.scheduleRepo.Upcoming(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error getting schedule: %v", err)
+		http.Error(w, "Failed to get schedule", http.StatusInternalServerError)
+		return
+	}
+	if tasks == nil {
+		tasks = []db.ScheduledTask{}
+	}
+
+	respondJSON(w, http.StatusOK, tasks)
+}
+
+// handleCreateScheduledTask queues an observation window for the autotracker,
+// e.g. {"kind": "arrivals", "target": "KCLT", "startTime": "...", "endTime": "..."}.
+// An optional "prediction" (auto, dead-reckoning, flight-plan or airway)
+// restricts how the autotracker predicts the task's targets.
+// Windows overlapping another task are rejected with 409 Conflict and the
+// conflicting tasks.
+func (s *Server) handleCreateScheduledTask(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	var req struct {
+		Title      string    `json:"title"`
+		Kind       string    `json:"kind"`
+		Target     string    `json:"target"`
+		StartTime  time.Time `json:"startTime"`
+		EndTime    time.Time `json:"endTime"`
+		Prediction string    `json:"prediction"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	task := &db.ScheduledTask{
+		UserID:     userID,
+		Title:      strings.TrimSpace(req.Title),
+		Kind:       req.Kind,
+		Target:     strings.ToUpper(strings.TrimSpace(req.Target)),
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Prediction: tracking.PredictionStrategy(req.Prediction),
+	}
+	if task.Title == "" {
+		task.Title = fmt.Sprintf("%s %s", task.Kind, task.Target)
+	}
+
+	if err := task.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !task.EndTime.After(time.Now()) {
+		http.Error(w, "Task window has already ended", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.scheduleRepo.Create(r.Context(), task); err != nil {
+		var conflict *db.ScheduleConflictError
+		if errors.As(err, &conflict) {
+			respondJSON(w, http.StatusConflict, map[string]interface{}{
+				"error":     conflict.Error(),
+				"conflicts": conflict.Conflicts,
+			})
+			return
+		}
+
+		log.Printf("Error creating scheduled task: %v", err)
+		http.Error(w, "Failed to create scheduled task", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, task)
+}
+
+// handleCancelScheduledTask cancels one of the user's tasks (admins may
+// cancel anyone's).
+func (s *Server) handleCancelScheduledTask(w http.ResponseWriter, r *http.Request) {
+	taskID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	owner := commandOwner(r)
+	if err := s.scheduleRepo.Cancel(r.Context(), taskID, owner.UserID, owner.Admin); err != nil {
+		log.Printf("Error cancelling scheduled task: %v", err)
+		http.Error(w, "Failed to cancel scheduled task", http.StatusNotFound)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
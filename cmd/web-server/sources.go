@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+)
+
+// handleGetSources compares the ADS-B data sources the collector has
+// queried: per-source aircraft counts, aircraft no other source reported,
+// position age and its percentiles, latency and availability, best coverage
+// first. predictionLatencyMs is the median position age (or the default
+// until measured) to predict the source's positions ahead by.
+//
+// Query parameters:
+//   - hours: report window (default 24, max 7 days)
+func (s *Server) handleGetSources(w http.ResponseWriter, r *http.Request) {
+	hours := 24
+	if v := r.URL.Query().Get("hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 7*24 {
+			http.Error(w, "Invalid hours parameter", http.StatusBadRequest)
+			return
+		}
+		hours = n
+	}
+
+	since := time.Now().UTC().Add(-time.Duration(hours) * time.Hour)
+	stats, err := s.collectorRepo.GetSourceStats(r.Context(), since)
+	if err != nil {
+		log.Printf("Error getting source stats: %v", err)
+		http.Error(w, "Failed to get source stats", http.StatusInternalServerError)
+		return
+	}
+
+	type sourceReport struct {
+		db.SourceSummary
+		Availability        float64 `json:"availability"`
+		PredictionLatencyMs int64   `json:"predictionLatencyMs"`
+	}
+	sources := make([]sourceReport, 0)
+	for _, summary := range db.SummarizeSources(stats) {
+		sources = append(sources, sourceReport{
+			SourceSummary:       summary,
+			Availability:        summary.Availability(),
+			PredictionLatencyMs: summary.PredictionLatency().Milliseconds(),
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"since":   since,
+		"hours":   hours,
+		"sources": sources,
+	})
+}
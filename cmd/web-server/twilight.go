@@ -0,0 +1,95 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+)
+
+// handleGetTwilight returns the current twilight phase at the user's
+// active observation point, the day's twilight times, and the night sky
+// brightness. Clients switch to red night mode while nightMode is set.
+//
+// Query parameters:
+//   - date: YYYY-MM-DD in the observer's time zone (default: today)
+func (s *Server) handleGetTwilight(w http.ResponseWriter, r *http.Request) {
+	obsPoint, err := s.requestObservationPoint(r)
+	if err != nil {
+		log.Printf("Error getting observation point: %v", err)
+		http.Error(w, "Failed to get observation point", http.StatusInternalServerError)
+		return
+	}
+	observer := coordinates.Observer{Location: observationPointLocation(obsPoint)}
+	sky := s.skyBrightness(obsPoint)
+
+	loc, err := time.LoadLocation(s.cfg.Observer.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().In(loc)
+	date := now
+	if v := r.URL.Query().Get("date"); v != "" {
+		date, err = time.ParseInLocation("2006-01-02", v, loc)
+		if err != nil {
+			http.Error(w, "date must be YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sun := coordinates.CalculateSunPosition(observer, now)
+	phase := coordinates.GetTwilightPhase(sun.Altitude)
+	times := coordinates.CalculateTwilight(observer, date)
+
+	// Crossings that don't happen that day are null
+	at := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"date":        times.Date.Format("2006-01-02"),
+		"timezone":    loc.String(),
+		"phase":       phase.String(),
+		"nightMode":   phase.IsDark(),
+		"sunAltitude": sun.Altitude,
+		"sky": map[string]interface{}{
+			"sqm":               sky.SQM,
+			"source":            sky.Source,
+			"bortle":            sky.Bortle(),
+			"limitingMagnitude": sky.LimitingMagnitude(),
+		},
+		"times": map[string]interface{}{
+			"astronomicalDawn": at(times.AstronomicalDawn),
+			"nauticalDawn":     at(times.NauticalDawn),
+			"civilDawn":        at(times.CivilDawn),
+			"sunrise":          at(times.Sunrise),
+			"sunset":           at(times.Sunset),
+			"civilDusk":        at(times.CivilDusk),
+			"nauticalDusk":     at(times.NauticalDusk),
+			"astronomicalDusk": at(times.AstronomicalDusk),
+		},
+	})
+}
+
+// skyBrightness returns the night sky brightness at an observation point:
+// the weather station's sky quality meter for stations using the server's
+// telescope, otherwise the point's Bortle class, otherwise the configured
+// sky.
+func (s *Server) skyBrightness(obsPoint *db.ObservationPoint) planner.SkyBrightness {
+	if obsPoint.TelescopeURL == "" && s.weather != nil {
+		if conditions, _ := s.weather.Latest(); conditions != nil && conditions.SkyQuality != nil {
+			return planner.SkyFromMeter(*conditions.SkyQuality)
+		}
+	}
+	if obsPoint.Bortle > 0 {
+		return planner.SkyFromBortle(obsPoint.Bortle)
+	}
+	return planner.ObserverSky(s.cfg.Observer)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/unklstewy/ads-bscope/internal/auth"
+	"github.com/unklstewy/ads-bscope/internal/db"
+)
+
+// minPasswordLength is the shortest password accepted for new accounts
+const minPasswordLength = 8
+
+// maxListedUsers caps the user list
+const maxListedUsers = 1000
+
+// adminMiddleware restricts endpoints to administrators. It must run after
+// authMiddleware.
+func (s *Server) adminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, _ := r.Context().Value("role").(string)
+		if !auth.CanManageUsers(role) {
+			http.Error(w, "Administrator access required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validRole reports whether role is one of the defined roles.
+func validRole(role string) bool {
+	return auth.HasRole(role, auth.RoleGuest)
+}
+
+// handleGetUsers lists all user accounts.
+func (s *Server) handleGetUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.userRepo.List(r.Context(), maxListedUsers, 0)
+	if err != nil {
+		log.Printf("Error listing users: %v", err)
+		http.Error(w, "Failed to list users", http.StatusInternalServerError)
+		return
+	}
+	if users == nil {
+		users = []*db.User{}
+	}
+
+	respondJSON(w, http.StatusOK, users)
+}
+
+// handleCreateUser creates a user account.
+func (s *Server) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" || strings.TrimSpace(req.Email) == "" {
+		http.Error(w, "username and email are required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Password) < minPasswordLength {
+		http.Error(w, "Password must be at least 8 characters", http.StatusBadRequest)
+		return
+	}
+	if req.Role == "" {
+		req.Role = auth.RoleViewer
+	}
+	if !validRole(req.Role) {
+		http.Error(w, "Role must be admin, observer, viewer or guest", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := s.authSvc.HashPassword(req.Password)
+	if err != nil {
+		log.Printf("Error hashing password: %v", err)
+		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	user := &db.User{
+		Username:     req.Username,
+		Email:        strings.TrimSpace(req.Email),
+		PasswordHash: hash,
+		Role:         req.Role,
+		IsActive:     true,
+	}
+	if err := s.userRepo.Create(r.Context(), user); err != nil {
+		if errors.Is(err, db.ErrUserExists) {
+			http.Error(w, "Username or email already in use", http.StatusConflict)
+			return
+		}
+		log.Printf("Error creating user: %v", err)
+		http.Error(w, "Failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("👤 %s created user %s (%s)", commandOwner(r).Username, user.Username, user.Role)
+	respondJSON(w, http.StatusCreated, user)
+}
+
+// handleUpdateUser changes a user's email, role, active flag or password.
+// Omitted fields are left unchanged.
+func (s *Server) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Email    *string `json:"email"`
+		Role     *string `json:"role"`
+		IsActive *bool   `json:"isActive"`
+		Password *string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := s.userRepo.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting user %d: %v", id, err)
+		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	// Admins can't lock themselves out
+	self := commandOwner(r).UserID == id
+	if self && ((req.Role != nil && *req.Role != auth.RoleAdmin) || (req.IsActive != nil && !*req.IsActive)) {
+		http.Error(w, "You can't demote or disable your own account", http.StatusBadRequest)
+		return
+	}
+
+	if req.Email != nil {
+		user.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.Role != nil {
+		if !validRole(*req.Role) {
+			http.Error(w, "Role must be admin, observer, viewer or guest", http.StatusBadRequest)
+			return
+		}
+		user.Role = *req.Role
+	}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+	if req.Password != nil && len(*req.Password) < minPasswordLength {
+		http.Error(w, "Password must be at least 8 characters", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.userRepo.Update(r.Context(), user); err != nil {
+		if errors.Is(err, db.ErrUserExists) {
+			http.Error(w, "Email already in use", http.StatusConflict)
+			return
+		}
+		log.Printf("Error updating user %d: %v", id, err)
+		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	if req.Password != nil {
+		hash, err := s.authSvc.HashPassword(*req.Password)
+		if err == nil {
+			err = s.userRepo.UpdatePassword(r.Context(), id, hash)
+		}
+		if err != nil {
+			log.Printf("Error setting password for user %d: %v", id, err)
+			http.Error(w, "Failed to set password", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	log.Printf("👤 %s updated user %s", commandOwner(r).Username, user.Username)
+	respondJSON(w, http.StatusOK, user)
+}
+
+// handleDeleteUser deletes a user account.
+func (s *Server) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	if commandOwner(r).UserID == id {
+		http.Error(w, "You can't delete your own account", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.userRepo.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error deleting user %d: %v", id, err)
+		http.Error(w, "Failed to delete user", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("👤 %s deleted user %d", commandOwner(r).Username, id)
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+)
+
+// handleGetWatchList returns the current user's watch list.
+func (s *Server) handleGetWatchList(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	list, err := s.watchRepo.List(r.Context(), userID)
+	if err != nil {
+		log.Printf("Error getting watch list: %v", err)
+		http.Error(w, "Failed to get watch list", http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = db.WatchList{}
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// handleAddWatchedAircraft stars an aircraft, e.g. {"kind": "icao",
+// "target": "a1b2c3"} or {"kind": "registration", "target": "N123AB",
+// "note": "Club Cessna"}. Starring an aircraft already on the list updates
+// its note.
+func (s *Server) handleAddWatchedAircraft(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	var req struct {
+		Kind   string `json:"kind"`
+		Target string `json:"target"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	watch := &db.WatchedAircraft{
+		UserID: userID,
+		Kind:   req.Kind,
+		Target: db.NormalizeWatchTarget(req.Kind, req.Target),
+		Note:   req.Note,
+	}
+	if err := watch.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.watchRepo.Add(r.Context(), watch); err != nil {
+		log.Printf("Error adding watched aircraft: %v", err)
+		http.Error(w, "Failed to add watched aircraft", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, watch)
+}
+
+// handleRemoveWatchedAircraft removes an entry from the current user's
+// watch list.
+func (s *Server) handleRemoveWatchedAircraft(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid watch list entry ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.watchRepo.Remove(r.Context(), userID, id); err != nil {
+		if errors.Is(err, db.ErrWatchNotFound) {
+			http.Error(w, "Watch list entry not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error removing watched aircraft: %v", err)
+		http.Error(w, "Failed to remove watched aircraft", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alerts"
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// weatherPollInterval is how often the weather station is read
+const weatherPollInterval = time.Minute
+
+// weatherMonitor polls the Alpaca ObservingConditions device, keeps the latest
+// reading for the weather endpoint, and raises high wind alerts.
+type weatherMonitor struct {
+	station    *alpaca.ObservingConditionsClient
+	dispatcher *alerts.Dispatcher
+	limitMS    float64
+
+	mu     sync.RWMutex
+	latest *alpaca.Conditions
+	err    error
+}
+
+// newWeatherMonitor creates a monitor for the station at cfg.BaseURL.
+// High wind alerts are delivered through the configured alert notifiers and
+// are always logged.
+func newWeatherMonitor(cfg config.TelescopeConfig, alertsCfg config.AlertsConfig) *weatherMonitor {
+	repeat := time.Duration(alertsCfg.RepeatIntervalMinutes) * time.Minute
+	var notifiers []alerts.Notifier
+	if alertsCfg.Enabled {
+		notifiers = alerts.NewNotifiers(alertsCfg)
+	}
+
+	return &weatherMonitor{
+		station:    alpaca.NewObservingConditionsClient(alpaca.NewClient(cfg)),
+		dispatcher: alerts.NewDispatcher(repeat, notifiers...),
+		limitMS:    cfg.MaxWindSpeedMS,
+	}
+}
+
+// Run polls the station until ctx is cancelled.
+func (m *weatherMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(weatherPollInterval)
+	defer ticker.Stop()
+
+	connected := false
+	for {
+		if !connected {
+			if err := m.station.Connect(); err != nil {
+				log.Printf("⚠️  Weather station unavailable: %v", err)
+				m.setReading(nil, err)
+			} else {
+				connected = true
+				log.Println("✓ Weather station connected")
+			}
+		}
+
+		if connected {
+			m.poll(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			m.station.Disconnect()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the station once and raises an alert if the wind is unsafe.
+func (m *weatherMonitor) poll(ctx context.Context) {
+	conditions, err := m.station.GetConditions()
+	if err != nil {
+		log.Printf("Error reading weather station: %v", err)
+		m.setReading(nil, err)
+		return
+	}
+	m.setReading(&conditions, nil)
+
+	if !conditions.WindExceeds(m.limitMS) {
+		return
+	}
+
+	wind, _ := conditions.MaxWindMS()
+	alert := alerts.NewHighWindAlert(wind, m.limitMS, conditions.ReadAt)
+	sent, err := m.dispatcher.Raise(ctx, alerts.AlertTypeHighWind, alert, conditions.ReadAt)
+	if sent {
+		log.Printf("⚠️  HIGH WIND: %s", alert.Description)
+	}
+	if err != nil {
+		log.Printf("✗ Failed to deliver high wind alert: %v", err)
+	}
+}
+
+// setReading records the latest reading (nil on error).
+func (m *weatherMonitor) setReading(conditions *alpaca.Conditions, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if conditions != nil {
+		m.latest = conditions
+	}
+	m.err = err
+}
+
+// Latest returns the most recent successful reading (nil if none) and the
+// error from the last poll, if it failed.
+func (m *weatherMonitor) Latest() (*alpaca.Conditions, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest, m.err
+}
+
+// handleGetWeather returns the latest weather station reading and whether the
+// wind is above the safe slewing limit.
+func (s *Server) handleGetWeather(w http.ResponseWriter, r *http.Request) {
+	if s.weather == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"available": false,
+		})
+		return
+	}
+
+	conditions, err := s.weather.Latest()
+	resp := map[string]interface{}{
+		"available":      conditions != nil,
+		"conditions":     conditions,
+		"maxWindSpeedMs": s.weather.limitMS,
+		"windUnsafe":     conditions != nil && conditions.WindExceeds(s.weather.limitMS),
+	}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
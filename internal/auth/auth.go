@@ -32,9 +32,22 @@ type Claims struct {
 	UserID   int    `json:"user_id"`
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	DeviceID int    `json:"device_id,omitempty"` // Set for paired device tokens
 	jwt.RegisteredClaims
 }
 
+// FeedTokenDuration is how long calendar feed tokens stay valid. Calendar
+// apps store the subscription URL, so these outlive session tokens.
+const FeedTokenDuration = 365 * 24 * time.Hour
+
+// feedAudience marks tokens that may only be used to read calendar feeds
+const feedAudience = "calendar-feed"
+
+// DeviceTokenDuration is how long paired device tokens stay valid. Devices
+// are paired once and used in the field, so their tokens are long-lived and
+// revoked by unpairing the device instead.
+const DeviceTokenDuration = 180 * 24 * time.Hour
+
 // Config holds authentication configuration
 type Config struct {
 	JWTSecret     string        // Secret key for signing JWTs
@@ -120,14 +133,76 @@ func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 	
-	// Extract claims
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	// Extract claims (feed tokens are not accepted as session tokens)
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && len(claims.Audience) == 0 {
 		return claims, nil
 	}
 	
 	return nil, ErrInvalidToken
 }
 
+// GenerateFeedToken generates a long-lived token that only grants read
+// access to the user's calendar feed, for embedding in subscription URLs.
+func (s *Service) GenerateFeedToken(userID int, username, role string) (string, error) {
+	claims := &Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(FeedTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "ads-bscope",
+			Audience:  jwt.ClaimStrings{feedAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.config.JWTSecret))
+}
+
+// ValidateFeedToken validates a calendar feed token and returns the claims.
+func (s *Service) ValidateFeedToken(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(s.config.JWTSecret), nil
+	}, jwt.WithAudience(feedAudience))
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, ErrInvalidToken
+}
+
+// GenerateDeviceToken generates a long-lived token for a paired device.
+// The role is the one the device was paired with, which may be lower than
+// the user's own role. Callers must check the device has not been revoked
+// when validating these tokens.
+func (s *Service) GenerateDeviceToken(userID int, username, role string, deviceID int) (string, error) {
+	claims := &Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		DeviceID: deviceID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(DeviceTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "ads-bscope",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.config.JWTSecret))
+}
+
 // HasRole checks if a user has a specific role or higher
 // Role hierarchy: Admin > Observer > Viewer > Guest
 func HasRole(userRole, requiredRole string) bool {
@@ -0,0 +1,197 @@
+// Package control arbitrates telescope commands between concurrent users.
+// One user at a time may hold an exclusive control lease; commands from
+// other users are rejected while the lease is held, and all commands are
+// executed one at a time so that slews cannot interleave.
+package control
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultLeaseDuration is how long control is held without further commands.
+const DefaultLeaseDuration = 10 * time.Minute
+
+// ErrControlHeld is returned when another user holds the control lease.
+var ErrControlHeld = errors.New("telescope is controlled by another user")
+
+// Owner identifies the user issuing a command.
+type Owner struct {
+	UserID   int    `json:"userId"`
+	Username string `json:"username"`
+
+	// Admin owners may take control from another user
+	Admin bool `json:"-"`
+}
+
+// Lease is an exclusive grant of telescope control.
+type Lease struct {
+	Owner
+
+	// Target is what the holder is tracking (e.g., an ICAO address)
+	Target string `json:"target,omitempty"`
+
+	// Since is when control was granted
+	Since time.Time `json:"since"`
+
+	// ExpiresAt is when the lease lapses unless renewed by another command
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// HeldError is returned when a command conflicts with another user's lease.
+type HeldError struct {
+	Lease Lease
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("telescope is controlled by %s until %s",
+		e.Lease.Username, e.Lease.ExpiresAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrControlHeld.
+func (e *HeldError) Is(target error) bool {
+	return target == ErrControlHeld
+}
+
+// Arbiter serializes telescope commands and manages the control lease.
+type Arbiter struct {
+	// cmd serializes command execution (waiting commands queue here)
+	cmd sync.Mutex
+
+	// mu guards lease
+	mu    sync.Mutex
+	lease *Lease
+
+	duration time.Duration
+
+	// now returns the current time (replaceable in tests)
+	now func() time.Time
+}
+
+// NewArbiter creates an arbiter whose leases last for duration after the
+// holder's last command. A non-positive duration uses DefaultLeaseDuration.
+func NewArbiter(duration time.Duration) *Arbiter {
+	if duration <= 0 {
+		duration = DefaultLeaseDuration
+	}
+	return &Arbiter{
+		duration: duration,
+		now:      time.Now,
+	}
+}
+
+// Current returns the active lease, or nil if nobody holds control.
+func (a *Arbiter) Current() *Lease {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if l := a.active(); l != nil {
+		lease := *l
+		return &lease
+	}
+	return nil
+}
+
+// Do runs a one-off command (e.g., a manual slew) on behalf of owner.
+// It is rejected with a *HeldError if another user holds control. If owner
+// holds control, the lease is renewed.
+func (a *Arbiter) Do(owner Owner, fn func() error) error {
+	a.cmd.Lock()
+	defer a.cmd.Unlock()
+
+	if err := a.check(owner); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	if l := a.active(); l != nil && l.UserID == owner.UserID {
+		l.ExpiresAt = a.now().Add(a.duration)
+	}
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Acquire runs fn and, if it succeeds, grants owner exclusive control for
+// target. It is rejected with a *HeldError if another user holds control,
+// unless owner is an admin.
+func (a *Arbiter) Acquire(owner Owner, target string, fn func() error) (Lease, error) {
+	a.cmd.Lock()
+	defer a.cmd.Unlock()
+
+	if err := a.check(owner); err != nil {
+		return Lease{}, err
+	}
+	if err := fn(); err != nil {
+		return Lease{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	lease := Lease{Owner: owner, Target: target, Since: now, ExpiresAt: now.Add(a.duration)}
+	if l := a.active(); l != nil && l.UserID == owner.UserID {
+		lease.Since = l.Since
+	}
+	a.lease = &lease
+
+	return lease, nil
+}
+
+// Release runs fn and, if it succeeds, gives up owner's control.
+// It is rejected with a *HeldError if another user holds control, unless
+// owner is an admin.
+func (a *Arbiter) Release(owner Owner, fn func() error) error {
+	a.cmd.Lock()
+	defer a.cmd.Unlock()
+
+	if err := a.check(owner); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.lease = nil
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Abort runs an emergency command for any user and clears the lease.
+// Safety commands are never queued behind or rejected by the lease.
+func (a *Arbiter) Abort(fn func() error) error {
+	a.mu.Lock()
+	a.lease = nil
+	a.mu.Unlock()
+
+	return fn()
+}
+
+// check returns a *HeldError if another user holds control and owner
+// cannot override it.
+func (a *Arbiter) check(owner Owner) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	l := a.active()
+	if l == nil || l.UserID == owner.UserID || owner.Admin {
+		return nil
+	}
+	return &HeldError{Lease: *l}
+}
+
+// active returns the lease if it has not expired. Caller must hold mu.
+func (a *Arbiter) active() *Lease {
+	if a.lease != nil && !a.now().Before(a.lease.ExpiresAt) {
+		a.lease = nil
+	}
+	return a.lease
+}
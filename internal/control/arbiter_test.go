@@ -0,0 +1,114 @@
+package control
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestArbiter tests lease ownership and conflict handling.
+func TestArbiter(t *testing.T) {
+	now := time.Now()
+	alice := Owner{UserID: 1, Username: "alice"}
+	bob := Owner{UserID: 2, Username: "bob"}
+	admin := Owner{UserID: 3, Username: "admin", Admin: true}
+	ok := func() error { return nil }
+
+	newArbiter := func() *Arbiter {
+		a := NewArbiter(time.Minute)
+		a.now = func() time.Time { return now }
+		return a
+	}
+
+	t.Run("Conflicting commands are rejected", func(t *testing.T) {
+		a := newArbiter()
+		if _, err := a.Acquire(alice, "a12345", ok); err != nil {
+			t.Fatalf("Expected alice to acquire control, got %v", err)
+		}
+
+		ran := false
+		err := a.Do(bob, func() error { ran = true; return nil })
+		if !errors.Is(err, ErrControlHeld) {
+			t.Fatalf("Expected ErrControlHeld, got %v", err)
+		}
+		if ran {
+			t.Error("Rejected command should not run")
+		}
+
+		var held *HeldError
+		if !errors.As(err, &held) || held.Lease.Username != "alice" {
+			t.Errorf("Expected lease held by alice, got %+v", held)
+		}
+
+		if err := a.Do(alice, ok); err != nil {
+			t.Errorf("Expected holder's command allowed, got %v", err)
+		}
+	})
+
+	t.Run("Failed command does not grant control", func(t *testing.T) {
+		a := newArbiter()
+		if _, err := a.Acquire(alice, "a12345", func() error { return errors.New("slew failed") }); err == nil {
+			t.Fatal("Expected command error")
+		}
+		if a.Current() != nil {
+			t.Error("Expected no lease after failed command")
+		}
+	})
+
+	t.Run("Lease expires without renewal", func(t *testing.T) {
+		a := newArbiter()
+		a.Acquire(alice, "a12345", ok)
+
+		now = now.Add(30 * time.Second)
+		a.Do(alice, ok) // renews until now+1m
+
+		now = now.Add(45 * time.Second)
+		if a.Current() == nil {
+			t.Fatal("Expected renewed lease still active")
+		}
+
+		now = now.Add(time.Minute)
+		if a.Current() != nil {
+			t.Error("Expected lease to expire")
+		}
+		if err := a.Do(bob, ok); err != nil {
+			t.Errorf("Expected command allowed after expiry, got %v", err)
+		}
+	})
+
+	t.Run("Release and admin override", func(t *testing.T) {
+		a := newArbiter()
+		a.Acquire(alice, "a12345", ok)
+
+		if err := a.Release(bob, ok); !errors.Is(err, ErrControlHeld) {
+			t.Errorf("Expected bob's release rejected, got %v", err)
+		}
+
+		lease, err := a.Acquire(admin, "b67890", ok)
+		if err != nil {
+			t.Fatalf("Expected admin override, got %v", err)
+		}
+		if lease.Username != "admin" || lease.Target != "b67890" {
+			t.Errorf("Unexpected lease %+v", lease)
+		}
+
+		if err := a.Release(admin, ok); err != nil {
+			t.Fatalf("Expected release, got %v", err)
+		}
+		if a.Current() != nil {
+			t.Error("Expected no lease after release")
+		}
+	})
+
+	t.Run("Abort always runs and clears control", func(t *testing.T) {
+		a := newArbiter()
+		a.Acquire(alice, "a12345", ok)
+
+		if err := a.Abort(ok); err != nil {
+			t.Fatalf("Expected abort to run, got %v", err)
+		}
+		if a.Current() != nil {
+			t.Error("Expected abort to clear the lease")
+		}
+	})
+}
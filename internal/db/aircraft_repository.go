@@ -5,16 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // AircraftRepository handles database operations for aircraft tracking.
 type AircraftRepository struct {
 	db       *DB
 	observer coordinates.Observer
+
+	// Position history settings (see ConfigurePositionHistory)
+	history   config.PositionHistoryConfig
+	positions *positionBuffer // nil to insert each position as it comes
 }
 
 // NewAircraftRepository creates a new aircraft repository.
@@ -28,17 +38,29 @@ func NewAircraftRepository(db *DB, observer coordinates.Observer) *AircraftRepos
 // UpsertAircraft inserts or updates an aircraft record.
 // Calculates deltas, observer-relative measurements, and stores position history.
 func (r *AircraftRepository) UpsertAircraft(ctx context.Context, aircraft adsb.Aircraft, now time.Time, regionName string) error {
+	return r.UpsertAircraftSeenIn(ctx, aircraft, now, []string{regionName})
+}
+
+// UpsertAircraftSeenIn is UpsertAircraft for an aircraft reported by several
+// collection regions. The first region is the one whose report is stored;
+// all are added to the regions the aircraft has been seen in.
+func (r *AircraftRepository) UpsertAircraftSeenIn(ctx context.Context, aircraft adsb.Aircraft, now time.Time, regions []string) error {
+	regionName := ""
+	if len(regions) > 0 {
+		regionName = regions[0]
+	}
+
 	// Get previous position if exists
 	var prevPos aircraftPosition
 	err := r.db.QueryRowContext(ctx,
 		`SELECT latitude, longitude, altitude_ft, ground_speed_kts, track_deg, 
-		        vertical_rate_fpm, last_seen
+		        vertical_rate_fpm, last_seen, position_count
 		 FROM aircraft 
 		 WHERE icao = $1`,
 		aircraft.ICAO,
 	).Scan(&prevPos.Latitude, &prevPos.Longitude, &prevPos.AltitudeFt,
 		&prevPos.GroundSpeedKts, &prevPos.TrackDeg, &prevPos.VerticalRateFpm,
-		&prevPos.Timestamp)
+		&prevPos.Timestamp, &prevPos.Count)
 
 	var prevPosPtr *aircraftPosition
 	if err == nil {
@@ -51,10 +73,11 @@ func (r *AircraftRepository) UpsertAircraft(ctx context.Context, aircraft adsb.A
 	acPos := coordinates.Geographic{
 		Latitude:  aircraft.Latitude,
 		Longitude: aircraft.Longitude,
-		Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+		Altitude:  aircraft.Altitude.Meters(),
 	}
 
 	rangeNM := coordinates.DistanceNauticalMiles(r.observer.Location, acPos)
+	bearing := coordinates.Bearing(r.observer.Location, acPos)
 	horiz := coordinates.GeographicToHorizontal(acPos, r.observer, now)
 
 	// Calculate approach information
@@ -75,10 +98,14 @@ func (r *AircraftRepository) UpsertAircraft(ctx context.Context, aircraft adsb.A
 			first_seen, last_seen, last_updated, position_count,
 			range_nm, bearing_deg, altitude_deg, azimuth_deg,
 			is_approaching, closest_range_nm, eta_closest_seconds,
-			collection_region, is_visible
+			collection_region, is_visible, squawk, source, aircraft_type,
+			selected_altitude_ft, selected_heading_deg, heading_deg, seen_regions,
+			geometry_latitude, geometry_longitude, geometry_elevation_m, registration
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 1,
-			$12, $13, $14, $15, $16, $17, $18, $19, TRUE
+			$12, $13, $14, $15, $16, $17, $18, $19, TRUE, NULLIF($20, ''), NULLIF($21, ''), NULLIF($22, ''),
+			NULLIF($23, 0), $24, $25, ARRAY(SELECT DISTINCT unnest($26::TEXT[]) ORDER BY 1),
+			$27, $28, $29, NULLIF($30, '')
 		)
 		ON CONFLICT (icao) DO UPDATE SET
 			callsign = EXCLUDED.callsign,
@@ -99,14 +126,31 @@ func (r *AircraftRepository) UpsertAircraft(ctx context.Context, aircraft adsb.A
 			closest_range_nm = EXCLUDED.closest_range_nm,
 			eta_closest_seconds = EXCLUDED.eta_closest_seconds,
 			collection_region = EXCLUDED.collection_region,
-			is_visible = TRUE`,
+			is_visible = TRUE,
+			squawk = EXCLUDED.squawk,
+			source = EXCLUDED.source,
+			aircraft_type = COALESCE(EXCLUDED.aircraft_type, aircraft.aircraft_type),
+			registration = COALESCE(EXCLUDED.registration, aircraft.registration),
+			selected_altitude_ft = EXCLUDED.selected_altitude_ft,
+			selected_heading_deg = EXCLUDED.selected_heading_deg,
+			heading_deg = EXCLUDED.heading_deg,
+			seen_regions = ARRAY(
+				SELECT DISTINCT unnest(aircraft.seen_regions || EXCLUDED.seen_regions) ORDER BY 1
+			),
+			geometry_latitude = EXCLUDED.geometry_latitude,
+			geometry_longitude = EXCLUDED.geometry_longitude,
+			geometry_elevation_m = EXCLUDED.geometry_elevation_m`,
 		aircraft.ICAO, aircraft.Callsign,
 		aircraft.Latitude, aircraft.Longitude, aircraft.Altitude,
 		aircraft.GroundSpeed, aircraft.Track, aircraft.VerticalRate,
 		now, now, now,
-		rangeNM, 0.0, horiz.Altitude, horiz.Azimuth,
+		rangeNM, bearing, horiz.Altitude, horiz.Azimuth,
 		approaching, closestRange, etaSeconds,
-		regionName,
+		regionName, aircraft.Squawk, aircraft.Source, aircraft.AircraftType,
+		aircraft.SelectedAltitude, aircraft.SelectedHeading, aircraft.Heading,
+		pq.Array(regions),
+		r.observer.Location.Latitude, r.observer.Location.Longitude, r.observer.Location.Altitude,
+		aircraft.Registration,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to upsert aircraft: %w", err)
@@ -124,15 +168,17 @@ func (r *AircraftRepository) UpsertAircraft(ctx context.Context, aircraft adsb.A
 type aircraftPosition struct {
 	Latitude        float64
 	Longitude       float64
-	AltitudeFt      float64
+	AltitudeFt      units.Feet
 	GroundSpeedKts  float64
 	TrackDeg        float64
 	VerticalRateFpm float64
 	Timestamp       time.Time
+	Count           int // Positions received so far
 }
 
-// insertPositionHistory stores a position record with calculated deltas.
-// Skips insertion if aircraft position hasn't changed (prevents redundant data).
+// insertPositionHistory stores a position record with calculated deltas,
+// or buffers it if batching is enabled. Skips insertion if aircraft position
+// hasn't changed (prevents redundant data) or the position is downsampled.
 func (r *AircraftRepository) insertPositionHistory(
 	ctx context.Context,
 	aircraft adsb.Aircraft,
@@ -141,97 +187,94 @@ func (r *AircraftRepository) insertPositionHistory(
 	rangeNM float64,
 	horiz coordinates.HorizontalCoordinates,
 ) error {
-	var (
-		deltaTime          sql.NullFloat64
-		deltaDistance      sql.NullFloat64
-		deltaAltitude      sql.NullFloat64
-		deltaTrack         sql.NullFloat64
-		actualSpeed        sql.NullFloat64
-		actualVerticalRate sql.NullFloat64
-	)
+	// Skip insertion if position is unchanged (common for grounded aircraft)
+	// Consider position unchanged if:
+	// - Lat/Lon unchanged (to 6 decimal places = ~0.1m precision)
+	// - Altitude unchanged (to nearest foot)
+	// - Ground speed near zero (<1 knot)
+	if prevPos != nil && positionsEqual(aircraft, *prevPos) {
+		return nil // Skip redundant position insert
+	}
 
-	// Check if position has changed since last update
+	count := 1
 	if prevPos != nil {
-		// Skip insertion if position is unchanged (common for grounded aircraft)
-		// Consider position unchanged if:
-		// - Lat/Lon unchanged (to 6 decimal places = ~0.1m precision)
-		// - Altitude unchanged (to nearest foot)
-		// - Ground speed near zero (<1 knot)
-		if positionsEqual(aircraft, *prevPos) {
-			return nil // Skip redundant position insert
-		}
+		count = prevPos.Count + 1
+	}
+	if !r.history.Keep(rangeNM, count) {
+		return nil
 	}
 
-	// Calculate deltas if we have a previous position
-	if prevPos != nil {
-		timeDelta := now.Sub(prevPos.Timestamp).Seconds()
-		if timeDelta > 0 {
-			deltaTime = sql.NullFloat64{Float64: timeDelta, Valid: true}
-
-			// Distance delta
-			prevGeo := coordinates.Geographic{
-				Latitude:  prevPos.Latitude,
-				Longitude: prevPos.Longitude,
-			}
-			currentGeo := coordinates.Geographic{
-				Latitude:  aircraft.Latitude,
-				Longitude: aircraft.Longitude,
-			}
-			distDelta := coordinates.DistanceNauticalMiles(prevGeo, currentGeo)
-			deltaDistance = sql.NullFloat64{Float64: distDelta, Valid: true}
-
-			// Altitude delta
-			altDelta := aircraft.Altitude - prevPos.AltitudeFt
-			deltaAltitude = sql.NullFloat64{Float64: altDelta, Valid: true}
-
-			// Track delta (handle wrap-around)
-			trackDelta := aircraft.Track - prevPos.TrackDeg
-			if trackDelta > 180 {
-				trackDelta -= 360
-			} else if trackDelta < -180 {
-				trackDelta += 360
-			}
-			deltaTrack = sql.NullFloat64{Float64: trackDelta, Valid: true}
-
-			// Actual speed from position delta (more accurate than reported)
-			timeHours := timeDelta / 3600.0
-			if timeHours > 0 {
-				actualSpeed = sql.NullFloat64{
-					Float64: distDelta / timeHours,
-					Valid:   true,
-				}
-			}
+	row := positionRow{
+		aircraft: aircraft,
+		now:      now,
+		deltas:   calculateDeltas(aircraft, now, prevPos),
+		rangeNM:  rangeNM,
+		horiz:    horiz,
+	}
+	if r.positions != nil {
+		r.positions.add(row)
+		return nil
+	}
+	return r.insertPositions(ctx, []positionRow{row})
+}
 
-			// Actual vertical rate from altitude delta
-			timeMinutes := timeDelta / 60.0
-			if timeMinutes > 0 {
-				actualVerticalRate = sql.NullFloat64{
-					Float64: altDelta / timeMinutes,
-					Valid:   true,
-				}
-			}
-		}
+// positionDeltas holds the changes since the previous position; fields are
+// NULL when there is no previous position.
+type positionDeltas struct {
+	time               sql.NullFloat64
+	distance           sql.NullFloat64
+	altitude           sql.NullFloat64
+	track              sql.NullFloat64
+	actualSpeed        sql.NullFloat64
+	actualVerticalRate sql.NullFloat64
+}
+
+// calculateDeltas computes the deltas and derived velocities between the
+// previous position and the current one at time now.
+func calculateDeltas(aircraft adsb.Aircraft, now time.Time, prevPos *aircraftPosition) positionDeltas {
+	var d positionDeltas
+	if prevPos == nil {
+		return d
 	}
 
-	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO aircraft_positions (
-			icao, timestamp, latitude, longitude, altitude_ft,
-			ground_speed_kts, track_deg, vertical_rate_fpm,
-			delta_time_seconds, delta_distance_nm, delta_altitude_ft, delta_track_deg,
-			actual_speed_kts, actual_vertical_rate_fpm,
-			range_nm, altitude_angle_deg, azimuth_deg
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
-		)`,
-		aircraft.ICAO, now,
-		aircraft.Latitude, aircraft.Longitude, aircraft.Altitude,
-		aircraft.GroundSpeed, aircraft.Track, aircraft.VerticalRate,
-		deltaTime, deltaDistance, deltaAltitude, deltaTrack,
-		actualSpeed, actualVerticalRate,
-		rangeNM, horiz.Altitude, horiz.Azimuth,
-	)
+	timeDelta := now.Sub(prevPos.Timestamp).Seconds()
+	if timeDelta <= 0 {
+		return d
+	}
+	d.time = sql.NullFloat64{Float64: timeDelta, Valid: true}
 
-	return err
+	// Distance delta
+	prevGeo := coordinates.Geographic{
+		Latitude:  prevPos.Latitude,
+		Longitude: prevPos.Longitude,
+	}
+	currentGeo := coordinates.Geographic{
+		Latitude:  aircraft.Latitude,
+		Longitude: aircraft.Longitude,
+	}
+	distDelta := coordinates.DistanceNauticalMiles(prevGeo, currentGeo)
+	d.distance = sql.NullFloat64{Float64: distDelta, Valid: true}
+
+	// Altitude delta
+	altDelta := float64(aircraft.Altitude - prevPos.AltitudeFt)
+	d.altitude = sql.NullFloat64{Float64: altDelta, Valid: true}
+
+	// Track delta (handle wrap-around)
+	trackDelta := aircraft.Track - prevPos.TrackDeg
+	if trackDelta > 180 {
+		trackDelta -= 360
+	} else if trackDelta < -180 {
+		trackDelta += 360
+	}
+	d.track = sql.NullFloat64{Float64: trackDelta, Valid: true}
+
+	// Actual speed from position delta (more accurate than reported)
+	d.actualSpeed = sql.NullFloat64{Float64: distDelta / (timeDelta / 3600.0), Valid: true}
+
+	// Actual vertical rate from altitude delta
+	d.actualVerticalRate = sql.NullFloat64{Float64: altDelta / (timeDelta / 60.0), Valid: true}
+
+	return d
 }
 
 // positionsEqual checks if two aircraft positions are effectively identical.
@@ -249,7 +292,7 @@ func positionsEqual(current adsb.Aircraft, prev aircraftPosition) bool {
 	lonChanged := math.Abs(current.Longitude-prev.Longitude) > positionTolerance
 
 	// Check if altitude changed
-	altChanged := math.Abs(current.Altitude-prev.AltitudeFt) > altitudeTolerance
+	altChanged := math.Abs(float64(current.Altitude-prev.AltitudeFt)) > altitudeTolerance
 
 	// Check if aircraft is moving (either current or previous speed >1 knot)
 	isMoving := current.GroundSpeed >= speedThreshold || prev.GroundSpeedKts >= speedThreshold
@@ -289,12 +332,138 @@ func (r *AircraftRepository) UpdateTrackableStatus(
 	return err
 }
 
-// GetVisibleAircraft returns all currently visible aircraft.
+// SetTerrainBlocked flags the visible aircraft hidden from the observer by
+// terrain and clears their is_trackable flag. Call it after
+// UpdateTrackableStatus; aircraft not listed are unflagged.
+func (r *AircraftRepository) SetTerrainBlocked(ctx context.Context, icaos []string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE aircraft
+		 SET terrain_blocked = (icao = ANY($1)),
+		     is_trackable = is_trackable AND NOT (icao = ANY($1))
+		 WHERE is_visible = TRUE`,
+		pq.Array(icaos),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to flag terrain-blocked aircraft: %w", err)
+	}
+	return nil
+}
+
+// ObservedAircraft is an aircraft with its observer-relative geometry.
+// The geometry is computed once when the position is stored (relative to
+// the storing repository's observer, normally the collector's), so
+// consumers with the same observer don't each recompute it.
+type ObservedAircraft struct {
+	adsb.Aircraft
+
+	// RangeNM is the distance from the observer in nautical miles
+	RangeNM float64
+
+	// BearingDeg is the great-circle bearing from the observer
+	BearingDeg float64
+
+	// Horizontal is the elevation and azimuth seen by the observer
+	Horizontal coordinates.HorizontalCoordinates
+
+	// observer is the location the geometry is relative to; nil if the row
+	// had no stored geometry or didn't record where it was computed from
+	observer *coordinates.Geographic
+
+	// terrainBlocked is the collector's terrain flag, valid for observer
+	terrainBlocked bool
+}
+
+// RelativeTo returns the aircraft's horizontal coordinates and range from
+// an observer location. The stored values are used when they were computed
+// for the same location; otherwise they are calculated.
+func (ac ObservedAircraft) RelativeTo(observer coordinates.Geographic) (coordinates.HorizontalCoordinates, float64) {
+	if ac.observer != nil && *ac.observer == observer {
+		return ac.Horizontal, ac.RangeNM
+	}
+
+	acPos := coordinates.Geographic{
+		Latitude:  ac.Latitude,
+		Longitude: ac.Longitude,
+		Altitude:  ac.Altitude.Meters(),
+	}
+	horiz := coordinates.GeographicToHorizontal(acPos, coordinates.Observer{Location: observer}, ac.LastSeen)
+	return horiz, coordinates.DistanceNauticalMiles(observer, acPos)
+}
+
+// From returns a copy of the aircraft with its geometry relative to another
+// observer location, such as a user's active observation point.
+func (ac ObservedAircraft) From(observer coordinates.Geographic) ObservedAircraft {
+	if ac.observer != nil && *ac.observer == observer {
+		return ac
+	}
+
+	acPos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+	ac.Horizontal, ac.RangeNM = ac.RelativeTo(observer)
+	ac.BearingDeg = coordinates.Bearing(observer, acPos)
+	ac.observer = &observer
+	ac.terrainBlocked = false // Only checked from the collector's observer
+	return ac
+}
+
+// observedFrom returns aircraft with their geometry relative to an observer
+// location, nearest first. Stored geometry computed from another location
+// is recomputed.
+func observedFrom(aircraft []ObservedAircraft, observer coordinates.Geographic) []ObservedAircraft {
+	recomputed := false
+	for i, ac := range aircraft {
+		if ac.observer == nil || *ac.observer != observer {
+			aircraft[i] = ac.From(observer)
+			recomputed = true
+		}
+	}
+	if recomputed {
+		sort.SliceStable(aircraft, func(i, j int) bool { return aircraft[i].RangeNM < aircraft[j].RangeNM })
+	}
+	return aircraft
+}
+
+// IsTrackable reports whether the aircraft is airborne and within the
+// telescope's altitude limits, as seen from the observer its geometry is
+// relative to. It matches the is_trackable flag set by UpdateTrackableStatus.
+func (ac ObservedAircraft) IsTrackable(minAlt, maxAlt float64) bool {
+	return ac.Altitude > 0 && ac.Horizontal.Altitude >= minAlt && ac.Horizontal.Altitude <= maxAlt
+}
+
+// AircraftOf returns the aircraft of a list of observed aircraft.
+func AircraftOf(observed []ObservedAircraft) []adsb.Aircraft {
+	aircraft := make([]adsb.Aircraft, len(observed))
+	for i, ac := range observed {
+		aircraft[i] = ac.Aircraft
+	}
+	return aircraft
+}
+
+// aircraftTypeColumn selects an aircraft's type designator, falling back to
+// the type in its latest flight plan when the feed didn't report one
+const aircraftTypeColumn = `COALESCE(aircraft_type,
+		        (SELECT fp.aircraft_type FROM flight_plans fp
+		         WHERE fp.icao = aircraft.icao ORDER BY fp.last_updated DESC LIMIT 1), '')`
+
+// aircraftIntentColumns selects the autopilot selected values, scanned into
+// SelectedAltitude, SelectedHeading and Heading
+const aircraftIntentColumns = `COALESCE(selected_altitude_ft, 0), selected_heading_deg, heading_deg`
+
+// GetVisibleAircraft returns all currently visible aircraft with their
+// range, bearing, elevation and azimuth from the repository's observer.
 // This includes aircraft that may not be trackable by the telescope.
-func (r *AircraftRepository) GetVisibleAircraft(ctx context.Context) ([]adsb.Aircraft, error) {
+func (r *AircraftRepository) GetVisibleAircraft(ctx context.Context) ([]ObservedAircraft, error) {
+	return r.GetVisibleAircraftFrom(ctx, r.observer.Location)
+}
+
+// visibleAircraft returns all currently visible aircraft with their stored
+// geometry, relative to the observer recorded with it.
+func (r *AircraftRepository) visibleAircraft(ctx context.Context) ([]ObservedAircraft, error) {
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT icao, callsign, latitude, longitude, altitude_ft,
-		        ground_speed_kts, track_deg, vertical_rate_fpm, last_seen
+		        ground_speed_kts, track_deg, vertical_rate_fpm,
+		        COALESCE(squawk, ''), COALESCE(source, ''), `+aircraftTypeColumn+`, COALESCE(registration, ''), `+aircraftIntentColumns+`, last_seen,
+		        range_nm, bearing_deg, altitude_deg, azimuth_deg,
+		        geometry_latitude, geometry_longitude, geometry_elevation_m, terrain_blocked
 		 FROM aircraft
 		 WHERE is_visible = TRUE
 		 ORDER BY range_nm ASC`,
@@ -304,55 +473,151 @@ func (r *AircraftRepository) GetVisibleAircraft(ctx context.Context) ([]adsb.Air
 	}
 	defer rows.Close()
 
-	var aircraft []adsb.Aircraft
+	var aircraft []ObservedAircraft
 	for rows.Next() {
-		var ac adsb.Aircraft
+		var ac ObservedAircraft
+		var rangeNM, bearing, elevation, azimuth sql.NullFloat64
+		var obsLat, obsLon, obsElev sql.NullFloat64
 		err := rows.Scan(
 			&ac.ICAO, &ac.Callsign,
 			&ac.Latitude, &ac.Longitude, &ac.Altitude,
 			&ac.GroundSpeed, &ac.Track, &ac.VerticalRate,
-			&ac.LastSeen,
+			&ac.Squawk, &ac.Source, &ac.AircraftType, &ac.Registration,
+			&ac.SelectedAltitude, &ac.SelectedHeading, &ac.Heading, &ac.LastSeen,
+			&rangeNM, &bearing, &elevation, &azimuth,
+			&obsLat, &obsLon, &obsElev, &ac.terrainBlocked,
 		)
 		if err != nil {
 			return nil, err
 		}
+
+		if rangeNM.Valid && elevation.Valid && azimuth.Valid && obsLat.Valid && obsLon.Valid && obsElev.Valid {
+			ac.RangeNM = rangeNM.Float64
+			ac.BearingDeg = bearing.Float64
+			ac.Horizontal = coordinates.HorizontalCoordinates{
+				Altitude: elevation.Float64,
+				Azimuth:  azimuth.Float64,
+			}
+			ac.observer = &coordinates.Geographic{
+				Latitude:  obsLat.Float64,
+				Longitude: obsLon.Float64,
+				Altitude:  units.Meters(obsElev.Float64),
+			}
+		}
 		aircraft = append(aircraft, ac)
 	}
 
 	return aircraft, rows.Err()
 }
 
-// GetTrackableAircraft returns all currently trackable aircraft.
-// This uses the observer location configured in the repository.
-func (r *AircraftRepository) GetTrackableAircraft(ctx context.Context) ([]adsb.Aircraft, error) {
+// GetVisibleAircraftFrom returns all currently visible aircraft with their
+// geometry relative to an observer location, nearest first. Stored values
+// are used when they were computed from that location; otherwise they are
+// computed at query time, so users at different sites (or readers whose
+// observer differs from the collector's) each get correct elevation and
+// azimuth.
+func (r *AircraftRepository) GetVisibleAircraftFrom(ctx context.Context, observer coordinates.Geographic) ([]ObservedAircraft, error) {
+	aircraft, err := r.visibleAircraft(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return observedFrom(aircraft, observer), nil
+}
+
+// GetTrackableAircraftFrom returns the visible aircraft that are trackable
+// from an observer location with the given telescope altitude limits.
+// It doesn't rely on the is_trackable flag, which is only valid for the
+// collector's observer and limits. Aircraft the collector found hidden by
+// terrain are excluded when the location is the collector's observer.
+func (r *AircraftRepository) GetTrackableAircraftFrom(
+	ctx context.Context,
+	observer coordinates.Geographic,
+	minAlt, maxAlt float64,
+) ([]ObservedAircraft, error) {
+	aircraft, err := r.GetVisibleAircraftFrom(ctx, observer)
+	if err != nil {
+		return nil, err
+	}
+
+	trackable := aircraft[:0]
+	for _, ac := range aircraft {
+		if ac.IsTrackable(minAlt, maxAlt) && !ac.terrainBlocked {
+			trackable = append(trackable, ac)
+		}
+	}
+	return trackable, nil
+}
+
+// RefreshGeometry recomputes the stored range, bearing, elevation and
+// azimuth of all visible aircraft relative to the repository's observer.
+// Call it when the observer may have moved since the values were stored
+// (e.g. at collector startup).
+func (r *AircraftRepository) RefreshGeometry(ctx context.Context) (int, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT icao, callsign, latitude, longitude, altitude_ft,
-		        ground_speed_kts, track_deg, vertical_rate_fpm, last_seen
+		`SELECT icao, latitude, longitude, COALESCE(altitude_ft, 0)
 		 FROM aircraft
-		 WHERE is_trackable = TRUE AND is_visible = TRUE
-		 ORDER BY range_nm ASC`,
+		 WHERE is_visible = TRUE`,
 	)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("failed to query aircraft: %w", err)
 	}
 	defer rows.Close()
 
-	var aircraft []adsb.Aircraft
+	var icaos []string
+	var ranges, bearings, elevations, azimuths []float64
+	now := time.Now().UTC()
 	for rows.Next() {
-		var ac adsb.Aircraft
-		err := rows.Scan(
-			&ac.ICAO, &ac.Callsign,
-			&ac.Latitude, &ac.Longitude, &ac.Altitude,
-			&ac.GroundSpeed, &ac.Track, &ac.VerticalRate,
-			&ac.LastSeen,
-		)
-		if err != nil {
-			return nil, err
+		var icao string
+		var acPos coordinates.Geographic
+		var altitude units.Feet
+		if err := rows.Scan(&icao, &acPos.Latitude, &acPos.Longitude, &altitude); err != nil {
+			return 0, fmt.Errorf("failed to scan aircraft: %w", err)
 		}
-		aircraft = append(aircraft, ac)
+		acPos.Altitude = altitude.Meters()
+
+		horiz := coordinates.GeographicToHorizontal(acPos, r.observer, now)
+		icaos = append(icaos, icao)
+		ranges = append(ranges, coordinates.DistanceNauticalMiles(r.observer.Location, acPos))
+		bearings = append(bearings, coordinates.Bearing(r.observer.Location, acPos))
+		elevations = append(elevations, horiz.Altitude)
+		azimuths = append(azimuths, horiz.Azimuth)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read aircraft: %w", err)
 	}
+	rows.Close()
 
-	return aircraft, rows.Err()
+	if len(icaos) == 0 {
+		return 0, nil
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE aircraft a
+		 SET range_nm = v.range_nm, bearing_deg = v.bearing_deg,
+		     altitude_deg = v.altitude_deg, azimuth_deg = v.azimuth_deg,
+		     geometry_latitude = $6, geometry_longitude = $7, geometry_elevation_m = $8
+		 FROM unnest($1::text[], $2::float8[], $3::float8[], $4::float8[], $5::float8[])
+		      AS v(icao, range_nm, bearing_deg, altitude_deg, azimuth_deg)
+		 WHERE a.icao = v.icao`,
+		pq.Array(icaos), pq.Array(ranges), pq.Array(bearings), pq.Array(elevations), pq.Array(azimuths),
+		r.observer.Location.Latitude, r.observer.Location.Longitude, r.observer.Location.Altitude,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update geometry: %w", err)
+	}
+
+	return len(icaos), nil
+}
+
+// GetTrackableAircraft returns all currently trackable aircraft from the
+// repository's observer with the given telescope altitude limits, nearest
+// first. See GetTrackableAircraftFrom.
+func (r *AircraftRepository) GetTrackableAircraft(ctx context.Context, minAlt, maxAlt float64) ([]adsb.Aircraft, error) {
+	aircraft, err := r.GetTrackableAircraftFrom(ctx, r.observer.Location, minAlt, maxAlt)
+	if err != nil {
+		return nil, err
+	}
+	return AircraftOf(aircraft), nil
 }
 
 // GetAircraftNear returns aircraft within a specified radius of an arbitrary center point.
@@ -365,7 +630,8 @@ func (r *AircraftRepository) GetAircraftNear(
 	// Fetch all visible aircraft
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT icao, callsign, latitude, longitude, altitude_ft,
-		        ground_speed_kts, track_deg, vertical_rate_fpm, last_seen
+		        ground_speed_kts, track_deg, vertical_rate_fpm,
+		        COALESCE(squawk, ''), COALESCE(source, ''), `+aircraftTypeColumn+`, COALESCE(registration, ''), `+aircraftIntentColumns+`, last_seen
 		 FROM aircraft
 		 WHERE is_visible = TRUE AND altitude_ft > 0
 		   AND latitude IS NOT NULL AND longitude IS NOT NULL`,
@@ -389,7 +655,8 @@ func (r *AircraftRepository) GetAircraftNear(
 			&ac.ICAO, &ac.Callsign,
 			&ac.Latitude, &ac.Longitude, &ac.Altitude,
 			&ac.GroundSpeed, &ac.Track, &ac.VerticalRate,
-			&ac.LastSeen,
+			&ac.Squawk, &ac.Source, &ac.AircraftType, &ac.Registration,
+			&ac.SelectedAltitude, &ac.SelectedHeading, &ac.Heading, &ac.LastSeen,
 		)
 		if err != nil {
 			return nil, err
@@ -399,7 +666,7 @@ func (r *AircraftRepository) GetAircraftNear(
 		acPos := coordinates.Geographic{
 			Latitude:  ac.Latitude,
 			Longitude: ac.Longitude,
-			Altitude:  ac.Altitude * coordinates.FeetToMeters,
+			Altitude:  ac.Altitude.Meters(),
 		}
 		distanceNM := coordinates.DistanceNauticalMiles(centerPos, acPos)
 
@@ -434,7 +701,8 @@ func (r *AircraftRepository) GetAircraftByICAO(ctx context.Context, icao string)
 	var ac adsb.Aircraft
 	err := r.db.QueryRowContext(ctx,
 		`SELECT icao, callsign, latitude, longitude, altitude_ft,
-		        ground_speed_kts, track_deg, vertical_rate_fpm, last_seen
+		        ground_speed_kts, track_deg, vertical_rate_fpm,
+		        COALESCE(squawk, ''), COALESCE(source, ''), `+aircraftTypeColumn+`, COALESCE(registration, ''), `+aircraftIntentColumns+`, last_seen
 		 FROM aircraft
 		 WHERE icao = $1 AND is_visible = TRUE`,
 		icao,
@@ -442,7 +710,8 @@ func (r *AircraftRepository) GetAircraftByICAO(ctx context.Context, icao string)
 		&ac.ICAO, &ac.Callsign,
 		&ac.Latitude, &ac.Longitude, &ac.Altitude,
 		&ac.GroundSpeed, &ac.Track, &ac.VerticalRate,
-		&ac.LastSeen,
+		&ac.Squawk, &ac.Source, &ac.AircraftType, &ac.Registration,
+		&ac.SelectedAltitude, &ac.SelectedHeading, &ac.Heading, &ac.LastSeen,
 	)
 
 	if err == sql.ErrNoRows {
@@ -455,6 +724,30 @@ func (r *AircraftRepository) GetAircraftByICAO(ctx context.Context, icao string)
 	return &ac, nil
 }
 
+// GetAircraftByCallsign retrieves the visible aircraft with a callsign
+// (case-insensitive). If several share it, the most recently seen is
+// returned. Returns nil if none is visible.
+func (r *AircraftRepository) GetAircraftByCallsign(ctx context.Context, callsign string) (*adsb.Aircraft, error) {
+	var icao string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT icao
+		 FROM aircraft
+		 WHERE UPPER(TRIM(callsign)) = UPPER(TRIM($1)) AND is_visible = TRUE
+		 ORDER BY last_seen DESC
+		 LIMIT 1`,
+		callsign,
+	).Scan(&icao)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetAircraftByICAO(ctx, icao)
+}
+
 // GetPositionHistory returns recent positions for an aircraft.
 // Used to calculate accurate velocities and accelerations.
 func (r *AircraftRepository) GetPositionHistory(
@@ -502,7 +795,7 @@ func (r *AircraftRepository) GetPositionHistory(
 			p.DeltaDistanceNM = deltaDistance.Float64
 		}
 		if deltaAltitude.Valid {
-			p.DeltaAltitudeFt = deltaAltitude.Float64
+			p.DeltaAltitudeFt = units.Feet(deltaAltitude.Float64)
 		}
 		if actualSpeed.Valid {
 			p.ActualSpeedKts = actualSpeed.Float64
@@ -517,18 +810,323 @@ func (r *AircraftRepository) GetPositionHistory(
 	return positions, rows.Err()
 }
 
+// GetRecentTrails returns the positions recorded since a time for several
+// aircraft at once, keyed by ICAO and ordered oldest first. Only the
+// timestamp and location fields are filled. Used to draw breadcrumb trails.
+func (r *AircraftRepository) GetRecentTrails(
+	ctx context.Context,
+	icaos []string,
+	since time.Time,
+) (map[string][]Position, error) {
+	trails := make(map[string][]Position)
+	if len(icaos) == 0 {
+		return trails, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT icao, timestamp, latitude, longitude, COALESCE(altitude_ft, 0)
+		 FROM aircraft_positions
+		 WHERE icao = ANY($1) AND timestamp >= $2
+		 ORDER BY icao, timestamp ASC`,
+		pq.Array(icaos), since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trails: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var icao string
+		var p Position
+		if err := rows.Scan(&icao, &p.Timestamp, &p.Latitude, &p.Longitude, &p.AltitudeFt); err != nil {
+			return nil, fmt.Errorf("failed to scan trail position: %w", err)
+		}
+		trails[icao] = append(trails[icao], p)
+	}
+
+	return trails, rows.Err()
+}
+
+// GetRecentTracks returns the position history since the given time of
+// several aircraft as aircraft states, oldest first, for track analysis such
+// as hold detection. Aircraft with no history are absent from the map.
+func (r *AircraftRepository) GetRecentTracks(
+	ctx context.Context,
+	icaos []string,
+	since time.Time,
+) (map[string][]adsb.Aircraft, error) {
+	tracks := make(map[string][]adsb.Aircraft)
+	if len(icaos) == 0 {
+		return tracks, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT icao, timestamp, latitude, longitude, COALESCE(altitude_ft, 0),
+		        COALESCE(ground_speed_kts, 0), COALESCE(track_deg, 0), COALESCE(vertical_rate_fpm, 0)
+		 FROM aircraft_positions
+		 WHERE icao = ANY($1) AND timestamp >= $2
+		 ORDER BY icao, timestamp ASC`,
+		pq.Array(icaos), since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ac adsb.Aircraft
+		if err := rows.Scan(&ac.ICAO, &ac.LastSeen, &ac.Latitude, &ac.Longitude, &ac.Altitude,
+			&ac.GroundSpeed, &ac.Track, &ac.VerticalRate); err != nil {
+			return nil, fmt.Errorf("failed to scan track position: %w", err)
+		}
+		tracks[ac.ICAO] = append(tracks[ac.ICAO], ac)
+	}
+
+	return tracks, rows.Err()
+}
+
+// TrackingLogEntry is one telescope command recorded in telescope_tracking_log.
+type TrackingLogEntry struct {
+	ICAO                 string
+	Timestamp            time.Time
+	Latitude             float64
+	Longitude            float64
+	AltitudeFt           units.Feet
+	RangeNM              float64
+	TelescopeAltitude    float64
+	TelescopeAzimuth     float64
+	MountType            string
+	CommandSent          bool
+	CommandSuccess       bool
+	ErrorMessage         string
+	Predicted            bool
+	PredictionLatency    float64
+	PredictionConfidence float64
+	PredictionMode       tracking.PredictionMode
+}
+
+// PointingSample returns the commanded position for accuracy analysis.
+func (e TrackingLogEntry) PointingSample() tracking.PointingSample {
+	return tracking.PointingSample{
+		Time:       e.Timestamp,
+		Commanded:  coordinates.HorizontalCoordinates{Altitude: e.TelescopeAltitude, Azimuth: e.TelescopeAzimuth},
+		DataAge:    e.PredictionLatency,
+		Mode:       e.PredictionMode,
+		Confidence: e.PredictionConfidence,
+	}
+}
+
+// LogTrackingCommand records a telescope command for later accuracy analysis.
+func (r *AircraftRepository) LogTrackingCommand(ctx context.Context, entry TrackingLogEntry) error {
+	var errorMessage sql.NullString
+	if entry.ErrorMessage != "" {
+		errorMessage = sql.NullString{String: entry.ErrorMessage, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO telescope_tracking_log (
+			icao, timestamp, aircraft_latitude, aircraft_longitude,
+			aircraft_altitude_ft, aircraft_range_nm,
+			telescope_altitude_deg, telescope_azimuth_deg, mount_type,
+			command_sent, command_success, error_message,
+			predicted_position, prediction_latency_seconds, prediction_confidence,
+			prediction_mode
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''))`,
+		entry.ICAO, entry.Timestamp, entry.Latitude, entry.Longitude,
+		entry.AltitudeFt, entry.RangeNM,
+		entry.TelescopeAltitude, entry.TelescopeAzimuth, entry.MountType,
+		entry.CommandSent, entry.CommandSuccess, errorMessage,
+		entry.Predicted, entry.PredictionLatency, entry.PredictionConfidence,
+		string(entry.PredictionMode),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log tracking command: %w", err)
+	}
+	return nil
+}
+
+// GetTrackingLog returns the commands logged for an aircraft in [from, to],
+// oldest first.
+func (r *AircraftRepository) GetTrackingLog(ctx context.Context, icao string, from, to time.Time) ([]TrackingLogEntry, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT icao, timestamp, aircraft_latitude, aircraft_longitude,
+		        COALESCE(aircraft_altitude_ft, 0), COALESCE(aircraft_range_nm, 0),
+		        telescope_altitude_deg, telescope_azimuth_deg, mount_type,
+		        COALESCE(command_sent, FALSE), COALESCE(command_success, FALSE), COALESCE(error_message, ''),
+		        COALESCE(predicted_position, FALSE), COALESCE(prediction_latency_seconds, 0),
+		        COALESCE(prediction_confidence, 0), COALESCE(prediction_mode, '')
+		 FROM telescope_tracking_log
+		 WHERE icao = $1 AND timestamp >= $2 AND timestamp <= $3
+		 ORDER BY timestamp ASC`,
+		icao, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracking log: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTrackingLog(rows)
+}
+
+// GetRecentTrackingCommands returns the commands sent to the telescope since
+// the given time for any aircraft, oldest first.
+func (r *AircraftRepository) GetRecentTrackingCommands(ctx context.Context, since time.Time) ([]TrackingLogEntry, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT icao, timestamp, aircraft_latitude, aircraft_longitude,
+		        COALESCE(aircraft_altitude_ft, 0), COALESCE(aircraft_range_nm, 0),
+		        telescope_altitude_deg, telescope_azimuth_deg, mount_type,
+		        COALESCE(command_sent, FALSE), COALESCE(command_success, FALSE), COALESCE(error_message, ''),
+		        COALESCE(predicted_position, FALSE), COALESCE(prediction_latency_seconds, 0),
+		        COALESCE(prediction_confidence, 0), COALESCE(prediction_mode, '')
+		 FROM telescope_tracking_log
+		 WHERE timestamp >= $1 AND command_sent
+		 ORDER BY timestamp ASC`,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracking log: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTrackingLog(rows)
+}
+
+// scanTrackingLog reads telescope_tracking_log rows selected in
+// TrackingLogEntry field order.
+func scanTrackingLog(rows *sql.Rows) ([]TrackingLogEntry, error) {
+	var entries []TrackingLogEntry
+	for rows.Next() {
+		var e TrackingLogEntry
+		if err := rows.Scan(&e.ICAO, &e.Timestamp, &e.Latitude, &e.Longitude,
+			&e.AltitudeFt, &e.RangeNM,
+			&e.TelescopeAltitude, &e.TelescopeAzimuth, &e.MountType,
+			&e.CommandSent, &e.CommandSuccess, &e.ErrorMessage,
+			&e.Predicted, &e.PredictionLatency,
+			&e.PredictionConfidence, &e.PredictionMode); err != nil {
+			return nil, fmt.Errorf("failed to scan tracking log entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// ImportPositions backfills position history for one aircraft from an
+// archive (e.g. ADS-B Exchange trace files). Positions must be in time order
+// and use LastSeen as their timestamp. Deltas and observer-relative values
+// are calculated as for live updates. Positions already stored for the same
+// timestamp are skipped, so importing a file twice is harmless. The aircraft
+// row is created if missing (not visible) but otherwise left alone, so live
+// state isn't overwritten with old data. Returns the number of rows inserted.
+func (r *AircraftRepository) ImportPositions(ctx context.Context, positions []adsb.Aircraft, regionName string) (int, error) {
+	if len(positions) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	first, last := positions[0], positions[len(positions)-1]
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO aircraft (
+			icao, callsign, latitude, longitude, altitude_ft,
+			ground_speed_kts, track_deg, vertical_rate_fpm,
+			first_seen, last_seen, last_updated, position_count,
+			collection_region, is_visible, squawk, source
+		) VALUES (
+			$1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, NOW(), $11,
+			$12, FALSE, NULLIF($13, ''), NULLIF($14, '')
+		)
+		ON CONFLICT (icao) DO NOTHING`,
+		last.ICAO, last.Callsign, last.Latitude, last.Longitude, last.Altitude,
+		last.GroundSpeed, last.Track, last.VerticalRate,
+		first.LastSeen, last.LastSeen, len(positions),
+		regionName, last.Squawk, last.Source,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert aircraft: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO aircraft_positions (
+			icao, timestamp, latitude, longitude, altitude_ft,
+			ground_speed_kts, track_deg, vertical_rate_fpm,
+			delta_time_seconds, delta_distance_nm, delta_altitude_ft, delta_track_deg,
+			actual_speed_kts, actual_vertical_rate_fpm,
+			range_nm, altitude_angle_deg, azimuth_deg
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		WHERE NOT EXISTS (
+			SELECT 1 FROM aircraft_positions WHERE icao = $1 AND timestamp = $2
+		)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare position insert: %w", err)
+	}
+	defer stmt.Close()
+
+	inserted := 0
+	var prevPos *aircraftPosition
+	for _, ac := range positions {
+		if prevPos != nil && positionsEqual(ac, *prevPos) {
+			continue
+		}
+
+		acPos := coordinates.Geographic{
+			Latitude:  ac.Latitude,
+			Longitude: ac.Longitude,
+			Altitude:  ac.Altitude.Meters(),
+		}
+		rangeNM := coordinates.DistanceNauticalMiles(r.observer.Location, acPos)
+		horiz := coordinates.GeographicToHorizontal(acPos, r.observer, ac.LastSeen)
+		d := calculateDeltas(ac, ac.LastSeen, prevPos)
+
+		res, err := stmt.ExecContext(ctx,
+			ac.ICAO, ac.LastSeen,
+			ac.Latitude, ac.Longitude, ac.Altitude,
+			ac.GroundSpeed, ac.Track, ac.VerticalRate,
+			d.time, d.distance, d.altitude, d.track,
+			d.actualSpeed, d.actualVerticalRate,
+			rangeNM, horiz.Altitude, horiz.Azimuth,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert position: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			inserted += int(n)
+		}
+
+		prevPos = &aircraftPosition{
+			Latitude:        ac.Latitude,
+			Longitude:       ac.Longitude,
+			AltitudeFt:      ac.Altitude,
+			GroundSpeedKts:  ac.GroundSpeed,
+			TrackDeg:        ac.Track,
+			VerticalRateFpm: ac.VerticalRate,
+			Timestamp:       ac.LastSeen,
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit positions: %w", err)
+	}
+
+	return inserted, nil
+}
+
 // Position represents a historical aircraft position with deltas.
 type Position struct {
 	Timestamp             time.Time
 	Latitude              float64
 	Longitude             float64
-	AltitudeFt            float64
+	AltitudeFt            units.Feet
 	GroundSpeedKts        float64
 	TrackDeg              float64
 	VerticalRateFpm       float64
 	DeltaTimeSeconds      float64
 	DeltaDistanceNM       float64
-	DeltaAltitudeFt       float64
+	DeltaAltitudeFt       units.Feet
 	ActualSpeedKts        float64
 	ActualVerticalRateFpm float64
 	RangeNM               float64
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -176,6 +177,188 @@ func TestPositionsEqual(t *testing.T) {
 	}
 }
 
+// TestObservedAircraftRelativeTo tests use of stored geometry.
+func TestObservedAircraftRelativeTo(t *testing.T) {
+	home := coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}
+	elsewhere := coordinates.Geographic{Latitude: 36.0, Longitude: -80.0}
+
+	ac := ObservedAircraft{
+		Aircraft:   adsb.Aircraft{ICAO: "a1b2c3", Latitude: 35.5, Longitude: -80.0, Altitude: 30000},
+		RangeNM:    12.3,
+		Horizontal: coordinates.HorizontalCoordinates{Altitude: 45.6, Azimuth: 78.9},
+		observer:   &home,
+	}
+
+	t.Run("Same observer uses stored values", func(t *testing.T) {
+		horiz, rangeNM := ac.RelativeTo(home)
+		if horiz.Altitude != 45.6 || horiz.Azimuth != 78.9 || rangeNM != 12.3 {
+			t.Errorf("Expected stored geometry, got %+v, %f NM", horiz, rangeNM)
+		}
+	})
+
+	t.Run("Other observer recomputes", func(t *testing.T) {
+		// Aircraft is 30 NM due south of this observer
+		horiz, rangeNM := ac.RelativeTo(elsewhere)
+		if rangeNM < 29.5 || rangeNM > 30.5 {
+			t.Errorf("Expected ~30 NM, got %f", rangeNM)
+		}
+		if math.Abs(horiz.Azimuth-180) > 0.5 {
+			t.Errorf("Expected azimuth ~180°, got %f", horiz.Azimuth)
+		}
+	})
+
+	t.Run("Missing geometry recomputes", func(t *testing.T) {
+		missing := ac
+		missing.observer = nil
+		if _, rangeNM := missing.RelativeTo(home); rangeNM == 12.3 {
+			t.Error("Expected recomputed range without stored geometry")
+		}
+	})
+
+	if got := AircraftOf([]ObservedAircraft{ac}); len(got) != 1 || got[0].ICAO != "a1b2c3" {
+		t.Errorf("AircraftOf returned %+v", got)
+	}
+}
+
+// TestObservedAircraftFrom tests per-observer geometry and trackability.
+func TestObservedAircraftFrom(t *testing.T) {
+	north := coordinates.Geographic{Latitude: 35.5, Longitude: -80.0}
+	south := coordinates.Geographic{Latitude: 34.0, Longitude: -80.0}
+
+	// Aircraft at 30,000 ft directly above the northern site
+	ac := ObservedAircraft{
+		Aircraft: adsb.Aircraft{ICAO: "a1b2c3", Latitude: 35.5, Longitude: -80.0, Altitude: 30000},
+	}
+
+	fromNorth := ac.From(north)
+	fromSouth := ac.From(south)
+
+	if fromNorth.Horizontal.Altitude < 89 {
+		t.Errorf("Expected ~90° elevation overhead, got %f", fromNorth.Horizontal.Altitude)
+	}
+	if fromSouth.Horizontal.Altitude > 10 || fromSouth.RangeNM < 89 || fromSouth.RangeNM > 91 {
+		t.Errorf("Expected low elevation ~90 NM away, got %f° at %f NM",
+			fromSouth.Horizontal.Altitude, fromSouth.RangeNM)
+	}
+	if math.Abs(fromSouth.BearingDeg) > 0.5 && math.Abs(fromSouth.BearingDeg-360) > 0.5 {
+		t.Errorf("Expected bearing ~0° (north), got %f", fromSouth.BearingDeg)
+	}
+
+	tests := []struct {
+		name     string
+		ac       ObservedAircraft
+		expected bool
+	}{
+		{"Within limits", fromSouth, true},
+		{"Above max altitude", fromNorth, false},
+		{"On the ground", ObservedAircraft{Horizontal: coordinates.HorizontalCoordinates{Altitude: 30}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ac.IsTrackable(0, 85); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestObservedFrom tests that geometry stored from another observer, such
+// as a collector configured elsewhere, is recomputed and re-sorted.
+func TestObservedFrom(t *testing.T) {
+	collector := coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}
+	reader := coordinates.Geographic{Latitude: 36.0, Longitude: -80.0}
+
+	// Nearest the collector first, as stored
+	stored := func() []ObservedAircraft {
+		return []ObservedAircraft{
+			ObservedAircraft{
+				Aircraft:       adsb.Aircraft{ICAO: "south", Latitude: 35.1, Longitude: -80.0, Altitude: 30000},
+				RangeNM:        6,
+				Horizontal:     coordinates.HorizontalCoordinates{Altitude: 40, Azimuth: 0},
+				observer:       &collector,
+				terrainBlocked: true,
+			}.From(collector),
+			ObservedAircraft{
+				Aircraft: adsb.Aircraft{ICAO: "north", Latitude: 35.9, Longitude: -80.0, Altitude: 30000},
+			}.From(collector),
+		}
+	}
+
+	t.Run("Same observer keeps stored values", func(t *testing.T) {
+		aircraft := observedFrom(stored(), collector)
+		if aircraft[0].ICAO != "south" || aircraft[0].RangeNM != 6 || !aircraft[0].terrainBlocked {
+			t.Errorf("Expected stored geometry and terrain flag, got %+v", aircraft[0])
+		}
+	})
+
+	t.Run("Other observer recomputes", func(t *testing.T) {
+		aircraft := observedFrom(stored(), reader)
+		if aircraft[0].ICAO != "north" {
+			t.Errorf("Expected the aircraft nearest the reader first, got %s", aircraft[0].ICAO)
+		}
+		for _, ac := range aircraft {
+			if *ac.observer != reader {
+				t.Errorf("Expected %s relative to the reader, got %+v", ac.ICAO, *ac.observer)
+			}
+			if ac.terrainBlocked {
+				t.Errorf("Expected the collector's terrain flag dropped for %s", ac.ICAO)
+			}
+		}
+		if south := aircraft[1]; south.RangeNM < 53 || south.RangeNM > 55 || math.Abs(south.Horizontal.Azimuth-180) > 0.5 {
+			t.Errorf("Expected south ~54 NM at 180°, got %f NM at %f°", south.RangeNM, south.Horizontal.Azimuth)
+		}
+	})
+}
+
+// TestCalculateDeltas tests delta and derived velocity calculation.
+func TestCalculateDeltas(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := &aircraftPosition{
+		Latitude:   35.0,
+		Longitude:  -80.0,
+		AltitudeFt: 10000.0,
+		TrackDeg:   350.0,
+		Timestamp:  base,
+	}
+
+	t.Run("No previous position", func(t *testing.T) {
+		d := calculateDeltas(adsb.Aircraft{Latitude: 35.0, Longitude: -80.0}, base, nil)
+		if d.time.Valid || d.distance.Valid || d.actualSpeed.Valid {
+			t.Error("Expected NULL deltas without a previous position")
+		}
+	})
+
+	t.Run("Same timestamp", func(t *testing.T) {
+		d := calculateDeltas(adsb.Aircraft{Latitude: 35.1, Longitude: -80.0}, base, prev)
+		if d.time.Valid {
+			t.Error("Expected NULL deltas for a zero time delta")
+		}
+	})
+
+	t.Run("One minute later", func(t *testing.T) {
+		// 0.1 degrees of latitude is 6 NM; climbing 1000 ft; track wraps through north
+		current := adsb.Aircraft{Latitude: 35.1, Longitude: -80.0, Altitude: 11000.0, Track: 10.0}
+		d := calculateDeltas(current, base.Add(time.Minute), prev)
+
+		if d.time.Float64 != 60 {
+			t.Errorf("Expected 60s delta, got %f", d.time.Float64)
+		}
+		if d.distance.Float64 < 5.9 || d.distance.Float64 > 6.1 {
+			t.Errorf("Expected ~6 NM, got %f", d.distance.Float64)
+		}
+		if d.actualSpeed.Float64 < 354 || d.actualSpeed.Float64 > 366 {
+			t.Errorf("Expected ~360 kts, got %f", d.actualSpeed.Float64)
+		}
+		if d.actualVerticalRate.Float64 != 1000 {
+			t.Errorf("Expected 1000 fpm, got %f", d.actualVerticalRate.Float64)
+		}
+		if d.track.Float64 != 20 {
+			t.Errorf("Expected +20° track change, got %f", d.track.Float64)
+		}
+	})
+}
+
 // TestCalculateAverageVelocity tests velocity averaging from position history.
 func TestCalculateAverageVelocity(t *testing.T) {
 	t.Run("Empty history", func(t *testing.T) {
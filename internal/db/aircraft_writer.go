@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// Write back-off after failed aircraft writes, doubling per consecutive
+// failure up to the maximum
+const (
+	writeBackoffInitial = time.Second
+	writeBackoffMax     = 30 * time.Second
+)
+
+// aircraftUpserter stores aircraft; implemented by AircraftRepository.
+type aircraftUpserter interface {
+	UpsertAircraftSeenIn(ctx context.Context, aircraft adsb.Aircraft, now time.Time, regions []string) error
+}
+
+// aircraftWrite is a queued aircraft upsert.
+type aircraftWrite struct {
+	aircraft adsb.Aircraft
+	now      time.Time
+	regions  []string
+}
+
+// WriterStats reports an AircraftWriter's queue and outcomes since it
+// started.
+type WriterStats struct {
+	QueueDepth    int   // Writes waiting for a worker
+	QueueCapacity int   // Writes that may wait before new ones are dropped
+	Written       int64 // Writes stored
+	Failed        int64 // Writes that returned an error
+	Dropped       int64 // Writes refused on a full queue or after Close
+}
+
+// AircraftWriter upserts aircraft on a bounded pool of workers, so the
+// collector can queue a cycle's writes and get back to polling its sources
+// while the database catches up.
+//
+// Each aircraft always goes to the same worker, so its positions are written
+// in the order they were queued. A worker whose write fails backs off before
+// its next write, doubling the delay while failures continue; its queue
+// fills meanwhile and further writes are dropped rather than blocking the
+// caller. The next cycle brings fresh positions anyway.
+type AircraftWriter struct {
+	repo     aircraftUpserter
+	queues   []chan aircraftWrite
+	capacity int
+	wg       sync.WaitGroup
+
+	mu     sync.RWMutex // Held to write to queues, exclusively to close them
+	closed bool
+
+	// Back-off delays, variables so tests can shorten them
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+
+	written atomic.Int64
+	failed  atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewAircraftWriter creates a writer with the given number of workers
+// sharing a queue of queueSize writes, storing aircraft with repo. Call
+// Start to begin writing.
+func NewAircraftWriter(repo *AircraftRepository, workers, queueSize int) *AircraftWriter {
+	return newAircraftWriter(repo, workers, queueSize)
+}
+
+func newAircraftWriter(repo aircraftUpserter, workers, queueSize int) *AircraftWriter {
+	workers = max(workers, 1)
+	perWorker := max(queueSize/workers, 1)
+
+	w := &AircraftWriter{
+		repo:           repo,
+		queues:         make([]chan aircraftWrite, workers),
+		capacity:       perWorker * workers,
+		backoffInitial: writeBackoffInitial,
+		backoffMax:     writeBackoffMax,
+	}
+	for i := range w.queues {
+		w.queues[i] = make(chan aircraftWrite, perWorker)
+	}
+	return w
+}
+
+// Start runs the workers until Close is called. ctx bounds the writes
+// themselves; cancelling it fails writes still queued.
+func (w *AircraftWriter) Start(ctx context.Context) {
+	for _, queue := range w.queues {
+		w.wg.Add(1)
+		go w.work(ctx, queue)
+	}
+}
+
+// Enqueue queues an aircraft write (see AircraftRepository.UpsertAircraftSeenIn)
+// without waiting for it. Returns false if the aircraft's worker queue is
+// full, or the writer closed, and the write was dropped.
+func (w *AircraftWriter) Enqueue(aircraft adsb.Aircraft, now time.Time, regions []string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.dropped.Add(1)
+		return false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(aircraft.ICAO))
+	queue := w.queues[h.Sum32()%uint32(len(w.queues))]
+
+	select {
+	case queue <- aircraftWrite{aircraft: aircraft, now: now, regions: regions}:
+		return true
+	default:
+		w.dropped.Add(1)
+		return false
+	}
+}
+
+// Close stops accepting writes and waits for the queued ones to finish.
+func (w *AircraftWriter) Close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		for _, queue := range w.queues {
+			close(queue)
+		}
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+}
+
+// Stats returns the current queue depth and write outcomes.
+func (w *AircraftWriter) Stats() WriterStats {
+	depth := 0
+	for _, queue := range w.queues {
+		depth += len(queue)
+	}
+	return WriterStats{
+		QueueDepth:    depth,
+		QueueCapacity: w.capacity,
+		Written:       w.written.Load(),
+		Failed:        w.failed.Load(),
+		Dropped:       w.dropped.Load(),
+	}
+}
+
+// work writes one worker's queue until it is closed.
+func (w *AircraftWriter) work(ctx context.Context, queue <-chan aircraftWrite) {
+	defer w.wg.Done()
+
+	failures := 0
+	for write := range queue {
+		err := w.repo.UpsertAircraftSeenIn(ctx, write.aircraft, write.now, write.regions)
+		if err == nil {
+			w.written.Add(1)
+			failures = 0
+			continue
+		}
+
+		w.failed.Add(1)
+		failures++
+		log.Printf("Error storing aircraft %s: %v", write.aircraft.ICAO, err)
+
+		// Give a struggling database room to recover
+		backoff := w.backoffInitial << min(failures-1, 10)
+		if backoff > w.backoffMax {
+			backoff = w.backoffMax
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
+}
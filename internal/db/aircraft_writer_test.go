@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// fakeUpserter records writes, failing while fail is set and blocking
+// while gate is non-nil.
+type fakeUpserter struct {
+	mu     sync.Mutex
+	writes []adsb.Aircraft
+	fail   bool
+	gate   chan struct{}
+}
+
+func (f *fakeUpserter) UpsertAircraftSeenIn(ctx context.Context, aircraft adsb.Aircraft, now time.Time, regions []string) error {
+	if f.gate != nil {
+		<-f.gate
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return errors.New("database unavailable")
+	}
+	f.writes = append(f.writes, aircraft)
+	return nil
+}
+
+// TestAircraftWriterOrder tests that an aircraft's writes are stored in the
+// order they were queued.
+func TestAircraftWriterOrder(t *testing.T) {
+	repo := &fakeUpserter{}
+	w := newAircraftWriter(repo, 4, 100)
+	w.Start(context.Background())
+
+	for i := 0; i < 20; i++ {
+		w.Enqueue(adsb.Aircraft{ICAO: "abc123", Altitude: units.Feet(1000 * i)}, time.Now(), []string{"home"})
+	}
+	w.Close()
+
+	if len(repo.writes) != 20 {
+		t.Fatalf("Expected 20 writes, got %d", len(repo.writes))
+	}
+	for i, ac := range repo.writes {
+		if ac.Altitude != units.Feet(1000*i) {
+			t.Fatalf("Write %d out of order: altitude %v", i, ac.Altitude)
+		}
+	}
+	if stats := w.Stats(); stats.Written != 20 || stats.QueueDepth != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestAircraftWriterDropsWhenFull tests that writes are dropped rather than
+// blocking while the database is slow.
+func TestAircraftWriterDropsWhenFull(t *testing.T) {
+	repo := &fakeUpserter{gate: make(chan struct{})}
+	w := newAircraftWriter(repo, 1, 2)
+	w.Start(context.Background())
+
+	// One write is held by the worker, two wait in the queue
+	accepted := 0
+	for i := 0; i < 5; i++ {
+		if w.Enqueue(adsb.Aircraft{ICAO: "abc123"}, time.Now(), nil) {
+			accepted++
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if accepted != 3 {
+		t.Errorf("Expected 3 writes accepted, got %d", accepted)
+	}
+	stats := w.Stats()
+	if stats.QueueDepth != 2 || stats.QueueCapacity != 2 || stats.Dropped != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	close(repo.gate)
+	w.Close()
+	if stats := w.Stats(); stats.Written != 3 {
+		t.Errorf("Expected 3 writes stored, got %d", stats.Written)
+	}
+}
+
+// TestAircraftWriterBacksOff tests that a worker pauses after failed writes.
+func TestAircraftWriterBacksOff(t *testing.T) {
+	repo := &fakeUpserter{fail: true}
+	w := newAircraftWriter(repo, 1, 10)
+	w.backoffInitial = 50 * time.Millisecond
+	w.Start(context.Background())
+
+	w.Enqueue(adsb.Aircraft{ICAO: "abc123"}, time.Now(), nil)
+	w.Enqueue(adsb.Aircraft{ICAO: "abc123"}, time.Now(), nil)
+	time.Sleep(20 * time.Millisecond)
+
+	// The second write waits out the first one's back-off
+	if stats := w.Stats(); stats.Failed != 1 || stats.QueueDepth != 1 {
+		t.Errorf("Expected 1 failed and 1 queued write during back-off, got %+v", stats)
+	}
+
+	w.Close()
+	if stats := w.Stats(); stats.Failed != 2 {
+		t.Errorf("Expected 2 failed writes, got %d", stats.Failed)
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/flightaware"
+)
+
+// APIUsageRepository records requests made to a paid API, implementing
+// flightaware.UsageStore.
+type APIUsageRepository struct {
+	db       *DB
+	provider string
+}
+
+// NewAPIUsageRepository creates a usage repository for a provider
+// (e.g., "flightaware").
+func NewAPIUsageRepository(db *DB, provider string) *APIUsageRepository {
+	return &APIUsageRepository{db: db, provider: provider}
+}
+
+// Usage returns the requests made so far in now's month and day (UTC).
+func (r *APIUsageRepository) Usage(ctx context.Context, now time.Time) (flightaware.Usage, flightaware.Usage, error) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var month, today flightaware.Usage
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(calls), 0), COALESCE(SUM(cost), 0),
+		        COALESCE(SUM(calls) FILTER (WHERE day = $3), 0),
+		        COALESCE(SUM(cost) FILTER (WHERE day = $3), 0)
+		 FROM api_usage
+		 WHERE provider = $1 AND day >= $2 AND day <= $3`,
+		r.provider, monthStart, day,
+	).Scan(&month.Calls, &month.Cost, &today.Calls, &today.Cost)
+	if err != nil {
+		return month, today, fmt.Errorf("failed to get API usage: %w", err)
+	}
+
+	return month, today, nil
+}
+
+// AddUsage records requests made on now's day (UTC).
+func (r *APIUsageRepository) AddUsage(ctx context.Context, now time.Time, calls int, cost float64) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO api_usage (provider, day, calls, cost, updated_at)
+		 VALUES ($1, $2, $3, $4, NOW())
+		 ON CONFLICT (provider, day) DO UPDATE SET
+			calls = api_usage.calls + EXCLUDED.calls,
+			cost = api_usage.cost + EXCLUDED.cost,
+			updated_at = NOW()`,
+		r.provider, now.UTC().Truncate(24*time.Hour), calls, cost,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record API usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// ErrorCalibration is a calibration of prediction confidence against the
+// errors observed in the passes that ended in [From, To).
+type ErrorCalibration struct {
+	ID        int64     `json:"id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Passes    int       `json:"passes"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	tracking.ErrorCalibration
+}
+
+// CalibrationRepository stores prediction error calibrations.
+type CalibrationRepository struct {
+	db *DB
+}
+
+// NewCalibrationRepository creates a new calibration repository.
+func NewCalibrationRepository(db *DB) *CalibrationRepository {
+	return &CalibrationRepository{db: db}
+}
+
+// Save stores a calibration and sets its ID and creation time.
+func (r *CalibrationRepository) Save(ctx context.Context, c *ErrorCalibration) error {
+	bins, err := json.Marshal(c.Bins)
+	if err != nil {
+		return fmt.Errorf("failed to encode calibration bins: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO error_calibrations (period_from, period_to, passes, samples, bins)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at`,
+		c.From, c.To, c.Passes, c.Samples, bins,
+	).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save calibration: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent calibration, or nil if there is none.
+func (r *CalibrationRepository) Latest(ctx context.Context) (*ErrorCalibration, error) {
+	var c ErrorCalibration
+	var bins []byte
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, period_from, period_to, passes, samples, bins, created_at
+		 FROM error_calibrations
+		 ORDER BY created_at DESC
+		 LIMIT 1`,
+	).Scan(&c.ID, &c.From, &c.To, &c.Passes, &c.Samples, &bins, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calibration: %w", err)
+	}
+	if err := json.Unmarshal(bins, &c.Bins); err != nil {
+		return nil, fmt.Errorf("failed to decode calibration bins: %w", err)
+	}
+	return &c, nil
+}
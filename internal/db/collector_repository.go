@@ -0,0 +1,410 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// CollectorStatus is the collector service's latest self-reported health.
+// It is written by the collector each update cycle and read by the web server.
+type CollectorStatus struct {
+	BreakerState        string     `json:"breakerState"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	BreakerTrips        int        `json:"breakerTrips"`
+	RetriesDenied       int        `json:"retriesDenied"`
+	OpenUntil           *time.Time `json:"openUntil,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	ActiveSource        string     `json:"activeSource,omitempty"`  // ADS-B source in use
+	PrimarySource       string     `json:"primarySource,omitempty"` // Preferred source; differs from ActiveSource while failed over
+	Failovers           int        `json:"failovers"`
+	FailedOverAt        *time.Time `json:"failedOverAt,omitempty"`
+	WriteQueueDepth     int        `json:"writeQueueDepth"`    // Aircraft writes waiting to be stored
+	WriteQueueCapacity  int        `json:"writeQueueCapacity"` // Writes beyond this are dropped
+	WritesFailed        int64      `json:"writesFailed"`
+	WritesDropped       int64      `json:"writesDropped"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// FailedOver reports whether the collector is using a backup source.
+func (s *CollectorStatus) FailedOver() bool {
+	return s.ActiveSource != s.PrimarySource
+}
+
+// CollectorRepository provides methods for collector health reporting.
+type CollectorRepository struct {
+	db *DB
+}
+
+// NewCollectorRepository creates a new collector repository.
+func NewCollectorRepository(db *DB) *CollectorRepository {
+	return &CollectorRepository{db: db}
+}
+
+// SaveStatus records the collector's current status, replacing the previous one.
+func (r *CollectorRepository) SaveStatus(ctx context.Context, status CollectorStatus) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO collector_status (
+			id, breaker_state, consecutive_failures, breaker_trips,
+			retries_denied, open_until, last_error, active_source,
+			primary_source, failovers, failed_over_at, write_queue_depth,
+			write_queue_capacity, writes_failed, writes_dropped, updated_at
+		) VALUES (1, $1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''),
+			NULLIF($8, ''), $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (id) DO UPDATE SET
+			breaker_state = EXCLUDED.breaker_state,
+			consecutive_failures = EXCLUDED.consecutive_failures,
+			breaker_trips = EXCLUDED.breaker_trips,
+			retries_denied = EXCLUDED.retries_denied,
+			open_until = EXCLUDED.open_until,
+			last_error = EXCLUDED.last_error,
+			active_source = EXCLUDED.active_source,
+			primary_source = EXCLUDED.primary_source,
+			failovers = EXCLUDED.failovers,
+			failed_over_at = EXCLUDED.failed_over_at,
+			write_queue_depth = EXCLUDED.write_queue_depth,
+			write_queue_capacity = EXCLUDED.write_queue_capacity,
+			writes_failed = EXCLUDED.writes_failed,
+			writes_dropped = EXCLUDED.writes_dropped,
+			updated_at = EXCLUDED.updated_at`,
+		status.BreakerState,
+		status.ConsecutiveFailures,
+		status.BreakerTrips,
+		status.RetriesDenied,
+		status.OpenUntil,
+		status.LastError,
+		status.ActiveSource,
+		status.PrimarySource,
+		status.Failovers,
+		status.FailedOverAt,
+		status.WriteQueueDepth,
+		status.WriteQueueCapacity,
+		status.WritesFailed,
+		status.WritesDropped,
+		status.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save collector status: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatus returns the collector's latest status.
+// Returns nil if the collector has never reported.
+func (r *CollectorRepository) GetStatus(ctx context.Context) (*CollectorStatus, error) {
+	var status CollectorStatus
+	var openUntil, failedOverAt sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT breaker_state, consecutive_failures, breaker_trips, retries_denied,
+		        open_until, COALESCE(last_error, ''), COALESCE(active_source, ''),
+		        COALESCE(primary_source, ''), failovers, failed_over_at,
+		        write_queue_depth, write_queue_capacity, writes_failed, writes_dropped, updated_at
+		 FROM collector_status
+		 WHERE id = 1`,
+	).Scan(
+		&status.BreakerState,
+		&status.ConsecutiveFailures,
+		&status.BreakerTrips,
+		&status.RetriesDenied,
+		&openUntil,
+		&status.LastError,
+		&status.ActiveSource,
+		&status.PrimarySource,
+		&status.Failovers,
+		&failedOverAt,
+		&status.WriteQueueDepth,
+		&status.WriteQueueCapacity,
+		&status.WritesFailed,
+		&status.WritesDropped,
+		&status.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collector status: %w", err)
+	}
+
+	if openUntil.Valid {
+		status.OpenUntil = &openUntil.Time
+	}
+	if failedOverAt.Valid {
+		status.FailedOverAt = &failedOverAt.Time
+	}
+
+	return &status, nil
+}
+
+// CollectorStat is the result of collecting one region in one cycle.
+type CollectorStat struct {
+	CycleAt   time.Time `json:"cycleAt"`
+	Region    string    `json:"region"`
+	Fetched   int       `json:"fetched"`
+	Stored    int       `json:"stored"`
+	Errors    int       `json:"errors"`
+	LatencyMs int       `json:"latencyMs"`
+}
+
+// SaveCycleStats records the per-region results of a collection cycle.
+func (r *CollectorRepository) SaveCycleStats(ctx context.Context, stats []CollectorStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, st := range stats {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO collector_stats (cycle_at, region, fetched, stored, errors, latency_ms)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			st.CycleAt, st.Region, st.Fetched, st.Stored, st.Errors, st.LatencyMs,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert collector stats: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collector stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetCycleStats returns per-region collector statistics since the given time,
+// oldest first.
+func (r *CollectorRepository) GetCycleStats(ctx context.Context, since time.Time) ([]CollectorStat, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT cycle_at, region, fetched, stored, errors, latency_ms
+		 FROM collector_stats
+		 WHERE cycle_at >= $1
+		 ORDER BY cycle_at ASC, region ASC`,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query collector stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []CollectorStat
+	for rows.Next() {
+		var st CollectorStat
+		if err := rows.Scan(&st.CycleAt, &st.Region, &st.Fetched, &st.Stored, &st.Errors, &st.LatencyMs); err != nil {
+			return nil, fmt.Errorf("failed to scan collector stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+
+	return stats, rows.Err()
+}
+
+// TotalsByCycle sums per-region statistics into one entry per cycle.
+// Latency is summed, since regions are fetched sequentially.
+// The input must be ordered by cycle; Region is empty in the result.
+func TotalsByCycle(stats []CollectorStat) []CollectorStat {
+	var totals []CollectorStat
+	for _, st := range stats {
+		n := len(totals)
+		if n == 0 || !totals[n-1].CycleAt.Equal(st.CycleAt) {
+			totals = append(totals, CollectorStat{CycleAt: st.CycleAt})
+			n++
+		}
+		t := &totals[n-1]
+		t.Fetched += st.Fetched
+		t.Stored += st.Stored
+		t.Errors += st.Errors
+		t.LatencyMs += st.LatencyMs
+	}
+	return totals
+}
+
+// SourceStat is what one ADS-B data source reported in one cycle.
+type SourceStat struct {
+	CycleAt       time.Time `json:"cycleAt"`
+	Source        string    `json:"source"`
+	Aircraft      int       `json:"aircraft"`      // Aircraft with a position
+	Unique        int       `json:"unique"`        // Of those, aircraft no other source reported this cycle
+	PositionAgeMs int       `json:"positionAgeMs"` // Mean age of the positions when fetched
+	Errors        int       `json:"errors"`        // Failed queries (after retries)
+	LatencyMs     int       `json:"latencyMs"`     // Total time spent querying
+
+	// PositionAges is the distribution of the positions' ages when fetched
+	PositionAges adsb.LatencyHistogram `json:"positionAges"`
+}
+
+// SaveSourceStats records the per-source results of a collection cycle.
+func (r *CollectorRepository) SaveSourceStats(ctx context.Context, stats []SourceStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, st := range stats {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO source_stats (cycle_at, source, aircraft, unique_aircraft, position_age_ms, errors, latency_ms, position_age_histogram)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			st.CycleAt, st.Source, st.Aircraft, st.Unique, st.PositionAgeMs, st.Errors, st.LatencyMs, pq.Array(st.PositionAges.Counts),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert source stats: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit source stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetSourceStats returns per-source statistics since the given time, oldest
+// first.
+func (r *CollectorRepository) GetSourceStats(ctx context.Context, since time.Time) ([]SourceStat, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT cycle_at, source, aircraft, unique_aircraft, position_age_ms, errors, latency_ms, position_age_histogram
+		 FROM source_stats
+		 WHERE cycle_at >= $1
+		 ORDER BY cycle_at ASC, source ASC`,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query source stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []SourceStat
+	for rows.Next() {
+		var st SourceStat
+		if err := rows.Scan(&st.CycleAt, &st.Source, &st.Aircraft, &st.Unique, &st.PositionAgeMs, &st.Errors, &st.LatencyMs, pq.Array(&st.PositionAges.Counts)); err != nil {
+			return nil, fmt.Errorf("failed to scan source stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+
+	return stats, rows.Err()
+}
+
+// SourceSummary compares one data source over a period.
+type SourceSummary struct {
+	Source string `json:"source"`
+
+	// Cycles is how many times the source was queried, and Answered how
+	// many of those returned data (or failed no query)
+	Cycles   int `json:"cycles"`
+	Answered int `json:"answered"`
+	Errors   int `json:"errors"`
+
+	// Averages over answered cycles
+	AvgAircraft  float64 `json:"avgAircraft"`
+	AvgUnique    float64 `json:"avgUnique"`
+	MaxAircraft  int     `json:"maxAircraft"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+
+	// AvgPositionAgeMs is the mean age of all positions the source reported
+	AvgPositionAgeMs float64 `json:"avgPositionAgeMs"`
+
+	// Percentiles of the position age, from the cycles that recorded its
+	// distribution
+	PositionAgeP50Ms float64 `json:"positionAgeP50Ms"`
+	PositionAgeP90Ms float64 `json:"positionAgeP90Ms"`
+	PositionAgeP99Ms float64 `json:"positionAgeP99Ms"`
+
+	// PositionAges is the distribution the percentiles are taken from
+	PositionAges *adsb.LatencyHistogram `json:"-"`
+}
+
+// PredictionLatency returns the latency to predict the source's positions
+// ahead by: their measured median age, or adsb.DefaultLatency until enough
+// have been measured.
+func (s SourceSummary) PredictionLatency() time.Duration {
+	if s.PositionAges == nil {
+		return adsb.DefaultLatency
+	}
+	return s.PositionAges.PredictionLatency()
+}
+
+// Availability returns the fraction of queried cycles the source answered.
+func (s SourceSummary) Availability() float64 {
+	if s.Cycles == 0 {
+		return 0
+	}
+	return float64(s.Answered) / float64(s.Cycles)
+}
+
+// SummarizeSources compares the sources in stats, ordered by average
+// unique coverage and then average aircraft, best first.
+func SummarizeSources(stats []SourceStat) []SourceSummary {
+	type totals struct {
+		summary   SourceSummary
+		aircraft  int
+		unique    int
+		latencyMs int
+		ageMs     float64 // Sum of position ages
+	}
+	bySource := make(map[string]*totals)
+	var order []string
+	for _, st := range stats {
+		t := bySource[st.Source]
+		if t == nil {
+			t = &totals{summary: SourceSummary{Source: st.Source, PositionAges: adsb.NewLatencyHistogram()}}
+			bySource[st.Source] = t
+			order = append(order, st.Source)
+		}
+		t.summary.Cycles++
+		t.summary.Errors += st.Errors
+		if st.Errors > 0 && st.Aircraft == 0 {
+			continue
+		}
+		t.summary.Answered++
+		t.aircraft += st.Aircraft
+		t.unique += st.Unique
+		t.latencyMs += st.LatencyMs
+		t.ageMs += float64(st.PositionAgeMs) * float64(st.Aircraft)
+		t.summary.MaxAircraft = max(t.summary.MaxAircraft, st.Aircraft)
+		t.summary.PositionAges.Add(st.PositionAges)
+	}
+
+	summaries := make([]SourceSummary, 0, len(order))
+	for _, source := range order {
+		t := bySource[source]
+		s := t.summary
+		if s.Answered > 0 {
+			n := float64(s.Answered)
+			s.AvgAircraft = float64(t.aircraft) / n
+			s.AvgUnique = float64(t.unique) / n
+			s.AvgLatencyMs = float64(t.latencyMs) / n
+		}
+		if t.aircraft > 0 {
+			s.AvgPositionAgeMs = t.ageMs / float64(t.aircraft)
+		}
+		s.PositionAgeP50Ms = float64(s.PositionAges.Percentile(0.5).Milliseconds())
+		s.PositionAgeP90Ms = float64(s.PositionAges.Percentile(0.9).Milliseconds())
+		s.PositionAgeP99Ms = float64(s.PositionAges.Percentile(0.99).Milliseconds())
+		summaries = append(summaries, s)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].AvgUnique != summaries[j].AvgUnique {
+			return summaries[i].AvgUnique > summaries[j].AvgUnique
+		}
+		return summaries[i].AvgAircraft > summaries[j].AvgAircraft
+	})
+	return summaries
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// TestTotalsByCycle tests aggregation of per-region stats into cycle totals.
+func TestTotalsByCycle(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(15 * time.Second)
+
+	stats := []CollectorStat{
+		{CycleAt: t0, Region: "Atlanta", Fetched: 10, Stored: 9, LatencyMs: 400},
+		{CycleAt: t0, Region: "Charlotte", Fetched: 5, Stored: 5, Errors: 1, LatencyMs: 300},
+		{CycleAt: t1, Region: "Charlotte", Fetched: 6, Stored: 6, LatencyMs: 250},
+	}
+
+	totals := TotalsByCycle(stats)
+	if len(totals) != 2 {
+		t.Fatalf("Expected 2 cycles, got %d", len(totals))
+	}
+
+	first := totals[0]
+	if !first.CycleAt.Equal(t0) || first.Fetched != 15 || first.Stored != 14 || first.Errors != 1 || first.LatencyMs != 700 {
+		t.Errorf("Unexpected first cycle totals: %+v", first)
+	}
+	if totals[1].Fetched != 6 || totals[1].Region != "" {
+		t.Errorf("Unexpected second cycle totals: %+v", totals[1])
+	}
+
+	if got := TotalsByCycle(nil); len(got) != 0 {
+		t.Errorf("Expected no totals for empty input, got %d", len(got))
+	}
+}
+
+// TestSummarizeSources tests the per-source comparison.
+func TestSummarizeSources(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(15 * time.Second)
+
+	stats := []SourceStat{
+		{CycleAt: t0, Source: "airplanes.live", Aircraft: 40, Unique: 30, PositionAgeMs: 2000, LatencyMs: 800},
+		{CycleAt: t0, Source: "UAT 978", Aircraft: 12, Unique: 2, PositionAgeMs: 500, LatencyMs: 20},
+		{CycleAt: t1, Source: "airplanes.live", Errors: 2, LatencyMs: 60000},
+		{CycleAt: t1, Source: "UAT 978", Aircraft: 8, Unique: 4, PositionAgeMs: 1000, LatencyMs: 40},
+	}
+
+	summaries := SummarizeSources(stats)
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 sources, got %d", len(summaries))
+	}
+
+	online := summaries[0]
+	if online.Source != "airplanes.live" {
+		t.Fatalf("Expected the source with the most unique coverage first, got %s", online.Source)
+	}
+	if online.Cycles != 2 || online.Answered != 1 || online.Errors != 2 || online.Availability() != 0.5 {
+		t.Errorf("Unexpected availability: %+v", online)
+	}
+	// The failed cycle doesn't count towards the averages
+	if online.AvgAircraft != 40 || online.AvgUnique != 30 || online.AvgLatencyMs != 800 {
+		t.Errorf("Unexpected averages: %+v", online)
+	}
+
+	uat := summaries[1]
+	if uat.AvgAircraft != 10 || uat.AvgUnique != 3 || uat.MaxAircraft != 12 {
+		t.Errorf("Unexpected UAT averages: %+v", uat)
+	}
+	// Position age is weighted by aircraft: (12*500 + 8*1000) / 20
+	if uat.AvgPositionAgeMs != 700 {
+		t.Errorf("Expected mean position age 700ms, got %.0f", uat.AvgPositionAgeMs)
+	}
+
+	if online.PredictionLatency() != adsb.DefaultLatency {
+		t.Errorf("Expected the default prediction latency without a measured distribution, got %v", online.PredictionLatency())
+	}
+
+	// Distributions of both cycles: 10 positions at 0.5-0.75s, 10 at 1-1.25s
+	ages := adsb.NewLatencyHistogram()
+	for i := 0; i < 5; i++ {
+		ages.Record(600 * time.Millisecond)
+		ages.Record(1100 * time.Millisecond)
+	}
+	stats[1].PositionAges, stats[3].PositionAges = *ages, *ages
+	uat = SummarizeSources(stats)[1]
+	if uat.PositionAgeP50Ms != 750 || uat.PositionAgeP90Ms != 1200 {
+		t.Errorf("Expected position age p50 750ms and p90 1200ms, got %.0f and %.0f", uat.PositionAgeP50Ms, uat.PositionAgeP90Ms)
+	}
+	if uat.PredictionLatency() != 750*time.Millisecond {
+		t.Errorf("Expected the measured median as prediction latency, got %v", uat.PredictionLatency())
+	}
+
+	if got := SummarizeSources(nil); len(got) != 0 {
+		t.Errorf("Expected no summaries for empty input, got %d", len(got))
+	}
+}
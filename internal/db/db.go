@@ -3,23 +3,31 @@ package db
 import (
 	"context"
 	"database/sql"
-	"embed"
 	"fmt"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
 	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/flightaware"
 )
 
-//go:embed schema.sql
-var schemaSQL embed.FS
-
 // DB wraps a database connection with helper methods.
 type DB struct {
 	*sql.DB
 	config config.DatabaseConfig
+
+	// The database size reported by CheckHealth, cached for sizeCacheTTL
+	sizeMu    sync.Mutex
+	sizeBytes int64
+	sizeAt    time.Time
 }
 
+// sizeCacheTTL is how long CheckHealth reuses the database size; measuring
+// it walks every relation's files, too slow for each status poll
+const sizeCacheTTL = time.Minute
+
 // Connect establishes a connection to the PostgreSQL database.
 func Connect(cfg config.DatabaseConfig) (*DB, error) {
 	// Build connection string
@@ -61,22 +69,14 @@ func Connect(cfg config.DatabaseConfig) (*DB, error) {
 	return db, nil
 }
 
-// InitSchema creates or updates the database schema.
-// This should be called once at application startup.
-func (db *DB) InitSchema(ctx context.Context) error {
-	// Read schema SQL
-	schemaBytes, err := schemaSQL.ReadFile("schema.sql")
-	if err != nil {
-		return fmt.Errorf("failed to read schema file: %w", err)
-	}
+// OceanicMaxAge is how long an ADS-C/satellite position remains visible.
+// These reports are sparse, so the normal staleness cutoff would hide them
+// almost immediately.
+const OceanicMaxAge = 45 * time.Minute
 
-	// Execute schema
-	if _, err := db.ExecContext(ctx, string(schemaBytes)); err != nil {
-		return fmt.Errorf("failed to execute schema: %w", err)
-	}
-
-	return nil
-}
+// FlightAwareMaxAge is how long a position ingested from FlightAware remains
+// visible. They are polled every few minutes to fill ADS-B coverage gaps.
+const FlightAwareMaxAge = 10 * time.Minute
 
 // CleanupOldData removes stale aircraft and old position history.
 // Should be called periodically to prevent unbounded growth.
@@ -84,9 +84,16 @@ func (db *DB) CleanupOldData(ctx context.Context, maxAge time.Duration) error {
 	cutoff := time.Now().UTC().Add(-maxAge)
 
 	// Mark aircraft as not visible if not seen recently
+	// Oceanic ADS-C reports arrive every 10-30 minutes and FlightAware
+	// positions every few minutes, so they get longer windows
+	oceanicCutoff := time.Now().UTC().Add(-OceanicMaxAge)
+	flightAwareCutoff := time.Now().UTC().Add(-FlightAwareMaxAge)
 	_, err := db.ExecContext(ctx,
-		`UPDATE aircraft SET is_visible = FALSE WHERE last_seen < $1`,
-		cutoff,
+		`UPDATE aircraft SET is_visible = FALSE
+		 WHERE last_seen < $1
+		   AND (source IS DISTINCT FROM $2 OR last_seen < $3)
+		   AND (source IS DISTINCT FROM $4 OR last_seen < $5)`,
+		cutoff, adsb.SourceADSC, oceanicCutoff, flightaware.Source, flightAwareCutoff,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to mark stale aircraft: %w", err)
@@ -102,6 +109,23 @@ func (db *DB) CleanupOldData(ctx context.Context, maxAge time.Duration) error {
 		return fmt.Errorf("failed to delete old positions: %w", err)
 	}
 
+	// Delete old collector statistics (keep last 7 days)
+	statsCutoff := time.Now().UTC().Add(-7 * 24 * time.Hour)
+	_, err = db.ExecContext(ctx,
+		`DELETE FROM collector_stats WHERE cycle_at < $1`,
+		statsCutoff,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete old collector stats: %w", err)
+	}
+	_, err = db.ExecContext(ctx,
+		`DELETE FROM source_stats WHERE cycle_at < $1`,
+		statsCutoff,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete old source stats: %w", err)
+	}
+
 	// Delete aircraft not seen in over 1 hour
 	deleteCutoff := time.Now().UTC().Add(-1 * time.Hour)
 	_, err = db.ExecContext(ctx,
@@ -161,3 +185,66 @@ func (db *DB) GetStats(ctx context.Context) (map[string]interface{}, error) {
 
 	return stats, nil
 }
+
+// Health is a snapshot of database connectivity and data freshness.
+type Health struct {
+	// PingLatency is the round-trip time of a connectivity check
+	PingLatency time.Duration
+
+	// SizeBytes is the on-disk size of the database
+	SizeBytes int64
+
+	// LatestAircraftSeen is the most recent aircraft update (zero if none)
+	LatestAircraftSeen time.Time
+}
+
+// CheckHealth pings the database and reports its size (measured at most
+// once per sizeCacheTTL) and the time of the most recent aircraft update.
+func (db *DB) CheckHealth(ctx context.Context) (Health, error) {
+	var health Health
+
+	start := time.Now()
+	if err := db.PingContext(ctx); err != nil {
+		return health, fmt.Errorf("failed to ping database: %w", err)
+	}
+	health.PingLatency = time.Since(start)
+
+	size, err := db.size(ctx)
+	if err != nil {
+		return health, err
+	}
+	health.SizeBytes = size
+
+	var latest sql.NullTime
+	err = db.QueryRowContext(ctx,
+		`SELECT MAX(last_seen) FROM aircraft`,
+	).Scan(&latest)
+	if err != nil {
+		return health, fmt.Errorf("failed to get latest aircraft update: %w", err)
+	}
+	if latest.Valid {
+		health.LatestAircraftSeen = latest.Time
+	}
+
+	return health, nil
+}
+
+// size returns the on-disk size of the database, cached for sizeCacheTTL.
+func (db *DB) size(ctx context.Context) (int64, error) {
+	db.sizeMu.Lock()
+	defer db.sizeMu.Unlock()
+
+	if !db.sizeAt.IsZero() && time.Since(db.sizeAt) < sizeCacheTTL {
+		return db.sizeBytes, nil
+	}
+
+	var size int64
+	err := db.QueryRowContext(ctx,
+		`SELECT pg_database_size(current_database())`,
+	).Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	db.sizeBytes, db.sizeAt = size, time.Now()
+	return size, nil
+}
@@ -0,0 +1,252 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PairingCodeTTL is how long a pairing code can be redeemed after it is issued
+const PairingCodeTTL = 10 * time.Minute
+
+// pairingAlphabet omits characters that are easily confused when a code is
+// read off one screen and typed on another (0/O, 1/I).
+const pairingAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// pairingCodeLength is the number of characters in a pairing code
+const pairingCodeLength = 8
+
+var (
+	// ErrPairingCodeInvalid is returned when a pairing code is unknown,
+	// expired or already used
+	ErrPairingCodeInvalid = errors.New("invalid or expired pairing code")
+	// ErrDeviceNotFound is returned when a paired device cannot be found
+	ErrDeviceNotFound = errors.New("device not found")
+)
+
+// PairedDevice represents a phone or tablet linked to a user account
+type PairedDevice struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"userId"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	UserAgent string     `json:"userAgent,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	LastSeen  *time.Time `json:"lastSeen,omitempty"`
+}
+
+// DeviceRepository provides methods for device pairing
+type DeviceRepository struct {
+	db *DB
+}
+
+// NewDeviceRepository creates a new device repository
+func NewDeviceRepository(db *DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+// generatePairingCode returns a random pairing code
+func generatePairingCode() (string, error) {
+	buf := make([]byte, pairingCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	// The alphabet has 32 characters, so the low five bits are uniform
+	code := make([]byte, pairingCodeLength)
+	for i, b := range buf {
+		code[i] = pairingAlphabet[b&0x1F]
+	}
+	return string(code), nil
+}
+
+// NormalizePairingCode converts a code as typed by a user ("abcd-2345",
+// "ABCD 2345") to its canonical form ("ABCD2345").
+func NormalizePairingCode(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FormatPairingCode splits a code into two groups for display ("ABCD-2345").
+func FormatPairingCode(code string) string {
+	if len(code) != pairingCodeLength {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// hashPairingCode returns the stored form of a pairing code
+func hashPairingCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizePairingCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreatePairingCode issues a one-time code that pairs a device named
+// deviceName with the user's account, limited to role. Only a hash of the
+// code is stored.
+func (r *DeviceRepository) CreatePairingCode(ctx context.Context, userID int, deviceName, role string) (string, time.Time, error) {
+	code, err := generatePairingCode()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate pairing code: %w", err)
+	}
+
+	// Clear out codes that can no longer be redeemed
+	_, err = r.db.ExecContext(ctx, `DELETE FROM pairing_codes WHERE expires_at < NOW() - INTERVAL '1 day'`)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to delete expired pairing codes: %w", err)
+	}
+
+	expiresAt := time.Now().Add(PairingCodeTTL)
+	query := `
+		INSERT INTO pairing_codes (code_hash, user_id, device_name, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err = r.db.ExecContext(ctx, query, hashPairingCode(code), userID, deviceName, role, expiresAt)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create pairing code: %w", err)
+	}
+
+	return code, expiresAt, nil
+}
+
+// RedeemPairingCode uses a pairing code to register a new device. The code
+// can only be redeemed once. If name is not empty it replaces the device
+// name chosen when the code was issued.
+func (r *DeviceRepository) RedeemPairingCode(ctx context.Context, code, name, userAgent string) (*PairedDevice, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	hash := hashPairingCode(code)
+	d := PairedDevice{UserAgent: userAgent}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE pairing_codes
+		SET redeemed_at = NOW()
+		WHERE code_hash = $1 AND redeemed_at IS NULL AND expires_at > NOW()
+		RETURNING user_id, device_name, role
+	`, hash).Scan(&d.UserID, &d.Name, &d.Role)
+	if err == sql.ErrNoRows {
+		return nil, ErrPairingCodeInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem pairing code: %w", err)
+	}
+	if name != "" {
+		d.Name = name
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO paired_devices (user_id, name, role, user_agent)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, d.UserID, d.Name, d.Role, userAgent).Scan(&d.ID, &d.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE pairing_codes SET device_id = $1 WHERE code_hash = $2`, d.ID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link pairing code: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit device pairing: %w", err)
+	}
+
+	return &d, nil
+}
+
+// ListDevices returns the devices paired with a user's account that have
+// not been revoked
+func (r *DeviceRepository) ListDevices(ctx context.Context, userID int) ([]PairedDevice, error) {
+	query := `
+		SELECT id, user_id, name, role, COALESCE(user_agent, ''), created_at, last_seen
+		FROM paired_devices
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := []PairedDevice{}
+	for rows.Next() {
+		var d PairedDevice
+		var lastSeen sql.NullTime
+		err := rows.Scan(&d.ID, &d.UserID, &d.Name, &d.Role, &d.UserAgent, &d.CreatedAt, &lastSeen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		if lastSeen.Valid {
+			d.LastSeen = &lastSeen.Time
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, rows.Err()
+}
+
+// RevokeDevice unpairs a device, invalidating its token
+func (r *DeviceRepository) RevokeDevice(ctx context.Context, deviceID, userID int) error {
+	query := `
+		UPDATE paired_devices
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, deviceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke device: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// TouchDevice records that a device has been used and returns the role it
+// was paired with. It returns ErrDeviceNotFound if the device has been
+// revoked or its owner deactivated.
+func (r *DeviceRepository) TouchDevice(ctx context.Context, deviceID, userID int) (string, error) {
+	query := `
+		UPDATE paired_devices d
+		SET last_seen = NOW()
+		FROM users u
+		WHERE d.id = $1 AND d.user_id = $2 AND d.revoked_at IS NULL
+		  AND u.id = d.user_id AND u.is_active
+		RETURNING d.role
+	`
+
+	var role string
+	err := r.db.QueryRowContext(ctx, query, deviceID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrDeviceNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to update device: %w", err)
+	}
+
+	return role, nil
+}
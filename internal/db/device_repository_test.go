@@ -0,0 +1,58 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGeneratePairingCode tests that codes use the unambiguous alphabet.
+func TestGeneratePairingCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := generatePairingCode()
+		if err != nil {
+			t.Fatalf("generatePairingCode failed: %v", err)
+		}
+		if len(code) != pairingCodeLength {
+			t.Errorf("Expected %d characters, got %q", pairingCodeLength, code)
+		}
+		for _, r := range code {
+			if !strings.ContainsRune(pairingAlphabet, r) {
+				t.Errorf("Unexpected character %q in %q", r, code)
+			}
+		}
+		if seen[code] {
+			t.Errorf("Duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+// TestNormalizePairingCode tests that typed codes match the issued code.
+func TestNormalizePairingCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{"Canonical", "ABCD2345", "ABCD2345"},
+		{"Formatted", "ABCD-2345", "ABCD2345"},
+		{"Lowercase with space", " abcd 2345 ", "ABCD2345"},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizePairingCode(tt.code); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+			if hashPairingCode(tt.code) != hashPairingCode(tt.want) {
+				t.Error("Expected equivalent codes to hash the same")
+			}
+		})
+	}
+
+	if got := FormatPairingCode("ABCD2345"); got != "ABCD-2345" {
+		t.Errorf("Expected ABCD-2345, got %q", got)
+	}
+}
@@ -0,0 +1,275 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ExportKind is the type of an exported column's values.
+type ExportKind int
+
+const (
+	ExportString ExportKind = iota // string
+	ExportFloat                    // float64
+	ExportInt                      // int64
+	ExportBool                     // bool
+	ExportTime                     // time.Time (UTC)
+)
+
+// ExportColumn describes one exported column.
+type ExportColumn struct {
+	Name string
+	Kind ExportKind
+}
+
+// ExportFilter narrows an export. Zero values mean "no filter"; filters a
+// dataset has no column for are ignored.
+type ExportFilter struct {
+	From time.Time
+	To   time.Time
+
+	ICAOs          []string
+	CallsignPrefix string
+
+	MinAltitudeFt float64
+	MaxAltitudeFt float64
+	MaxRangeNM    float64
+}
+
+// exportDataset maps a dataset name to its table and filterable columns.
+type exportDataset struct {
+	table   string
+	columns []ExportColumn
+
+	// Columns used by the filters ("" if the dataset can't be filtered that way)
+	timeColumn     string
+	icaoColumn     string
+	callsignColumn string
+	altitudeColumn string
+	rangeColumn    string
+}
+
+// exportDatasets are the datasets available to Export.
+var exportDatasets = map[string]exportDataset{
+	"positions": {
+		table: "aircraft_positions p LEFT JOIN aircraft a ON a.icao = p.icao",
+		columns: []ExportColumn{
+			{"p.icao", ExportString}, {"a.callsign", ExportString}, {"p.timestamp", ExportTime},
+			{"p.latitude", ExportFloat}, {"p.longitude", ExportFloat}, {"p.altitude_ft", ExportFloat},
+			{"p.ground_speed_kts", ExportFloat}, {"p.track_deg", ExportFloat}, {"p.vertical_rate_fpm", ExportFloat},
+			{"p.actual_speed_kts", ExportFloat}, {"p.actual_vertical_rate_fpm", ExportFloat},
+			{"p.range_nm", ExportFloat}, {"p.bearing_deg", ExportFloat},
+			{"p.altitude_angle_deg", ExportFloat}, {"p.azimuth_deg", ExportFloat},
+		},
+		timeColumn:     "p.timestamp",
+		icaoColumn:     "p.icao",
+		callsignColumn: "a.callsign",
+		altitudeColumn: "p.altitude_ft",
+		rangeColumn:    "p.range_nm",
+	},
+	"sessions": {
+		table: "tracking_sessions",
+		columns: []ExportColumn{
+			{"id", ExportInt}, {"start_time", ExportTime}, {"end_time", ExportTime},
+			{"observer_latitude", ExportFloat}, {"observer_longitude", ExportFloat},
+			{"observer_elevation_m", ExportFloat}, {"search_radius_nm", ExportFloat},
+			{"update_interval_seconds", ExportInt}, {"total_updates", ExportInt},
+			{"unique_aircraft", ExportInt}, {"notes", ExportString},
+		},
+		timeColumn: "start_time",
+	},
+	"flightplans": {
+		table: "flight_plans",
+		columns: []ExportColumn{
+			{"id", ExportInt}, {"icao", ExportString}, {"callsign", ExportString},
+			{"departure_icao", ExportString}, {"arrival_icao", ExportString}, {"route", ExportString},
+			{"filed_altitude", ExportInt}, {"aircraft_type", ExportString}, {"filed_time", ExportTime},
+			{"etd", ExportTime}, {"eta", ExportTime}, {"last_updated", ExportTime},
+		},
+		timeColumn:     "last_updated",
+		icaoColumn:     "icao",
+		callsignColumn: "callsign",
+	},
+	"tracking": {
+		table: "telescope_tracking_log",
+		columns: []ExportColumn{
+			{"icao", ExportString}, {"timestamp", ExportTime},
+			{"aircraft_latitude", ExportFloat}, {"aircraft_longitude", ExportFloat},
+			{"aircraft_altitude_ft", ExportFloat}, {"aircraft_range_nm", ExportFloat},
+			{"telescope_altitude_deg", ExportFloat}, {"telescope_azimuth_deg", ExportFloat},
+			{"mount_type", ExportString}, {"command_sent", ExportBool}, {"command_success", ExportBool},
+			{"error_message", ExportString}, {"predicted_position", ExportBool},
+			{"prediction_latency_seconds", ExportFloat}, {"prediction_confidence", ExportFloat},
+			{"prediction_mode", ExportString},
+		},
+		timeColumn:     "timestamp",
+		icaoColumn:     "icao",
+		altitudeColumn: "aircraft_altitude_ft",
+		rangeColumn:    "aircraft_range_nm",
+	},
+}
+
+// ExportDatasets returns the names of the datasets Export can dump.
+func ExportDatasets() []string {
+	names := make([]string, 0, len(exportDatasets))
+	for name := range exportDatasets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExportColumns returns the columns of a dataset, named without table
+// aliases.
+func ExportColumns(dataset string) ([]ExportColumn, error) {
+	ds, ok := exportDatasets[dataset]
+	if !ok {
+		return nil, fmt.Errorf("unknown dataset %q (expected one of %s)", dataset, strings.Join(ExportDatasets(), ", "))
+	}
+
+	columns := make([]ExportColumn, len(ds.columns))
+	for i, c := range ds.columns {
+		_, name, found := strings.Cut(c.Name, ".")
+		if !found {
+			name = c.Name
+		}
+		columns[i] = ExportColumn{Name: name, Kind: c.Kind}
+	}
+	return columns, nil
+}
+
+// exportQuery builds the SELECT for a dataset and filter.
+func exportQuery(dataset string, filter ExportFilter) (string, []interface{}, error) {
+	ds, ok := exportDatasets[dataset]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown dataset %q", dataset)
+	}
+
+	names := make([]string, len(ds.columns))
+	for i, c := range ds.columns {
+		names[i] = c.Name
+	}
+
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if ds.timeColumn != "" && !filter.From.IsZero() {
+		add(ds.timeColumn+" >= $%d", filter.From)
+	}
+	if ds.timeColumn != "" && !filter.To.IsZero() {
+		add(ds.timeColumn+" < $%d", filter.To)
+	}
+	if ds.icaoColumn != "" && len(filter.ICAOs) > 0 {
+		icaos := make([]string, len(filter.ICAOs))
+		for i, icao := range filter.ICAOs {
+			icaos[i] = strings.ToUpper(strings.TrimSpace(icao))
+		}
+		add("UPPER("+ds.icaoColumn+") = ANY($%d)", pq.Array(icaos))
+	}
+	if ds.callsignColumn != "" && filter.CallsignPrefix != "" {
+		add("UPPER("+ds.callsignColumn+") LIKE $%d", strings.ToUpper(filter.CallsignPrefix)+"%")
+	}
+	if ds.altitudeColumn != "" && filter.MinAltitudeFt > 0 {
+		add(ds.altitudeColumn+" >= $%d", filter.MinAltitudeFt)
+	}
+	if ds.altitudeColumn != "" && filter.MaxAltitudeFt > 0 {
+		add(ds.altitudeColumn+" <= $%d", filter.MaxAltitudeFt)
+	}
+	if ds.rangeColumn != "" && filter.MaxRangeNM > 0 {
+		add(ds.rangeColumn+" <= $%d", filter.MaxRangeNM)
+	}
+
+	query := "SELECT " + strings.Join(names, ", ") + " FROM " + ds.table
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if ds.timeColumn != "" {
+		query += " ORDER BY " + ds.timeColumn
+	}
+	return query, args, nil
+}
+
+// Export streams the rows of a dataset matching filter to fn, in time order.
+// Values are string, float64, int64, bool, time.Time or nil for NULL, in the
+// order of ExportColumns.
+func (db *DB) Export(ctx context.Context, dataset string, filter ExportFilter, fn func(values []interface{}) error) error {
+	columns, err := ExportColumns(dataset)
+	if err != nil {
+		return err
+	}
+	query, args, err := exportQuery(dataset, filter)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", dataset, err)
+	}
+	defer rows.Close()
+
+	dest := make([]interface{}, len(columns))
+	for i, c := range columns {
+		switch c.Kind {
+		case ExportFloat:
+			dest[i] = new(sql.NullFloat64)
+		case ExportInt:
+			dest[i] = new(sql.NullInt64)
+		case ExportBool:
+			dest[i] = new(sql.NullBool)
+		case ExportTime:
+			dest[i] = new(sql.NullTime)
+		default:
+			dest[i] = new(sql.NullString)
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan %s row: %w", dataset, err)
+		}
+
+		for i, d := range dest {
+			values[i] = nil
+			switch v := d.(type) {
+			case *sql.NullFloat64:
+				if v.Valid {
+					values[i] = v.Float64
+				}
+			case *sql.NullInt64:
+				if v.Valid {
+					values[i] = v.Int64
+				}
+			case *sql.NullBool:
+				if v.Valid {
+					values[i] = v.Bool
+				}
+			case *sql.NullTime:
+				if v.Valid {
+					values[i] = v.Time.UTC()
+				}
+			case *sql.NullString:
+				if v.Valid {
+					values[i] = v.String
+				}
+			}
+		}
+
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExportColumns tests dataset column lookup.
+func TestExportColumns(t *testing.T) {
+	for _, dataset := range ExportDatasets() {
+		t.Run(dataset, func(t *testing.T) {
+			columns, err := ExportColumns(dataset)
+			if err != nil {
+				t.Fatalf("ExportColumns failed: %v", err)
+			}
+			for _, c := range columns {
+				if strings.Contains(c.Name, ".") {
+					t.Errorf("Column %q still has a table alias", c.Name)
+				}
+			}
+		})
+	}
+
+	if _, err := ExportColumns("weather"); err == nil {
+		t.Error("Expected error for unknown dataset")
+	}
+}
+
+// TestExportQuery tests filter conditions in the generated SQL.
+func TestExportQuery(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		dataset  string
+		filter   ExportFilter
+		contains []string
+		excludes []string
+		args     int
+	}{
+		{
+			name:     "No filter",
+			dataset:  "positions",
+			contains: []string{"FROM aircraft_positions p", "ORDER BY p.timestamp"},
+			excludes: []string{"WHERE"},
+		},
+		{
+			name:    "All position filters",
+			dataset: "positions",
+			filter: ExportFilter{
+				From: from, To: from.Add(24 * time.Hour), ICAOs: []string{"a1b2c3"},
+				CallsignPrefix: "ual", MinAltitudeFt: 1000, MaxAltitudeFt: 40000, MaxRangeNM: 20,
+			},
+			contains: []string{
+				"p.timestamp >= $1", "p.timestamp < $2", "UPPER(p.icao) = ANY($3)",
+				"UPPER(a.callsign) LIKE $4", "p.altitude_ft >= $5", "p.altitude_ft <= $6", "p.range_nm <= $7",
+			},
+			args: 7,
+		},
+		{
+			name:     "Aircraft filters ignored for sessions",
+			dataset:  "sessions",
+			filter:   ExportFilter{From: from, ICAOs: []string{"A1B2C3"}, MinAltitudeFt: 1000},
+			contains: []string{"start_time >= $1"},
+			excludes: []string{"icao", "altitude"},
+			args:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := exportQuery(tt.dataset, tt.filter)
+			if err != nil {
+				t.Fatalf("exportQuery failed: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(query, want) {
+					t.Errorf("Query missing %q: %s", want, query)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(query, unwanted) {
+					t.Errorf("Query unexpectedly contains %q: %s", unwanted, query)
+				}
+			}
+			if len(args) != tt.args {
+				t.Errorf("len(args) = %d, want %d", len(args), tt.args)
+			}
+		})
+	}
+}
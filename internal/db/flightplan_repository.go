@@ -4,8 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
 )
 
 // FlightPlanRepository handles database operations for flight plans and routes.
@@ -32,6 +38,12 @@ type FlightPlan struct {
 	ETD           time.Time
 	ETA           time.Time
 	LastUpdated   time.Time
+	FAFlightID    string // FlightAware flight ID; empty if not from FlightAware
+
+	// Route conformance, updated by the fetcher
+	DeviationNM   *float64   // Distance from the route at the last check; nil if never checked
+	OffRouteSince *time.Time // When the aircraft left the route; nil while on route
+	Rerouted      bool       // Off route long enough that the stored route is stale
 }
 
 // FlightPlanRoute represents a resolved waypoint in a flight plan.
@@ -47,6 +59,17 @@ type FlightPlanRoute struct {
 	Passed       bool
 }
 
+// Waypoint returns the route waypoint for route-based prediction.
+func (r FlightPlanRoute) Waypoint() tracking.Waypoint {
+	return tracking.Waypoint{
+		Name:      r.WaypointName,
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Sequence:  r.Sequence,
+		Passed:    r.Passed,
+	}
+}
+
 // Waypoint represents a navigation waypoint from the NASR database.
 type Waypoint struct {
 	ID         int
@@ -67,8 +90,9 @@ func (r *FlightPlanRepository) UpsertFlightPlan(ctx context.Context, fp FlightPl
 	err := r.db.QueryRowContext(ctx,
 		`INSERT INTO flight_plans (
 			icao, callsign, departure_icao, arrival_icao, route,
-			filed_altitude, aircraft_type, filed_time, etd, eta, last_updated
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			filed_altitude, aircraft_type, filed_time, etd, eta, last_updated,
+			fa_flight_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))
 		ON CONFLICT (icao) DO UPDATE SET
 			callsign = EXCLUDED.callsign,
 			departure_icao = EXCLUDED.departure_icao,
@@ -79,10 +103,16 @@ func (r *FlightPlanRepository) UpsertFlightPlan(ctx context.Context, fp FlightPl
 			filed_time = EXCLUDED.filed_time,
 			etd = EXCLUDED.etd,
 			eta = EXCLUDED.eta,
-			last_updated = EXCLUDED.last_updated
+			last_updated = EXCLUDED.last_updated,
+			fa_flight_id = EXCLUDED.fa_flight_id,
+			-- A new route replaces the one the aircraft left
+			off_route_since = CASE WHEN flight_plans.route IS DISTINCT FROM EXCLUDED.route
+				THEN NULL ELSE flight_plans.off_route_since END,
+			rerouted = flight_plans.rerouted AND flight_plans.route IS NOT DISTINCT FROM EXCLUDED.route
 		RETURNING id`,
 		fp.ICAO, fp.Callsign, fp.DepartureICAO, fp.ArrivalICAO, fp.Route,
 		fp.FiledAltitude, fp.AircraftType, fp.FiledTime, fp.ETD, fp.ETA, fp.LastUpdated,
+		fp.FAFlightID,
 	).Scan(&id)
 
 	if err != nil {
@@ -97,13 +127,15 @@ func (r *FlightPlanRepository) GetFlightPlanByICAO(ctx context.Context, icao str
 	var fp FlightPlan
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, icao, callsign, departure_icao, arrival_icao, route,
-		        filed_altitude, aircraft_type, filed_time, etd, eta, last_updated
+		        filed_altitude, aircraft_type, filed_time, etd, eta, last_updated,
+		        COALESCE(fa_flight_id, ''), route_deviation_nm, off_route_since, rerouted
 		 FROM flight_plans
 		 WHERE icao = $1`,
 		icao,
 	).Scan(
 		&fp.ID, &fp.ICAO, &fp.Callsign, &fp.DepartureICAO, &fp.ArrivalICAO, &fp.Route,
 		&fp.FiledAltitude, &fp.AircraftType, &fp.FiledTime, &fp.ETD, &fp.ETA, &fp.LastUpdated,
+		&fp.FAFlightID, &fp.DeviationNM, &fp.OffRouteSince, &fp.Rerouted,
 	)
 
 	if err == sql.ErrNoRows {
@@ -116,6 +148,171 @@ func (r *FlightPlanRepository) GetFlightPlanByICAO(ctx context.Context, icao str
 	return &fp, nil
 }
 
+// CoverageGap is a flight of interest whose ADS-B reception has lapsed.
+type CoverageGap struct {
+	ICAO       string
+	Callsign   string
+	FAFlightID string
+	LastSeen   time.Time
+}
+
+// FindCoverageGaps returns flights with a FlightAware flight plan that have
+// not been received for at least gap, but were within maxAge. Only flights of
+// interest are returned: those approaching the observer, trackable by the
+// telescope within maxAge, or the target of a pending or running scheduled
+// observation. The longest gaps come first.
+func (r *FlightPlanRepository) FindCoverageGaps(ctx context.Context, gap, maxAge time.Duration) ([]CoverageGap, error) {
+	now := time.Now().UTC()
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT a.icao, COALESCE(a.callsign, ''), fp.fa_flight_id, a.last_seen
+		 FROM aircraft a
+		 JOIN flight_plans fp ON fp.icao = a.icao
+		 WHERE fp.fa_flight_id IS NOT NULL
+		   AND a.last_seen < $1
+		   AND a.last_seen > $2
+		   AND (a.is_approaching
+		        OR a.last_trackable > $2
+		        OR EXISTS (
+		            SELECT 1 FROM observation_schedule s
+		            WHERE s.kind = 'track'
+		              AND s.status IN ('pending', 'running')
+		              AND s.end_time > NOW()
+		              AND UPPER(s.target) IN (UPPER(a.icao), UPPER(a.callsign))
+		        ))
+		 ORDER BY a.last_seen`,
+		now.Add(-gap), now.Add(-maxAge),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coverage gaps: %w", err)
+	}
+	defer rows.Close()
+
+	var gaps []CoverageGap
+	for rows.Next() {
+		var g CoverageGap
+		if err := rows.Scan(&g.ICAO, &g.Callsign, &g.FAFlightID, &g.LastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan coverage gap: %w", err)
+		}
+		gaps = append(gaps, g)
+	}
+
+	return gaps, rows.Err()
+}
+
+// RoutedAircraft is a visible aircraft with a resolved flight plan route,
+// for checking that it is still following the route.
+type RoutedAircraft struct {
+	FlightPlanID  int
+	ICAO          string
+	Callsign      string
+	Latitude      float64
+	Longitude     float64
+	OffRouteSince *time.Time
+	Rerouted      bool
+}
+
+// GetRoutedAircraft returns visible aircraft whose flight plan has at least
+// one resolved route waypoint, with their route conformance so far.
+func (r *FlightPlanRepository) GetRoutedAircraft(ctx context.Context) ([]RoutedAircraft, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT fp.id, a.icao, COALESCE(a.callsign, ''), a.latitude, a.longitude,
+		        fp.off_route_since, fp.rerouted
+		 FROM aircraft a
+		 JOIN flight_plans fp ON fp.icao = a.icao
+		 WHERE a.is_visible = TRUE
+		   AND EXISTS (SELECT 1 FROM flight_plan_routes r WHERE r.flight_plan_id = fp.id)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query routed aircraft: %w", err)
+	}
+	defer rows.Close()
+
+	var aircraft []RoutedAircraft
+	for rows.Next() {
+		var ac RoutedAircraft
+		if err := rows.Scan(&ac.FlightPlanID, &ac.ICAO, &ac.Callsign, &ac.Latitude, &ac.Longitude,
+			&ac.OffRouteSince, &ac.Rerouted); err != nil {
+			return nil, fmt.Errorf("failed to scan routed aircraft: %w", err)
+		}
+		aircraft = append(aircraft, ac)
+	}
+
+	return aircraft, rows.Err()
+}
+
+// Destination is the arrival airport of an aircraft's flight plan.
+type Destination struct {
+	Airport       string
+	Latitude      float64
+	Longitude     float64
+	FiledAltitude int // Feet MSL; 0 if not filed
+}
+
+// FlightContext returns what the destination tells predictions about the
+// flight. A nil destination gives an empty context.
+func (d *Destination) FlightContext() tracking.FlightContext {
+	if d == nil {
+		return tracking.FlightContext{}
+	}
+	return tracking.FlightContext{
+		Destination:     &coordinates.Geographic{Latitude: d.Latitude, Longitude: d.Longitude},
+		FiledAltitudeFt: float64(d.FiledAltitude),
+	}
+}
+
+// GetDestinations returns the destination of each visible aircraft whose
+// flight plan's arrival airport is in the waypoints table, keyed by ICAO.
+func (r *FlightPlanRepository) GetDestinations(ctx context.Context) (map[string]*Destination, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT ON (fp.icao) fp.icao, fp.arrival_icao, w.latitude, w.longitude,
+		        COALESCE(fp.filed_altitude, 0)
+		 FROM flight_plans fp
+		 JOIN aircraft a ON a.icao = fp.icao
+		 JOIN waypoints w ON w.identifier = fp.arrival_icao AND w.type = 'airport'
+		 WHERE a.is_visible = TRUE
+		 ORDER BY fp.icao, fp.last_updated DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query destinations: %w", err)
+	}
+	defer rows.Close()
+
+	destinations := make(map[string]*Destination)
+	for rows.Next() {
+		var icao string
+		var d Destination
+		if err := rows.Scan(&icao, &d.Airport, &d.Latitude, &d.Longitude, &d.FiledAltitude); err != nil {
+			return nil, fmt.Errorf("failed to scan destination: %w", err)
+		}
+		destinations[icao] = &d
+	}
+
+	return destinations, rows.Err()
+}
+
+// UpdateRouteConformance records an aircraft's distance from its flight plan
+// route, when it left the route (nil while on route) and whether it has been
+// rerouted.
+func (r *FlightPlanRepository) UpdateRouteConformance(
+	ctx context.Context,
+	flightPlanID int,
+	deviationNM float64,
+	offRouteSince *time.Time,
+	rerouted bool,
+) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE flight_plans
+		 SET route_deviation_nm = $2, off_route_since = $3, rerouted = $4
+		 WHERE id = $1`,
+		flightPlanID, deviationNM, offRouteSince, rerouted,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update route conformance: %w", err)
+	}
+
+	return nil
+}
+
 // GetWaypointByIdentifier looks up a waypoint by its identifier (e.g., "CHSLY", "ATL").
 //
 // If multiple waypoints exist with the same identifier (e.g., different regions),
@@ -223,16 +420,62 @@ func (r *FlightPlanRepository) FindAirportsNear(
 	return airports, rows.Err()
 }
 
+// FindWaypointsNear returns the waypoints, including airports, within
+// radiusNM of a position, ordered by type and identifier. If types are
+// given (e.g. "airport", "vor"), only waypoints of those types are returned.
+func (r *FlightPlanRepository) FindWaypointsNear(
+	ctx context.Context,
+	lat, lon float64,
+	radiusNM float64,
+	types ...string,
+) ([]Waypoint, error) {
+	// Bounding box first (1 degree latitude ≈ 60 NM, longitude shrinks
+	// towards the poles), then the exact distance
+	latDelta := radiusNM / 60.0
+	lonDelta := radiusNM / (60.0 * math.Max(math.Cos(lat*math.Pi/180.0), 0.01))
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, identifier, COALESCE(name, ''), latitude, longitude, type, COALESCE(region, '')
+		 FROM waypoints
+		 WHERE latitude BETWEEN $1 - $3 AND $1 + $3
+		   AND longitude BETWEEN $2 - $4 AND $2 + $4
+		   AND ($5::text[] IS NULL OR type = ANY($5::text[]))
+		 ORDER BY type, identifier, region`,
+		lat, lon, latDelta, lonDelta, pq.Array(types),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query waypoints: %w", err)
+	}
+	defer rows.Close()
+
+	center := coordinates.Geographic{Latitude: lat, Longitude: lon}
+	var waypoints []Waypoint
+	for rows.Next() {
+		var wp Waypoint
+		if err := rows.Scan(&wp.ID, &wp.Identifier, &wp.Name, &wp.Latitude, &wp.Longitude, &wp.Type, &wp.Region); err != nil {
+			return nil, fmt.Errorf("failed to scan waypoint: %w", err)
+		}
+		pos := coordinates.Geographic{Latitude: wp.Latitude, Longitude: wp.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) <= radiusNM {
+			waypoints = append(waypoints, wp)
+		}
+	}
+
+	return waypoints, rows.Err()
+}
+
 // ParseAndStoreRoute parses a route string and stores the waypoint sequence.
 //
 // Route format examples:
 // - "KCLT..CHSLY.J121.ATL..KATL" (airport, direct, fix, airway, vor, direct, airport)
 // - "KCLT.CHSLY.J121.ATL.KATL" (with dots between all waypoints)
 // - "KCLT CHSLY J121 ATL KATL" (space-separated)
+// - "KCLT 3550N/08000W KATL" (with a latitude/longitude point)
 //
 // The parser handles:
 // - Direct waypoints (simple identifiers)
-// - Airways (preceded by airway identifier like J121, V1)
+// - Airways (J121, V1), expanded into the waypoints between the fixes around them
+// - Latitude/longitude points (3550N/08000W, 3550N08000W, 35N080W)
 // - DCT (explicit direct routing)
 // - .. (implied direct routing)
 //
@@ -264,7 +507,24 @@ func (r *FlightPlanRepository) ParseAndStoreRoute(ctx context.Context, flightPla
 	}
 
 	sequence := 0
-	resolvedCount := 0
+
+	// insert appends a waypoint to the route sequence
+	insert := func(wp *Waypoint) error {
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO flight_plan_routes (
+				flight_plan_id, sequence, waypoint_id, passed
+			) VALUES ($1, $2, $3, FALSE)`,
+			flightPlanID, sequence, wp.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert route waypoint: %w", err)
+		}
+		sequence++
+		return nil
+	}
+
+	// previous is the last fix resolved, the entry point of a following airway
+	var previous *Waypoint
 
 	for i := 0; i < len(tokens); i++ {
 		token := tokens[i]
@@ -274,41 +534,222 @@ func (r *FlightPlanRepository) ParseAndStoreRoute(ctx context.Context, flightPla
 			continue
 		}
 
-		// Check if this is an airway identifier
-		if isAirway(token) {
-			// Airway waypoints will come after, just skip the identifier
-			// In the future, we can resolve airway segments here
+		// Latitude/longitude points aren't in the waypoints table until a
+		// route first uses them
+		if lat, lon, ok := parseLatLonToken(token); ok {
+			wp, err := r.upsertLatLonWaypoint(ctx, token, lat, lon)
+			if err != nil {
+				return sequence, err
+			}
+			if err := insert(wp); err != nil {
+				return sequence, err
+			}
+			previous = wp
 			continue
 		}
 
+		// Check if this is an airway identifier. Some fixes and navaids
+		// (e.g., "ATL") look like airways, so fall back to a waypoint lookup
+		// if no such airway is known.
+		if isAirway(token) {
+			points, err := r.GetAirwayWaypoints(ctx, token)
+			if err != nil {
+				return sequence, fmt.Errorf("failed to lookup airway %s: %w", token, err)
+			}
+			if len(points) > 0 {
+				// The entry and exit fixes are inserted as ordinary tokens;
+				// only the waypoints between them come from the airway
+				if previous != nil && i+1 < len(tokens) {
+					for _, wp := range airwayBetween(points, previous.Identifier, tokens[i+1]) {
+						if err := insert(&wp); err != nil {
+							return sequence, err
+						}
+					}
+				}
+				continue
+			}
+		}
+
 		// Try to resolve as waypoint
 		wp, err := r.GetWaypointByIdentifier(ctx, token)
 		if err != nil {
-			return resolvedCount, fmt.Errorf("failed to lookup waypoint %s: %w", token, err)
+			return sequence, fmt.Errorf("failed to lookup waypoint %s: %w", token, err)
 		}
 
 		if wp == nil {
 			// Waypoint not found in database - log but continue
-			// This can happen with airways or special routing instructions
+			// This can happen with procedures or special routing instructions
 			continue
 		}
 
-		// Insert waypoint into route sequence
-		_, err = r.db.ExecContext(ctx,
-			`INSERT INTO flight_plan_routes (
-				flight_plan_id, sequence, waypoint_id, passed
-			) VALUES ($1, $2, $3, FALSE)`,
-			flightPlanID, sequence, wp.ID,
-		)
-		if err != nil {
-			return resolvedCount, fmt.Errorf("failed to insert route waypoint: %w", err)
+		if err := insert(wp); err != nil {
+			return sequence, err
 		}
+		previous = wp
+	}
 
-		sequence++
-		resolvedCount++
+	return sequence, nil
+}
+
+// GetAirwayWaypoints returns the waypoints along an airway in sequence order.
+// Returns an empty slice if the airway is unknown.
+func (r *FlightPlanRepository) GetAirwayWaypoints(ctx context.Context, identifier string) ([]Waypoint, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT w.id, w.identifier, COALESCE(w.name, ''), w.latitude, w.longitude, w.type, COALESCE(w.region, '')
+		 FROM airways a
+		 JOIN waypoints w ON a.waypoint_id = w.id
+		 WHERE a.identifier = $1
+		 ORDER BY a.sequence`,
+		identifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query airway waypoints: %w", err)
+	}
+	defer rows.Close()
+
+	var waypoints []Waypoint
+	for rows.Next() {
+		var wp Waypoint
+		if err := rows.Scan(&wp.ID, &wp.Identifier, &wp.Name, &wp.Latitude, &wp.Longitude, &wp.Type, &wp.Region); err != nil {
+			return nil, fmt.Errorf("failed to scan airway waypoint: %w", err)
+		}
+		waypoints = append(waypoints, wp)
+	}
+
+	return waypoints, rows.Err()
+}
+
+// upsertLatLonWaypoint stores a latitude/longitude route point as a waypoint
+// of type "latlon", identified by its route token, and returns it.
+func (r *FlightPlanRepository) upsertLatLonWaypoint(ctx context.Context, token string, lat, lon float64) (*Waypoint, error) {
+	wp := Waypoint{Identifier: token, Latitude: lat, Longitude: lon, Type: "latlon"}
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO waypoints (identifier, latitude, longitude, type, region)
+		 VALUES ($1, $2, $3, $4, '')
+		 ON CONFLICT (identifier, region) DO UPDATE SET
+		 latitude = EXCLUDED.latitude,
+		 longitude = EXCLUDED.longitude
+		 RETURNING id`,
+		token, lat, lon, wp.Type,
+	).Scan(&wp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store lat/lon waypoint %s: %w", token, err)
+	}
+
+	return &wp, nil
+}
+
+// airwayBetween returns the waypoints along an airway strictly between the
+// entry and exit fixes, in the order they are flown. Airways can be flown in
+// either direction. Returns nil if either fix is not on the airway.
+func airwayBetween(points []Waypoint, entry, exit string) []Waypoint {
+	from, to := -1, -1
+	for i, wp := range points {
+		if wp.Identifier == entry && from < 0 {
+			from = i
+		}
+		if wp.Identifier == exit && to < 0 {
+			to = i
+		}
+	}
+	if from < 0 || to < 0 || from == to {
+		return nil
+	}
+
+	var between []Waypoint
+	if from < to {
+		between = append(between, points[from+1:to]...)
+	} else {
+		for i := from - 1; i > to; i-- {
+			between = append(between, points[i])
+		}
+	}
+	return between
+}
+
+// parseLatLonToken parses a latitude/longitude route point. Supported forms:
+//   - 3550N/08000W (FAA, degrees and minutes separated by a slash)
+//   - 3550N08000W (ICAO, degrees and minutes)
+//   - 355030N0800000W (degrees, minutes and seconds)
+//   - 35N080W (whole degrees)
+func parseLatLonToken(token string) (lat, lon float64, ok bool) {
+	token = strings.Replace(token, "/", "", 1)
+
+	// The latitude ends at its hemisphere letter
+	split := strings.IndexAny(token, "NS")
+	if split < 0 {
+		return 0, 0, false
+	}
+	latPart, lonPart := token[:split+1], token[split+1:]
+
+	// Degrees are 2 digits for latitude and 3 for longitude, followed by
+	// the same number of minutes/seconds digits
+	lat, ok = parseDegrees(latPart, 2, "NS", 90)
+	if !ok {
+		return 0, 0, false
+	}
+	lon, ok = parseDegrees(lonPart, 3, "EW", 180)
+	if !ok {
+		return 0, 0, false
+	}
+	if len(latPart)-2 != len(lonPart)-3 {
+		return 0, 0, false
 	}
 
-	return resolvedCount, nil
+	return lat, lon, true
+}
+
+// parseDegrees parses degrees (degDigits long) optionally followed by minutes
+// and seconds, ending in a hemisphere letter. The second hemisphere letter is
+// negative (south, west).
+func parseDegrees(s string, degDigits int, hemispheres string, limit float64) (float64, bool) {
+	if len(s) < degDigits+1 {
+		return 0, false
+	}
+	digits, hemi := s[:len(s)-1], s[len(s)-1]
+	sign := 1.0
+	switch hemi {
+	case hemispheres[0]:
+	case hemispheres[1]:
+		sign = -1
+	default:
+		return 0, false
+	}
+
+	// Whole degrees, minutes, or minutes and seconds
+	var parts []string
+	switch len(digits) - degDigits {
+	case 0:
+		parts = []string{digits}
+	case 2:
+		parts = []string{digits[:degDigits], digits[degDigits:]}
+	case 4:
+		parts = []string{digits[:degDigits], digits[degDigits : degDigits+2], digits[degDigits+2:]}
+	default:
+		return 0, false
+	}
+
+	value := 0.0
+	scale := 1.0
+	for i, part := range parts {
+		n := 0
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			n = n*10 + int(c-'0')
+		}
+		if i > 0 && n >= 60 {
+			return 0, false
+		}
+		value += float64(n) / scale
+		scale *= 60
+	}
+	if value > limit {
+		return 0, false
+	}
+
+	return sign * value, true
 }
 
 // isAirway checks if a token represents an airway identifier.
@@ -433,6 +874,20 @@ type AirwaySegment struct {
 	DistanceNM   float64 // Distance in nautical miles
 }
 
+// TrackingSegment returns the segment for airway matching and prediction.
+func (s AirwaySegment) TrackingSegment() tracking.AirwaySegment {
+	return tracking.AirwaySegment{
+		AirwayID:    s.AirwayID,
+		AirwayType:  s.AirwayType,
+		FromLat:     s.FromWaypoint.Latitude,
+		FromLon:     s.FromWaypoint.Longitude,
+		ToLat:       s.ToWaypoint.Latitude,
+		ToLon:       s.ToWaypoint.Longitude,
+		MinAltitude: s.MinAltitude,
+		MaxAltitude: s.MaxAltitude,
+	}
+}
+
 // FindNearbyAirways finds airways within a given radius of a position.
 // This is used to match aircraft to airways when no flight plan is available.
 //
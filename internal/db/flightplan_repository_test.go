@@ -1,6 +1,8 @@
 package db
 
 import (
+	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -264,3 +266,93 @@ func replaceDotsAndSplit(s string) string {
 	}
 	return result
 }
+
+// TestParseLatLonToken tests parsing of latitude/longitude route points.
+func TestParseLatLonToken(t *testing.T) {
+	tests := []struct {
+		token  string
+		lat    float64
+		lon    float64
+		wantOK bool
+	}{
+		{"3550N/08000W", 35 + 50.0/60, -80, true},
+		{"3550N08000W", 35 + 50.0/60, -80, true},
+		{"35N080W", 35, -80, true},
+		{"355030N0800000W", 35 + 50.0/60 + 30.0/3600, -80, true},
+		{"3330S15110E", -(33 + 30.0/60), 151 + 10.0/60, true},
+		{"9100N08000W", 0, 0, false}, // Latitude out of range
+		{"3560N08000W", 0, 0, false}, // Minutes out of range
+		{"3550N0800W", 0, 0, false},  // Mismatched precision
+		{"3550N08000", 0, 0, false},  // No longitude hemisphere
+		{"CHSLY", 0, 0, false},
+		{"J121", 0, 0, false},
+		{"KATL", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			lat, lon, ok := parseLatLonToken(tt.token)
+			if ok != tt.wantOK {
+				t.Fatalf("parseLatLonToken(%q) ok = %v, expected %v", tt.token, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(lat-tt.lat) > 1e-9 || math.Abs(lon-tt.lon) > 1e-9 {
+				t.Errorf("parseLatLonToken(%q) = %.6f, %.6f, expected %.6f, %.6f", tt.token, lat, lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+// TestAirwayBetween tests selecting the waypoints of an airway between fixes.
+func TestAirwayBetween(t *testing.T) {
+	var points []Waypoint
+	for _, id := range []string{"SPA", "CHSLY", "ODF", "ATL", "LGC"} {
+		points = append(points, Waypoint{Identifier: id})
+	}
+
+	tests := []struct {
+		name     string
+		entry    string
+		exit     string
+		expected []string
+	}{
+		{"Forward", "SPA", "ATL", []string{"CHSLY", "ODF"}},
+		{"Reverse", "LGC", "CHSLY", []string{"ATL", "ODF"}},
+		{"Adjacent", "ODF", "ATL", nil},
+		{"Unknown entry", "KCLT", "ATL", nil},
+		{"Unknown exit", "SPA", "KATL", nil},
+		{"Same fix", "ODF", "ODF", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			between := airwayBetween(points, tt.entry, tt.exit)
+			var got []string
+			for _, wp := range between {
+				got = append(got, wp.Identifier)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("airwayBetween(%s, %s) = %v, expected %v", tt.entry, tt.exit, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDestinationFlightContext tests converting a destination for predictions.
+func TestDestinationFlightContext(t *testing.T) {
+	var none *Destination
+	if fc := none.FlightContext(); fc.Destination != nil || fc.FiledAltitudeFt != 0 {
+		t.Errorf("nil destination gave %+v, expected an empty context", fc)
+	}
+
+	d := &Destination{Airport: "KATL", Latitude: 33.6367, Longitude: -84.4281, FiledAltitude: 35000}
+	fc := d.FlightContext()
+	if fc.Destination == nil || fc.Destination.Latitude != d.Latitude || fc.Destination.Longitude != d.Longitude {
+		t.Errorf("Destination = %+v, expected KATL's location", fc.Destination)
+	}
+	if fc.FiledAltitudeFt != 35000 {
+		t.Errorf("FiledAltitudeFt = %.0f, expected 35000", fc.FiledAltitudeFt)
+	}
+}
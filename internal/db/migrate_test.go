@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+// TestLoadMigrations tests parsing and ordering of migration files.
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Embedded migrations are ordered and contiguous", func(t *testing.T) {
+		migrations, err := loadMigrations(migrationFiles)
+		if err != nil {
+			t.Fatalf("Failed to load embedded migrations: %v", err)
+		}
+		if len(migrations) == 0 {
+			t.Fatal("Expected embedded migrations")
+		}
+		for i, m := range migrations {
+			if m.Version != i+1 {
+				t.Errorf("Expected version %d at position %d, got %d", i+1, i, m.Version)
+			}
+			if m.SQL == "" {
+				t.Errorf("Migration %d is empty", m.Version)
+			}
+		}
+	})
+
+	t.Run("Sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/010_later.sql":  {Data: []byte("SELECT 10;")},
+			"migrations/002_second.sql": {Data: []byte("SELECT 2;")},
+			"migrations/001_first.sql":  {Data: []byte("SELECT 1;")},
+		}
+		migrations, err := loadMigrations(fsys)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want := []struct {
+			version int
+			name    string
+		}{{1, "first"}, {2, "second"}, {10, "later"}}
+		if len(migrations) != len(want) {
+			t.Fatalf("Expected %d migrations, got %d", len(want), len(migrations))
+		}
+		for i, w := range want {
+			if migrations[i].Version != w.version || migrations[i].Name != w.name {
+				t.Errorf("Position %d: expected %d_%s, got %d_%s",
+					i, w.version, w.name, migrations[i].Version, migrations[i].Name)
+			}
+		}
+	})
+
+	invalid := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"Missing version", fstest.MapFS{"migrations/create_users.sql": {}}},
+		{"Missing name", fstest.MapFS{"migrations/004.sql": {}}},
+		{"Zero version", fstest.MapFS{"migrations/000_base.sql": {}}},
+		{"Duplicate version", fstest.MapFS{
+			"migrations/003_a.sql": {},
+			"migrations/003_b.sql": {},
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loadMigrations(tt.fsys); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
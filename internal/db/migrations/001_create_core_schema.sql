@@ -1,4 +1,5 @@
 -- ADS-B Scope Database Schema
+-- Migration: 001_create_core_schema
 -- PostgreSQL schema for storing aircraft positions and tracking history
 
 -- Aircraft table: stores current state of each tracked aircraft
@@ -16,6 +17,8 @@ CREATE TABLE IF NOT EXISTS aircraft (
     ground_speed_kts DOUBLE PRECISION,        -- Ground speed in knots
     track_deg DOUBLE PRECISION,               -- Ground track in degrees (0-360)
     vertical_rate_fpm DOUBLE PRECISION,       -- Vertical rate in feet/minute
+    squawk TEXT,                              -- Mode A transponder code (e.g., "7700")
+    source TEXT,                              -- Data feed that reported this aircraft (e.g., "uat978")
     
     -- Tracking metadata
     first_seen TIMESTAMP NOT NULL,            -- First time seen in current session
@@ -145,6 +148,11 @@ CREATE INDEX IF NOT EXISTS idx_aircraft_is_trackable ON aircraft(is_trackable) W
 CREATE INDEX IF NOT EXISTS idx_aircraft_approaching ON aircraft(is_approaching) WHERE is_approaching = TRUE;
 CREATE INDEX IF NOT EXISTS idx_aircraft_range ON aircraft(range_nm);
 
+-- Columns added after initial release (for existing databases)
+ALTER TABLE aircraft ADD COLUMN IF NOT EXISTS squawk TEXT;
+ALTER TABLE aircraft ADD COLUMN IF NOT EXISTS source TEXT;
+CREATE INDEX IF NOT EXISTS idx_aircraft_squawk ON aircraft(squawk) WHERE squawk IN ('7500', '7600', '7700');
+
 -- Position history lookups
 CREATE INDEX IF NOT EXISTS idx_positions_icao_timestamp ON aircraft_positions(icao, timestamp DESC);
 CREATE INDEX IF NOT EXISTS idx_positions_timestamp ON aircraft_positions(timestamp DESC);
@@ -221,6 +229,31 @@ CREATE INDEX IF NOT EXISTS idx_flight_plans_callsign ON flight_plans(callsign);
 
 CREATE INDEX IF NOT EXISTS idx_flight_plan_routes_plan ON flight_plan_routes(flight_plan_id, sequence);
 
+-- Collector status: latest self-reported health of the collector service (single row)
+CREATE TABLE IF NOT EXISTS collector_status (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    breaker_state TEXT NOT NULL,           -- closed, open, half-open
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    breaker_trips INTEGER NOT NULL DEFAULT 0,
+    retries_denied INTEGER NOT NULL DEFAULT 0,
+    open_until TIMESTAMP,                  -- When an open breaker will probe again
+    last_error TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Collector statistics: per-region results of each collection cycle
+CREATE TABLE IF NOT EXISTS collector_stats (
+    id BIGSERIAL PRIMARY KEY,
+    cycle_at TIMESTAMP NOT NULL,           -- Start time of the collection cycle
+    region TEXT NOT NULL,
+    fetched INTEGER NOT NULL DEFAULT 0,    -- Aircraft returned by the API
+    stored INTEGER NOT NULL DEFAULT 0,     -- Aircraft stored for this region after deduplication
+    errors INTEGER NOT NULL DEFAULT 0,     -- Failed fetches (after retries)
+    latency_ms INTEGER NOT NULL DEFAULT 0  -- Total time spent fetching, including retries
+);
+
+CREATE INDEX IF NOT EXISTS idx_collector_stats_cycle ON collector_stats(cycle_at DESC);
+
 -- Comments for documentation
 COMMENT ON TABLE aircraft IS 'Current state of all tracked aircraft within range';
 COMMENT ON TABLE aircraft_positions IS 'Time-series history of aircraft positions for velocity/acceleration analysis';
@@ -230,6 +263,8 @@ COMMENT ON TABLE waypoints IS 'Navigation waypoints, fixes, VORs, NDBs, and airp
 COMMENT ON TABLE airways IS 'Victor airways, Jet routes, and RNAV routes with waypoint sequences';
 COMMENT ON TABLE flight_plans IS 'Filed flight plans retrieved from external APIs';
 COMMENT ON TABLE flight_plan_routes IS 'Resolved waypoint sequences from flight plans';
+COMMENT ON TABLE collector_status IS 'Latest collector health including API circuit breaker state';
+COMMENT ON TABLE collector_stats IS 'Per-region collection results for charting collector health over time';
 
 COMMENT ON COLUMN aircraft.position_count IS 'Number of position updates received - used for data quality assessment';
 COMMENT ON COLUMN aircraft.is_trackable IS 'Whether aircraft is within telescope altitude limits (considering imaging mode)';
@@ -1,5 +1,5 @@
 -- Authentication and Authorization Tables
--- Migration: 001_create_auth_tables
+-- Migration: 002_create_auth_tables
 -- Creates users, sessions, and audit_log tables for web authentication
 
 -- Users table: stores user accounts for web interface
@@ -96,6 +96,7 @@ END;
 $$ LANGUAGE plpgsql;
 
 -- Trigger to update updated_at on users table
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
 CREATE TRIGGER update_users_updated_at
     BEFORE UPDATE ON users
     FOR EACH ROW
@@ -0,0 +1,26 @@
+-- Migration: Create observation schedule table
+-- Description: Planned observation windows queued by users and executed by
+-- the autotracker daemon. The telescope is shared, so active windows must not
+-- overlap (enforced by the repository when tasks are created).
+
+CREATE TABLE IF NOT EXISTS observation_schedule (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('track', 'arrivals', 'departures')),
+    target TEXT NOT NULL,                    -- ICAO hex/callsign, or airport ICAO code
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_observation_schedule_window ON observation_schedule(start_time, end_time);
+CREATE INDEX IF NOT EXISTS idx_observation_schedule_user ON observation_schedule(user_id, start_time);
+
+COMMENT ON TABLE observation_schedule IS 'Planned observation windows executed by the autotracker';
@@ -0,0 +1,33 @@
+-- Migration: Create device pairing tables
+-- Description: Phones and tablets linked to an account with a one-time
+-- pairing code (typed or scanned as a QR code) instead of a password. Each
+-- device gets a long-lived token limited to the role chosen when pairing;
+-- revoking the device invalidates its token.
+
+CREATE TABLE IF NOT EXISTS paired_devices (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'observer', 'viewer', 'guest')),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS pairing_codes (
+    code_hash TEXT PRIMARY KEY,              -- SHA256 of the one-time code
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_name VARCHAR(100) NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'observer', 'viewer', 'guest')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE,
+    device_id INTEGER REFERENCES paired_devices(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_paired_devices_user ON paired_devices(user_id);
+CREATE INDEX IF NOT EXISTS idx_pairing_codes_expires ON pairing_codes(expires_at);
+
+COMMENT ON TABLE paired_devices IS 'Devices linked to an account through the pairing flow';
+COMMENT ON TABLE pairing_codes IS 'One-time codes for pairing devices, stored hashed';
@@ -0,0 +1,10 @@
+-- Migration: Add a telescope to observation points
+-- Description: Users with several fixed stations can give each its own
+-- Alpaca telescope, so a target can be handed over from one station's
+-- telescope to another's as it leaves the first station's limits. Empty
+-- means the server's configured telescope.
+
+ALTER TABLE observation_points
+    ADD COLUMN IF NOT EXISTS telescope_url TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN observation_points.telescope_url IS 'Alpaca telescope at this station; empty for the server''s configured telescope';
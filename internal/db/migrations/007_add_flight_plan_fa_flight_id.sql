@@ -0,0 +1,9 @@
+-- Migration: Add the FlightAware flight ID to flight plans
+-- Description: The fetcher polls en-route positions for flights whose ADS-B
+-- coverage has lapsed. AeroAPI looks positions up by its own flight ID, which
+-- is returned with the flight plan.
+
+ALTER TABLE flight_plans
+    ADD COLUMN IF NOT EXISTS fa_flight_id TEXT;
+
+COMMENT ON COLUMN flight_plans.fa_flight_id IS 'FlightAware AeroAPI flight ID, for position lookups';
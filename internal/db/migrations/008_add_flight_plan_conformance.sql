@@ -0,0 +1,14 @@
+-- Migration: Track conformance to flight plan routes
+-- Description: Plans are cached for an hour, so a reroute leaves a stale
+-- route behind. The fetcher records how far each aircraft is from its
+-- route; once it has been off route for long enough the plan is flagged
+-- as rerouted and re-fetched.
+
+ALTER TABLE flight_plans
+    ADD COLUMN IF NOT EXISTS route_deviation_nm DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS off_route_since TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS rerouted BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN flight_plans.route_deviation_nm IS 'Distance from the aircraft to its route at the last check, in NM';
+COMMENT ON COLUMN flight_plans.off_route_since IS 'When the aircraft moved beyond the reroute threshold; NULL while on route';
+COMMENT ON COLUMN flight_plans.rerouted IS 'Aircraft has left its stored route; cleared when a plan with a new route is stored';
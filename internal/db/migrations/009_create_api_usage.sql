@@ -0,0 +1,15 @@
+-- Migration: Create API usage table
+-- Description: Paid APIs (FlightAware AeroAPI) are budgeted per month.
+-- Requests are counted per provider and day, so the budget holds across
+-- restarts and between processes sharing an API key.
+
+CREATE TABLE IF NOT EXISTS api_usage (
+    provider TEXT NOT NULL,                       -- e.g., "flightaware"
+    day DATE NOT NULL,                            -- UTC
+    calls INTEGER NOT NULL DEFAULT 0,
+    cost DOUBLE PRECISION NOT NULL DEFAULT 0,     -- In the account's currency
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, day)
+);
+
+COMMENT ON TABLE api_usage IS 'Requests made to paid APIs per provider and day, for monthly budgets';
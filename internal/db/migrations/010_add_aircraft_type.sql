@@ -0,0 +1,9 @@
+-- Migration: Store aircraft type designators
+-- Description: Feeds such as airplanes.live report each aircraft's ICAO type
+-- designator. Predictions use it to look up the type's typical performance
+-- and keep extrapolated climbs and descents plausible.
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS aircraft_type TEXT;
+
+COMMENT ON COLUMN aircraft.aircraft_type IS 'ICAO type designator (e.g., B738) from the ADS-B feed; NULL if not reported';
@@ -0,0 +1,43 @@
+-- Migration: Create pass accuracy reports
+-- Description: After each tracked pass the autotracker compares the commanded
+-- telescope positions with where the aircraft actually was (from the position
+-- history) and stores the result, so pointing accuracy can be reviewed per
+-- pass in the PWA and cmd/analyze-session. Tracking log entries now record
+-- how each position was predicted.
+
+ALTER TABLE telescope_tracking_log
+    ADD COLUMN IF NOT EXISTS prediction_mode TEXT;
+
+COMMENT ON COLUMN telescope_tracking_log.prediction_mode IS 'How the position was predicted: live, dead_reckoning or hold; NULL for entries logged before this was recorded';
+
+CREATE TABLE IF NOT EXISTS pass_reports (
+    id BIGSERIAL PRIMARY KEY,
+    icao TEXT NOT NULL,
+    callsign TEXT,
+    rule TEXT,                                   -- Autotracker rule or scheduled task that picked the target
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_reason TEXT,
+
+    commands INTEGER NOT NULL DEFAULT 0,         -- Telescope commands sent during the pass
+    assessed INTEGER NOT NULL DEFAULT 0,         -- Commands with position reports either side
+
+    -- Pointing error (degrees) against the interpolated actual position
+    rms_error_deg DOUBLE PRECISION,
+    max_error_deg DOUBLE PRECISION,
+    max_error_at TIMESTAMP WITH TIME ZONE,
+
+    -- Latency breakdown
+    mean_data_age_seconds DOUBLE PRECISION,
+    data_age_deg DOUBLE PRECISION,               -- RMS motion while reports aged
+    mean_interval_seconds DOUBLE PRECISION,
+    update_lag_deg DOUBLE PRECISION,             -- RMS motion between commands (half interval)
+
+    mode_usage JSONB NOT NULL DEFAULT '{}',      -- Percentage of commands per prediction mode
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pass_reports_ended_at ON pass_reports(ended_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pass_reports_icao ON pass_reports(icao, ended_at DESC);
+
+COMMENT ON TABLE pass_reports IS 'Pointing accuracy of each pass tracked by the autotracker';
@@ -0,0 +1,9 @@
+-- Migration: Add sky brightness to observation points
+-- Description: Each station's Bortle class sets its expected night sky
+-- brightness for exposure recommendations when no sky quality meter is
+-- reading. Zero means the server's configured sky.
+
+ALTER TABLE observation_points
+    ADD COLUMN IF NOT EXISTS bortle SMALLINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN observation_points.bortle IS 'Bortle dark-sky class (1-9) at this station; 0 for the server''s configured sky';
@@ -0,0 +1,15 @@
+-- Migration: Record the collector's active ADS-B source
+-- Description: The collector fails over to the next enabled online source
+-- after repeated failed cycles and fails back when the primary recovers.
+-- The status row records which source is in use for the system status page.
+
+ALTER TABLE collector_status
+    ADD COLUMN IF NOT EXISTS active_source TEXT,
+    ADD COLUMN IF NOT EXISTS primary_source TEXT,
+    ADD COLUMN IF NOT EXISTS failovers INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS failed_over_at TIMESTAMP;
+
+COMMENT ON COLUMN collector_status.active_source IS 'ADS-B source currently collected from; NULL if only local receivers are configured';
+COMMENT ON COLUMN collector_status.primary_source IS 'Preferred ADS-B source (first enabled online source)';
+COMMENT ON COLUMN collector_status.failovers IS 'Times the collector has switched away from a failing source since it started';
+COMMENT ON COLUMN collector_status.failed_over_at IS 'When the collector switched away from the primary source; NULL while on the primary';
@@ -0,0 +1,19 @@
+-- Migration: Per-source collection statistics
+-- Description: The collector records what each ADS-B data source reported
+-- every cycle (aircraft, aircraft no other source saw, position age) so
+-- sources can be compared for the observer's area.
+
+CREATE TABLE IF NOT EXISTS source_stats (
+    id BIGSERIAL PRIMARY KEY,
+    cycle_at TIMESTAMP NOT NULL,                -- Start time of the collection cycle
+    source TEXT NOT NULL,
+    aircraft INTEGER NOT NULL DEFAULT 0,        -- Aircraft with a position reported
+    unique_aircraft INTEGER NOT NULL DEFAULT 0, -- Of those, aircraft no other source reported this cycle
+    position_age_ms INTEGER NOT NULL DEFAULT 0, -- Mean age of the reported positions when fetched
+    errors INTEGER NOT NULL DEFAULT 0,          -- Failed queries (after retries)
+    latency_ms INTEGER NOT NULL DEFAULT 0       -- Total time spent querying, including retries
+);
+
+CREATE INDEX IF NOT EXISTS idx_source_stats_cycle ON source_stats(cycle_at DESC);
+
+COMMENT ON TABLE source_stats IS 'Per-source collection results for comparing ADS-B data sources';
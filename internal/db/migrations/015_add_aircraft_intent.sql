@@ -0,0 +1,14 @@
+-- Migration: Store autopilot selected values
+-- Description: Feeds such as airplanes.live report the altitude and heading
+-- set on an aircraft's autopilot (MCP/FCU) and its magnetic heading.
+-- Predictions use them to level aircraft off at the selected altitude and
+-- turn them onto the selected heading.
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS selected_altitude_ft DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS selected_heading_deg DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS heading_deg DOUBLE PRECISION;
+
+COMMENT ON COLUMN aircraft.selected_altitude_ft IS 'Altitude selected on the autopilot (MCP/FCU) in feet; NULL if not reported';
+COMMENT ON COLUMN aircraft.selected_heading_deg IS 'Heading selected on the autopilot in degrees magnetic; NULL if not reported';
+COMMENT ON COLUMN aircraft.heading_deg IS 'Current heading in degrees magnetic; NULL if not reported';
@@ -0,0 +1,13 @@
+-- Migration: Record a hash of each imported NASR record
+-- Description: Each 28-day NASR cycle changes only a small fraction of its
+-- waypoints and airways. The importer stores a hash of every record it
+-- writes and skips records whose hash is unchanged on the next import.
+
+ALTER TABLE waypoints
+    ADD COLUMN IF NOT EXISTS record_hash TEXT;
+
+ALTER TABLE airways
+    ADD COLUMN IF NOT EXISTS record_hash TEXT;
+
+COMMENT ON COLUMN waypoints.record_hash IS 'Hash of the imported source record; NULL for rows not written by the NASR importer';
+COMMENT ON COLUMN airways.record_hash IS 'Hash of the imported source record; NULL for rows not written by the NASR importer';
@@ -0,0 +1,29 @@
+-- Migration: Airspaces
+-- Description: Controlled, restricted and other airspaces imported from
+-- OpenAIP, for regions outside NASR's US coverage. The outline is stored
+-- as JSON with its bounding box for finding airspaces near a point.
+
+CREATE TABLE IF NOT EXISTS airspaces (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL,                 -- Dataset the airspace came from, e.g. "openaip"
+    source_id TEXT NOT NULL,              -- ID within the dataset
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,                   -- ctr, tma, restricted, danger, prohibited, ...
+    icao_class TEXT,                      -- A-G; NULL if unclassified
+    country TEXT,                         -- ISO 3166 country code
+    lower_limit_ft DOUBLE PRECISION NOT NULL,
+    lower_datum TEXT NOT NULL,            -- GND, MSL or STD (flight level)
+    upper_limit_ft DOUBLE PRECISION NOT NULL,
+    upper_datum TEXT NOT NULL,
+    min_latitude DOUBLE PRECISION NOT NULL,
+    min_longitude DOUBLE PRECISION NOT NULL,
+    max_latitude DOUBLE PRECISION NOT NULL,
+    max_longitude DOUBLE PRECISION NOT NULL,
+    boundary JSONB NOT NULL,              -- [[lat, lon], ...]
+    record_hash TEXT,
+    UNIQUE(source, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_airspaces_bounds ON airspaces(min_latitude, max_latitude, min_longitude, max_longitude);
+
+COMMENT ON TABLE airspaces IS 'Airspace outlines and vertical limits from OpenAIP';
@@ -0,0 +1,10 @@
+-- Migration: Record every collection region an aircraft is seen in
+-- Description: Overlapping collection regions report the same aircraft.
+-- The collector merges the reports, keeping the freshest and most accurate
+-- position; collection_region is the region that report came from, and
+-- seen_regions accumulates all the regions that have reported the aircraft.
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS seen_regions TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN aircraft.seen_regions IS 'Every collection region (or feed) that has reported this aircraft';
@@ -0,0 +1,16 @@
+-- Migration: Report the collector's aircraft write queue
+-- Description: The collector queues aircraft writes for a pool of workers
+-- instead of writing them between polls. Its status row reports how full
+-- the queue is and how many writes failed or were dropped, to tell when the
+-- database can't keep up.
+
+ALTER TABLE collector_status
+    ADD COLUMN IF NOT EXISTS write_queue_depth INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS write_queue_capacity INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS writes_failed BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS writes_dropped BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN collector_status.write_queue_depth IS 'Aircraft writes waiting for a worker when the status was saved';
+COMMENT ON COLUMN collector_status.write_queue_capacity IS 'Aircraft writes that may wait before new ones are dropped';
+COMMENT ON COLUMN collector_status.writes_failed IS 'Aircraft writes that failed since the collector started';
+COMMENT ON COLUMN collector_status.writes_dropped IS 'Aircraft writes dropped on a full queue since the collector started';
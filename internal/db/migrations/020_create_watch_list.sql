@@ -0,0 +1,18 @@
+-- Migration: Create aircraft watch lists
+-- Description: Aircraft users have starred, by ICAO address or registration.
+-- The collector notifies when a watched aircraft reappears in coverage, and
+-- the PWA and terminal clients highlight watched aircraft.
+
+CREATE TABLE IF NOT EXISTS watched_aircraft (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('icao', 'registration')),
+    target TEXT NOT NULL,                    -- Upper case ICAO hex or registration without dashes
+    note VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, kind, target)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watched_aircraft_target ON watched_aircraft(kind, target);
+
+COMMENT ON TABLE watched_aircraft IS 'Per-user watch lists of aircraft to notify about and highlight';
@@ -0,0 +1,9 @@
+-- Migration: Let observation points follow a GPS receiver
+-- Description: Mobile stations (a vehicle, or a quick setup at a new site)
+-- take their position from the server's GPS receiver instead of typed
+-- coordinates. Only the active point of each user is moved.
+
+ALTER TABLE observation_points
+    ADD COLUMN IF NOT EXISTS follow_gps BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN observation_points.follow_gps IS 'Move this point to each GPS fix while it is active';
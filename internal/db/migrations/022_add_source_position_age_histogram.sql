@@ -0,0 +1,10 @@
+-- Migration: Per-source latency distribution
+-- Description: The collector records the distribution of each source's
+-- position age (now - last_seen) per cycle, as counts in fixed buckets, so
+-- latency percentiles can be reported for any period and the measured
+-- median used as the prediction latency.
+
+ALTER TABLE source_stats
+    ADD COLUMN IF NOT EXISTS position_age_histogram BIGINT[];
+
+COMMENT ON COLUMN source_stats.position_age_histogram IS 'Positions per position-age bucket (bounds in pkg/adsb LatencyBuckets, then overflow)';
@@ -0,0 +1,19 @@
+-- Migration: Create prediction error calibrations
+-- Description: cmd/analyze-session -calibrate compares the positions commanded
+-- in recorded passes with where the aircraft actually was and fits the
+-- angular error to expect at each prediction confidence. The latest
+-- calibration turns confidences into errors such as "±0.3°" for display.
+
+CREATE TABLE IF NOT EXISTS error_calibrations (
+    id BIGSERIAL PRIMARY KEY,
+    period_from TIMESTAMP WITH TIME ZONE NOT NULL, -- Passes that ended in [period_from, period_to)
+    period_to TIMESTAMP WITH TIME ZONE NOT NULL,
+    passes INTEGER NOT NULL DEFAULT 0,
+    samples INTEGER NOT NULL DEFAULT 0,             -- Assessed predictions
+    bins JSONB NOT NULL DEFAULT '[]',               -- [{confidence, errorDeg, samples}], ascending confidence
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_error_calibrations_created_at ON error_calibrations(created_at DESC);
+
+COMMENT ON TABLE error_calibrations IS 'Angular prediction error observed per confidence, fitted to recorded passes';
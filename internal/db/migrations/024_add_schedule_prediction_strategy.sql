@@ -0,0 +1,11 @@
+-- Migration: Prediction strategy per scheduled task
+-- Description: A scheduled task can restrict how the autotracker predicts
+-- its targets' positions (auto, dead-reckoning, flight-plan or airway), e.g.
+-- when an aircraft's flight plan is known to be wrong.
+
+ALTER TABLE observation_schedule
+    ADD COLUMN IF NOT EXISTS prediction TEXT NOT NULL DEFAULT 'auto'
+        CHECK (prediction IN ('auto', 'dead-reckoning', 'flight-plan', 'airway'));
+
+COMMENT ON COLUMN observation_schedule.prediction IS 'Prediction strategy for the task''s targets (pkg/tracking PredictionStrategy)';
+COMMENT ON COLUMN telescope_tracking_log.prediction_mode IS 'How the position was predicted: live, dead_reckoning, flight_plan, airway or hold; NULL for entries logged before this was recorded';
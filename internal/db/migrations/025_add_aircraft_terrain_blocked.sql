@@ -0,0 +1,9 @@
+-- Migration: Terrain line of sight
+-- Description: The collector flags low aircraft whose line of sight from
+-- the observer is blocked by terrain (checked against SRTM elevation
+-- tiles), and excludes them from the trackable aircraft.
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS terrain_blocked BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN aircraft.terrain_blocked IS 'Hidden from the observer by terrain at the last check; never trackable while set';
@@ -0,0 +1,14 @@
+-- Migration: Observer of the stored aircraft geometry
+-- Description: Records the observer location the collector computed each
+-- aircraft's range, bearing, elevation and azimuth from, so readers
+-- configured with another observer (or whose observer changed at runtime)
+-- recompute the geometry instead of using the collector's.
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS geometry_latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS geometry_longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS geometry_elevation_m DOUBLE PRECISION;
+
+COMMENT ON COLUMN aircraft.geometry_latitude IS 'Latitude of the observer range_nm, bearing_deg, altitude_deg and azimuth_deg were computed from; NULL for rows stored before this was recorded';
+COMMENT ON COLUMN aircraft.geometry_longitude IS 'Longitude of the observer the geometry was computed from';
+COMMENT ON COLUMN aircraft.geometry_elevation_m IS 'Elevation in meters of the observer the geometry was computed from';
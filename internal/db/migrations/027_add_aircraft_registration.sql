@@ -0,0 +1,9 @@
+-- Migration: Aircraft registration
+-- Description: Stores the registration (tail number) the ADS-B feed looks
+-- up for each aircraft, so registration watch list entries match airliners
+-- flying under a flight number (e.g. G-EUPT as BAW123).
+
+ALTER TABLE aircraft
+    ADD COLUMN IF NOT EXISTS registration TEXT;
+
+COMMENT ON COLUMN aircraft.registration IS 'Registration (e.g., G-EUPT) from the ADS-B feed''s aircraft database; NULL if never reported';
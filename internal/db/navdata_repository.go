@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
+)
+
+// NavdataRepository writes imported navigation data (waypoints, airways
+// and airspaces) and the record hashes importers use to skip records that
+// haven't changed since the last data cycle.
+type NavdataRepository struct {
+	db *DB
+}
+
+// NewNavdataRepository creates a new navigation data repository.
+func NewNavdataRepository(db *DB) *NavdataRepository {
+	return &NavdataRepository{db: db}
+}
+
+// WaypointKey identifies a row of the waypoints table, which is unique by
+// identifier and region.
+func WaypointKey(identifier, region string) string {
+	return identifier + "\x00" + region
+}
+
+// AirwayKey identifies a row of the airways table, which is unique by
+// identifier and sequence.
+func AirwayKey(identifier string, sequence int) string {
+	return fmt.Sprintf("%s\x00%d", identifier, sequence)
+}
+
+// WaypointHashes returns the record hashes of imported waypoints, keyed by
+// WaypointKey.
+func (r *NavdataRepository) WaypointHashes(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT identifier, COALESCE(region, ''), record_hash
+		 FROM waypoints
+		 WHERE record_hash IS NOT NULL`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load waypoint hashes: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var identifier, region, hash string
+		if err := rows.Scan(&identifier, &region, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan waypoint hash: %w", err)
+		}
+		hashes[WaypointKey(identifier, region)] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// AirwayHashes returns the record hashes of imported airway segments, keyed
+// by AirwayKey.
+func (r *NavdataRepository) AirwayHashes(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT identifier, sequence, record_hash
+		 FROM airways
+		 WHERE record_hash IS NOT NULL`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load airway hashes: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var identifier, hash string
+		var sequence int
+		if err := rows.Scan(&identifier, &sequence, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan airway hash: %w", err)
+		}
+		hashes[AirwayKey(identifier, sequence)] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// WaypointIDs maps each waypoint identifier to its ID, for resolving airway
+// segments without a query per segment. Where an identifier is used in
+// more than one region the first row wins.
+func (r *NavdataRepository) WaypointIDs(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT identifier, MIN(id) FROM waypoints GROUP BY identifier`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load waypoint IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int)
+	for rows.Next() {
+		var identifier string
+		var id int
+		if err := rows.Scan(&identifier, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan waypoint ID: %w", err)
+		}
+		ids[identifier] = id
+	}
+	return ids, rows.Err()
+}
+
+// UpsertWaypoint inserts or updates a waypoint and its record hash.
+func (r *NavdataRepository) UpsertWaypoint(ctx context.Context, wp Waypoint, hash string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO waypoints (identifier, name, latitude, longitude, type, region, record_hash)
+		 VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7)
+		 ON CONFLICT (identifier, region) DO UPDATE SET
+		 name = EXCLUDED.name,
+		 latitude = EXCLUDED.latitude,
+		 longitude = EXCLUDED.longitude,
+		 type = EXCLUDED.type,
+		 record_hash = EXCLUDED.record_hash`,
+		wp.Identifier, wp.Name, wp.Latitude, wp.Longitude, wp.Type, wp.Region, hash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert waypoint %s: %w", wp.Identifier, err)
+	}
+	return nil
+}
+
+// AirspaceHashes returns the record hashes of the airspaces imported from
+// a source, keyed by source ID.
+func (r *NavdataRepository) AirspaceHashes(ctx context.Context, source string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT source_id, record_hash
+		 FROM airspaces
+		 WHERE source = $1 AND record_hash IS NOT NULL`,
+		source,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load airspace hashes: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan airspace hash: %w", err)
+		}
+		hashes[id] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// UpsertAirspace inserts or updates an airspace from a source and its
+// record hash.
+func (r *NavdataRepository) UpsertAirspace(ctx context.Context, source string, a navdata.Airspace, hash string) error {
+	boundary, err := json.Marshal(a.Boundary)
+	if err != nil {
+		return fmt.Errorf("failed to encode airspace %s boundary: %w", a.Name, err)
+	}
+	minLat, minLon, maxLat, maxLon := a.Bounds()
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO airspaces (
+			source, source_id, name, type, icao_class, country,
+			lower_limit_ft, lower_datum, upper_limit_ft, upper_datum,
+			min_latitude, min_longitude, max_latitude, max_longitude,
+			boundary, record_hash
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (source, source_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			type = EXCLUDED.type,
+			icao_class = EXCLUDED.icao_class,
+			country = EXCLUDED.country,
+			lower_limit_ft = EXCLUDED.lower_limit_ft,
+			lower_datum = EXCLUDED.lower_datum,
+			upper_limit_ft = EXCLUDED.upper_limit_ft,
+			upper_datum = EXCLUDED.upper_datum,
+			min_latitude = EXCLUDED.min_latitude,
+			min_longitude = EXCLUDED.min_longitude,
+			max_latitude = EXCLUDED.max_latitude,
+			max_longitude = EXCLUDED.max_longitude,
+			boundary = EXCLUDED.boundary,
+			record_hash = EXCLUDED.record_hash`,
+		source, a.SourceID, a.Name, a.Type, a.Class, a.Country,
+		a.Lower.Feet, a.Lower.Datum, a.Upper.Feet, a.Upper.Datum,
+		minLat, minLon, maxLat, maxLon,
+		string(boundary), hash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert airspace %s: %w", a.Name, err)
+	}
+	return nil
+}
+
+// Centers returns the areas of air traffic control centers (US ARTCCs and
+// FIRs) from every source, for locating aircraft and the observer.
+func (r *NavdataRepository) Centers(ctx context.Context) (navdata.Centers, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT source_id, name, type, COALESCE(country, ''),
+		        lower_limit_ft, lower_datum, upper_limit_ft, upper_datum, boundary
+		 FROM airspaces
+		 WHERE type = ANY($1)
+		 ORDER BY source, source_id`,
+		pq.Array(navdata.CenterTypes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load centers: %w", err)
+	}
+	defer rows.Close()
+
+	var centers navdata.Centers
+	for rows.Next() {
+		var a navdata.Airspace
+		var boundary []byte
+		if err := rows.Scan(&a.SourceID, &a.Name, &a.Type, &a.Country,
+			&a.Lower.Feet, &a.Lower.Datum, &a.Upper.Feet, &a.Upper.Datum, &boundary); err != nil {
+			return nil, fmt.Errorf("failed to scan center: %w", err)
+		}
+		if err := json.Unmarshal(boundary, &a.Boundary); err != nil {
+			return nil, fmt.Errorf("failed to decode center %s boundary: %w", a.Name, err)
+		}
+		centers = append(centers, a)
+	}
+	return centers, rows.Err()
+}
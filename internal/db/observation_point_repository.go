@@ -5,19 +5,24 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // ObservationPoint represents a user-defined observation location
 type ObservationPoint struct {
-	ID              int       `json:"id"`
-	UserID          int       `json:"userId"`
-	Name            string    `json:"name"`
-	Latitude        float64   `json:"latitude"`
-	Longitude       float64   `json:"longitude"`
-	ElevationMeters float64   `json:"elevationMeters"`
-	IsActive        bool      `json:"isActive"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
+	ID              int          `json:"id"`
+	UserID          int          `json:"userId"`
+	Name            string       `json:"name"`
+	Latitude        float64      `json:"latitude"`
+	Longitude       float64      `json:"longitude"`
+	ElevationMeters units.Meters `json:"elevationMeters"`
+	IsActive        bool         `json:"isActive"`
+	TelescopeURL    string       `json:"telescopeUrl"` // Alpaca telescope at this station ("" = the server's)
+	Bortle          int          `json:"bortle"`       // Bortle dark-sky class 1-9 (0 = the server's configured sky)
+	FollowGPS       bool         `json:"followGps"`    // Moved to each fix of the server's GPS receiver while active
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
 }
 
 // ObservationPointRepository provides methods for managing observation points
@@ -33,7 +38,7 @@ func NewObservationPointRepository(db *DB) *ObservationPointRepository {
 // GetUserPoints returns all observation points for a user
 func (r *ObservationPointRepository) GetUserPoints(ctx context.Context, userID int) ([]ObservationPoint, error) {
 	query := `
-		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, created_at, updated_at
+		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, telescope_url, bortle, follow_gps, created_at, updated_at
 		FROM observation_points
 		WHERE user_id = $1
 		ORDER BY is_active DESC, name ASC
@@ -56,6 +61,9 @@ func (r *ObservationPointRepository) GetUserPoints(ctx context.Context, userID i
 			&p.Longitude,
 			&p.ElevationMeters,
 			&p.IsActive,
+			&p.TelescopeURL,
+			&p.Bortle,
+			&p.FollowGPS,
 			&p.CreatedAt,
 			&p.UpdatedAt,
 		)
@@ -71,7 +79,7 @@ func (r *ObservationPointRepository) GetUserPoints(ctx context.Context, userID i
 // GetActivePoint returns the active observation point for a user
 func (r *ObservationPointRepository) GetActivePoint(ctx context.Context, userID int) (*ObservationPoint, error) {
 	query := `
-		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, created_at, updated_at
+		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, telescope_url, bortle, follow_gps, created_at, updated_at
 		FROM observation_points
 		WHERE user_id = $1 AND is_active = TRUE
 		LIMIT 1
@@ -86,6 +94,9 @@ func (r *ObservationPointRepository) GetActivePoint(ctx context.Context, userID
 		&p.Longitude,
 		&p.ElevationMeters,
 		&p.IsActive,
+		&p.TelescopeURL,
+		&p.Bortle,
+		&p.FollowGPS,
 		&p.CreatedAt,
 		&p.UpdatedAt,
 	)
@@ -103,7 +114,7 @@ func (r *ObservationPointRepository) GetActivePoint(ctx context.Context, userID
 // GetByID returns a specific observation point by ID
 func (r *ObservationPointRepository) GetByID(ctx context.Context, pointID, userID int) (*ObservationPoint, error) {
 	query := `
-		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, created_at, updated_at
+		SELECT id, user_id, name, latitude, longitude, elevation_meters, is_active, telescope_url, bortle, follow_gps, created_at, updated_at
 		FROM observation_points
 		WHERE id = $1 AND user_id = $2
 	`
@@ -117,6 +128,9 @@ func (r *ObservationPointRepository) GetByID(ctx context.Context, pointID, userI
 		&p.Longitude,
 		&p.ElevationMeters,
 		&p.IsActive,
+		&p.TelescopeURL,
+		&p.Bortle,
+		&p.FollowGPS,
 		&p.CreatedAt,
 		&p.UpdatedAt,
 	)
@@ -134,8 +148,8 @@ func (r *ObservationPointRepository) GetByID(ctx context.Context, pointID, userI
 // Create creates a new observation point
 func (r *ObservationPointRepository) Create(ctx context.Context, point *ObservationPoint) error {
 	query := `
-		INSERT INTO observation_points (user_id, name, latitude, longitude, elevation_meters, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO observation_points (user_id, name, latitude, longitude, elevation_meters, is_active, telescope_url, bortle, follow_gps)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -148,6 +162,9 @@ func (r *ObservationPointRepository) Create(ctx context.Context, point *Observat
 		point.Longitude,
 		point.ElevationMeters,
 		point.IsActive,
+		point.TelescopeURL,
+		point.Bortle,
+		point.FollowGPS,
 	).Scan(&point.ID, &point.CreatedAt, &point.UpdatedAt)
 
 	if err != nil {
@@ -161,8 +178,8 @@ func (r *ObservationPointRepository) Create(ctx context.Context, point *Observat
 func (r *ObservationPointRepository) Update(ctx context.Context, point *ObservationPoint) error {
 	query := `
 		UPDATE observation_points
-		SET name = $1, latitude = $2, longitude = $3, elevation_meters = $4, is_active = $5, updated_at = NOW()
-		WHERE id = $6 AND user_id = $7
+		SET name = $1, latitude = $2, longitude = $3, elevation_meters = $4, is_active = $5, telescope_url = $6, bortle = $7, follow_gps = $8, updated_at = NOW()
+		WHERE id = $9 AND user_id = $10
 		RETURNING updated_at
 	`
 
@@ -174,6 +191,9 @@ func (r *ObservationPointRepository) Update(ctx context.Context, point *Observat
 		point.Longitude,
 		point.ElevationMeters,
 		point.IsActive,
+		point.TelescopeURL,
+		point.Bortle,
+		point.FollowGPS,
 		point.ID,
 		point.UserID,
 	).Scan(&point.UpdatedAt)
@@ -233,3 +253,25 @@ func (r *ObservationPointRepository) SetActive(ctx context.Context, pointID, use
 
 	return nil
 }
+
+// MoveGPSPoints moves the active observation points that follow GPS to a
+// fix, keeping their elevation if the fix has none. Returns how many were
+// moved.
+func (r *ObservationPointRepository) MoveGPSPoints(ctx context.Context, latitude, longitude float64, elevation *units.Meters) (int64, error) {
+	query := `
+		UPDATE observation_points
+		SET latitude = $1, longitude = $2, elevation_meters = COALESCE($3, elevation_meters), updated_at = NOW()
+		WHERE is_active = TRUE AND follow_gps = TRUE
+	`
+
+	result, err := r.db.ExecContext(ctx, query, latitude, longitude, elevation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move GPS observation points: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// PassReport is the pointing accuracy of one tracked pass, computed by the
+// autotracker when the pass ends.
+type PassReport struct {
+	ID        int64     `json:"id"`
+	ICAO      string    `json:"icao"`
+	Callsign  string    `json:"callsign,omitempty"`
+	Rule      string    `json:"rule,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	EndReason string    `json:"endReason,omitempty"`
+
+	Commands    int        `json:"commands"`
+	Assessed    int        `json:"assessed"`
+	RMSErrorDeg float64    `json:"rmsErrorDeg"`
+	MaxErrorDeg float64    `json:"maxErrorDeg"`
+	MaxErrorAt  *time.Time `json:"maxErrorAt,omitempty"`
+
+	MeanDataAge  float64 `json:"meanDataAgeSeconds"`
+	DataAgeDeg   float64 `json:"dataAgeDeg"`
+	MeanInterval float64 `json:"meanIntervalSeconds"`
+	UpdateLagDeg float64 `json:"updateLagDeg"`
+
+	// ModeUsage is the percentage of commands per prediction mode
+	ModeUsage map[tracking.PredictionMode]float64 `json:"modeUsage"`
+}
+
+// SetAccuracy fills the report from an analysed pass.
+func (p *PassReport) SetAccuracy(a tracking.PassAccuracy) {
+	p.Commands = a.Commands
+	p.Assessed = a.Assessed
+	p.RMSErrorDeg = a.RMSErrorDeg
+	p.MaxErrorDeg = a.MaxErrorDeg
+	p.MaxErrorAt = nil
+	if !a.MaxErrorAt.IsZero() {
+		at := a.MaxErrorAt
+		p.MaxErrorAt = &at
+	}
+	p.MeanDataAge = a.Latency.MeanDataAge
+	p.DataAgeDeg = a.Latency.DataAgeDeg
+	p.MeanInterval = a.Latency.MeanInterval
+	p.UpdateLagDeg = a.Latency.UpdateLagDeg
+	p.ModeUsage = a.ModeUsage
+}
+
+// PassReportRepository stores and lists pass accuracy reports.
+type PassReportRepository struct {
+	db *DB
+}
+
+// NewPassReportRepository creates a new pass report repository.
+func NewPassReportRepository(db *DB) *PassReportRepository {
+	return &PassReportRepository{db: db}
+}
+
+// Save stores a report and sets its ID.
+func (r *PassReportRepository) Save(ctx context.Context, report *PassReport) error {
+	modeUsage, err := json.Marshal(report.ModeUsage)
+	if err != nil {
+		return fmt.Errorf("failed to encode mode usage: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO pass_reports (
+			icao, callsign, rule, started_at, ended_at, end_reason,
+			commands, assessed, rms_error_deg, max_error_deg, max_error_at,
+			mean_data_age_seconds, data_age_deg, mean_interval_seconds, update_lag_deg,
+			mode_usage
+		) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, NULLIF($6, ''),
+			$7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id`,
+		report.ICAO, report.Callsign, report.Rule, report.StartedAt, report.EndedAt, report.EndReason,
+		report.Commands, report.Assessed, report.RMSErrorDeg, report.MaxErrorDeg, report.MaxErrorAt,
+		report.MeanDataAge, report.DataAgeDeg, report.MeanInterval, report.UpdateLagDeg,
+		modeUsage,
+	).Scan(&report.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save pass report: %w", err)
+	}
+	return nil
+}
+
+const passReportColumns = `id, icao, COALESCE(callsign, ''), COALESCE(rule, ''),
+		started_at, ended_at, COALESCE(end_reason, ''),
+		commands, assessed, COALESCE(rms_error_deg, 0), COALESCE(max_error_deg, 0), max_error_at,
+		COALESCE(mean_data_age_seconds, 0), COALESCE(data_age_deg, 0),
+		COALESCE(mean_interval_seconds, 0), COALESCE(update_lag_deg, 0), mode_usage`
+
+// scanPassReport scans a row selected with passReportColumns.
+func scanPassReport(row interface{ Scan(...interface{}) error }) (PassReport, error) {
+	var p PassReport
+	var maxErrorAt sql.NullTime
+	var modeUsage []byte
+	err := row.Scan(
+		&p.ID, &p.ICAO, &p.Callsign, &p.Rule,
+		&p.StartedAt, &p.EndedAt, &p.EndReason,
+		&p.Commands, &p.Assessed, &p.RMSErrorDeg, &p.MaxErrorDeg, &maxErrorAt,
+		&p.MeanDataAge, &p.DataAgeDeg, &p.MeanInterval, &p.UpdateLagDeg, &modeUsage,
+	)
+	if err != nil {
+		return p, err
+	}
+	if maxErrorAt.Valid {
+		p.MaxErrorAt = &maxErrorAt.Time
+	}
+	if err := json.Unmarshal(modeUsage, &p.ModeUsage); err != nil {
+		return p, fmt.Errorf("failed to decode mode usage: %w", err)
+	}
+	return p, nil
+}
+
+// List returns the reports of passes that ended in [from, to), most recent
+// first, optionally for one aircraft (icao "" for all, matched ignoring
+// case). limit <= 0 returns them all.
+func (r *PassReportRepository) List(ctx context.Context, from, to time.Time, icao string, limit int) ([]PassReport, error) {
+	query := `SELECT ` + passReportColumns + `
+		FROM pass_reports
+		WHERE ended_at >= $1 AND ended_at < $2 AND ($3::text = '' OR lower(icao) = lower($3))
+		ORDER BY ended_at DESC`
+	args := []interface{}{from, to, icao}
+	if limit > 0 {
+		query += ` LIMIT $4`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pass reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []PassReport
+	for rows.Next() {
+		p, err := scanPassReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pass report: %w", err)
+		}
+		reports = append(reports, p)
+	}
+	return reports, rows.Err()
+}
+
+// Get returns one report, or nil if there is none with that ID.
+func (r *PassReportRepository) Get(ctx context.Context, id int64) (*PassReport, error) {
+	p, err := scanPassReport(r.db.QueryRowContext(ctx,
+		`SELECT `+passReportColumns+` FROM pass_reports WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pass report: %w", err)
+	}
+	return &p, nil
+}
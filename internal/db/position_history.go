@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// positionBatchRows caps the rows per INSERT statement, well within
+// PostgreSQL's limit of 65535 parameters
+const positionBatchRows = 500
+
+// positionColumns are the aircraft_positions columns written per row
+var positionColumns = []string{
+	"icao", "timestamp", "latitude", "longitude", "altitude_ft",
+	"ground_speed_kts", "track_deg", "vertical_rate_fpm",
+	"delta_time_seconds", "delta_distance_nm", "delta_altitude_ft", "delta_track_deg",
+	"actual_speed_kts", "actual_vertical_rate_fpm",
+	"range_nm", "altitude_angle_deg", "azimuth_deg",
+}
+
+// positionRow is an aircraft_positions row waiting to be inserted.
+type positionRow struct {
+	aircraft adsb.Aircraft
+	now      time.Time
+	deltas   positionDeltas
+	rangeNM  float64
+	horiz    coordinates.HorizontalCoordinates
+}
+
+// values returns the row's values in positionColumns order.
+func (p positionRow) values() []interface{} {
+	return []interface{}{
+		p.aircraft.ICAO, p.now,
+		p.aircraft.Latitude, p.aircraft.Longitude, p.aircraft.Altitude,
+		p.aircraft.GroundSpeed, p.aircraft.Track, p.aircraft.VerticalRate,
+		p.deltas.time, p.deltas.distance, p.deltas.altitude, p.deltas.track,
+		p.deltas.actualSpeed, p.deltas.actualVerticalRate,
+		p.rangeNM, p.horiz.Altitude, p.horiz.Azimuth,
+	}
+}
+
+// positionBuffer collects position rows from concurrent upserts until they
+// are flushed.
+type positionBuffer struct {
+	mu   sync.Mutex
+	rows []positionRow
+}
+
+func (b *positionBuffer) add(row positionRow) {
+	b.mu.Lock()
+	b.rows = append(b.rows, row)
+	b.mu.Unlock()
+}
+
+// take empties the buffer, returning its rows.
+func (b *positionBuffer) take() []positionRow {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rows := b.rows
+	b.rows = nil
+	return rows
+}
+
+// ConfigurePositionHistory sets how positions are stored by UpsertAircraft.
+// With batch set, positions are buffered until FlushPositionHistory stores
+// them with multi-row inserts, rather than with one insert each; the
+// collector flushes once per cycle. Downsampling applies either way.
+func (r *AircraftRepository) ConfigurePositionHistory(cfg config.PositionHistoryConfig, batch bool) {
+	r.history = cfg
+	if batch && r.positions == nil {
+		r.positions = &positionBuffer{}
+	}
+}
+
+// FlushPositionHistory stores the buffered positions, returning how many
+// were stored. Positions of a failed batch are dropped rather than retried,
+// so a database outage can't grow the buffer without bound.
+func (r *AircraftRepository) FlushPositionHistory(ctx context.Context) (int, error) {
+	if r.positions == nil {
+		return 0, nil
+	}
+
+	rows := r.positions.take()
+	stored := 0
+	for start := 0; start < len(rows); start += positionBatchRows {
+		batch := rows[start:min(start+positionBatchRows, len(rows))]
+		if err := r.insertPositions(ctx, batch); err != nil {
+			return stored, fmt.Errorf("failed to store %d positions: %w", len(rows)-stored, err)
+		}
+		stored += len(batch)
+	}
+	return stored, nil
+}
+
+// insertPositions inserts position rows with a single statement.
+func (r *AircraftRepository) insertPositions(ctx context.Context, rows []positionRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	query.WriteString("INSERT INTO aircraft_positions (")
+	query.WriteString(strings.Join(positionColumns, ", "))
+	query.WriteString(") VALUES ")
+
+	args := make([]interface{}, 0, len(rows)*len(positionColumns))
+	for i, row := range rows {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for j := range positionColumns {
+			if j > 0 {
+				query.WriteString(", ")
+			}
+			fmt.Fprintf(&query, "$%d", len(args)+j+1)
+		}
+		query.WriteString(")")
+		args = append(args, row.values()...)
+	}
+
+	_, err := r.db.ExecContext(ctx, query.String(), args...)
+	return err
+}
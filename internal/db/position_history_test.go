@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestPositionBuffer tests buffering positions until they are taken.
+func TestPositionBuffer(t *testing.T) {
+	var b positionBuffer
+	b.add(positionRow{aircraft: adsb.Aircraft{ICAO: "A1"}})
+	b.add(positionRow{aircraft: adsb.Aircraft{ICAO: "A2"}})
+
+	rows := b.take()
+	if len(rows) != 2 || rows[0].aircraft.ICAO != "A1" || rows[1].aircraft.ICAO != "A2" {
+		t.Fatalf("Expected A1 and A2 in order, got %+v", rows)
+	}
+	if rows := b.take(); len(rows) != 0 {
+		t.Errorf("Expected empty buffer after take, got %d rows", len(rows))
+	}
+}
+
+// TestPositionRowValues tests that a row has a value for each column.
+func TestPositionRowValues(t *testing.T) {
+	if got := len(positionRow{}.values()); got != len(positionColumns) {
+		t.Errorf("Expected %d values, got %d", len(positionColumns), got)
+	}
+}
+
+// TestFlushPositionHistoryUnbatched tests that flushing without batching
+// is a no-op.
+func TestFlushPositionHistoryUnbatched(t *testing.T) {
+	repo := NewAircraftRepository(nil, coordinates.Observer{})
+	repo.ConfigurePositionHistory(config.PositionHistoryConfig{}, false)
+
+	n, err := repo.FlushPositionHistory(context.Background())
+	if n != 0 || err != nil {
+		t.Errorf("Expected nothing flushed, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// Scheduled task kinds
+const (
+	// TaskTrack tracks one aircraft (Target is an ICAO hex code or callsign)
+	TaskTrack = "track"
+
+	// TaskArrivals tracks aircraft arriving at an airport (Target is its ICAO code)
+	TaskArrivals = "arrivals"
+
+	// TaskDepartures tracks aircraft departing an airport (Target is its ICAO code)
+	TaskDepartures = "departures"
+)
+
+// Scheduled task statuses
+const (
+	TaskPending   = "pending"
+	TaskRunning   = "running"
+	TaskCompleted = "completed"
+	TaskFailed    = "failed"
+	TaskCancelled = "cancelled"
+)
+
+// scheduleLockID is the advisory lock key held while checking for conflicts
+// and inserting a task, so two overlapping tasks can't be booked at once.
+const scheduleLockID = 0x7363686564 // "sched"
+
+// ErrScheduleConflict is returned when a task overlaps an already scheduled one.
+var ErrScheduleConflict = errors.New("schedule conflict")
+
+// ScheduledTask is a planned observation window, e.g. "track UAL123 at
+// 21:03" or "capture KCLT arrivals 17:00–18:00".
+type ScheduledTask struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"userId"`
+	Title     string    `json:"title"`
+	Kind      string    `json:"kind"`
+	Target    string    `json:"target"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	// Prediction is how the task's targets are predicted (auto if empty)
+	Prediction tracking.PredictionStrategy `json:"prediction"`
+
+	Status    string    `json:"status"`
+	LastError string    `json:"lastError,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate checks the task before it is stored.
+func (t ScheduledTask) Validate() error {
+	switch t.Kind {
+	case TaskTrack, TaskArrivals, TaskDepartures:
+	default:
+		return fmt.Errorf("unknown task kind %q (expected %s, %s or %s)", t.Kind, TaskTrack, TaskArrivals, TaskDepartures)
+	}
+	if strings.TrimSpace(t.Target) == "" {
+		return errors.New("task target is required")
+	}
+	if t.StartTime.IsZero() || t.EndTime.IsZero() {
+		return errors.New("task start and end times are required")
+	}
+	if !t.EndTime.After(t.StartTime) {
+		return errors.New("task must end after it starts")
+	}
+	if _, err := tracking.ParsePredictionStrategy(string(t.Prediction)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ScheduleConflictError is returned when a task overlaps others.
+type ScheduleConflictError struct {
+	Conflicts []ScheduledTask
+}
+
+func (e *ScheduleConflictError) Error() string {
+	titles := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		titles[i] = fmt.Sprintf("%q (%s–%s)", c.Title,
+			c.StartTime.Format(time.RFC3339), c.EndTime.Format(time.RFC3339))
+	}
+	return "telescope is already scheduled: " + strings.Join(titles, ", ")
+}
+
+// Is reports whether target is ErrScheduleConflict.
+func (e *ScheduleConflictError) Is(target error) bool {
+	return target == ErrScheduleConflict
+}
+
+// ScheduleRepository stores planned observation windows
+type ScheduleRepository struct {
+	db *DB
+}
+
+// NewScheduleRepository creates a new schedule repository
+func NewScheduleRepository(db *DB) *ScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+const scheduleColumns = `id, user_id, title, kind, target, start_time, end_time, prediction,
+		status, COALESCE(last_error, ''), created_at, updated_at`
+
+// scanTask scans a row selected with scheduleColumns.
+func scanTask(row interface{ Scan(...interface{}) error }) (ScheduledTask, error) {
+	var t ScheduledTask
+	err := row.Scan(
+		&t.ID, &t.UserID, &t.Title, &t.Kind, &t.Target, &t.StartTime, &t.EndTime, &t.Prediction,
+		&t.Status, &t.LastError, &t.CreatedAt, &t.UpdatedAt,
+	)
+	return t, err
+}
+
+// queryTasks runs a query selecting scheduleColumns.
+func queryTasks(ctx context.Context, q interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}, query string, args ...interface{}) ([]ScheduledTask, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedule: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []ScheduledTask
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled task: %w", err)
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// Upcoming returns pending and running tasks that end after since, earliest first.
+func (r *ScheduleRepository) Upcoming(ctx context.Context, since time.Time) ([]ScheduledTask, error) {
+	return queryTasks(ctx, r.db,
+		`SELECT `+scheduleColumns+`
+		 FROM observation_schedule
+		 WHERE status IN ('pending', 'running') AND end_time > $1
+		 ORDER BY start_time ASC`,
+		since,
+	)
+}
+
+// Due returns the task whose window contains now, or nil if none is due.
+func (r *ScheduleRepository) Due(ctx context.Context, now time.Time) (*ScheduledTask, error) {
+	t, err := scanTask(r.db.QueryRowContext(ctx,
+		`SELECT `+scheduleColumns+`
+		 FROM observation_schedule
+		 WHERE status IN ('pending', 'running') AND start_time <= $1 AND end_time > $1
+		 ORDER BY start_time ASC
+		 LIMIT 1`,
+		now,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due task: %w", err)
+	}
+	return &t, nil
+}
+
+// Expired returns pending and running tasks whose window ended before now.
+func (r *ScheduleRepository) Expired(ctx context.Context, now time.Time) ([]ScheduledTask, error) {
+	return queryTasks(ctx, r.db,
+		`SELECT `+scheduleColumns+`
+		 FROM observation_schedule
+		 WHERE status IN ('pending', 'running') AND end_time <= $1
+		 ORDER BY start_time ASC`,
+		now,
+	)
+}
+
+// Create validates and stores a task. It returns a *ScheduleConflictError if
+// the window overlaps another pending or running task (windows that merely
+// touch, one ending as the other starts, do not conflict).
+func (r *ScheduleRepository) Create(ctx context.Context, task *ScheduledTask) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, scheduleLockID); err != nil {
+		return fmt.Errorf("failed to lock schedule: %w", err)
+	}
+
+	conflicts, err := queryTasks(ctx, tx,
+		`SELECT `+scheduleColumns+`
+		 FROM observation_schedule
+		 WHERE status IN ('pending', 'running') AND start_time < $2 AND end_time > $1
+		 ORDER BY start_time ASC`,
+		task.StartTime, task.EndTime,
+	)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+
+	task.Status = TaskPending
+	task.Prediction, _ = tracking.ParsePredictionStrategy(string(task.Prediction)) // Validated above
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO observation_schedule (user_id, title, kind, target, start_time, end_time, prediction, status)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id, created_at, updated_at`,
+		task.UserID, task.Title, task.Kind, strings.TrimSpace(task.Target),
+		task.StartTime, task.EndTime, task.Prediction, task.Status,
+	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled task: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Cancel cancels a pending or running task owned by userID. Admins may
+// cancel any user's task.
+func (r *ScheduleRepository) Cancel(ctx context.Context, taskID, userID int, admin bool) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE observation_schedule
+		 SET status = 'cancelled', updated_at = NOW()
+		 WHERE id = $1 AND ($3 OR user_id = $2) AND status IN ('pending', 'running')`,
+		taskID, userID, admin,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("scheduled task not found")
+	}
+
+	return nil
+}
+
+// SetStatus records the progress of a task. errorMessage is stored for
+// failed tasks.
+func (r *ScheduleRepository) SetStatus(ctx context.Context, taskID int, status, errorMessage string) error {
+	var lastError sql.NullString
+	if errorMessage != "" {
+		lastError = sql.NullString{String: errorMessage, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE observation_schedule
+		 SET status = $2, last_error = $3, updated_at = NOW()
+		 WHERE id = $1`,
+		taskID, status, lastError,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled task: %w", err)
+	}
+	return nil
+}
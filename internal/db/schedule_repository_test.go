@@ -0,0 +1,55 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// TestScheduledTaskValidate tests task validation.
+func TestScheduledTaskValidate(t *testing.T) {
+	start := time.Date(2026, 6, 1, 21, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		task    ScheduledTask
+		wantErr bool
+	}{
+		{"Valid track", ScheduledTask{Kind: TaskTrack, Target: "A1B2C3", StartTime: start, EndTime: start.Add(10 * time.Minute)}, false},
+		{"Valid arrivals", ScheduledTask{Kind: TaskArrivals, Target: "KCLT", StartTime: start, EndTime: start.Add(time.Hour)}, false},
+		{"Unknown kind", ScheduledTask{Kind: "photograph", Target: "KCLT", StartTime: start, EndTime: start.Add(time.Hour)}, true},
+		{"Missing target", ScheduledTask{Kind: TaskTrack, Target: "  ", StartTime: start, EndTime: start.Add(time.Hour)}, true},
+		{"Missing times", ScheduledTask{Kind: TaskTrack, Target: "A1B2C3"}, true},
+		{"Ends before start", ScheduledTask{Kind: TaskDepartures, Target: "KCLT", StartTime: start, EndTime: start.Add(-time.Minute)}, true},
+		{"Flight plan prediction", ScheduledTask{Kind: TaskTrack, Target: "A1B2C3", StartTime: start, EndTime: start.Add(time.Hour), Prediction: tracking.StrategyFlightPlan}, false},
+		{"Unknown prediction", ScheduledTask{Kind: TaskTrack, Target: "A1B2C3", StartTime: start, EndTime: start.Add(time.Hour), Prediction: "kalman"}, true},
+		{"Zero length", ScheduledTask{Kind: TaskDepartures, Target: "KCLT", StartTime: start, EndTime: start}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.task.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestScheduleConflictError tests the conflict error.
+func TestScheduleConflictError(t *testing.T) {
+	start := time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC)
+	var err error = &ScheduleConflictError{Conflicts: []ScheduledTask{
+		{Title: "KCLT arrivals", StartTime: start, EndTime: start.Add(time.Hour)},
+	}}
+
+	if !errors.Is(err, ErrScheduleConflict) {
+		t.Error("Expected errors.Is(err, ErrScheduleConflict)")
+	}
+	if !strings.Contains(err.Error(), "KCLT arrivals") {
+		t.Errorf("Expected conflicting task in message, got %q", err.Error())
+	}
+}
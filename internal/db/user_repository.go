@@ -218,6 +218,27 @@ func (r *UserRepository) Update(ctx context.Context, user *User) error {
 	return nil
 }
 
+// UpdatePassword replaces a user's password hash
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, passwordHash, userID)
+	if err != nil {
+		return err
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+	
+	return nil
+}
+
 // Delete deletes a user from the database
 func (r *UserRepository) Delete(ctx context.Context, userID int) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// Watch list entry kinds
+const (
+	// WatchICAO matches an aircraft's 24-bit ICAO address (hex)
+	WatchICAO = "icao"
+
+	// WatchRegistration matches an aircraft's registration as reported by
+	// the feed, or, when the feed doesn't know it, an aircraft broadcasting
+	// its registration as its callsign, as most general aviation aircraft do
+	WatchRegistration = "registration"
+)
+
+// maxWatchNoteLength matches the watched_aircraft.note column
+const maxWatchNoteLength = 100
+
+// ErrWatchNotFound is returned when a watch list entry cannot be found
+var ErrWatchNotFound = errors.New("watch list entry not found")
+
+// WatchedAircraft is an aircraft on a user's watch list.
+type WatchedAircraft struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"userId"`
+	Username  string    `json:"username,omitempty"`
+	Kind      string    `json:"kind"`
+	Target    string    `json:"target"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NormalizeWatchTarget converts a target as typed by a user to the form it
+// is stored and matched in: upper case, and for registrations without
+// dashes or spaces ("n-123ab" becomes "N123AB", as it is broadcast).
+func NormalizeWatchTarget(kind, target string) string {
+	target = strings.ToUpper(strings.TrimSpace(target))
+	if kind == WatchRegistration {
+		target = strings.NewReplacer("-", "", " ", "").Replace(target)
+	}
+	return target
+}
+
+// Validate checks the entry before it is stored. Its target must already be
+// normalized.
+func (w WatchedAircraft) Validate() error {
+	switch w.Kind {
+	case WatchICAO:
+		if len(w.Target) != 6 || strings.Trim(w.Target, "0123456789ABCDEF") != "" {
+			return fmt.Errorf("invalid ICAO address %q (expected 6 hex digits)", w.Target)
+		}
+	case WatchRegistration:
+		if w.Target == "" || len(w.Target) > 8 {
+			return fmt.Errorf("invalid registration %q", w.Target)
+		}
+	default:
+		return fmt.Errorf("unknown watch kind %q (expected %s or %s)", w.Kind, WatchICAO, WatchRegistration)
+	}
+	if len(w.Note) > maxWatchNoteLength {
+		return errors.New("note is too long")
+	}
+	return nil
+}
+
+// Matches reports whether the entry matches an aircraft.
+func (w WatchedAircraft) Matches(ac adsb.Aircraft) bool {
+	switch w.Kind {
+	case WatchICAO:
+		return strings.EqualFold(ac.ICAO, w.Target)
+	case WatchRegistration:
+		registration := ac.Registration
+		if registration == "" {
+			registration = ac.Callsign
+		}
+		return NormalizeWatchTarget(WatchRegistration, registration) == w.Target
+	}
+	return false
+}
+
+// WatchList is a set of watch list entries, e.g. all users' entries.
+type WatchList []WatchedAircraft
+
+// Match returns the entries matching an aircraft.
+func (l WatchList) Match(ac adsb.Aircraft) []WatchedAircraft {
+	var matches []WatchedAircraft
+	for _, w := range l {
+		if w.Matches(ac) {
+			matches = append(matches, w)
+		}
+	}
+	return matches
+}
+
+// Watches reports whether any entry matches an aircraft.
+func (l WatchList) Watches(ac adsb.Aircraft) bool {
+	for _, w := range l {
+		if w.Matches(ac) {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchRepository stores users' watch lists
+type WatchRepository struct {
+	db *DB
+}
+
+// NewWatchRepository creates a new watch list repository
+func NewWatchRepository(db *DB) *WatchRepository {
+	return &WatchRepository{db: db}
+}
+
+const watchColumns = `w.id, w.user_id, u.username, w.kind, w.target,
+		COALESCE(w.note, ''), w.created_at`
+
+// queryWatchList runs a query selecting watchColumns.
+func (r *WatchRepository) queryWatchList(ctx context.Context, query string, args ...interface{}) (WatchList, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watch list: %w", err)
+	}
+	defer rows.Close()
+
+	var list WatchList
+	for rows.Next() {
+		var w WatchedAircraft
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Username, &w.Kind, &w.Target, &w.Note, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan watch list entry: %w", err)
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+// List returns a user's watch list, oldest entries first.
+func (r *WatchRepository) List(ctx context.Context, userID int) (WatchList, error) {
+	return r.queryWatchList(ctx,
+		`SELECT `+watchColumns+`
+		 FROM watched_aircraft w
+		 JOIN users u ON u.id = w.user_id
+		 WHERE w.user_id = $1
+		 ORDER BY w.created_at ASC, w.id ASC`,
+		userID,
+	)
+}
+
+// All returns every user's watch list entries, for notifications and for
+// clients without a user such as the terminal viewers.
+func (r *WatchRepository) All(ctx context.Context) (WatchList, error) {
+	return r.queryWatchList(ctx,
+		`SELECT `+watchColumns+`
+		 FROM watched_aircraft w
+		 JOIN users u ON u.id = w.user_id AND u.is_active
+		 ORDER BY w.user_id ASC, w.created_at ASC, w.id ASC`,
+	)
+}
+
+// Add normalizes, validates and stores an entry. Watching an aircraft that
+// is already on the user's list updates its note.
+func (r *WatchRepository) Add(ctx context.Context, w *WatchedAircraft) error {
+	w.Target = NormalizeWatchTarget(w.Kind, w.Target)
+	w.Note = strings.TrimSpace(w.Note)
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	var note sql.NullString
+	if w.Note != "" {
+		note = sql.NullString{String: w.Note, Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO watched_aircraft (user_id, kind, target, note)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (user_id, kind, target) DO UPDATE SET note = EXCLUDED.note
+		 RETURNING id, created_at`,
+		w.UserID, w.Kind, w.Target, note,
+	).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add watched aircraft: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes an entry from a user's watch list.
+func (r *WatchRepository) Remove(ctx context.Context, userID, id int) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM watched_aircraft WHERE id = $1 AND user_id = $2`,
+		id, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove watched aircraft: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrWatchNotFound
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// TestNormalizeWatchTarget tests normalizing typed watch list targets.
+func TestNormalizeWatchTarget(t *testing.T) {
+	tests := []struct {
+		kind, target, expected string
+	}{
+		{WatchICAO, " a1b2c3 ", "A1B2C3"},
+		{WatchRegistration, "n-123ab", "N123AB"},
+		{WatchRegistration, "G-ABCD", "GABCD"},
+		{WatchRegistration, "D EABC", "DEABC"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeWatchTarget(tt.kind, tt.target); got != tt.expected {
+			t.Errorf("NormalizeWatchTarget(%q, %q) = %q, expected %q", tt.kind, tt.target, got, tt.expected)
+		}
+	}
+}
+
+// TestWatchedAircraftValidate tests watch list entry validation.
+func TestWatchedAircraftValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		watch   WatchedAircraft
+		wantErr bool
+	}{
+		{"ICAO address", WatchedAircraft{Kind: WatchICAO, Target: "A1B2C3"}, false},
+		{"Short ICAO address", WatchedAircraft{Kind: WatchICAO, Target: "A1B2"}, true},
+		{"Non-hex ICAO address", WatchedAircraft{Kind: WatchICAO, Target: "A1B2CZ"}, true},
+		{"Registration", WatchedAircraft{Kind: WatchRegistration, Target: "N123AB"}, false},
+		{"Empty registration", WatchedAircraft{Kind: WatchRegistration}, true},
+		{"Unknown kind", WatchedAircraft{Kind: "callsign", Target: "UAL123"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.watch.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestWatchListMatch tests matching aircraft against watch list entries.
+func TestWatchListMatch(t *testing.T) {
+	list := WatchList{
+		{ID: 1, Kind: WatchICAO, Target: "A1B2C3"},
+		{ID: 2, Kind: WatchRegistration, Target: "N123AB"},
+		{ID: 3, Kind: WatchICAO, Target: "ABCDEF"},
+		{ID: 4, Kind: WatchRegistration, Target: "GEUPT"},
+	}
+
+	tests := []struct {
+		name     string
+		aircraft adsb.Aircraft
+		expected []int
+	}{
+		{"By ICAO address", adsb.Aircraft{ICAO: "a1b2c3", Callsign: "UAL123"}, []int{1}},
+		{"By registration", adsb.Aircraft{ICAO: "a00001", Callsign: "N123AB  "}, []int{2}},
+		{"By reported registration", adsb.Aircraft{ICAO: "400abc", Callsign: "BAW123", Registration: "G-EUPT"}, []int{4}},
+		{"Reported registration overrides callsign", adsb.Aircraft{ICAO: "a00001", Callsign: "N123AB", Registration: "N123AC"}, nil},
+		{"By both", adsb.Aircraft{ICAO: "abcdef", Callsign: "N123AB"}, []int{2, 3}},
+		{"Not watched", adsb.Aircraft{ICAO: "a00001", Callsign: "N123AC"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := list.Match(tt.aircraft)
+			if len(matches) != len(tt.expected) {
+				t.Fatalf("Expected %d matches, got %+v", len(tt.expected), matches)
+			}
+			for i, m := range matches {
+				if m.ID != tt.expected[i] {
+					t.Errorf("Expected match %d, got %d", tt.expected[i], m.ID)
+				}
+			}
+			if got := list.Watches(tt.aircraft); got != (len(tt.expected) > 0) {
+				t.Errorf("Expected Watches %v, got %v", len(tt.expected) > 0, got)
+			}
+		})
+	}
+}
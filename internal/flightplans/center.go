@@ -0,0 +1,69 @@
+package flightplans
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
+)
+
+// centerStep is the interval at which tracks are extrapolated when checking
+// whether they enter the observer's center
+const centerStep = time.Minute
+
+// centerScope limits plan fetches to flights transiting the observer's
+// ARTCC or FIR.
+type centerScope struct {
+	centers   navdata.Centers
+	center    string // Name of the observer's center
+	lookahead time.Duration
+}
+
+// centerScope returns the scope for plan fetches, or nil to fetch for every
+// flight: when no lookahead is configured, no center boundaries have been
+// imported, or the observer is in none of them.
+func (w *Worker) centerScope(ctx context.Context) (*centerScope, error) {
+	if w.cfg.CenterLookaheadMinutes <= 0 {
+		return nil, nil
+	}
+
+	centers, err := db.NewNavdataRepository(w.db).Centers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The observer is on the ground, in the center's lowest stratum
+	loc := w.observer.Location
+	center := centers.Locate(loc.Latitude, loc.Longitude, 0)
+	if center == "" {
+		log.Println("Observer is in no imported ARTCC/FIR; fetching plans for every flight")
+		return nil, nil
+	}
+
+	return &centerScope{
+		centers:   centers,
+		center:    center,
+		lookahead: time.Duration(w.cfg.CenterLookaheadMinutes) * time.Minute,
+	}, nil
+}
+
+// transits reports whether an aircraft is in the observer's center, or is
+// predicted to enter it within the lookahead by extrapolating its track at
+// its current altitude and ground speed.
+func (s *centerScope) transits(pos coordinates.Geographic, altitudeFt, speedKts, trackDeg float64) bool {
+	if s.centers.Locate(pos.Latitude, pos.Longitude, altitudeFt) == s.center {
+		return true
+	}
+	if speedKts <= 0 {
+		return false
+	}
+	for t := centerStep; t <= s.lookahead; t += centerStep {
+		p := coordinates.Destination(pos, trackDeg, speedKts*t.Hours())
+		if s.centers.Locate(p.Latitude, p.Longitude, altitudeFt) == s.center {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package flightplans
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/navdata"
+)
+
+// TestCenterScopeTransits tests which flights are predicted to transit the
+// observer's center.
+func TestCenterScopeTransits(t *testing.T) {
+	scope := &centerScope{
+		centers: navdata.Centers{
+			{Name: "HOME", Upper: navdata.Limit{Feet: 60000}, Boundary: [][2]float64{{30, -100}, {30, -90}, {40, -90}, {40, -100}}},
+			{Name: "NEXT", Upper: navdata.Limit{Feet: 60000}, Boundary: [][2]float64{{30, -90}, {30, -80}, {40, -80}, {40, -90}}},
+		},
+		center:    "HOME",
+		lookahead: 30 * time.Minute,
+	}
+
+	// At 480 knots a flight covers 240 NM in 30 minutes, about 5 degrees of
+	// longitude at 35N
+	tests := []struct {
+		name     string
+		lon      float64
+		speedKts float64
+		trackDeg float64
+		want     bool
+	}{
+		{"Inside", -95, 0, 0, true},
+		{"Entering within the lookahead", -88, 480, 270, true},
+		{"Leaving the neighbour eastbound", -88, 480, 90, false},
+		{"Too far to enter", -81, 480, 270, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := coordinates.Geographic{Latitude: 35, Longitude: tt.lon}
+			if got := scope.transits(pos, 35000, tt.speedKts, tt.trackDeg); got != tt.want {
+				t.Errorf("transits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
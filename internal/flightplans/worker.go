@@ -0,0 +1,461 @@
+// Package flightplans keeps the flight plans of active aircraft up to date
+// from a flightroute.Provider. Its Worker runs inside the collector, sharing
+// its database connection, or on its own in cmd/fetch-flightplans.
+package flightplans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/internal/db"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/flightaware"
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// Enabled reports whether the configuration asks for flight plans to be
+// fetched automatically.
+func Enabled(cfg config.FlightAwareConfig) bool {
+	return cfg.Enabled && cfg.AutoFetchEnabled
+}
+
+// NewFlightAwareProvider creates the FlightAware client as a route provider,
+// with usage counted against the monthly budget in the database.
+func NewFlightAwareProvider(database *db.DB, cfg config.FlightAwareConfig) (flightroute.Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("FlightAware API key not configured: set 'flightaware.api_key' or ADS_BSCOPE_FLIGHTAWARE_API_KEY")
+	}
+
+	quota := flightaware.NewQuota(flightaware.BudgetFromConfig(cfg), db.NewAPIUsageRepository(database, flightaware.Source))
+	return flightaware.NewClient(flightaware.Config{
+		APIKey:          cfg.APIKey,
+		RequestsPerHour: cfg.RequestsPerHour,
+		Timeout:         10 * time.Second,
+		Quota:           quota,
+	}), nil
+}
+
+// LogSettings logs the worker's rate limit, budget and optional tasks.
+func LogSettings(cfg config.FlightAwareConfig) {
+	log.Printf("API Rate Limit: %d requests/hour\n", cfg.RequestsPerHour)
+	log.Printf("Fetch Interval: %d minutes\n", cfg.FetchIntervalMinutes)
+	if budget := flightaware.BudgetFromConfig(cfg); budget.Limited() {
+		log.Printf("Monthly Budget: %d requests, %.2f cost\n", budget.MonthlyCalls, budget.MonthlyCost)
+	}
+	if cfg.RerouteThresholdNM > 0 {
+		log.Printf("Reroute Check: %.0f NM off route for %d minutes\n",
+			cfg.RerouteThresholdNM, cfg.RerouteMinutes)
+	}
+	if cfg.CenterLookaheadMinutes > 0 {
+		log.Printf("Center Scope: flights entering the observer's center within %d minutes\n",
+			cfg.CenterLookaheadMinutes)
+	}
+	if cfg.PositionsEnabled {
+		log.Printf("Position Fill: every %d minutes after %ds without ADS-B\n",
+			cfg.PositionIntervalMinutes, cfg.PositionGapSeconds)
+	}
+}
+
+// Worker periodically fetches flight plans for active aircraft, checks them
+// for reroutes and, if enabled, fills ADS-B coverage gaps with provider
+// positions.
+//
+// Run blocks until its context is cancelled. Start and Stop run it in the
+// background, for services that host the worker alongside other work.
+type Worker struct {
+	db       *db.DB
+	provider flightroute.Provider
+	fpRepo   *db.FlightPlanRepository
+	acRepo   *db.AircraftRepository
+	cfg      config.FlightAwareConfig
+	observer coordinates.Observer
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // nil unless started
+	done   chan struct{}      // closed when the started worker returns
+}
+
+// NewWorker creates a worker storing plans from provider in database.
+// Aircraft positions are relative to observer.
+func NewWorker(database *db.DB, provider flightroute.Provider, cfg config.FlightAwareConfig, observer coordinates.Observer) *Worker {
+	return &Worker{
+		db:       database,
+		provider: provider,
+		fpRepo:   db.NewFlightPlanRepository(database),
+		acRepo:   db.NewAircraftRepository(database, observer),
+		cfg:      cfg,
+		observer: observer,
+	}
+}
+
+// Start runs the worker in the background until Stop is called or ctx is
+// cancelled. Starting a running worker returns an error.
+func (w *Worker) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return errors.New("flight plan worker already running")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	w.cancel, w.done = cancel, done
+
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+	return nil
+}
+
+// Stop cancels a started worker and waits for the task in progress to
+// finish. Stopping a worker that is not running does nothing.
+func (w *Worker) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Run fetches flight plans at once and then on each interval, until ctx is
+// cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(w.cfg.FetchIntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	// Positions are only polled if enabled; a nil channel never fires
+	var positionTicks <-chan time.Time
+	if w.cfg.PositionsEnabled {
+		positionTicker := time.NewTicker(time.Duration(w.cfg.PositionIntervalMinutes) * time.Minute)
+		defer positionTicker.Stop()
+		positionTicks = positionTicker.C
+	}
+
+	// Route conformance is checked every minute unless disabled
+	var conformanceTicks <-chan time.Time
+	if w.cfg.RerouteThresholdNM > 0 {
+		conformanceTicker := time.NewTicker(conformanceInterval)
+		defer conformanceTicker.Stop()
+		conformanceTicks = conformanceTicker.C
+	}
+
+	// Run immediately on startup
+	w.run(ctx, "fetching flight plans", w.fetchFlightPlans)
+
+	// Then run periodically
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx, "fetching flight plans", w.fetchFlightPlans)
+		case <-positionTicks:
+			w.run(ctx, "filling coverage gaps", w.fillCoverageGaps)
+		case <-conformanceTicks:
+			w.run(ctx, "checking route conformance", w.checkRouteConformance)
+		}
+	}
+}
+
+// run performs one task, logging its error. A panic is logged rather than
+// taking down the hosting service; the task runs again on its next tick.
+func (w *Worker) run(ctx context.Context, name string, task func(context.Context) error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("PANIC %s: %v\n%s", name, r, debug.Stack())
+		}
+	}()
+
+	if err := task(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Error %s: %v", name, err)
+	}
+}
+
+// fetchFlightPlans retrieves flight plans for all active aircraft, those most
+// likely to be tracked first so a limited budget is spent where it matters.
+// With a center lookahead, aircraft that won't transit the observer's center
+// are left out.
+func (w *Worker) fetchFlightPlans(ctx context.Context) error {
+	scope, err := w.centerScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Query for active aircraft (seen in last 5 minutes, have callsign)
+	rows, err := w.db.QueryContext(ctx,
+		`SELECT DISTINCT icao, callsign, last_seen,
+		        COALESCE(is_trackable, FALSE), COALESCE(is_approaching, FALSE),
+		        COALESCE(closest_range_nm, 0), COALESCE(eta_closest_seconds, 0),
+		        latitude, longitude, COALESCE(altitude_ft, 0),
+		        COALESCE(ground_speed_kts, 0), COALESCE(track_deg, 0)
+		 FROM aircraft
+		 WHERE is_visible = TRUE 
+		   AND callsign IS NOT NULL 
+		   AND callsign != ''
+		   AND last_seen > NOW() - INTERVAL '5 minutes'
+		 ORDER BY last_seen DESC`,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query aircraft: %w", err)
+	}
+	defer rows.Close()
+
+	var aircraft []flightroute.Candidate
+	outside := 0
+
+	for rows.Next() {
+		var ac flightroute.Candidate
+		var lastSeen time.Time
+		var etaSeconds int
+		var pos coordinates.Geographic
+		var altitudeFt, speedKts, trackDeg float64
+		if err := rows.Scan(&ac.ICAO, &ac.Callsign, &lastSeen,
+			&ac.Trackable, &ac.Approaching, &ac.ClosestRangeNM, &etaSeconds,
+			&pos.Latitude, &pos.Longitude, &altitudeFt, &speedKts, &trackDeg); err != nil {
+			return fmt.Errorf("failed to scan aircraft: %w", err)
+		}
+		if scope != nil && !scope.transits(pos, altitudeFt, speedKts, trackDeg) {
+			outside++
+			continue
+		}
+		ac.TimeToClosest = time.Duration(etaSeconds) * time.Second
+		aircraft = append(aircraft, ac)
+	}
+
+	if outside > 0 {
+		log.Printf("Skipping %d aircraft not transiting %s\n", outside, scope.center)
+	}
+	if len(aircraft) == 0 {
+		log.Println("No active aircraft found with callsigns")
+		return nil
+	}
+
+	// With a budget, spend it on the aircraft most likely to be tracked
+	flightroute.Prioritize(aircraft)
+
+	log.Printf("Found %d active aircraft with callsigns\n", len(aircraft))
+
+	// Fetch flight plans for each aircraft
+	successCount := 0
+	notFoundCount := 0
+	errorCount := 0
+
+candidates:
+	for _, ac := range aircraft {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Check if we already have a recent flight plan (within last hour)
+		existing, err := w.fpRepo.GetFlightPlanByICAO(ctx, ac.ICAO)
+		if err != nil {
+			log.Printf("Error checking existing plan for %s: %v", ac.Callsign, err)
+		}
+
+		if existing != nil && time.Since(existing.LastUpdated) < time.Hour {
+			log.Printf("  ✓ %s (%s) - Using cached flight plan", ac.Callsign, ac.ICAO)
+			continue
+		}
+
+		// Fetch from the provider
+		log.Printf("  → Fetching flight plan for %s (%s)...", ac.Callsign, ac.ICAO)
+
+		found, err := w.fetchFlightPlan(ctx, ac.ICAO, ac.Callsign)
+		switch {
+		case errors.Is(err, flightroute.ErrQuotaExceeded):
+			log.Printf("    - Today's %s budget is used up; skipping the rest", w.provider.Name())
+			break candidates
+		case err != nil:
+			log.Printf("    ✗ Error: %v", err)
+			errorCount++
+		case !found:
+			log.Printf("    - No flight plan found")
+			notFoundCount++
+		default:
+			successCount++
+		}
+	}
+
+	log.Println("\n===========================================")
+	log.Printf("Fetch Summary:\n")
+	log.Printf("  Success: %d\n", successCount)
+	log.Printf("  Not Found: %d\n", notFoundCount)
+	log.Printf("  Errors: %d\n", errorCount)
+	log.Println("===========================================")
+
+	return nil
+}
+
+// fetchFlightPlan fetches an aircraft's flight plan from the provider and
+// stores it with its resolved route. Returns false if the provider has no
+// plan for the callsign.
+func (w *Worker) fetchFlightPlan(ctx context.Context, icao, callsign string) (bool, error) {
+	flightPlan, err := w.provider.GetRouteByCallsign(ctx, callsign)
+	if err != nil {
+		return false, err
+	}
+	if flightPlan == nil {
+		return false, nil
+	}
+
+	// Store in database
+	fp := db.FlightPlan{
+		ICAO:          icao,
+		Callsign:      flightPlan.Callsign,
+		DepartureICAO: flightPlan.Departure,
+		ArrivalICAO:   flightPlan.Arrival,
+		Route:         flightPlan.Route,
+		FiledAltitude: flightPlan.FiledAltitude,
+		AircraftType:  flightPlan.AircraftType,
+		FiledTime:     flightPlan.FiledTime,
+		ETD:           flightPlan.ETD,
+		ETA:           flightPlan.ETA,
+		LastUpdated:   time.Now(),
+		FAFlightID:    flightPlan.FlightID,
+	}
+
+	fpID, err := w.fpRepo.UpsertFlightPlan(ctx, fp)
+	if err != nil {
+		return false, fmt.Errorf("failed to store: %w", err)
+	}
+
+	// Parse and store route waypoints
+	if flightPlan.Route != "" {
+		waypointCount, err := w.fpRepo.ParseAndStoreRoute(ctx, fpID, flightPlan.Route)
+		if err != nil {
+			log.Printf("    ⚠ Route parsing error: %v", err)
+		} else {
+			log.Printf("    ✓ Stored: %s → %s (%d waypoints)",
+				flightPlan.Departure, flightPlan.Arrival, waypointCount)
+		}
+	} else {
+		log.Printf("    ✓ Stored: %s → %s (no route string)",
+			flightPlan.Departure, flightPlan.Arrival)
+	}
+
+	return true, nil
+}
+
+// conformanceInterval is how often aircraft are checked against their routes
+const conformanceInterval = time.Minute
+
+// checkRouteConformance measures how far each visible aircraft is from its
+// flight plan route. An aircraft that stays more than the reroute threshold
+// off route for long enough is flagged as rerouted and its plan re-fetched at
+// once, rather than waiting for the cached plan to expire.
+func (w *Worker) checkRouteConformance(ctx context.Context) error {
+	thresholdNM := w.cfg.RerouteThresholdNM
+	persist := time.Duration(w.cfg.RerouteMinutes) * time.Minute
+
+	aircraft, err := w.fpRepo.GetRoutedAircraft(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, ac := range aircraft {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		waypoints, err := w.fpRepo.GetFlightPlanRoute(ctx, ac.FlightPlanID)
+		if err != nil {
+			log.Printf("Error getting route for %s (%s): %v", ac.Callsign, ac.ICAO, err)
+			continue
+		}
+
+		route := make([]coordinates.Geographic, len(waypoints))
+		for i, wp := range waypoints {
+			route[i] = coordinates.Geographic{Latitude: wp.Latitude, Longitude: wp.Longitude}
+		}
+		deviation := tracking.RouteDeviationNM(coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}, route)
+
+		conformance := tracking.RouteConformance{OffRouteSince: ac.OffRouteSince, Rerouted: ac.Rerouted}
+		conformance, rerouted := conformance.Update(deviation, thresholdNM, persist, now)
+		if err := w.fpRepo.UpdateRouteConformance(ctx, ac.FlightPlanID, deviation, conformance.OffRouteSince, conformance.Rerouted); err != nil {
+			log.Printf("Error updating route conformance for %s (%s): %v", ac.Callsign, ac.ICAO, err)
+			continue
+		}
+		if !rerouted {
+			continue
+		}
+
+		log.Printf("  ↻ %s (%s) is %.0f NM off its route - re-fetching flight plan", ac.Callsign, ac.ICAO, deviation)
+		if _, err := w.fetchFlightPlan(ctx, ac.ICAO, ac.Callsign); err != nil {
+			log.Printf("    ✗ Error: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// positionMaxAge is how long after ADS-B reception lapses positions are still
+// requested from the w.provider. Beyond this the flight has left the area.
+const positionMaxAge = 30 * time.Minute
+
+// fillCoverageGaps ingests provider positions for flights of interest whose
+// ADS-B reception has lapsed for the configured gap. Positions no newer than
+// the last one received, and positions the provider projected itself, are
+// skipped.
+func (w *Worker) fillCoverageGaps(ctx context.Context) error {
+	gap := time.Duration(w.cfg.PositionGapSeconds) * time.Second
+
+	gaps, err := w.fpRepo.FindCoverageGaps(ctx, gap, positionMaxAge)
+	if err != nil {
+		return err
+	}
+	if len(gaps) == 0 {
+		return nil
+	}
+
+	log.Printf("Filling %d ADS-B coverage gaps from %s\n", len(gaps), w.provider.Name())
+
+	for _, g := range gaps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		positions, err := w.provider.GetPositions(ctx, g.FAFlightID)
+		if errors.Is(err, flightroute.ErrQuotaExceeded) {
+			log.Printf("  - Today's %s budget is used up; skipping the rest", w.provider.Name())
+			return nil
+		}
+		if err != nil {
+			log.Printf("  ✗ %s (%s): %v", g.Callsign, g.ICAO, err)
+			continue
+		}
+		if len(positions) == 0 {
+			log.Printf("  - %s (%s): no newer position", g.Callsign, g.ICAO)
+			continue
+		}
+		pos := positions[len(positions)-1]
+		if pos.Projected || !pos.Timestamp.After(g.LastSeen) {
+			log.Printf("  - %s (%s): no newer position", g.Callsign, g.ICAO)
+			continue
+		}
+
+		ac := pos.Aircraft(g.ICAO, g.Callsign, w.provider.Name())
+		if err := w.acRepo.UpsertAircraft(ctx, ac, pos.Timestamp.UTC(), w.provider.Name()); err != nil {
+			log.Printf("  ✗ %s (%s): failed to store position: %v", g.Callsign, g.ICAO, err)
+			continue
+		}
+		log.Printf("  ✓ %s (%s): position from %s ago",
+			g.Callsign, g.ICAO, time.Since(pos.Timestamp).Round(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package flightplans
+
+import (
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestEnabled tests which configurations fetch flight plans automatically.
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.FlightAwareConfig
+		expected bool
+	}{
+		{"Disabled", config.FlightAwareConfig{}, false},
+		{"Enabled without auto fetch", config.FlightAwareConfig{Enabled: true}, false},
+		{"Auto fetch without FlightAware", config.FlightAwareConfig{AutoFetchEnabled: true}, false},
+		{"Auto fetch", config.FlightAwareConfig{Enabled: true, AutoFetchEnabled: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enabled(tt.cfg); got != tt.expected {
+				t.Errorf("Enabled() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNewFlightAwareProvider tests that an API key is required.
+func TestNewFlightAwareProvider(t *testing.T) {
+	if _, err := NewFlightAwareProvider(nil, config.FlightAwareConfig{Enabled: true}); err == nil {
+		t.Error("Expected an error without an API key")
+	}
+
+	provider, err := NewFlightAwareProvider(nil, config.FlightAwareConfig{Enabled: true, APIKey: "key", RequestsPerHour: 10})
+	if err != nil {
+		t.Fatalf("NewFlightAwareProvider(): %v", err)
+	}
+	if provider.Name() != "flightaware" {
+		t.Errorf("Name() = %q, expected flightaware", provider.Name())
+	}
+}
+
+// TestStopNotStarted tests that stopping an idle worker does nothing.
+func TestStopNotStarted(t *testing.T) {
+	w := NewWorker(nil, nil, config.FlightAwareConfig{}, coordinates.Observer{})
+	w.Stop()
+	w.Stop()
+}
@@ -1,6 +1,10 @@
 package adsb
 
-import "time"
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
 
 // Aircraft represents an aircraft tracked via ADS-B.
 // All position data is in WGS84 coordinate system.
@@ -19,7 +23,7 @@ type Aircraft struct {
 
 	// Altitude in feet above mean sea level (MSL)
 	// Note: Some aircraft report geometric altitude, others barometric
-	Altitude float64
+	Altitude units.Feet
 
 	// GroundSpeed in knots
 	GroundSpeed float64
@@ -31,6 +35,40 @@ type Aircraft struct {
 	// VerticalRate in feet per minute (positive = climbing, negative = descending)
 	VerticalRate float64
 
+	// AircraftType is the ICAO type designator (e.g., "B738")
+	// Empty if the source did not report the type
+	AircraftType string
+
+	// Registration is the tail number (e.g., "G-EUPT"), from the source's
+	// aircraft database
+	// Empty if the source did not report it
+	Registration string
+
+	// Squawk is the 4-digit octal transponder code (e.g., "1200")
+	// Empty if the source did not report a squawk
+	Squawk string
+
+	// SelectedAltitude is the altitude set on the autopilot's mode control
+	// panel (MCP/FCU) in feet
+	// 0 if the source did not report it
+	SelectedAltitude units.Feet
+
+	// SelectedHeading is the heading set on the autopilot and Heading the
+	// aircraft's current heading, both in degrees magnetic. Their
+	// difference is the turn the autopilot is about to fly; Track can't be
+	// compared directly as it is true and includes the wind.
+	// nil if the source did not report them
+	SelectedHeading *float64
+	Heading         *float64
+
+	// NACp is the Navigation Accuracy Category for position (1-11, higher
+	// is more accurate; 9 is within 30 m)
+	// 0 if the source did not report it
+	NACp int
+
+	// Source identifies the feed that reported this aircraft (e.g., "airplanes.live", "uat978")
+	Source string
+
 	// LastSeen is the timestamp of the last position update
 	LastSeen time.Time
 }
@@ -294,7 +294,14 @@ func TestConvertAirplanesLiveAircraft(t *testing.T) {
 		Gs:       floatPtr(450.5),
 		Track:    floatPtr(270.0),
 		BaroRate: floatPtr(1500.0),
+		Squawk:   strPtr("7700"),
+		T:        strPtr("B738"),
+		R:        strPtr("N123AB"),
 		Seen:     floatPtr(3.0),
+
+		NavAltitudeMCP: floatPtr(37000.0),
+		NavHeading:     floatPtr(280.0),
+		MagHeading:     floatPtr(275.5),
 	}
 
 	result := convertAirplanesLiveAircraft(input)
@@ -317,6 +324,24 @@ func TestConvertAirplanesLiveAircraft(t *testing.T) {
 	if result.VerticalRate != 1500.0 {
 		t.Errorf("Expected vertical rate 1500, got %f", result.VerticalRate)
 	}
+	if result.Squawk != "7700" {
+		t.Errorf("Expected squawk 7700, got %s", result.Squawk)
+	}
+	if result.AircraftType != "B738" {
+		t.Errorf("Expected aircraft type B738, got %s", result.AircraftType)
+	}
+	if result.Registration != "N123AB" {
+		t.Errorf("Expected registration N123AB, got %s", result.Registration)
+	}
+	if result.SelectedAltitude != 37000.0 {
+		t.Errorf("Expected selected altitude 37000, got %f", result.SelectedAltitude)
+	}
+	if result.SelectedHeading == nil || *result.SelectedHeading != 280.0 {
+		t.Errorf("Expected selected heading 280, got %v", result.SelectedHeading)
+	}
+	if result.Heading == nil || *result.Heading != 275.5 {
+		t.Errorf("Expected heading 275.5, got %v", result.Heading)
+	}
 
 	// Verify LastSeen is approximately 3 seconds ago
 	expectedTime := now.Add(-3 * time.Second)
@@ -476,6 +501,37 @@ func TestRateLimitWait(t *testing.T) {
 	}
 }
 
+// TestIsEmergencySquawk tests emergency squawk classification.
+func TestIsEmergencySquawk(t *testing.T) {
+	tests := []struct {
+		squawk      string
+		emergency   bool
+		description string
+	}{
+		{"7500", true, "Hijack"},
+		{"7600", true, "Radio failure"},
+		{"7700", true, "General emergency"},
+		{"1200", false, ""},
+		{"7777", false, ""},
+		{"", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.squawk, func(t *testing.T) {
+			if got := IsEmergencySquawk(tt.squawk); got != tt.emergency {
+				t.Errorf("IsEmergencySquawk(%q) = %v, want %v", tt.squawk, got, tt.emergency)
+			}
+			if got := EmergencyDescription(tt.squawk); got != tt.description {
+				t.Errorf("EmergencyDescription(%q) = %q, want %q", tt.squawk, got, tt.description)
+			}
+			ac := Aircraft{Squawk: tt.squawk}
+			if ac.IsEmergency() != tt.emergency {
+				t.Errorf("Aircraft.IsEmergency() = %v, want %v", ac.IsEmergency(), tt.emergency)
+			}
+		})
+	}
+}
+
 // Helper functions
 func strPtr(s string) *string {
 	return &s
@@ -0,0 +1,223 @@
+package adsb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// SourceADSC tags positions derived from ADS-C contracts and ACARS position
+// reports relayed by satellite (Inmarsat/Iridium) or HF data link.
+// These cover oceanic airspace where there is no ground-based ADS-B reception,
+// but are sparse: a report every 10-30 minutes is typical.
+const SourceADSC = "adsc"
+
+// ADSCClient implements the DataSource interface for an oceanic position feed
+// such as airframes.io, which aggregates ACARS/ADS-C messages from satellite,
+// HFDL and VDL2 ground stations.
+//
+// The client expects a JSON document of the form:
+//
+//	{
+//	  "positions": [
+//	    {"icao": "a1b2c3", "callsign": "UAL901", "latitude": 45.2, "longitude": -30.1,
+//	     "altitude": 37000, "ground_speed": 480, "track": 85,
+//	     "timestamp": "2024-01-01T12:00:00Z"}
+//	  ]
+//	}
+//
+// Requests include lat/lon/radius_nm query parameters so the provider can filter
+// server-side; results are also filtered client-side.
+type ADSCClient struct {
+	// baseURL is the full position feed URL
+	baseURL string
+
+	// apiKey is sent in the X-API-Key header (optional)
+	apiKey string
+
+	// httpClient is the HTTP client used for requests
+	httpClient *http.Client
+}
+
+// NewADSCClient creates a new ADS-C/satellite position feed client.
+func NewADSCClient(baseURL, apiKey string) *ADSCClient {
+	return &ADSCClient{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// GetAircraft returns the latest reported position of each aircraft within
+// radiusNM of the center point.
+func (c *ADSCClient) GetAircraft(centerLat, centerLon, radiusNM float64) ([]Aircraft, error) {
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%.4f", centerLat))
+	params.Set("lon", fmt.Sprintf("%.4f", centerLon))
+	params.Set("radius_nm", fmt.Sprintf("%.0f", radiusNM))
+
+	reports, err := c.fetch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+	latest := make(map[string]Aircraft)
+	for _, r := range reports {
+		ac := r.toAircraft()
+		pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) > radiusNM {
+			continue
+		}
+		// Keep only the newest report per aircraft
+		if prev, ok := latest[ac.ICAO]; ok && !ac.LastSeen.After(prev.LastSeen) {
+			continue
+		}
+		latest[ac.ICAO] = ac
+	}
+
+	aircraft := make([]Aircraft, 0, len(latest))
+	for _, ac := range latest {
+		aircraft = append(aircraft, ac)
+	}
+
+	return aircraft, nil
+}
+
+// GetAircraftByICAO returns the latest reported position for an aircraft.
+// Returns nil if no report is available.
+func (c *ADSCClient) GetAircraftByICAO(icao string) (*Aircraft, error) {
+	params := url.Values{}
+	params.Set("icao", strings.ToLower(icao))
+
+	reports, err := c.fetch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Aircraft
+	for _, r := range reports {
+		if !strings.EqualFold(r.ICAO, icao) {
+			continue
+		}
+		ac := r.toAircraft()
+		if result == nil || ac.LastSeen.After(result.LastSeen) {
+			result = &ac
+		}
+	}
+
+	return result, nil
+}
+
+// Close cleanly shuts down the client.
+func (c *ADSCClient) Close() error {
+	return nil
+}
+
+// fetch requests the position feed with the given query parameters.
+func (c *ADSCClient) fetch(params url.Values) ([]adscReport, error) {
+	reqURL := c.baseURL
+	if strings.Contains(reqURL, "?") {
+		reqURL += "&" + params.Encode()
+	} else {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch oceanic positions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header),
+			Message:    "Rate limit exceeded",
+			Headers:    extractRateLimitHeaders(resp.Header),
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("position feed returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var data adscResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse position feed: %w", err)
+	}
+
+	return data.Positions, nil
+}
+
+// adscResponse is the position feed document.
+type adscResponse struct {
+	Positions []adscReport `json:"positions"`
+}
+
+// adscReport is a single ADS-C or ACARS position report.
+type adscReport struct {
+	// ICAO is the aircraft's 24-bit address in hex
+	ICAO string `json:"icao"`
+
+	// Callsign is the flight number (may be empty)
+	Callsign string `json:"callsign"`
+
+	// Latitude and Longitude in decimal degrees
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+
+	// Altitude in feet (pressure altitude)
+	Altitude float64 `json:"altitude"`
+
+	// GroundSpeed in knots (0 if not reported)
+	GroundSpeed float64 `json:"ground_speed"`
+
+	// Track in degrees true (0 if not reported)
+	Track float64 `json:"track"`
+
+	// VerticalRate in feet/minute (0 if not reported)
+	VerticalRate float64 `json:"vertical_rate"`
+
+	// Timestamp is when the aircraft was at the reported position
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// toAircraft converts a report to our Aircraft type.
+func (r adscReport) toAircraft() Aircraft {
+	lastSeen := r.Timestamp.UTC()
+	if lastSeen.IsZero() {
+		lastSeen = time.Now().UTC()
+	}
+
+	return Aircraft{
+		ICAO:         strings.ToLower(r.ICAO),
+		Callsign:     strings.TrimSpace(r.Callsign),
+		Latitude:     r.Latitude,
+		Longitude:    r.Longitude,
+		Altitude:     units.Feet(r.Altitude),
+		GroundSpeed:  r.GroundSpeed,
+		Track:        r.Track,
+		VerticalRate: r.VerticalRate,
+		Source:       SourceADSC,
+		LastSeen:     lastSeen,
+	}
+}
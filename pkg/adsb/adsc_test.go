@@ -0,0 +1,77 @@
+package adsb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// adscSample contains two reports for the same aircraft and one far away.
+const adscSample = `{
+  "positions": [
+    {"icao": "A1B2C3", "callsign": "UAL901 ", "latitude": 45.0, "longitude": -30.0,
+     "altitude": 37000, "ground_speed": 480, "track": 85, "timestamp": "2024-01-01T12:00:00Z"},
+    {"icao": "a1b2c3", "callsign": "UAL901", "latitude": 45.1, "longitude": -28.5,
+     "altitude": 37000, "ground_speed": 480, "track": 85, "timestamp": "2024-01-01T12:14:00Z"},
+    {"icao": "c0ffee", "latitude": -33.0, "longitude": 151.0, "altitude": 39000,
+     "timestamp": "2024-01-01T12:10:00Z"}
+  ]
+}`
+
+// TestADSCGetAircraft tests fetching oceanic position reports.
+func TestADSCGetAircraft(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			t.Errorf("Expected API key header, got %q", r.Header.Get("X-API-Key"))
+		}
+		if r.URL.Query().Get("radius_nm") != "1000" {
+			t.Errorf("Expected radius_nm=1000, got %q", r.URL.Query().Get("radius_nm"))
+		}
+		w.Write([]byte(adscSample))
+	}))
+	defer server.Close()
+
+	client := NewADSCClient(server.URL, "secret")
+	aircraft, err := client.GetAircraft(45.0, -30.0, 1000)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(aircraft) != 1 {
+		t.Fatalf("Expected 1 aircraft, got %d", len(aircraft))
+	}
+
+	ac := aircraft[0]
+	if ac.ICAO != "a1b2c3" {
+		t.Errorf("Expected lowercase ICAO a1b2c3, got %s", ac.ICAO)
+	}
+	if ac.Longitude != -28.5 {
+		t.Errorf("Expected newest report (lon -28.5), got %f", ac.Longitude)
+	}
+	if ac.Source != SourceADSC {
+		t.Errorf("Expected source %s, got %s", SourceADSC, ac.Source)
+	}
+	expected := time.Date(2024, 1, 1, 12, 14, 0, 0, time.UTC)
+	if !ac.LastSeen.Equal(expected) {
+		t.Errorf("Expected LastSeen %v, got %v", expected, ac.LastSeen)
+	}
+}
+
+// TestADSCRateLimit tests that 429 responses surface as RateLimitError.
+func TestADSCRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewADSCClient(server.URL, "")
+	_, err := client.GetAircraft(45.0, -30.0, 1000)
+	rle, ok := IsRateLimitError(err)
+	if !ok {
+		t.Fatalf("Expected RateLimitError, got %v", err)
+	}
+	if rle.RetryAfter != 60*time.Second {
+		t.Errorf("Expected RetryAfter 60s, got %v", rle.RetryAfter)
+	}
+}
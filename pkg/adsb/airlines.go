@@ -0,0 +1,45 @@
+package adsb
+
+// airlineNames maps common ICAO airline designators (the callsign prefix)
+// to names.
+var airlineNames = map[string]string{
+	"AAL": "American Airlines",
+	"ACA": "Air Canada",
+	"AFR": "Air France",
+	"ASA": "Alaska Airlines",
+	"BAW": "British Airways",
+	"DAL": "Delta Air Lines",
+	"DLH": "Lufthansa",
+	"EDV": "Endeavor Air",
+	"EJA": "NetJets",
+	"ENY": "Envoy Air",
+	"FDX": "FedEx",
+	"FFT": "Frontier Airlines",
+	"JBU": "JetBlue",
+	"JIA": "PSA Airlines",
+	"KLM": "KLM",
+	"NKS": "Spirit Airlines",
+	"PDT": "Piedmont Airlines",
+	"RPA": "Republic Airways",
+	"SKW": "SkyWest Airlines",
+	"SWA": "Southwest Airlines",
+	"UAE": "Emirates",
+	"UAL": "United Airlines",
+	"UPS": "UPS Airlines",
+}
+
+// AirlineName returns the name of the airline with an ICAO designator
+// ("DAL"), and whether it is known.
+func AirlineName(designator string) (string, bool) {
+	name, ok := airlineNames[designator]
+	return name, ok
+}
+
+// Airlines returns all known airline designators and names.
+func Airlines() map[string]string {
+	airlines := make(map[string]string, len(airlineNames))
+	for designator, name := range airlineNames {
+		airlines[designator] = name
+	}
+	return airlines
+}
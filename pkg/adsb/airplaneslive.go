@@ -6,7 +6,10 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // AirplanesLiveClient implements the DataSource interface for airplanes.live API.
@@ -74,7 +77,7 @@ func (c *AirplanesLiveClient) GetAircraft(centerLat, centerLon, radiusNM float64
 	// Check other error status codes
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse response
@@ -126,7 +129,7 @@ func (c *AirplanesLiveClient) GetAircraftByICAO(icao string) (*Aircraft, error)
 
 	// Check other error status codes
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	// Parse response
@@ -209,6 +212,27 @@ type airplanesLiveAircraft struct {
 	// BaroRate is barometric vertical rate in feet/minute
 	BaroRate *float64 `json:"baro_rate"`
 
+	// Squawk is the Mode A transponder code (4 octal digits)
+	Squawk *string `json:"squawk"`
+
+	// T is the ICAO type designator, from the aircraft database
+	T *string `json:"t"`
+
+	// R is the registration, from the aircraft database
+	R *string `json:"r"`
+
+	// NavAltitudeMCP is the altitude selected on the MCP/FCU in feet
+	NavAltitudeMCP *float64 `json:"nav_altitude_mcp"`
+
+	// NavHeading is the selected heading in degrees magnetic
+	NavHeading *float64 `json:"nav_heading"`
+
+	// MagHeading is the current heading in degrees magnetic
+	MagHeading *float64 `json:"mag_heading"`
+
+	// NacP is the Navigation Accuracy Category for position
+	NacP *int `json:"nac_p"`
+
 	// Seen is seconds since last position update
 	Seen *float64 `json:"seen"`
 
@@ -219,7 +243,8 @@ type airplanesLiveAircraft struct {
 // convertAirplanesLiveAircraft converts an airplanes.live aircraft to our Aircraft type.
 func convertAirplanesLiveAircraft(ac airplanesLiveAircraft) Aircraft {
 	aircraft := Aircraft{
-		ICAO: ac.Hex,
+		ICAO:   ac.Hex,
+		Source: SourceAirplanesLive,
 	}
 
 	// Callsign (trim whitespace)
@@ -238,9 +263,9 @@ func convertAirplanesLiveAircraft(ac airplanesLiveAircraft) Aircraft {
 	// Altitude - prefer geometric (GPS) over barometric
 	// Handle interface{} which can be float64 or string ("ground")
 	if alt := parseAltitude(ac.AltGeom); alt != nil {
-		aircraft.Altitude = *alt
+		aircraft.Altitude = units.Feet(*alt)
 	} else if alt := parseAltitude(ac.AltBaro); alt != nil {
-		aircraft.Altitude = *alt
+		aircraft.Altitude = units.Feet(*alt)
 	}
 
 	// Velocity
@@ -254,6 +279,31 @@ func convertAirplanesLiveAircraft(ac airplanesLiveAircraft) Aircraft {
 		aircraft.VerticalRate = *ac.BaroRate
 	}
 
+	// Transponder code
+	if ac.Squawk != nil {
+		aircraft.Squawk = *ac.Squawk
+	}
+
+	// Type designator and registration
+	if ac.T != nil {
+		aircraft.AircraftType = strings.TrimSpace(*ac.T)
+	}
+	if ac.R != nil {
+		aircraft.Registration = strings.TrimSpace(*ac.R)
+	}
+
+	// Autopilot selected values
+	if ac.NavAltitudeMCP != nil {
+		aircraft.SelectedAltitude = units.Feet(*ac.NavAltitudeMCP)
+	}
+	aircraft.SelectedHeading = ac.NavHeading
+	aircraft.Heading = ac.MagHeading
+
+	// Position quality
+	if ac.NacP != nil {
+		aircraft.NACp = *ac.NacP
+	}
+
 	// Timestamp - calculate from "seen" seconds ago
 	if ac.Seen != nil {
 		seenDuration := time.Duration(*ac.Seen * float64(time.Second))
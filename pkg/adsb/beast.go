@@ -0,0 +1,379 @@
+package adsb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Beast binary format (dump1090/readsb TCP port 30005): each frame is
+// 0x1a, a type byte, a 6-byte 12 MHz timestamp, a signal level byte and
+// the message. 0x1a bytes after the type are doubled.
+const (
+	beastEscape    = 0x1a
+	beastModeAC    = '1' // 2-byte Mode A/C reply
+	beastModeShort = '2' // 7-byte Mode S reply
+	beastModeLong  = '3' // 14-byte Mode S reply (extended squitter)
+)
+
+const (
+	// intentMaxAge is how long decoded intent is kept without an update.
+	// Aircraft broadcast target state every 1.25 s, so a minute of silence
+	// means the aircraft is out of range.
+	intentMaxAge = 60 * time.Second
+
+	// beastReconnectDelay is the wait before reconnecting a dropped feed
+	beastReconnectDelay = 5 * time.Second
+)
+
+// BeastFrame is one Mode S or Mode A/C reply read from a Beast feed.
+type BeastFrame struct {
+	Timestamp uint64 // 12 MHz receiver clock
+	Signal    byte   // Signal level, 0-255
+	Message   []byte // 2, 7 or 14 bytes
+}
+
+// ReadBeastFrame reads the next frame from a Beast feed, skipping any bytes
+// before the next frame start and frames of unknown type.
+func ReadBeastFrame(r *bufio.Reader) (BeastFrame, error) {
+	// synced is set when a frame start was found inside a truncated frame
+	synced := false
+	for {
+		// Sync to the start of a frame
+		if !synced {
+			b, err := r.ReadByte()
+			if err != nil {
+				return BeastFrame{}, err
+			}
+			if b != beastEscape {
+				continue
+			}
+		}
+		synced = false
+
+		frameType, err := r.ReadByte()
+		if err != nil {
+			return BeastFrame{}, err
+		}
+		var msgLen int
+		switch frameType {
+		case beastModeAC:
+			msgLen = 2
+		case beastModeShort:
+			msgLen = 7
+		case beastModeLong:
+			msgLen = 14
+		default:
+			// Unknown type, or an escaped 0x1a we synced on mid-frame
+			continue
+		}
+
+		payload := make([]byte, 0, 7+msgLen)
+		for len(payload) < cap(payload) {
+			b, err := r.ReadByte()
+			if err != nil {
+				return BeastFrame{}, err
+			}
+			if b == beastEscape {
+				next, err := r.ReadByte()
+				if err != nil {
+					return BeastFrame{}, err
+				}
+				if next != beastEscape {
+					// Unescaped 0x1a: the frame was cut short and a new
+					// one starts here
+					r.UnreadByte()
+					synced = true
+					break
+				}
+			}
+			payload = append(payload, b)
+		}
+		if len(payload) < cap(payload) {
+			continue
+		}
+
+		var timestamp uint64
+		for _, b := range payload[:6] {
+			timestamp = timestamp<<8 | uint64(b)
+		}
+		return BeastFrame{
+			Timestamp: timestamp,
+			Signal:    payload[6],
+			Message:   payload[7:],
+		}, nil
+	}
+}
+
+// Intent is the autopilot and air data an aircraft broadcasts in its DF17
+// extended squitters: what the crew has set up, ahead of the aircraft
+// actually turning or changing altitude. Fields not heard are nil.
+type Intent struct {
+	ICAO string
+
+	// SelectedAltitude is the altitude set on the autopilot; SelectedByFMS
+	// is true when it comes from the flight management system rather than
+	// the mode control panel
+	SelectedAltitude *units.Feet
+	SelectedByFMS    bool
+
+	// SelectedHeading is the heading bug in degrees
+	SelectedHeading *float64
+
+	// IAS and TAS are the indicated and true airspeeds in knots
+	IAS *float64
+	TAS *float64
+
+	// UpdatedAt is when the last of these fields was heard
+	UpdatedAt time.Time
+}
+
+// merge copies the fields an update carries into the intent.
+func (i *Intent) merge(update Intent) {
+	if update.SelectedAltitude != nil {
+		i.SelectedAltitude = update.SelectedAltitude
+		i.SelectedByFMS = update.SelectedByFMS
+	}
+	if update.SelectedHeading != nil {
+		i.SelectedHeading = update.SelectedHeading
+	}
+	if update.IAS != nil {
+		i.IAS = update.IAS
+	}
+	if update.TAS != nil {
+		i.TAS = update.TAS
+	}
+	i.UpdatedAt = update.UpdatedAt
+}
+
+// Apply copies the selected altitude and heading into an aircraft's state,
+// so its position is predicted with them. Values the aircraft already has
+// from its source are kept when the intent lacks them.
+func (i Intent) Apply(aircraft *Aircraft) {
+	if i.SelectedAltitude != nil {
+		aircraft.SelectedAltitude = *i.SelectedAltitude
+	}
+	if i.SelectedHeading != nil {
+		heading := *i.SelectedHeading
+		aircraft.SelectedHeading = &heading
+	}
+}
+
+// DecodeIntent decodes the intent fields of a DF17 extended squitter:
+// target state and status (type code 29) and airspeed velocity (type code
+// 19, subtypes 3 and 4). ok is false for other messages, messages with
+// none of these fields, and messages that fail the parity check.
+func DecodeIntent(msg []byte) (Intent, bool) {
+	if len(msg) != 14 || msg[0]>>3 != 17 || modeSParity(msg) != 0 {
+		return Intent{}, false
+	}
+
+	intent := Intent{ICAO: fmt.Sprintf("%02x%02x%02x", msg[1], msg[2], msg[3])}
+	me := msg[4:11]
+
+	switch typeCode := meBits(me, 1, 5); typeCode {
+	case 19:
+		subtype := meBits(me, 6, 3)
+		if subtype != 3 && subtype != 4 {
+			return Intent{}, false
+		}
+		speed := meBits(me, 26, 10)
+		if speed == 0 {
+			return Intent{}, false
+		}
+		knots := float64(speed - 1)
+		if subtype == 4 {
+			knots *= 4 // Supersonic
+		}
+		if meBits(me, 25, 1) == 1 {
+			intent.TAS = &knots
+		} else {
+			intent.IAS = &knots
+		}
+
+	case 29:
+		// Only version 2 (subtype 1) carries selected altitude and heading
+		if meBits(me, 6, 2) != 1 {
+			return Intent{}, false
+		}
+		if alt := meBits(me, 10, 11); alt != 0 {
+			ft := units.Feet((alt - 1) * 32)
+			intent.SelectedAltitude = &ft
+			intent.SelectedByFMS = meBits(me, 9, 1) == 1
+		}
+		if meBits(me, 30, 1) == 1 {
+			heading := float64(meBits(me, 31, 9)) * 180.0 / 256.0
+			intent.SelectedHeading = &heading
+		}
+		if intent.SelectedAltitude == nil && intent.SelectedHeading == nil {
+			return Intent{}, false
+		}
+
+	default:
+		return Intent{}, false
+	}
+
+	return intent, true
+}
+
+// meBits returns n bits of a 56-bit ME field starting at bit first,
+// numbered from 1 as in the ADS-B specifications.
+func meBits(me []byte, first, n int) int {
+	v := 0
+	for bit := first - 1; bit < first-1+n; bit++ {
+		v = v<<1 | int(me[bit/8]>>(7-bit%8)&1)
+	}
+	return v
+}
+
+// modeSParity returns the Mode S CRC remainder of a message including its
+// parity field; it is zero for an undamaged DF17 message.
+func modeSParity(msg []byte) uint32 {
+	const generator = 0x1fff409
+
+	var crc uint32
+	for _, b := range msg[:len(msg)-3] {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= generator
+			}
+		}
+	}
+	parity := uint32(msg[len(msg)-3])<<16 | uint32(msg[len(msg)-2])<<8 | uint32(msg[len(msg)-1])
+	return (crc ^ parity) & 0xffffff
+}
+
+// BeastClient reads a local receiver's Beast binary feed and keeps the
+// latest intent decoded for each aircraft.
+type BeastClient struct {
+	// addr is the feed's host:port (dump1090's Beast output is port 30005)
+	addr string
+
+	mu      sync.RWMutex
+	intents map[string]*Intent
+	err     error // Last connection error, nil while connected
+}
+
+// NewBeastClient creates a client for the Beast feed at addr. Call Run to
+// start reading.
+func NewBeastClient(addr string) *BeastClient {
+	return &BeastClient{
+		addr:    addr,
+		intents: make(map[string]*Intent),
+	}
+}
+
+// Run reads the feed until ctx is cancelled, reconnecting after errors.
+func (c *BeastClient) Run(ctx context.Context) {
+	for {
+		err := c.read(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.mu.Lock()
+		c.err = fmt.Errorf("beast feed %s: %w", c.addr, err)
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(beastReconnectDelay):
+		}
+	}
+}
+
+// read connects to the feed and decodes frames until the connection drops.
+func (c *BeastClient) read(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	c.mu.Lock()
+	c.err = nil
+	c.mu.Unlock()
+
+	// Unblock the read when ctx is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	r := bufio.NewReader(conn)
+	lastPrune := time.Now()
+	for {
+		frame, err := ReadBeastFrame(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("connection closed")
+			}
+			return err
+		}
+
+		now := time.Now()
+		if intent, ok := DecodeIntent(frame.Message); ok {
+			intent.UpdatedAt = now
+			c.update(intent)
+		}
+		if now.Sub(lastPrune) > intentMaxAge {
+			c.prune(now)
+			lastPrune = now
+		}
+	}
+}
+
+// update merges newly decoded intent into the aircraft's.
+func (c *BeastClient) update(intent Intent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.intents[intent.ICAO]
+	if !ok {
+		current = &Intent{ICAO: intent.ICAO}
+		c.intents[intent.ICAO] = current
+	}
+	current.merge(intent)
+}
+
+// prune drops aircraft not heard from within intentMaxAge.
+func (c *BeastClient) prune(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for icao, intent := range c.intents {
+		if now.Sub(intent.UpdatedAt) > intentMaxAge {
+			delete(c.intents, icao)
+		}
+	}
+}
+
+// Intent returns the latest intent heard from an aircraft, or false if
+// none was heard within the last minute.
+func (c *BeastClient) Intent(icao string) (Intent, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	intent, ok := c.intents[strings.ToLower(icao)]
+	if !ok || time.Since(intent.UpdatedAt) > intentMaxAge {
+		return Intent{}, false
+	}
+	return *intent, true
+}
+
+// Err returns the feed's last connection error, or nil while connected.
+// The client keeps retrying in the background.
+func (c *BeastClient) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
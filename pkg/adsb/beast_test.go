@@ -0,0 +1,184 @@
+package adsb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+)
+
+// Reference extended squitters from "The 1090 Megahertz Riddle"
+const (
+	targetStateSample = "8DA05629EA21485CBF3F8CADAEEB" // TC 29: 16992 ft MCP, heading 66.8°
+	airspeedSample    = "8DA05F219B06B6AF189400CBC33F" // TC 19 subtype 3: 375 kt TAS
+	identSample       = "8D4840D6202CC371C32CE0576098" // TC 4: identification
+)
+
+// mustHex decodes a hex message.
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("Bad hex %q: %v", s, err)
+	}
+	return b
+}
+
+// beastFrame encodes a message as a Beast frame, doubling 0x1a bytes.
+func beastFrame(frameType byte, msg []byte) []byte {
+	frame := []byte{beastEscape, frameType}
+	payload := append([]byte{0, 0, 0, 0x1a, 0, 1, 200}, msg...)
+	for _, b := range payload {
+		frame = append(frame, b)
+		if b == beastEscape {
+			frame = append(frame, b)
+		}
+	}
+	return frame
+}
+
+// TestDecodeIntent tests decoding target state and airspeed squitters.
+func TestDecodeIntent(t *testing.T) {
+	t.Run("Target state and status", func(t *testing.T) {
+		intent, ok := DecodeIntent(mustHex(t, targetStateSample))
+		if !ok {
+			t.Fatal("Expected target state to decode")
+		}
+		if intent.ICAO != "a05629" {
+			t.Errorf("Expected ICAO a05629, got %s", intent.ICAO)
+		}
+		if intent.SelectedAltitude == nil || *intent.SelectedAltitude != 16992 {
+			t.Errorf("Expected selected altitude 16992 ft, got %v", intent.SelectedAltitude)
+		}
+		if intent.SelectedByFMS {
+			t.Error("Expected MCP/FCU selected altitude")
+		}
+		if intent.SelectedHeading == nil || *intent.SelectedHeading < 66.7 || *intent.SelectedHeading > 66.9 {
+			t.Errorf("Expected selected heading 66.8°, got %v", intent.SelectedHeading)
+		}
+	})
+
+	t.Run("Airspeed", func(t *testing.T) {
+		intent, ok := DecodeIntent(mustHex(t, airspeedSample))
+		if !ok {
+			t.Fatal("Expected airspeed to decode")
+		}
+		if intent.TAS == nil || *intent.TAS != 375 {
+			t.Errorf("Expected TAS 375 kt, got %v", intent.TAS)
+		}
+		if intent.IAS != nil {
+			t.Errorf("Expected no IAS, got %v", *intent.IAS)
+		}
+	})
+
+	t.Run("Ignores other type codes", func(t *testing.T) {
+		if _, ok := DecodeIntent(mustHex(t, identSample)); ok {
+			t.Error("Expected identification message to be ignored")
+		}
+	})
+
+	t.Run("Rejects damaged messages", func(t *testing.T) {
+		msg := mustHex(t, targetStateSample)
+		msg[6] ^= 0x01
+		if _, ok := DecodeIntent(msg); ok {
+			t.Error("Expected parity failure to be rejected")
+		}
+	})
+}
+
+// TestReadBeastFrame tests framing, unescaping and resyncing.
+func TestReadBeastFrame(t *testing.T) {
+	msg := mustHex(t, targetStateSample)
+
+	var feed bytes.Buffer
+	feed.Write([]byte{0x00, 0x42})                       // Noise before the first frame
+	feed.Write(beastFrame(beastModeAC, []byte{0x1a, 1})) // Escaped byte in the message
+	feed.Write(beastFrame(beastModeLong, msg)[:10])      // Truncated frame
+	feed.Write(beastFrame(beastModeLong, msg))
+
+	r := bufio.NewReader(&feed)
+
+	frame, err := ReadBeastFrame(r)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(frame.Message, []byte{0x1a, 1}) {
+		t.Errorf("Expected unescaped Mode A/C message, got %x", frame.Message)
+	}
+	if frame.Timestamp != 0x1a0001 || frame.Signal != 200 {
+		t.Errorf("Expected timestamp 0x1a0001 and signal 200, got %#x and %d", frame.Timestamp, frame.Signal)
+	}
+
+	frame, err = ReadBeastFrame(r)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(frame.Message, msg) {
+		t.Errorf("Expected the frame after the truncated one, got %x", frame.Message)
+	}
+
+	if _, err := ReadBeastFrame(r); err == nil {
+		t.Error("Expected an error at the end of the feed")
+	}
+}
+
+// TestBeastClient tests reading intent from a Beast feed over TCP.
+func TestBeastClient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	frame := beastFrame(beastModeLong, mustHex(t, targetStateSample))
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write(frame)
+		time.Sleep(time.Second)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewBeastClient(listener.Addr().String())
+	go client.Run(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if intent, ok := client.Intent("A05629"); ok {
+			if intent.SelectedAltitude == nil || *intent.SelectedAltitude != 16992 {
+				t.Errorf("Expected selected altitude 16992 ft, got %v", intent.SelectedAltitude)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Expected intent for A05629")
+}
+
+// TestIntentApply tests copying intent into an aircraft's state.
+func TestIntentApply(t *testing.T) {
+	heading := 90.0
+	aircraft := Aircraft{ICAO: "a05629", SelectedAltitude: 12000, SelectedHeading: &heading}
+
+	intent, _ := DecodeIntent(mustHex(t, airspeedSample))
+	intent.Apply(&aircraft)
+	if aircraft.SelectedAltitude != 12000 || *aircraft.SelectedHeading != 90 {
+		t.Errorf("Expected source values kept, got %.0f ft and %.1f°", aircraft.SelectedAltitude, *aircraft.SelectedHeading)
+	}
+
+	intent, _ = DecodeIntent(mustHex(t, targetStateSample))
+	intent.Apply(&aircraft)
+	if aircraft.SelectedAltitude != 16992 {
+		t.Errorf("Expected selected altitude 16992 ft, got %.0f", aircraft.SelectedAltitude)
+	}
+	if *aircraft.SelectedHeading < 66.7 || *aircraft.SelectedHeading > 66.9 {
+		t.Errorf("Expected selected heading 66.8°, got %.1f°", *aircraft.SelectedHeading)
+	}
+}
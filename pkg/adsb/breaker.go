@@ -0,0 +1,264 @@
+package adsb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrCircuitOpen is returned when a request is refused because the circuit
+// breaker is open after repeated API failures.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// ErrRetryBudgetExhausted is returned when a retry is refused because the
+// shared retry budget has been used up.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed allows all requests (normal operation)
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen refuses all requests until the cool-down has elapsed
+	BreakerOpen
+
+	// BreakerHalfOpen allows a single probe request to test recovery
+	BreakerHalfOpen
+)
+
+// String returns the state name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig configures a CircuitBreaker.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive 429/5xx responses that trips the breaker
+	FailureThreshold int
+
+	// OpenDuration is how long the breaker stays open before probing
+	OpenDuration time.Duration
+
+	// RetriesPerMinute is the refill rate of the shared retry budget
+	RetriesPerMinute float64
+
+	// RetryBurst is the capacity of the shared retry budget
+	RetryBurst int
+}
+
+// DefaultBreakerConfig returns defaults suited to the airplanes.live API.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenDuration:     2 * time.Minute,
+		RetriesPerMinute: 6,
+		RetryBurst:       6,
+	}
+}
+
+// BreakerStatus is a snapshot of a CircuitBreaker for reporting.
+type BreakerStatus struct {
+	// State is the current breaker state
+	State BreakerState
+
+	// ConsecutiveFailures is the current run of 429/5xx responses
+	ConsecutiveFailures int
+
+	// Trips is how many times the breaker has opened
+	Trips int
+
+	// RetriesDenied is how many retries were refused by the shared budget
+	RetriesDenied int
+
+	// OpenUntil is when an open breaker will allow a probe (zero if closed)
+	OpenUntil time.Time
+
+	// LastError is the most recent failure (empty if none)
+	LastError string
+}
+
+// CircuitBreaker protects an API from load multiplication during outages.
+//
+// All callers share one breaker: after FailureThreshold consecutive rate
+// limit or server errors it opens and refuses requests for OpenDuration,
+// then lets a single probe through. Retries are additionally drawn from a
+// shared token bucket so that many regions retrying at once cannot multiply
+// the request rate.
+type CircuitBreaker struct {
+	mu sync.Mutex
+
+	cfg         BreakerConfig
+	retryBudget *rate.Limiter
+
+	state         BreakerState
+	failures      int
+	trips         int
+	retriesDenied int
+	openUntil     time.Time
+	probing       bool
+	lastError     string
+
+	// now returns the current time (replaceable in tests)
+	now func() time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.RetryBurst < 1 {
+		cfg.RetryBurst = 1
+	}
+
+	limit := rate.Inf
+	if cfg.RetriesPerMinute > 0 {
+		limit = rate.Limit(cfg.RetriesPerMinute / 60.0)
+	}
+
+	return &CircuitBreaker{
+		cfg:         cfg,
+		retryBudget: rate.NewLimiter(limit, cfg.RetryBurst),
+		now:         time.Now,
+	}
+}
+
+// Allow reports whether a request may be made now.
+// Returns ErrCircuitOpen if the breaker is open or a probe is already in flight.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openUntil) {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// AllowRetry reports whether a retry may be made, consuming from the shared budget.
+func (b *CircuitBreaker) AllowRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.retryBudget.AllowN(b.now(), 1) {
+		return true
+	}
+	b.retriesDenied++
+	return false
+}
+
+// Record updates the breaker with the outcome of a request.
+// Only rate limit (429) and server (5xx) errors count as failures; other
+// errors such as bad requests say nothing about the API's health.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	if !IsBackpressureError(err) {
+		if b.state == BreakerHalfOpen {
+			b.state = BreakerClosed
+		}
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		openFor := b.cfg.OpenDuration
+		if rle, ok := IsRateLimitError(err); ok && rle.RetryAfter > openFor {
+			openFor = rle.RetryAfter
+		}
+		if b.state != BreakerOpen {
+			b.trips++
+		}
+		b.state = BreakerOpen
+		b.openUntil = b.now().Add(openFor)
+	}
+}
+
+// Status returns a snapshot of the breaker state.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Trips:               b.trips,
+		RetriesDenied:       b.retriesDenied,
+		LastError:           b.lastError,
+	}
+	if b.state == BreakerOpen {
+		status.OpenUntil = b.openUntil
+	}
+	return status
+}
+
+// StatusError is returned when an API responds with an unexpected HTTP status.
+type StatusError struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+
+	// Body is the response body (may be empty)
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsBackpressureError reports whether an error indicates the API is
+// overloaded or failing: a rate limit (429) or a server error (5xx).
+func IsBackpressureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := IsRateLimitError(err); ok {
+		return true
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= 500
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package adsb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests state transitions of the circuit breaker.
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	serverErr := &StatusError{StatusCode: 503}
+
+	newBreaker := func() *CircuitBreaker {
+		b := NewCircuitBreaker(BreakerConfig{FailureThreshold: 3, OpenDuration: time.Minute, RetryBurst: 1})
+		b.now = func() time.Time { return now }
+		return b
+	}
+
+	t.Run("Trips after consecutive backpressure errors", func(t *testing.T) {
+		b := newBreaker()
+		for i := 0; i < 3; i++ {
+			if err := b.Allow(); err != nil {
+				t.Fatalf("Expected request %d allowed, got %v", i, err)
+			}
+			b.Record(serverErr)
+		}
+		if b.Status().State != BreakerOpen {
+			t.Fatalf("Expected open breaker, got %s", b.Status().State)
+		}
+		if !errors.Is(b.Allow(), ErrCircuitOpen) {
+			t.Error("Expected ErrCircuitOpen while open")
+		}
+		if b.Status().Trips != 1 {
+			t.Errorf("Expected 1 trip, got %d", b.Status().Trips)
+		}
+	})
+
+	t.Run("Ignores client errors", func(t *testing.T) {
+		b := newBreaker()
+		for i := 0; i < 5; i++ {
+			b.Record(&StatusError{StatusCode: 404})
+		}
+		if b.Status().State != BreakerClosed {
+			t.Errorf("Expected closed breaker, got %s", b.Status().State)
+		}
+	})
+
+	t.Run("Half-open probe closes on success", func(t *testing.T) {
+		b := newBreaker()
+		for i := 0; i < 3; i++ {
+			b.Record(serverErr)
+		}
+
+		now = now.Add(2 * time.Minute)
+		if err := b.Allow(); err != nil {
+			t.Fatalf("Expected probe allowed, got %v", err)
+		}
+		if !errors.Is(b.Allow(), ErrCircuitOpen) {
+			t.Error("Expected only one probe in flight")
+		}
+		b.Record(nil)
+		if b.Status().State != BreakerClosed {
+			t.Errorf("Expected closed breaker after probe, got %s", b.Status().State)
+		}
+	})
+
+	t.Run("Failed probe reopens", func(t *testing.T) {
+		b := newBreaker()
+		for i := 0; i < 3; i++ {
+			b.Record(serverErr)
+		}
+
+		now = now.Add(2 * time.Minute)
+		b.Allow()
+		b.Record(&RateLimitError{StatusCode: 429, RetryAfter: 5 * time.Minute})
+		status := b.Status()
+		if status.State != BreakerOpen {
+			t.Fatalf("Expected reopened breaker, got %s", status.State)
+		}
+		if !status.OpenUntil.Equal(now.Add(5 * time.Minute)) {
+			t.Errorf("Expected Retry-After to extend open period, got %v", status.OpenUntil.Sub(now))
+		}
+	})
+}
+
+// TestRetryWithBreaker tests that retries share the breaker budget.
+func TestRetryWithBreaker(t *testing.T) {
+	cfg := RetryConfig{
+		MaxRetries:   5,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+		Multiplier:   1,
+		Breaker: NewCircuitBreaker(BreakerConfig{
+			FailureThreshold: 100,
+			RetriesPerMinute: 0.001,
+			RetryBurst:       2,
+		}),
+	}
+
+	attempts := 0
+	err := RetryWithBackoff(context.Background(), cfg, func() error {
+		attempts++
+		return &StatusError{StatusCode: 502}
+	})
+
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("Expected ErrRetryBudgetExhausted, got %v", err)
+	}
+	// One initial attempt plus the two retries in the budget
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if cfg.Breaker.Status().RetriesDenied != 1 {
+		t.Errorf("Expected 1 denied retry, got %d", cfg.Breaker.Status().RetriesDenied)
+	}
+}
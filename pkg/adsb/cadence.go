@@ -0,0 +1,206 @@
+package adsb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RegionActivity classifies how interesting a collection region currently is.
+// It determines how often the region is polled.
+type RegionActivity int
+
+const (
+	// ActivityIdle means no trackable aircraft were seen in the region
+	ActivityIdle RegionActivity = iota
+
+	// ActivityNormal means trackable aircraft are present
+	ActivityNormal
+
+	// ActivityApproaching means at least one aircraft is closing on the observer
+	ActivityApproaching
+)
+
+// String returns a human-readable name for the activity level.
+func (a RegionActivity) String() string {
+	switch a {
+	case ActivityIdle:
+		return "idle"
+	case ActivityNormal:
+		return "normal"
+	case ActivityApproaching:
+		return "approaching"
+	default:
+		return "unknown"
+	}
+}
+
+// CadenceScheduler decides which collection regions to poll on each tick.
+//
+// Each region has its own polling interval that adapts to activity:
+//   - Approaching: polled every MinInterval
+//   - Normal:      polled every BaseInterval
+//   - Idle:        interval doubles after each quiet poll, up to IdleInterval
+//
+// All regions share a single token bucket so that speeding up busy regions
+// never exceeds the API request budget. Regions that are due but cannot get
+// a token stay due and are served first on the next tick.
+type CadenceScheduler struct {
+	mu sync.Mutex
+
+	// minInterval is the polling interval for regions with approaching aircraft
+	minInterval time.Duration
+
+	// baseInterval is the polling interval for regions with trackable aircraft
+	baseInterval time.Duration
+
+	// idleInterval is the maximum polling interval for idle regions
+	idleInterval time.Duration
+
+	// limiter is the token bucket shared across all regions
+	limiter *rate.Limiter
+
+	// regions tracks per-region scheduling state by region name
+	regions map[string]*regionCadence
+}
+
+// regionCadence is the scheduling state for a single region.
+type regionCadence struct {
+	interval time.Duration
+	nextDue  time.Time
+}
+
+// CadenceConfig configures a CadenceScheduler.
+type CadenceConfig struct {
+	// MinInterval is used for regions with approaching aircraft
+	MinInterval time.Duration
+
+	// BaseInterval is used for regions with trackable aircraft
+	BaseInterval time.Duration
+
+	// IdleInterval caps the back-off for regions with no trackable aircraft
+	IdleInterval time.Duration
+
+	// RequestsPerMinute is the token bucket refill rate across all regions
+	RequestsPerMinute float64
+
+	// Burst is the token bucket capacity (minimum 1)
+	Burst int
+}
+
+// NewCadenceScheduler creates a scheduler with the given configuration.
+// Intervals are normalized so that MinInterval <= BaseInterval <= IdleInterval.
+func NewCadenceScheduler(cfg CadenceConfig) *CadenceScheduler {
+	if cfg.BaseInterval < cfg.MinInterval {
+		cfg.BaseInterval = cfg.MinInterval
+	}
+	if cfg.IdleInterval < cfg.BaseInterval {
+		cfg.IdleInterval = cfg.BaseInterval
+	}
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+
+	limit := rate.Inf
+	if cfg.RequestsPerMinute > 0 {
+		limit = rate.Limit(cfg.RequestsPerMinute / 60.0)
+	}
+
+	return &CadenceScheduler{
+		minInterval:  cfg.MinInterval,
+		baseInterval: cfg.BaseInterval,
+		idleInterval: cfg.IdleInterval,
+		limiter:      rate.NewLimiter(limit, cfg.Burst),
+		regions:      make(map[string]*regionCadence),
+	}
+}
+
+// Due returns the regions that should be polled now, most overdue first.
+// A token is consumed for each returned region; when the budget runs out the
+// remaining due regions are deferred to a later call.
+// Regions not seen before are due immediately.
+func (s *CadenceScheduler) Due(names []string, now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]string, 0, len(names))
+	for _, name := range names {
+		rc, ok := s.regions[name]
+		if !ok || !now.Before(rc.nextDue) {
+			due = append(due, name)
+		}
+	}
+
+	// Most overdue first; ties go to the region with the shorter interval
+	sort.SliceStable(due, func(i, j int) bool {
+		a, b := s.regions[due[i]], s.regions[due[j]]
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		if !a.nextDue.Equal(b.nextDue) {
+			return a.nextDue.Before(b.nextDue)
+		}
+		return a.interval < b.interval
+	})
+
+	for i := range due {
+		if !s.limiter.AllowN(now, 1) {
+			return due[:i]
+		}
+	}
+
+	return due
+}
+
+// Record updates a region's interval after a poll and schedules its next poll.
+// Returns the new interval.
+func (s *CadenceScheduler) Record(name string, activity RegionActivity, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rc, ok := s.regions[name]
+	if !ok {
+		rc = &regionCadence{interval: s.baseInterval}
+		s.regions[name] = rc
+	}
+
+	switch activity {
+	case ActivityApproaching:
+		rc.interval = s.minInterval
+	case ActivityNormal:
+		rc.interval = s.baseInterval
+	default:
+		// Back off gradually so a single quiet poll does not stall the region
+		next := rc.interval * 2
+		if next < s.baseInterval {
+			next = s.baseInterval
+		}
+		if next > s.idleInterval {
+			next = s.idleInterval
+		}
+		rc.interval = next
+	}
+
+	rc.nextDue = now.Add(rc.interval)
+	return rc.interval
+}
+
+// Interval returns the current polling interval for a region.
+// Unknown regions report the base interval.
+func (s *CadenceScheduler) Interval(name string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rc, ok := s.regions[name]; ok {
+		return rc.interval
+	}
+	return s.baseInterval
+}
+
+// MinInterval returns the shortest polling interval, which callers should use
+// as their tick rate.
+func (s *CadenceScheduler) MinInterval() time.Duration {
+	return s.minInterval
+}
@@ -0,0 +1,89 @@
+package adsb
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCadenceSchedulerIntervals tests interval adaptation to region activity.
+func TestCadenceSchedulerIntervals(t *testing.T) {
+	s := NewCadenceScheduler(CadenceConfig{
+		MinInterval:  2 * time.Second,
+		BaseInterval: 5 * time.Second,
+		IdleInterval: 30 * time.Second,
+	})
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		activity RegionActivity
+		expected time.Duration
+	}{
+		{"Approaching uses min interval", ActivityApproaching, 2 * time.Second},
+		{"Normal uses base interval", ActivityNormal, 5 * time.Second},
+		{"First idle poll doubles", ActivityIdle, 10 * time.Second},
+		{"Second idle poll doubles again", ActivityIdle, 20 * time.Second},
+		{"Idle back-off is capped", ActivityIdle, 30 * time.Second},
+		{"Approaching resets immediately", ActivityApproaching, 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Record("region", tt.activity, now)
+			if got != tt.expected {
+				t.Errorf("Expected interval %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestCadenceSchedulerDue tests due-region selection and the shared budget.
+func TestCadenceSchedulerDue(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Unknown regions are due", func(t *testing.T) {
+		s := NewCadenceScheduler(CadenceConfig{BaseInterval: 5 * time.Second})
+		due := s.Due([]string{"A", "B"}, now)
+		if len(due) != 2 {
+			t.Errorf("Expected 2 due regions, got %d", len(due))
+		}
+	})
+
+	t.Run("Regions wait for their interval", func(t *testing.T) {
+		s := NewCadenceScheduler(CadenceConfig{MinInterval: 2 * time.Second, BaseInterval: 10 * time.Second})
+		s.Record("busy", ActivityApproaching, now)
+		s.Record("quiet", ActivityNormal, now)
+
+		due := s.Due([]string{"busy", "quiet"}, now.Add(3*time.Second))
+		if len(due) != 1 || due[0] != "busy" {
+			t.Errorf("Expected only busy region due, got %v", due)
+		}
+	})
+
+	t.Run("Budget limits requests and favors overdue regions", func(t *testing.T) {
+		s := NewCadenceScheduler(CadenceConfig{
+			BaseInterval:      5 * time.Second,
+			RequestsPerMinute: 6, // One token every 10 seconds
+			Burst:             2,
+		})
+		s.Record("A", ActivityNormal, now)
+		s.Record("B", ActivityNormal, now.Add(-20*time.Second))
+		s.Record("C", ActivityNormal, now.Add(-10*time.Second))
+
+		due := s.Due([]string{"A", "B", "C"}, now.Add(5*time.Second))
+		if len(due) != 2 {
+			t.Fatalf("Expected 2 regions within budget, got %v", due)
+		}
+		if due[0] != "B" || due[1] != "C" {
+			t.Errorf("Expected most overdue first [B C], got %v", due)
+		}
+
+		// Budget exhausted until the bucket refills
+		if due := s.Due([]string{"A"}, now.Add(5*time.Second)); len(due) != 0 {
+			t.Errorf("Expected no regions with empty budget, got %v", due)
+		}
+		if due := s.Due([]string{"A"}, now.Add(16*time.Second)); len(due) != 1 {
+			t.Errorf("Expected region after refill, got %v", due)
+		}
+	})
+}
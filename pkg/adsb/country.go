@@ -0,0 +1,255 @@
+package adsb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Country is the state of registry an ICAO 24-bit address is allocated to.
+type Country struct {
+	Name string // e.g. "United States"
+	Code string // ISO 3166-1 alpha-2 code, e.g. "US"
+}
+
+// Flag returns the country's flag emoji, built from the regional indicator
+// symbols for its code. Returns "" if the code isn't two letters.
+func (c Country) Flag() string {
+	if len(c.Code) != 2 {
+		return ""
+	}
+	var flag strings.Builder
+	for _, r := range strings.ToUpper(c.Code) {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+		flag.WriteRune(0x1F1E6 + r - 'A')
+	}
+	return flag.String()
+}
+
+// addressBlock is a block of ICAO addresses allocated to a country.
+type addressBlock struct {
+	first, last uint32
+	country     Country
+}
+
+// addressBlocks are the ICAO 24-bit address blocks allocated to states of
+// registry (ICAO Annex 10, Volume III, Part I, Chapter 9). Some blocks lie
+// within a larger one; the smallest block containing an address wins.
+var addressBlocks = []addressBlock{
+	{0x004000, 0x0043FF, Country{"Zimbabwe", "ZW"}},
+	{0x006000, 0x006FFF, Country{"Mozambique", "MZ"}},
+	{0x008000, 0x00FFFF, Country{"South Africa", "ZA"}},
+	{0x010000, 0x017FFF, Country{"Egypt", "EG"}},
+	{0x018000, 0x01FFFF, Country{"Libya", "LY"}},
+	{0x020000, 0x027FFF, Country{"Morocco", "MA"}},
+	{0x028000, 0x02FFFF, Country{"Tunisia", "TN"}},
+	{0x030000, 0x0303FF, Country{"Botswana", "BW"}},
+	{0x032000, 0x032FFF, Country{"Burundi", "BI"}},
+	{0x034000, 0x034FFF, Country{"Cameroon", "CM"}},
+	{0x035000, 0x0353FF, Country{"Comoros", "KM"}},
+	{0x036000, 0x036FFF, Country{"Congo", "CG"}},
+	{0x038000, 0x038FFF, Country{"Côte d'Ivoire", "CI"}},
+	{0x03E000, 0x03EFFF, Country{"Gabon", "GA"}},
+	{0x040000, 0x040FFF, Country{"Ethiopia", "ET"}},
+	{0x042000, 0x042FFF, Country{"Equatorial Guinea", "GQ"}},
+	{0x044000, 0x044FFF, Country{"Ghana", "GH"}},
+	{0x046000, 0x046FFF, Country{"Guinea", "GN"}},
+	{0x048000, 0x0483FF, Country{"Guinea-Bissau", "GW"}},
+	{0x04A000, 0x04A3FF, Country{"Lesotho", "LS"}},
+	{0x04C000, 0x04CFFF, Country{"Kenya", "KE"}},
+	{0x050000, 0x050FFF, Country{"Liberia", "LR"}},
+	{0x054000, 0x054FFF, Country{"Madagascar", "MG"}},
+	{0x058000, 0x058FFF, Country{"Malawi", "MW"}},
+	{0x05A000, 0x05A3FF, Country{"Maldives", "MV"}},
+	{0x05C000, 0x05CFFF, Country{"Mali", "ML"}},
+	{0x05E000, 0x05E3FF, Country{"Mauritania", "MR"}},
+	{0x060000, 0x0603FF, Country{"Mauritius", "MU"}},
+	{0x062000, 0x062FFF, Country{"Niger", "NE"}},
+	{0x064000, 0x064FFF, Country{"Nigeria", "NG"}},
+	{0x068000, 0x068FFF, Country{"Uganda", "UG"}},
+	{0x06A000, 0x06A3FF, Country{"Qatar", "QA"}},
+	{0x06C000, 0x06CFFF, Country{"Central African Republic", "CF"}},
+	{0x06E000, 0x06EFFF, Country{"Rwanda", "RW"}},
+	{0x070000, 0x070FFF, Country{"Senegal", "SN"}},
+	{0x074000, 0x0743FF, Country{"Seychelles", "SC"}},
+	{0x076000, 0x0763FF, Country{"Sierra Leone", "SL"}},
+	{0x078000, 0x078FFF, Country{"Somalia", "SO"}},
+	{0x07A000, 0x07A3FF, Country{"Eswatini", "SZ"}},
+	{0x07C000, 0x07CFFF, Country{"Sudan", "SD"}},
+	{0x080000, 0x080FFF, Country{"Tanzania", "TZ"}},
+	{0x084000, 0x084FFF, Country{"Chad", "TD"}},
+	{0x088000, 0x088FFF, Country{"Togo", "TG"}},
+	{0x08A000, 0x08AFFF, Country{"Zambia", "ZM"}},
+	{0x08C000, 0x08CFFF, Country{"DR Congo", "CD"}},
+	{0x090000, 0x090FFF, Country{"Angola", "AO"}},
+	{0x094000, 0x0943FF, Country{"Benin", "BJ"}},
+	{0x096000, 0x0963FF, Country{"Cabo Verde", "CV"}},
+	{0x098000, 0x0983FF, Country{"Djibouti", "DJ"}},
+	{0x09A000, 0x09AFFF, Country{"Gambia", "GM"}},
+	{0x09C000, 0x09CFFF, Country{"Burkina Faso", "BF"}},
+	{0x09E000, 0x09E3FF, Country{"São Tomé and Príncipe", "ST"}},
+	{0x0A0000, 0x0A7FFF, Country{"Algeria", "DZ"}},
+	{0x0A8000, 0x0A8FFF, Country{"Bahamas", "BS"}},
+	{0x0AA000, 0x0AA3FF, Country{"Barbados", "BB"}},
+	{0x0AB000, 0x0AB3FF, Country{"Belize", "BZ"}},
+	{0x0AC000, 0x0ACFFF, Country{"Colombia", "CO"}},
+	{0x0AE000, 0x0AEFFF, Country{"Costa Rica", "CR"}},
+	{0x0B0000, 0x0B0FFF, Country{"Cuba", "CU"}},
+	{0x0B2000, 0x0B2FFF, Country{"El Salvador", "SV"}},
+	{0x0B4000, 0x0B4FFF, Country{"Guatemala", "GT"}},
+	{0x0B6000, 0x0B6FFF, Country{"Guyana", "GY"}},
+	{0x0B8000, 0x0B8FFF, Country{"Haiti", "HT"}},
+	{0x0BA000, 0x0BAFFF, Country{"Honduras", "HN"}},
+	{0x0BC000, 0x0BC3FF, Country{"Saint Vincent and the Grenadines", "VC"}},
+	{0x0BE000, 0x0BEFFF, Country{"Jamaica", "JM"}},
+	{0x0C0000, 0x0C0FFF, Country{"Nicaragua", "NI"}},
+	{0x0C2000, 0x0C2FFF, Country{"Panama", "PA"}},
+	{0x0C4000, 0x0C4FFF, Country{"Dominican Republic", "DO"}},
+	{0x0C6000, 0x0C6FFF, Country{"Trinidad and Tobago", "TT"}},
+	{0x0C8000, 0x0C8FFF, Country{"Suriname", "SR"}},
+	{0x0CA000, 0x0CA3FF, Country{"Antigua and Barbuda", "AG"}},
+	{0x0CC000, 0x0CC3FF, Country{"Grenada", "GD"}},
+	{0x0D0000, 0x0D7FFF, Country{"Mexico", "MX"}},
+	{0x0D8000, 0x0DFFFF, Country{"Venezuela", "VE"}},
+	{0x100000, 0x1FFFFF, Country{"Russia", "RU"}},
+	{0x201000, 0x2013FF, Country{"Namibia", "NA"}},
+	{0x202000, 0x2023FF, Country{"Eritrea", "ER"}},
+	{0x300000, 0x33FFFF, Country{"Italy", "IT"}},
+	{0x340000, 0x37FFFF, Country{"Spain", "ES"}},
+	{0x380000, 0x3BFFFF, Country{"France", "FR"}},
+	{0x3C0000, 0x3FFFFF, Country{"Germany", "DE"}},
+	{0x400000, 0x43FFFF, Country{"United Kingdom", "GB"}},
+	{0x440000, 0x447FFF, Country{"Austria", "AT"}},
+	{0x448000, 0x44FFFF, Country{"Belgium", "BE"}},
+	{0x450000, 0x457FFF, Country{"Bulgaria", "BG"}},
+	{0x458000, 0x45FFFF, Country{"Denmark", "DK"}},
+	{0x460000, 0x467FFF, Country{"Finland", "FI"}},
+	{0x468000, 0x46FFFF, Country{"Greece", "GR"}},
+	{0x470000, 0x477FFF, Country{"Hungary", "HU"}},
+	{0x478000, 0x47FFFF, Country{"Norway", "NO"}},
+	{0x480000, 0x487FFF, Country{"Netherlands", "NL"}},
+	{0x488000, 0x48FFFF, Country{"Poland", "PL"}},
+	{0x490000, 0x497FFF, Country{"Portugal", "PT"}},
+	{0x498000, 0x49FFFF, Country{"Czechia", "CZ"}},
+	{0x4A0000, 0x4A7FFF, Country{"Romania", "RO"}},
+	{0x4A8000, 0x4AFFFF, Country{"Sweden", "SE"}},
+	{0x4B0000, 0x4B7FFF, Country{"Switzerland", "CH"}},
+	{0x4B8000, 0x4BFFFF, Country{"Türkiye", "TR"}},
+	{0x4C0000, 0x4C7FFF, Country{"Serbia", "RS"}},
+	{0x4C8000, 0x4C83FF, Country{"Cyprus", "CY"}},
+	{0x4CA000, 0x4CAFFF, Country{"Ireland", "IE"}},
+	{0x4CC000, 0x4CCFFF, Country{"Iceland", "IS"}},
+	{0x4D0000, 0x4D03FF, Country{"Luxembourg", "LU"}},
+	{0x4D2000, 0x4D23FF, Country{"Malta", "MT"}},
+	{0x4D4000, 0x4D43FF, Country{"Monaco", "MC"}},
+	{0x500000, 0x5003FF, Country{"San Marino", "SM"}},
+	{0x501000, 0x5013FF, Country{"Albania", "AL"}},
+	{0x501C00, 0x501FFF, Country{"Croatia", "HR"}},
+	{0x502C00, 0x502FFF, Country{"Latvia", "LV"}},
+	{0x503C00, 0x503FFF, Country{"Lithuania", "LT"}},
+	{0x504C00, 0x504FFF, Country{"Moldova", "MD"}},
+	{0x505C00, 0x505FFF, Country{"Slovakia", "SK"}},
+	{0x506C00, 0x506FFF, Country{"Slovenia", "SI"}},
+	{0x507C00, 0x507FFF, Country{"Uzbekistan", "UZ"}},
+	{0x508000, 0x50FFFF, Country{"Ukraine", "UA"}},
+	{0x510000, 0x5103FF, Country{"Belarus", "BY"}},
+	{0x511000, 0x5113FF, Country{"Estonia", "EE"}},
+	{0x512000, 0x5123FF, Country{"North Macedonia", "MK"}},
+	{0x513000, 0x5133FF, Country{"Bosnia and Herzegovina", "BA"}},
+	{0x514000, 0x5143FF, Country{"Georgia", "GE"}},
+	{0x515000, 0x5153FF, Country{"Tajikistan", "TJ"}},
+	{0x516000, 0x5163FF, Country{"Montenegro", "ME"}},
+	{0x600000, 0x6003FF, Country{"Armenia", "AM"}},
+	{0x600800, 0x600BFF, Country{"Azerbaijan", "AZ"}},
+	{0x601000, 0x6013FF, Country{"Kyrgyzstan", "KG"}},
+	{0x601800, 0x601BFF, Country{"Turkmenistan", "TM"}},
+	{0x680000, 0x6803FF, Country{"Bhutan", "BT"}},
+	{0x681000, 0x6813FF, Country{"Micronesia", "FM"}},
+	{0x682000, 0x6823FF, Country{"Mongolia", "MN"}},
+	{0x683000, 0x6833FF, Country{"Kazakhstan", "KZ"}},
+	{0x684000, 0x6843FF, Country{"Palau", "PW"}},
+	{0x700000, 0x700FFF, Country{"Afghanistan", "AF"}},
+	{0x702000, 0x702FFF, Country{"Bangladesh", "BD"}},
+	{0x704000, 0x704FFF, Country{"Myanmar", "MM"}},
+	{0x706000, 0x706FFF, Country{"Kuwait", "KW"}},
+	{0x708000, 0x708FFF, Country{"Laos", "LA"}},
+	{0x70A000, 0x70AFFF, Country{"Nepal", "NP"}},
+	{0x70C000, 0x70C3FF, Country{"Oman", "OM"}},
+	{0x70E000, 0x70EFFF, Country{"Cambodia", "KH"}},
+	{0x710000, 0x717FFF, Country{"Saudi Arabia", "SA"}},
+	{0x718000, 0x71FFFF, Country{"South Korea", "KR"}},
+	{0x720000, 0x727FFF, Country{"North Korea", "KP"}},
+	{0x728000, 0x72FFFF, Country{"Iraq", "IQ"}},
+	{0x730000, 0x737FFF, Country{"Iran", "IR"}},
+	{0x738000, 0x73FFFF, Country{"Israel", "IL"}},
+	{0x740000, 0x747FFF, Country{"Jordan", "JO"}},
+	{0x748000, 0x74FFFF, Country{"Lebanon", "LB"}},
+	{0x750000, 0x757FFF, Country{"Malaysia", "MY"}},
+	{0x758000, 0x75FFFF, Country{"Philippines", "PH"}},
+	{0x760000, 0x767FFF, Country{"Pakistan", "PK"}},
+	{0x768000, 0x76FFFF, Country{"Singapore", "SG"}},
+	{0x770000, 0x777FFF, Country{"Sri Lanka", "LK"}},
+	{0x778000, 0x77FFFF, Country{"Syria", "SY"}},
+	{0x780000, 0x7BFFFF, Country{"China", "CN"}},
+	{0x789000, 0x789FFF, Country{"Hong Kong", "HK"}},
+	{0x7C0000, 0x7FFFFF, Country{"Australia", "AU"}},
+	{0x800000, 0x83FFFF, Country{"India", "IN"}},
+	{0x840000, 0x87FFFF, Country{"Japan", "JP"}},
+	{0x880000, 0x887FFF, Country{"Thailand", "TH"}},
+	{0x888000, 0x88FFFF, Country{"Vietnam", "VN"}},
+	{0x890000, 0x890FFF, Country{"Yemen", "YE"}},
+	{0x894000, 0x894FFF, Country{"Bahrain", "BH"}},
+	{0x895000, 0x8953FF, Country{"Brunei", "BN"}},
+	{0x896000, 0x896FFF, Country{"United Arab Emirates", "AE"}},
+	{0x897000, 0x8973FF, Country{"Solomon Islands", "SB"}},
+	{0x898000, 0x898FFF, Country{"Papua New Guinea", "PG"}},
+	{0x899000, 0x8993FF, Country{"Taiwan", "TW"}},
+	{0x8A0000, 0x8A7FFF, Country{"Indonesia", "ID"}},
+	{0x900000, 0x9003FF, Country{"Marshall Islands", "MH"}},
+	{0x901000, 0x9013FF, Country{"Cook Islands", "CK"}},
+	{0x902000, 0x9023FF, Country{"Samoa", "WS"}},
+	{0xA00000, 0xAFFFFF, Country{"United States", "US"}},
+	{0xC00000, 0xC3FFFF, Country{"Canada", "CA"}},
+	{0xC80000, 0xC87FFF, Country{"New Zealand", "NZ"}},
+	{0xC88000, 0xC88FFF, Country{"Fiji", "FJ"}},
+	{0xC8A000, 0xC8A3FF, Country{"Nauru", "NR"}},
+	{0xC8C000, 0xC8C3FF, Country{"Saint Lucia", "LC"}},
+	{0xC8D000, 0xC8D3FF, Country{"Tonga", "TO"}},
+	{0xC8E000, 0xC8E3FF, Country{"Kiribati", "KI"}},
+	{0xC90000, 0xC903FF, Country{"Vanuatu", "VU"}},
+	{0xE00000, 0xE3FFFF, Country{"Argentina", "AR"}},
+	{0xE40000, 0xE7FFFF, Country{"Brazil", "BR"}},
+	{0xE80000, 0xE80FFF, Country{"Chile", "CL"}},
+	{0xE84000, 0xE84FFF, Country{"Ecuador", "EC"}},
+	{0xE88000, 0xE88FFF, Country{"Paraguay", "PY"}},
+	{0xE8C000, 0xE8CFFF, Country{"Peru", "PE"}},
+	{0xE90000, 0xE90FFF, Country{"Uruguay", "UY"}},
+	{0xE94000, 0xE94FFF, Country{"Bolivia", "BO"}},
+}
+
+// CountryOf returns the country an ICAO address (hex, e.g. "A1B2C3") is
+// allocated to, and whether it falls in an allocated block. Addresses
+// outside them, including non-ICAO ones such as TIS-B's "~"-prefixed
+// addresses, are unknown.
+func CountryOf(icao string) (Country, bool) {
+	address, err := strconv.ParseUint(strings.TrimSpace(icao), 16, 32)
+	if err != nil || address > 0xFFFFFF {
+		return Country{}, false
+	}
+
+	var found *addressBlock
+	for i := range addressBlocks {
+		block := &addressBlocks[i]
+		if uint32(address) < block.first || uint32(address) > block.last {
+			continue
+		}
+		if found == nil || block.last-block.first < found.last-found.first {
+			found = block
+		}
+	}
+	if found == nil {
+		return Country{}, false
+	}
+	return found.country, true
+}
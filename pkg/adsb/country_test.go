@@ -0,0 +1,54 @@
+package adsb
+
+import "testing"
+
+// TestCountryOf tests decoding the country an ICAO address is allocated to.
+func TestCountryOf(t *testing.T) {
+	tests := []struct {
+		icao string
+		code string
+		ok   bool
+	}{
+		{"A1B2C3", "US", true},
+		{"a00000", "US", true},
+		{"AFFFFF", "US", true},
+		{"C01234", "CA", true},
+		{"3C6444", "DE", true},
+		{"406A93", "GB", true},
+		{"780ABC", "CN", true},
+		{"789123", "HK", true}, // Within China's block
+		{"7C1234", "AU", true},
+		{"4B1234", "CH", true},
+		{"B00000", "", false}, // Unallocated
+		{"~1A2B3C", "", false},
+		{"1000000", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		country, ok := CountryOf(tt.icao)
+		if ok != tt.ok || country.Code != tt.code {
+			t.Errorf("CountryOf(%q) = %+v, %v, want %s, %v", tt.icao, country, ok, tt.code, tt.ok)
+		}
+	}
+}
+
+// TestCountryFlag tests building flag emoji from country codes.
+func TestCountryFlag(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"US", "🇺🇸"},
+		{"de", "🇩🇪"},
+		{"", ""},
+		{"U1", ""},
+		{"USA", ""},
+	}
+
+	for _, tt := range tests {
+		if got := (Country{Code: tt.code}).Flag(); got != tt.want {
+			t.Errorf("Country{Code: %q}.Flag() = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
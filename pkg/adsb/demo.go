@@ -0,0 +1,228 @@
+package adsb
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// SourceDemo identifies synthetic aircraft generated by DemoSource.
+const SourceDemo = "demo"
+
+const (
+	// DemoRangeNM is how far from the center demo aircraft fly before being
+	// replaced by a new arrival
+	DemoRangeNM = 60.0
+
+	// demoOrbitRangeNM is how close to the center orbiting aircraft circle
+	demoOrbitRangeNM = 12.0
+)
+
+// demoType is an aircraft type flown by demo traffic.
+type demoType struct {
+	code     string
+	airline  bool    // Airline callsign, otherwise an N-number
+	minAltFt float64 // Cruise altitude range
+	maxAltFt float64
+	speedKts float64
+	orbits   bool // Flies orbits (e.g., sightseeing or survey) rather than transits
+}
+
+var demoTypes = []demoType{
+	{"B738", true, 24000, 39000, 450, false},
+	{"A320", true, 24000, 38000, 440, false},
+	{"B77W", true, 33000, 41000, 490, false},
+	{"E75L", true, 18000, 35000, 420, false},
+	{"CRJ9", true, 18000, 35000, 410, false},
+	{"C172", false, 2500, 7500, 110, true},
+	{"PC12", false, 8000, 24000, 260, false},
+	{"BE20", false, 6000, 20000, 240, false},
+}
+
+var demoAirlines = []string{"AAL", "DAL", "UAL", "SWA", "SKW", "JBU", "FDX", "ENY"}
+
+// demoFlight is one synthetic aircraft and where it's going.
+type demoFlight struct {
+	aircraft     Aircraft
+	targetAltFt  units.Feet // Altitude it is climbing or descending to
+	turnRateDegS float64    // Non-zero for orbiting aircraft
+}
+
+// DemoSource is a DataSource of synthetic aircraft flying around a center
+// point, for demo mode and for exploring the UIs without a receiver or API
+// access. Airliners cross the area at cruise altitude or climbing and
+// descending, and light aircraft circle nearby. Aircraft leaving the area are
+// replaced by new arrivals, so the traffic keeps flowing.
+//
+// Positions advance in real time between calls. Aircraft get ICAO addresses
+// counting up from "ff0000", so they're easy to tell from real ones.
+type DemoSource struct {
+	mu      sync.Mutex
+	center  coordinates.Geographic
+	rng     *rand.Rand
+	flights []*demoFlight
+	updated time.Time
+	serial  int
+
+	// now returns the current time (replaced in tests)
+	now func() time.Time
+}
+
+// NewDemoSource creates count demo aircraft around center. The same seed
+// produces the same traffic.
+func NewDemoSource(center coordinates.Geographic, count int, seed int64) *DemoSource {
+	d := &DemoSource{
+		center: center,
+		rng:    rand.New(rand.NewSource(seed)),
+		now:    time.Now,
+	}
+	d.updated = d.now().UTC()
+	for i := 0; i < count; i++ {
+		// Spread the first flights over the area rather than all at its edge
+		d.flights = append(d.flights, d.spawn(DemoRangeNM*(0.1+0.8*d.rng.Float64())))
+	}
+	return d
+}
+
+// GetAircraft implements DataSource. Aircraft are advanced to the current
+// time first.
+func (d *DemoSource) GetAircraft(centerLat, centerLon, radiusNM float64) ([]Aircraft, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.advance()
+
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+	var aircraft []Aircraft
+	for _, f := range d.flights {
+		pos := coordinates.Geographic{Latitude: f.aircraft.Latitude, Longitude: f.aircraft.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) <= radiusNM {
+			aircraft = append(aircraft, f.aircraft)
+		}
+	}
+	return aircraft, nil
+}
+
+// GetAircraftByICAO implements DataSource.
+func (d *DemoSource) GetAircraftByICAO(icao string) (*Aircraft, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.advance()
+
+	for _, f := range d.flights {
+		if strings.EqualFold(f.aircraft.ICAO, icao) {
+			ac := f.aircraft
+			return &ac, nil
+		}
+	}
+	return nil, nil
+}
+
+// Close implements DataSource.
+func (d *DemoSource) Close() error {
+	return nil
+}
+
+// advance moves every aircraft to the current time, replacing those that
+// have left the area. Called with d.mu held.
+func (d *DemoSource) advance() {
+	now := d.now().UTC()
+	dt := now.Sub(d.updated).Seconds()
+	d.updated = now
+	if dt <= 0 {
+		return
+	}
+
+	for i, f := range d.flights {
+		ac := &f.aircraft
+
+		// Turn (orbits), then fly the average track over the step
+		track := ac.Track + f.turnRateDegS*dt/2
+		ac.Track = coordinates.NormalizeAzimuth(ac.Track + f.turnRateDegS*dt)
+		pos := coordinates.Destination(
+			coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude},
+			track, ac.GroundSpeed*dt/3600)
+		ac.Latitude, ac.Longitude = pos.Latitude, pos.Longitude
+
+		// Climb or descend to the target altitude, then level off
+		if ac.VerticalRate != 0 {
+			ac.Altitude += units.Feet(ac.VerticalRate * dt / 60)
+			if (ac.VerticalRate > 0) == (ac.Altitude >= f.targetAltFt) {
+				ac.Altitude = f.targetAltFt
+				ac.VerticalRate = 0
+			}
+		}
+		ac.LastSeen = now
+
+		if coordinates.DistanceNauticalMiles(d.center, pos) > DemoRangeNM {
+			d.flights[i] = d.spawn(DemoRangeNM)
+		}
+	}
+}
+
+// spawn creates a flight distanceNM from the center. Transits head across
+// the area, passing within about 15 nm of the center; orbits circle nearby.
+// Called with d.mu held.
+func (d *DemoSource) spawn(distanceNM float64) *demoFlight {
+	d.serial++
+	typ := demoTypes[d.rng.Intn(len(demoTypes))]
+	bearing := d.rng.Float64() * 360
+
+	ac := Aircraft{
+		ICAO:         fmt.Sprintf("ff%04x", d.serial%0x10000),
+		AircraftType: typ.code,
+		GroundSpeed:  typ.speedKts * (0.9 + 0.2*d.rng.Float64()),
+		Squawk:       fmt.Sprintf("%04o", 0o1000+d.rng.Intn(0o6000)),
+		Source:       SourceDemo,
+		LastSeen:     d.updated,
+	}
+	if typ.airline {
+		ac.Callsign = fmt.Sprintf("%s%d", demoAirlines[d.rng.Intn(len(demoAirlines))], 100+d.rng.Intn(9000))
+	} else {
+		ac.Callsign = fmt.Sprintf("N%d%c%c", 100+d.rng.Intn(900), 'A'+rune(d.rng.Intn(26)), 'A'+rune(d.rng.Intn(26)))
+	}
+
+	f := &demoFlight{aircraft: ac}
+	cruise := units.Feet(typ.minAltFt + d.rng.Float64()*(typ.maxAltFt-typ.minAltFt))
+	cruise = units.Feet(math.Round(float64(cruise)/1000) * 1000)
+
+	if typ.orbits {
+		distanceNM = math.Min(distanceNM, demoOrbitRangeNM)
+		f.aircraft.Track = d.rng.Float64() * 360
+		f.turnRateDegS = 1.5 // Gentle orbit, about 2.3 nm across at 110 kts
+		if d.rng.Intn(2) == 0 {
+			f.turnRateDegS = -f.turnRateDegS
+		}
+		f.aircraft.Altitude = cruise
+	} else {
+		// Head back across the area, missing the center by up to ~15 nm
+		miss := (d.rng.Float64()*2 - 1) * 15
+		offset := math.Asin(math.Max(-1, math.Min(1, miss/math.Max(distanceNM, 15)))) * coordinates.RadiansToDegrees
+		f.aircraft.Track = coordinates.NormalizeAzimuth(bearing + 180 + offset)
+
+		// Two thirds are climbing out or descending in
+		switch d.rng.Intn(3) {
+		case 0:
+			f.aircraft.Altitude = cruise
+		case 1:
+			f.aircraft.Altitude = units.Feet(math.Round(float64(cruise)*0.3/100) * 100)
+			f.aircraft.VerticalRate = 2000
+		default:
+			f.aircraft.Altitude = cruise
+			cruise = units.Feet(math.Round(float64(cruise)*0.3/100) * 100)
+			f.aircraft.VerticalRate = -1500
+		}
+	}
+	f.targetAltFt = cruise
+
+	pos := coordinates.Destination(d.center, bearing, distanceNM)
+	f.aircraft.Latitude, f.aircraft.Longitude = pos.Latitude, pos.Longitude
+	return f
+}
@@ -0,0 +1,94 @@
+package adsb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestDemoSource tests that demo traffic moves at its ground speed and stays
+// in the area as aircraft come and go.
+func TestDemoSource(t *testing.T) {
+	center := coordinates.Geographic{Latitude: 37.08, Longitude: -94.51}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	demo := NewDemoSource(center, 20, 1)
+	demo.now = func() time.Time { return now }
+	demo.updated = now
+
+	before, err := demo.GetAircraft(center.Latitude, center.Longitude, DemoRangeNM)
+	if err != nil {
+		t.Fatalf("GetAircraft failed: %v", err)
+	}
+	if len(before) != 20 {
+		t.Fatalf("Expected 20 aircraft, got %d", len(before))
+	}
+
+	// One step: straight-flying aircraft cover their ground speed
+	now = now.Add(10 * time.Second)
+	for _, ac := range before {
+		after, err := demo.GetAircraftByICAO(ac.ICAO)
+		if err != nil {
+			t.Fatalf("GetAircraftByICAO failed: %v", err)
+		}
+		if after == nil {
+			continue // Left the area and was replaced
+		}
+		if after.Source != SourceDemo || !after.LastSeen.Equal(now) {
+			t.Errorf("%s: expected source %q seen at %v, got %q at %v", ac.ICAO, SourceDemo, now, after.Source, after.LastSeen)
+		}
+		moved := coordinates.DistanceNauticalMiles(
+			coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude},
+			coordinates.Geographic{Latitude: after.Latitude, Longitude: after.Longitude})
+		want := ac.GroundSpeed * 10 / 3600
+		if after.Track == ac.Track && math.Abs(moved-want) > 0.01 {
+			t.Errorf("%s: expected to move %.3f nm, moved %.3f nm", ac.ICAO, want, moved)
+		}
+	}
+
+	// An hour later traffic has turned over but is still in the area
+	for i := 0; i < 1800; i++ {
+		now = now.Add(2 * time.Second)
+		if _, err := demo.GetAircraft(center.Latitude, center.Longitude, 1); err != nil {
+			t.Fatalf("GetAircraft failed: %v", err)
+		}
+	}
+	aircraft, err := demo.GetAircraft(center.Latitude, center.Longitude, 2*DemoRangeNM)
+	if err != nil {
+		t.Fatalf("GetAircraft failed: %v", err)
+	}
+	if len(aircraft) != 20 {
+		t.Fatalf("Expected 20 aircraft after an hour, got %d", len(aircraft))
+	}
+
+	replaced := 0
+	for _, ac := range aircraft {
+		distance := coordinates.DistanceNauticalMiles(center, coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude})
+		if distance > DemoRangeNM {
+			t.Errorf("%s is %.1f nm away, beyond the demo range", ac.ICAO, distance)
+		}
+		if ac.Altitude < 500 || ac.Altitude > 45000 {
+			t.Errorf("%s at implausible altitude %.0f ft", ac.ICAO, ac.Altitude)
+		}
+		if ac.Callsign == "" || ac.AircraftType == "" {
+			t.Errorf("%s missing callsign or type: %+v", ac.ICAO, ac)
+		}
+		if ac.ICAO > "ff0014" {
+			replaced++
+		}
+	}
+	if replaced == 0 {
+		t.Error("Expected aircraft leaving the area to be replaced")
+	}
+
+	// A small radius only returns nearby aircraft
+	nearby, err := demo.GetAircraft(center.Latitude, center.Longitude, 5)
+	if err != nil {
+		t.Fatalf("GetAircraft failed: %v", err)
+	}
+	if len(nearby) >= len(aircraft) {
+		t.Errorf("Expected fewer aircraft within 5 nm, got %d of %d", len(nearby), len(aircraft))
+	}
+}
@@ -0,0 +1,140 @@
+package adsb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// Source tags identify which feed an aircraft report came from.
+const (
+	// SourceAirplanesLive is the airplanes.live online API (1090 MHz ADS-B/MLAT)
+	SourceAirplanesLive = "airplanes.live"
+
+	// SourceUAT978 is a local dump978 receiver (978 MHz UAT, US general aviation)
+	SourceUAT978 = "uat978"
+)
+
+// Dump978Client implements the DataSource interface for a local dump978 receiver.
+// It reads the aircraft.json file published by skyaware978 (dump978-fa), which
+// uses the same field layout as dump1090's aircraft.json.
+//
+// UAT is only used in the United States below 18,000 ft, so this source mostly
+// adds low and slow general aviation traffic that online 1090 MHz feeds miss.
+type Dump978Client struct {
+	// baseURL is the skyaware978 root (e.g., "http://piaware.local/skyaware978")
+	baseURL string
+
+	// httpClient is the HTTP client used for requests
+	httpClient *http.Client
+}
+
+// NewDump978Client creates a new dump978 client.
+// baseURL should point to the skyaware978 web root; /data/aircraft.json is appended.
+func NewDump978Client(baseURL string) *Dump978Client {
+	return &Dump978Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// GetAircraft returns all UAT aircraft with a position within radiusNM of the center.
+// The receiver reports everything it hears, so filtering is done client-side.
+func (c *Dump978Client) GetAircraft(centerLat, centerLon, radiusNM float64) ([]Aircraft, error) {
+	all, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+	aircraft := make([]Aircraft, 0, len(all))
+	for _, ac := range all {
+		pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) <= radiusNM {
+			aircraft = append(aircraft, ac)
+		}
+	}
+
+	return aircraft, nil
+}
+
+// GetAircraftByICAO returns a specific aircraft by its ICAO hex code.
+// Returns nil if the receiver is not currently hearing the aircraft.
+func (c *Dump978Client) GetAircraftByICAO(icao string) (*Aircraft, error) {
+	all, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ac := range all {
+		if strings.EqualFold(ac.ICAO, icao) {
+			return &ac, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Close cleanly shuts down the client.
+// For dump978, this is a no-op as there are no persistent connections.
+func (c *Dump978Client) Close() error {
+	return nil
+}
+
+// fetch downloads and converts the receiver's current aircraft list.
+// Aircraft without a position are skipped.
+func (c *Dump978Client) fetch() ([]Aircraft, error) {
+	url := c.baseURL + "/data/aircraft.json"
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dump978 data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("dump978 returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var data dump978Response
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse dump978 response: %w", err)
+	}
+
+	aircraft := make([]Aircraft, 0, len(data.Aircraft))
+	for _, ac := range data.Aircraft {
+		if ac.Lat == nil || ac.Lon == nil {
+			continue
+		}
+
+		converted := convertAirplanesLiveAircraft(ac)
+		converted.Source = SourceUAT978
+		if converted.Callsign != "" {
+			converted.Callsign = strings.TrimSpace(converted.Callsign)
+		}
+		aircraft = append(aircraft, converted)
+	}
+
+	return aircraft, nil
+}
+
+// dump978Response represents skyaware978's aircraft.json.
+// Individual aircraft use the dump1090 field names, shared with airplanes.live.
+type dump978Response struct {
+	// Now is the receiver's current time (Unix seconds)
+	Now float64 `json:"now"`
+
+	// Messages is the total number of messages received
+	Messages int `json:"messages"`
+
+	// Aircraft is the list of aircraft currently heard
+	Aircraft []airplanesLiveAircraft `json:"aircraft"`
+}
@@ -0,0 +1,100 @@
+package adsb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// dump978Sample is a trimmed skyaware978 aircraft.json payload.
+const dump978Sample = `{
+  "now": 1700000000.0,
+  "messages": 1234,
+  "aircraft": [
+    {"hex": "a1b2c3", "type": "adsb_icao", "flight": "N123AB  ", "lat": 35.20, "lon": -80.90,
+     "alt_baro": 4500, "gs": 110.0, "track": 45.0, "baro_rate": -300, "squawk": "1200", "seen": 1.0},
+    {"hex": "~2a0001", "type": "tisb_other", "lat": 38.50, "lon": -77.00, "alt_baro": 2500, "seen": 3.0},
+    {"hex": "a99999", "type": "adsb_icao", "flight": "N999ZZ", "seen": 0.5}
+  ]
+}`
+
+// TestDump978GetAircraft tests fetching and filtering UAT aircraft.
+func TestDump978GetAircraft(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/skyaware978/data/aircraft.json" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(dump978Sample))
+	}))
+	defer server.Close()
+
+	client := NewDump978Client(server.URL + "/skyaware978/")
+
+	t.Run("Filters by radius", func(t *testing.T) {
+		aircraft, err := client.GetAircraft(35.0, -80.8, 50)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(aircraft) != 1 {
+			t.Fatalf("Expected 1 aircraft within 50 NM, got %d", len(aircraft))
+		}
+
+		ac := aircraft[0]
+		if ac.ICAO != "a1b2c3" {
+			t.Errorf("Expected ICAO a1b2c3, got %s", ac.ICAO)
+		}
+		if ac.Callsign != "N123AB" {
+			t.Errorf("Expected trimmed callsign N123AB, got %q", ac.Callsign)
+		}
+		if ac.Source != SourceUAT978 {
+			t.Errorf("Expected source %s, got %s", SourceUAT978, ac.Source)
+		}
+		if ac.Altitude != 4500 {
+			t.Errorf("Expected altitude 4500, got %f", ac.Altitude)
+		}
+		if ac.Squawk != "1200" {
+			t.Errorf("Expected squawk 1200, got %s", ac.Squawk)
+		}
+	})
+
+	t.Run("Skips aircraft without position", func(t *testing.T) {
+		aircraft, err := client.GetAircraft(35.0, -80.8, 2500)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(aircraft) != 2 {
+			t.Errorf("Expected 2 positioned aircraft, got %d", len(aircraft))
+		}
+	})
+
+	t.Run("Lookup by ICAO", func(t *testing.T) {
+		ac, err := client.GetAircraftByICAO("A1B2C3")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if ac == nil {
+			t.Fatal("Expected aircraft, got nil")
+		}
+
+		missing, err := client.GetAircraftByICAO("ffffff")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if missing != nil {
+			t.Error("Expected nil for unknown aircraft")
+		}
+	})
+}
+
+// TestDump978ServerError tests error handling for a failing receiver.
+func TestDump978ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewDump978Client(server.URL)
+	if _, err := client.GetAircraft(35.0, -80.8, 50); err == nil {
+		t.Error("Expected error for 503 response")
+	}
+}
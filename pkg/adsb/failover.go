@@ -0,0 +1,201 @@
+package adsb
+
+import (
+	"sync"
+	"time"
+)
+
+// FailoverConfig configures a Failover.
+type FailoverConfig struct {
+	// FailureThreshold is the number of consecutive failed collection
+	// cycles before switching to the next source
+	FailureThreshold int
+
+	// ProbeInterval is how often the primary is probed while a backup
+	// source is active
+	ProbeInterval time.Duration
+}
+
+// DefaultFailoverConfig returns the default failover behavior: switch
+// after 3 failed cycles and probe the primary every minute.
+func DefaultFailoverConfig() FailoverConfig {
+	return FailoverConfig{
+		FailureThreshold: 3,
+		ProbeInterval:    time.Minute,
+	}
+}
+
+// FailoverSwitch describes a change of the active source.
+type FailoverSwitch struct {
+	// From and To are the names of the previous and new active source
+	From string
+	To   string
+
+	// Recovered is set when a probe found the primary healthy again
+	Recovered bool
+
+	// Downtime is how long the primary was out of use (set on recovery)
+	Downtime time.Duration
+
+	// Reason is the last error from the source switched away from
+	// (empty on recovery)
+	Reason string
+}
+
+// FailoverStatus is a snapshot of a Failover for reporting.
+type FailoverStatus struct {
+	// Active is the name of the source currently collected from
+	Active string
+
+	// Primary is the name of the preferred source
+	Primary string
+
+	// ConsecutiveFailures is the active source's current run of failed cycles
+	ConsecutiveFailures int
+
+	// Failovers is how many times the collector has switched away from a
+	// failing source
+	Failovers int
+
+	// FailedOverAt is when the primary was last switched away from (zero
+	// while the primary is active)
+	FailedOverAt time.Time
+}
+
+// FailedOver reports whether a backup source is active.
+func (s FailoverStatus) FailedOver() bool {
+	return s.Active != s.Primary
+}
+
+// Failover chooses which of several redundant sources to collect from.
+//
+// Sources are listed in order of preference. After FailureThreshold
+// consecutive failed cycles the next source becomes active (wrapping
+// around once every source has failed). While a backup is active the
+// primary is probed every ProbeInterval, and collection fails back to it
+// as soon as a probe succeeds.
+type Failover struct {
+	mu sync.Mutex
+
+	cfg     FailoverConfig
+	sources []string
+
+	active       int
+	failures     int
+	failovers    int
+	failedOverAt time.Time
+	lastProbe    time.Time
+	lastError    string
+
+	// now returns the current time (replaceable in tests)
+	now func() time.Time
+}
+
+// NewFailover creates a failover across the named sources, starting on the
+// first (primary) source.
+func NewFailover(sources []string, cfg FailoverConfig) *Failover {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	return &Failover{
+		cfg:     cfg,
+		sources: sources,
+		now:     time.Now,
+	}
+}
+
+// Active returns the index of the source to collect from.
+func (f *Failover) Active() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+// RecordCycle records the outcome of a collection cycle on the active
+// source: nil if any query succeeded, otherwise the last error. Returns the
+// switch made, or nil if the active source is unchanged.
+func (f *Failover) RecordCycle(err error) *FailoverSwitch {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		f.failures = 0
+		return nil
+	}
+
+	f.failures++
+	f.lastError = err.Error()
+	if f.failures < f.cfg.FailureThreshold || len(f.sources) < 2 {
+		return nil
+	}
+
+	sw := &FailoverSwitch{From: f.sources[f.active], Reason: f.lastError}
+	if f.active == 0 {
+		f.failedOverAt = f.now()
+		f.lastProbe = f.failedOverAt
+	}
+	f.active = (f.active + 1) % len(f.sources)
+	if f.active == 0 {
+		f.failedOverAt = time.Time{}
+	}
+	f.failures = 0
+	f.failovers++
+	sw.To = f.sources[f.active]
+	return sw
+}
+
+// ProbeDue reports whether the primary should be probed now. Returning true
+// starts a new probe interval.
+func (f *Failover) ProbeDue() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == 0 {
+		return false
+	}
+	now := f.now()
+	if now.Sub(f.lastProbe) < f.cfg.ProbeInterval {
+		return false
+	}
+	f.lastProbe = now
+	return true
+}
+
+// RecordProbe records the outcome of a probe of the primary. A successful
+// probe fails back to the primary and returns the switch; otherwise nil.
+func (f *Failover) RecordProbe(err error) *FailoverSwitch {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil || f.active == 0 {
+		return nil
+	}
+
+	sw := &FailoverSwitch{
+		From:      f.sources[f.active],
+		To:        f.sources[0],
+		Recovered: true,
+		Downtime:  f.now().Sub(f.failedOverAt),
+	}
+	f.active = 0
+	f.failures = 0
+	f.failedOverAt = time.Time{}
+	return sw
+}
+
+// Status returns a snapshot of the failover state.
+func (f *Failover) Status() FailoverStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := FailoverStatus{
+		ConsecutiveFailures: f.failures,
+		Failovers:           f.failovers,
+		FailedOverAt:        f.failedOverAt,
+	}
+	if len(f.sources) > 0 {
+		status.Active = f.sources[f.active]
+		status.Primary = f.sources[0]
+	}
+	return status
+}
@@ -0,0 +1,96 @@
+package adsb
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestFailover tests switching between sources and failing back.
+func TestFailover(t *testing.T) {
+	now := time.Now()
+	outage := errors.New("503 Service Unavailable")
+
+	newFailover := func(sources ...string) *Failover {
+		f := NewFailover(sources, FailoverConfig{FailureThreshold: 3, ProbeInterval: time.Minute})
+		f.now = func() time.Time { return now }
+		return f
+	}
+
+	t.Run("Switches after consecutive failed cycles", func(t *testing.T) {
+		f := newFailover("primary", "backup")
+		f.RecordCycle(outage)
+		f.RecordCycle(nil) // A good cycle resets the count
+		f.RecordCycle(outage)
+		if sw := f.RecordCycle(outage); sw != nil {
+			t.Fatalf("Expected no switch after 2 failures, got %+v", sw)
+		}
+
+		sw := f.RecordCycle(outage)
+		if sw == nil || sw.From != "primary" || sw.To != "backup" || sw.Recovered {
+			t.Fatalf("Expected switch from primary to backup, got %+v", sw)
+		}
+		if sw.Reason != outage.Error() {
+			t.Errorf("Expected reason %q, got %q", outage, sw.Reason)
+		}
+
+		status := f.Status()
+		if !status.FailedOver() || status.Active != "backup" || status.Failovers != 1 {
+			t.Errorf("Unexpected status after failover: %+v", status)
+		}
+	})
+
+	t.Run("Fails back when the primary probe succeeds", func(t *testing.T) {
+		f := newFailover("primary", "backup")
+		for i := 0; i < 3; i++ {
+			f.RecordCycle(outage)
+		}
+
+		if f.ProbeDue() {
+			t.Error("Expected no probe before the probe interval")
+		}
+		now = now.Add(time.Minute)
+		if !f.ProbeDue() {
+			t.Fatal("Expected probe after the probe interval")
+		}
+		if f.ProbeDue() {
+			t.Error("Expected one probe per interval")
+		}
+		if sw := f.RecordProbe(outage); sw != nil {
+			t.Fatalf("Expected failed probe to stay on backup, got %+v", sw)
+		}
+
+		now = now.Add(time.Minute)
+		f.ProbeDue()
+		sw := f.RecordProbe(nil)
+		if sw == nil || !sw.Recovered || sw.To != "primary" || sw.Downtime != 2*time.Minute {
+			t.Fatalf("Expected recovery to primary after 2m, got %+v", sw)
+		}
+		if f.Active() != 0 || f.Status().FailedOver() {
+			t.Error("Expected primary active after recovery")
+		}
+		if f.ProbeDue() {
+			t.Error("Expected no probes while on the primary")
+		}
+	})
+
+	t.Run("Wraps around when every source fails", func(t *testing.T) {
+		f := newFailover("primary", "backup")
+		for i := 0; i < 6; i++ {
+			f.RecordCycle(outage)
+		}
+		status := f.Status()
+		if status.Active != "primary" || !status.FailedOverAt.IsZero() || status.Failovers != 2 {
+			t.Errorf("Expected primary active again after 2 failovers, got %+v", status)
+		}
+	})
+
+	t.Run("Single source never switches", func(t *testing.T) {
+		f := newFailover("primary")
+		for i := 0; i < 10; i++ {
+			if sw := f.RecordCycle(outage); sw != nil {
+				t.Fatalf("Expected no switch with one source, got %+v", sw)
+			}
+		}
+	})
+}
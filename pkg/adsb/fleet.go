@@ -0,0 +1,200 @@
+package adsb
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Aircraft categories, from the type designator
+const (
+	CategoryWidebody   = "widebody"
+	CategoryNarrowbody = "narrowbody"
+	CategoryRegional   = "regional"
+	CategoryTurboprop  = "turboprop"
+	CategoryBizjet     = "bizjet"
+	CategoryLight      = "light"
+)
+
+// Categories lists the aircraft categories.
+var Categories = []string{
+	CategoryWidebody, CategoryNarrowbody, CategoryRegional,
+	CategoryTurboprop, CategoryBizjet, CategoryLight,
+}
+
+// fleetType is the family and category of an ICAO type designator.
+type fleetType struct {
+	family   string
+	category string
+}
+
+// fleetTypes maps common ICAO type designators to their family and
+// category. Designators not listed are their own family, of unknown
+// category.
+var fleetTypes = func() map[string]fleetType {
+	families := []struct {
+		family, category string
+		designators      []string
+	}{
+		{"747", CategoryWidebody, []string{"B741", "B742", "B743", "B744", "B748", "B74R", "B74S"}},
+		{"767", CategoryWidebody, []string{"B762", "B763", "B764"}},
+		{"777", CategoryWidebody, []string{"B772", "B773", "B77L", "B77W", "B778", "B779"}},
+		{"787", CategoryWidebody, []string{"B788", "B789", "B78X"}},
+		{"A300", CategoryWidebody, []string{"A306", "A30B"}},
+		{"A310", CategoryWidebody, []string{"A310"}},
+		{"A330", CategoryWidebody, []string{"A332", "A333", "A338", "A339"}},
+		{"A340", CategoryWidebody, []string{"A342", "A343", "A345", "A346"}},
+		{"A350", CategoryWidebody, []string{"A359", "A35K"}},
+		{"A380", CategoryWidebody, []string{"A388"}},
+		{"MD-11", CategoryWidebody, []string{"MD11"}},
+		{"DC-10", CategoryWidebody, []string{"DC10"}},
+		{"717", CategoryNarrowbody, []string{"B712"}},
+		{"737", CategoryNarrowbody, []string{"B731", "B732", "B733", "B734", "B735", "B736", "B737", "B738", "B739", "B37M", "B38M", "B39M", "B3XM"}},
+		{"757", CategoryNarrowbody, []string{"B752", "B753"}},
+		{"A220", CategoryNarrowbody, []string{"BCS1", "BCS3"}},
+		{"A320", CategoryNarrowbody, []string{"A318", "A319", "A320", "A321", "A19N", "A20N", "A21N"}},
+		{"MD-80", CategoryNarrowbody, []string{"MD81", "MD82", "MD83", "MD87", "MD88", "MD90"}},
+		{"E-Jet", CategoryRegional, []string{"E170", "E75L", "E75S", "E190", "E195", "E290", "E295"}},
+		{"ERJ", CategoryRegional, []string{"E135", "E145", "E45X"}},
+		{"CRJ", CategoryRegional, []string{"CRJ1", "CRJ2", "CRJ7", "CRJ9", "CRJX"}},
+		{"Dash 8", CategoryTurboprop, []string{"DH8A", "DH8B", "DH8C", "DH8D"}},
+		{"ATR", CategoryTurboprop, []string{"AT43", "AT45", "AT72", "AT75", "AT76"}},
+		{"Caravan", CategoryTurboprop, []string{"C208"}},
+		{"King Air", CategoryTurboprop, []string{"BE20", "BE30", "BE35", "B350", "BE9L"}},
+		{"PC-12", CategoryTurboprop, []string{"PC12"}},
+		{"Citation", CategoryBizjet, []string{"C25A", "C25B", "C25C", "C500", "C510", "C525", "C550", "C560", "C56X", "C650", "C680", "C68A", "C700", "C750"}},
+		{"Challenger", CategoryBizjet, []string{"CL30", "CL35", "CL60"}},
+		{"Global", CategoryBizjet, []string{"GL5T", "GL7T", "GLEX"}},
+		{"Gulfstream", CategoryBizjet, []string{"G150", "G280", "GLF4", "GLF5", "GLF6", "GA5C", "GA6C"}},
+		{"Learjet", CategoryBizjet, []string{"LJ35", "LJ40", "LJ45", "LJ60", "LJ75"}},
+		{"Phenom", CategoryBizjet, []string{"E50P", "E55P"}},
+		{"Cessna", CategoryLight, []string{"C150", "C152", "C162", "C172", "C177", "C180", "C182", "C206", "C210"}},
+		{"Piper", CategoryLight, []string{"P28A", "P28B", "P28R", "PA32", "PA34", "PA44", "PA46"}},
+		{"Cirrus", CategoryLight, []string{"SR20", "SR22", "S22T"}},
+		{"Diamond", CategoryLight, []string{"DA40", "DA42", "DA62"}},
+	}
+
+	types := make(map[string]fleetType)
+	for _, f := range families {
+		for _, d := range f.designators {
+			types[d] = fleetType{family: f.family, category: f.category}
+		}
+	}
+	return types
+}()
+
+// TypeFamily returns the family of an ICAO type designator ("B744" is a
+// "747"), or the designator itself if its family is unknown.
+func TypeFamily(aircraftType string) string {
+	aircraftType = strings.ToUpper(strings.TrimSpace(aircraftType))
+	if t, ok := fleetTypes[aircraftType]; ok {
+		return t.family
+	}
+	return aircraftType
+}
+
+// TypeCategory returns the category of an ICAO type designator (e.g.
+// "widebody"), or "" if it is unknown.
+func TypeCategory(aircraftType string) string {
+	return fleetTypes[strings.ToUpper(strings.TrimSpace(aircraftType))].category
+}
+
+// Operator returns the ICAO designator of the operator flying under a
+// callsign ("FDX1234" is flown by "FDX"), or "" if the callsign isn't a
+// designator followed by a flight number, e.g. a registration.
+func Operator(callsign string) string {
+	callsign = strings.ToUpper(strings.TrimSpace(callsign))
+	if len(callsign) < 4 || !unicode.IsDigit(rune(callsign[3])) {
+		return ""
+	}
+	for _, r := range callsign[:3] {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+	}
+	return callsign[:3]
+}
+
+// FleetFilter selects aircraft by operator, type family and category. Each
+// set criterion must match one of its values; empty criteria match any
+// aircraft. Aircraft whose operator or type isn't known don't match a
+// criterion on it.
+type FleetFilter struct {
+	// Operators are ICAO airline designators ("FDX") or parts of airline
+	// names ("fedex")
+	Operators []string `json:"operators,omitempty"`
+
+	// TypeFamilies are type families ("747", "A320") or ICAO type
+	// designators ("B744")
+	TypeFamilies []string `json:"type_families,omitempty"`
+
+	// Categories are aircraft categories (see Categories)
+	Categories []string `json:"categories,omitempty"`
+}
+
+// Active reports whether any criterion is set.
+func (f FleetFilter) Active() bool {
+	return len(f.Operators) > 0 || len(f.TypeFamilies) > 0 || len(f.Categories) > 0
+}
+
+// Matches reports whether an aircraft passes the filter.
+func (f FleetFilter) Matches(ac Aircraft) bool {
+	if len(f.Operators) > 0 && !matchesOperator(f.Operators, ac.Callsign) {
+		return false
+	}
+	if len(f.TypeFamilies) > 0 && !matchesFamily(f.TypeFamilies, ac.AircraftType) {
+		return false
+	}
+	if len(f.Categories) > 0 && !matchesAny(f.Categories, TypeCategory(ac.AircraftType)) {
+		return false
+	}
+	return true
+}
+
+// matchesOperator reports whether a callsign's operator is one of
+// operators, by designator or part of its name.
+func matchesOperator(operators []string, callsign string) bool {
+	designator := Operator(callsign)
+	if designator == "" {
+		return false
+	}
+	name, _ := AirlineName(designator)
+	for _, op := range operators {
+		op = strings.TrimSpace(op)
+		if strings.EqualFold(op, designator) ||
+			(name != "" && op != "" && strings.Contains(strings.ToLower(name), strings.ToLower(op))) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFamily reports whether an aircraft type is in one of families,
+// given as family names (with or without Boeing's "B": "747", "B747") or
+// type designators.
+func matchesFamily(families []string, aircraftType string) bool {
+	if aircraftType == "" {
+		return false
+	}
+	family := TypeFamily(aircraftType)
+	for _, f := range families {
+		f = strings.TrimSpace(f)
+		if strings.EqualFold(f, family) || strings.EqualFold(f, "B"+family) ||
+			strings.EqualFold(f, aircraftType) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether value is one of values, ignoring case.
+func matchesAny(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package adsb
+
+import "testing"
+
+// TestOperator tests taking the operator from a callsign.
+func TestOperator(t *testing.T) {
+	tests := []struct {
+		callsign string
+		want     string
+	}{
+		{"FDX1234", "FDX"},
+		{"ual12 ", "UAL"},
+		{"N12345", ""},
+		{"DLH4AB", "DLH"},
+		{"GABCD", ""},
+		{"AB1", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Operator(tt.callsign); got != tt.want {
+			t.Errorf("Operator(%q) = %q, want %q", tt.callsign, got, tt.want)
+		}
+	}
+}
+
+// TestTypeFamily tests grouping type designators into families and
+// categories.
+func TestTypeFamily(t *testing.T) {
+	tests := []struct {
+		aircraftType string
+		family       string
+		category     string
+	}{
+		{"B744", "747", CategoryWidebody},
+		{"b38m", "737", CategoryNarrowbody},
+		{"A21N", "A320", CategoryNarrowbody},
+		{"E75L", "E-Jet", CategoryRegional},
+		{"DH8D", "Dash 8", CategoryTurboprop},
+		{"GLF6", "Gulfstream", CategoryBizjet},
+		{"C172", "Cessna", CategoryLight},
+		{"ZZZZ", "ZZZZ", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := TypeFamily(tt.aircraftType); got != tt.family {
+			t.Errorf("TypeFamily(%q) = %q, want %q", tt.aircraftType, got, tt.family)
+		}
+		if got := TypeCategory(tt.aircraftType); got != tt.category {
+			t.Errorf("TypeCategory(%q) = %q, want %q", tt.aircraftType, got, tt.category)
+		}
+	}
+}
+
+// TestFleetFilter tests selecting aircraft by operator, family and category.
+func TestFleetFilter(t *testing.T) {
+	fedex747 := Aircraft{Callsign: "FDX5X", AircraftType: "B744"}
+	fedex757 := Aircraft{Callsign: "FDX1234", AircraftType: "B752"}
+	united737 := Aircraft{Callsign: "UAL12", AircraftType: "B38M"}
+	private := Aircraft{Callsign: "N172SP", AircraftType: "C172"}
+	unknown := Aircraft{Callsign: "FDX99"}
+
+	tests := []struct {
+		name   string
+		filter FleetFilter
+		ac     Aircraft
+		want   bool
+	}{
+		{"Empty filter", FleetFilter{}, private, true},
+		{"Operator designator", FleetFilter{Operators: []string{"fdx"}}, fedex757, true},
+		{"Operator name", FleetFilter{Operators: []string{"FedEx"}}, fedex747, true},
+		{"Other operator", FleetFilter{Operators: []string{"fedex"}}, united737, false},
+		{"No operator", FleetFilter{Operators: []string{"fedex"}}, private, false},
+		{"Family", FleetFilter{TypeFamilies: []string{"747"}}, fedex747, true},
+		{"Family with B", FleetFilter{TypeFamilies: []string{"B737"}}, united737, true},
+		{"Designator", FleetFilter{TypeFamilies: []string{"B752"}}, fedex757, true},
+		{"Other family", FleetFilter{TypeFamilies: []string{"747"}}, fedex757, false},
+		{"Unknown type", FleetFilter{TypeFamilies: []string{"747"}}, unknown, false},
+		{"Category", FleetFilter{Categories: []string{"Light"}}, private, true},
+		{"Other category", FleetFilter{Categories: []string{"widebody"}}, united737, false},
+		{"All criteria", FleetFilter{Operators: []string{"FDX"}, TypeFamilies: []string{"747", "777"}, Categories: []string{"widebody"}}, fedex747, true},
+		{"One criterion fails", FleetFilter{Operators: []string{"FDX"}, TypeFamilies: []string{"747"}}, fedex757, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.ac); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
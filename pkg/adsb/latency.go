@@ -0,0 +1,128 @@
+package adsb
+
+import (
+	"time"
+)
+
+// DefaultLatency is the prediction latency assumed for a source until its
+// latency has been measured: typical of the online aggregators.
+const DefaultLatency = 2500 * time.Millisecond
+
+// minLatencySamples is how many positions must be measured before the
+// measured median replaces DefaultLatency
+const minLatencySamples = 10
+
+// LatencyBuckets are the upper bounds of the LatencyHistogram buckets. A
+// final bucket holds latencies beyond the last bound.
+var LatencyBuckets = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	750 * time.Millisecond,
+	1 * time.Second,
+	1250 * time.Millisecond,
+	1500 * time.Millisecond,
+	2 * time.Second,
+	2500 * time.Millisecond,
+	3 * time.Second,
+	4 * time.Second,
+	5 * time.Second,
+	7500 * time.Millisecond,
+	10 * time.Second,
+	15 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+}
+
+// LatencyHistogram is the distribution of a source's latency: how old its
+// positions are when fetched (now − LastSeen). Histograms of different
+// cycles add up, so they can be stored per cycle and summarized over any
+// period.
+type LatencyHistogram struct {
+	// Counts holds the number of positions in each of LatencyBuckets and
+	// the overflow bucket
+	Counts []int64 `json:"counts"`
+}
+
+// NewLatencyHistogram returns an empty histogram.
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{Counts: make([]int64, len(LatencyBuckets)+1)}
+}
+
+// Record adds one latency.
+func (h *LatencyHistogram) Record(latency time.Duration) {
+	i := 0
+	for i < len(LatencyBuckets) && latency > LatencyBuckets[i] {
+		i++
+	}
+	h.Counts[i]++
+}
+
+// RecordAircraft adds the latency of each aircraft's position, fetched at
+// fetched. Aircraft without a position or a report time are skipped.
+func (h *LatencyHistogram) RecordAircraft(aircraft []Aircraft, fetched time.Time) {
+	for _, ac := range aircraft {
+		if (ac.Latitude == 0 && ac.Longitude == 0) || ac.LastSeen.IsZero() {
+			continue
+		}
+		h.Record(max(fetched.Sub(ac.LastSeen), 0))
+	}
+}
+
+// Add adds another histogram's counts. Histograms with a different bucket
+// layout (stored before LatencyBuckets changed) are ignored.
+func (h *LatencyHistogram) Add(other LatencyHistogram) {
+	if len(other.Counts) != len(h.Counts) {
+		return
+	}
+	for i, n := range other.Counts {
+		h.Counts[i] += n
+	}
+}
+
+// Count returns the number of latencies recorded.
+func (h LatencyHistogram) Count() int64 {
+	var n int64
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Percentile returns the latency below which a fraction p (0-1) of the
+// positions fall, interpolated within its bucket. Latencies in the overflow
+// bucket count as the last bound. Returns 0 for an empty histogram.
+func (h LatencyHistogram) Percentile(p float64) time.Duration {
+	total := h.Count()
+	if total == 0 || len(h.Counts) != len(LatencyBuckets)+1 {
+		return 0
+	}
+
+	rank := p * float64(total)
+	var below int64
+	for i, n := range h.Counts {
+		if n == 0 || float64(below+n) < rank {
+			below += n
+			continue
+		}
+		if i == len(LatencyBuckets) {
+			break
+		}
+		lower := time.Duration(0)
+		if i > 0 {
+			lower = LatencyBuckets[i-1]
+		}
+		frac := max(rank-float64(below), 0) / float64(n)
+		return lower + time.Duration(frac*float64(LatencyBuckets[i]-lower))
+	}
+	return LatencyBuckets[len(LatencyBuckets)-1]
+}
+
+// PredictionLatency returns the measured median latency, or DefaultLatency
+// until enough positions have been measured.
+func (h LatencyHistogram) PredictionLatency() time.Duration {
+	if h.Count() < minLatencySamples {
+		return DefaultLatency
+	}
+	return h.Percentile(0.5)
+}
@@ -0,0 +1,90 @@
+package adsb
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLatencyHistogramPercentile tests percentiles interpolated in buckets.
+func TestLatencyHistogramPercentile(t *testing.T) {
+	h := NewLatencyHistogram()
+	if got := h.Percentile(0.5); got != 0 {
+		t.Errorf("Expected 0 for an empty histogram, got %v", got)
+	}
+
+	// 10 positions between 1.5s and 2s, 10 between 2s and 2.5s
+	for i := 0; i < 10; i++ {
+		h.Record(1800 * time.Millisecond)
+		h.Record(2200 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name     string
+		p        float64
+		expected time.Duration
+	}{
+		{"Median at the bucket boundary", 0.5, 2 * time.Second},
+		{"Quarter through the first bucket", 0.25, 1750 * time.Millisecond},
+		{"Maximum at the top of the last bucket", 1, 2500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Percentile(tt.p); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Overflow counts as the last bound", func(t *testing.T) {
+		h := NewLatencyHistogram()
+		h.Record(5 * time.Minute)
+		if got := h.Percentile(0.5); got != LatencyBuckets[len(LatencyBuckets)-1] {
+			t.Errorf("Expected %v, got %v", LatencyBuckets[len(LatencyBuckets)-1], got)
+		}
+	})
+}
+
+// TestLatencyHistogramAdd tests combining histograms of several cycles.
+func TestLatencyHistogramAdd(t *testing.T) {
+	total := NewLatencyHistogram()
+	cycle := NewLatencyHistogram()
+	cycle.Record(time.Second)
+	cycle.Record(3 * time.Second)
+
+	total.Add(*cycle)
+	total.Add(*cycle)
+	if got := total.Count(); got != 4 {
+		t.Errorf("Expected 4 latencies, got %d", got)
+	}
+
+	// Stored with another bucket layout
+	total.Add(LatencyHistogram{Counts: []int64{5, 5}})
+	if got := total.Count(); got != 4 {
+		t.Errorf("Expected a mismatched histogram to be ignored, got %d latencies", got)
+	}
+}
+
+// TestPredictionLatency tests the measured median replacing the default.
+func TestPredictionLatency(t *testing.T) {
+	fetched := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	aircraft := []Aircraft{
+		{ICAO: "NOPOS", LastSeen: fetched.Add(-time.Second)},
+		{ICAO: "NOTIME", Latitude: 40, Longitude: -75},
+	}
+	h := NewLatencyHistogram()
+	h.RecordAircraft(aircraft, fetched)
+	if got := h.Count(); got != 0 {
+		t.Fatalf("Expected aircraft without a position or time to be skipped, got %d", got)
+	}
+	if got := h.PredictionLatency(); got != DefaultLatency {
+		t.Errorf("Expected the default latency before measuring, got %v", got)
+	}
+
+	for i := 0; i < minLatencySamples; i++ {
+		aircraft = append(aircraft, Aircraft{Latitude: 40, Longitude: -75, LastSeen: fetched.Add(-900 * time.Millisecond)})
+	}
+	h.RecordAircraft(aircraft, fetched)
+	if got := h.PredictionLatency(); got != 875*time.Millisecond {
+		t.Errorf("Expected the measured median 875ms, got %v", got)
+	}
+}
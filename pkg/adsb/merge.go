@@ -0,0 +1,56 @@
+package adsb
+
+import "time"
+
+// mergeTieWindow is how close two reports' timestamps must be for position
+// quality to decide between them. Feeds poll the same receivers a few
+// seconds apart, so a report only slightly newer isn't necessarily better.
+const mergeTieWindow = 2 * time.Second
+
+// Better reports whether report a of an aircraft should be preferred over
+// report b: the newer report, unless they are within mergeTieWindow of each
+// other, in which case the one with the more accurate position (higher
+// NACp) wins, then the newer.
+func Better(a, b Aircraft) bool {
+	diff := a.LastSeen.Sub(b.LastSeen)
+	if diff > mergeTieWindow || diff < -mergeTieWindow {
+		return diff > 0
+	}
+	if a.NACp != b.NACp {
+		return a.NACp > b.NACp
+	}
+	return diff > 0
+}
+
+// Merge combines two reports of the same aircraft, e.g. from overlapping
+// collection regions or different feeds. Position, velocity and timestamp
+// come from the better report (see Better); identification and autopilot
+// fields it lacks are filled in from the other.
+func Merge(a, b Aircraft) Aircraft {
+	if !Better(a, b) {
+		a, b = b, a
+	}
+
+	if a.Callsign == "" {
+		a.Callsign = b.Callsign
+	}
+	if a.Squawk == "" {
+		a.Squawk = b.Squawk
+	}
+	if a.AircraftType == "" {
+		a.AircraftType = b.AircraftType
+	}
+	if a.Registration == "" {
+		a.Registration = b.Registration
+	}
+	if a.SelectedAltitude == 0 {
+		a.SelectedAltitude = b.SelectedAltitude
+	}
+	if a.SelectedHeading == nil {
+		a.SelectedHeading = b.SelectedHeading
+	}
+	if a.Heading == nil {
+		a.Heading = b.Heading
+	}
+	return a
+}
@@ -0,0 +1,54 @@
+package adsb
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBetter tests choosing between two reports of an aircraft.
+func TestBetter(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		a, b Aircraft
+		want bool
+	}{
+		{"Much newer", Aircraft{LastSeen: now, NACp: 5}, Aircraft{LastSeen: now.Add(-10 * time.Second), NACp: 10}, true},
+		{"Much older", Aircraft{LastSeen: now.Add(-10 * time.Second), NACp: 10}, Aircraft{LastSeen: now, NACp: 5}, false},
+		{"Slightly older but more accurate", Aircraft{LastSeen: now.Add(-time.Second), NACp: 10}, Aircraft{LastSeen: now, NACp: 8}, true},
+		{"Slightly newer but less accurate", Aircraft{LastSeen: now, NACp: 0}, Aircraft{LastSeen: now.Add(-time.Second), NACp: 8}, false},
+		{"Same quality, newer", Aircraft{LastSeen: now, NACp: 8}, Aircraft{LastSeen: now.Add(-time.Second), NACp: 8}, true},
+		{"Identical", Aircraft{LastSeen: now}, Aircraft{LastSeen: now}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Better(tt.a, tt.b); got != tt.want {
+				t.Errorf("Better() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMerge tests that the better report's position is kept and missing
+// fields are filled from the other.
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	heading := 270.0
+	stale := Aircraft{ICAO: "abc123", Callsign: "UAL1", Squawk: "1200", AircraftType: "B738",
+		Heading: &heading, Latitude: 35, Longitude: -80, LastSeen: now.Add(-30 * time.Second), Source: "region-a"}
+	fresh := Aircraft{ICAO: "abc123", Latitude: 35.1, Longitude: -80.1, LastSeen: now, Source: "region-b"}
+
+	for _, merged := range []Aircraft{Merge(stale, fresh), Merge(fresh, stale)} {
+		if merged.Latitude != 35.1 || merged.Source != "region-b" || !merged.LastSeen.Equal(now) {
+			t.Errorf("Expected the fresh position, got %+v", merged)
+		}
+		if merged.Callsign != "UAL1" || merged.Squawk != "1200" || merged.AircraftType != "B738" {
+			t.Errorf("Expected identification from the stale report, got %+v", merged)
+		}
+		if merged.Heading == nil || *merged.Heading != 270 {
+			t.Errorf("Expected heading from the stale report, got %v", merged.Heading)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package adsb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// metersPerNM converts dump1090's distances (meters) to nautical miles
+const metersPerNM = 1852.0
+
+// ReceiverStats summarises the health of a local 1090 MHz SDR receiver over
+// the last minute, read from dump1090's stats.json.
+type ReceiverStats struct {
+	MessagesPerSecond float64   `json:"messagesPerSecond"`
+	MaxRangeNM        float64   `json:"maxRangeNm"`               // Furthest position decoded
+	GainDB            *float64  `json:"gainDb,omitempty"`         // Tuner gain; nil if not reported
+	SignalDBFS        *float64  `json:"signalDbfs,omitempty"`     // Mean signal level
+	NoiseDBFS         *float64  `json:"noiseDbfs,omitempty"`      // Mean noise floor
+	PeakSignalDBFS    *float64  `json:"peakSignalDbfs,omitempty"` // Strongest signal
+	StrongSignals     int       `json:"strongSignals"`            // Messages above -3 dBFS (gain too high if many)
+	Tracks            int       `json:"tracks"`                   // Aircraft tracks started
+	PeriodSeconds     float64   `json:"periodSeconds"`
+	ReadAt            time.Time `json:"readAt"` // End of the period
+}
+
+// ReceiverClient reads statistics from a dump1090 (dump1090-fa, readsb)
+// receiver.
+type ReceiverClient struct {
+	// baseURL is the dump1090 web root (e.g., "http://piaware.local/skyaware")
+	baseURL string
+
+	// httpClient is the HTTP client used for requests
+	httpClient *http.Client
+}
+
+// NewReceiverClient creates a new receiver client.
+// baseURL should point to the dump1090 web root; /data/stats.json is appended.
+func NewReceiverClient(baseURL string) *ReceiverClient {
+	return &ReceiverClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Stats returns the receiver's statistics for the last minute.
+func (c *ReceiverClient) Stats() (*ReceiverStats, error) {
+	url := c.baseURL + "/data/stats.json"
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receiver stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("receiver returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return ParseReceiverStats(resp.Body)
+}
+
+// ParseReceiverStats parses a dump1090 stats.json document.
+func ParseReceiverStats(r io.Reader) (*ReceiverStats, error) {
+	var data receiverStatsResponse
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse receiver stats: %w", err)
+	}
+
+	period := data.Last1Min
+	if period == nil {
+		return nil, fmt.Errorf("receiver stats have no last1min period")
+	}
+
+	stats := &ReceiverStats{
+		MaxRangeNM:     period.MaxDistance / metersPerNM,
+		GainDB:         period.Local.GainDB,
+		SignalDBFS:     period.Local.Signal,
+		NoiseDBFS:      period.Local.Noise,
+		PeakSignalDBFS: period.Local.PeakSignal,
+		StrongSignals:  period.Local.StrongSignals,
+		Tracks:         period.Tracks.All,
+		PeriodSeconds:  period.End - period.Start,
+		ReadAt:         unixSeconds(period.End),
+	}
+	if stats.PeriodSeconds > 0 {
+		stats.MessagesPerSecond = float64(period.Messages) / stats.PeriodSeconds
+	}
+
+	// readsb reports the gain at the top level
+	if stats.GainDB == nil {
+		stats.GainDB = data.GainDB
+	}
+
+	return stats, nil
+}
+
+// unixSeconds converts fractional Unix seconds to a time.
+func unixSeconds(s float64) time.Time {
+	sec, frac := math.Modf(s)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC()
+}
+
+// receiverStatsResponse represents dump1090's stats.json, which holds the
+// same statistics for several periods.
+type receiverStatsResponse struct {
+	Last1Min *receiverStatsPeriod `json:"last1min"`
+	GainDB   *float64             `json:"gain_db"`
+}
+
+// receiverStatsPeriod holds the statistics for one period.
+type receiverStatsPeriod struct {
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Messages    int     `json:"messages"`
+	MaxDistance float64 `json:"max_distance"` // Meters
+	Local       struct {
+		Signal        *float64 `json:"signal"`
+		Noise         *float64 `json:"noise"`
+		PeakSignal    *float64 `json:"peak_signal"`
+		StrongSignals int      `json:"strong_signals"`
+		GainDB        *float64 `json:"gain_db"`
+	} `json:"local"`
+	Tracks struct {
+		All int `json:"all"`
+	} `json:"tracks"`
+}
@@ -0,0 +1,75 @@
+package adsb
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseReceiverStats tests parsing dump1090-fa and readsb stats.json.
+func TestParseReceiverStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		wantRate float64
+		wantNM   float64
+		wantGain float64
+		wantErr  bool
+	}{
+		{
+			name: "dump1090-fa",
+			json: `{"last1min":{"start":1700000000.0,"end":1700000060.0,"messages":1200,"max_distance":370400.0,
+				"local":{"signal":-18.2,"noise":-32.5,"peak_signal":-2.1,"strong_signals":4,"gain_db":49.6},
+				"tracks":{"all":25,"single_message":6}},"total":{"messages":99999}}`,
+			wantRate: 20,
+			wantNM:   200,
+			wantGain: 49.6,
+		},
+		{
+			name: "readsb top-level gain",
+			json: `{"gain_db":43.9,"last1min":{"start":1700000000.0,"end":1700000030.0,"messages":300,"max_distance":92600.0,
+				"local":{"signal":-20.0,"noise":-30.0},"tracks":{"all":5}}}`,
+			wantRate: 10,
+			wantNM:   50,
+			wantGain: 43.9,
+		},
+		{"No period", `{"total":{}}`, 0, 0, 0, true},
+		{"Invalid JSON", `{`, 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := ParseReceiverStats(strings.NewReader(tt.json))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseReceiverStats failed: %v", err)
+			}
+
+			if stats.MessagesPerSecond != tt.wantRate {
+				t.Errorf("Expected %.1f msg/s, got %.1f", tt.wantRate, stats.MessagesPerSecond)
+			}
+			if stats.MaxRangeNM != tt.wantNM {
+				t.Errorf("Expected %.1f NM, got %.1f", tt.wantNM, stats.MaxRangeNM)
+			}
+			if stats.GainDB == nil || *stats.GainDB != tt.wantGain {
+				t.Errorf("Expected gain %.1f, got %v", tt.wantGain, stats.GainDB)
+			}
+			if stats.SignalDBFS == nil || stats.NoiseDBFS == nil {
+				t.Error("Expected signal and noise levels")
+			}
+		})
+	}
+
+	stats, _ := ParseReceiverStats(strings.NewReader(tests[0].json))
+	if want := time.Unix(1700000060, 0).UTC(); !stats.ReadAt.Equal(want) {
+		t.Errorf("Expected read at %v, got %v", want, stats.ReadAt)
+	}
+	if stats.StrongSignals != 4 || stats.Tracks != 25 {
+		t.Errorf("Expected 4 strong signals and 25 tracks, got %d and %d", stats.StrongSignals, stats.Tracks)
+	}
+}
@@ -23,6 +23,11 @@ type RetryConfig struct {
 
 	// RespectRetryAfter uses Retry-After header if available (default: true)
 	RespectRetryAfter bool
+
+	// Breaker is an optional circuit breaker shared across callers.
+	// When set, attempts are refused while it is open and retries are drawn
+	// from its shared retry budget.
+	Breaker *CircuitBreaker
 }
 
 // DefaultRetryConfig returns sensible defaults for retry behavior.
@@ -36,6 +41,18 @@ func DefaultRetryConfig() RetryConfig {
 	}
 }
 
+// allowAttempt checks the circuit breaker and shared retry budget before an attempt.
+// Returns nil if no breaker is configured.
+func (cfg RetryConfig) allowAttempt(attempt int) error {
+	if cfg.Breaker == nil {
+		return nil
+	}
+	if attempt > 0 && !cfg.Breaker.AllowRetry() {
+		return ErrRetryBudgetExhausted
+	}
+	return cfg.Breaker.Allow()
+}
+
 // RetryableFunc is a function that can be retried.
 // It should return an error if the operation failed.
 type RetryableFunc func() error
@@ -65,8 +82,19 @@ func RetryWithBackoff(ctx context.Context, cfg RetryConfig, fn RetryableFunc) er
 			}
 		}
 
+		// Check the shared circuit breaker and retry budget
+		if err := cfg.allowAttempt(attempt); err != nil {
+			if lastErr != nil {
+				return fmt.Errorf("%w after %d attempts: %v", err, attempt, lastErr)
+			}
+			return err
+		}
+
 		// Execute the function
 		err := fn()
+		if cfg.Breaker != nil {
+			cfg.Breaker.Record(err)
+		}
 		if err == nil {
 			return nil // Success!
 		}
@@ -131,8 +159,19 @@ func RetryWithBackoffResult[T any](ctx context.Context, cfg RetryConfig, fn func
 			}
 		}
 
+		// Check the shared circuit breaker and retry budget
+		if err := cfg.allowAttempt(attempt); err != nil {
+			if lastErr != nil {
+				return result, fmt.Errorf("%w after %d attempts: %v", err, attempt, lastErr)
+			}
+			return result, err
+		}
+
 		// Execute the function
 		res, err := fn()
+		if cfg.Breaker != nil {
+			cfg.Breaker.Record(err)
+		}
 		if err == nil {
 			return res, nil // Success!
 		}
@@ -0,0 +1,44 @@
+package adsb
+
+// Emergency transponder codes defined by ICAO Annex 10.
+const (
+	// SquawkHijack indicates unlawful interference (hijacking)
+	SquawkHijack = "7500"
+
+	// SquawkRadioFailure indicates loss of two-way radio communication
+	SquawkRadioFailure = "7600"
+
+	// SquawkEmergency indicates a general emergency
+	SquawkEmergency = "7700"
+)
+
+// IsEmergencySquawk reports whether a squawk code is one of the
+// ICAO emergency codes (7500, 7600, 7700).
+func IsEmergencySquawk(squawk string) bool {
+	switch squawk {
+	case SquawkHijack, SquawkRadioFailure, SquawkEmergency:
+		return true
+	default:
+		return false
+	}
+}
+
+// EmergencyDescription returns a human-readable description of an emergency squawk.
+// Returns an empty string for non-emergency codes.
+func EmergencyDescription(squawk string) string {
+	switch squawk {
+	case SquawkHijack:
+		return "Hijack"
+	case SquawkRadioFailure:
+		return "Radio failure"
+	case SquawkEmergency:
+		return "General emergency"
+	default:
+		return ""
+	}
+}
+
+// IsEmergency reports whether the aircraft is squawking an emergency code.
+func (a Aircraft) IsEmergency() bool {
+	return IsEmergencySquawk(a.Squawk)
+}
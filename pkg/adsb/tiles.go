@@ -0,0 +1,79 @@
+package adsb
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// MaxQueryRadiusNM is the largest radius the online APIs accept in one query.
+// Larger regions must be split into tiles.
+const MaxQueryRadiusNM = 250.0
+
+// Tile is a circular sub-query covering part of a larger region.
+type Tile struct {
+	// Latitude and Longitude of the tile center in decimal degrees
+	Latitude  float64
+	Longitude float64
+
+	// RadiusNM is the query radius of this tile
+	RadiusNM float64
+
+	// OffsetNM is the distance from the region center to the tile center
+	OffsetNM float64
+}
+
+// tileOverlap shrinks the grid spacing so neighbouring tiles overlap slightly.
+// This absorbs the distortion of laying a flat grid over the sphere at
+// several thousand nautical miles.
+const tileOverlap = 0.9
+
+// TileRegion splits a circular region into tiles no larger than maxTileRadiusNM.
+// Tiles are laid out on a square grid (centered on the region) whose spacing
+// guarantees every point of the region is inside at least one tile.
+// Tiles are returned nearest-first. A region that already fits in one query
+// is returned as a single tile.
+func TileRegion(centerLat, centerLon, radiusNM, maxTileRadiusNM float64) []Tile {
+	if maxTileRadiusNM <= 0 || radiusNM <= maxTileRadiusNM {
+		return []Tile{{Latitude: centerLat, Longitude: centerLon, RadiusNM: radiusNM}}
+	}
+
+	// Circles of radius r on a square grid with spacing r*sqrt(2) cover the plane
+	spacing := maxTileRadiusNM * math.Sqrt2 * tileOverlap
+	steps := int(math.Ceil(radiusNM / spacing))
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+
+	tiles := make([]Tile, 0, (2*steps+1)*(2*steps+1))
+	for i := -steps; i <= steps; i++ {
+		for j := -steps; j <= steps; j++ {
+			x := float64(i) * spacing // east offset in NM
+			y := float64(j) * spacing // north offset in NM
+			offset := math.Hypot(x, y)
+
+			// Skip tiles that cannot intersect the region
+			if offset-maxTileRadiusNM > radiusNM {
+				continue
+			}
+
+			pos := center
+			if offset > 0 {
+				bearing := math.Atan2(x, y) * coordinates.RadiansToDegrees
+				pos = coordinates.Destination(center, bearing, offset)
+			}
+
+			tiles = append(tiles, Tile{
+				Latitude:  pos.Latitude,
+				Longitude: pos.Longitude,
+				RadiusNM:  maxTileRadiusNM,
+				OffsetNM:  offset,
+			})
+		}
+	}
+
+	sort.SliceStable(tiles, func(a, b int) bool {
+		return tiles[a].OffsetNM < tiles[b].OffsetNM
+	})
+
+	return tiles
+}
@@ -0,0 +1,67 @@
+package adsb
+
+import (
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestTileRegion tests splitting large regions into API-sized tiles.
+func TestTileRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		radiusNM float64
+		single   bool
+	}{
+		{"Small region is a single tile", 100, true},
+		{"Region at the limit is a single tile", MaxQueryRadiusNM, true},
+		{"Medium region is tiled", 600, false},
+		{"Radar mode coverage is tiled", 2500, false},
+	}
+
+	centerLat, centerLon := 35.2, -80.9
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiles := TileRegion(centerLat, centerLon, tt.radiusNM, MaxQueryRadiusNM)
+
+			if tt.single {
+				if len(tiles) != 1 || tiles[0].RadiusNM != tt.radiusNM {
+					t.Fatalf("Expected a single %.0f NM tile, got %+v", tt.radiusNM, tiles)
+				}
+				return
+			}
+
+			if len(tiles) < 2 {
+				t.Fatalf("Expected multiple tiles, got %d", len(tiles))
+			}
+			for i, tile := range tiles {
+				if tile.RadiusNM > MaxQueryRadiusNM {
+					t.Errorf("Tile %d radius %.0f exceeds limit", i, tile.RadiusNM)
+				}
+				if i > 0 && tile.OffsetNM < tiles[i-1].OffsetNM {
+					t.Errorf("Tiles not sorted nearest-first at %d", i)
+				}
+			}
+
+			// Sample points across the region must fall inside some tile
+			for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+				for _, frac := range []float64{0, 0.33, 0.66, 0.99} {
+					p := coordinates.Destination(center, bearing, tt.radiusNM*frac)
+					covered := false
+					for _, tile := range tiles {
+						tc := coordinates.Geographic{Latitude: tile.Latitude, Longitude: tile.Longitude}
+						if coordinates.DistanceNauticalMiles(tc, p) <= tile.RadiusNM {
+							covered = true
+							break
+						}
+					}
+					if !covered {
+						t.Errorf("Point at bearing %.0f, %.0f NM not covered", bearing, tt.radiusNM*frac)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package adsb
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// SourceADSBxHistory tags positions imported from ADS-B Exchange historical
+// trace files
+const SourceADSBxHistory = "adsbx-history"
+
+// traceFlagNewLeg is the trace point flag bit marking the start of a new
+// flight leg (readsb trace format)
+const traceFlagNewLeg = 1 << 1
+
+// Trace is one aircraft's position history from an ADS-B Exchange
+// globe_history trace file (trace_full_<icao>.json).
+type Trace struct {
+	// ICAO is the aircraft address in lower case, as reported by live feeds
+	// ("~" prefixed for non-ICAO TIS-B addresses)
+	ICAO string
+
+	// Registration and AircraftType come from the ADSBx aircraft database
+	// and may be empty
+	Registration string
+	AircraftType string
+
+	// Positions are in time order, with LastSeen set to the report time and
+	// Source set to SourceADSBxHistory. Aircraft on the ground have altitude 0.
+	Positions []Aircraft
+
+	// Legs holds the index into Positions where each flight leg starts
+	// (always including 0 when there are positions)
+	Legs []int
+}
+
+// traceFile is the JSON layout of a trace file.
+type traceFile struct {
+	ICAO         string          `json:"icao"`
+	Registration string          `json:"r"`
+	AircraftType string          `json:"t"`
+	Timestamp    float64         `json:"timestamp"`
+	Trace        [][]interface{} `json:"trace"`
+}
+
+// ParseTrace reads an ADS-B Exchange trace file. Files may be gzip
+// compressed (as served by globe_history) or plain JSON.
+//
+// Each trace point is an array:
+//
+//	[seconds after timestamp, lat, lon, altitude ft or "ground", ground speed,
+//	 track, flags, vertical rate, aircraft details or null, ...]
+//
+// Points without a position are skipped. Callsign and squawk are carried
+// forward from the last point that reported them.
+func ParseTrace(r io.Reader) (*Trace, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip trace: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	var file traceFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode trace: %w", err)
+	}
+	if file.ICAO == "" {
+		return nil, fmt.Errorf("trace has no icao")
+	}
+
+	trace := &Trace{
+		ICAO:         strings.ToLower(file.ICAO),
+		Registration: file.Registration,
+		AircraftType: file.AircraftType,
+		Positions:    make([]Aircraft, 0, len(file.Trace)),
+	}
+
+	secs, frac := math.Modf(file.Timestamp)
+	base := time.Unix(int64(secs), int64(frac*1e9)).UTC()
+
+	var callsign, squawk string
+	for _, point := range file.Trace {
+		if len(point) < 8 {
+			continue
+		}
+
+		if len(point) > 8 {
+			// Optional aircraft object carrying fields that change rarely
+			if details, ok := point[8].(map[string]interface{}); ok {
+				if flight, _ := details["flight"].(string); strings.TrimSpace(flight) != "" {
+					callsign = strings.TrimSpace(flight)
+				}
+				if code, _ := details["squawk"].(string); code != "" {
+					squawk = code
+				}
+			}
+		}
+
+		offset, ok1 := traceFloat(point[0])
+		lat, ok2 := traceFloat(point[1])
+		lon, ok3 := traceFloat(point[2])
+		if !ok1 || !ok2 || !ok3 {
+			continue
+		}
+
+		// Altitude is a number, "ground" or null (unknown)
+		altitude, ok := traceFloat(point[3])
+		if !ok && point[3] != "ground" {
+			continue
+		}
+
+		flags, _ := traceFloat(point[6])
+		if int(flags)&traceFlagNewLeg != 0 || len(trace.Positions) == 0 {
+			trace.Legs = append(trace.Legs, len(trace.Positions))
+		}
+
+		speed, _ := traceFloat(point[4])
+		track, _ := traceFloat(point[5])
+		verticalRate, _ := traceFloat(point[7])
+
+		trace.Positions = append(trace.Positions, Aircraft{
+			ICAO:         trace.ICAO,
+			Callsign:     callsign,
+			Latitude:     lat,
+			Longitude:    lon,
+			Altitude:     units.Feet(altitude),
+			GroundSpeed:  speed,
+			Track:        track,
+			VerticalRate: verticalRate,
+			Squawk:       squawk,
+			Source:       SourceADSBxHistory,
+			LastSeen:     base.Add(time.Duration(offset * float64(time.Second))),
+		})
+	}
+
+	return trace, nil
+}
+
+// traceFloat converts a decoded JSON number, reporting false for null and
+// non-numeric values.
+func traceFloat(v interface{}) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
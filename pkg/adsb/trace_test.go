@@ -0,0 +1,80 @@
+package adsb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// traceSample is a trimmed globe_history trace_full file.
+const traceSample = `{
+  "icao": "A1B2C3", "r": "N123AB", "t": "B738", "timestamp": 1700000000.5,
+  "trace": [
+    [0.0, 35.20, -80.90, "ground", 12.0, 90.0, 0, null, {"type": "adsb_icao", "flight": "UAL123  ", "squawk": "4521"}, "adsb_icao"],
+    [30.5, 35.21, -80.88, 1500, 160.0, 92.0, 0, 2000, null, "adsb_icao"],
+    [45.0, null, null, null, null, null, 0, null, null, "other"],
+    [3600.0, 36.00, -79.00, 34000, 450.0, 45.0, 2, 0, {"type": "adsb_icao", "flight": "UAL456"}, "adsb_icao"]
+  ]
+}`
+
+// TestParseTrace tests decoding a trace file.
+func TestParseTrace(t *testing.T) {
+	check := func(t *testing.T, trace *Trace) {
+		if trace.ICAO != "a1b2c3" || trace.Registration != "N123AB" || trace.AircraftType != "B738" {
+			t.Errorf("Unexpected identity: %+v", trace)
+		}
+		if len(trace.Positions) != 3 {
+			t.Fatalf("Expected 3 positions (null position skipped), got %d", len(trace.Positions))
+		}
+		if len(trace.Legs) != 2 || trace.Legs[0] != 0 || trace.Legs[1] != 2 {
+			t.Errorf("Expected legs [0 2], got %v", trace.Legs)
+		}
+
+		ground, climb, cruise := trace.Positions[0], trace.Positions[1], trace.Positions[2]
+		if ground.Altitude != 0 || ground.Callsign != "UAL123" || ground.Squawk != "4521" {
+			t.Errorf("Unexpected ground position: %+v", ground)
+		}
+		if climb.Altitude != 1500 || climb.VerticalRate != 2000 || climb.Callsign != "UAL123" || climb.Squawk != "4521" {
+			t.Errorf("Unexpected climb position (details should carry forward): %+v", climb)
+		}
+		if cruise.Callsign != "UAL456" || cruise.Source != SourceADSBxHistory {
+			t.Errorf("Unexpected cruise position: %+v", cruise)
+		}
+
+		want := time.Unix(1700000031, 0).UTC()
+		if !climb.LastSeen.Equal(want) {
+			t.Errorf("Expected time %v, got %v", want, climb.LastSeen)
+		}
+	}
+
+	t.Run("Plain JSON", func(t *testing.T) {
+		trace, err := ParseTrace(strings.NewReader(traceSample))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		check(t, trace)
+	})
+
+	t.Run("Gzip compressed", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte(traceSample))
+		gz.Close()
+
+		trace, err := ParseTrace(&buf)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		check(t, trace)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{`not json`, `{"trace": []}`} {
+			if _, err := ParseTrace(strings.NewReader(input)); err == nil {
+				t.Errorf("Expected error for %q", input)
+			}
+		}
+	})
+}
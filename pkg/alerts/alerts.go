@@ -0,0 +1,327 @@
+// Package alerts delivers notifications about noteworthy events, such as
+// emergency squawks, unsafe wind at the telescope or aircraft about to pass
+// overhead, to external systems (webhooks, MQTT brokers).
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Alert describes a single notable event. Aircraft fields are empty for
+// site alerts such as high wind.
+type Alert struct {
+	// Type identifies the kind of alert (e.g., "emergency_squawk")
+	Type string `json:"type"`
+
+	// ICAO is the aircraft's 24-bit ICAO address
+	ICAO string `json:"icao"`
+
+	// Callsign is the flight number or registration (may be empty)
+	Callsign string `json:"callsign"`
+
+	// Squawk is the transponder code that triggered the alert
+	Squawk string `json:"squawk"`
+
+	// Description is a human-readable summary (e.g., "General emergency")
+	Description string `json:"description"`
+
+	// Latitude in decimal degrees
+	Latitude float64 `json:"latitude"`
+
+	// Longitude in decimal degrees
+	Longitude float64 `json:"longitude"`
+
+	// Altitude in feet MSL
+	Altitude units.Feet `json:"altitude"`
+
+	// Region is the collection region the aircraft was seen in
+	Region string `json:"region,omitempty"`
+
+	// WindSpeedMS is the wind speed (m/s) that triggered a high wind alert
+	WindSpeedMS float64 `json:"windSpeedMs,omitempty"`
+
+	// Source is the ADS-B data source a failover alert switched to
+	Source string `json:"source,omitempty"`
+
+	// Rule is the pass notification rule an upcoming pass alert matched
+	Rule string `json:"rule,omitempty"`
+
+	// PassStart is when an upcoming pass reaches the rule's elevation, and
+	// PeakElevation and PeakAzimuth (degrees) where it is highest
+	PassStart     *time.Time `json:"passStart,omitempty"`
+	PeakElevation float64    `json:"peakElevation,omitempty"`
+	PeakAzimuth   float64    `json:"peakAzimuth,omitempty"`
+
+	// Watchers are the users with a watched aircraft alert's aircraft on
+	// their watch list
+	Watchers []string `json:"watchers,omitempty"`
+
+	// Time is when the alert was raised
+	Time time.Time `json:"time"`
+}
+
+// AlertTypeEmergencySquawk is raised when an aircraft squawks 7500, 7600 or 7700.
+const AlertTypeEmergencySquawk = "emergency_squawk"
+
+// AlertTypeHighWind is raised when wind at the telescope exceeds the safe slewing limit.
+const AlertTypeHighWind = "high_wind"
+
+// AlertTypeUpcomingPass is raised when an aircraft matching a pass
+// notification rule is about to pass high over the observer.
+const AlertTypeUpcomingPass = "upcoming_pass"
+
+// AlertTypeTelescopeDisconnected is raised when the telescope's Alpaca
+// server stops answering or drops the session.
+const AlertTypeTelescopeDisconnected = "telescope_disconnected"
+
+// AlertTypeTelescopeReconnected is raised when the telescope session has
+// been re-established after a disconnect.
+const AlertTypeTelescopeReconnected = "telescope_reconnected"
+
+// AlertTypeSourceFailover is raised when the collector switches away from a
+// failing ADS-B data source.
+const AlertTypeSourceFailover = "source_failover"
+
+// AlertTypeSourceRecovered is raised when the collector fails back to its
+// primary ADS-B data source.
+const AlertTypeSourceRecovered = "source_recovered"
+
+// AlertTypeWatchedAircraft is raised when an aircraft on a user's watch
+// list reappears in coverage.
+const AlertTypeWatchedAircraft = "watched_aircraft"
+
+// Notifier delivers alerts to an external system.
+type Notifier interface {
+	// Notify sends a single alert. Implementations should honor ctx cancellation.
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NewEmergencyAlert builds an emergency squawk alert for an aircraft.
+func NewEmergencyAlert(ac adsb.Aircraft, region string, now time.Time) Alert {
+	return Alert{
+		Type:        AlertTypeEmergencySquawk,
+		ICAO:        ac.ICAO,
+		Callsign:    ac.Callsign,
+		Squawk:      ac.Squawk,
+		Description: adsb.EmergencyDescription(ac.Squawk),
+		Latitude:    ac.Latitude,
+		Longitude:   ac.Longitude,
+		Altitude:    ac.Altitude,
+		Region:      region,
+		Time:        now,
+	}
+}
+
+// NewHighWindAlert builds an alert for wind above the safe slewing limit.
+func NewHighWindAlert(windMS, limitMS float64, now time.Time) Alert {
+	return Alert{
+		Type:        AlertTypeHighWind,
+		Description: fmt.Sprintf("Wind %.1f m/s exceeds safe slewing limit of %.1f m/s", windMS, limitMS),
+		WindSpeedMS: windMS,
+		Time:        now,
+	}
+}
+
+// NewTelescopeDisconnectedAlert builds an alert for a lost telescope
+// connection.
+func NewTelescopeDisconnectedAlert(reason string, now time.Time) Alert {
+	return Alert{
+		Type:        AlertTypeTelescopeDisconnected,
+		Description: fmt.Sprintf("Telescope connection lost: %s", reason),
+		Time:        now,
+	}
+}
+
+// NewTelescopeReconnectedAlert builds an alert for a telescope connection
+// restored after downtime. restoreErr describes a failure to restore the
+// mount state (empty if it was restored).
+func NewTelescopeReconnectedAlert(downtime time.Duration, restoreErr string, now time.Time) Alert {
+	description := fmt.Sprintf("Telescope reconnected after %s", downtime.Round(time.Second))
+	if restoreErr != "" {
+		description += fmt.Sprintf(", but restoring its state failed: %s", restoreErr)
+	}
+	return Alert{
+		Type:        AlertTypeTelescopeReconnected,
+		Description: description,
+		Time:        now,
+	}
+}
+
+// NewSourceFailoverAlert builds an alert for the collector switching from a
+// failing data source to the next one.
+func NewSourceFailoverAlert(from, to, reason string, now time.Time) Alert {
+	return Alert{
+		Type:        AlertTypeSourceFailover,
+		Description: fmt.Sprintf("ADS-B source %s failing (%s), switched to %s", from, reason, to),
+		Source:      to,
+		Time:        now,
+	}
+}
+
+// NewSourceRecoveredAlert builds an alert for the collector failing back to
+// its primary data source after downtime.
+func NewSourceRecoveredAlert(primary string, downtime time.Duration, now time.Time) Alert {
+	return Alert{
+		Type:        AlertTypeSourceRecovered,
+		Description: fmt.Sprintf("ADS-B source %s recovered after %s, switched back", primary, downtime.Round(time.Second)),
+		Source:      primary,
+		Time:        now,
+	}
+}
+
+// NewUpcomingPassAlert builds an alert for an aircraft that will reach
+// minElevation at pass.Start.
+func NewUpcomingPassAlert(ac adsb.Aircraft, rule string, minElevation float64, pass tracking.Pass, now time.Time) Alert {
+	name := strings.TrimSpace(ac.Callsign)
+	if name == "" {
+		name = ac.ICAO
+	}
+
+	when := "now"
+	if lead := pass.Start.Sub(now).Round(time.Minute); lead > 0 {
+		when = fmt.Sprintf("in %d min", int(lead.Minutes()))
+	}
+
+	start := pass.Start
+	return Alert{
+		Type:     AlertTypeUpcomingPass,
+		ICAO:     ac.ICAO,
+		Callsign: ac.Callsign,
+		Description: fmt.Sprintf("%s above %.0f° %s, peaking at %.0f° (az %.0f°) at %s UTC",
+			name, minElevation, when, pass.MaxElevation, pass.PeakAzimuth, pass.Peak.UTC().Format("15:04")),
+		Latitude:      ac.Latitude,
+		Longitude:     ac.Longitude,
+		Altitude:      ac.Altitude,
+		Rule:          rule,
+		PassStart:     &start,
+		PeakElevation: pass.MaxElevation,
+		PeakAzimuth:   pass.PeakAzimuth,
+		Time:          now,
+	}
+}
+
+// NewWatchedAircraftAlert builds an alert for a watched aircraft back in
+// coverage. watchers are the users watching it.
+func NewWatchedAircraftAlert(ac adsb.Aircraft, region string, watchers []string, now time.Time) Alert {
+	name := strings.TrimSpace(ac.Callsign)
+	if name == "" {
+		name = ac.ICAO
+	}
+
+	return Alert{
+		Type:        AlertTypeWatchedAircraft,
+		ICAO:        ac.ICAO,
+		Callsign:    ac.Callsign,
+		Description: fmt.Sprintf("Watched aircraft %s is back in coverage at %.0f ft", name, ac.Altitude),
+		Latitude:    ac.Latitude,
+		Longitude:   ac.Longitude,
+		Altitude:    ac.Altitude,
+		Region:      region,
+		Watchers:    watchers,
+		Time:        now,
+	}
+}
+
+// NewNotifiers creates the notifiers configured in cfg (webhook and/or MQTT).
+// Returns an empty slice if none are configured.
+func NewNotifiers(cfg config.AlertsConfig) []Notifier {
+	var notifiers []Notifier
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.WebhookURL))
+	}
+	if cfg.MQTTBroker != "" {
+		notifiers = append(notifiers, NewMQTTNotifier(MQTTConfig{
+			Broker:   cfg.MQTTBroker,
+			Topic:    cfg.MQTTTopic,
+			Username: cfg.MQTTUsername,
+			Password: cfg.MQTTPassword,
+		}))
+	}
+	return notifiers
+}
+
+// Dispatcher fans alerts out to multiple notifiers.
+// It suppresses duplicates so an aircraft holding an emergency squawk
+// does not trigger a new notification on every collection cycle.
+type Dispatcher struct {
+	notifiers      []Notifier
+	repeatInterval time.Duration
+
+	mu   sync.Mutex
+	sent map[string]time.Time // alert key -> last notification time
+}
+
+// NewDispatcher creates a dispatcher for the given notifiers.
+// repeatInterval controls how often an unchanged alert is re-sent (0 = never).
+func NewDispatcher(repeatInterval time.Duration, notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{
+		notifiers:      notifiers,
+		repeatInterval: repeatInterval,
+		sent:           make(map[string]time.Time),
+	}
+}
+
+// ProcessAircraft raises an emergency alert for an aircraft if it is squawking
+// an emergency code and has not been alerted recently.
+// Returns true if the alert was dispatched.
+func (d *Dispatcher) ProcessAircraft(ctx context.Context, ac adsb.Aircraft, region string, now time.Time) (bool, error) {
+	if !ac.IsEmergency() {
+		return false, nil
+	}
+
+	return d.Raise(ctx, ac.ICAO+":"+ac.Squawk, NewEmergencyAlert(ac, region, now), now)
+}
+
+// Raise dispatches alert unless an alert with the same key was sent within
+// the repeat interval. Returns true if the alert was dispatched.
+func (d *Dispatcher) Raise(ctx context.Context, key string, alert Alert, now time.Time) (bool, error) {
+	d.mu.Lock()
+	last, seen := d.sent[key]
+	if seen && (d.repeatInterval <= 0 || now.Sub(last) < d.repeatInterval) {
+		d.mu.Unlock()
+		return false, nil
+	}
+	d.sent[key] = now
+	d.mu.Unlock()
+
+	return true, d.Dispatch(ctx, alert)
+}
+
+// Dispatch sends an alert to every notifier.
+// All notifiers are attempted; the first error encountered is returned.
+func (d *Dispatcher) Dispatch(ctx context.Context, alert Alert) error {
+	subject := alert.ICAO
+	if subject == "" {
+		subject = alert.Type
+	}
+
+	var firstErr error
+	for _, n := range d.notifiers {
+		if err := n.Notify(ctx, alert); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to deliver alert for %s: %w", subject, err)
+		}
+	}
+	return firstErr
+}
+
+// Prune forgets alerts older than maxAge so that an aircraft which resumes
+// squawking an emergency code later is alerted again.
+func (d *Dispatcher) Prune(now time.Time, maxAge time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, last := range d.sent {
+		if now.Sub(last) > maxAge {
+			delete(d.sent, key)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// recordingNotifier captures alerts for assertions.
+type recordingNotifier struct {
+	alerts []Alert
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, alert Alert) error {
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+// TestDispatcherProcessAircraft tests emergency detection and duplicate suppression.
+func TestDispatcherProcessAircraft(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Ignores non-emergency squawk", func(t *testing.T) {
+		rec := &recordingNotifier{}
+		d := NewDispatcher(0, rec)
+
+		sent, err := d.ProcessAircraft(ctx, adsb.Aircraft{ICAO: "a12345", Squawk: "1200"}, "", now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if sent || len(rec.alerts) != 0 {
+			t.Error("Expected no alert for squawk 1200")
+		}
+	})
+
+	t.Run("Suppresses duplicates", func(t *testing.T) {
+		rec := &recordingNotifier{}
+		d := NewDispatcher(10*time.Minute, rec)
+		ac := adsb.Aircraft{ICAO: "a12345", Callsign: "UAL123", Squawk: "7700"}
+
+		for i := 0; i < 3; i++ {
+			d.ProcessAircraft(ctx, ac, "Charlotte Metro", now.Add(time.Duration(i)*time.Minute))
+		}
+		if len(rec.alerts) != 1 {
+			t.Fatalf("Expected 1 alert, got %d", len(rec.alerts))
+		}
+
+		alert := rec.alerts[0]
+		if alert.Type != AlertTypeEmergencySquawk {
+			t.Errorf("Expected type %s, got %s", AlertTypeEmergencySquawk, alert.Type)
+		}
+		if alert.Description != "General emergency" {
+			t.Errorf("Expected description 'General emergency', got %q", alert.Description)
+		}
+		if alert.Region != "Charlotte Metro" {
+			t.Errorf("Expected region Charlotte Metro, got %s", alert.Region)
+		}
+
+		// Repeat interval elapsed
+		d.ProcessAircraft(ctx, ac, "Charlotte Metro", now.Add(11*time.Minute))
+		if len(rec.alerts) != 2 {
+			t.Errorf("Expected repeat alert after interval, got %d alerts", len(rec.alerts))
+		}
+	})
+
+	t.Run("New squawk code alerts again", func(t *testing.T) {
+		rec := &recordingNotifier{}
+		d := NewDispatcher(0, rec)
+
+		d.ProcessAircraft(ctx, adsb.Aircraft{ICAO: "a12345", Squawk: "7600"}, "", now)
+		d.ProcessAircraft(ctx, adsb.Aircraft{ICAO: "a12345", Squawk: "7700"}, "", now)
+		if len(rec.alerts) != 2 {
+			t.Errorf("Expected 2 alerts, got %d", len(rec.alerts))
+		}
+	})
+
+	t.Run("Prune forgets old alerts", func(t *testing.T) {
+		rec := &recordingNotifier{}
+		d := NewDispatcher(0, rec)
+		ac := adsb.Aircraft{ICAO: "a12345", Squawk: "7500"}
+
+		d.ProcessAircraft(ctx, ac, "", now)
+		d.Prune(now.Add(2*time.Hour), time.Hour)
+		d.ProcessAircraft(ctx, ac, "", now.Add(2*time.Hour))
+		if len(rec.alerts) != 2 {
+			t.Errorf("Expected 2 alerts after prune, got %d", len(rec.alerts))
+		}
+	})
+}
+
+// TestDispatcherRaise tests keyed suppression of site alerts.
+func TestDispatcherRaise(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	rec := &recordingNotifier{}
+	d := NewDispatcher(30*time.Minute, rec)
+
+	for i := 0; i < 3; i++ {
+		d.Raise(ctx, AlertTypeHighWind, NewHighWindAlert(14.2, 10, now), now.Add(time.Duration(i)*time.Minute))
+	}
+	if len(rec.alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(rec.alerts))
+	}
+
+	alert := rec.alerts[0]
+	if alert.Type != AlertTypeHighWind {
+		t.Errorf("Expected type %s, got %s", AlertTypeHighWind, alert.Type)
+	}
+	if alert.WindSpeedMS != 14.2 {
+		t.Errorf("Expected wind 14.2, got %f", alert.WindSpeedMS)
+	}
+	if !strings.Contains(alert.Description, "14.2 m/s") {
+		t.Errorf("Expected wind speed in description, got %q", alert.Description)
+	}
+
+	// A different key is not suppressed
+	sent, err := d.Raise(ctx, "other", NewHighWindAlert(12, 10, now), now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !sent || len(rec.alerts) != 2 {
+		t.Errorf("Expected alert for new key, got %d alerts", len(rec.alerts))
+	}
+}
+
+// TestNewUpcomingPassAlert tests describing an upcoming pass.
+func TestNewUpcomingPassAlert(t *testing.T) {
+	now := time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC)
+	pass := tracking.Pass{
+		Start:        now.Add(4 * time.Minute),
+		End:          now.Add(7 * time.Minute),
+		Peak:         now.Add(5*time.Minute + 30*time.Second),
+		MaxElevation: 71.6,
+		PeakAzimuth:  182,
+	}
+
+	tests := []struct {
+		name     string
+		callsign string
+		start    time.Time
+		expected string
+	}{
+		{"Ahead", "UAL123 ", pass.Start, "UAL123 above 45° in 4 min, peaking at 72° (az 182°) at 21:05 UTC"},
+		{"Already up", "UAL123", now, "UAL123 above 45° now, peaking at 72° (az 182°) at 21:05 UTC"},
+		{"No callsign", "", pass.Start, "A1B2C3 above 45° in 4 min, peaking at 72° (az 182°) at 21:05 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pass
+			p.Start = tt.start
+			ac := adsb.Aircraft{ICAO: "A1B2C3", Callsign: tt.callsign, Altitude: 35000}
+
+			alert := NewUpcomingPassAlert(ac, "overhead", 45, p, now)
+			if alert.Type != AlertTypeUpcomingPass || alert.Rule != "overhead" {
+				t.Errorf("Type, Rule = %s, %s; expected %s, overhead", alert.Type, alert.Rule, AlertTypeUpcomingPass)
+			}
+			if alert.Description != tt.expected {
+				t.Errorf("Description = %q, expected %q", alert.Description, tt.expected)
+			}
+			if alert.PassStart == nil || !alert.PassStart.Equal(tt.start) {
+				t.Errorf("PassStart = %v, expected %v", alert.PassStart, tt.start)
+			}
+		})
+	}
+}
+
+// TestWebhookNotifier tests JSON delivery to a webhook endpoint.
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("Successful delivery", func(t *testing.T) {
+		var received Alert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected application/json, got %s", ct)
+			}
+			json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifier(server.URL)
+		err := n.Notify(context.Background(), Alert{ICAO: "abc123", Squawk: "7700"})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if received.ICAO != "abc123" || received.Squawk != "7700" {
+			t.Errorf("Unexpected payload: %+v", received)
+		}
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifier(server.URL)
+		if err := n.Notify(context.Background(), Alert{}); err == nil {
+			t.Error("Expected error for 500 response")
+		}
+	})
+}
+
+// TestMQTTNotifier tests publishing against a minimal fake broker.
+func TestMQTTNotifier(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	published := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Read CONNECT
+		if pkt := readPacket(conn); pkt == nil || pkt[0] != 0x10 {
+			return
+		}
+		conn.Write([]byte{0x20, 0x02, 0x00, 0x00})
+
+		// Read PUBLISH
+		pkt := readPacket(conn)
+		published <- pkt
+	}()
+
+	n := NewMQTTNotifier(MQTTConfig{Broker: ln.Addr().String(), Topic: "test/alerts"})
+	if err := n.Notify(context.Background(), Alert{ICAO: "abc123", Squawk: "7500"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	select {
+	case pkt := <-published:
+		if pkt[0] != 0x30 {
+			t.Fatalf("Expected PUBLISH packet, got 0x%02x", pkt[0])
+		}
+		if !strings.Contains(string(pkt), "test/alerts") {
+			t.Error("Expected topic in PUBLISH packet")
+		}
+		if !strings.Contains(string(pkt), `"squawk":"7500"`) {
+			t.Error("Expected alert JSON in PUBLISH payload")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for PUBLISH")
+	}
+}
+
+// TestMQTTPacketLength tests remaining-length encoding for large payloads.
+func TestMQTTPacketLength(t *testing.T) {
+	pkt := mqttPacket(0x30, make([]byte, 321))
+
+	// 321 = 0xC1 0x02 in MQTT variable-length encoding
+	if pkt[1] != 0xC1 || pkt[2] != 0x02 {
+		t.Errorf("Expected length bytes C1 02, got %02X %02X", pkt[1], pkt[2])
+	}
+	if len(pkt) != 1+2+321 {
+		t.Errorf("Expected packet length %d, got %d", 1+2+321, len(pkt))
+	}
+}
+
+// readPacket reads a single MQTT packet (fixed header plus body).
+func readPacket(r io.Reader) []byte {
+	header := make([]byte, 1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil
+	}
+
+	pkt := []byte{header[0]}
+	length, multiplier := 0, 1
+	for {
+		b := make([]byte, 1)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil
+		}
+		pkt = append(pkt, b[0])
+		length += int(b[0]&0x7F) * multiplier
+		multiplier *= 128
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil
+	}
+	return append(pkt, body...)
+}
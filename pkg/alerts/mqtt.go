@@ -0,0 +1,181 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// MQTTNotifier publishes alerts to an MQTT broker.
+// It implements the small subset of MQTT 3.1.1 needed for fire-and-forget
+// publishing (CONNECT, PUBLISH at QoS 0, DISCONNECT). Alerts are rare, so a
+// fresh connection is opened for each one instead of keeping a session alive.
+type MQTTNotifier struct {
+	// broker is the TCP address of the broker (e.g., "localhost:1883")
+	broker string
+
+	// topic is the topic alerts are published to
+	topic string
+
+	// clientID identifies this client to the broker
+	clientID string
+
+	// username and password are optional broker credentials
+	username string
+	password string
+
+	// timeout bounds the whole connect/publish exchange
+	timeout time.Duration
+}
+
+// MQTTConfig contains MQTT notifier settings.
+type MQTTConfig struct {
+	Broker   string
+	Topic    string
+	ClientID string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// NewMQTTNotifier creates an MQTT notifier.
+func NewMQTTNotifier(cfg MQTTConfig) *MQTTNotifier {
+	if cfg.Topic == "" {
+		cfg.Topic = "ads-bscope/alerts"
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = "ads-bscope"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &MQTTNotifier{
+		broker:   cfg.Broker,
+		topic:    cfg.Topic,
+		clientID: cfg.ClientID,
+		username: cfg.Username,
+		password: cfg.Password,
+		timeout:  cfg.Timeout,
+	}
+}
+
+// Notify publishes the alert as JSON to the configured topic.
+func (m *MQTTNotifier) Notify(ctx context.Context, alert Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	dialer := net.Dialer{Timeout: m.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.broker)
+	if err != nil {
+		return fmt.Errorf("connect to broker: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(m.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	if _, err := conn.Write(m.connectPacket()); err != nil {
+		return fmt.Errorf("send connect: %w", err)
+	}
+
+	// CONNACK: fixed header (0x20, 0x02), session present flag, return code
+	connack := make([]byte, 4)
+	if _, err := io.ReadFull(conn, connack); err != nil {
+		return fmt.Errorf("read connack: %w", err)
+	}
+	if connack[0] != 0x20 {
+		return fmt.Errorf("unexpected packet type 0x%02x waiting for connack", connack[0])
+	}
+	if connack[3] != 0 {
+		return fmt.Errorf("broker refused connection (code %d)", connack[3])
+	}
+
+	if _, err := conn.Write(publishPacket(m.topic, payload)); err != nil {
+		return fmt.Errorf("send publish: %w", err)
+	}
+
+	// DISCONNECT
+	if _, err := conn.Write([]byte{0xE0, 0x00}); err != nil {
+		return fmt.Errorf("send disconnect: %w", err)
+	}
+
+	return nil
+}
+
+// connectPacket builds an MQTT 3.1.1 CONNECT packet with a clean session.
+func (m *MQTTNotifier) connectPacket() []byte {
+	var body bytes.Buffer
+
+	// Variable header: protocol name, level 4 (3.1.1), flags, keep alive
+	writeMQTTString(&body, "MQTT")
+	body.WriteByte(0x04)
+
+	flags := byte(0x02) // Clean session
+	if m.username != "" {
+		flags |= 0x80
+		if m.password != "" {
+			flags |= 0x40
+		}
+	}
+	body.WriteByte(flags)
+	binary.Write(&body, binary.BigEndian, uint16(60))
+
+	// Payload
+	writeMQTTString(&body, m.clientID)
+	if m.username != "" {
+		writeMQTTString(&body, m.username)
+		if m.password != "" {
+			writeMQTTString(&body, m.password)
+		}
+	}
+
+	return mqttPacket(0x10, body.Bytes())
+}
+
+// publishPacket builds a QoS 0 PUBLISH packet.
+func publishPacket(topic string, payload []byte) []byte {
+	var body bytes.Buffer
+	writeMQTTString(&body, topic)
+	body.Write(payload)
+	return mqttPacket(0x30, body.Bytes())
+}
+
+// mqttPacket prefixes a packet body with its fixed header.
+func mqttPacket(packetType byte, body []byte) []byte {
+	var pkt bytes.Buffer
+	pkt.WriteByte(packetType)
+
+	// Remaining length uses a variable-length encoding (7 bits per byte)
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		pkt.WriteByte(b)
+		if length == 0 {
+			break
+		}
+	}
+
+	pkt.Write(body)
+	return pkt.Bytes()
+}
+
+// writeMQTTString writes a length-prefixed UTF-8 string.
+func writeMQTTString(buf *bytes.Buffer, s string) {
+	binary.Write(buf, binary.BigEndian, uint16(len(s)))
+	buf.WriteString(s)
+}
@@ -0,0 +1,45 @@
+package alerts
+
+import (
+	"sync"
+	"time"
+)
+
+// WatchTracker tells when a watched aircraft reappears in coverage, so it is
+// announced once per visit rather than on every collection cycle.
+type WatchTracker struct {
+	gone time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time // ICAO -> last seen
+}
+
+// NewWatchTracker creates a tracker that takes an aircraft unseen for gone
+// to have left coverage.
+func NewWatchTracker(gone time.Duration) *WatchTracker {
+	return &WatchTracker{gone: gone, seen: make(map[string]time.Time)}
+}
+
+// Seen records that a watched aircraft was seen at now. Returns true if it
+// has (re)appeared: it was never seen before, or not within the gone
+// duration.
+func (t *WatchTracker) Seen(icao string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, ok := t.seen[icao]
+	t.seen[icao] = now
+	return !ok || now.Sub(last) > t.gone
+}
+
+// Prune forgets aircraft that have left coverage.
+func (t *WatchTracker) Prune(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for icao, last := range t.seen {
+		if now.Sub(last) > t.gone {
+			delete(t.seen, icao)
+		}
+	}
+}
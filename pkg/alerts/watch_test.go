@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// TestWatchTracker tests telling when a watched aircraft reappears.
+func TestWatchTracker(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tracker := NewWatchTracker(15 * time.Minute)
+
+	steps := []struct {
+		name     string
+		icao     string
+		at       time.Duration
+		expected bool
+	}{
+		{"First sighting", "A1B2C3", 0, true},
+		{"Still in coverage", "A1B2C3", 10 * time.Second, false},
+		{"Another aircraft", "ABCDEF", 20 * time.Second, true},
+		{"Short gap", "A1B2C3", 10 * time.Minute, false},
+		{"Back after leaving coverage", "A1B2C3", 30 * time.Minute, true},
+	}
+
+	for _, step := range steps {
+		if got := tracker.Seen(step.icao, now.Add(step.at)); got != step.expected {
+			t.Errorf("%s: expected %v, got %v", step.name, step.expected, got)
+		}
+	}
+
+	// Pruned aircraft appear anew
+	tracker.Prune(now.Add(time.Hour))
+	if !tracker.Seen("ABCDEF", now.Add(time.Hour)) {
+		t.Error("Expected pruned aircraft to reappear")
+	}
+}
+
+// TestNewWatchedAircraftAlert tests describing a watched aircraft.
+func TestNewWatchedAircraftAlert(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	ac := adsb.Aircraft{ICAO: "A1B2C3", Callsign: "N123AB", Altitude: 4500}
+
+	alert := NewWatchedAircraftAlert(ac, "Local", []string{"alice", "bob"}, now)
+	if alert.Type != AlertTypeWatchedAircraft || alert.ICAO != "A1B2C3" || alert.Region != "Local" {
+		t.Errorf("Unexpected alert %+v", alert)
+	}
+	if len(alert.Watchers) != 2 {
+		t.Errorf("Expected 2 watchers, got %v", alert.Watchers)
+	}
+	if !strings.Contains(alert.Description, "N123AB is back in coverage at 4500 ft") {
+		t.Errorf("Unexpected description %q", alert.Description)
+	}
+}
@@ -0,0 +1,247 @@
+// Package alignment measures and corrects an alt-az mount's pointing
+// errors for users without a precise level and north alignment. The mount
+// is pointed at a few references whose true positions are known (bright
+// stars or aircraft), the operator centers each one, and the offsets
+// between where the mount says it points and where the references are fit
+// a Model of the mount's azimuth zero, altitude zero and level error.
+package alignment
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// maxLeverAltitude caps the altitude used for the tilt's azimuth term,
+// which grows as tan(altitude) and is meaningless at the zenith
+const maxLeverAltitude = 85.0
+
+// Model is a mount's pointing model: where its azimuth and altitude zeros
+// really point and how its azimuth axis is tilted, in degrees. The zero
+// Model is a level mount aligned to true north.
+type Model struct {
+	// AzimuthZero is the true azimuth the mount reports as 0°
+	AzimuthZero float64 `json:"azimuthZero"`
+
+	// AltitudeZero is the true altitude the mount reports as 0°
+	AltitudeZero float64 `json:"altitudeZero"`
+
+	// TiltNorth and TiltEast are the tilt of the azimuth axis towards north
+	// and east: the mount's base is low on that side
+	TiltNorth float64 `json:"tiltNorth"`
+	TiltEast  float64 `json:"tiltEast"`
+}
+
+// ModelFromConfig returns the configured model of a mount.
+func ModelFromConfig(cfg config.AlignmentConfig) Model {
+	return Model{
+		AzimuthZero:  cfg.AzimuthZero,
+		AltitudeZero: cfg.AltitudeZero,
+		TiltNorth:    cfg.TiltNorth,
+		TiltEast:     cfg.TiltEast,
+	}
+}
+
+// Config returns the model as configuration, to be saved.
+func (m Model) Config() config.AlignmentConfig {
+	return config.AlignmentConfig{
+		AzimuthZero:  m.AzimuthZero,
+		AltitudeZero: m.AltitudeZero,
+		TiltNorth:    m.TiltNorth,
+		TiltEast:     m.TiltEast,
+	}
+}
+
+// Level returns the tilt of the mount's azimuth axis in degrees and the
+// azimuth of the low side of its base, which needs raising to level it.
+func (m Model) Level() (tilt, lowAzimuth float64) {
+	tilt = math.Hypot(m.TiltNorth, m.TiltEast)
+	if tilt == 0 {
+		return 0, 0
+	}
+	return tilt, coordinates.NormalizeAzimuth(math.Atan2(m.TiltEast, m.TiltNorth) * coordinates.RadiansToDegrees)
+}
+
+// ToMount converts a position in the sky to the position the mount must be
+// commanded to (and reports) to point there. The tilt is modelled to first
+// order, which is accurate for the few degrees a tripod is off level.
+func (m Model) ToMount(sky coordinates.HorizontalCoordinates) coordinates.HorizontalCoordinates {
+	azRad := sky.Azimuth * coordinates.DegreesToRadians
+	sinAz, cosAz := math.Sin(azRad), math.Cos(azRad)
+
+	altitude := sky.Altitude - m.AltitudeZero + m.TiltNorth*cosAz + m.TiltEast*sinAz
+	azimuth := sky.Azimuth - m.AzimuthZero + (m.TiltNorth*sinAz-m.TiltEast*cosAz)*lever(sky.Altitude)
+	return coordinates.HorizontalCoordinates{
+		Altitude: altitude,
+		Azimuth:  coordinates.NormalizeAzimuth(azimuth),
+	}
+}
+
+// FromMount converts a position reported by the mount to where it points
+// in the sky, inverting ToMount.
+func (m Model) FromMount(mount coordinates.HorizontalCoordinates) coordinates.HorizontalCoordinates {
+	sky := mount
+	for i := 0; i < 20; i++ {
+		got := m.ToMount(sky)
+		dAlt, dAz := mount.Altitude-got.Altitude, math.Remainder(mount.Azimuth-got.Azimuth, 360)
+		if math.Abs(dAlt) < 1e-9 && math.Abs(dAz) < 1e-9 {
+			break
+		}
+		sky.Altitude += dAlt
+		sky.Azimuth = coordinates.NormalizeAzimuth(sky.Azimuth + dAz)
+	}
+	return sky
+}
+
+// lever returns how much the tilt moves the azimuth at an altitude.
+func lever(altitude float64) float64 {
+	return math.Tan(math.Min(altitude, maxLeverAltitude) * coordinates.DegreesToRadians)
+}
+
+// Sample is a reference centered in the mount: where the reference was and
+// where the mount reported it was pointing.
+type Sample struct {
+	// Reference names the reference, e.g. "Vega" or "aircraft A1B2C3"
+	Reference string `json:"reference"`
+
+	// Sky is the reference's true position and Mount the mount's reported
+	// position, uncorrected by any model
+	Sky   coordinates.HorizontalCoordinates `json:"sky"`
+	Mount coordinates.HorizontalCoordinates `json:"mount"`
+
+	Time time.Time `json:"time"`
+}
+
+// Offset returns how far the mount's reported position is from the
+// reference, in altitude and azimuth (mount minus sky).
+func (s Sample) Offset() (altitude, azimuth float64) {
+	return s.Mount.Altitude - s.Sky.Altitude, math.Remainder(s.Mount.Azimuth-s.Sky.Azimuth, 360)
+}
+
+// Fit is a model fitted to samples and how well it fits them.
+type Fit struct {
+	Model Model `json:"model"`
+
+	// Residuals is the pointing error left at each sample and RMS their
+	// root mean square, in degrees on the sky
+	Residuals []float64 `json:"residuals"`
+	RMS       float64   `json:"rms"`
+
+	// ZerosOnly is set when there was only one sample, which can't
+	// measure the tilt: only the zeros were fitted
+	ZerosOnly bool `json:"zerosOnly"`
+}
+
+var (
+	// ErrNoSamples is returned when fitting without samples.
+	ErrNoSamples = errors.New("no alignment samples")
+
+	// ErrDegenerate is returned when the samples can't separate the tilt
+	// from the zeros, e.g. all at about the same azimuth.
+	ErrDegenerate = errors.New("alignment references are too close together; spread them around the sky")
+)
+
+// FitModel fits a model to samples by least squares. One sample fits the
+// azimuth and altitude zeros only; two or more, well spread in azimuth,
+// also fit the tilt. Three or more give meaningful residuals.
+func FitModel(samples []Sample) (Fit, error) {
+	if len(samples) == 0 {
+		return Fit{}, ErrNoSamples
+	}
+
+	// Each sample gives an altitude and an azimuth equation, linear in
+	// the unknowns (AzimuthZero, AltitudeZero, TiltNorth, TiltEast). The
+	// azimuth equation is scaled by cos(altitude) so both measure error on
+	// the sky.
+	unknowns := 4
+	if len(samples) == 1 {
+		unknowns = 2
+	}
+	var rows [][]float64
+	var values []float64
+	for _, s := range samples {
+		azRad := s.Sky.Azimuth * coordinates.DegreesToRadians
+		sinAz, cosAz := math.Sin(azRad), math.Cos(azRad)
+		cosAlt := math.Cos(s.Sky.Altitude * coordinates.DegreesToRadians)
+		dAlt, dAz := s.Offset()
+
+		rows = append(rows, []float64{0, -1, cosAz, sinAz}[:unknowns])
+		values = append(values, dAlt)
+
+		l := lever(s.Sky.Altitude) * cosAlt
+		rows = append(rows, []float64{-cosAlt, 0, sinAz * l, -cosAz * l}[:unknowns])
+		values = append(values, dAz*cosAlt)
+	}
+
+	x, ok := leastSquares(rows, values, unknowns)
+	if !ok {
+		return Fit{}, ErrDegenerate
+	}
+	fit := Fit{
+		Model: Model{
+			AzimuthZero:  coordinates.NormalizeAzimuth(x[0]),
+			AltitudeZero: x[1],
+		},
+		ZerosOnly: unknowns == 2,
+	}
+	if unknowns == 4 {
+		fit.Model.TiltNorth, fit.Model.TiltEast = x[2], x[3]
+	}
+
+	var sumSq float64
+	for _, s := range samples {
+		residual := coordinates.AngularSeparation(fit.Model.ToMount(s.Sky), s.Mount)
+		fit.Residuals = append(fit.Residuals, residual)
+		sumSq += residual * residual
+	}
+	fit.RMS = math.Sqrt(sumSq / float64(len(samples)))
+	return fit, nil
+}
+
+// leastSquares solves rows·x = values for x by the normal equations with
+// Gaussian elimination. Returns false if the system is (nearly) singular.
+func leastSquares(rows [][]float64, values []float64, n int) ([]float64, bool) {
+	// Augmented normal matrix [AᵀA | Aᵀb]
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n+1)
+		for k, row := range rows {
+			for j := 0; j < n; j++ {
+				m[i][j] += row[i] * row[j]
+			}
+			m[i][n] += row[i] * values[k]
+		}
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-6 {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for r := 0; r < n; r++ {
+			if r == col {
+				continue
+			}
+			f := m[r][col] / m[col][col]
+			for c := col; c <= n; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = m[i][n] / m[i][i]
+	}
+	return x, true
+}
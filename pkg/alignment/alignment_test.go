@@ -0,0 +1,102 @@
+package alignment
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestModelRoundTrip tests that FromMount inverts ToMount.
+func TestModelRoundTrip(t *testing.T) {
+	model := Model{AzimuthZero: 358, AltitudeZero: 0.7, TiltNorth: 1.2, TiltEast: -0.8}
+
+	for _, sky := range []coordinates.HorizontalCoordinates{
+		{Altitude: 10, Azimuth: 0.5},
+		{Altitude: 45, Azimuth: 135},
+		{Altitude: 80, Azimuth: 359.9},
+	} {
+		mount := model.ToMount(sky)
+		got := model.FromMount(mount)
+		if math.Abs(got.Altitude-sky.Altitude) > 1e-6 || math.Abs(math.Remainder(got.Azimuth-sky.Azimuth, 360)) > 1e-6 {
+			t.Errorf("FromMount(ToMount(%+v)) = %+v", sky, got)
+		}
+	}
+
+	// A level mount aligned to north reports the sky as it is
+	sky := coordinates.HorizontalCoordinates{Altitude: 30, Azimuth: 200}
+	if got := (Model{}).ToMount(sky); got != sky {
+		t.Errorf("Model{}.ToMount(%+v) = %+v", sky, got)
+	}
+}
+
+// TestLevel tests describing the tilt.
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     Model
+		tilt, low float64
+	}{
+		{name: "Level", model: Model{}, tilt: 0, low: 0},
+		{name: "Low to the north-east", model: Model{TiltNorth: 1, TiltEast: 1}, tilt: math.Sqrt2, low: 45},
+		{name: "Low to the west", model: Model{TiltEast: -0.5}, tilt: 0.5, low: 270},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tilt, low := tt.model.Level()
+			if math.Abs(tilt-tt.tilt) > 1e-9 || math.Abs(low-tt.low) > 1e-9 {
+				t.Errorf("Level() = %.2f, %.1f, want %.2f, %.1f", tilt, low, tt.tilt, tt.low)
+			}
+		})
+	}
+}
+
+// TestFitModel tests recovering a mount's model from centered references.
+func TestFitModel(t *testing.T) {
+	want := Model{AzimuthZero: 2.5, AltitudeZero: -0.8, TiltNorth: 0.6, TiltEast: -1.1}
+	sample := func(alt, az float64) Sample {
+		sky := coordinates.HorizontalCoordinates{Altitude: alt, Azimuth: az}
+		return Sample{Sky: sky, Mount: want.ToMount(sky)}
+	}
+
+	t.Run("Spread references", func(t *testing.T) {
+		fit, err := FitModel([]Sample{sample(20, 10), sample(50, 130), sample(35, 250), sample(65, 300)})
+		if err != nil {
+			t.Fatalf("FitModel() error = %v", err)
+		}
+		got := fit.Model
+		if math.Abs(math.Remainder(got.AzimuthZero-want.AzimuthZero, 360)) > 0.05 || math.Abs(got.AltitudeZero-want.AltitudeZero) > 0.05 ||
+			math.Abs(got.TiltNorth-want.TiltNorth) > 0.05 || math.Abs(got.TiltEast-want.TiltEast) > 0.05 {
+			t.Errorf("FitModel() model = %+v, want %+v", got, want)
+		}
+		if fit.RMS > 0.05 || len(fit.Residuals) != 4 || fit.ZerosOnly {
+			t.Errorf("FitModel() RMS = %.3f, %d residuals, zeros only %v", fit.RMS, len(fit.Residuals), fit.ZerosOnly)
+		}
+	})
+
+	t.Run("One reference", func(t *testing.T) {
+		offset := Model{AzimuthZero: 355, AltitudeZero: 1}
+		sky := coordinates.HorizontalCoordinates{Altitude: 40, Azimuth: 90}
+		fit, err := FitModel([]Sample{{Sky: sky, Mount: offset.ToMount(sky)}})
+		if err != nil {
+			t.Fatalf("FitModel() error = %v", err)
+		}
+		if !fit.ZerosOnly || math.Abs(fit.Model.AzimuthZero-355) > 1e-6 || math.Abs(fit.Model.AltitudeZero-1) > 1e-6 {
+			t.Errorf("FitModel() = %+v, want zeros 355, 1 only", fit)
+		}
+	})
+
+	t.Run("Same reference twice", func(t *testing.T) {
+		if _, err := FitModel([]Sample{sample(30, 100), sample(30, 100)}); !errors.Is(err, ErrDegenerate) {
+			t.Errorf("FitModel() error = %v, want ErrDegenerate", err)
+		}
+	})
+
+	t.Run("No samples", func(t *testing.T) {
+		if _, err := FitModel(nil); !errors.Is(err, ErrNoSamples) {
+			t.Errorf("FitModel() error = %v, want ErrNoSamples", err)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package alignment
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// j2000 is the epoch of the star catalogue
+var j2000 = time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Star is a bright star used as an alignment reference.
+type Star struct {
+	Name string `json:"name"`
+
+	// RightAscension (hours) and Declination (degrees) at epoch J2000
+	RightAscension float64 `json:"rightAscension"`
+	Declination    float64 `json:"declination"`
+
+	Magnitude float64 `json:"magnitude"`
+}
+
+// Stars are the brightest stars, easy to find and center in any sky,
+// brightest first.
+var Stars = []Star{
+	{"Sirius", 6.7525, -16.7161, -1.46},
+	{"Canopus", 6.3992, -52.6957, -0.74},
+	{"Alpha Centauri", 14.6600, -60.8340, -0.27},
+	{"Arcturus", 14.2610, 19.1825, -0.05},
+	{"Vega", 18.6156, 38.7837, 0.03},
+	{"Capella", 5.2782, 45.9980, 0.08},
+	{"Rigel", 5.2423, -8.2016, 0.13},
+	{"Procyon", 7.6550, 5.2250, 0.34},
+	{"Achernar", 1.6286, -57.2368, 0.46},
+	{"Betelgeuse", 5.9195, 7.4071, 0.50},
+	{"Hadar", 14.0637, -60.3730, 0.61},
+	{"Altair", 19.8464, 8.8683, 0.76},
+	{"Acrux", 12.4433, -63.0991, 0.76},
+	{"Aldebaran", 4.5987, 16.5093, 0.86},
+	{"Antares", 16.4901, -26.4320, 0.96},
+	{"Spica", 13.4199, -11.1613, 0.97},
+	{"Pollux", 7.7553, 28.0262, 1.14},
+	{"Fomalhaut", 22.9608, -29.6222, 1.16},
+	{"Deneb", 20.6905, 45.2803, 1.25},
+	{"Mimosa", 12.7953, -59.6888, 1.25},
+	{"Regulus", 10.1395, 11.9672, 1.40},
+	{"Castor", 7.5767, 31.8883, 1.58},
+	{"Shaula", 17.5601, -37.1038, 1.62},
+	{"Bellatrix", 5.4189, 6.3497, 1.64},
+	{"Alnilam", 5.6036, -1.2019, 1.69},
+	{"Alioth", 12.9005, 55.9598, 1.77},
+	{"Mirfak", 3.4054, 49.8612, 1.79},
+	{"Dubhe", 11.0621, 61.7510, 1.79},
+	{"Alkaid", 13.7923, 49.3133, 1.86},
+	{"Polaris", 2.5303, 89.2641, 1.98},
+}
+
+// FindStar returns the star with a name (case-insensitive).
+func FindStar(name string) (Star, bool) {
+	for _, s := range Stars {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Star{}, false
+}
+
+// Position returns where the star appears from the observer at t: its
+// catalogue position precessed to t, then raised by atmospheric
+// refraction. Nutation and aberration (under 0.01°) are ignored.
+func (s Star) Position(observer coordinates.Observer, t time.Time) coordinates.HorizontalCoordinates {
+	horiz := coordinates.EquatorialToHorizontal(precess(s.RightAscension, s.Declination, t), observer, t)
+	horiz.Altitude += refraction(horiz.Altitude)
+	return horiz
+}
+
+// VisibleStars returns the stars between minAlt and maxAlt degrees from the
+// observer at t, brightest first.
+func VisibleStars(observer coordinates.Observer, t time.Time, minAlt, maxAlt float64) []Star {
+	var visible []Star
+	for _, s := range Stars {
+		if alt := s.Position(observer, t).Altitude; alt >= minAlt && alt <= maxAlt {
+			visible = append(visible, s)
+		}
+	}
+	return visible
+}
+
+// NextStar picks the visible star that best complements the samples taken
+// so far: the one farthest in azimuth from all of them, which constrains
+// the tilt best. Ties go to the brighter star.
+func NextStar(observer coordinates.Observer, t time.Time, minAlt, maxAlt float64, samples []Sample) (Star, bool) {
+	visible := VisibleStars(observer, t, minAlt, maxAlt)
+	if len(visible) == 0 {
+		return Star{}, false
+	}
+
+	gap := func(s Star) float64 {
+		az := s.Position(observer, t).Azimuth
+		nearest := 180.0
+		for _, sample := range samples {
+			nearest = math.Min(nearest, math.Abs(math.Remainder(az-sample.Sky.Azimuth, 360)))
+		}
+		return nearest
+	}
+	sort.SliceStable(visible, func(i, j int) bool {
+		// Round so that nearly equal gaps prefer brightness
+		return math.Round(gap(visible[i])/10) > math.Round(gap(visible[j])/10)
+	})
+	return visible[0], true
+}
+
+// AircraftPosition returns where an aircraft is from the observer at t,
+// extrapolated from its last report.
+func AircraftPosition(aircraft adsb.Aircraft, observer coordinates.Observer, t time.Time) coordinates.HorizontalCoordinates {
+	pos := tracking.PredictPosition(aircraft, t).Position
+	return coordinates.GeographicToHorizontal(pos, observer, t)
+}
+
+// precess moves J2000 equatorial coordinates to the equinox of t, using
+// the IAU 1976 precession angles.
+func precess(ra, dec float64, t time.Time) coordinates.EquatorialCoordinates {
+	T := t.Sub(j2000).Hours() / 24 / 36525 // Julian centuries
+	arcsec := coordinates.DegreesToRadians / 3600
+	zeta := (2306.2181*T + 0.30188*T*T + 0.017998*T*T*T) * arcsec
+	z := (2306.2181*T + 1.09468*T*T + 0.018203*T*T*T) * arcsec
+	theta := (2004.3109*T - 0.42665*T*T - 0.041833*T*T*T) * arcsec
+
+	raRad := ra * 15 * coordinates.DegreesToRadians
+	decRad := dec * coordinates.DegreesToRadians
+	a := math.Cos(decRad) * math.Sin(raRad+zeta)
+	b := math.Cos(theta)*math.Cos(decRad)*math.Cos(raRad+zeta) - math.Sin(theta)*math.Sin(decRad)
+	c := math.Sin(theta)*math.Cos(decRad)*math.Cos(raRad+zeta) + math.Cos(theta)*math.Sin(decRad)
+
+	return coordinates.EquatorialCoordinates{
+		RightAscension: coordinates.NormalizeRA((math.Atan2(a, b) + z) * coordinates.RadiansToDegrees / 15),
+		Declination:    math.Asin(c) * coordinates.RadiansToDegrees,
+	}
+}
+
+// refraction returns how far the atmosphere raises an object at a true
+// altitude, in degrees (Sæmundsson's formula, standard conditions).
+func refraction(altitude float64) float64 {
+	if altitude < -1 {
+		return 0
+	}
+	arcmin := 1.02 / math.Tan((altitude+10.3/(altitude+5.11))*coordinates.DegreesToRadians)
+	return math.Max(arcmin, 0) / 60
+}
@@ -0,0 +1,63 @@
+package alignment
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestStarPosition tests star positions, precessed and refracted.
+func TestStarPosition(t *testing.T) {
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 40, Longitude: -75}}
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+
+	// Vega's RA has moved about 53s of time since J2000
+	vega, _ := FindStar("vega")
+	if got := precess(vega.RightAscension, vega.Declination, now); math.Abs(got.RightAscension-18.6302) > 0.001 || math.Abs(got.Declination-38.807) > 0.01 {
+		t.Errorf("precess(Vega) = %+v, want RA 18.6302h, Dec 38.807°", got)
+	}
+
+	// Polaris sits within a degree of the pole, at the latitude's altitude
+	polaris, ok := FindStar("Polaris")
+	if !ok {
+		t.Fatal("FindStar(Polaris) not found")
+	}
+	pos := polaris.Position(observer, now)
+	if math.Abs(pos.Altitude-40) > 1 || math.Abs(math.Remainder(pos.Azimuth, 360)) > 1.5 {
+		t.Errorf("Polaris.Position() = %+v, want about 40° altitude due north", pos)
+	}
+
+	// Refraction raises stars at the horizon by about half a degree
+	if r := refraction(0); math.Abs(r-0.48) > 0.02 {
+		t.Errorf("refraction(0) = %.3f°, want about 0.48°", r)
+	}
+	if r := refraction(90); r != 0 {
+		t.Errorf("refraction(90) = %.4f°, want 0", r)
+	}
+}
+
+// TestNextStar tests picking references spread around the sky.
+func TestNextStar(t *testing.T) {
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 40, Longitude: -75}}
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+
+	first, ok := NextStar(observer, now, 15, 80, nil)
+	if !ok {
+		t.Fatal("NextStar() found no star")
+	}
+	visible := VisibleStars(observer, now, 15, 80)
+	if first != visible[0] {
+		t.Errorf("NextStar() without samples = %s, want the brightest visible %s", first.Name, visible[0].Name)
+	}
+
+	sample := Sample{Reference: first.Name, Sky: first.Position(observer, now)}
+	second, ok := NextStar(observer, now, 15, 80, []Sample{sample})
+	if !ok {
+		t.Fatal("NextStar() found no second star")
+	}
+	if gap := math.Abs(math.Remainder(second.Position(observer, now).Azimuth-sample.Sky.Azimuth, 360)); gap < 90 {
+		t.Errorf("NextStar() = %s, %.0f° in azimuth from %s, want a star far from it", second.Name, gap, first.Name)
+	}
+}
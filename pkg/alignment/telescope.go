@@ -0,0 +1,117 @@
+package alignment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+var (
+	_ alpaca.TelescopeDriver = (*Telescope)(nil)
+	_ alpaca.Guider          = (*Telescope)(nil)
+)
+
+// Telescope corrects a mount's alt-az pointing with a Model: slew targets
+// are converted to the mount's frame and positions read back to the sky's.
+// Wrap it in an alpaca.SafeTelescope so the limits apply to the sky. Axis
+// rates are passed through unchanged; the model's errors are too small to
+// change them noticeably.
+type Telescope struct {
+	driver alpaca.TelescopeDriver
+
+	mu    sync.RWMutex
+	model Model
+}
+
+// NewTelescope corrects driver's pointing with model.
+func NewTelescope(driver alpaca.TelescopeDriver, model Model) *Telescope {
+	return &Telescope{driver: driver, model: model}
+}
+
+// Model returns the model in use.
+func (t *Telescope) Model() Model {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.model
+}
+
+// SetModel replaces the model, e.g. after an alignment run.
+func (t *Telescope) SetModel(model Model) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.model = model
+}
+
+// SlewToAltAzContext points the mount at an altitude and azimuth in the sky.
+func (t *Telescope) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	mount := t.Model().ToMount(coordinates.HorizontalCoordinates{Altitude: altitude, Azimuth: azimuth})
+	return t.driver.SlewToAltAzContext(ctx, mount.Altitude, mount.Azimuth)
+}
+
+// MoveAxisContext moves an axis of the mount at rate degrees per second.
+func (t *Telescope) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	return t.driver.MoveAxisContext(ctx, axis, rate)
+}
+
+// GetAltitude returns the altitude the mount points at in the sky.
+func (t *Telescope) GetAltitude() (float64, error) {
+	pos, err := t.Position()
+	return pos.Altitude, err
+}
+
+// GetAzimuth returns the azimuth the mount points at in the sky.
+func (t *Telescope) GetAzimuth() (float64, error) {
+	pos, err := t.Position()
+	return pos.Azimuth, err
+}
+
+// PulseGuide sends a guide pulse to the mount, if it can be pulse guided.
+// Like axis rates, pulses are passed through unchanged.
+func (t *Telescope) PulseGuide(direction alpaca.GuideDirection, duration time.Duration) error {
+	guider, ok := t.driver.(alpaca.Guider)
+	if !ok {
+		return errors.New("telescope cannot pulse guide")
+	}
+	return guider.PulseGuide(direction, duration)
+}
+
+// GetGuideRates returns the mount's guide rates, if it can be pulse guided.
+func (t *Telescope) GetGuideRates() (raRate, decRate float64, err error) {
+	guider, ok := t.driver.(alpaca.Guider)
+	if !ok {
+		return 0, 0, errors.New("telescope cannot pulse guide")
+	}
+	return guider.GetGuideRates()
+}
+
+// IsSlewing reports whether the mount is slewing.
+func (t *Telescope) IsSlewing() (bool, error) {
+	return t.driver.IsSlewing()
+}
+
+// Position returns where the mount points in the sky.
+func (t *Telescope) Position() (coordinates.HorizontalCoordinates, error) {
+	mount, err := t.MountPosition()
+	if err != nil {
+		return coordinates.HorizontalCoordinates{}, err
+	}
+	return t.Model().FromMount(mount), nil
+}
+
+// MountPosition returns the position the mount reports, uncorrected, as
+// measured for alignment samples.
+func (t *Telescope) MountPosition() (coordinates.HorizontalCoordinates, error) {
+	altitude, err := t.driver.GetAltitude()
+	if err != nil {
+		return coordinates.HorizontalCoordinates{}, err
+	}
+	azimuth, err := t.driver.GetAzimuth()
+	if err != nil {
+		return coordinates.HorizontalCoordinates{}, err
+	}
+	return coordinates.HorizontalCoordinates{Altitude: altitude, Azimuth: azimuth}, nil
+}
@@ -0,0 +1,58 @@
+package alignment
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// fakeMount is a mount that goes wherever it is told.
+type fakeMount struct {
+	altitude, azimuth float64
+}
+
+func (m *fakeMount) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	m.altitude, m.azimuth = altitude, azimuth
+	return nil
+}
+
+func (m *fakeMount) MoveAxisContext(ctx context.Context, axis int, rate float64) error { return nil }
+func (m *fakeMount) GetAltitude() (float64, error)                                     { return m.altitude, nil }
+func (m *fakeMount) GetAzimuth() (float64, error)                                      { return m.azimuth, nil }
+func (m *fakeMount) IsSlewing() (bool, error)                                          { return false, nil }
+
+// TestTelescope tests correcting slews and positions with a model.
+func TestTelescope(t *testing.T) {
+	mount := &fakeMount{}
+	model := Model{AzimuthZero: 3, AltitudeZero: -1, TiltNorth: 0.5}
+	telescope := NewTelescope(mount, model)
+
+	if err := telescope.SlewToAltAzContext(context.Background(), 30, 1); err != nil {
+		t.Fatalf("SlewToAltAzContext() error = %v", err)
+	}
+	want := model.ToMount(coordinates.HorizontalCoordinates{Altitude: 30, Azimuth: 1})
+	if mount.altitude != want.Altitude || mount.azimuth != want.Azimuth {
+		t.Errorf("mount commanded to %.2f, %.2f, want %.2f, %.2f", mount.altitude, mount.azimuth, want.Altitude, want.Azimuth)
+	}
+
+	alt, err := telescope.GetAltitude()
+	if err != nil || math.Abs(alt-30) > 1e-6 {
+		t.Errorf("GetAltitude() = %.4f, %v, want 30", alt, err)
+	}
+	az, err := telescope.GetAzimuth()
+	if err != nil || math.Abs(az-1) > 1e-6 {
+		t.Errorf("GetAzimuth() = %.4f, %v, want 1", az, err)
+	}
+	if raw, _ := telescope.MountPosition(); raw != want {
+		t.Errorf("MountPosition() = %+v, want %+v", raw, want)
+	}
+
+	// Without a model the mount is commanded as is
+	telescope.SetModel(Model{})
+	telescope.SlewToAltAzContext(context.Background(), 30, 1)
+	if mount.altitude != 30 || mount.azimuth != 1 {
+		t.Errorf("mount commanded to %.2f, %.2f without a model, want 30, 1", mount.altitude, mount.azimuth)
+	}
+}
@@ -1,13 +1,13 @@
 package alpaca
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/unklstewy/ads-bscope/pkg/config"
@@ -20,28 +20,61 @@ type Client struct {
 	// config contains all telescope configuration from the config system
 	config config.TelescopeConfig
 
-	// clientID is a unique identifier for this client instance
-	// Generated at client creation to comply with Alpaca specification
-	clientID int
-
-	// httpClient is the HTTP client used for API requests
-	httpClient *http.Client
+	// transport sends requests to the Alpaca server, shared with the
+	// focuser, switch and other devices created from this client
+	transport *transport
 
 	// connected tracks if we're currently connected to the telescope
-	connected bool
+	connected atomic.Bool
+
+	// capabilities are probed at connect time and gate commands the
+	// telescope cannot perform (nil if probing failed)
+	capabilities atomic.Pointer[TelescopeCapabilities]
+
+	// slewPoll is how often a slew is polled until it finishes
+	slewPoll time.Duration
+
+	// mu guards the mount state re-applied by RestoreState
+	mu       sync.Mutex
+	tracking *bool // Last tracking state set, nil if never set
+	unparked bool  // Unpark was called since the last Park
 }
 
 // NewClient creates a new Alpaca telescope client from configuration.
 // The configuration should be loaded from config file or database.
 func NewClient(cfg config.TelescopeConfig) *Client {
+	timeout, retries := requestPolicy(cfg)
 	return &Client{
-		config:   cfg,
-		clientID: generateClientID(),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second, // Increased timeout for slow simulations
-		},
-		connected: false,
+		config:    cfg,
+		transport: newTransport(cfg.BaseURL, generateClientID(), timeout, retries),
+		slewPoll:  slewPollInterval,
+	}
+}
+
+const (
+	// slewPollInterval is how often a slew is polled until it finishes
+	slewPollInterval = 250 * time.Millisecond
+
+	// maxSlewDuration bounds the wait for a slew to finish
+	maxSlewDuration = 5 * time.Minute
+)
+
+// requestPolicy returns the per-request timeout and retry count from the
+// configuration, applying defaults for unset values.
+func requestPolicy(cfg config.TelescopeConfig) (time.Duration, int) {
+	timeout := time.Duration(cfg.RequestTimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
 	}
+
+	retries := cfg.RequestRetries
+	if retries == 0 {
+		retries = DefaultRequestRetries
+	} else if retries < 0 {
+		retries = 0 // Negative disables retries
+	}
+
+	return timeout, retries
 }
 
 // generateClientID creates a unique client ID for this Alpaca session.
@@ -58,37 +91,57 @@ func (c *Client) Connect() error {
 	// Set connected=true via Alpaca API
 	params := url.Values{}
 	params.Add("Connected", "true")
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 
 	resp, err := c.put("connected", params)
 	if err != nil {
 		return fmt.Errorf("failed to connect to telescope: %w", err)
 	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
 
-	c.connected = true
-	return resp.Error()
+	c.connected.Store(true)
+
+	// Cache capabilities so unsupported commands fail with a clear error.
+	// If probing fails nothing is gated and the driver has the final say.
+	caps, _ := probeCapabilities(func(endpoint string, params url.Values) (interface{}, error) {
+		resp, err := c.getWithParams(endpoint, params)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Error(); err != nil {
+			return nil, err
+		}
+		return resp.Value, nil
+	})
+	c.capabilities.Store(caps)
+
+	return nil
+}
+
+// Capabilities returns the capabilities probed when the telescope was
+// connected, or nil if they are unknown.
+func (c *Client) Capabilities() *TelescopeCapabilities {
+	return c.capabilities.Load()
 }
 
 // Disconnect closes the connection to the telescope.
 // Implements: PUT /api/v1/telescope/{device_number}/connected
 func (c *Client) Disconnect() error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return nil
 	}
 
 	// Set connected=false via Alpaca API
 	params := url.Values{}
 	params.Add("Connected", "false")
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 
 	resp, err := c.put("connected", params)
 	if err != nil {
 		return fmt.Errorf("failed to disconnect from telescope: %w", err)
 	}
 
-	c.connected = false
+	c.connected.Store(false)
 	return resp.Error()
 }
 
@@ -116,9 +169,17 @@ func (c *Client) IsConnected() (bool, error) {
 // altitude: angle above horizon in degrees (0-90)
 // azimuth: angle from north clockwise in degrees (0-360)
 // This is used for Alt/Az mounted telescopes.
-// Implements: PUT /api/v1/telescope/{device_number}/slewtoaltaz
+// The slew is started asynchronously and polled until it finishes, so a long
+// slew doesn't outlast the request timeout; drivers without the async method
+// get the synchronous one.
+// Implements: PUT /api/v1/telescope/{device_number}/slewtoaltazasync
 func (c *Client) SlewToAltAz(altitude, azimuth float64) error {
-	if !c.connected {
+	return c.SlewToAltAzContext(context.Background(), altitude, azimuth)
+}
+
+// SlewToAltAzContext is SlewToAltAz, giving up (including retries) when ctx is done.
+func (c *Client) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
 
@@ -126,28 +187,29 @@ func (c *Client) SlewToAltAz(altitude, azimuth float64) error {
 	if strings.ToLower(c.config.MountType) != "altaz" {
 		return fmt.Errorf("telescope mount type is %s, not altaz", c.config.MountType)
 	}
+	endpoint := "slewtoaltazasync"
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanSlewAltAzAsync {
+		if !caps.CanSlewAltAz {
+			return unsupported("slew to alt/az", "CanSlewAltAz")
+		}
+		endpoint = "slewtoaltaz"
+	}
 
 	params := url.Values{}
 	params.Add("Azimuth", fmt.Sprintf("%.6f", azimuth))
 	params.Add("Altitude", fmt.Sprintf("%.6f", altitude))
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
-
-	resp, err := c.put("slewtoaltaz", params)
-	if err != nil {
-		return fmt.Errorf("failed to slew telescope: %w", err)
-	}
 
-	return resp.Error()
+	return c.slew(ctx, endpoint, params)
 }
 
 // SlewToCoordinates slews the telescope to the specified equatorial coordinates.
 // ra: right ascension in decimal hours (0-24)
 // dec: declination in decimal degrees (-90 to +90)
 // This is used for equatorially mounted telescopes.
-// Implements: PUT /api/v1/telescope/{device_number}/slewtocoordinates
+// Like SlewToAltAz, the slew is polled until it finishes.
+// Implements: PUT /api/v1/telescope/{device_number}/slewtocoordinatesasync
 func (c *Client) SlewToCoordinates(ra, dec float64) error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
 
@@ -155,25 +217,64 @@ func (c *Client) SlewToCoordinates(ra, dec float64) error {
 	if strings.ToLower(c.config.MountType) != "equatorial" {
 		return fmt.Errorf("telescope mount type is %s, not equatorial", c.config.MountType)
 	}
+	endpoint := "slewtocoordinatesasync"
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanSlewAsync {
+		if !caps.CanSlew {
+			return unsupported("slew to coordinates", "CanSlew")
+		}
+		endpoint = "slewtocoordinates"
+	}
 
 	params := url.Values{}
 	params.Add("RightAscension", fmt.Sprintf("%.6f", ra))
 	params.Add("Declination", fmt.Sprintf("%.6f", dec))
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 
-	resp, err := c.put("slewtocoordinates", params)
+	return c.slew(context.Background(), endpoint, params)
+}
+
+// slew starts a slew and, for the asynchronous methods, polls until it
+// finishes. The synchronous methods return when the slew has finished.
+func (c *Client) slew(ctx context.Context, endpoint string, params url.Values) error {
+	resp, err := c.putContext(ctx, endpoint, params)
 	if err != nil {
 		return fmt.Errorf("failed to slew telescope: %w", err)
 	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(endpoint, "async") {
+		return nil
+	}
 
-	return resp.Error()
+	ctx, cancel := context.WithTimeout(ctx, maxSlewDuration)
+	defer cancel()
+
+	ticker := time.NewTicker(c.slewPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("slew did not finish: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		resp, err := c.transport.get(ctx, "telescope", c.config.DeviceNumber, "slewing", nil)
+		if err != nil {
+			return fmt.Errorf("failed to get slewing status: %w", err)
+		}
+		if err := resp.Error(); err != nil {
+			return err
+		}
+		if slewing, _ := resp.Value.(bool); !slewing {
+			return nil
+		}
+	}
 }
 
 // IsSlewing returns true if the telescope is currently slewing.
 // Implements: GET /api/v1/telescope/{device_number}/slewing
 func (c *Client) IsSlewing() (bool, error) {
-	if !c.connected {
+	if !c.connected.Load() {
 		return false, fmt.Errorf("telescope not connected")
 	}
 
@@ -197,15 +298,16 @@ func (c *Client) IsSlewing() (bool, error) {
 // AbortSlew immediately stops any telescope motion.
 // Implements: PUT /api/v1/telescope/{device_number}/abortslew
 func (c *Client) AbortSlew() error {
-	if !c.connected {
+	return c.AbortSlewContext(context.Background())
+}
+
+// AbortSlewContext is AbortSlew, giving up (including retries) when ctx is done.
+func (c *Client) AbortSlewContext(ctx context.Context) error {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
 
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
-
-	resp, err := c.put("abortslew", params)
+	resp, err := c.putContext(ctx, "abortslew", nil)
 	if err != nil {
 		return fmt.Errorf("failed to abort slew: %w", err)
 	}
@@ -216,7 +318,7 @@ func (c *Client) AbortSlew() error {
 // GetAltitude returns the telescope's current altitude.
 // Implements: GET /api/v1/telescope/{device_number}/altitude
 func (c *Client) GetAltitude() (float64, error) {
-	if !c.connected {
+	if !c.connected.Load() {
 		return 0, fmt.Errorf("telescope not connected")
 	}
 
@@ -240,7 +342,7 @@ func (c *Client) GetAltitude() (float64, error) {
 // GetAzimuth returns the telescope's current azimuth.
 // Implements: GET /api/v1/telescope/{device_number}/azimuth
 func (c *Client) GetAzimuth() (float64, error) {
-	if !c.connected {
+	if !c.connected.Load() {
 		return 0, fmt.Errorf("telescope not connected")
 	}
 
@@ -269,7 +371,12 @@ func (c *Client) GetAzimuth() (float64, error) {
 // Set rate to 0 to stop movement on that axis.
 // Implements: PUT /api/v1/telescope/{device_number}/moveaxis
 func (c *Client) MoveAxis(axis int, rate float64) error {
-	if !c.connected {
+	return c.MoveAxisContext(context.Background(), axis, rate)
+}
+
+// MoveAxisContext is MoveAxis, giving up (including retries) when ctx is done.
+func (c *Client) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
 
@@ -277,6 +384,9 @@ func (c *Client) MoveAxis(axis int, rate float64) error {
 	if axis < 0 || axis > 1 {
 		return fmt.Errorf("invalid axis %d: must be 0 (azimuth) or 1 (altitude)", axis)
 	}
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanMoveAxis {
+		return unsupported("move axis", "CanMoveAxis")
+	}
 
 	// Validate rate against configured slew rate
 	if rate > c.config.SlewRate || rate < -c.config.SlewRate {
@@ -286,10 +396,8 @@ func (c *Client) MoveAxis(axis int, rate float64) error {
 	params := url.Values{}
 	params.Add("Axis", strconv.Itoa(axis))
 	params.Add("Rate", fmt.Sprintf("%.6f", rate))
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 
-	resp, err := c.put("moveaxis", params)
+	resp, err := c.putContext(ctx, "moveaxis", params)
 	if err != nil {
 		return fmt.Errorf("failed to move axis: %w", err)
 	}
@@ -300,10 +408,15 @@ func (c *Client) MoveAxis(axis int, rate float64) error {
 // StopAxes stops movement on both axes by setting their rates to 0.
 // Convenience method for stopping all telescope motion.
 func (c *Client) StopAxes() error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
 
+	// Nothing can be moving on an axis the telescope cannot move
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanMoveAxis {
+		return nil
+	}
+
 	// Stop both axes
 	if err := c.MoveAxis(0, 0); err != nil {
 		return fmt.Errorf("failed to stop azimuth axis: %w", err)
@@ -339,39 +452,49 @@ func (c *Client) GetAtPark() (bool, error) {
 // Must be called before any slewing operations if the telescope is parked.
 // Implements: PUT /api/v1/telescope/{device_number}/unpark
 func (c *Client) Unpark() error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanUnpark {
+		return unsupported("unpark", "CanUnpark")
+	}
 
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
-
-	resp, err := c.put("unpark", params)
+	resp, err := c.put("unpark", nil)
 	if err != nil {
 		return fmt.Errorf("failed to unpark telescope: %w", err)
 	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
 
-	return resp.Error()
+	c.mu.Lock()
+	c.unparked = true
+	c.mu.Unlock()
+	return nil
 }
 
 // Park parks the telescope at its configured park position.
 // Implements: PUT /api/v1/telescope/{device_number}/park
 func (c *Client) Park() error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanPark {
+		return unsupported("park", "CanPark")
+	}
 
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
-
-	resp, err := c.put("park", params)
+	resp, err := c.put("park", nil)
 	if err != nil {
 		return fmt.Errorf("failed to park telescope: %w", err)
 	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
 
-	return resp.Error()
+	c.mu.Lock()
+	c.unparked = false
+	c.mu.Unlock()
+	return nil
 }
 
 // SetTracking enables or disables telescope tracking.
@@ -379,27 +502,63 @@ func (c *Client) Park() error {
 // For equatorial mounts, enables sidereal tracking.
 // Implements: PUT /api/v1/telescope/{device_number}/tracking
 func (c *Client) SetTracking(enabled bool) error {
-	if !c.connected {
+	if !c.connected.Load() {
 		return fmt.Errorf("telescope not connected")
 	}
+	if caps := c.capabilities.Load(); caps != nil && !caps.CanSetTracking {
+		return unsupported("set tracking", "CanSetTracking")
+	}
 
 	params := url.Values{}
 	params.Add("Tracking", strconv.FormatBool(enabled))
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 
 	resp, err := c.put("tracking", params)
 	if err != nil {
 		return fmt.Errorf("failed to set tracking: %w", err)
 	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
 
-	return resp.Error()
+	c.mu.Lock()
+	c.tracking = &enabled
+	c.mu.Unlock()
+	return nil
+}
+
+// RestoreState re-applies the park and tracking state set during this
+// session, for use after reconnecting to an Alpaca server that restarted.
+// The mount is only unparked if it was unparked through this client.
+func (c *Client) RestoreState() error {
+	c.mu.Lock()
+	unparked, tracking := c.unparked, c.tracking
+	c.mu.Unlock()
+
+	if unparked {
+		atPark, err := c.GetAtPark()
+		if err != nil {
+			return fmt.Errorf("failed to restore park state: %w", err)
+		}
+		if atPark {
+			if err := c.Unpark(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if tracking != nil {
+		if err := c.SetTracking(*tracking); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // GetTracking returns the current tracking state.
 // Implements: GET /api/v1/telescope/{device_number}/tracking
 func (c *Client) GetTracking() (bool, error) {
-	if !c.connected {
+	if !c.connected.Load() {
 		return false, fmt.Errorf("telescope not connected")
 	}
 
@@ -426,85 +585,25 @@ func (c *Client) GetConfig() config.TelescopeConfig {
 	return c.config
 }
 
-// getTransactionID generates a unique transaction ID for each API call.
-// Uses Unix timestamp modulo 2^31-1 to keep within 32-bit signed integer range.
-// Alpaca spec requires transaction IDs to fit in a 32-bit signed integer.
-func (c *Client) getTransactionID() int {
-	// Use Unix timestamp in seconds modulo a large prime to avoid overflow
-	// while maintaining uniqueness across restarts
-	now := time.Now().Unix()
-	ms := int64(time.Now().Nanosecond() / 1000000)
-	return int((now + ms) % 2147483647)
-}
-
 // get performs an HTTP GET request to an Alpaca endpoint.
 func (c *Client) get(endpoint string) (*alpacaResponse, error) {
-	// Build URL
-	apiURL := fmt.Sprintf("%s/api/v1/telescope/%d/%s",
-		c.config.BaseURL, c.config.DeviceNumber, endpoint)
-
-	// Add query parameters
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(c.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
-
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	// Make request
-	resp, err := c.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	var alpacaResp alpacaResponse
-	if err := json.Unmarshal(body, &alpacaResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+	return c.getWithParams(endpoint, nil)
+}
 
-	return &alpacaResp, nil
+// getWithParams performs an HTTP GET request with extra query parameters,
+// for properties such as canmoveaxis that take arguments.
+func (c *Client) getWithParams(endpoint string, params url.Values) (*alpacaResponse, error) {
+	return c.transport.get(context.Background(), "telescope", c.config.DeviceNumber, endpoint, params)
 }
 
 // put performs an HTTP PUT request to an Alpaca endpoint.
 func (c *Client) put(endpoint string, params url.Values) (*alpacaResponse, error) {
-	// Build URL
-	apiURL := fmt.Sprintf("%s/api/v1/telescope/%d/%s",
-		c.config.BaseURL, c.config.DeviceNumber, endpoint)
-
-	// Create PUT request with form-encoded body
-	req, err := http.NewRequest("PUT", apiURL, strings.NewReader(params.Encode()))
-	if err != nil {
-		return nil, err
-	}
-
-	// Set Content-Type header for form data
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	var alpacaResp alpacaResponse
-	if err := json.Unmarshal(body, &alpacaResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+	return c.putContext(context.Background(), endpoint, params)
+}
 
-	return &alpacaResp, nil
+// putContext performs an HTTP PUT request that is abandoned when ctx is done.
+func (c *Client) putContext(ctx context.Context, endpoint string, params url.Values) (*alpacaResponse, error) {
+	return c.transport.put(ctx, "telescope", c.config.DeviceNumber, endpoint, params)
 }
 
 // alpacaResponse represents the standard Alpaca API response format.
@@ -525,25 +624,10 @@ type alpacaResponse struct {
 	ErrorMessage string `json:"ErrorMessage"`
 }
 
-// Error returns an error if the Alpaca response indicates failure.
+// Error returns an *AlpacaError if the Alpaca response indicates failure.
 func (r *alpacaResponse) Error() error {
 	if r.ErrorNumber != 0 {
-		return fmt.Errorf("alpaca error %d: %s", r.ErrorNumber, r.ErrorMessage)
+		return &AlpacaError{Number: r.ErrorNumber, Message: r.ErrorMessage}
 	}
 	return nil
 }
-
-// parseAlpacaResponse parses an Alpaca JSON response from an io.Reader.
-// Helper function for other clients to reuse.
-func parseAlpacaResponse(body io.Reader, resp *alpacaResponse) error {
-	data, err := io.ReadAll(body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if err := json.Unmarshal(data, resp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return nil
-}
@@ -0,0 +1,120 @@
+package alpaca
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// newSlewServer starts a simulator whose clock advances a second each time
+// the mount is polled for slewing, and which holds synchronous slews open for
+// hold as a driver does until the slew finishes. It returns a connected
+// client and a count of the requests per method.
+func newSlewServer(t *testing.T, cfg config.TelescopeConfig, hold time.Duration, lacking ...string) (*Client, map[string]*atomic.Int32) {
+	t.Helper()
+
+	var mu sync.Mutex
+	now := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	sim := NewSimulator(coordinates.Observer{
+		Location: coordinates.Geographic{Latitude: 37.08, Longitude: -94.51, Altitude: 300},
+	})
+	sim.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	sim.lacking = make(map[string]bool)
+	for _, name := range lacking {
+		sim.lacking[name] = true
+	}
+
+	requests := map[string]*atomic.Int32{}
+	for _, method := range []string{"slewing", "slewtoaltaz", "slewtoaltazasync"} {
+		requests[method] = &atomic.Int32{}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if count, ok := requests[method]; ok {
+			count.Add(1)
+		}
+		switch method {
+		case "slewing":
+			mu.Lock()
+			now = now.Add(time.Second)
+			mu.Unlock()
+		case "slewtoaltaz":
+			rec := httptest.NewRecorder()
+			sim.ServeHTTP(rec, r)
+			time.Sleep(hold)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(rec.Body.Bytes())
+			return
+		}
+		sim.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.BaseURL = server.URL
+	cfg.MountType = "altaz"
+	client := NewClient(cfg)
+	client.slewPoll = time.Millisecond
+	client.transport.backoff = time.Millisecond
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	return client, requests
+}
+
+// TestClientSlewOutlastsTimeout tests that slews taking longer than the
+// request timeout finish rather than failing while the mount is moving.
+func TestClientSlewOutlastsTimeout(t *testing.T) {
+	t.Run("Async slew is polled", func(t *testing.T) {
+		// 60° at the simulated slew rate takes 10 s, twice the default timeout
+		client, requests := newSlewServer(t, config.TelescopeConfig{}, 0)
+		if err := client.SlewToAltAz(90, 0); err != nil {
+			t.Fatalf("SlewToAltAz failed: %v", err)
+		}
+
+		if got := requests["slewtoaltazasync"].Load(); got != 1 {
+			t.Errorf("Expected 1 async slew, got %d", got)
+		}
+		if got := requests["slewtoaltaz"].Load(); got != 0 {
+			t.Errorf("Expected no synchronous slews, got %d", got)
+		}
+		if got := requests["slewing"].Load(); got < 10 {
+			t.Errorf("Expected the slew to be polled for 10 s, got %d polls", got)
+		}
+
+		altitude, err := client.GetAltitude()
+		if err != nil {
+			t.Fatalf("GetAltitude failed: %v", err)
+		}
+		if math.Abs(altitude-90) > 1e-6 {
+			t.Errorf("Expected the slew to have finished at 90°, got %.4f", altitude)
+		}
+	})
+
+	t.Run("Sync slew is not timed out or retried", func(t *testing.T) {
+		// The driver holds the slew open for four times the request timeout
+		cfg := config.TelescopeConfig{RequestTimeoutMs: 50}
+		client, requests := newSlewServer(t, cfg, 200*time.Millisecond, "canslewaltazasync")
+		if err := client.SlewToAltAz(90, 0); err != nil {
+			t.Fatalf("SlewToAltAz failed: %v", err)
+		}
+
+		if got := requests["slewtoaltaz"].Load(); got != 1 {
+			t.Errorf("Expected 1 synchronous slew, got %d", got)
+		}
+		if got := requests["slewtoaltazasync"].Load(); got != 0 {
+			t.Errorf("Expected no async slews, got %d", got)
+		}
+	})
+}
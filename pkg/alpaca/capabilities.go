@@ -0,0 +1,84 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrNotSupported is returned instead of sending a command the telescope
+// reported it cannot perform when its capabilities were probed.
+var ErrNotSupported = errors.New("not supported by telescope")
+
+// queryFunc reads an Alpaca property, returning its value or the
+// transport/Alpaca error.
+type queryFunc func(endpoint string, params url.Values) (interface{}, error)
+
+// probeCapabilities reads the telescope's Can* properties and descriptive
+// strings. It fails only if the first property cannot be read; after that a
+// property the driver does not implement is reported as false.
+func probeCapabilities(query queryFunc) (*TelescopeCapabilities, error) {
+	if _, err := query("canslew", nil); err != nil {
+		return nil, fmt.Errorf("failed to probe telescope capabilities: %w", err)
+	}
+
+	flag := func(endpoint string, params url.Values) bool {
+		v, err := query(endpoint, params)
+		if err != nil {
+			return false
+		}
+		b, _ := v.(bool)
+		return b
+	}
+	text := func(endpoint string) string {
+		v, err := query(endpoint, nil)
+		if err != nil {
+			return ""
+		}
+		s, _ := v.(string)
+		return s
+	}
+
+	caps := &TelescopeCapabilities{
+		Description:       text("description"),
+		DriverInfo:        text("driverinfo"),
+		CanSetTracking:    flag("cansettracking", nil),
+		CanSlew:           flag("canslew", nil),
+		CanSlewAsync:      flag("canslewasync", nil),
+		CanSlewAltAz:      flag("canslewaltaz", nil),
+		CanSlewAltAzAsync: flag("canslewaltazasync", nil),
+		CanPark:           flag("canpark", nil),
+		CanUnpark:         flag("canunpark", nil),
+		// Rate tracking drives both axes, so both must be movable
+		CanMoveAxis: flag("canmoveaxis", axisParams(0)) && flag("canmoveaxis", axisParams(1)),
+	}
+
+	if v, err := query("interfaceversion", nil); err == nil {
+		if n, ok := v.(float64); ok {
+			caps.InterfaceVersion = int(n)
+		}
+	}
+	if v, err := query("supportedactions", nil); err == nil {
+		if actions, ok := v.([]interface{}); ok {
+			for _, action := range actions {
+				if str, ok := action.(string); ok {
+					caps.SupportedActions = append(caps.SupportedActions, str)
+				}
+			}
+		}
+	}
+
+	return caps, nil
+}
+
+// unsupported returns the error for an operation gated on a capability the
+// telescope reported as false.
+func unsupported(operation, capability string) error {
+	return fmt.Errorf("cannot %s: %s is false: %w", operation, capability, ErrNotSupported)
+}
+
+// axisParams returns the query parameters for a per-axis property.
+func axisParams(axis int) url.Values {
+	return url.Values{"Axis": {strconv.Itoa(axis)}}
+}
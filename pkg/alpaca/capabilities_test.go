@@ -0,0 +1,77 @@
+package alpaca
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestTelescopeClientCapabilityGating tests that once capabilities are
+// probed, alt-az slews the telescope cannot perform are refused locally.
+func TestTelescopeClientCapabilityGating(t *testing.T) {
+	sim, client, _ := newTestSimulator(t)
+	sim.lacking = map[string]bool{"canslewaltazasync": true}
+
+	// Nothing is gated before probing
+	if err := client.SlewToAltAz(45, 90); err != nil {
+		t.Fatalf("SlewToAltAz before probing failed: %v", err)
+	}
+
+	caps, err := client.ProbeCapabilities()
+	if err != nil {
+		t.Fatalf("ProbeCapabilities failed: %v", err)
+	}
+	if caps.CanSlewAltAzAsync || !caps.CanSlewAltAz || !caps.CanMoveAxis {
+		t.Errorf("Capabilities = %+v, want only CanSlewAltAzAsync false", caps)
+	}
+
+	if err := client.SlewToAltAz(45, 90); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("SlewToAltAz error = %v, want ErrNotSupported", err)
+	}
+	if err := client.SetTracking(true); err != nil {
+		t.Errorf("SetTracking failed: %v", err)
+	}
+}
+
+// TestClientCapabilityGating tests that Connect caches capabilities and
+// MoveAxis is refused on a telescope that cannot move its axes.
+func TestClientCapabilityGating(t *testing.T) {
+	sim := NewSimulator(coordinates.Observer{
+		Location: coordinates.Geographic{Latitude: 37.08, Longitude: -94.51},
+	})
+	sim.lacking = map[string]bool{"canmoveaxis": true}
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+
+	client := NewClient(config.TelescopeConfig{BaseURL: server.URL, MountType: "altaz", SlewRate: SimulatorSlewRate})
+	if client.Capabilities() != nil {
+		t.Error("Capabilities known before Connect")
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	caps := client.Capabilities()
+	if caps == nil {
+		t.Fatal("Capabilities not probed at connect time")
+	}
+	if caps.CanMoveAxis {
+		t.Error("CanMoveAxis = true, want false")
+	}
+	if caps.Description == "" {
+		t.Error("Description not probed")
+	}
+
+	if err := client.MoveAxis(0, 1); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("MoveAxis error = %v, want ErrNotSupported", err)
+	}
+	if err := client.StopAxes(); err != nil {
+		t.Errorf("StopAxes failed: %v", err)
+	}
+	if err := client.SlewToAltAz(45, 90); err != nil {
+		t.Errorf("SlewToAltAz failed: %v", err)
+	}
+}
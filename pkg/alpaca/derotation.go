@@ -0,0 +1,136 @@
+package alpaca
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// DerotationResult describes one derotation update.
+type DerotationResult struct {
+	// ParallacticAngle is the field angle at the pointing position in degrees
+	ParallacticAngle float64
+
+	// RateDegPerSec is the change of the parallactic angle since the last
+	// update in degrees per second (0 on the first update of a pass)
+	RateDegPerSec float64
+
+	// RotatorTarget is the rotator position that cancels the rotation
+	RotatorTarget float64
+
+	// Moved is true if the rotator was commanded
+	Moved bool
+}
+
+// DerotationController counter-rotates the camera during a pass so that the
+// field does not rotate in long exposures on alt-az mounts.
+//
+// The first update of a pass records the rotator position as the reference;
+// later updates move the rotator opposite to the change in parallactic angle
+// since then. Call Reset between passes.
+type DerotationController struct {
+	rotator  *RotatorClient
+	cfg      config.DerotationConfig
+	latitude float64
+
+	started    bool
+	reference  float64   // rotator position at the start of the pass
+	startAngle float64   // parallactic angle at the start of the pass
+	lastAngle  float64   // unwrapped parallactic angle at the last update
+	lastTime   time.Time // time of the last update
+	lastTarget float64   // last commanded rotator position
+}
+
+// NewDerotationController creates a controller for an observer at latitude
+// (degrees). rotator may be nil to compute angles without moving anything.
+func NewDerotationController(rotator *RotatorClient, cfg config.DerotationConfig, latitude float64) *DerotationController {
+	return &DerotationController{
+		rotator:  rotator,
+		cfg:      cfg,
+		latitude: latitude,
+	}
+}
+
+// Reset ends the current pass; the next update starts a new one.
+func (c *DerotationController) Reset() {
+	c.started = false
+}
+
+// Update computes the parallactic angle for the telescope's pointing position
+// and moves the rotator to cancel the rotation since the start of the pass.
+// Moves smaller than DeadbandDegrees are skipped.
+func (c *DerotationController) Update(pointing coordinates.HorizontalCoordinates, now time.Time) (DerotationResult, error) {
+	angle := coordinates.ParallacticAngle(pointing, c.latitude)
+
+	if !c.started {
+		reference := 0.0
+		if c.rotator != nil {
+			position, err := c.rotator.GetPosition()
+			if err != nil {
+				return DerotationResult{}, fmt.Errorf("failed to get rotator position: %w", err)
+			}
+			reference = position
+		}
+
+		c.started = true
+		c.reference = reference
+		c.startAngle = angle
+		c.lastAngle = angle
+		c.lastTime = now
+		c.lastTarget = reference
+
+		return DerotationResult{ParallacticAngle: angle, RotatorTarget: reference}, nil
+	}
+
+	// Unwrap so the angle is continuous across ±180°
+	unwrapped := c.lastAngle + angleDelta(angle, c.lastAngle)
+
+	result := DerotationResult{
+		ParallacticAngle: angle,
+		RotatorTarget:    DerotatorTarget(c.reference, unwrapped-c.startAngle, c.cfg.Reverse),
+	}
+	if dt := now.Sub(c.lastTime).Seconds(); dt > 0 {
+		result.RateDegPerSec = (unwrapped - c.lastAngle) / dt
+	}
+
+	c.lastAngle = unwrapped
+	c.lastTime = now
+
+	if math.Abs(angleDelta(result.RotatorTarget, c.lastTarget)) < c.cfg.DeadbandDegrees {
+		return result, nil
+	}
+
+	if c.rotator != nil {
+		if err := c.rotator.MoveAbsolute(result.RotatorTarget); err != nil {
+			return result, fmt.Errorf("failed to move rotator: %w", err)
+		}
+		result.Moved = true
+	}
+	c.lastTarget = result.RotatorTarget
+
+	return result, nil
+}
+
+// DerotatorTarget returns the rotator position (0-360°) that cancels a field
+// rotation of rotation degrees from the reference position. reverse flips
+// the direction for optical trains that mirror the image.
+func DerotatorTarget(reference, rotation float64, reverse bool) float64 {
+	if reverse {
+		rotation = -rotation
+	}
+	return coordinates.NormalizeAzimuth(reference - rotation)
+}
+
+// angleDelta returns the shortest signed difference a-b in degrees (-180 to 180).
+func angleDelta(a, b float64) float64 {
+	d := math.Mod(a-b, 360)
+	if d > 180 {
+		d -= 360
+	} else if d < -180 {
+		d += 360
+	}
+	return d
+}
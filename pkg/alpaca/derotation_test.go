@@ -0,0 +1,98 @@
+package alpaca
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestDerotatorTarget tests rotator targets for a given field rotation.
+func TestDerotatorTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference float64
+		rotation  float64
+		reverse   bool
+		want      float64
+	}{
+		{"No rotation", 90, 0, false, 90},
+		{"Counter-rotates", 90, 10, false, 80},
+		{"Reversed", 90, 10, true, 100},
+		{"Wraps below zero", 5, 10, false, 355},
+		{"Wraps above 360", 355, -10, false, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DerotatorTarget(tt.reference, tt.rotation, tt.reverse)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Expected %.1f°, got %.1f°", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDerotationController tests angle tracking over a pass without a rotator.
+func TestDerotationController(t *testing.T) {
+	const lat = 37.0
+	start := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	c := NewDerotationController(nil, config.DerotationConfig{DeadbandDegrees: 0.5}, lat)
+
+	first, err := c.Update(coordinates.HorizontalCoordinates{Altitude: 40, Azimuth: 150}, start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.RateDegPerSec != 0 || first.RotatorTarget != 0 {
+		t.Errorf("Expected first update to set the reference, got %+v", first)
+	}
+
+	t.Run("Follows parallactic angle", func(t *testing.T) {
+		pointing := coordinates.HorizontalCoordinates{Altitude: 45, Azimuth: 170}
+		got, err := c.Update(pointing, start.Add(10*time.Second))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		rotation := got.ParallacticAngle - first.ParallacticAngle
+		if want := coordinates.NormalizeAzimuth(-rotation); math.Abs(got.RotatorTarget-want) > 1e-9 {
+			t.Errorf("Expected target %.2f°, got %.2f°", want, got.RotatorTarget)
+		}
+		if want := rotation / 10; math.Abs(got.RateDegPerSec-want) > 1e-9 {
+			t.Errorf("Expected rate %.3f°/s, got %.3f°/s", want, got.RateDegPerSec)
+		}
+	})
+
+	t.Run("Reset starts a new pass", func(t *testing.T) {
+		c.Reset()
+		got, err := c.Update(coordinates.HorizontalCoordinates{Altitude: 20, Azimuth: 250}, start.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got.RateDegPerSec != 0 || got.RotatorTarget != 0 {
+			t.Errorf("Expected new reference after reset, got %+v", got)
+		}
+	})
+}
+
+// TestAngleDelta tests shortest signed angular differences.
+func TestAngleDelta(t *testing.T) {
+	tests := []struct {
+		a, b, want float64
+	}{
+		{10, 5, 5},
+		{5, 10, -5},
+		{179, -179, -2},
+		{-179, 179, 2},
+		{350, 10, -20},
+	}
+
+	for _, tt := range tests {
+		if got := angleDelta(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("angleDelta(%.0f, %.0f) = %.1f, want %.1f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
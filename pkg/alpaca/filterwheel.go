@@ -1,6 +1,7 @@
 package alpaca
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -33,9 +34,6 @@ type FilterWheelClient struct {
 	// config contains telescope configuration
 	config config.TelescopeConfig
 
-	// clientID is a unique identifier for this client instance
-	clientID int
-
 	// telescope is the parent telescope client (for HTTP access)
 	telescope *Client
 
@@ -50,7 +48,6 @@ type FilterWheelClient struct {
 func NewFilterWheelClient(telescopeClient *Client) *FilterWheelClient {
 	return &FilterWheelClient{
 		config:          telescopeClient.config,
-		clientID:        telescopeClient.clientID,
 		telescope:       telescopeClient,
 		connected:       false,
 		currentPosition: FilterUVIRCut, // Default to UV/IR Cut
@@ -62,8 +59,6 @@ func NewFilterWheelClient(telescopeClient *Client) *FilterWheelClient {
 func (fw *FilterWheelClient) Connect() error {
 	params := url.Values{}
 	params.Add("Connected", "true")
-	params.Add("ClientID", strconv.Itoa(fw.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(fw.getTransactionID()))
 
 	resp, err := fw.put("connected", params)
 	if err != nil {
@@ -90,8 +85,6 @@ func (fw *FilterWheelClient) Disconnect() error {
 
 	params := url.Values{}
 	params.Add("Connected", "false")
-	params.Add("ClientID", strconv.Itoa(fw.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(fw.getTransactionID()))
 
 	resp, err := fw.put("connected", params)
 	if err != nil {
@@ -144,8 +137,6 @@ func (fw *FilterWheelClient) SetPosition(position FilterPosition) error {
 
 	params := url.Values{}
 	params.Add("Position", strconv.Itoa(int(position)))
-	params.Add("ClientID", strconv.Itoa(fw.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(fw.getTransactionID()))
 
 	resp, err := fw.put("position", params)
 	if err != nil {
@@ -229,58 +220,12 @@ func (fw *FilterWheelClient) IsSafeForSolarProximity() bool {
 	return fw.currentPosition != FilterSolar
 }
 
-// getTransactionID generates a unique transaction ID for each API call.
-func (fw *FilterWheelClient) getTransactionID() int {
-	return int(time.Now().UnixNano() / 1000000)
-}
-
 // get performs an HTTP GET request to a filter wheel endpoint.
 func (fw *FilterWheelClient) get(endpoint string) (*alpacaResponse, error) {
-	// Build URL using filter wheel device number (typically 0)
-	apiURL := fmt.Sprintf("%s/api/v1/filterwheel/%d/%s",
-		fw.config.BaseURL, fw.config.FilterWheelDeviceNumber, endpoint)
-
-	// Add query parameters
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(fw.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(fw.getTransactionID()))
-
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	// Use telescope's HTTP client
-	resp, err := fw.telescope.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
-
-	return &alpacaResp, nil
+	return fw.telescope.transport.get(context.Background(), "filterwheel", fw.config.FilterWheelDeviceNumber, endpoint, nil)
 }
 
 // put performs an HTTP PUT request to a filter wheel endpoint.
 func (fw *FilterWheelClient) put(endpoint string, params url.Values) (*alpacaResponse, error) {
-	// Build URL using filter wheel device number (typically 0)
-	apiURL := fmt.Sprintf("%s/api/v1/filterwheel/%d/%s",
-		fw.config.BaseURL, fw.config.FilterWheelDeviceNumber, endpoint)
-
-	// Make request with form data using telescope's HTTP client
-	resp, err := fw.telescope.httpClient.PostForm(apiURL, params)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
-
-	return &alpacaResp, nil
+	return fw.telescope.transport.put(context.Background(), "filterwheel", fw.config.FilterWheelDeviceNumber, endpoint, params)
 }
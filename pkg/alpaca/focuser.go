@@ -1,6 +1,7 @@
 package alpaca
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -16,9 +17,6 @@ type FocuserClient struct {
 	// config contains telescope configuration (includes focuser settings)
 	config config.TelescopeConfig
 
-	// clientID is a unique identifier for this client instance
-	clientID int
-
 	// httpClient is the HTTP client used for API requests (shared with telescope)
 	telescope *Client
 
@@ -30,7 +28,6 @@ type FocuserClient struct {
 func NewFocuserClient(telescopeClient *Client) *FocuserClient {
 	return &FocuserClient{
 		config:    telescopeClient.config,
-		clientID:  telescopeClient.clientID,
 		telescope: telescopeClient,
 		connected: false,
 	}
@@ -41,8 +38,6 @@ func NewFocuserClient(telescopeClient *Client) *FocuserClient {
 func (f *FocuserClient) Connect() error {
 	params := url.Values{}
 	params.Add("Connected", "true")
-	params.Add("ClientID", strconv.Itoa(f.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(f.getTransactionID()))
 
 	resp, err := f.put("connected", params)
 	if err != nil {
@@ -62,8 +57,6 @@ func (f *FocuserClient) Disconnect() error {
 
 	params := url.Values{}
 	params.Add("Connected", "false")
-	params.Add("ClientID", strconv.Itoa(f.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(f.getTransactionID()))
 
 	resp, err := f.put("connected", params)
 	if err != nil {
@@ -129,8 +122,6 @@ func (f *FocuserClient) Move(position int) error {
 
 	params := url.Values{}
 	params.Add("Position", strconv.Itoa(position))
-	params.Add("ClientID", strconv.Itoa(f.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(f.getTransactionID()))
 
 	resp, err := f.put("move", params)
 	if err != nil {
@@ -172,11 +163,7 @@ func (f *FocuserClient) Halt() error {
 		return fmt.Errorf("focuser not connected")
 	}
 
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(f.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(f.getTransactionID()))
-
-	resp, err := f.put("halt", params)
+	resp, err := f.put("halt", nil)
 	if err != nil {
 		return fmt.Errorf("failed to halt focuser: %w", err)
 	}
@@ -244,58 +231,12 @@ func (f *FocuserClient) MoveToInfinity() error {
 	}
 }
 
-// getTransactionID generates a unique transaction ID for each API call.
-func (f *FocuserClient) getTransactionID() int {
-	return int(time.Now().UnixNano() / 1000000)
-}
-
 // get performs an HTTP GET request to a focuser endpoint.
 func (f *FocuserClient) get(endpoint string) (*alpacaResponse, error) {
-	// Build URL using focuser device number
-	apiURL := fmt.Sprintf("%s/api/v1/focuser/%d/%s",
-		f.config.BaseURL, f.config.FocuserDeviceNumber, endpoint)
-
-	// Add query parameters
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(f.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(f.getTransactionID()))
-
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	// Use telescope's HTTP client
-	resp, err := f.telescope.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
-
-	return &alpacaResp, nil
+	return f.telescope.transport.get(context.Background(), "focuser", f.config.FocuserDeviceNumber, endpoint, nil)
 }
 
 // put performs an HTTP PUT request to a focuser endpoint.
 func (f *FocuserClient) put(endpoint string, params url.Values) (*alpacaResponse, error) {
-	// Build URL using focuser device number
-	apiURL := fmt.Sprintf("%s/api/v1/focuser/%d/%s",
-		f.config.BaseURL, f.config.FocuserDeviceNumber, endpoint)
-
-	// Make request with form data using telescope's HTTP client
-	resp, err := f.telescope.httpClient.PostForm(apiURL, params)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response (reuse telescope client's parsing logic)
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
-
-	return &alpacaResp, nil
+	return f.telescope.transport.put(context.Background(), "focuser", f.config.FocuserDeviceNumber, endpoint, params)
 }
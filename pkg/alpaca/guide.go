@@ -0,0 +1,184 @@
+package alpaca
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GuideDirection is an ASCOM guide direction for PulseGuide.
+type GuideDirection int
+
+const (
+	// GuideNorth moves north (up in altitude on alt-az mounts)
+	GuideNorth GuideDirection = 0
+
+	// GuideSouth moves south (down in altitude on alt-az mounts)
+	GuideSouth GuideDirection = 1
+
+	// GuideEast moves east (decreasing azimuth on alt-az mounts)
+	GuideEast GuideDirection = 2
+
+	// GuideWest moves west (increasing azimuth on alt-az mounts)
+	GuideWest GuideDirection = 3
+)
+
+// MaxPulseDuration is the longest single guide pulse accepted for a nudge.
+const MaxPulseDuration = 5 * time.Second
+
+// Guider is a telescope that can be pulse guided. Both Client and
+// TelescopeClient implement it.
+type Guider interface {
+	PulseGuide(direction GuideDirection, duration time.Duration) error
+	GetGuideRates() (raRate, decRate float64, err error)
+}
+
+var (
+	_ Guider = (*Client)(nil)
+	_ Guider = (*TelescopeClient)(nil)
+)
+
+// String returns the lowercase direction name.
+func (d GuideDirection) String() string {
+	switch d {
+	case GuideNorth:
+		return "north"
+	case GuideSouth:
+		return "south"
+	case GuideEast:
+		return "east"
+	case GuideWest:
+		return "west"
+	default:
+		return fmt.Sprintf("GuideDirection(%d)", int(d))
+	}
+}
+
+// ParseGuideDirection parses a direction name ("north", "s", "up", "left", ...).
+// Up/down/left/right follow the usual sky orientation (north up, east left).
+func ParseGuideDirection(s string) (GuideDirection, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "north", "n", "up":
+		return GuideNorth, nil
+	case "south", "s", "down":
+		return GuideSouth, nil
+	case "east", "e", "left":
+		return GuideEast, nil
+	case "west", "w", "right":
+		return GuideWest, nil
+	default:
+		return 0, fmt.Errorf("invalid guide direction %q", s)
+	}
+}
+
+// AltAzOffset returns the altitude and azimuth change for a move of degrees
+// in this direction on an alt-az mount.
+func (d GuideDirection) AltAzOffset(degrees float64) (altitude, azimuth float64) {
+	switch d {
+	case GuideNorth:
+		return degrees, 0
+	case GuideSouth:
+		return -degrees, 0
+	case GuideEast:
+		return 0, -degrees
+	case GuideWest:
+		return 0, degrees
+	default:
+		return 0, 0
+	}
+}
+
+// validatePulse checks a guide pulse before it is sent.
+func validatePulse(direction GuideDirection, duration time.Duration) error {
+	if direction < GuideNorth || direction > GuideWest {
+		return fmt.Errorf("invalid guide direction %d", int(direction))
+	}
+	if duration <= 0 || duration > MaxPulseDuration {
+		return fmt.Errorf("pulse duration %v out of range (0-%v]", duration, MaxPulseDuration)
+	}
+	return nil
+}
+
+// PulseGuide moves the telescope at the guide rate in direction for duration.
+// The call returns immediately; the mount applies the pulse on top of any
+// tracking motion.
+// Implements: PUT /api/v1/telescope/{device_number}/pulseguide
+func (c *Client) PulseGuide(direction GuideDirection, duration time.Duration) error {
+	if !c.connected.Load() {
+		return fmt.Errorf("telescope not connected")
+	}
+	if err := validatePulse(direction, duration); err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Add("Direction", strconv.Itoa(int(direction)))
+	params.Add("Duration", strconv.FormatInt(duration.Milliseconds(), 10))
+
+	resp, err := c.put("pulseguide", params)
+	if err != nil {
+		return fmt.Errorf("failed to pulse guide: %w", err)
+	}
+
+	return resp.Error()
+}
+
+// GetGuideRates returns the guide rates in degrees per second for the
+// right ascension (azimuth) and declination (altitude) axes.
+// Implements: GET /api/v1/telescope/{device_number}/guideraterightascension
+// and GET /api/v1/telescope/{device_number}/guideratedeclination
+func (c *Client) GetGuideRates() (raRate, decRate float64, err error) {
+	if !c.connected.Load() {
+		return 0, 0, fmt.Errorf("telescope not connected")
+	}
+
+	rates := make([]float64, 2)
+	for i, endpoint := range []string{"guideraterightascension", "guideratedeclination"} {
+		resp, err := c.get(endpoint)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get %s: %w", endpoint, err)
+		}
+		if err := resp.Error(); err != nil {
+			return 0, 0, err
+		}
+		rate, ok := resp.Value.(float64)
+		if !ok {
+			return 0, 0, fmt.Errorf("unexpected response type for %s", endpoint)
+		}
+		rates[i] = rate
+	}
+
+	return rates[0], rates[1], nil
+}
+
+// PulseGuide moves the telescope at the guide rate in direction for duration.
+// Implements: PUT /api/v1/telescope/{device_number}/pulseguide
+func (c *TelescopeClient) PulseGuide(direction GuideDirection, duration time.Duration) error {
+	if err := validatePulse(direction, duration); err != nil {
+		return err
+	}
+
+	params := map[string]string{
+		"Direction": strconv.Itoa(int(direction)),
+		"Duration":  strconv.FormatInt(duration.Milliseconds(), 10),
+	}
+
+	_, err := c.put("pulseguide", params)
+	return err
+}
+
+// GetGuideRates returns the guide rates in degrees per second for the
+// right ascension (azimuth) and declination (altitude) axes.
+// Implements: GET /api/v1/telescope/{device_number}/guideraterightascension
+// and GET /api/v1/telescope/{device_number}/guideratedeclination
+func (c *TelescopeClient) GetGuideRates() (raRate, decRate float64, err error) {
+	if raRate, err = c.getFloat64("guideraterightascension"); err != nil {
+		return 0, 0, err
+	}
+	if decRate, err = c.getFloat64("guideratedeclination"); err != nil {
+		return 0, 0, err
+	}
+	return raRate, decRate, nil
+}
@@ -0,0 +1,59 @@
+package alpaca
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseGuideDirection tests parsing of direction names and aliases.
+func TestParseGuideDirection(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    GuideDirection
+		wantErr bool
+	}{
+		{"north", GuideNorth, false},
+		{"S", GuideSouth, false},
+		{"left", GuideEast, false},
+		{" West ", GuideWest, false},
+		{"up", GuideNorth, false},
+		{"sideways", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGuideDirection(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestValidatePulse tests guide pulse limits.
+func TestValidatePulse(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction GuideDirection
+		duration  time.Duration
+		wantErr   bool
+	}{
+		{"Valid", GuideWest, 500 * time.Millisecond, false},
+		{"Maximum", GuideNorth, MaxPulseDuration, false},
+		{"Zero duration", GuideNorth, 0, true},
+		{"Too long", GuideSouth, MaxPulseDuration + time.Millisecond, true},
+		{"Invalid direction", GuideDirection(7), time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePulse(tt.direction, tt.duration); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
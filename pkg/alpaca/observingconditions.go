@@ -0,0 +1,263 @@
+package alpaca
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// ObservingConditionsClient represents an ASCOM Alpaca ObservingConditions client.
+// Used to read ambient weather (temperature, humidity, wind, sky brightness)
+// from a weather station.
+// Reference: https://ascom-standards.org/Developer/Alpaca.htm
+type ObservingConditionsClient struct {
+	// config contains telescope configuration (includes device number)
+	config config.TelescopeConfig
+
+	// telescope is the parent telescope client (for HTTP access)
+	telescope *Client
+
+	// connected tracks if we're currently connected to the device
+	connected bool
+}
+
+// NewObservingConditionsClient creates a new Alpaca ObservingConditions client from telescope client.
+func NewObservingConditionsClient(telescopeClient *Client) *ObservingConditionsClient {
+	return &ObservingConditionsClient{
+		config:    telescopeClient.config,
+		telescope: telescopeClient,
+		connected: false,
+	}
+}
+
+// Connect establishes a connection to the weather station.
+// Implements: PUT /api/v1/observingconditions/{device_number}/connected
+func (o *ObservingConditionsClient) Connect() error {
+	params := url.Values{}
+	params.Add("Connected", "true")
+
+	resp, err := o.put("connected", params)
+	if err != nil {
+		return fmt.Errorf("failed to connect to observing conditions: %w", err)
+	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
+
+	o.connected = true
+	return nil
+}
+
+// Disconnect closes the connection to the weather station.
+// Implements: PUT /api/v1/observingconditions/{device_number}/connected
+func (o *ObservingConditionsClient) Disconnect() error {
+	if !o.connected {
+		return nil
+	}
+
+	params := url.Values{}
+	params.Add("Connected", "false")
+
+	resp, err := o.put("connected", params)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect from observing conditions: %w", err)
+	}
+
+	o.connected = false
+	return resp.Error()
+}
+
+// GetTemperature returns the ambient temperature in °C.
+// Implements: GET /api/v1/observingconditions/{device_number}/temperature
+func (o *ObservingConditionsClient) GetTemperature() (float64, error) {
+	return o.getFloat("temperature")
+}
+
+// GetDewPoint returns the dew point in °C.
+// Implements: GET /api/v1/observingconditions/{device_number}/dewpoint
+func (o *ObservingConditionsClient) GetDewPoint() (float64, error) {
+	return o.getFloat("dewpoint")
+}
+
+// GetHumidity returns the relative humidity in percent (0-100).
+// Implements: GET /api/v1/observingconditions/{device_number}/humidity
+func (o *ObservingConditionsClient) GetHumidity() (float64, error) {
+	return o.getFloat("humidity")
+}
+
+// GetPressure returns the atmospheric pressure in hPa.
+// Implements: GET /api/v1/observingconditions/{device_number}/pressure
+func (o *ObservingConditionsClient) GetPressure() (float64, error) {
+	return o.getFloat("pressure")
+}
+
+// GetWindSpeed returns the sustained wind speed in m/s.
+// Implements: GET /api/v1/observingconditions/{device_number}/windspeed
+func (o *ObservingConditionsClient) GetWindSpeed() (float64, error) {
+	return o.getFloat("windspeed")
+}
+
+// GetWindGust returns the peak wind gust over the last two minutes in m/s.
+// Implements: GET /api/v1/observingconditions/{device_number}/windgust
+func (o *ObservingConditionsClient) GetWindGust() (float64, error) {
+	return o.getFloat("windgust")
+}
+
+// GetWindDirection returns the direction the wind blows from in degrees (0 = north).
+// Implements: GET /api/v1/observingconditions/{device_number}/winddirection
+func (o *ObservingConditionsClient) GetWindDirection() (float64, error) {
+	return o.getFloat("winddirection")
+}
+
+// GetSkyBrightness returns the sky brightness in lux.
+// Implements: GET /api/v1/observingconditions/{device_number}/skybrightness
+func (o *ObservingConditionsClient) GetSkyBrightness() (float64, error) {
+	return o.getFloat("skybrightness")
+}
+
+// GetSkyQuality returns the sky quality in magnitudes per square arc-second.
+// Implements: GET /api/v1/observingconditions/{device_number}/skyquality
+func (o *ObservingConditionsClient) GetSkyQuality() (float64, error) {
+	return o.getFloat("skyquality")
+}
+
+// GetCloudCover returns the cloud cover in percent (0-100).
+// Implements: GET /api/v1/observingconditions/{device_number}/cloudcover
+func (o *ObservingConditionsClient) GetCloudCover() (float64, error) {
+	return o.getFloat("cloudcover")
+}
+
+// Conditions is a snapshot of all sensors of a weather station.
+// Sensors the station does not implement are nil.
+type Conditions struct {
+	TemperatureC     *float64  `json:"temperatureC"`
+	HumidityPercent  *float64  `json:"humidity"`
+	DewPointC        *float64  `json:"dewPointC"`
+	PressureHPa      *float64  `json:"pressureHPa"`
+	WindSpeedMS      *float64  `json:"windSpeedMs"`
+	WindGustMS       *float64  `json:"windGustMs"`
+	WindDirection    *float64  `json:"windDirection"`
+	SkyBrightnessLux *float64  `json:"skyBrightnessLux"`
+	SkyQuality       *float64  `json:"skyQuality"` // mag/arcsec²
+	CloudCover       *float64  `json:"cloudCover"`
+	ReadAt           time.Time `json:"readAt"`
+}
+
+// MaxWindMS returns the strongest reported wind (sustained or gust) in m/s,
+// and false if the station has no wind sensor.
+func (c Conditions) MaxWindMS() (float64, bool) {
+	switch {
+	case c.WindSpeedMS != nil && c.WindGustMS != nil:
+		return math.Max(*c.WindSpeedMS, *c.WindGustMS), true
+	case c.WindSpeedMS != nil:
+		return *c.WindSpeedMS, true
+	case c.WindGustMS != nil:
+		return *c.WindGustMS, true
+	default:
+		return 0, false
+	}
+}
+
+// WindExceeds reports whether the wind is above limitMS (0 = no limit).
+func (c Conditions) WindExceeds(limitMS float64) bool {
+	wind, ok := c.MaxWindMS()
+	return ok && limitMS > 0 && wind > limitMS
+}
+
+// GetConditions reads every sensor of the station.
+// Sensors that fail (typically NotImplemented) are left nil; an error is
+// returned only if no sensor could be read.
+func (o *ObservingConditionsClient) GetConditions() (Conditions, error) {
+	c := Conditions{ReadAt: time.Now().UTC()}
+
+	sensors := []struct {
+		read func() (float64, error)
+		dst  **float64
+	}{
+		{o.GetTemperature, &c.TemperatureC},
+		{o.GetHumidity, &c.HumidityPercent},
+		{o.GetDewPoint, &c.DewPointC},
+		{o.GetPressure, &c.PressureHPa},
+		{o.GetWindSpeed, &c.WindSpeedMS},
+		{o.GetWindGust, &c.WindGustMS},
+		{o.GetWindDirection, &c.WindDirection},
+		{o.GetSkyBrightness, &c.SkyBrightnessLux},
+		{o.GetSkyQuality, &c.SkyQuality},
+		{o.GetCloudCover, &c.CloudCover},
+	}
+
+	var firstErr error
+	read := 0
+	for _, sensor := range sensors {
+		value, err := sensor.read()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		*sensor.dst = &value
+		read++
+	}
+
+	if read == 0 {
+		return c, fmt.Errorf("failed to read observing conditions: %w", firstErr)
+	}
+
+	return c, nil
+}
+
+// GetAmbient reads temperature and, if the station reports it, dew point.
+func (o *ObservingConditionsClient) GetAmbient() (Ambient, error) {
+	temp, err := o.GetTemperature()
+	if err != nil {
+		return Ambient{}, err
+	}
+
+	amb := Ambient{TemperatureC: temp}
+
+	// Not every station has a humidity sensor
+	if dewPoint, err := o.GetDewPoint(); err == nil {
+		amb.DewPointC = dewPoint
+		amb.HasDewPoint = true
+	}
+
+	return amb, nil
+}
+
+// getFloat reads a numeric property.
+func (o *ObservingConditionsClient) getFloat(endpoint string) (float64, error) {
+	if !o.connected {
+		return 0, fmt.Errorf("observing conditions not connected")
+	}
+
+	resp, err := o.get(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s: %w", endpoint, err)
+	}
+
+	if err := resp.Error(); err != nil {
+		return 0, err
+	}
+
+	value, ok := resp.Value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response type for %s", endpoint)
+	}
+
+	return value, nil
+}
+
+// get performs an HTTP GET request to a observingconditions endpoint.
+func (o *ObservingConditionsClient) get(endpoint string) (*alpacaResponse, error) {
+	return o.telescope.transport.get(context.Background(), "observingconditions", o.config.ObservingConditionsDeviceNumber, endpoint, nil)
+}
+
+// put performs an HTTP PUT request to a observingconditions endpoint.
+func (o *ObservingConditionsClient) put(endpoint string, params url.Values) (*alpacaResponse, error) {
+	return o.telescope.transport.put(context.Background(), "observingconditions", o.config.ObservingConditionsDeviceNumber, endpoint, params)
+}
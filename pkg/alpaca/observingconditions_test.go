@@ -0,0 +1,65 @@
+package alpaca
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// TestConditionsWindExceeds tests the wind safety check against sustained wind and gusts.
+func TestConditionsWindExceeds(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		conditions Conditions
+		limit      float64
+		want       bool
+	}{
+		{"No wind sensor", Conditions{}, 10, false},
+		{"Calm", Conditions{WindSpeedMS: f(3)}, 10, false},
+		{"Sustained above limit", Conditions{WindSpeedMS: f(12)}, 10, true},
+		{"Gust above limit", Conditions{WindSpeedMS: f(6), WindGustMS: f(14)}, 10, true},
+		{"Gust sensor only", Conditions{WindGustMS: f(11)}, 10, true},
+		{"No limit", Conditions{WindSpeedMS: f(30)}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conditions.WindExceeds(tt.limit); got != tt.want {
+				t.Errorf("WindExceeds(%.0f) = %v, want %v", tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConnectRefused tests that a device refusing the connection is not
+// marked connected.
+func TestConnectRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(AlpacaResponse{ErrorNumber: AlpacaNotConnected, ErrorMessage: "not connected"})
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(config.TelescopeConfig{BaseURL: server.URL})
+
+	weather := NewObservingConditionsClient(client)
+	var alpacaErr *AlpacaError
+	if err := weather.Connect(); !errors.As(err, &alpacaErr) {
+		t.Errorf("Weather station Connect() = %v, want the device error", err)
+	}
+	if weather.connected {
+		t.Error("Expected the refused weather station to stay disconnected")
+	}
+
+	rotator := NewRotatorClient(client)
+	if err := rotator.Connect(); !errors.As(err, &alpacaErr) {
+		t.Errorf("Rotator Connect() = %v, want the device error", err)
+	}
+	if rotator.connected {
+		t.Error("Expected the refused rotator to stay disconnected")
+	}
+}
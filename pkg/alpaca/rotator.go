@@ -0,0 +1,161 @@
+package alpaca
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// RotatorClient represents an ASCOM Alpaca rotator client.
+// Used to counter field rotation on alt-az mounts during long exposures.
+// Reference: https://ascom-standards.org/Developer/Alpaca.htm
+type RotatorClient struct {
+	// config contains telescope configuration (includes device number)
+	config config.TelescopeConfig
+
+	// telescope is the parent telescope client (for HTTP access)
+	telescope *Client
+
+	// connected tracks if we're currently connected to the rotator
+	connected bool
+}
+
+// NewRotatorClient creates a new Alpaca rotator client from telescope client.
+func NewRotatorClient(telescopeClient *Client) *RotatorClient {
+	return &RotatorClient{
+		config:    telescopeClient.config,
+		telescope: telescopeClient,
+		connected: false,
+	}
+}
+
+// Connect establishes a connection to the rotator.
+// Implements: PUT /api/v1/rotator/{device_number}/connected
+func (r *RotatorClient) Connect() error {
+	params := url.Values{}
+	params.Add("Connected", "true")
+
+	resp, err := r.put("connected", params)
+	if err != nil {
+		return fmt.Errorf("failed to connect to rotator: %w", err)
+	}
+	if err := resp.Error(); err != nil {
+		return err
+	}
+
+	r.connected = true
+	return nil
+}
+
+// Disconnect closes the connection to the rotator.
+// Implements: PUT /api/v1/rotator/{device_number}/connected
+func (r *RotatorClient) Disconnect() error {
+	if !r.connected {
+		return nil
+	}
+
+	params := url.Values{}
+	params.Add("Connected", "false")
+
+	resp, err := r.put("connected", params)
+	if err != nil {
+		return fmt.Errorf("failed to disconnect from rotator: %w", err)
+	}
+
+	r.connected = false
+	return resp.Error()
+}
+
+// GetPosition returns the current rotator position in degrees (0-360).
+// Implements: GET /api/v1/rotator/{device_number}/position
+func (r *RotatorClient) GetPosition() (float64, error) {
+	if !r.connected {
+		return 0, fmt.Errorf("rotator not connected")
+	}
+
+	resp, err := r.get("position")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rotator position: %w", err)
+	}
+
+	if err := resp.Error(); err != nil {
+		return 0, err
+	}
+
+	position, ok := resp.Value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response type for rotator position")
+	}
+
+	return position, nil
+}
+
+// MoveAbsolute starts a move to an absolute position in degrees (0-360).
+// Implements: PUT /api/v1/rotator/{device_number}/moveabsolute
+func (r *RotatorClient) MoveAbsolute(position float64) error {
+	if !r.connected {
+		return fmt.Errorf("rotator not connected")
+	}
+
+	params := url.Values{}
+	params.Add("Position", strconv.FormatFloat(position, 'f', 3, 64))
+
+	resp, err := r.put("moveabsolute", params)
+	if err != nil {
+		return fmt.Errorf("failed to move rotator: %w", err)
+	}
+
+	return resp.Error()
+}
+
+// IsMoving returns true if the rotator is currently moving.
+// Implements: GET /api/v1/rotator/{device_number}/ismoving
+func (r *RotatorClient) IsMoving() (bool, error) {
+	if !r.connected {
+		return false, fmt.Errorf("rotator not connected")
+	}
+
+	resp, err := r.get("ismoving")
+	if err != nil {
+		return false, fmt.Errorf("failed to get rotator moving status: %w", err)
+	}
+
+	if err := resp.Error(); err != nil {
+		return false, err
+	}
+
+	moving, ok := resp.Value.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected response type for rotator moving status")
+	}
+
+	return moving, nil
+}
+
+// Halt immediately stops rotator movement.
+// Implements: PUT /api/v1/rotator/{device_number}/halt
+func (r *RotatorClient) Halt() error {
+	if !r.connected {
+		return fmt.Errorf("rotator not connected")
+	}
+
+	resp, err := r.put("halt", nil)
+	if err != nil {
+		return fmt.Errorf("failed to halt rotator: %w", err)
+	}
+
+	return resp.Error()
+}
+
+// get performs an HTTP GET request to a rotator endpoint.
+func (r *RotatorClient) get(endpoint string) (*alpacaResponse, error) {
+	return r.telescope.transport.get(context.Background(), "rotator", r.config.RotatorDeviceNumber, endpoint, nil)
+}
+
+// put performs an HTTP PUT request to a rotator endpoint.
+func (r *RotatorClient) put(endpoint string, params url.Values) (*alpacaResponse, error) {
+	return r.telescope.transport.put(context.Background(), "rotator", r.config.RotatorDeviceNumber, endpoint, params)
+}
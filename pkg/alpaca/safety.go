@@ -0,0 +1,458 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+)
+
+const (
+	// DefaultGuardInterval is how often SafeTelescope.Run checks the
+	// position of a mount whose axes are moving
+	DefaultGuardInterval = 250 * time.Millisecond
+
+	// waypointTimeout bounds the wait for a slew to reach a sun-avoidance
+	// waypoint before continuing to the target
+	waypointTimeout = 2 * time.Minute
+)
+
+// ErrOutsideLimits is matched (via errors.Is) by a *LimitError.
+var ErrOutsideLimits = errors.New("outside mount safety limits")
+
+// TelescopeDriver is the part of a telescope client that moves the mount.
+// Both Client and TelescopeClient implement it, and so does SafeTelescope,
+// so the safety envelope can wrap either client.
+type TelescopeDriver interface {
+	SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error
+	MoveAxisContext(ctx context.Context, axis int, rate float64) error
+	GetAltitude() (float64, error)
+	GetAzimuth() (float64, error)
+	IsSlewing() (bool, error)
+}
+
+var (
+	_ TelescopeDriver = (*Client)(nil)
+	_ TelescopeDriver = (*TelescopeClient)(nil)
+	_ TelescopeDriver = (*SafeTelescope)(nil)
+)
+
+// LimitError is returned when a slew target is beyond a hard limit.
+type LimitError struct {
+	Axis     string // "altitude" or "azimuth"
+	Value    float64
+	Min, Max float64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("target %s %.1f° is outside the mount limits (%.1f-%.1f°)", e.Axis, e.Value, e.Min, e.Max)
+}
+
+// Is reports whether target is ErrOutsideLimits.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrOutsideLimits
+}
+
+// SafetyEnvelope is the region of sky the mount may point at and how fast
+// its axes may be driven.
+type SafetyEnvelope struct {
+	// MinAltitude and MaxAltitude are the hard altitude limits
+	MinAltitude, MaxAltitude float64
+
+	// SoftMargin is how far inside the hard limits the soft limits lie
+	SoftMargin float64
+
+	// MinAzimuth and MaxAzimuth are the hard azimuth limits, running
+	// clockwise from MinAzimuth; only enforced if AzimuthLimited
+	AzimuthLimited         bool
+	MinAzimuth, MaxAzimuth float64
+
+	// MaxRate caps axis rates in degrees per second (0 = no cap)
+	MaxRate float64
+}
+
+// NewSafetyEnvelope builds the envelope for a telescope: the altitude limits
+// from GetAltitudeLimits, the configured soft margin and azimuth range, and
+// the configured slew rate as the axis rate cap.
+func NewSafetyEnvelope(cfg config.TelescopeConfig) SafetyEnvelope {
+	minAlt, maxAlt := cfg.GetAltitudeLimits()
+	return SafetyEnvelope{
+		MinAltitude:    minAlt,
+		MaxAltitude:    maxAlt,
+		SoftMargin:     math.Max(cfg.SoftLimitMargin, 0),
+		AzimuthLimited: normalizeAzimuth(cfg.MinAzimuth) != normalizeAzimuth(cfg.MaxAzimuth),
+		MinAzimuth:     normalizeAzimuth(cfg.MinAzimuth),
+		MaxAzimuth:     normalizeAzimuth(cfg.MaxAzimuth),
+		MaxRate:        cfg.SlewRate,
+	}
+}
+
+// Check returns the position to slew to for a target: the target itself,
+// or the nearest soft limit if it lies between a soft and hard limit.
+// Targets beyond a hard limit are refused with a *LimitError.
+func (e SafetyEnvelope) Check(altitude, azimuth float64) (float64, float64, error) {
+	if altitude < e.MinAltitude || altitude > e.MaxAltitude {
+		return 0, 0, &LimitError{Axis: "altitude", Value: altitude, Min: e.MinAltitude, Max: e.MaxAltitude}
+	}
+	softMin, softMax := e.softAltitude()
+	altitude = math.Min(math.Max(altitude, softMin), softMax)
+
+	azimuth = normalizeAzimuth(azimuth)
+	if !e.AzimuthLimited {
+		return altitude, azimuth, nil
+	}
+
+	offset, span := e.azimuthOffset(azimuth)
+	if offset > span {
+		return 0, 0, &LimitError{Axis: "azimuth", Value: azimuth, Min: e.MinAzimuth, Max: e.MaxAzimuth}
+	}
+	margin := math.Min(e.SoftMargin, span/2)
+	offset = math.Min(math.Max(offset, margin), span-margin)
+	return altitude, normalizeAzimuth(e.MinAzimuth + offset), nil
+}
+
+// ClampRate limits an axis rate (0 = azimuth, 1 = altitude) to MaxRate, and
+// stops the axis if the mount, at altitude/azimuth, has reached a soft limit
+// and the rate would drive it further out.
+func (e SafetyEnvelope) ClampRate(axis int, rate, altitude, azimuth float64) float64 {
+	if e.MaxRate > 0 {
+		rate = math.Min(math.Max(rate, -e.MaxRate), e.MaxRate)
+	}
+
+	switch axis {
+	case 1:
+		softMin, softMax := e.softAltitude()
+		if (rate > 0 && altitude >= softMax) || (rate < 0 && altitude <= softMin) {
+			return 0
+		}
+	case 0:
+		if !e.AzimuthLimited {
+			break
+		}
+		offset, span := e.azimuthOffset(normalizeAzimuth(azimuth))
+		margin := math.Min(e.SoftMargin, span/2)
+		// Outside the range, the nearer edge is the one being approached
+		beyondMax := offset > span-margin && offset-span < (360-span)/2
+		beforeMin := offset < margin || (offset > span && !beyondMax)
+		if (rate > 0 && beyondMax) || (rate < 0 && beforeMin) {
+			return 0
+		}
+	}
+	return rate
+}
+
+// softAltitude returns the soft altitude limits.
+func (e SafetyEnvelope) softAltitude() (float64, float64) {
+	margin := math.Min(e.SoftMargin, (e.MaxAltitude-e.MinAltitude)/2)
+	return e.MinAltitude + margin, e.MaxAltitude - margin
+}
+
+// azimuthOffset returns how far azimuth is clockwise of MinAzimuth, and the
+// width of the allowed range.
+func (e SafetyEnvelope) azimuthOffset(azimuth float64) (float64, float64) {
+	return normalizeAzimuth(azimuth - e.MinAzimuth), normalizeAzimuth(e.MaxAzimuth - e.MinAzimuth)
+}
+
+// normalizeAzimuth wraps an azimuth into [0, 360).
+func normalizeAzimuth(azimuth float64) float64 {
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	return azimuth
+}
+
+// SafeTelescope enforces a SafetyEnvelope on every slew and axis move sent
+// to a telescope, so the web server, TUI and autotracker all get the same
+// protection. Axis rates are checked when commanded; callers that drive
+// axes should also call Run so a moving axis is stopped at the soft limits.
+// Once SetSolarGuard is called, slew targets are also checked against the
+// sun and slews are routed around it.
+type SafeTelescope struct {
+	driver   TelescopeDriver
+	envelope SafetyEnvelope
+
+	// solar checks targets and paths against the sun; nil disables it
+	solar *safety.SolarGuard
+
+	// rates are the last commanded axis rates (azimuth, altitude)
+	mu    sync.Mutex
+	rates [2]float64
+}
+
+// NewSafeTelescope wraps driver with envelope.
+func NewSafeTelescope(driver TelescopeDriver, envelope SafetyEnvelope) *SafeTelescope {
+	return &SafeTelescope{driver: driver, envelope: envelope}
+}
+
+// SetSolarGuard refuses slews to targets the guard rejects and routes slews
+// around the sun's exclusion zone (see coordinates.PlanSunSafeSlew). The
+// guard must be for the telescope's location. Call it before slewing.
+func (t *SafeTelescope) SetSolarGuard(guard *safety.SolarGuard) {
+	t.solar = guard
+}
+
+// Check returns the position a slew to a target would be sent to (see
+// SafetyEnvelope.Check), or an error if the target is outside the mount
+// limits or too close to the sun. Use it to vet targets slewed to by other
+// means, such as equatorial coordinates.
+func (t *SafeTelescope) Check(altitude, azimuth float64) (float64, float64, error) {
+	altitude, azimuth, err := t.envelope.Check(altitude, azimuth)
+	if err != nil {
+		return 0, 0, err
+	}
+	if t.solar != nil {
+		if _, err := t.solar.Check(coordinates.HorizontalCoordinates{Altitude: altitude, Azimuth: azimuth}); err != nil {
+			return 0, 0, err
+		}
+	}
+	return altitude, azimuth, nil
+}
+
+// Envelope returns the enforced safety envelope.
+func (t *SafeTelescope) Envelope() SafetyEnvelope {
+	return t.envelope
+}
+
+// SlewToAltAz slews to a target within the envelope (see SafetyEnvelope.Check).
+func (t *SafeTelescope) SlewToAltAz(altitude, azimuth float64) error {
+	return t.SlewToAltAzContext(context.Background(), altitude, azimuth)
+}
+
+// SlewToAltAzContext is SlewToAltAz, giving up when ctx is done. If the
+// direct path passes too close to the sun, it slews to a waypoint first and
+// returns once the mount has reached it and been sent on to the target.
+func (t *SafeTelescope) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	altitude, azimuth, err := t.Check(altitude, azimuth)
+	if err != nil {
+		return err
+	}
+
+	path := []coordinates.HorizontalCoordinates{{Altitude: altitude, Azimuth: azimuth}}
+	if t.solar != nil {
+		if sun := t.solar.Sun(); t.solar.ExclusionRadius(sun) > 0 {
+			if path, err = t.planSunSafe(path[0], sun); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, point := range path {
+		if i > 0 {
+			if err := t.waitForSlew(ctx); err != nil {
+				return fmt.Errorf("failed to reach sun-avoidance waypoint: %w", err)
+			}
+		}
+		if err := t.driver.SlewToAltAzContext(ctx, point.Altitude, point.Azimuth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planSunSafe plans a slew from the mount's current position to target that
+// keeps clear of the sun, through waypoints within the soft limits.
+func (t *SafeTelescope) planSunSafe(target coordinates.HorizontalCoordinates, sun coordinates.SunPosition) ([]coordinates.HorizontalCoordinates, error) {
+	// Fail safe: without the current position the path is unknown
+	var from coordinates.HorizontalCoordinates
+	var err error
+	if from.Altitude, err = t.driver.GetAltitude(); err != nil {
+		return nil, fmt.Errorf("failed to read position for sun avoidance: %w", err)
+	}
+	if from.Azimuth, err = t.driver.GetAzimuth(); err != nil {
+		return nil, fmt.Errorf("failed to read position for sun avoidance: %w", err)
+	}
+
+	reachable := func(p coordinates.HorizontalCoordinates) bool {
+		alt, az, err := t.envelope.Check(p.Altitude, p.Azimuth)
+		return err == nil && alt == p.Altitude && az == p.Azimuth
+	}
+	return coordinates.PlanSunSafeSlew(from, target, sun, t.solar.ExclusionRadius(sun), reachable)
+}
+
+// waitForSlew waits until the mount stops slewing.
+func (t *SafeTelescope) waitForSlew(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, waypointTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(DefaultGuardInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			slewing, err := t.driver.IsSlewing()
+			if err != nil {
+				return err
+			}
+			if !slewing {
+				return nil
+			}
+		}
+	}
+}
+
+// MoveAxis drives an axis at a rate clamped by the envelope (see
+// SafetyEnvelope.ClampRate). Stopping an axis is always allowed.
+func (t *SafeTelescope) MoveAxis(axis int, rate float64) error {
+	return t.MoveAxisContext(context.Background(), axis, rate)
+}
+
+// MoveAxisContext is MoveAxis, giving up when ctx is done.
+func (t *SafeTelescope) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	if rate != 0 {
+		// Fail safe: an axis is only moved if its position is known
+		var altitude, azimuth float64
+		var err error
+		if axis == 1 {
+			altitude, err = t.driver.GetAltitude()
+		} else if t.envelope.AzimuthLimited {
+			azimuth, err = t.driver.GetAzimuth()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read position for limit check: %w", err)
+		}
+		rate = t.envelope.ClampRate(axis, rate, altitude, azimuth)
+	}
+	if err := t.driver.MoveAxisContext(ctx, axis, rate); err != nil {
+		return err
+	}
+
+	if axis == 0 || axis == 1 {
+		t.mu.Lock()
+		t.rates[axis] = rate
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// PulseGuide sends a guide pulse to the mount, if it can be pulse guided
+// (see Guider). Where the pulse ends is predicted from the guide rates and
+// checked like a slew target (see Check): a pulse ending beyond a hard limit
+// or near the sun is refused, and one ending beyond a soft limit is
+// shortened to stop there. Returns the duration sent.
+func (t *SafeTelescope) PulseGuide(direction GuideDirection, duration time.Duration) (time.Duration, error) {
+	guider, ok := t.driver.(Guider)
+	if !ok {
+		return 0, errors.New("telescope cannot pulse guide")
+	}
+	if err := validatePulse(direction, duration); err != nil {
+		return 0, err
+	}
+
+	// Fail safe: a pulse is only sent if where it ends is known
+	raRate, decRate, err := guider.GetGuideRates()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read guide rates for limit check: %w", err)
+	}
+	altitude, err := t.driver.GetAltitude()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read position for limit check: %w", err)
+	}
+	azimuth, err := t.driver.GetAzimuth()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read position for limit check: %w", err)
+	}
+
+	rate := raRate
+	if direction == GuideNorth || direction == GuideSouth {
+		rate = decRate
+	}
+	dAlt, dAz := direction.AltAzOffset(rate * duration.Seconds())
+	safeAlt, safeAz, err := t.Check(altitude+dAlt, azimuth+dAz)
+	if err != nil {
+		return 0, err
+	}
+
+	// Held at a soft limit: send only the part of the pulse that gets there
+	if safeAlt != altitude+dAlt || safeAz != normalizeAzimuth(azimuth+dAz) {
+		moved, allowed := dAlt, safeAlt-altitude
+		if dAz != 0 {
+			moved, allowed = dAz, math.Remainder(safeAz-azimuth, 360)
+		}
+		duration = time.Duration(float64(duration) * math.Max(allowed/moved, 0)).Truncate(time.Millisecond)
+		if duration <= 0 {
+			return 0, fmt.Errorf("cannot guide %s at the soft limit: %w", direction, ErrOutsideLimits)
+		}
+	}
+
+	if err := guider.PulseGuide(direction, duration); err != nil {
+		return 0, err
+	}
+	return duration, nil
+}
+
+// Run stops moving axes as they reach the soft limits, checking every
+// interval (0 = DefaultGuardInterval) until ctx is cancelled.
+func (t *SafeTelescope) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultGuardInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.guard(ctx)
+		}
+	}
+}
+
+// guard stops any axis moving beyond a soft limit. Position read errors are
+// left for the next check.
+func (t *SafeTelescope) guard(ctx context.Context) {
+	t.mu.Lock()
+	rates := t.rates
+	t.mu.Unlock()
+
+	var altitude, azimuth float64
+	var err error
+	if rates[1] != 0 {
+		if altitude, err = t.driver.GetAltitude(); err != nil {
+			return
+		}
+	}
+	if rates[0] != 0 && t.envelope.AzimuthLimited {
+		if azimuth, err = t.driver.GetAzimuth(); err != nil {
+			return
+		}
+	}
+
+	for axis, rate := range rates {
+		if rate == 0 || t.envelope.ClampRate(axis, rate, altitude, azimuth) != 0 {
+			continue
+		}
+		if err := t.driver.MoveAxisContext(ctx, axis, 0); err == nil {
+			t.mu.Lock()
+			if t.rates[axis] == rate {
+				t.rates[axis] = 0
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// IsSlewing reports whether the telescope is slewing.
+func (t *SafeTelescope) IsSlewing() (bool, error) {
+	return t.driver.IsSlewing()
+}
+
+// GetAltitude returns the telescope's current altitude.
+func (t *SafeTelescope) GetAltitude() (float64, error) {
+	return t.driver.GetAltitude()
+}
+
+// GetAzimuth returns the telescope's current azimuth.
+func (t *SafeTelescope) GetAzimuth() (float64, error) {
+	return t.driver.GetAzimuth()
+}
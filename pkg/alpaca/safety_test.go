@@ -0,0 +1,292 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/safety"
+)
+
+// testEnvelope allows altitude 10-80° with soft limits 2° inside, azimuth
+// 300° clockwise through north to 60°, and rates up to 5°/s.
+var testEnvelope = SafetyEnvelope{
+	MinAltitude:    10,
+	MaxAltitude:    80,
+	SoftMargin:     2,
+	AzimuthLimited: true,
+	MinAzimuth:     300,
+	MaxAzimuth:     60,
+	MaxRate:        5,
+}
+
+// TestSafetyEnvelopeCheck tests that targets inside the soft limits pass,
+// targets between the soft and hard limits are held at the soft limit, and
+// targets beyond the hard limits are refused.
+func TestSafetyEnvelopeCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		alt, az         float64
+		wantAlt, wantAz float64
+		wantErr         bool
+	}{
+		{"Inside", 45, 10, 45, 10, false},
+		{"Inside across north", 45, 330, 45, 330, false},
+		{"Negative azimuth", 45, -30, 45, 330, false},
+		{"Soft altitude minimum", 11, 10, 12, 10, false},
+		{"Soft altitude maximum", 79, 10, 78, 10, false},
+		{"Soft azimuth minimum", 45, 301, 45, 302, false},
+		{"Soft azimuth maximum", 45, 59, 45, 58, false},
+		{"Below hard minimum", 5, 10, 0, 0, true},
+		{"Above hard maximum", 85, 10, 0, 0, true},
+		{"Outside azimuth range", 45, 180, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alt, az, err := testEnvelope.Check(tt.alt, tt.az)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOutsideLimits) {
+					t.Errorf("Check error = %v, want ErrOutsideLimits", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if math.Abs(alt-tt.wantAlt) > 1e-9 || math.Abs(az-tt.wantAz) > 1e-9 {
+				t.Errorf("Check = (%.1f, %.1f), want (%.1f, %.1f)", alt, az, tt.wantAlt, tt.wantAz)
+			}
+		})
+	}
+}
+
+// TestSafetyEnvelopeClampRate tests that rates are capped and that an axis
+// at a soft limit may only move back inside.
+func TestSafetyEnvelopeClampRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		axis    int
+		rate    float64
+		alt, az float64
+		want    float64
+	}{
+		{"Capped", 1, 8, 45, 10, 5},
+		{"Capped negative", 0, -8, 45, 10, -5},
+		{"Up at soft maximum", 1, 1, 78.5, 10, 0},
+		{"Down at soft maximum", 1, -1, 78.5, 10, -1},
+		{"Down at soft minimum", 1, -1, 11, 10, 0},
+		{"Clockwise at soft maximum", 0, 1, 45, 59, 0},
+		{"Anticlockwise at soft maximum", 0, -1, 45, 59, -1},
+		{"Anticlockwise at soft minimum", 0, -1, 45, 301, 0},
+		{"Clockwise past maximum", 0, 1, 45, 70, 0},
+		{"Anticlockwise past minimum", 0, -1, 45, 290, 0},
+		{"Clockwise back from past minimum", 0, 1, 45, 290, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testEnvelope.ClampRate(tt.axis, tt.rate, tt.alt, tt.az); got != tt.want {
+				t.Errorf("ClampRate = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeDriver records axis rates and slews for a mount at a fixed position.
+type fakeDriver struct {
+	mu      sync.Mutex
+	alt, az float64
+	rates   [2]float64
+	slews   [][2]float64
+	pulses  []time.Duration
+}
+
+func (d *fakeDriver) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.slews = append(d.slews, [2]float64{altitude, azimuth})
+	return nil
+}
+
+func (d *fakeDriver) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rates[axis] = rate
+	return nil
+}
+
+func (d *fakeDriver) GetAltitude() (float64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.alt, nil
+}
+
+func (d *fakeDriver) GetAzimuth() (float64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.az, nil
+}
+
+func (d *fakeDriver) IsSlewing() (bool, error) {
+	return false, nil
+}
+
+func (d *fakeDriver) PulseGuide(direction GuideDirection, duration time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pulses = append(d.pulses, duration)
+	return nil
+}
+
+// GetGuideRates returns 1°/s on both axes.
+func (d *fakeDriver) GetGuideRates() (float64, float64, error) {
+	return 1, 1, nil
+}
+
+func (d *fakeDriver) rate(axis int) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.rates[axis]
+}
+
+// TestSafeTelescope tests that slews and axis moves sent through the
+// wrapper are limited, and that Run stops an axis that reaches a soft limit.
+func TestSafeTelescope(t *testing.T) {
+	driver := &fakeDriver{alt: 45, az: 10}
+	mount := NewSafeTelescope(driver, testEnvelope)
+
+	if err := mount.SlewToAltAz(5, 10); !errors.Is(err, ErrOutsideLimits) {
+		t.Errorf("SlewToAltAz below limit error = %v, want ErrOutsideLimits", err)
+	}
+	if len(driver.slews) != 0 {
+		t.Errorf("Refused slew reached the driver: %v", driver.slews)
+	}
+	if err := mount.SlewToAltAz(79, 10); err != nil {
+		t.Fatalf("SlewToAltAz failed: %v", err)
+	}
+	if driver.slews[0][0] != 78 {
+		t.Errorf("Slewed to altitude %.1f, want soft limit 78", driver.slews[0][0])
+	}
+
+	if err := mount.MoveAxis(1, 10); err != nil {
+		t.Fatalf("MoveAxis failed: %v", err)
+	}
+	if got := driver.rate(1); got != 5 {
+		t.Errorf("Altitude rate = %.1f, want capped 5", got)
+	}
+
+	// The mount reaches the soft limit while moving up
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mount.Run(ctx, time.Millisecond)
+
+	driver.mu.Lock()
+	driver.alt = 79
+	driver.mu.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	for driver.rate(1) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Altitude axis was not stopped at the soft limit")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestSafeTelescopePulseGuide tests that guide pulses are shortened to stop
+// at a soft limit and refused once there or past a hard limit.
+func TestSafeTelescopePulseGuide(t *testing.T) {
+	tests := []struct {
+		name      string
+		alt, az   float64
+		direction GuideDirection
+		duration  time.Duration
+		want      time.Duration
+		wantErr   bool
+	}{
+		{"Inside", 45, 10, GuideNorth, time.Second, time.Second, false},
+		{"Shortened at soft altitude maximum", 76.5, 10, GuideNorth, 2 * time.Second, 1500 * time.Millisecond, false},
+		{"Across north", 45, 357, GuideWest, 5 * time.Second, 5 * time.Second, false},
+		{"Shortened at soft azimuth maximum", 45, 57, GuideWest, 2 * time.Second, time.Second, false},
+		{"Shortened at soft azimuth minimum", 45, 303, GuideEast, 2 * time.Second, time.Second, false},
+		{"At soft limit", 78, 10, GuideNorth, time.Second, 0, true},
+		{"Inside south", 45, 10, GuideSouth, 5 * time.Second, 5 * time.Second, false},
+		{"Beyond hard limit", 12, 10, GuideSouth, 3 * time.Second, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := &fakeDriver{alt: tt.alt, az: tt.az}
+			mount := NewSafeTelescope(driver, testEnvelope)
+
+			sent, err := mount.PulseGuide(tt.direction, tt.duration)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOutsideLimits) {
+					t.Errorf("PulseGuide error = %v, want ErrOutsideLimits", err)
+				}
+				if len(driver.pulses) != 0 {
+					t.Errorf("Refused pulse reached the driver: %v", driver.pulses)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PulseGuide failed: %v", err)
+			}
+			if sent != tt.want || len(driver.pulses) != 1 || driver.pulses[0] != tt.want {
+				t.Errorf("PulseGuide sent %v (driver got %v), want %v", sent, driver.pulses, tt.want)
+			}
+		})
+	}
+}
+
+// TestSafeTelescopeAvoidsSun tests that a slew whose direct path sweeps
+// past the sun is sent through a waypoint clear of it, and that a target
+// near the sun is refused.
+func TestSafeTelescopeAvoidsSun(t *testing.T) {
+	// An observer who sees the sun about 30° up right now
+	now := time.Now().UTC()
+	hours := float64(now.Hour()) + float64(now.Minute())/60
+	observer := coordinates.Observer{Location: coordinates.Geographic{Longitude: (12 - hours) * 15}}
+	var sun coordinates.SunPosition
+	for lat := -85.0; lat <= 85; lat += 5 {
+		observer.Location.Latitude = lat
+		if sun = coordinates.CalculateSunPosition(observer, now); sun.Altitude >= 25 && sun.Altitude <= 45 {
+			break
+		}
+	}
+	if sun.Altitude < 25 || sun.Altitude > 45 {
+		t.Fatalf("No observer found with the sun 25-45° up (last %.1f°)", sun.Altitude)
+	}
+
+	// Either side of the sun at its altitude
+	driver := &fakeDriver{alt: sun.Altitude, az: sun.Azimuth - 40}
+	mount := NewSafeTelescope(driver, SafetyEnvelope{MinAltitude: 0, MaxAltitude: 85})
+	mount.SetSolarGuard(safety.NewSolarGuard(config.TelescopeConfig{SolarSafetyEnabled: true}, observer))
+
+	if err := mount.SlewToAltAz(sun.Altitude, sun.Azimuth+40); err != nil {
+		t.Fatalf("SlewToAltAz failed: %v", err)
+	}
+	if len(driver.slews) != 2 {
+		t.Fatalf("Slews = %v, want waypoint then target", driver.slews)
+	}
+	if sep := sun.AngularSeparation(driver.slews[0][0], driver.slews[0][1]); sep < 20 {
+		t.Errorf("Waypoint is %.1f° from the sun, want at least 20°", sep)
+	}
+	if last := driver.slews[1]; math.Abs(last[0]-sun.Altitude) > 1e-9 || math.Abs(last[1]-math.Mod(sun.Azimuth+40, 360)) > 1e-9 {
+		t.Errorf("Final slew = %v, want the target", last)
+	}
+
+	// A target inside the exclusion zone is refused
+	if err := mount.SlewToAltAz(sun.Altitude+5, sun.Azimuth); !errors.Is(err, safety.ErrSolarProximity) {
+		t.Errorf("Slew near the sun error = %v, want ErrSolarProximity", err)
+	}
+	if _, _, err := mount.Check(sun.Altitude+5, sun.Azimuth); !errors.Is(err, safety.ErrSolarProximity) {
+		t.Errorf("Check near the sun error = %v, want ErrSolarProximity", err)
+	}
+}
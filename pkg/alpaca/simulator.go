@@ -0,0 +1,303 @@
+package alpaca
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+const (
+	// SimulatorSlewRate is how fast the simulated mount slews each axis (deg/sec)
+	SimulatorSlewRate = 6.0
+
+	// SimulatorGuideRate is the simulated guide rate (deg/sec), fast enough
+	// that a nudge is visible in the UI
+	SimulatorGuideRate = 0.5
+)
+
+// Simulator is an in-memory alt-az telescope serving the Alpaca REST API
+// (/api/v1/telescope/{device}/...), so the clients in this package and
+// everything built on them can run without hardware (e.g. in demo mode).
+//
+// Both axes slew toward the target at SimulatorSlewRate. Tracking is only a
+// flag: between slews the mount holds its altitude and azimuth.
+type Simulator struct {
+	mu       sync.Mutex
+	observer coordinates.Observer
+
+	connected bool
+	tracking  bool
+	atPark    bool
+	slewing   bool
+	altitude  float64
+	azimuth   float64
+	targetAlt float64
+	targetAz  float64
+	axisRates [2]float64 // MoveAxis rates: 0 = azimuth, 1 = altitude (deg/sec)
+	updated   time.Time
+
+	serverTxn int
+
+	// lacking lists Can* properties reported as false (set in tests)
+	lacking map[string]bool
+
+	// now returns the current time (replaced in tests)
+	now func() time.Time
+}
+
+// NewSimulator creates a connected, unparked simulated telescope at observer,
+// pointing north 30° above the horizon.
+func NewSimulator(observer coordinates.Observer) *Simulator {
+	return &Simulator{
+		observer:  observer,
+		connected: true,
+		altitude:  30,
+		targetAlt: 30,
+		now:       time.Now,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// /api/v1/telescope/{device}/{method}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "api" || parts[1] != "v1" || parts[2] != "telescope" {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	method := strings.ToLower(parts[4])
+
+	s.mu.Lock()
+	s.step()
+	s.serverTxn++
+	var value interface{}
+	var errNum int
+	var errMsg string
+	if r.Method == http.MethodGet {
+		value, errNum, errMsg = s.get(method, r)
+	} else {
+		errNum, errMsg = s.put(method, r)
+	}
+	resp := AlpacaResponse{
+		Value:               value,
+		ServerTransactionID: s.serverTxn,
+		ErrorNumber:         errNum,
+		ErrorMessage:        errMsg,
+	}
+	s.mu.Unlock()
+
+	resp.ClientTransactionID, _ = strconv.Atoi(param(r, "ClientTransactionID"))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// get answers a property read. Called with s.mu held.
+func (s *Simulator) get(method string, r *http.Request) (interface{}, int, string) {
+	switch method {
+	case "connected":
+		return s.connected, 0, ""
+	case "tracking":
+		return s.tracking, 0, ""
+	case "slewing":
+		return s.slewing, 0, ""
+	case "atpark":
+		return s.atPark, 0, ""
+	case "ispulseguiding":
+		return false, 0, ""
+	case "altitude":
+		return s.altitude, 0, ""
+	case "azimuth":
+		return s.azimuth, 0, ""
+	case "rightascension":
+		return s.equatorial().RightAscension, 0, ""
+	case "declination":
+		return s.equatorial().Declination, 0, ""
+	case "alignmentmode":
+		return 0, 0, "" // Alt-az
+	case "sitelatitude":
+		return s.observer.Location.Latitude, 0, ""
+	case "sitelongitude":
+		return s.observer.Location.Longitude, 0, ""
+	case "siteelevation":
+		return s.observer.Location.Altitude, 0, ""
+	case "utcdate":
+		return s.now().UTC().Format(time.RFC3339), 0, ""
+	case "guideraterightascension", "guideratedeclination":
+		return SimulatorGuideRate, 0, ""
+	case "cansettracking", "canslew", "canslewasync", "canslewaltaz", "canslewaltazasync",
+		"canpark", "canunpark", "canpulseguide", "canmoveaxis":
+		return !s.lacking[method], 0, ""
+	case "description", "name":
+		return "ADS-B Scope simulated telescope", 0, ""
+	case "driverinfo":
+		return "In-memory Alpaca telescope simulator", 0, ""
+	case "driverversion":
+		return "1.0", 0, ""
+	case "interfaceversion":
+		return 3, 0, ""
+	case "supportedactions":
+		return []string{}, 0, ""
+	}
+	return nil, AlpacaNotImplemented, fmt.Sprintf("%s is not implemented by the simulator", method)
+}
+
+// put executes a method or property write. Called with s.mu held.
+func (s *Simulator) put(method string, r *http.Request) (int, string) {
+	if method == "connected" {
+		connected, err := strconv.ParseBool(param(r, "Connected"))
+		if err != nil {
+			return AlpacaInvalidValue, "invalid Connected value"
+		}
+		s.connected = connected
+		return 0, ""
+	}
+	if !s.connected {
+		return AlpacaNotConnected, "telescope not connected"
+	}
+
+	switch method {
+	case "tracking":
+		tracking, err := strconv.ParseBool(param(r, "Tracking"))
+		if err != nil {
+			return AlpacaInvalidValue, "invalid Tracking value"
+		}
+		s.tracking = tracking
+		return 0, ""
+
+	case "slewtoaltaz", "slewtoaltazasync":
+		alt, errAlt := strconv.ParseFloat(param(r, "Altitude"), 64)
+		az, errAz := strconv.ParseFloat(param(r, "Azimuth"), 64)
+		if errAlt != nil || errAz != nil || alt < -90 || alt > 90 || az < 0 || az > 360 {
+			return AlpacaInvalidValue, "invalid altitude or azimuth"
+		}
+		return s.slewTo(alt, az)
+
+	case "slewtocoordinates", "slewtocoordinatesasync":
+		ra, errRA := strconv.ParseFloat(param(r, "RightAscension"), 64)
+		dec, errDec := strconv.ParseFloat(param(r, "Declination"), 64)
+		if errRA != nil || errDec != nil || ra < 0 || ra >= 24 || dec < -90 || dec > 90 {
+			return AlpacaInvalidValue, "invalid right ascension or declination"
+		}
+		horizontal := coordinates.EquatorialToHorizontal(
+			coordinates.EquatorialCoordinates{RightAscension: ra, Declination: dec},
+			s.observer, s.now().UTC())
+		return s.slewTo(horizontal.Altitude, horizontal.Azimuth)
+
+	case "abortslew":
+		s.targetAlt, s.targetAz = s.altitude, s.azimuth
+		s.axisRates = [2]float64{}
+		s.slewing = false
+		return 0, ""
+
+	case "moveaxis":
+		axis, errAxis := strconv.Atoi(param(r, "Axis"))
+		rate, errRate := strconv.ParseFloat(param(r, "Rate"), 64)
+		if errAxis != nil || errRate != nil || axis < 0 || axis > 1 || math.Abs(rate) > SimulatorSlewRate {
+			return AlpacaInvalidValue, "invalid axis or rate"
+		}
+		if s.atPark {
+			return AlpacaInvalidWhileParked, "telescope is parked"
+		}
+		s.axisRates[axis] = rate
+		return 0, ""
+
+	case "pulseguide":
+		direction, errDir := strconv.Atoi(param(r, "Direction"))
+		ms, errDur := strconv.Atoi(param(r, "Duration"))
+		if errDir != nil || errDur != nil || ms < 0 || direction < int(GuideNorth) || direction > int(GuideWest) {
+			return AlpacaInvalidValue, "invalid direction or duration"
+		}
+		if s.atPark {
+			return AlpacaInvalidWhileParked, "telescope is parked"
+		}
+		dAlt, dAz := GuideDirection(direction).AltAzOffset(SimulatorGuideRate * float64(ms) / 1000)
+		s.altitude = math.Max(-90, math.Min(90, s.altitude+dAlt))
+		s.azimuth = coordinates.NormalizeAzimuth(s.azimuth + dAz)
+		if !s.slewing {
+			s.targetAlt, s.targetAz = s.altitude, s.azimuth
+		}
+		return 0, ""
+
+	case "park":
+		s.tracking = false
+		s.targetAlt, s.targetAz = 0, 0
+		s.slewing = true
+		s.atPark = true
+		return 0, ""
+
+	case "unpark":
+		s.atPark = false
+		return 0, ""
+	}
+
+	return AlpacaNotImplemented, fmt.Sprintf("%s is not implemented by the simulator", method)
+}
+
+// slewTo starts a slew. Called with s.mu held.
+func (s *Simulator) slewTo(altitude, azimuth float64) (int, string) {
+	if s.atPark {
+		return AlpacaInvalidWhileParked, "telescope is parked"
+	}
+	s.targetAlt = altitude
+	s.targetAz = coordinates.NormalizeAzimuth(azimuth)
+	s.slewing = true
+	return 0, ""
+}
+
+// step advances the mount to the current time. Called with s.mu held.
+func (s *Simulator) step() {
+	now := s.now()
+	dt := now.Sub(s.updated).Seconds()
+	s.updated = now
+	if dt <= 0 {
+		return
+	}
+
+	if s.slewing {
+		maxMove := SimulatorSlewRate * dt
+		dAlt := s.targetAlt - s.altitude
+		dAz := math.Mod(s.targetAz-s.azimuth+540, 360) - 180 // Shortest way round
+		s.altitude += math.Max(-maxMove, math.Min(maxMove, dAlt))
+		s.azimuth = coordinates.NormalizeAzimuth(s.azimuth + math.Max(-maxMove, math.Min(maxMove, dAz)))
+		if math.Abs(dAlt) <= maxMove && math.Abs(dAz) <= maxMove {
+			s.altitude, s.azimuth = s.targetAlt, s.targetAz
+			s.slewing = false
+		}
+		return
+	}
+
+	if s.axisRates != [2]float64{} {
+		s.azimuth = coordinates.NormalizeAzimuth(s.azimuth + s.axisRates[0]*dt)
+		s.altitude = math.Max(-90, math.Min(90, s.altitude+s.axisRates[1]*dt))
+		s.targetAlt, s.targetAz = s.altitude, s.azimuth
+	}
+}
+
+// equatorial returns where the mount points in RA/Dec. Called with s.mu held.
+func (s *Simulator) equatorial() coordinates.EquatorialCoordinates {
+	return coordinates.HorizontalToEquatorial(
+		coordinates.HorizontalCoordinates{Altitude: s.altitude, Azimuth: s.azimuth},
+		s.observer, s.now().UTC())
+}
+
+// param returns a request parameter. Alpaca parameter names are
+// case-insensitive.
+func param(r *http.Request, name string) string {
+	for key, values := range r.Form {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
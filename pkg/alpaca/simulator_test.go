@@ -0,0 +1,127 @@
+package alpaca
+
+import (
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// newTestSimulator starts a simulator with a controllable clock and returns
+// a client for it.
+func newTestSimulator(t *testing.T) (*Simulator, *TelescopeClient, *time.Time) {
+	t.Helper()
+
+	now := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	sim := NewSimulator(coordinates.Observer{
+		Location: coordinates.Geographic{Latitude: 37.08, Longitude: -94.51, Altitude: 300},
+	})
+	sim.now = func() time.Time { return now }
+
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+
+	return sim, NewTelescopeClient(server.URL, 0), &now
+}
+
+// TestSimulatorSlew tests that slews move at the simulated slew rate and
+// take the shortest way round in azimuth.
+func TestSimulatorSlew(t *testing.T) {
+	tests := []struct {
+		name      string
+		altitude  float64
+		azimuth   float64
+		after     time.Duration
+		wantAlt   float64
+		wantAz    float64
+		wantSlews bool
+	}{
+		{"Partway", 60, 0, 2 * time.Second, 42, 0, true},
+		{"Arrived", 60, 0, 10 * time.Second, 60, 0, false},
+		{"Shortest way through north", 30, 350, time.Second, 30, 354, true},
+		{"Arrived through north", 30, 350, 5 * time.Second, 30, 350, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client, now := newTestSimulator(t)
+
+			// The first request starts the simulator's clock
+			if _, err := client.GetStatus(); err != nil {
+				t.Fatalf("GetStatus failed: %v", err)
+			}
+			if err := client.SlewToAltAz(tt.altitude, tt.azimuth); err != nil {
+				t.Fatalf("SlewToAltAz failed: %v", err)
+			}
+			*now = now.Add(tt.after)
+
+			status, err := client.GetStatus()
+			if err != nil {
+				t.Fatalf("GetStatus failed: %v", err)
+			}
+			if math.Abs(status.Altitude-tt.wantAlt) > 1e-6 {
+				t.Errorf("Expected altitude %.1f, got %.4f", tt.wantAlt, status.Altitude)
+			}
+			if math.Abs(status.Azimuth-tt.wantAz) > 1e-6 {
+				t.Errorf("Expected azimuth %.1f, got %.4f", tt.wantAz, status.Azimuth)
+			}
+			if status.Slewing != tt.wantSlews {
+				t.Errorf("Expected slewing %v, got %v", tt.wantSlews, status.Slewing)
+			}
+		})
+	}
+}
+
+// TestSimulatorCommands tests tracking, guiding, aborts and parking.
+func TestSimulatorCommands(t *testing.T) {
+	_, client, now := newTestSimulator(t)
+
+	if err := client.SetTracking(true); err != nil {
+		t.Fatalf("SetTracking failed: %v", err)
+	}
+	if err := client.PulseGuide(GuideNorth, 2*time.Second); err != nil {
+		t.Fatalf("PulseGuide failed: %v", err)
+	}
+	status, err := client.GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if !status.Tracking || !status.Connected {
+		t.Errorf("Expected connected and tracking, got %+v", status)
+	}
+	if want := 30 + 2*SimulatorGuideRate; math.Abs(status.Altitude-want) > 1e-6 {
+		t.Errorf("Expected altitude %.1f after guiding north, got %.4f", want, status.Altitude)
+	}
+
+	// Aborting stops where the mount is
+	if err := client.SlewToAltAz(80, 90); err != nil {
+		t.Fatalf("SlewToAltAz failed: %v", err)
+	}
+	*now = now.Add(time.Second)
+	if err := client.AbortSlew(); err != nil {
+		t.Fatalf("AbortSlew failed: %v", err)
+	}
+	*now = now.Add(time.Minute)
+	status, err = client.GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status.Slewing || math.Abs(status.Azimuth-SimulatorSlewRate) > 1e-6 {
+		t.Errorf("Expected to stop at azimuth %.1f, got %.4f (slewing %v)", SimulatorSlewRate, status.Azimuth, status.Slewing)
+	}
+
+	// A parked mount refuses to slew
+	if _, err := client.put("park", nil); err != nil {
+		t.Fatalf("Park failed: %v", err)
+	}
+	if err := client.SlewToAltAz(45, 180); err == nil {
+		t.Error("Expected an error slewing while parked")
+	}
+
+	// Unsupported methods are reported as not implemented
+	if _, err := client.get("destinationsideofpier"); err == nil {
+		t.Error("Expected an error for an unimplemented method")
+	}
+}
@@ -0,0 +1,179 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSupervisorInterval is how often a healthy connection is checked
+	DefaultSupervisorInterval = 5 * time.Second
+
+	// supervisorMaxBackoff caps the delay between reconnect attempts
+	supervisorMaxBackoff = time.Minute
+)
+
+// Connection event types.
+const (
+	// ConnectionLost is emitted when the telescope stops answering or
+	// reports that it is no longer connected
+	ConnectionLost = "telescope_disconnected"
+
+	// ConnectionRestored is emitted once the session has been
+	// re-established and the mount state restored
+	ConnectionRestored = "telescope_reconnected"
+)
+
+// errSessionLost is reported when the device answers but is not connected.
+var errSessionLost = errors.New("telescope reports it is not connected")
+
+// Connection is the part of a telescope client a Supervisor drives.
+// Both Client and TelescopeClient implement it.
+type Connection interface {
+	// IsConnected asks the device whether the session is connected
+	IsConnected() (bool, error)
+
+	// Connect (re-)establishes the session
+	Connect() error
+
+	// RestoreState re-applies mount state set during the session (e.g.
+	// tracking) after the device has been reconnected
+	RestoreState() error
+}
+
+// ConnectionEvent reports a change in the telescope connection.
+type ConnectionEvent struct {
+	// Type is ConnectionLost or ConnectionRestored
+	Type string `json:"type"`
+
+	// Error describes why the connection was lost, or why restoring the
+	// mount state after reconnecting failed
+	Error string `json:"error,omitempty"`
+
+	// Attempts is how many reconnect attempts it took (ConnectionRestored)
+	Attempts int `json:"attempts,omitempty"`
+
+	// DowntimeSeconds is how long the telescope was unavailable
+	// (ConnectionRestored)
+	DowntimeSeconds float64 `json:"downtimeSeconds,omitempty"`
+
+	// Time is when the change was detected
+	Time time.Time `json:"time"`
+}
+
+// Supervisor watches a telescope connection, reconnects with backoff when
+// the Alpaca server restarts or the link drops, and restores the mount
+// state afterwards. Set OnEvent before calling Run.
+type Supervisor struct {
+	conn     Connection
+	interval time.Duration
+
+	// OnEvent, if set, is called from Run's goroutine for every connection
+	// change (e.g. to notify the UI)
+	OnEvent func(ConnectionEvent)
+
+	mu        sync.RWMutex
+	connected bool
+	last      *ConnectionEvent
+}
+
+// NewSupervisor creates a supervisor that checks conn every interval
+// (0 = DefaultSupervisorInterval). The connection is assumed healthy until
+// the first check says otherwise.
+func NewSupervisor(conn Connection, interval time.Duration) *Supervisor {
+	if interval <= 0 {
+		interval = DefaultSupervisorInterval
+	}
+	return &Supervisor{
+		conn:      conn,
+		interval:  interval,
+		connected: true,
+	}
+}
+
+// Connected reports whether the last check found the telescope connected.
+func (s *Supervisor) Connected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.connected
+}
+
+// LastEvent returns the most recent connection event, or nil if the
+// connection has not changed since the supervisor started.
+func (s *Supervisor) LastEvent() *ConnectionEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.last
+}
+
+// Run supervises the connection until ctx is cancelled.
+func (s *Supervisor) Run(ctx context.Context) {
+	var lostAt time.Time
+	attempts := 0
+	delay := s.interval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if s.Connected() {
+			if err := s.check(); err != nil {
+				lostAt = time.Now()
+				attempts = 0
+				s.emit(false, ConnectionEvent{Type: ConnectionLost, Error: err.Error(), Time: lostAt})
+			} else {
+				delay = s.interval
+				continue
+			}
+		}
+
+		// Disconnected: try to re-establish the session, backing off
+		attempts++
+		if err := s.conn.Connect(); err != nil {
+			delay = min(s.interval<<min(attempts, 8), supervisorMaxBackoff)
+			continue
+		}
+
+		event := ConnectionEvent{
+			Type:     ConnectionRestored,
+			Attempts: attempts,
+			Time:     time.Now(),
+		}
+		event.DowntimeSeconds = event.Time.Sub(lostAt).Seconds()
+		if err := s.conn.RestoreState(); err != nil {
+			event.Error = err.Error()
+		}
+		s.emit(true, event)
+		delay = s.interval
+	}
+}
+
+// check returns an error if the telescope is unreachable or reports that it
+// is no longer connected (e.g. the Alpaca server restarted).
+func (s *Supervisor) check() error {
+	connected, err := s.conn.IsConnected()
+	if err != nil {
+		return err
+	}
+	if !connected {
+		return errSessionLost
+	}
+	return nil
+}
+
+// emit records a connection change and reports it.
+func (s *Supervisor) emit(connected bool, event ConnectionEvent) {
+	s.mu.Lock()
+	s.connected = connected
+	s.last = &event
+	s.mu.Unlock()
+
+	if s.OnEvent != nil {
+		s.OnEvent(event)
+	}
+}
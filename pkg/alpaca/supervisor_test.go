@@ -0,0 +1,142 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestSupervisorRestoresSession tests that after the Alpaca server restarts
+// the supervisor reconnects and restores the park and tracking state.
+func TestSupervisorRestoresSession(t *testing.T) {
+	sim := NewSimulator(coordinates.Observer{
+		Location: coordinates.Geographic{Latitude: 37.08, Longitude: -94.51},
+	})
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+
+	client := NewClient(config.TelescopeConfig{BaseURL: server.URL, MountType: "altaz", SlewRate: SimulatorSlewRate})
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := client.Unpark(); err != nil {
+		t.Fatalf("Unpark failed: %v", err)
+	}
+	if err := client.SetTracking(true); err != nil {
+		t.Fatalf("SetTracking failed: %v", err)
+	}
+
+	events := make(chan ConnectionEvent, 4)
+	supervisor := NewSupervisor(client, 10*time.Millisecond)
+	supervisor.OnEvent = func(e ConnectionEvent) { events <- e }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go supervisor.Run(ctx)
+
+	// The server restarts: a fresh session is disconnected, parked and idle
+	sim.mu.Lock()
+	sim.connected, sim.atPark, sim.tracking = false, true, false
+	sim.mu.Unlock()
+
+	for _, want := range []string{ConnectionLost, ConnectionRestored} {
+		select {
+		case e := <-events:
+			if e.Type != want {
+				t.Fatalf("Event = %s, want %s", e.Type, want)
+			}
+			if want == ConnectionRestored && e.Error != "" {
+				t.Errorf("Restore failed: %s", e.Error)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	if !sim.connected || sim.atPark || !sim.tracking {
+		t.Errorf("After restore connected=%t atPark=%t tracking=%t, want true false true",
+			sim.connected, sim.atPark, sim.tracking)
+	}
+	if !supervisor.Connected() {
+		t.Error("Supervisor reports disconnected after restore")
+	}
+}
+
+// fakeConnection is a Connection whose server is up or down on demand.
+type fakeConnection struct {
+	down     chan bool // receives the server's state before each call
+	isDown   bool
+	connects int
+}
+
+func (f *fakeConnection) poll() {
+	select {
+	case f.isDown = <-f.down:
+	default:
+	}
+}
+
+func (f *fakeConnection) IsConnected() (bool, error) {
+	f.poll()
+	if f.isDown {
+		return false, errors.New("connection refused")
+	}
+	return true, nil
+}
+
+func (f *fakeConnection) Connect() error {
+	f.poll()
+	f.connects++
+	if f.isDown {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (f *fakeConnection) RestoreState() error { return nil }
+
+// TestSupervisorRetriesUntilServerReturns tests that reconnect attempts
+// continue while the server is down and are counted in the restore event.
+func TestSupervisorRetriesUntilServerReturns(t *testing.T) {
+	conn := &fakeConnection{down: make(chan bool, 1)}
+	supervisor := NewSupervisor(conn, time.Millisecond)
+
+	events := make(chan ConnectionEvent, 4)
+	supervisor.OnEvent = func(e ConnectionEvent) { events <- e }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go supervisor.Run(ctx)
+
+	conn.down <- true
+	select {
+	case e := <-events:
+		if e.Type != ConnectionLost || e.Error == "" {
+			t.Fatalf("Event = %+v, want %s with error", e, ConnectionLost)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for disconnect")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	conn.down <- false
+
+	select {
+	case e := <-events:
+		if e.Type != ConnectionRestored {
+			t.Fatalf("Event = %s, want %s", e.Type, ConnectionRestored)
+		}
+		if e.Attempts < 2 {
+			t.Errorf("Attempts = %d, want at least 2", e.Attempts)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for reconnect")
+	}
+}
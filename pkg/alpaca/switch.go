@@ -1,10 +1,11 @@
 package alpaca
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net/url"
 	"strconv"
-	"time"
 
 	"github.com/unklstewy/ads-bscope/pkg/config"
 )
@@ -23,9 +24,6 @@ type SwitchClient struct {
 	// config contains telescope configuration
 	config config.TelescopeConfig
 
-	// clientID is a unique identifier for this client instance
-	clientID int
-
 	// telescope is the parent telescope client (for HTTP access)
 	telescope *Client
 
@@ -40,7 +38,6 @@ type SwitchClient struct {
 func NewSwitchClient(telescopeClient *Client) *SwitchClient {
 	return &SwitchClient{
 		config:         telescopeClient.config,
-		clientID:       telescopeClient.clientID,
 		telescope:      telescopeClient,
 		connected:      false,
 		dewHeaterState: false,
@@ -52,8 +49,6 @@ func NewSwitchClient(telescopeClient *Client) *SwitchClient {
 func (s *SwitchClient) Connect() error {
 	params := url.Values{}
 	params.Add("Connected", "true")
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
 
 	resp, err := s.put("connected", params)
 	if err != nil {
@@ -80,8 +75,6 @@ func (s *SwitchClient) Disconnect() error {
 
 	params := url.Values{}
 	params.Add("Connected", "false")
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
 
 	resp, err := s.put("connected", params)
 	if err != nil {
@@ -99,29 +92,13 @@ func (s *SwitchClient) GetDewHeater() (bool, error) {
 		return false, fmt.Errorf("switch not connected")
 	}
 
-	// Build URL with ID parameter for dew heater (ID=0)
-	apiURL := fmt.Sprintf("%s/api/v1/switch/%d/getswitch",
-		s.config.BaseURL, s.config.SwitchDeviceNumber)
-
 	params := url.Values{}
 	params.Add("Id", "0") // Dew heater is ID 0
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
 
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	// Use telescope's HTTP client
-	resp, err := s.telescope.httpClient.Get(fullURL)
+	alpacaResp, err := s.getWithParams("getswitch", params)
 	if err != nil {
 		return false, fmt.Errorf("failed to get dew heater state: %w", err)
 	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return false, err
-	}
 
 	if err := alpacaResp.Error(); err != nil {
 		return false, err
@@ -151,8 +128,6 @@ func (s *SwitchClient) SetDewHeater(enabled bool) error {
 	params := url.Values{}
 	params.Add("Id", "0") // Dew heater is ID 0
 	params.Add("State", strconv.FormatBool(enabled))
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
 
 	resp, err := s.put("setswitch", params)
 	if err != nil {
@@ -167,6 +142,64 @@ func (s *SwitchClient) SetDewHeater(enabled bool) error {
 	return nil
 }
 
+// SetDewHeaterPower sets the dew heater output as a fraction (0-1) of full power.
+// Heaters that only support on/off are switched on at half power or more.
+// Implements: PUT /api/v1/switch/{device_number}/setswitchvalue
+func (s *SwitchClient) SetDewHeaterPower(power float64) error {
+	if !s.connected {
+		return fmt.Errorf("switch not connected")
+	}
+
+	power = math.Max(0, math.Min(1, power))
+
+	minValue, errMin := s.getSwitchValue("minswitchvalue", int(SwitchDewHeater))
+	maxValue, errMax := s.getSwitchValue("maxswitchvalue", int(SwitchDewHeater))
+	if errMin != nil || errMax != nil || maxValue-minValue <= 1 {
+		// Boolean switch (e.g., Seestar lens heater)
+		return s.SetDewHeater(power >= 0.5)
+	}
+
+	value := math.Round(minValue + power*(maxValue-minValue))
+
+	params := url.Values{}
+	params.Add("Id", strconv.Itoa(int(SwitchDewHeater)))
+	params.Add("Value", strconv.FormatFloat(value, 'f', -1, 64))
+
+	resp, err := s.put("setswitchvalue", params)
+	if err != nil {
+		return fmt.Errorf("failed to set dew heater power: %w", err)
+	}
+
+	if err := resp.Error(); err != nil {
+		return err
+	}
+
+	s.dewHeaterState = value > minValue
+	return nil
+}
+
+// getSwitchValue reads a numeric property of the switch with the given ID.
+func (s *SwitchClient) getSwitchValue(endpoint string, id int) (float64, error) {
+	params := url.Values{}
+	params.Add("Id", strconv.Itoa(id))
+
+	alpacaResp, err := s.getWithParams(endpoint, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s: %w", endpoint, err)
+	}
+
+	if err := alpacaResp.Error(); err != nil {
+		return 0, err
+	}
+
+	value, ok := alpacaResp.Value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response type for %s", endpoint)
+	}
+
+	return value, nil
+}
+
 // EnableDewHeater turns on the dew heater.
 // Use this in humid conditions or for long tracking sessions.
 func (s *SwitchClient) EnableDewHeater() error {
@@ -198,26 +231,13 @@ func (s *SwitchClient) GetSwitchDescription(id int) (string, error) {
 		return "", fmt.Errorf("invalid switch ID %d: Seestar only has ID 0 (dew heater)", id)
 	}
 
-	apiURL := fmt.Sprintf("%s/api/v1/switch/%d/getswitchdescription",
-		s.config.BaseURL, s.config.SwitchDeviceNumber)
-
 	params := url.Values{}
 	params.Add("Id", strconv.Itoa(id))
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
 
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	resp, err := s.telescope.httpClient.Get(fullURL)
+	alpacaResp, err := s.getWithParams("getswitchdescription", params)
 	if err != nil {
 		return "", fmt.Errorf("failed to get switch description: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return "", err
-	}
 
 	if err := alpacaResp.Error(); err != nil {
 		return "", err
@@ -255,58 +275,18 @@ func (s *SwitchClient) GetMaxSwitch() (int, error) {
 	return int(maxSwitch), nil
 }
 
-// getTransactionID generates a unique transaction ID for each API call.
-func (s *SwitchClient) getTransactionID() int {
-	return int(time.Now().UnixNano() / 1000000)
-}
-
 // get performs an HTTP GET request to a switch endpoint.
 func (s *SwitchClient) get(endpoint string) (*alpacaResponse, error) {
-	// Build URL using switch device number (typically 0)
-	apiURL := fmt.Sprintf("%s/api/v1/switch/%d/%s",
-		s.config.BaseURL, s.config.SwitchDeviceNumber, endpoint)
-
-	// Add query parameters
-	params := url.Values{}
-	params.Add("ClientID", strconv.Itoa(s.clientID))
-	params.Add("ClientTransactionID", strconv.Itoa(s.getTransactionID()))
-
-	fullURL := fmt.Sprintf("%s?%s", apiURL, params.Encode())
-
-	// Use telescope's HTTP client
-	resp, err := s.telescope.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
+	return s.telescope.transport.get(context.Background(), "switch", s.config.SwitchDeviceNumber, endpoint, nil)
+}
 
-	return &alpacaResp, nil
+// getWithParams performs an HTTP GET request to a switch endpoint with extra
+// query parameters, such as the switch Id.
+func (s *SwitchClient) getWithParams(endpoint string, params url.Values) (*alpacaResponse, error) {
+	return s.telescope.transport.get(context.Background(), "switch", s.config.SwitchDeviceNumber, endpoint, params)
 }
 
 // put performs an HTTP PUT request to a switch endpoint.
 func (s *SwitchClient) put(endpoint string, params url.Values) (*alpacaResponse, error) {
-	// Build URL using switch device number (typically 0)
-	apiURL := fmt.Sprintf("%s/api/v1/switch/%d/%s",
-		s.config.BaseURL, s.config.SwitchDeviceNumber, endpoint)
-
-	// Make request with form data using telescope's HTTP client
-	resp, err := s.telescope.httpClient.PostForm(apiURL, params)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var alpacaResp alpacaResponse
-	if err := parseAlpacaResponse(resp.Body, &alpacaResp); err != nil {
-		return nil, err
-	}
-
-	return &alpacaResp, nil
+	return s.telescope.transport.put(context.Background(), "switch", s.config.SwitchDeviceNumber, endpoint, params)
 }
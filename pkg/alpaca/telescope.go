@@ -1,22 +1,25 @@
 package alpaca
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
-	"time"
+	"sync"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
 )
 
 // TelescopeClient represents a connection to an ASCOM Alpaca telescope
 type TelescopeClient struct {
-	baseURL      string
 	deviceNumber int
-	clientID     int
-	txnCounter   int
-	httpClient   *http.Client
+	transport    *transport
+
+	// capabilities are probed once and reused to gate commands; tracking
+	// is the last tracking state set, re-applied by RestoreState
+	mu           sync.Mutex
+	capabilities *TelescopeCapabilities
+	tracking     *bool
 }
 
 // TelescopeStatus represents the current status of the telescope
@@ -33,13 +36,18 @@ type TelescopeStatus struct {
 
 // TelescopeCapabilities represents the telescope's capabilities
 type TelescopeCapabilities struct {
-	Description      string   `json:"description"`
-	DriverInfo       string   `json:"driverInfo"`
-	InterfaceVersion int      `json:"interfaceVersion"`
-	CanSetTracking   bool     `json:"canSetTracking"`
-	CanSlew          bool     `json:"canSlew"`
-	CanSlewAltAz     bool     `json:"canSlewAltAz"`
-	SupportedActions []string `json:"supportedActions"`
+	Description       string   `json:"description"`
+	DriverInfo        string   `json:"driverInfo"`
+	InterfaceVersion  int      `json:"interfaceVersion"`
+	CanSetTracking    bool     `json:"canSetTracking"`
+	CanSlew           bool     `json:"canSlew"`
+	CanSlewAsync      bool     `json:"canSlewAsync"`
+	CanSlewAltAz      bool     `json:"canSlewAltAz"`
+	CanSlewAltAzAsync bool     `json:"canSlewAltAzAsync"`
+	CanMoveAxis       bool     `json:"canMoveAxis"` // Both axes
+	CanPark           bool     `json:"canPark"`
+	CanUnpark         bool     `json:"canUnpark"`
+	SupportedActions  []string `json:"supportedActions"`
 }
 
 // AlpacaResponse represents a standard Alpaca API response
@@ -54,98 +62,88 @@ type AlpacaResponse struct {
 // NewTelescopeClient creates a new Alpaca telescope client
 func NewTelescopeClient(baseURL string, deviceNumber int) *TelescopeClient {
 	return &TelescopeClient{
-		baseURL:      baseURL,
 		deviceNumber: deviceNumber,
-		clientID:     1,
-		txnCounter:   0,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		transport:    newTransport(baseURL, 1, DefaultRequestTimeout, DefaultRequestRetries),
 	}
 }
 
-// getTransactionID returns a unique transaction ID
-func (c *TelescopeClient) getTransactionID() int {
-	c.txnCounter++
-	return c.txnCounter
+// SetRequestPolicy applies the request timeout and retry settings from cfg.
+// Call it before making requests.
+func (c *TelescopeClient) SetRequestPolicy(cfg config.TelescopeConfig) {
+	timeout, retries := requestPolicy(cfg)
+	c.transport = newTransport(c.transport.baseURL, c.transport.clientID, timeout, retries)
 }
 
 // get performs a GET request to the Alpaca API
 func (c *TelescopeClient) get(endpoint string) (*AlpacaResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/telescope/%d/%s", c.baseURL, c.deviceNumber, endpoint)
-	
-	// Add query parameters
-	params := url
-	if endpoint != "" {
-		params += fmt.Sprintf("?ClientID=%d&ClientTransactionID=%d", c.clientID, c.getTransactionID())
-	}
-	
-	resp, err := c.httpClient.Get(params)
+	return c.getWithParams(endpoint, nil)
+}
+
+// getWithParams performs a GET request with extra query parameters, for
+// properties such as canmoveaxis that take arguments
+func (c *TelescopeClient) getWithParams(endpoint string, params url.Values) (*AlpacaResponse, error) {
+	resp, err := c.transport.get(context.Background(), "telescope", c.deviceNumber, endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("alpaca GET request failed: %w", err)
 	}
-	defer resp.Body.Close()
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-	
-	var alpacaResp AlpacaResponse
-	if err := json.Unmarshal(body, &alpacaResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-	
-	if alpacaResp.ErrorNumber != 0 {
-		return nil, fmt.Errorf("alpaca error %d: %s", alpacaResp.ErrorNumber, alpacaResp.ErrorMessage)
+	if err := resp.Error(); err != nil {
+		return nil, err
 	}
-	
-	return &alpacaResp, nil
+	return (*AlpacaResponse)(resp), nil
 }
 
 // put performs a PUT request to the Alpaca API
 func (c *TelescopeClient) put(endpoint string, params map[string]string) (*AlpacaResponse, error) {
-	urlStr := fmt.Sprintf("%s/api/v1/telescope/%d/%s", c.baseURL, c.deviceNumber, endpoint)
-	
-	// Build form data
+	return c.putContext(context.Background(), endpoint, params)
+}
+
+// putContext performs a PUT request that is abandoned when ctx is done
+func (c *TelescopeClient) putContext(ctx context.Context, endpoint string, params map[string]string) (*AlpacaResponse, error) {
 	formData := url.Values{}
-	formData.Set("ClientID", strconv.Itoa(c.clientID))
-	formData.Set("ClientTransactionID", strconv.Itoa(c.getTransactionID()))
 	for k, v := range params {
 		formData.Set(k, v)
 	}
 	
-	resp, err := c.httpClient.PostForm(urlStr, formData)
+	resp, err := c.transport.put(ctx, "telescope", c.deviceNumber, endpoint, formData)
 	if err != nil {
 		return nil, fmt.Errorf("alpaca PUT request failed: %w", err)
 	}
-	defer resp.Body.Close()
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	if err := resp.Error(); err != nil {
+		return nil, err
 	}
-	
-	// Handle empty response (some ASCOM commands return no content on success)
-	if len(body) == 0 {
-		// Check HTTP status code
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			// Success with no content - return empty response
-			return &AlpacaResponse{ErrorNumber: 0}, nil
-		}
-		return nil, fmt.Errorf("empty response with status %d", resp.StatusCode)
+	return (*AlpacaResponse)(resp), nil
+}
+
+// Connect sets the telescope's Connected property, (re-)establishing the
+// session, and re-probes its capabilities if they were probed before
+func (c *TelescopeClient) Connect() error {
+	params := map[string]string{
+		"Connected": "true",
 	}
 	
-	var alpacaResp AlpacaResponse
-	if err := json.Unmarshal(body, &alpacaResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w (body: %s)", err, string(body))
+	if _, err := c.put("connected", params); err != nil {
+		return fmt.Errorf("failed to connect to telescope: %w", err)
 	}
 	
-	if alpacaResp.ErrorNumber != 0 {
-		return nil, fmt.Errorf("alpaca error %d: %s", alpacaResp.ErrorNumber, alpacaResp.ErrorMessage)
+	if c.cachedCapabilities() != nil {
+		if _, err := c.ProbeCapabilities(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// RestoreState re-applies the tracking state set through this client, for
+// use after reconnecting to an Alpaca server that restarted
+func (c *TelescopeClient) RestoreState() error {
+	c.mu.Lock()
+	tracking := c.tracking
+	c.mu.Unlock()
 	
-	return &alpacaResp, nil
+	if tracking == nil {
+		return nil
+	}
+	return c.SetTracking(*tracking)
 }
 
 // IsConnected checks if the telescope is connected
@@ -219,13 +217,23 @@ func (c *TelescopeClient) GetStatus() (*TelescopeStatus, error) {
 // SlewToAltAz slews the telescope to the specified altitude and azimuth
 // Uses async slew to return immediately without blocking
 func (c *TelescopeClient) SlewToAltAz(altitude, azimuth float64) error {
+	return c.SlewToAltAzContext(context.Background(), altitude, azimuth)
+}
+
+// SlewToAltAzContext is SlewToAltAz, giving up (including retries) when
+// ctx is done
+func (c *TelescopeClient) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	if caps := c.cachedCapabilities(); caps != nil && !caps.CanSlewAltAzAsync {
+		return unsupported("slew to alt/az", "CanSlewAltAzAsync")
+	}
+	
 	params := map[string]string{
 		"Altitude": fmt.Sprintf("%.6f", altitude),
 		"Azimuth":  fmt.Sprintf("%.6f", azimuth),
 	}
 	
 	// Use async endpoint to avoid blocking until slew completes
-	_, err := c.put("slewtoaltazasync", params)
+	_, err := c.putContext(ctx, "slewtoaltazasync", params)
 	return err
 }
 
@@ -235,46 +243,98 @@ func (c *TelescopeClient) AbortSlew() error {
 	return err
 }
 
+// MoveAxis moves an axis (0 = azimuth, 1 = altitude) at a constant rate in
+// degrees per second; a rate of 0 stops it
+func (c *TelescopeClient) MoveAxis(axis int, rate float64) error {
+	return c.MoveAxisContext(context.Background(), axis, rate)
+}
+
+// MoveAxisContext is MoveAxis, giving up (including retries) when ctx is done
+func (c *TelescopeClient) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	if caps := c.cachedCapabilities(); caps != nil && !caps.CanMoveAxis {
+		return unsupported("move axis", "CanMoveAxis")
+	}
+	
+	params := map[string]string{
+		"Axis": strconv.Itoa(axis),
+		"Rate": fmt.Sprintf("%.6f", rate),
+	}
+	
+	_, err := c.putContext(ctx, "moveaxis", params)
+	return err
+}
+
+// IsSlewing reports whether the telescope is slewing
+func (c *TelescopeClient) IsSlewing() (bool, error) {
+	return c.getBool("slewing")
+}
+
+// GetAltitude returns the telescope's current altitude in degrees
+func (c *TelescopeClient) GetAltitude() (float64, error) {
+	return c.getFloat64("altitude")
+}
+
+// GetAzimuth returns the telescope's current azimuth in degrees
+func (c *TelescopeClient) GetAzimuth() (float64, error) {
+	return c.getFloat64("azimuth")
+}
+
 // SetTracking enables or disables telescope tracking
 func (c *TelescopeClient) SetTracking(enabled bool) error {
+	if caps := c.cachedCapabilities(); caps != nil && !caps.CanSetTracking {
+		return unsupported("set tracking", "CanSetTracking")
+	}
+	
 	params := map[string]string{
 		"Tracking": strconv.FormatBool(enabled),
 	}
 	
-	_, err := c.put("tracking", params)
-	return err
+	if _, err := c.put("tracking", params); err != nil {
+		return err
+	}
+	
+	c.mu.Lock()
+	c.tracking = &enabled
+	c.mu.Unlock()
+	return nil
 }
 
-// GetCapabilities retrieves the telescope's capabilities
+// GetCapabilities returns the telescope's capabilities, probing them on
+// first use and reusing the result afterwards
 func (c *TelescopeClient) GetCapabilities() (*TelescopeCapabilities, error) {
-	description, _ := c.getString("description")
-	driverInfo, _ := c.getString("driverinfo")
-	interfaceVersion, _ := c.getInt("interfaceversion")
-	canSetTracking, _ := c.getBool("cansettracking")
-	canSlew, _ := c.getBool("canslew")
-	canSlewAltAz, _ := c.getBool("canslewaltaz")
-	
-	supportedActionsResp, _ := c.get("supportedactions")
-	var supportedActions []string
-	if supportedActionsResp != nil {
-		if actions, ok := supportedActionsResp.Value.([]interface{}); ok {
-			for _, action := range actions {
-				if str, ok := action.(string); ok {
-					supportedActions = append(supportedActions, str)
-				}
-			}
+	if caps := c.cachedCapabilities(); caps != nil {
+		return caps, nil
+	}
+	return c.ProbeCapabilities()
+}
+
+// ProbeCapabilities queries the telescope's capabilities and caches them.
+// Once cached, commands the telescope cannot perform fail with
+// ErrNotSupported instead of being sent. Call again after swapping drivers.
+func (c *TelescopeClient) ProbeCapabilities() (*TelescopeCapabilities, error) {
+	caps, err := probeCapabilities(func(endpoint string, params url.Values) (interface{}, error) {
+		resp, err := c.getWithParams(endpoint, params)
+		if err != nil {
+			return nil, err
 		}
+		return resp.Value, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	
-	return &TelescopeCapabilities{
-		Description:      description,
-		DriverInfo:       driverInfo,
-		InterfaceVersion: interfaceVersion,
-		CanSetTracking:   canSetTracking,
-		CanSlew:          canSlew,
-		CanSlewAltAz:     canSlewAltAz,
-		SupportedActions: supportedActions,
-	}, nil
+	c.mu.Lock()
+	c.capabilities = caps
+	c.mu.Unlock()
+	return caps, nil
+}
+
+// cachedCapabilities returns the probed capabilities, or nil if they have
+// not been probed (in which case nothing is gated)
+func (c *TelescopeClient) cachedCapabilities() *TelescopeCapabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capabilities
 }
 
 // Helper methods
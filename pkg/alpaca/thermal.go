@@ -0,0 +1,146 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// Ambient is an ambient conditions reading used for thermal compensation.
+type Ambient struct {
+	// TemperatureC is the air temperature in °C
+	TemperatureC float64
+
+	// DewPointC is the dew point in °C (valid only if HasDewPoint)
+	DewPointC float64
+
+	// HasDewPoint is true if the source reported a dew point
+	HasDewPoint bool
+}
+
+// HeaterPower returns the dew heater power (0-1) for the given conditions.
+// Power follows the configured temperature curve, and is forced to full when
+// the temperature is within DewPointMarginC of the dew point.
+func HeaterPower(cfg config.ThermalConfig, amb Ambient) float64 {
+	if amb.HasDewPoint && cfg.DewPointMarginC > 0 &&
+		amb.TemperatureC-amb.DewPointC <= cfg.DewPointMarginC {
+		return 1.0
+	}
+
+	curve := make([]config.HeaterPoint, len(cfg.HeaterCurve))
+	copy(curve, cfg.HeaterCurve)
+	if len(curve) == 0 {
+		return 0
+	}
+	sort.Slice(curve, func(i, j int) bool {
+		return curve[i].TemperatureC < curve[j].TemperatureC
+	})
+
+	clamp := func(p float64) float64 { return math.Max(0, math.Min(1, p)) }
+
+	// Clamp beyond the ends of the curve
+	if amb.TemperatureC <= curve[0].TemperatureC {
+		return clamp(curve[0].Power)
+	}
+	last := curve[len(curve)-1]
+	if amb.TemperatureC >= last.TemperatureC {
+		return clamp(last.Power)
+	}
+
+	// Interpolate between the surrounding points
+	for i := 1; i < len(curve); i++ {
+		lo, hi := curve[i-1], curve[i]
+		if amb.TemperatureC <= hi.TemperatureC {
+			frac := (amb.TemperatureC - lo.TemperatureC) / (hi.TemperatureC - lo.TemperatureC)
+			return clamp(lo.Power + frac*(hi.Power-lo.Power))
+		}
+	}
+
+	return clamp(last.Power)
+}
+
+// CompensatedFocusPosition returns the infinity focus position corrected for
+// temperature: the offset from the reference temperature times FocusStepsPerDegree.
+func CompensatedFocusPosition(infinityPosition int, cfg config.ThermalConfig, temperatureC float64) int {
+	offset := (temperatureC - cfg.FocusReferenceTemperatureC) * cfg.FocusStepsPerDegree
+	return infinityPosition + int(math.Round(offset))
+}
+
+// ThermalResult describes what a ThermalController applied.
+type ThermalResult struct {
+	// Ambient is the reading the adjustment was based on
+	Ambient Ambient
+
+	// HeaterPower is the power sent to the dew heater (-1 if no heater)
+	HeaterPower float64
+
+	// FocusPosition is the compensated focus target (0 if no focuser)
+	FocusPosition int
+
+	// FocusMoved is true if the focuser was moved
+	FocusMoved bool
+}
+
+// ThermalController applies temperature profiles to the dew heater and focuser.
+// Either device may be nil if it is not connected.
+type ThermalController struct {
+	cfg     config.TelescopeConfig
+	heater  *SwitchClient
+	focuser *FocuserClient
+}
+
+// NewThermalController creates a controller for the given devices.
+func NewThermalController(cfg config.TelescopeConfig, heater *SwitchClient, focuser *FocuserClient) *ThermalController {
+	return &ThermalController{
+		cfg:     cfg,
+		heater:  heater,
+		focuser: focuser,
+	}
+}
+
+// Apply sets the dew heater power and compensates focus for the given conditions.
+// Focus is only moved when the correction exceeds FocusDeadbandSteps. Errors
+// from each device are returned together; one failing does not skip the other.
+func (c *ThermalController) Apply(amb Ambient) (ThermalResult, error) {
+	thermal := c.cfg.Thermal
+	result := ThermalResult{Ambient: amb, HeaterPower: -1}
+	var errs []error
+
+	if c.heater != nil {
+		power := HeaterPower(thermal, amb)
+		if err := c.heater.SetDewHeaterPower(power); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set dew heater power: %w", err))
+		} else {
+			result.HeaterPower = power
+		}
+	}
+
+	if c.focuser != nil && c.cfg.InfinityFocusPosition > 0 && thermal.FocusStepsPerDegree != 0 {
+		target := CompensatedFocusPosition(c.cfg.InfinityFocusPosition, thermal, amb.TemperatureC)
+		result.FocusPosition = target
+
+		current, err := c.focuser.GetPosition()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get focuser position: %w", err))
+		} else if abs(target-current) >= thermal.FocusDeadbandSteps && target != current {
+			if err := c.focuser.Move(target); err != nil {
+				errs = append(errs, fmt.Errorf("failed to move focuser: %w", err))
+			} else {
+				result.FocusMoved = true
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// abs returns the absolute value of an integer.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
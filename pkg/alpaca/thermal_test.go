@@ -0,0 +1,74 @@
+package alpaca
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// TestHeaterPower tests the dew heater power curve.
+func TestHeaterPower(t *testing.T) {
+	cfg := config.ThermalConfig{
+		HeaterCurve: []config.HeaterPoint{
+			{TemperatureC: 20, Power: 0},
+			{TemperatureC: 0, Power: 1.0},
+			{TemperatureC: 10, Power: 0.5},
+		},
+		DewPointMarginC: 3,
+	}
+
+	tests := []struct {
+		name  string
+		amb   Ambient
+		power float64
+	}{
+		{"Below curve clamps to first point", Ambient{TemperatureC: -10}, 1.0},
+		{"Above curve clamps to last point", Ambient{TemperatureC: 30}, 0},
+		{"Exact point", Ambient{TemperatureC: 10}, 0.5},
+		{"Interpolated", Ambient{TemperatureC: 15}, 0.25},
+		{"Near dew point forces full power", Ambient{TemperatureC: 18, DewPointC: 16, HasDewPoint: true}, 1.0},
+		{"Dry air follows curve", Ambient{TemperatureC: 15, DewPointC: 2, HasDewPoint: true}, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HeaterPower(cfg, tt.amb)
+			if math.Abs(got-tt.power) > 1e-9 {
+				t.Errorf("Expected power %.2f, got %.2f", tt.power, got)
+			}
+		})
+	}
+
+	t.Run("Empty curve is off", func(t *testing.T) {
+		if got := HeaterPower(config.ThermalConfig{}, Ambient{TemperatureC: 0}); got != 0 {
+			t.Errorf("Expected 0, got %.2f", got)
+		}
+	})
+}
+
+// TestCompensatedFocusPosition tests the focus offset per degree.
+func TestCompensatedFocusPosition(t *testing.T) {
+	cfg := config.ThermalConfig{
+		FocusStepsPerDegree:        -2.5,
+		FocusReferenceTemperatureC: 15,
+	}
+
+	tests := []struct {
+		name string
+		temp float64
+		want int
+	}{
+		{"At reference", 15, 1775},
+		{"Colder", 5, 1800},
+		{"Warmer", 25, 1750},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompensatedFocusPosition(1775, cfg, tt.temp); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package alpaca
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout bounds a single Alpaca request attempt
+	DefaultRequestTimeout = 5 * time.Second
+
+	// DefaultRequestRetries is how many times a request that failed in
+	// transit (dropped connection, timeout, HTTP 5xx) is retried
+	DefaultRequestRetries = 2
+
+	// retryBackoff is the delay before the first retry; it doubles after that
+	retryBackoff = 250 * time.Millisecond
+)
+
+// ASCOM Alpaca error numbers.
+// Reference: https://ascom-standards.org/api/#/ASCOM%20Error%20Codes
+const (
+	AlpacaNotImplemented       = 0x400
+	AlpacaInvalidValue         = 0x401
+	AlpacaValueNotSet          = 0x402
+	AlpacaNotConnected         = 0x407
+	AlpacaInvalidWhileParked   = 0x408
+	AlpacaInvalidWhileSlaved   = 0x409
+	AlpacaInvalidOperation     = 0x40B
+	AlpacaActionNotImplemented = 0x40C
+)
+
+// noRetry lists methods that are not safe to repeat if a response is lost,
+// because the device may already have acted on the first request.
+var noRetry = map[string]bool{
+	"pulseguide": true, // Would nudge twice
+	"move":       true, // Relative on rotators and relative focusers
+}
+
+// synchronous lists methods the server holds open until the device has
+// finished, for drivers without the asynchronous versions. They are not
+// bounded by the per-attempt timeout, which a long slew would outlast, and
+// are not retried, since a lost response may only mean the slew is still
+// running.
+var synchronous = map[string]bool{
+	"slewtoaltaz":       true,
+	"slewtocoordinates": true,
+}
+
+// AlpacaError is an error reported by the device in an Alpaca response.
+type AlpacaError struct {
+	// Number is the ASCOM error number (see the Alpaca* constants)
+	Number int
+
+	// Message is the driver's description of the error
+	Message string
+}
+
+// Error implements error.
+func (e *AlpacaError) Error() string {
+	return fmt.Sprintf("alpaca error %d: %s", e.Number, e.Message)
+}
+
+// Is reports not-implemented errors as ErrNotSupported.
+func (e *AlpacaError) Is(target error) bool {
+	return target == ErrNotSupported &&
+		(e.Number == AlpacaNotImplemented || e.Number == AlpacaActionNotImplemented)
+}
+
+// transport sends Alpaca requests for every device on one server. It owns
+// the ClientID and the ClientTransactionID sequence, bounds each attempt
+// with a timeout, and retries requests that failed in transit. Synchronous
+// slews are exempt from both.
+type transport struct {
+	baseURL    string
+	httpClient *http.Client
+	clientID   int
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+
+	// lastTxn is the last ClientTransactionID issued
+	lastTxn atomic.Uint32
+}
+
+// newTransport creates a transport for the Alpaca server at baseURL. A zero
+// timeout selects the default.
+func newTransport(baseURL string, clientID int, timeout time.Duration, retries int) *transport {
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+	if retries < 0 {
+		retries = 0
+	}
+	return &transport{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{},
+		clientID:   clientID,
+		timeout:    timeout,
+		retries:    retries,
+		backoff:    retryBackoff,
+	}
+}
+
+// nextTransactionID returns the next ClientTransactionID. IDs start at 1
+// and wrap back to 1 before overflowing a 32-bit signed integer.
+func (t *transport) nextTransactionID() int {
+	for {
+		last := t.lastTxn.Load()
+		next := last + 1
+		if next > math.MaxInt32 {
+			next = 1
+		}
+		if t.lastTxn.CompareAndSwap(last, next) {
+			return int(next)
+		}
+	}
+}
+
+// get reads a device property. GETs are always safe to retry.
+func (t *transport) get(ctx context.Context, device string, number int, endpoint string, params url.Values) (*alpacaResponse, error) {
+	return t.call(ctx, http.MethodGet, device, number, endpoint, params)
+}
+
+// put executes a device method or sets a property.
+func (t *transport) put(ctx context.Context, device string, number int, endpoint string, params url.Values) (*alpacaResponse, error) {
+	return t.call(ctx, http.MethodPut, device, number, endpoint, params)
+}
+
+// call sends a request, retrying failures in transit with exponential
+// backoff. Errors reported by the device are returned in the response, not
+// retried.
+func (t *transport) call(ctx context.Context, method, device string, number int, endpoint string, params url.Values) (*alpacaResponse, error) {
+	apiURL := fmt.Sprintf("%s/api/v1/%s/%d/%s", t.baseURL, device, number, endpoint)
+
+	attempts := 1 + t.retries
+	if method != http.MethodGet && (noRetry[endpoint] || synchronous[endpoint]) {
+		attempts = 1
+	}
+	timeout := t.timeout
+	if method != http.MethodGet && synchronous[endpoint] {
+		timeout = 0
+	}
+
+	delay := t.backoff
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%s %s: %w (last error: %v)", method, endpoint, ctx.Err(), lastErr)
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		resp, err := t.attempt(ctx, method, apiURL, params, timeout)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		var transient *transientError
+		if !errors.As(err, &transient) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if attempts > 1 {
+		return nil, fmt.Errorf("%s %s failed after %d attempts: %w", method, endpoint, attempts, lastErr)
+	}
+	return nil, fmt.Errorf("%s %s: %w", method, endpoint, lastErr)
+}
+
+// attempt sends one request with a fresh transaction ID, abandoning it
+// after timeout unless that is 0.
+func (t *transport) attempt(ctx context.Context, method, apiURL string, params url.Values, timeout time.Duration) (*alpacaResponse, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	txnID := t.nextTransactionID()
+	values := url.Values{}
+	for key, v := range params {
+		values[key] = v
+	}
+	values.Set("ClientID", strconv.Itoa(t.clientID))
+	values.Set("ClientTransactionID", strconv.Itoa(txnID))
+
+	var req *http.Request
+	var err error
+	if method == http.MethodGet {
+		req, err = http.NewRequestWithContext(ctx, method, apiURL+"?"+values.Encode(), nil)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, apiURL, strings.NewReader(values.Encode()))
+		if req != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, &transientError{err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &transientError{fmt.Errorf("failed to read response: %w", err)}
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		return nil, &transientError{fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))}
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	case len(body) == 0:
+		// Some ASCOM commands return no content on success
+		return &alpacaResponse{ClientTransactionID: txnID}, nil
+	}
+
+	var alpacaResp alpacaResponse
+	if err := json.Unmarshal(body, &alpacaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// A reply to a different transaction means responses are crossed
+	if alpacaResp.ClientTransactionID != 0 && alpacaResp.ClientTransactionID != txnID {
+		return nil, fmt.Errorf("response is for transaction %d, expected %d",
+			alpacaResp.ClientTransactionID, txnID)
+	}
+
+	return &alpacaResp, nil
+}
+
+// transientError marks a failure in transit that is worth retrying.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
@@ -0,0 +1,137 @@
+package alpaca
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer starts an Alpaca server that answers HTTP 503 to the first
+// failures requests and echoes the transaction ID after that.
+func newFlakyServer(t *testing.T, failures int32, errorNumber int) (*transport, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			http.Error(w, "driver busy", http.StatusServiceUnavailable)
+			return
+		}
+		r.ParseForm()
+		txnID, _ := strconv.Atoi(r.Form.Get("ClientTransactionID"))
+		json.NewEncoder(w).Encode(AlpacaResponse{
+			Value:               true,
+			ClientTransactionID: txnID,
+			ErrorNumber:         errorNumber,
+			ErrorMessage:        "test error",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	tr := newTransport(server.URL, 1, time.Second, 2)
+	tr.backoff = time.Millisecond
+	return tr, &requests
+}
+
+// TestTransactionIDs tests that transaction IDs count up from 1 and wrap
+// before overflowing a 32-bit signed integer.
+func TestTransactionIDs(t *testing.T) {
+	tr := newTransport("http://localhost", 1, 0, 0)
+	for want := 1; want <= 3; want++ {
+		if got := tr.nextTransactionID(); got != want {
+			t.Errorf("nextTransactionID() = %d, want %d", got, want)
+		}
+	}
+
+	tr.lastTxn.Store(2147483647)
+	if got := tr.nextTransactionID(); got != 1 {
+		t.Errorf("nextTransactionID() after max = %d, want 1", got)
+	}
+}
+
+// TestTransportRetries tests that failures in transit are retried up to the
+// limit, except for methods that are unsafe to repeat.
+func TestTransportRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		endpoint     string
+		failures     int32
+		wantErr      bool
+		wantRequests int32
+	}{
+		{"GET recovers", http.MethodGet, "altitude", 2, false, 3},
+		{"GET gives up", http.MethodGet, "altitude", 3, true, 3},
+		{"PUT recovers", http.MethodPut, "moveaxis", 1, false, 2},
+		{"Pulse guide not retried", http.MethodPut, "pulseguide", 1, true, 1},
+		{"Sync slew not retried", http.MethodPut, "slewtoaltaz", 1, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, requests := newFlakyServer(t, tt.failures, 0)
+
+			_, err := tr.call(context.Background(), tt.method, "telescope", 0, tt.endpoint, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("call error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("Requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+// TestTransportTimeout tests that a hung request is abandoned after the
+// per-attempt timeout.
+func TestTransportTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	tr := newTransport(server.URL, 1, 20*time.Millisecond, 1)
+	tr.backoff = time.Millisecond
+
+	start := time.Now()
+	if _, err := tr.get(context.Background(), "telescope", 0, "altitude", nil); err == nil {
+		t.Fatal("Expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Took %v to give up, want about 40ms", elapsed)
+	}
+}
+
+// TestAlpacaErrorNumbers tests that device errors are typed, are not
+// retried, and that not-implemented errors match ErrNotSupported.
+func TestAlpacaErrorNumbers(t *testing.T) {
+	tr, requests := newFlakyServer(t, 0, AlpacaNotImplemented)
+
+	resp, err := tr.get(context.Background(), "telescope", 0, "canmoveaxis", nil)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Requests = %d, want 1", got)
+	}
+
+	err = resp.Error()
+	var alpacaErr *AlpacaError
+	if !errors.As(err, &alpacaErr) || alpacaErr.Number != AlpacaNotImplemented {
+		t.Fatalf("Error() = %v, want AlpacaError %#x", err, AlpacaNotImplemented)
+	}
+	if !errors.Is(err, ErrNotSupported) {
+		t.Error("Not implemented error does not match ErrNotSupported")
+	}
+	if errors.Is(&AlpacaError{Number: AlpacaInvalidWhileParked}, ErrNotSupported) {
+		t.Error("Parked error matches ErrNotSupported")
+	}
+}
@@ -0,0 +1,326 @@
+// Package autotrack chooses telescope targets automatically from declarative
+// rules, e.g. "anything above 40° elevation within 20 nm, prefer heavies".
+package autotrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Rule describes which aircraft are worth tracking. All set criteria must
+// match; zero values mean "any".
+type Rule struct {
+	// Name identifies the rule in logs
+	Name string `json:"name"`
+
+	// MinElevation and MaxElevation bound the target's elevation (degrees).
+	// The telescope's own altitude limits always apply as well.
+	MinElevation float64 `json:"min_elevation"`
+	MaxElevation float64 `json:"max_elevation,omitempty"`
+
+	// MaxRangeNM is the maximum distance from the observer
+	MaxRangeNM float64 `json:"max_range_nm,omitempty"`
+
+	// MinAltitudeFt and MaxAltitudeFt bound the aircraft's altitude (feet MSL)
+	MinAltitudeFt units.Feet `json:"min_altitude_ft,omitempty"`
+	MaxAltitudeFt units.Feet `json:"max_altitude_ft,omitempty"`
+
+	// CallsignPrefixes limits the rule to callsigns starting with one of
+	// these (e.g. airline designators "UAL", "DAL")
+	CallsignPrefixes []string `json:"callsign_prefixes,omitempty"`
+
+	// AircraftTypes limits the rule to these ICAO type designators (from the
+	// flight plan, e.g. "B77W", "A388")
+	AircraftTypes []string `json:"aircraft_types,omitempty"`
+
+	// PreferHeavy ranks heavy aircraft (wide-bodies) above others
+	PreferHeavy bool `json:"prefer_heavy,omitempty"`
+
+	// Priority ranks rules: a match on a higher priority rule always wins
+	Priority int `json:"priority"`
+}
+
+// RuleSet is the autotracker's rules file.
+type RuleSet struct {
+	// Rules are evaluated together; the best scoring match is tracked
+	Rules []Rule `json:"rules"`
+
+	// MaxTrackMinutes ends a track after this long (0 = until the target is lost)
+	MaxTrackMinutes float64 `json:"max_track_minutes,omitempty"`
+
+	// CooldownMinutes is how long before an aircraft already tracked can be
+	// picked again (default: 30)
+	CooldownMinutes float64 `json:"cooldown_minutes,omitempty"`
+
+	// CaptureCommand is run when a target culminates, e.g. a script that
+	// triggers the camera. Target details are passed in ADS_BSCOPE_*
+	// environment variables.
+	CaptureCommand string `json:"capture_command,omitempty"`
+
+	// CaptureExposureMS overrides the suggested exposure passed to the
+	// capture command, in milliseconds, by twilight phase ("day", "civil",
+	// "nautical", "astronomical" or "night")
+	CaptureExposureMS map[string]float64 `json:"capture_exposure_ms,omitempty"`
+
+	// TimeSlice enables the experimental time-sliced mode (nil = off)
+	TimeSlice *TimeSlice `json:"time_slice,omitempty"`
+
+	// Scoring ranks matches of rules with the same priority (default: by
+	// elevation)
+	Scoring ScoringConfig `json:"scoring,omitempty"`
+
+	// scorer ranks matches; ElevationScorer if nil (see UseScorer)
+	scorer Scorer
+}
+
+// LoadRules reads and validates a rules file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var rules RuleSet
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// Validate checks the rules for obvious mistakes.
+func (rs *RuleSet) Validate() error {
+	if len(rs.Rules) == 0 {
+		return errors.New("rules file has no rules")
+	}
+	for i, r := range rs.Rules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if r.MaxElevation > 0 && r.MaxElevation < r.MinElevation {
+			return fmt.Errorf("rule %s: max_elevation is below min_elevation", name)
+		}
+		if r.MaxAltitudeFt > 0 && r.MaxAltitudeFt < r.MinAltitudeFt {
+			return fmt.Errorf("rule %s: max_altitude_ft is below min_altitude_ft", name)
+		}
+	}
+	for phase, ms := range rs.CaptureExposureMS {
+		if _, ok := coordinates.ParseTwilightPhase(phase); !ok {
+			return fmt.Errorf("capture_exposure_ms: unknown twilight phase %q", phase)
+		}
+		if ms <= 0 {
+			return fmt.Errorf("capture_exposure_ms: %s exposure must be positive", phase)
+		}
+	}
+	if err := rs.Scoring.Validate(); err != nil {
+		return err
+	}
+	if ts := rs.TimeSlice; ts != nil {
+		if ts.Targets < 2 || ts.Targets > MaxTimeSliceTargets {
+			return fmt.Errorf("time_slice: targets must be between 2 and %d", MaxTimeSliceTargets)
+		}
+		if ts.DwellSeconds < 0 {
+			return errors.New("time_slice: dwell_seconds is negative")
+		}
+	}
+	return nil
+}
+
+// GetCooldown returns how long to wait before re-tracking an aircraft.
+// Returns 30 minutes if not configured.
+func (rs *RuleSet) GetCooldown() time.Duration {
+	if rs.CooldownMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(rs.CooldownMinutes * float64(time.Minute))
+}
+
+// GetMaxTrackDuration returns the longest a single target is tracked, or 0
+// for no limit.
+func (rs *RuleSet) GetMaxTrackDuration() time.Duration {
+	if rs.MaxTrackMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(rs.MaxTrackMinutes * float64(time.Minute))
+}
+
+// defaultCaptureExposureMS are the suggested exposures by twilight phase:
+// short enough in daylight to freeze a fast-moving aircraft, longer as the
+// sky darkens and only its lights remain.
+var defaultCaptureExposureMS = map[coordinates.TwilightPhase]float64{
+	coordinates.PhaseDay:                  0.5,
+	coordinates.PhaseCivilTwilight:        2,
+	coordinates.PhaseNauticalTwilight:     10,
+	coordinates.PhaseAstronomicalTwilight: 25,
+	coordinates.PhaseNight:                25,
+}
+
+// GetCaptureExposure returns the suggested capture exposure for a twilight
+// phase, from capture_exposure_ms or the built-in defaults. Defaults are
+// shortened under a bright sky (see planner.SkyBrightness.ExposureScale);
+// configured exposures are used as they are.
+func (rs *RuleSet) GetCaptureExposure(phase coordinates.TwilightPhase, sky planner.SkyBrightness) time.Duration {
+	ms, ok := rs.CaptureExposureMS[phase.String()]
+	if !ok {
+		ms = defaultCaptureExposureMS[phase] * sky.ExposureScale(phase)
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// Candidate is an aircraft the rules are evaluated against.
+type Candidate struct {
+	Aircraft adsb.Aircraft
+
+	// AircraftType is the ICAO type designator from the flight plan ("" if unknown)
+	AircraftType string
+
+	// Horizontal is the aircraft's position in the sky
+	Horizontal coordinates.HorizontalCoordinates
+
+	// RangeNM is the distance from the observer
+	RangeNM float64
+}
+
+// Matches reports whether a candidate satisfies every criterion of the rule.
+func (r Rule) Matches(c Candidate) bool {
+	elevation := c.Horizontal.Altitude
+	if elevation < r.MinElevation {
+		return false
+	}
+	if r.MaxElevation > 0 && elevation > r.MaxElevation {
+		return false
+	}
+	if r.MaxRangeNM > 0 && c.RangeNM > r.MaxRangeNM {
+		return false
+	}
+	if c.Aircraft.Altitude < r.MinAltitudeFt {
+		return false
+	}
+	if r.MaxAltitudeFt > 0 && c.Aircraft.Altitude > r.MaxAltitudeFt {
+		return false
+	}
+
+	if len(r.CallsignPrefixes) > 0 {
+		callsign := strings.ToUpper(strings.TrimSpace(c.Aircraft.Callsign))
+		matched := false
+		for _, prefix := range r.CallsignPrefixes {
+			if strings.HasPrefix(callsign, strings.ToUpper(prefix)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(r.AircraftTypes) > 0 {
+		matched := false
+		for _, t := range r.AircraftTypes {
+			if strings.EqualFold(t, c.AircraftType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Score ranks a matching candidate: rule priority first, then heavies (if
+// preferred), then higher elevation (better seeing, slower apparent motion).
+func (r Rule) Score(c Candidate) float64 {
+	return r.scoreWith(c, ElevationScorer{})
+}
+
+// scoreWith ranks a matching candidate like Score, ranking candidates the
+// rule's priority and heavy preference don't separate with scorer.
+func (r Rule) scoreWith(c Candidate, scorer Scorer) float64 {
+	score := float64(r.Priority)*1000 + scorer.Score(c)
+	if r.PreferHeavy && IsHeavy(c.AircraftType) {
+		score += 500
+	}
+	return score
+}
+
+// UseScorer ranks matches with a scorer, e.g. the one configured in Scoring
+// (see ScoringConfig.NewScorer), instead of by elevation.
+func (rs *RuleSet) UseScorer(scorer Scorer) {
+	rs.scorer = scorer
+}
+
+// NeedsAircraftType reports whether any rule looks at the aircraft type, so
+// callers can skip flight plan lookups when none does.
+func (rs *RuleSet) NeedsAircraftType() bool {
+	for _, r := range rs.Rules {
+		if len(r.AircraftTypes) > 0 || r.PreferHeavy {
+			return true
+		}
+	}
+	return rs.Scoring.usesType()
+}
+
+// Select returns the best candidate and the rule it matched. Candidates for
+// which skip returns true (e.g. recently tracked) are ignored.
+func (rs *RuleSet) Select(candidates []Candidate, skip func(icao string) bool) (Candidate, Rule, bool) {
+	var best Candidate
+	var bestRule Rule
+	bestScore := 0.0
+	found := false
+
+	scorer := rs.scorer
+	if scorer == nil {
+		scorer = ElevationScorer{}
+	}
+	if o, ok := scorer.(Observer); ok {
+		o.Observe(candidates)
+	}
+
+	for _, c := range candidates {
+		if skip != nil && skip(c.Aircraft.ICAO) {
+			continue
+		}
+		for _, r := range rs.Rules {
+			if !r.Matches(c) {
+				continue
+			}
+			if score := r.scoreWith(c, scorer); !found || score > bestScore {
+				best, bestRule, bestScore, found = c, r, score, true
+			}
+		}
+	}
+
+	return best, bestRule, found
+}
+
+// heavyTypes are common ICAO type designators in the heavy wake category.
+var heavyTypes = map[string]bool{
+	"A306": true, "A310": true, "A332": true, "A333": true, "A338": true, "A339": true,
+	"A342": true, "A343": true, "A345": true, "A346": true, "A359": true, "A35K": true,
+	"A388": true, "B742": true, "B744": true, "B748": true, "B762": true, "B763": true,
+	"B764": true, "B772": true, "B773": true, "B77L": true, "B77W": true, "B778": true,
+	"B779": true, "B788": true, "B789": true, "B78X": true, "MD11": true, "DC10": true,
+	"C5M": true, "C17": true, "KC10": true, "K35R": true, "IL76": true, "AN12": true,
+	"A400": true, "A124": true,
+}
+
+// IsHeavy reports whether an ICAO type designator is a heavy (wide-body) aircraft.
+func IsHeavy(aircraftType string) bool {
+	return heavyTypes[strings.ToUpper(aircraftType)]
+}
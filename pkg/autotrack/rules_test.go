@@ -0,0 +1,172 @@
+package autotrack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/planner"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// candidate builds a test candidate.
+func candidate(icao, callsign, aircraftType string, elevation, rangeNM, altitudeFt float64) Candidate {
+	return Candidate{
+		Aircraft:     adsb.Aircraft{ICAO: icao, Callsign: callsign, Altitude: units.Feet(altitudeFt)},
+		AircraftType: aircraftType,
+		Horizontal:   coordinates.HorizontalCoordinates{Altitude: elevation},
+		RangeNM:      rangeNM,
+	}
+}
+
+// TestRuleMatches tests each rule criterion.
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		c    Candidate
+		want bool
+	}{
+		{"Empty rule matches anything", Rule{}, candidate("A", "", "", 10, 50, 5000), true},
+		{"Below min elevation", Rule{MinElevation: 40}, candidate("A", "", "", 35, 5, 5000), false},
+		{"Above max elevation", Rule{MaxElevation: 60}, candidate("A", "", "", 70, 5, 5000), false},
+		{"Too far", Rule{MaxRangeNM: 20}, candidate("A", "", "", 50, 25, 5000), false},
+		{"Within range", Rule{MinElevation: 40, MaxRangeNM: 20}, candidate("A", "", "", 50, 15, 5000), true},
+		{"Too low", Rule{MinAltitudeFt: 10000}, candidate("A", "", "", 50, 5, 5000), false},
+		{"Too high", Rule{MaxAltitudeFt: 10000}, candidate("A", "", "", 50, 5, 35000), false},
+		{"Callsign prefix", Rule{CallsignPrefixes: []string{"ual", "DAL"}}, candidate("A", "DAL123", "", 50, 5, 5000), true},
+		{"Wrong callsign prefix", Rule{CallsignPrefixes: []string{"UAL"}}, candidate("A", "DAL123", "", 50, 5, 5000), false},
+		{"Aircraft type", Rule{AircraftTypes: []string{"B77W"}}, candidate("A", "", "b77w", 50, 5, 5000), true},
+		{"Unknown aircraft type", Rule{AircraftTypes: []string{"B77W"}}, candidate("A", "", "", 50, 5, 5000), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.c); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRuleSetSelect tests choosing the best target.
+func TestRuleSetSelect(t *testing.T) {
+	rules := RuleSet{Rules: []Rule{
+		{Name: "overhead heavies", MinElevation: 40, MaxRangeNM: 20, PreferHeavy: true, Priority: 1},
+		{Name: "anything", MinElevation: 20},
+	}}
+
+	candidates := []Candidate{
+		candidate("LOW", "N123AB", "C172", 25, 10, 3000),
+		candidate("HIGH", "SWA1", "B738", 70, 5, 20000),
+		candidate("HEAVY", "UAL9", "B77W", 45, 18, 30000),
+	}
+
+	t.Run("Heavy preferred over higher elevation", func(t *testing.T) {
+		got, rule, ok := rules.Select(candidates, nil)
+		if !ok || got.Aircraft.ICAO != "HEAVY" || rule.Name != "overhead heavies" {
+			t.Errorf("Select() = %s (%s), want HEAVY (overhead heavies)", got.Aircraft.ICAO, rule.Name)
+		}
+	})
+
+	t.Run("Skipped aircraft are ignored", func(t *testing.T) {
+		got, _, ok := rules.Select(candidates, func(icao string) bool { return icao == "HEAVY" })
+		if !ok || got.Aircraft.ICAO != "HIGH" {
+			t.Errorf("Select() = %s, want HIGH", got.Aircraft.ICAO)
+		}
+	})
+
+	t.Run("Lower priority rule as fallback", func(t *testing.T) {
+		got, rule, ok := rules.Select(candidates[:1], nil)
+		if !ok || got.Aircraft.ICAO != "LOW" || rule.Name != "anything" {
+			t.Errorf("Select() = %s (%s), want LOW (anything)", got.Aircraft.ICAO, rule.Name)
+		}
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		if _, _, ok := rules.Select([]Candidate{candidate("X", "", "", 5, 5, 5000)}, nil); ok {
+			t.Error("Select() found a target below every rule's elevation")
+		}
+	})
+}
+
+// TestLoadRules tests reading and validating a rules file.
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("Valid file", func(t *testing.T) {
+		path := write("valid.json", `{"rules": [{"name": "overhead", "min_elevation": 40, "max_range_nm": 20, "prefer_heavy": true}], "cooldown_minutes": 15}`)
+		rules, err := LoadRules(path)
+		if err != nil {
+			t.Fatalf("LoadRules failed: %v", err)
+		}
+		if len(rules.Rules) != 1 || rules.Rules[0].MinElevation != 40 || rules.CooldownMinutes != 15 {
+			t.Errorf("LoadRules() = %+v", rules)
+		}
+		if !rules.NeedsAircraftType() {
+			t.Error("NeedsAircraftType() = false with prefer_heavy set")
+		}
+	})
+
+	t.Run("No rules", func(t *testing.T) {
+		if _, err := LoadRules(write("empty.json", `{"rules": []}`)); err == nil {
+			t.Error("expected error for empty rules")
+		}
+	})
+
+	t.Run("Inverted elevation", func(t *testing.T) {
+		if _, err := LoadRules(write("bad.json", `{"rules": [{"min_elevation": 60, "max_elevation": 30}]}`)); err == nil {
+			t.Error("expected error for max_elevation below min_elevation")
+		}
+	})
+
+	t.Run("Time slice", func(t *testing.T) {
+		rules, err := LoadRules(write("slice.json", `{"rules": [{"min_elevation": 30}], "time_slice": {"targets": 3}}`))
+		if err != nil {
+			t.Fatalf("LoadRules failed: %v", err)
+		}
+		if rules.GetTimeSliceTargets() != 3 || rules.GetDwell() != 20*time.Second {
+			t.Errorf("GetTimeSliceTargets() = %d, GetDwell() = %v, want 3 and 20s", rules.GetTimeSliceTargets(), rules.GetDwell())
+		}
+	})
+
+	t.Run("Too many time slice targets", func(t *testing.T) {
+		if _, err := LoadRules(write("slice-bad.json", `{"rules": [{"min_elevation": 30}], "time_slice": {"targets": 5}}`)); err == nil {
+			t.Error("expected error for more than 3 time slice targets")
+		}
+	})
+
+	t.Run("Capture exposures", func(t *testing.T) {
+		rules, err := LoadRules(write("exposure.json", `{"rules": [{"min_elevation": 30}], "capture_exposure_ms": {"night": 40}}`))
+		if err != nil {
+			t.Fatalf("LoadRules failed: %v", err)
+		}
+		city := planner.SkyFromBortle(8)
+		if got := rules.GetCaptureExposure(coordinates.PhaseNight, city); got != 40*time.Millisecond {
+			t.Errorf("Night exposure = %v, want configured 40ms", got)
+		}
+		if got := rules.GetCaptureExposure(coordinates.PhaseDay, city); got != 500*time.Microsecond {
+			t.Errorf("Day exposure = %v, want default 500µs", got)
+		}
+		if got := rules.GetCaptureExposure(coordinates.PhaseAstronomicalTwilight, city); got >= 25*time.Millisecond {
+			t.Errorf("Astronomical twilight exposure under a city sky = %v, want shorter than the default 25ms", got)
+		}
+	})
+
+	t.Run("Unknown exposure phase", func(t *testing.T) {
+		if _, err := LoadRules(write("exposure-bad.json", `{"rules": [{"min_elevation": 30}], "capture_exposure_ms": {"dusk": 40}}`)); err == nil {
+			t.Error("expected error for unknown twilight phase")
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package autotrack
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// Scoring strategies
+const (
+	// ScoreElevation prefers the highest aircraft: better seeing, slower
+	// apparent motion (the default)
+	ScoreElevation = "elevation"
+
+	// ScoreClosestApproach prefers aircraft that will pass closest to the
+	// observer
+	ScoreClosestApproach = "closest_approach"
+
+	// ScoreLongestVisibility prefers aircraft that will stay within the
+	// altitude limits longest
+	ScoreLongestVisibility = "longest_visibility"
+
+	// ScoreRarestType prefers aircraft types seen least often
+	ScoreRarestType = "rarest_type"
+
+	// ScoreWeighted combines the other strategies by configured weights
+	ScoreWeighted = "weighted"
+)
+
+// MaxScore bounds the scores of every strategy, so a rule's priority always
+// outranks them.
+const MaxScore = 100.0
+
+// visibilityHorizon is how far ahead ScoreLongestVisibility looks; staying
+// within the limits that long scores MaxScore
+const visibilityHorizon = 30 * time.Minute
+
+// approachRangeNM is the closest approach scoring 0 with
+// ScoreClosestApproach; passing overhead scores MaxScore
+const approachRangeNM = 50.0
+
+// Scorer ranks candidates that match a rule, from 0 to MaxScore; higher is
+// better.
+type Scorer interface {
+	Score(c Candidate) float64
+}
+
+// Observer is implemented by scorers that learn from the candidates seen,
+// such as RarityScorer. RuleSet.Select shows them all candidates before
+// scoring any.
+type Observer interface {
+	Observe(candidates []Candidate)
+}
+
+// ScoringConfig selects the strategy that ranks candidates matching rules
+// of the same priority.
+type ScoringConfig struct {
+	// Strategy is one of ScoreElevation (default), ScoreClosestApproach,
+	// ScoreLongestVisibility, ScoreRarestType or ScoreWeighted
+	Strategy string `json:"strategy,omitempty"`
+
+	// Weights are the weighted strategy's weights, by strategy name, e.g.
+	// {"elevation": 1, "rarest_type": 2}
+	Weights map[string]float64 `json:"weights,omitempty"`
+}
+
+// Validate checks the strategy and weights are known.
+func (cfg ScoringConfig) Validate() error {
+	switch cfg.Strategy {
+	case "", ScoreElevation, ScoreClosestApproach, ScoreLongestVisibility, ScoreRarestType:
+		return nil
+	case ScoreWeighted:
+	default:
+		return fmt.Errorf("scoring: unknown strategy %q", cfg.Strategy)
+	}
+
+	total := 0.0
+	for name, weight := range cfg.Weights {
+		switch name {
+		case ScoreElevation, ScoreClosestApproach, ScoreLongestVisibility, ScoreRarestType:
+		default:
+			return fmt.Errorf("scoring: unknown weight %q", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("scoring: %s weight is negative", name)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("scoring: the weighted strategy needs weights")
+	}
+	return nil
+}
+
+// usesType reports whether the strategy looks at the aircraft type.
+func (cfg ScoringConfig) usesType() bool {
+	return cfg.Strategy == ScoreRarestType ||
+		(cfg.Strategy == ScoreWeighted && cfg.Weights[ScoreRarestType] > 0)
+}
+
+// NewScorer creates the configured strategy's scorer. Predictions are made
+// for the observer and altitude limits, from the current time.
+func (cfg ScoringConfig) NewScorer(observer coordinates.Observer, limits tracking.TrackingLimits) (Scorer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Strategy != ScoreWeighted {
+		return newScorer(cfg.Strategy, observer, limits), nil
+	}
+
+	weighted := &WeightedScorer{}
+	for name, weight := range cfg.Weights {
+		if weight > 0 {
+			weighted.Add(newScorer(name, observer, limits), weight)
+		}
+	}
+	return weighted, nil
+}
+
+// newScorer creates a built-in strategy's scorer.
+func newScorer(strategy string, observer coordinates.Observer, limits tracking.TrackingLimits) Scorer {
+	switch strategy {
+	case ScoreClosestApproach:
+		return &ClosestApproachScorer{Observer: observer, Limits: limits}
+	case ScoreLongestVisibility:
+		return &VisibilityScorer{Observer: observer, Limits: limits}
+	case ScoreRarestType:
+		return NewRarityScorer()
+	default:
+		return ElevationScorer{}
+	}
+}
+
+// ElevationScorer scores candidates by elevation.
+type ElevationScorer struct{}
+
+// Score returns the candidate's elevation, scaled to MaxScore at the zenith.
+func (ElevationScorer) Score(c Candidate) float64 {
+	return math.Max(0, math.Min(c.Horizontal.Altitude, 90)) * MaxScore / 90
+}
+
+// ClosestApproachScorer scores candidates by how close to the observer their
+// current track takes them.
+type ClosestApproachScorer struct {
+	Observer coordinates.Observer
+	Limits   tracking.TrackingLimits
+
+	// now returns the time predictions start from; time.Now if nil
+	now func() time.Time
+}
+
+// Score returns MaxScore for an aircraft passing overhead, falling to 0 at
+// approachRangeNM.
+func (s *ClosestApproachScorer) Score(c Candidate) float64 {
+	approach := tracking.PredictApproach(c.Aircraft, s.Observer, clock(s.now), s.Limits)
+	return math.Max(0, 1-approach.ClosestRangeNM/approachRangeNM) * MaxScore
+}
+
+// VisibilityScorer scores candidates by how long they stay within the
+// altitude limits.
+type VisibilityScorer struct {
+	Observer coordinates.Observer
+	Limits   tracking.TrackingLimits
+
+	// now returns the time predictions start from; time.Now if nil
+	now func() time.Time
+}
+
+// Score returns MaxScore for an aircraft staying within the limits for
+// visibilityHorizon or more, in proportion for less.
+func (s *VisibilityScorer) Score(c Candidate) float64 {
+	window, ok := tracking.PredictLimitWindow(c.Aircraft, s.Observer, clock(s.now), visibilityHorizon, s.Limits)
+	if !ok {
+		return 0
+	}
+	visible := visibilityHorizon - window.Enter
+	if window.Leaves {
+		visible = window.Leave - window.Enter
+	}
+	return math.Max(0, visible.Seconds()/visibilityHorizon.Seconds()) * MaxScore
+}
+
+// RarityScorer scores candidates by how rarely their aircraft type has been
+// seen: it counts the aircraft of each type among the candidates observed.
+type RarityScorer struct {
+	mu   sync.Mutex
+	seen map[string]map[string]bool // Type -> ICAO addresses
+}
+
+// NewRarityScorer creates a scorer that hasn't seen any aircraft yet.
+func NewRarityScorer() *RarityScorer {
+	return &RarityScorer{seen: make(map[string]map[string]bool)}
+}
+
+// Observe counts the candidates' aircraft by type.
+func (s *RarityScorer) Observe(candidates []Candidate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range candidates {
+		aircraftType := normalizeType(c.AircraftType)
+		if aircraftType == "" {
+			continue
+		}
+		aircraft := s.seen[aircraftType]
+		if aircraft == nil {
+			aircraft = make(map[string]bool)
+			s.seen[aircraftType] = aircraft
+		}
+		aircraft[c.Aircraft.ICAO] = true
+	}
+}
+
+// Score returns MaxScore for a type seen once (or not yet), halving as the
+// type is seen twice as often. Candidates of unknown type score 0.
+func (s *RarityScorer) Score(c Candidate) float64 {
+	aircraftType := normalizeType(c.AircraftType)
+	if aircraftType == "" {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return MaxScore / float64(max(len(s.seen[aircraftType]), 1))
+}
+
+// normalizeType normalizes an ICAO type designator for counting.
+func normalizeType(aircraftType string) string {
+	return strings.ToUpper(strings.TrimSpace(aircraftType))
+}
+
+// WeightedScorer combines scorers by weight.
+type WeightedScorer struct {
+	scorers []Scorer
+	weights []float64
+}
+
+// Add adds a scorer with a weight.
+func (s *WeightedScorer) Add(scorer Scorer, weight float64) {
+	s.scorers = append(s.scorers, scorer)
+	s.weights = append(s.weights, weight)
+}
+
+// Observe shows the candidates to the scorers that learn from them.
+func (s *WeightedScorer) Observe(candidates []Candidate) {
+	for _, scorer := range s.scorers {
+		if o, ok := scorer.(Observer); ok {
+			o.Observe(candidates)
+		}
+	}
+}
+
+// Score returns the weighted average of the scorers' scores.
+func (s *WeightedScorer) Score(c Candidate) float64 {
+	score, total := 0.0, 0.0
+	for i, scorer := range s.scorers {
+		score += scorer.Score(c) * s.weights[i]
+		total += s.weights[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	return score / total
+}
+
+// clock returns now(), or the current time if now is nil.
+func clock(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
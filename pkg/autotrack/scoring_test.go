@@ -0,0 +1,122 @@
+package autotrack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// TestScoringConfigValidate tests rejecting unknown strategies and weights.
+func TestScoringConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ScoringConfig
+		wantErr bool
+	}{
+		{"Default", ScoringConfig{}, false},
+		{"Built-in", ScoringConfig{Strategy: ScoreRarestType}, false},
+		{"Unknown strategy", ScoringConfig{Strategy: "loudest"}, true},
+		{"Weighted", ScoringConfig{Strategy: ScoreWeighted, Weights: map[string]float64{ScoreElevation: 1, ScoreRarestType: 2}}, false},
+		{"Weighted without weights", ScoringConfig{Strategy: ScoreWeighted}, true},
+		{"Unknown weight", ScoringConfig{Strategy: ScoreWeighted, Weights: map[string]float64{"loudest": 1}}, true},
+		{"Negative weight", ScoringConfig{Strategy: ScoreWeighted, Weights: map[string]float64{ScoreElevation: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRarityScorer tests preferring types seen least often.
+func TestRarityScorer(t *testing.T) {
+	scorer := NewRarityScorer()
+	candidates := []Candidate{
+		candidate("A", "", "B738", 50, 5, 5000),
+		candidate("B", "", "b738", 50, 5, 5000),
+		candidate("C", "", "A388", 50, 5, 5000),
+		candidate("D", "", "", 50, 5, 5000),
+	}
+	scorer.Observe(candidates)
+	scorer.Observe(candidates[:1]) // Seeing an aircraft again doesn't count
+
+	if got := scorer.Score(candidates[0]); got != MaxScore/2 {
+		t.Errorf("Score(B738) = %.1f, expected %.1f", got, MaxScore/2)
+	}
+	if got := scorer.Score(candidates[2]); got != MaxScore {
+		t.Errorf("Score(A388) = %.1f, expected %.1f", got, MaxScore)
+	}
+	if got := scorer.Score(candidates[3]); got != 0 {
+		t.Errorf("Score(unknown type) = %.1f, expected 0", got)
+	}
+}
+
+// TestPredictionScorers tests the closest approach and visibility scorers.
+func TestPredictionScorers(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := tracking.TrackingLimits{MinAltitude: 30, MaxAltitude: 90}
+
+	// 20 NM east at 10,000 ft flying west at 300 kts: passes overhead
+	inbound := Candidate{Aircraft: adsb.Aircraft{
+		ICAO: "INB001", Latitude: 35.0, Longitude: -79.5928, Altitude: 10000,
+		GroundSpeed: 300, Track: 270, LastSeen: now,
+	}}
+
+	// Same position flying away
+	outbound := inbound
+	outbound.Aircraft.ICAO = "OUT001"
+	outbound.Aircraft.Track = 90
+
+	clock := func() time.Time { return now }
+	approach := &ClosestApproachScorer{Observer: observer, Limits: limits, now: clock}
+	if in, out := approach.Score(inbound), approach.Score(outbound); in <= out || in < 90 {
+		t.Errorf("Closest approach scores inbound %.1f, outbound %.1f; expected inbound near %.0f and higher", in, out, MaxScore)
+	}
+
+	visibility := &VisibilityScorer{Observer: observer, Limits: limits, now: clock}
+	if in := visibility.Score(inbound); in <= 0 || in >= MaxScore {
+		t.Errorf("Visibility scores inbound %.1f, expected a few minutes' worth", in)
+	}
+	if out := visibility.Score(outbound); out != 0 {
+		t.Errorf("Visibility scores outbound %.1f, expected 0", out)
+	}
+}
+
+// TestRuleSetUseScorer tests a scorer changing which target is selected.
+func TestRuleSetUseScorer(t *testing.T) {
+	rules := RuleSet{Rules: []Rule{{Name: "anything", MinElevation: 20}}}
+	candidates := []Candidate{
+		candidate("HIGH", "", "B738", 80, 5, 5000),
+		candidate("COMMON", "", "B738", 60, 5, 5000),
+		candidate("RARE", "", "A388", 40, 5, 5000),
+	}
+
+	if best, _, _ := rules.Select(candidates, nil); best.Aircraft.ICAO != "HIGH" {
+		t.Errorf("Select() by elevation = %s, expected HIGH", best.Aircraft.ICAO)
+	}
+
+	scorer, err := ScoringConfig{Strategy: ScoreRarestType}.NewScorer(coordinates.Observer{}, tracking.TrackingLimits{})
+	if err != nil {
+		t.Fatalf("NewScorer() error = %v", err)
+	}
+	rules.UseScorer(scorer)
+	if best, _, _ := rules.Select(candidates, nil); best.Aircraft.ICAO != "RARE" {
+		t.Errorf("Select() by rarity = %s, expected RARE", best.Aircraft.ICAO)
+	}
+
+	// Weighting elevation enough outranks rarity
+	weighted := &WeightedScorer{}
+	weighted.Add(ElevationScorer{}, 10)
+	weighted.Add(NewRarityScorer(), 1)
+	rules.UseScorer(weighted)
+	if best, _, _ := rules.Select(candidates, nil); best.Aircraft.ICAO != "HIGH" {
+		t.Errorf("Select() by weights = %s, expected HIGH", best.Aircraft.ICAO)
+	}
+}
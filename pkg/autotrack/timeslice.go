@@ -0,0 +1,187 @@
+package autotrack
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// MaxTimeSliceTargets is the most targets tracked at once in time-sliced
+// mode. More would leave too long between visits to each.
+const MaxTimeSliceTargets = 3
+
+// TimeSlice configures the experimental time-sliced mode, in which the
+// telescope alternates between several concurrent passes instead of
+// following one, e.g. for a wide-field camera.
+type TimeSlice struct {
+	// Targets is how many aircraft are tracked at once (2-3)
+	Targets int `json:"targets"`
+
+	// DwellSeconds is how long the telescope stays on each target before
+	// moving to the next (default: 20)
+	DwellSeconds float64 `json:"dwell_seconds,omitempty"`
+}
+
+// GetTimeSliceTargets returns how many targets are tracked at once in
+// time-sliced mode, or 0 if the mode is off.
+func (rs *RuleSet) GetTimeSliceTargets() int {
+	if rs.TimeSlice == nil {
+		return 0
+	}
+	return rs.TimeSlice.Targets
+}
+
+// GetDwell returns how long the telescope stays on each target in
+// time-sliced mode. Returns 20 seconds if not configured.
+func (rs *RuleSet) GetDwell() time.Duration {
+	if rs.TimeSlice == nil || rs.TimeSlice.DwellSeconds <= 0 {
+		return 20 * time.Second
+	}
+	return time.Duration(rs.TimeSlice.DwellSeconds * float64(time.Second))
+}
+
+// SlewCost estimates the time to slew between two positions as the larger of
+// the azimuth and altitude moves (degrees), since both axes move at once.
+func SlewCost(from, to coordinates.HorizontalCoordinates) float64 {
+	dAz := math.Abs(from.Azimuth - to.Azimuth)
+	if dAz > 180 {
+		dAz = 360 - dAz
+	}
+	return max(dAz, math.Abs(from.Altitude-to.Altitude))
+}
+
+// SlewOrder returns the order (indices into targets) in which to visit the
+// targets repeatedly, starting from pointing, that needs the least slewing.
+// The cost of a cycle includes the move back to the first target, since the
+// cycle repeats; between cycles of equal cost the one with the shorter first
+// pass wins.
+func SlewOrder(pointing coordinates.HorizontalCoordinates, targets []coordinates.HorizontalCoordinates) []int {
+	order := make([]int, len(targets))
+	for i := range order {
+		order[i] = i
+	}
+	if len(targets) < 2 {
+		return order
+	}
+
+	cost := func(order []int) (cycle, firstPass float64) {
+		firstPass = SlewCost(pointing, targets[order[0]])
+		for i := 1; i < len(order); i++ {
+			firstPass += SlewCost(targets[order[i-1]], targets[order[i]])
+		}
+		return firstPass + SlewCost(targets[order[len(order)-1]], targets[order[0]]), firstPass
+	}
+
+	// Few enough targets to try every order
+	best := slices.Clone(order)
+	bestCycle, bestFirst := cost(best)
+	permute(order, 0, func(p []int) {
+		cycle, first := cost(p)
+		if cycle < bestCycle || (cycle == bestCycle && first < bestFirst) {
+			best, bestCycle, bestFirst = slices.Clone(p), cycle, first
+		}
+	})
+	return best
+}
+
+// permute calls visit with every permutation of items[k:].
+func permute(items []int, k int, visit func([]int)) {
+	if k == len(items) {
+		visit(items)
+		return
+	}
+	for i := k; i < len(items); i++ {
+		items[k], items[i] = items[i], items[k]
+		permute(items, k+1, visit)
+		items[k], items[i] = items[i], items[k]
+	}
+}
+
+// SliceTarget is an aircraft tracked in time-sliced mode.
+type SliceTarget struct {
+	ICAO       string
+	Horizontal coordinates.HorizontalCoordinates
+}
+
+// TimeSlicer decides which of several targets the telescope points at,
+// dwelling on each in turn.
+type TimeSlicer struct {
+	dwell   time.Duration
+	order   []string // ICAOs in slew order
+	current int
+	since   time.Time // When the current target's turn started
+}
+
+// NewTimeSlicer creates a time slicer that stays on each target for dwell.
+func NewTimeSlicer(dwell time.Duration) *TimeSlicer {
+	return &TimeSlicer{dwell: dwell}
+}
+
+// SetTargets replaces the targets, ordered to need the least slewing from
+// pointing, and starts the first target's turn.
+func (s *TimeSlicer) SetTargets(targets []SliceTarget, pointing coordinates.HorizontalCoordinates, now time.Time) {
+	positions := make([]coordinates.HorizontalCoordinates, len(targets))
+	for i, t := range targets {
+		positions[i] = t.Horizontal
+	}
+
+	s.order = s.order[:0]
+	for _, i := range SlewOrder(pointing, positions) {
+		s.order = append(s.order, targets[i].ICAO)
+	}
+	s.current = 0
+	s.since = now
+}
+
+// Current returns the target to point at, moving on to the next once the
+// current target's dwell is up. Returns false if there are no targets.
+func (s *TimeSlicer) Current(now time.Time) (string, bool) {
+	if len(s.order) == 0 {
+		return "", false
+	}
+	if now.Sub(s.since) >= s.dwell {
+		s.current = (s.current + 1) % len(s.order)
+		s.since = now
+	}
+	return s.order[s.current], true
+}
+
+// Focus makes a target current now, e.g. to capture it as it culminates.
+// Returns false if it isn't one of the targets.
+func (s *TimeSlicer) Focus(icao string, now time.Time) bool {
+	i := slices.Index(s.order, icao)
+	if i < 0 {
+		return false
+	}
+	if i != s.current {
+		s.current = i
+		s.since = now
+	}
+	return true
+}
+
+// Remove drops a target. If it was current, the next target's turn starts.
+func (s *TimeSlicer) Remove(icao string, now time.Time) {
+	i := slices.Index(s.order, icao)
+	if i < 0 {
+		return
+	}
+	s.order = slices.Delete(s.order, i, i+1)
+
+	switch {
+	case len(s.order) == 0:
+		s.current = 0
+	case i < s.current:
+		s.current--
+	case i == s.current:
+		s.current %= len(s.order)
+		s.since = now
+	}
+}
+
+// Targets returns the targets in visiting order.
+func (s *TimeSlicer) Targets() []string {
+	return slices.Clone(s.order)
+}
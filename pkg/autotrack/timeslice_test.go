@@ -0,0 +1,152 @@
+package autotrack
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// sky builds a test sky position.
+func sky(azimuth, altitude float64) coordinates.HorizontalCoordinates {
+	return coordinates.HorizontalCoordinates{Azimuth: azimuth, Altitude: altitude}
+}
+
+// TestSlewCost tests the slew time estimate.
+func TestSlewCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to coordinates.HorizontalCoordinates
+		want     float64
+	}{
+		{"Azimuth move", sky(10, 40), sky(70, 45), 60},
+		{"Altitude move", sky(10, 30), sky(20, 80), 50},
+		{"Across north", sky(350, 40), sky(10, 40), 20},
+		{"No move", sky(90, 45), sky(90, 45), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlewCost(tt.from, tt.to); got != tt.want {
+				t.Errorf("SlewCost() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSlewOrder tests ordering targets for the least slewing.
+func TestSlewOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		pointing coordinates.HorizontalCoordinates
+		targets  []coordinates.HorizontalCoordinates
+		want     []int
+	}{
+		{"Single target", sky(0, 45), []coordinates.HorizontalCoordinates{sky(90, 45)}, []int{0}},
+		{
+			name:     "Nearest first",
+			pointing: sky(180, 45),
+			targets:  []coordinates.HorizontalCoordinates{sky(20, 45), sky(170, 45)},
+			want:     []int{1, 0},
+		},
+		{
+			// Pointing west, targets north-east, east and north-west: start
+			// north-west and carry on round through north
+			name:     "Round the sky",
+			pointing: sky(270, 45),
+			targets:  []coordinates.HorizontalCoordinates{sky(45, 45), sky(90, 45), sky(315, 45)},
+			want:     []int{2, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlewOrder(tt.pointing, tt.targets); !slices.Equal(got, tt.want) {
+				t.Errorf("SlewOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTimeSlicer tests alternating between targets.
+func TestTimeSlicer(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	targets := []SliceTarget{
+		{ICAO: "EAST", Horizontal: sky(90, 45)},
+		{ICAO: "WEST", Horizontal: sky(270, 45)},
+		{ICAO: "NORTH", Horizontal: sky(0, 45)},
+	}
+
+	newSlicer := func() *TimeSlicer {
+		s := NewTimeSlicer(20 * time.Second)
+		s.SetTargets(targets, sky(80, 45), now)
+		return s
+	}
+
+	current := func(s *TimeSlicer, at time.Time) string {
+		icao, ok := s.Current(at)
+		if !ok {
+			t.Fatal("Expected a current target")
+		}
+		return icao
+	}
+
+	t.Run("Dwells on each in turn", func(t *testing.T) {
+		s := newSlicer()
+		if got := s.Targets(); !slices.Equal(got, []string{"EAST", "NORTH", "WEST"}) {
+			t.Fatalf("Targets() = %v, want [EAST NORTH WEST]", got)
+		}
+
+		order := s.Targets()
+		steps := []struct {
+			at   time.Duration
+			want string
+		}{
+			{0, order[0]},
+			{19 * time.Second, order[0]},
+			{20 * time.Second, order[1]},
+			{40 * time.Second, order[2]},
+			{60 * time.Second, order[0]},
+		}
+		for _, step := range steps {
+			if got := current(s, now.Add(step.at)); got != step.want {
+				t.Errorf("Current() at %v = %s, want %s", step.at, got, step.want)
+			}
+		}
+	})
+
+	t.Run("Focus", func(t *testing.T) {
+		s := newSlicer()
+		last := s.Targets()[2]
+		if !s.Focus(last, now.Add(5*time.Second)) {
+			t.Fatal("Focus() = false for a target")
+		}
+		if got := current(s, now.Add(20*time.Second)); got != last {
+			t.Errorf("Current() = %s, want the focused %s for a full dwell", got, last)
+		}
+		if s.Focus("OTHER", now) {
+			t.Error("Focus() = true for an unknown aircraft")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		s := newSlicer()
+		order := s.Targets()
+
+		s.Remove(order[0], now.Add(5*time.Second))
+		if got := current(s, now.Add(5*time.Second)); got != order[1] {
+			t.Errorf("Current() after removing the current target = %s, want %s", got, order[1])
+		}
+
+		s.Remove(order[2], now.Add(6*time.Second))
+		if got := s.Targets(); !slices.Equal(got, []string{order[1]}) {
+			t.Errorf("Targets() = %v, want [%s]", got, order[1])
+		}
+
+		s.Remove(order[1], now.Add(7*time.Second))
+		if _, ok := s.Current(now.Add(7 * time.Second)); ok {
+			t.Error("Expected no current target with none left")
+		}
+	})
+}
@@ -2,9 +2,17 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // Config represents the complete application configuration.
@@ -16,6 +24,8 @@ type Config struct {
 	ADSB        ADSBConfig        `json:"adsb"`
 	Observer    ObserverConfig    `json:"observer"`
 	FlightAware FlightAwareConfig `json:"flightaware"`
+	Alerts      AlertsConfig      `json:"alerts"`
+	Display     DisplayConfig     `json:"display"`
 }
 
 // ServerConfig contains HTTP server configuration.
@@ -34,6 +44,17 @@ type ServerConfig struct {
 
 	// TLSKeyFile is the path to the TLS private key
 	TLSKeyFile string `json:"tls_key_file"`
+
+	// PublicView exposes read-only aircraft, telescope status and system
+	// endpoints without login so a live view can be shared. Telescope control
+	// and observation point management still require authentication.
+	PublicView bool `json:"public_view"`
+
+	// HookAPIKey authenticates inbound webhooks (POST /api/v1/hooks/track)
+	// from external systems such as Node-RED or a Stream Deck button, sent
+	// in the X-API-Key header (empty = webhooks disabled; should be loaded
+	// from environment)
+	HookAPIKey string `json:"hook_api_key,omitempty"`
 }
 
 // DatabaseConfig contains database connection settings.
@@ -64,16 +85,67 @@ type DatabaseConfig struct {
 
 	// MaxIdleConns is the maximum number of idle connections
 	MaxIdleConns int `json:"max_idle_conns"`
+
+	// WriteWorkers is how many connections the collector writes aircraft on
+	// concurrently (0 = 4); keep it well below MaxOpenConns
+	WriteWorkers int `json:"write_workers"`
+
+	// WriteQueueSize is how many aircraft writes may wait for a worker
+	// before new ones are dropped (0 = 1000)
+	WriteQueueSize int `json:"write_queue_size"`
+
+	// PositionHistory controls how much position history is kept
+	PositionHistory PositionHistoryConfig `json:"position_history"`
+}
+
+// PositionHistoryConfig downsamples the position history of distant
+// aircraft, which are rarely tracked, to keep aircraft_positions small.
+type PositionHistoryConfig struct {
+	// DownsampleBeyondNM is the range from the observer beyond which only
+	// some positions are stored (0 = store every position)
+	DownsampleBeyondNM float64 `json:"downsample_beyond_nm"`
+
+	// DownsampleEvery stores every Nth position of aircraft beyond
+	// DownsampleBeyondNM (0 or 1 = every position)
+	DownsampleEvery int `json:"downsample_every"`
+}
+
+// Keep reports whether an aircraft's count'th position (counting from 1),
+// at rangeNM from the observer, is stored. The first position is always
+// stored.
+func (cfg PositionHistoryConfig) Keep(rangeNM float64, count int) bool {
+	if cfg.DownsampleBeyondNM <= 0 || cfg.DownsampleEvery <= 1 || rangeNM <= cfg.DownsampleBeyondNM {
+		return true
+	}
+	return (count-1)%cfg.DownsampleEvery == 0
 }
 
 // TelescopeConfig contains ASCOM Alpaca telescope settings.
 type TelescopeConfig struct {
+	// Driver selects the mount backend: "alpaca" (default) for an ASCOM
+	// Alpaca telescope at BaseURL, "rotctld" for an azimuth/elevation
+	// antenna rotator behind hamlib's rotctld at RotctldAddress, or "onvif"
+	// for a PTZ camera (see ONVIF)
+	Driver string `json:"driver"`
+
 	// BaseURL is the Alpaca server address (e.g., "http://192.168.1.100:11111")
 	BaseURL string `json:"base_url"`
 
+	// RotctldAddress is the rotctld address (host:port) when Driver is
+	// "rotctld"
+	RotctldAddress string `json:"rotctld_address"`
+
 	// DeviceNumber is the Alpaca device number (typically 0)
 	DeviceNumber int `json:"device_number"`
 
+	// RequestTimeoutMs bounds each Alpaca request attempt (0 = 5000)
+	RequestTimeoutMs int `json:"request_timeout_ms"`
+
+	// RequestRetries is how many times a request that fails in transit
+	// (dropped connection, timeout, HTTP 5xx) is retried
+	// (0 = default of 2, negative = never retry)
+	RequestRetries int `json:"request_retries"`
+
 	// MountType is either "altaz" or "equatorial"
 	MountType string `json:"mount_type"`
 
@@ -109,6 +181,18 @@ type TelescopeConfig struct {
 	// Set to 0 for auto-detection based on imaging_mode
 	MinAltitude float64 `json:"min_altitude"`
 
+	// SoftLimitMargin is how many degrees inside the altitude (and azimuth)
+	// limits the soft limits lie. Slews between a soft and hard limit are
+	// held at the soft limit and axis moves stop there; slews beyond a hard
+	// limit are refused (0 = soft and hard limits coincide)
+	SoftLimitMargin float64 `json:"soft_limit_margin"`
+
+	// MinAzimuth and MaxAzimuth restrict azimuth to the clockwise range
+	// between them, which may span north, e.g. for cable wrap or
+	// obstructions (equal = unrestricted)
+	MinAzimuth float64 `json:"min_azimuth"`
+	MaxAzimuth float64 `json:"max_azimuth"`
+
 	// FocuserDeviceNumber is the Alpaca device number for the focuser (typically 0)
 	FocuserDeviceNumber int `json:"focuser_device_number"`
 
@@ -133,6 +217,8 @@ type TelescopeConfig struct {
 	// MinSolarSeparation is the minimum allowed angular separation from sun (degrees)
 	// Default: 20° (CRITICAL protection)
 	// With solar filter: can be reduced to 2°
+	// Applies along the whole slew path, not just at the target: slews that
+	// would sweep past the sun are routed around it
 	MinSolarSeparation float64 `json:"min_solar_separation"`
 
 	// AutoDarkFilterOnSolarProximity automatically engages dark filter when approaching sun
@@ -143,6 +229,212 @@ type TelescopeConfig struct {
 
 	// EnableDewHeaterOnStartup automatically enables dew heater on startup
 	EnableDewHeaterOnStartup bool `json:"enable_dew_heater_on_startup"`
+
+	// ObservingConditionsDeviceNumber is the Alpaca device number for the
+	// weather station (ObservingConditions), typically 0
+	ObservingConditionsDeviceNumber int `json:"observingconditions_device_number"`
+
+	// WeatherStationEnabled polls the ObservingConditions device for the
+	// weather endpoint and high wind alerts
+	WeatherStationEnabled bool `json:"weather_station_enabled"`
+
+	// MaxWindSpeedMS is the highest wind (sustained or gust) in m/s at which
+	// slewing is considered safe; above it a high wind alert is raised (0 = no limit)
+	MaxWindSpeedMS float64 `json:"max_wind_speed_ms"`
+
+	// Thermal configures temperature-based dew heater and focus compensation
+	Thermal ThermalConfig `json:"thermal"`
+
+	// NudgeDurationMs is the guide pulse length for a manual pointing nudge
+	// (arrow keys in nudge mode, or the nudge API without a duration)
+	NudgeDurationMs int `json:"nudge_duration_ms"`
+
+	// FieldOfViewDegrees is the camera's field of view along its wider axis,
+	// used to draw the pointing footprint on the map
+	FieldOfViewDegrees float64 `json:"field_of_view_degrees"`
+
+	// RotatorDeviceNumber is the Alpaca device number for the camera rotator,
+	// typically 0
+	RotatorDeviceNumber int `json:"rotator_device_number"`
+
+	// Derotation configures field derotation with the rotator while tracking
+	Derotation DerotationConfig `json:"derotation"`
+
+	// Gamepad configures manual control with a joystick or gamepad
+	Gamepad GamepadConfig `json:"gamepad"`
+
+	// ONVIF configures the PTZ camera when Driver is "onvif"
+	ONVIF ONVIFConfig `json:"onvif"`
+
+	// Alignment corrects alt-az pointing for a mount that isn't precisely
+	// level or aligned to north, as measured by the alignment wizard
+	Alignment AlignmentConfig `json:"alignment"`
+}
+
+// AlignmentConfig is a mount's pointing model: where its azimuth and
+// altitude zeros really point and how its azimuth axis is tilted. All in
+// degrees; all zero for a level mount aligned to true north.
+type AlignmentConfig struct {
+	// AzimuthZero is the true azimuth the mount reports as 0°
+	AzimuthZero float64 `json:"azimuth_zero"`
+
+	// AltitudeZero is the true altitude the mount reports as 0° (its index
+	// error)
+	AltitudeZero float64 `json:"altitude_zero"`
+
+	// TiltNorth and TiltEast are the tilt of the azimuth axis towards north
+	// and east; the base is low on that side
+	TiltNorth float64 `json:"tilt_north"`
+	TiltEast  float64 `json:"tilt_east"`
+}
+
+// ONVIFConfig configures a PTZ camera driven through the ONVIF PTZ service
+// and calibrates its pan/tilt frame: pan and tilt each run from -1 to 1 and
+// map linearly to azimuth and elevation (see cmd/ptz-calibrate).
+type ONVIFConfig struct {
+	// PTZURL is the camera's PTZ service address
+	// (e.g., "http://192.168.1.64/onvif/ptz_service")
+	PTZURL string `json:"ptz_url"`
+
+	// Username and Password authenticate requests (WS-Security digest)
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// ProfileToken is the media profile whose PTZ configuration is moved
+	// (default "Profile_1")
+	ProfileToken string `json:"profile_token"`
+
+	// PanZeroAzimuth is the azimuth at pan 0; PanDegreesPerUnit the
+	// degrees turned per unit of pan, negative if pan runs counter-clockwise
+	// (0 = 180, a full turn)
+	PanZeroAzimuth    float64 `json:"pan_zero_azimuth"`
+	PanDegreesPerUnit float64 `json:"pan_degrees_per_unit"`
+
+	// TiltZeroElevation is the elevation at tilt 0; TiltDegreesPerUnit the
+	// degrees raised per unit of tilt, negative if tilt runs downwards
+	// (0 = 90)
+	TiltZeroElevation  float64 `json:"tilt_zero_elevation"`
+	TiltDegreesPerUnit float64 `json:"tilt_degrees_per_unit"`
+}
+
+// GetProfileToken returns the media profile token, defaulting to
+// "Profile_1" when profile_token is not set.
+func (cfg *ONVIFConfig) GetProfileToken() string {
+	if cfg.ProfileToken == "" {
+		return "Profile_1"
+	}
+	return cfg.ProfileToken
+}
+
+// GetPanDegreesPerUnit returns the pan scale, defaulting to 180 (pan -1 to
+// 1 spans a full turn) when pan_degrees_per_unit is not set.
+func (cfg *ONVIFConfig) GetPanDegreesPerUnit() float64 {
+	if cfg.PanDegreesPerUnit == 0 {
+		return 180
+	}
+	return cfg.PanDegreesPerUnit
+}
+
+// GetTiltDegreesPerUnit returns the tilt scale, defaulting to 90 when
+// tilt_degrees_per_unit is not set.
+func (cfg *ONVIFConfig) GetTiltDegreesPerUnit() float64 {
+	if cfg.TiltDegreesPerUnit == 0 {
+		return 90
+	}
+	return cfg.TiltDegreesPerUnit
+}
+
+// GamepadConfig maps a joystick or gamepad to manual telescope control.
+// Sticks drive the axes only while the dead-man button is held.
+type GamepadConfig struct {
+	// Enabled turns on gamepad control in the terminal client
+	Enabled bool `json:"enabled"`
+
+	// Device is the joystick device path (e.g., "/dev/input/js0")
+	Device string `json:"device"`
+
+	// AzimuthAxis is the stick axis that drives azimuth (typically 0, left X)
+	AzimuthAxis int `json:"azimuth_axis"`
+
+	// AltitudeAxis is the stick axis that drives altitude (typically 1, left Y)
+	AltitudeAxis int `json:"altitude_axis"`
+
+	// InvertAltitude makes pushing the stick forward lower the telescope
+	InvertAltitude bool `json:"invert_altitude"`
+
+	// DeadManButton must be held for the sticks to move the telescope;
+	// releasing it stops both axes
+	DeadManButton int `json:"dead_man_button"`
+
+	// HandoffButton starts automatic tracking of the aircraft nearest to
+	// where the telescope is pointing
+	HandoffButton int `json:"handoff_button"`
+
+	// Deadzone is the stick deflection (0-1) ignored around centre
+	Deadzone float64 `json:"deadzone"`
+
+	// MaxRate is the axis rate at full deflection in deg/sec
+	// (0 = use slew_rate)
+	MaxRate float64 `json:"max_rate"`
+}
+
+// DerotationConfig controls counter-rotation of the camera to cancel field
+// rotation on alt-az mounts during long exposures.
+type DerotationConfig struct {
+	// Enabled turns on field derotation while tracking
+	Enabled bool `json:"enabled"`
+
+	// DeadbandDegrees is the smallest correction worth moving the rotator for
+	DeadbandDegrees float64 `json:"deadband_degrees"`
+
+	// Reverse flips the rotation direction (depends on the optical train;
+	// e.g., a diagonal mirror reverses the image)
+	Reverse bool `json:"reverse"`
+}
+
+// ThermalConfig controls automatic dew heater power and focus compensation
+// based on ambient temperature.
+type ThermalConfig struct {
+	// Enabled turns on periodic thermal adjustment
+	Enabled bool `json:"enabled"`
+
+	// TemperatureSource is where ambient conditions are read from:
+	// "alpaca" (ObservingConditions device) or "metar" (nearest airport report)
+	TemperatureSource string `json:"temperature_source"`
+
+	// METARStation is the ICAO station used when TemperatureSource is "metar" (e.g., "KJLN")
+	METARStation string `json:"metar_station"`
+
+	// IntervalSeconds is how often conditions are read and applied (default: 300)
+	IntervalSeconds int `json:"interval_seconds"`
+
+	// HeaterCurve maps ambient temperature to dew heater power (0-1).
+	// Power is interpolated linearly between points and clamped at the ends.
+	HeaterCurve []HeaterPoint `json:"heater_curve"`
+
+	// DewPointMarginC runs the heater at full power when the temperature is
+	// within this many degrees of the dew point (0 = ignore dew point)
+	DewPointMarginC float64 `json:"dew_point_margin_c"`
+
+	// FocusStepsPerDegree is the focuser offset per °C away from the reference
+	// temperature (negative if the focus point moves inward as it cools)
+	FocusStepsPerDegree float64 `json:"focus_steps_per_degree"`
+
+	// FocusReferenceTemperatureC is the temperature at which
+	// infinity_focus_position was measured
+	FocusReferenceTemperatureC float64 `json:"focus_reference_temperature_c"`
+
+	// FocusDeadbandSteps is the smallest correction worth moving the focuser for
+	FocusDeadbandSteps int `json:"focus_deadband_steps"`
+}
+
+// HeaterPoint is one point of a dew heater power curve.
+type HeaterPoint struct {
+	// TemperatureC is the ambient temperature in °C
+	TemperatureC float64 `json:"temperature_c"`
+
+	// Power is the heater power at this temperature (0 = off, 1 = full)
+	Power float64 `json:"power"`
 }
 
 // CollectionRegion represents a geographic region for aircraft data collection.
@@ -162,6 +454,12 @@ type CollectionRegion struct {
 
 	// Enabled determines if this region should be actively collected
 	Enabled bool `json:"enabled"`
+
+	// Center is an optional airport or waypoint identifier (e.g., "KCLT", "CHSLY").
+	// When set, it is resolved against the waypoints table at startup and
+	// replaces Latitude/Longitude. The configured coordinates are kept as a
+	// fallback if the identifier cannot be resolved.
+	Center string `json:"center,omitempty"`
 }
 
 // ADSBConfig contains ADS-B data source configuration.
@@ -187,6 +485,45 @@ type ADSBConfig struct {
 
 	// UpdateIntervalSeconds is how often to refresh aircraft data
 	UpdateIntervalSeconds int `json:"update_interval_seconds"`
+
+	// Cadence configures adaptive per-region polling
+	Cadence CadenceConfig `json:"cadence"`
+
+	// FailoverAfterCycles is the number of consecutive failed collection
+	// cycles before the collector switches to the next enabled online
+	// source. It fails back once the primary (first) source recovers.
+	// If 0, defaults to 3.
+	FailoverAfterCycles int `json:"failover_after_cycles,omitempty"`
+
+	// PerformanceFile is a CSV of aircraft type performance (type,
+	// climb_fpm, descent_fpm, cruise_kts, ceiling_ft) that adds to or
+	// overrides the built-in table used to keep predictions plausible.
+	// Empty uses the built-in table only.
+	PerformanceFile string `json:"performance_file,omitempty"`
+}
+
+// CadenceConfig controls adaptive collection cadence.
+// When enabled, regions without trackable aircraft are polled less often and
+// regions with aircraft approaching the observer are polled more often, all
+// within a shared request budget.
+type CadenceConfig struct {
+	// Enabled turns on adaptive cadence (otherwise every region is polled
+	// every UpdateIntervalSeconds)
+	Enabled bool `json:"enabled"`
+
+	// MinIntervalSeconds is the polling interval for regions with approaching aircraft
+	MinIntervalSeconds int `json:"min_interval_seconds"`
+
+	// IdleIntervalSeconds is the longest interval an idle region backs off to
+	// Keep below 120 so idle regions are refreshed before aircraft go stale
+	IdleIntervalSeconds int `json:"idle_interval_seconds"`
+
+	// RequestsPerMinute is the API request budget shared across all regions
+	// If 0, derived from the primary source's rate_limit_seconds
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+
+	// Burst is how many requests may be made back-to-back when budget has accrued
+	Burst int `json:"burst"`
 }
 
 // ADSBSource represents a single ADS-B data source configuration.
@@ -194,24 +531,43 @@ type ADSBSource struct {
 	// Name is a friendly name for this source
 	Name string `json:"name"`
 
-	// Type is the source type: "airplanes.live", "adsbexchange", "local", etc.
+	// Type is the source type: "airplanes.live", "adsbexchange", "local", "dump978", etc.
+	// "local" is a dump1090/readsb 1090 MHz SDR receiver; the web server
+	// reports its health from stats.json (base_url is the web root, e.g.
+	// "http://piaware.local/skyaware")
+	// "dump978" reads a local UAT 978 MHz receiver (skyaware978 aircraft.json)
+	// and is merged with the primary online source
+	// "airframes" reads an oceanic ADS-C/satellite position feed (e.g., airframes.io)
+	// for long-range radar coverage over water; polled at most every rate_limit_seconds (min 60)
+	// "scenario" plays scripted synthetic flights from scenario_file around the
+	// observer (see pkg/scenario), for testing without live traffic
 	Type string `json:"type"`
 
 	// Enabled determines if this source should be used
 	Enabled bool `json:"enabled"`
 
 	// BaseURL is the API base URL for online sources
+	// For dump978, the skyaware978 web root (e.g., "http://piaware.local/skyaware978")
 	BaseURL string `json:"base_url"`
 
 	// APIKey is the API key for services that require authentication
 	APIKey string `json:"api_key,omitempty"`
 
+	// ScenarioFile is the scenario played by a "scenario" source
+	ScenarioFile string `json:"scenario_file,omitempty"`
+
 	// LocalHost is the hostname for local SDR receivers
 	LocalHost string `json:"local_host,omitempty"`
 
 	// LocalPort is the port for local SDR receivers
 	LocalPort int `json:"local_port,omitempty"`
 
+	// BeastPort is the Beast binary output port of a "local" receiver
+	// (dump1090 and readsb use 30005). When set, the TUI decodes the
+	// selected altitude, heading bug and airspeeds aircraft broadcast in
+	// their extended squitters. 0 disables it.
+	BeastPort int `json:"beast_port,omitempty"`
+
 	// RateLimitSeconds is the minimum time between API calls in seconds
 	// 0 = no rate limit, >0 = enforce minimum delay between calls
 	// airplanes.live: recommend 3 seconds to avoid 429 errors
@@ -233,10 +589,85 @@ type ObserverConfig struct {
 	Longitude float64 `json:"longitude"`
 
 	// Elevation in meters above sea level
-	Elevation float64 `json:"elevation"`
+	Elevation units.Meters `json:"elevation"`
 
 	// TimeZone is the IANA timezone name (e.g., "America/New_York")
 	TimeZone string `json:"timezone"`
+
+	// Bortle is the site's Bortle dark-sky class, 1 (excellent dark site)
+	// to 9 (inner city); 0 = unknown (assumed 4, rural/suburban)
+	Bortle int `json:"bortle,omitempty"`
+
+	// SkyQualitySQM is a measured night sky brightness in magnitudes per
+	// square arcsecond (e.g., from a hand-held Sky Quality Meter). Takes
+	// precedence over Bortle; a live reading from the weather station's
+	// sky quality sensor takes precedence over both
+	SkyQualitySQM float64 `json:"sky_quality_sqm,omitempty"`
+
+	// GPS moves observation points that follow it with a GPS receiver
+	GPS GPSConfig `json:"gps"`
+
+	// Terrain excludes low aircraft hidden behind high ground from the
+	// trackable lists
+	Terrain TerrainConfig `json:"terrain"`
+}
+
+// TerrainConfig configures the terrain line-of-sight check.
+type TerrainConfig struct {
+	// SRTMDir is a directory of SRTM .hgt tiles around the observer (e.g.
+	// N35W081.hgt); empty disables the check
+	SRTMDir string `json:"srtm_dir"`
+
+	// MaxElevation is the elevation angle in degrees up to which aircraft
+	// are checked; terrain doesn't hide aircraft higher in the sky (0 = 15)
+	MaxElevation float64 `json:"max_elevation"`
+}
+
+// GetMaxElevation returns the elevation angle up to which aircraft are
+// checked, defaulting to 15° when max_elevation is not set.
+func (cfg *TerrainConfig) GetMaxElevation() float64 {
+	if cfg.MaxElevation <= 0 {
+		return 15
+	}
+	return cfg.MaxElevation
+}
+
+// GPSConfig configures a GPS receiver on the server for mobile observation
+// points: active points set to follow GPS are moved to each new fix.
+type GPSConfig struct {
+	// Enabled turns on the receiver in the web server
+	Enabled bool `json:"enabled"`
+
+	// Source is "gpsd" (default) or "nmea" to read a dongle directly
+	Source string `json:"source"`
+
+	// Address is gpsd's address as host:port (default "localhost:2947")
+	Address string `json:"address"`
+
+	// Device is the dongle's serial device for "nmea" (e.g., "/dev/ttyACM0")
+	Device string `json:"device"`
+
+	// MinMoveMeters is how far the receiver must move before points are
+	// updated, so GPS jitter doesn't rewrite them (0 = 25)
+	MinMoveMeters float64 `json:"min_move_meters"`
+}
+
+// GetAddress returns gpsd's address, defaulting to localhost:2947 when
+// address is not set.
+func (cfg *GPSConfig) GetAddress() string {
+	if cfg.Address == "" {
+		return "localhost:2947"
+	}
+	return cfg.Address
+}
+
+// GetMinMove returns the movement that updates points, defaulting to 25 m
+// when min_move_meters is not set.
+func (cfg *GPSConfig) GetMinMove() units.Meters {
+	if cfg.MinMoveMeters <= 0 {
+		return 25
+	}
+	return units.Meters(cfg.MinMoveMeters)
 }
 
 // FlightAwareConfig contains FlightAware AeroAPI settings.
@@ -259,6 +690,170 @@ type FlightAwareConfig struct {
 
 	// FetchIntervalMinutes is how often to refresh flight plans for active aircraft
 	FetchIntervalMinutes int `json:"fetch_interval_minutes"`
+
+	// PositionsEnabled polls FlightAware for en-route positions of flights of
+	// interest (approaching, trackable or scheduled) whose ADS-B reception
+	// has lapsed, as a low-rate secondary source
+	PositionsEnabled bool `json:"positions_enabled"`
+
+	// PositionIntervalMinutes is how often coverage gaps are filled
+	PositionIntervalMinutes int `json:"position_interval_minutes"`
+
+	// PositionGapSeconds is how long an aircraft must go unreceived over
+	// ADS-B before its position is requested from FlightAware
+	PositionGapSeconds int `json:"position_gap_seconds"`
+
+	// RerouteThresholdNM is how far an aircraft can stray from its stored
+	// route before it may have been rerouted (0 = don't check)
+	RerouteThresholdNM float64 `json:"reroute_threshold_nm"`
+
+	// RerouteMinutes is how long an aircraft must stay beyond the threshold
+	// before its plan is flagged as rerouted and re-fetched
+	RerouteMinutes int `json:"reroute_minutes"`
+
+	// CenterLookaheadMinutes limits plan fetches to flights that are in the
+	// observer's ARTCC/FIR, or predicted from their track to enter it within
+	// this many minutes (0 = fetch for every flight). Needs center
+	// boundaries imported by import-nasr or import-navdata.
+	CenterLookaheadMinutes int `json:"center_lookahead_minutes"`
+
+	// MonthlyCallBudget caps AeroAPI requests per calendar month, spread
+	// evenly over its days (0 = unlimited). Usage is kept in the database.
+	MonthlyCallBudget int `json:"monthly_call_budget"`
+
+	// MonthlyCostBudget caps AeroAPI spend per calendar month, using
+	// CostPerCall for each request (0 = unlimited)
+	MonthlyCostBudget float64 `json:"monthly_cost_budget"`
+
+	// CostPerCall is the charge per AeroAPI request on your plan
+	CostPerCall float64 `json:"cost_per_call"`
+}
+
+// AlertsConfig contains settings for emergency squawk notifications.
+// Alerts are raised when an aircraft squawks 7500 (hijack), 7600 (radio failure)
+// or 7700 (general emergency).
+type AlertsConfig struct {
+	// Enabled determines if alerts should be dispatched
+	Enabled bool `json:"enabled"`
+
+	// WebhookURL receives a JSON POST for each alert (empty = disabled)
+	WebhookURL string `json:"webhook_url"`
+
+	// MQTTBroker is the broker address, e.g. "localhost:1883" (empty = disabled)
+	MQTTBroker string `json:"mqtt_broker"`
+
+	// MQTTTopic is the topic alerts are published to (default: "ads-bscope/alerts")
+	MQTTTopic string `json:"mqtt_topic"`
+
+	// MQTTUsername for broker authentication (optional)
+	MQTTUsername string `json:"mqtt_username,omitempty"`
+
+	// MQTTPassword for broker authentication (should be loaded from environment)
+	MQTTPassword string `json:"mqtt_password,omitempty"`
+
+	// RepeatIntervalMinutes is how often an ongoing emergency is re-announced
+	// 0 = announce once per aircraft and squawk code
+	RepeatIntervalMinutes int `json:"repeat_interval_minutes"`
+
+	// PassNotifications announce aircraft about to pass high over the
+	// observer, in time to get outside (or to the eyepiece) to see them
+	PassNotifications []PassNotificationConfig `json:"pass_notifications,omitempty"`
+
+	// WatchGoneMinutes is how long a watched aircraft must be out of
+	// coverage to be announced again when it reappears (default: 15)
+	WatchGoneMinutes float64 `json:"watch_gone_minutes"`
+}
+
+// GetWatchGone returns how long a watched aircraft must be unseen to be
+// announced again. Returns 15 minutes if not configured.
+func (cfg *AlertsConfig) GetWatchGone() time.Duration {
+	if cfg.WatchGoneMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(cfg.WatchGoneMinutes * float64(time.Minute))
+}
+
+// PassNotificationConfig is a "notify me N minutes before any aircraft
+// matching the filter reaches at least Y° elevation" rule. Zero filter
+// values match any aircraft.
+type PassNotificationConfig struct {
+	// Name identifies the rule in notifications
+	Name string `json:"name"`
+
+	// LeadMinutes is how long before the aircraft reaches MinElevation to
+	// notify (default: 5)
+	LeadMinutes float64 `json:"lead_minutes"`
+
+	// MinElevation is the elevation (degrees) the aircraft must reach
+	MinElevation float64 `json:"min_elevation"`
+
+	// CallsignPrefixes limits the rule to callsigns starting with one of
+	// these (e.g. airline designators "UAL", "DAL")
+	CallsignPrefixes []string `json:"callsign_prefixes,omitempty"`
+
+	// AircraftTypes limits the rule to these ICAO type designators
+	// (e.g. "B77W", "A388")
+	AircraftTypes []string `json:"aircraft_types,omitempty"`
+
+	// Operators limits the rule to these operators, by ICAO airline
+	// designator or part of the airline's name (e.g. "FDX", "fedex")
+	Operators []string `json:"operators,omitempty"`
+
+	// TypeFamilies limits the rule to these type families (e.g. "747",
+	// "A320")
+	TypeFamilies []string `json:"type_families,omitempty"`
+
+	// Categories limits the rule to these aircraft categories: widebody,
+	// narrowbody, regional, turboprop, bizjet or light
+	Categories []string `json:"categories,omitempty"`
+}
+
+// GetLead returns how long before a pass to notify.
+// Returns 5 minutes if not configured.
+func (cfg *PassNotificationConfig) GetLead() time.Duration {
+	if cfg.LeadMinutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(cfg.LeadMinutes * float64(time.Minute))
+}
+
+// DisplayConfig contains settings shared by the terminal and web clients.
+type DisplayConfig struct {
+	// TrailMinutes is how much position history is drawn behind each aircraft
+	// (default: 5). Trails are loaded from the position history table, so
+	// they survive client restarts.
+	TrailMinutes int `json:"trail_minutes"`
+
+	// AudibleAlerts rings the terminal bell for tracking events (culmination,
+	// approaching the altitude limits, target lost), since the operator is
+	// usually at the eyepiece rather than watching the screen
+	AudibleAlerts bool `json:"audible_alerts"`
+
+	// AlertSoundCommand is an optional command run for each tracking event,
+	// e.g. "paplay /usr/share/sounds/freedesktop/stereo/bell.oga".
+	// The event name is passed in the ADS_BSCOPE_EVENT environment variable.
+	AlertSoundCommand string `json:"alert_sound_command,omitempty"`
+
+	// LimitWarningDegrees is how close to an altitude limit a tracked target
+	// gets before the approaching-limit alert (default: 5)
+	LimitWarningDegrees float64 `json:"limit_warning_degrees"`
+
+	// Theme is the terminal clients' color theme: "default",
+	// "high-contrast", "deuteranopia" (red-green color-blind safe) or "red"
+	// (night vision). Empty means "default"; see pkg/theme.
+	Theme string `json:"theme,omitempty"`
+
+	// Language is the terminal and web clients' language: "en", "de" or
+	// "es". Empty means English; see pkg/i18n.
+	Language string `json:"language,omitempty"`
+
+	// Units shows altitudes, distances and speeds in "aviation" units
+	// (ft, NM, kt) or "metric" units (m, km, km/h). Empty means "aviation".
+	Units string `json:"units,omitempty"`
+
+	// RadarRingNM is the spacing of the radar views' range rings in
+	// nautical miles. 0 picks a round spacing giving at most 5 rings.
+	RadarRingNM float64 `json:"radar_ring_nm,omitempty"`
 }
 
 // Load reads configuration from a JSON file.
@@ -330,6 +925,8 @@ func DefaultConfig() *Config {
 		Telescope: TelescopeConfig{
 			BaseURL:              "http://localhost:11111",
 			DeviceNumber:         0,
+			RequestTimeoutMs:     5000,
+			RequestRetries:       2,
 			MountType:            "altaz", // "altaz" or "equatorial" (when using EQ wedge)
 			SlewRate:             1.0,
 			TrackingEnabled:      true,
@@ -338,6 +935,35 @@ func DefaultConfig() *Config {
 			SupportsMeridianFlip: false,         // Seestar: false (360° rotation), GEM: true
 			MaxAltitude:          0.0,           // 0 = auto-detect based on model+mount_type
 			MinAltitude:          0.0,           // 0 = auto-detect based on imaging_mode
+			SoftLimitMargin:      2.0,
+			MaxWindSpeedMS:       10.0,
+			NudgeDurationMs:      500,
+			FieldOfViewDegrees:   1.3, // Seestar S50: 1.3° x 0.7°
+			Thermal: ThermalConfig{
+				Enabled:           false,
+				TemperatureSource: "alpaca",
+				IntervalSeconds:   300,
+				HeaterCurve: []HeaterPoint{
+					{TemperatureC: 0, Power: 1.0},
+					{TemperatureC: 10, Power: 0.5},
+					{TemperatureC: 20, Power: 0},
+				},
+				DewPointMarginC:    3.0,
+				FocusDeadbandSteps: 5,
+			},
+			Derotation: DerotationConfig{
+				Enabled:         false,
+				DeadbandDegrees: 0.5,
+			},
+			Gamepad: GamepadConfig{
+				Enabled:       false,
+				Device:        "/dev/input/js0",
+				AzimuthAxis:   0,
+				AltitudeAxis:  1,
+				DeadManButton: 4, // left bumper on most pads
+				HandoffButton: 0, // A / cross
+				Deadzone:      0.1,
+			},
 		},
 		ADSB: ADSBConfig{
 			Sources: []ADSBSource{
@@ -356,6 +982,12 @@ func DefaultConfig() *Config {
 				// By default, no regions enabled - will use legacy MaxCollectionRadiusNM
 			},
 			UpdateIntervalSeconds: 2,
+			Cadence: CadenceConfig{
+				Enabled:             false,
+				MinIntervalSeconds:  2,
+				IdleIntervalSeconds: 60,
+				Burst:               3,
+			},
 		},
 		Observer: ObserverConfig{
 			Name:      "Primary Observer",
@@ -365,14 +997,134 @@ func DefaultConfig() *Config {
 			TimeZone:  "UTC",
 		},
 		FlightAware: FlightAwareConfig{
-			Enabled:              false,
-			RequestsPerHour:      1, // Conservative default for free tier
-			AutoFetchEnabled:     false,
-			FetchIntervalMinutes: 60, // Refresh every hour
+			Enabled:                 false,
+			RequestsPerHour:         1, // Conservative default for free tier
+			AutoFetchEnabled:        false,
+			FetchIntervalMinutes:    60, // Refresh every hour
+			PositionsEnabled:        false,
+			PositionIntervalMinutes: 5,
+			PositionGapSeconds:      120,
+			RerouteThresholdNM:      10,
+			RerouteMinutes:          3,
+		},
+		Alerts: AlertsConfig{
+			Enabled:               true,
+			MQTTTopic:             "ads-bscope/alerts",
+			RepeatIntervalMinutes: 15,
+		},
+		Display: DisplayConfig{
+			TrailMinutes:        5,
+			AudibleAlerts:       true,
+			LimitWarningDegrees: 5,
 		},
 	}
 }
 
+// GetWriteWorkers returns the number of collector write workers,
+// defaulting to 4 when write_workers is not set.
+func (cfg *DatabaseConfig) GetWriteWorkers() int {
+	if cfg.WriteWorkers <= 0 {
+		return 4
+	}
+	return cfg.WriteWorkers
+}
+
+// GetWriteQueueSize returns the collector's write queue capacity,
+// defaulting to 1000 when write_queue_size is not set.
+func (cfg *DatabaseConfig) GetWriteQueueSize() int {
+	if cfg.WriteQueueSize <= 0 {
+		return 1000
+	}
+	return cfg.WriteQueueSize
+}
+
+// GetFailoverAfterCycles returns the number of failed collection cycles
+// before failing over, defaulting to 3 when failover_after_cycles is not set.
+func (cfg *ADSBConfig) GetFailoverAfterCycles() int {
+	if cfg.FailoverAfterCycles <= 0 {
+		return 3
+	}
+	return cfg.FailoverAfterCycles
+}
+
+// GetLimitWarning returns the approaching-limit alert margin in degrees,
+// defaulting to 5° when limit_warning_degrees is not set.
+func (cfg *DisplayConfig) GetLimitWarning() float64 {
+	if cfg.LimitWarningDegrees <= 0 {
+		return 5
+	}
+	return cfg.LimitWarningDegrees
+}
+
+// GetTrailDuration returns how far back aircraft trails reach,
+// defaulting to 5 minutes when trail_minutes is not set.
+func (cfg *DisplayConfig) GetTrailDuration() time.Duration {
+	if cfg.TrailMinutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(cfg.TrailMinutes) * time.Minute
+}
+
+// radarRingIntervals are the round range ring spacings, in nautical miles,
+// picked from when radar_ring_nm is not set
+var radarRingIntervals = []float64{5, 10, 25, 50, 100, 250, 500, 1000}
+
+// maxRadarRings caps the number of rings a configured spacing may draw, so
+// a small spacing on a wide radar doesn't fill the screen with circles.
+const maxRadarRings = 10
+
+// GetRadarRingInterval returns the range ring spacing in nautical miles for
+// a radar of the given radius. A configured radar_ring_nm is used unless it
+// would draw more than 10 rings; otherwise the smallest round spacing that
+// gives at most 5 rings is picked.
+func (cfg *DisplayConfig) GetRadarRingInterval(radiusNM float64) float64 {
+	if cfg.RadarRingNM > 0 && radiusNM/cfg.RadarRingNM <= maxRadarRings {
+		return cfg.RadarRingNM
+	}
+	for _, interval := range radarRingIntervals {
+		if radiusNM/interval <= 5 {
+			return interval
+		}
+	}
+	return radarRingIntervals[len(radarRingIntervals)-1]
+}
+
+// GetDriver returns the mount backend, "alpaca", "rotctld" or "onvif",
+// defaulting to "alpaca" when driver is not set.
+func (cfg *TelescopeConfig) GetDriver() string {
+	if cfg.Driver == "" {
+		return "alpaca"
+	}
+	return strings.ToLower(cfg.Driver)
+}
+
+// GetRotctldAddress returns the rotctld address, defaulting to
+// localhost:4533 (rotctld's default port) when rotctld_address is not set.
+func (cfg *TelescopeConfig) GetRotctldAddress() string {
+	if cfg.RotctldAddress == "" {
+		return "localhost:4533"
+	}
+	return cfg.RotctldAddress
+}
+
+// GetNudgeDuration returns the guide pulse length for a manual nudge,
+// defaulting to 500ms when nudge_duration_ms is not set.
+func (cfg *TelescopeConfig) GetNudgeDuration() time.Duration {
+	if cfg.NudgeDurationMs <= 0 {
+		return 500 * time.Millisecond
+	}
+	return time.Duration(cfg.NudgeDurationMs) * time.Millisecond
+}
+
+// GetFieldOfView returns the camera's field of view in degrees,
+// defaulting to 1.3° (Seestar S50) when field_of_view_degrees is not set.
+func (cfg *TelescopeConfig) GetFieldOfView() float64 {
+	if cfg.FieldOfViewDegrees <= 0 {
+		return 1.3
+	}
+	return cfg.FieldOfViewDegrees
+}
+
 // GetAltitudeLimits returns the appropriate altitude limits based on telescope model, mount type, and imaging mode.
 // This automatically adjusts limits for Seestar Alt-Az mode field rotation issues and terrestrial vs astronomical use.
 func (cfg *TelescopeConfig) GetAltitudeLimits() (minAlt, maxAlt float64) {
@@ -447,6 +1199,62 @@ func (cfg *ADSBConfig) GetCollectionRegions(observer ObserverConfig) []Collectio
 	}
 }
 
+// GetBeastAddress returns the first enabled local receiver's Beast feed
+// address as host:port, or "" if none has a beast_port. The host is
+// local_host, or the host of base_url, or localhost.
+func (cfg *ADSBConfig) GetBeastAddress() string {
+	for _, src := range cfg.Sources {
+		if !src.Enabled || src.Type != "local" || src.BeastPort <= 0 {
+			continue
+		}
+		host := src.LocalHost
+		if host == "" && src.BaseURL != "" {
+			if u, err := url.Parse(src.BaseURL); err == nil {
+				host = u.Hostname()
+			}
+		}
+		if host == "" {
+			host = "localhost"
+		}
+		return net.JoinHostPort(host, strconv.Itoa(src.BeastPort))
+	}
+	return ""
+}
+
+// WaypointLookup resolves an airport or waypoint identifier to coordinates.
+// It returns found=false if the identifier is unknown.
+type WaypointLookup func(identifier string) (lat, lon float64, found bool, err error)
+
+// ResolveRegionCenters replaces the coordinates of each region that has a
+// Center identifier with the coordinates returned by lookup.
+// Regions that cannot be resolved keep their configured coordinates; the
+// failures are returned together so callers can log them and continue.
+func (cfg *ADSBConfig) ResolveRegionCenters(lookup WaypointLookup) error {
+	var errs []error
+	for i := range cfg.CollectionRegions {
+		region := &cfg.CollectionRegions[i]
+		identifier := strings.ToUpper(strings.TrimSpace(region.Center))
+		if identifier == "" {
+			continue
+		}
+
+		lat, lon, found, err := lookup(identifier)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("region %s: failed to resolve center %s: %w", region.Name, identifier, err))
+			continue
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("region %s: center %s not found", region.Name, identifier))
+			continue
+		}
+
+		region.Latitude = lat
+		region.Longitude = lon
+	}
+
+	return errors.Join(errs...)
+}
+
 // applyEnvironmentOverrides applies environment variable overrides to the config.
 // This allows sensitive data like passwords to be kept out of config files.
 func (c *Config) applyEnvironmentOverrides() {
@@ -471,4 +1279,16 @@ func (c *Config) applyEnvironmentOverrides() {
 	if faKey := os.Getenv("ADS_BSCOPE_FLIGHTAWARE_API_KEY"); faKey != "" {
 		c.FlightAware.APIKey = faKey
 	}
+	if webhook := os.Getenv("ADS_BSCOPE_ALERT_WEBHOOK_URL"); webhook != "" {
+		c.Alerts.WebhookURL = webhook
+	}
+	if mqttPassword := os.Getenv("ADS_BSCOPE_MQTT_PASSWORD"); mqttPassword != "" {
+		c.Alerts.MQTTPassword = mqttPassword
+	}
+	if onvifPassword := os.Getenv("ADS_BSCOPE_ONVIF_PASSWORD"); onvifPassword != "" {
+		c.Telescope.ONVIF.Password = onvifPassword
+	}
+	if hookKey := os.Getenv("ADS_BSCOPE_HOOK_API_KEY"); hookKey != "" {
+		c.Server.HookAPIKey = hookKey
+	}
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -70,6 +71,12 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.FlightAware.RequestsPerHour != 1 {
 		t.Errorf("Expected 1 request/hour, got %d", cfg.FlightAware.RequestsPerHour)
 	}
+	if cfg.FlightAware.PositionsEnabled {
+		t.Error("Expected FlightAware positions disabled by default")
+	}
+	if cfg.FlightAware.PositionGapSeconds != 120 {
+		t.Errorf("Expected 120s position gap, got %d", cfg.FlightAware.PositionGapSeconds)
+	}
 }
 
 // TestLoadNonExistentFile tests that Load returns default config when file doesn't exist.
@@ -456,6 +463,134 @@ func TestGetCollectionRegions(t *testing.T) {
 	})
 }
 
+// TestGetRadarRingInterval tests the GetRadarRingInterval method.
+func TestGetRadarRingInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		ringNM   float64
+		radiusNM float64
+		expected float64
+	}{
+		{"Auto small radius", 0, 20, 5},
+		{"Auto picks at most 5 rings", 0, 200, 50},
+		{"Auto large radius", 0, 2500, 500},
+		{"Auto beyond largest interval", 0, 10000, 1000},
+		{"Configured spacing", 20, 100, 20},
+		{"Configured spacing too dense", 5, 500, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DisplayConfig{RadarRingNM: tt.ringNM}
+			if got := cfg.GetRadarRingInterval(tt.radiusNM); got != tt.expected {
+				t.Errorf("Expected interval %.0f NM, got %.0f NM", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestPositionHistoryKeep tests downsampling distant aircraft's positions.
+func TestPositionHistoryKeep(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      PositionHistoryConfig
+		rangeNM  float64
+		count    int
+		expected bool
+	}{
+		{"Disabled", PositionHistoryConfig{}, 500, 2, true},
+		{"Every position", PositionHistoryConfig{DownsampleBeyondNM: 100, DownsampleEvery: 1}, 500, 2, true},
+		{"Within range", PositionHistoryConfig{DownsampleBeyondNM: 100, DownsampleEvery: 5}, 50, 2, true},
+		{"First position", PositionHistoryConfig{DownsampleBeyondNM: 100, DownsampleEvery: 5}, 500, 1, true},
+		{"Skipped position", PositionHistoryConfig{DownsampleBeyondNM: 100, DownsampleEvery: 5}, 500, 2, false},
+		{"Nth position", PositionHistoryConfig{DownsampleBeyondNM: 100, DownsampleEvery: 5}, 500, 6, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Keep(tt.rangeNM, tt.count); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestGetBeastAddress tests finding the local receiver's Beast feed.
+func TestGetBeastAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   ADSBSource
+		expected string
+	}{
+		{"No beast port", ADSBSource{Type: "local", Enabled: true, LocalHost: "piaware"}, ""},
+		{"Disabled", ADSBSource{Type: "local", BeastPort: 30005}, ""},
+		{"Local host", ADSBSource{Type: "local", Enabled: true, LocalHost: "piaware", BeastPort: 30005}, "piaware:30005"},
+		{"Host from base URL", ADSBSource{Type: "local", Enabled: true, BaseURL: "http://piaware.local/skyaware", BeastPort: 30005}, "piaware.local:30005"},
+		{"Default host", ADSBSource{Type: "local", Enabled: true, BeastPort: 30005}, "localhost:30005"},
+		{"Online source", ADSBSource{Type: "airplanes.live", Enabled: true, BeastPort: 30005}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ADSBConfig{Sources: []ADSBSource{tt.source}}
+			if got := cfg.GetBeastAddress(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestResolveRegionCenters tests resolving region centers from identifiers.
+func TestResolveRegionCenters(t *testing.T) {
+	lookup := func(identifier string) (float64, float64, bool, error) {
+		switch identifier {
+		case "KCLT":
+			return 35.2140, -80.9431, true, nil
+		case "BROKEN":
+			return 0, 0, false, errors.New("database unavailable")
+		}
+		return 0, 0, false, nil
+	}
+
+	tests := []struct {
+		name        string
+		center      string
+		expectedLat float64
+		expectedLon float64
+		expectError bool
+	}{
+		{"No center keeps coordinates", "", 35.0, -80.0, false},
+		{"Known airport", "KCLT", 35.2140, -80.9431, false},
+		{"Identifier is normalized", " kclt ", 35.2140, -80.9431, false},
+		{"Unknown identifier keeps coordinates", "ZZZZ", 35.0, -80.0, true},
+		{"Lookup error keeps coordinates", "BROKEN", 35.0, -80.0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ADSBConfig{
+				CollectionRegions: []CollectionRegion{
+					{Name: "Test", Latitude: 35.0, Longitude: -80.0, RadiusNM: 100, Enabled: true, Center: tt.center},
+				},
+			}
+
+			err := cfg.ResolveRegionCenters(lookup)
+			if tt.expectError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			region := cfg.CollectionRegions[0]
+			if region.Latitude != tt.expectedLat || region.Longitude != tt.expectedLon {
+				t.Errorf("Expected %f,%f, got %f,%f",
+					tt.expectedLat, tt.expectedLon, region.Latitude, region.Longitude)
+			}
+		})
+	}
+}
+
 // TestConfigRoundTrip tests saving and loading config preserves data.
 func TestConfigRoundTrip(t *testing.T) {
 	tmpDir := t.TempDir()
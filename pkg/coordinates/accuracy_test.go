@@ -0,0 +1,202 @@
+package coordinates
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Accuracy of the conversions against independent reference fixtures in
+// testdata, generated by testdata/reference.py from the rigorous models:
+// WGS84 ellipsoid topocentric coordinates for aircraft, and IAU 2000
+// sidereal time for the equatorial frame. Thresholds are regression
+// limits: they sit just above today's worst case per band, so a change
+// that makes any conversion less accurate fails.
+
+// readFixture returns the rows of a CSV fixture keyed by column name.
+func readFixture(t *testing.T, name string) []map[string]string {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(record))
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// fixtureFloat parses a numeric fixture column.
+func fixtureFloat(t *testing.T, row map[string]string, column string) float64 {
+	t.Helper()
+	v, err := strconv.ParseFloat(row[column], 64)
+	if err != nil {
+		t.Fatalf("Bad %s in fixture row %v: %v", column, row, err)
+	}
+	return v
+}
+
+// azimuthError returns the difference between two azimuths in degrees,
+// allowing for wrap-around at north.
+func azimuthError(a, b float64) float64 {
+	d := math.Abs(math.Mod(a-b, 360))
+	return math.Min(d, 360-d)
+}
+
+// geographicAltitudeLimits are the largest allowed altitude errors
+// (degrees) by target distance. The error grows with distance because
+// GeographicToHorizontal measures elevation along a sphere and ignores the
+// Earth's curvature drop.
+var geographicAltitudeLimits = []struct {
+	maxDistanceKm float64
+	limit         float64
+}{
+	{5, 0.16},
+	{25, 0.25},
+	{75, 0.42},
+	{150, 0.75},
+}
+
+// geographicAzimuthLimit is the largest allowed azimuth error (degrees): the
+// spherical bearing ignores the WGS84 flattening, worst near the equator.
+const geographicAzimuthLimit = 0.21
+
+// Largest allowed equatorial errors, in arcseconds on the sky
+const (
+	raLimitArcsec  = 0.5
+	decLimitArcsec = 0.01
+)
+
+// TestGeographicToHorizontalAccuracy compares GeographicToHorizontal with
+// WGS84 topocentric references over a grid of sites, bearings, distances
+// and aircraft heights. Heights are in meters; feeding feet (as ADS-B
+// reports) puts the elevation several degrees off and fails.
+func TestGeographicToHorizontalAccuracy(t *testing.T) {
+	rows := readFixture(t, "geographic.csv")
+	if len(rows) == 0 {
+		t.Fatal("Empty geographic fixture")
+	}
+
+	worstAlt := make(map[float64]float64)
+	var worstAz float64
+	for _, row := range rows {
+		observer := Observer{Location: Geographic{
+			Latitude:  fixtureFloat(t, row, "obs_lat"),
+			Longitude: fixtureFloat(t, row, "obs_lon"),
+			Altitude:  units.Meters(fixtureFloat(t, row, "obs_height_m")),
+		}}
+		target := Geographic{
+			Latitude:  fixtureFloat(t, row, "lat"),
+			Longitude: fixtureFloat(t, row, "lon"),
+			Altitude:  units.Meters(fixtureFloat(t, row, "height_m")),
+		}
+		distance := fixtureFloat(t, row, "distance_km")
+		want := HorizontalCoordinates{
+			Altitude: fixtureFloat(t, row, "altitude"),
+			Azimuth:  fixtureFloat(t, row, "azimuth"),
+		}
+
+		got := GeographicToHorizontal(target, observer, time.Time{})
+
+		for _, band := range geographicAltitudeLimits {
+			if distance > band.maxDistanceKm {
+				continue
+			}
+			altErr := math.Abs(got.Altitude - want.Altitude)
+			worstAlt[band.maxDistanceKm] = math.Max(worstAlt[band.maxDistanceKm], altErr)
+			if altErr > band.limit {
+				t.Errorf("%s: target %.0f km away at %.0f m: altitude %.3f°, want %.3f° (error %.3f° > %.2f°)",
+					row["observer"], distance, target.Altitude, got.Altitude, want.Altitude, altErr, band.limit)
+			}
+			break
+		}
+
+		// Azimuth is ill-defined near the zenith
+		if want.Altitude > 85 {
+			continue
+		}
+		azErr := azimuthError(got.Azimuth, want.Azimuth)
+		worstAz = math.Max(worstAz, azErr)
+		if azErr > geographicAzimuthLimit {
+			t.Errorf("%s: target %.0f km away: azimuth %.3f°, want %.3f° (error %.3f° > %.2f°)",
+				row["observer"], distance, got.Azimuth, want.Azimuth, azErr, geographicAzimuthLimit)
+		}
+	}
+
+	for _, band := range geographicAltitudeLimits {
+		t.Logf("Worst altitude error within %.0f km: %.3f°", band.maxDistanceKm, worstAlt[band.maxDistanceKm])
+	}
+	t.Logf("Worst azimuth error: %.3f°", worstAz)
+}
+
+// TestEquatorialAccuracy compares HorizontalToEquatorial and
+// EquatorialToHorizontal with IAU 2000 sidereal time references over a grid
+// of sites, times and pointing directions.
+func TestEquatorialAccuracy(t *testing.T) {
+	rows := readFixture(t, "equatorial.csv")
+	if len(rows) == 0 {
+		t.Fatal("Empty equatorial fixture")
+	}
+
+	var worstRA, worstDec, worstHoriz float64
+	for _, row := range rows {
+		observer := Observer{Location: Geographic{
+			Latitude:  fixtureFloat(t, row, "obs_lat"),
+			Longitude: fixtureFloat(t, row, "obs_lon"),
+		}}
+		at, err := time.Parse(time.RFC3339, row["time"])
+		if err != nil {
+			t.Fatalf("Bad time in fixture row %v: %v", row, err)
+		}
+		horizontal := HorizontalCoordinates{
+			Altitude: fixtureFloat(t, row, "altitude"),
+			Azimuth:  fixtureFloat(t, row, "azimuth"),
+		}
+		want := EquatorialCoordinates{
+			RightAscension: fixtureFloat(t, row, "ra_hours"),
+			Declination:    fixtureFloat(t, row, "dec"),
+		}
+
+		got := HorizontalToEquatorial(horizontal, observer, at)
+
+		// RA error as an angle on the sky
+		raErr := math.Abs(got.RightAscension - want.RightAscension)
+		raErr = math.Min(raErr, 24-raErr) * 15 * math.Cos(want.Declination*DegreesToRadians) * 3600
+		decErr := math.Abs(got.Declination-want.Declination) * 3600
+		worstRA = math.Max(worstRA, raErr)
+		worstDec = math.Max(worstDec, decErr)
+		if raErr > raLimitArcsec || decErr > decLimitArcsec {
+			t.Errorf("%s at %s, alt %.0f° az %.0f°: RA %.6fh Dec %.5f°, want RA %.6fh Dec %.5f°",
+				row["observer"], row["time"], horizontal.Altitude, horizontal.Azimuth,
+				got.RightAscension, got.Declination, want.RightAscension, want.Declination)
+		}
+
+		// And back from the reference RA/Dec
+		back := EquatorialToHorizontal(want, observer, at)
+		horizErr := AngularSeparation(back, horizontal) * 3600
+		worstHoriz = math.Max(worstHoriz, horizErr)
+		if horizErr > raLimitArcsec {
+			t.Errorf("%s at %s: RA %.6fh Dec %.5f° converts to alt %.5f° az %.5f°, want alt %.0f° az %.0f°",
+				row["observer"], row["time"], want.RightAscension, want.Declination,
+				back.Altitude, back.Azimuth, horizontal.Altitude, horizontal.Azimuth)
+		}
+	}
+
+	t.Logf("Worst errors: RA %.3f\", Dec %.3f\", alt/az %.3f\"", worstRA, worstDec, worstHoriz)
+}
@@ -3,6 +3,8 @@ package coordinates
 import (
 	"math"
 	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // Constants for coordinate calculations
@@ -35,7 +37,7 @@ type Geographic struct {
 	Longitude float64
 
 	// Altitude in meters above mean sea level (MSL)
-	Altitude float64
+	Altitude units.Meters
 }
 
 // HorizontalCoordinates represents a position in the local horizontal coordinate system.
@@ -102,7 +104,7 @@ type AircraftPosition struct {
 func (g Geographic) ToRadians() (float64, float64, float64) {
 	return g.Latitude * DegreesToRadians,
 		g.Longitude * DegreesToRadians,
-		g.Altitude
+		float64(g.Altitude)
 }
 
 // ToRadians converts HorizontalCoordinates to radians.
@@ -150,6 +152,22 @@ func NormalizeAzimuth(azimuth float64) float64 {
 	return az
 }
 
+// AngularSeparation calculates the great-circle angle between two points in
+// the sky. Returns the separation in degrees (0-180).
+func AngularSeparation(a, b HorizontalCoordinates) float64 {
+	altA, azA := a.ToRadians()
+	altB, azB := b.ToRadians()
+	dAz := azB - azA
+
+	sinDist := math.Hypot(
+		math.Cos(altB)*math.Sin(dAz),
+		math.Cos(altA)*math.Sin(altB)-math.Sin(altA)*math.Cos(altB)*math.Cos(dAz),
+	)
+	cosDist := math.Sin(altA)*math.Sin(altB) + math.Cos(altA)*math.Cos(altB)*math.Cos(dAz)
+
+	return math.Atan2(sinDist, cosDist) * RadiansToDegrees
+}
+
 // NormalizeRA ensures right ascension is in the range [0, 24).
 func NormalizeRA(ra float64) float64 {
 	raHours := math.Mod(ra, 24.0)
@@ -181,6 +199,71 @@ func Bearing(from, to Geographic) float64 {
 	return bearing
 }
 
+// Destination returns the point reached by travelling distanceNM along a great
+// circle from the starting point on the given initial bearing (degrees true).
+// The altitude of the starting point is preserved.
+func Destination(from Geographic, bearingDeg, distanceNM float64) Geographic {
+	lat1 := from.Latitude * DegreesToRadians
+	lon1 := from.Longitude * DegreesToRadians
+	brng := bearingDeg * DegreesToRadians
+
+	// Angular distance (1 nm = 1.852 km)
+	d := distanceNM * 1.852 / EarthRadiusKm
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(brng))
+	lon2 := lon1 + math.Atan2(
+		math.Sin(brng)*math.Sin(d)*math.Cos(lat1),
+		math.Cos(d)-math.Sin(lat1)*math.Sin(lat2),
+	)
+
+	// Normalize longitude to -180..+180
+	lon := math.Mod(lon2*RadiansToDegrees+540, 360) - 180
+
+	return Geographic{
+		Latitude:  lat2 * RadiansToDegrees,
+		Longitude: lon,
+		Altitude:  from.Altitude,
+	}
+}
+
+// GreatCirclePoints returns points along the great circle from one point to
+// another, no more than maxStepNM apart, including both ends. Drawing
+// straight lines between them on a flat map approximates the curved route.
+// Altitude is interpolated linearly.
+func GreatCirclePoints(from, to Geographic, maxStepNM float64) []Geographic {
+	distance := DistanceNauticalMiles(from, to)
+	steps := 1
+	if maxStepNM > 0 {
+		steps = max(1, int(math.Ceil(distance/maxStepNM)))
+	}
+
+	lat1 := from.Latitude * DegreesToRadians
+	lon1 := from.Longitude * DegreesToRadians
+	lat2 := to.Latitude * DegreesToRadians
+	lon2 := to.Longitude * DegreesToRadians
+	d := distance * 1.852 / EarthRadiusKm
+
+	points := make([]Geographic, 0, steps+1)
+	points = append(points, from)
+	for i := 1; i < steps; i++ {
+		f := float64(i) / float64(steps)
+
+		// Spherical interpolation between the two unit vectors
+		a := math.Sin((1-f)*d) / math.Sin(d)
+		b := math.Sin(f*d) / math.Sin(d)
+		x := a*math.Cos(lat1)*math.Cos(lon1) + b*math.Cos(lat2)*math.Cos(lon2)
+		y := a*math.Cos(lat1)*math.Sin(lon1) + b*math.Cos(lat2)*math.Sin(lon2)
+		z := a*math.Sin(lat1) + b*math.Sin(lat2)
+
+		points = append(points, Geographic{
+			Latitude:  math.Atan2(z, math.Hypot(x, y)) * RadiansToDegrees,
+			Longitude: math.Atan2(y, x) * RadiansToDegrees,
+			Altitude:  from.Altitude + units.Meters(f)*(to.Altitude-from.Altitude),
+		})
+	}
+	return append(points, to)
+}
+
 // DistanceNauticalMiles calculates the great-circle distance between two points.
 // Uses the Haversine formula for accuracy over short and long distances.
 // Returns distance in nautical miles.
@@ -0,0 +1,58 @@
+package coordinates
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGreatCirclePoints tests interpolation along great circles.
+func TestGreatCirclePoints(t *testing.T) {
+	jfk := Geographic{Latitude: 40.6398, Longitude: -73.7789, Altitude: 0}
+	lhr := Geographic{Latitude: 51.4700, Longitude: -0.4543, Altitude: 1000}
+
+	tests := []struct {
+		name       string
+		from, to   Geographic
+		maxStepNM  float64
+		wantPoints int
+	}{
+		{"Transatlantic", jfk, lhr, 100, 31},
+		{"Short leg", jfk, Destination(jfk, 90, 10), 100, 2},
+		{"No step limit", jfk, lhr, 0, 2},
+		{"Same point", jfk, jfk, 100, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := GreatCirclePoints(tt.from, tt.to, tt.maxStepNM)
+			if len(points) != tt.wantPoints {
+				t.Fatalf("Expected %d points, got %d", tt.wantPoints, len(points))
+			}
+			if points[0] != tt.from || points[len(points)-1] != tt.to {
+				t.Error("Expected the route to start and end at the given points")
+			}
+
+			// Every point lies on the great circle: distances add up
+			total := DistanceNauticalMiles(tt.from, tt.to)
+			for i, p := range points {
+				via := DistanceNauticalMiles(tt.from, p) + DistanceNauticalMiles(p, tt.to)
+				if math.Abs(via-total) > 0.01 {
+					t.Errorf("Point %d is %.3f NM off the great circle", i, via-total)
+				}
+				if i > 0 && tt.maxStepNM > 0 && DistanceNauticalMiles(points[i-1], p) > tt.maxStepNM+0.01 {
+					t.Errorf("Step %d is longer than %.0f NM", i, tt.maxStepNM)
+				}
+			}
+		})
+	}
+
+	// The great circle from New York to London bulges north of both ends
+	points := GreatCirclePoints(jfk, lhr, 100)
+	mid := points[len(points)/2]
+	if mid.Latitude <= lhr.Latitude {
+		t.Errorf("Expected the midpoint north of London, got %.2f°", mid.Latitude)
+	}
+	if math.Abs(float64(mid.Altitude)-500) > 50 {
+		t.Errorf("Expected interpolated altitude near 500, got %.0f", mid.Altitude)
+	}
+}
@@ -0,0 +1,79 @@
+package coordinates
+
+import (
+	"math"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// footprintSegments is the number of points on a footprint outline.
+const footprintSegments = 16
+
+// PointingGroundPoint finds where a line of sight from the observer reaches
+// a given altitude, i.e. the spot on the map beneath what the telescope sees
+// at that height.
+//
+// Earth curvature is included (spherical Earth, no refraction), so lines of
+// sight slightly below the horizon still reach altitudes above the observer.
+//
+// Parameters:
+//   - observer: Observer location (altitude in meters)
+//   - pointing: Line of sight (altitude and azimuth in degrees)
+//   - altitude: Altitude the line of sight is intersected with
+//   - maxRangeNM: Ground distance cap for lines of sight that never reach it
+//
+// Returns: The ground position and its distance from the observer in
+// nautical miles. ok is false if the distance was capped.
+//
+// Formula: d²/2R + d·tan(alt) − (h − h₀) = 0, solved for ground distance d
+func PointingGroundPoint(observer Geographic, pointing HorizontalCoordinates, altitude units.Meters, maxRangeNM float64) (point Geographic, distanceNM float64, ok bool) {
+	radiusM := EarthRadiusKm * 1000
+	tanAlt := math.Tan(pointing.Altitude * DegreesToRadians)
+	climb := float64(altitude - observer.Altitude)
+
+	distanceM := math.NaN()
+	if disc := tanAlt*tanAlt + 2*climb/radiusM; disc >= 0 {
+		distanceM = radiusM * (math.Sqrt(disc) - tanAlt)
+	}
+
+	distanceNM = distanceM / 1852
+	ok = distanceNM >= 0 && distanceNM <= maxRangeNM
+	if !ok {
+		distanceNM = maxRangeNM
+	}
+
+	point = Destination(observer, pointing.Azimuth, distanceNM)
+	point.Altitude = altitude
+	return point, distanceNM, ok
+}
+
+// PointingFootprint outlines the telescope's field of view where it meets a
+// given altitude, as a closed ring of ground positions for drawing on a map.
+//
+// Parameters:
+//   - observer: Observer location (altitude in meters)
+//   - pointing: Centre of the field of view (altitude and azimuth in degrees)
+//   - fovDeg: Field of view diameter in degrees
+//   - altitude: Altitude the field of view is intersected with
+//   - maxRangeNM: Ground distance cap (see PointingGroundPoint)
+//
+// Returns: Outline points; the first point is repeated at the end.
+func PointingFootprint(observer Geographic, pointing HorizontalCoordinates, fovDeg float64, altitude units.Meters, maxRangeNM float64) []Geographic {
+	half := fovDeg / 2
+	outline := make([]Geographic, 0, footprintSegments+1)
+
+	for i := 0; i < footprintSegments; i++ {
+		angle := 2 * math.Pi * float64(i) / footprintSegments
+
+		// Offset on the sky; azimuth spacing widens with altitude
+		edge := HorizontalCoordinates{
+			Altitude: pointing.Altitude + half*math.Cos(angle),
+			Azimuth:  NormalizeAzimuth(pointing.Azimuth + half*math.Sin(angle)/math.Max(math.Cos(pointing.Altitude*DegreesToRadians), 0.01)),
+		}
+
+		point, _, _ := PointingGroundPoint(observer, edge, altitude, maxRangeNM)
+		outline = append(outline, point)
+	}
+
+	return append(outline, outline[0])
+}
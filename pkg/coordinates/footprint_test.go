@@ -0,0 +1,75 @@
+package coordinates
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestPointingGroundPoint tests where a line of sight reaches an altitude.
+func TestPointingGroundPoint(t *testing.T) {
+	observer := Geographic{Latitude: 35.0, Longitude: -80.0, Altitude: 0}
+
+	tests := []struct {
+		name        string
+		pointing    HorizontalCoordinates
+		altitudeM   units.Meters
+		wantNM      float64
+		tolerance   float64
+		wantOK      bool
+		wantBearing float64
+	}{
+		{"45 degrees east", HorizontalCoordinates{Altitude: 45, Azimuth: 90}, 3048, 3048.0 / 1852, 0.01, true, 90},
+		{"Zenith", HorizontalCoordinates{Altitude: 90, Azimuth: 0}, 3048, 0, 0.01, true, 0},
+		{"Low north", HorizontalCoordinates{Altitude: 5, Azimuth: 0}, 10000, 56.4, 0.1, true, 0},
+		{"Below target altitude", HorizontalCoordinates{Altitude: -10, Azimuth: 180}, 1000, 200, 0.01, false, 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point, distance, ok := PointingGroundPoint(observer, tt.pointing, tt.altitudeM, 200)
+
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(distance-tt.wantNM) > tt.tolerance {
+				t.Errorf("distance = %.3f NM, want %.3f NM", distance, tt.wantNM)
+			}
+			if got := DistanceNauticalMiles(observer, point); math.Abs(got-distance) > 0.01 {
+				t.Errorf("point is %.3f NM away, want %.3f NM", got, distance)
+			}
+			if distance > 0.1 {
+				if bearing := Bearing(observer, point); math.Abs(bearing-tt.wantBearing) > 0.5 {
+					t.Errorf("bearing = %.2f°, want %.2f°", bearing, tt.wantBearing)
+				}
+			}
+			if point.Altitude != tt.altitudeM {
+				t.Errorf("point altitude = %.0f m, want %.0f m", point.Altitude, tt.altitudeM)
+			}
+		})
+	}
+}
+
+// TestPointingFootprint tests the field of view outline.
+func TestPointingFootprint(t *testing.T) {
+	observer := Geographic{Latitude: 35.0, Longitude: -80.0, Altitude: 0}
+	pointing := HorizontalCoordinates{Altitude: 30, Azimuth: 45}
+
+	outline := PointingFootprint(observer, pointing, 2.0, 5000, 200)
+
+	if len(outline) != footprintSegments+1 {
+		t.Fatalf("len(outline) = %d, want %d", len(outline), footprintSegments+1)
+	}
+	if outline[0] != outline[len(outline)-1] {
+		t.Error("outline is not closed")
+	}
+
+	center, _, _ := PointingGroundPoint(observer, pointing, 5000, 200)
+	for i, p := range outline {
+		// 2° at ~10 km slant range is a few hundred meters
+		if d := DistanceNauticalMiles(center, p); d <= 0 || d > 2 {
+			t.Errorf("point %d is %.3f NM from the centre", i, d)
+		}
+	}
+}
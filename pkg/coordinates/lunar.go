@@ -0,0 +1,88 @@
+package coordinates
+
+import (
+	"math"
+	"time"
+)
+
+// MoonPosition represents the moon's position and phase in the sky
+type MoonPosition struct {
+	Altitude     float64   // Degrees above horizon (topocentric)
+	Azimuth      float64   // Degrees from north
+	Illumination float64   // Illuminated fraction of the disk (0 = new, 1 = full)
+	Waxing       bool      // True between new and full moon
+	Time         time.Time // Calculation time
+}
+
+// CalculateMoonPosition calculates the moon's position for a given observer and time.
+// Uses the low-precision series from the Astronomical Almanac, accurate to
+// about 0.3°, which is plenty for plotting and glare avoidance. Parallax is
+// corrected since the moon is close enough to shift by up to 1°.
+func CalculateMoonPosition(observer Observer, t time.Time) MoonPosition {
+	jd := julianDate(t.UTC())
+	jc := (jd - 2451545.0) / 36525.0
+
+	// Fundamental arguments (degrees)
+	meanLong := 218.316 + 481267.881*jc   // Moon's mean longitude
+	moonAnom := 134.963 + 477198.868*jc   // Moon's mean anomaly
+	argLat := 93.272 + 483202.019*jc      // Moon's argument of latitude
+	elongation := 297.850 + 445267.112*jc // Mean elongation from the sun
+	sunAnom := 357.529 + 35999.050*jc     // Sun's mean anomaly
+
+	Mp, F, D, M := deg2rad(moonAnom), deg2rad(argLat), deg2rad(elongation), deg2rad(sunAnom)
+
+	// Ecliptic longitude and latitude (degrees)
+	lambda := meanLong +
+		6.289*math.Sin(Mp) +
+		1.274*math.Sin(2*D-Mp) +
+		0.658*math.Sin(2*D) +
+		0.214*math.Sin(2*Mp) -
+		0.186*math.Sin(M) -
+		0.114*math.Sin(2*F)
+	beta := 5.128*math.Sin(F) +
+		0.281*math.Sin(Mp+F) +
+		0.278*math.Sin(Mp-F) +
+		0.173*math.Sin(2*D-F)
+
+	// Horizontal parallax (degrees)
+	parallax := 0.9508 +
+		0.0518*math.Cos(Mp) +
+		0.0095*math.Cos(2*D-Mp) +
+		0.0078*math.Cos(2*D) +
+		0.0028*math.Cos(2*Mp)
+
+	// Equatorial coordinates
+	epsilon := deg2rad(23.439 - 0.0130*jc)
+	lambdaRad, betaRad := deg2rad(lambda), deg2rad(beta)
+	ra := rad2deg(math.Atan2(
+		math.Sin(lambdaRad)*math.Cos(epsilon)-math.Tan(betaRad)*math.Sin(epsilon),
+		math.Cos(lambdaRad),
+	))
+	dec := math.Asin(math.Sin(betaRad)*math.Cos(epsilon) + math.Cos(betaRad)*math.Sin(epsilon)*math.Sin(lambdaRad))
+
+	// Greenwich mean sidereal time and local hour angle (degrees)
+	gmst := math.Mod(280.46061837+360.98564736629*(jd-2451545.0)+
+		0.000387933*jc*jc-jc*jc*jc/38710000.0, 360.0)
+	ha := deg2rad(gmst + observer.Location.Longitude - ra)
+
+	// Horizontal coordinates (geocentric)
+	lat := deg2rad(observer.Location.Latitude)
+	altitude := math.Asin(math.Sin(lat)*math.Sin(dec) + math.Cos(lat)*math.Cos(dec)*math.Cos(ha))
+	azimuth := math.Atan2(-math.Sin(ha)*math.Cos(dec), math.Sin(dec)*math.Cos(lat)-math.Cos(dec)*math.Sin(lat)*math.Cos(ha))
+
+	// Topocentric correction: parallax lowers the moon by up to ~1°
+	topoAlt := rad2deg(altitude) - parallax*math.Cos(altitude)
+
+	// Phase from the elongation between moon and sun
+	sunLong := 280.460 + 36000.770*jc + 1.915*math.Sin(M) + 0.020*math.Sin(2*M)
+	diff := deg2rad(lambda - sunLong)
+	cosElong := math.Cos(betaRad) * math.Cos(diff)
+
+	return MoonPosition{
+		Altitude:     topoAlt,
+		Azimuth:      NormalizeAzimuth(rad2deg(azimuth)),
+		Illumination: (1 - cosElong) / 2,
+		Waxing:       math.Sin(diff) > 0,
+		Time:         t,
+	}
+}
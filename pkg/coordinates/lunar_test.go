@@ -0,0 +1,56 @@
+package coordinates
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestCalculateMoonPosition tests the moon's phase and position at known lunations.
+func TestCalculateMoonPosition(t *testing.T) {
+	observer := Observer{Location: Geographic{Latitude: 35.0, Longitude: -80.0}}
+
+	tests := []struct {
+		name             string
+		time             time.Time
+		wantIllumination float64
+		tolerance        float64
+	}{
+		{"New moon 2024-01-11", time.Date(2024, 1, 11, 11, 57, 0, 0, time.UTC), 0.0, 0.02},
+		{"First quarter 2024-01-18", time.Date(2024, 1, 18, 3, 53, 0, 0, time.UTC), 0.5, 0.05},
+		{"Full moon 2024-01-25", time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC), 1.0, 0.02},
+		{"Last quarter 2024-02-02", time.Date(2024, 2, 2, 23, 18, 0, 0, time.UTC), 0.5, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moon := CalculateMoonPosition(observer, tt.time)
+
+			if math.Abs(moon.Illumination-tt.wantIllumination) > tt.tolerance {
+				t.Errorf("Illumination = %.3f, want %.3f", moon.Illumination, tt.wantIllumination)
+			}
+			if moon.Altitude < -90 || moon.Altitude > 90 {
+				t.Errorf("Altitude = %.2f out of range", moon.Altitude)
+			}
+			if moon.Azimuth < 0 || moon.Azimuth >= 360 {
+				t.Errorf("Azimuth = %.2f out of range", moon.Azimuth)
+			}
+		})
+	}
+
+	// Full moon rises around sunset, so it is roughly opposite the sun
+	full := time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC)
+	moon := CalculateMoonPosition(observer, full)
+	sun := CalculateSunPosition(observer, full)
+	if sep := sun.AngularSeparation(moon.Altitude, moon.Azimuth); sep < 170 {
+		t.Errorf("full moon is %.1f° from the sun, want ~180°", sep)
+	}
+
+	// Between first quarter and full the moon is waxing
+	if !CalculateMoonPosition(observer, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)).Waxing {
+		t.Error("moon on 2024-01-20 should be waxing")
+	}
+	if CalculateMoonPosition(observer, time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)).Waxing {
+		t.Error("moon on 2024-01-30 should be waning")
+	}
+}
@@ -0,0 +1,54 @@
+package coordinates
+
+import "math"
+
+// SiderealRate is the Earth's rotation rate relative to the stars in rad/s.
+const SiderealRate = 7.2921159e-5
+
+// ParallacticAngle calculates the parallactic angle for a pointing direction.
+//
+// The parallactic angle is the angle between the direction to the celestial
+// pole and the zenith, measured at the target. On an alt-az mount the sky
+// rotates by this angle in the field of view; a rotator must counter it to
+// keep long exposures from smearing.
+//
+// Parameters:
+//   - horizontal: The pointing direction (altitude and azimuth in degrees)
+//   - latitudeDeg: Observer's latitude in decimal degrees
+//
+// Returns: Parallactic angle in degrees (-180 to +180). Negative east of the
+// meridian, zero on it, positive west of it (for the northern hemisphere).
+//
+// Formula: q = atan2(-sin(az)·cos(lat), sin(lat)·cos(alt) - cos(lat)·sin(alt)·cos(az))
+func ParallacticAngle(horizontal HorizontalCoordinates, latitudeDeg float64) float64 {
+	altRad, azRad := horizontal.ToRadians()
+	latRad := latitudeDeg * DegreesToRadians
+
+	q := math.Atan2(
+		-math.Sin(azRad)*math.Cos(latRad),
+		math.Sin(latRad)*math.Cos(altRad)-math.Cos(latRad)*math.Sin(altRad)*math.Cos(azRad),
+	)
+
+	return q * RadiansToDegrees
+}
+
+// FieldRotationRate calculates how fast the field rotates at a fixed point
+// on the sky for an alt-az mount, i.e. the sidereal rate of change of the
+// parallactic angle.
+//
+// Parameters:
+//   - horizontal: The pointing direction (altitude and azimuth in degrees)
+//   - latitudeDeg: Observer's latitude in decimal degrees
+//
+// Returns: Rotation rate in degrees per second. The rate grows without bound
+// near the zenith, where an alt-az mount cannot keep up.
+//
+// Formula: dq/dt = -ω·cos(lat)·cos(az) / cos(alt)
+func FieldRotationRate(horizontal HorizontalCoordinates, latitudeDeg float64) float64 {
+	altRad, azRad := horizontal.ToRadians()
+	latRad := latitudeDeg * DegreesToRadians
+
+	rate := -SiderealRate * math.Cos(latRad) * math.Cos(azRad) / math.Cos(altRad)
+
+	return rate * RadiansToDegrees
+}
@@ -0,0 +1,85 @@
+package coordinates
+
+import (
+	"math"
+	"testing"
+)
+
+// horizontalFromHourAngle converts hour angle and declination (degrees) to
+// alt/az for tests, independent of sidereal time.
+func horizontalFromHourAngle(haDeg, decDeg, latDeg float64) HorizontalCoordinates {
+	ha, dec, lat := haDeg*DegreesToRadians, decDeg*DegreesToRadians, latDeg*DegreesToRadians
+
+	alt := math.Asin(math.Sin(dec)*math.Sin(lat) + math.Cos(dec)*math.Cos(lat)*math.Cos(ha))
+	az := math.Atan2(-math.Sin(ha)*math.Cos(dec), math.Sin(dec)*math.Cos(lat)-math.Cos(dec)*math.Sin(lat)*math.Cos(ha))
+
+	return HorizontalCoordinates{
+		Altitude: alt * RadiansToDegrees,
+		Azimuth:  NormalizeAzimuth(az * RadiansToDegrees),
+	}
+}
+
+// TestParallacticAngle tests the parallactic angle against the hour angle form.
+func TestParallacticAngle(t *testing.T) {
+	const lat = 37.0
+
+	tests := []struct {
+		name string
+		ha   float64 // degrees, positive west
+		dec  float64
+	}{
+		{"South on meridian", 0, 20},
+		{"East", -45, 10},
+		{"West", 45, 10},
+		{"Northeast low", -100, 50},
+		{"Southwest high", 20, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			horiz := horizontalFromHourAngle(tt.ha, tt.dec, lat)
+			got := ParallacticAngle(horiz, lat)
+
+			// tan q = sin(HA) / (tan(lat)·cos(dec) - sin(dec)·cos(HA))
+			ha, dec, latRad := tt.ha*DegreesToRadians, tt.dec*DegreesToRadians, lat*DegreesToRadians
+			want := math.Atan2(math.Sin(ha), math.Tan(latRad)*math.Cos(dec)-math.Sin(dec)*math.Cos(ha)) * RadiansToDegrees
+
+			if math.Abs(got-want) > 0.01 {
+				t.Errorf("ParallacticAngle = %.3f°, want %.3f°", got, want)
+			}
+			if tt.ha < 0 && got >= 0 {
+				t.Errorf("Expected negative angle east of meridian, got %.2f°", got)
+			}
+		})
+	}
+}
+
+// TestFieldRotationRate tests the analytic rate against the change in
+// parallactic angle of a star over one minute either side.
+func TestFieldRotationRate(t *testing.T) {
+	const lat = 37.0
+	degPerMinute := SiderealRate * RadiansToDegrees * 60
+
+	stars := []struct {
+		ha  float64
+		dec float64
+	}{
+		{-60, 20},
+		{-10, -10},
+		{30, 50},
+		{90, 60},
+	}
+
+	for _, star := range stars {
+		before := horizontalFromHourAngle(star.ha-degPerMinute, star.dec, lat)
+		after := horizontalFromHourAngle(star.ha+degPerMinute, star.dec, lat)
+
+		measured := (ParallacticAngle(after, lat) - ParallacticAngle(before, lat)) / 120.0
+		got := FieldRotationRate(horizontalFromHourAngle(star.ha, star.dec, lat), lat)
+
+		if math.Abs(got-measured) > 1e-5 {
+			t.Errorf("HA %.0f° Dec %.0f°: FieldRotationRate = %.6f°/s, measured %.6f°/s",
+				star.ha, star.dec, got, measured)
+		}
+	}
+}
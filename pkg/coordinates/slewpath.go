@@ -0,0 +1,145 @@
+package coordinates
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoSunSafePath is returned when a slew cannot be planned clear of the
+// sun: the target itself is too close to the sun, or no single detour
+// clears the exclusion zone.
+var ErrNoSunSafePath = errors.New("no sun-safe slew path")
+
+// detourMargins are the waypoint distances from the sun tried when routing
+// around the exclusion zone, as multiples of its radius.
+var detourMargins = []float64{1.1, 1.25, 1.5, 2, 3}
+
+// vector is a unit vector on the celestial sphere: x north, y east, z up.
+type vector [3]float64
+
+// toVector converts horizontal coordinates to a unit vector.
+func toVector(h HorizontalCoordinates) vector {
+	alt := h.Altitude * DegreesToRadians
+	az := h.Azimuth * DegreesToRadians
+	return vector{math.Cos(alt) * math.Cos(az), math.Cos(alt) * math.Sin(az), math.Sin(alt)}
+}
+
+// horizontal converts a unit vector back to horizontal coordinates.
+func (v vector) horizontal() HorizontalCoordinates {
+	az := math.Atan2(v[1], v[0]) * RadiansToDegrees
+	if az < 0 {
+		az += 360
+	}
+	return HorizontalCoordinates{
+		Altitude: math.Asin(math.Max(-1, math.Min(1, v[2]))) * RadiansToDegrees,
+		Azimuth:  az,
+	}
+}
+
+func (v vector) dot(w vector) float64 {
+	return v[0]*w[0] + v[1]*w[1] + v[2]*w[2]
+}
+
+func (v vector) cross(w vector) vector {
+	return vector{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
+
+func (v vector) scale(k float64) vector {
+	return vector{v[0] * k, v[1] * k, v[2] * k}
+}
+
+func (v vector) add(w vector) vector {
+	return vector{v[0] + w[0], v[1] + w[1], v[2] + w[2]}
+}
+
+func (v vector) norm() float64 {
+	return math.Sqrt(v.dot(v))
+}
+
+// angle returns the angle between two unit vectors in degrees.
+func (v vector) angle(w vector) float64 {
+	return math.Atan2(v.cross(w).norm(), v.dot(w)) * RadiansToDegrees
+}
+
+// SlewPathSeparation returns the closest approach in degrees of the
+// great-circle slew from `from` to `to` to a point in the sky (e.g. the sun).
+func SlewPathSeparation(from, to, point HorizontalCoordinates) float64 {
+	a, b, p := toVector(from), toVector(to), toVector(point)
+	closest := math.Min(a.angle(p), b.angle(p))
+
+	// The path's plane; a zero-length or antipodal slew has no unique path
+	normal := a.cross(b)
+	if normal.norm() < 1e-9 {
+		return closest
+	}
+	normal = normal.scale(1 / normal.norm())
+
+	// The nearest point of the full great circle, if it lies on the arc
+	foot := p.add(normal.scale(-p.dot(normal)))
+	if foot.norm() < 1e-9 {
+		return closest
+	}
+	foot = foot.scale(1 / foot.norm())
+	if math.Abs(a.angle(foot)+foot.angle(b)-a.angle(b)) < 1e-6 {
+		closest = math.Min(closest, foot.angle(p))
+	}
+	return closest
+}
+
+// PlanSunSafeSlew plans a slew from `from` to `to` that keeps at least
+// exclusion degrees from the sun along the great-circle path. The result is
+// the points to slew to in order: just the target if the direct path is
+// clear, otherwise an intermediate waypoint that routes around the sun
+// followed by the target.
+//
+// Parameters:
+//   - sun: The sun's current position
+//   - exclusion: Radius of the solar exclusion zone in degrees
+//   - reachable: Reports whether the mount may be pointed at a waypoint
+//     (e.g. it is within the altitude limits); nil allows any
+//
+// A slew starting inside the zone is not re-routed, since the quickest way
+// out is the best one. Returns ErrNoSunSafePath if the target is inside the
+// zone or no single waypoint clears it.
+func PlanSunSafeSlew(from, to HorizontalCoordinates, sun SunPosition, exclusion float64, reachable func(HorizontalCoordinates) bool) ([]HorizontalCoordinates, error) {
+	s := HorizontalCoordinates{Altitude: sun.Altitude, Azimuth: sun.Azimuth}
+	if sep := sun.AngularSeparation(to.Altitude, to.Azimuth); sep < exclusion {
+		return nil, fmt.Errorf("target is %.1f° from the sun (minimum %.0f°): %w", sep, exclusion, ErrNoSunSafePath)
+	}
+	if sun.AngularSeparation(from.Altitude, from.Azimuth) < exclusion ||
+		SlewPathSeparation(from, to, s) >= exclusion {
+		return []HorizontalCoordinates{to}, nil
+	}
+
+	// Detour perpendicular to the path's plane, first on the side of the sun
+	// the path passes
+	a, b, sv := toVector(from), toVector(to), toVector(s)
+	normal := a.cross(b)
+	if normal.norm() < 1e-9 {
+		// Antipodal slew: any plane through both ends will do
+		normal = a.cross(vector{0, 0, 1})
+		if normal.norm() < 1e-9 {
+			normal = vector{0, 1, 0}
+		}
+	}
+	away := normal.add(sv.scale(-normal.dot(sv)))
+	away = away.scale(1 / away.norm())
+	if sv.dot(normal) > 0 {
+		away = away.scale(-1)
+	}
+
+	for _, direction := range []float64{1, -1} {
+		for _, margin := range detourMargins {
+			r := math.Min(exclusion*margin, 90) * DegreesToRadians
+			waypoint := sv.scale(math.Cos(r)).add(away.scale(direction * math.Sin(r))).horizontal()
+			if reachable != nil && !reachable(waypoint) {
+				continue
+			}
+			if SlewPathSeparation(from, waypoint, s) >= exclusion && SlewPathSeparation(waypoint, to, s) >= exclusion {
+				return []HorizontalCoordinates{waypoint, to}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("slew passes within %.0f° of the sun and no detour clears it: %w", exclusion, ErrNoSunSafePath)
+}
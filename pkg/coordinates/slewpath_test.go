@@ -0,0 +1,90 @@
+package coordinates
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestSlewPathSeparation tests the closest approach of a slew to a point.
+func TestSlewPathSeparation(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to HorizontalCoordinates
+		point    HorizontalCoordinates
+		want     float64
+	}{
+		{"Along the horizon through the point", HorizontalCoordinates{Altitude: 0, Azimuth: 100}, HorizontalCoordinates{Altitude: 0, Azimuth: 260}, HorizontalCoordinates{Altitude: 0, Azimuth: 180}, 0},
+		{"Below the point", HorizontalCoordinates{Altitude: 0, Azimuth: 150}, HorizontalCoordinates{Altitude: 0, Azimuth: 210}, HorizontalCoordinates{Altitude: 10, Azimuth: 180}, 10},
+		{"Point beyond the end", HorizontalCoordinates{Altitude: 0, Azimuth: 90}, HorizontalCoordinates{Altitude: 0, Azimuth: 120}, HorizontalCoordinates{Altitude: 0, Azimuth: 180}, 60},
+		{"Zero-length slew", HorizontalCoordinates{Altitude: 45, Azimuth: 0}, HorizontalCoordinates{Altitude: 45, Azimuth: 0}, HorizontalCoordinates{Altitude: 45, Azimuth: 90}, 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlewPathSeparation(tt.from, tt.to, tt.point); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("SlewPathSeparation = %.2f°, want %.2f°", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPlanSunSafeSlew tests that slews are routed around the sun only when
+// the direct path sweeps through the exclusion zone.
+func TestPlanSunSafeSlew(t *testing.T) {
+	sun := SunPosition{Altitude: 30, Azimuth: 180}
+	sunPoint := HorizontalCoordinates{Altitude: 30, Azimuth: 180}
+	withinLimits := func(h HorizontalCoordinates) bool {
+		return h.Altitude >= 0 && h.Altitude <= 80
+	}
+
+	t.Run("Clear path is direct", func(t *testing.T) {
+		to := HorizontalCoordinates{Altitude: 30, Azimuth: 60}
+		path, err := PlanSunSafeSlew(HorizontalCoordinates{Altitude: 30, Azimuth: 10}, to, sun, 20, withinLimits)
+		if err != nil {
+			t.Fatalf("PlanSunSafeSlew failed: %v", err)
+		}
+		if len(path) != 1 || path[0] != to {
+			t.Errorf("Path = %v, want direct to %v", path, to)
+		}
+	})
+
+	t.Run("Path past the sun is detoured", func(t *testing.T) {
+		from := HorizontalCoordinates{Altitude: 30, Azimuth: 130}
+		to := HorizontalCoordinates{Altitude: 30, Azimuth: 230}
+		if sep := SlewPathSeparation(from, to, sunPoint); sep >= 20 {
+			t.Fatalf("Direct path is %.1f° from the sun, test needs it inside the zone", sep)
+		}
+
+		path, err := PlanSunSafeSlew(from, to, sun, 20, withinLimits)
+		if err != nil {
+			t.Fatalf("PlanSunSafeSlew failed: %v", err)
+		}
+		if len(path) != 2 || path[1] != to {
+			t.Fatalf("Path = %v, want waypoint then %v", path, to)
+		}
+		if !withinLimits(path[0]) {
+			t.Errorf("Waypoint %v is outside the altitude limits", path[0])
+		}
+		for i, leg := range [][2]HorizontalCoordinates{{from, path[0]}, {path[0], to}} {
+			if sep := SlewPathSeparation(leg[0], leg[1], sunPoint); sep < 20 {
+				t.Errorf("Leg %d passes %.1f° from the sun", i+1, sep)
+			}
+		}
+	})
+
+	t.Run("Target near the sun is refused", func(t *testing.T) {
+		_, err := PlanSunSafeSlew(HorizontalCoordinates{Altitude: 30, Azimuth: 90}, HorizontalCoordinates{Altitude: 35, Azimuth: 185}, sun, 20, withinLimits)
+		if !errors.Is(err, ErrNoSunSafePath) {
+			t.Errorf("Error = %v, want ErrNoSunSafePath", err)
+		}
+	})
+
+	t.Run("Leaving the zone is direct", func(t *testing.T) {
+		to := HorizontalCoordinates{Altitude: 30, Azimuth: 240}
+		path, err := PlanSunSafeSlew(HorizontalCoordinates{Altitude: 30, Azimuth: 175}, to, sun, 20, withinLimits)
+		if err != nil || len(path) != 1 {
+			t.Errorf("Path = %v, %v; want direct", path, err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+#!/usr/bin/env python3
+"""Generate reference fixtures for the coordinate conversion accuracy tests.
+
+The references are computed independently of the Go code, with the
+rigorous models it approximates:
+
+  geographic.csv  Topocentric altitude/azimuth of targets on the WGS84
+                  ellipsoid: geodetic -> ECEF -> local east/north/up.
+                  Geometric (no refraction), heights in meters.
+  equatorial.csv  Right ascension/declination of alt/az directions using
+                  the IAU 2000 Earth rotation angle based GMST (UT1 = UTC),
+                  mean equator and equinox of date, no refraction.
+
+Only the Python standard library is used. Rerun after changing the grid:
+
+    python3 pkg/coordinates/testdata/reference.py
+"""
+
+import csv
+import math
+import os
+from datetime import datetime, timezone
+
+HERE = os.path.dirname(os.path.abspath(__file__))
+
+# WGS84 ellipsoid
+A = 6378137.0
+F = 1 / 298.257223563
+E2 = F * (2 - F)
+
+OBSERVERS = [
+    # name, latitude, longitude, height (m)
+    ("Greenwich", 51.4779, -0.0015, 46),
+    ("Kansas City", 39.0997, -94.5786, 277),
+    ("Quito", -0.1807, -78.4678, 2850),
+    ("Sydney", -33.8688, 151.2093, 58),
+    ("Tromso", 69.6492, 18.9553, 10),
+    ("Dateline", -16.5, 179.95, 5),
+]
+
+TIMES = [
+    "2000-01-01T12:00:00Z",
+    "2024-03-20T03:06:00Z",
+    "2025-06-21T21:45:30Z",
+    "2030-12-31T23:59:59Z",
+]
+
+BEARINGS = [0, 45, 90, 135, 180, 225, 270, 315]
+DISTANCES_KM = [5, 25, 75, 150]
+HEIGHTS_M = [500, 3000, 11000]
+
+ALTITUDES = [10, 30, 60, 85]
+AZIMUTHS = [0, 45, 90, 135, 180, 225, 270, 315]
+
+
+def ecef(lat, lon, h):
+    lat, lon = math.radians(lat), math.radians(lon)
+    n = A / math.sqrt(1 - E2 * math.sin(lat) ** 2)
+    return (
+        (n + h) * math.cos(lat) * math.cos(lon),
+        (n + h) * math.cos(lat) * math.sin(lon),
+        (n * (1 - E2) + h) * math.sin(lat),
+    )
+
+
+def topocentric(obs, target):
+    """Altitude and azimuth (degrees) of target seen from obs."""
+    lat, lon = math.radians(obs[0]), math.radians(obs[1])
+    o, t = ecef(*obs), ecef(*target)
+    dx, dy, dz = t[0] - o[0], t[1] - o[1], t[2] - o[2]
+    east = -math.sin(lon) * dx + math.cos(lon) * dy
+    north = (-math.sin(lat) * math.cos(lon) * dx
+             - math.sin(lat) * math.sin(lon) * dy
+             + math.cos(lat) * dz)
+    up = (math.cos(lat) * math.cos(lon) * dx
+          + math.cos(lat) * math.sin(lon) * dy
+          + math.sin(lat) * dz)
+    alt = math.degrees(math.atan2(up, math.hypot(east, north)))
+    az = math.degrees(math.atan2(east, north)) % 360
+    return alt, az
+
+
+def destination(lat, lon, bearing, km):
+    """Point km along bearing on a 6371 km sphere (placement only)."""
+    lat, lon, b = math.radians(lat), math.radians(lon), math.radians(bearing)
+    d = km / 6371.0
+    lat2 = math.asin(math.sin(lat) * math.cos(d)
+                     + math.cos(lat) * math.sin(d) * math.cos(b))
+    lon2 = lon + math.atan2(math.sin(b) * math.sin(d) * math.cos(lat),
+                            math.cos(d) - math.sin(lat) * math.sin(lat2))
+    lon2 = (math.degrees(lon2) + 540) % 360 - 180
+    return math.degrees(lat2), lon2
+
+
+def gmst_hours(t):
+    """IAU 2000 GMST from the Earth rotation angle, taking UT1 = UTC."""
+    jd = t.timestamp() / 86400.0 + 2440587.5
+    du = jd - 2451545.0
+    era = 2 * math.pi * ((0.7790572732640 + 1.00273781191135448 * du) % 1)
+    tc = du / 36525.0
+    arcsec = (0.014506 + 4612.15739966 * tc + 1.39667721 * tc ** 2
+              - 0.00009344 * tc ** 3 + 0.00001882 * tc ** 4)
+    gmst = era + math.radians(arcsec / 3600.0)
+    return (math.degrees(gmst) / 15.0) % 24
+
+
+def equatorial(obs, t, alt, az):
+    """Right ascension (hours) and declination (degrees) of an alt/az direction."""
+    lat = math.radians(obs[0])
+    alt, az = math.radians(alt), math.radians(az)
+    # Direction in the local frame, rotated to hour angle/declination
+    sin_dec = math.sin(lat) * math.sin(alt) + math.cos(lat) * math.cos(alt) * math.cos(az)
+    dec = math.asin(sin_dec)
+    ha = math.atan2(-math.cos(alt) * math.sin(az),
+                    math.cos(lat) * math.sin(alt) - math.sin(lat) * math.cos(alt) * math.cos(az))
+    lst = gmst_hours(t) + obs[1] / 15.0
+    ra = (lst - math.degrees(ha) / 15.0) % 24
+    return ra, math.degrees(dec)
+
+
+def main():
+    with open(os.path.join(HERE, "geographic.csv"), "w", newline="") as f:
+        w = csv.writer(f, lineterminator="\n")
+        w.writerow(["observer", "obs_lat", "obs_lon", "obs_height_m",
+                    "lat", "lon", "height_m", "distance_km", "altitude", "azimuth"])
+        for name, lat, lon, h in OBSERVERS:
+            for bearing in BEARINGS:
+                for km in DISTANCES_KM:
+                    tlat, tlon = destination(lat, lon, bearing, km)
+                    for th in HEIGHTS_M:
+                        alt, az = topocentric((lat, lon, h), (tlat, tlon, th))
+                        w.writerow([name, lat, lon, h, f"{tlat:.9f}", f"{tlon:.9f}", th, km,
+                                    f"{alt:.6f}", f"{az:.6f}"])
+
+    with open(os.path.join(HERE, "equatorial.csv"), "w", newline="") as f:
+        w = csv.writer(f, lineterminator="\n")
+        w.writerow(["observer", "obs_lat", "obs_lon", "time",
+                    "altitude", "azimuth", "ra_hours", "dec"])
+        for name, lat, lon, _ in OBSERVERS:
+            for ts in TIMES:
+                t = datetime.strptime(ts, "%Y-%m-%dT%H:%M:%SZ").replace(tzinfo=timezone.utc)
+                for alt in ALTITUDES:
+                    for az in AZIMUTHS:
+                        ra, dec = equatorial((lat, lon), t, alt, az)
+                        w.writerow([name, lat, lon, ts, alt, az, f"{ra:.8f}", f"{dec:.6f}"])
+
+
+if __name__ == "__main__":
+    main()
@@ -96,11 +96,11 @@ func HorizontalToEquatorial(horizontal HorizontalCoordinates, observer Observer,
 	lst := CalculateLocalSiderealTime(observer.Location.Longitude, timestamp)
 	lstRad := lst * 15.0 * DegreesToRadians // Convert hours to radians
 
-	// Calculate Hour Angle (HA)
-	// HA = atan2(-sin(az), cos(az)·sin(lat) - tan(alt)·cos(lat))
+	// Calculate Hour Angle (HA), with azimuth measured from north
+	// HA = atan2(-sin(az), tan(alt)·cos(lat) - cos(az)·sin(lat))
 	haRad := math.Atan2(
 		-math.Sin(azRad),
-		math.Cos(azRad)*math.Sin(latRad)-math.Tan(altRad)*math.Cos(latRad),
+		math.Tan(altRad)*math.Cos(latRad)-math.Cos(azRad)*math.Sin(latRad),
 	)
 
 	// Calculate Declination
@@ -152,11 +152,11 @@ func EquatorialToHorizontal(equatorial EquatorialCoordinates, observer Observer,
 			math.Cos(decRad)*math.Cos(latRad)*math.Cos(haRad),
 	)
 
-	// Calculate Azimuth
-	// az = atan2(-sin(HA), cos(HA)·sin(lat) - tan(dec)·cos(lat))
+	// Calculate Azimuth (from north)
+	// az = atan2(-sin(HA), tan(dec)·cos(lat) - cos(HA)·sin(lat))
 	azRad := math.Atan2(
 		-math.Sin(haRad),
-		math.Cos(haRad)*math.Sin(latRad)-math.Tan(decRad)*math.Cos(latRad),
+		math.Tan(decRad)*math.Cos(latRad)-math.Cos(haRad)*math.Sin(latRad),
 	)
 
 	// Convert to degrees and normalize
@@ -217,6 +217,30 @@ func TestNormalizeAzimuth(t *testing.T) {
 	}
 }
 
+// TestAngularSeparation tests great-circle separation between sky positions
+func TestAngularSeparation(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b HorizontalCoordinates
+		want float64
+	}{
+		{"Same point", HorizontalCoordinates{Altitude: 30, Azimuth: 100}, HorizontalCoordinates{Altitude: 30, Azimuth: 100}, 0},
+		{"Along horizon", HorizontalCoordinates{Altitude: 0, Azimuth: 350}, HorizontalCoordinates{Altitude: 0, Azimuth: 20}, 30},
+		{"Horizon to zenith", HorizontalCoordinates{Altitude: 0, Azimuth: 45}, HorizontalCoordinates{Altitude: 90, Azimuth: 0}, 90},
+		{"Opposite horizon", HorizontalCoordinates{Altitude: 0, Azimuth: 0}, HorizontalCoordinates{Altitude: 0, Azimuth: 180}, 180},
+		{"Azimuth shrinks near zenith", HorizontalCoordinates{Altitude: 89, Azimuth: 0}, HorizontalCoordinates{Altitude: 89, Azimuth: 180}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AngularSeparation(tt.a, tt.b)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("AngularSeparation = %.6f°, want %.6f°", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestNormalizeRA tests right ascension normalization
 func TestNormalizeRA(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,147 @@
+package coordinates
+
+import (
+	"time"
+)
+
+// Sun altitudes (degrees) that bound the twilight phases
+const (
+	SunriseAltitude              = -0.833 // Upper limb on the horizon, with refraction
+	CivilTwilightAltitude        = -6.0
+	NauticalTwilightAltitude     = -12.0
+	AstronomicalTwilightAltitude = -18.0
+)
+
+// TwilightPhase is how dark the sky is, from the sun's depth below the horizon.
+type TwilightPhase int
+
+const (
+	PhaseDay                  TwilightPhase = 0 // Sun above the horizon
+	PhaseCivilTwilight        TwilightPhase = 1 // Sun 0-6° below the horizon
+	PhaseNauticalTwilight     TwilightPhase = 2 // Sun 6-12° below the horizon
+	PhaseAstronomicalTwilight TwilightPhase = 3 // Sun 12-18° below the horizon
+	PhaseNight                TwilightPhase = 4 // Sun more than 18° below the horizon
+)
+
+// GetTwilightPhase returns the twilight phase for a sun altitude in degrees.
+func GetTwilightPhase(sunAltitude float64) TwilightPhase {
+	if sunAltitude > SunriseAltitude {
+		return PhaseDay
+	} else if sunAltitude > CivilTwilightAltitude {
+		return PhaseCivilTwilight
+	} else if sunAltitude > NauticalTwilightAltitude {
+		return PhaseNauticalTwilight
+	} else if sunAltitude > AstronomicalTwilightAltitude {
+		return PhaseAstronomicalTwilight
+	}
+	return PhaseNight
+}
+
+// ParseTwilightPhase returns the phase with the given name (see String).
+func ParseTwilightPhase(name string) (TwilightPhase, bool) {
+	for p := PhaseDay; p <= PhaseNight; p++ {
+		if p.String() == name {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// String returns the phase's name: "day", "civil", "nautical",
+// "astronomical" or "night".
+func (p TwilightPhase) String() string {
+	switch p {
+	case PhaseDay:
+		return "day"
+	case PhaseCivilTwilight:
+		return "civil"
+	case PhaseNauticalTwilight:
+		return "nautical"
+	case PhaseAstronomicalTwilight:
+		return "astronomical"
+	case PhaseNight:
+		return "night"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDark reports whether the sky is dark enough for displays to switch to
+// red night mode to preserve dark adaptation: from the end of civil twilight.
+func (p TwilightPhase) IsDark() bool {
+	return p >= PhaseNauticalTwilight
+}
+
+// TwilightTimes are the times the sun crosses each twilight altitude on a
+// day. A time is zero if the sun doesn't cross that altitude that day (e.g.
+// no astronomical night in high-latitude summers).
+type TwilightTimes struct {
+	Date time.Time // Start of the day (midnight in the date's time zone)
+
+	AstronomicalDawn time.Time
+	NauticalDawn     time.Time
+	CivilDawn        time.Time
+	Sunrise          time.Time
+	Sunset           time.Time
+	CivilDusk        time.Time
+	NauticalDusk     time.Time
+	AstronomicalDusk time.Time
+}
+
+// twilightStep is the scan interval used to find altitude crossings; the sun
+// doesn't cross a twilight altitude twice within it
+const twilightStep = 10 * time.Minute
+
+// CalculateTwilight returns the twilight times for the day containing date,
+// in date's time zone. Use the observer's local time zone so the morning and
+// evening times fall on the same local day.
+func CalculateTwilight(observer Observer, date time.Time) TwilightTimes {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+	times := TwilightTimes{Date: start}
+
+	crossings := []struct {
+		altitude   float64
+		dawn, dusk *time.Time
+	}{
+		{AstronomicalTwilightAltitude, &times.AstronomicalDawn, &times.AstronomicalDusk},
+		{NauticalTwilightAltitude, &times.NauticalDawn, &times.NauticalDusk},
+		{CivilTwilightAltitude, &times.CivilDawn, &times.CivilDusk},
+		{SunriseAltitude, &times.Sunrise, &times.Sunset},
+	}
+
+	altitude := func(t time.Time) float64 {
+		return CalculateSunPosition(observer, t).Altitude
+	}
+
+	prevTime, prevAlt := start, altitude(start)
+	for t := start.Add(twilightStep); !t.After(end); t = t.Add(twilightStep) {
+		alt := altitude(t)
+		for _, c := range crossings {
+			rising := prevAlt <= c.altitude && alt > c.altitude
+			setting := prevAlt > c.altitude && alt <= c.altitude
+			if rising && c.dawn.IsZero() {
+				*c.dawn = findCrossing(altitude, prevTime, t, c.altitude)
+			} else if setting && c.dusk.IsZero() {
+				*c.dusk = findCrossing(altitude, prevTime, t, c.altitude)
+			}
+		}
+		prevTime, prevAlt = t, alt
+	}
+	return times
+}
+
+// findCrossing bisects [from, to] for when the sun's altitude crosses the
+// given altitude, to within a second.
+func findCrossing(altitude func(time.Time) float64, from, to time.Time, target float64) time.Time {
+	rising := altitude(from) <= target
+	for to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2)
+		if (altitude(mid) <= target) == rising {
+			from = mid
+		} else {
+			to = mid
+		}
+	}
+	return to.Round(time.Second)
+}
@@ -0,0 +1,68 @@
+package coordinates
+
+import (
+	"testing"
+	"time"
+)
+
+// TestGetTwilightPhase tests the phase boundaries and night mode threshold.
+func TestGetTwilightPhase(t *testing.T) {
+	tests := []struct {
+		altitude float64
+		want     TwilightPhase
+		wantDark bool
+	}{
+		{10, PhaseDay, false},
+		{-0.5, PhaseDay, false},
+		{-3, PhaseCivilTwilight, false},
+		{-9, PhaseNauticalTwilight, true},
+		{-15, PhaseAstronomicalTwilight, true},
+		{-30, PhaseNight, true},
+	}
+
+	for _, tt := range tests {
+		got := GetTwilightPhase(tt.altitude)
+		if got != tt.want {
+			t.Errorf("GetTwilightPhase(%.1f) = %s, want %s", tt.altitude, got, tt.want)
+		}
+		if got.IsDark() != tt.wantDark {
+			t.Errorf("%s.IsDark() = %v, want %v", got, got.IsDark(), tt.wantDark)
+		}
+		if parsed, ok := ParseTwilightPhase(got.String()); !ok || parsed != got {
+			t.Errorf("ParseTwilightPhase(%q) = %s, %v", got.String(), parsed, ok)
+		}
+	}
+}
+
+// TestCalculateTwilight tests twilight times against published values for
+// London at the June solstice, when there is no astronomical night.
+func TestCalculateTwilight(t *testing.T) {
+	london := Observer{Location: Geographic{Latitude: 51.5074, Longitude: -0.1278}}
+	times := CalculateTwilight(london, time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"Civil dawn", times.CivilDawn, time.Date(2024, 6, 21, 2, 58, 0, 0, time.UTC)},
+		{"Sunrise", times.Sunrise, time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC)},
+		{"Sunset", times.Sunset, time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC)},
+		{"Civil dusk", times.CivilDusk, time.Date(2024, 6, 21, 21, 6, 0, 0, time.UTC)},
+	}
+
+	// The sun moves slowly in altitude at this latitude, so small position
+	// errors shift the times by a few minutes
+	for _, tt := range tests {
+		if diff := tt.got.Sub(tt.want); diff > 5*time.Minute || diff < -5*time.Minute {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got.Format("15:04"), tt.want.Format("15:04"))
+		}
+	}
+
+	if !times.NauticalDawn.Before(times.CivilDawn) || !times.NauticalDusk.After(times.CivilDusk) {
+		t.Errorf("Nautical twilight %s-%s is not outside civil twilight", times.NauticalDawn, times.NauticalDusk)
+	}
+	if !times.AstronomicalDawn.IsZero() || !times.AstronomicalDusk.IsZero() {
+		t.Errorf("Astronomical twilight = %s-%s, want none", times.AstronomicalDawn, times.AstronomicalDusk)
+	}
+}
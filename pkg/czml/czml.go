@@ -0,0 +1,290 @@
+// Package czml builds CZML documents (the JSON scene format read by Cesium)
+// showing aircraft, their predicted trajectories and the observer in 3D.
+package czml
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Packet is one CZML object. Only the properties used here are modelled.
+type Packet struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Availability string    `json:"availability,omitempty"`
+	Clock        *Clock    `json:"clock,omitempty"`
+	Position     *Position `json:"position,omitempty"`
+	Point        *Point    `json:"point,omitempty"`
+	Label        *Label    `json:"label,omitempty"`
+	Path         *Path     `json:"path,omitempty"`
+	Polyline     *Polyline `json:"polyline,omitempty"`
+}
+
+// Clock sets the scene's time range and playback.
+type Clock struct {
+	Interval    string  `json:"interval"`
+	CurrentTime string  `json:"currentTime"`
+	Multiplier  float64 `json:"multiplier"`
+	Range       string  `json:"range"`
+	Step        string  `json:"step"`
+}
+
+// Position is a fixed or time-sampled position in degrees and meters.
+// Sampled positions are [seconds since Epoch, lon, lat, height, ...].
+type Position struct {
+	Epoch                  string    `json:"epoch,omitempty"`
+	CartographicDegrees    []float64 `json:"cartographicDegrees"`
+	InterpolationAlgorithm string    `json:"interpolationAlgorithm,omitempty"`
+	InterpolationDegree    int       `json:"interpolationDegree,omitempty"`
+}
+
+// Color is an RGBA color (0-255).
+type Color struct {
+	RGBA [4]int `json:"rgba"`
+}
+
+// Point draws a dot at the position.
+type Point struct {
+	PixelSize    float64 `json:"pixelSize"`
+	Color        Color   `json:"color"`
+	OutlineColor *Color  `json:"outlineColor,omitempty"`
+	OutlineWidth float64 `json:"outlineWidth,omitempty"`
+}
+
+// Label draws text next to the position.
+type Label struct {
+	Text        string     `json:"text"`
+	Font        string     `json:"font"`
+	FillColor   Color      `json:"fillColor"`
+	PixelOffset Cartesian2 `json:"pixelOffset"`
+}
+
+// Cartesian2 is a screen-space offset in pixels.
+type Cartesian2 struct {
+	Cartesian2 [2]float64 `json:"cartesian2"`
+}
+
+// Path draws the trajectory traced by a sampled position.
+type Path struct {
+	LeadTime  float64  `json:"leadTime"`
+	TrailTime float64  `json:"trailTime"`
+	Width     float64  `json:"width"`
+	Material  Material `json:"material"`
+}
+
+// Polyline draws a line through fixed positions.
+type Polyline struct {
+	Positions Position `json:"positions"`
+	Width     float64  `json:"width"`
+	Material  Material `json:"material"`
+}
+
+// Material is a surface appearance.
+type Material struct {
+	SolidColor struct {
+		Color Color `json:"color"`
+	} `json:"solidColor"`
+}
+
+// solid returns a solid color material.
+func solid(c Color) Material {
+	var m Material
+	m.SolidColor.Color = c
+	return m
+}
+
+// Colors used in the scene
+var (
+	colorAircraft  = Color{RGBA: [4]int{96, 165, 250, 255}}
+	colorEmergency = Color{RGBA: [4]int{239, 68, 68, 255}}
+	colorPath      = Color{RGBA: [4]int{96, 165, 250, 160}}
+	colorObserver  = Color{RGBA: [4]int{249, 115, 22, 255}}
+	colorPointing  = Color{RGBA: [4]int{34, 197, 94, 200}}
+	colorLabel     = Color{RGBA: [4]int{228, 228, 231, 255}}
+	colorOutline   = Color{RGBA: [4]int{15, 15, 30, 255}}
+)
+
+// Options controls the trajectory predictions in a scene.
+type Options struct {
+	// Horizon is how far ahead trajectories are predicted
+	Horizon time.Duration
+
+	// Step is the time between trajectory samples
+	Step time.Duration
+}
+
+// DefaultOptions predicts five minutes ahead in 15 second steps.
+func DefaultOptions() Options {
+	return Options{Horizon: 5 * time.Minute, Step: 15 * time.Second}
+}
+
+// Pointing is an optional telescope line of sight to include in the scene.
+type Pointing struct {
+	Horizontal coordinates.HorizontalCoordinates
+
+	// AltitudeMeters is where the line of sight is cut off (e.g. the
+	// tracked aircraft's altitude)
+	AltitudeMeters units.Meters
+}
+
+// maxPointingRangeNM caps the line of sight for low pointing
+const maxPointingRangeNM = 100.0
+
+// BuildAirspace returns a CZML document for the aircraft around an observer.
+//
+// Each aircraft's position is sampled from now to now+Horizon using
+// dead-reckoning prediction, so Cesium animates it along its predicted
+// trajectory and draws the path ahead. pointing may be nil.
+func BuildAirspace(observer coordinates.Observer, aircraft []adsb.Aircraft, pointing *Pointing, now time.Time, opts Options) []Packet {
+	if opts.Horizon <= 0 || opts.Step <= 0 {
+		opts = DefaultOptions()
+	}
+
+	now = now.UTC()
+	end := now.Add(opts.Horizon)
+	interval := fmt.Sprintf("%s/%s", isoTime(now), isoTime(end))
+
+	packets := []Packet{
+		{
+			ID:      "document",
+			Name:    "ADS-B Scope airspace",
+			Version: "1.0",
+			Clock: &Clock{
+				Interval:    interval,
+				CurrentTime: isoTime(now),
+				Multiplier:  1,
+				Range:       "CLAMPED",
+				Step:        "SYSTEM_CLOCK_MULTIPLIER",
+			},
+		},
+		observerPacket(observer),
+	}
+
+	if pointing != nil {
+		packets = append(packets, pointingPacket(observer, *pointing))
+	}
+
+	for _, ac := range aircraft {
+		packets = append(packets, aircraftPacket(ac, now, interval, opts))
+	}
+
+	return packets
+}
+
+// observerPacket marks the observer on the ground.
+func observerPacket(observer coordinates.Observer) Packet {
+	loc := observer.Location
+	return Packet{
+		ID:   "observer",
+		Name: "Observer",
+		Position: &Position{
+			CartographicDegrees: []float64{loc.Longitude, loc.Latitude, float64(loc.Altitude)},
+		},
+		Point: &Point{PixelSize: 12, Color: colorObserver, OutlineColor: &colorOutline, OutlineWidth: 2},
+		Label: &Label{
+			Text:        "Observer",
+			Font:        "12px sans-serif",
+			FillColor:   colorObserver,
+			PixelOffset: Cartesian2{Cartesian2: [2]float64{0, -20}},
+		},
+	}
+}
+
+// pointingPacket draws the telescope's line of sight up to the given altitude.
+func pointingPacket(observer coordinates.Observer, pointing Pointing) Packet {
+	loc := observer.Location
+	end, _, _ := coordinates.PointingGroundPoint(loc, pointing.Horizontal, pointing.AltitudeMeters, maxPointingRangeNM)
+
+	return Packet{
+		ID:   "telescope",
+		Name: "Telescope line of sight",
+		Description: fmt.Sprintf("Alt %.1f° Az %.1f°",
+			pointing.Horizontal.Altitude, pointing.Horizontal.Azimuth),
+		Polyline: &Polyline{
+			Positions: Position{CartographicDegrees: []float64{
+				loc.Longitude, loc.Latitude, float64(loc.Altitude),
+				end.Longitude, end.Latitude, float64(end.Altitude),
+			}},
+			Width:    2,
+			Material: solid(colorPointing),
+		},
+	}
+}
+
+// aircraftPacket animates an aircraft along its predicted trajectory.
+func aircraftPacket(ac adsb.Aircraft, now time.Time, interval string, opts Options) Packet {
+	samples := int(opts.Horizon/opts.Step) + 1
+	degrees := make([]float64, 0, samples*4)
+	for i := 0; i < samples; i++ {
+		offset := time.Duration(i) * opts.Step
+		predicted := tracking.PredictPosition(ac, now.Add(offset)).Position
+		degrees = append(degrees,
+			offset.Seconds(),
+			predicted.Longitude,
+			predicted.Latitude,
+			float64(predicted.Altitude),
+		)
+	}
+
+	name := ac.Callsign
+	if name == "" {
+		name = ac.ICAO
+	}
+
+	color := colorAircraft
+	if ac.IsEmergency() {
+		color = colorEmergency
+	}
+
+	return Packet{
+		ID:           "aircraft-" + ac.ICAO,
+		Name:         name,
+		Description:  describe(ac),
+		Availability: interval,
+		Position: &Position{
+			Epoch:                  isoTime(now),
+			CartographicDegrees:    degrees,
+			InterpolationAlgorithm: "LAGRANGE",
+			InterpolationDegree:    1,
+		},
+		Point: &Point{PixelSize: 8, Color: color, OutlineColor: &colorOutline, OutlineWidth: 1},
+		Label: &Label{
+			Text:        name,
+			Font:        "11px sans-serif",
+			FillColor:   colorLabel,
+			PixelOffset: Cartesian2{Cartesian2: [2]float64{12, 0}},
+		},
+		Path: &Path{
+			LeadTime:  opts.Horizon.Seconds(),
+			TrailTime: 0,
+			Width:     1.5,
+			Material:  solid(colorPath),
+		},
+	}
+}
+
+// describe returns the info box HTML for an aircraft.
+func describe(ac adsb.Aircraft) string {
+	return fmt.Sprintf(
+		"<table><tr><td>ICAO</td><td>%s</td></tr>"+
+			"<tr><td>Altitude</td><td>%.0f ft</td></tr>"+
+			"<tr><td>Speed</td><td>%.0f kts</td></tr>"+
+			"<tr><td>Track</td><td>%.0f°</td></tr>"+
+			"<tr><td>Vertical rate</td><td>%+.0f ft/min</td></tr></table>",
+		html.EscapeString(ac.ICAO), ac.Altitude, ac.GroundSpeed, math.Mod(ac.Track, 360), ac.VerticalRate,
+	)
+}
+
+// isoTime formats a time as CZML expects (ISO 8601, UTC).
+func isoTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,97 @@
+package czml
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestBuildAirspace tests the CZML scene for a small set of aircraft.
+func TestBuildAirspace(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0, Altitude: 200}}
+	aircraft := []adsb.Aircraft{
+		{ICAO: "A1B2C3", Callsign: "UAL123", Latitude: 35.1, Longitude: -80.1, Altitude: 10000, GroundSpeed: 300, Track: 90, LastSeen: now},
+		{ICAO: "ABCDEF", Latitude: 35.2, Longitude: -79.9, Altitude: 5000, Squawk: "7700", LastSeen: now},
+	}
+	pointing := &Pointing{
+		Horizontal:     coordinates.HorizontalCoordinates{Altitude: 30, Azimuth: 45},
+		AltitudeMeters: 3000,
+	}
+	opts := Options{Horizon: time.Minute, Step: 15 * time.Second}
+
+	packets := BuildAirspace(observer, aircraft, pointing, now, opts)
+
+	if len(packets) != 5 {
+		t.Fatalf("len(packets) = %d, want 5 (document, observer, telescope, 2 aircraft)", len(packets))
+	}
+	if packets[0].ID != "document" || packets[0].Version != "1.0" {
+		t.Errorf("first packet = %q version %q, want the document packet", packets[0].ID, packets[0].Version)
+	}
+	if got, want := packets[0].Clock.Interval, "2025-06-01T12:00:00Z/2025-06-01T12:01:00Z"; got != want {
+		t.Errorf("clock interval = %q, want %q", got, want)
+	}
+	if packets[2].ID != "telescope" || packets[2].Polyline == nil {
+		t.Errorf("packet 2 = %q, want the telescope line of sight", packets[2].ID)
+	}
+
+	t.Run("Aircraft trajectory is sampled", func(t *testing.T) {
+		ac := packets[3]
+		if ac.ID != "aircraft-A1B2C3" || ac.Name != "UAL123" {
+			t.Fatalf("packet = %q (%q), want aircraft-A1B2C3 (UAL123)", ac.ID, ac.Name)
+		}
+
+		samples := ac.Position.CartographicDegrees
+		if len(samples) != 5*4 {
+			t.Fatalf("len(samples) = %d, want 20 (5 samples of t, lon, lat, h)", len(samples))
+		}
+		if samples[0] != 0 || samples[1] != -80.1 || samples[2] != 35.1 {
+			t.Errorf("first sample = %v, want the current position at t=0", samples[:4])
+		}
+		if math.Abs(samples[3]-10000*coordinates.FeetToMeters) > 0.01 {
+			t.Errorf("height = %.1f m, want %.1f m", samples[3], 10000*coordinates.FeetToMeters)
+		}
+
+		// Eastbound at 300 kts: 5 NM further east after 60s
+		last := samples[16:]
+		if last[0] != 60 {
+			t.Errorf("last sample time = %.0f s, want 60 s", last[0])
+		}
+		from := coordinates.Geographic{Latitude: 35.1, Longitude: -80.1}
+		to := coordinates.Geographic{Latitude: last[2], Longitude: last[1]}
+		if d := coordinates.DistanceNauticalMiles(from, to); math.Abs(d-5) > 0.1 {
+			t.Errorf("moved %.2f NM in 60s, want 5 NM", d)
+		}
+		if last[1] <= -80.1 {
+			t.Errorf("longitude %.4f did not move east", last[1])
+		}
+	})
+
+	t.Run("Missing callsign and emergency", func(t *testing.T) {
+		ac := packets[4]
+		if ac.Name != "ABCDEF" {
+			t.Errorf("Name = %q, want ICAO fallback", ac.Name)
+		}
+		if ac.Point.Color != colorEmergency {
+			t.Errorf("Point color = %v, want emergency color", ac.Point.Color)
+		}
+	})
+
+	t.Run("Encodes as JSON", func(t *testing.T) {
+		data, err := json.Marshal(packets)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if _, ok := decoded[1]["path"]; ok {
+			t.Error("observer packet should not have a path")
+		}
+	})
+}
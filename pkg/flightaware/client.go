@@ -32,6 +32,7 @@ type Client struct {
 	apiKey      string
 	httpClient  *http.Client
 	rateLimiter *rate.Limiter
+	quota       *Quota
 	baseURL     string
 }
 
@@ -40,6 +41,9 @@ type Config struct {
 	APIKey          string
 	RequestsPerHour int
 	Timeout         time.Duration
+
+	// Quota, if set, limits requests to a monthly budget
+	Quota *Quota
 }
 
 // NewClient creates a new FlightAware AeroAPI client.
@@ -68,10 +72,23 @@ func NewClient(cfg Config) *Client {
 			Timeout: cfg.Timeout,
 		},
 		rateLimiter: limiter,
+		quota:       cfg.Quota,
 		baseURL:     BaseURL,
 	}
 }
 
+// wait blocks until the rate limiter allows a request, then records it
+// against the quota. Returns ErrQuotaExceeded if the budget is used up.
+func (c *Client) wait(ctx context.Context) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	if c.quota != nil {
+		return c.quota.Acquire(ctx)
+	}
+	return nil
+}
+
 // FlightPlan represents a filed flight plan from AeroAPI.
 type FlightPlan struct {
 	// Identifiers
@@ -135,9 +152,9 @@ type FlightInfo struct {
 // Returns nil, nil if no flight plan is found (not an error).
 // Returns error for API failures or network issues.
 func (c *Client) GetFlightPlanByCallsign(ctx context.Context, callsign string) (*FlightPlan, error) {
-	// Wait for rate limiter
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limiter: %w", err)
+	// Wait for rate limiter and check the budget
+	if err := c.wait(ctx); err != nil {
+		return nil, err
 	}
 
 	// AeroAPI endpoint: /flights/{ident}
@@ -209,9 +226,9 @@ func (c *Client) GetFlightInfoByICAO(ctx context.Context, icaoHex string) (*Flig
 // This provides waypoint-by-waypoint information including ETAs.
 // The fa_flight_id can be obtained from GetFlightPlanByCallsign.
 func (c *Client) GetRoute(ctx context.Context, faFlightID string) ([]Waypoint, error) {
-	// Wait for rate limiter
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limiter: %w", err)
+	// Wait for rate limiter and check the budget
+	if err := c.wait(ctx); err != nil {
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/flights/%s/route", c.baseURL, faFlightID)
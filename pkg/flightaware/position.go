@@ -0,0 +1,86 @@
+package flightaware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Source tags aircraft positions ingested from FlightAware rather than
+// received over ADS-B. FlightAware positions are polled at a low rate to fill
+// coverage gaps, so they are sparse and usually a minute or more old.
+const Source = "flightaware"
+
+// Position is an en-route position report from AeroAPI.
+type Position struct {
+	FAFlightID     string    `json:"fa_flight_id"`
+	Altitude       int       `json:"altitude"`        // Hundreds of feet MSL
+	AltitudeChange string    `json:"altitude_change"` // "C" climbing, "D" descending, "-" level
+	GroundSpeed    int       `json:"groundspeed"`     // Knots
+	Heading        *int      `json:"heading"`         // Degrees true; may be null
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	Timestamp      time.Time `json:"timestamp"`
+	UpdateType     string    `json:"update_type"` // e.g., "A" ADS-B, "Z" radar, "P" projected
+}
+
+// Projected reports whether FlightAware estimated the position rather than
+// receiving it. Projected positions are dead reckoning, which the predictor
+// does better with the live track.
+func (p Position) Projected() bool {
+	return p.UpdateType == "P"
+}
+
+// GetPosition retrieves the latest en-route position of a flight by
+// FlightAware flight ID.
+//
+// Returns nil, nil if the flight has no position (not yet departed, or
+// unknown to FlightAware).
+func (c *Client) GetPosition(ctx context.Context, faFlightID string) (*Position, error) {
+	// Wait for rate limiter and check the budget
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/flights/%s/position", c.baseURL, faFlightID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("x-apikey", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, nil // Unknown flight, not an error
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		LastPosition *Position `json:"last_position"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	return response.LastPosition, nil
+}
@@ -0,0 +1,80 @@
+package flightaware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetPosition tests reading a flight's last position from AeroAPI.
+func TestGetPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name:   "Position",
+			status: http.StatusOK,
+			body: `{"ident":"UAL123","fa_flight_id":"UAL123-1700000000-airline-0001",
+				"last_position":{"fa_flight_id":"UAL123-1700000000-airline-0001",
+				"altitude":350,"altitude_change":"-","groundspeed":452,"heading":271,
+				"latitude":35.2,"longitude":-80.9,"timestamp":"2026-10-16T12:00:00Z","update_type":"A"}}`,
+		},
+		{
+			name:    "No position yet",
+			status:  http.StatusOK,
+			body:    `{"ident":"UAL123","last_position":null}`,
+			wantNil: true,
+		},
+		{
+			name:    "Unknown flight",
+			status:  http.StatusNotFound,
+			body:    `{"title":"Not found"}`,
+			wantNil: true,
+		},
+		{
+			name:    "API error",
+			status:  http.StatusUnauthorized,
+			body:    `{"title":"Invalid API key"}`,
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/flights/UAL123-1700000000-airline-0001/position" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				if r.Header.Get("x-apikey") != "test-key" {
+					t.Errorf("Expected API key header")
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(Config{APIKey: "test-key"})
+			client.baseURL = server.URL
+
+			pos, err := client.GetPosition(context.Background(), "UAL123-1700000000-airline-0001")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPosition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (pos == nil) != tt.wantNil {
+				t.Fatalf("GetPosition() = %+v, wantNil %v", pos, tt.wantNil)
+			}
+			if pos == nil {
+				return
+			}
+			if pos.Altitude != 350 || pos.GroundSpeed != 452 || pos.Heading == nil || *pos.Heading != 271 {
+				t.Errorf("Unexpected position %+v", pos)
+			}
+		})
+	}
+}
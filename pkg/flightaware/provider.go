@@ -0,0 +1,66 @@
+package flightaware
+
+import (
+	"context"
+
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+)
+
+// Client is a flightroute.Provider
+var _ flightroute.Provider = (*Client)(nil)
+
+// Name implements flightroute.Provider. Positions from FlightAware are
+// tagged with Source.
+func (c *Client) Name() string {
+	return Source
+}
+
+// GetRouteByCallsign implements flightroute.Provider using
+// GetFlightPlanByCallsign.
+func (c *Client) GetRouteByCallsign(ctx context.Context, callsign string) (*flightroute.Route, error) {
+	fp, err := c.GetFlightPlanByCallsign(ctx, callsign)
+	if err != nil || fp == nil {
+		return nil, err
+	}
+
+	return &flightroute.Route{
+		FlightID:      fp.FAFlightID,
+		Callsign:      fp.ICAO,
+		Departure:     fp.Departure.Code,
+		Arrival:       fp.Arrival.Code,
+		Route:         fp.RouteString,
+		FiledAltitude: fp.FiledAltitude,
+		AircraftType:  fp.AircraftType,
+		FiledTime:     fp.FiledTime,
+		ETD:           fp.ETD,
+		ETA:           fp.ETA,
+		Status:        fp.Status,
+	}, nil
+}
+
+// GetPositions implements flightroute.Provider using GetPosition. AeroAPI
+// is only asked for the latest position, so at most one is returned.
+func (c *Client) GetPositions(ctx context.Context, faFlightID string) ([]flightroute.Position, error) {
+	pos, err := c.GetPosition(ctx, faFlightID)
+	if err != nil {
+		return nil, err
+	}
+	if pos == nil {
+		return []flightroute.Position{}, nil
+	}
+
+	rp := flightroute.Position{
+		FlightID:       pos.FAFlightID,
+		Latitude:       pos.Latitude,
+		Longitude:      pos.Longitude,
+		AltitudeFt:     float64(pos.Altitude) * 100,
+		GroundSpeedKts: float64(pos.GroundSpeed),
+		Timestamp:      pos.Timestamp,
+		Projected:      pos.Projected(),
+	}
+	if pos.Heading != nil {
+		track := float64(*pos.Heading)
+		rp.Track = &track
+	}
+	return []flightroute.Position{rp}, nil
+}
@@ -0,0 +1,78 @@
+package flightaware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+	"github.com/unklstewy/ads-bscope/pkg/flightroute/routetest"
+)
+
+// fakeAeroAPI serves the fixture flights in AeroAPI's format.
+func fakeAeroAPI(t *testing.T, flights []routetest.Flight) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/flights/")
+
+		for _, f := range flights {
+			route := f.Route
+			switch path {
+			case route.Callsign:
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"flights": []map[string]interface{}{{
+						"ident":                    route.Callsign,
+						"fa_flight_id":             route.FlightID,
+						"origin":                   map[string]string{"code_icao": route.Departure},
+						"destination":              map[string]string{"code_icao": route.Arrival},
+						"route":                    route.Route,
+						"filed_altitude":           route.FiledAltitude,
+						"aircraft_type":            route.AircraftType,
+						"filed_time":               route.FiledTime,
+						"estimated_time_departure": route.ETD,
+						"estimated_time_arrival":   route.ETA,
+						"status":                   route.Status,
+					}},
+				})
+				return
+
+			case route.FlightID + "/position":
+				var last interface{}
+				if n := len(f.Positions); n > 0 {
+					p := f.Positions[n-1]
+					last = map[string]interface{}{
+						"fa_flight_id":    p.FlightID,
+						"altitude":        int(p.AltitudeFt / 100),
+						"altitude_change": "-",
+						"groundspeed":     int(p.GroundSpeedKts),
+						"heading":         p.Track,
+						"latitude":        p.Latitude,
+						"longitude":       p.Longitude,
+						"timestamp":       p.Timestamp,
+						"update_type":     "A",
+					}
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"ident":         route.Callsign,
+					"fa_flight_id":  route.FlightID,
+					"last_position": last,
+				})
+				return
+			}
+		}
+
+		http.Error(w, `{"title":"Not found"}`, http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestClientProviderContract tests the AeroAPI client against the provider contract.
+func TestClientProviderContract(t *testing.T) {
+	routetest.Run(t, func(t *testing.T, flights []routetest.Flight) flightroute.Provider {
+		client := NewClient(Config{APIKey: "test-key", RequestsPerHour: 3600 * 100})
+		client.baseURL = fakeAeroAPI(t, flights).URL
+		return client
+	})
+}
@@ -0,0 +1,178 @@
+package flightaware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+)
+
+// ErrQuotaExceeded is returned instead of making a request when the monthly
+// budget, or today's share of it, has been used.
+var ErrQuotaExceeded = flightroute.ErrQuotaExceeded
+
+// Budget limits AeroAPI usage per calendar month (UTC). Zero limits are
+// unlimited.
+type Budget struct {
+	MonthlyCalls int     // Requests per month
+	MonthlyCost  float64 // Spend per month, in the account's currency
+	CostPerCall  float64 // Charge per request, for the spend limit
+}
+
+// BudgetFromConfig returns the budget set in the FlightAware configuration.
+func BudgetFromConfig(cfg config.FlightAwareConfig) Budget {
+	return Budget{
+		MonthlyCalls: cfg.MonthlyCallBudget,
+		MonthlyCost:  cfg.MonthlyCostBudget,
+		CostPerCall:  cfg.CostPerCall,
+	}
+}
+
+// Limited reports whether the budget limits usage at all.
+func (b Budget) Limited() bool {
+	return b.MonthlyCalls > 0 || (b.MonthlyCost > 0 && b.CostPerCall > 0)
+}
+
+// Usage is the number of requests made and their cost.
+type Usage struct {
+	Calls int     `json:"calls"`
+	Cost  float64 `json:"cost"`
+}
+
+// Remaining returns how many more requests the month's budget allows after
+// month's usage. Returns math.MaxInt if the budget is unlimited.
+func (b Budget) Remaining(month Usage) int {
+	remaining := math.MaxInt
+	if b.MonthlyCalls > 0 {
+		remaining = b.MonthlyCalls - month.Calls
+	}
+	if b.MonthlyCost > 0 && b.CostPerCall > 0 {
+		remaining = min(remaining, int(math.Floor((b.MonthlyCost-month.Cost)/b.CostPerCall+1e-9)))
+	}
+	return max(remaining, 0)
+}
+
+// Allowance returns how many more requests may be made today. What was left
+// of the month's budget at the start of today is spread evenly over the days
+// left in the month, so a busy day can't use up the rest of the month.
+// Returns math.MaxInt if the budget is unlimited.
+func (b Budget) Allowance(month, today Usage, now time.Time) int {
+	if !b.Limited() {
+		return math.MaxInt
+	}
+
+	now = now.UTC()
+	startOfDay := b.Remaining(Usage{Calls: month.Calls - today.Calls, Cost: month.Cost - today.Cost})
+	daysLeft := daysIn(now) - now.Day() + 1
+	share := (startOfDay + daysLeft - 1) / daysLeft
+
+	return max(min(share-today.Calls, b.Remaining(month)), 0)
+}
+
+// daysIn returns the number of days in t's month.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// UsageStore persists AeroAPI usage, so the budget holds across restarts
+// and between processes sharing an API key.
+type UsageStore interface {
+	// Usage returns the usage so far in now's month and day (UTC)
+	Usage(ctx context.Context, now time.Time) (month, today Usage, err error)
+
+	// AddUsage records requests made on now's day (UTC)
+	AddUsage(ctx context.Context, now time.Time, calls int, cost float64) error
+}
+
+// QuotaStatus summarises the budget and usage for the status endpoint.
+type QuotaStatus struct {
+	Limited        bool     `json:"limited"`
+	Month          Usage    `json:"month"`
+	Today          Usage    `json:"today"`
+	MonthlyCalls   int      `json:"monthlyCalls,omitempty"`
+	MonthlyCost    float64  `json:"monthlyCost,omitempty"`
+	RemainingCalls *int     `json:"remainingCalls,omitempty"` // nil if unlimited
+	RemainingCost  *float64 `json:"remainingCost,omitempty"`
+	TodayAllowance *int     `json:"todayAllowance,omitempty"`
+}
+
+// Quota enforces a Budget on AeroAPI requests, recording each request in a
+// UsageStore.
+type Quota struct {
+	budget Budget
+	store  UsageStore
+	now    func() time.Time
+
+	// mu serializes the check and record of each request in this process
+	mu sync.Mutex
+}
+
+// NewQuota creates a quota enforcing budget, with usage kept in store.
+func NewQuota(budget Budget, store UsageStore) *Quota {
+	return &Quota{budget: budget, store: store, now: time.Now}
+}
+
+// Acquire records one request if today's allowance permits it, and returns
+// ErrQuotaExceeded otherwise.
+func (q *Quota) Acquire(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	month, today, err := q.store.Usage(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to read FlightAware usage: %w", err)
+	}
+	if q.budget.Allowance(month, today, now) <= 0 {
+		return ErrQuotaExceeded
+	}
+
+	if err := q.store.AddUsage(ctx, now, 1, q.budget.CostPerCall); err != nil {
+		return fmt.Errorf("failed to record FlightAware usage: %w", err)
+	}
+	return nil
+}
+
+// Allowance returns how many more requests may be made today
+// (math.MaxInt if unlimited).
+func (q *Quota) Allowance(ctx context.Context) (int, error) {
+	now := q.now()
+	month, today, err := q.store.Usage(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read FlightAware usage: %w", err)
+	}
+	return q.budget.Allowance(month, today, now), nil
+}
+
+// QuotaStatusOf returns the budget, usage so far and what remains. Readers
+// such as the web server use it without making requests themselves.
+func QuotaStatusOf(ctx context.Context, budget Budget, store UsageStore, now time.Time) (QuotaStatus, error) {
+	month, today, err := store.Usage(ctx, now)
+	if err != nil {
+		return QuotaStatus{}, fmt.Errorf("failed to read FlightAware usage: %w", err)
+	}
+
+	status := QuotaStatus{
+		Limited:      budget.Limited(),
+		Month:        month,
+		Today:        today,
+		MonthlyCalls: budget.MonthlyCalls,
+		MonthlyCost:  budget.MonthlyCost,
+	}
+	if status.Limited {
+		remaining := budget.Remaining(month)
+		allowance := budget.Allowance(month, today, now)
+		status.RemainingCalls = &remaining
+		status.TodayAllowance = &allowance
+		if budget.MonthlyCost > 0 {
+			cost := math.Max(budget.MonthlyCost-month.Cost, 0)
+			status.RemainingCost = &cost
+		}
+	}
+
+	return status, nil
+}
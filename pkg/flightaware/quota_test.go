@@ -0,0 +1,111 @@
+package flightaware
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// memoryUsage is an in-memory UsageStore for tests.
+type memoryUsage struct {
+	days map[string]Usage
+}
+
+func (m *memoryUsage) Usage(ctx context.Context, now time.Time) (Usage, Usage, error) {
+	var month Usage
+	for day, u := range m.days {
+		if day[:7] == now.UTC().Format("2006-01") {
+			month.Calls += u.Calls
+			month.Cost += u.Cost
+		}
+	}
+	return month, m.days[now.UTC().Format("2006-01-02")], nil
+}
+
+func (m *memoryUsage) AddUsage(ctx context.Context, now time.Time, calls int, cost float64) error {
+	if m.days == nil {
+		m.days = make(map[string]Usage)
+	}
+	day := now.UTC().Format("2006-01-02")
+	u := m.days[day]
+	u.Calls += calls
+	u.Cost += cost
+	m.days[day] = u
+	return nil
+}
+
+// TestBudgetAllowance tests spreading the monthly budget over the month.
+func TestBudgetAllowance(t *testing.T) {
+	june1 := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)   // 30 days left
+	june30 := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC) // Last day
+
+	tests := []struct {
+		name     string
+		budget   Budget
+		month    Usage
+		today    Usage
+		now      time.Time
+		expected int
+	}{
+		{"Unlimited", Budget{}, Usage{Calls: 1000}, Usage{}, june1, math.MaxInt},
+		{"Cost without a per-call charge is unlimited", Budget{MonthlyCost: 10}, Usage{}, Usage{}, june1, math.MaxInt},
+		{"Even share on the first day", Budget{MonthlyCalls: 300}, Usage{}, Usage{}, june1, 10},
+		{"Share rounds up", Budget{MonthlyCalls: 500}, Usage{}, Usage{}, june1, 17},
+		{"Share less today's usage", Budget{MonthlyCalls: 300}, Usage{Calls: 4}, Usage{Calls: 4}, june1, 6},
+		{"Today's share used", Budget{MonthlyCalls: 300}, Usage{Calls: 10}, Usage{Calls: 10}, june1, 0},
+		{"Everything left on the last day", Budget{MonthlyCalls: 300}, Usage{Calls: 250}, Usage{}, june30, 50},
+		{"Month used up", Budget{MonthlyCalls: 300}, Usage{Calls: 300}, Usage{}, june30, 0},
+		{"Cost limit", Budget{MonthlyCost: 15, CostPerCall: 0.5}, Usage{Calls: 20, Cost: 10}, Usage{}, june30, 10},
+		{"Tighter of calls and cost", Budget{MonthlyCalls: 100, MonthlyCost: 15, CostPerCall: 0.5}, Usage{Calls: 95, Cost: 10}, Usage{}, june30, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.budget.Allowance(tt.month, tt.today, tt.now); got != tt.expected {
+				t.Errorf("Allowance() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestQuotaAcquire tests recording requests until the day's allowance is used.
+func TestQuotaAcquire(t *testing.T) {
+	store := &memoryUsage{}
+	quota := NewQuota(Budget{MonthlyCalls: 60, MonthlyCost: 100, CostPerCall: 0.25}, store)
+	day := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC) // 2 calls/day
+	quota.now = func() time.Time { return day }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := quota.Acquire(ctx); err != nil {
+			t.Fatalf("Acquire() #%d: %v", i+1, err)
+		}
+	}
+	if err := quota.Acquire(ctx); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded after today's allowance, got %v", err)
+	}
+
+	month, today, _ := store.Usage(ctx, day)
+	if month.Calls != 2 || today.Cost != 0.5 {
+		t.Errorf("Expected 2 calls costing 0.5, got %+v", today)
+	}
+
+	// The next day gets its own share
+	day = day.Add(24 * time.Hour)
+	if err := quota.Acquire(ctx); err != nil {
+		t.Errorf("Expected a new allowance the next day, got %v", err)
+	}
+
+	status, err := QuotaStatusOf(ctx, quota.budget, store, day)
+	if err != nil {
+		t.Fatalf("QuotaStatusOf() error: %v", err)
+	}
+	if !status.Limited || status.RemainingCalls == nil || *status.RemainingCalls != 57 {
+		t.Errorf("Expected 57 calls remaining, got %+v", status)
+	}
+	if status.RemainingCost == nil || *status.RemainingCost != 99.25 {
+		t.Errorf("Expected 99.25 remaining, got %v", status.RemainingCost)
+	}
+}
@@ -0,0 +1,91 @@
+package flightroute
+
+import (
+	"context"
+	"sync"
+)
+
+// MockName is the name of the Mock provider.
+const MockName = "mock"
+
+// Mock is an in-memory Provider for tests and demos. Flights are added with
+// AddFlight; every call is counted.
+type Mock struct {
+	// Err, if set, is returned by every call
+	Err error
+
+	mu        sync.Mutex
+	routes    map[string]Route      // By callsign
+	positions map[string][]Position // By flight ID
+	calls     int
+}
+
+// NewMock creates an empty mock provider.
+func NewMock() *Mock {
+	return &Mock{
+		routes:    make(map[string]Route),
+		positions: make(map[string][]Position),
+	}
+}
+
+// AddFlight adds a flight plan and its positions (oldest first). A flight
+// with the same callsign replaces the previous one.
+func (m *Mock) AddFlight(route Route, positions ...Position) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.routes[route.Callsign] = route
+	m.positions[route.FlightID] = append([]Position(nil), positions...)
+}
+
+// Calls returns how many requests have been made.
+func (m *Mock) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// Name implements Provider.
+func (m *Mock) Name() string {
+	return MockName
+}
+
+// GetRouteByCallsign implements Provider.
+func (m *Mock) GetRouteByCallsign(ctx context.Context, callsign string) (*Route, error) {
+	if err := m.call(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	route, ok := m.routes[callsign]
+	if !ok {
+		return nil, nil
+	}
+	return &route, nil
+}
+
+// GetPositions implements Provider.
+func (m *Mock) GetPositions(ctx context.Context, flightID string) ([]Position, error) {
+	if err := m.call(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Position{}, m.positions[flightID]...), nil
+}
+
+// call counts a request and returns the error it should fail with, if any.
+func (m *Mock) call(ctx context.Context) error {
+	m.mu.Lock()
+	m.calls++
+	m.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.Err
+}
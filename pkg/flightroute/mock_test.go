@@ -0,0 +1,59 @@
+package flightroute_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+	"github.com/unklstewy/ads-bscope/pkg/flightroute/routetest"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestMockContract tests the mock provider against the provider contract.
+func TestMockContract(t *testing.T) {
+	routetest.Run(t, func(t *testing.T, flights []routetest.Flight) flightroute.Provider {
+		mock := flightroute.NewMock()
+		for _, f := range flights {
+			mock.AddFlight(f.Route, f.Positions...)
+		}
+		return mock
+	})
+}
+
+// TestMockErr tests that the mock fails every call with Err and counts calls.
+func TestMockErr(t *testing.T) {
+	mock := flightroute.NewMock()
+	mock.Err = flightroute.ErrQuotaExceeded
+
+	if _, err := mock.GetRouteByCallsign(context.Background(), "UAL123"); !errors.Is(err, flightroute.ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if _, err := mock.GetPositions(context.Background(), "UAL123-1"); !errors.Is(err, flightroute.ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if mock.Calls() != 2 {
+		t.Errorf("Expected 2 calls, got %d", mock.Calls())
+	}
+}
+
+// TestPositionAircraft tests converting a provider position to an aircraft update.
+func TestPositionAircraft(t *testing.T) {
+	pos := routetest.Flights[0].Positions[1]
+
+	ac := pos.Aircraft("a1b2c3", "UAL123", "flightaware")
+	if ac.ICAO != "a1b2c3" || ac.Callsign != "UAL123" || ac.Source != "flightaware" {
+		t.Errorf("Unexpected identity %s/%s from %s", ac.ICAO, ac.Callsign, ac.Source)
+	}
+	if ac.Altitude != units.Feet(pos.AltitudeFt) || ac.GroundSpeed != pos.GroundSpeedKts || ac.Track != *pos.Track {
+		t.Errorf("Unexpected state %.0f ft, %.0f kts, %.0f°", ac.Altitude, ac.GroundSpeed, ac.Track)
+	}
+	if !ac.LastSeen.Equal(pos.Timestamp) {
+		t.Errorf("Expected last seen %v, got %v", pos.Timestamp, ac.LastSeen)
+	}
+
+	pos.Track = nil
+	if ac := pos.Aircraft("a1b2c3", "UAL123", "flightaware"); ac.Track != 0 {
+		t.Errorf("Expected track 0 without one reported, got %.0f", ac.Track)
+	}
+}
@@ -0,0 +1,47 @@
+package flightroute
+
+import (
+	"sort"
+	"time"
+)
+
+// Candidate is an aircraft whose flight plan could be fetched.
+type Candidate struct {
+	ICAO           string
+	Callsign       string
+	Trackable      bool          // Within the telescope's limits now
+	Approaching    bool          // Closing on the observer
+	ClosestRangeNM float64       // Predicted closest approach, if approaching
+	TimeToClosest  time.Duration // Until closest approach, if approaching
+}
+
+// Prioritize orders candidates by how likely their plan is to be used for
+// tracking: trackable aircraft first, then those approaching, closest
+// predicted approach first (soonest on ties), then the rest. The order is
+// stable otherwise, so callers can pre-sort by recency.
+func Prioritize(candidates []Candidate) {
+	rank := func(c Candidate) int {
+		switch {
+		case c.Trackable:
+			return 0
+		case c.Approaching:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra < rb
+		}
+		if a.Approaching && b.Approaching && !a.Trackable && !b.Trackable {
+			if a.ClosestRangeNM != b.ClosestRangeNM {
+				return a.ClosestRangeNM < b.ClosestRangeNM
+			}
+			return a.TimeToClosest < b.TimeToClosest
+		}
+		return false
+	})
+}
@@ -0,0 +1,27 @@
+package flightroute
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPrioritize tests ordering flight plan candidates by likely use.
+func TestPrioritize(t *testing.T) {
+	candidates := []Candidate{
+		{ICAO: "far"},
+		{ICAO: "approaching-20nm", Approaching: true, ClosestRangeNM: 20, TimeToClosest: time.Minute},
+		{ICAO: "trackable", Trackable: true},
+		{ICAO: "approaching-5nm-late", Approaching: true, ClosestRangeNM: 5, TimeToClosest: 10 * time.Minute},
+		{ICAO: "approaching-5nm-soon", Approaching: true, ClosestRangeNM: 5, TimeToClosest: 2 * time.Minute},
+		{ICAO: "receding"},
+	}
+
+	Prioritize(candidates)
+
+	expected := []string{"trackable", "approaching-5nm-soon", "approaching-5nm-late", "approaching-20nm", "far", "receding"}
+	for i, c := range candidates {
+		if c.ICAO != expected[i] {
+			t.Errorf("Position %d: got %s, expected %s", i, c.ICAO, expected[i])
+		}
+	}
+}
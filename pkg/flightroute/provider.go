@@ -0,0 +1,84 @@
+// Package flightroute defines the interface to flight plan and en-route
+// position providers such as FlightAware AeroAPI, so the flight plan fetcher
+// works with any of them.
+//
+// Providers are tested against the shared contract in package routetest.
+package flightroute
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// ErrQuotaExceeded is returned by a provider instead of making a request
+// when its usage budget has been used up.
+var ErrQuotaExceeded = errors.New("flight route provider quota exceeded")
+
+// Route is a flight's filed flight plan.
+type Route struct {
+	FlightID      string // Provider's ID for this flight, for GetPositions
+	Callsign      string
+	Departure     string // ICAO airport code (e.g., "KCLT")
+	Arrival       string
+	Route         string // ICAO route string (e.g., "KCLT..CHSLY.J121.ATL..KATL"); may be empty
+	FiledAltitude int    // Feet MSL
+	AircraftType  string // ICAO type designator (e.g., "B738")
+	FiledTime     time.Time
+	ETD           time.Time
+	ETA           time.Time
+	Status        string // Provider's flight status (e.g., "Scheduled", "En Route")
+}
+
+// Position is an en-route position report from a provider.
+type Position struct {
+	FlightID       string
+	Latitude       float64
+	Longitude      float64
+	AltitudeFt     float64 // MSL
+	GroundSpeedKts float64
+	Track          *float64 // Degrees true; nil if not reported
+	Timestamp      time.Time
+
+	// Projected is set if the provider estimated the position rather than
+	// receiving it
+	Projected bool
+}
+
+// Aircraft converts the position to an aircraft update for the given ICAO
+// address and callsign, tagged with source (usually the provider's name).
+func (p Position) Aircraft(icao, callsign, source string) adsb.Aircraft {
+	ac := adsb.Aircraft{
+		ICAO:        icao,
+		Callsign:    callsign,
+		Latitude:    p.Latitude,
+		Longitude:   p.Longitude,
+		Altitude:    units.Feet(p.AltitudeFt),
+		GroundSpeed: p.GroundSpeedKts,
+		Source:      source,
+		LastSeen:    p.Timestamp,
+	}
+	if p.Track != nil {
+		ac.Track = *p.Track
+	}
+	return ac
+}
+
+// Provider retrieves flight plans and en-route positions.
+type Provider interface {
+	// Name identifies the provider; positions it supplies are tagged with it
+	Name() string
+
+	// GetRouteByCallsign returns the flight plan of the most recent flight
+	// with the callsign. Returns nil, nil if there is none.
+	GetRouteByCallsign(ctx context.Context, callsign string) (*Route, error)
+
+	// GetPositions returns recent positions of a flight by the provider's
+	// flight ID, oldest first. Providers that only report the latest
+	// position return just that. Returns an empty slice if the flight is
+	// unknown or has no positions yet.
+	GetPositions(ctx context.Context, flightID string) ([]Position, error)
+}
@@ -0,0 +1,181 @@
+// Package routetest is the contract every flightroute.Provider must meet.
+// A provider's tests call Run with a constructor that serves the fixture
+// flights (for example from a fake HTTP API).
+package routetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/flightroute"
+)
+
+// Flight is a fixture flight: its plan and positions, oldest first.
+type Flight struct {
+	Route     flightroute.Route
+	Positions []flightroute.Position
+}
+
+func track(deg float64) *float64 {
+	return &deg
+}
+
+// Flights are the fixtures a provider under test must serve. Values are
+// chosen to survive the units of real APIs (e.g., altitudes in hundreds of
+// feet, whole-knot speeds and whole-degree tracks).
+var Flights = []Flight{
+	{
+		Route: flightroute.Route{
+			FlightID:      "UAL123-1790000000-airline-0123",
+			Callsign:      "UAL123",
+			Departure:     "KCLT",
+			Arrival:       "KATL",
+			Route:         "KCLT..CHSLY.J121.ATL..KATL",
+			FiledAltitude: 35000,
+			AircraftType:  "B738",
+			FiledTime:     time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC),
+			ETD:           time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			ETA:           time.Date(2026, 10, 16, 13, 10, 0, 0, time.UTC),
+			Status:        "En Route",
+		},
+		Positions: []flightroute.Position{
+			{
+				FlightID:       "UAL123-1790000000-airline-0123",
+				Latitude:       35.1,
+				Longitude:      -81.2,
+				AltitudeFt:     33000,
+				GroundSpeedKts: 440,
+				Track:          track(250),
+				Timestamp:      time.Date(2026, 10, 16, 12, 20, 0, 0, time.UTC),
+			},
+			{
+				FlightID:       "UAL123-1790000000-airline-0123",
+				Latitude:       34.9,
+				Longitude:      -81.9,
+				AltitudeFt:     35000,
+				GroundSpeedKts: 452,
+				Track:          track(247),
+				Timestamp:      time.Date(2026, 10, 16, 12, 25, 0, 0, time.UTC),
+			},
+		},
+	},
+	{
+		// Filed but not yet departed: no route string, no positions
+		Route: flightroute.Route{
+			FlightID:      "DAL456-1790000000-airline-0456",
+			Callsign:      "DAL456",
+			Departure:     "KATL",
+			Arrival:       "KLAX",
+			FiledAltitude: 37000,
+			AircraftType:  "A321",
+			FiledTime:     time.Date(2026, 10, 16, 11, 30, 0, 0, time.UTC),
+			ETD:           time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC),
+			ETA:           time.Date(2026, 10, 16, 16, 45, 0, 0, time.UTC),
+			Status:        "Scheduled",
+		},
+	},
+}
+
+// Run checks a provider against the contract. newProvider is called for each
+// subtest and must return a provider serving flights.
+func Run(t *testing.T, newProvider func(t *testing.T, flights []Flight) flightroute.Provider) {
+	t.Helper()
+
+	t.Run("Name", func(t *testing.T) {
+		if newProvider(t, Flights).Name() == "" {
+			t.Error("Provider name must not be empty")
+		}
+	})
+
+	t.Run("Route by callsign", func(t *testing.T) {
+		for _, flight := range Flights {
+			want := flight.Route
+			got, err := newProvider(t, Flights).GetRouteByCallsign(context.Background(), want.Callsign)
+			if err != nil {
+				t.Fatalf("GetRouteByCallsign(%s): %v", want.Callsign, err)
+			}
+			if got == nil {
+				t.Fatalf("GetRouteByCallsign(%s) returned no route", want.Callsign)
+			}
+			if !sameRoute(*got, want) {
+				t.Errorf("GetRouteByCallsign(%s) = %+v, expected %+v", want.Callsign, *got, want)
+			}
+		}
+	})
+
+	t.Run("Unknown callsign", func(t *testing.T) {
+		got, err := newProvider(t, Flights).GetRouteByCallsign(context.Background(), "NOPE999")
+		if err != nil || got != nil {
+			t.Errorf("GetRouteByCallsign(unknown) = %+v, %v; expected nil, nil", got, err)
+		}
+	})
+
+	t.Run("Positions", func(t *testing.T) {
+		flight := Flights[0]
+		got, err := newProvider(t, Flights).GetPositions(context.Background(), flight.Route.FlightID)
+		if err != nil {
+			t.Fatalf("GetPositions(): %v", err)
+		}
+		if len(got) == 0 || len(got) > len(flight.Positions) {
+			t.Fatalf("GetPositions() returned %d positions, expected 1-%d", len(got), len(flight.Positions))
+		}
+
+		// Oldest first, ending with the latest
+		for i := 1; i < len(got); i++ {
+			if !got[i].Timestamp.After(got[i-1].Timestamp) {
+				t.Errorf("Positions not in time order at %d", i)
+			}
+		}
+		latest := flight.Positions[len(flight.Positions)-1]
+		if !samePosition(got[len(got)-1], latest) {
+			t.Errorf("Latest position = %+v, expected %+v", got[len(got)-1], latest)
+		}
+	})
+
+	t.Run("No positions yet", func(t *testing.T) {
+		got, err := newProvider(t, Flights).GetPositions(context.Background(), Flights[1].Route.FlightID)
+		if err != nil || len(got) != 0 {
+			t.Errorf("GetPositions(not departed) = %+v, %v; expected none", got, err)
+		}
+	})
+
+	t.Run("Unknown flight", func(t *testing.T) {
+		got, err := newProvider(t, Flights).GetPositions(context.Background(), "NOPE999-0-0-0")
+		if err != nil || len(got) != 0 {
+			t.Errorf("GetPositions(unknown) = %+v, %v; expected none", got, err)
+		}
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		provider := newProvider(t, Flights)
+		if _, err := provider.GetRouteByCallsign(ctx, Flights[0].Route.Callsign); err == nil {
+			t.Error("GetRouteByCallsign() succeeded with a cancelled context")
+		}
+		if _, err := provider.GetPositions(ctx, Flights[0].Route.FlightID); err == nil {
+			t.Error("GetPositions() succeeded with a cancelled context")
+		}
+	})
+}
+
+// sameRoute compares routes, with times compared as instants.
+func sameRoute(a, b flightroute.Route) bool {
+	times := a.FiledTime.Equal(b.FiledTime) && a.ETD.Equal(b.ETD) && a.ETA.Equal(b.ETA)
+	a.FiledTime, a.ETD, a.ETA = b.FiledTime, b.ETD, b.ETA
+	return times && a == b
+}
+
+// samePosition compares positions, with times compared as instants.
+func samePosition(a, b flightroute.Position) bool {
+	if (a.Track == nil) != (b.Track == nil) || (a.Track != nil && *a.Track != *b.Track) {
+		return false
+	}
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return false
+	}
+	a.Track, a.Timestamp = b.Track, b.Timestamp
+	return a == b
+}
@@ -0,0 +1,184 @@
+// Package gamepad reads joysticks and gamepads through the Linux joystick
+// API (/dev/input/jsN) and maps analog sticks to telescope axis rates.
+package gamepad
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultDevice is the first joystick device on Linux.
+const DefaultDevice = "/dev/input/js0"
+
+// eventSize is the size of a Linux js_event: u32 time, s16 value, u8 type, u8 number.
+const eventSize = 8
+
+// Event types reported by the joystick API.
+const (
+	typeButton = 0x01
+	typeAxis   = 0x02
+	typeInit   = 0x80 // OR'ed into the type for synthetic initial-state events
+)
+
+// ErrUnknownEvent is returned for event types other than buttons and axes.
+var ErrUnknownEvent = errors.New("unknown gamepad event type")
+
+// axisMax is the largest magnitude reported by an axis.
+const axisMax = 32767.0
+
+// Event is a single button or axis change.
+type Event struct {
+	// Time is the driver timestamp in milliseconds
+	Time uint32
+
+	// Axis is true for axis events, false for button events
+	Axis bool
+
+	// Number is the axis or button index
+	Number int
+
+	// Value is the axis position (-32767 to 32767) or button state (0 or 1)
+	Value int16
+
+	// Init is true for synthetic events describing the initial state
+	Init bool
+}
+
+// parseEvent decodes a js_event.
+func parseEvent(b []byte) (Event, error) {
+	if len(b) != eventSize {
+		return Event{}, fmt.Errorf("invalid event size %d", len(b))
+	}
+
+	kind := b[6]
+	if kind&^typeInit != typeButton && kind&^typeInit != typeAxis {
+		return Event{}, fmt.Errorf("%w 0x%02x", ErrUnknownEvent, kind)
+	}
+
+	return Event{
+		Time:   binary.LittleEndian.Uint32(b[0:4]),
+		Value:  int16(binary.LittleEndian.Uint16(b[4:6])),
+		Axis:   kind&^typeInit == typeAxis,
+		Number: int(b[7]),
+		Init:   kind&typeInit != 0,
+	}, nil
+}
+
+// State is the current position of every axis and button.
+// It is safe for concurrent use.
+type State struct {
+	mu      sync.RWMutex
+	axes    map[int]int16
+	buttons map[int]bool
+	updated time.Time
+}
+
+// NewState creates an empty state (all axes centred, buttons released).
+func NewState() *State {
+	return &State{
+		axes:    make(map[int]int16),
+		buttons: make(map[int]bool),
+	}
+}
+
+// Apply records an event.
+func (s *State) Apply(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Axis {
+		s.axes[e.Number] = e.Value
+	} else {
+		s.buttons[e.Number] = e.Value != 0
+	}
+	s.updated = time.Now()
+}
+
+// Reset releases every button and centres every axis, as if the
+// operator had let go of the controller.
+func (s *State) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.axes = make(map[int]int16)
+	s.buttons = make(map[int]bool)
+	s.updated = time.Now()
+}
+
+// Axis returns an axis position normalized to -1..1.
+func (s *State) Axis(n int) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return math.Max(-1, float64(s.axes[n])/axisMax)
+}
+
+// Button reports whether a button is held.
+func (s *State) Button(n int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.buttons[n]
+}
+
+// Rate maps a normalized stick position to a rate in -maxRate..maxRate.
+// Positions inside the deadzone map to zero; beyond it the response is
+// quadratic so small deflections give fine control.
+func Rate(position, deadzone, maxRate float64) float64 {
+	magnitude := math.Abs(position)
+	if magnitude <= deadzone || deadzone >= 1 {
+		return 0
+	}
+
+	scaled := math.Min(1, (magnitude-deadzone)/(1-deadzone))
+	return math.Copysign(scaled*scaled*maxRate, position)
+}
+
+// Device is an open joystick device.
+type Device struct {
+	file *os.File
+}
+
+// Open opens a joystick device such as DefaultDevice.
+func Open(path string) (*Device, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gamepad %s: %w", path, err)
+	}
+	return &Device{file: file}, nil
+}
+
+// Read blocks until the next event.
+func (d *Device) Read() (Event, error) {
+	buf := make([]byte, eventSize)
+	if _, err := io.ReadFull(d.file, buf); err != nil {
+		return Event{}, fmt.Errorf("failed to read gamepad event: %w", err)
+	}
+	return parseEvent(buf)
+}
+
+// Run reads events into state until the device is closed or unplugged.
+// The state is then reset, so a dead-man button held when the controller
+// was unplugged doesn't keep the mount moving.
+func (d *Device) Run(state *State) error {
+	defer state.Reset()
+	for {
+		e, err := d.Read()
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		state.Apply(e)
+	}
+}
+
+// Close closes the device, ending Run.
+func (d *Device) Close() error {
+	return d.file.Close()
+}
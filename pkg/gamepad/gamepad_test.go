@@ -0,0 +1,143 @@
+package gamepad
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestParseEvent tests decoding of Linux js_event records.
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Event
+		wantErr error
+	}{
+		{
+			name: "Axis full left",
+			data: []byte{0x10, 0x27, 0, 0, 0x01, 0x80, typeAxis, 0},
+			want: Event{Time: 10000, Axis: true, Number: 0, Value: -32767},
+		},
+		{
+			name: "Button pressed",
+			data: []byte{0, 0, 0, 0, 1, 0, typeButton, 4},
+			want: Event{Number: 4, Value: 1},
+		},
+		{
+			name: "Initial axis state",
+			data: []byte{0, 0, 0, 0, 0xff, 0x7f, typeAxis | typeInit, 1},
+			want: Event{Axis: true, Number: 1, Value: 32767, Init: true},
+		},
+		{
+			name:    "Unknown type",
+			data:    []byte{0, 0, 0, 0, 0, 0, 0x04, 0},
+			wantErr: ErrUnknownEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEvent(tt.data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestState tests that events update axes and buttons.
+func TestState(t *testing.T) {
+	s := NewState()
+	s.Apply(Event{Axis: true, Number: 1, Value: -32767})
+	s.Apply(Event{Number: 4, Value: 1})
+
+	if got := s.Axis(1); got != -1 {
+		t.Errorf("Expected axis 1 at -1, got %.3f", got)
+	}
+	if got := s.Axis(0); got != 0 {
+		t.Errorf("Expected unseen axis centred, got %.3f", got)
+	}
+	if !s.Button(4) {
+		t.Error("Expected button 4 held")
+	}
+
+	s.Apply(Event{Number: 4, Value: 0})
+	if s.Button(4) {
+		t.Error("Expected button 4 released")
+	}
+}
+
+// TestRate tests the stick response curve.
+func TestRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		position float64
+		want     float64
+	}{
+		{"Centred", 0, 0},
+		{"Inside deadzone", 0.05, 0},
+		{"Full forward", 1, 4},
+		{"Full back", -1, -4},
+		{"Half way past deadzone", 0.55, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Rate(tt.position, 0.1, 4)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Rate(%.2f) = %.3f, want %.3f", tt.position, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRunDisconnect tests that unplugging the controller while driving
+// releases the dead-man button and centres the sticks.
+func TestRunDisconnect(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	device := &Device{file: r}
+	defer device.Close()
+
+	// Dead-man button held, stick full right
+	w.Write([]byte{0, 0, 0, 0, 1, 0, typeButton, 4})
+	w.Write([]byte{0, 0, 0, 0, 0xff, 0x7f, typeAxis, 0})
+
+	state := NewState()
+	done := make(chan error)
+	go func() { done <- device.Run(state) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !state.Button(4) || state.Axis(0) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Events were not applied")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Unplugged
+	w.Close()
+	if err := <-done; err == nil {
+		t.Error("Expected an error from Run after the device went away")
+	}
+	if state.Button(4) {
+		t.Error("Expected the dead-man button released")
+	}
+	if rate := Rate(state.Axis(0), 0.1, 3); rate != 0 {
+		t.Errorf("Expected the axis stopped, got rate %.2f", rate)
+	}
+}
@@ -0,0 +1,64 @@
+// Package geojson builds GeoJSON (RFC 7946) documents for drawing routes and
+// positions on web maps.
+package geojson
+
+import "github.com/unklstewy/ads-bscope/pkg/coordinates"
+
+// FeatureCollection is a GeoJSON document holding several features.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a geometry with arbitrary properties.
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a Point ([lon, lat, alt]) or LineString ([][lon, lat, alt]).
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// NewFeatureCollection creates a feature collection.
+func NewFeatureCollection(features ...Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// NewFeature creates a feature. Properties may be nil.
+func NewFeature(id string, geometry Geometry, properties map[string]interface{}) Feature {
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return Feature{Type: "Feature", ID: id, Geometry: geometry, Properties: properties}
+}
+
+// position converts a location to a GeoJSON position. GeoJSON puts longitude
+// first; altitude (meters) is included when non-zero.
+func position(p coordinates.Geographic) []float64 {
+	if p.Altitude != 0 {
+		return []float64{p.Longitude, p.Latitude, float64(p.Altitude)}
+	}
+	return []float64{p.Longitude, p.Latitude}
+}
+
+// Point creates a Point geometry.
+func Point(p coordinates.Geographic) Geometry {
+	return Geometry{Type: "Point", Coordinates: position(p)}
+}
+
+// LineString creates a LineString geometry through the points in order.
+func LineString(points []coordinates.Geographic) Geometry {
+	positions := make([][]float64, len(points))
+	for i, p := range points {
+		positions[i] = position(p)
+	}
+	return Geometry{Type: "LineString", Coordinates: positions}
+}
@@ -0,0 +1,36 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestFeatureCollection tests the JSON encoding of features.
+func TestFeatureCollection(t *testing.T) {
+	line := []coordinates.Geographic{
+		{Latitude: 35.2, Longitude: -80.9},
+		{Latitude: 35.5, Longitude: -80.5, Altitude: 3000},
+	}
+	fc := NewFeatureCollection(
+		NewFeature("route", LineString(line), map[string]interface{}{"kind": "route"}),
+		NewFeature("", Point(line[0]), nil),
+	)
+
+	data, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	want := `{"type":"FeatureCollection","features":[` +
+		`{"type":"Feature","id":"route","geometry":{"type":"LineString","coordinates":[[-80.9,35.2],[-80.5,35.5,3000]]},"properties":{"kind":"route"}},` +
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[-80.9,35.2]},"properties":{}}]}`
+	if string(data) != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, data)
+	}
+
+	if data, _ := json.Marshal(NewFeatureCollection()); string(data) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("Unexpected empty collection: %s", data)
+	}
+}
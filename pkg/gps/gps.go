@@ -0,0 +1,84 @@
+// Package gps reads the observer's position from a GPS receiver, through
+// gpsd or straight from a dongle's NMEA sentences, for mobile observation
+// points.
+package gps
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// reconnectDelay is how long Run waits before reopening a failed source
+const reconnectDelay = 5 * time.Second
+
+// Fix is a position reported by the receiver.
+type Fix struct {
+	Latitude  float64
+	Longitude float64
+
+	// Altitude is above mean sea level; only set if HasAltitude (a 3D fix)
+	Altitude    units.Meters
+	HasAltitude bool
+
+	// Time is when the fix was received
+	Time time.Time
+}
+
+// Receiver keeps the latest fix from a GPS source. Call Run to start
+// reading.
+type Receiver struct {
+	name string
+
+	// read reads fixes from the source until it fails or ctx is cancelled,
+	// passing each to update
+	read func(ctx context.Context, update func(Fix)) error
+
+	mu  sync.Mutex
+	fix Fix
+	ok  bool
+	err error
+}
+
+// Run reads the source until ctx is cancelled, reopening it after errors.
+func (r *Receiver) Run(ctx context.Context) {
+	for {
+		err := r.read(ctx, r.update)
+		if ctx.Err() != nil {
+			return
+		}
+		r.mu.Lock()
+		r.err = fmt.Errorf("gps %s: %w", r.name, err)
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Latest returns the latest fix, or false if there hasn't been one.
+func (r *Receiver) Latest() (Fix, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fix, r.ok
+}
+
+// Err returns the error that last interrupted reading, or nil.
+func (r *Receiver) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// update stores a fix.
+func (r *Receiver) update(fix Fix) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fix, r.ok, r.err = fix, true, nil
+}
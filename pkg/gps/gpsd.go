@@ -0,0 +1,93 @@
+package gps
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// DefaultGPSDAddress is where gpsd listens by default.
+const DefaultGPSDAddress = "localhost:2947"
+
+// gpsdWatch asks gpsd to stream JSON reports
+const gpsdWatch = `?WATCH={"enable":true,"json":true}` + "\n"
+
+// tpv is a gpsd time-position-velocity report.
+type tpv struct {
+	Class string `json:"class"`
+
+	// Mode is the fix type: 0-1 none, 2 = 2D, 3 = 3D
+	Mode int      `json:"mode"`
+	Lat  *float64 `json:"lat"`
+	Lon  *float64 `json:"lon"`
+
+	// AltMSL is the altitude above mean sea level; Alt is its older name
+	AltMSL *float64 `json:"altMSL"`
+	Alt    *float64 `json:"alt"`
+}
+
+// NewGPSD creates a receiver reading TPV reports from gpsd at addr
+// (host:port).
+func NewGPSD(addr string) *Receiver {
+	return &Receiver{
+		name: addr,
+		read: func(ctx context.Context, update func(Fix)) error {
+			return readGPSD(ctx, addr, update)
+		},
+	}
+}
+
+// readGPSD watches gpsd until the connection fails or ctx is cancelled.
+func readGPSD(ctx context.Context, addr string, update func(Fix)) error {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock the read when ctx is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if _, err := conn.Write([]byte(gpsdWatch)); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var report tpv
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil || report.Class != "TPV" {
+			continue // VERSION, DEVICES, WATCH, SKY...
+		}
+		if fix, ok := report.fix(time.Now()); ok {
+			update(fix)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("connection closed")
+}
+
+// fix converts a TPV report, or returns false without a 2D or 3D fix.
+func (r tpv) fix(now time.Time) (Fix, bool) {
+	if r.Mode < 2 || r.Lat == nil || r.Lon == nil {
+		return Fix{}, false
+	}
+
+	fix := Fix{Latitude: *r.Lat, Longitude: *r.Lon, Time: now}
+	alt := r.AltMSL
+	if alt == nil {
+		alt = r.Alt
+	}
+	if r.Mode >= 3 && alt != nil {
+		fix.Altitude, fix.HasAltitude = units.Meters(*alt), true
+	}
+	return fix, true
+}
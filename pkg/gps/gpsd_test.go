@@ -0,0 +1,72 @@
+package gps
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestGPSD tests reading fixes from gpsd's JSON stream.
+func TestGPSD(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintln(conn, `{"class":"VERSION","release":"3.25","proto_major":3,"proto_minor":15}`)
+
+		// Fixes are only streamed once watching
+		if line, err := bufio.NewReader(conn).ReadString('\n'); err != nil || line != gpsdWatch {
+			return
+		}
+		fmt.Fprintln(conn, `{"class":"TPV","device":"/dev/ttyACM0","mode":1}`)
+		fmt.Fprintln(conn, `{"class":"SKY","device":"/dev/ttyACM0","satellites":[]}`)
+		fmt.Fprintln(conn, `{"class":"TPV","device":"/dev/ttyACM0","mode":3,"lat":35.1401,"lon":-80.8,"altHAE":217.0,"altMSL":250.0}`)
+		time.Sleep(time.Second)
+	}()
+
+	receiver := NewGPSD(listener.Addr().String())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go receiver.Run(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if fix, ok := receiver.Latest(); ok {
+			if fix.Latitude != 35.1401 || fix.Longitude != -80.8 || !fix.HasAltitude || fix.Altitude != 250 {
+				t.Errorf("Latest() = %+v, want 35.1401, -80.8 at 250 m MSL", fix)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("No fix received from gpsd")
+}
+
+// TestTPVFix tests which reports are fixes.
+func TestTPVFix(t *testing.T) {
+	lat, lon, alt := 35.0, -80.0, 100.0
+	now := time.Now()
+
+	if _, ok := (tpv{Class: "TPV", Mode: 1, Lat: &lat, Lon: &lon}).fix(now); ok {
+		t.Error("A mode 1 report is a fix, want none")
+	}
+	fix, ok := (tpv{Class: "TPV", Mode: 2, Lat: &lat, Lon: &lon, Alt: &alt}).fix(now)
+	if !ok || fix.HasAltitude {
+		t.Errorf("2D fix = %+v, %v, want a fix without altitude", fix, ok)
+	}
+	fix, ok = (tpv{Class: "TPV", Mode: 3, Lat: &lat, Lon: &lon, Alt: &alt}).fix(now)
+	if !ok || !fix.HasAltitude || fix.Altitude != 100 {
+		t.Errorf("3D fix = %+v, %v, want altitude 100 m from alt", fix, ok)
+	}
+}
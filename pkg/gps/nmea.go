@@ -0,0 +1,125 @@
+package gps
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// NewNMEA creates a receiver reading NMEA 0183 sentences from a GPS dongle's
+// serial device (e.g. /dev/ttyACM0). The device's baud rate must already be
+// set (e.g. with stty) if it isn't a USB modem device, which ignores it.
+func NewNMEA(device string) *Receiver {
+	return &Receiver{
+		name: device,
+		read: func(ctx context.Context, update func(Fix)) error {
+			f, err := os.Open(device)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			// Unblock the read when ctx is cancelled
+			stop := context.AfterFunc(ctx, func() { f.Close() })
+			defer stop()
+
+			return readNMEA(f, update)
+		},
+	}
+}
+
+// readNMEA reads sentences until r fails or ends, passing the fix of each
+// valid GGA sentence to update.
+func readNMEA(r io.Reader, update func(Fix)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if fix, ok := ParseGGA(scanner.Text(), time.Now()); ok {
+			update(fix)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("device closed")
+}
+
+// ParseGGA parses an NMEA GGA (fix data) sentence from any talker (GP, GN,
+// GL...). Returns false if it isn't a GGA sentence, its checksum is wrong
+// or it has no fix.
+func ParseGGA(sentence string, now time.Time) (Fix, bool) {
+	sentence = strings.TrimSpace(sentence)
+	if !strings.HasPrefix(sentence, "$") {
+		return Fix{}, false
+	}
+	data, checksum, hasChecksum := strings.Cut(sentence[1:], "*")
+	if hasChecksum && !validChecksum(data, checksum) {
+		return Fix{}, false
+	}
+
+	// $xxGGA,time,lat,N/S,lon,E/W,quality,satellites,hdop,altitude,M,...
+	fields := strings.Split(data, ",")
+	if len(fields) < 10 || len(fields[0]) != 5 || fields[0][2:] != "GGA" {
+		return Fix{}, false
+	}
+	if fields[6] == "" || fields[6] == "0" {
+		return Fix{}, false // No fix
+	}
+
+	lat, ok := parseCoordinate(fields[2], fields[3], "N", "S", 2)
+	if !ok {
+		return Fix{}, false
+	}
+	lon, ok := parseCoordinate(fields[4], fields[5], "E", "W", 3)
+	if !ok {
+		return Fix{}, false
+	}
+
+	fix := Fix{Latitude: lat, Longitude: lon, Time: now}
+	if alt, err := strconv.ParseFloat(fields[9], 64); err == nil {
+		fix.Altitude, fix.HasAltitude = units.Meters(alt), true
+	}
+	return fix, true
+}
+
+// parseCoordinate parses an NMEA (d)ddmm.mmmm coordinate with degDigits
+// degree digits and its hemisphere, negative for neg.
+func parseCoordinate(value, hemisphere, pos, neg string, degDigits int) (float64, bool) {
+	if len(value) < degDigits+2 || (hemisphere != pos && hemisphere != neg) {
+		return 0, false
+	}
+	degrees, err := strconv.Atoi(value[:degDigits])
+	if err != nil {
+		return 0, false
+	}
+	minutes, err := strconv.ParseFloat(value[degDigits:], 64)
+	if err != nil || minutes >= 60 {
+		return 0, false
+	}
+
+	coord := float64(degrees) + minutes/60
+	if hemisphere == neg {
+		coord = -coord
+	}
+	return coord, true
+}
+
+// validChecksum reports whether checksum (two hex digits) is the XOR of the
+// sentence's data characters.
+func validChecksum(data, checksum string) bool {
+	want, err := strconv.ParseUint(strings.TrimSpace(checksum), 16, 8)
+	if err != nil {
+		return false
+	}
+	var sum byte
+	for i := 0; i < len(data); i++ {
+		sum ^= data[i]
+	}
+	return sum == byte(want)
+}
@@ -0,0 +1,66 @@
+package gps
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseGGA tests decoding NMEA fix data sentences.
+func TestParseGGA(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		sentence string
+		wantOK   bool
+		lat, lon float64
+		alt      float64
+	}{
+		{"GPS fix", "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47", true, 48.1173, 11.5167, 545.4},
+		{"Multi-constellation, western hemisphere", "$GNGGA,001043.00,3508.4080,N,08048.0000,W,1,12,0.8,250.0,M,-33.0,M,,*41\r", true, 35.1401, -80.8, 250},
+		{"No fix", "$GPGGA,001043.00,,,,,0,00,99.99,,,,,,*60", false, 0, 0, 0},
+		{"Bad checksum", "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48", false, 0, 0, 0},
+		{"Other sentence", "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A", false, 0, 0, 0},
+		{"Garbage", "4807.038,N", false, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fix, ok := ParseGGA(tt.sentence, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseGGA() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(fix.Latitude-tt.lat) > 0.0001 || math.Abs(fix.Longitude-tt.lon) > 0.0001 {
+				t.Errorf("Position = %.4f, %.4f, want %.4f, %.4f", fix.Latitude, fix.Longitude, tt.lat, tt.lon)
+			}
+			if !fix.HasAltitude || math.Abs(float64(fix.Altitude)-tt.alt) > 0.01 {
+				t.Errorf("Altitude = %.1f (%v), want %.1f", fix.Altitude, fix.HasAltitude, tt.alt)
+			}
+			if !fix.Time.Equal(now) {
+				t.Errorf("Time = %v, want %v", fix.Time, now)
+			}
+		})
+	}
+}
+
+// TestReadNMEA tests keeping the latest fix from a sentence stream.
+func TestReadNMEA(t *testing.T) {
+	stream := strings.Join([]string{
+		"$GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00*74",
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+		"$GPGGA,001043.00,,,,,0,00,99.99,,,,,,*60",
+	}, "\r\n")
+
+	r := &Receiver{name: "test"}
+	if err := readNMEA(strings.NewReader(stream), r.update); err == nil {
+		t.Error("readNMEA() returned nil at the end of the stream, want an error")
+	}
+	fix, ok := r.Latest()
+	if !ok || math.Abs(fix.Latitude-48.1173) > 0.0001 {
+		t.Errorf("Latest() = %+v, %v, want the GGA fix (a lost fix keeps the last)", fix, ok)
+	}
+}
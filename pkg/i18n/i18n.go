@@ -0,0 +1,186 @@
+// Package i18n translates the terminal clients' strings and formats numbers
+// and units for the observer's locale.
+//
+// Messages are looked up by key in the language's catalog, falling back to
+// English and then to the key itself, so a missing translation shows up as
+// English text rather than an empty field. Quantities are passed in the
+// units the rest of the code uses (feet, nautical miles, knots) and shown
+// in either aviation or metric units.
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// DefaultLanguage is the language used when none is configured.
+const DefaultLanguage = "en"
+
+// UnitSystem selects the units quantities are shown in.
+type UnitSystem string
+
+const (
+	// Aviation shows feet, nautical miles, knots and feet per minute
+	Aviation UnitSystem = "aviation"
+
+	// Metric shows meters, kilometers, kilometers per hour and meters per
+	// second
+	Metric UnitSystem = "metric"
+)
+
+// Conversion factors from the aviation units
+const (
+	kmPerNM    = 1.852
+	kmhPerKnot = 1.852
+	mpsPerFPM  = 0.00508
+)
+
+// numberFormat is a language's decimal and thousands separators.
+type numberFormat struct {
+	decimal string
+	group   string
+}
+
+// numberFormats lists the separators for each supported language.
+var numberFormats = map[string]numberFormat{
+	"en": {decimal: ".", group: ","},
+	"de": {decimal: ",", group: "."},
+	"es": {decimal: ",", group: "."},
+}
+
+// Localizer translates messages and formats quantities for one language and
+// unit system.
+type Localizer struct {
+	Language string
+	Units    UnitSystem
+
+	messages map[string]string
+	numbers  numberFormat
+}
+
+// New returns a localizer for a language ("en", "de" or "es") and unit
+// system. Empty values give English and aviation units. Regional variants
+// such as "de-AT" or "es_MX" use their base language.
+func New(language string, system UnitSystem) (Localizer, error) {
+	lang := strings.ToLower(language)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" {
+		lang = DefaultLanguage
+	}
+	messages, ok := catalogs[lang]
+	if !ok {
+		return Localizer{}, fmt.Errorf("unsupported language %q (available: %s)", language, strings.Join(Languages(), ", "))
+	}
+
+	if system == "" {
+		system = Aviation
+	}
+	if system != Aviation && system != Metric {
+		return Localizer{}, fmt.Errorf("unknown unit system %q (use %q or %q)", system, Aviation, Metric)
+	}
+
+	return Localizer{
+		Language: lang,
+		Units:    system,
+		messages: messages,
+		numbers:  numberFormats[lang],
+	}, nil
+}
+
+// Default returns the English, aviation units localizer.
+func Default() Localizer {
+	l, _ := New(DefaultLanguage, Aviation)
+	return l
+}
+
+// Languages returns the supported language codes.
+func Languages() []string {
+	return []string{"en", "de", "es"}
+}
+
+// T returns the message for key, formatted with args if any are given.
+func (l Localizer) T(key string, args ...interface{}) string {
+	msg, ok := l.messages[key]
+	if !ok {
+		if msg, ok = catalogs[DefaultLanguage][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Number formats v with the given number of decimals and the language's
+// decimal and thousands separators, e.g. 12345.6 is "12,345.6" in English
+// and "12.345,6" in German.
+func (l Localizer) Number(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(l.numbers.group)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if frac == "" {
+		return sign + grouped.String()
+	}
+	return sign + grouped.String() + l.numbers.decimal + frac
+}
+
+// signed formats v like Number with an explicit "+" for positive values.
+func (l Localizer) signed(v float64, decimals int) string {
+	s := l.Number(v, decimals)
+	if v > 0 && s != l.Number(0, decimals) {
+		return "+" + s
+	}
+	return s
+}
+
+// Altitude formats an altitude as "35,000 ft" or "10,668 m".
+func (l Localizer) Altitude(ft units.Feet) string {
+	if l.Units == Metric {
+		return l.Number(float64(ft.Meters()), 0) + " m"
+	}
+	return l.Number(float64(ft), 0) + " ft"
+}
+
+// Distance formats a distance in nautical miles as "12.3 NM" or "22.8 km".
+func (l Localizer) Distance(nm float64, decimals int) string {
+	if l.Units == Metric {
+		return l.Number(nm*kmPerNM, decimals) + " km"
+	}
+	return l.Number(nm, decimals) + " NM"
+}
+
+// Speed formats a ground speed in knots as "450 kt" or "833 km/h".
+func (l Localizer) Speed(knots float64) string {
+	if l.Units == Metric {
+		return l.Number(knots*kmhPerKnot, 0) + " km/h"
+	}
+	return l.Number(knots, 0) + " kt"
+}
+
+// VerticalRate formats a climb (positive) or descent rate in feet per
+// minute as "+1,500 fpm" or "+7.6 m/s".
+func (l Localizer) VerticalRate(fpm float64) string {
+	if l.Units == Metric {
+		return l.signed(fpm*mpsPerFPM, 1) + " m/s"
+	}
+	return l.signed(fpm, 0) + " fpm"
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestNew tests choosing a language and unit system.
+func TestNew(t *testing.T) {
+	l, err := New("", "")
+	if err != nil || l.Language != "en" || l.Units != Aviation {
+		t.Errorf("Expected English and aviation units, got %q %q (%v)", l.Language, l.Units, err)
+	}
+
+	l, err = New("de-AT", Metric)
+	if err != nil || l.Language != "de" {
+		t.Errorf("Expected de-AT to use German, got %q (%v)", l.Language, err)
+	}
+
+	if _, err := New("fr", ""); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+	if _, err := New("en", "imperial"); err == nil {
+		t.Error("Expected error for unknown unit system")
+	}
+}
+
+// TestCatalogsComplete tests that every language translates every message
+// with the same format arguments as English.
+func TestCatalogsComplete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+ 0#]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+	for _, lang := range Languages() {
+		messages, ok := catalogs[lang]
+		if !ok {
+			t.Fatalf("No catalog for %s", lang)
+		}
+		for key, en := range catalogs[DefaultLanguage] {
+			msg, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, key)
+				continue
+			}
+			want, got := verbs.FindAllString(en, -1), verbs.FindAllString(msg, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+					break
+				}
+			}
+		}
+		for key := range messages {
+			if _, ok := catalogs[DefaultLanguage][key]; !ok {
+				t.Errorf("%s: %q is not an English message", lang, key)
+			}
+		}
+	}
+}
+
+// TestT tests message lookup and fallbacks.
+func TestT(t *testing.T) {
+	de, _ := New("de", "")
+	if got := de.T("radar.aircraft", 3); got != "Flugzeuge: 3 in Reichweite" {
+		t.Errorf("Unexpected German message %q", got)
+	}
+	if got := de.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected unknown key back, got %q", got)
+	}
+}
+
+// TestFormatting tests numbers and units in each locale.
+func TestFormatting(t *testing.T) {
+	en, _ := New("en", Aviation)
+	de, _ := New("de", Metric)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"English number", en.Number(12345.67, 1), "12,345.7"},
+		{"German number", de.Number(12345.67, 1), "12.345,7"},
+		{"Negative number", en.Number(-1234, 0), "-1,234"},
+		{"Small number", de.Number(0.5, 2), "0,50"},
+		{"Feet", en.Altitude(units.Feet(35000)), "35,000 ft"},
+		{"Meters", de.Altitude(units.Feet(35000)), "10.668 m"},
+		{"Nautical miles", en.Distance(12.34, 1), "12.3 NM"},
+		{"Kilometers", de.Distance(10, 1), "18,5 km"},
+		{"Knots", en.Speed(450), "450 kt"},
+		{"Kilometers per hour", de.Speed(450), "833 km/h"},
+		{"Feet per minute", en.VerticalRate(1500), "+1,500 fpm"},
+		{"Meters per second", de.VerticalRate(-1500), "-7,6 m/s"},
+		{"Level flight", en.VerticalRate(0), "0 fpm"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
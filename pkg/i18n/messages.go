@@ -0,0 +1,367 @@
+package i18n
+
+// catalogs maps each language to its messages. English is the reference:
+// every key must be present there, and translations must take the same
+// format arguments. Detail popup labels are padded to 14 columns, so keep
+// them to 13 characters.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"title.viewfinder": "ADS-B SCOPE TUI VIEWFINDER",
+		"title.radar":      "ADS-B SCOPE RADAR MODE",
+
+		"prompt.airport": "Enter airport code (e.g., KATL, JFK):",
+		"prompt.radius":  "Enter radar radius (50-2500 NM):",
+		"prompt.help":    "ENTER: Submit  ESC: Cancel",
+		"error":          "Error: %v",
+		"error.continue": "Press SPACE to continue (use 3-letter codes: RDU, ATL, JFK, LAX, ORD)...",
+		"help.sky":       "↑/↓/Click: Select  ENTER/SPACE: Track  S: Stop  B: Bells  I: Info  /: Search  1/2/3: Filters  C: Config  R: Radar  +/-/Wheel: Zoom  0: Reset  P: Projection  T: Theme  A: Auto  Q: Quit",
+
+		"list.header":        "Trackable Aircraft:",
+		"list.empty":         "No trackable aircraft in range",
+		"list.emptyFiltered": "No aircraft match the filters (ESC: Clear)",
+		"list.tracking":      "[TRACKING]",
+		"list.plan":          "Plan: %s → %s",
+		"list.planNext":      "Plan: %s → %s (next: %s)",
+		"list.planNextETA":   "Plan: %s → %s (next: %s at %s)",
+		"telescope.radec":    "Telescope: RA %02d:%02d:%02d  Dec %+6.2f°  Zoom: %sx",
+		"telescope.altaz":    "Telescope: Az %s°  Alt %s°  Zoom: %sx",
+		"alert":              "Alert: %s",
+		"alert.muted":        "(muted)",
+
+		"autoselect.tracking": "Auto-select: %s (rule %q)",
+		"autoselect.waiting":  "Auto-select: waiting for a target",
+
+		"handoff.leaves":  "Leaves limits in %s",
+		"handoff.staysUp": "Within limits for %.0f+ min",
+		"handoff.none":    "No next target predicted",
+		"handoff.next":    "Next: %s, max %s°",
+		"handoff.nextIn":  "Next: %s, in limits %s after",
+
+		"legend":               "Legend",
+		"legend.untracked":     "Untracked",
+		"legend.selected":      "Selected",
+		"legend.tracking":      "Tracking",
+		"legend.watched":       "Watched",
+		"legend.telescope":     "Telescope",
+		"legend.commanded":     "Commanded",
+		"legend.trail":         "Trail/Ring",
+		"legend.velocity":      "Velocity",
+		"legend.projection":    "Projection: %s",
+		"legend.theme":         "Theme: %s",
+		"legend.prediction":    "Prediction",
+		"legend.waypoint":      "Waypoint",
+		"legend.airway":        "Airway",
+		"legend.deadreckoning": "Dead Reckoning",
+		"legend.adsc":          "Oceanic ADS-C",
+		"legend.climb":         "Climbing",
+		"legend.descent":       "Descending",
+		"legend.approach":      "On approach",
+		"legend.hold":          "Holding",
+		"legend.rings":         "Range Rings",
+
+		"radar":          "RADAR MODE",
+		"radar.center":   "Center: %s",
+		"radar.radius":   "Radius: %s",
+		"radar.rings":    "Rings: every %s",
+		"radar.overlay":  "Airports: %d  Navaids: %d",
+		"radar.airways":  "Airways: %d segments, %d fixes",
+		"radar.zoomin":   "Airways: zoom in to %s",
+		"radar.position": "Position: %.4f°, %.4f°",
+		"radar.aircraft": "Aircraft: %d in range",
+		"radar.filtered": "Filtered: %d shown",
+		"radar.help1":    "R: Exit radar  +/-: Adjust radius  A: Airways",
+		"radar.help2":    "/: Search  1/2/3: Filters  ESC: Clear",
+		"radar.help3":    "Wheel: Zoom  Click: Select/Track",
+		"radar.help4":    "↑/↓: Select  ENTER: Track  I: Info  Q: Quit",
+
+		"detail.gone":               "Aircraft is no longer in range",
+		"detail.help":               "ESC/I: Close",
+		"detail.registration":       "Registration",
+		"detail.airline":            "Airline",
+		"detail.country":            "Registered",
+		"detail.type":               "Type",
+		"detail.squawk":             "Squawk",
+		"detail.source":             "Source",
+		"detail.flightPlan":         "Flight Plan",
+		"detail.noFlightPlan":       "No flight plan on file",
+		"detail.origin":             "Origin",
+		"detail.destination":        "Destination",
+		"detail.filedAltitude":      "Filed alt",
+		"detail.eta":                "ETA",
+		"detail.routeUnresolved":    "Route not resolved",
+		"detail.earlier":            "  ... %d earlier",
+		"detail.more":               "  ... %d more",
+		"detail.prediction":         "Prediction",
+		"detail.mode":               "Mode",
+		"detail.mode.live":          "Live position",
+		"detail.mode.waypoint":      "Waypoint route",
+		"detail.mode.airway":        "Airway %s",
+		"detail.mode.deadreckoning": "Dead reckoning",
+		"detail.mode.hold":          "Holding pattern",
+		"detail.phase":              "Phase",
+		"detail.hold":               "Hold",
+		"detail.hold.left":          "%03.0f°  %s legs  left turns",
+		"detail.hold.right":         "%03.0f°  %s legs  right turns",
+		"detail.dataAge":            "Data age",
+		"detail.confidence":         "Confidence",
+		"detail.reported":           "Reported",
+		"detail.predicted":          "Predicted",
+		"detail.predictedFrom":      "%.4f°, %.4f° (%s from report)",
+		"detail.motion":             "Motion",
+		"detail.sky":                "Sky",
+		"detail.skyPosition":        "Az %s°  Alt %s°  %s",
+		"detail.pass":               "Pass",
+		"detail.intent":             "Autopilot (local receiver)",
+		"detail.noIntent":           "No extended squitter heard yet",
+		"detail.selectedAltitude":   "Selected alt",
+		"detail.selectedHeading":    "Heading bug",
+		"detail.airspeed":           "Airspeed",
+		"detail.pass.none":          "None above %.0f° in the next %.0f min",
+		"detail.pass.peak":          "%s  peak %.0f° at %s",
+
+		"phase.climb":    "Climb",
+		"phase.cruise":   "Cruise",
+		"phase.descent":  "Descent",
+		"phase.approach": "Approach",
+	},
+
+	"de": {
+		"title.viewfinder": "ADS-B SCOPE TUI-SUCHER",
+		"title.radar":      "ADS-B SCOPE RADARMODUS",
+
+		"prompt.airport": "Flughafencode eingeben (z. B. KATL, JFK):",
+		"prompt.radius":  "Radarradius eingeben (50-2500 NM):",
+		"prompt.help":    "ENTER: Übernehmen  ESC: Abbrechen",
+		"error":          "Fehler: %v",
+		"error.continue": "LEERTASTE zum Fortfahren (3-Buchstaben-Codes verwenden: RDU, ATL, JFK, LAX, ORD)...",
+		"help.sky":       "↑/↓/Klick: Auswahl  ENTER/LEER: Verfolgen  S: Stopp  B: Signale  I: Info  /: Suche  1/2/3: Filter  C: Konfig.  R: Radar  +/-/Rad: Zoom  0: Zurücksetzen  P: Projektion  T: Farbschema  A: Auto  Q: Beenden",
+
+		"list.header":        "Verfolgbare Flugzeuge:",
+		"list.empty":         "Keine verfolgbaren Flugzeuge in Reichweite",
+		"list.emptyFiltered": "Keine Flugzeuge entsprechen den Filtern (ESC: Zurücksetzen)",
+		"list.tracking":      "[VERFOLGT]",
+		"list.plan":          "Flugplan: %s → %s",
+		"list.planNext":      "Flugplan: %s → %s (nächster: %s)",
+		"list.planNextETA":   "Flugplan: %s → %s (nächster: %s um %s)",
+		"telescope.radec":    "Teleskop: RA %02d:%02d:%02d  Dek %+6.2f°  Zoom: %sx",
+		"telescope.altaz":    "Teleskop: Az %s°  Höhe %s°  Zoom: %sx",
+		"alert":              "Warnung: %s",
+		"alert.muted":        "(stumm)",
+
+		"autoselect.tracking": "Automatik: %s (Regel %q)",
+		"autoselect.waiting":  "Automatik: warte auf ein Ziel",
+
+		"handoff.leaves":  "Verlässt die Grenzen in %s",
+		"handoff.staysUp": "%.0f+ Min. innerhalb der Grenzen",
+		"handoff.none":    "Kein nächstes Ziel vorhergesagt",
+		"handoff.next":    "Nächstes: %s, max. %s°",
+		"handoff.nextIn":  "Nächstes: %s, %s danach in den Grenzen",
+
+		"legend":               "Legende",
+		"legend.untracked":     "Nicht verfolgt",
+		"legend.selected":      "Ausgewählt",
+		"legend.tracking":      "Verfolgt",
+		"legend.watched":       "Beobachtet",
+		"legend.telescope":     "Teleskop",
+		"legend.commanded":     "Befohlen",
+		"legend.trail":         "Spur/Ring",
+		"legend.velocity":      "Geschwindigkeit",
+		"legend.projection":    "Projektion: %s",
+		"legend.theme":         "Farbschema: %s",
+		"legend.prediction":    "Vorhersage",
+		"legend.waypoint":      "Wegpunkt",
+		"legend.airway":        "Luftstraße",
+		"legend.deadreckoning": "Koppelnavigation",
+		"legend.adsc":          "Ozeanisches ADS-C",
+		"legend.climb":         "Steigflug",
+		"legend.descent":       "Sinkflug",
+		"legend.approach":      "Im Anflug",
+		"legend.hold":          "Warteschleife",
+		"legend.rings":         "Entfernungsringe",
+
+		"radar":          "RADARMODUS",
+		"radar.center":   "Zentrum: %s",
+		"radar.radius":   "Radius: %s",
+		"radar.rings":    "Ringe: alle %s",
+		"radar.overlay":  "Flughäfen: %d  Funkfeuer: %d",
+		"radar.airways":  "Luftstraßen: %d Segmente, %d Fixe",
+		"radar.zoomin":   "Luftstraßen: auf %s heranzoomen",
+		"radar.position": "Position: %.4f°, %.4f°",
+		"radar.aircraft": "Flugzeuge: %d in Reichweite",
+		"radar.filtered": "Gefiltert: %d angezeigt",
+		"radar.help1":    "R: Radar verlassen  +/-: Radius ändern  A: Luftstraßen",
+		"radar.help2":    "/: Suche  1/2/3: Filter  ESC: Zurücksetzen",
+		"radar.help3":    "Rad: Zoom  Klick: Auswahl/Verfolgen",
+		"radar.help4":    "↑/↓: Auswahl  ENTER: Verfolgen  I: Info  Q: Beenden",
+
+		"detail.gone":               "Flugzeug ist nicht mehr in Reichweite",
+		"detail.help":               "ESC/I: Schließen",
+		"detail.registration":       "Kennzeichen",
+		"detail.airline":            "Airline",
+		"detail.country":            "Registriert",
+		"detail.type":               "Typ",
+		"detail.squawk":             "Squawk",
+		"detail.source":             "Quelle",
+		"detail.flightPlan":         "Flugplan",
+		"detail.noFlightPlan":       "Kein Flugplan hinterlegt",
+		"detail.origin":             "Abflug",
+		"detail.destination":        "Ziel",
+		"detail.filedAltitude":      "Geplante Höhe",
+		"detail.eta":                "ETA",
+		"detail.routeUnresolved":    "Route nicht aufgelöst",
+		"detail.earlier":            "  ... %d vorher",
+		"detail.more":               "  ... %d weitere",
+		"detail.prediction":         "Vorhersage",
+		"detail.mode":               "Modus",
+		"detail.mode.live":          "Live-Position",
+		"detail.mode.waypoint":      "Wegpunktroute",
+		"detail.mode.airway":        "Luftstraße %s",
+		"detail.mode.deadreckoning": "Koppelnavigation",
+		"detail.mode.hold":          "Warteschleife",
+		"detail.phase":              "Phase",
+		"detail.hold":               "Warteschleife",
+		"detail.hold.left":          "%03.0f°  Schenkel %s  Linkskurven",
+		"detail.hold.right":         "%03.0f°  Schenkel %s  Rechtskurven",
+		"detail.dataAge":            "Datenalter",
+		"detail.confidence":         "Konfidenz",
+		"detail.reported":           "Gemeldet",
+		"detail.predicted":          "Vorhergesagt",
+		"detail.predictedFrom":      "%.4f°, %.4f° (%s von Meldung)",
+		"detail.motion":             "Bewegung",
+		"detail.sky":                "Himmel",
+		"detail.skyPosition":        "Az %s°  Höhe %s°  %s",
+		"detail.pass":               "Überflug",
+		"detail.intent":             "Autopilot (lokaler Empfänger)",
+		"detail.noIntent":           "Noch kein Extended Squitter empfangen",
+		"detail.selectedAltitude":   "Gewählte Höhe",
+		"detail.selectedHeading":    "Steuerkurs-Bug",
+		"detail.airspeed":           "Fahrt",
+		"detail.pass.none":          "Keiner über %.0f° in den nächsten %.0f min",
+		"detail.pass.peak":          "%s  Gipfel %.0f° um %s",
+
+		"phase.climb":    "Steigflug",
+		"phase.cruise":   "Reiseflug",
+		"phase.descent":  "Sinkflug",
+		"phase.approach": "Anflug",
+	},
+
+	"es": {
+		"title.viewfinder": "ADS-B SCOPE VISOR TUI",
+		"title.radar":      "ADS-B SCOPE MODO RADAR",
+
+		"prompt.airport": "Introduzca el código de aeropuerto (p. ej., KATL, JFK):",
+		"prompt.radius":  "Introduzca el radio del radar (50-2500 NM):",
+		"prompt.help":    "ENTER: Aceptar  ESC: Cancelar",
+		"error":          "Error: %v",
+		"error.continue": "Pulse ESPACIO para continuar (use códigos de 3 letras: RDU, ATL, JFK, LAX, ORD)...",
+		"help.sky":       "↑/↓/Clic: Elegir  ENTER/ESPACIO: Seguir  S: Parar  B: Avisos  I: Info  /: Buscar  1/2/3: Filtros  C: Config.  R: Radar  +/-/Rueda: Zoom  0: Restablecer  P: Proyección  T: Tema  A: Auto  Q: Salir",
+
+		"list.header":        "Aeronaves seguibles:",
+		"list.empty":         "No hay aeronaves seguibles en alcance",
+		"list.emptyFiltered": "Ninguna aeronave coincide con los filtros (ESC: Borrar)",
+		"list.tracking":      "[SIGUIENDO]",
+		"list.plan":          "Plan: %s → %s",
+		"list.planNext":      "Plan: %s → %s (siguiente: %s)",
+		"list.planNextETA":   "Plan: %s → %s (siguiente: %s a las %s)",
+		"telescope.radec":    "Telescopio: AR %02d:%02d:%02d  Dec %+6.2f°  Zoom: %sx",
+		"telescope.altaz":    "Telescopio: Az %s°  Alt %s°  Zoom: %sx",
+		"alert":              "Aviso: %s",
+		"alert.muted":        "(silenciado)",
+
+		"autoselect.tracking": "Selección automática: %s (regla %q)",
+		"autoselect.waiting":  "Selección automática: esperando un objetivo",
+
+		"handoff.leaves":  "Sale de los límites en %s",
+		"handoff.staysUp": "Dentro de los límites %.0f+ min",
+		"handoff.none":    "Ningún objetivo siguiente previsto",
+		"handoff.next":    "Siguiente: %s, máx. %s°",
+		"handoff.nextIn":  "Siguiente: %s, en límites %s después",
+
+		"legend":               "Leyenda",
+		"legend.untracked":     "Sin seguir",
+		"legend.selected":      "Seleccionada",
+		"legend.tracking":      "Siguiendo",
+		"legend.watched":       "Vigilada",
+		"legend.telescope":     "Telescopio",
+		"legend.commanded":     "Ordenada",
+		"legend.trail":         "Estela/Anillo",
+		"legend.velocity":      "Velocidad",
+		"legend.projection":    "Proyección: %s",
+		"legend.theme":         "Tema: %s",
+		"legend.prediction":    "Predicción",
+		"legend.waypoint":      "Punto de ruta",
+		"legend.airway":        "Aerovía",
+		"legend.deadreckoning": "Navegación por estima",
+		"legend.adsc":          "ADS-C oceánico",
+		"legend.climb":         "En ascenso",
+		"legend.descent":       "En descenso",
+		"legend.approach":      "En aproximación",
+		"legend.hold":          "En espera",
+		"legend.rings":         "Anillos de distancia",
+
+		"radar":          "MODO RADAR",
+		"radar.center":   "Centro: %s",
+		"radar.radius":   "Radio: %s",
+		"radar.rings":    "Anillos: cada %s",
+		"radar.overlay":  "Aeropuertos: %d  Radioayudas: %d",
+		"radar.airways":  "Aerovías: %d tramos, %d fijos",
+		"radar.zoomin":   "Aerovías: acerque a %s",
+		"radar.position": "Posición: %.4f°, %.4f°",
+		"radar.aircraft": "Aeronaves: %d en alcance",
+		"radar.filtered": "Filtradas: %d mostradas",
+		"radar.help1":    "R: Salir del radar  +/-: Ajustar radio  A: Aerovías",
+		"radar.help2":    "/: Buscar  1/2/3: Filtros  ESC: Borrar",
+		"radar.help3":    "Rueda: Zoom  Clic: Elegir/Seguir",
+		"radar.help4":    "↑/↓: Elegir  ENTER: Seguir  I: Info  Q: Salir",
+
+		"detail.gone":               "La aeronave ya no está en alcance",
+		"detail.help":               "ESC/I: Cerrar",
+		"detail.registration":       "Matrícula",
+		"detail.airline":            "Aerolínea",
+		"detail.country":            "País",
+		"detail.type":               "Tipo",
+		"detail.squawk":             "Squawk",
+		"detail.source":             "Fuente",
+		"detail.flightPlan":         "Plan de vuelo",
+		"detail.noFlightPlan":       "Sin plan de vuelo registrado",
+		"detail.origin":             "Origen",
+		"detail.destination":        "Destino",
+		"detail.filedAltitude":      "Alt. prevista",
+		"detail.eta":                "ETA",
+		"detail.routeUnresolved":    "Ruta no resuelta",
+		"detail.earlier":            "  ... %d anteriores",
+		"detail.more":               "  ... %d más",
+		"detail.prediction":         "Predicción",
+		"detail.mode":               "Modo",
+		"detail.mode.live":          "Posición en vivo",
+		"detail.mode.waypoint":      "Ruta por puntos",
+		"detail.mode.airway":        "Aerovía %s",
+		"detail.mode.deadreckoning": "Navegación por estima",
+		"detail.mode.hold":          "Circuito de espera",
+		"detail.phase":              "Fase",
+		"detail.hold":               "Espera",
+		"detail.hold.left":          "%03.0f°  tramos de %s  virajes a la izquierda",
+		"detail.hold.right":         "%03.0f°  tramos de %s  virajes a la derecha",
+		"detail.dataAge":            "Edad de datos",
+		"detail.confidence":         "Confianza",
+		"detail.reported":           "Notificada",
+		"detail.predicted":          "Prevista",
+		"detail.predictedFrom":      "%.4f°, %.4f° (%s del informe)",
+		"detail.motion":             "Movimiento",
+		"detail.sky":                "Cielo",
+		"detail.skyPosition":        "Az %s°  Alt %s°  %s",
+		"detail.pass":               "Paso",
+		"detail.intent":             "Piloto automático (receptor local)",
+		"detail.noIntent":           "Aún no se ha recibido ningún squitter extendido",
+		"detail.selectedAltitude":   "Alt. selecc.",
+		"detail.selectedHeading":    "Rumbo selecc.",
+		"detail.airspeed":           "Velocidad",
+		"detail.pass.none":          "Ninguno sobre %.0f° en los próximos %.0f min",
+		"detail.pass.peak":          "%s  máx. %.0f° a las %s",
+
+		"phase.climb":    "Ascenso",
+		"phase.cruise":   "Crucero",
+		"phase.descent":  "Descenso",
+		"phase.approach": "Aproximación",
+	},
+}
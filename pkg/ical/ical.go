@@ -0,0 +1,137 @@
+// Package ical writes iCalendar (RFC 5545) feeds so scheduled observations
+// and predicted passes can be subscribed to from a phone calendar.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line allowed before folding
+const maxLineOctets = 75
+
+// timeFormat is the UTC date-time form used for all timestamps
+const timeFormat = "20060102T150405Z"
+
+// Event is one VEVENT.
+type Event struct {
+	// UID identifies the event across refreshes so calendars update it in place
+	UID string
+
+	Start time.Time
+	End   time.Time
+
+	Summary     string
+	Description string
+	Location    string
+
+	// Categories are shown by some calendars as tags (e.g. "Pass", "Scheduled")
+	Categories []string
+}
+
+// Calendar is a VCALENDAR feed.
+type Calendar struct {
+	// Name is the calendar's display name
+	Name string
+
+	// RefreshInterval asks subscribing clients to poll this often (0 = client default)
+	RefreshInterval time.Duration
+
+	Events []Event
+}
+
+// Write writes the calendar in iCalendar format. stamp is the DTSTAMP of
+// every event (normally the time the feed is generated).
+func (c Calendar) Write(w io.Writer, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//ads-bscope//Observation Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		duration := formatDuration(c.RefreshInterval)
+		writeFolded(bw, "REFRESH-INTERVAL;VALUE=DURATION:"+duration)
+		line("X-PUBLISHED-TTL", duration)
+	}
+
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", stamp.UTC().Format(timeFormat))
+		line("DTSTART", e.Start.UTC().Format(timeFormat))
+		line("DTEND", e.End.UTC().Format(timeFormat))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", escape(e.Location))
+		}
+		if len(e.Categories) > 0 {
+			escaped := make([]string, len(e.Categories))
+			for i, category := range e.Categories {
+				escaped[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(escaped, ","))
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded writes a content line terminated by CRLF, folding it into
+// continuation lines (starting with a space) of at most 75 octets without
+// splitting UTF-8 characters.
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1 // Continuation lines start with a space
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+// formatDuration formats a whole number of minutes as an iCalendar duration.
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes%60 == 0 {
+		return "PT" + strconv.Itoa(minutes/60) + "H"
+	}
+	return "PT" + strconv.Itoa(minutes) + "M"
+}
@@ -0,0 +1,79 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestCalendarWrite tests the generated feed.
+func TestCalendarWrite(t *testing.T) {
+	stamp := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 6, 1, 21, 3, 0, 0, time.FixedZone("EDT", -4*3600))
+
+	cal := Calendar{
+		Name:            "ads-bscope",
+		RefreshInterval: 15 * time.Minute,
+		Events: []Event{{
+			UID:         "pass-A1B2C3@ads-bscope",
+			Start:       start,
+			End:         start.Add(3 * time.Minute),
+			Summary:     "UAL123 pass, peak 67°",
+			Description: "Alt 35000 ft\nAz 120°; heading east",
+			Categories:  []string{"Pass"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf, stamp); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT15M\r\n",
+		"DTSTAMP:20250601T120000Z\r\n",
+		"DTSTART:20250602T010300Z\r\n",
+		"DTEND:20250602T010600Z\r\n",
+		"SUMMARY:UAL123 pass\\, peak 67°\r\n",
+		"DESCRIPTION:Alt 35000 ft\\nAz 120°\\; heading east\r\n",
+		"CATEGORIES:Pass\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestWriteFolded tests folding of long content lines.
+func TestWriteFolded(t *testing.T) {
+	cal := Calendar{Events: []Event{{
+		UID:     "long",
+		Summary: strings.Repeat("é", 60), // 120 octets
+	}}}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf, time.Now()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Line is %d octets, want at most %d: %q", len(line), maxLineOctets, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("Line splits a UTF-8 character: %q", line)
+		}
+	}
+
+	// Unfolding restores the original value
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("é", 60)+"\r\n") {
+		t.Error("Unfolded output does not contain the original summary")
+	}
+}
@@ -0,0 +1,169 @@
+package navdata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CenterTypes are the airspace types of air traffic control centers' areas:
+// US ARTCCs from NASR, and FIRs and UIRs elsewhere from OpenAIP.
+var CenterTypes = []string{"artcc", "fir", "uir"}
+
+// Contains reports whether a point is inside the airspace's outline,
+// ignoring its vertical limits.
+func (a Airspace) Contains(lat, lon float64) bool {
+	minLat, minLon, maxLat, maxLon := a.Bounds()
+	if len(a.Boundary) < 3 || lat < minLat || lat > maxLat || lon < minLon || lon > maxLon {
+		return false
+	}
+
+	// Ray casting: count the edges crossed by a ray east of the point
+	inside := false
+	for i, j := 0, len(a.Boundary)-1; i < len(a.Boundary); j, i = i, i+1 {
+		pi, pj := a.Boundary[i], a.Boundary[j]
+		if (pi[0] > lat) != (pj[0] > lat) &&
+			lon < (pj[1]-pi[1])*(lat-pi[0])/(pj[0]-pi[0])+pi[1] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ContainsAltitude reports whether an altitude in feet is within the
+// airspace's vertical limits. GND is taken as sea level and flight levels as
+// altitudes, which is close enough to tell apart the low and high strata of
+// a center. An upper limit not above the lower one (e.g. missing from
+// the source) is taken as unlimited.
+func (a Airspace) ContainsAltitude(altitudeFt float64) bool {
+	if altitudeFt < a.Lower.Feet {
+		return false
+	}
+	return a.Upper.Feet <= a.Lower.Feet || altitudeFt < a.Upper.Feet
+}
+
+// Centers are the areas of air traffic control centers (ARTCCs and FIRs).
+// A center may have several areas, e.g. a low and a high stratum; they
+// share its name.
+type Centers []Airspace
+
+// Locate returns the name of the center whose area contains a position, or
+// "" if none does.
+func (c Centers) Locate(lat, lon, altitudeFt float64) string {
+	for _, a := range c {
+		if a.ContainsAltitude(altitudeFt) && a.Contains(lat, lon) {
+			return a.Name
+		}
+	}
+	return ""
+}
+
+// NASR ARTCC boundary altitude structures and the limits stored for them.
+// Other structures (FIR-only, oceanic control areas) are not imported.
+var arbLimits = map[string][2]Limit{
+	"LOW":  {{Feet: 0, Datum: "GND"}, {Feet: 18000, Datum: "STD"}},
+	"HIGH": {{Feet: 18000, Datum: "STD"}, {Feet: 60000, Datum: "STD"}},
+}
+
+// ReadARTCCBoundaries reads US ARTCC boundaries from NASR's ARB.txt. Each
+// center's low and high strata are separate airspaces with source IDs
+// like "ZAB/HIGH", named after the center (e.g. "ALBUQUERQUE ARTCC").
+//
+// ARB.txt is fixed width: the record identifier (ARTCC, altitude code and
+// point designator) in columns 1-12, the center's name in 13-52, the
+// altitude structure in 53-62, latitude and longitude in 63-76 and 77-90,
+// and the point's sequence number in 391-396. Points are listed in order
+// around each boundary.
+func ReadARTCCBoundaries(r io.Reader) ([]Airspace, error) {
+	var airspaces []Airspace
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 90 {
+			continue
+		}
+
+		structure := strings.TrimSpace(line[52:62])
+		limits, ok := arbLimits[structure]
+		if !ok {
+			continue
+		}
+		lat, err := ParseDMS(line[62:76])
+		if err != nil {
+			continue
+		}
+		lon, err := ParseDMS(line[76:90])
+		if err != nil {
+			continue
+		}
+
+		artcc := strings.TrimSpace(line[0:3])
+		id := artcc + "/" + structure
+		i, seen := index[id]
+		if !seen {
+			i = len(airspaces)
+			index[id] = i
+			airspaces = append(airspaces, Airspace{
+				SourceID: id,
+				Name:     strings.TrimSpace(line[12:52]),
+				Type:     "artcc",
+				Country:  "US",
+				Lower:    limits[0],
+				Upper:    limits[1],
+			})
+		}
+		airspaces[i].Boundary = append(airspaces[i].Boundary, [2]float64{lat, lon})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ARTCC boundaries: %w", err)
+	}
+	return airspaces, nil
+}
+
+// ParseDMS parses a NASR latitude or longitude, DD-MM-SS.SSSH where H is N,
+// S, E or W, to decimal degrees.
+func ParseDMS(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 11 {
+		return 0, fmt.Errorf("invalid lat/lon format: %s", s)
+	}
+
+	// Extract hemisphere
+	hemisphere := s[len(s)-1:]
+	s = s[:len(s)-1]
+
+	// Parse DD-MM-SS.SSS
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid lat/lon parts: %s", s)
+	}
+
+	degrees, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	// Convert to decimal degrees
+	decimal := degrees + (minutes / 60.0) + (seconds / 3600.0)
+
+	// Apply hemisphere
+	if hemisphere == "S" || hemisphere == "W" {
+		decimal = -decimal
+	}
+
+	return decimal, nil
+}
@@ -0,0 +1,100 @@
+package navdata
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAirspaceContains tests point-in-outline checks, including a concave
+// outline.
+func TestAirspaceContains(t *testing.T) {
+	// An L shape: the square 0-2 by 0-2 without its north-east quarter
+	a := Airspace{Boundary: [][2]float64{{0, 0}, {0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}}}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     bool
+	}{
+		{"South-west quarter", 0.5, 0.5, true},
+		{"South-east quarter", 0.5, 1.5, true},
+		{"North-west quarter", 1.5, 0.5, true},
+		{"Missing quarter", 1.5, 1.5, false},
+		{"Outside bounds", 3, 3, false},
+	}
+
+	for _, tt := range tests {
+		if got := a.Contains(tt.lat, tt.lon); got != tt.want {
+			t.Errorf("%s: Contains(%v, %v) = %v, want %v", tt.name, tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
+
+// TestCentersLocate tests picking a center's stratum by altitude.
+func TestCentersLocate(t *testing.T) {
+	square := [][2]float64{{30, -100}, {30, -90}, {40, -90}, {40, -100}}
+	centers := Centers{
+		{Name: "LOW CENTER", Lower: Limit{Feet: 0}, Upper: Limit{Feet: 18000}, Boundary: square},
+		{Name: "HIGH CENTER", Lower: Limit{Feet: 18000}, Upper: Limit{Feet: 60000}, Boundary: square},
+		{Name: "FIR", Lower: Limit{Feet: 0}, Boundary: [][2]float64{{50, 0}, {50, 10}, {60, 10}, {60, 0}}},
+	}
+
+	tests := []struct {
+		lat, lon, altitude float64
+		want               string
+	}{
+		{35, -95, 5000, "LOW CENTER"},
+		{35, -95, 35000, "HIGH CENTER"},
+		{55, 5, 45000, "FIR"}, // No upper limit
+		{0, 0, 5000, ""},
+	}
+
+	for _, tt := range tests {
+		if got := centers.Locate(tt.lat, tt.lon, tt.altitude); got != tt.want {
+			t.Errorf("Locate(%v, %v, %v) = %q, want %q", tt.lat, tt.lon, tt.altitude, got, tt.want)
+		}
+	}
+}
+
+// arbLine formats an ARB.txt record.
+func arbLine(id, name, structure, lat, lon string) string {
+	return id + strings.Repeat(" ", 12-len(id)) +
+		name + strings.Repeat(" ", 40-len(name)) +
+		structure + strings.Repeat(" ", 10-len(structure)) +
+		lat + strings.Repeat(" ", 14-len(lat)) +
+		lon + strings.Repeat(" ", 14-len(lon)) +
+		strings.Repeat(" ", 300)
+}
+
+// TestReadARTCCBoundaries tests reading NASR ARTCC boundaries.
+func TestReadARTCCBoundaries(t *testing.T) {
+	data := strings.Join([]string{
+		arbLine("ZAB*H*57760", "ALBUQUERQUE ARTCC", "HIGH", "36-02-00.0N", "114-32-00.0W"),
+		arbLine("ZAB*H*57761", "ALBUQUERQUE ARTCC", "HIGH", "36-02-00.0N", "103-00-00.0W"),
+		arbLine("ZAB*H*57762", "ALBUQUERQUE ARTCC", "HIGH", "31-00-00.0N", "103-00-00.0W"),
+		arbLine("ZAB*L*57763", "ALBUQUERQUE ARTCC", "LOW", "36-00-00.0N", "114-00-00.0W"),
+		arbLine("ZAK*F*00001", "OAKLAND OCEANIC ARTCC", "FIR ONLY", "30-00-00.0N", "140-00-00.0W"),
+	}, "\n")
+
+	airspaces, err := ReadARTCCBoundaries(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadARTCCBoundaries(): %v", err)
+	}
+	if len(airspaces) != 2 {
+		t.Fatalf("Expected 2 airspaces (FIR-only skipped), got %d", len(airspaces))
+	}
+
+	high := airspaces[0]
+	if high.SourceID != "ZAB/HIGH" || high.Name != "ALBUQUERQUE ARTCC" || high.Type != "artcc" {
+		t.Errorf("Unexpected high stratum: %+v", high)
+	}
+	if high.Lower.String() != "FL180" || len(high.Boundary) != 3 {
+		t.Errorf("Expected FL180 lower limit and 3 points, got %s and %d", high.Lower, len(high.Boundary))
+	}
+	if high.Boundary[0][0] != 36+2.0/60 || high.Boundary[0][1] != -(114+32.0/60) {
+		t.Errorf("Unexpected first point %v", high.Boundary[0])
+	}
+	if airspaces[1].SourceID != "ZAB/LOW" || airspaces[1].Lower.String() != "GND" {
+		t.Errorf("Unexpected low stratum: %+v", airspaces[1])
+	}
+}
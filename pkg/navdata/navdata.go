@@ -0,0 +1,156 @@
+// Package navdata reads worldwide navigation data for the regions the FAA's
+// NASR data doesn't cover: airports and navaids from OurAirports
+// (https://ourairports.com/data/) and navaids, reporting points and
+// airspaces from OpenAIP (https://www.openaip.net) exports.
+//
+// Waypoints are keyed by ICAO region, the first letters of the ICAO
+// location indicators in a country (e.g. "ED" for Germany, "EG" for the
+// UK), matching the region codes NASR uses for US fixes.
+package navdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Waypoint is an airport, navaid or reporting point.
+type Waypoint struct {
+	Identifier string
+	Name       string
+	Latitude   float64
+	Longitude  float64
+	Type       string // airport, vor, ndb, tacan, dme, reporting
+
+	// Region is the ICAO region; Country the ISO 3166 country code
+	Region  string
+	Country string
+}
+
+// Limit is an airspace's lower or upper vertical limit.
+type Limit struct {
+	// Feet is the limit in feet above the datum; a flight level is given
+	// in feet (FL95 is 9500) with the "STD" datum
+	Feet float64
+
+	// Datum is "GND", "MSL" or "STD" (standard pressure)
+	Datum string
+}
+
+// String formats the limit as pilots read it, e.g. "GND", "2500 ft MSL" or
+// "FL95".
+func (l Limit) String() string {
+	switch {
+	case l.Datum == "GND" && l.Feet == 0:
+		return "GND"
+	case l.Datum == "STD":
+		return fmt.Sprintf("FL%.0f", l.Feet/100)
+	}
+	return fmt.Sprintf("%.0f ft %s", l.Feet, l.Datum)
+}
+
+// Airspace is a controlled, restricted or other airspace.
+type Airspace struct {
+	// SourceID identifies the airspace in its source dataset
+	SourceID string
+
+	Name    string
+	Type    string // ctr, tma, cta, restricted, danger, prohibited, ...
+	Class   string // ICAO class "A" to "G", empty if unclassified
+	Country string
+
+	Lower Limit
+	Upper Limit
+
+	// Boundary is the outline as latitude, longitude pairs
+	Boundary [][2]float64
+}
+
+// Bounds returns the boundary's bounding box.
+func (a Airspace) Bounds() (minLat, minLon, maxLat, maxLon float64) {
+	for i, p := range a.Boundary {
+		if i == 0 {
+			minLat, maxLat, minLon, maxLon = p[0], p[0], p[1], p[1]
+			continue
+		}
+		minLat, maxLat = min(minLat, p[0]), max(maxLat, p[0])
+		minLon, maxLon = min(minLon, p[1]), max(maxLon, p[1])
+	}
+	return minLat, minLon, maxLat, maxLon
+}
+
+// RecordHash returns the hash stored with an imported record: the fields as
+// written to the database, so a record hashes the same in every data cycle
+// until one of them changes.
+func RecordHash(fields ...interface{}) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprint(f)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RegionFromICAO returns the ICAO region of a four-letter ICAO location
+// indicator: its first two letters, or "K" for the contiguous US, whose
+// airports don't carry NASR's region in their codes. Returns "" if code
+// isn't an ICAO location indicator.
+func RegionFromICAO(code string) string {
+	if len(code) != 4 {
+		return ""
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return ""
+		}
+	}
+	if code[0] == 'K' {
+		return "K"
+	}
+	return code[:2]
+}
+
+// CountryRegions maps each country to the ICAO region most of its airports
+// are in, for keying navaids and reporting points, which don't have ICAO
+// codes of their own.
+func CountryRegions(airports []Waypoint) map[string]string {
+	counts := make(map[string]map[string]int)
+	for _, a := range airports {
+		if a.Region == "" || a.Country == "" {
+			continue
+		}
+		if counts[a.Country] == nil {
+			counts[a.Country] = make(map[string]int)
+		}
+		counts[a.Country][a.Region]++
+	}
+
+	regions := make(map[string]string, len(counts))
+	for country, byRegion := range counts {
+		best := ""
+		for region, n := range byRegion {
+			// Ties go to the alphabetically first region, for stable keys
+			if n > byRegion[best] || (n == byRegion[best] && region < best) {
+				best = region
+			}
+		}
+		regions[country] = best
+	}
+	return regions
+}
+
+// AssignRegions sets the region of waypoints without one from their
+// country, falling back to the country code itself.
+func AssignRegions(waypoints []Waypoint, regions map[string]string) {
+	for i := range waypoints {
+		if waypoints[i].Region != "" {
+			continue
+		}
+		if region, ok := regions[waypoints[i].Country]; ok {
+			waypoints[i].Region = region
+		} else {
+			waypoints[i].Region = waypoints[i].Country
+		}
+	}
+}
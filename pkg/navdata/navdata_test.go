@@ -0,0 +1,86 @@
+package navdata
+
+import "testing"
+
+// TestRegionFromICAO tests deriving ICAO regions from location indicators.
+func TestRegionFromICAO(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"EDDF", "ED"},
+		{"EGLL", "EG"},
+		{"YSSY", "YS"},
+		{"KCLT", "K"},
+		{"DE-0001", ""},
+		{"edd", ""},
+		{"ED12", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := RegionFromICAO(tt.code); got != tt.want {
+			t.Errorf("RegionFromICAO(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+// TestAssignRegions tests keying waypoints by their country's region.
+func TestAssignRegions(t *testing.T) {
+	airports := []Waypoint{
+		{Identifier: "EDDF", Region: "ED", Country: "DE"},
+		{Identifier: "EDDM", Region: "ED", Country: "DE"},
+		{Identifier: "ETNL", Region: "ET", Country: "DE"},
+		{Identifier: "LFPG", Region: "LF", Country: "FR"},
+	}
+	regions := CountryRegions(airports)
+	if regions["DE"] != "ED" || regions["FR"] != "LF" {
+		t.Fatalf("Expected DE→ED and FR→LF, got %v", regions)
+	}
+
+	navaids := []Waypoint{
+		{Identifier: "FFM", Country: "DE"},
+		{Identifier: "TRA", Region: "LS", Country: "CH"},
+		{Identifier: "LUX", Country: "LU"},
+	}
+	AssignRegions(navaids, regions)
+	for i, want := range []string{"ED", "LS", "LU"} {
+		if navaids[i].Region != want {
+			t.Errorf("Expected %s in region %s, got %q", navaids[i].Identifier, want, navaids[i].Region)
+		}
+	}
+}
+
+// TestLimitString tests formatting airspace limits.
+func TestLimitString(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  string
+	}{
+		{Limit{Feet: 0, Datum: "GND"}, "GND"},
+		{Limit{Feet: 1500, Datum: "GND"}, "1500 ft GND"},
+		{Limit{Feet: 2500, Datum: "MSL"}, "2500 ft MSL"},
+		{Limit{Feet: 9500, Datum: "STD"}, "FL95"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.limit.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+// TestRecordHash tests that hashes change with any field.
+func TestRecordHash(t *testing.T) {
+	a := RecordHash("FFM", 50.05, 8.64, "vor")
+	if a != RecordHash("FFM", 50.05, 8.64, "vor") {
+		t.Error("Expected the same fields to hash the same")
+	}
+	if a == RecordHash("FFM", 50.05, 8.65, "vor") {
+		t.Error("Expected a changed field to change the hash")
+	}
+	// Fields are separated, so moving text between them changes the hash
+	if RecordHash("AB", "C") == RecordHash("A", "BC") {
+		t.Error("Expected field boundaries to be part of the hash")
+	}
+}
@@ -0,0 +1,269 @@
+package navdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OpenAIP's numeric codes, from its API documentation
+var (
+	// openAIPNavaidTypes maps navaid type codes to waypoint types
+	openAIPNavaidTypes = map[int]string{
+		0: "dme",
+		1: "tacan",
+		2: "ndb",
+		3: "vor",
+		4: "vor", // VOR-DME
+		5: "vor", // VORTAC
+		6: "vor", // DVOR
+		7: "vor", // DVOR-DME
+		8: "vor", // DVORTAC
+	}
+
+	// openAIPAirspaceTypes maps airspace type codes to airspace types
+	openAIPAirspaceTypes = map[int]string{
+		0:  "other",
+		1:  "restricted",
+		2:  "danger",
+		3:  "prohibited",
+		4:  "ctr",
+		5:  "tmz",
+		6:  "rmz",
+		7:  "tma",
+		8:  "tra",
+		9:  "tsa",
+		10: "fir",
+		11: "uir",
+		12: "adiz",
+		13: "atz",
+		14: "matz",
+		15: "airway",
+	}
+
+	// openAIPClasses maps ICAO class codes to classes (8 is unclassified)
+	openAIPClasses = map[int]string{0: "A", 1: "B", 2: "C", 3: "D", 4: "E", 5: "F", 6: "G"}
+
+	// openAIPDatums maps limit reference datum codes to datums
+	openAIPDatums = map[int]string{0: "GND", 1: "MSL", 2: "STD"}
+)
+
+// OpenAIP limit unit codes
+const (
+	openAIPMeters      = 0
+	openAIPFeet        = 1
+	openAIPFlightLevel = 6
+)
+
+// openAIPItem is a navaid, reporting point or airspace in an OpenAIP
+// export. Fields not used by a kind of item are left zero.
+type openAIPItem struct {
+	ID         string          `json:"_id"`
+	Name       string          `json:"name"`
+	Identifier string          `json:"identifier"`
+	Type       int             `json:"type"`
+	ICAOClass  *int            `json:"icaoClass"`
+	Country    string          `json:"country"`
+	Geometry   openAIPGeometry `json:"geometry"`
+	LowerLimit *openAIPLimit   `json:"lowerLimit"`
+	UpperLimit *openAIPLimit   `json:"upperLimit"`
+}
+
+// openAIPGeometry is a GeoJSON point or polygon, in longitude, latitude
+// order.
+type openAIPGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// point returns a point geometry's latitude and longitude.
+func (g openAIPGeometry) point() (float64, float64, bool) {
+	var c []float64
+	if g.Type != "Point" || json.Unmarshal(g.Coordinates, &c) != nil || len(c) < 2 {
+		return 0, 0, false
+	}
+	return c[1], c[0], true
+}
+
+// outline returns a polygon geometry's outer ring as latitude, longitude
+// pairs.
+func (g openAIPGeometry) outline() ([][2]float64, bool) {
+	var rings [][][]float64
+	if g.Type != "Polygon" || json.Unmarshal(g.Coordinates, &rings) != nil || len(rings) == 0 {
+		return nil, false
+	}
+	var outline [][2]float64
+	for _, c := range rings[0] {
+		if len(c) >= 2 {
+			outline = append(outline, [2]float64{c[1], c[0]})
+		}
+	}
+	return outline, len(outline) >= 3
+}
+
+// openAIPLimit is an airspace's vertical limit.
+type openAIPLimit struct {
+	Value          float64 `json:"value"`
+	Unit           int     `json:"unit"`
+	ReferenceDatum int     `json:"referenceDatum"`
+}
+
+// limit converts the limit to feet.
+func (l *openAIPLimit) limit() Limit {
+	if l == nil {
+		return Limit{Datum: "GND"}
+	}
+	feet := l.Value
+	switch l.Unit {
+	case openAIPMeters:
+		feet = l.Value / 0.3048
+	case openAIPFlightLevel:
+		feet = l.Value * 100
+	}
+	datum, ok := openAIPDatums[l.ReferenceDatum]
+	if !ok {
+		datum = "MSL"
+	}
+	return Limit{Feet: feet, Datum: datum}
+}
+
+// readOpenAIP decodes an OpenAIP export: a JSON array of items, an API
+// response page with an "items" array, or a GeoJSON feature collection
+// whose features' properties are items.
+func readOpenAIP(r io.Reader) ([]openAIPItem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+
+	var items []openAIPItem
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("invalid OpenAIP export: %w", err)
+		}
+		return items, nil
+	}
+
+	var doc struct {
+		Items    []openAIPItem `json:"items"`
+		Features []struct {
+			ID         interface{}     `json:"id"`
+			Properties json.RawMessage `json:"properties"`
+			Geometry   openAIPGeometry `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAIP export: %w", err)
+	}
+	items = doc.Items
+	for _, f := range doc.Features {
+		var item openAIPItem
+		if err := json.Unmarshal(f.Properties, &item); err != nil {
+			return nil, fmt.Errorf("invalid OpenAIP feature: %w", err)
+		}
+		item.Geometry = f.Geometry
+		if item.ID == "" && f.ID != nil {
+			item.ID = fmt.Sprint(f.ID)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// ReadOpenAIPNavaids reads navaids from an OpenAIP navaid export. They are
+// left without a region for AssignRegions.
+func ReadOpenAIPNavaids(r io.Reader) ([]Waypoint, error) {
+	items, err := readOpenAIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var navaids []Waypoint
+	for _, item := range items {
+		wpType, known := openAIPNavaidTypes[item.Type]
+		lat, lon, ok := item.Geometry.point()
+		if !known || !ok || item.Identifier == "" {
+			continue
+		}
+		navaids = append(navaids, Waypoint{
+			Identifier: strings.ToUpper(item.Identifier),
+			Name:       item.Name,
+			Latitude:   lat,
+			Longitude:  lon,
+			Type:       wpType,
+			Country:    item.Country,
+		})
+	}
+	return navaids, nil
+}
+
+// ReadOpenAIPReportingPoints reads VFR reporting points from an OpenAIP
+// export. Reporting points have no identifiers of their own, so they are
+// identified by name (e.g. "NOVEMBER 1"), and left without a region for
+// AssignRegions.
+func ReadOpenAIPReportingPoints(r io.Reader) ([]Waypoint, error) {
+	items, err := readOpenAIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []Waypoint
+	for _, item := range items {
+		lat, lon, ok := item.Geometry.point()
+		ident := item.Identifier
+		if ident == "" {
+			ident = item.Name
+		}
+		if !ok || ident == "" {
+			continue
+		}
+		points = append(points, Waypoint{
+			Identifier: strings.ToUpper(ident),
+			Name:       item.Name,
+			Latitude:   lat,
+			Longitude:  lon,
+			Type:       "reporting",
+			Country:    item.Country,
+		})
+	}
+	return points, nil
+}
+
+// ReadOpenAIPAirspaces reads airspaces from an OpenAIP airspace export.
+// Airspaces without an ID or a polygon outline are skipped.
+func ReadOpenAIPAirspaces(r io.Reader) ([]Airspace, error) {
+	items, err := readOpenAIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var airspaces []Airspace
+	for _, item := range items {
+		outline, ok := item.Geometry.outline()
+		if !ok || item.ID == "" {
+			continue
+		}
+		asType, known := openAIPAirspaceTypes[item.Type]
+		if !known {
+			asType = "other"
+		}
+		class := ""
+		if item.ICAOClass != nil {
+			class = openAIPClasses[*item.ICAOClass]
+		}
+		airspaces = append(airspaces, Airspace{
+			SourceID: item.ID,
+			Name:     item.Name,
+			Type:     asType,
+			Class:    class,
+			Country:  item.Country,
+			Lower:    item.LowerLimit.limit(),
+			Upper:    item.UpperLimit.limit(),
+			Boundary: outline,
+		})
+	}
+	return airspaces, nil
+}
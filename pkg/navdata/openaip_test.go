@@ -0,0 +1,112 @@
+package navdata
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const openAIPNavaids = `[
+  {"_id": "a1", "name": "FRANKFURT", "identifier": "ffm", "type": 4, "country": "DE",
+   "geometry": {"type": "Point", "coordinates": [8.637, 50.053]}},
+  {"_id": "a2", "name": "NO IDENT", "identifier": "", "type": 2, "country": "DE",
+   "geometry": {"type": "Point", "coordinates": [8.0, 50.0]}},
+  {"_id": "a3", "name": "METZ", "identifier": "MTZ", "type": 2, "country": "FR",
+   "geometry": {"type": "Point", "coordinates": [6.1, 49.0]}}
+]`
+
+const openAIPReportingPoints = `{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "id": "r1",
+   "properties": {"name": "November 1", "compulsory": true, "country": "DE"},
+   "geometry": {"type": "Point", "coordinates": [8.5, 50.1]}}
+]}`
+
+const openAIPAirspaces = `{"items": [
+  {"_id": "s1", "name": "FRANKFURT CTR", "type": 4, "icaoClass": 3, "country": "DE",
+   "lowerLimit": {"value": 0, "unit": 1, "referenceDatum": 0},
+   "upperLimit": {"value": 1500, "unit": 1, "referenceDatum": 1},
+   "geometry": {"type": "Polygon", "coordinates": [[[8.4, 49.9], [8.7, 49.9], [8.7, 50.2], [8.4, 50.2], [8.4, 49.9]]]}},
+  {"_id": "s2", "name": "ED-R 1", "type": 1, "icaoClass": 8, "country": "DE",
+   "lowerLimit": {"value": 300, "unit": 0, "referenceDatum": 0},
+   "upperLimit": {"value": 95, "unit": 6, "referenceDatum": 2},
+   "geometry": {"type": "Polygon", "coordinates": [[[9.0, 50.0], [9.1, 50.0], [9.1, 50.1], [9.0, 49.9]]]}},
+  {"_id": "s3", "name": "LINE", "type": 0, "country": "DE",
+   "geometry": {"type": "LineString", "coordinates": [[9.0, 50.0], [9.1, 50.0]]}}
+]}`
+
+// TestReadOpenAIPNavaids tests reading a JSON array navaid export.
+func TestReadOpenAIPNavaids(t *testing.T) {
+	navaids, err := ReadOpenAIPNavaids(strings.NewReader(openAIPNavaids))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(navaids) != 2 {
+		t.Fatalf("Expected 2 navaids (none without an identifier), got %d", len(navaids))
+	}
+	ffm := navaids[0]
+	if ffm.Identifier != "FFM" || ffm.Type != "vor" || ffm.Country != "DE" {
+		t.Errorf("Expected VOR FFM in DE, got %+v", ffm)
+	}
+	if ffm.Latitude != 50.053 || ffm.Longitude != 8.637 {
+		t.Errorf("Expected latitude before longitude, got %f, %f", ffm.Latitude, ffm.Longitude)
+	}
+	if navaids[1].Type != "ndb" {
+		t.Errorf("Expected NDB MTZ, got %+v", navaids[1])
+	}
+}
+
+// TestReadOpenAIPReportingPoints tests reading a GeoJSON export.
+func TestReadOpenAIPReportingPoints(t *testing.T) {
+	points, err := ReadOpenAIPReportingPoints(strings.NewReader(openAIPReportingPoints))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("Expected 1 reporting point, got %d", len(points))
+	}
+	p := points[0]
+	if p.Identifier != "NOVEMBER 1" || p.Type != "reporting" || p.Latitude != 50.1 {
+		t.Errorf("Expected reporting point NOVEMBER 1, got %+v", p)
+	}
+}
+
+// TestReadOpenAIPAirspaces tests reading an API page of airspaces.
+func TestReadOpenAIPAirspaces(t *testing.T) {
+	airspaces, err := ReadOpenAIPAirspaces(strings.NewReader(openAIPAirspaces))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(airspaces) != 2 {
+		t.Fatalf("Expected 2 airspaces (no line geometry), got %d", len(airspaces))
+	}
+
+	ctr := airspaces[0]
+	if ctr.SourceID != "s1" || ctr.Type != "ctr" || ctr.Class != "D" {
+		t.Errorf("Expected class D CTR s1, got %+v", ctr)
+	}
+	if ctr.Lower.String() != "GND" || ctr.Upper.String() != "1500 ft MSL" {
+		t.Errorf("Expected GND to 1500 ft MSL, got %s to %s", ctr.Lower, ctr.Upper)
+	}
+	minLat, minLon, maxLat, maxLon := ctr.Bounds()
+	if minLat != 49.9 || minLon != 8.4 || maxLat != 50.2 || maxLon != 8.7 {
+		t.Errorf("Expected bounds 49.9,8.4 to 50.2,8.7, got %f,%f to %f,%f", minLat, minLon, maxLat, maxLon)
+	}
+
+	restricted := airspaces[1]
+	if restricted.Type != "restricted" || restricted.Class != "" {
+		t.Errorf("Expected unclassified restricted area, got %+v", restricted)
+	}
+	if math.Abs(restricted.Lower.Feet-984.25) > 0.01 || restricted.Lower.Datum != "GND" {
+		t.Errorf("Expected 300 m as 984 ft GND, got %s", restricted.Lower)
+	}
+	if restricted.Upper.String() != "FL95" {
+		t.Errorf("Expected FL95, got %s", restricted.Upper)
+	}
+}
+
+// TestReadOpenAIPInvalid tests rejecting files that aren't JSON.
+func TestReadOpenAIPInvalid(t *testing.T) {
+	if _, err := ReadOpenAIPNavaids(strings.NewReader("not json")); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
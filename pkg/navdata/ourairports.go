@@ -0,0 +1,188 @@
+package navdata
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ourAirportsTypes are the OurAirports airport types imported; heliports,
+// balloonports and closed airports are left out.
+var ourAirportsTypes = map[string]bool{
+	"small_airport":  true,
+	"medium_airport": true,
+	"large_airport":  true,
+	"seaplane_base":  true,
+}
+
+// ourAirportsNavaidTypes maps OurAirports navaid types to waypoint types.
+var ourAirportsNavaidTypes = map[string]string{
+	"VOR":     "vor",
+	"VOR-DME": "vor",
+	"VORTAC":  "vor",
+	"TACAN":   "tacan",
+	"NDB":     "ndb",
+	"NDB-DME": "ndb",
+	"DME":     "dme",
+}
+
+// csvTable reads a CSV file with a header row, looking columns up by name
+// so that columns OurAirports adds don't break the reader.
+type csvTable struct {
+	r       *csv.Reader
+	columns map[string]int
+	record  []string
+}
+
+// newCSVTable reads the header and checks the required columns are present.
+func newCSVTable(r io.Reader, required ...string) (*csvTable, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	t := &csvTable{r: cr, columns: make(map[string]int, len(header))}
+	for i, name := range header {
+		t.columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range required {
+		if _, ok := t.columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+	return t, nil
+}
+
+// next reads the next row, returning false at the end of the file.
+func (t *csvTable) next() (bool, error) {
+	record, err := t.r.Read()
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	t.record = record
+	return true, nil
+}
+
+// get returns a column of the current row, or "" if the row is short or
+// the file has no such column.
+func (t *csvTable) get(name string) string {
+	i, ok := t.columns[name]
+	if !ok || i >= len(t.record) {
+		return ""
+	}
+	return strings.TrimSpace(t.record[i])
+}
+
+// latLon parses the current row's coordinates.
+func (t *csvTable) latLon() (float64, float64, bool) {
+	lat, err := strconv.ParseFloat(t.get("latitude_deg"), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(t.get("longitude_deg"), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// ReadOurAirports reads OurAirports' airports.csv. Airports are identified
+// by their ICAO code where they have one and by their OurAirports ident
+// otherwise, and keyed by the ICAO region of their code. Rows that can't be
+// parsed are skipped.
+func ReadOurAirports(r io.Reader) ([]Waypoint, error) {
+	t, err := newCSVTable(r, "ident", "type", "name", "latitude_deg", "longitude_deg", "iso_country")
+	if err != nil {
+		return nil, fmt.Errorf("airports.csv: %w", err)
+	}
+
+	var airports []Waypoint
+	for {
+		ok, err := t.next()
+		if err != nil {
+			return nil, fmt.Errorf("airports.csv: %w", err)
+		}
+		if !ok {
+			return airports, nil
+		}
+
+		if !ourAirportsTypes[t.get("type")] {
+			continue
+		}
+		lat, lon, ok := t.latLon()
+		if !ok {
+			continue
+		}
+
+		// icao_code is a newer column; gps_code usually holds the ICAO
+		// code in older files
+		ident := t.get("ident")
+		region := ""
+		for _, code := range []string{t.get("icao_code"), t.get("gps_code"), ident} {
+			if region = RegionFromICAO(code); region != "" {
+				ident = code
+				break
+			}
+		}
+
+		airports = append(airports, Waypoint{
+			Identifier: ident,
+			Name:       t.get("name"),
+			Latitude:   lat,
+			Longitude:  lon,
+			Type:       "airport",
+			Region:     region,
+			Country:    t.get("iso_country"),
+		})
+	}
+}
+
+// ReadOurAirportsNavaids reads OurAirports' navaids.csv. A navaid associated
+// with an airport is keyed by the airport's ICAO region; others are left
+// without a region for AssignRegions. Rows that can't be parsed are
+// skipped.
+func ReadOurAirportsNavaids(r io.Reader) ([]Waypoint, error) {
+	t, err := newCSVTable(r, "ident", "name", "type", "latitude_deg", "longitude_deg", "iso_country")
+	if err != nil {
+		return nil, fmt.Errorf("navaids.csv: %w", err)
+	}
+
+	var navaids []Waypoint
+	for {
+		ok, err := t.next()
+		if err != nil {
+			return nil, fmt.Errorf("navaids.csv: %w", err)
+		}
+		if !ok {
+			return navaids, nil
+		}
+
+		wpType, known := ourAirportsNavaidTypes[t.get("type")]
+		ident := t.get("ident")
+		if !known || ident == "" {
+			continue
+		}
+		lat, lon, ok := t.latLon()
+		if !ok {
+			continue
+		}
+
+		navaids = append(navaids, Waypoint{
+			Identifier: ident,
+			Name:       t.get("name"),
+			Latitude:   lat,
+			Longitude:  lon,
+			Type:       wpType,
+			Region:     RegionFromICAO(t.get("associated_airport")),
+			Country:    t.get("iso_country"),
+		})
+	}
+}
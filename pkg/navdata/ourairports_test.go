@@ -0,0 +1,83 @@
+package navdata
+
+import (
+	"strings"
+	"testing"
+)
+
+const ourAirportsCSV = `"id","ident","type","name","latitude_deg","longitude_deg","elevation_ft","continent","iso_country","iso_region","municipality","scheduled_service","gps_code","iata_code","local_code"
+2212,"EDDF","large_airport","Frankfurt am Main Airport",50.036249,8.559294,364,"EU","DE","DE-HE","Frankfurt am Main","yes","EDDF","FRA",""
+28432,"DE-0042","small_airport","Segelfluggelände Example",49.5,8.1,500,"EU","DE","DE-RP","","no","","",""
+99999,"DE-0043","heliport","Hospital Heliport",49.6,8.2,300,"EU","DE","DE-RP","","no","","",""
+4567,"X01","seaplane_base","Lake Base",61.2,-149.9,100,"NA","US","US-AK","","no","PALB","",""
+1111,"BAD","small_airport","No Position","","","","EU","FR","FR-U","","no","","",""
+`
+
+const ourAirportsNavaidsCSV = `"id","filename","ident","name","type","frequency_khz","latitude_deg","longitude_deg","elevation_ft","iso_country","dme_frequency_khz","dme_channel","dme_latitude_deg","dme_longitude_deg","dme_elevation_ft","slaved_variation_deg","magnetic_variation_deg","usageType","power","associated_airport"
+85155,"Frankfurt_VOR-DME_DE","FFM","Frankfurt","VOR-DME",114200,50.053,8.637,400,"DE","","","","","","","","BOTH","HIGH","EDDF"
+85200,"Example_NDB_DE","EX","Example","NDB",350,49.9,8.4,300,"DE","","","","","","","","TERMINAL","LOW",""
+85300,"Test_TACAN_DE","TST","Test","TACAN",0,49.8,8.3,300,"DE","","","","","","","","BOTH","HIGH",""
+85400,"Marker","MK","Marker","OM",75000,49.7,8.2,300,"DE","","","","","","","","","",""
+`
+
+// TestReadOurAirports tests reading airports.csv.
+func TestReadOurAirports(t *testing.T) {
+	airports, err := ReadOurAirports(strings.NewReader(ourAirportsCSV))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(airports) != 3 {
+		t.Fatalf("Expected 3 airports (no heliport or unparseable row), got %d: %+v", len(airports), airports)
+	}
+
+	fra := airports[0]
+	if fra.Identifier != "EDDF" || fra.Region != "ED" || fra.Country != "DE" || fra.Type != "airport" {
+		t.Errorf("Expected EDDF in region ED, got %+v", fra)
+	}
+	if fra.Latitude != 50.036249 || fra.Longitude != 8.559294 {
+		t.Errorf("Expected EDDF position, got %f, %f", fra.Latitude, fra.Longitude)
+	}
+
+	// No ICAO code: keeps its ident and gets a region later
+	if airports[1].Identifier != "DE-0042" || airports[1].Region != "" {
+		t.Errorf("Expected DE-0042 without a region, got %+v", airports[1])
+	}
+
+	// ICAO code from gps_code
+	if airports[2].Identifier != "PALB" || airports[2].Region != "PA" {
+		t.Errorf("Expected PALB in region PA, got %+v", airports[2])
+	}
+}
+
+// TestReadOurAirportsMissingColumn tests rejecting files that aren't
+// airports.csv.
+func TestReadOurAirportsMissingColumn(t *testing.T) {
+	if _, err := ReadOurAirports(strings.NewReader("id,ident,name\n1,EDDF,Frankfurt\n")); err == nil {
+		t.Error("Expected an error for a file without the required columns")
+	}
+}
+
+// TestReadOurAirportsNavaids tests reading navaids.csv.
+func TestReadOurAirportsNavaids(t *testing.T) {
+	navaids, err := ReadOurAirportsNavaids(strings.NewReader(ourAirportsNavaidsCSV))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(navaids) != 3 {
+		t.Fatalf("Expected 3 navaids (no marker beacon), got %d", len(navaids))
+	}
+
+	want := []struct {
+		ident, wpType, region string
+	}{
+		{"FFM", "vor", "ED"},
+		{"EX", "ndb", ""},
+		{"TST", "tacan", ""},
+	}
+	for i, w := range want {
+		n := navaids[i]
+		if n.Identifier != w.ident || n.Type != w.wpType || n.Region != w.region || n.Country != "DE" {
+			t.Errorf("Expected %s %s in region %q, got %+v", w.wpType, w.ident, w.region, n)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package offline builds versioned bundles of reference data (waypoints,
+// airports and airlines) that the PWA downloads ahead of time, so the field
+// UI keeps working on a spotty cellular connection.
+//
+// A bundle's version is a hash of its contents, so unchanged data always has
+// the same version and clients can ask for only what changed since the
+// version they hold.
+package offline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Waypoint is a navigation fix, navaid or airport in a bundle.
+type Waypoint struct {
+	Identifier string  `json:"identifier"`
+	Name       string  `json:"name,omitempty"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Type       string  `json:"type"`
+	Region     string  `json:"region,omitempty"`
+}
+
+// Key identifies a waypoint across bundle versions. Identifiers are reused
+// between types and regions, so all three are needed.
+func (w Waypoint) Key() string {
+	return w.Type + ":" + w.Identifier + ":" + w.Region
+}
+
+// Airline maps an ICAO airline designator (the callsign prefix) to a name.
+type Airline struct {
+	Designator string `json:"designator"`
+	Name       string `json:"name"`
+}
+
+// Key identifies an airline across bundle versions.
+func (a Airline) Key() string {
+	return a.Designator
+}
+
+// Area is the region a bundle covers.
+type Area struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusNM  float64 `json:"radiusNm"`
+}
+
+// Bundle is a versioned snapshot of reference data for offline use.
+type Bundle struct {
+	Version     string     `json:"version"`
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Area        Area       `json:"area"`
+	Waypoints   []Waypoint `json:"waypoints"`
+	Airports    []Waypoint `json:"airports"`
+	Airlines    []Airline  `json:"airlines"`
+}
+
+// NewBundle creates a bundle and computes its version. Airports are split
+// out of waypoints by type. The slices are sorted in place so that the same
+// data always produces the same version.
+func NewBundle(area Area, waypoints []Waypoint, airlines []Airline) *Bundle {
+	b := &Bundle{
+		GeneratedAt: time.Now().UTC(),
+		Area:        area,
+		Waypoints:   []Waypoint{},
+		Airports:    []Waypoint{},
+		Airlines:    airlines,
+	}
+	if b.Airlines == nil {
+		b.Airlines = []Airline{}
+	}
+
+	for _, w := range waypoints {
+		if w.Type == "airport" {
+			b.Airports = append(b.Airports, w)
+		} else {
+			b.Waypoints = append(b.Waypoints, w)
+		}
+	}
+
+	sortByKey(b.Waypoints, Waypoint.Key)
+	sortByKey(b.Airports, Waypoint.Key)
+	sortByKey(b.Airlines, Airline.Key)
+
+	b.Version = b.hash()
+	return b
+}
+
+// sortByKey sorts items by their key
+func sortByKey[T any](items []T, key func(T) string) {
+	sort.Slice(items, func(i, j int) bool {
+		return key(items[i]) < key(items[j])
+	})
+}
+
+// hash returns the version of the bundle's contents
+func (b *Bundle) hash() string {
+	content := struct {
+		Area      Area
+		Waypoints []Waypoint
+		Airports  []Waypoint
+		Airlines  []Airline
+	}{b.Area, b.Waypoints, b.Airports, b.Airlines}
+
+	// Marshalling plain structs of strings and floats cannot fail
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Changes lists the items of one dataset that changed between versions.
+// Upserted items are new or modified; Removed holds the keys of items that
+// are no longer present.
+type Changes[T any] struct {
+	Upserted []T      `json:"upserted"`
+	Removed  []string `json:"removed"`
+}
+
+// Empty reports whether nothing changed.
+func (c Changes[T]) Empty() bool {
+	return len(c.Upserted) == 0 && len(c.Removed) == 0
+}
+
+// Diff is the update from one bundle version to another.
+type Diff struct {
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Waypoints Changes[Waypoint] `json:"waypoints"`
+	Airports  Changes[Waypoint] `json:"airports"`
+	Airlines  Changes[Airline]  `json:"airlines"`
+}
+
+// Compare returns the changes needed to update from to to.
+func Compare(from, to *Bundle) *Diff {
+	return &Diff{
+		From:      from.Version,
+		To:        to.Version,
+		Waypoints: compare(from.Waypoints, to.Waypoints, Waypoint.Key),
+		Airports:  compare(from.Airports, to.Airports, Waypoint.Key),
+		Airlines:  compare(from.Airlines, to.Airlines, Airline.Key),
+	}
+}
+
+// compare diffs two versions of a dataset by key
+func compare[T comparable](old, current []T, key func(T) string) Changes[T] {
+	changes := Changes[T]{Upserted: []T{}, Removed: []string{}}
+
+	previous := make(map[string]T, len(old))
+	for _, item := range old {
+		previous[key(item)] = item
+	}
+
+	for _, item := range current {
+		k := key(item)
+		if prev, ok := previous[k]; !ok || prev != item {
+			changes.Upserted = append(changes.Upserted, item)
+		}
+		delete(previous, k)
+	}
+
+	for k := range previous {
+		changes.Removed = append(changes.Removed, k)
+	}
+	sort.Strings(changes.Removed)
+
+	return changes
+}
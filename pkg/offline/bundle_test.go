@@ -0,0 +1,113 @@
+package offline
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testArea = Area{Latitude: 35.2, Longitude: -80.9, RadiusNM: 100}
+
+// testWaypoints returns a small set of waypoints including an airport.
+func testWaypoints() []Waypoint {
+	return []Waypoint{
+		{Identifier: "KCLT", Name: "Charlotte Douglas", Latitude: 35.214, Longitude: -80.943, Type: "airport"},
+		{Identifier: "CHSLY", Latitude: 35.0, Longitude: -80.8, Type: "fix", Region: "K7"},
+		{Identifier: "LIB", Name: "Liberty", Latitude: 35.8, Longitude: -79.6, Type: "vor", Region: "K7"},
+	}
+}
+
+// TestNewBundle tests airport splitting and stable versions.
+func TestNewBundle(t *testing.T) {
+	airlines := []Airline{{"UAL", "United Airlines"}, {"AAL", "American Airlines"}}
+	b := NewBundle(testArea, testWaypoints(), airlines)
+
+	if len(b.Airports) != 1 || b.Airports[0].Identifier != "KCLT" {
+		t.Errorf("Expected KCLT as the only airport, got %+v", b.Airports)
+	}
+	if len(b.Waypoints) != 2 || b.Waypoints[0].Identifier != "CHSLY" {
+		t.Errorf("Expected 2 sorted waypoints, got %+v", b.Waypoints)
+	}
+	if b.Airlines[0].Designator != "AAL" {
+		t.Errorf("Expected airlines sorted by designator, got %+v", b.Airlines)
+	}
+
+	// Same data in a different order has the same version
+	reversed := testWaypoints()
+	reversed[0], reversed[2] = reversed[2], reversed[0]
+	again := NewBundle(testArea, reversed, []Airline{{"AAL", "American Airlines"}, {"UAL", "United Airlines"}})
+	if again.Version != b.Version {
+		t.Errorf("Expected stable version %s, got %s", b.Version, again.Version)
+	}
+
+	// Different area or data changes the version
+	moved := testArea
+	moved.RadiusNM = 200
+	if NewBundle(moved, testWaypoints(), airlines).Version == b.Version {
+		t.Error("Expected a different version for a different area")
+	}
+	if NewBundle(testArea, testWaypoints()[:2], airlines).Version == b.Version {
+		t.Error("Expected a different version for different waypoints")
+	}
+}
+
+// TestCompare tests diffs between bundle versions.
+func TestCompare(t *testing.T) {
+	old := NewBundle(testArea, testWaypoints(), []Airline{{"AAL", "American Airlines"}})
+
+	// CHSLY moves, LIB is removed and BUCKL added
+	updated := testWaypoints()
+	updated[1].Latitude = 35.01
+	updated = append(updated[:2], Waypoint{Identifier: "BUCKL", Type: "fix", Region: "K7"})
+	current := NewBundle(testArea, updated, []Airline{{"AAL", "American Airlines"}})
+
+	diff := Compare(old, current)
+	if diff.From != old.Version || diff.To != current.Version {
+		t.Errorf("Expected %s -> %s, got %s -> %s", old.Version, current.Version, diff.From, diff.To)
+	}
+
+	var upserted []string
+	for _, w := range diff.Waypoints.Upserted {
+		upserted = append(upserted, w.Identifier)
+	}
+	if !reflect.DeepEqual(upserted, []string{"BUCKL", "CHSLY"}) {
+		t.Errorf("Expected BUCKL and CHSLY upserted, got %v", upserted)
+	}
+	if !reflect.DeepEqual(diff.Waypoints.Removed, []string{"vor:LIB:K7"}) {
+		t.Errorf("Expected LIB removed, got %v", diff.Waypoints.Removed)
+	}
+	if !diff.Airports.Empty() || !diff.Airlines.Empty() {
+		t.Errorf("Expected no airport or airline changes, got %+v %+v", diff.Airports, diff.Airlines)
+	}
+
+	if d := Compare(current, current); !d.Waypoints.Empty() || !d.Airports.Empty() || !d.Airlines.Empty() {
+		t.Error("Expected an empty diff against the same version")
+	}
+}
+
+// TestStore tests that the store keeps the most recent versions.
+func TestStore(t *testing.T) {
+	s := NewStore(2)
+	a := &Bundle{Version: "a"}
+	b := &Bundle{Version: "b"}
+	c := &Bundle{Version: "c"}
+
+	s.Add(a)
+	s.Add(b)
+	s.Add(a) // a is now the most recent
+	s.Add(c)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if _, ok := s.Get(tt.version); ok != tt.want {
+			t.Errorf("Get(%q): expected %v, got %v", tt.version, tt.want, ok)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package offline
+
+import "sync"
+
+// DefaultStoreSize is the number of bundle versions kept for diffs
+const DefaultStoreSize = 16
+
+// Store keeps recently served bundles so later requests can be answered with
+// a diff. Clients holding a version that has been evicted (or that predates a
+// server restart) get a full bundle instead.
+type Store struct {
+	mu      sync.Mutex
+	size    int
+	order   []string // Versions, oldest first
+	bundles map[string]*Bundle
+}
+
+// NewStore creates a store that keeps up to size bundle versions.
+func NewStore(size int) *Store {
+	if size <= 0 {
+		size = DefaultStoreSize
+	}
+	return &Store{
+		size:    size,
+		bundles: make(map[string]*Bundle),
+	}
+}
+
+// Add records a bundle, evicting the oldest version if the store is full.
+// Adding a version that is already stored marks it as recently used.
+func (s *Store) Add(b *Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.bundles[b.Version]; ok {
+		s.remove(b.Version)
+	}
+	s.order = append(s.order, b.Version)
+	s.bundles[b.Version] = b
+
+	for len(s.order) > s.size {
+		delete(s.bundles, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// remove drops a version from the eviction order
+func (s *Store) remove(version string) {
+	for i, v := range s.order {
+		if v == version {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// Get returns a stored bundle by version.
+func (s *Store) Get(version string) (*Bundle, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.bundles[version]
+	return b, ok
+}
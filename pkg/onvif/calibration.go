@@ -0,0 +1,91 @@
+package onvif
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// Calibration maps the camera's generic pan/tilt space (-1 to 1 on each
+// axis) to azimuth and elevation. Each axis is linear: a negative scale
+// means the axis runs backwards (e.g. a ceiling-mounted camera).
+type Calibration struct {
+	// PanZeroAzimuth is the azimuth at pan 0 and PanDegreesPerUnit the
+	// degrees turned per unit of pan (180 if -1 to 1 spans a full turn)
+	PanZeroAzimuth    float64
+	PanDegreesPerUnit float64
+
+	// TiltZeroElevation is the elevation at tilt 0 and TiltDegreesPerUnit
+	// the degrees raised per unit of tilt
+	TiltZeroElevation  float64
+	TiltDegreesPerUnit float64
+}
+
+// CalibrationFromConfig returns the calibration configured for a camera.
+func CalibrationFromConfig(cfg config.ONVIFConfig) Calibration {
+	return Calibration{
+		PanZeroAzimuth:     cfg.PanZeroAzimuth,
+		PanDegreesPerUnit:  cfg.GetPanDegreesPerUnit(),
+		TiltZeroElevation:  cfg.TiltZeroElevation,
+		TiltDegreesPerUnit: cfg.GetTiltDegreesPerUnit(),
+	}
+}
+
+// ReferencePoint is a pan/tilt position with the azimuth and elevation the
+// camera is known to point at there, e.g. a landmark or aircraft.
+type ReferencePoint struct {
+	Pan, Tilt          float64
+	Azimuth, Elevation float64
+}
+
+// ErrOutsideRange is returned for directions beyond the camera's pan/tilt
+// range.
+var ErrOutsideRange = errors.New("outside the camera's pan/tilt range")
+
+// FitCalibration calibrates from two reference points, which must differ in
+// both pan and tilt (by under 180° in azimuth).
+func FitCalibration(a, b ReferencePoint) (Calibration, error) {
+	if math.Abs(b.Pan-a.Pan) < 0.01 || math.Abs(b.Tilt-a.Tilt) < 0.01 {
+		return Calibration{}, fmt.Errorf("reference points must differ in both pan and tilt")
+	}
+
+	var cal Calibration
+	cal.PanDegreesPerUnit = math.Remainder(b.Azimuth-a.Azimuth, 360) / (b.Pan - a.Pan)
+	cal.PanZeroAzimuth = normalizeAzimuth(a.Azimuth - a.Pan*cal.PanDegreesPerUnit)
+	cal.TiltDegreesPerUnit = (b.Elevation - a.Elevation) / (b.Tilt - a.Tilt)
+	cal.TiltZeroElevation = a.Elevation - a.Tilt*cal.TiltDegreesPerUnit
+	return cal, nil
+}
+
+// PanTilt returns the pan and tilt that point the camera at an elevation
+// and azimuth, or ErrOutsideRange if either is beyond -1 to 1.
+func (cal Calibration) PanTilt(elevation, azimuth float64) (pan, tilt float64, err error) {
+	if cal.PanDegreesPerUnit == 0 || cal.TiltDegreesPerUnit == 0 {
+		return 0, 0, fmt.Errorf("pan/tilt calibration has no scale")
+	}
+
+	pan = math.Remainder(azimuth-cal.PanZeroAzimuth, 360) / cal.PanDegreesPerUnit
+	tilt = (elevation - cal.TiltZeroElevation) / cal.TiltDegreesPerUnit
+	if math.Abs(pan) > 1 || math.Abs(tilt) > 1 {
+		return 0, 0, fmt.Errorf("elevation %.1f°, azimuth %.1f°: %w", elevation, azimuth, ErrOutsideRange)
+	}
+	return pan, tilt, nil
+}
+
+// Direction returns the elevation and azimuth the camera points at for a
+// pan and tilt.
+func (cal Calibration) Direction(pan, tilt float64) (elevation, azimuth float64) {
+	return cal.TiltZeroElevation + tilt*cal.TiltDegreesPerUnit,
+		normalizeAzimuth(cal.PanZeroAzimuth + pan*cal.PanDegreesPerUnit)
+}
+
+// normalizeAzimuth returns azimuth in 0-360°.
+func normalizeAzimuth(azimuth float64) float64 {
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	return azimuth
+}
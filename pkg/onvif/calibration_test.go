@@ -0,0 +1,73 @@
+package onvif
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestFitCalibration tests calibrating from reference points and converting
+// both ways.
+func TestFitCalibration(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b ReferencePoint
+		want Calibration
+	}{
+		{
+			name: "Upright camera facing south",
+			a:    ReferencePoint{Pan: 0, Tilt: 0, Azimuth: 180, Elevation: 0},
+			b:    ReferencePoint{Pan: 0.5, Tilt: 0.5, Azimuth: 270, Elevation: 45},
+			want: Calibration{PanZeroAzimuth: 180, PanDegreesPerUnit: 180, TiltZeroElevation: 0, TiltDegreesPerUnit: 90},
+		},
+		{
+			name: "Reversed pan across north",
+			a:    ReferencePoint{Pan: -0.1, Tilt: -0.5, Azimuth: 10, Elevation: 10},
+			b:    ReferencePoint{Pan: 0.1, Tilt: 0.5, Azimuth: 350, Elevation: 70},
+			want: Calibration{PanZeroAzimuth: 0, PanDegreesPerUnit: -100, TiltZeroElevation: 40, TiltDegreesPerUnit: 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := FitCalibration(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("FitCalibration() error = %v", err)
+			}
+			if !near(cal.PanZeroAzimuth, tt.want.PanZeroAzimuth) || !near(cal.PanDegreesPerUnit, tt.want.PanDegreesPerUnit) ||
+				!near(cal.TiltZeroElevation, tt.want.TiltZeroElevation) || !near(cal.TiltDegreesPerUnit, tt.want.TiltDegreesPerUnit) {
+				t.Errorf("FitCalibration() = %+v, want %+v", cal, tt.want)
+			}
+
+			// The reference points convert back to themselves
+			for _, p := range []ReferencePoint{tt.a, tt.b} {
+				pan, tilt, err := cal.PanTilt(p.Elevation, p.Azimuth)
+				if err != nil || !near(pan, p.Pan) || !near(tilt, p.Tilt) {
+					t.Errorf("PanTilt(%.0f, %.0f) = %.3f, %.3f, %v, want %.3f, %.3f", p.Elevation, p.Azimuth, pan, tilt, err, p.Pan, p.Tilt)
+				}
+				if el, az := cal.Direction(p.Pan, p.Tilt); !near(el, p.Elevation) || !near(az, p.Azimuth) {
+					t.Errorf("Direction(%.3f, %.3f) = %.1f, %.1f, want %.1f, %.1f", p.Pan, p.Tilt, el, az, p.Elevation, p.Azimuth)
+				}
+			}
+		})
+	}
+
+	t.Run("Outside range", func(t *testing.T) {
+		cal := Calibration{PanZeroAzimuth: 0, PanDegreesPerUnit: 60, TiltZeroElevation: 0, TiltDegreesPerUnit: 90}
+		if _, _, err := cal.PanTilt(30, 180); !errors.Is(err, ErrOutsideRange) {
+			t.Errorf("PanTilt() error = %v, want ErrOutsideRange", err)
+		}
+	})
+
+	t.Run("Degenerate reference points", func(t *testing.T) {
+		p := ReferencePoint{Pan: 0.2, Tilt: 0.2, Azimuth: 90, Elevation: 30}
+		if _, err := FitCalibration(p, p); err == nil {
+			t.Error("FitCalibration() accepted identical reference points")
+		}
+	})
+}
+
+// near reports whether two values agree to within 0.001.
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
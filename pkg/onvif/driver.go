@@ -0,0 +1,128 @@
+package onvif
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+)
+
+// DefaultMaxRate is the axis rate (deg/sec) at full velocity assumed when
+// none is configured
+const DefaultMaxRate = 30.0
+
+var _ alpaca.TelescopeDriver = (*Driver)(nil)
+
+// Driver drives a PTZ camera as a mount, so the tracking engine (and
+// alpaca.SafeTelescope) can point it at aircraft. Elevation and azimuth are
+// converted to the camera's pan/tilt space by its Calibration. Axis 0 is
+// azimuth and axis 1 elevation, as for Alpaca alt-az mounts.
+type Driver struct {
+	client      *Client
+	calibration Calibration
+	maxRate     float64
+
+	mu       sync.Mutex
+	velocity [2]float64 // Continuous move velocities: 0 = azimuth, 1 = elevation (deg/sec)
+}
+
+// NewDriver creates a driver for the camera behind client. maxRate is the
+// camera's axis rate in degrees per second at full velocity, used to scale
+// MoveAxis rates (DefaultMaxRate if 0).
+func NewDriver(client *Client, calibration Calibration, maxRate float64) *Driver {
+	if maxRate <= 0 {
+		maxRate = DefaultMaxRate
+	}
+	return &Driver{client: client, calibration: calibration, maxRate: maxRate}
+}
+
+// Connect checks the camera answers a status request.
+func (d *Driver) Connect(ctx context.Context) error {
+	_, err := d.client.GetStatus(ctx)
+	return err
+}
+
+// SlewToAltAzContext points the camera at an elevation and azimuth.
+func (d *Driver) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	pan, tilt, err := d.calibration.PanTilt(altitude, azimuth)
+	if err != nil {
+		return err
+	}
+	if err := d.client.AbsoluteMove(ctx, pan, tilt); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.velocity = [2]float64{}
+	d.mu.Unlock()
+	return nil
+}
+
+// MoveAxisContext moves axis 0 (azimuth) or 1 (elevation) at rate degrees
+// per second; 0 stops that axis.
+func (d *Driver) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	if axis < 0 || axis > 1 {
+		return fmt.Errorf("invalid axis %d: must be 0 (azimuth) or 1 (altitude)", axis)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	velocity := d.velocity
+	velocity[axis] = rate
+	if velocity == [2]float64{} {
+		if err := d.client.Stop(ctx); err != nil {
+			return err
+		}
+		d.velocity = velocity
+		return nil
+	}
+
+	// The camera's velocity runs the same way as its position, so a
+	// reversed axis reverses it too
+	pan := clampUnit(velocity[0] / d.maxRate * math.Copysign(1, d.calibration.PanDegreesPerUnit))
+	tilt := clampUnit(velocity[1] / d.maxRate * math.Copysign(1, d.calibration.TiltDegreesPerUnit))
+	if err := d.client.ContinuousMove(ctx, pan, tilt); err != nil {
+		return err
+	}
+	d.velocity = velocity
+	return nil
+}
+
+// GetAltitude returns the camera's elevation.
+func (d *Driver) GetAltitude() (float64, error) {
+	altitude, _, err := d.direction()
+	return altitude, err
+}
+
+// GetAzimuth returns the camera's azimuth.
+func (d *Driver) GetAzimuth() (float64, error) {
+	_, azimuth, err := d.direction()
+	return azimuth, err
+}
+
+// IsSlewing reports whether the camera is moving.
+func (d *Driver) IsSlewing() (bool, error) {
+	status, err := d.client.GetStatus(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return status.Moving, nil
+}
+
+// direction returns the elevation and azimuth the camera points at.
+func (d *Driver) direction() (float64, float64, error) {
+	status, err := d.client.GetStatus(context.Background())
+	if err != nil {
+		return 0, 0, err
+	}
+	altitude, azimuth := d.calibration.Direction(status.Pan, status.Tilt)
+	return altitude, azimuth, nil
+}
+
+// clampUnit clamps v to -1 to 1.
+func clampUnit(v float64) float64 {
+	return math.Max(-1, math.Min(v, 1))
+}
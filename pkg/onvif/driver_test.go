@@ -0,0 +1,152 @@
+package onvif
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCamera serves the ONVIF PTZ operations the driver uses, moving
+// instantly unless moving is set.
+type fakeCamera struct {
+	mu        sync.Mutex
+	pan, tilt float64
+	moving    bool
+	velocity  string // Last ContinuousMove "x y"
+	requests  []string
+}
+
+var panTiltAttrs = regexp.MustCompile(`PanTilt x="([-0-9.]+)" y="([-0-9.]+)"`)
+
+func (c *fakeCamera) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	req := string(body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+
+	w.Header().Set("Content-Type", "application/soap+xml")
+	reply := func(inner string) {
+		fmt.Fprintf(w, `<?xml version="1.0"?><env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope" `+
+			`xmlns:tptz="http://www.onvif.org/ver20/ptz/wsdl" xmlns:tt="http://www.onvif.org/ver10/schema">`+
+			`<env:Body>%s</env:Body></env:Envelope>`, inner)
+	}
+
+	switch {
+	case !strings.Contains(req, "<wsse:Username>admin</wsse:Username>"):
+		w.WriteHeader(http.StatusBadRequest)
+		reply(`<env:Fault><env:Reason><env:Text xml:lang="en">Sender not authorized</env:Text></env:Reason></env:Fault>`)
+	case strings.Contains(req, "<tptz:AbsoluteMove"):
+		m := panTiltAttrs.FindStringSubmatch(req)
+		c.pan, _ = strconv.ParseFloat(m[1], 64)
+		c.tilt, _ = strconv.ParseFloat(m[2], 64)
+		reply(`<tptz:AbsoluteMoveResponse/>`)
+	case strings.Contains(req, "<tptz:ContinuousMove"):
+		m := panTiltAttrs.FindStringSubmatch(req)
+		c.velocity = m[1] + " " + m[2]
+		reply(`<tptz:ContinuousMoveResponse/>`)
+	case strings.Contains(req, "<tptz:Stop"):
+		c.velocity = ""
+		reply(`<tptz:StopResponse/>`)
+	case strings.Contains(req, "<tptz:GetStatus"):
+		status := "IDLE"
+		if c.moving {
+			status = "MOVING"
+		}
+		reply(fmt.Sprintf(`<tptz:GetStatusResponse><tptz:PTZStatus><tt:Position>`+
+			`<tt:PanTilt x="%f" y="%f" space="http://www.onvif.org/ver10/tptz/PanTiltSpaces/PositionGenericSpace"/>`+
+			`</tt:Position><tt:MoveStatus><tt:PanTilt>%s</tt:PanTilt></tt:MoveStatus></tptz:PTZStatus>`+
+			`</tptz:GetStatusResponse>`, c.pan, c.tilt, status))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		reply(`<env:Fault><env:Reason><env:Text>Unknown operation</env:Text></env:Reason></env:Fault>`)
+	}
+}
+
+// TestDriver tests pointing a PTZ camera as a mount.
+func TestDriver(t *testing.T) {
+	camera := &fakeCamera{}
+	server := httptest.NewServer(camera)
+	defer server.Close()
+
+	// Facing south, pan reversed (ceiling mount)
+	cal := Calibration{PanZeroAzimuth: 180, PanDegreesPerUnit: -180, TiltZeroElevation: 0, TiltDegreesPerUnit: 90}
+	driver := NewDriver(NewClient(server.URL, "admin", "secret", "Profile_1"), cal, 20)
+	ctx := context.Background()
+
+	if err := driver.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	t.Run("Slew", func(t *testing.T) {
+		if err := driver.SlewToAltAzContext(ctx, 45, 270); err != nil {
+			t.Fatalf("SlewToAltAzContext() error = %v", err)
+		}
+		camera.mu.Lock()
+		pan, tilt, last := camera.pan, camera.tilt, camera.requests[len(camera.requests)-1]
+		camera.mu.Unlock()
+		if !near(pan, -0.5) || !near(tilt, 0.5) {
+			t.Errorf("Camera at pan %.3f, tilt %.3f, want -0.5, 0.5", pan, tilt)
+		}
+		if !strings.Contains(last, "<tptz:ProfileToken>Profile_1</tptz:ProfileToken>") || !strings.Contains(last, "PasswordDigest") {
+			t.Errorf("Request lacks the profile token or password digest: %s", last)
+		}
+
+		if alt, err := driver.GetAltitude(); err != nil || !near(alt, 45) {
+			t.Errorf("GetAltitude() = %.1f, %v, want 45", alt, err)
+		}
+		if az, err := driver.GetAzimuth(); err != nil || !near(az, 270) {
+			t.Errorf("GetAzimuth() = %.1f, %v, want 270", az, err)
+		}
+		if slewing, err := driver.IsSlewing(); err != nil || slewing {
+			t.Errorf("IsSlewing() = %v, %v, want false", slewing, err)
+		}
+
+		if err := driver.SlewToAltAzContext(ctx, 120, 180); !errors.Is(err, ErrOutsideRange) {
+			t.Errorf("SlewToAltAzContext() beyond the tilt range error = %v, want ErrOutsideRange", err)
+		}
+	})
+
+	t.Run("Move axis", func(t *testing.T) {
+		if err := driver.MoveAxisContext(ctx, 0, 10); err != nil {
+			t.Fatalf("MoveAxisContext() error = %v", err)
+		}
+		if err := driver.MoveAxisContext(ctx, 1, -40); err != nil {
+			t.Fatalf("MoveAxisContext() error = %v", err)
+		}
+
+		// Clockwise is negative pan on a reversed axis; elevation is capped
+		camera.mu.Lock()
+		velocity := camera.velocity
+		camera.mu.Unlock()
+		if velocity != "-0.500000 -1.000000" {
+			t.Errorf("Velocity = %q, want pan -0.5, tilt -1", velocity)
+		}
+
+		driver.MoveAxisContext(ctx, 0, 0)
+		driver.MoveAxisContext(ctx, 1, 0)
+		camera.mu.Lock()
+		velocity = camera.velocity
+		camera.mu.Unlock()
+		if velocity != "" {
+			t.Errorf("Velocity = %q after stopping both axes, want stopped", velocity)
+		}
+	})
+
+	t.Run("Fault", func(t *testing.T) {
+		unauthorized := NewDriver(NewClient(server.URL, "guest", "", "Profile_1"), cal, 20)
+		err := unauthorized.Connect(ctx)
+		if err == nil || !strings.Contains(err.Error(), "Sender not authorized") {
+			t.Errorf("Connect() error = %v, want the SOAP fault", err)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+// Package onvif points ONVIF PTZ (pan/tilt/zoom) cameras, such as
+// security cameras, at aircraft through the ONVIF PTZ service.
+package onvif
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+)
+
+// requestTimeout bounds each PTZ request
+const requestTimeout = 5 * time.Second
+
+// PTZ service and schema namespaces
+const (
+	nsPTZ    = "http://www.onvif.org/ver20/ptz/wsdl"
+	nsSchema = "http://www.onvif.org/ver10/schema"
+)
+
+// Status is a camera's pan/tilt position and whether it is moving.
+type Status struct {
+	// Pan and Tilt are in the camera's generic position space (-1 to 1)
+	Pan, Tilt float64
+
+	Moving bool
+}
+
+// Client sends PTZ commands to an ONVIF camera for one media profile.
+type Client struct {
+	endpoint     string // PTZ service URL, e.g. http://camera/onvif/ptz_service
+	username     string
+	password     string
+	profileToken string
+	http         *http.Client
+}
+
+// NewClient creates a client for the PTZ service at endpoint, moving the
+// PTZ configuration of the media profile with profileToken. Requests are
+// authenticated with a WS-Security username token if username is set.
+func NewClient(endpoint, username, password, profileToken string) *Client {
+	return &Client{
+		endpoint:     endpoint,
+		username:     username,
+		password:     password,
+		profileToken: profileToken,
+		http:         &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// NewClientFromConfig creates a client for the configured camera.
+func NewClientFromConfig(cfg config.ONVIFConfig) *Client {
+	return NewClient(cfg.PTZURL, cfg.Username, cfg.Password, cfg.GetProfileToken())
+}
+
+// AbsoluteMove moves the camera to a pan and tilt in the generic position
+// space (-1 to 1).
+func (c *Client) AbsoluteMove(ctx context.Context, pan, tilt float64) error {
+	body := fmt.Sprintf(`<tptz:AbsoluteMove xmlns:tptz="%s" xmlns:tt="%s">`+
+		`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+		`<tptz:Position><tt:PanTilt x="%.6f" y="%.6f"/></tptz:Position>`+
+		`</tptz:AbsoluteMove>`, nsPTZ, nsSchema, escape(c.profileToken), pan, tilt)
+	return c.call(ctx, "AbsoluteMove", body, nil)
+}
+
+// ContinuousMove moves the camera at a pan and tilt velocity in the generic
+// velocity space (-1 to 1) until stopped.
+func (c *Client) ContinuousMove(ctx context.Context, pan, tilt float64) error {
+	body := fmt.Sprintf(`<tptz:ContinuousMove xmlns:tptz="%s" xmlns:tt="%s">`+
+		`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+		`<tptz:Velocity><tt:PanTilt x="%.6f" y="%.6f"/></tptz:Velocity>`+
+		`</tptz:ContinuousMove>`, nsPTZ, nsSchema, escape(c.profileToken), pan, tilt)
+	return c.call(ctx, "ContinuousMove", body, nil)
+}
+
+// Stop stops pan and tilt movement.
+func (c *Client) Stop(ctx context.Context) error {
+	body := fmt.Sprintf(`<tptz:Stop xmlns:tptz="%s">`+
+		`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+		`<tptz:PanTilt>true</tptz:PanTilt><tptz:Zoom>false</tptz:Zoom>`+
+		`</tptz:Stop>`, nsPTZ, escape(c.profileToken))
+	return c.call(ctx, "Stop", body, nil)
+}
+
+// GetStatus returns the camera's pan/tilt position and move status.
+func (c *Client) GetStatus(ctx context.Context) (Status, error) {
+	body := fmt.Sprintf(`<tptz:GetStatus xmlns:tptz="%s">`+
+		`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+		`</tptz:GetStatus>`, nsPTZ, escape(c.profileToken))
+
+	var resp struct {
+		PanTilt struct {
+			X float64 `xml:"x,attr"`
+			Y float64 `xml:"y,attr"`
+		} `xml:"PTZStatus>Position>PanTilt"`
+		MoveStatus string `xml:"PTZStatus>MoveStatus>PanTilt"`
+	}
+	if err := c.call(ctx, "GetStatus", body, &resp); err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Pan:    resp.PanTilt.X,
+		Tilt:   resp.PanTilt.Y,
+		Moving: strings.EqualFold(strings.TrimSpace(resp.MoveStatus), "MOVING"),
+	}, nil
+}
+
+// call posts a SOAP request and decodes the body's response element into
+// result (if not nil). SOAP faults are returned as errors.
+func (c *Client) call(ctx context.Context, operation, body string, result interface{}) error {
+	envelope := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope">` +
+		c.securityHeader() +
+		`<s:Body>` + body + `</s:Body></s:Envelope>`
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(envelope))
+	if err != nil {
+		return fmt.Errorf("onvif %s: %w", operation, err)
+	}
+	req.Header.Set("Content-Type", `application/soap+xml; charset=utf-8; action="`+nsPTZ+`/`+operation+`"`)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("onvif %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("onvif %s: %w", operation, err)
+	}
+
+	var reply struct {
+		Body struct {
+			Fault *struct {
+				Reason string `xml:"Reason>Text"`
+			} `xml:"Fault"`
+			Inner []byte `xml:",innerxml"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(data, &reply); err != nil {
+		return fmt.Errorf("onvif %s: HTTP %d: invalid response: %w", operation, resp.StatusCode, err)
+	}
+	if fault := reply.Body.Fault; fault != nil {
+		return fmt.Errorf("onvif %s: %s", operation, strings.TrimSpace(fault.Reason))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("onvif %s: HTTP %d", operation, resp.StatusCode)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := xml.NewDecoder(bytes.NewReader(reply.Body.Inner)).Decode(result); err != nil {
+		return fmt.Errorf("onvif %s: invalid response: %w", operation, err)
+	}
+	return nil
+}
+
+// securityHeader returns a WS-Security header with a digest username token,
+// or "" without credentials.
+func (c *Client) securityHeader() string {
+	if c.username == "" {
+		return ""
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	created := time.Now().UTC().Format(time.RFC3339)
+	digest := sha1.Sum(append(append(nonce, created...), c.password...))
+
+	return `<s:Header><wsse:Security s:mustUnderstand="1" ` +
+		`xmlns:wsse="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd" ` +
+		`xmlns:wsu="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd">` +
+		`<wsse:UsernameToken><wsse:Username>` + escape(c.username) + `</wsse:Username>` +
+		`<wsse:Password Type="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-username-token-profile-1.0#PasswordDigest">` +
+		base64.StdEncoding.EncodeToString(digest[:]) + `</wsse:Password>` +
+		`<wsse:Nonce EncodingType="http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-soap-message-security-1.0#Base64Binary">` +
+		base64.StdEncoding.EncodeToString(nonce) + `</wsse:Nonce>` +
+		`<wsu:Created>` + created + `</wsu:Created>` +
+		`</wsse:UsernameToken></wsse:Security></s:Header>`
+}
+
+// escape escapes text for an XML element or attribute.
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
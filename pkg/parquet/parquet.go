@@ -0,0 +1,380 @@
+// Package parquet writes flat tables as Apache Parquet files that pandas,
+// DuckDB, Spark and friends can read. It implements only what bulk exports
+// need: nullable columns of a few primitive types, PLAIN encoding, no
+// compression and no statistics.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// DefaultRowGroupSize is how many rows are buffered before a row group is written
+const DefaultRowGroupSize = 100000
+
+// Type is the type of a column's values.
+type Type int
+
+const (
+	// Int64 columns take int64 or int values
+	Int64 Type = iota
+
+	// Double columns take float64 values
+	Double
+
+	// String columns take string values (stored as UTF-8 byte arrays)
+	String
+
+	// Bool columns take bool values
+	Bool
+
+	// Timestamp columns take time.Time values (stored as UTC milliseconds)
+	Timestamp
+)
+
+// Parquet physical types, converted types and enums used in the metadata
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionOptional = 1
+	pageTypeData       = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+)
+
+// Column describes one column of the table.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// physical returns the Parquet physical type of the column.
+func (c Column) physical() int32 {
+	switch c.Type {
+	case Double:
+		return physicalDouble
+	case String:
+		return physicalByteArray
+	case Bool:
+		return physicalBoolean
+	default:
+		return physicalInt64
+	}
+}
+
+// columnBuffer holds the values of one column for the current row group.
+type columnBuffer struct {
+	present []bool // Definition levels: false for null values
+	values  bytes.Buffer
+	bools   []bool // Bool values are bit-packed when the page is written
+}
+
+// chunkMeta records where a column chunk was written.
+type chunkMeta struct {
+	offset int64
+	size   int64
+}
+
+// rowGroupMeta records a written row group.
+type rowGroupMeta struct {
+	numRows int64
+	size    int64
+	chunks  []chunkMeta
+}
+
+// Writer writes rows to a Parquet file. Call Close to write the footer.
+type Writer struct {
+	w       io.Writer
+	offset  int64
+	columns []Column
+	buffers []columnBuffer
+	rows    int64
+
+	// RowGroupSize is how many rows are buffered before a row group is written
+	RowGroupSize int
+
+	rowGroups []rowGroupMeta
+	closed    bool
+}
+
+// NewWriter starts a Parquet file with the given columns.
+func NewWriter(w io.Writer, columns []Column) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet: no columns")
+	}
+
+	pw := &Writer{
+		w:            w,
+		columns:      columns,
+		buffers:      make([]columnBuffer, len(columns)),
+		RowGroupSize: DefaultRowGroupSize,
+	}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write adds a row. values must match the columns in order; nil is null.
+func (pw *Writer) Write(values []interface{}) error {
+	if pw.closed {
+		return errors.New("parquet: write after close")
+	}
+	if len(values) != len(pw.columns) {
+		return fmt.Errorf("parquet: row has %d values, want %d", len(values), len(pw.columns))
+	}
+
+	// Validate the whole row first so a bad value doesn't leave it half written
+	for i, v := range values {
+		if v != nil && !accepts(pw.columns[i].Type, v) {
+			return fmt.Errorf("parquet: column %s: unexpected value type %T", pw.columns[i].Name, v)
+		}
+	}
+
+	for i, v := range values {
+		buf := &pw.buffers[i]
+		buf.present = append(buf.present, v != nil)
+
+		switch v := v.(type) {
+		case nil:
+		case int64:
+			binary.Write(&buf.values, binary.LittleEndian, v)
+		case int:
+			binary.Write(&buf.values, binary.LittleEndian, int64(v))
+		case float64:
+			binary.Write(&buf.values, binary.LittleEndian, math.Float64bits(v))
+		case string:
+			binary.Write(&buf.values, binary.LittleEndian, uint32(len(v)))
+			buf.values.WriteString(v)
+		case bool:
+			buf.bools = append(buf.bools, v)
+		case time.Time:
+			binary.Write(&buf.values, binary.LittleEndian, v.UnixMilli())
+		}
+	}
+
+	pw.rows++
+	if pw.RowGroupSize > 0 && pw.rows >= int64(pw.RowGroupSize) {
+		return pw.flush()
+	}
+	return nil
+}
+
+// accepts reports whether a value can be stored in a column of type t.
+func accepts(t Type, v interface{}) bool {
+	switch v.(type) {
+	case int64, int:
+		return t == Int64
+	case float64:
+		return t == Double
+	case string:
+		return t == String
+	case bool:
+		return t == Bool
+	case time.Time:
+		return t == Timestamp
+	}
+	return false
+}
+
+// Close writes any buffered rows and the file footer. It does not close the
+// underlying writer.
+func (pw *Writer) Close() error {
+	if pw.closed {
+		return nil
+	}
+	if pw.rows > 0 {
+		if err := pw.flush(); err != nil {
+			return err
+		}
+	}
+	pw.closed = true
+
+	footer := pw.footer()
+	if err := pw.write(footer); err != nil {
+		return err
+	}
+
+	var tail [8]byte
+	binary.LittleEndian.PutUint32(tail[:4], uint32(len(footer)))
+	copy(tail[4:], magic)
+	return pw.write(tail[:])
+}
+
+// flush writes the buffered rows as a row group with one data page per column.
+func (pw *Writer) flush() error {
+	group := rowGroupMeta{numRows: pw.rows}
+
+	for i := range pw.columns {
+		buf := &pw.buffers[i]
+
+		var page bytes.Buffer
+		levels := encodeLevels(buf.present)
+		binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+		page.Write(levels)
+		if pw.columns[i].Type == Bool {
+			page.Write(packBools(buf.bools))
+		} else {
+			page.Write(buf.values.Bytes())
+		}
+
+		header := pageHeader(page.Len(), len(buf.present))
+		chunk := chunkMeta{offset: pw.offset, size: int64(len(header) + page.Len())}
+		if err := pw.write(header); err != nil {
+			return err
+		}
+		if err := pw.write(page.Bytes()); err != nil {
+			return err
+		}
+
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.size
+		pw.buffers[i] = columnBuffer{}
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.rows = 0
+	return nil
+}
+
+// write writes to the underlying writer, tracking the file offset.
+func (pw *Writer) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	if err != nil {
+		return fmt.Errorf("parquet: %w", err)
+	}
+	return nil
+}
+
+// encodeLevels encodes definition levels (bit width 1) with the RLE half of
+// Parquet's RLE/bit-packing hybrid: a run length header then the value.
+func encodeLevels(present []bool) []byte {
+	var out []byte
+	var tmp [binary.MaxVarintLen64]byte
+	for i := 0; i < len(present); {
+		j := i
+		for j < len(present) && present[j] == present[i] {
+			j++
+		}
+
+		n := binary.PutUvarint(tmp[:], uint64(j-i)<<1)
+		out = append(out, tmp[:n]...)
+		if present[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// packBools bit-packs booleans, least significant bit first.
+func packBools(values []bool) []byte {
+	out := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+// pageHeader serializes a data page header.
+func pageHeader(size, numValues int) []byte {
+	var t thriftWriter
+	t.beginStruct()
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(size)) // Uncompressed size
+	t.i32(3, int32(size)) // Compressed size
+	t.structField(5)
+	t.i32(1, int32(numValues))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE) // Definition levels
+	t.i32(4, encodingRLE) // Repetition levels
+	t.endStruct()
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// footer serializes the FileMetaData.
+func (pw *Writer) footer() []byte {
+	var numRows int64
+	for _, g := range pw.rowGroups {
+		numRows += g.numRows
+	}
+
+	var t thriftWriter
+	t.beginStruct()
+	t.i32(1, 1) // Format version
+
+	// Schema: a root element followed by one leaf per column
+	t.listField(2, thriftStruct, len(pw.columns)+1)
+	t.beginStruct()
+	t.string(4, "schema")
+	t.i32(5, int32(len(pw.columns)))
+	t.endStruct()
+	for _, c := range pw.columns {
+		t.beginStruct()
+		t.i32(1, c.physical())
+		t.i32(3, repetitionOptional)
+		t.string(4, c.Name)
+		switch c.Type {
+		case String:
+			t.i32(6, convertedUTF8)
+		case Timestamp:
+			t.i32(6, convertedTimestampMillis)
+		}
+		t.endStruct()
+	}
+
+	t.i64(3, numRows)
+
+	t.listField(4, thriftStruct, len(pw.rowGroups))
+	for _, g := range pw.rowGroups {
+		t.beginStruct()
+		t.listField(1, thriftStruct, len(g.chunks))
+		for i, chunk := range g.chunks {
+			c := pw.columns[i]
+			t.beginStruct()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, c.physical())
+			t.listField(2, thriftI32, 2)
+			t.listI32(encodingPlain)
+			t.listI32(encodingRLE)
+			t.listField(3, thriftBinary, 1)
+			t.listString(c.Name)
+			t.i32(4, codecUncompressed)
+			t.i64(5, g.numRows)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, g.size)
+		t.i64(3, g.numRows)
+		t.endStruct()
+	}
+
+	t.string(6, "ads-bscope")
+	t.endStruct()
+	return t.buf.Bytes()
+}
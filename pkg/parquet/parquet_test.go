@@ -0,0 +1,219 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// thriftReader decodes the compact protocol into maps of field ID to value,
+// enough to check what Writer produced.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.b[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		s := string(r.b[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		h := r.byte()
+		size, elem := int(h>>4), h&0x0F
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case thriftStruct:
+		return r.structure()
+	}
+	panic("unsupported thrift type")
+}
+
+func (r *thriftReader) structure() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var last int16
+	for {
+		h := r.byte()
+		if h == 0 {
+			return fields
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(h & 0x0F)
+		last = id
+	}
+}
+
+// TestWriter writes a small table and decodes it again.
+func TestWriter(t *testing.T) {
+	columns := []Column{
+		{Name: "icao", Type: String},
+		{Name: "timestamp", Type: Timestamp},
+		{Name: "altitude_ft", Type: Double},
+		{Name: "position_count", Type: Int64},
+		{Name: "is_trackable", Type: Bool},
+	}
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	rows := [][]interface{}{
+		{"A1B2C3", ts, 35000.0, int64(12), true},
+		{"ABCDEF", ts.Add(time.Second), nil, 3, false},
+		{"C0FFEE", nil, 1500.5, nil, true},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	w.RowGroupSize = 2 // Force two row groups
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	file := buf.Bytes()
+	if string(file[:4]) != magic || string(file[len(file)-4:]) != magic {
+		t.Fatal("File does not start and end with PAR1")
+	}
+
+	footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := (&thriftReader{b: file[len(file)-8-footerLen : len(file)-8]}).structure()
+
+	if footer[3] != int64(len(rows)) {
+		t.Errorf("num_rows = %v, want %d", footer[3], len(rows))
+	}
+
+	schema := footer[2].([]interface{})
+	if len(schema) != len(columns)+1 {
+		t.Fatalf("schema has %d elements, want %d", len(schema), len(columns)+1)
+	}
+	for i, c := range columns {
+		if name := schema[i+1].(map[int16]interface{})[4]; name != c.Name {
+			t.Errorf("schema[%d] name = %v, want %s", i+1, name, c.Name)
+		}
+	}
+
+	// Decode every column chunk back into values
+	got := make([][]interface{}, 0, len(rows))
+	for _, g := range footer[4].([]interface{}) {
+		group := g.(map[int16]interface{})
+		numRows := int(group[3].(int64))
+		groupRows := make([][]interface{}, numRows)
+		for i := range groupRows {
+			groupRows[i] = make([]interface{}, len(columns))
+		}
+
+		for col, c := range group[1].([]interface{}) {
+			meta := c.(map[int16]interface{})[3].(map[int16]interface{})
+			r := &thriftReader{b: file, pos: int(meta[9].(int64))}
+			header := r.structure()
+			page := file[r.pos : r.pos+int(header[3].(int64))]
+
+			// Definition levels
+			levelsLen := int(binary.LittleEndian.Uint32(page))
+			lr := &thriftReader{b: page[4 : 4+levelsLen]}
+			var present []bool
+			for lr.pos < len(lr.b) {
+				run := int(lr.uvarint() >> 1)
+				v := lr.byte() == 1
+				for k := 0; k < run; k++ {
+					present = append(present, v)
+				}
+			}
+
+			values := page[4+levelsLen:]
+			n := 0
+			for i := 0; i < numRows; i++ {
+				if !present[i] {
+					continue
+				}
+				var v interface{}
+				switch columns[col].Type {
+				case String:
+					size := int(binary.LittleEndian.Uint32(values))
+					v, values = string(values[4:4+size]), values[4+size:]
+				case Timestamp:
+					v, values = time.UnixMilli(int64(binary.LittleEndian.Uint64(values))).UTC(), values[8:]
+				case Double:
+					v, values = math.Float64frombits(binary.LittleEndian.Uint64(values)), values[8:]
+				case Int64:
+					v, values = int64(binary.LittleEndian.Uint64(values)), values[8:]
+				case Bool:
+					v = values[n/8]&(1<<(n%8)) != 0
+				}
+				groupRows[i][col] = v
+				n++
+			}
+		}
+		got = append(got, groupRows...)
+	}
+
+	want := [][]interface{}{
+		{"A1B2C3", ts, 35000.0, int64(12), true},
+		{"ABCDEF", ts.Add(time.Second), nil, int64(3), false},
+		{"C0FFEE", nil, 1500.5, nil, true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded rows = %v, want %v", got, want)
+	}
+}
+
+// TestWriterRejectsBadRows tests row validation.
+func TestWriterRejectsBadRows(t *testing.T) {
+	w, err := NewWriter(&bytes.Buffer{}, []Column{{Name: "altitude_ft", Type: Double}})
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		row  []interface{}
+	}{
+		{"Wrong value count", []interface{}{1.0, 2.0}},
+		{"Wrong value type", []interface{}{"high"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := w.Write(tt.row); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
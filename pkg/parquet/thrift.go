@@ -0,0 +1,112 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type codes used by the Parquet metadata
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs with the Thrift compact protocol, which is how
+// Parquet page headers and the file footer are serialized. Only the subset
+// needed by this package is implemented.
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	// lastField holds the previous field ID of each open struct, since field
+	// headers are delta encoded
+	lastField []int16
+}
+
+// beginStruct starts a nested struct (or the top-level one).
+func (w *thriftWriter) beginStruct() {
+	w.lastField = append(w.lastField, 0)
+}
+
+// endStruct writes the stop field and closes the current struct.
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+// fieldHeader writes the header of field id with the given type.
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastField[len(w.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// varint writes a zigzag varint.
+func (w *thriftWriter) varint(v int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64((v<<1)^(v>>63)))
+	w.buf.Write(tmp[:n])
+}
+
+// uvarint writes an unsigned varint (used for lengths).
+func (w *thriftWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	w.buf.Write(tmp[:n])
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) string(id int16, s string) {
+	w.fieldHeader(id, thriftBinary)
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// structField starts a struct-valued field; close it with endStruct.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+}
+
+// listField starts a list-valued field of size elements of type elem.
+func (w *thriftWriter) listField(id int16, elem byte, size int) {
+	w.fieldHeader(id, thriftList)
+	w.listHeader(elem, size)
+}
+
+// listHeader writes a list header.
+func (w *thriftWriter) listHeader(elem byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elem)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elem)
+	w.uvarint(uint64(size))
+}
+
+// listI32 writes a list element of type i32.
+func (w *thriftWriter) listI32(v int32) {
+	w.varint(int64(v))
+}
+
+// listString writes a list element of type binary.
+func (w *thriftWriter) listString(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
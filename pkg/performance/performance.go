@@ -0,0 +1,188 @@
+// Package performance provides typical performance figures for aircraft
+// types: climb and descent rates, cruise speed and service ceiling. They are
+// used to keep predictions physically plausible when ADS-B velocity data is
+// noisy, for example by clamping an implausible vertical rate before it is
+// extrapolated for several minutes.
+//
+// A built-in table covers common airliners, regional, business and general
+// aviation types. Figures are typical values, not limits from the type
+// certificate.
+package performance
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+//go:embed types.csv
+var builtinCSV string
+
+// Performance is the typical performance of an aircraft type.
+type Performance struct {
+	// Type is the ICAO type designator (e.g., "B738"), or "" for Generic
+	Type string
+
+	// ClimbFPM is a typical climb rate in feet per minute
+	ClimbFPM float64
+
+	// DescentFPM is a typical descent rate in feet per minute (positive)
+	DescentFPM float64
+
+	// CruiseKts is a typical cruise speed in knots
+	CruiseKts float64
+
+	// CeilingFt is the service ceiling in feet MSL
+	CeilingFt float64
+}
+
+// Generic is used for aircraft of unknown type. Its limits are wide enough
+// for any aircraft in normal flight and only catch data that is clearly bad.
+var Generic = Performance{
+	ClimbFPM:   6000,
+	DescentFPM: 6000,
+	CruiseKts:  600,
+	CeilingFt:  60000,
+}
+
+// rateMargin is how far a reported vertical rate may exceed the type's
+// typical rate before it is treated as noise. Aircraft regularly beat their
+// typical rates, e.g. light climbing out or expediting a descent.
+const rateMargin = 1.5
+
+// MaxClimbFPM returns the highest plausible climb rate in feet per minute.
+func (p Performance) MaxClimbFPM() float64 {
+	return p.ClimbFPM * rateMargin
+}
+
+// MaxDescentFPM returns the highest plausible descent rate in feet per
+// minute (positive).
+func (p Performance) MaxDescentFPM() float64 {
+	return p.DescentFPM * rateMargin
+}
+
+// ClampVerticalRate limits a vertical rate in feet per minute to what the
+// type can plausibly fly. The second result reports whether it was clamped.
+func (p Performance) ClampVerticalRate(fpm float64) (float64, bool) {
+	switch {
+	case fpm > p.MaxClimbFPM():
+		return p.MaxClimbFPM(), true
+	case fpm < -p.MaxDescentFPM():
+		return -p.MaxDescentFPM(), true
+	default:
+		return fpm, false
+	}
+}
+
+// Table is a set of performance figures keyed by ICAO type designator.
+type Table map[string]Performance
+
+// Parse reads a table in CSV form with the columns type, climb_fpm,
+// descent_fpm, cruise_kts and ceiling_ft. A header row and lines starting
+// with '#' are skipped.
+func Parse(r io.Reader) (Table, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read performance table: %w", err)
+	}
+
+	table := make(Table, len(records))
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "type") {
+			continue
+		}
+
+		var values [4]float64
+		for j, field := range record[1:] {
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("invalid value %q for %s on line %d", field, record[0], i+1)
+			}
+			values[j] = v
+		}
+
+		aircraftType := strings.ToUpper(strings.TrimSpace(record[0]))
+		table[aircraftType] = Performance{
+			Type:       aircraftType,
+			ClimbFPM:   values[0],
+			DescentFPM: values[1],
+			CruiseKts:  values[2],
+			CeilingFt:  values[3],
+		}
+	}
+
+	return table, nil
+}
+
+// Load reads a table from a CSV file (see Parse).
+func Load(path string) (Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open performance table: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Builtin returns the built-in table.
+func Builtin() Table {
+	table, err := Parse(strings.NewReader(builtinCSV))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in performance table: %v", err))
+	}
+	return table
+}
+
+// Lookup returns the performance of an aircraft type, or Generic if the
+// type is unknown.
+func (t Table) Lookup(aircraftType string) Performance {
+	if p, ok := t[strings.ToUpper(strings.TrimSpace(aircraftType))]; ok {
+		return p
+	}
+	return Generic
+}
+
+// current is the table used by Lookup
+var current atomic.Pointer[Table]
+
+func init() {
+	table := Builtin()
+	current.Store(&table)
+}
+
+// Use replaces the table used by Lookup, e.g. with one loaded from a file.
+// Types missing from table fall back to the built-in figures.
+func Use(table Table) {
+	merged := Builtin()
+	for k, p := range table {
+		merged[k] = p
+	}
+	current.Store(&merged)
+}
+
+// UseFile loads a table from a CSV file and uses it as with Use. Returns the
+// number of types loaded.
+func UseFile(path string) (int, error) {
+	table, err := Load(path)
+	if err != nil {
+		return 0, err
+	}
+	Use(table)
+	return len(table), nil
+}
+
+// Lookup returns the performance of an aircraft type from the table in use,
+// or Generic if the type is unknown.
+func Lookup(aircraftType string) Performance {
+	return current.Load().Lookup(aircraftType)
+}
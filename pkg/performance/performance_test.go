@@ -0,0 +1,91 @@
+package performance
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBuiltin tests that the built-in table parses and has common types.
+func TestBuiltin(t *testing.T) {
+	table := Builtin()
+	for _, aircraftType := range []string{"B738", "A320", "B77W", "C172"} {
+		if _, ok := table[aircraftType]; !ok {
+			t.Errorf("Built-in table is missing %s", aircraftType)
+		}
+	}
+
+	if got := table.Lookup(" b738 "); got.Type != "B738" {
+		t.Errorf("Lookup(b738) = %+v, expected B738", got)
+	}
+	if got := table.Lookup("ZZZZ"); got != Generic {
+		t.Errorf("Lookup(unknown) = %+v, expected Generic", got)
+	}
+}
+
+// TestParse tests reading a performance table.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		types   int
+		wantErr bool
+	}{
+		{"Header and comment", "# comment\ntype,climb_fpm,descent_fpm,cruise_kts,ceiling_ft\nB738,2500,2500,453,41000\n", 1, false},
+		{"No header", "B738,2500,2500,453,41000\nA320, 2500, 2500, 450, 39000\n", 2, false},
+		{"Missing column", "B738,2500,2500,453\n", 0, true},
+		{"Not a number", "B738,fast,2500,453,41000\n", 0, true},
+		{"Zero rate", "B738,0,2500,453,41000\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := Parse(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(table) != tt.types {
+				t.Errorf("Parse() read %d types, expected %d", len(table), tt.types)
+			}
+		})
+	}
+}
+
+// TestClampVerticalRate tests limiting vertical rates to the type.
+func TestClampVerticalRate(t *testing.T) {
+	p := Performance{ClimbFPM: 2000, DescentFPM: 3000, CeilingFt: 40000}
+
+	tests := []struct {
+		name     string
+		fpm      float64
+		expected float64
+		clamped  bool
+	}{
+		{"Level", 0, 0, false},
+		{"Climb within margin", 2800, 2800, false},
+		{"Climb beyond margin", 5000, 3000, true},
+		{"Descent within margin", -4000, -4000, false},
+		{"Descent beyond margin", -9000, -4500, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clamped := p.ClampVerticalRate(tt.fpm)
+			if got != tt.expected || clamped != tt.clamped {
+				t.Errorf("ClampVerticalRate(%.0f) = %.0f, %v; expected %.0f, %v", tt.fpm, got, clamped, tt.expected, tt.clamped)
+			}
+		})
+	}
+}
+
+// TestUse tests that a custom table extends the built-in one.
+func TestUse(t *testing.T) {
+	defer Use(nil)
+
+	Use(Table{"ZZZZ": {Type: "ZZZZ", ClimbFPM: 100, DescentFPM: 100, CruiseKts: 50, CeilingFt: 1000}})
+	if got := Lookup("ZZZZ"); got.ClimbFPM != 100 {
+		t.Errorf("Lookup(ZZZZ) = %+v, expected the custom figures", got)
+	}
+	if got := Lookup("B738"); got.Type != "B738" {
+		t.Errorf("Lookup(B738) = %+v, expected the built-in figures", got)
+	}
+}
@@ -0,0 +1,78 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// Handoff is when a tracked aircraft leaves the altitude limits and the
+// aircraft recommended to track next.
+type Handoff struct {
+	// Leaves is how long until the target leaves the limits; only set if
+	// LeavesLimits (otherwise it is still within them at the horizon)
+	Leaves       time.Duration
+	LeavesLimits bool
+
+	// Next is the recommended next target, nil if none is predicted
+	Next *NextTarget
+}
+
+// NextTarget is an aircraft to track after the current target.
+type NextTarget struct {
+	Aircraft adsb.Aircraft
+	Pass     tracking.Pass // Its pass above the minimum altitude
+
+	// Wait is how long after the handoff it enters the limits (0 if it is
+	// already within them by then)
+	Wait time.Duration
+}
+
+// PlanHandoff predicts when the current target leaves the limits and
+// recommends the next target among others: the aircraft within the limits
+// soonest after the handoff, the one staying longest after it on a tie.
+// Aircraft are only considered within horizon. Returns false if the current
+// target isn't predicted to be within the limits at all.
+func PlanHandoff(
+	current adsb.Aircraft,
+	others []adsb.Aircraft,
+	observer coordinates.Observer,
+	now time.Time,
+	horizon time.Duration,
+	limits tracking.TrackingLimits,
+) (Handoff, bool) {
+	window, ok := tracking.PredictLimitWindow(current, observer, now, horizon, limits)
+	if !ok {
+		return Handoff{}, false
+	}
+
+	handoff := Handoff{Leaves: window.Leave, LeavesLimits: window.Leaves}
+	at := now.Add(horizon)
+	if window.Leaves {
+		at = now.Add(window.Leave)
+	}
+
+	var best *NextTarget
+	bestStays := time.Duration(0)
+	for _, ac := range others {
+		if ac.ICAO == current.ICAO {
+			continue
+		}
+		pass, ok := tracking.PredictPass(ac, observer, now, horizon, limits)
+		if !ok || !pass.End.After(at) {
+			continue
+		}
+
+		wait := max(pass.Start.Sub(at), 0)
+		stays := pass.End.Sub(at) - wait
+		if best == nil || wait < best.Wait || (wait == best.Wait && stays > bestStays) {
+			best = &NextTarget{Aircraft: ac, Pass: pass, Wait: wait}
+			bestStays = stays
+		}
+	}
+	handoff.Next = best
+
+	return handoff, true
+}
@@ -0,0 +1,67 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// TestPlanHandoff tests recommending the next target as the current one
+// leaves the limits.
+func TestPlanHandoff(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := tracking.TrackingLimits{MinAltitude: 30, MaxAltitude: 90}
+
+	// Just west at 10,000 ft flying west at 300 kts: drops below 30° in ~2 min
+	current := adsb.Aircraft{
+		ICAO: "CUR001", Latitude: 35.0, Longitude: -80.03, Altitude: 10000,
+		GroundSpeed: 300, Track: 270, LastSeen: now,
+	}
+
+	// 20 NM east flying west: above 30° from ~2.5 min
+	inbound := adsb.Aircraft{
+		ICAO: "INB001", Latitude: 35.0, Longitude: -79.5928, Altitude: 10000,
+		GroundSpeed: 300, Track: 270, LastSeen: now,
+	}
+
+	// Further out on the same line: enters the limits later
+	later := inbound
+	later.ICAO = "LAT001"
+	later.Longitude = -79.3
+
+	// Flying away: never rises
+	outbound := inbound
+	outbound.ICAO = "OUT001"
+	outbound.Track = 90
+
+	handoff, ok := PlanHandoff(current, []adsb.Aircraft{current, outbound, later, inbound}, observer, now, DefaultHorizon, limits)
+	if !ok {
+		t.Fatal("PlanHandoff() = false, expected the current target within limits")
+	}
+	if !handoff.LeavesLimits || handoff.Leaves <= 0 || handoff.Leaves > 5*time.Minute {
+		t.Errorf("Handoff leaves = %v (%v), expected within 5 minutes", handoff.Leaves, handoff.LeavesLimits)
+	}
+	if handoff.Next == nil {
+		t.Fatal("Handoff has no next target, expected INB001")
+	}
+	if handoff.Next.Aircraft.ICAO != "INB001" {
+		t.Errorf("Next target = %s, expected INB001", handoff.Next.Aircraft.ICAO)
+	}
+
+	t.Run("No next target", func(t *testing.T) {
+		handoff, ok := PlanHandoff(current, []adsb.Aircraft{outbound}, observer, now, DefaultHorizon, limits)
+		if !ok || handoff.Next != nil {
+			t.Errorf("PlanHandoff() = %+v, %v, expected no next target", handoff, ok)
+		}
+	})
+
+	t.Run("Current target out of limits", func(t *testing.T) {
+		if _, ok := PlanHandoff(outbound, []adsb.Aircraft{inbound}, observer, now, DefaultHorizon, limits); ok {
+			t.Error("PlanHandoff() = true for a target never within limits")
+		}
+	})
+}
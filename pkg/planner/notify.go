@@ -0,0 +1,124 @@
+package planner
+
+import (
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// UpcomingPass is an aircraft predicted to reach a notification rule's
+// elevation within its lead time.
+type UpcomingPass struct {
+	Aircraft     adsb.Aircraft
+	Rule         string
+	MinElevation float64
+	Pass         tracking.Pass // Start is when it reaches MinElevation
+}
+
+// PassNotifier finds aircraft about to pass high over the observer, for
+// "go outside now" notifications. Each aircraft is announced once per pass
+// and rule.
+type PassNotifier struct {
+	rules    []config.PassNotificationConfig
+	observer coordinates.Observer
+
+	// notified holds, per aircraft and rule, the end of the pass announced
+	notified map[string]time.Time
+}
+
+// NewPassNotifier creates a notifier for the configured rules.
+func NewPassNotifier(rules []config.PassNotificationConfig, observer coordinates.Observer) *PassNotifier {
+	return &PassNotifier{
+		rules:    rules,
+		observer: observer,
+		notified: make(map[string]time.Time),
+	}
+}
+
+// Check returns the aircraft that will reach a rule's elevation within its
+// lead time and haven't been announced for that pass yet. Aircraft already
+// above the elevation are announced too: it's still time to go outside.
+func (n *PassNotifier) Check(aircraft []adsb.Aircraft, now time.Time) []UpcomingPass {
+	var upcoming []UpcomingPass
+	for _, ac := range aircraft {
+		for i := range n.rules {
+			rule := &n.rules[i]
+			if !matches(rule, ac) {
+				continue
+			}
+
+			key := ac.ICAO + ":" + rule.Name
+			if end, ok := n.notified[key]; ok && now.Before(end) {
+				continue
+			}
+
+			limits := tracking.TrackingLimits{MinAltitude: rule.MinElevation, MaxAltitude: 90}
+			pass, ok := tracking.PredictPass(ac, n.observer, now, rule.GetLead(), limits)
+			if !ok {
+				continue
+			}
+
+			// The pass ends at the horizon if it's still going then; hold
+			// off at least until the aircraft could have reached its real end
+			n.notified[key] = pass.End.Add(rule.GetLead())
+			upcoming = append(upcoming, UpcomingPass{
+				Aircraft:     ac,
+				Rule:         rule.Name,
+				MinElevation: rule.MinElevation,
+				Pass:         pass,
+			})
+		}
+	}
+	return upcoming
+}
+
+// Prune forgets announced passes that have ended, so an aircraft that comes
+// round again is announced again.
+func (n *PassNotifier) Prune(now time.Time) {
+	for key, end := range n.notified {
+		if now.After(end) {
+			delete(n.notified, key)
+		}
+	}
+}
+
+// matches reports whether an aircraft passes a rule's filter.
+func matches(rule *config.PassNotificationConfig, ac adsb.Aircraft) bool {
+	if len(rule.CallsignPrefixes) > 0 {
+		callsign := strings.ToUpper(strings.TrimSpace(ac.Callsign))
+		matched := false
+		for _, prefix := range rule.CallsignPrefixes {
+			if strings.HasPrefix(callsign, strings.ToUpper(prefix)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(rule.AircraftTypes) > 0 {
+		matched := false
+		for _, t := range rule.AircraftTypes {
+			if strings.EqualFold(t, ac.AircraftType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	fleet := adsb.FleetFilter{
+		Operators:    rule.Operators,
+		TypeFamilies: rule.TypeFamilies,
+		Categories:   rule.Categories,
+	}
+	return fleet.Matches(ac)
+}
@@ -0,0 +1,77 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestPassNotifier tests announcing aircraft about to pass overhead.
+func TestPassNotifier(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	rules := []config.PassNotificationConfig{
+		{Name: "overhead", LeadMinutes: 5, MinElevation: 45},
+		{Name: "heavies", LeadMinutes: 10, MinElevation: 30, AircraftTypes: []string{"B77W"}},
+		{Name: "united widebodies", LeadMinutes: 10, MinElevation: 30, Operators: []string{"united"}, Categories: []string{"widebody"}},
+	}
+
+	// 20 NM west at 10,000 ft flying east at 300 kts: above 45° in ~3 min
+	inbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Callsign: "UAL123", Latitude: 35.0, Longitude: -80.4072, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now, AircraftType: "A320",
+	}
+
+	tests := []struct {
+		name     string
+		aircraft adsb.Aircraft
+		expected []string // Rules announced
+	}{
+		{"Inbound", inbound, []string{"overhead"}},
+		{"Inbound heavy", func() adsb.Aircraft { ac := inbound; ac.AircraftType = "b77w"; return ac }(), []string{"overhead", "heavies", "united widebodies"}},
+		{"Other operator's heavy", func() adsb.Aircraft { ac := inbound; ac.Callsign = "DAL123"; ac.AircraftType = "B77W"; return ac }(), []string{"overhead", "heavies"}},
+		{"Outbound", func() adsb.Aircraft { ac := inbound; ac.Track = 270; return ac }(), nil},
+		{"Too far ahead", func() adsb.Aircraft { ac := inbound; ac.GroundSpeed = 100; return ac }(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewPassNotifier(rules, observer)
+			got := n.Check([]adsb.Aircraft{tt.aircraft}, now)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Check() announced %d passes, expected %d", len(got), len(tt.expected))
+			}
+			for i, up := range got {
+				if up.Rule != tt.expected[i] {
+					t.Errorf("Pass %d rule = %s, expected %s", i, up.Rule, tt.expected[i])
+				}
+				if up.Pass.Start.Before(now) || up.Pass.MaxElevation < up.MinElevation {
+					t.Errorf("Pass %d = %+v, expected it to reach %.0f° after now", i, up.Pass, up.MinElevation)
+				}
+			}
+		})
+	}
+
+	t.Run("Announced once", func(t *testing.T) {
+		n := NewPassNotifier(rules[:1], observer)
+		if got := n.Check([]adsb.Aircraft{inbound}, now); len(got) != 1 {
+			t.Fatalf("First Check() announced %d passes, expected 1", len(got))
+		}
+
+		later := inbound
+		later.LastSeen = now.Add(30 * time.Second)
+		later.Longitude += 0.0305 // 2.5 NM on
+		if got := n.Check([]adsb.Aircraft{later}, later.LastSeen); len(got) != 0 {
+			t.Errorf("Second Check() announced %d passes, expected none", len(got))
+		}
+
+		// Forgotten once the pass is long over
+		n.Prune(now.Add(time.Hour))
+		if len(n.notified) != 0 {
+			t.Errorf("Prune() kept %d announced passes", len(n.notified))
+		}
+	})
+}
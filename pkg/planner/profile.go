@@ -0,0 +1,102 @@
+// Package planner turns predicted aircraft passes into data for planning
+// observations, such as the elevation profiles shown as sparkline charts in
+// the TUIs and the PWA, and notifications of passes about to happen.
+package planner
+
+import (
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+const (
+	// DefaultInterval is the default spacing of profile samples
+	DefaultInterval = 10 * time.Second
+
+	// DefaultHorizon is how far ahead passes are looked for by default
+	DefaultHorizon = 30 * time.Minute
+)
+
+// Sample is an aircraft's predicted position in the observer's sky at one
+// time.
+type Sample struct {
+	Time      time.Time `json:"time"`
+	Azimuth   float64   `json:"azimuth"`
+	Elevation float64   `json:"elevation"`
+	RangeNM   float64   `json:"rangeNm"`
+}
+
+// Profile is a predicted pass sampled at a fixed interval.
+type Profile struct {
+	Pass     tracking.Pass
+	Interval time.Duration
+	Samples  []Sample
+}
+
+// PassProfile finds the aircraft's next pass above limits.MinAltitude
+// between from and from+horizon and samples it every interval from start to
+// end. Returns false if there is no pass.
+func PassProfile(
+	aircraft adsb.Aircraft,
+	observer coordinates.Observer,
+	from time.Time,
+	horizon time.Duration,
+	interval time.Duration,
+	limits tracking.TrackingLimits,
+) (*Profile, bool) {
+	pass, ok := tracking.PredictPass(aircraft, observer, from, horizon, limits)
+	if !ok {
+		return nil, false
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	profile := &Profile{Pass: pass, Interval: interval}
+	for t := pass.Start; !t.After(pass.End); t = t.Add(interval) {
+		pos := tracking.PredictPosition(aircraft, t).Position
+		horiz := coordinates.GeographicToHorizontal(pos, observer, t)
+		profile.Samples = append(profile.Samples, Sample{
+			Time:      t,
+			Azimuth:   horiz.Azimuth,
+			Elevation: horiz.Altitude,
+			RangeNM:   coordinates.DistanceNauticalMiles(observer.Location, pos),
+		})
+	}
+
+	return profile, true
+}
+
+// sparkBlocks are the sparkline levels, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the elevations of samples as a line of block characters
+// at most width wide, scaled from the horizon (0°) to the zenith (90°) so
+// passes can be compared. Samples are merged into columns by their highest
+// elevation when there are more than width.
+func Sparkline(samples []Sample, width int) string {
+	if len(samples) == 0 || width <= 0 {
+		return ""
+	}
+	columns := min(width, len(samples))
+
+	var b strings.Builder
+	for col := 0; col < columns; col++ {
+		first := col * len(samples) / columns
+		last := (col + 1) * len(samples) / columns
+
+		peak := samples[first].Elevation
+		for _, s := range samples[first:last] {
+			peak = max(peak, s.Elevation)
+		}
+
+		level := int(peak / 90 * float64(len(sparkBlocks)))
+		level = max(0, min(level, len(sparkBlocks)-1))
+		b.WriteRune(sparkBlocks[level])
+	}
+
+	return b.String()
+}
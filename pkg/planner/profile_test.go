@@ -0,0 +1,107 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// TestPassProfile tests sampling a predicted pass.
+func TestPassProfile(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := tracking.TrackingLimits{MinAltitude: 30, MaxAltitude: 90}
+
+	// 10 NM west of the observer at 10,000 ft, flying east at 300 kts
+	inbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Latitude: 35.0, Longitude: -80.2036, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now,
+	}
+
+	t.Run("Overflight", func(t *testing.T) {
+		profile, ok := PassProfile(inbound, observer, now, 10*time.Minute, 5*time.Second, limits)
+		if !ok {
+			t.Fatal("Expected a pass for an aircraft flying overhead")
+		}
+
+		if len(profile.Samples) < 2 {
+			t.Fatalf("Got %d samples, want several", len(profile.Samples))
+		}
+		if first := profile.Samples[0]; !first.Time.Equal(profile.Pass.Start) {
+			t.Errorf("First sample at %v, want the pass start %v", first.Time, profile.Pass.Start)
+		}
+		for i, s := range profile.Samples {
+			if s.Time.After(profile.Pass.End) {
+				t.Errorf("Sample %d at %v is after the pass end %v", i, s.Time, profile.Pass.End)
+			}
+			if i > 0 && s.Time.Sub(profile.Samples[i-1].Time) != 5*time.Second {
+				t.Errorf("Sample %d is %v after the previous, want 5s", i, s.Time.Sub(profile.Samples[i-1].Time))
+			}
+		}
+
+		// Rises to near the zenith and falls again
+		peak := 0.0
+		for _, s := range profile.Samples {
+			peak = max(peak, s.Elevation)
+		}
+		if peak < 80 {
+			t.Errorf("Peak elevation %.1f°, want near zenith", peak)
+		}
+		if last := profile.Samples[len(profile.Samples)-1]; last.Elevation >= peak {
+			t.Errorf("Last sample at %.1f°, want below the peak", last.Elevation)
+		}
+	})
+
+	t.Run("Default interval", func(t *testing.T) {
+		profile, ok := PassProfile(inbound, observer, now, 10*time.Minute, 0, limits)
+		if !ok {
+			t.Fatal("Expected a pass")
+		}
+		if profile.Interval != DefaultInterval {
+			t.Errorf("Interval = %v, want %v", profile.Interval, DefaultInterval)
+		}
+	})
+
+	t.Run("No pass", func(t *testing.T) {
+		outbound := inbound
+		outbound.Track = 270
+		if _, ok := PassProfile(outbound, observer, now, 10*time.Minute, DefaultInterval, limits); ok {
+			t.Error("Expected no pass for an aircraft flying away")
+		}
+	})
+}
+
+// TestSparkline tests rendering elevations as block characters.
+func TestSparkline(t *testing.T) {
+	samples := func(elevations ...float64) []Sample {
+		s := make([]Sample, len(elevations))
+		for i, e := range elevations {
+			s[i] = Sample{Elevation: e}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		width   int
+		want    string
+	}{
+		{"Empty", nil, 10, ""},
+		{"Zero width", samples(45), 0, ""},
+		{"One per sample", samples(0, 45, 90, 45, 0), 10, "▁▅█▅▁"},
+		{"Merged by peak", samples(0, 10, 90, 20, 30, 0), 3, "▁█▃"},
+		{"Below horizon clamped", samples(-5), 1, "▁"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.samples, tt.width); got != tt.want {
+				t.Errorf("Sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package planner
+
+import (
+	"math"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// Sky brightness sources
+const (
+	SkySourceMeter   = "sqm"     // Live reading from a sky quality meter
+	SkySourceBortle  = "bortle"  // Configured Bortle class
+	SkySourceDefault = "default" // Nothing configured; DefaultBortle assumed
+)
+
+// DefaultBortle is the Bortle class assumed when the site's isn't known:
+// the rural/suburban transition the default capture exposures suit.
+const DefaultBortle = 4
+
+// bortleSQM is the typical zenith sky brightness (mag/arcsec²) of each
+// Bortle class, from class 1 at index 0.
+var bortleSQM = [9]float64{21.9, 21.6, 21.4, 20.9, 19.9, 19.0, 18.6, 18.1, 17.5}
+
+// SkyBrightness is the night sky brightness at a site, in magnitudes per
+// square arcsecond as read by a sky quality meter (higher is darker).
+type SkyBrightness struct {
+	SQM    float64 `json:"sqm"`
+	Source string  `json:"source"`
+}
+
+// SkyFromBortle returns the typical sky brightness for a Bortle class
+// (1-9). Classes outside the range are clamped.
+func SkyFromBortle(class int) SkyBrightness {
+	class = min(max(class, 1), 9)
+	return SkyBrightness{SQM: bortleSQM[class-1], Source: SkySourceBortle}
+}
+
+// SkyFromMeter returns the sky brightness read by a sky quality meter.
+func SkyFromMeter(sqm float64) SkyBrightness {
+	return SkyBrightness{SQM: sqm, Source: SkySourceMeter}
+}
+
+// ObserverSky returns the configured sky brightness of the observer's site:
+// the measured sky_quality_sqm if set, otherwise the Bortle class, otherwise
+// DefaultBortle.
+func ObserverSky(cfg config.ObserverConfig) SkyBrightness {
+	if cfg.SkyQualitySQM > 0 {
+		return SkyBrightness{SQM: cfg.SkyQualitySQM, Source: SkySourceMeter}
+	}
+	if cfg.Bortle > 0 {
+		return SkyFromBortle(cfg.Bortle)
+	}
+	sky := SkyFromBortle(DefaultBortle)
+	sky.Source = SkySourceDefault
+	return sky
+}
+
+// Bortle returns the Bortle class whose typical brightness is nearest.
+func (s SkyBrightness) Bortle() int {
+	best := 1
+	for i, sqm := range bortleSQM {
+		if math.Abs(sqm-s.SQM) < math.Abs(bortleSQM[best-1]-s.SQM) {
+			best = i + 1
+		}
+	}
+	return best
+}
+
+// LimitingMagnitude returns the faintest star visible to the naked eye at
+// the zenith on a moonless night.
+func (s SkyBrightness) LimitingMagnitude() float64 {
+	return 7.93 - 5*math.Log10(math.Pow(10, 4.316-s.SQM/5)+1)
+}
+
+// ExposureScale returns the factor to shorten capture exposures by for the
+// sky's brightness, relative to the DefaultBortle sky. Only applies once
+// the sky is dark (see TwilightPhase.IsDark); before then sunlight, not
+// light pollution, sets the background. Exposures are never lengthened.
+func (s SkyBrightness) ExposureScale(phase coordinates.TwilightPhase) float64 {
+	if !phase.IsDark() || s.SQM <= 0 {
+		return 1
+	}
+	return math.Min(1, math.Pow(10, 0.4*(s.SQM-bortleSQM[DefaultBortle-1])))
+}
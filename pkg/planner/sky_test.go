@@ -0,0 +1,55 @@
+package planner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestObserverSky tests which configured sky brightness wins.
+func TestObserverSky(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.ObserverConfig
+		wantSource string
+		wantBortle int
+	}{
+		{"Nothing configured", config.ObserverConfig{}, SkySourceDefault, DefaultBortle},
+		{"Bortle class", config.ObserverConfig{Bortle: 7}, SkySourceBortle, 7},
+		{"Measured", config.ObserverConfig{Bortle: 7, SkyQualitySQM: 21.5}, SkySourceMeter, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sky := ObserverSky(tt.cfg)
+			if sky.Source != tt.wantSource || sky.Bortle() != tt.wantBortle {
+				t.Errorf("ObserverSky = %s Bortle %d, want %s Bortle %d", sky.Source, sky.Bortle(), tt.wantSource, tt.wantBortle)
+			}
+		})
+	}
+}
+
+// TestSkyBrightness tests the limiting magnitude and exposure scale.
+func TestSkyBrightness(t *testing.T) {
+	dark, city := SkyFromBortle(1), SkyFromBortle(9)
+
+	if nelm := dark.LimitingMagnitude(); nelm < 6.3 || nelm > 7 {
+		t.Errorf("Dark site limiting magnitude = %.1f, want about 6.5", nelm)
+	}
+	if nelm := city.LimitingMagnitude(); nelm < 3.5 || nelm > 4.5 {
+		t.Errorf("City limiting magnitude = %.1f, want about 4", nelm)
+	}
+
+	if scale := dark.ExposureScale(coordinates.PhaseNight); scale != 1 {
+		t.Errorf("Dark site exposure scale = %.2f, want 1 (never lengthened)", scale)
+	}
+	if scale := city.ExposureScale(coordinates.PhaseDay); scale != 1 {
+		t.Errorf("Daytime exposure scale = %.2f, want 1", scale)
+	}
+	// 3.4 magnitudes brighter than the default sky
+	if scale := city.ExposureScale(coordinates.PhaseNight); math.Abs(scale-math.Pow(10, -0.4*3.4)) > 1e-9 {
+		t.Errorf("City exposure scale = %.3f, want %.3f", scale, math.Pow(10, -0.4*3.4))
+	}
+}
@@ -0,0 +1,511 @@
+// Package qrcode encodes short strings (such as device pairing URLs) as QR
+// codes. It implements byte mode at error correction level M for versions
+// 1 to 10 (up to 213 bytes), which is plenty for links, and renders codes
+// as SVG.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxVersion is the largest QR version (symbol size) supported
+const MaxVersion = 10
+
+// quietZone is the blank border around the symbol, in modules
+const quietZone = 4
+
+// ErrTooLong is returned when the text does not fit in a MaxVersion symbol
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// blockSpec describes the error correction blocks of a version at level M.
+type blockSpec struct {
+	ecPerBlock  int
+	shortBlocks int // Number of blocks with shortData data codewords
+	shortData   int
+	longBlocks  int // Number of blocks with shortData+1 data codewords
+}
+
+// levelM lists the block structure of versions 1-10 at error correction
+// level M (ISO/IEC 18004 table 9), indexed by version.
+var levelM = [MaxVersion + 1]blockSpec{
+	1:  {10, 1, 16, 0},
+	2:  {16, 1, 28, 0},
+	3:  {26, 1, 44, 0},
+	4:  {18, 2, 32, 0},
+	5:  {24, 2, 43, 0},
+	6:  {16, 4, 27, 0},
+	7:  {18, 4, 31, 0},
+	8:  {22, 2, 38, 2},
+	9:  {22, 3, 36, 2},
+	10: {26, 4, 43, 1},
+}
+
+// alignmentPositions lists the alignment pattern centre coordinates of
+// each version, indexed by version.
+var alignmentPositions = [MaxVersion + 1][]int{
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+// dataCodewords returns the number of data codewords of a version.
+func (b blockSpec) dataCodewords() int {
+	return b.shortBlocks*b.shortData + b.longBlocks*(b.shortData+1)
+}
+
+// Code is an encoded QR symbol.
+type Code struct {
+	// Version is the symbol version (1-10); the symbol is 17+4·Version modules wide
+	Version int
+
+	// Size is the width and height in modules, excluding the quiet zone
+	Size int
+
+	// modules holds the module colours, true for dark, indexed [row][col]
+	modules [][]bool
+
+	// function marks finder, timing, alignment, format and version modules
+	function [][]bool
+}
+
+// Dark reports whether the module at row, col is dark.
+func (c *Code) Dark(row, col int) bool {
+	return c.modules[row][col]
+}
+
+// Encode encodes text as a QR code in byte mode at error correction level
+// M, using the smallest version it fits in.
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= MaxVersion; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*levelM[v].dataCodewords() {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+	}
+
+	c := newCode(version)
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.addErrorCorrection(c.encodeData(data)))
+
+	// Apply the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR again to undo
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	return c, nil
+}
+
+// newCode allocates an empty symbol.
+func newCode(version int) *Code {
+	size := 17 + 4*version
+	c := &Code{Version: version, Size: size}
+	c.modules = make([][]bool, size)
+	c.function = make([][]bool, size)
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+	return c
+}
+
+// setFunction sets a function module.
+func (c *Code) setFunction(row, col int, dark bool) {
+	c.modules[row][col] = dark
+	c.function[row][col] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns and
+// reserves the format and version areas.
+func (c *Code) drawFunctionPatterns() {
+	// Timing patterns
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with their separators
+	c.drawFinder(3, 3)
+	c.drawFinder(3, c.Size-4)
+	c.drawFinder(c.Size-4, 3)
+
+	// Alignment patterns, except where they would overlap a finder
+	pos := alignmentPositions[c.Version]
+	last := len(pos) - 1
+	for i, row := range pos {
+		for j, col := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dr := -2; dr <= 2; dr++ {
+				for dc := -2; dc <= 2; dc++ {
+					c.setFunction(row+dr, col+dc, max(abs(dr), abs(dc)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas (drawn per mask) and draw the version
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern centred at row, col including the
+// light separator around it.
+func (c *Code) drawFinder(row, col int) {
+	for dr := -4; dr <= 4; dr++ {
+		for dc := -4; dc <= 4; dc++ {
+			r, cc := row+dr, col+dc
+			if r < 0 || r >= c.Size || cc < 0 || cc >= c.Size {
+				continue
+			}
+			d := max(abs(dr), abs(dc))
+			c.setFunction(r, cc, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for level M
+// and the given mask, plus the dark module.
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	// Around the top-left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(i, 8, bit(i))
+	}
+	c.setFunction(7, 8, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(8, 7, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(8, 14-i, bit(i))
+	}
+
+	// Split between the top-right and bottom-left finders
+	for i := 0; i < 8; i++ {
+		c.setFunction(8, c.Size-1-i, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(c.Size-15+i, 8, bit(i))
+	}
+	c.setFunction(c.Size-8, 8, true)
+}
+
+// formatBits returns the 15-bit format information for level M and a mask:
+// the level (00 for M) and mask with a BCH(15,5) code, XORed with 0x5412.
+func formatBits(mask int) int {
+	data := 0<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawVersion draws both copies of the version information (versions 7+).
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// versionBits returns the 18-bit version information: the version with a
+// BCH(18,6) code.
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+// encodeData builds the data codewords: byte mode indicator, character
+// count, the data, a terminator and padding.
+func (c *Code) encodeData(data []byte) []byte {
+	capacity := levelM[c.Version].dataCodewords()
+
+	var bits []bool
+	appendBits := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, v>>i&1 != 0)
+		}
+	}
+
+	appendBits(0x4, 4) // Byte mode
+	if c.Version >= 10 {
+		appendBits(len(data), 16)
+	} else {
+		appendBits(len(data), 8)
+	}
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+
+	// Terminator of up to four zero bits, then pad to a byte boundary
+	appendBits(0, min(4, capacity*8-len(bits)))
+	appendBits(0, (8-len(bits)%8)%8)
+
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+
+	// Alternating pad codewords fill the remaining capacity
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// addErrorCorrection splits the data into blocks, computes each block's
+// Reed-Solomon codewords and interleaves the result.
+func (c *Code) addErrorCorrection(data []byte) []byte {
+	spec := levelM[c.Version]
+	generator := rsGenerator(spec.ecPerBlock)
+
+	var blocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < spec.shortBlocks+spec.longBlocks; i++ {
+		n := spec.shortData
+		if i >= spec.shortBlocks {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+	}
+
+	var result []byte
+	for i := 0; i <= spec.shortData; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, ec := range ecBlocks {
+			result = append(result, ec[i])
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codeword bits in the two-module-wide zigzag
+// columns, right to left, skipping function modules.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.Size; vert++ {
+			row := vert
+			if upward {
+				row = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if c.function[row][col] {
+					continue
+				}
+				// Remainder bits after the last codeword stay light
+				if i < len(codewords)*8 {
+					c.modules[row][col] = codewords[i>>3]>>(7-i&7)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask XORs the data modules with a mask pattern.
+func (c *Code) applyMask(mask int) {
+	for r := 0; r < c.Size; r++ {
+		for col := 0; col < c.Size; col++ {
+			if !c.function[r][col] && maskBit(mask, r, col) {
+				c.modules[r][col] = !c.modules[r][col]
+			}
+		}
+	}
+}
+
+// maskBit reports whether a mask pattern inverts the module at row, col.
+func maskBit(mask, r, c int) bool {
+	switch mask {
+	case 0:
+		return (r+c)%2 == 0
+	case 1:
+		return r%2 == 0
+	case 2:
+		return c%3 == 0
+	case 3:
+		return (r+c)%3 == 0
+	case 4:
+		return (r/2+c/3)%2 == 0
+	case 5:
+		return r*c%2+r*c%3 == 0
+	case 6:
+		return (r*c%2+r*c%3)%2 == 0
+	default:
+		return ((r+c)%2+r*c%3)%2 == 0
+	}
+}
+
+// penalty scores the symbol with the four mask evaluation rules; lower
+// scores are easier to scan.
+func (c *Code) penalty() int {
+	score := 0
+	dark := 0
+
+	for i := 0; i < c.Size; i++ {
+		// Rules 1 and 3 on row i and column i
+		for _, line := range [2]func(int) bool{
+			func(j int) bool { return c.modules[i][j] },
+			func(j int) bool { return c.modules[j][i] },
+		} {
+			run := 1
+			for j := 1; j < c.Size; j++ {
+				if line(j) == line(j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			if run >= 5 {
+				score += 3 + run - 5
+			}
+
+			for j := 0; j+11 <= c.Size; j++ {
+				if finderLike(line, j) {
+					score += 40
+				}
+			}
+		}
+
+		for j := 0; j < c.Size; j++ {
+			if c.modules[i][j] {
+				dark++
+			}
+			// Rule 2: 2x2 blocks of one colour
+			if i+1 < c.Size && j+1 < c.Size {
+				m := c.modules[i][j]
+				if c.modules[i][j+1] == m && c.modules[i+1][j] == m && c.modules[i+1][j+1] == m {
+					score += 3
+				}
+			}
+		}
+	}
+
+	// Rule 4: deviation of the dark proportion from 50%, in 5% steps
+	total := c.Size * c.Size
+	score += abs(dark*20-total*10) / total * 10
+	return score
+}
+
+// finderLike reports whether the 11 modules of a line starting at j are
+// dark-light-dark-dark-dark-light-dark with four light modules on one side.
+func finderLike(line func(int) bool, j int) bool {
+	pattern := [7]bool{true, false, true, true, true, false, true}
+	for _, start := range [2]int{j, j + 4} {
+		match := true
+		for k, want := range pattern {
+			if line(start+k) != want {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		light := j + 7 // Light run after the pattern
+		if start != j {
+			light = j // Light run before it
+		}
+		allLight := true
+		for k := 0; k < 4; k++ {
+			if line(light + k) {
+				allLight = false
+				break
+			}
+		}
+		if allLight {
+			return true
+		}
+	}
+	return false
+}
+
+// SVG renders the code as an SVG image with a quiet zone, scale pixels per
+// module.
+func (c *Code) SVG(scale int) string {
+	if scale < 1 {
+		scale = 1
+	}
+	dim := c.Size + 2*quietZone
+
+	var path strings.Builder
+	for r := 0; r < c.Size; r++ {
+		for col := 0; col < c.Size; col++ {
+			if c.modules[r][col] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", col+quietZone, r+quietZone)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`+
+		`<rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		dim, dim, dim*scale, dim*scale, path.String())
+}
+
+// abs returns the absolute value of an int.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,203 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestRSRemainder tests Reed-Solomon codewords against the worked example
+// in ISO/IEC 18004 annex I (version 1-M, "01234567").
+func TestRSRemainder(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	want := []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}
+
+	if got := rsRemainder(data, rsGenerator(10)); !bytes.Equal(got, want) {
+		t.Errorf("Expected % X, got % X", want, got)
+	}
+}
+
+// TestFormatAndVersionBits tests the BCH-coded format and version information.
+func TestFormatAndVersionBits(t *testing.T) {
+	formats := []struct {
+		mask int
+		want int
+	}{
+		{0, 0b101010000010010},
+		{1, 0b101000100100101},
+		{4, 0b100010111111001},
+		{7, 0b100101010100000},
+	}
+	for _, tt := range formats {
+		if got := formatBits(tt.mask); got != tt.want {
+			t.Errorf("Mask %d: expected %015b, got %015b", tt.mask, tt.want, got)
+		}
+	}
+
+	if got := versionBits(7); got != 0b000111110010010100 {
+		t.Errorf("Version 7: expected 000111110010010100, got %018b", got)
+	}
+}
+
+// readFormat reads the mask from the top-left format information copy.
+func readFormat(t *testing.T, c *Code) int {
+	var bits int
+	set := func(i int, dark bool) {
+		if dark {
+			bits |= 1 << i
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		set(i, c.Dark(i, 8))
+	}
+	set(6, c.Dark(7, 8))
+	set(7, c.Dark(8, 8))
+	set(8, c.Dark(8, 7))
+	for i := 9; i < 15; i++ {
+		set(i, c.Dark(8, 14-i))
+	}
+
+	for mask := 0; mask < 8; mask++ {
+		if formatBits(mask) == bits {
+			return mask
+		}
+	}
+	t.Fatalf("Unrecognised format bits %015b", bits)
+	return 0
+}
+
+// readData reads the codewords back out of a symbol, de-interleaves them
+// and returns the data codewords.
+func readData(t *testing.T, c *Code) []byte {
+	mask := readFormat(t, c)
+
+	var stream []byte
+	var cur byte
+	n := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			row := vert
+			if (right+1)&2 == 0 {
+				row = c.Size - 1 - vert
+			}
+			for col := right; col >= right-1; col-- {
+				if c.function[row][col] {
+					continue
+				}
+				bit := c.Dark(row, col) != maskBit(mask, row, col)
+				cur <<= 1
+				if bit {
+					cur |= 1
+				}
+				if n++; n%8 == 0 {
+					stream = append(stream, cur)
+				}
+			}
+		}
+	}
+
+	spec := levelM[c.Version]
+	blocks := spec.shortBlocks + spec.longBlocks
+	data := make([][]byte, blocks)
+	k := 0
+	for i := 0; i <= spec.shortData; i++ {
+		for b := 0; b < blocks; b++ {
+			if i < spec.shortData || b >= spec.shortBlocks {
+				data[b] = append(data[b], stream[k])
+				k++
+			}
+		}
+	}
+
+	// Check each block's error correction codewords
+	ec := stream[k:]
+	generator := rsGenerator(spec.ecPerBlock)
+	for b := range data {
+		want := rsRemainder(data[b], generator)
+		for i := range want {
+			if ec[i*blocks+b] != want[i] {
+				t.Fatalf("Block %d error correction mismatch", b)
+			}
+		}
+	}
+
+	return bytes.Join(data, nil)
+}
+
+// TestEncode tests that encoded text can be read back.
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		version int
+	}{
+		{"Short", "HELLO", 1},
+		{"Pairing URL", "https://ads-bscope.local:8080/?pair=ABCD2345", 4},
+		{"Two block sizes", strings.Repeat("x", 150), 8},
+		{"Version 10", strings.Repeat("y", 200), 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encode(tt.text)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if c.Version != tt.version || c.Size != 17+4*tt.version {
+				t.Errorf("Expected version %d, got %d (size %d)", tt.version, c.Version, c.Size)
+			}
+
+			// Finder pattern corners are dark and their separators light
+			for _, p := range [][2]int{{0, 0}, {0, c.Size - 1}, {c.Size - 1, 0}} {
+				if !c.Dark(p[0], p[1]) {
+					t.Errorf("Expected dark finder corner at %v", p)
+				}
+			}
+			if c.Dark(7, 7) || c.Dark(7, c.Size-1) || c.Dark(c.Size-8, 0) {
+				t.Error("Expected light separators")
+			}
+
+			data := readData(t, c)
+			countBytes := 1
+			if c.Version >= 10 {
+				countBytes = 2
+			}
+
+			// Byte mode (0100) then the count, so the text starts 4 bits in
+			var decoded []byte
+			for i := 0; i < len(tt.text); i++ {
+				j := i + countBytes
+				decoded = append(decoded, data[j]<<4|data[j+1]>>4)
+			}
+			if data[0]>>4 != 0x4 {
+				t.Errorf("Expected byte mode indicator, got %X", data[0]>>4)
+			}
+			if string(decoded) != tt.text {
+				t.Errorf("Expected %q, got %q", tt.text, decoded)
+			}
+		})
+	}
+}
+
+// TestEncodeTooLong tests the capacity limit.
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("z", 214)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong, got %v", err)
+	}
+}
+
+// TestSVG tests SVG rendering.
+func TestSVG(t *testing.T) {
+	c, err := Encode("HELLO")
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	svg := c.SVG(4)
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 29 29"`) || !strings.Contains(svg, "M4,4h1v1h-1z") {
+		t.Errorf("Unexpected SVG: %.200s", svg)
+	}
+}
@@ -0,0 +1,157 @@
+package rotctl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/unklstewy/ads-bscope/pkg/alpaca"
+)
+
+// slewTolerance is how close (degrees) the rotator must get to a slew
+// target for the slew to be complete; rotators typically resolve about 1°
+const slewTolerance = 1.0
+
+// DefaultMaxRate is the axis rate (deg/sec) at full Move speed assumed when
+// none is configured, typical of small az/el rotators
+const DefaultMaxRate = 6.0
+
+var _ alpaca.TelescopeDriver = (*Driver)(nil)
+
+// Driver drives an azimuth/elevation rotator through rotctld as a mount, so
+// the tracking engine (and alpaca.SafeTelescope) can point an antenna
+// without any Alpaca hardware. Axis 0 is azimuth and axis 1 elevation, as
+// for Alpaca alt-az mounts.
+type Driver struct {
+	client  *Client
+	maxRate float64
+
+	mu        sync.Mutex
+	slewing   bool // A slew is under way to targetAlt/targetAz
+	targetAlt float64
+	targetAz  float64
+	moving    bool // An axis is moving (MoveAxis)
+}
+
+// NewDriver creates a driver for the rotator behind client. maxRate is the
+// rotator's axis rate in degrees per second at full speed, used to scale
+// MoveAxis rates (DefaultMaxRate if 0).
+func NewDriver(client *Client, maxRate float64) *Driver {
+	if maxRate <= 0 {
+		maxRate = DefaultMaxRate
+	}
+	return &Driver{client: client, maxRate: maxRate}
+}
+
+// Connect checks rotctld answers a position query.
+func (d *Driver) Connect(ctx context.Context) error {
+	_, _, err := d.client.Position(ctx)
+	return err
+}
+
+// Disconnect closes the connection to rotctld.
+func (d *Driver) Disconnect() error {
+	return d.client.Close()
+}
+
+// SlewToAltAzContext turns the rotator to an elevation and azimuth. The
+// rotator moves on its own; IsSlewing reports when it gets there.
+func (d *Driver) SlewToAltAzContext(ctx context.Context, altitude, azimuth float64) error {
+	if err := d.client.SetPosition(ctx, azimuth, altitude); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.slewing, d.moving = true, false
+	d.targetAlt, d.targetAz = math.Max(0, math.Min(altitude, 90)), azimuth
+	d.mu.Unlock()
+	return nil
+}
+
+// MoveAxisContext moves axis 0 (azimuth, positive clockwise) or 1
+// (elevation, positive up) at rate degrees per second; 0 stops. rotctld
+// can only stop both axes at once, so stopping one stops the other.
+func (d *Driver) MoveAxisContext(ctx context.Context, axis int, rate float64) error {
+	if axis < 0 || axis > 1 {
+		return fmt.Errorf("invalid axis %d: must be 0 (azimuth) or 1 (altitude)", axis)
+	}
+
+	if rate == 0 {
+		if err := d.client.Stop(ctx); err != nil {
+			return err
+		}
+		d.mu.Lock()
+		d.slewing, d.moving = false, false
+		d.mu.Unlock()
+		return nil
+	}
+
+	direction := MoveRight
+	switch {
+	case axis == 0 && rate < 0:
+		direction = MoveLeft
+	case axis == 1 && rate > 0:
+		direction = MoveUp
+	case axis == 1:
+		direction = MoveDown
+	}
+	speed := int(math.Ceil(math.Abs(rate) / d.maxRate * 100))
+	if err := d.client.Move(ctx, direction, speed); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.slewing, d.moving = false, true
+	d.mu.Unlock()
+	return nil
+}
+
+// GetAltitude returns the rotator's elevation.
+func (d *Driver) GetAltitude() (float64, error) {
+	_, elevation, err := d.client.Position(context.Background())
+	return elevation, err
+}
+
+// GetAzimuth returns the rotator's azimuth, normalized to 0-360°.
+func (d *Driver) GetAzimuth() (float64, error) {
+	azimuth, _, err := d.client.Position(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	return azimuth, nil
+}
+
+// IsSlewing reports whether an axis is moving or the rotator is still more
+// than slewTolerance from its slew target.
+func (d *Driver) IsSlewing() (bool, error) {
+	d.mu.Lock()
+	slewing, moving := d.slewing, d.moving
+	targetAlt, targetAz := d.targetAlt, d.targetAz
+	d.mu.Unlock()
+
+	if moving {
+		return true, nil
+	}
+	if !slewing {
+		return false, nil
+	}
+
+	azimuth, elevation, err := d.client.Position(context.Background())
+	if err != nil {
+		return false, err
+	}
+	azError := math.Abs(math.Remainder(azimuth-targetAz, 360))
+	if azError > slewTolerance || math.Abs(elevation-targetAlt) > slewTolerance {
+		return true, nil
+	}
+
+	d.mu.Lock()
+	d.slewing = false
+	d.mu.Unlock()
+	return false, nil
+}
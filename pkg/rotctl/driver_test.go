@@ -0,0 +1,80 @@
+package rotctl
+
+import (
+	"context"
+	"testing"
+)
+
+// TestDriver tests driving a rotator as a mount.
+func TestDriver(t *testing.T) {
+	rotctld := newFakeRotctld(t)
+	driver := NewDriver(NewClient(rotctld.listener.Addr().String()), 4)
+	defer driver.Disconnect()
+	ctx := context.Background()
+
+	if err := driver.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	t.Run("Slew", func(t *testing.T) {
+		rotctld.mu.Lock()
+		rotctld.held = true
+		rotctld.mu.Unlock()
+
+		if err := driver.SlewToAltAzContext(ctx, 30, 359.5); err != nil {
+			t.Fatalf("SlewToAltAzContext() error = %v", err)
+		}
+		if slewing, err := driver.IsSlewing(); err != nil || !slewing {
+			t.Errorf("IsSlewing() = %v, %v before the rotator arrives, want true", slewing, err)
+		}
+
+		// Within tolerance across north
+		rotctld.mu.Lock()
+		rotctld.held = false
+		rotctld.azimuth, rotctld.elevation = 0.2, 30.5
+		rotctld.mu.Unlock()
+		if slewing, err := driver.IsSlewing(); err != nil || slewing {
+			t.Errorf("IsSlewing() = %v, %v after the rotator arrives, want false", slewing, err)
+		}
+
+		if alt, err := driver.GetAltitude(); err != nil || alt != 30.5 {
+			t.Errorf("GetAltitude() = %.1f, %v, want 30.5", alt, err)
+		}
+		if az, err := driver.GetAzimuth(); err != nil || az != 0.2 {
+			t.Errorf("GetAzimuth() = %.1f, %v, want 0.2", az, err)
+		}
+	})
+
+	t.Run("Move axis", func(t *testing.T) {
+		if err := driver.MoveAxisContext(ctx, 0, -2); err != nil {
+			t.Fatalf("MoveAxisContext() error = %v", err)
+		}
+		if err := driver.MoveAxisContext(ctx, 1, 8); err != nil {
+			t.Fatalf("MoveAxisContext() error = %v", err)
+		}
+		if slewing, _ := driver.IsSlewing(); !slewing {
+			t.Error("IsSlewing() = false while an axis moves")
+		}
+
+		rotctld.mu.Lock()
+		moves := rotctld.moves
+		rotctld.mu.Unlock()
+
+		// Half of the maximum rate counter-clockwise, then capped at full
+		// speed up
+		if len(moves) != 2 || moves[0] != "8 50" || moves[1] != "2 100" {
+			t.Errorf("Moves = %q, want [\"8 50\" \"2 100\"]", moves)
+		}
+
+		if err := driver.MoveAxisContext(ctx, 1, 0); err != nil {
+			t.Fatalf("MoveAxisContext(stop) error = %v", err)
+		}
+		if slewing, _ := driver.IsSlewing(); slewing {
+			t.Error("IsSlewing() = true after stopping")
+		}
+
+		if err := driver.MoveAxisContext(ctx, 2, 1); err == nil {
+			t.Error("MoveAxisContext() accepted axis 2")
+		}
+	})
+}
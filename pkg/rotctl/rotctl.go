@@ -0,0 +1,223 @@
+// Package rotctl drives antenna rotators through hamlib's rotctld network
+// daemon, so aircraft can be followed with a directional antenna.
+package rotctl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAddress is where rotctld listens by default.
+const DefaultAddress = "localhost:4533"
+
+const (
+	// dialTimeout bounds connecting to rotctld
+	dialTimeout = 5 * time.Second
+
+	// replyTimeout bounds waiting for a reply when ctx has no deadline
+	replyTimeout = 10 * time.Second
+)
+
+// hamlib error codes (RIG_E*) reported in "RPRT n" replies
+var errorCodes = map[int]string{
+	-1:  "invalid parameter",
+	-2:  "invalid configuration",
+	-4:  "function not implemented",
+	-5:  "communication timed out",
+	-6:  "I/O error",
+	-8:  "protocol error",
+	-9:  "command rejected by the rotator",
+	-11: "function not available",
+	-17: "argument out of domain",
+}
+
+// Error is a non-zero "RPRT" reply from rotctld.
+type Error struct {
+	Command string
+	Code    int
+}
+
+func (e *Error) Error() string {
+	msg, ok := errorCodes[e.Code]
+	if !ok {
+		msg = "error"
+	}
+	return fmt.Sprintf("rotctld %s: %s (RPRT %d)", e.Command, msg, e.Code)
+}
+
+// Client sends commands to rotctld over TCP. It connects on first use and
+// reconnects after network errors. It is safe for concurrent use.
+type Client struct {
+	addr string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient creates a client for the rotctld at addr (host:port).
+func NewClient(addr string) *Client {
+	return &Client{addr: addr}
+}
+
+// Address returns the rotctld address.
+func (c *Client) Address() string {
+	return c.addr
+}
+
+// SetPosition turns the rotator to an azimuth (normalized to 0-360°) and
+// elevation (degrees, clamped to 0-90).
+func (c *Client) SetPosition(ctx context.Context, azimuth, elevation float64) error {
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	elevation = math.Max(0, math.Min(elevation, 90))
+
+	_, err := c.command(ctx, fmt.Sprintf("P %.2f %.2f", azimuth, elevation), 0)
+	return err
+}
+
+// Position returns the rotator's current azimuth and elevation.
+func (c *Client) Position(ctx context.Context) (azimuth, elevation float64, err error) {
+	lines, err := c.command(ctx, "p", 2)
+	if err != nil {
+		return 0, 0, err
+	}
+	if azimuth, err = strconv.ParseFloat(lines[0], 64); err != nil {
+		return 0, 0, fmt.Errorf("rotctld p: invalid azimuth %q", lines[0])
+	}
+	if elevation, err = strconv.ParseFloat(lines[1], 64); err != nil {
+		return 0, 0, fmt.Errorf("rotctld p: invalid elevation %q", lines[1])
+	}
+	return azimuth, elevation, nil
+}
+
+// Directions for Move (hamlib's ROT_MOVE_*)
+const (
+	MoveUp    = 2
+	MoveDown  = 4
+	MoveLeft  = 8  // Counter-clockwise
+	MoveRight = 16 // Clockwise
+)
+
+// Move starts the rotator moving in a direction at a speed from 1 to 100
+// (percent of its maximum), until stopped.
+func (c *Client) Move(ctx context.Context, direction, speed int) error {
+	speed = max(1, min(speed, 100))
+	_, err := c.command(ctx, fmt.Sprintf("M %d %d", direction, speed), 0)
+	return err
+}
+
+// Stop stops the rotator where it is.
+func (c *Client) Stop(ctx context.Context) error {
+	_, err := c.command(ctx, "S", 0)
+	return err
+}
+
+// Park moves the rotator to its park position.
+func (c *Client) Park(ctx context.Context) error {
+	_, err := c.command(ctx, "K", 0)
+	return err
+}
+
+// Close closes the connection, if open.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.disconnect()
+}
+
+// command sends a command and reads its reply: lines values for a query,
+// or "RPRT 0" for a command that returns none (lines 0). A query's failure
+// is reported as a single "RPRT n" line instead of its values.
+func (c *Client) command(ctx context.Context, cmd string, lines int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := strings.Fields(cmd)[0]
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(replyTimeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		c.disconnect()
+		return nil, fmt.Errorf("rotctld %s: %w", name, err)
+	}
+
+	if _, err := fmt.Fprintf(c.conn, "%s\n", cmd); err != nil {
+		c.disconnect()
+		return nil, fmt.Errorf("rotctld %s: %w", name, err)
+	}
+
+	var values []string
+	for len(values) < max(lines, 1) {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			c.disconnect()
+			return nil, fmt.Errorf("rotctld %s: %w", name, err)
+		}
+		line = strings.TrimSpace(line)
+
+		if code, ok := strings.CutPrefix(line, "RPRT "); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(code))
+			if err != nil {
+				return nil, fmt.Errorf("rotctld %s: invalid reply %q", name, line)
+			}
+			if n != 0 {
+				return nil, &Error{Command: name, Code: n}
+			}
+			if lines == 0 {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("rotctld %s: no values in reply", name)
+		}
+		if lines == 0 {
+			return nil, fmt.Errorf("rotctld %s: unexpected reply %q", name, line)
+		}
+		values = append(values, line)
+	}
+	return values, nil
+}
+
+// connect opens the connection if it isn't open. Callers hold c.mu.
+func (c *Client) connect(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("rotctld %s: %w", c.addr, err)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	return nil
+}
+
+// disconnect closes the connection, so the next command reconnects.
+// Callers hold c.mu.
+func (c *Client) disconnect() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.reader = nil, nil
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
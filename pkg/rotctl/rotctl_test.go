@@ -0,0 +1,145 @@
+package rotctl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRotctld is a rotctld answering P, p, M and S like hamlib's dummy
+// rotator, rejecting elevations above maxElevation. Unless held, P moves
+// the rotator instantly.
+type fakeRotctld struct {
+	listener net.Listener
+
+	mu                 sync.Mutex
+	maxElevation       float64
+	held               bool
+	azimuth, elevation float64
+	moves              []string // M arguments
+}
+
+// newFakeRotctld starts a fake rotctld on a free port.
+func newFakeRotctld(t *testing.T) *fakeRotctld {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	f := &fakeRotctld{listener: listener, maxElevation: 90}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRotctld) serve(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		f.mu.Lock()
+		switch fields[0] {
+		case "P":
+			az, _ := strconv.ParseFloat(fields[1], 64)
+			el, _ := strconv.ParseFloat(fields[2], 64)
+			if el > f.maxElevation {
+				fmt.Fprint(conn, "RPRT -1\n")
+				break
+			}
+			if !f.held {
+				f.azimuth, f.elevation = az, el
+			}
+			fmt.Fprint(conn, "RPRT 0\n")
+		case "p":
+			fmt.Fprintf(conn, "%.6f\n%.6f\n", f.azimuth, f.elevation)
+		case "M":
+			f.moves = append(f.moves, strings.Join(fields[1:], " "))
+			fmt.Fprint(conn, "RPRT 0\n")
+		case "S":
+			fmt.Fprint(conn, "RPRT 0\n")
+		default:
+			fmt.Fprint(conn, "RPRT -4\n")
+		}
+		f.mu.Unlock()
+	}
+}
+
+// TestClient tests setting and reading the rotator position.
+func TestClient(t *testing.T) {
+	rotctld := newFakeRotctld(t)
+	client := NewClient(rotctld.listener.Addr().String())
+	defer client.Close()
+	ctx := context.Background()
+
+	if err := client.SetPosition(ctx, -90, 45.5); err != nil {
+		t.Fatalf("SetPosition() error = %v", err)
+	}
+	az, el, err := client.Position(ctx)
+	if err != nil {
+		t.Fatalf("Position() error = %v", err)
+	}
+	if az != 270 || el != 45.5 {
+		t.Errorf("Position() = %.2f, %.2f, want 270 (normalized), 45.5", az, el)
+	}
+
+	// Negative elevations are clamped to the horizon
+	if err := client.SetPosition(ctx, 10, -5); err != nil {
+		t.Fatalf("SetPosition() error = %v", err)
+	}
+	if _, el, _ := client.Position(ctx); el != 0 {
+		t.Errorf("Elevation = %.2f, want 0", el)
+	}
+
+	t.Run("Rejected command", func(t *testing.T) {
+		rotctld.mu.Lock()
+		rotctld.maxElevation = 60
+		rotctld.mu.Unlock()
+
+		err := client.SetPosition(ctx, 10, 80)
+		var rotErr *Error
+		if !errors.As(err, &rotErr) || rotErr.Code != -1 {
+			t.Errorf("SetPosition() error = %v, want RPRT -1", err)
+		}
+
+		// The connection is still usable after an error reply
+		if err := client.Stop(ctx); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		client.Close()
+		if _, _, err := client.Position(ctx); err != nil {
+			t.Errorf("Position() after Close() error = %v", err)
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		listener, _ := net.Listen("tcp", "127.0.0.1:0")
+		addr := listener.Addr().String()
+		listener.Close()
+
+		if err := NewClient(addr).SetPosition(ctx, 0, 0); err == nil {
+			t.Error("SetPosition() succeeded with no rotctld listening")
+		}
+	})
+}
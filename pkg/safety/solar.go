@@ -0,0 +1,128 @@
+// Package safety keeps the telescope from pointing at or sweeping past the
+// sun. Every client that slews the mount (web server, TUI, track-aircraft
+// CLIs and autotracker) checks its targets with the same SolarGuard.
+package safety
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// DefaultMinSolarSeparation is the minimum sun separation in degrees when
+// the configuration doesn't set one.
+const DefaultMinSolarSeparation = 20.0
+
+// ErrSolarProximity is matched (via errors.Is) by a *SolarError.
+var ErrSolarProximity = errors.New("too close to the sun")
+
+// SolarError is returned for a target within the minimum separation from
+// the sun when no solar filter is installed.
+type SolarError struct {
+	Separation    float64 // Degrees between the target and the sun
+	MinSeparation float64
+}
+
+func (e *SolarError) Error() string {
+	return fmt.Sprintf("target is %.1f° from the sun (minimum %.0f° without a solar filter)", e.Separation, e.MinSeparation)
+}
+
+// Is reports whether target is ErrSolarProximity.
+func (e *SolarError) Is(target error) bool {
+	return target == ErrSolarProximity
+}
+
+// SolarCheck describes a target's position relative to the sun.
+type SolarCheck struct {
+	Sun        coordinates.SunPosition
+	Separation float64 // Degrees between the target and the sun
+	Zone       coordinates.SolarSafetyZone
+
+	// FilterRequired is set for a target within the minimum separation
+	// that is only allowed because a solar filter is installed; the caller
+	// should verify the filter is in place
+	FilterRequired bool
+}
+
+// SolarGuard checks telescope targets against the sun's position as seen
+// by the observer.
+type SolarGuard struct {
+	observer        coordinates.Observer
+	enabled         bool
+	minSeparation   float64
+	filterInstalled bool
+	now             func() time.Time
+}
+
+// NewSolarGuard creates a guard from the telescope's solar settings. It
+// only refuses targets if solar_safety_enabled is set; see Enforce.
+func NewSolarGuard(cfg config.TelescopeConfig, observer coordinates.Observer) *SolarGuard {
+	minSeparation := cfg.MinSolarSeparation
+	if minSeparation <= 0 {
+		minSeparation = DefaultMinSolarSeparation
+	}
+	return &SolarGuard{
+		observer:        observer,
+		enabled:         cfg.SolarSafetyEnabled,
+		minSeparation:   minSeparation,
+		filterInstalled: cfg.SolarFilterInstalled,
+		now:             time.Now,
+	}
+}
+
+// Enforce turns the guard on regardless of solar_safety_enabled, for
+// unattended operation.
+func (g *SolarGuard) Enforce() *SolarGuard {
+	g.enabled = true
+	return g
+}
+
+// Enabled reports whether the guard refuses targets near the sun.
+func (g *SolarGuard) Enabled() bool {
+	return g.enabled
+}
+
+// MinSeparation returns the minimum allowed separation from the sun in degrees.
+func (g *SolarGuard) MinSeparation() float64 {
+	return g.minSeparation
+}
+
+// Sun returns the sun's current position.
+func (g *SolarGuard) Sun() coordinates.SunPosition {
+	return coordinates.CalculateSunPosition(g.observer, g.now())
+}
+
+// ExclusionRadius returns how far in degrees slews must keep from the sun
+// at the given position: the minimum separation while the guard is enabled,
+// no solar filter is installed and the sun is up, otherwise 0.
+func (g *SolarGuard) ExclusionRadius(sun coordinates.SunPosition) float64 {
+	if !g.enabled || g.filterInstalled || !sun.IsSunAboveHorizon() {
+		return 0
+	}
+	return g.minSeparation
+}
+
+// Check reports where a target is relative to the sun. It returns a
+// *SolarError if the guard is enabled, the sun is up, and the target is
+// within the minimum separation without a solar filter installed.
+func (g *SolarGuard) Check(target coordinates.HorizontalCoordinates) (SolarCheck, error) {
+	sun := g.Sun()
+	separation := sun.AngularSeparation(target.Altitude, target.Azimuth)
+	check := SolarCheck{
+		Sun:        sun,
+		Separation: separation,
+		Zone:       coordinates.GetSafetyZone(separation),
+	}
+
+	if !g.enabled || !sun.IsSunAboveHorizon() || separation >= g.minSeparation {
+		return check, nil
+	}
+	if !g.filterInstalled {
+		return check, &SolarError{Separation: separation, MinSeparation: g.minSeparation}
+	}
+	check.FilterRequired = true
+	return check, nil
+}
@@ -0,0 +1,85 @@
+package safety
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+var testObserver = coordinates.Observer{Location: coordinates.Geographic{Latitude: 35, Longitude: -80}}
+
+// newTestGuard creates a guard whose clock is fixed at noon or midnight
+// local time in June.
+func newTestGuard(cfg config.TelescopeConfig, day bool) *SolarGuard {
+	at := time.Date(2024, 6, 21, 17, 0, 0, 0, time.UTC)
+	if !day {
+		at = at.Add(12 * time.Hour)
+	}
+	g := NewSolarGuard(cfg, testObserver)
+	g.now = func() time.Time { return at }
+	return g
+}
+
+// TestSolarGuardCheck tests which targets near the sun are refused.
+func TestSolarGuardCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config.TelescopeConfig
+		enforce        bool
+		day            bool
+		offset         float64 // Degrees below the sun
+		wantErr        bool
+		wantFilterWarn bool
+	}{
+		{"Far from the sun", config.TelescopeConfig{SolarSafetyEnabled: true}, false, true, 40, false, false},
+		{"Near the sun", config.TelescopeConfig{SolarSafetyEnabled: true}, false, true, 10, true, false},
+		{"Near the sun with custom minimum", config.TelescopeConfig{SolarSafetyEnabled: true, MinSolarSeparation: 5}, false, true, 10, false, false},
+		{"Near the sun with filter", config.TelescopeConfig{SolarSafetyEnabled: true, SolarFilterInstalled: true}, false, true, 10, false, true},
+		{"Safety disabled", config.TelescopeConfig{}, false, true, 10, false, false},
+		{"Safety enforced", config.TelescopeConfig{}, true, true, 10, true, false},
+		{"Sun below the horizon", config.TelescopeConfig{SolarSafetyEnabled: true}, false, false, 10, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGuard(tt.cfg, tt.day)
+			if tt.enforce {
+				g.Enforce()
+			}
+			sun := g.Sun()
+			target := coordinates.HorizontalCoordinates{Altitude: sun.Altitude - tt.offset, Azimuth: sun.Azimuth}
+
+			check, err := g.Check(target)
+			if tt.wantErr != errors.Is(err, ErrSolarProximity) {
+				t.Errorf("Check error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if check.FilterRequired != tt.wantFilterWarn {
+				t.Errorf("FilterRequired = %v, want %v", check.FilterRequired, tt.wantFilterWarn)
+			}
+			if diff := check.Separation - tt.offset; diff > 0.01 || diff < -0.01 {
+				t.Errorf("Separation = %.2f°, want %.2f°", check.Separation, tt.offset)
+			}
+		})
+	}
+}
+
+// TestSolarGuardExclusionRadius tests when slews must be routed around the sun.
+func TestSolarGuardExclusionRadius(t *testing.T) {
+	guarded := newTestGuard(config.TelescopeConfig{SolarSafetyEnabled: true}, true)
+	if got := guarded.ExclusionRadius(guarded.Sun()); got != DefaultMinSolarSeparation {
+		t.Errorf("ExclusionRadius = %.0f°, want %.0f°", got, DefaultMinSolarSeparation)
+	}
+
+	filtered := newTestGuard(config.TelescopeConfig{SolarSafetyEnabled: true, SolarFilterInstalled: true}, true)
+	if got := filtered.ExclusionRadius(filtered.Sun()); got != 0 {
+		t.Errorf("ExclusionRadius with filter = %.0f°, want 0", got)
+	}
+
+	night := newTestGuard(config.TelescopeConfig{SolarSafetyEnabled: true}, false)
+	if got := night.ExclusionRadius(night.Sun()); got != 0 {
+		t.Errorf("ExclusionRadius at night = %.0f°, want 0", got)
+	}
+}
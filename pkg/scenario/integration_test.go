@@ -0,0 +1,195 @@
+package scenario
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/tracking"
+)
+
+// sampleInterval is how often the integration tests look at the scenarios,
+// about as often as the collector stores positions.
+const sampleInterval = 2 * time.Second
+
+var testStart = time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+
+// testObserver is the observer scenarios are played around.
+func testObserver() coordinates.Observer {
+	return coordinates.Observer{Location: testCenter}
+}
+
+// loadScenario loads a scenario from testdata.
+func loadScenario(t *testing.T, name string) *Scenario {
+	t.Helper()
+	s, err := Load(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", name, err)
+	}
+	return s
+}
+
+// sample is one position of the scenario's only flight.
+type sample struct {
+	at       time.Time
+	aircraft adsb.Aircraft
+	horiz    coordinates.HorizontalCoordinates
+}
+
+// play returns the scenario's single flight every sampleInterval, as seen by
+// observer.
+func play(t *testing.T, s *Scenario, observer coordinates.Observer) []sample {
+	t.Helper()
+	var samples []sample
+	for elapsed := time.Duration(0); elapsed <= s.Duration(); elapsed += sampleInterval {
+		aircraft := s.Aircraft(testCenter, testStart, elapsed)
+		if len(aircraft) != 1 {
+			t.Fatalf("Expected 1 aircraft at %v, got %d", elapsed, len(aircraft))
+		}
+		ac := aircraft[0]
+		at := testStart.Add(elapsed)
+		pos := coordinates.Geographic{
+			Latitude:  ac.Latitude,
+			Longitude: ac.Longitude,
+			Altitude:  ac.Altitude.Meters(),
+		}
+		samples = append(samples, sample{at, ac, coordinates.GeographicToHorizontal(pos, observer, at)})
+	}
+	return samples
+}
+
+// TestOverheadScenario tests the zenith keyhole: an aircraft passing
+// overhead is predicted to exceed the altitude limits, is lost as it climbs
+// through the maximum altitude, and is flagged as a zenith crossing.
+func TestOverheadScenario(t *testing.T) {
+	s := loadScenario(t, "overhead.json")
+	observer := testObserver()
+	limits := tracking.DefaultTrackingLimits()
+	samples := play(t, s, observer)
+
+	approach := tracking.PredictApproach(samples[0].aircraft, observer, samples[0].at, limits)
+	if !approach.EntersLimits || !approach.ExceedsLimits {
+		t.Errorf("Expected the approach to enter and exceed the limits, got %+v", approach)
+	}
+	if approach.ClosestRangeNM > 0.1 {
+		t.Errorf("Expected to pass overhead, closest approach %.2f nm", approach.ClosestRangeNM)
+	}
+
+	monitor := tracking.NewPassMonitor(limits, 5)
+	var events []tracking.PassEvent
+	zenith := false
+	for i, smp := range samples {
+		events = append(events, monitor.Update(smp.horiz.Altitude, true)...)
+		if i > 0 {
+			event, _ := tracking.CheckMeridianEvent(samples[i-1].horiz, smp.horiz, observer, limits, false)
+			zenith = zenith || event == tracking.ZenithCrossing
+		}
+	}
+
+	if len(events) < 2 || events[0] != tracking.ApproachingLimit || events[1] != tracking.TargetLost {
+		t.Errorf("Expected approaching limit then target lost, got %v", events)
+	}
+	if !zenith {
+		t.Error("Expected a zenith crossing")
+	}
+}
+
+// TestMeridianScenario tests an aircraft crossing north of the observer,
+// where azimuth wraps from 359° to 0°: an alt-az mount with a limited
+// azimuth range must not see the wrap as a meridian flip.
+func TestMeridianScenario(t *testing.T) {
+	s := loadScenario(t, "meridian.json")
+	observer := testObserver()
+	limits := tracking.DefaultTrackingLimits()
+	limits.AzimuthWrapLimit = 270
+	samples := play(t, s, observer)
+
+	crossed := false
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1].horiz, samples[i].horiz
+		if cur.Altitude < limits.MinAltitude {
+			continue
+		}
+		if prev.Azimuth > 270 && cur.Azimuth < 90 {
+			crossed = true
+		}
+
+		event, reason := tracking.CheckMeridianEvent(prev, cur, observer, limits, true)
+		if event != tracking.NoMeridianEvent {
+			t.Fatalf("At az %.1f° el %.1f°: unexpected %s", cur.Azimuth, cur.Altitude, reason)
+		}
+	}
+	if !crossed {
+		t.Error("Expected the flight to cross north within the limits")
+	}
+}
+
+// TestHandoverScenario tests handover between stations: the recommended
+// station and times match where the scripted flight actually goes.
+func TestHandoverScenario(t *testing.T) {
+	s := loadScenario(t, "handover.json")
+	limits := tracking.DefaultTrackingLimits()
+
+	station := func(id int, name string, loc coordinates.Geographic) tracking.Station {
+		return tracking.Station{ID: id, Name: name, Observer: coordinates.Observer{Location: loc}}
+	}
+	home := station(1, "Home", testCenter)
+	east := station(2, "East", coordinates.Destination(testCenter, 90, 20))
+	north := station(3, "North", coordinates.Destination(testCenter, 0, 80))
+
+	homeSamples := play(t, s, home.Observer)
+	eastSamples := play(t, s, east.Observer)
+
+	// Recommend once the flight has finished its turn and climb
+	var from sample
+	for _, smp := range homeSamples {
+		if smp.aircraft.VerticalRate == 0 && smp.aircraft.Track == 90 && smp.horiz.Altitude >= limits.MinAltitude {
+			from = smp
+			break
+		}
+	}
+	if from.at.IsZero() {
+		t.Fatal("Flight never levelled off within the home station's limits")
+	}
+
+	handover, ok := tracking.RecommendHandover(from.aircraft, home, []tracking.Station{north, east}, from.at, 15*time.Minute, limits)
+	if !ok {
+		t.Fatal("Expected a handover recommendation")
+	}
+	if handover.To.ID != east.ID {
+		t.Errorf("Expected handover to %s, got %s", east.Name, handover.To.Name)
+	}
+	if handover.Gap != 0 {
+		t.Errorf("Expected overlapping windows, got a %v gap", handover.Gap)
+	}
+
+	// Compare with when the flight really leaves home's limits and enters east's
+	truth := func(samples []sample, entering bool) time.Time {
+		for i := 1; i < len(samples); i++ {
+			if !samples[i].at.After(from.at) {
+				continue
+			}
+			wasIn := samples[i-1].horiz.Altitude >= limits.MinAltitude
+			isIn := samples[i].horiz.Altitude >= limits.MinAltitude
+			if entering && !wasIn && isIn || !entering && wasIn && !isIn {
+				return samples[i].at
+			}
+		}
+		return time.Time{}
+	}
+	leaves := truth(homeSamples, false)
+	enters := truth(eastSamples, true)
+	if leaves.IsZero() || enters.IsZero() {
+		t.Fatalf("Expected the flight to leave home (%v) and enter east (%v)", leaves, enters)
+	}
+
+	const tolerance = 15 * time.Second
+	if d := handover.LeavesAt.Sub(leaves); d < -tolerance || d > tolerance {
+		t.Errorf("Predicted leaving home at %v, actually %v", handover.LeavesAt.Format("15:04:05"), leaves.Format("15:04:05"))
+	}
+	if d := handover.EntersAt.Sub(enters); d < -tolerance || d > tolerance {
+		t.Errorf("Predicted entering east at %v, actually %v", handover.EntersAt.Format("15:04:05"), enters.Format("15:04:05"))
+	}
+}
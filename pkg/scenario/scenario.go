@@ -0,0 +1,352 @@
+// Package scenario plays scripted synthetic flights — when each aircraft
+// appears, where, and the turns, climbs and speed changes it flies — as an
+// ADS-B data source. Scenarios are deterministic, so they drive integration
+// tests of meridian, keyhole and handover behaviour, and the collector can
+// ingest them like any other feed (source type "scenario").
+//
+// Scenarios are JSON files, like the rest of the configuration. Flights start
+// at a bearing and distance from the observer and fly their legs in order,
+// then continue straight and level:
+//
+//	{
+//	  "name": "Overhead",
+//	  "duration_seconds": 900,
+//	  "flights": [{
+//	    "icao": "a00001", "callsign": "TEST1", "type": "B738",
+//	    "start_seconds": 0, "bearing_deg": 270, "distance_nm": 20,
+//	    "altitude_ft": 9000, "speed_kts": 250, "track_deg": 90,
+//	    "legs": [
+//	      {"seconds": 240},
+//	      {"turn_to_deg": 180, "turn": "right"},
+//	      {"climb_to_ft": 15000, "vertical_rate_fpm": 2000}
+//	    ]
+//	  }]
+//	}
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// Source identifies aircraft played from a scenario.
+const Source = "scenario"
+
+const (
+	// simStep is the integration step for flight paths
+	simStep = 1.0 // seconds
+
+	// DefaultTurnRate is a standard rate turn (deg/sec)
+	DefaultTurnRate = 3.0
+
+	// DefaultVerticalRate is used for climbs and descents without a rate (ft/min)
+	DefaultVerticalRate = 1500.0
+
+	// speedChangeRate is how fast aircraft accelerate and decelerate (knots/sec)
+	speedChangeRate = 1.0
+)
+
+// Scenario is a scripted set of flights.
+type Scenario struct {
+	// Name and Description identify the scenario in logs
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// DurationSeconds ends the scenario (0 = flights continue indefinitely)
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+
+	// Loop restarts the scenario when it ends (requires DurationSeconds)
+	Loop bool `json:"loop,omitempty"`
+
+	Flights []Flight `json:"flights"`
+}
+
+// Flight is one scripted aircraft.
+type Flight struct {
+	ICAO     string `json:"icao"`
+	Callsign string `json:"callsign,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Squawk   string `json:"squawk,omitempty"`
+
+	// StartSeconds is when the aircraft appears, and EndSeconds when it
+	// disappears (0 = at the end of the scenario)
+	StartSeconds float64 `json:"start_seconds,omitempty"`
+	EndSeconds   float64 `json:"end_seconds,omitempty"`
+
+	// BearingDeg and DistanceNM place the aircraft relative to the observer
+	// when it appears
+	BearingDeg float64 `json:"bearing_deg"`
+	DistanceNM float64 `json:"distance_nm"`
+
+	// Initial altitude (feet MSL), ground speed and track
+	AltitudeFt float64 `json:"altitude_ft"`
+	SpeedKts   float64 `json:"speed_kts"`
+	TrackDeg   float64 `json:"track_deg"`
+
+	// Legs are flown in order; afterwards the aircraft flies straight and level
+	Legs []Leg `json:"legs,omitempty"`
+}
+
+// Leg is one segment of a flight. A leg with Seconds lasts that long;
+// otherwise it ends once all of its targets (track, altitude, speed) are
+// reached. Targets are pursued together, so a leg can climb while turning.
+type Leg struct {
+	Seconds float64 `json:"seconds,omitempty"`
+
+	// TurnToDeg turns onto a track. Turn is "left", "right" or "" for the
+	// shorter way. TurnRate defaults to DefaultTurnRate.
+	TurnToDeg *float64 `json:"turn_to_deg,omitempty"`
+	Turn      string   `json:"turn,omitempty"`
+	TurnRate  float64  `json:"turn_rate,omitempty"`
+
+	// ClimbToFt climbs or descends to an altitude at VerticalRateFPM
+	// (defaults to DefaultVerticalRate)
+	ClimbToFt       *float64 `json:"climb_to_ft,omitempty"`
+	VerticalRateFPM float64  `json:"vertical_rate_fpm,omitempty"`
+
+	// SpeedToKts accelerates or decelerates to a ground speed
+	SpeedToKts *float64 `json:"speed_to_kts,omitempty"`
+}
+
+// Load reads and validates a scenario file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates a scenario.
+func Parse(data []byte) (*Scenario, error) {
+	var s Scenario
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks the scenario for obvious mistakes.
+func (s *Scenario) Validate() error {
+	if len(s.Flights) == 0 {
+		return errors.New("scenario has no flights")
+	}
+	if s.DurationSeconds < 0 {
+		return errors.New("duration_seconds must not be negative")
+	}
+	if s.Loop && s.DurationSeconds == 0 {
+		return errors.New("loop requires duration_seconds")
+	}
+
+	seen := make(map[string]bool)
+	for i, f := range s.Flights {
+		name := f.ICAO
+		if name == "" {
+			return fmt.Errorf("flight %d has no icao", i+1)
+		}
+		icao := strings.ToLower(f.ICAO)
+		if seen[icao] {
+			return fmt.Errorf("flight %s appears more than once", name)
+		}
+		seen[icao] = true
+
+		if f.SpeedKts <= 0 {
+			return fmt.Errorf("flight %s: speed_kts must be positive", name)
+		}
+		if f.StartSeconds < 0 || f.DistanceNM < 0 {
+			return fmt.Errorf("flight %s: start_seconds and distance_nm must not be negative", name)
+		}
+		if f.EndSeconds != 0 && f.EndSeconds <= f.StartSeconds {
+			return fmt.Errorf("flight %s: end_seconds must be after start_seconds", name)
+		}
+		for j, leg := range f.Legs {
+			if err := leg.validate(); err != nil {
+				return fmt.Errorf("flight %s leg %d: %w", name, j+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks a leg.
+func (l Leg) validate() error {
+	if l.Seconds < 0 || l.TurnRate < 0 || l.VerticalRateFPM < 0 {
+		return errors.New("seconds, turn_rate and vertical_rate_fpm must not be negative")
+	}
+	if l.Turn != "" && l.Turn != "left" && l.Turn != "right" {
+		return fmt.Errorf("turn must be \"left\" or \"right\", got %q", l.Turn)
+	}
+	if l.SpeedToKts != nil && *l.SpeedToKts <= 0 {
+		return errors.New("speed_to_kts must be positive")
+	}
+	if l.Seconds == 0 && l.TurnToDeg == nil && l.ClimbToFt == nil && l.SpeedToKts == nil {
+		return errors.New("leg needs seconds or a turn, climb or speed target")
+	}
+	return nil
+}
+
+// Duration returns the scenario's length (0 if unbounded).
+func (s *Scenario) Duration() time.Duration {
+	return time.Duration(s.DurationSeconds * float64(time.Second))
+}
+
+// Aircraft returns the aircraft present elapsed into a scenario started at
+// start around center, as they would be reported by a feed.
+func (s *Scenario) Aircraft(center coordinates.Geographic, start time.Time, elapsed time.Duration) []adsb.Aircraft {
+	t := elapsed.Seconds()
+	if s.DurationSeconds > 0 && t > s.DurationSeconds {
+		return nil
+	}
+
+	var aircraft []adsb.Aircraft
+	for i := range s.Flights {
+		f := &s.Flights[i]
+		if t < f.StartSeconds || (f.EndSeconds > 0 && t >= f.EndSeconds) {
+			continue
+		}
+
+		st := f.simulate(center, t-f.StartSeconds)
+		aircraft = append(aircraft, adsb.Aircraft{
+			ICAO:         strings.ToLower(f.ICAO),
+			Callsign:     f.Callsign,
+			Latitude:     st.position.Latitude,
+			Longitude:    st.position.Longitude,
+			Altitude:     units.Feet(st.altitudeFt),
+			GroundSpeed:  st.speedKts,
+			Track:        st.track,
+			VerticalRate: st.verticalRate,
+			AircraftType: f.Type,
+			Squawk:       f.Squawk,
+			Source:       Source,
+			LastSeen:     start.Add(elapsed),
+		})
+	}
+	return aircraft
+}
+
+// flightState is a flight's kinematic state.
+type flightState struct {
+	position     coordinates.Geographic
+	altitudeFt   float64
+	speedKts     float64
+	track        float64
+	verticalRate float64 // ft/min
+}
+
+// simulate flies the flight for t seconds from when it appears.
+func (f *Flight) simulate(center coordinates.Geographic, t float64) flightState {
+	st := flightState{
+		position:   coordinates.Destination(center, f.BearingDeg, f.DistanceNM),
+		altitudeFt: f.AltitudeFt,
+		speedKts:   f.SpeedKts,
+		track:      coordinates.NormalizeAzimuth(f.TrackDeg),
+	}
+
+	legIndex, legTime := 0, 0.0
+	for elapsed := 0.0; elapsed < t; {
+		dt := math.Min(simStep, t-elapsed)
+
+		var leg *Leg
+		if legIndex < len(f.Legs) {
+			leg = &f.Legs[legIndex]
+		}
+		reached := st.step(leg, dt)
+		elapsed += dt
+		legTime += dt
+
+		if leg != nil && ((leg.Seconds > 0 && legTime >= leg.Seconds) || (leg.Seconds == 0 && reached)) {
+			legIndex++
+			legTime = 0
+		}
+	}
+	return st
+}
+
+// step advances the state by dt seconds flying leg (nil = straight and
+// level) and reports whether all of the leg's targets have been reached.
+func (st *flightState) step(leg *Leg, dt float64) bool {
+	turnRate := 0.0
+	turned := false
+	reached := true
+	st.verticalRate = 0
+
+	if leg != nil && leg.TurnToDeg != nil {
+		remaining := turnRemaining(st.track, coordinates.NormalizeAzimuth(*leg.TurnToDeg), leg.Turn)
+		rate := leg.TurnRate
+		if rate == 0 {
+			rate = DefaultTurnRate
+		}
+		turn := math.Min(rate*dt, math.Abs(remaining))
+		if remaining < 0 {
+			turn = -turn
+		}
+		turnRate = turn / dt
+		turned = math.Abs(remaining) <= rate*dt
+		reached = turned
+	}
+
+	if leg != nil && leg.SpeedToKts != nil {
+		diff := *leg.SpeedToKts - st.speedKts
+		change := math.Max(-speedChangeRate*dt, math.Min(speedChangeRate*dt, diff))
+		st.speedKts += change
+		if change != diff {
+			reached = false
+		}
+	}
+
+	// Fly the average track over the step
+	distance := st.speedKts * dt / 3600
+	st.position = coordinates.Destination(st.position, st.track+turnRate*dt/2, distance)
+	st.track = coordinates.NormalizeAzimuth(st.track + turnRate*dt)
+	if turned {
+		// Exactly on the new track, so rounding can't start another circle
+		st.track = coordinates.NormalizeAzimuth(*leg.TurnToDeg)
+	}
+
+	if leg != nil && leg.ClimbToFt != nil {
+		rate := leg.VerticalRateFPM
+		if rate == 0 {
+			rate = DefaultVerticalRate
+		}
+		diff := *leg.ClimbToFt - st.altitudeFt
+		change := math.Max(-rate*dt/60, math.Min(rate*dt/60, diff))
+		st.altitudeFt += change
+		if change != diff {
+			reached = false
+			st.verticalRate = math.Copysign(rate, diff)
+		}
+	}
+
+	return reached
+}
+
+// turnRemaining returns the signed turn (degrees, positive = right) from
+// track onto target in the given direction ("" = the shorter way).
+func turnRemaining(track, target float64, direction string) float64 {
+	right := math.Mod(target-track+360, 360)
+	switch direction {
+	case "right":
+		return right
+	case "left":
+		if right == 0 {
+			return 0
+		}
+		return right - 360
+	}
+	if right > 180 {
+		return right - 360
+	}
+	return right
+}
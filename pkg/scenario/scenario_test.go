@@ -0,0 +1,188 @@
+package scenario
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+var testCenter = coordinates.Geographic{Latitude: 37.08, Longitude: -94.51, Altitude: 300}
+
+// TestParse tests scenario validation.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"Valid", `{"flights": [{"icao": "a1", "speed_kts": 200, "legs": [{"seconds": 10}, {"turn_to_deg": 90, "turn": "left"}]}]}`, ""},
+		{"No flights", `{"flights": []}`, "no flights"},
+		{"Missing ICAO", `{"flights": [{"speed_kts": 200}]}`, "no icao"},
+		{"Duplicate ICAO", `{"flights": [{"icao": "A1", "speed_kts": 200}, {"icao": "a1", "speed_kts": 200}]}`, "more than once"},
+		{"No speed", `{"flights": [{"icao": "a1"}]}`, "speed_kts"},
+		{"Ends before start", `{"flights": [{"icao": "a1", "speed_kts": 200, "start_seconds": 60, "end_seconds": 30}]}`, "end_seconds"},
+		{"Empty leg", `{"flights": [{"icao": "a1", "speed_kts": 200, "legs": [{}]}]}`, "leg 1"},
+		{"Bad turn", `{"flights": [{"icao": "a1", "speed_kts": 200, "legs": [{"turn_to_deg": 90, "turn": "up"}]}]}`, "turn must be"},
+		{"Loop without duration", `{"loop": true, "flights": [{"icao": "a1", "speed_kts": 200}]}`, "loop"},
+		{"Invalid JSON", `{"flights": [`, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.json))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestFlightLegs tests straight flight, turns, climbs and speed changes.
+func TestFlightLegs(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		flight    Flight
+		at        float64
+		wantTrack float64
+		wantAlt   float64
+		wantSpeed float64
+		wantVS    float64
+		wantNM    float64 // Distance from the start point; <0 to skip
+	}{
+		{
+			name:      "Straight and level",
+			flight:    Flight{AltitudeFt: 5000, SpeedKts: 360, TrackDeg: 90},
+			at:        60,
+			wantTrack: 90, wantAlt: 5000, wantSpeed: 360, wantNM: 6,
+		},
+		{
+			name:      "Right turn half done",
+			flight:    Flight{SpeedKts: 200, TrackDeg: 350, Legs: []Leg{{TurnToDeg: ptr(80), Turn: "right"}}},
+			at:        15,
+			wantTrack: 35, wantSpeed: 200, wantNM: -1,
+		},
+		{
+			name:      "Left turn the long way round finishes exactly",
+			flight:    Flight{SpeedKts: 200, TrackDeg: 0, Legs: []Leg{{TurnToDeg: ptr(90), Turn: "left", TurnRate: 6}}},
+			at:        120,
+			wantTrack: 90, wantSpeed: 200, wantNM: -1,
+		},
+		{
+			name:    "Climbing",
+			flight:  Flight{AltitudeFt: 3000, SpeedKts: 200, Legs: []Leg{{ClimbToFt: ptr(9000), VerticalRateFPM: 2000}}},
+			at:      60,
+			wantAlt: 5000, wantSpeed: 200, wantVS: 2000, wantNM: -1,
+		},
+		{
+			name:    "Descent then hold altitude",
+			flight:  Flight{AltitudeFt: 9000, SpeedKts: 200, Legs: []Leg{{ClimbToFt: ptr(8000)}, {Seconds: 60}}},
+			at:      120,
+			wantAlt: 8000, wantSpeed: 200, wantNM: -1,
+		},
+		{
+			name:      "Slowing down",
+			flight:    Flight{SpeedKts: 250, Legs: []Leg{{SpeedToKts: ptr(210)}}},
+			at:        30,
+			wantSpeed: 220, wantNM: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := tt.flight.simulate(testCenter, tt.at)
+
+			if math.Abs(st.track-tt.wantTrack) > 0.01 {
+				t.Errorf("Expected track %.1f, got %.3f", tt.wantTrack, st.track)
+			}
+			if math.Abs(st.altitudeFt-tt.wantAlt) > 0.01 {
+				t.Errorf("Expected altitude %.0f, got %.2f", tt.wantAlt, st.altitudeFt)
+			}
+			if math.Abs(st.speedKts-tt.wantSpeed) > 0.01 {
+				t.Errorf("Expected speed %.0f, got %.2f", tt.wantSpeed, st.speedKts)
+			}
+			if st.verticalRate != tt.wantVS {
+				t.Errorf("Expected vertical rate %.0f, got %.0f", tt.wantVS, st.verticalRate)
+			}
+			if tt.wantNM >= 0 {
+				if got := coordinates.DistanceNauticalMiles(testCenter, st.position); math.Abs(got-tt.wantNM) > 0.01 {
+					t.Errorf("Expected to fly %.2f nm, flew %.3f", tt.wantNM, got)
+				}
+			}
+		})
+	}
+}
+
+// TestPlaybackSource tests real-time playback, flight timing and looping.
+func TestPlaybackSource(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"duration_seconds": 300, "loop": true,
+		"flights": [
+			{"icao": "A00001", "bearing_deg": 0, "distance_nm": 5, "speed_kts": 120, "track_deg": 180, "altitude_ft": 3000},
+			{"icao": "a00002", "start_seconds": 60, "end_seconds": 120, "bearing_deg": 90, "distance_nm": 40, "speed_kts": 300, "altitude_ft": 30000}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	source := NewPlaybackSource(s, testCenter, start)
+	source.now = func() time.Time { return now }
+
+	tests := []struct {
+		name     string
+		at       time.Duration
+		radiusNM float64
+		want     []string
+	}{
+		{"Start", 0, 100, []string{"a00001"}},
+		{"Second flight appears", 90 * time.Second, 100, []string{"a00001", "a00002"}},
+		{"Radius filters", 90 * time.Second, 10, []string{"a00001"}},
+		{"Second flight gone", 150 * time.Second, 100, []string{"a00001"}},
+		{"Looped", 390 * time.Second, 100, []string{"a00001", "a00002"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = start.Add(tt.at)
+			aircraft, err := source.GetAircraft(testCenter.Latitude, testCenter.Longitude, tt.radiusNM)
+			if err != nil {
+				t.Fatalf("GetAircraft failed: %v", err)
+			}
+
+			var got []string
+			for _, ac := range aircraft {
+				got = append(got, ac.ICAO)
+				if ac.Source != Source || !ac.LastSeen.Equal(now) {
+					t.Errorf("%s: expected source %q seen at %v, got %q at %v", ac.ICAO, Source, now, ac.Source, ac.LastSeen)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// The first flight is at the center 150 s into each loop
+	now = start.Add(450 * time.Second)
+	ac, err := source.GetAircraftByICAO("A00001")
+	if err != nil || ac == nil {
+		t.Fatalf("GetAircraftByICAO failed: %v, %v", ac, err)
+	}
+	pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+	if d := coordinates.DistanceNauticalMiles(testCenter, pos); d > 0.01 {
+		t.Errorf("Expected the first flight overhead, it is %.3f nm away", d)
+	}
+}
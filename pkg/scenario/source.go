@@ -0,0 +1,86 @@
+package scenario
+
+import (
+	"strings"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// PlaybackSource plays a scenario in real time as an adsb.DataSource, so it
+// can be ingested like a receiver or online feed.
+type PlaybackSource struct {
+	scenario *Scenario
+	center   coordinates.Geographic
+	start    time.Time
+
+	// now returns the current time (replaced in tests)
+	now func() time.Time
+}
+
+// NewPlaybackSource starts playing a scenario around center at start.
+func NewPlaybackSource(s *Scenario, center coordinates.Geographic, start time.Time) *PlaybackSource {
+	return &PlaybackSource{
+		scenario: s,
+		center:   center,
+		start:    start,
+		now:      time.Now,
+	}
+}
+
+// Elapsed returns how far into the scenario playback is, wrapping around if
+// it loops.
+func (p *PlaybackSource) Elapsed() time.Duration {
+	return p.elapsedAt(p.now())
+}
+
+// elapsedAt returns how far into the scenario playback is at now.
+func (p *PlaybackSource) elapsedAt(now time.Time) time.Duration {
+	elapsed := now.Sub(p.start)
+	if p.scenario.Loop {
+		elapsed %= p.scenario.Duration()
+	}
+	return elapsed
+}
+
+// aircraft returns the aircraft present now.
+func (p *PlaybackSource) aircraft() []adsb.Aircraft {
+	now := p.now()
+	elapsed := p.elapsedAt(now)
+	return p.scenario.Aircraft(p.center, now.Add(-elapsed), elapsed)
+}
+
+// Finished reports whether a scenario that doesn't loop has ended.
+func (p *PlaybackSource) Finished() bool {
+	d := p.scenario.Duration()
+	return !p.scenario.Loop && d > 0 && p.now().Sub(p.start) > d
+}
+
+// GetAircraft implements adsb.DataSource.
+func (p *PlaybackSource) GetAircraft(centerLat, centerLon, radiusNM float64) ([]adsb.Aircraft, error) {
+	center := coordinates.Geographic{Latitude: centerLat, Longitude: centerLon}
+	var aircraft []adsb.Aircraft
+	for _, ac := range p.aircraft() {
+		pos := coordinates.Geographic{Latitude: ac.Latitude, Longitude: ac.Longitude}
+		if coordinates.DistanceNauticalMiles(center, pos) <= radiusNM {
+			aircraft = append(aircraft, ac)
+		}
+	}
+	return aircraft, nil
+}
+
+// GetAircraftByICAO implements adsb.DataSource.
+func (p *PlaybackSource) GetAircraftByICAO(icao string) (*adsb.Aircraft, error) {
+	for _, ac := range p.aircraft() {
+		if strings.EqualFold(ac.ICAO, icao) {
+			return &ac, nil
+		}
+	}
+	return nil, nil
+}
+
+// Close implements adsb.DataSource.
+func (p *PlaybackSource) Close() error {
+	return nil
+}
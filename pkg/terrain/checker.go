@@ -0,0 +1,38 @@
+package terrain
+
+import (
+	"github.com/unklstewy/ads-bscope/pkg/config"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// Checker decides which low aircraft are hidden by terrain.
+type Checker struct {
+	Model Model
+
+	// MaxElevation is the elevation angle in degrees up to which aircraft
+	// are checked
+	MaxElevation float64
+}
+
+// CheckerFromConfig returns the configured checker, or nil if no terrain
+// tiles are configured.
+func CheckerFromConfig(cfg config.TerrainConfig) (*Checker, error) {
+	if cfg.SRTMDir == "" {
+		return nil, nil
+	}
+	model, err := OpenSRTM(cfg.SRTMDir)
+	if err != nil {
+		return nil, err
+	}
+	return &Checker{Model: model, MaxElevation: cfg.GetMaxElevation()}, nil
+}
+
+// Blocked reports whether terrain hides a target seen from the observer at
+// the given elevation angle. Targets above MaxElevation aren't checked. A
+// nil Checker blocks nothing.
+func (c *Checker) Blocked(observer, target coordinates.Geographic, elevation float64) bool {
+	if c == nil || elevation > c.MaxElevation {
+		return false
+	}
+	return LineOfSight(c.Model, observer, target) != nil
+}
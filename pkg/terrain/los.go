@@ -0,0 +1,78 @@
+package terrain
+
+import (
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+const (
+	// sampleSpacingNM is the spacing of terrain samples along a line of
+	// sight, about that of 3 arc-second SRTM
+	sampleSpacingNM = 0.05
+
+	// maxSamples bounds the samples along one line of sight; longer lines
+	// are sampled more sparsely
+	maxSamples = 4000
+
+	// instrumentHeight is how far above the ground the telescope is
+	instrumentHeight = units.Meters(2)
+
+	// nearFieldNM is the distance from the observer within which terrain is
+	// ignored: the site's own cell and buildings are below the DEM's
+	// resolution
+	nearFieldNM = 0.1
+
+	// effectiveRadiusFactor scales the Earth's radius for standard optical
+	// refraction, which bends lines of sight over the horizon slightly
+	effectiveRadiusFactor = 7.0 / 6.0
+)
+
+// Obstruction is the terrain that first blocks a line of sight.
+type Obstruction struct {
+	// Location is the blocking point, with the ground elevation there
+	Location coordinates.Geographic
+
+	// DistanceNM is its distance from the observer
+	DistanceNM float64
+}
+
+// LineOfSight checks whether terrain blocks the line of sight from an
+// observer to a target (altitudes above mean sea level), sampling the model
+// along the great circle between them and allowing for the Earth's
+// curvature and refraction. The observer is taken to stand on the ground
+// where the model is higher than its configured elevation. Returns nil if
+// the line is clear or the model has no data along it.
+func LineOfSight(model Model, observer, target coordinates.Geographic) *Obstruction {
+	distanceNM := coordinates.DistanceNauticalMiles(observer, target)
+	spacing := max(sampleSpacingNM, distanceNM/maxSamples)
+
+	if ground, ok := model.Elevation(observer.Latitude, observer.Longitude); ok && ground > observer.Altitude {
+		observer.Altitude = ground
+	}
+	observer.Altitude += instrumentHeight
+
+	// The points' altitudes are the straight line between the two ends
+	points := coordinates.GreatCirclePoints(observer, target, spacing)
+	d := distanceNM * 1852
+	radius := coordinates.EarthRadiusKm * 1000 * effectiveRadiusFactor
+	for i := 1; i < len(points)-1; i++ {
+		x := d * float64(i) / float64(len(points)-1)
+		if x < nearFieldNM*1852 {
+			continue
+		}
+
+		p := points[i]
+		ground, ok := model.Elevation(p.Latitude, p.Longitude)
+		if !ok {
+			continue
+		}
+
+		// The Earth bulges up between the two ends
+		bulge := units.Meters(x * (d - x) / (2 * radius))
+		if ground+bulge > p.Altitude {
+			p.Altitude = ground
+			return &Obstruction{Location: p, DistanceNM: x / 1852}
+		}
+	}
+	return nil
+}
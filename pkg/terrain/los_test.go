@@ -0,0 +1,89 @@
+package terrain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// ridge is flat ground at sea level with a north-south ridge.
+type ridge struct {
+	lon    float64
+	height units.Meters
+}
+
+func (r ridge) Elevation(lat, lon float64) (units.Meters, bool) {
+	if math.Abs(lon-r.lon) < 0.01 {
+		return r.height, true
+	}
+	return 0, true
+}
+
+// noData has no elevation anywhere.
+type noData struct{}
+
+func (noData) Elevation(lat, lon float64) (units.Meters, bool) {
+	return 0, false
+}
+
+// TestLineOfSight tests terrain and curvature blocking the view of an
+// aircraft 30 NM east of the observer.
+func TestLineOfSight(t *testing.T) {
+	observer := coordinates.Geographic{}
+	aircraft := coordinates.Geographic{Longitude: 0.5, Altitude: 500}
+
+	tests := []struct {
+		name    string
+		model   Model
+		target  coordinates.Geographic
+		blocked bool
+	}{
+		{"Clear over a low ridge", ridge{lon: 0.25, height: 100}, aircraft, false},
+		{"Behind a high ridge", ridge{lon: 0.25, height: 1000}, aircraft, true},
+		{"Below the horizon", ridge{lon: 0.25, height: 0}, coordinates.Geographic{Longitude: 0.5, Altitude: 10}, true},
+		{"Without terrain data", noData{}, coordinates.Geographic{Longitude: 0.5, Altitude: 10}, false},
+		{"Observer's own hill ignored", ridge{lon: 0, height: 300}, aircraft, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LineOfSight(tt.model, observer, tt.target)
+			if (got != nil) != tt.blocked {
+				t.Fatalf("Expected blocked %v, got %+v", tt.blocked, got)
+			}
+		})
+	}
+
+	t.Run("Obstruction at the ridge", func(t *testing.T) {
+		got := LineOfSight(ridge{lon: 0.25, height: 1000}, observer, aircraft)
+		if got == nil {
+			t.Fatal("Expected an obstruction")
+		}
+		if math.Abs(got.Location.Longitude-0.25) > 0.01 || got.Location.Altitude != 1000 {
+			t.Errorf("Expected the ridge top at 0.25°E, got %+v", got.Location)
+		}
+		if math.Abs(got.DistanceNM-15) > 1 {
+			t.Errorf("Expected about 15 NM away, got %.1f NM", got.DistanceNM)
+		}
+	})
+}
+
+// TestCheckerBlocked tests that only low targets are checked.
+func TestCheckerBlocked(t *testing.T) {
+	observer := coordinates.Geographic{}
+	aircraft := coordinates.Geographic{Longitude: 0.5, Altitude: 500}
+	c := &Checker{Model: ridge{lon: 0.25, height: 1000}, MaxElevation: 15}
+
+	if !c.Blocked(observer, aircraft, 0.5) {
+		t.Error("Expected a low aircraft behind the ridge to be blocked")
+	}
+	if c.Blocked(observer, aircraft, 20) {
+		t.Error("Expected an aircraft above the maximum elevation not to be checked")
+	}
+
+	var disabled *Checker
+	if disabled.Blocked(observer, aircraft, 0.5) {
+		t.Error("Expected a nil checker to block nothing")
+	}
+}
@@ -0,0 +1,159 @@
+// Package terrain looks up ground elevation from SRTM tiles and checks
+// whether terrain blocks the line of sight from an observer to an aircraft,
+// so low aircraft hidden behind ridges aren't offered as targets in
+// mountainous regions.
+package terrain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// void is the SRTM value of cells without data
+const void = -32768
+
+// Model returns the ground elevation at a location.
+type Model interface {
+	// Elevation returns the elevation above mean sea level, or false where
+	// the model has no data (e.g. over the sea or outside its tiles)
+	Elevation(lat, lon float64) (units.Meters, bool)
+}
+
+// SRTM is an elevation model of SRTM .hgt tiles in a directory, named by
+// their south-west corner (e.g. N35W081.hgt). Both the 1 arc-second (3601
+// samples square) and 3 arc-second (1201) resolutions are read. Tiles are
+// loaded on first use and kept in memory; missing tiles have no data. It is
+// safe for concurrent use.
+type SRTM struct {
+	dir string
+
+	mu    sync.Mutex
+	tiles map[tileKey]*tile // nil for tiles that are missing or unreadable
+}
+
+// tileKey is a tile's south-west corner in whole degrees.
+type tileKey struct {
+	lat, lon int
+}
+
+// tile is one tile's samples, rows from north to south.
+type tile struct {
+	size    int // Samples per row and column
+	samples []int16
+}
+
+// OpenSRTM returns the elevation model of the tiles in dir. The tiles'
+// sizes are checked up front, so a truncated download is reported rather
+// than read as missing.
+func OpenSRTM(dir string) (*SRTM, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.hgt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no SRTM .hgt tiles in %s", dir)
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read terrain tile: %w", err)
+		}
+		if tileSize(info.Size()) == 0 {
+			return nil, fmt.Errorf("terrain tile %s has unexpected size %d bytes", path, info.Size())
+		}
+	}
+	return &SRTM{dir: dir, tiles: make(map[tileKey]*tile)}, nil
+}
+
+// tileSize returns the samples per row of a tile file of n bytes, or 0 if it
+// isn't an SRTM tile.
+func tileSize(n int64) int {
+	for _, size := range []int{3601, 1201} {
+		if n == int64(size*size*2) {
+			return size
+		}
+	}
+	return 0
+}
+
+// Elevation returns the elevation at a location, interpolated between the
+// four surrounding samples.
+func (s *SRTM) Elevation(lat, lon float64) (units.Meters, bool) {
+	key := tileKey{lat: int(math.Floor(lat)), lon: int(math.Floor(lon))}
+	t := s.tile(key)
+	if t == nil {
+		return 0, false
+	}
+
+	// Sample coordinates within the tile, from its north-west corner
+	n := float64(t.size - 1)
+	x := (lon - float64(key.lon)) * n
+	y := (float64(key.lat+1) - lat) * n
+	col := min(int(x), t.size-2)
+	row := min(int(y), t.size-2)
+	fx, fy := x-float64(col), y-float64(row)
+
+	var corners [4]float64
+	for i, at := range [4][2]int{{row, col}, {row, col + 1}, {row + 1, col}, {row + 1, col + 1}} {
+		v := t.samples[at[0]*t.size+at[1]]
+		if v == void {
+			return 0, false
+		}
+		corners[i] = float64(v)
+	}
+	top := corners[0] + fx*(corners[1]-corners[0])
+	bottom := corners[2] + fx*(corners[3]-corners[2])
+	return units.Meters(top + fy*(bottom-top)), true
+}
+
+// tile returns a tile, loading it on first use.
+func (s *SRTM) tile(key tileKey) *tile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.tiles[key]; ok {
+		return t
+	}
+	// Tiles that can't be read are remembered as missing
+	t, _ := readTile(filepath.Join(s.dir, tileName(key)))
+	s.tiles[key] = t
+	return t
+}
+
+// tileName returns the file name of a tile, e.g. "N35W081.hgt".
+func tileName(key tileKey) string {
+	ns, ew := 'N', 'E'
+	lat, lon := key.lat, key.lon
+	if lat < 0 {
+		ns, lat = 'S', -lat
+	}
+	if lon < 0 {
+		ew, lon = 'W', -lon
+	}
+	return fmt.Sprintf("%c%02d%c%03d.hgt", ns, lat, ew, lon)
+}
+
+// readTile reads a tile of big-endian 16-bit samples.
+func readTile(path string) (*tile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	size := tileSize(int64(len(data)))
+	if size == 0 {
+		return nil, fmt.Errorf("terrain tile %s has unexpected size %d bytes", path, len(data))
+	}
+
+	samples := make([]int16, size*size)
+	for i := range samples {
+		samples[i] = int16(binary.BigEndian.Uint16(data[2*i:]))
+	}
+	return &tile{size: size, samples: samples}, nil
+}
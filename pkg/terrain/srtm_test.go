@@ -0,0 +1,91 @@
+package terrain
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTile writes a 3 arc-second tile whose elevation is its column
+// number, with a void in the north-west corner.
+func writeTile(t *testing.T, dir, name string) {
+	t.Helper()
+	const size = 1201
+	data := make([]byte, size*size*2)
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; col++ {
+			v := uint16(col)
+			if row == 0 && col == 0 {
+				v = 0x8000 // -32768
+			}
+			binary.BigEndian.PutUint16(data[2*(row*size+col):], v)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSRTMElevation tests tile lookup and interpolation.
+func TestSRTMElevation(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenSRTM(dir); err == nil {
+		t.Error("Expected an error without tiles")
+	}
+
+	writeTile(t, dir, "N35W081.hgt")
+	model, err := OpenSRTM(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		expected float64
+		ok       bool
+	}{
+		{"West edge", 35.5, -81, 0, true},
+		{"Middle", 35.5, -80.5, 600, true},
+		{"Between samples", 35.5, -81 + 10.5/1200, 10.5, true},
+		{"Void", 35.99999, -80.99999, 0, false},
+		{"Missing tile", 36.5, -80.5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := model.Elevation(tt.lat, tt.lon)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if ok && math.Abs(float64(got)-tt.expected) > 1e-3 {
+				t.Errorf("Expected %.2fm, got %.2fm", tt.expected, float64(got))
+			}
+		})
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "N36W081.hgt"), []byte("truncated"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenSRTM(dir); err == nil {
+		t.Error("Expected an error for a truncated tile")
+	}
+}
+
+// TestTileName tests tile names in each hemisphere.
+func TestTileName(t *testing.T) {
+	tests := []struct {
+		key      tileKey
+		expected string
+	}{
+		{tileKey{35, -81}, "N35W081.hgt"},
+		{tileKey{-34, 151}, "S34E151.hgt"},
+		{tileKey{0, 0}, "N00E000.hgt"},
+	}
+	for _, tt := range tests {
+		if got := tileName(tt.key); got != tt.expected {
+			t.Errorf("tileName(%v) = %q, expected %q", tt.key, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Package theme defines the color palettes of the terminal clients.
+//
+// Colors are "#rrggbb" strings, which lipgloss and tcell both accept, so
+// each client converts them with its own UI library. The default palette
+// reproduces the original xterm-256 colors; the others trade hue for
+// brightness where the default relies on telling red from green.
+package theme
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultName is the theme used when none is configured.
+const DefaultName = "default"
+
+// Data age thresholds for the Fresh, Stale and Lost colors
+const (
+	StaleAfter = 30 * time.Second
+	LostAfter  = 60 * time.Second
+)
+
+// Palette maps UI roles to colors.
+type Palette struct {
+	// Text is regular text; Muted is labels and tooltips; Faint is help
+	// lines; Border is panel borders and read-only fields
+	Text   string
+	Muted  string
+	Faint  string
+	Border string
+
+	// Header is section headers; Accent is the active field or item;
+	// Title and TitleBackground are the title bar; Highlight is the
+	// background of the selected row
+	Header          string
+	Accent          string
+	Title           string
+	TitleBackground string
+	Highlight       string
+
+	// OK, Warning and Error are status messages
+	OK      string
+	Warning string
+	Error   string
+
+	// Fresh, Stale and Lost color an aircraft's data age (see AgeColor)
+	Fresh string
+	Stale string
+	Lost  string
+
+	// Aircraft, Selected, Tracked and Watched (on a watch list) are
+	// aircraft symbols; Telescope and Commanded are the telescope crosshair
+	// and its commanded positions
+	Aircraft  string
+	Selected  string
+	Tracked   string
+	Watched   string
+	Telescope string
+	Commanded string
+
+	// Sky and radar furniture: Grid is range rings and grid lines, Label
+	// is their labels, Trail is breadcrumbs, Vector is velocity vectors,
+	// Observer is the observer or radar centre, Airport is airport symbols
+	Grid     string
+	Label    string
+	Trail    string
+	Vector   string
+	Observer string
+	Airport  string
+
+	// Rings color the range rings at 5, 10, 25 and 50 NM
+	Rings [4]string
+}
+
+// AgeColor returns the color for data last updated age ago: Fresh, Stale
+// after StaleAfter, Lost after LostAfter.
+func (p Palette) AgeColor(age time.Duration) string {
+	switch {
+	case age > LostAfter:
+		return p.Lost
+	case age > StaleAfter:
+		return p.Stale
+	default:
+		return p.Fresh
+	}
+}
+
+// Theme is a named palette.
+type Theme struct {
+	Name        string
+	Description string
+	Palette
+}
+
+// themes lists the built-in themes in the order they are cycled through.
+var themes = []Theme{
+	{
+		Name:        DefaultName,
+		Description: "Standard colors",
+		Palette: Palette{
+			Text:            "#eeeeee",
+			Muted:           "#808080",
+			Faint:           "#626262",
+			Border:          "#585858",
+			Header:          "#00afff",
+			Accent:          "#00ffff",
+			Title:           "#5fffd7",
+			TitleBackground: "#262626",
+			Highlight:       "#3a3a3a",
+			OK:              "#00ff00",
+			Warning:         "#ffff00",
+			Error:           "#ff0000",
+			Fresh:           "#00ff00",
+			Stale:           "#ffff00",
+			Lost:            "#ff0000",
+			Aircraft:        "#5fafff",
+			Selected:        "#ffff00",
+			Tracked:         "#00ff00",
+			Watched:         "#ff5fd7",
+			Telescope:       "#ff8700",
+			Commanded:       "#af5f00",
+			Grid:            "#8a8a8a",
+			Label:           "#a8a8a8",
+			Trail:           "#3a3a3a",
+			Vector:          "#00afff",
+			Observer:        "#ff8700",
+			Airport:         "#800080",
+			Rings:           [4]string{"#00ff00", "#ffff00", "#ffaf00", "#ff0000"},
+		},
+	},
+	{
+		Name:        "high-contrast",
+		Description: "Bright colors on black, no dim grays",
+		Palette: Palette{
+			Text:            "#ffffff",
+			Muted:           "#d0d0d0",
+			Faint:           "#b2b2b2",
+			Border:          "#ffffff",
+			Header:          "#00ffff",
+			Accent:          "#ffff00",
+			Title:           "#000000",
+			TitleBackground: "#ffffff",
+			Highlight:       "#0000af",
+			OK:              "#00ff00",
+			Warning:         "#ffff00",
+			Error:           "#ff5f5f",
+			Fresh:           "#00ff00",
+			Stale:           "#ffff00",
+			Lost:            "#ff5f5f",
+			Aircraft:        "#ffffff",
+			Selected:        "#ffff00",
+			Tracked:         "#00ff00",
+			Watched:         "#ff8700",
+			Telescope:       "#ff00ff",
+			Commanded:       "#ff87ff",
+			Grid:            "#bcbcbc",
+			Label:           "#ffffff",
+			Trail:           "#8a8a8a",
+			Vector:          "#00ffff",
+			Observer:        "#ff00ff",
+			Airport:         "#ff87ff",
+			Rings:           [4]string{"#00ff00", "#ffff00", "#ff8700", "#ff5f5f"},
+		},
+	},
+	{
+		// Okabe-Ito colors, distinguishable with red-green color blindness:
+		// data age goes sky blue, yellow, vermillion
+		Name:        "deuteranopia",
+		Description: "Red-green color-blind safe (blue/yellow/vermillion)",
+		Palette: Palette{
+			Text:            "#eeeeee",
+			Muted:           "#808080",
+			Faint:           "#626262",
+			Border:          "#585858",
+			Header:          "#56b4e9",
+			Accent:          "#f0e442",
+			Title:           "#56b4e9",
+			TitleBackground: "#262626",
+			Highlight:       "#3a3a3a",
+			OK:              "#56b4e9",
+			Warning:         "#f0e442",
+			Error:           "#d55e00",
+			Fresh:           "#56b4e9",
+			Stale:           "#f0e442",
+			Lost:            "#d55e00",
+			Aircraft:        "#bcbcbc",
+			Selected:        "#f0e442",
+			Tracked:         "#56b4e9",
+			Watched:         "#ffffff",
+			Telescope:       "#e69f00",
+			Commanded:       "#cc79a7",
+			Grid:            "#8a8a8a",
+			Label:           "#a8a8a8",
+			Trail:           "#4e4e4e",
+			Vector:          "#0072b2",
+			Observer:        "#e69f00",
+			Airport:         "#cc79a7",
+			Rings:           [4]string{"#56b4e9", "#f0e442", "#e69f00", "#d55e00"},
+		},
+	},
+	{
+		// Dim reds only, to keep night vision at the eyepiece; roles are
+		// told apart by brightness
+		Name:        "red",
+		Description: "Red night vision",
+		Palette: Palette{
+			Text:            "#d70000",
+			Muted:           "#870000",
+			Faint:           "#5f0000",
+			Border:          "#5f0000",
+			Header:          "#ff0000",
+			Accent:          "#ff5f5f",
+			Title:           "#d70000",
+			TitleBackground: "#1c0000",
+			Highlight:       "#3a0000",
+			OK:              "#d70000",
+			Warning:         "#ff5f5f",
+			Error:           "#ff8787",
+			Fresh:           "#ff5f5f",
+			Stale:           "#af0000",
+			Lost:            "#5f0000",
+			Aircraft:        "#af0000",
+			Selected:        "#ff5f5f",
+			Tracked:         "#ff8787",
+			Watched:         "#d75f5f",
+			Telescope:       "#ff0000",
+			Commanded:       "#870000",
+			Grid:            "#5f0000",
+			Label:           "#870000",
+			Trail:           "#3a0000",
+			Vector:          "#870000",
+			Observer:        "#ff0000",
+			Airport:         "#870000",
+			Rings:           [4]string{"#ff5f5f", "#d70000", "#af0000", "#870000"},
+		},
+	},
+}
+
+// Names returns the names of the built-in themes.
+func Names() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Lookup returns the theme with the given name (case-insensitive). An
+// empty name is the default theme.
+func Lookup(name string) (Theme, error) {
+	if name == "" {
+		return themes[0], nil
+	}
+	for _, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// Next returns the theme after the named one, wrapping around, for
+// switching themes at runtime. An unknown name gives the default theme.
+func Next(name string) Theme {
+	for i, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return themes[0]
+}
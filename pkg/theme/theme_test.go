@@ -0,0 +1,88 @@
+package theme
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// TestLookup tests finding themes by name.
+func TestLookup(t *testing.T) {
+	t.Run("Empty name is the default", func(t *testing.T) {
+		th, err := Lookup("")
+		if err != nil || th.Name != DefaultName {
+			t.Errorf("Expected default theme, got %q (%v)", th.Name, err)
+		}
+	})
+
+	t.Run("Names are case-insensitive", func(t *testing.T) {
+		th, err := Lookup("Deuteranopia")
+		if err != nil || th.Name != "deuteranopia" {
+			t.Errorf("Expected deuteranopia theme, got %q (%v)", th.Name, err)
+		}
+	})
+
+	t.Run("Unknown name is an error", func(t *testing.T) {
+		if _, err := Lookup("sepia"); err == nil {
+			t.Error("Expected error for unknown theme")
+		}
+	})
+}
+
+// TestNext tests cycling through the themes.
+func TestNext(t *testing.T) {
+	names := Names()
+	name := DefaultName
+	for i := 0; i < len(names); i++ {
+		name = Next(name).Name
+	}
+	if name != DefaultName {
+		t.Errorf("Expected to cycle back to %q after %d themes, got %q", DefaultName, len(names), name)
+	}
+	if got := Next("sepia").Name; got != DefaultName {
+		t.Errorf("Expected default after unknown theme, got %q", got)
+	}
+}
+
+// TestPalettesComplete tests that every theme sets every color.
+func TestPalettesComplete(t *testing.T) {
+	hex := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	for _, th := range themes {
+		p := reflect.ValueOf(th.Palette)
+		for i := 0; i < p.NumField(); i++ {
+			field := p.Type().Field(i).Name
+			var colors []string
+			if v, ok := p.Field(i).Interface().(string); ok {
+				colors = []string{v}
+			} else {
+				rings := p.Field(i).Interface().([4]string)
+				colors = rings[:]
+			}
+			for _, c := range colors {
+				if !hex.MatchString(c) {
+					t.Errorf("%s: %s = %q, want #rrggbb", th.Name, field, c)
+				}
+			}
+		}
+	}
+}
+
+// TestAgeColor tests the data age colors.
+func TestAgeColor(t *testing.T) {
+	p := themes[0].Palette
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{5 * time.Second, p.Fresh},
+		{StaleAfter, p.Fresh},
+		{45 * time.Second, p.Stale},
+		{2 * time.Minute, p.Lost},
+	}
+	for _, tt := range tests {
+		if got := p.AgeColor(tt.age); got != tt.want {
+			t.Errorf("AgeColor(%v) = %s, want %s", tt.age, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package tracking
+
+import (
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// PredictionMode is how a commanded position was worked out from the
+// aircraft's last report.
+type PredictionMode string
+
+const (
+	// PredictionLive means the report was fresh enough to point at as is
+	PredictionLive PredictionMode = "live"
+
+	// PredictionDeadReckoning means the report was extrapolated along the
+	// aircraft's track, speed and vertical rate
+	PredictionDeadReckoning PredictionMode = "dead_reckoning"
+
+	// PredictionHold means the report was extrapolated around a detected
+	// holding pattern
+	PredictionHold PredictionMode = "hold"
+
+	// PredictionFlightPlan means the report was extrapolated along the
+	// aircraft's flight plan route
+	PredictionFlightPlan PredictionMode = "flight_plan"
+
+	// PredictionAirway means the report was extrapolated along a matching
+	// airway
+	PredictionAirway PredictionMode = "airway"
+)
+
+// PredictionModes lists the modes in the order reports show them.
+var PredictionModes = []PredictionMode{PredictionLive, PredictionDeadReckoning, PredictionFlightPlan, PredictionAirway, PredictionHold}
+
+const (
+	// liveDataAge is the report age (seconds) below which a position counts
+	// as live rather than predicted
+	liveDataAge = 1.0
+
+	// maxTruthGap is the longest gap between reports that a commanded
+	// position is assessed across; wider gaps are skipped because the
+	// interpolated truth would be a guess itself
+	maxTruthGap = 30 * time.Second
+
+	// maxCommandInterval is the longest gap between commands that counts
+	// towards the update lag. Longer gaps mean the telescope was away (e.g.
+	// on another target while time slicing), not waiting for an update.
+	maxCommandInterval = 10 * time.Second
+)
+
+// ClassifyPrediction returns the prediction mode for a position computed
+// from a report dataAge seconds old.
+func ClassifyPrediction(dataAge float64, holding bool) PredictionMode {
+	switch {
+	case holding:
+		return PredictionHold
+	case dataAge < liveDataAge:
+		return PredictionLive
+	default:
+		return PredictionDeadReckoning
+	}
+}
+
+// PointingSample is one position the telescope was commanded to during a pass.
+type PointingSample struct {
+	Time       time.Time
+	Commanded  coordinates.HorizontalCoordinates
+	DataAge    float64 // Age of the report the position was predicted from (seconds)
+	Mode       PredictionMode
+	Confidence float64 // Confidence of the prediction (0-1)
+}
+
+// LatencyBreakdown splits the pointing error a pass was exposed to by its
+// source. All angles are RMS over the assessed commands, in degrees.
+type LatencyBreakdown struct {
+	// MeanDataAge is the average age of the reports commands were based on
+	// (seconds), and DataAgeDeg how far the aircraft moved across the sky in
+	// that time: the error had positions not been predicted. Compare with
+	// the pass's RMS error to see how much prediction recovered.
+	MeanDataAge float64
+	DataAgeDeg  float64
+
+	// MeanInterval is the average time between commands (seconds), and
+	// UpdateLagDeg how far the aircraft moved in half that time: the
+	// average error while the mount waits for the next command.
+	MeanInterval float64
+	UpdateLagDeg float64
+}
+
+// PassAccuracy is how well the telescope followed an aircraft through a pass.
+type PassAccuracy struct {
+	// Commands is the number of positions commanded, and Assessed how many
+	// of them had reports either side to compare against
+	Commands int
+	Assessed int
+
+	// RMSErrorDeg and MaxErrorDeg are the angles between the commanded
+	// positions and where the aircraft actually was at the time
+	RMSErrorDeg float64
+	MaxErrorDeg float64
+	MaxErrorAt  time.Time
+
+	Latency LatencyBreakdown
+
+	// ModeUsage is the percentage of commands in each prediction mode
+	ModeUsage map[PredictionMode]float64
+}
+
+// AnalyzePass compares the commanded positions of a pass with the aircraft's
+// reported track (oldest first), which is known after the fact. The actual
+// position at each command is interpolated between the reports either side
+// of it, so commands after the last report are not assessed.
+func AnalyzePass(samples []PointingSample, track []adsb.Aircraft, observer coordinates.Observer) PassAccuracy {
+	result := PassAccuracy{
+		Commands:  len(samples),
+		ModeUsage: make(map[PredictionMode]float64),
+	}
+	if len(samples) == 0 {
+		return result
+	}
+
+	for _, s := range samples {
+		result.ModeUsage[s.Mode]++
+	}
+	for mode, count := range result.ModeUsage {
+		result.ModeUsage[mode] = 100 * count / float64(len(samples))
+	}
+
+	var sumErr, sumAge, sumAgeDeg, sumInterval, sumLagDeg float64
+	intervals := 0
+	var previous time.Time
+	for _, s := range samples {
+		interval := s.Time.Sub(previous)
+		previous = s.Time
+
+		actual, rate, ok := actualPosition(track, s.Time, observer)
+		if !ok {
+			continue
+		}
+
+		errDeg := coordinates.AngularSeparation(s.Commanded, actual)
+		result.Assessed++
+		sumErr += errDeg * errDeg
+		if errDeg > result.MaxErrorDeg {
+			result.MaxErrorDeg = errDeg
+			result.MaxErrorAt = s.Time
+		}
+
+		sumAge += s.DataAge
+		ageDeg := rate * s.DataAge
+		sumAgeDeg += ageDeg * ageDeg
+
+		if interval > 0 && interval <= maxCommandInterval {
+			intervals++
+			sumInterval += interval.Seconds()
+			lagDeg := rate * interval.Seconds() / 2
+			sumLagDeg += lagDeg * lagDeg
+		}
+	}
+
+	if result.Assessed > 0 {
+		n := float64(result.Assessed)
+		result.RMSErrorDeg = math.Sqrt(sumErr / n)
+		result.Latency.MeanDataAge = sumAge / n
+		result.Latency.DataAgeDeg = math.Sqrt(sumAgeDeg / n)
+	}
+	if intervals > 0 {
+		n := float64(intervals)
+		result.Latency.MeanInterval = sumInterval / n
+		result.Latency.UpdateLagDeg = math.Sqrt(sumLagDeg / n)
+	}
+	return result
+}
+
+// actualPosition interpolates where the aircraft was in the sky at t from
+// the reports either side of it, and how fast it was moving across the sky
+// (degrees/second). Returns false if t isn't between two reports close
+// enough together.
+func actualPosition(track []adsb.Aircraft, t time.Time, observer coordinates.Observer) (coordinates.HorizontalCoordinates, float64, bool) {
+	for i := 1; i < len(track); i++ {
+		before, after := track[i-1], track[i]
+		if t.Before(before.LastSeen) || t.After(after.LastSeen) {
+			continue
+		}
+		gap := after.LastSeen.Sub(before.LastSeen)
+		if gap <= 0 {
+			continue
+		}
+		if gap > maxTruthGap {
+			return coordinates.HorizontalCoordinates{}, 0, false
+		}
+
+		from, to := reportPosition(before), reportPosition(after)
+		fraction := t.Sub(before.LastSeen).Seconds() / gap.Seconds()
+		pos := coordinates.Destination(from, coordinates.Bearing(from, to),
+			coordinates.DistanceNauticalMiles(from, to)*fraction)
+		pos.Altitude = from.Altitude + (to.Altitude-from.Altitude)*units.Meters(fraction)
+
+		fromHoriz := coordinates.GeographicToHorizontal(from, observer, before.LastSeen)
+		toHoriz := coordinates.GeographicToHorizontal(to, observer, after.LastSeen)
+		rate := coordinates.AngularSeparation(fromHoriz, toHoriz) / gap.Seconds()
+
+		return coordinates.GeographicToHorizontal(pos, observer, t), rate, true
+	}
+	return coordinates.HorizontalCoordinates{}, 0, false
+}
+
+// reportPosition returns a report's position with the altitude in meters.
+func reportPosition(ac adsb.Aircraft) coordinates.Geographic {
+	return coordinates.Geographic{
+		Latitude:  ac.Latitude,
+		Longitude: ac.Longitude,
+		Altitude:  ac.Altitude.Meters(),
+	}
+}
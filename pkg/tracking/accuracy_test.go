@@ -0,0 +1,139 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestClassifyPrediction tests prediction modes from report age.
+func TestClassifyPrediction(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataAge float64
+		holding bool
+		want    PredictionMode
+	}{
+		{"Fresh report", 0.4, false, PredictionLive},
+		{"Stale report", 3, false, PredictionDeadReckoning},
+		{"Holding", 0.2, true, PredictionHold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyPrediction(tt.dataAge, tt.holding); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAnalyzePass tests pointing error and latency against a known track.
+func TestAnalyzePass(t *testing.T) {
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Eastbound at 300 kts, 10 nm north of the observer, reported every 2 s for a minute
+	origin := coordinates.Destination(observer.Location, 0, 10)
+	var track []adsb.Aircraft
+	for i := 0; i <= 30; i++ {
+		pos := coordinates.Destination(origin, 90, 300*float64(2*i)/3600)
+		track = append(track, adsb.Aircraft{
+			ICAO: "abc123", Latitude: pos.Latitude, Longitude: pos.Longitude,
+			Altitude: 10000, GroundSpeed: 300, Track: 90,
+			LastSeen: start.Add(time.Duration(2*i) * time.Second),
+		})
+	}
+	at := func(seconds float64) coordinates.HorizontalCoordinates {
+		pos := coordinates.Destination(origin, 90, 300*seconds/3600)
+		pos.Altitude = units.Feet(10000).Meters()
+		return coordinates.GeographicToHorizontal(pos, observer, start.Add(time.Duration(seconds*float64(time.Second))))
+	}
+
+	// commands points every 2 s from 1 s in, where offset says, until the track ends
+	commands := func(offset func(seconds float64) coordinates.HorizontalCoordinates, dataAge float64, mode PredictionMode) []PointingSample {
+		var samples []PointingSample
+		for s := 1.0; s < 60; s += 2 {
+			samples = append(samples, PointingSample{
+				Time:      start.Add(time.Duration(s * float64(time.Second))),
+				Commanded: offset(s),
+				DataAge:   dataAge,
+				Mode:      mode,
+			})
+		}
+		return samples
+	}
+
+	t.Run("Perfect pointing", func(t *testing.T) {
+		result := AnalyzePass(commands(at, 1, PredictionDeadReckoning), track, observer)
+		if result.Commands != 30 || result.Assessed != 30 {
+			t.Errorf("Expected 30 commands assessed, got %d of %d", result.Assessed, result.Commands)
+		}
+		if result.RMSErrorDeg > 0.01 || result.MaxErrorDeg > 0.01 {
+			t.Errorf("Expected no error, got RMS %.3f° max %.3f°", result.RMSErrorDeg, result.MaxErrorDeg)
+		}
+		if math.Abs(result.Latency.MeanInterval-2) > 1e-9 || math.Abs(result.Latency.MeanDataAge-1) > 1e-9 {
+			t.Errorf("Expected 2 s interval and 1 s data age, got %.2f s and %.2f s",
+				result.Latency.MeanInterval, result.Latency.MeanDataAge)
+		}
+		// Aging 1 s and waiting 1 s on average are the same motion
+		if result.Latency.DataAgeDeg <= 0 || math.Abs(result.Latency.DataAgeDeg-result.Latency.UpdateLagDeg) > 0.01*result.Latency.DataAgeDeg {
+			t.Errorf("Expected equal non-zero data age and update lag, got %.4f° and %.4f°",
+				result.Latency.DataAgeDeg, result.Latency.UpdateLagDeg)
+		}
+		if result.ModeUsage[PredictionDeadReckoning] != 100 {
+			t.Errorf("Expected 100%% dead reckoning, got %v", result.ModeUsage)
+		}
+	})
+
+	t.Run("Pointing at stale reports", func(t *testing.T) {
+		stale := func(s float64) coordinates.HorizontalCoordinates { return at(s - 4) }
+		result := AnalyzePass(commands(stale, 4, PredictionLive), track, observer)
+		if result.RMSErrorDeg < 0.1 {
+			t.Fatalf("Expected an error pointing 4 s behind, got %.3f°", result.RMSErrorDeg)
+		}
+		// Without prediction the error is the data age contribution
+		if math.Abs(result.RMSErrorDeg-result.Latency.DataAgeDeg) > 0.05*result.RMSErrorDeg {
+			t.Errorf("Expected RMS error %.3f° to match the data age contribution %.3f°",
+				result.RMSErrorDeg, result.Latency.DataAgeDeg)
+		}
+	})
+
+	t.Run("Constant offset", func(t *testing.T) {
+		high := func(s float64) coordinates.HorizontalCoordinates {
+			h := at(s)
+			h.Altitude++
+			return h
+		}
+		result := AnalyzePass(commands(high, 0, PredictionLive), track, observer)
+		if math.Abs(result.RMSErrorDeg-1) > 0.01 || math.Abs(result.MaxErrorDeg-1) > 0.01 {
+			t.Errorf("Expected 1° RMS and max error, got %.3f° and %.3f°", result.RMSErrorDeg, result.MaxErrorDeg)
+		}
+	})
+
+	t.Run("Commands outside the track are not assessed", func(t *testing.T) {
+		samples := commands(at, 0, PredictionLive)
+		samples = append(samples, PointingSample{Time: start.Add(90 * time.Second), Commanded: at(120), Mode: PredictionHold})
+		result := AnalyzePass(samples, track, observer)
+		if result.Commands != 31 || result.Assessed != 30 {
+			t.Errorf("Expected 30 of 31 commands assessed, got %d of %d", result.Assessed, result.Commands)
+		}
+		if result.RMSErrorDeg > 0.01 {
+			t.Errorf("Expected the unassessed command to be ignored, got RMS %.3f°", result.RMSErrorDeg)
+		}
+		if hold := result.ModeUsage[PredictionHold]; math.Abs(hold-100.0/31) > 1e-9 {
+			t.Errorf("Expected %.2f%% hold, got %.2f%%", 100.0/31, hold)
+		}
+	})
+
+	t.Run("No commands", func(t *testing.T) {
+		result := AnalyzePass(nil, track, observer)
+		if result.Commands != 0 || result.Assessed != 0 || result.RMSErrorDeg != 0 {
+			t.Errorf("Expected an empty result, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// Approach is an aircraft's closest approach to the observer, extrapolated
+// along its current track.
+type Approach struct {
+	// ClosestRangeNM is the ground range at closest approach, reached after
+	// TimeToClosest. A receding aircraft is closest now.
+	ClosestRangeNM float64
+	TimeToClosest  time.Duration
+	IsApproaching  bool
+
+	// Culmination is where the aircraft is highest in the sky, at closest
+	// approach (assuming it holds its altitude)
+	Culmination  time.Time
+	MaxElevation float64
+	Azimuth      float64
+
+	// EntersLimits reports whether the aircraft rises above the minimum
+	// altitude, and ExceedsLimits whether it then passes above the maximum
+	// altitude (through the zenith keyhole)
+	EntersLimits  bool
+	ExceedsLimits bool
+}
+
+// PredictApproach predicts an aircraft's closest approach to the observer
+// from its position extrapolated to from.
+func PredictApproach(
+	aircraft adsb.Aircraft,
+	observer coordinates.Observer,
+	from time.Time,
+	limits TrackingLimits,
+) Approach {
+	current := PredictPosition(aircraft, from).Position
+	closestRange, timeToClosest, approaching := coordinates.EstimateTimeToClosestApproach(
+		observer.Location,
+		current,
+		aircraft.GroundSpeed,
+		aircraft.Track,
+	)
+
+	culmination := from.Add(timeToClosest)
+	pos := PredictPosition(aircraft, culmination).Position
+	horiz := coordinates.GeographicToHorizontal(pos, observer, culmination)
+
+	return Approach{
+		ClosestRangeNM: closestRange,
+		TimeToClosest:  timeToClosest,
+		IsApproaching:  approaching,
+		Culmination:    culmination,
+		MaxElevation:   horiz.Altitude,
+		Azimuth:        horiz.Azimuth,
+		EntersLimits:   horiz.Altitude >= limits.MinAltitude,
+		ExceedsLimits:  horiz.Altitude > limits.MaxAltitude,
+	}
+}
@@ -0,0 +1,88 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestPredictApproach tests closest approach prediction for passing aircraft.
+func TestPredictApproach(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := TrackingLimits{MinAltitude: 30, MaxAltitude: 80}
+
+	tests := []struct {
+		name            string
+		latitude        float64 // Start 10 NM west of the observer at this latitude
+		track           float64
+		wantApproaching bool
+		wantRangeNM     float64
+		wantTime        time.Duration
+		wantEnters      bool
+		wantExceeds     bool
+	}{
+		{
+			name:            "Overhead pass",
+			latitude:        35.0,
+			track:           90,
+			wantApproaching: true,
+			wantRangeNM:     0,
+			wantTime:        2 * time.Minute,
+			wantEnters:      true,
+			wantExceeds:     true,
+		},
+		{
+			name:            "Offset pass",
+			latitude:        35.0333, // 2 NM north of the observer's latitude
+			track:           90,
+			wantApproaching: true,
+			wantRangeNM:     2,
+			wantTime:        2 * time.Minute,
+			wantEnters:      true,
+			wantExceeds:     false,
+		},
+		{
+			name:            "Receding",
+			latitude:        35.0,
+			track:           270,
+			wantApproaching: false,
+			wantRangeNM:     10,
+			wantTime:        0,
+			wantEnters:      false,
+			wantExceeds:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aircraft := adsb.Aircraft{
+				ICAO: "A1B2C3", Latitude: tt.latitude, Longitude: -80.2036, Altitude: 10000,
+				GroundSpeed: 300, Track: tt.track, LastSeen: now,
+			}
+			approach := PredictApproach(aircraft, observer, now, limits)
+
+			if approach.IsApproaching != tt.wantApproaching {
+				t.Errorf("IsApproaching = %v, want %v", approach.IsApproaching, tt.wantApproaching)
+			}
+			if math.Abs(approach.ClosestRangeNM-tt.wantRangeNM) > 0.2 {
+				t.Errorf("ClosestRangeNM = %.2f, want %.1f", approach.ClosestRangeNM, tt.wantRangeNM)
+			}
+			if d := approach.TimeToClosest - tt.wantTime; d < -5*time.Second || d > 5*time.Second {
+				t.Errorf("TimeToClosest = %v, want about %v", approach.TimeToClosest, tt.wantTime)
+			}
+			if !approach.Culmination.Equal(now.Add(approach.TimeToClosest)) {
+				t.Errorf("Culmination = %v, want now + TimeToClosest", approach.Culmination)
+			}
+			if approach.EntersLimits != tt.wantEnters {
+				t.Errorf("EntersLimits = %v (max %.1f°), want %v", approach.EntersLimits, approach.MaxElevation, tt.wantEnters)
+			}
+			if approach.ExceedsLimits != tt.wantExceeds {
+				t.Errorf("ExceedsLimits = %v (max %.1f°), want %v", approach.ExceedsLimits, approach.MaxElevation, tt.wantExceeds)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package tracking
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+const (
+	// calibrationBins is how many equal-width confidence bins the observed
+	// errors are grouped into
+	calibrationBins = 10
+
+	// minBinSamples is how many assessed predictions a bin needs to count
+	minBinSamples = 20
+)
+
+// CalibrationSample is one prediction assessed after the fact: its
+// confidence and the angle between the predicted and actual positions.
+type CalibrationSample struct {
+	Confidence float64 `json:"confidence"`
+	ErrorDeg   float64 `json:"errorDeg"`
+}
+
+// CalibrationBin is the error observed for predictions of similar
+// confidence.
+type CalibrationBin struct {
+	// Confidence is the mean confidence of the bin's predictions
+	Confidence float64 `json:"confidence"`
+
+	// ErrorDeg is their RMS angular error in degrees
+	ErrorDeg float64 `json:"errorDeg"`
+
+	Samples int `json:"samples"`
+}
+
+// ErrorCalibration maps a prediction's confidence to the angular error to
+// expect, as observed in recorded sessions. The confidence heuristics are
+// only ordered (higher is better); calibration gives them a scale.
+type ErrorCalibration struct {
+	// Bins are in ascending confidence, with errors that never increase
+	// with confidence
+	Bins []CalibrationBin `json:"bins"`
+
+	// Samples is how many predictions the calibration was fitted to, 0 for
+	// DefaultCalibration
+	Samples int `json:"samples"`
+}
+
+// DefaultCalibration is a rough scale for the confidence heuristics, used
+// until a calibration has been fitted to recorded sessions: fresh reports
+// are good to a tenth of a degree and a minute-old extrapolation to
+// several degrees.
+var DefaultCalibration = ErrorCalibration{
+	Bins: []CalibrationBin{
+		{Confidence: 0, ErrorDeg: 5},
+		{Confidence: 0.5, ErrorDeg: 1},
+		{Confidence: 0.95, ErrorDeg: 0.2},
+		{Confidence: 1, ErrorDeg: 0.1},
+	},
+}
+
+// Calibrated reports whether the calibration was fitted to recorded
+// predictions rather than being DefaultCalibration.
+func (c ErrorCalibration) Calibrated() bool {
+	return c.Samples > 0
+}
+
+// ExpectedError returns the angular error in degrees to expect from a
+// prediction of the given confidence, interpolated between the bins and
+// held at the end bins' errors beyond them.
+func (c ErrorCalibration) ExpectedError(confidence float64) float64 {
+	bins := c.Bins
+	if len(bins) == 0 {
+		bins = DefaultCalibration.Bins
+	}
+	if confidence <= bins[0].Confidence {
+		return bins[0].ErrorDeg
+	}
+	for i := 1; i < len(bins); i++ {
+		lo, hi := bins[i-1], bins[i]
+		if confidence <= hi.Confidence {
+			frac := (confidence - lo.Confidence) / (hi.Confidence - lo.Confidence)
+			return lo.ErrorDeg + frac*(hi.ErrorDeg-lo.ErrorDeg)
+		}
+	}
+	return bins[len(bins)-1].ErrorDeg
+}
+
+// FormatExpectedError formats an expected error for display, e.g. "±0.3°".
+func FormatExpectedError(deg float64) string {
+	if deg < 1 {
+		return fmt.Sprintf("±%.1f°", math.Max(deg, 0.1))
+	}
+	return fmt.Sprintf("±%.0f°", deg)
+}
+
+// CalibrateErrors fits a calibration to assessed predictions. They are
+// grouped into confidence bins, bins with too few predictions are dropped,
+// and neighboring bins whose error rises with confidence are pooled, so
+// the expected error never increases with confidence. Returns false if no
+// bin has enough predictions.
+func CalibrateErrors(samples []CalibrationSample) (ErrorCalibration, bool) {
+	type bin struct {
+		n              int
+		sumConf, sumSq float64
+	}
+	bins := make([]bin, calibrationBins)
+	for _, s := range samples {
+		i := min(int(math.Max(s.Confidence, 0)*calibrationBins), calibrationBins-1)
+		bins[i].n++
+		bins[i].sumConf += s.Confidence
+		bins[i].sumSq += s.ErrorDeg * s.ErrorDeg
+	}
+
+	// Pool adjacent violators: scanning up in confidence, merge a bin into
+	// the one below while its error is higher
+	var pooled []bin
+	for _, b := range bins {
+		if b.n < minBinSamples {
+			continue
+		}
+		pooled = append(pooled, b)
+		for len(pooled) > 1 {
+			lo, hi := pooled[len(pooled)-2], pooled[len(pooled)-1]
+			if hi.sumSq/float64(hi.n) <= lo.sumSq/float64(lo.n) {
+				break
+			}
+			pooled = pooled[:len(pooled)-1]
+			pooled[len(pooled)-1] = bin{n: lo.n + hi.n, sumConf: lo.sumConf + hi.sumConf, sumSq: lo.sumSq + hi.sumSq}
+		}
+	}
+	if len(pooled) == 0 {
+		return ErrorCalibration{}, false
+	}
+
+	var c ErrorCalibration
+	for _, b := range pooled {
+		n := float64(b.n)
+		c.Bins = append(c.Bins, CalibrationBin{
+			Confidence: b.sumConf / n,
+			ErrorDeg:   math.Sqrt(b.sumSq / n),
+			Samples:    b.n,
+		})
+		c.Samples += b.n
+	}
+	return c, true
+}
+
+// PointingErrors assesses the commanded positions of a pass against the
+// aircraft's reported track (oldest first), as AnalyzePass does, pairing
+// each assessed command's confidence with its error.
+func PointingErrors(samples []PointingSample, track []adsb.Aircraft, observer coordinates.Observer) []CalibrationSample {
+	var assessed []CalibrationSample
+	for _, s := range samples {
+		actual, _, ok := actualPosition(track, s.Time, observer)
+		if !ok {
+			continue
+		}
+		assessed = append(assessed, CalibrationSample{
+			Confidence: s.Confidence,
+			ErrorDeg:   coordinates.AngularSeparation(s.Commanded, actual),
+		})
+	}
+	return assessed
+}
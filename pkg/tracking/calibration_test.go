@@ -0,0 +1,86 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalibrateErrors tests fitting errors to confidence bins.
+func TestCalibrateErrors(t *testing.T) {
+	var samples []CalibrationSample
+	add := func(confidence, errorDeg float64, n int) {
+		for i := 0; i < n; i++ {
+			samples = append(samples, CalibrationSample{Confidence: confidence, ErrorDeg: errorDeg})
+		}
+	}
+	add(0.95, 0.2, 50)
+	add(0.75, 0.6, 30)
+	add(0.65, 0.4, 30) // Better than the bin above: pooled with it
+	add(0.45, 2, 5)    // Too few to count
+
+	c, ok := CalibrateErrors(samples)
+	if !ok {
+		t.Fatal("Expected a calibration")
+	}
+	if !c.Calibrated() || c.Samples != 110 {
+		t.Errorf("Expected a calibration of 110 samples, got %d", c.Samples)
+	}
+	if len(c.Bins) != 2 {
+		t.Fatalf("Expected 2 bins after pooling, got %+v", c.Bins)
+	}
+
+	pooled := c.Bins[0]
+	if math.Abs(pooled.Confidence-0.7) > 1e-9 || pooled.Samples != 60 {
+		t.Errorf("Expected the pooled bin at confidence 0.7 of 60 samples, got %+v", pooled)
+	}
+	if want := math.Sqrt((0.36 + 0.16) / 2); math.Abs(pooled.ErrorDeg-want) > 1e-9 {
+		t.Errorf("Expected the pooled RMS error %.3f°, got %.3f°", want, pooled.ErrorDeg)
+	}
+
+	tests := []struct {
+		name       string
+		confidence float64
+		expected   float64
+	}{
+		{"Held below the lowest bin", 0.2, pooled.ErrorDeg},
+		{"At a bin", 0.95, 0.2},
+		{"Interpolated between bins", 0.825, (pooled.ErrorDeg + 0.2) / 2},
+		{"Held above the highest bin", 1, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.ExpectedError(tt.confidence); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected %.3f°, got %.3f°", tt.expected, got)
+			}
+		})
+	}
+
+	if _, ok := CalibrateErrors(samples[len(samples)-5:]); ok {
+		t.Error("Expected no calibration without a bin of enough samples")
+	}
+}
+
+// TestExpectedErrorDefault tests the uncalibrated scale and formatting.
+func TestExpectedErrorDefault(t *testing.T) {
+	var c ErrorCalibration
+	if c.Calibrated() {
+		t.Error("Expected the zero calibration to be uncalibrated")
+	}
+	if got := c.ExpectedError(0.5); got != 1 {
+		t.Errorf("Expected the default 1° at confidence 0.5, got %.2f°", got)
+	}
+
+	tests := []struct {
+		deg      float64
+		expected string
+	}{
+		{0.31, "±0.3°"},
+		{0.01, "±0.1°"},
+		{2.4, "±2°"},
+	}
+	for _, tt := range tests {
+		if got := FormatExpectedError(tt.deg); got != tt.expected {
+			t.Errorf("FormatExpectedError(%v) = %q, expected %q", tt.deg, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// Station is a fixed observation point that can take over a target.
+type Station struct {
+	ID       int
+	Name     string
+	Observer coordinates.Observer
+}
+
+// Handover recommends passing a target to another station as it leaves the
+// current station's altitude limits.
+type Handover struct {
+	From Station
+	To   Station
+
+	// LeavesAt is when the target leaves From's limits (the prediction time
+	// if it already has)
+	LeavesAt time.Time
+
+	// EntersAt and ExitsAt bound the target's window within To's limits.
+	// ExitsAt is zero if it is still within them at the end of the horizon.
+	EntersAt time.Time
+	ExitsAt  time.Time
+
+	// Gap is how long neither station can track the target (0 if the
+	// windows overlap)
+	Gap time.Duration
+}
+
+// RecommendHandover predicts when the aircraft leaves the current station's
+// limits and picks the station that can take it over soonest, preferring the
+// longest window among stations that can take it at the same time. Returns
+// false if the current station keeps the target for the whole horizon or no
+// other station will see it after it leaves.
+func RecommendHandover(
+	aircraft adsb.Aircraft,
+	current Station,
+	others []Station,
+	from time.Time,
+	horizon time.Duration,
+	limits TrackingLimits,
+) (Handover, bool) {
+	end := from.Add(horizon)
+
+	leavesAt := from
+	if window, ok := PredictLimitWindow(aircraft, current.Observer, from, horizon, limits); ok {
+		if !window.Leaves {
+			return Handover{}, false
+		}
+		leavesAt = from.Add(window.Leave)
+	}
+
+	var best Handover
+	found := false
+	for _, station := range others {
+		if station.ID == current.ID {
+			continue
+		}
+
+		window, ok := PredictLimitWindow(aircraft, station.Observer, from, horizon, limits)
+		if !ok {
+			continue
+		}
+		candidate := Handover{
+			From:     current,
+			To:       station,
+			LeavesAt: leavesAt,
+			EntersAt: from.Add(window.Enter),
+		}
+		windowEnd := end
+		if window.Leaves {
+			candidate.ExitsAt = from.Add(window.Leave)
+			windowEnd = candidate.ExitsAt
+		}
+		if !windowEnd.After(leavesAt) {
+			continue // Gone again before the current station loses it
+		}
+		candidate.Gap = max(0, candidate.EntersAt.Sub(leavesAt))
+
+		if !found || candidate.Gap < best.Gap || (candidate.Gap == best.Gap && windowEnd.After(best.windowEnd(end))) {
+			best, found = candidate, true
+		}
+	}
+
+	return best, found
+}
+
+// windowEnd returns when the target leaves To's limits, or end if it doesn't
+// within the horizon.
+func (h Handover) windowEnd(end time.Time) time.Time {
+	if h.ExitsAt.IsZero() {
+		return end
+	}
+	return h.ExitsAt
+}
@@ -0,0 +1,85 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestRecommendHandover tests choosing a station to take over a target.
+func TestRecommendHandover(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limits := TrackingLimits{MinAltitude: 30, MaxAltitude: 90}
+
+	station := func(id int, name string, lat, lon float64) Station {
+		return Station{ID: id, Name: name, Observer: coordinates.Observer{
+			Location: coordinates.Geographic{Latitude: lat, Longitude: lon},
+		}}
+	}
+
+	// 1 NM of longitude is about 0.0203° at 35°N
+	west := station(1, "West", 35.0, -80.0)
+	near := station(2, "Near east", 35.0, -79.9187) // 4 NM east: windows overlap
+	far := station(3, "Far east", 35.0, -79.7967)   // 10 NM east: gap between windows
+	south := station(4, "South", 34.5, -80.0)       // 30 NM south: never sees it
+
+	// 2 NM west of the West station at 10,000 ft, flying east at 300 kts.
+	// Within 30° elevation of a station inside about 2.85 NM.
+	eastbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Latitude: 35.0, Longitude: -80.0407, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now,
+	}
+
+	t.Run("Overlapping station preferred", func(t *testing.T) {
+		h, ok := RecommendHandover(eastbound, west, []Station{west, far, near, south}, now, 10*time.Minute, limits)
+		if !ok {
+			t.Fatal("Expected a handover")
+		}
+		if h.To.ID != near.ID {
+			t.Errorf("Handover to %s, want %s", h.To.Name, near.Name)
+		}
+		if h.Gap != 0 {
+			t.Errorf("Gap = %v, want 0 for overlapping windows", h.Gap)
+		}
+
+		// Leaves the West station 4.85 NM on, after about 58s
+		if d := h.LeavesAt.Sub(now); d < 50*time.Second || d > 70*time.Second {
+			t.Errorf("Leaves after %v, want about 58s", d)
+		}
+		if !h.EntersAt.Before(h.LeavesAt) {
+			t.Errorf("Enters at %v, want before it leaves at %v", h.EntersAt, h.LeavesAt)
+		}
+	})
+
+	t.Run("Gap between windows", func(t *testing.T) {
+		h, ok := RecommendHandover(eastbound, west, []Station{far, south}, now, 10*time.Minute, limits)
+		if !ok {
+			t.Fatal("Expected a handover")
+		}
+		if h.To.ID != far.ID {
+			t.Errorf("Handover to %s, want %s", h.To.Name, far.Name)
+		}
+
+		// Enters the far station's limits 7.15 NM past the West station,
+		// 4.3 NM after leaving West's: about 52s
+		if h.Gap < 40*time.Second || h.Gap > 65*time.Second {
+			t.Errorf("Gap = %v, want about 52s", h.Gap)
+		}
+	})
+
+	t.Run("No station sees it", func(t *testing.T) {
+		if _, ok := RecommendHandover(eastbound, west, []Station{south}, now, 10*time.Minute, limits); ok {
+			t.Error("Expected no handover when no other station sees the target")
+		}
+	})
+
+	t.Run("Current station keeps it", func(t *testing.T) {
+		slow := eastbound
+		slow.GroundSpeed = 0
+		if _, ok := RecommendHandover(slow, west, []Station{near}, now, 10*time.Minute, limits); ok {
+			t.Error("Expected no handover while the target stays within limits")
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package tracking
+
+import (
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+const (
+	// HoldHistory is how much position history is needed to recognise a
+	// hold: a standard hold takes 4-6 minutes per circuit
+	HoldHistory = 15 * time.Minute
+
+	// holdMinTurnDeg is the net turn, all in one direction, that makes a
+	// hold rather than a procedure turn or vectors
+	holdMinTurnDeg = 360.0
+
+	// holdMaxSpanNM is the largest extent of a hold (long legs at high
+	// altitude are up to ~10 NM)
+	holdMaxSpanNM = 20.0
+
+	// holdMinPoints is the fewest positions a hold is fitted from
+	holdMinPoints = 12
+)
+
+// Hold is a racetrack holding pattern fitted to an aircraft's recent track:
+// two parallel legs joined by 180° turns.
+type Hold struct {
+	// Center is the middle of the racetrack
+	Center coordinates.Geographic
+
+	// CourseDeg is the direction of the legs (either way round; together
+	// with RightTurns it fixes which leg is flown which way)
+	CourseDeg float64
+
+	// LegNM is the length of the straight legs and TurnRadiusNM the radius
+	// of the turns between them, so the legs are 2×TurnRadiusNM apart
+	LegNM        float64
+	TurnRadiusNM float64
+
+	// RightTurns is true for a standard (clockwise) hold
+	RightTurns bool
+}
+
+// DetectHold recognises a holding pattern in an aircraft's position history
+// (oldest first): the aircraft has turned at least a full circuit in one
+// direction while staying within a small area. Returns false if the track
+// isn't a hold.
+func DetectHold(history []adsb.Aircraft) (Hold, bool) {
+	if len(history) < holdMinPoints {
+		return Hold{}, false
+	}
+
+	// Net turn: a hold keeps turning the same way, vectors and S-turns don't
+	turn := 0.0
+	for i := 1; i < len(history); i++ {
+		turn += normalizeAngle(history[i].Track - history[i-1].Track)
+	}
+	if math.Abs(turn) < holdMinTurnDeg {
+		return Hold{}, false
+	}
+
+	// Local flat frame around the mean position, in NM east and north
+	var center coordinates.Geographic
+	for _, p := range history {
+		center.Latitude += p.Latitude
+		center.Longitude += p.Longitude
+	}
+	center.Latitude /= float64(len(history))
+	center.Longitude /= float64(len(history))
+
+	points := make([][2]float64, len(history))
+	var cxx, cyy, cxy float64
+	for i, p := range history {
+		x, y := toLocalNM(center, p.Latitude, p.Longitude)
+		points[i] = [2]float64{x, y}
+		cxx += x * x
+		cyy += y * y
+		cxy += x * y
+	}
+
+	// The legs run along the principal axis of the positions, or more
+	// precisely along the track flown while not turning
+	axis := 0.5 * math.Atan2(2*cxy, cxx-cyy) // radians from east, counterclockwise
+	course := math.Mod(90-axis*coordinates.RadiansToDegrees+360, 360)
+	if legCourse, ok := holdLegCourse(history); ok {
+		course = legCourse
+	}
+	u, w := courseAxes(course)
+
+	minA, maxA, minP, maxP := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, pt := range points {
+		a := pt[0]*u[0] + pt[1]*u[1]
+		p := pt[0]*w[0] + pt[1]*w[1]
+		minA, maxA = math.Min(minA, a), math.Max(maxA, a)
+		minP, maxP = math.Min(minP, p), math.Max(maxP, p)
+	}
+	if maxA-minA > holdMaxSpanNM || maxP-minP > holdMaxSpanNM {
+		return Hold{}, false
+	}
+
+	radius := (maxP - minP) / 2
+	if radius <= 0 {
+		return Hold{}, false
+	}
+
+	// Recentre on the racetrack rather than the mean of the positions,
+	// which is biased towards wherever the history starts and ends
+	midA, midP := (minA+maxA)/2, (minP+maxP)/2
+	center.Latitude, center.Longitude = fromLocalNM(center, midA*u[0]+midP*w[0], midA*u[1]+midP*w[1])
+
+	return Hold{
+		Center:       center,
+		CourseDeg:    course,
+		LegNM:        math.Max(0, (maxA-minA)-2*radius),
+		TurnRadiusNM: radius,
+		RightTurns:   turn > 0,
+	}, true
+}
+
+// holdLegTurnDeg is the most the track may change between positions on a
+// straight leg
+const holdLegTurnDeg = 1.0
+
+// holdLegCourse returns the mean course of the straight legs in a hold, with
+// inbound and outbound legs counted alike (so in 0-180°).
+func holdLegCourse(history []adsb.Aircraft) (float64, bool) {
+	var sumSin, sumCos float64
+	n := 0
+	for i := 1; i < len(history); i++ {
+		if math.Abs(normalizeAngle(history[i].Track-history[i-1].Track)) > holdLegTurnDeg {
+			continue
+		}
+		doubled := 2 * history[i].Track * coordinates.DegreesToRadians
+		sumSin += math.Sin(doubled)
+		sumCos += math.Cos(doubled)
+		n++
+	}
+	if n < 2 {
+		return 0, false
+	}
+
+	course := math.Atan2(sumSin, sumCos) / 2 * coordinates.RadiansToDegrees
+	return math.Mod(course+180, 180), true
+}
+
+// PerimeterNM returns the distance flown in one circuit of the hold.
+func (h Hold) PerimeterNM() float64 {
+	return 2*h.LegNM + 2*math.Pi*h.TurnRadiusNM
+}
+
+// point returns the position distanceNM along the racetrack from the start
+// of the leg flown along CourseDeg, in NM along and across the course from
+// the centre (across is to the right of the course).
+func (h Hold) point(distanceNM float64) (float64, float64) {
+	l, r := h.LegNM, h.TurnRadiusNM
+	side := 1.0
+	if !h.RightTurns {
+		side = -1
+	}
+
+	s := math.Mod(distanceNM, h.PerimeterNM())
+	if s < 0 {
+		s += h.PerimeterNM()
+	}
+
+	switch {
+	case s < l: // Leg along the course
+		return -l/2 + s, -r * side
+	case s < l+math.Pi*r: // Turn at the far end
+		phi := -math.Pi/2 + (s-l)/r
+		return l/2 + r*math.Cos(phi), r * math.Sin(phi) * side
+	case s < 2*l+math.Pi*r: // Leg back
+		return l/2 - (s - l - math.Pi*r), r * side
+	default: // Turn at the near end
+		phi := math.Pi/2 + (s-2*l-math.Pi*r)/r
+		return -l/2 + r*math.Cos(phi), r * math.Sin(phi) * side
+	}
+}
+
+// holdSamples is how finely the racetrack is searched for the point nearest
+// the aircraft
+const holdSamples = 360
+
+// Predict returns where an aircraft at pos, flying the hold at speedKts,
+// will be after d.
+func (h Hold) Predict(pos coordinates.Geographic, speedKts float64, d time.Duration) coordinates.Geographic {
+	perimeter := h.PerimeterNM()
+	if perimeter <= 0 {
+		return pos
+	}
+
+	// Find where on the racetrack the aircraft is
+	u, w := courseAxes(h.CourseDeg)
+	x, y := toLocalNM(h.Center, pos.Latitude, pos.Longitude)
+	a, p := x*u[0]+y*u[1], x*w[0]+y*w[1]
+
+	best, bestDist := 0.0, math.Inf(1)
+	for i := 0; i < holdSamples; i++ {
+		s := perimeter * float64(i) / holdSamples
+		pa, pp := h.point(s)
+		if dist := math.Hypot(pa-a, pp-p); dist < bestDist {
+			best, bestDist = s, dist
+		}
+	}
+
+	// Fly on around it
+	na, np := h.point(best + speedKts*d.Hours())
+	lat, lon := fromLocalNM(h.Center, na*u[0]+np*w[0], na*u[1]+np*w[1])
+	return coordinates.Geographic{Latitude: lat, Longitude: lon, Altitude: pos.Altitude}
+}
+
+// PredictPositionInHold predicts an aircraft's position by flying it around
+// a hold, in place of dead reckoning, which would send it off in a straight
+// line. Altitude is held: aircraft hold at an assigned altitude.
+func PredictPositionInHold(aircraft adsb.Aircraft, hold Hold, predictionTime time.Time) PredictedPosition {
+	pred := PredictPosition(aircraft, predictionTime)
+	d := predictionTime.Sub(aircraft.LastSeen)
+	if d <= 0 {
+		return pred
+	}
+
+	current := coordinates.Geographic{
+		Latitude:  aircraft.Latitude,
+		Longitude: aircraft.Longitude,
+		Altitude:  aircraft.Altitude.Meters(),
+	}
+	pred.Position = hold.Predict(current, aircraft.GroundSpeed, d)
+
+	// Circuits are repeatable, but the aircraft may be cleared out of the
+	// hold at any time
+	pred.Confidence = math.Max(0, 1.0-d.Seconds()/240)
+	return pred
+}
+
+// courseAxes returns unit vectors (east, north) along a course and to its
+// right.
+func courseAxes(courseDeg float64) ([2]float64, [2]float64) {
+	rad := courseDeg * coordinates.DegreesToRadians
+	return [2]float64{math.Sin(rad), math.Cos(rad)}, [2]float64{math.Cos(rad), -math.Sin(rad)}
+}
+
+// toLocalNM returns a position's offset from origin in NM east and north,
+// on a flat earth (accurate over the few NM of a hold).
+func toLocalNM(origin coordinates.Geographic, lat, lon float64) (float64, float64) {
+	east := normalizeAngle(lon-origin.Longitude) * 60 * math.Cos(origin.Latitude*coordinates.DegreesToRadians)
+	north := (lat - origin.Latitude) * 60
+	return east, north
+}
+
+// fromLocalNM is the inverse of toLocalNM.
+func fromLocalNM(origin coordinates.Geographic, east, north float64) (float64, float64) {
+	lat := origin.Latitude + north/60
+	lon := origin.Longitude + east/(60*math.Cos(origin.Latitude*coordinates.DegreesToRadians))
+	return lat, lon
+}
@@ -0,0 +1,136 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// flyHold returns the positions, every 10 seconds for d, of an aircraft
+// flying hold at speedKts from the start of its inbound leg.
+func flyHold(hold Hold, speedKts float64, start time.Time, d time.Duration) []adsb.Aircraft {
+	u, w := courseAxes(hold.CourseDeg)
+	at := func(s float64) (float64, float64) {
+		a, p := hold.point(s)
+		return a*u[0] + p*w[0], a*u[1] + p*w[1]
+	}
+
+	var history []adsb.Aircraft
+	for t := time.Duration(0); t <= d; t += 10 * time.Second {
+		s := speedKts * t.Hours()
+		x, y := at(s)
+		nx, ny := at(s + 0.01)
+		lat, lon := fromLocalNM(hold.Center, x, y)
+		history = append(history, adsb.Aircraft{
+			Latitude:    lat,
+			Longitude:   lon,
+			Altitude:    8000,
+			GroundSpeed: speedKts,
+			Track:       math.Mod(math.Atan2(nx-x, ny-y)*coordinates.RadiansToDegrees+360, 360),
+			LastSeen:    start.Add(t),
+		})
+	}
+	return history
+}
+
+// TestDetectHold tests recognising a racetrack hold in a position history.
+func TestDetectHold(t *testing.T) {
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	center := coordinates.Geographic{Latitude: 35.2, Longitude: -80.9}
+
+	tests := []struct {
+		name   string
+		hold   Hold
+		flown  time.Duration
+		isHold bool
+	}{
+		{"Standard hold", Hold{Center: center, CourseDeg: 60, LegNM: 4, TurnRadiusNM: 1.5, RightTurns: true}, 8 * time.Minute, true},
+		{"Left turns", Hold{Center: center, CourseDeg: 300, LegNM: 5, TurnRadiusNM: 2, RightTurns: false}, 9 * time.Minute, true},
+		{"Less than a circuit", Hold{Center: center, CourseDeg: 60, LegNM: 4, TurnRadiusNM: 1.5, RightTurns: true}, 3 * time.Minute, false},
+		{"Too large", Hold{Center: center, CourseDeg: 0, LegNM: 40, TurnRadiusNM: 2, RightTurns: true}, 40 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectHold(flyHold(tt.hold, 200, start, tt.flown))
+			if ok != tt.isHold {
+				t.Fatalf("DetectHold() = %v, expected %v", ok, tt.isHold)
+			}
+			if !ok {
+				return
+			}
+
+			if got.RightTurns != tt.hold.RightTurns {
+				t.Errorf("RightTurns = %v, expected %v", got.RightTurns, tt.hold.RightTurns)
+			}
+			if diff := math.Abs(math.Mod(got.CourseDeg-tt.hold.CourseDeg+360, 180)); diff > 2 && diff < 178 {
+				t.Errorf("CourseDeg = %.1f, expected %.1f or its reciprocal", got.CourseDeg, tt.hold.CourseDeg)
+			}
+			if math.Abs(got.LegNM-tt.hold.LegNM) > 0.3 {
+				t.Errorf("LegNM = %.2f, expected %.2f", got.LegNM, tt.hold.LegNM)
+			}
+			if math.Abs(got.TurnRadiusNM-tt.hold.TurnRadiusNM) > 0.2 {
+				t.Errorf("TurnRadiusNM = %.2f, expected %.2f", got.TurnRadiusNM, tt.hold.TurnRadiusNM)
+			}
+			if dist := coordinates.DistanceNauticalMiles(got.Center, tt.hold.Center); dist > 0.3 {
+				t.Errorf("Center is %.2f NM off", dist)
+			}
+		})
+	}
+
+	t.Run("Straight flight", func(t *testing.T) {
+		var history []adsb.Aircraft
+		for i := 0; i < 60; i++ {
+			history = append(history, adsb.Aircraft{
+				Latitude: 35 + float64(i)*0.01, Longitude: -80, GroundSpeed: 200, Track: 0,
+				LastSeen: start.Add(time.Duration(i) * 10 * time.Second),
+			})
+		}
+		if _, ok := DetectHold(history); ok {
+			t.Error("DetectHold() recognised straight flight as a hold")
+		}
+	})
+}
+
+// TestPredictPositionInHold tests flying a prediction around the hold rather
+// than straight ahead.
+func TestPredictPositionInHold(t *testing.T) {
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	hold := Hold{
+		Center:       coordinates.Geographic{Latitude: 35.2, Longitude: -80.9},
+		CourseDeg:    90,
+		LegNM:        4,
+		TurnRadiusNM: 1.5,
+		RightTurns:   true,
+	}
+
+	// 9 minutes flown, then predict 2 minutes ahead against the truth
+	track := flyHold(hold, 200, start, 11*time.Minute)
+	current := track[54]
+	truth := track[len(track)-1]
+
+	detected, ok := DetectHold(track[:55])
+	if !ok {
+		t.Fatal("DetectHold() failed on a hold")
+	}
+
+	pred := PredictPositionInHold(current, detected, truth.LastSeen)
+	if dist := coordinates.DistanceNauticalMiles(pred.Position, coordinates.Geographic{Latitude: truth.Latitude, Longitude: truth.Longitude}); dist > 0.5 {
+		t.Errorf("Hold prediction is %.2f NM from the truth", dist)
+	}
+	if pred.Position.Altitude != current.Altitude.Meters() {
+		t.Errorf("Altitude = %.0f m, expected it held at %.0f m", pred.Position.Altitude, current.Altitude.Meters())
+	}
+
+	// Dead reckoning leaves the hold entirely
+	dr := PredictPosition(current, truth.LastSeen)
+	if dist := coordinates.DistanceNauticalMiles(dr.Position, hold.Center); dist < 4 {
+		t.Errorf("Dead reckoning stayed %.2f NM from the hold, expected it to leave", dist)
+	}
+	if dist := coordinates.DistanceNauticalMiles(pred.Position, hold.Center); dist > 4 {
+		t.Errorf("Hold prediction is %.2f NM from the hold center", dist)
+	}
+}
@@ -0,0 +1,58 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// HorizonCrossings is when and where an aircraft is predicted to rise above
+// and set below an elevation mask, e.g. for pointing a directional antenna
+// before it has line of sight.
+type HorizonCrossings struct {
+	// Rise is when it rises above the mask, at RiseAzimuth; only set if
+	// Rises (otherwise it is already above it)
+	Rise        time.Time
+	RiseAzimuth float64
+	Rises       bool
+
+	// Set is when it sets below the mask, at SetAzimuth; only set if Sets
+	// (otherwise it is still above it at the end of the horizon)
+	Set        time.Time
+	SetAzimuth float64
+	Sets       bool
+}
+
+// PredictHorizonCrossings extrapolates the aircraft along its current track
+// and returns its first crossings of the elevation mask (degrees) between
+// from and from+horizon. Returns false if it is never above the mask.
+func PredictHorizonCrossings(
+	aircraft adsb.Aircraft,
+	observer coordinates.Observer,
+	from time.Time,
+	horizon time.Duration,
+	mask float64,
+) (HorizonCrossings, bool) {
+	var crossings HorizonCrossings
+	above := false
+
+	for offset := time.Duration(0); offset <= horizon; offset += passStep {
+		t := from.Add(offset)
+		pos := PredictPosition(aircraft, t).Position
+		horiz := coordinates.GeographicToHorizontal(pos, observer, t)
+
+		switch {
+		case horiz.Altitude >= mask && !above:
+			above = true
+			if offset > 0 {
+				crossings.Rise, crossings.RiseAzimuth, crossings.Rises = t, horiz.Azimuth, true
+			}
+		case horiz.Altitude < mask && above:
+			crossings.Set, crossings.SetAzimuth, crossings.Sets = t, horiz.Azimuth, true
+			return crossings, true
+		}
+	}
+
+	return crossings, above
+}
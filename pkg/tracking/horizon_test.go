@@ -0,0 +1,62 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestPredictHorizonCrossings tests when and where aircraft cross the
+// elevation mask.
+func TestPredictHorizonCrossings(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+
+	// 10 NM west of the observer at 10,000 ft, flying east at 300 kts
+	inbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Latitude: 35.0, Longitude: -80.2036, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now,
+	}
+
+	t.Run("Overflight", func(t *testing.T) {
+		crossings, ok := PredictHorizonCrossings(inbound, observer, now, 10*time.Minute, 30)
+		if !ok {
+			t.Fatal("Expected the aircraft to rise above the mask")
+		}
+
+		// Above 30° after about 86s in the west, below it again about 70s
+		// later in the east
+		if !crossings.Rises || crossings.Rise.Sub(now) < 70*time.Second || crossings.Rise.Sub(now) > 100*time.Second {
+			t.Errorf("Rise = %v (%v), want after about 86s", crossings.Rise.Sub(now), crossings.Rises)
+		}
+		if d := azimuthDifference(crossings.RiseAzimuth, 270); d > 5 {
+			t.Errorf("RiseAzimuth = %.1f°, want about 270°", crossings.RiseAzimuth)
+		}
+		if !crossings.Sets || !crossings.Set.After(crossings.Rise) {
+			t.Fatalf("Set = %v (%v), want after the rise", crossings.Set.Sub(now), crossings.Sets)
+		}
+		if d := azimuthDifference(crossings.SetAzimuth, 90); d > 5 {
+			t.Errorf("SetAzimuth = %.1f°, want about 90°", crossings.SetAzimuth)
+		}
+	})
+
+	t.Run("Already above the mask", func(t *testing.T) {
+		crossings, ok := PredictHorizonCrossings(inbound, observer, now, time.Minute, 0)
+		if !ok {
+			t.Fatal("Expected the aircraft to be above the horizon")
+		}
+		if crossings.Rises || crossings.Sets {
+			t.Errorf("Crossings = %+v, want neither a rise nor a set", crossings)
+		}
+	})
+
+	t.Run("Receding aircraft", func(t *testing.T) {
+		outbound := inbound
+		outbound.Track = 270
+		if _, ok := PredictHorizonCrossings(outbound, observer, now, 10*time.Minute, 30); ok {
+			t.Error("Expected a receding aircraft never to rise above the mask")
+		}
+	})
+}
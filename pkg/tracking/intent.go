@@ -0,0 +1,108 @@
+package tracking
+
+import (
+	"math"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+const (
+	// intentLevelBandFt is how close to the selected altitude an aircraft
+	// counts as already levelled off (altitude reports are in 25 ft steps
+	// and the autopilot holds within about 50 ft)
+	intentLevelBandFt = 100.0
+
+	// intentMinTurnDeg is the smallest heading change predicted as a turn;
+	// smaller differences are heading bug noise or the autopilot trimming
+	intentMinTurnDeg = 2.0
+
+	// standardRateDegPerSec is a standard rate turn (360° in two minutes)
+	standardRateDegPerSec = 3.0
+
+	// maxBankDeg is the bank angle autopilots limit turns to, which makes
+	// turns slower than standard rate above ~170 kt
+	maxBankDeg = 25.0
+)
+
+// levelOffAltitude limits a predicted altitude to the aircraft's selected
+// altitude: an aircraft climbing or descending towards the altitude set on
+// its autopilot levels off there rather than carrying on. A selected
+// altitude the aircraft is moving away from (set but not yet engaged, or a
+// wrong value) is ignored.
+func levelOffAltitude(aircraft adsb.Aircraft, predicted units.Feet) units.Feet {
+	selected := aircraft.SelectedAltitude
+	if selected <= 0 {
+		return predicted
+	}
+
+	toGo := float64(selected - aircraft.Altitude)
+	switch {
+	case math.Abs(toGo) <= intentLevelBandFt:
+		// Already capturing the altitude
+		return selected
+	case toGo > 0 && predicted > aircraft.Altitude:
+		return min(predicted, selected)
+	case toGo < 0 && predicted < aircraft.Altitude:
+		return max(predicted, selected)
+	}
+	return predicted
+}
+
+// intentTurn returns the turn in degrees (positive right) the aircraft's
+// autopilot will fly to reach its selected heading, or 0 if there is none.
+// The selected heading is compared with the magnetic heading rather than the
+// track, which is true and includes the wind correction.
+func intentTurn(aircraft adsb.Aircraft) float64 {
+	if aircraft.SelectedHeading == nil || aircraft.Heading == nil {
+		return 0
+	}
+	turn := normalizeAngle(*aircraft.SelectedHeading - *aircraft.Heading)
+	if math.Abs(turn) < intentMinTurnDeg {
+		return 0
+	}
+	return turn
+}
+
+// turnRate returns the rate in degrees per second an aircraft turns at a
+// ground speed: standard rate, or slower where that would need more than
+// maxBankDeg of bank. Rate of turn is 1091·tan(bank)/speed.
+func turnRate(speedKnots float64) float64 {
+	if speedKnots <= 0 {
+		return standardRateDegPerSec
+	}
+	bankLimited := 1091.0 * math.Tan(maxBankDeg*coordinates.DegreesToRadians) / speedKnots
+	return math.Min(standardRateDegPerSec, bankLimited)
+}
+
+// predictTurningPosition predicts where an aircraft turning turnDeg onto a
+// new heading will be deltaT seconds ahead: a constant rate arc until the
+// turn is complete, then straight on along the new track. The arc is
+// replaced by its chord, which runs along the mean of the start and end
+// tracks.
+func predictTurningPosition(aircraft adsb.Aircraft, turnDeg, deltaT float64) (float64, float64) {
+	speed := aircraft.GroundSpeed
+	rate := turnRate(speed)
+	turnTime := math.Min(deltaT, math.Abs(turnDeg)/rate)
+
+	// Arc of angle turned at radius speed/ω
+	turned := math.Copysign(rate*turnTime, turnDeg)
+	radiusNM := speed / 3600.0 / (rate * coordinates.DegreesToRadians)
+	chordNM := 2 * radiusNM * math.Sin(math.Abs(turned)/2*coordinates.DegreesToRadians)
+
+	pos := coordinates.Destination(coordinates.Geographic{
+		Latitude:  aircraft.Latitude,
+		Longitude: aircraft.Longitude,
+	}, coordinates.NormalizeAzimuth(aircraft.Track+turned/2), chordNM)
+
+	if remaining := deltaT - turnTime; remaining > 0 {
+		return predictHorizontalPosition(
+			pos.Latitude, pos.Longitude,
+			speed,
+			coordinates.NormalizeAzimuth(aircraft.Track+turned),
+			remaining,
+		)
+	}
+	return pos.Latitude, pos.Longitude
+}
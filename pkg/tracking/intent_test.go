@@ -0,0 +1,130 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestLevelOffAltitude tests stopping climbs and descents at the selected
+// altitude.
+func TestLevelOffAltitude(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		altitude     units.Feet
+		verticalRate float64
+		selected     units.Feet
+		want         units.Feet
+	}{
+		{"Climb levels off", 30000, 2000, 31000, 31000},
+		{"Descent levels off", 12000, -1500, 11000, 11000},
+		{"Climb short of selected altitude", 30000, 1000, 35000, 31000},
+		{"Moving away is ignored", 30000, 1200, 25000, 31200},
+		{"Within level band", 24950, 0, 25000, 25000},
+		{"No selected altitude", 30000, 2000, 0, 32000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aircraft := adsb.Aircraft{
+				Latitude:         35.0,
+				Longitude:        -80.0,
+				Altitude:         tt.altitude,
+				GroundSpeed:      300.0,
+				Track:            90.0,
+				VerticalRate:     tt.verticalRate,
+				SelectedAltitude: tt.selected,
+				AircraftType:     "B738",
+				LastSeen:         now,
+			}
+
+			pred := PredictPosition(aircraft, now.Add(60*time.Second))
+			got := units.Meters(pred.Position.Altitude).Feet()
+			if math.Abs(float64(got-tt.want)) > 1 {
+				t.Errorf("Expected %.0f ft, got %.0f ft", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestPredictTurn tests turning onto the selected heading.
+func TestPredictTurn(t *testing.T) {
+	now := time.Now().UTC()
+	heading := func(deg float64) *float64 { return &deg }
+
+	aircraft := adsb.Aircraft{
+		Latitude:        35.0,
+		Longitude:       -80.0,
+		Altitude:        10000,
+		GroundSpeed:     240.0,
+		Track:           90.0,
+		Heading:         heading(95.0),
+		SelectedHeading: heading(185.0),
+		LastSeen:        now,
+	}
+	start := coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}
+
+	t.Run("Turn rate", func(t *testing.T) {
+		if rate := turnRate(120); rate != standardRateDegPerSec {
+			t.Errorf("Expected standard rate at 120 kt, got %.2f°/s", rate)
+		}
+		// 25° bank at 240 kt is about 2.1°/s
+		if rate := turnRate(240); math.Abs(rate-2.12) > 0.05 {
+			t.Errorf("Expected ~2.12°/s at 240 kt, got %.2f°/s", rate)
+		}
+	})
+
+	t.Run("Completes the turn and flies the new track", func(t *testing.T) {
+		pred := PredictPosition(aircraft, now.Add(120*time.Second))
+		end := pred.Position
+
+		// 90° right from 090 is a track of 180: south of the start and
+		// offset east by about the turn radius (1.8 NM at 240 kt)
+		if end.Latitude >= start.Latitude {
+			t.Errorf("Expected aircraft south of start, got lat %.4f", end.Latitude)
+		}
+		last := PredictPosition(aircraft, now.Add(110*time.Second)).Position
+		if track := coordinates.Bearing(last, end); math.Abs(track-180.0) > 1 {
+			t.Errorf("Expected final track 180°, got %.1f°", track)
+		}
+		distance := coordinates.DistanceNauticalMiles(start, end)
+		straight := aircraft.GroundSpeed * 120 / 3600
+		if distance >= straight {
+			t.Errorf("Expected turning aircraft to end closer than %.1f NM, got %.1f NM", straight, distance)
+		}
+	})
+
+	t.Run("Mid turn", func(t *testing.T) {
+		end := PredictPosition(aircraft, now.Add(10*time.Second)).Position
+		bearing := coordinates.Bearing(start, end)
+		// About 21° turned, so the chord runs along ~100°
+		if bearing < 95.0 || bearing > 105.0 {
+			t.Errorf("Expected bearing ~100° after 10 s, got %.1f°", bearing)
+		}
+	})
+
+	t.Run("Left turn", func(t *testing.T) {
+		left := aircraft
+		left.SelectedHeading = heading(5.0)
+		end := PredictPosition(left, now.Add(120*time.Second)).Position
+		if end.Latitude <= start.Latitude {
+			t.Errorf("Expected aircraft north of start, got lat %.4f", end.Latitude)
+		}
+	})
+
+	t.Run("Small difference keeps the track", func(t *testing.T) {
+		steady := aircraft
+		steady.SelectedHeading = heading(96.0)
+		got := PredictPosition(steady, now.Add(60*time.Second)).Position
+		lat, lon := predictHorizontalPosition(35.0, -80.0, 240.0, 90.0, 60)
+		if math.Abs(got.Latitude-lat) > 1e-9 || math.Abs(got.Longitude-lon) > 1e-9 {
+			t.Errorf("Expected straight line prediction, got %.5f, %.5f", got.Latitude, got.Longitude)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// passStep is the sampling interval used when predicting passes
+const passStep = 10 * time.Second
+
+// Pass is a predicted visible pass of an aircraft across the sky.
+type Pass struct {
+	// Start and End bound the time the aircraft is above the minimum altitude
+	Start time.Time
+	End   time.Time
+
+	// Peak is when the aircraft is highest, at MaxElevation and PeakAzimuth
+	Peak         time.Time
+	MaxElevation float64
+	PeakAzimuth  float64
+}
+
+// PredictPass extrapolates the aircraft along its current track and returns
+// the first pass above limits.MinAltitude between from and from+horizon.
+// A pass still in progress at the end of the horizon ends there. Returns
+// false if the aircraft never rises above the minimum altitude.
+func PredictPass(
+	aircraft adsb.Aircraft,
+	observer coordinates.Observer,
+	from time.Time,
+	horizon time.Duration,
+	limits TrackingLimits,
+) (Pass, bool) {
+	var pass Pass
+	inPass := false
+
+	for offset := time.Duration(0); offset <= horizon; offset += passStep {
+		t := from.Add(offset)
+		pos := PredictPosition(aircraft, t).Position
+		horiz := coordinates.GeographicToHorizontal(pos, observer, t)
+
+		if horiz.Altitude < limits.MinAltitude {
+			if inPass {
+				return pass, true
+			}
+			continue
+		}
+
+		if !inPass {
+			inPass = true
+			pass = Pass{Start: t, Peak: t, MaxElevation: horiz.Altitude, PeakAzimuth: horiz.Azimuth}
+		}
+		pass.End = t
+		if horiz.Altitude > pass.MaxElevation {
+			pass.Peak, pass.MaxElevation, pass.PeakAzimuth = t, horiz.Altitude, horiz.Azimuth
+		}
+	}
+
+	return pass, inPass
+}
+
+// LimitWindow is when an aircraft is predicted to be within the telescope's
+// altitude limits, as offsets from the time of the prediction.
+type LimitWindow struct {
+	// Enter is zero if the aircraft is already within limits
+	Enter time.Duration
+
+	// Leave is when it drops out of limits again; only set if Leaves
+	Leave  time.Duration
+	Leaves bool
+}
+
+// PredictLimitWindow extrapolates the aircraft along its current track and
+// returns the first window within limits between from and from+horizon.
+// Leaves is false if the aircraft is still within limits at the end of the
+// horizon. Returns false if it never enters limits.
+func PredictLimitWindow(
+	aircraft adsb.Aircraft,
+	observer coordinates.Observer,
+	from time.Time,
+	horizon time.Duration,
+	limits TrackingLimits,
+) (LimitWindow, bool) {
+	var window LimitWindow
+	inside := false
+
+	for offset := time.Duration(0); offset <= horizon; offset += passStep {
+		t := from.Add(offset)
+		pos := PredictPosition(aircraft, t).Position
+		horiz := coordinates.GeographicToHorizontal(pos, observer, t)
+		within := horiz.Altitude >= limits.MinAltitude && horiz.Altitude <= limits.MaxAltitude
+
+		switch {
+		case within && !inside:
+			inside = true
+			window.Enter = offset
+		case !within && inside:
+			window.Leave = offset
+			window.Leaves = true
+			return window, true
+		}
+	}
+
+	return window, inside
+}
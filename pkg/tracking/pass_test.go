@@ -0,0 +1,119 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestPredictPass tests pass prediction along the aircraft's current track.
+func TestPredictPass(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := TrackingLimits{MinAltitude: 30, MaxAltitude: 90}
+
+	// 10 NM west of the observer at 10,000 ft
+	inbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Latitude: 35.0, Longitude: -80.2036, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now,
+	}
+
+	t.Run("Overflight", func(t *testing.T) {
+		pass, ok := PredictPass(inbound, observer, now, 10*time.Minute, limits)
+		if !ok {
+			t.Fatal("Expected a pass for an aircraft flying overhead")
+		}
+
+		// 10 NM at 300 kts: overhead after about 2 minutes
+		if d := pass.Peak.Sub(now); d < 110*time.Second || d > 130*time.Second {
+			t.Errorf("Peak after %v, want about 2m", d)
+		}
+		if pass.MaxElevation < 80 {
+			t.Errorf("MaxElevation = %.1f°, want near zenith", pass.MaxElevation)
+		}
+		if !pass.Start.Before(pass.Peak) || !pass.Peak.Before(pass.End) {
+			t.Errorf("Expected Start < Peak < End, got %v, %v, %v", pass.Start, pass.Peak, pass.End)
+		}
+
+		// Above 30° within about 2.85 NM of the observer (1.65 NM up)
+		if d := pass.Start.Sub(now); d < 70*time.Second || d > 100*time.Second {
+			t.Errorf("Start after %v, want about 86s", d)
+		}
+	})
+
+	t.Run("Pass cut off by the horizon", func(t *testing.T) {
+		pass, ok := PredictPass(inbound, observer, now, 100*time.Second, limits)
+		if !ok {
+			t.Fatal("Expected a pass in progress at the end of the horizon")
+		}
+		if pass.End != now.Add(100*time.Second) {
+			t.Errorf("End = %v, want the end of the horizon", pass.End)
+		}
+	})
+
+	t.Run("Receding aircraft", func(t *testing.T) {
+		outbound := inbound
+		outbound.Track = 270
+		if _, ok := PredictPass(outbound, observer, now, 10*time.Minute, limits); ok {
+			t.Error("Expected no pass for an aircraft flying away")
+		}
+	})
+}
+
+// TestPredictLimitWindow tests when aircraft enter and leave the altitude limits.
+func TestPredictLimitWindow(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	observer := coordinates.Observer{Location: coordinates.Geographic{Latitude: 35.0, Longitude: -80.0}}
+	limits := TrackingLimits{MinAltitude: 30, MaxAltitude: 80}
+
+	// 10 NM west of the observer at 10,000 ft, flying east at 300 kts
+	inbound := adsb.Aircraft{
+		ICAO: "A1B2C3", Latitude: 35.0, Longitude: -80.2036, Altitude: 10000,
+		GroundSpeed: 300, Track: 90, LastSeen: now,
+	}
+
+	t.Run("Approaching aircraft", func(t *testing.T) {
+		window, ok := PredictLimitWindow(inbound, observer, now, 10*time.Minute, limits)
+		if !ok {
+			t.Fatal("Expected the aircraft to enter limits")
+		}
+
+		// Above 30° after about 86s; above 80° (0.29 NM out) about 2s before overhead
+		if window.Enter < 70*time.Second || window.Enter > 100*time.Second {
+			t.Errorf("Enter = %v, want about 86s", window.Enter)
+		}
+		if !window.Leaves {
+			t.Fatal("Expected the aircraft to leave limits within the horizon")
+		}
+		if window.Leave < 100*time.Second || window.Leave > 130*time.Second {
+			t.Errorf("Leave = %v, want about 2m", window.Leave)
+		}
+	})
+
+	t.Run("Already within limits", func(t *testing.T) {
+		// 2 NM west: about 39° up
+		near := inbound
+		near.Longitude = -80.0407
+		near.GroundSpeed = 0
+		window, ok := PredictLimitWindow(near, observer, now, 5*time.Minute, limits)
+		if !ok {
+			t.Fatal("Expected the aircraft to be within limits")
+		}
+		if window.Enter != 0 {
+			t.Errorf("Enter = %v, want 0", window.Enter)
+		}
+		if window.Leaves {
+			t.Errorf("Expected a stationary aircraft to stay within limits, leaves after %v", window.Leave)
+		}
+	})
+
+	t.Run("Receding aircraft", func(t *testing.T) {
+		outbound := inbound
+		outbound.Track = 270
+		if _, ok := PredictLimitWindow(outbound, observer, now, 10*time.Minute, limits); ok {
+			t.Error("Expected a receding aircraft never to enter limits")
+		}
+	})
+}
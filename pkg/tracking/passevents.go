@@ -0,0 +1,103 @@
+package tracking
+
+// PassEvent is a notable moment while tracking an aircraft across the sky.
+type PassEvent int
+
+const (
+	// Culmination means the target has passed its highest elevation
+	Culmination PassEvent = iota + 1
+
+	// ApproachingLimit means the target is about to leave the altitude limits
+	// (descending towards the minimum or climbing towards the maximum)
+	ApproachingLimit
+
+	// TargetLost means the target is no longer reported or is outside the limits
+	TargetLost
+)
+
+// String returns a short description of the event.
+func (e PassEvent) String() string {
+	switch e {
+	case Culmination:
+		return "culmination"
+	case ApproachingLimit:
+		return "approaching limit"
+	case TargetLost:
+		return "target lost"
+	default:
+		return "unknown"
+	}
+}
+
+// culminationDrop is how far (degrees) elevation must fall from its peak
+// before culmination is announced, so position jitter doesn't trigger it.
+const culminationDrop = 0.2
+
+// PassMonitor watches the elevation of a tracked target and raises
+// PassEvents as the pass progresses. Each event is raised once per pass.
+type PassMonitor struct {
+	limits TrackingLimits
+	margin float64 // Degrees from a limit that counts as approaching it
+
+	started    bool
+	first      float64 // Elevation when tracking started
+	peak       float64 // Highest elevation seen
+	last       float64 // Previous elevation
+	culminated bool
+	nearLimit  bool
+	lost       bool
+}
+
+// NewPassMonitor creates a monitor for the given altitude limits. margin is
+// how close (degrees) to a limit triggers ApproachingLimit.
+func NewPassMonitor(limits TrackingLimits, margin float64) *PassMonitor {
+	return &PassMonitor{limits: limits, margin: margin}
+}
+
+// Reset starts monitoring a new pass.
+func (p *PassMonitor) Reset() {
+	*p = PassMonitor{limits: p.limits, margin: p.margin}
+}
+
+// Update feeds the target's current elevation (found is false when the
+// target was not reported) and returns the events raised by this sample.
+func (p *PassMonitor) Update(elevation float64, found bool) []PassEvent {
+	var events []PassEvent
+
+	if !found || elevation < p.limits.MinAltitude || elevation > p.limits.MaxAltitude {
+		if p.started && !p.lost {
+			events = append(events, TargetLost)
+		}
+		p.lost = true
+		return events
+	}
+	p.lost = false
+
+	if !p.started {
+		p.started = true
+		p.first, p.peak, p.last = elevation, elevation, elevation
+		return events
+	}
+
+	// Culmination: rose noticeably, then fell back from the peak
+	if elevation > p.peak {
+		p.peak = elevation
+	}
+	if !p.culminated && p.peak-p.first >= culminationDrop && p.peak-elevation >= culminationDrop {
+		p.culminated = true
+		events = append(events, Culmination)
+	}
+
+	// Approaching a limit in the direction of travel
+	near := (elevation < p.last && elevation-p.limits.MinAltitude <= p.margin) ||
+		(elevation > p.last && p.limits.MaxAltitude-elevation <= p.margin)
+	if near && !p.nearLimit {
+		events = append(events, ApproachingLimit)
+	}
+	if elevation != p.last {
+		p.nearLimit = near
+	}
+
+	p.last = elevation
+	return events
+}
@@ -0,0 +1,79 @@
+package tracking
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestPassMonitor tests pass events raised from elevation samples.
+func TestPassMonitor(t *testing.T) {
+	limits := TrackingLimits{MinAltitude: 15, MaxAltitude: 85}
+
+	type sample struct {
+		elevation float64
+		found     bool
+	}
+
+	tests := []struct {
+		name    string
+		samples []sample
+		want    []PassEvent
+	}{
+		{
+			name:    "Rising then setting pass",
+			samples: []sample{{30, true}, {40, true}, {50, true}, {48, true}, {30, true}, {18, true}, {16, true}, {12, true}},
+			want:    []PassEvent{Culmination, ApproachingLimit, TargetLost},
+		},
+		{
+			name:    "Jitter at the peak is not culmination",
+			samples: []sample{{40, true}, {40.1, true}, {40.0, true}, {40.1, true}},
+			want:    nil,
+		},
+		{
+			name:    "Acquired while descending has no culmination",
+			samples: []sample{{50, true}, {45, true}, {40, true}},
+			want:    nil,
+		},
+		{
+			name:    "Climbing towards the maximum",
+			samples: []sample{{70, true}, {78, true}, {82, true}, {84, true}},
+			want:    []PassEvent{ApproachingLimit},
+		},
+		{
+			name:    "Target no longer reported",
+			samples: []sample{{30, true}, {0, false}, {0, false}},
+			want:    []PassEvent{TargetLost},
+		},
+		{
+			name:    "Lost before first sample raises nothing",
+			samples: []sample{{0, false}, {10, true}},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := NewPassMonitor(limits, 5)
+
+			var got []PassEvent
+			for _, s := range tt.samples {
+				got = append(got, monitor.Update(s.elevation, s.found)...)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Reset starts a new pass", func(t *testing.T) {
+		monitor := NewPassMonitor(limits, 5)
+		monitor.Update(30, true)
+		monitor.Update(0, false)
+		monitor.Reset()
+
+		if events := monitor.Update(0, false); len(events) != 0 {
+			t.Errorf("events after Reset = %v, want none", events)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package tracking
+
+import (
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/performance"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// FlightPhase is the stage of flight an aircraft is in.
+type FlightPhase string
+
+const (
+	PhaseUnknown  FlightPhase = ""
+	PhaseClimb    FlightPhase = "climb"
+	PhaseCruise   FlightPhase = "cruise"
+	PhaseDescent  FlightPhase = "descent"
+	PhaseApproach FlightPhase = "approach"
+)
+
+const (
+	// levelRateFPM is the vertical rate below which an aircraft is level
+	levelRateFPM = 300.0
+
+	// approachRangeNM is how close to its destination a descending or
+	// level aircraft below approachCeilingFt is on approach
+	approachRangeNM   = 30.0
+	approachCeilingFt = 10000.0
+
+	// Without a destination, an aircraft descending below this altitude at
+	// less than approachSpeedKts is assumed to be on approach
+	lowApproachFt    = 5000.0
+	approachSpeedKts = 200.0
+
+	// glidePathFtPerNM is the height above the runway of a 3° glide path
+	// per nautical mile from it
+	glidePathFtPerNM = 318.0
+
+	// decelerationTau is the time constant of the slowdown to approach speed
+	decelerationTau = 2 * time.Minute
+)
+
+// FlightContext is what is known of an aircraft's flight beyond its ADS-B
+// state, typically from its flight plan.
+type FlightContext struct {
+	// Destination is the arrival airport, nil if unknown. Its Altitude is
+	// the field elevation in meters (0 if unknown).
+	Destination *coordinates.Geographic
+
+	// FiledAltitudeFt is the filed cruise altitude, 0 if unknown
+	FiledAltitudeFt float64
+}
+
+// DetectPhase classifies an aircraft's phase of flight from its altitude,
+// vertical rate and, if known, distance to its destination.
+func DetectPhase(aircraft adsb.Aircraft, fc FlightContext) FlightPhase {
+	if aircraft.GroundSpeed <= 0 {
+		return PhaseUnknown
+	}
+
+	climbing := aircraft.VerticalRate >= levelRateFPM
+	if !climbing && onApproach(aircraft, fc) {
+		return PhaseApproach
+	}
+
+	switch {
+	case climbing:
+		return PhaseClimb
+	case aircraft.VerticalRate <= -levelRateFPM:
+		return PhaseDescent
+	default:
+		return PhaseCruise
+	}
+}
+
+// onApproach reports whether a level or descending aircraft is on approach.
+func onApproach(aircraft adsb.Aircraft, fc FlightContext) bool {
+	if fc.Destination != nil {
+		pos := coordinates.Geographic{Latitude: aircraft.Latitude, Longitude: aircraft.Longitude}
+		heightFt := aircraft.Altitude - fc.Destination.Altitude.Feet()
+		return coordinates.DistanceNauticalMiles(pos, *fc.Destination) <= approachRangeNM &&
+			heightFt < approachCeilingFt
+	}
+
+	return aircraft.VerticalRate <= -levelRateFPM &&
+		aircraft.Altitude < lowApproachFt &&
+		aircraft.GroundSpeed < approachSpeedKts
+}
+
+// PredictPositionInPhase predicts an aircraft's position like
+// PredictPosition, adjusted for its phase of flight:
+//   - Climbing aircraft level off at their filed altitude
+//   - Aircraft on approach to a known destination slow towards approach
+//     speed, and descend no lower than a 3° glide path to the field
+//     (holding altitude until they intercept it from below)
+func PredictPositionInPhase(aircraft adsb.Aircraft, fc FlightContext, predictionTime time.Time) PredictedPosition {
+	pred := PredictPosition(aircraft, predictionTime)
+	deltaT := predictionTime.Sub(aircraft.LastSeen).Seconds()
+	if deltaT <= 0 {
+		return pred
+	}
+
+	switch DetectPhase(aircraft, fc) {
+	case PhaseClimb:
+		if filed := units.Feet(fc.FiledAltitudeFt); filed > aircraft.Altitude {
+			pred.Position.Altitude = min(pred.Position.Altitude, filed.Meters())
+		}
+
+	case PhaseApproach:
+		if fc.Destination == nil {
+			break
+		}
+
+		// Slow exponentially towards approach speed
+		perf := performance.Lookup(aircraft.AircraftType)
+		target := math.Min(aircraft.GroundSpeed, approachSpeed(perf))
+		tau := decelerationTau.Seconds()
+		distanceNM := (target*deltaT + (aircraft.GroundSpeed-target)*tau*(1-math.Exp(-deltaT/tau))) / 3600
+		avgSpeed := distanceNM * 3600 / deltaT
+		lat, lon := predictHorizontalPosition(aircraft.Latitude, aircraft.Longitude, avgSpeed, aircraft.Track, deltaT)
+		pred.Position.Latitude, pred.Position.Longitude = lat, lon
+
+		// Capture the glide path
+		fieldFt := fc.Destination.Altitude.Feet()
+		toFieldNM := coordinates.DistanceNauticalMiles(pred.Position, *fc.Destination)
+		glidePathFt := fieldFt + units.Feet(toFieldNM*glidePathFtPerNM)
+		floorFt := min(aircraft.Altitude, glidePathFt)
+		pred.Position.Altitude = max(pred.Position.Altitude, floorFt.Meters())
+	}
+
+	return pred
+}
+
+// approachSpeed returns a typical final approach speed for a type, in knots.
+func approachSpeed(perf performance.Performance) float64 {
+	return math.Max(60, perf.CruiseKts*0.3)
+}
@@ -0,0 +1,100 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
+)
+
+// TestDetectPhase tests classifying the phase of flight.
+func TestDetectPhase(t *testing.T) {
+	// Destination on the equator; 0.1° of longitude is 6 NM
+	dest := FlightContext{Destination: &coordinates.Geographic{}}
+
+	tests := []struct {
+		name     string
+		lon      float64
+		altitude units.Feet
+		speed    float64
+		vr       float64
+		fc       FlightContext
+		expected FlightPhase
+	}{
+		{"Climbing", -1, 12000, 300, 2000, FlightContext{}, PhaseClimb},
+		{"Level at altitude", -1, 35000, 450, 0, FlightContext{}, PhaseCruise},
+		{"Descending", -1, 20000, 400, -1500, FlightContext{}, PhaseDescent},
+		{"Low and slow descending", -1, 3000, 160, -700, FlightContext{}, PhaseApproach},
+		{"Near destination and low", -0.3, 4000, 180, -800, dest, PhaseApproach},
+		{"Level near destination", -0.3, 3000, 180, 0, dest, PhaseApproach},
+		{"Near destination but high", -0.3, 15000, 300, -1500, dest, PhaseDescent},
+		{"Far from destination", -1, 4000, 180, -800, dest, PhaseDescent},
+		{"Go-around near destination", -0.1, 1500, 150, 1500, dest, PhaseClimb},
+		{"No velocity", -1, 0, 0, 0, FlightContext{}, PhaseUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aircraft := adsb.Aircraft{
+				Longitude:    tt.lon,
+				Altitude:     tt.altitude,
+				GroundSpeed:  tt.speed,
+				Track:        90,
+				VerticalRate: tt.vr,
+			}
+			if got := DetectPhase(aircraft, tt.fc); got != tt.expected {
+				t.Errorf("DetectPhase() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPredictPositionInPhase tests phase adjustments to predictions.
+func TestPredictPositionInPhase(t *testing.T) {
+	now := time.Now().UTC()
+	dest := &coordinates.Geographic{}
+
+	t.Run("Climb levels off at filed altitude", func(t *testing.T) {
+		aircraft := adsb.Aircraft{Altitude: 33000, GroundSpeed: 400, Track: 90, VerticalRate: 2000, LastSeen: now}
+		pred := PredictPositionInPhase(aircraft, FlightContext{FiledAltitudeFt: 35000}, now.Add(2*time.Minute))
+		if got := pred.Position.Altitude.Feet(); math.Abs(float64(got)-35000) > 1 {
+			t.Errorf("Altitude = %.0f ft, expected 35000 ft", got)
+		}
+	})
+
+	t.Run("Approach decelerates", func(t *testing.T) {
+		aircraft := adsb.Aircraft{Longitude: -0.4, Altitude: 6000, GroundSpeed: 250, Track: 90, VerticalRate: -800, LastSeen: now}
+		at := now.Add(2 * time.Minute)
+		plain := PredictPosition(aircraft, at).Position
+		pred := PredictPositionInPhase(aircraft, FlightContext{Destination: dest}, at).Position
+
+		start := coordinates.Geographic{Longitude: aircraft.Longitude}
+		plainNM := coordinates.DistanceNauticalMiles(start, plain)
+		phaseNM := coordinates.DistanceNauticalMiles(start, pred)
+		if phaseNM >= plainNM || phaseNM < plainNM*0.5 {
+			t.Errorf("Distance flown = %.1f NM, expected less than dead reckoning's %.1f NM", phaseNM, plainNM)
+		}
+	})
+
+	t.Run("Approach captures the glide path", func(t *testing.T) {
+		// 12 NM out descending steeply: a minute later it is ~9 NM out,
+		// where the glide path is at ~2860 ft (dead reckoning gives 1500 ft)
+		aircraft := adsb.Aircraft{Longitude: -0.2, Altitude: 4500, GroundSpeed: 180, Track: 90, VerticalRate: -3000, LastSeen: now}
+		pred := PredictPositionInPhase(aircraft, FlightContext{Destination: dest}, now.Add(time.Minute))
+		got := pred.Position.Altitude.Feet()
+		if got < 2700 || got > 3000 {
+			t.Errorf("Altitude = %.0f ft, expected on the glide path (2700-3000 ft)", got)
+		}
+	})
+
+	t.Run("Level below glide path holds altitude", func(t *testing.T) {
+		aircraft := adsb.Aircraft{Longitude: -0.3, Altitude: 3000, GroundSpeed: 180, Track: 90, LastSeen: now}
+		pred := PredictPositionInPhase(aircraft, FlightContext{Destination: dest}, now.Add(30*time.Second))
+		if got := pred.Position.Altitude.Feet(); math.Abs(float64(got)-3000) > 1 {
+			t.Errorf("Altitude = %.0f ft, expected 3000 ft", got)
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/unklstewy/ads-bscope/pkg/adsb"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/performance"
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // Waypoint represents a navigation waypoint from a flight plan.
@@ -40,10 +42,13 @@ type PredictedPosition struct {
 // - Current position (lat/lon/altitude)
 // - Ground speed and track (horizontal motion)
 // - Vertical rate (climb/descent)
+// - Autopilot selected altitude and heading, when the source reports them
 //
 // Assumptions:
 // - Aircraft maintains current speed and heading (reasonable for short predictions)
-// - Vertical rate remains constant
+// - Aircraft turns onto a new selected heading at standard rate or 25° bank
+// - Vertical rate remains constant, within the aircraft type's performance
+// - Climbs and descents stop at the selected altitude
 // - No wind correction (would require weather data)
 //
 // Parameters:
@@ -61,7 +66,7 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 			Position: coordinates.Geographic{
 				Latitude:  aircraft.Latitude,
 				Longitude: aircraft.Longitude,
-				Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+				Altitude:  aircraft.Altitude.Meters(),
 			},
 			PredictionTime:   predictionTime,
 			Confidence:       1.0,
@@ -79,7 +84,8 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 		confidence *= 0.5
 	}
 
-	// Predict horizontal position using great circle navigation
+	// Predict horizontal position using great circle navigation, turning
+	// onto the selected heading if the autopilot has a new one
 	newLat, newLon := predictHorizontalPosition(
 		aircraft.Latitude,
 		aircraft.Longitude,
@@ -87,15 +93,18 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 		aircraft.Track,
 		deltaT,
 	)
+	if turn := intentTurn(aircraft); turn != 0 {
+		newLat, newLon = predictTurningPosition(aircraft, turn, deltaT)
+	}
 
-	// Predict altitude change
-	// VerticalRate is in feet per minute, convert to feet per second
-	altitudeChangeFt := aircraft.VerticalRate * (deltaT / 60.0)
-	newAltitudeFt := aircraft.Altitude + altitudeChangeFt
+	// Predict altitude change, within the aircraft type's performance
+	newAltitudeFt, plausible := predictAltitude(aircraft, deltaT)
+	if !plausible {
+		confidence *= 0.8 // Reported vertical rate is noise
+	}
 
 	// Ensure altitude doesn't go below ground (0 feet MSL minimum)
-	if newAltitudeFt < 0 {
-		newAltitudeFt = 0
+	if newAltitudeFt <= 0 && aircraft.VerticalRate < 0 {
 		confidence *= 0.5 // Reduce confidence if we hit ground
 	}
 
@@ -103,7 +112,7 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 		Position: coordinates.Geographic{
 			Latitude:  newLat,
 			Longitude: newLon,
-			Altitude:  newAltitudeFt * coordinates.FeetToMeters,
+			Altitude:  newAltitudeFt.Meters(),
 		},
 		PredictionTime:   predictionTime,
 		Confidence:       confidence,
@@ -111,6 +120,29 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 	}
 }
 
+// predictAltitude extrapolates an aircraft's altitude in feet deltaT seconds
+// ahead. The vertical rate is limited to what the aircraft's type can
+// plausibly fly, so a noisy rate isn't carried for minutes, and the result to
+// between the ground and the type's ceiling. A climb or descent stops at the
+// autopilot's selected altitude. The second result is false if the reported
+// vertical rate was implausible.
+func predictAltitude(aircraft adsb.Aircraft, deltaT float64) (units.Feet, bool) {
+	perf := performance.Lookup(aircraft.AircraftType)
+
+	// VerticalRate is in feet per minute
+	rate, clamped := perf.ClampVerticalRate(aircraft.VerticalRate)
+	altitudeFt := aircraft.Altitude + units.Feet(rate*(deltaT/60.0))
+
+	// An aircraft already above the ceiling (bad type or data) keeps its
+	// altitude rather than being pulled down to it
+	ceiling := units.Feet(perf.CeilingFt)
+	if altitudeFt > ceiling && altitudeFt > aircraft.Altitude {
+		altitudeFt = max(aircraft.Altitude, ceiling)
+	}
+	altitudeFt = levelOffAltitude(aircraft, altitudeFt)
+	return max(0, altitudeFt), !clamped
+}
+
 // PredictPositionWithLatency predicts position accounting for typical system latency.
 // This is a convenience function that adds an estimated latency to the current time.
 //
@@ -118,9 +150,12 @@ func PredictPosition(aircraft adsb.Aircraft, predictionTime time.Time) Predicted
 // - Online ADS-B services: 2-3 seconds
 // - Local SDR receivers: 0.5-1 second
 //
+// Measure the source's latency with an adsb.LatencyHistogram rather than
+// assuming one: its PredictionLatency is the median age of the positions.
+//
 // Parameters:
 //   - aircraft: Current aircraft state
-//   - estimatedLatencySeconds: Expected system latency (recommend 2.5 for online, 0.75 for local)
+//   - estimatedLatencySeconds: Expected system latency (the source's measured median)
 func PredictPositionWithLatency(aircraft adsb.Aircraft, estimatedLatencySeconds float64) PredictedPosition {
 	predictionTime := time.Now().UTC().Add(time.Duration(estimatedLatencySeconds * float64(time.Second)))
 	return PredictPosition(aircraft, predictionTime)
@@ -221,7 +256,7 @@ func CalculateLeadTime(currentAlt, currentAz, targetAlt, targetAz, slewRateDegPe
 //   - aircraft: Current aircraft state
 //   - currentAlt, currentAz: Telescope's current position
 //   - slewRateDegPerSec: Telescope slew rate
-//   - systemLatencySeconds: Estimated latency (recommend 2.5s for online, 0.75s for local)
+//   - systemLatencySeconds: Estimated latency (the source's measured median, see PredictPositionWithLatency)
 //
 // Returns: Predicted position at the time telescope will actually be pointing
 func PredictTrackingPosition(
@@ -375,7 +410,7 @@ func predictAlongGreatCircle(
 			Position: coordinates.Geographic{
 				Latitude:  waypointPos.Latitude,
 				Longitude: waypointPos.Longitude,
-				Altitude:  aircraft.Altitude * coordinates.FeetToMeters,
+				Altitude:  aircraft.Altitude.Meters(),
 			},
 			PredictionTime:   predictionTime,
 			Confidence:       confidence,
@@ -396,8 +431,7 @@ func predictAlongGreatCircle(
 	)
 
 	// Predict altitude change
-	altitudeChangeFt := aircraft.VerticalRate * (deltaT / 60.0)
-	newAltitudeFt := math.Max(0, aircraft.Altitude+altitudeChangeFt)
+	newAltitudeFt, _ := predictAltitude(aircraft, deltaT)
 
 	// Calculate confidence - higher for waypoint-based prediction
 	// Starts at 0.95 (better than dead reckoning's 1.0 due to realism)
@@ -410,7 +444,7 @@ func predictAlongGreatCircle(
 		Position: coordinates.Geographic{
 			Latitude:  newLat,
 			Longitude: newLon,
-			Altitude:  newAltitudeFt * coordinates.FeetToMeters,
+			Altitude:  newAltitudeFt.Meters(),
 		},
 		PredictionTime:   predictionTime,
 		Confidence:       confidence,
@@ -540,10 +574,10 @@ func MatchAirway(aircraft adsb.Aircraft, airways []AirwaySegment) *AirwaySegment
 		seg := &airways[i]
 
 		// Check altitude limits
-		if seg.MinAltitude > 0 && aircraft.Altitude < float64(seg.MinAltitude) {
+		if seg.MinAltitude > 0 && aircraft.Altitude < units.Feet(seg.MinAltitude) {
 			continue
 		}
-		if seg.MaxAltitude > 0 && aircraft.Altitude > float64(seg.MaxAltitude) {
+		if seg.MaxAltitude > 0 && aircraft.Altitude > units.Feet(seg.MaxAltitude) {
 			continue
 		}
 
@@ -670,7 +704,7 @@ func PredictPositionWithAirway(
 // FilterAirwaysByAltitude filters airways based on aircraft altitude.
 // Victor airways: <18,000 ft MSL
 // Jet routes: >=18,000 ft MSL
-func FilterAirwaysByAltitude(airways []AirwaySegment, altitudeFt float64) []AirwaySegment {
+func FilterAirwaysByAltitude(airways []AirwaySegment, altitudeFt units.Feet) []AirwaySegment {
 	filtered := make([]AirwaySegment, 0)
 
 	for _, airway := range airways {
@@ -697,10 +731,10 @@ func FilterAirwaysByAltitude(airways []AirwaySegment, altitudeFt float64) []Airw
 		}
 
 		// Other airways - check altitude limits if specified
-		if airway.MinAltitude > 0 && altitudeFt < float64(airway.MinAltitude) {
+		if airway.MinAltitude > 0 && altitudeFt < units.Feet(airway.MinAltitude) {
 			continue
 		}
-		if airway.MaxAltitude > 0 && altitudeFt > float64(airway.MaxAltitude) {
+		if airway.MaxAltitude > 0 && altitudeFt > units.Feet(airway.MaxAltitude) {
 			continue
 		}
 
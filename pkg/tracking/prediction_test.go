@@ -7,6 +7,7 @@ import (
 
 	"github.com/unklstewy/ads-bscope/pkg/adsb"
 	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+	"github.com/unklstewy/ads-bscope/pkg/units"
 )
 
 // TestPredictPosition tests basic position prediction.
@@ -97,8 +98,8 @@ func TestPredictPosition(t *testing.T) {
 		// Predict 1 minute ahead
 		pred := PredictPosition(aircraft, now.Add(60*time.Second))
 
-		expectedAlt := 11000.0 * coordinates.FeetToMeters
-		if math.Abs(pred.Position.Altitude-expectedAlt) > 10.0 {
+		expectedAlt := units.Feet(11000).Meters()
+		if math.Abs(float64(pred.Position.Altitude-expectedAlt)) > 10.0 {
 			t.Errorf("Expected altitude ~%f, got %f", expectedAlt, pred.Position.Altitude)
 		}
 	})
@@ -125,6 +126,41 @@ func TestPredictPosition(t *testing.T) {
 	})
 }
 
+// TestPredictAltitudePerformance tests that extrapolated altitude stays
+// within the aircraft type's performance.
+func TestPredictAltitudePerformance(t *testing.T) {
+	tests := []struct {
+		name         string
+		aircraftType string
+		altitude     units.Feet
+		verticalRate float64
+		minutes      float64
+		expected     units.Feet
+		plausible    bool
+	}{
+		{"Normal climb", "B738", 10000, 2000, 2, 14000, true},
+		{"Noisy climb clamped to type", "B738", 10000, 12000, 2, 17500, false},
+		{"Noisy descent clamped to type", "B738", 20000, -9000, 2, 12500, false},
+		{"Stops at the ceiling", "C172", 12000, 800, 5, 14000, true},
+		{"Unknown type uses generic limits", "", 10000, 20000, 1, 19000, false},
+		{"Already above the ceiling", "C172", 16000, 500, 1, 16000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aircraft := adsb.Aircraft{
+				AircraftType: tt.aircraftType,
+				Altitude:     tt.altitude,
+				VerticalRate: tt.verticalRate,
+			}
+			got, plausible := predictAltitude(aircraft, tt.minutes*60)
+			if math.Abs(float64(got-tt.expected)) > 1 || plausible != tt.plausible {
+				t.Errorf("predictAltitude() = %.0f ft, %v; expected %.0f ft, %v", got, plausible, tt.expected, tt.plausible)
+			}
+		})
+	}
+}
+
 // TestPredictPositionWithLatency tests latency compensation.
 func TestPredictPositionWithLatency(t *testing.T) {
 	now := time.Now().UTC()
@@ -0,0 +1,115 @@
+package tracking
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// minETASpeedKts is the ground speed below which no ETAs are estimated: the
+// aircraft is taxiing or parked
+const minETASpeedKts = 50.0
+
+// WaypointETA is when an aircraft is expected at one of its remaining
+// waypoints.
+type WaypointETA struct {
+	Name     string
+	Sequence int
+
+	// DistanceNM is the distance along the route from the aircraft
+	DistanceNM float64
+
+	// ETA is nil if the aircraft is too slow to estimate one
+	ETA *time.Time
+}
+
+// RouteProgress is how far an aircraft has flown along its route.
+type RouteProgress struct {
+	// TotalNM is the length of the route from its first waypoint to its
+	// last, the destination
+	TotalNM float64
+
+	// RemainingNM is the distance from the aircraft through the waypoints
+	// not yet passed
+	RemainingNM float64
+
+	// PercentComplete is the share of the route flown (0-100)
+	PercentComplete float64
+
+	// Remaining are the waypoints not yet passed, in route order
+	Remaining []WaypointETA
+}
+
+// Next returns the next waypoint, or nil once all have been passed.
+func (p RouteProgress) Next() *WaypointETA {
+	if len(p.Remaining) == 0 {
+		return nil
+	}
+	return &p.Remaining[0]
+}
+
+// Destination returns the last waypoint, or nil once it has been passed.
+func (p RouteProgress) Destination() *WaypointETA {
+	if len(p.Remaining) == 0 {
+		return nil
+	}
+	return &p.Remaining[len(p.Remaining)-1]
+}
+
+// EstimateRouteProgress works out how much of its route an aircraft has
+// flown and when it will reach each remaining waypoint, flying the legs
+// between them as great circles at its current ground speed from the time
+// of its report. The last waypoint is taken as the destination, so callers
+// append the arrival airport if the route doesn't end there. Passed flags
+// are used as given (see DeterminePassedWaypoints). Returns false without
+// waypoints.
+func EstimateRouteProgress(aircraft adsb.Aircraft, waypoints []Waypoint) (RouteProgress, bool) {
+	if len(waypoints) == 0 {
+		return RouteProgress{}, false
+	}
+	route := make([]Waypoint, len(waypoints))
+	copy(route, waypoints)
+	sort.SliceStable(route, func(i, j int) bool { return route[i].Sequence < route[j].Sequence })
+
+	var progress RouteProgress
+	for i := 1; i < len(route); i++ {
+		progress.TotalNM += coordinates.DistanceNauticalMiles(waypointPosition(route[i-1]), waypointPosition(route[i]))
+	}
+
+	// Along the route from the aircraft: direct to the next waypoint, then
+	// leg by leg
+	pos := coordinates.Geographic{Latitude: aircraft.Latitude, Longitude: aircraft.Longitude}
+	speed := float64(aircraft.GroundSpeed)
+	for _, wp := range route {
+		if wp.Passed {
+			continue
+		}
+		next := waypointPosition(wp)
+		progress.RemainingNM += coordinates.DistanceNauticalMiles(pos, next)
+		pos = next
+
+		eta := WaypointETA{Name: wp.Name, Sequence: wp.Sequence, DistanceNM: progress.RemainingNM}
+		if speed >= minETASpeedKts {
+			at := aircraft.LastSeen.Add(time.Duration(progress.RemainingNM / speed * float64(time.Hour)))
+			eta.ETA = &at
+		}
+		progress.Remaining = append(progress.Remaining, eta)
+	}
+
+	switch {
+	case len(progress.Remaining) == 0:
+		progress.PercentComplete = 100
+	case progress.TotalNM > 0:
+		// Off the route the remaining distance can exceed the route's
+		progress.PercentComplete = math.Max(0, math.Min(100, 100*(1-progress.RemainingNM/progress.TotalNM)))
+	}
+	return progress, true
+}
+
+// waypointPosition returns a waypoint's location.
+func waypointPosition(wp Waypoint) coordinates.Geographic {
+	return coordinates.Geographic{Latitude: wp.Latitude, Longitude: wp.Longitude}
+}
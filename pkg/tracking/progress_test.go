@@ -0,0 +1,81 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/adsb"
+)
+
+// TestEstimateRouteProgress tests progress and ETAs along a route.
+func TestEstimateRouteProgress(t *testing.T) {
+	reported := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Three waypoints a degree of longitude (~60 NM) apart on the equator,
+	// given out of order
+	waypoints := []Waypoint{
+		{Name: "DEST", Sequence: 3, Longitude: 2},
+		{Name: "ORIG", Sequence: 1, Longitude: 0, Passed: true},
+		{Name: "MID", Sequence: 2, Longitude: 1},
+	}
+	aircraft := adsb.Aircraft{Longitude: 0.5, GroundSpeed: 120, Track: 90, LastSeen: reported}
+
+	p, ok := EstimateRouteProgress(aircraft, waypoints)
+	if !ok {
+		t.Fatal("Expected progress")
+	}
+	if math.Abs(p.PercentComplete-25) > 0.01 {
+		t.Errorf("Expected 25%% complete, got %.2f%%", p.PercentComplete)
+	}
+	if math.Abs(p.RemainingNM-0.75*p.TotalNM) > 0.01 {
+		t.Errorf("Expected three quarters of %.1f NM remaining, got %.1f NM", p.TotalNM, p.RemainingNM)
+	}
+
+	next, dest := p.Next(), p.Destination()
+	if next == nil || next.Name != "MID" || dest == nil || dest.Name != "DEST" {
+		t.Fatalf("Expected MID next and DEST last, got %+v", p.Remaining)
+	}
+	tests := []struct {
+		name     string
+		eta      *time.Time
+		expected time.Duration
+	}{
+		{"Next waypoint, 30 NM at 120 kt", next.ETA, 15 * time.Minute},
+		{"Destination, 90 NM at 120 kt", dest.ETA, 45 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.eta == nil {
+				t.Fatal("Expected an ETA")
+			}
+			if got := tt.eta.Sub(reported); math.Abs((got - tt.expected).Seconds()) > 5 {
+				t.Errorf("Expected %v after the report, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("No ETAs while taxiing", func(t *testing.T) {
+		slow := aircraft
+		slow.GroundSpeed = 15
+		p, _ := EstimateRouteProgress(slow, waypoints)
+		if p.Next().ETA != nil {
+			t.Errorf("Expected no ETA, got %v", p.Next().ETA)
+		}
+	})
+
+	t.Run("All passed", func(t *testing.T) {
+		passed := make([]Waypoint, len(waypoints))
+		for i, wp := range waypoints {
+			wp.Passed = true
+			passed[i] = wp
+		}
+		p, _ := EstimateRouteProgress(aircraft, passed)
+		if p.PercentComplete != 100 || p.Next() != nil {
+			t.Errorf("Expected 100%% and no next waypoint, got %.0f%% and %+v", p.PercentComplete, p.Next())
+		}
+	})
+
+	if _, ok := EstimateRouteProgress(aircraft, nil); ok {
+		t.Error("Expected no progress without waypoints")
+	}
+}
@@ -0,0 +1,91 @@
+package tracking
+
+import (
+	"math"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// earthRadiusNM is the Earth's mean radius in nautical miles
+const earthRadiusNM = coordinates.EarthRadiusKm / 1.852
+
+// RouteDeviationNM returns how far a position is from a route, in nautical
+// miles: the shortest distance to any leg between consecutive waypoints.
+// Positions beyond either end of a leg are measured to the nearer waypoint.
+// Returns +Inf for an empty route.
+func RouteDeviationNM(pos coordinates.Geographic, route []coordinates.Geographic) float64 {
+	if len(route) == 0 {
+		return math.Inf(1)
+	}
+	if len(route) == 1 {
+		return coordinates.DistanceNauticalMiles(pos, route[0])
+	}
+
+	best := math.Inf(1)
+	for i := 1; i < len(route); i++ {
+		best = math.Min(best, distanceToLeg(pos, route[i-1], route[i]))
+	}
+	return best
+}
+
+// distanceToLeg returns the distance in nautical miles from a position to the
+// great-circle leg between two waypoints.
+func distanceToLeg(pos, start, end coordinates.Geographic) float64 {
+	legNM := coordinates.DistanceNauticalMiles(start, end)
+	toPosNM := coordinates.DistanceNauticalMiles(start, pos)
+	if legNM == 0 {
+		return toPosNM
+	}
+
+	// Cross-track and along-track distances as angles on the sphere
+	d13 := toPosNM / earthRadiusNM
+	angle := (coordinates.Bearing(start, pos) - coordinates.Bearing(start, end)) * coordinates.DegreesToRadians
+	crossTrack := math.Asin(math.Sin(d13) * math.Sin(angle))
+	alongTrack := math.Acos(math.Max(-1, math.Min(1, math.Cos(d13)/math.Cos(crossTrack))))
+
+	// Abeam a point before the start or after the end of the leg
+	if math.Cos(angle) < 0 {
+		return toPosNM
+	}
+	if alongTrack*earthRadiusNM > legNM {
+		return coordinates.DistanceNauticalMiles(end, pos)
+	}
+
+	return math.Abs(crossTrack) * earthRadiusNM
+}
+
+// RouteConformance is an aircraft's conformance to its stored flight plan
+// route.
+type RouteConformance struct {
+	// OffRouteSince is when the aircraft last moved beyond the deviation
+	// threshold; nil while on route
+	OffRouteSince *time.Time
+
+	// Rerouted is set once the aircraft has been off route for long enough
+	// that the stored plan no longer describes where it is going
+	Rerouted bool
+}
+
+// Update returns the conformance after observing the aircraft deviationNM
+// from its route at now. An aircraft is rerouted once it has stayed further
+// than thresholdNM from the route for persist. Returning within the
+// threshold clears both. The second result is true only when the aircraft
+// has just been found rerouted, which is when its plan should be re-fetched.
+func (c RouteConformance) Update(deviationNM, thresholdNM float64, persist time.Duration, now time.Time) (RouteConformance, bool) {
+	if deviationNM <= thresholdNM {
+		return RouteConformance{}, false
+	}
+
+	if c.OffRouteSince == nil {
+		since := now
+		c.OffRouteSince = &since
+	}
+
+	if c.Rerouted || now.Sub(*c.OffRouteSince) < persist {
+		return c, false
+	}
+
+	c.Rerouted = true
+	return c, true
+}
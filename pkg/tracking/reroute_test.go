@@ -0,0 +1,82 @@
+package tracking
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unklstewy/ads-bscope/pkg/coordinates"
+)
+
+// TestRouteDeviationNM tests the distance from a position to a route.
+func TestRouteDeviationNM(t *testing.T) {
+	// Eastbound along the equator, then north: 1° is 60 NM
+	route := []coordinates.Geographic{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+		{Latitude: 1, Longitude: 1},
+	}
+
+	tests := []struct {
+		name     string
+		pos      coordinates.Geographic
+		route    []coordinates.Geographic
+		expected float64
+	}{
+		{"On first leg", coordinates.Geographic{Latitude: 0, Longitude: 0.5}, route, 0},
+		{"Abeam first leg", coordinates.Geographic{Latitude: -0.1, Longitude: 0.5}, route, 6},
+		{"Abeam second leg", coordinates.Geographic{Latitude: 0.5, Longitude: 1.2}, route, 12},
+		{"Before the start", coordinates.Geographic{Latitude: 0, Longitude: -0.2}, route, 12},
+		{"Beyond the end", coordinates.Geographic{Latitude: 1.3, Longitude: 1}, route, 18},
+		{"Inside the corner", coordinates.Geographic{Latitude: 0.05, Longitude: 0.9}, route, 3},
+		{"Single waypoint", coordinates.Geographic{Latitude: 0.1, Longitude: 0}, route[:1], 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RouteDeviationNM(tt.pos, tt.route)
+			if math.Abs(got-tt.expected) > 0.2 {
+				t.Errorf("RouteDeviationNM() = %.2f NM, expected %.2f NM", got, tt.expected)
+			}
+		})
+	}
+
+	if !math.IsInf(RouteDeviationNM(coordinates.Geographic{}, nil), 1) {
+		t.Error("Expected +Inf deviation from an empty route")
+	}
+}
+
+// TestRouteConformanceUpdate tests detecting a reroute from sustained deviation.
+func TestRouteConformanceUpdate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	const threshold = 10.0
+	const persist = 3 * time.Minute
+
+	steps := []struct {
+		offset        time.Duration
+		deviation     float64
+		offRoute      bool
+		rerouted      bool
+		newlyRerouted bool
+	}{
+		{0, 2, false, false, false},               // On route
+		{time.Minute, 15, true, false, false},     // Leaves the route
+		{3 * time.Minute, 20, true, false, false}, // Not yet for long enough
+		{4 * time.Minute, 25, true, true, true},   // Off route for 3 minutes
+		{5 * time.Minute, 30, true, true, false},  // Already flagged
+		{6 * time.Minute, 5, false, false, false}, // Back on route clears it
+		{7 * time.Minute, 12, true, false, false}, // Leaving again restarts the clock
+		{9 * time.Minute, 12, true, false, false},
+		{10 * time.Minute, 12, true, true, true},
+	}
+
+	var c RouteConformance
+	for i, step := range steps {
+		var newly bool
+		c, newly = c.Update(step.deviation, threshold, persist, start.Add(step.offset))
+		if (c.OffRouteSince != nil) != step.offRoute || c.Rerouted != step.rerouted || newly != step.newlyRerouted {
+			t.Errorf("Step %d: off route %v, rerouted %v, newly %v; expected %v, %v, %v",
+				i, c.OffRouteSince != nil, c.Rerouted, newly, step.offRoute, step.rerouted, step.newlyRerouted)
+		}
+	}
+}
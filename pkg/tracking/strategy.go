@@ -0,0 +1,65 @@
+package tracking
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PredictionStrategy selects which models predict a tracked aircraft's
+// position from a stale report.
+type PredictionStrategy string
+
+const (
+	// StrategyAuto picks the best model available: around a detected hold,
+	// along the flight plan route, along a matching airway, and otherwise
+	// by dead reckoning
+	StrategyAuto PredictionStrategy = "auto"
+
+	// StrategyDeadReckoning only extrapolates along the aircraft's track,
+	// speed and vertical rate
+	StrategyDeadReckoning PredictionStrategy = "dead-reckoning"
+
+	// StrategyFlightPlan only predicts along the flight plan route, e.g. to
+	// check a route against the reports
+	StrategyFlightPlan PredictionStrategy = "flight-plan"
+
+	// StrategyAirway only predicts along a matching airway, e.g. when the
+	// flight plan is known to be wrong
+	StrategyAirway PredictionStrategy = "airway"
+)
+
+// PredictionStrategies lists the strategies in the order help text shows
+// them.
+var PredictionStrategies = []PredictionStrategy{StrategyAuto, StrategyDeadReckoning, StrategyFlightPlan, StrategyAirway}
+
+// ParsePredictionStrategy parses a strategy name, case-insensitively. An
+// empty name is StrategyAuto.
+func ParsePredictionStrategy(name string) (PredictionStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return StrategyAuto, nil
+	}
+	for _, s := range PredictionStrategies {
+		if name == string(s) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown prediction strategy %q (expected auto, dead-reckoning, flight-plan or airway)", name)
+}
+
+// UsesHolds reports whether aircraft flying a hold are predicted around it.
+func (s PredictionStrategy) UsesHolds() bool {
+	return s == StrategyAuto || s == ""
+}
+
+// UsesFlightPlan reports whether aircraft are predicted along their flight
+// plan route when one is filed.
+func (s PredictionStrategy) UsesFlightPlan() bool {
+	return s == StrategyAuto || s == "" || s == StrategyFlightPlan
+}
+
+// UsesAirways reports whether aircraft are predicted along a matching
+// airway. Under StrategyAuto airways are only tried without a flight plan.
+func (s PredictionStrategy) UsesAirways() bool {
+	return s == StrategyAuto || s == "" || s == StrategyAirway
+}
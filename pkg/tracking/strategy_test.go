@@ -0,0 +1,41 @@
+package tracking
+
+import "testing"
+
+// TestParsePredictionStrategy tests strategy names and the models they use.
+func TestParsePredictionStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   PredictionStrategy
+		flightPlan bool
+		airways    bool
+	}{
+		{"", StrategyAuto, true, true},
+		{"auto", StrategyAuto, true, true},
+		{" Dead-Reckoning ", StrategyDeadReckoning, false, false},
+		{"flight-plan", StrategyFlightPlan, true, false},
+		{"airway", StrategyAirway, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParsePredictionStrategy(tt.name)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if s != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, s)
+			}
+			if s.UsesFlightPlan() != tt.flightPlan || s.UsesAirways() != tt.airways {
+				t.Errorf("Expected flight plan %v and airways %v, got %v and %v",
+					tt.flightPlan, tt.airways, s.UsesFlightPlan(), s.UsesAirways())
+			}
+			if s.UsesHolds() != (s == StrategyAuto) {
+				t.Errorf("Expected only auto to use holds, got %v for %q", s.UsesHolds(), s)
+			}
+		})
+	}
+
+	if _, err := ParsePredictionStrategy("kalman"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
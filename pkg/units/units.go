@@ -0,0 +1,27 @@
+// Package units gives quantities that are easily mixed up their own types,
+// so that passing an altitude in feet where meters are expected is a
+// compile error rather than a pointing error.
+//
+// ADS-B feeds and the database report aircraft altitudes in feet; WGS84
+// heights, observer elevations and the coordinate transforms use meters.
+// Convert explicitly with Feet.Meters and Meters.Feet at the boundary.
+package units
+
+// metersPerFoot is the international foot.
+const metersPerFoot = 0.3048
+
+// Feet is an altitude or length in feet.
+type Feet float64
+
+// Meters is an altitude or length in meters.
+type Meters float64
+
+// Meters converts to meters.
+func (f Feet) Meters() Meters {
+	return Meters(float64(f) * metersPerFoot)
+}
+
+// Feet converts to feet.
+func (m Meters) Feet() Feet {
+	return Feet(float64(m) / metersPerFoot)
+}
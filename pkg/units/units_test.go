@@ -0,0 +1,19 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+// TestConversions tests converting between feet and meters.
+func TestConversions(t *testing.T) {
+	if m := Feet(35000).Meters(); m != 10668 {
+		t.Errorf("35000 ft = %.3f m, want 10668 m", m)
+	}
+	if ft := Meters(0.3048).Feet(); ft != 1 {
+		t.Errorf("0.3048 m = %.6f ft, want 1 ft", ft)
+	}
+	if ft := Feet(12345.6).Meters().Feet(); math.Abs(float64(ft)-12345.6) > 1e-9 {
+		t.Errorf("Round trip gave %.9f ft, want 12345.6 ft", ft)
+	}
+}
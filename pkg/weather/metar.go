@@ -0,0 +1,159 @@
+// Package weather provides ambient weather observations for the observing site.
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultMETARBaseURL is the aviationweather.gov data API.
+const DefaultMETARBaseURL = "https://aviationweather.gov/api/data"
+
+// METAR is a decoded surface weather observation from an airport.
+type METAR struct {
+	// Station is the ICAO identifier of the reporting station
+	Station string
+
+	// ObservedAt is the observation time
+	ObservedAt time.Time
+
+	// TemperatureC is the air temperature in °C
+	TemperatureC float64
+
+	// DewPointC is the dew point in °C (valid only if HasDewPoint)
+	DewPointC float64
+
+	// HasDewPoint is true if the report included a dew point
+	HasDewPoint bool
+
+	// WindDirection is the direction the wind blows from in degrees true
+	// (-1 if variable or calm)
+	WindDirection float64
+
+	// WindSpeedKts is the sustained wind speed in knots
+	WindSpeedKts float64
+
+	// WindGustKts is the gust speed in knots (0 if no gusts reported)
+	WindGustKts float64
+
+	// Raw is the undecoded report text
+	Raw string
+}
+
+// METARClient fetches METAR reports from the aviationweather.gov API.
+type METARClient struct {
+	// baseURL is the API base URL (default: DefaultMETARBaseURL)
+	baseURL string
+
+	// httpClient is the HTTP client used for API requests
+	httpClient *http.Client
+}
+
+// NewMETARClient creates a new METAR client.
+// baseURL should be DefaultMETARBaseURL (or custom for testing).
+func NewMETARClient(baseURL string) *METARClient {
+	if baseURL == "" {
+		baseURL = DefaultMETARBaseURL
+	}
+	return &METARClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// metarResponse is one entry of the aviationweather.gov JSON response.
+type metarResponse struct {
+	ICAOID  string          `json:"icaoId"`
+	ObsTime int64           `json:"obsTime"`
+	Temp    *float64        `json:"temp"`
+	Dewp    *float64        `json:"dewp"`
+	Wdir    json.RawMessage `json:"wdir"` // degrees, or "VRB"
+	Wspd    *float64        `json:"wspd"`
+	Wgst    *float64        `json:"wgst"`
+	RawOb   string          `json:"rawOb"`
+}
+
+// Latest returns the most recent METAR for an ICAO station (e.g., "KJLN").
+func (c *METARClient) Latest(ctx context.Context, station string) (*METAR, error) {
+	station = strings.ToUpper(strings.TrimSpace(station))
+	if station == "" {
+		return nil, fmt.Errorf("METAR station not configured")
+	}
+
+	params := url.Values{}
+	params.Add("ids", station)
+	params.Add("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/metar?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch METAR: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("METAR API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var reports []metarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reports); err != nil {
+		return nil, fmt.Errorf("failed to parse METAR response: %w", err)
+	}
+
+	// Reports are newest first
+	for _, r := range reports {
+		if r.Temp == nil {
+			continue
+		}
+		return r.decode(), nil
+	}
+
+	return nil, fmt.Errorf("no METAR with temperature for %s", station)
+}
+
+// decode converts the API representation to a METAR.
+func (r metarResponse) decode() *METAR {
+	m := &METAR{
+		Station:       r.ICAOID,
+		ObservedAt:    time.Unix(r.ObsTime, 0).UTC(),
+		TemperatureC:  *r.Temp,
+		WindDirection: -1,
+		Raw:           r.RawOb,
+	}
+
+	if r.Dewp != nil {
+		m.DewPointC = *r.Dewp
+		m.HasDewPoint = true
+	}
+
+	// Direction is a number, or the string "VRB" for variable winds
+	var dir float64
+	if err := json.Unmarshal(r.Wdir, &dir); err == nil {
+		m.WindDirection = dir
+	}
+
+	if r.Wspd != nil {
+		m.WindSpeedKts = *r.Wspd
+		if m.WindSpeedKts == 0 {
+			m.WindDirection = -1 // Calm
+		}
+	}
+	if r.Wgst != nil {
+		m.WindGustKts = *r.Wgst
+	}
+
+	return m
+}
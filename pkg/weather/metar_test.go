@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMETARClientLatest tests decoding of aviationweather.gov METAR responses.
+func TestMETARClientLatest(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantErr     bool
+		temp        float64
+		hasDewPoint bool
+		windDir     float64
+		gust        float64
+	}{
+		{
+			name:        "Full report",
+			body:        `[{"icaoId":"KJLN","obsTime":1700000000,"temp":12.2,"dewp":8.3,"wdir":210,"wspd":8,"wgst":17,"rawOb":"KJLN 142253Z 21008G17KT"}]`,
+			temp:        12.2,
+			hasDewPoint: true,
+			windDir:     210,
+			gust:        17,
+		},
+		{
+			name:    "Variable wind without dew point",
+			body:    `[{"icaoId":"KJLN","obsTime":1700000000,"temp":-3,"dewp":null,"wdir":"VRB","wspd":3}]`,
+			temp:    -3,
+			windDir: -1,
+		},
+		{
+			name:    "Skips reports without temperature",
+			body:    `[{"icaoId":"KJLN","obsTime":1700003600,"temp":null},{"icaoId":"KJLN","obsTime":1700000000,"temp":5,"wdir":0,"wspd":0}]`,
+			temp:    5,
+			windDir: -1,
+		},
+		{
+			name:    "No reports",
+			body:    `[]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/metar" || r.URL.Query().Get("ids") != "KJLN" {
+					t.Errorf("Unexpected request %s", r.URL)
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			m, err := NewMETARClient(server.URL).Latest(context.Background(), "kjln")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if m.TemperatureC != tt.temp {
+				t.Errorf("Expected temperature %.1f, got %.1f", tt.temp, m.TemperatureC)
+			}
+			if m.HasDewPoint != tt.hasDewPoint {
+				t.Errorf("Expected HasDewPoint %v, got %v", tt.hasDewPoint, m.HasDewPoint)
+			}
+			if m.WindDirection != tt.windDir {
+				t.Errorf("Expected wind direction %.0f, got %.0f", tt.windDir, m.WindDirection)
+			}
+			if m.WindGustKts != tt.gust {
+				t.Errorf("Expected gust %.0f, got %.0f", tt.gust, m.WindGustKts)
+			}
+		})
+	}
+}
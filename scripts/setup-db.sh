@@ -62,14 +62,7 @@ for i in {1..30}; do
     sleep 1
 done
 
-# Apply main schema if it exists
-if [ -f "internal/db/schema.sql" ]; then
-    echo -e "${BLUE}📝 Applying main schema...${NC}"
-    docker exec -i ${DB_CONTAINER} psql -U ${DB_USER} -d ${DB_NAME} < internal/db/schema.sql > /dev/null 2>&1 || true
-    echo -e "${GREEN}✓ Main schema applied${NC}"
-fi
-
-# Apply migrations
+# Apply migrations (the Go commands also apply these automatically on startup)
 echo -e "${BLUE}📝 Applying migrations...${NC}"
 MIGRATION_DIR="internal/db/migrations"
 
@@ -64,6 +64,26 @@ export const auth = {
         throw new Error('Login failed');
     },
     
+    async redeemPairingCode(code, name) {
+        const response = await apiRequest('/auth/pair', {
+            method: 'POST',
+            body: JSON.stringify({ code, name }),
+        });
+        
+        if (response.success && response.token) {
+            authToken = response.token;
+            currentUser = response.user;
+            
+            // Paired devices stay signed in across browser restarts
+            localStorage.setItem('authToken', authToken);
+            localStorage.setItem('currentUser', JSON.stringify(currentUser));
+            
+            return response;
+        }
+        
+        throw new Error('Pairing failed');
+    },
+    
     async logout() {
         try {
             await apiRequest('/auth/logout', { method: 'POST' });
@@ -73,17 +93,20 @@ export const auth = {
             authToken = null;
             sessionStorage.removeItem('authToken');
             sessionStorage.removeItem('currentUser');
+            localStorage.removeItem('authToken');
+            localStorage.removeItem('currentUser');
         }
         return { success: true };
     },
     
     getCurrentUser() {
-        // Try to restore from sessionStorage
-        if (!currentUser) {
-            const stored = sessionStorage.getItem('currentUser');
+        // Try to restore from sessionStorage, then a paired device token
+        for (const storage of [sessionStorage, localStorage]) {
+            if (currentUser) break;
+            const stored = storage.getItem('currentUser');
             if (stored) {
                 currentUser = JSON.parse(stored);
-                authToken = sessionStorage.getItem('authToken');
+                authToken = storage.getItem('authToken');
             }
         }
         return currentUser;
@@ -92,6 +115,15 @@ export const auth = {
     isAuthenticated() {
         return !!this.getCurrentUser();
     },
+    
+    getToken() {
+        this.getCurrentUser();
+        return authToken;
+    },
+    
+    async getConfig() {
+        return await apiRequest('/auth/config');
+    },
 };
 
 /**
@@ -103,9 +135,35 @@ export const aircraft = {
         return response.aircraft || [];
     },
     
+    async getTrackable() {
+        const response = await apiRequest('/aircraft?trackable=true');
+        return response.aircraft || [];
+    },
+    
+    async getEmergencies() {
+        const response = await apiRequest('/aircraft?emergency=true');
+        return response.aircraft || [];
+    },
+    
     async getById(icao) {
         return await apiRequest(`/aircraft/${icao}`);
     },
+    
+    async getRoute(icao) {
+        return await apiRequest(`/aircraft/${icao}/route`);
+    },
+    
+    async getApproach(icao) {
+        return await apiRequest(`/aircraft/${icao}/approach`);
+    },
+    
+    async getProfile(icao) {
+        return await apiRequest(`/aircraft/${icao}/profile`);
+    },
+    
+    async getNextTarget(icao) {
+        return await apiRequest(`/aircraft/${icao}/next`);
+    },
 };
 
 /**
@@ -224,6 +282,78 @@ export const observer = {
     },
 };
 
+/**
+ * Paired devices API
+ */
+export const devices = {
+    async getAll() {
+        return await apiRequest('/devices');
+    },
+    
+    async createPairingCode(name, role) {
+        return await apiRequest('/devices/pair', {
+            method: 'POST',
+            body: JSON.stringify({ name, role }),
+        });
+    },
+    
+    async revoke(id) {
+        return await apiRequest(`/devices/${id}`, {
+            method: 'DELETE',
+        });
+    },
+};
+
+/**
+ * Watch list API (aircraft starred by the current user)
+ */
+export const watchList = {
+    async getAll() {
+        return await apiRequest('/watchlist');
+    },
+    
+    async add(kind, target, note = '') {
+        return await apiRequest('/watchlist', {
+            method: 'POST',
+            body: JSON.stringify({ kind, target, note }),
+        });
+    },
+    
+    async remove(id) {
+        return await apiRequest(`/watchlist/${id}`, {
+            method: 'DELETE',
+        });
+    },
+};
+
+/**
+ * Watch list entries matching an aircraft: by ICAO address, or by
+ * registration as reported by the feed, falling back to the callsign when
+ * it isn't known (matched like the server does, upper case without dashes
+ * or spaces)
+ */
+export function watchMatches(entries, ac) {
+    const icao = (ac.icao || '').toUpperCase();
+    const registration = (ac.registration || ac.callsign || '').toUpperCase().replace(/[-\s]/g, '');
+    return (entries || []).filter(w =>
+        (w.kind === 'icao' && w.target === icao) ||
+        (w.kind === 'registration' && w.target === registration)
+    );
+}
+
+/**
+ * Offline reference data (waypoints, airports, airlines) API
+ */
+export const offline = {
+    async getBundle() {
+        return await apiRequest('/offline/bundle');
+    },
+    
+    async getBundleDiff(since) {
+        return await apiRequest(`/offline/bundle/diff?since=${encodeURIComponent(since)}`);
+    },
+};
+
 /**
  * System status API
  */
@@ -231,6 +361,85 @@ export const system = {
     async getStatus() {
         return await apiRequest('/system/status');
     },
+
+    async getCollector(minutes = 60) {
+        return await apiRequest(`/system/collector?minutes=${minutes}`);
+    },
+
+    async getWeather() {
+        return await apiRequest('/weather');
+    },
+
+    async getReceiver() {
+        return await apiRequest('/system/receiver');
+    },
+
+    async getTwilight() {
+        return await apiRequest('/twilight');
+    },
+};
+
+/**
+ * Pass accuracy reports API
+ */
+export const passes = {
+    async getRecent(hours = 24) {
+        return await apiRequest(`/passes?hours=${hours}`);
+    },
+};
+
+/**
+ * Live feed (WebSocket) of aircraft and telescope snapshots
+ */
+export const live = {
+    /**
+     * Connect to the live feed and reconnect with backoff when it drops.
+     * onSnapshot receives each snapshot; onStatus receives 'open' or 'closed'.
+     * Returns a function that closes the feed for good.
+     */
+    connect(onSnapshot, onStatus = () => {}) {
+        let socket = null;
+        let retryDelay = 1000;
+        let retryTimer = null;
+        let stopped = false;
+        
+        const open = () => {
+            // Browsers can't send headers on WebSockets, so the token goes in the URL
+            auth.getCurrentUser();
+            const protocol = location.protocol === 'https:' ? 'wss:' : 'ws:';
+            const query = authToken ? `?token=${encodeURIComponent(authToken)}` : '';
+            socket = new WebSocket(`${protocol}//${location.host}${API_BASE}/ws${query}`);
+            
+            socket.onopen = () => {
+                retryDelay = 1000;
+                onStatus('open');
+            };
+            
+            socket.onmessage = (event) => {
+                try {
+                    const message = JSON.parse(event.data);
+                    if (message.type === 'snapshot') onSnapshot(message);
+                } catch (error) {
+                    console.error('Bad live feed message:', error);
+                }
+            };
+            
+            socket.onclose = () => {
+                onStatus('closed');
+                if (stopped) return;
+                retryTimer = setTimeout(open, retryDelay);
+                retryDelay = Math.min(retryDelay * 2, 30000);
+            };
+        };
+        
+        open();
+        
+        return () => {
+            stopped = true;
+            clearTimeout(retryTimer);
+            socket?.close();
+        };
+    },
 };
 
 /**
@@ -1,5 +1,10 @@
 // Main application entry point
-import { auth, aircraft, telescope, system, showToast } from './api.js';
+import { auth, aircraft, telescope, system, live, devices, offline, passes, watchList, watchMatches, showToast } from './api.js';
+import { SkyChart } from './skychart.js';
+import {
+    LANGUAGES, UNIT_SYSTEMS, initLocale, getLanguage, getUnits, setLanguage, setUnits, t, translatePage,
+    formatNumber, formatAltitude, formatDistance, formatSpeed, formatWindSpeed,
+} from './i18n.js';
 
 /**
  * Application state
@@ -10,21 +15,55 @@ const state = {
     observerMarker: null,
     selectedAircraft: null,
     altitudeChart: null,
+    collectorChart: null,
     updateInterval: null,
+    collectorInterval: null,
     activeObserver: null,
     aircraftData: [], // Cache of current aircraft data
     telescopeConfig: null, // Telescope configuration and capabilities
+    alertedEmergencies: new Set(), // ICAO:squawk pairs already announced
+    windAlerted: false, // High wind warning already announced
+    connectionEventTime: null, // Telescope connection change already announced
+    adsbSource: null, // ADS-B source the collector was last seen using
+    routeLayer: null, // Selected aircraft's flight plan route on the map
+    skyChart: null, // Alt-az sky chart (shown instead of the map)
+    stopLiveFeed: null, // Closes the sky chart's WebSocket feed
+    referenceData: null, // Offline bundle of waypoints, airports and airlines
+    expandedPass: null, // ID of the pass report shown in detail
+    nightMode: null, // Red night mode, switched by the twilight phase
+    watchList: [], // Current user's watched aircraft, highlighted in every view
+    handoff: null, // Tracked target's latest next-target prediction and when it was fetched
 };
 
+/**
+ * localStorage key of the offline reference data bundle
+ */
+const OFFLINE_BUNDLE_KEY = 'offlineBundle';
+
 /**
  * Initialize the application
  */
 async function init() {
     console.log('Initializing ADS-B Scope PWA...');
     
+    // Language and units default to the server's display settings
+    const config = await loadAuthConfig();
+    initLocale(config);
+    translatePage();
+    setupLocaleSelectors();
+    
+    // Opened from a pairing QR code: pair first, then continue as usual
+    const pairCode = new URLSearchParams(window.location.search).get('pair');
+    if (pairCode) {
+        window.history.replaceState(null, '', window.location.pathname);
+        await pairDevice(pairCode);
+    }
+    
     // Check if user is already logged in
     if (auth.isAuthenticated()) {
         showAppScreen();
+    } else if (config.publicView) {
+        showAppScreen();
     } else {
         showLoginScreen();
     }
@@ -33,6 +72,45 @@ async function init() {
     setupEventListeners();
 }
 
+/**
+ * Load whether the server allows viewing without login, and its display
+ * language and units
+ */
+async function loadAuthConfig() {
+    try {
+        return await auth.getConfig();
+    } catch (error) {
+        console.error('Failed to load auth config:', error);
+        return {};
+    }
+}
+
+/**
+ * Fill the language and units pickers; a change is saved and the page
+ * reloaded in the new language
+ */
+function setupLocaleSelectors() {
+    const languageEl = document.getElementById('language-select');
+    const unitsEl = document.getElementById('units-select');
+    if (!languageEl || !unitsEl) return;
+    
+    languageEl.innerHTML = Object.entries(LANGUAGES)
+        .map(([code, name]) => `<option value="${code}">${name}</option>`).join('');
+    languageEl.value = getLanguage();
+    languageEl.addEventListener('change', () => {
+        setLanguage(languageEl.value);
+        window.location.reload();
+    });
+    
+    unitsEl.innerHTML = UNIT_SYSTEMS
+        .map(system => `<option value="${system}">${t(`units.${system}`)}</option>`).join('');
+    unitsEl.value = getUnits();
+    unitsEl.addEventListener('change', () => {
+        setUnits(unitsEl.value);
+        window.location.reload();
+    });
+}
+
 /**
  * Setup all event listeners
  */
@@ -40,6 +118,16 @@ function setupEventListeners() {
     // Login form
     document.getElementById('login-form')?.addEventListener('submit', handleLogin);
     
+    // Device pairing
+    document.getElementById('pair-form')?.addEventListener('submit', handlePairForm);
+    document.getElementById('btn-pair-device')?.addEventListener('click', handleShowPairingCode);
+    document.getElementById('btn-pair-close')?.addEventListener('click', () => {
+        document.getElementById('pair-overlay').classList.add('hidden');
+    });
+    
+    // Login button (shown to public viewers)
+    document.getElementById('btn-login')?.addEventListener('click', showLoginScreen);
+    
     // Logout button
     document.getElementById('btn-logout')?.addEventListener('click', handleLogout);
     
@@ -58,6 +146,7 @@ function setupEventListeners() {
     
     // Map controls
     document.getElementById('btn-center-telescope')?.addEventListener('click', centerOnTelescope);
+    document.getElementById('btn-toggle-sky')?.addEventListener('click', toggleSkyChart);
     
     // Aircraft search
     document.getElementById('aircraft-search')?.addEventListener('input', filterAircraft);
@@ -77,7 +166,7 @@ async function handleLogin(e) {
     try {
         const result = await auth.login(username, password);
         console.log('Login successful:', result.user);
-        showToast(`Welcome, ${result.user.username}!`, 'success');
+        showToast(t('toast.welcome', { username: result.user.username }), 'success');
         showAppScreen();
     } catch (error) {
         console.error('Login failed:', error);
@@ -86,17 +175,78 @@ async function handleLogin(e) {
     }
 }
 
+/**
+ * Pair this device with a one-time code. Returns whether it succeeded.
+ */
+async function pairDevice(code) {
+    const errorEl = document.getElementById('pair-error');
+    
+    try {
+        const result = await auth.redeemPairingCode(code, deviceName());
+        showToast(t('toast.paired', { username: result.user.username }), 'success');
+        errorEl.classList.add('hidden');
+        return true;
+    } catch (error) {
+        console.error('Pairing failed:', error);
+        errorEl.textContent = error.message;
+        errorEl.classList.remove('hidden');
+        return false;
+    }
+}
+
+/**
+ * Handle pairing code form submission
+ */
+async function handlePairForm(e) {
+    e.preventDefault();
+    
+    const code = document.getElementById('pair-code-input').value;
+    if (await pairDevice(code)) {
+        showAppScreen();
+    }
+}
+
+/**
+ * Describe this device for the paired devices list
+ */
+function deviceName() {
+    const ua = navigator.userAgent;
+    if (/iPhone/.test(ua)) return 'iPhone';
+    if (/iPad/.test(ua)) return 'iPad';
+    if (/Android/.test(ua)) return /Mobile/.test(ua) ? 'Android phone' : 'Android tablet';
+    return '';
+}
+
+/**
+ * Show a pairing code and QR code for linking another device
+ */
+async function handleShowPairingCode() {
+    try {
+        const pairing = await devices.createPairingCode('', '');
+        const expires = new Date(pairing.expiresAt);
+        
+        document.getElementById('pair-qr').innerHTML = pairing.qrSvg;
+        document.getElementById('pair-code').textContent = pairing.code;
+        document.getElementById('pair-expiry').textContent =
+            t('pair.expiry', { role: pairing.role, time: expires.toLocaleTimeString(getLanguage()) });
+        document.getElementById('pair-overlay').classList.remove('hidden');
+    } catch (error) {
+        console.error('Failed to create pairing code:', error);
+        showToast(t('toast.pairingFailed', { error: error.message }), 'error');
+    }
+}
+
 /**
  * Handle logout
  */
 async function handleLogout() {
     try {
         await auth.logout();
-        showToast('Logged out successfully', 'info');
+        showToast(t('toast.loggedOut'), 'info');
         showLoginScreen();
     } catch (error) {
         console.error('Logout failed:', error);
-        showToast('Logout failed', 'error');
+        showToast(t('toast.logoutFailed'), 'error');
     }
 }
 
@@ -114,6 +264,16 @@ function showLoginScreen() {
         clearInterval(state.updateInterval);
         state.updateInterval = null;
     }
+    if (state.collectorInterval) {
+        clearInterval(state.collectorInterval);
+        state.collectorInterval = null;
+    }
+    if (state.stopLiveFeed) {
+        state.stopLiveFeed();
+        state.stopLiveFeed = null;
+    }
+    
+    state.watchList = [];
 }
 
 /**
@@ -124,23 +284,96 @@ async function showAppScreen() {
     
     document.getElementById('login-screen').classList.add('hidden');
     document.getElementById('app-screen').classList.remove('hidden');
-    document.getElementById('btn-login').classList.add('hidden');
-    document.getElementById('user-menu').classList.remove('hidden');
-    document.getElementById('username').textContent = user.username;
-    document.getElementById('control-role').textContent = user.role;
     
-    // Load active observation point first
-    await loadActiveObserver();
+    // Public viewers get a read-only view without telescope controls
+    document.getElementById('btn-login').classList.toggle('hidden', !!user);
+    document.getElementById('user-menu').classList.toggle('hidden', !user);
+    document.querySelector('.telescope-controls-section')?.classList.toggle('hidden', !user);
+    
+    if (user) {
+        document.getElementById('username').textContent = user.username;
+        document.getElementById('control-role').textContent = user.role;
+        
+        // Load active observation point first
+        await loadActiveObserver();
+        await loadWatchList();
+    }
     
     // Load telescope configuration
     await loadTelescopeConfig();
     
-    // Initialize components
-    initMap();
-    initChart();
+    // Refresh offline reference data in the background
+    syncOfflineBundle();
+    
+    // Initialize components (once; they survive logging in from public view)
+    if (!state.map) initMap();
+    if (!state.altitudeChart) initChart();
+    if (!state.collectorChart) initCollectorChart();
     startUpdates();
 }
 
+/**
+ * Load the stored offline bundle and bring it up to date. Only changes are
+ * downloaded when a bundle is already stored; if the network is down the
+ * stored bundle is used as-is.
+ */
+async function syncOfflineBundle() {
+    if (!state.referenceData) {
+        try {
+            state.referenceData = JSON.parse(localStorage.getItem(OFFLINE_BUNDLE_KEY));
+        } catch (error) {
+            console.error('Discarding unreadable offline bundle:', error);
+        }
+    }
+    
+    try {
+        let bundle;
+        if (state.referenceData?.version) {
+            const update = await offline.getBundleDiff(state.referenceData.version);
+            bundle = update.full ? update.bundle : applyBundleDiff(state.referenceData, update.diff);
+        } else {
+            bundle = await offline.getBundle();
+        }
+        
+        state.referenceData = bundle;
+        localStorage.setItem(OFFLINE_BUNDLE_KEY, JSON.stringify(bundle));
+    } catch (error) {
+        console.warn('Offline bundle not updated:', error);
+    }
+}
+
+/**
+ * Apply a bundle diff, returning the updated bundle
+ */
+function applyBundleDiff(bundle, diff) {
+    const waypointKey = (w) => `${w.type}:${w.identifier}:${w.region || ''}`;
+    const airlineKey = (a) => a.designator;
+    
+    const apply = (items, changes, key) => {
+        const byKey = new Map(items.map(item => [key(item), item]));
+        changes.removed.forEach(k => byKey.delete(k));
+        changes.upserted.forEach(item => byKey.set(key(item), item));
+        return [...byKey.values()];
+    };
+    
+    return {
+        ...bundle,
+        version: diff.to,
+        waypoints: apply(bundle.waypoints, diff.waypoints, waypointKey),
+        airports: apply(bundle.airports, diff.airports, waypointKey),
+        airlines: apply(bundle.airlines, diff.airlines, airlineKey),
+    };
+}
+
+/**
+ * Look up the airline flying a callsign ("DAL123") in the offline bundle
+ */
+function callsignAirline(callsign) {
+    const match = /^([A-Z]{3})[0-9]/.exec(callsign || '');
+    if (!match) return null;
+    return state.referenceData?.airlines.find(a => a.designator === match[1])?.name || null;
+}
+
 /**
  * Initialize Leaflet map
  */
@@ -238,15 +471,88 @@ function initChart() {
     });
 }
 
+/**
+ * Initialize collection health chart
+ */
+function initCollectorChart() {
+    const ctx = document.getElementById('collector-chart');
+    if (!ctx) return;
+    
+    const dataset = (label, color) => ({
+        label,
+        data: [],
+        borderColor: color,
+        tension: 0.3,
+        pointRadius: 0,
+        fill: false,
+    });
+    
+    state.collectorChart = new Chart(ctx, {
+        type: 'line',
+        data: {
+            labels: [],
+            datasets: [
+                dataset('Fetched', '#3b82f6'),
+                dataset('Stored', '#22c55e'),
+                dataset('Errors', '#ef4444'),
+            ],
+        },
+        options: {
+            responsive: true,
+            maintainAspectRatio: false,
+            plugins: {
+                legend: {
+                    labels: {
+                        color: '#a1a1aa',
+                        boxWidth: 12,
+                    },
+                },
+            },
+            scales: {
+                y: {
+                    beginAtZero: true,
+                    grid: {
+                        color: 'rgba(255, 255, 255, 0.1)',
+                    },
+                    ticks: {
+                        color: '#a1a1aa',
+                    },
+                },
+                x: {
+                    grid: {
+                        color: 'rgba(255, 255, 255, 0.1)',
+                    },
+                    ticks: {
+                        color: '#a1a1aa',
+                        maxTicksLimit: 6,
+                    },
+                },
+            },
+        },
+    });
+}
+
 /**
  * Start periodic updates
  */
 function startUpdates() {
     // Initial update
     updateAll();
+    updateCollectorChart();
+    updateReceiver();
+    updatePasses();
+    updateTwilight();
     
     // Update every 2 seconds
     state.updateInterval = setInterval(updateAll, 2000);
+    
+    // Collection history, receiver stats and pass reports change about once a cycle
+    state.collectorInterval = setInterval(() => {
+        updateCollectorChart();
+        updateReceiver();
+        updatePasses();
+        updateTwilight();
+    }, 30000);
 }
 
 /**
@@ -258,6 +564,7 @@ async function updateAll() {
             updateAircraft(),
             updateTelescope(),
             updateSystemStatus(),
+            updateWeather(),
         ]);
     } catch (error) {
         console.error('Update failed:', error);
@@ -271,7 +578,7 @@ async function loadActiveObserver() {
     try {
         const observer = await fetch('/api/v1/observer/active', {
             headers: {
-                'Authorization': `Bearer ${sessionStorage.getItem('authToken')}`
+                'Authorization': `Bearer ${auth.getToken()}`
             }
         });
         
@@ -284,15 +591,33 @@ async function loadActiveObserver() {
     }
 }
 
+/**
+ * Load the current user's watch list
+ */
+async function loadWatchList() {
+    try {
+        state.watchList = await watchList.getAll();
+        state.skyChart?.setWatchList(state.watchList);
+    } catch (error) {
+        console.error('Failed to load watch list:', error);
+    }
+}
+
+/**
+ * Whether an aircraft is on the current user's watch list
+ */
+function isWatched(ac) {
+    return watchMatches(state.watchList, ac).length > 0;
+}
+
 /**
  * Load telescope configuration
  */
 async function loadTelescopeConfig() {
     try {
+        const token = auth.getToken();
         const response = await fetch('/api/v1/telescope/config', {
-            headers: {
-                'Authorization': `Bearer ${sessionStorage.getItem('authToken')}`
-            }
+            headers: token ? { 'Authorization': `Bearer ${token}` } : {},
         });
         
         if (response.ok) {
@@ -348,14 +673,14 @@ async function updateAircraft() {
         if (!state.aircraftMarkers[ac.icao]) {
             // Create new marker
             const icon = L.divIcon({
-                className: 'aircraft-marker',
+                className: isWatched(ac) ? 'aircraft-marker watched' : 'aircraft-marker',
                 html: `<div style="font-size: 20px; transform: rotate(${ac.heading}deg);">✈️</div>`,
                 iconSize: [24, 24],
                 iconAnchor: [12, 12],
             });
             
             const marker = L.marker([ac.lat, ac.lon], { icon })
-                .bindPopup(`${ac.callsign} - ${ac.altitude}ft`)
+                .bindPopup(`${ac.callsign} - ${formatAltitude(ac.altitude)}`)
                 .addTo(state.map);
             
             marker.on('click', () => selectAircraft(ac.icao));
@@ -368,7 +693,7 @@ async function updateAircraft() {
             
             // Update the icon with new rotation
             const icon = L.divIcon({
-                className: 'aircraft-marker',
+                className: isWatched(ac) ? 'aircraft-marker watched' : 'aircraft-marker',
                 html: `<div style="font-size: 20px; transform: rotate(${ac.heading}deg);">✈️</div>`,
                 iconSize: [24, 24],
                 iconAnchor: [12, 12],
@@ -376,7 +701,7 @@ async function updateAircraft() {
             marker.setIcon(icon);
             
             // Update popup
-            marker.setPopupContent(`${ac.callsign} - ${ac.altitude}ft`);
+            marker.setPopupContent(`${ac.callsign} - ${formatAltitude(ac.altitude)}`);
         }
     });
     
@@ -385,6 +710,37 @@ async function updateAircraft() {
     
     // Update aircraft list
     updateAircraftList(aircraftData);
+    
+    // Show banner for emergency squawks
+    updateEmergencyBanner(aircraftData.filter(ac => ac.emergency));
+}
+
+/**
+ * Show or hide the emergency squawk banner
+ */
+function updateEmergencyBanner(emergencies) {
+    const bannerEl = document.getElementById('emergency-banner');
+    if (!bannerEl) return;
+    
+    if (emergencies.length === 0) {
+        bannerEl.classList.add('hidden');
+        return;
+    }
+    
+    // Toast once per new emergency
+    emergencies.forEach(ac => {
+        const key = `${ac.icao}:${ac.squawk}`;
+        if (!state.alertedEmergencies.has(key)) {
+            state.alertedEmergencies.add(key);
+            showToast(`EMERGENCY: ${ac.callsign || ac.icao} squawking ${ac.squawk} (${ac.emergency})`, 'error');
+        }
+    });
+    
+    bannerEl.innerHTML = emergencies.map(ac =>
+        `🚨 ${ac.callsign || ac.icao} squawking ${ac.squawk} - ${ac.emergency}`
+    ).join(' &nbsp;|&nbsp; ');
+    bannerEl.onclick = () => selectAircraft(emergencies[0].icao);
+    bannerEl.classList.remove('hidden');
 }
 
 /**
@@ -395,72 +751,158 @@ function updateAircraftList(aircraftData) {
     if (!listEl) return;
     
     listEl.innerHTML = aircraftData.map(ac => `
-        <div class="aircraft-item ${state.selectedAircraft === ac.icao ? 'selected' : ''}" 
+        <div class="aircraft-item ${state.selectedAircraft === ac.icao ? 'selected' : ''} ${ac.emergency ? 'emergency' : ''} ${isWatched(ac) ? 'watched' : ''}" 
              data-icao="${ac.icao}"
              onclick="window.selectAircraft('${ac.icao}')">
             <div class="aircraft-header">
-                <span class="aircraft-id">${ac.callsign}</span>
-                <span class="aircraft-distance">${ac.distance.toFixed(1)} km</span>
+                <span class="aircraft-id">${isWatched(ac) ? '<span class="aircraft-star">★</span>' : ''}${ac.flag ? `<span class="aircraft-flag" title="${ac.country}">${ac.flag}</span>` : ''}${ac.callsign}${ac.emergency ? `<span class="aircraft-squawk">${ac.squawk}</span>` : ''}</span>
+                <span class="aircraft-distance">${formatDistance(ac.distance)}</span>
             </div>
             <div class="aircraft-details">
                 <div class="aircraft-detail">
-                    <span class="aircraft-detail-label">Alt</span>
-                    <span class="aircraft-detail-value">${(ac.altitude / 1000).toFixed(1)}k ft</span>
+                    <span class="aircraft-detail-label">${t('list.alt')}</span>
+                    <span class="aircraft-detail-value">${formatAltitude(ac.altitude)}</span>
                 </div>
                 <div class="aircraft-detail">
-                    <span class="aircraft-detail-label">Speed</span>
-                    <span class="aircraft-detail-value">${ac.speed} kts</span>
+                    <span class="aircraft-detail-label">${t('list.speed')}</span>
+                    <span class="aircraft-detail-value">${formatSpeed(ac.speed)}</span>
                 </div>
                 <div class="aircraft-detail">
-                    <span class="aircraft-detail-label">Elev</span>
-                    <span class="aircraft-detail-value">${ac.elevation.toFixed(1)}°</span>
+                    <span class="aircraft-detail-label">${t('list.elev')}</span>
+                    <span class="aircraft-detail-value">${formatNumber(ac.elevation, 1)}°</span>
                 </div>
             </div>
         </div>
     `).join('');
 }
 
+/**
+ * Draw the selected aircraft's flight plan route on the map and sky chart.
+ * The remaining part of the route is highlighted.
+ */
+async function showRoute(icao) {
+    let route = null;
+    try {
+        route = await aircraft.getRoute(icao);
+    } catch (error) {
+        // Most aircraft have no flight plan on file
+    }
+    if (state.selectedAircraft !== icao) return; // Selection changed meanwhile
+    
+    if (state.routeLayer) {
+        state.routeLayer.remove();
+        state.routeLayer = null;
+    }
+    const remaining = route?.features.find(f => f.id === 'remaining');
+    state.skyChart?.setRoute(remaining?.properties.skyPath);
+    if (!route || !state.map) return;
+    
+    state.routeLayer = L.geoJSON(route, {
+        style: (feature) => feature.id === 'remaining'
+            ? { color: '#facc15', weight: 3 }
+            : { color: '#a1a1aa', weight: 2, dashArray: '6 4' },
+        pointToLayer: (feature, latlng) => L.circleMarker(latlng, {
+            radius: 3,
+            color: feature.properties.passed ? '#71717a' : '#facc15',
+        }).bindTooltip(feature.properties.name),
+    }).addTo(state.map);
+}
+
+/**
+ * Show the selected aircraft's next pass as an elevation sparkline, with the
+ * telescope's minimum altitude dashed.
+ */
+async function showPassProfile(icao) {
+    let profile = null;
+    try {
+        profile = await aircraft.getProfile(icao);
+    } catch (error) {
+        console.error('Failed to load pass profile:', error);
+    }
+    const el = document.getElementById('target-profile');
+    if (state.selectedAircraft !== icao || !el) return; // Selection changed meanwhile
+    
+    if (!profile?.pass) {
+        el.innerHTML = `<span class="target-label">${t('target.noPass')}</span>`;
+        return;
+    }
+    
+    const width = 200, height = 40;
+    const n = profile.samples.length;
+    const x = (i) => n > 1 ? (i / (n - 1)) * width : width / 2;
+    const y = (elevation) => height - (Math.max(0, elevation) / 90) * height;
+    const points = profile.samples.map((s, i) => `${x(i).toFixed(1)},${y(s.elevation).toFixed(1)}`).join(' ');
+    const peak = new Date(profile.peak).toLocaleTimeString(getLanguage(), { hour: '2-digit', minute: '2-digit' });
+    
+    el.innerHTML = `
+        <svg class="profile-sparkline" viewBox="0 0 ${width} ${height}" preserveAspectRatio="none">
+            <line x1="0" x2="${width}" y1="${y(profile.minAltitude)}" y2="${y(profile.minAltitude)}" class="profile-limit"/>
+            <polyline points="${points}" class="profile-line"/>
+        </svg>
+        <span class="target-label">${t('target.peak', { elevation: formatNumber(profile.maxElevation), time: peak })}</span>
+    `;
+}
+
 /**
  * Select an aircraft
  */
 function selectAircraft(icao) {
     state.selectedAircraft = icao;
+    state.skyChart?.select(icao);
+    showRoute(icao);
     
     try {
         // Find aircraft in cached data
         const ac = state.aircraftData.find(a => a.icao === icao);
         
         if (!ac) {
-            showToast('Aircraft not found', 'error');
+            showToast(t('toast.aircraftNotFound'), 'error');
             return;
         }
         
         // Update target info
+        const airline = callsignAirline(ac.callsign);
         const targetInfo = document.getElementById('target-info');
         targetInfo.innerHTML = `
             <div class="target-data">
                 <div class="target-row">
-                    <span class="target-label">Callsign:</span>
+                    <span class="target-label">${t('target.callsign')}</span>
                     <span class="target-value">${ac.callsign}</span>
                 </div>
+                ${airline ? `
                 <div class="target-row">
-                    <span class="target-label">Altitude:</span>
-                    <span class="target-value">${ac.altitude.toLocaleString()} ft</span>
+                    <span class="target-label">${t('target.airline')}</span>
+                    <span class="target-value">${airline}</span>
+                </div>` : ''}
+                ${ac.country ? `
+                <div class="target-row">
+                    <span class="target-label">${t('target.country')}</span>
+                    <span class="target-value">${ac.flag} ${ac.country}</span>
+                </div>` : ''}
+                <div class="target-row">
+                    <span class="target-label">${t('target.altitude')}</span>
+                    <span class="target-value">${formatAltitude(ac.altitude)}</span>
                 </div>
                 <div class="target-row">
-                    <span class="target-label">Distance:</span>
-                    <span class="target-value">${ac.distance.toFixed(1)} km</span>
+                    <span class="target-label">${t('target.distance')}</span>
+                    <span class="target-value">${formatDistance(ac.distance)}</span>
                 </div>
                 <div class="target-row">
-                    <span class="target-label">Azimuth:</span>
-                    <span class="target-value">${ac.azimuth.toFixed(1)}°</span>
+                    <span class="target-label">${t('target.azimuth')}</span>
+                    <span class="target-value">${formatNumber(ac.azimuth, 1)}°</span>
                 </div>
                 <div class="target-row">
-                    <span class="target-label">Elevation:</span>
-                    <span class="target-value">${ac.elevation.toFixed(1)}°</span>
+                    <span class="target-label">${t('target.elevation')}</span>
+                    <span class="target-value">${formatNumber(ac.elevation, 1)}°</span>
                 </div>
+                <div id="target-profile" class="target-profile"></div>
+                ${auth.getCurrentUser() ? `
+                <button id="btn-watch" class="btn btn-sm" onclick="window.toggleWatch('${ac.icao}')">
+                    ${t(isWatched(ac) ? 'target.unwatch' : 'target.watch')}
+                </button>` : ''}
             </div>
         `;
+        showPassProfile(icao);
         
         // Enable tracking button
         document.getElementById('btn-start-tracking').disabled = false;
@@ -470,15 +912,46 @@ function selectAircraft(icao) {
             state.map.setView([ac.lat, ac.lon], 12);
         }
         
-        showToast(`Selected ${ac.callsign}`, 'info');
+        showToast(t('toast.selected', { callsign: ac.callsign }), 'info');
     } catch (error) {
         console.error('Failed to select aircraft:', error);
-        showToast('Failed to select aircraft', 'error');
+        showToast(t('toast.selectFailed'), 'error');
     }
 }
 
-// Make selectAircraft available globally for onclick handlers
+/**
+ * Star or unstar an aircraft. Unstarring removes every entry matching it,
+ * whether added by ICAO address or registration.
+ */
+async function toggleWatch(icao) {
+    const ac = state.aircraftData.find(a => a.icao === icao);
+    if (!ac) return;
+    
+    try {
+        const matches = watchMatches(state.watchList, ac);
+        if (matches.length > 0) {
+            await Promise.all(matches.map(w => watchList.remove(w.id)));
+            showToast(t('toast.unwatched', { callsign: ac.callsign || ac.icao }), 'info');
+        } else {
+            await watchList.add('icao', icao);
+            showToast(t('toast.watching', { callsign: ac.callsign || ac.icao }), 'success');
+        }
+        await loadWatchList();
+    } catch (error) {
+        console.error('Failed to update watch list:', error);
+        showToast(t('toast.watchFailed'), 'error');
+        return;
+    }
+    
+    const button = document.getElementById('btn-watch');
+    if (button) button.textContent = t(isWatched(ac) ? 'target.unwatch' : 'target.watch');
+    updateAircraftList(state.aircraftData);
+}
+
+// Make selectAircraft, togglePass and toggleWatch available globally for onclick handlers
 window.selectAircraft = selectAircraft;
+window.togglePass = togglePass;
+window.toggleWatch = toggleWatch;
 
 /**
  * Update telescope telemetry
@@ -488,14 +961,18 @@ async function updateTelescope() {
     
     // Update telemetry display
     document.getElementById('tel-altaz').textContent = 
-        `${status.altitude.toFixed(1)}° / ${status.azimuth.toFixed(1)}°`;
+        `${formatNumber(status.altitude, 1)}° / ${formatNumber(status.azimuth, 1)}°`;
     document.getElementById('tel-radec').textContent = 
         status.rightAscension != null && status.declination != null 
-            ? `${status.rightAscension.toFixed(1)}h / ${status.declination.toFixed(1)}°`
-            : 'N/A';
+            ? `${formatNumber(status.rightAscension, 1)}h / ${formatNumber(status.declination, 1)}°`
+            : t('telemetry.na');
     document.getElementById('tel-state').textContent = 
-        status.tracking ? 'Tracking' : status.slewing ? 'Slewing' : 'Idle';
-    document.getElementById('tel-slewing').textContent = status.slewing ? 'Yes' : 'No';
+        t(status.tracking ? 'state.tracking' : status.slewing ? 'state.slewing' : 'state.idle');
+    document.getElementById('tel-slewing').textContent = t(status.slewing ? 'state.yes' : 'state.no');
+    document.getElementById('tel-control').textContent = status.control
+        ? `${status.control.username}${status.control.target ? ` (${status.control.target.toUpperCase()})` : ''}`
+        : t('state.available');
+    updateHandoff(status.tracking ? status.control?.target : null);
     
     // Update altitude chart
     if (state.altitudeChart) {
@@ -519,23 +996,89 @@ async function updateTelescope() {
     const warnThreshold = 5; // Warn 5° before limit
     
     if (status.altitude > maxAlt) {
-        warningEl.textContent = 'Limit Exceeded!';
+        warningEl.textContent = t('warning.exceeded');
         warningEl.className = 'value warning-exceeded';
     } else if (status.altitude > (maxAlt - warnThreshold)) {
-        warningEl.textContent = 'Approaching Upper Limit';
+        warningEl.textContent = t('warning.upper');
         warningEl.className = 'value warning-approaching';
     } else if (status.altitude < minAlt) {
-        warningEl.textContent = 'Limit Exceeded!';
+        warningEl.textContent = t('warning.exceeded');
         warningEl.className = 'value warning-exceeded';
     } else if (status.altitude < (minAlt + warnThreshold)) {
-        warningEl.textContent = 'Approaching Lower Limit';
+        warningEl.textContent = t('warning.lower');
         warningEl.className = 'value warning-approaching';
     } else {
-        warningEl.textContent = 'None';
+        warningEl.textContent = t('warning.none');
         warningEl.className = 'value warning-none';
     }
 }
 
+/**
+ * Refresh the tracked target's handoff prediction at most this often;
+ * the countdown runs locally in between
+ */
+const HANDOFF_REFRESH_MS = 10000;
+
+/**
+ * Show how long until the tracked aircraft leaves the limits and the
+ * recommended next target; hidden when not tracking
+ */
+async function updateHandoff(icao) {
+    const el = document.getElementById('tracking-handoff');
+    if (!el) return;
+    if (!icao) {
+        state.handoff = null;
+        el.classList.add('hidden');
+        return;
+    }
+    
+    const now = Date.now();
+    if (state.handoff?.icao !== icao || now - state.handoff.fetchedAt > HANDOFF_REFRESH_MS) {
+        try {
+            state.handoff = { icao, fetchedAt: now, data: await aircraft.getNextTarget(icao) };
+        } catch (error) {
+            console.error('Failed to load next target:', error);
+            el.classList.add('hidden');
+            return;
+        }
+    }
+    
+    const { data, fetchedAt } = state.handoff;
+    const elapsed = (now - fetchedAt) / 1000;
+    let leaves = t('handoff.staysUp');
+    if (!data.inLimits) {
+        leaves = t('handoff.outOfLimits');
+    } else if (data.secondsUntilLeaving != null) {
+        leaves = t('handoff.leavesIn', { time: formatCountdown(data.secondsUntilLeaving - elapsed) });
+    }
+    
+    let next = t('handoff.none');
+    if (data.next) {
+        // The wait is counted from the handoff, so it doesn't run down
+        const wait = data.next.waitSeconds;
+        next = t(wait > 0 ? 'handoff.nextIn' : 'handoff.next', {
+            callsign: data.next.callsign || data.next.icao,
+            azimuth: formatNumber(data.next.azimuth, 0),
+            elevation: formatNumber(data.next.elevation, 0),
+            time: formatCountdown(wait),
+        });
+    }
+    
+    el.innerHTML = `
+        <div class="handoff-leaves">${leaves}</div>
+        <div class="handoff-next">${next}</div>
+    `;
+    el.classList.remove('hidden');
+}
+
+/**
+ * Format seconds as m:ss for countdowns
+ */
+function formatCountdown(seconds) {
+    const s = Math.max(0, Math.round(seconds));
+    return `${Math.floor(s / 60)}:${String(s % 60).padStart(2, '0')}`;
+}
+
 /**
  * Update system status indicators
  */
@@ -544,10 +1087,253 @@ async function updateSystemStatus() {
     
     document.getElementById('status-telescope').className = 
         `status-dot ${status.telescope ? 'connected' : 'error'}`;
-    document.getElementById('status-adsb').className = 
-        `status-dot ${status.adsb ? 'connected' : 'error'}`;
+    // A backup data source keeps aircraft coming, but flag it
+    let adsbClass = status.adsb ? 'connected' : 'error';
+    if (status.adsb && status.adsbFailedOver) {
+        adsbClass = 'degraded';
+    }
+    document.getElementById('status-adsb').className = `status-dot ${adsbClass}`;
+    let adsbTitle = status.adsbLagSeconds != null
+        ? `Last update ${status.adsbLagSeconds}s ago`
+        : 'No aircraft data';
+    if (status.adsbSource) {
+        adsbTitle += status.adsbFailedOver
+            ? ` (failed over to ${status.adsbSource})`
+            : ` (${status.adsbSource})`;
+    }
+    document.getElementById('status-adsb').title = adsbTitle;
+    
+    // Announce each switch between data sources once
+    if (status.adsbSource && status.adsbSource !== state.adsbSource) {
+        if (state.adsbSource !== null) {
+            showToast(status.adsbFailedOver
+                ? `ADS-B source failed over to ${status.adsbSource}`
+                : `ADS-B source ${status.adsbSource} recovered`,
+                status.adsbFailedOver ? 'error' : 'success');
+        }
+        state.adsbSource = status.adsbSource;
+    }
     document.getElementById('status-tracking').className = 
         `status-dot ${status.tracking ? 'tracking' : ''}`;
+    
+    // Announce each telescope disconnect and reconnect once
+    const event = status.telescopeConnection;
+    if (event && event.time !== state.connectionEventTime) {
+        if (state.connectionEventTime !== null || event.type === 'telescope_disconnected') {
+            if (event.type === 'telescope_disconnected') {
+                showToast(`Telescope disconnected: ${event.error}. Reconnecting...`, 'error');
+            } else if (event.error) {
+                showToast(`Telescope reconnected, but tracking was not restored: ${event.error}`, 'error');
+            } else {
+                showToast(`Telescope reconnected after ${event.downtimeSeconds.toFixed(0)}s`, 'success');
+            }
+        }
+        state.connectionEventTime = event.time;
+    }
+}
+
+/**
+ * Show the twilight phase and sky brightness, and switch red night mode on
+ * after civil twilight ends and off at dawn
+ */
+async function updateTwilight() {
+    let twilight;
+    try {
+        twilight = await system.getTwilight();
+    } catch (error) {
+        console.error('Failed to update twilight:', error);
+        return;
+    }
+    
+    // Twilight phase and the sky brightness that limits night exposures
+    const sky = twilight.sky;
+    const darkness = document.getElementById('tel-darkness');
+    darkness.textContent = `${t(`twilight.${twilight.phase}`)} · Bortle ${sky.bortle}`;
+    darkness.title = `${sky.sqm.toFixed(1)} mag/″² (${sky.source}), naked-eye limit ${sky.limitingMagnitude.toFixed(1)} mag`;
+    
+    if (twilight.nightMode === state.nightMode) {
+        return;
+    }
+    document.body.classList.toggle('night-mode', twilight.nightMode);
+    
+    // Announce changes, not the mode found at startup
+    if (state.nightMode !== null) {
+        showToast(t(twilight.nightMode ? 'toast.nightOn' : 'toast.nightOff'), 'info');
+    }
+    state.nightMode = twilight.nightMode;
+}
+
+/**
+ * Update weather station readings and warn when wind is unsafe for slewing
+ */
+async function updateWeather() {
+    const weather = await system.getWeather();
+    const c = weather.conditions;
+    
+    const fmt = (value, digits, unit) => value != null ? `${formatNumber(value, digits)}${unit}` : '--';
+    
+    document.getElementById('tel-weather-temp').textContent = c
+        ? `${fmt(c.temperatureC, 1, '°C')} / ${fmt(c.humidity, 0, '%')}`
+        : t('telemetry.na');
+    document.getElementById('tel-weather-sky').textContent = c
+        ? (c.skyQuality != null ? fmt(c.skyQuality, 1, ' mag/″²') : fmt(c.cloudCover, 0, '% cloud'))
+        : t('telemetry.na');
+    
+    const windEl = document.getElementById('tel-weather-wind');
+    if (!c || (c.windSpeedMs == null && c.windGustMs == null)) {
+        windEl.textContent = t('telemetry.na');
+        windEl.className = 'value';
+        return;
+    }
+    
+    const speed = c.windSpeedMs != null ? formatWindSpeed(c.windSpeedMs) : '--';
+    const gust = c.windGustMs != null ? ` G${formatWindSpeed(c.windGustMs)}` : '';
+    windEl.textContent = `${speed}${gust}`;
+    windEl.className = `value ${weather.windUnsafe ? 'warning-exceeded' : 'warning-none'}`;
+    
+    // Announce once each time the wind rises above the limit
+    if (weather.windUnsafe && !state.windAlerted) {
+        showToast(t('toast.highWind', { limit: formatWindSpeed(weather.maxWindSpeedMs) }), 'error');
+    }
+    state.windAlerted = weather.windUnsafe;
+}
+
+/**
+ * Update local SDR receiver health (hidden if no receiver is configured)
+ */
+async function updateReceiver() {
+    try {
+        const receiver = await system.getReceiver();
+        document.getElementById('receiver-card').classList.toggle('hidden', !receiver.configured);
+        if (!receiver.configured) return;
+        
+        const stats = receiver.stats;
+        const messagesEl = document.getElementById('rx-messages');
+        messagesEl.textContent = stats && receiver.online ? `${formatNumber(stats.messagesPerSecond)}/s` : t('telemetry.offline');
+        messagesEl.className = `value ${receiver.online ? 'warning-none' : 'warning-exceeded'}`;
+        messagesEl.title = receiver.error || '';
+        
+        document.getElementById('rx-range').textContent = stats ? formatDistance(stats.maxRangeNm * 1.852, 0) : '--';
+        const gain = stats?.gainDb != null ? `${stats.gainDb.toFixed(1)} dB` : '--';
+        const noise = stats?.noiseDbfs != null ? `${stats.noiseDbfs.toFixed(1)} dBFS` : '--';
+        document.getElementById('rx-gain').textContent = `${gain} / ${noise}`;
+    } catch (error) {
+        console.error('Failed to update receiver status:', error);
+    }
+}
+
+/**
+ * Update collection health chart from persisted collector statistics
+ */
+async function updateCollectorChart() {
+    if (!state.collectorChart) return;
+    
+    try {
+        const data = await system.getCollector(60);
+        const history = data.history || [];
+        
+        state.collectorChart.data.labels = history.map(h =>
+            new Date(h.cycleAt).toLocaleTimeString());
+        state.collectorChart.data.datasets[0].data = history.map(h => h.fetched);
+        state.collectorChart.data.datasets[1].data = history.map(h => h.stored);
+        state.collectorChart.data.datasets[2].data = history.map(h => h.errors);
+        state.collectorChart.update('none');
+    } catch (error) {
+        console.error('Failed to update collection health:', error);
+    }
+}
+
+/**
+ * Prediction mode labels for pass reports
+ */
+const PREDICTION_MODES = {
+    live: 'Live',
+    dead_reckoning: 'Dead reckoning',
+    flight_plan: 'Flight plan',
+    airway: 'Airway',
+    hold: 'Hold',
+};
+
+/**
+ * Update the list of tracked passes and their pointing accuracy. Clicking a
+ * pass shows its latency breakdown and prediction modes.
+ */
+async function updatePasses() {
+    const listEl = document.getElementById('pass-list');
+    if (!listEl) return;
+    
+    let reports;
+    try {
+        reports = await passes.getRecent(24);
+    } catch (error) {
+        console.error('Failed to update pass reports:', error);
+        return;
+    }
+    
+    if (reports.length === 0) {
+        listEl.innerHTML = `<p class="target-none">${t('passes.none')}</p>`;
+        return;
+    }
+    
+    listEl.innerHTML = reports.map(p => {
+        const ended = new Date(p.endedAt).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+        const minutes = ((new Date(p.endedAt) - new Date(p.startedAt)) / 60000).toFixed(1);
+        const rms = p.assessed > 0 ? `${p.rmsErrorDeg.toFixed(2)}°` : '--';
+        const expanded = state.expandedPass === p.id;
+        return `
+            <div class="pass-item ${expanded ? 'selected' : ''}" onclick="window.togglePass(${p.id})">
+                <div class="aircraft-header">
+                    <span class="aircraft-id">${p.callsign || p.icao}</span>
+                    <span class="aircraft-distance">${ended} · ${minutes} min · RMS ${rms}</span>
+                </div>
+                ${expanded ? passDetails(p) : ''}
+            </div>
+        `;
+    }).join('');
+}
+
+/**
+ * Render a pass report's details
+ */
+function passDetails(p) {
+    const modes = Object.entries(p.modeUsage || {})
+        .sort((a, b) => b[1] - a[1])
+        .map(([mode, pct]) => `${PREDICTION_MODES[mode] || mode || 'Unknown'} ${pct.toFixed(0)}%`)
+        .join(', ');
+    const maxAt = p.maxErrorAt ? ` at ${new Date(p.maxErrorAt).toLocaleTimeString()}` : '';
+    return `
+        <div class="telemetry-data pass-details">
+            <div class="data-row">
+                <span class="label">Max error:</span>
+                <span class="value">${p.maxErrorDeg.toFixed(2)}°${maxAt}</span>
+            </div>
+            <div class="data-row">
+                <span class="label">Assessed:</span>
+                <span class="value">${p.assessed} of ${p.commands} commands</span>
+            </div>
+            <div class="data-row">
+                <span class="label">Data age:</span>
+                <span class="value">${p.meanDataAgeSeconds.toFixed(1)} s (${p.dataAgeDeg.toFixed(2)}°)</span>
+            </div>
+            <div class="data-row">
+                <span class="label">Update lag:</span>
+                <span class="value">${p.meanIntervalSeconds.toFixed(1)} s (${p.updateLagDeg.toFixed(2)}°)</span>
+            </div>
+            <div class="data-row">
+                <span class="label">Prediction:</span>
+                <span class="value">${modes || '--'}</span>
+            </div>
+            ${p.endReason ? `<div class="data-row"><span class="label">Ended:</span><span class="value">${p.endReason}</span></div>` : ''}
+        </div>
+    `;
+}
+
+/**
+ * Show or hide a pass report's details
+ */
+function togglePass(id) {
+    state.expandedPass = state.expandedPass === id ? null : id;
+    updatePasses();
 }
 
 /**
@@ -555,7 +1341,7 @@ async function updateSystemStatus() {
  */
 async function handleStartTracking() {
     if (!state.selectedAircraft) {
-        showToast('Please select an aircraft first', 'error');
+        showToast(t('toast.selectFirst'), 'error');
         return;
     }
     
@@ -565,7 +1351,7 @@ async function handleStartTracking() {
         document.getElementById('btn-start-tracking').classList.add('hidden');
         document.getElementById('btn-stop-tracking').classList.remove('hidden');
         
-        showToast('Tracking started', 'success');
+        showToast(t('toast.trackingStarted'), 'success');
     } catch (error) {
         console.error('Failed to start tracking:', error);
         // Show the specific error message from the API
@@ -584,10 +1370,10 @@ async function handleStopTracking() {
         document.getElementById('btn-start-tracking').classList.remove('hidden');
         document.getElementById('btn-stop-tracking').classList.add('hidden');
         
-        showToast('Tracking stopped', 'info');
+        showToast(t('toast.trackingStopped'), 'info');
     } catch (error) {
         console.error('Failed to stop tracking:', error);
-        showToast('Failed to stop tracking', 'error');
+        showToast(t('toast.stopTrackingFailed'), 'error');
     }
 }
 
@@ -601,10 +1387,10 @@ async function handleAbort() {
         document.getElementById('btn-start-tracking').classList.remove('hidden');
         document.getElementById('btn-stop-tracking').classList.add('hidden');
         
-        showToast('Telescope stopped', 'info');
+        showToast(t('toast.telescopeStopped'), 'info');
     } catch (error) {
         console.error('Failed to abort:', error);
-        showToast('Failed to stop telescope', 'error');
+        showToast(t('toast.abortFailed'), 'error');
     }
 }
 
@@ -616,7 +1402,7 @@ async function handleManualSlew(direction) {
         await telescope.slewDirection(direction);
     } catch (error) {
         console.error('Slew failed:', error);
-        showToast('Slew failed', 'error');
+        showToast(t('toast.slewFailed'), 'error');
     }
 }
 
@@ -629,6 +1415,35 @@ function centerOnTelescope() {
     }
 }
 
+/**
+ * Switch the map section between the map and the sky chart.
+ * The sky chart is fed by the live WebSocket feed while it is shown.
+ */
+function toggleSkyChart() {
+    const canvas = document.getElementById('sky-chart');
+    const mapEl = document.getElementById('sky-map');
+    const showSky = canvas.classList.contains('hidden');
+    
+    canvas.classList.toggle('hidden', !showSky);
+    mapEl.classList.toggle('hidden', showSky);
+    document.getElementById('sky-section-title').textContent = t(showSky ? 'sky.chartTitle' : 'sky.title');
+    
+    if (showSky) {
+        if (!state.skyChart) {
+            state.skyChart = new SkyChart(canvas);
+            state.skyChart.setWatchList(state.watchList);
+            if (state.selectedAircraft) showRoute(state.selectedAircraft);
+        }
+        state.skyChart.select(state.selectedAircraft);
+        state.stopLiveFeed = live.connect(snapshot => state.skyChart.update(snapshot));
+    } else {
+        state.stopLiveFeed?.();
+        state.stopLiveFeed = null;
+        // Leaflet needs to re-measure after being hidden
+        state.map?.invalidateSize();
+    }
+}
+
 /**
  * Filter aircraft list
  */
@@ -0,0 +1,547 @@
+// Localization of the PWA's strings, numbers and units
+
+/**
+ * Supported languages and their names in that language
+ */
+export const LANGUAGES = {
+    en: 'English',
+    de: 'Deutsch',
+    es: 'Español',
+};
+
+/**
+ * Unit systems: aviation (ft, NM, kt) and metric (m, km, km/h)
+ */
+export const UNIT_SYSTEMS = ['aviation', 'metric'];
+
+/**
+ * localStorage keys of the user's choices, which override the server's
+ * configured defaults
+ */
+const LANGUAGE_KEY = 'language';
+const UNITS_KEY = 'units';
+
+const KM_PER_NM = 1.852;
+const FT_PER_M = 1 / 0.3048;
+const KT_PER_MS = 3600 / 1852;
+
+/**
+ * Message catalogs. English is the reference; missing translations fall
+ * back to it. Parameters are written {name}.
+ */
+const MESSAGES = {
+    en: {
+        'header.map': '🗺️ Map',
+        'header.mapTitle': 'Live map',
+        'header.login': 'Login',
+        'header.pairDevice': 'Pair Device',
+        'header.pairDeviceTitle': 'Link a phone or tablet without a password',
+        'header.logout': 'Logout',
+        'header.language': 'Language',
+        'header.units': 'Units',
+        'units.aviation': 'ft · NM · kt',
+        'units.metric': 'm · km · km/h',
+
+        'login.welcome': 'Welcome to ADS-B Scope',
+        'login.subtitle': 'Track aircraft with your telescope',
+        'login.username': 'Username',
+        'login.password': 'Password',
+        'login.signIn': 'Sign In',
+        'login.pairingCode': 'Pairing code',
+        'login.pair': 'Pair This Device',
+        'login.demo': 'Demo credentials: admin / admin',
+        'pair.title': 'Pair a Device',
+        'pair.subtitle': 'Scan the code with your phone, or enter the pairing code on its sign-in screen',
+        'pair.done': 'Done',
+        'pair.expiry': 'Grants {role} access · expires at {time}',
+
+        'sky.title': 'Sky Map',
+        'sky.chartTitle': 'Sky Chart',
+        'sky.toggleChart': 'Toggle sky chart',
+        'sky.centerTelescope': 'Center on telescope',
+        'sky.toggleGrid': 'Toggle grid',
+        'list.title': 'Nearby Aircraft',
+        'list.search': 'Search...',
+        'list.sortDistance': 'Distance',
+        'list.sortAltitude': 'Altitude',
+        'list.sortSpeed': 'Speed',
+        'list.alt': 'Alt',
+        'list.speed': 'Speed',
+        'list.elev': 'Elev',
+
+        'status.telescope': 'Telescope',
+        'status.adsb': 'ADS-B',
+        'status.tracking': 'Tracking',
+
+        'control.title': 'Telescope Control',
+        'control.observer': 'Observer',
+        'control.currentTarget': 'Current Target',
+        'control.noTarget': 'No target selected',
+        'control.startTracking': 'Start Tracking',
+        'control.stopTracking': 'Stop Tracking',
+        'control.manualSlew': 'Manual Slew',
+        'control.stop': 'STOP',
+
+        'target.callsign': 'Callsign:',
+        'target.airline': 'Airline:',
+        'target.country': 'Registered:',
+        'target.altitude': 'Altitude:',
+        'target.distance': 'Distance:',
+        'target.azimuth': 'Azimuth:',
+        'target.elevation': 'Elevation:',
+        'handoff.leavesIn': 'Leaves limits in {time}',
+        'handoff.staysUp': 'Within limits for 30+ min',
+        'handoff.outOfLimits': 'Not within the limits',
+        'handoff.next': 'Next: {callsign} at Az {azimuth}° El {elevation}°',
+        'handoff.nextIn': 'Next: {callsign}, in limits {time} after',
+        'handoff.none': 'No next target predicted',
+        'target.noPass': 'No pass above the limits in the next 30 min',
+        'target.peak': 'Peak {elevation}° at {time}',
+        'target.watch': '☆ Watch',
+        'target.unwatch': '★ Unwatch',
+
+        'telemetry.title': 'Telemetry',
+        'telemetry.position': 'Position',
+        'telemetry.status': 'Status',
+        'telemetry.state': 'State:',
+        'telemetry.slewing': 'Slewing:',
+        'telemetry.control': 'Control:',
+        'telemetry.target': 'Target',
+        'telemetry.distance': 'Distance:',
+        'telemetry.velocity': 'Velocity:',
+        'telemetry.limits': 'Limits',
+        'telemetry.altRange': 'Alt Range:',
+        'telemetry.warning': 'Warning:',
+        'telemetry.weather': 'Weather',
+        'telemetry.wind': 'Wind:',
+        'telemetry.sky': 'Sky:',
+        'telemetry.darkness': 'Darkness:',
+        'telemetry.receiver': 'Receiver',
+        'telemetry.messages': 'Messages:',
+        'telemetry.maxRange': 'Max Range:',
+        'telemetry.gainNoise': 'Gain / Noise:',
+        'telemetry.collectionHealth': 'Collection Health',
+        'telemetry.na': 'N/A',
+        'telemetry.offline': 'Offline',
+
+        'state.idle': 'Idle',
+        'state.tracking': 'Tracking',
+        'state.slewing': 'Slewing',
+        'state.yes': 'Yes',
+        'state.no': 'No',
+        'state.available': 'Available',
+
+        'warning.exceeded': 'Limit Exceeded!',
+        'warning.upper': 'Approaching Upper Limit',
+        'warning.lower': 'Approaching Lower Limit',
+        'warning.none': 'None',
+
+        'twilight.day': 'Day',
+        'twilight.civil': 'Civil twilight',
+        'twilight.nautical': 'Nautical twilight',
+        'twilight.astronomical': 'Astronomical twilight',
+        'twilight.night': 'Night',
+
+        'passes.title': 'Pass Accuracy',
+        'passes.none': 'No tracked passes in the last 24 hours',
+
+        'toast.welcome': 'Welcome, {username}!',
+        'toast.paired': "Paired with {username}'s account",
+        'toast.pairingFailed': 'Pairing failed: {error}',
+        'toast.loggedOut': 'Logged out successfully',
+        'toast.logoutFailed': 'Logout failed',
+        'toast.aircraftNotFound': 'Aircraft not found',
+        'toast.selected': 'Selected {callsign}',
+        'toast.selectFailed': 'Failed to select aircraft',
+        'toast.watching': 'Watching {callsign}',
+        'toast.unwatched': 'Stopped watching {callsign}',
+        'toast.watchFailed': 'Failed to update watch list',
+        'toast.selectFirst': 'Please select an aircraft first',
+        'toast.trackingStarted': 'Tracking started',
+        'toast.trackingStopped': 'Tracking stopped',
+        'toast.stopTrackingFailed': 'Failed to stop tracking',
+        'toast.telescopeStopped': 'Telescope stopped',
+        'toast.abortFailed': 'Failed to stop telescope',
+        'toast.slewFailed': 'Slew failed',
+        'toast.highWind': 'High wind: above safe slewing limit of {limit}',
+        'toast.nightOn': 'Night mode on: twilight has ended',
+        'toast.nightOff': 'Night mode off',
+    },
+
+    de: {
+        'header.map': '🗺️ Karte',
+        'header.mapTitle': 'Live-Karte',
+        'header.login': 'Anmelden',
+        'header.pairDevice': 'Gerät koppeln',
+        'header.pairDeviceTitle': 'Telefon oder Tablet ohne Passwort verbinden',
+        'header.logout': 'Abmelden',
+        'header.language': 'Sprache',
+        'header.units': 'Einheiten',
+
+        'login.welcome': 'Willkommen bei ADS-B Scope',
+        'login.subtitle': 'Flugzeuge mit dem Teleskop verfolgen',
+        'login.username': 'Benutzername',
+        'login.password': 'Passwort',
+        'login.signIn': 'Anmelden',
+        'login.pairingCode': 'Kopplungscode',
+        'login.pair': 'Dieses Gerät koppeln',
+        'login.demo': 'Demo-Zugang: admin / admin',
+        'pair.title': 'Gerät koppeln',
+        'pair.subtitle': 'Code mit dem Telefon scannen oder den Kopplungscode auf dessen Anmeldeseite eingeben',
+        'pair.done': 'Fertig',
+        'pair.expiry': 'Gewährt {role}-Zugriff · läuft um {time} ab',
+
+        'sky.title': 'Himmelskarte',
+        'sky.chartTitle': 'Sternkarte',
+        'sky.toggleChart': 'Sternkarte ein/aus',
+        'sky.centerTelescope': 'Auf Teleskop zentrieren',
+        'sky.toggleGrid': 'Gitter ein/aus',
+        'list.title': 'Flugzeuge in der Nähe',
+        'list.search': 'Suchen...',
+        'list.sortDistance': 'Entfernung',
+        'list.sortAltitude': 'Höhe',
+        'list.sortSpeed': 'Geschwindigkeit',
+        'list.alt': 'Höhe',
+        'list.speed': 'Geschw.',
+        'list.elev': 'Elev.',
+
+        'status.telescope': 'Teleskop',
+        'status.tracking': 'Verfolgung',
+
+        'control.title': 'Teleskopsteuerung',
+        'control.observer': 'Beobachter',
+        'control.currentTarget': 'Aktuelles Ziel',
+        'control.noTarget': 'Kein Ziel ausgewählt',
+        'control.startTracking': 'Verfolgung starten',
+        'control.stopTracking': 'Verfolgung beenden',
+        'control.manualSlew': 'Manuell schwenken',
+
+        'target.callsign': 'Rufzeichen:',
+        'target.airline': 'Airline:',
+        'target.country': 'Registriert:',
+        'target.altitude': 'Höhe:',
+        'target.distance': 'Entfernung:',
+        'target.azimuth': 'Azimut:',
+        'target.elevation': 'Elevation:',
+        'handoff.leavesIn': 'Verlässt die Grenzen in {time}',
+        'handoff.staysUp': 'Über 30 Min. innerhalb der Grenzen',
+        'handoff.outOfLimits': 'Nicht innerhalb der Grenzen',
+        'handoff.next': 'Nächstes: {callsign} bei Az {azimuth}° H {elevation}°',
+        'handoff.nextIn': 'Nächstes: {callsign}, {time} danach in den Grenzen',
+        'handoff.none': 'Kein nächstes Ziel vorhergesagt',
+        'target.noPass': 'Kein Überflug über den Grenzen in den nächsten 30 min',
+        'target.peak': 'Gipfel {elevation}° um {time}',
+        'target.watch': '☆ Beobachten',
+        'target.unwatch': '★ Nicht mehr beobachten',
+
+        'telemetry.title': 'Telemetrie',
+        'telemetry.position': 'Position',
+        'telemetry.status': 'Status',
+        'telemetry.state': 'Zustand:',
+        'telemetry.slewing': 'Schwenkt:',
+        'telemetry.control': 'Steuerung:',
+        'telemetry.target': 'Ziel',
+        'telemetry.distance': 'Entfernung:',
+        'telemetry.velocity': 'Geschwindigkeit:',
+        'telemetry.limits': 'Grenzen',
+        'telemetry.altRange': 'Höhenbereich:',
+        'telemetry.warning': 'Warnung:',
+        'telemetry.weather': 'Wetter',
+        'telemetry.wind': 'Wind:',
+        'telemetry.sky': 'Himmel:',
+        'telemetry.darkness': 'Dunkelheit:',
+        'telemetry.receiver': 'Empfänger',
+        'telemetry.messages': 'Nachrichten:',
+        'telemetry.maxRange': 'Max. Reichweite:',
+        'telemetry.gainNoise': 'Verstärkung / Rauschen:',
+        'telemetry.collectionHealth': 'Datenerfassung',
+        'telemetry.na': 'k. A.',
+        'telemetry.offline': 'Offline',
+
+        'state.idle': 'Bereit',
+        'state.tracking': 'Verfolgt',
+        'state.slewing': 'Schwenkt',
+        'state.yes': 'Ja',
+        'state.no': 'Nein',
+        'state.available': 'Verfügbar',
+
+        'warning.exceeded': 'Grenze überschritten!',
+        'warning.upper': 'Nähert sich der oberen Grenze',
+        'warning.lower': 'Nähert sich der unteren Grenze',
+        'warning.none': 'Keine',
+
+        'twilight.day': 'Tag',
+        'twilight.civil': 'Bürgerliche Dämmerung',
+        'twilight.nautical': 'Nautische Dämmerung',
+        'twilight.astronomical': 'Astronomische Dämmerung',
+        'twilight.night': 'Nacht',
+
+        'passes.title': 'Genauigkeit der Überflüge',
+        'passes.none': 'Keine verfolgten Überflüge in den letzten 24 Stunden',
+
+        'toast.welcome': 'Willkommen, {username}!',
+        'toast.paired': 'Mit dem Konto von {username} gekoppelt',
+        'toast.pairingFailed': 'Kopplung fehlgeschlagen: {error}',
+        'toast.loggedOut': 'Erfolgreich abgemeldet',
+        'toast.logoutFailed': 'Abmelden fehlgeschlagen',
+        'toast.aircraftNotFound': 'Flugzeug nicht gefunden',
+        'toast.selected': '{callsign} ausgewählt',
+        'toast.selectFailed': 'Flugzeug konnte nicht ausgewählt werden',
+        'toast.watching': '{callsign} wird beobachtet',
+        'toast.unwatched': '{callsign} wird nicht mehr beobachtet',
+        'toast.watchFailed': 'Beobachtungsliste konnte nicht aktualisiert werden',
+        'toast.selectFirst': 'Bitte zuerst ein Flugzeug auswählen',
+        'toast.trackingStarted': 'Verfolgung gestartet',
+        'toast.trackingStopped': 'Verfolgung beendet',
+        'toast.stopTrackingFailed': 'Verfolgung konnte nicht beendet werden',
+        'toast.telescopeStopped': 'Teleskop gestoppt',
+        'toast.abortFailed': 'Teleskop konnte nicht gestoppt werden',
+        'toast.slewFailed': 'Schwenken fehlgeschlagen',
+        'toast.highWind': 'Starker Wind: über dem sicheren Schwenklimit von {limit}',
+        'toast.nightOn': 'Nachtmodus an: die Dämmerung ist vorbei',
+        'toast.nightOff': 'Nachtmodus aus',
+    },
+
+    es: {
+        'header.map': '🗺️ Mapa',
+        'header.mapTitle': 'Mapa en vivo',
+        'header.login': 'Iniciar sesión',
+        'header.pairDevice': 'Vincular dispositivo',
+        'header.pairDeviceTitle': 'Vincular un teléfono o tableta sin contraseña',
+        'header.logout': 'Cerrar sesión',
+        'header.language': 'Idioma',
+        'header.units': 'Unidades',
+
+        'login.welcome': 'Bienvenido a ADS-B Scope',
+        'login.subtitle': 'Siga aeronaves con su telescopio',
+        'login.username': 'Usuario',
+        'login.password': 'Contraseña',
+        'login.signIn': 'Entrar',
+        'login.pairingCode': 'Código de vinculación',
+        'login.pair': 'Vincular este dispositivo',
+        'login.demo': 'Credenciales de demostración: admin / admin',
+        'pair.title': 'Vincular un dispositivo',
+        'pair.subtitle': 'Escanee el código con su teléfono o introduzca el código de vinculación en su pantalla de inicio de sesión',
+        'pair.done': 'Listo',
+        'pair.expiry': 'Concede acceso de {role} · caduca a las {time}',
+
+        'sky.title': 'Mapa del cielo',
+        'sky.chartTitle': 'Carta celeste',
+        'sky.toggleChart': 'Mostrar/ocultar carta celeste',
+        'sky.centerTelescope': 'Centrar en el telescopio',
+        'sky.toggleGrid': 'Mostrar/ocultar cuadrícula',
+        'list.title': 'Aeronaves cercanas',
+        'list.search': 'Buscar...',
+        'list.sortDistance': 'Distancia',
+        'list.sortAltitude': 'Altitud',
+        'list.sortSpeed': 'Velocidad',
+        'list.alt': 'Alt',
+        'list.speed': 'Vel.',
+        'list.elev': 'Elev.',
+
+        'status.telescope': 'Telescopio',
+        'status.tracking': 'Seguimiento',
+
+        'control.title': 'Control del telescopio',
+        'control.observer': 'Observador',
+        'control.currentTarget': 'Objetivo actual',
+        'control.noTarget': 'Ningún objetivo seleccionado',
+        'control.startTracking': 'Iniciar seguimiento',
+        'control.stopTracking': 'Detener seguimiento',
+        'control.manualSlew': 'Movimiento manual',
+        'control.stop': 'PARAR',
+
+        'target.callsign': 'Indicativo:',
+        'target.airline': 'Aerolínea:',
+        'target.country': 'País:',
+        'target.altitude': 'Altitud:',
+        'target.distance': 'Distancia:',
+        'target.azimuth': 'Acimut:',
+        'target.elevation': 'Elevación:',
+        'handoff.leavesIn': 'Sale de los límites en {time}',
+        'handoff.staysUp': 'Dentro de los límites más de 30 min',
+        'handoff.outOfLimits': 'Fuera de los límites',
+        'handoff.next': 'Siguiente: {callsign} en Az {azimuth}° El {elevation}°',
+        'handoff.nextIn': 'Siguiente: {callsign}, en límites {time} después',
+        'handoff.none': 'Ningún objetivo siguiente previsto',
+        'target.noPass': 'Ningún paso sobre los límites en los próximos 30 min',
+        'target.peak': 'Máx. {elevation}° a las {time}',
+        'target.watch': '☆ Vigilar',
+        'target.unwatch': '★ Dejar de vigilar',
+
+        'telemetry.title': 'Telemetría',
+        'telemetry.position': 'Posición',
+        'telemetry.status': 'Estado',
+        'telemetry.state': 'Estado:',
+        'telemetry.slewing': 'Moviendo:',
+        'telemetry.control': 'Control:',
+        'telemetry.target': 'Objetivo',
+        'telemetry.distance': 'Distancia:',
+        'telemetry.velocity': 'Velocidad:',
+        'telemetry.limits': 'Límites',
+        'telemetry.altRange': 'Rango de alt.:',
+        'telemetry.warning': 'Aviso:',
+        'telemetry.weather': 'Tiempo',
+        'telemetry.wind': 'Viento:',
+        'telemetry.sky': 'Cielo:',
+        'telemetry.darkness': 'Oscuridad:',
+        'telemetry.receiver': 'Receptor',
+        'telemetry.messages': 'Mensajes:',
+        'telemetry.maxRange': 'Alcance máx.:',
+        'telemetry.gainNoise': 'Ganancia / Ruido:',
+        'telemetry.collectionHealth': 'Estado de la recogida',
+        'telemetry.na': 'N/D',
+        'telemetry.offline': 'Desconectado',
+
+        'state.idle': 'Inactivo',
+        'state.tracking': 'Siguiendo',
+        'state.slewing': 'Moviendo',
+        'state.yes': 'Sí',
+        'state.no': 'No',
+        'state.available': 'Disponible',
+
+        'warning.exceeded': '¡Límite superado!',
+        'warning.upper': 'Cerca del límite superior',
+        'warning.lower': 'Cerca del límite inferior',
+        'warning.none': 'Ninguno',
+
+        'twilight.day': 'Día',
+        'twilight.civil': 'Crepúsculo civil',
+        'twilight.nautical': 'Crepúsculo náutico',
+        'twilight.astronomical': 'Crepúsculo astronómico',
+        'twilight.night': 'Noche',
+
+        'passes.title': 'Precisión de los pasos',
+        'passes.none': 'Ningún paso seguido en las últimas 24 horas',
+
+        'toast.welcome': '¡Bienvenido, {username}!',
+        'toast.paired': 'Vinculado con la cuenta de {username}',
+        'toast.pairingFailed': 'Error al vincular: {error}',
+        'toast.loggedOut': 'Sesión cerrada',
+        'toast.logoutFailed': 'Error al cerrar sesión',
+        'toast.aircraftNotFound': 'Aeronave no encontrada',
+        'toast.selected': '{callsign} seleccionada',
+        'toast.selectFailed': 'No se pudo seleccionar la aeronave',
+        'toast.watching': 'Vigilando {callsign}',
+        'toast.unwatched': 'Ya no se vigila {callsign}',
+        'toast.watchFailed': 'No se pudo actualizar la lista de vigilancia',
+        'toast.selectFirst': 'Seleccione primero una aeronave',
+        'toast.trackingStarted': 'Seguimiento iniciado',
+        'toast.trackingStopped': 'Seguimiento detenido',
+        'toast.stopTrackingFailed': 'No se pudo detener el seguimiento',
+        'toast.telescopeStopped': 'Telescopio detenido',
+        'toast.abortFailed': 'No se pudo detener el telescopio',
+        'toast.slewFailed': 'Error en el movimiento',
+        'toast.highWind': 'Viento fuerte: por encima del límite seguro de {limit}',
+        'toast.nightOn': 'Modo nocturno activado: ha terminado el crepúsculo',
+        'toast.nightOff': 'Modo nocturno desactivado',
+    },
+};
+
+let language = 'en';
+let units = 'aviation';
+
+/**
+ * Pick the language and units: the user's saved choice, else the server's
+ * configured default, else the browser language. Call before rendering.
+ */
+export function initLocale(defaults = {}) {
+    const browser = (navigator.language || 'en').slice(0, 2).toLowerCase();
+    language = [localStorage.getItem(LANGUAGE_KEY), defaults.language, browser]
+        .find(lang => lang && MESSAGES[lang]) || 'en';
+    units = [localStorage.getItem(UNITS_KEY), defaults.units]
+        .find(u => UNIT_SYSTEMS.includes(u)) || 'aviation';
+    document.documentElement.lang = language;
+}
+
+export function getLanguage() {
+    return language;
+}
+
+export function getUnits() {
+    return units;
+}
+
+/**
+ * Save the user's language; the page is reloaded to apply it
+ */
+export function setLanguage(lang) {
+    if (MESSAGES[lang]) localStorage.setItem(LANGUAGE_KEY, lang);
+}
+
+/**
+ * Save the user's unit system; the page is reloaded to apply it
+ */
+export function setUnits(system) {
+    if (UNIT_SYSTEMS.includes(system)) localStorage.setItem(UNITS_KEY, system);
+}
+
+/**
+ * Translate a message, filling in {name} parameters
+ */
+export function t(key, params = {}) {
+    const msg = MESSAGES[language][key] ?? MESSAGES.en[key] ?? key;
+    return msg.replace(/\{(\w+)\}/g, (match, name) => params[name] ?? match);
+}
+
+/**
+ * Translate the static page: data-i18n sets the text, data-i18n-title the
+ * tooltip and data-i18n-placeholder the placeholder
+ */
+export function translatePage(root = document) {
+    root.querySelectorAll('[data-i18n]').forEach(el => {
+        el.textContent = t(el.dataset.i18n);
+    });
+    root.querySelectorAll('[data-i18n-title]').forEach(el => {
+        el.title = t(el.dataset.i18nTitle);
+    });
+    root.querySelectorAll('[data-i18n-placeholder]').forEach(el => {
+        el.placeholder = t(el.dataset.i18nPlaceholder);
+    });
+}
+
+/**
+ * Format a number with the language's separators
+ */
+export function formatNumber(value, digits = 0) {
+    return value.toLocaleString(language, {
+        minimumFractionDigits: digits,
+        maximumFractionDigits: digits,
+    });
+}
+
+/**
+ * Format an altitude given in feet
+ */
+export function formatAltitude(ft) {
+    return units === 'metric'
+        ? `${formatNumber(ft / FT_PER_M)} m`
+        : `${formatNumber(ft)} ft`;
+}
+
+/**
+ * Format a distance given in kilometers
+ */
+export function formatDistance(km, digits = 1) {
+    return units === 'metric'
+        ? `${formatNumber(km, digits)} km`
+        : `${formatNumber(km / KM_PER_NM, digits)} NM`;
+}
+
+/**
+ * Format a ground speed given in knots
+ */
+export function formatSpeed(kts) {
+    return units === 'metric'
+        ? `${formatNumber(kts * KM_PER_NM)} km/h`
+        : `${formatNumber(kts)} kt`;
+}
+
+/**
+ * Format a wind speed given in meters per second
+ */
+export function formatWindSpeed(ms, digits = 1) {
+    return units === 'metric'
+        ? `${formatNumber(ms, digits)} m/s`
+        : `${formatNumber(ms * KT_PER_MS, digits)} kt`;
+}
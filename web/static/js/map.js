@@ -0,0 +1,234 @@
+// Live map page: aircraft, observer and telescope footprint from the WebSocket feed
+import { auth, telescope, live, watchList, watchMatches, showToast } from './api.js';
+
+/**
+ * Map state
+ */
+const state = {
+    map: null,
+    aircraftMarkers: {},
+    observerMarker: null,
+    footprint: null,   // Field of view outline at the footprint altitude
+    sightLine: null,   // Observer to footprint centre
+    trackICAO: null,   // Aircraft the telescope is tracking (from the control lease)
+    centered: false,   // Map has been centred on the observer once
+    watchList: [],     // Signed-in user's watched aircraft
+};
+
+/**
+ * Initialize the map page
+ */
+function init() {
+    state.map = L.map('live-map', {
+        center: [37.1401, -94.4912],
+        zoom: 9,
+        zoomControl: true,
+    });
+
+    // Same dark tiles as the dashboard map
+    L.tileLayer('https://{s}.basemaps.cartocdn.com/dark_all/{z}/{x}/{y}{r}.png', {
+        attribution: '&copy; OpenStreetMap contributors &copy; CARTO',
+        subdomains: 'abcd',
+        maxZoom: 19,
+    }).addTo(state.map);
+
+    if (auth.isAuthenticated()) {
+        watchList.getAll()
+            .then(entries => { state.watchList = entries; })
+            .catch(error => console.error('Failed to load watch list:', error));
+    }
+
+    live.connect(handleSnapshot, handleLiveStatus);
+}
+
+/**
+ * Show the live feed connection state
+ */
+function handleLiveStatus(status) {
+    const dot = document.getElementById('status-live');
+    const label = document.getElementById('live-label');
+    dot.classList.toggle('connected', status === 'open');
+    label.textContent = status === 'open' ? 'Live' : 'Reconnecting...';
+}
+
+/**
+ * Apply a snapshot from the live feed
+ */
+function handleSnapshot(snapshot) {
+    updateObserver(snapshot.observer);
+    updateTelescope(snapshot.telescope);
+    updateAircraft(snapshot.aircraft || []);
+}
+
+/**
+ * Place the observer marker (and centre on it the first time)
+ */
+function updateObserver(observer) {
+    const position = [observer.latitude, observer.longitude];
+
+    if (!state.observerMarker) {
+        const icon = L.divIcon({
+            className: 'observer-marker',
+            html: '<div style="font-size: 24px;">🔭</div>',
+            iconSize: [30, 30],
+            iconAnchor: [15, 15],
+        });
+        state.observerMarker = L.marker(position, { icon })
+            .addTo(state.map)
+            .bindPopup('Observer');
+    } else {
+        state.observerMarker.setLatLng(position);
+    }
+
+    if (!state.centered) {
+        state.map.setView(position, 9);
+        state.centered = true;
+    }
+}
+
+/**
+ * Draw the telescope's pointing footprint and update the panel
+ */
+function updateTelescope(scope) {
+    if (!scope) {
+        document.getElementById('map-altaz').textContent = 'Unavailable';
+        document.getElementById('map-state').textContent = '--';
+        document.getElementById('map-footprint').textContent = '--';
+        state.footprint?.remove();
+        state.sightLine?.remove();
+        state.footprint = null;
+        state.sightLine = null;
+        state.trackICAO = null;
+        return;
+    }
+
+    state.trackICAO = scope.control?.target || null;
+
+    document.getElementById('map-altaz').textContent =
+        `${scope.altitude.toFixed(1)}° / ${scope.azimuth.toFixed(1)}°`;
+    document.getElementById('map-state').textContent = scope.slewing
+        ? 'Slewing'
+        : state.trackICAO ? `Tracking ${state.trackICAO.toUpperCase()}` : 'Idle';
+    document.getElementById('map-footprint').textContent =
+        `${scope.fieldOfViewDeg.toFixed(1)}° at ${Math.round(scope.footprintAltitudeFt).toLocaleString()} ft`;
+
+    const observer = state.observerMarker.getLatLng();
+
+    if (!state.footprint) {
+        state.footprint = L.polygon(scope.footprint, {
+            color: '#22c55e',
+            weight: 2,
+            fillOpacity: 0.2,
+        }).addTo(state.map);
+        state.sightLine = L.polyline([observer, scope.center], {
+            color: '#22c55e',
+            weight: 1,
+            dashArray: '4 6',
+        }).addTo(state.map);
+    } else {
+        state.footprint.setLatLngs(scope.footprint);
+        state.sightLine.setLatLngs([observer, scope.center]);
+    }
+}
+
+/**
+ * Add, move and remove aircraft markers
+ */
+function updateAircraft(aircraftData) {
+    document.getElementById('map-count').textContent = aircraftData.length;
+
+    const current = new Set(aircraftData.map(ac => ac.icao));
+    Object.keys(state.aircraftMarkers).forEach(icao => {
+        if (!current.has(icao)) {
+            state.aircraftMarkers[icao].remove();
+            delete state.aircraftMarkers[icao];
+        }
+    });
+
+    aircraftData.forEach(ac => {
+        const icon = aircraftIcon(ac);
+        let marker = state.aircraftMarkers[ac.icao];
+
+        if (!marker) {
+            marker = L.marker([ac.lat, ac.lon], { icon }).addTo(state.map);
+            marker.bindPopup('');
+            marker.on('popupopen', (e) => wireTrackButton(e.popup, ac.icao));
+            state.aircraftMarkers[ac.icao] = marker;
+        } else {
+            marker.setLatLng([ac.lat, ac.lon]);
+            marker.setIcon(icon);
+        }
+
+        // Keep an open popup's button working when its content is replaced
+        marker.setPopupContent(popupContent(ac));
+        if (marker.isPopupOpen()) wireTrackButton(marker.getPopup(), ac.icao);
+    });
+}
+
+/**
+ * Marker icon rotated to the aircraft's heading; the tracked and watched
+ * aircraft are highlighted
+ */
+function aircraftIcon(ac) {
+    const tracked = state.trackICAO && state.trackICAO.toUpperCase() === ac.icao.toUpperCase();
+    const watched = watchMatches(state.watchList, ac).length > 0;
+    return L.divIcon({
+        className: 'aircraft-marker' + (tracked ? ' tracked' : '') + (watched ? ' watched' : ''),
+        html: `<div style="font-size: 20px; transform: rotate(${ac.heading}deg);">✈️</div>`,
+        iconSize: [24, 24],
+        iconAnchor: [12, 12],
+    });
+}
+
+/**
+ * Popup with aircraft details and a track button for signed-in users
+ */
+function popupContent(ac) {
+    const name = (ac.flag ? `<span title="${escapeHTML(ac.country)}">${ac.flag}</span> ` : '') +
+        escapeHTML(ac.callsign || ac.icao);
+    const details = `${Math.round(ac.altitude).toLocaleString()} ft · ${Math.round(ac.speed)} kts<br>` +
+        `Az ${ac.azimuth.toFixed(1)}° El ${ac.elevation.toFixed(1)}° · ${ac.distance.toFixed(1)} km`;
+    const button = auth.isAuthenticated()
+        ? '<button class="btn btn-success btn-sm map-track-btn">Track</button>'
+        : '<div class="map-hint">Log in to track</div>';
+
+    return `<div class="map-popup"><strong>${name}</strong><br>${details}<br>${button}</div>`;
+}
+
+/**
+ * Hook the popup's track button up to the track endpoint
+ */
+function wireTrackButton(popup, icao) {
+    const button = popup.getElement()?.querySelector('.map-track-btn');
+    if (!button) return;
+
+    button.onclick = async () => {
+        button.disabled = true;
+        try {
+            await telescope.startTracking(icao);
+            state.trackICAO = icao;
+            showToast(`Tracking ${icao.toUpperCase()}`, 'success');
+            popup.close();
+        } catch (error) {
+            console.error('Failed to start tracking:', error);
+            showToast(error.message || 'Failed to start tracking', 'error');
+            button.disabled = false;
+        }
+    };
+}
+
+/**
+ * Escape text for use in popup HTML
+ */
+function escapeHTML(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML;
+}
+
+// Initialize on DOMContentLoaded
+if (document.readyState === 'loading') {
+    document.addEventListener('DOMContentLoaded', init);
+} else {
+    init();
+}
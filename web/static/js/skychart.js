@@ -0,0 +1,388 @@
+// Alt-az sky dome chart (canvas), mirroring the terminal client's sky view
+
+/**
+ * Number of past positions kept per aircraft for trails
+ */
+const TRAIL_LENGTH = 30;
+
+import { watchMatches } from './api.js';
+
+/**
+ * Chart colors (match the CSS theme)
+ */
+const COLORS = {
+    background: '#0f0f1e',
+    grid: '#3f3f46',
+    horizon: '#a1a1aa',
+    label: '#a1a1aa',
+    limits: 'rgba(239, 68, 68, 0.15)',
+    aircraft: '#60a5fa',
+    selected: '#facc15',
+    tracked: '#22c55e',
+    watched: '#f472b6',
+    trail: 'rgba(96, 165, 250, 0.35)',
+    trackedTrail: 'rgba(34, 197, 94, 0.7)',
+    commanded: '#f97316',
+    route: 'rgba(250, 204, 21, 0.6)',
+    sun: '#f59e0b',
+    moon: '#e4e4e7',
+    crosshair: '#22c55e',
+};
+
+/**
+ * Sky chart: zenith in the centre, horizon at the edge, north up and east
+ * right like the terminal client. Altitude uses a stereographic projection
+ * so shapes near the horizon are not squashed.
+ */
+export class SkyChart {
+    constructor(canvas) {
+        this.canvas = canvas;
+        this.ctx = canvas.getContext('2d');
+        this.snapshot = null;
+        this.selectedICAO = null;
+        this.trails = new Map(); // ICAO -> [{altitude, azimuth}]
+        this.route = []; // Selected aircraft's remaining flight plan [{azimuth, elevation}]
+        this.watchList = []; // Current user's watched aircraft
+
+        this.resizeObserver = new ResizeObserver(() => this.draw());
+        this.resizeObserver.observe(canvas);
+    }
+
+    /**
+     * Apply a live feed snapshot and redraw
+     */
+    update(snapshot) {
+        this.snapshot = snapshot;
+
+        const seen = new Set();
+        (snapshot.aircraft || []).forEach(ac => {
+            seen.add(ac.icao);
+            const trail = this.trails.get(ac.icao) || [];
+            trail.push({ altitude: ac.elevation, azimuth: ac.azimuth });
+            if (trail.length > TRAIL_LENGTH) trail.shift();
+            this.trails.set(ac.icao, trail);
+        });
+        for (const icao of this.trails.keys()) {
+            if (!seen.has(icao)) this.trails.delete(icao);
+        }
+
+        this.draw();
+    }
+
+    /**
+     * Highlight an aircraft (the dashboard's selection)
+     */
+    select(icao) {
+        this.selectedICAO = icao;
+        this.draw();
+    }
+
+    /**
+     * Highlight the aircraft on a watch list
+     */
+    setWatchList(entries) {
+        this.watchList = entries || [];
+        this.draw();
+    }
+
+    /**
+     * Show the selected aircraft's remaining flight plan route (empty to clear)
+     */
+    setRoute(skyPath) {
+        this.route = skyPath || [];
+        this.draw();
+    }
+
+    /**
+     * Project altitude/azimuth to canvas pixels
+     */
+    project(altitude, azimuth) {
+        const zenithAngle = (90 - altitude) * Math.PI / 180;
+        const r = this.radius * Math.tan(zenithAngle / 2);
+        const az = azimuth * Math.PI / 180;
+        return {
+            x: this.cx + r * Math.sin(az),
+            y: this.cy - r * Math.cos(az),
+        };
+    }
+
+    /**
+     * Radius in pixels of an altitude circle
+     */
+    altitudeRadius(altitude) {
+        return this.radius * Math.tan((90 - altitude) * Math.PI / 360);
+    }
+
+    /**
+     * Redraw the whole chart
+     */
+    draw() {
+        const { canvas, ctx } = this;
+        const dpr = window.devicePixelRatio || 1;
+        const width = canvas.clientWidth;
+        const height = canvas.clientHeight;
+        if (!width || !height) return;
+
+        canvas.width = width * dpr;
+        canvas.height = height * dpr;
+        ctx.setTransform(dpr, 0, 0, dpr, 0, 0);
+
+        this.cx = width / 2;
+        this.cy = height / 2;
+        this.radius = Math.min(width, height) / 2 - 20;
+
+        ctx.fillStyle = COLORS.background;
+        ctx.fillRect(0, 0, width, height);
+
+        this.drawLimits();
+        this.drawGrid();
+
+        if (!this.snapshot) return;
+
+        this.drawBodies();
+        this.drawRoute();
+        this.drawTrails();
+        this.drawCommanded();
+        this.drawAircraft();
+        this.drawCrosshair();
+    }
+
+    /**
+     * Shade the sky outside the telescope's altitude limits
+     */
+    drawLimits() {
+        const sky = this.snapshot?.sky;
+        if (!sky) return;
+
+        const { ctx, cx, cy } = this;
+        ctx.fillStyle = COLORS.limits;
+
+        // Below the minimum: ring between the horizon and the limit
+        if (sky.minAltitude > 0) {
+            ctx.beginPath();
+            ctx.arc(cx, cy, this.radius, 0, Math.PI * 2);
+            ctx.arc(cx, cy, this.altitudeRadius(sky.minAltitude), 0, Math.PI * 2, true);
+            ctx.fill();
+        }
+
+        // Above the maximum: disc around the zenith
+        if (sky.maxAltitude > 0 && sky.maxAltitude < 90) {
+            ctx.beginPath();
+            ctx.arc(cx, cy, this.altitudeRadius(sky.maxAltitude), 0, Math.PI * 2);
+            ctx.fill();
+        }
+    }
+
+    /**
+     * Altitude rings, azimuth spokes and compass labels
+     */
+    drawGrid() {
+        const { ctx, cx, cy } = this;
+
+        ctx.strokeStyle = COLORS.grid;
+        ctx.lineWidth = 1;
+        ctx.setLineDash([2, 4]);
+        ctx.fillStyle = COLORS.label;
+        ctx.font = '11px sans-serif';
+        ctx.textAlign = 'center';
+        ctx.textBaseline = 'middle';
+
+        [30, 60].forEach(alt => {
+            const r = this.altitudeRadius(alt);
+            ctx.beginPath();
+            ctx.arc(cx, cy, r, 0, Math.PI * 2);
+            ctx.stroke();
+            ctx.fillText(`${alt}°`, cx, cy - r - 8);
+        });
+
+        for (let az = 0; az < 360; az += 45) {
+            const edge = this.project(0, az);
+            ctx.beginPath();
+            ctx.moveTo(cx, cy);
+            ctx.lineTo(edge.x, edge.y);
+            ctx.stroke();
+        }
+        ctx.setLineDash([]);
+
+        // Horizon
+        ctx.strokeStyle = COLORS.horizon;
+        ctx.beginPath();
+        ctx.arc(cx, cy, this.radius, 0, Math.PI * 2);
+        ctx.stroke();
+
+        // Zenith
+        ctx.fillText('+', cx, cy);
+
+        const labels = { 0: 'N', 90: 'E', 180: 'S', 270: 'W' };
+        Object.entries(labels).forEach(([az, label]) => {
+            const angle = az * Math.PI / 180;
+            ctx.fillText(label, cx + (this.radius + 10) * Math.sin(angle), cy - (this.radius + 10) * Math.cos(angle));
+        });
+    }
+
+    /**
+     * Sun and moon (when above the horizon)
+     */
+    drawBodies() {
+        const sky = this.snapshot.sky;
+        if (!sky) return;
+
+        const { ctx } = this;
+
+        if (sky.sun.altitude > 0) {
+            const p = this.project(sky.sun.altitude, sky.sun.azimuth);
+            ctx.fillStyle = COLORS.sun;
+            ctx.beginPath();
+            ctx.arc(p.x, p.y, 8, 0, Math.PI * 2);
+            ctx.fill();
+        }
+
+        if (sky.moon.altitude > 0) {
+            const p = this.project(sky.moon.altitude, sky.moon.azimuth);
+            ctx.fillStyle = COLORS.moon;
+            ctx.globalAlpha = 0.3 + 0.7 * (sky.moon.illumination ?? 1);
+            ctx.beginPath();
+            ctx.arc(p.x, p.y, 7, 0, Math.PI * 2);
+            ctx.fill();
+            ctx.globalAlpha = 1;
+        }
+    }
+
+    /**
+     * Expected path of the selected aircraft along its flight plan, dashed
+     * and broken where it dips below the horizon
+     */
+    drawRoute() {
+        const { ctx } = this;
+        ctx.strokeStyle = COLORS.route;
+        ctx.lineWidth = 1.5;
+        ctx.setLineDash([6, 4]);
+
+        ctx.beginPath();
+        let drawing = false;
+        this.route.forEach(pos => {
+            if (pos.elevation <= 0) {
+                drawing = false;
+                return;
+            }
+            const p = this.project(pos.elevation, pos.azimuth);
+            if (drawing) ctx.lineTo(p.x, p.y);
+            else ctx.moveTo(p.x, p.y);
+            drawing = true;
+        });
+        ctx.stroke();
+        ctx.setLineDash([]);
+    }
+
+    /**
+     * Recent positions of each aircraft, highlighted for the one whose
+     * commanded telescope positions are shown
+     */
+    drawTrails() {
+        const { ctx } = this;
+        const commandedICAO = this.snapshot.commanded?.icao?.toUpperCase();
+
+        this.trails.forEach((trail, icao) => {
+            const visible = trail.filter(p => p.altitude > 0);
+            if (visible.length < 2) return;
+            const highlighted = icao.toUpperCase() === commandedICAO;
+            ctx.strokeStyle = highlighted ? COLORS.trackedTrail : COLORS.trail;
+            ctx.lineWidth = highlighted ? 2 : 1;
+            ctx.beginPath();
+            visible.forEach((pos, i) => {
+                const p = this.project(pos.altitude, pos.azimuth);
+                if (i === 0) ctx.moveTo(p.x, p.y);
+                else ctx.lineTo(p.x, p.y);
+            });
+            ctx.stroke();
+        });
+    }
+
+    /**
+     * Recently commanded telescope positions for the tracked aircraft: a
+     * line through small crosses, ending at the latest command. Compared
+     * with the aircraft's trail it shows the mount lagging behind or
+     * overshooting the target.
+     */
+    drawCommanded() {
+        const positions = (this.snapshot.commanded?.positions || []).filter(p => p.altitude > 0);
+        if (positions.length === 0) return;
+
+        const { ctx } = this;
+        ctx.strokeStyle = COLORS.commanded;
+        ctx.lineWidth = 1;
+
+        ctx.beginPath();
+        positions.forEach((pos, i) => {
+            const p = this.project(pos.altitude, pos.azimuth);
+            if (i === 0) ctx.moveTo(p.x, p.y);
+            else ctx.lineTo(p.x, p.y);
+        });
+        ctx.stroke();
+
+        ctx.beginPath();
+        positions.forEach(pos => {
+            const p = this.project(pos.altitude, pos.azimuth);
+            ctx.moveTo(p.x - 3, p.y - 3);
+            ctx.lineTo(p.x + 3, p.y + 3);
+            ctx.moveTo(p.x + 3, p.y - 3);
+            ctx.lineTo(p.x - 3, p.y + 3);
+        });
+        ctx.stroke();
+    }
+
+    /**
+     * Aircraft above the horizon, with callsigns for selected, tracked and
+     * watched ones
+     */
+    drawAircraft() {
+        const { ctx } = this;
+        const trackICAO = this.snapshot.telescope?.control?.target?.toUpperCase();
+
+        ctx.font = '11px sans-serif';
+        ctx.textAlign = 'left';
+
+        (this.snapshot.aircraft || []).forEach(ac => {
+            if (ac.elevation <= 0) return;
+
+            const p = this.project(ac.elevation, ac.azimuth);
+            const tracked = trackICAO && ac.icao.toUpperCase() === trackICAO;
+            const selected = ac.icao === this.selectedICAO;
+            const watched = watchMatches(this.watchList, ac).length > 0;
+
+            ctx.fillStyle = tracked ? COLORS.tracked : selected ? COLORS.selected : watched ? COLORS.watched : COLORS.aircraft;
+            ctx.beginPath();
+            ctx.arc(p.x, p.y, tracked || selected || watched ? 5 : 3, 0, Math.PI * 2);
+            ctx.fill();
+
+            if (tracked || selected || watched) {
+                ctx.fillText(ac.callsign || ac.icao, p.x + 8, p.y);
+            }
+        });
+    }
+
+    /**
+     * Telescope pointing crosshair
+     */
+    drawCrosshair() {
+        const scope = this.snapshot.telescope;
+        if (!scope) return;
+
+        const { ctx } = this;
+        const p = this.project(scope.altitude, scope.azimuth);
+
+        ctx.strokeStyle = COLORS.crosshair;
+        ctx.lineWidth = 2;
+        ctx.beginPath();
+        ctx.arc(p.x, p.y, 10, 0, Math.PI * 2);
+        ctx.moveTo(p.x - 16, p.y);
+        ctx.lineTo(p.x - 4, p.y);
+        ctx.moveTo(p.x + 4, p.y);
+        ctx.lineTo(p.x + 16, p.y);
+        ctx.moveTo(p.x, p.y - 16);
+        ctx.lineTo(p.x, p.y - 4);
+        ctx.moveTo(p.x, p.y + 4);
+        ctx.lineTo(p.x, p.y + 16);
+        ctx.stroke();
+    }
+}
@@ -1,11 +1,15 @@
 // Service Worker for ADS-B Scope PWA
-const CACHE_NAME = 'ads-bscope-v2';
+const CACHE_NAME = 'ads-bscope-v16';
 const STATIC_ASSETS = [
     '/',
     '/index.html',
+    '/map',
     '/css/main.css',
     '/js/app.js',
     '/js/api.js',
+    '/js/map.js',
+    '/js/skychart.js',
+    '/js/i18n.js',
     '/manifest.json',
     'https://unpkg.com/leaflet@1.9.4/dist/leaflet.css',
     'https://unpkg.com/leaflet@1.9.4/dist/leaflet.js',